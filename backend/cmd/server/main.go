@@ -3,7 +3,6 @@ package main
 //go:generate go run github.com/google/wire/cmd/wire
 
 import (
-	"context"
 	_ "embed"
 	"errors"
 	"flag"
@@ -19,6 +18,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/handler"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/server"
 	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/setup"
 	"github.com/Wei-Shaw/sub2api/internal/web"
@@ -167,11 +167,10 @@ func runMainServer() {
 
 	log.Println("Shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := app.Server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	// 不使用 log.Fatalf：强制关闭后仍需执行 Cleanup 以落盘已完成请求的计费
+	grace := time.Duration(cfg.Server.ShutdownGracePeriod) * time.Second
+	if err := server.GracefulShutdown(app.Server, app.InFlight, grace); err != nil {
+		log.Printf("Server forced to shutdown: %v", err)
 	}
 
 	log.Println("Server exited")
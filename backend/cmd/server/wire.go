@@ -24,8 +24,9 @@ import (
 )
 
 type Application struct {
-	Server  *http.Server
-	Cleanup func()
+	Server   *http.Server
+	InFlight *server.InFlightTracker
+	Cleanup  func()
 }
 
 func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
@@ -53,7 +54,7 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 		provideCleanup,
 
 		// Application struct
-		wire.Struct(new(Application), "Server", "Cleanup"),
+		wire.Struct(new(Application), "Server", "InFlight", "Cleanup"),
 	)
 	return nil, nil
 }
@@ -78,13 +79,16 @@ func provideCleanup(
 	opsCleanup *service.OpsCleanupService,
 	opsScheduledReport *service.OpsScheduledReportService,
 	opsSystemLogSink *service.OpsSystemLogSink,
+	auditService *service.AuditService,
 	schedulerSnapshot *service.SchedulerSnapshotService,
 	tokenRefresh *service.TokenRefreshService,
 	accountExpiry *service.AccountExpiryService,
+	accountHealthCheck *service.AccountHealthCheckService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
 	usageCleanup *service.UsageCleanupService,
 	idempotencyCleanup *service.IdempotencyCleanupService,
 	pricing *service.PricingService,
+	billing *service.BillingService,
 	emailQueue *service.EmailQueueService,
 	billingCache *service.BillingCacheService,
 	usageRecordWorkerPool *service.UsageRecordWorkerPool,
@@ -108,6 +112,21 @@ func provideCleanup(
 			fn   func() error
 		}
 
+		// 计费落盘最先按顺序执行：先排空用量记录队列，再刷出其产生的计费缓存写入，
+		// 确保已完成请求的计费在其他服务及 Redis/Ent 关闭前写入。
+		billingFlushSteps := []cleanupStep{
+			{"UsageRecordWorkerPool", func() error {
+				if usageRecordWorkerPool != nil {
+					usageRecordWorkerPool.Stop()
+				}
+				return nil
+			}},
+			{"BillingCacheService", func() error {
+				billingCache.Stop()
+				return nil
+			}},
+		}
+
 		// 应用层清理步骤可并行执行，基础设施资源（Redis/Ent）最后按顺序关闭。
 		parallelSteps := []cleanupStep{
 			{"OpsScheduledReportService", func() error {
@@ -128,6 +147,12 @@ func provideCleanup(
 				}
 				return nil
 			}},
+			{"AuditService", func() error {
+				if auditService != nil {
+					auditService.Stop()
+				}
+				return nil
+			}},
 			{"OpsAlertEvaluatorService", func() error {
 				if opsAlertEvaluator != nil {
 					opsAlertEvaluator.Stop()
@@ -172,6 +197,10 @@ func provideCleanup(
 				accountExpiry.Stop()
 				return nil
 			}},
+			{"AccountHealthCheckService", func() error {
+				accountHealthCheck.Stop()
+				return nil
+			}},
 			{"SubscriptionExpiryService", func() error {
 				subscriptionExpiry.Stop()
 				return nil
@@ -186,18 +215,12 @@ func provideCleanup(
 				pricing.Stop()
 				return nil
 			}},
-			{"EmailQueueService", func() error {
-				emailQueue.Stop()
-				return nil
-			}},
-			{"BillingCacheService", func() error {
-				billingCache.Stop()
+			{"BillingService", func() error {
+				billing.StopPricingAutoRefresh()
 				return nil
 			}},
-			{"UsageRecordWorkerPool", func() error {
-				if usageRecordWorkerPool != nil {
-					usageRecordWorkerPool.Stop()
-				}
+			{"EmailQueueService", func() error {
+				emailQueue.Stop()
 				return nil
 			}},
 			{"OAuthService", func() error {
@@ -291,6 +314,7 @@ func provideCleanup(
 			}
 		}
 
+		runSequential(billingFlushSteps)
 		runParallel(parallelSteps)
 		runSequential(infraSteps)
 
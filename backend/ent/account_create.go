@@ -153,6 +153,20 @@ func (_c *AccountCreate) SetNillableLoadFactor(v *int) *AccountCreate {
 	return _c
 }
 
+// SetWeight sets the "weight" field.
+func (_c *AccountCreate) SetWeight(v int) *AccountCreate {
+	_c.mutation.SetWeight(v)
+	return _c
+}
+
+// SetNillableWeight sets the "weight" field if the given value is not nil.
+func (_c *AccountCreate) SetNillableWeight(v *int) *AccountCreate {
+	if v != nil {
+		_c.SetWeight(*v)
+	}
+	return _c
+}
+
 // SetPriority sets the "priority" field.
 func (_c *AccountCreate) SetPriority(v int) *AccountCreate {
 	_c.mutation.SetPriority(v)
@@ -641,6 +655,10 @@ func (_c *AccountCreate) createSpec() (*Account, *sqlgraph.CreateSpec) {
 		_spec.SetField(account.FieldLoadFactor, field.TypeInt, value)
 		_node.LoadFactor = &value
 	}
+	if value, ok := _c.mutation.Weight(); ok {
+		_spec.SetField(account.FieldWeight, field.TypeInt, value)
+		_node.Weight = &value
+	}
 	if value, ok := _c.mutation.Priority(); ok {
 		_spec.SetField(account.FieldPriority, field.TypeInt, value)
 		_node.Priority = value
@@ -978,6 +996,30 @@ func (u *AccountUpsert) ClearLoadFactor() *AccountUpsert {
 	return u
 }
 
+// SetWeight sets the "weight" field.
+func (u *AccountUpsert) SetWeight(v int) *AccountUpsert {
+	u.Set(account.FieldWeight, v)
+	return u
+}
+
+// UpdateWeight sets the "weight" field to the value that was provided on create.
+func (u *AccountUpsert) UpdateWeight() *AccountUpsert {
+	u.SetExcluded(account.FieldWeight)
+	return u
+}
+
+// AddWeight adds v to the "weight" field.
+func (u *AccountUpsert) AddWeight(v int) *AccountUpsert {
+	u.Add(account.FieldWeight, v)
+	return u
+}
+
+// ClearWeight clears the value of the "weight" field.
+func (u *AccountUpsert) ClearWeight() *AccountUpsert {
+	u.SetNull(account.FieldWeight)
+	return u
+}
+
 // SetPriority sets the "priority" field.
 func (u *AccountUpsert) SetPriority(v int) *AccountUpsert {
 	u.Set(account.FieldPriority, v)
@@ -1489,6 +1531,34 @@ func (u *AccountUpsertOne) ClearLoadFactor() *AccountUpsertOne {
 	})
 }
 
+// SetWeight sets the "weight" field.
+func (u *AccountUpsertOne) SetWeight(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetWeight(v)
+	})
+}
+
+// AddWeight adds v to the "weight" field.
+func (u *AccountUpsertOne) AddWeight(v int) *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddWeight(v)
+	})
+}
+
+// UpdateWeight sets the "weight" field to the value that was provided on create.
+func (u *AccountUpsertOne) UpdateWeight() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateWeight()
+	})
+}
+
+// ClearWeight clears the value of the "weight" field.
+func (u *AccountUpsertOne) ClearWeight() *AccountUpsertOne {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearWeight()
+	})
+}
+
 // SetPriority sets the "priority" field.
 func (u *AccountUpsertOne) SetPriority(v int) *AccountUpsertOne {
 	return u.Update(func(s *AccountUpsert) {
@@ -2211,6 +2281,34 @@ func (u *AccountUpsertBulk) ClearLoadFactor() *AccountUpsertBulk {
 	})
 }
 
+// SetWeight sets the "weight" field.
+func (u *AccountUpsertBulk) SetWeight(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.SetWeight(v)
+	})
+}
+
+// AddWeight adds v to the "weight" field.
+func (u *AccountUpsertBulk) AddWeight(v int) *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.AddWeight(v)
+	})
+}
+
+// UpdateWeight sets the "weight" field to the value that was provided on create.
+func (u *AccountUpsertBulk) UpdateWeight() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.UpdateWeight()
+	})
+}
+
+// ClearWeight clears the value of the "weight" field.
+func (u *AccountUpsertBulk) ClearWeight() *AccountUpsertBulk {
+	return u.Update(func(s *AccountUpsert) {
+		s.ClearWeight()
+	})
+}
+
 // SetPriority sets the "priority" field.
 func (u *AccountUpsertBulk) SetPriority(v int) *AccountUpsertBulk {
 	return u.Update(func(s *AccountUpsert) {
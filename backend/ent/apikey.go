@@ -66,6 +66,42 @@ type APIKey struct {
 	Window1dStart *time.Time `json:"window_1d_start,omitempty"`
 	// Start time of the current 7d rate limit window
 	Window7dStart *time.Time `json:"window_7d_start,omitempty"`
+	// Requests per minute limit (0 = use global default)
+	RpmLimit int `json:"rpm_limit,omitempty"`
+	// Tokens per minute limit (0 = use global default)
+	TpmLimit int `json:"tpm_limit,omitempty"`
+	// Capture request/response bodies in audit logs
+	AuditCaptureBody bool `json:"audit_capture_body,omitempty"`
+	// Models this key may request; empty means all models
+	AllowedModels []string `json:"allowed_models,omitempty"`
+	// Models this key may not request; takes precedence over allowed_models
+	DeniedModels []string `json:"denied_models,omitempty"`
+	// Expose billed cost and token usage in response headers/trailers
+	UsageHeaders bool `json:"usage_headers,omitempty"`
+	// Sandbox key: cost is computed but never charged or counted toward spend and budgets
+	IsSandbox bool `json:"is_sandbox,omitempty"`
+	// Gateway queue priority tier (higher tiers are served first when the queue is full)
+	PriorityTier int `json:"priority_tier,omitempty"`
+	// Hash of the full key (bcrypt/argon2id); empty for legacy plaintext keys
+	KeyHash string `json:"-"`
+	// Max request body size in bytes (0 = use global default)
+	MaxRequestBytes int64 `json:"max_request_bytes,omitempty"`
+	// Max response body size in bytes (0 = use global default)
+	MaxResponseBytes int64 `json:"max_response_bytes,omitempty"`
+	// Max requests per quota window (0 = unlimited)
+	UsageQuotaRequests int64 `json:"usage_quota_requests,omitempty"`
+	// Max tokens per quota window (0 = unlimited)
+	UsageQuotaTokens int64 `json:"usage_quota_tokens,omitempty"`
+	// Quota reset schedule: hourly/daily/weekly/monthly (UTC calendar boundaries)
+	UsageQuotaPeriod string `json:"usage_quota_period,omitempty"`
+	// Requests consumed in the current quota window
+	UsageQuotaRequestsUsed int64 `json:"usage_quota_requests_used,omitempty"`
+	// Tokens consumed in the current quota window
+	UsageQuotaTokensUsed int64 `json:"usage_quota_tokens_used,omitempty"`
+	// Start time of the current quota window
+	UsageQuotaWindowStart *time.Time `json:"usage_quota_window_start,omitempty"`
+	// Echo the requested model name in responses instead of the real upstream model
+	MaskResponseModel bool `json:"mask_response_model,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the APIKeyQuery when eager-loading is set.
 	Edges        APIKeyEdges `json:"edges"`
@@ -121,15 +157,17 @@ func (*APIKey) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case apikey.FieldIPWhitelist, apikey.FieldIPBlacklist:
+		case apikey.FieldIPWhitelist, apikey.FieldIPBlacklist, apikey.FieldAllowedModels, apikey.FieldDeniedModels:
 			values[i] = new([]byte)
+		case apikey.FieldAuditCaptureBody, apikey.FieldUsageHeaders, apikey.FieldIsSandbox, apikey.FieldMaskResponseModel:
+			values[i] = new(sql.NullBool)
 		case apikey.FieldQuota, apikey.FieldQuotaUsed, apikey.FieldRateLimit5h, apikey.FieldRateLimit1d, apikey.FieldRateLimit7d, apikey.FieldUsage5h, apikey.FieldUsage1d, apikey.FieldUsage7d:
 			values[i] = new(sql.NullFloat64)
-		case apikey.FieldID, apikey.FieldUserID, apikey.FieldGroupID:
+		case apikey.FieldID, apikey.FieldUserID, apikey.FieldGroupID, apikey.FieldRpmLimit, apikey.FieldTpmLimit, apikey.FieldPriorityTier, apikey.FieldMaxRequestBytes, apikey.FieldMaxResponseBytes, apikey.FieldUsageQuotaRequests, apikey.FieldUsageQuotaTokens, apikey.FieldUsageQuotaRequestsUsed, apikey.FieldUsageQuotaTokensUsed:
 			values[i] = new(sql.NullInt64)
-		case apikey.FieldKey, apikey.FieldName, apikey.FieldStatus:
+		case apikey.FieldKey, apikey.FieldName, apikey.FieldStatus, apikey.FieldKeyHash, apikey.FieldUsageQuotaPeriod:
 			values[i] = new(sql.NullString)
-		case apikey.FieldCreatedAt, apikey.FieldUpdatedAt, apikey.FieldDeletedAt, apikey.FieldLastUsedAt, apikey.FieldExpiresAt, apikey.FieldWindow5hStart, apikey.FieldWindow1dStart, apikey.FieldWindow7dStart:
+		case apikey.FieldCreatedAt, apikey.FieldUpdatedAt, apikey.FieldDeletedAt, apikey.FieldLastUsedAt, apikey.FieldExpiresAt, apikey.FieldWindow5hStart, apikey.FieldWindow1dStart, apikey.FieldWindow7dStart, apikey.FieldUsageQuotaWindowStart:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -301,6 +339,119 @@ func (_m *APIKey) assignValues(columns []string, values []any) error {
 				_m.Window7dStart = new(time.Time)
 				*_m.Window7dStart = value.Time
 			}
+		case apikey.FieldRpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rpm_limit", values[i])
+			} else if value.Valid {
+				_m.RpmLimit = int(value.Int64)
+			}
+		case apikey.FieldTpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field tpm_limit", values[i])
+			} else if value.Valid {
+				_m.TpmLimit = int(value.Int64)
+			}
+		case apikey.FieldAuditCaptureBody:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field audit_capture_body", values[i])
+			} else if value.Valid {
+				_m.AuditCaptureBody = value.Bool
+			}
+		case apikey.FieldAllowedModels:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_models", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AllowedModels); err != nil {
+					return fmt.Errorf("unmarshal field allowed_models: %w", err)
+				}
+			}
+		case apikey.FieldDeniedModels:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field denied_models", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.DeniedModels); err != nil {
+					return fmt.Errorf("unmarshal field denied_models: %w", err)
+				}
+			}
+		case apikey.FieldUsageHeaders:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_headers", values[i])
+			} else if value.Valid {
+				_m.UsageHeaders = value.Bool
+			}
+		case apikey.FieldIsSandbox:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field is_sandbox", values[i])
+			} else if value.Valid {
+				_m.IsSandbox = value.Bool
+			}
+		case apikey.FieldPriorityTier:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field priority_tier", values[i])
+			} else if value.Valid {
+				_m.PriorityTier = int(value.Int64)
+			}
+		case apikey.FieldKeyHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key_hash", values[i])
+			} else if value.Valid {
+				_m.KeyHash = value.String
+			}
+		case apikey.FieldMaxRequestBytes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_request_bytes", values[i])
+			} else if value.Valid {
+				_m.MaxRequestBytes = value.Int64
+			}
+		case apikey.FieldMaxResponseBytes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_response_bytes", values[i])
+			} else if value.Valid {
+				_m.MaxResponseBytes = value.Int64
+			}
+		case apikey.FieldUsageQuotaRequests:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_requests", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaRequests = value.Int64
+			}
+		case apikey.FieldUsageQuotaTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_tokens", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaTokens = value.Int64
+			}
+		case apikey.FieldUsageQuotaPeriod:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_period", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaPeriod = value.String
+			}
+		case apikey.FieldUsageQuotaRequestsUsed:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_requests_used", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaRequestsUsed = value.Int64
+			}
+		case apikey.FieldUsageQuotaTokensUsed:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_tokens_used", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaTokensUsed = value.Int64
+			}
+		case apikey.FieldUsageQuotaWindowStart:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quota_window_start", values[i])
+			} else if value.Valid {
+				_m.UsageQuotaWindowStart = new(time.Time)
+				*_m.UsageQuotaWindowStart = value.Time
+			}
+		case apikey.FieldMaskResponseModel:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mask_response_model", values[i])
+			} else if value.Valid {
+				_m.MaskResponseModel = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -434,6 +585,61 @@ func (_m *APIKey) String() string {
 		builder.WriteString("window_7d_start=")
 		builder.WriteString(v.Format(time.ANSIC))
 	}
+	builder.WriteString(", ")
+	builder.WriteString("rpm_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RpmLimit))
+	builder.WriteString(", ")
+	builder.WriteString("tpm_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TpmLimit))
+	builder.WriteString(", ")
+	builder.WriteString("audit_capture_body=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AuditCaptureBody))
+	builder.WriteString(", ")
+	builder.WriteString("allowed_models=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowedModels))
+	builder.WriteString(", ")
+	builder.WriteString("denied_models=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DeniedModels))
+	builder.WriteString(", ")
+	builder.WriteString("usage_headers=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageHeaders))
+	builder.WriteString(", ")
+	builder.WriteString("is_sandbox=")
+	builder.WriteString(fmt.Sprintf("%v", _m.IsSandbox))
+	builder.WriteString(", ")
+	builder.WriteString("priority_tier=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PriorityTier))
+	builder.WriteString(", ")
+	builder.WriteString("key_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("max_request_bytes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxRequestBytes))
+	builder.WriteString(", ")
+	builder.WriteString("max_response_bytes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxResponseBytes))
+	builder.WriteString(", ")
+	builder.WriteString("usage_quota_requests=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageQuotaRequests))
+	builder.WriteString(", ")
+	builder.WriteString("usage_quota_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageQuotaTokens))
+	builder.WriteString(", ")
+	builder.WriteString("usage_quota_period=")
+	builder.WriteString(_m.UsageQuotaPeriod)
+	builder.WriteString(", ")
+	builder.WriteString("usage_quota_requests_used=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageQuotaRequestsUsed))
+	builder.WriteString(", ")
+	builder.WriteString("usage_quota_tokens_used=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageQuotaTokensUsed))
+	builder.WriteString(", ")
+	if v := _m.UsageQuotaWindowStart; v != nil {
+		builder.WriteString("usage_quota_window_start=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("mask_response_model=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaskResponseModel))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -61,6 +61,42 @@ const (
 	FieldWindow1dStart = "window_1d_start"
 	// FieldWindow7dStart holds the string denoting the window_7d_start field in the database.
 	FieldWindow7dStart = "window_7d_start"
+	// FieldRpmLimit holds the string denoting the rpm_limit field in the database.
+	FieldRpmLimit = "rpm_limit"
+	// FieldTpmLimit holds the string denoting the tpm_limit field in the database.
+	FieldTpmLimit = "tpm_limit"
+	// FieldAuditCaptureBody holds the string denoting the audit_capture_body field in the database.
+	FieldAuditCaptureBody = "audit_capture_body"
+	// FieldAllowedModels holds the string denoting the allowed_models field in the database.
+	FieldAllowedModels = "allowed_models"
+	// FieldDeniedModels holds the string denoting the denied_models field in the database.
+	FieldDeniedModels = "denied_models"
+	// FieldUsageHeaders holds the string denoting the usage_headers field in the database.
+	FieldUsageHeaders = "usage_headers"
+	// FieldIsSandbox holds the string denoting the is_sandbox field in the database.
+	FieldIsSandbox = "is_sandbox"
+	// FieldPriorityTier holds the string denoting the priority_tier field in the database.
+	FieldPriorityTier = "priority_tier"
+	// FieldKeyHash holds the string denoting the key_hash field in the database.
+	FieldKeyHash = "key_hash"
+	// FieldMaxRequestBytes holds the string denoting the max_request_bytes field in the database.
+	FieldMaxRequestBytes = "max_request_bytes"
+	// FieldMaxResponseBytes holds the string denoting the max_response_bytes field in the database.
+	FieldMaxResponseBytes = "max_response_bytes"
+	// FieldUsageQuotaRequests holds the string denoting the usage_quota_requests field in the database.
+	FieldUsageQuotaRequests = "usage_quota_requests"
+	// FieldUsageQuotaTokens holds the string denoting the usage_quota_tokens field in the database.
+	FieldUsageQuotaTokens = "usage_quota_tokens"
+	// FieldUsageQuotaPeriod holds the string denoting the usage_quota_period field in the database.
+	FieldUsageQuotaPeriod = "usage_quota_period"
+	// FieldUsageQuotaRequestsUsed holds the string denoting the usage_quota_requests_used field in the database.
+	FieldUsageQuotaRequestsUsed = "usage_quota_requests_used"
+	// FieldUsageQuotaTokensUsed holds the string denoting the usage_quota_tokens_used field in the database.
+	FieldUsageQuotaTokensUsed = "usage_quota_tokens_used"
+	// FieldUsageQuotaWindowStart holds the string denoting the usage_quota_window_start field in the database.
+	FieldUsageQuotaWindowStart = "usage_quota_window_start"
+	// FieldMaskResponseModel holds the string denoting the mask_response_model field in the database.
+	FieldMaskResponseModel = "mask_response_model"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgeGroup holds the string denoting the group edge name in mutations.
@@ -118,6 +154,24 @@ var Columns = []string{
 	FieldWindow5hStart,
 	FieldWindow1dStart,
 	FieldWindow7dStart,
+	FieldRpmLimit,
+	FieldTpmLimit,
+	FieldAuditCaptureBody,
+	FieldAllowedModels,
+	FieldDeniedModels,
+	FieldUsageHeaders,
+	FieldIsSandbox,
+	FieldPriorityTier,
+	FieldKeyHash,
+	FieldMaxRequestBytes,
+	FieldMaxResponseBytes,
+	FieldUsageQuotaRequests,
+	FieldUsageQuotaTokens,
+	FieldUsageQuotaPeriod,
+	FieldUsageQuotaRequestsUsed,
+	FieldUsageQuotaTokensUsed,
+	FieldUsageQuotaWindowStart,
+	FieldMaskResponseModel,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -168,6 +222,40 @@ var (
 	DefaultUsage1d float64
 	// DefaultUsage7d holds the default value on creation for the "usage_7d" field.
 	DefaultUsage7d float64
+	// DefaultRpmLimit holds the default value on creation for the "rpm_limit" field.
+	DefaultRpmLimit int
+	// DefaultTpmLimit holds the default value on creation for the "tpm_limit" field.
+	DefaultTpmLimit int
+	// DefaultAuditCaptureBody holds the default value on creation for the "audit_capture_body" field.
+	DefaultAuditCaptureBody bool
+	// DefaultUsageHeaders holds the default value on creation for the "usage_headers" field.
+	DefaultUsageHeaders bool
+	// DefaultIsSandbox holds the default value on creation for the "is_sandbox" field.
+	DefaultIsSandbox bool
+	// DefaultMaskResponseModel holds the default value on creation for the "mask_response_model" field.
+	DefaultMaskResponseModel bool
+	// DefaultPriorityTier holds the default value on creation for the "priority_tier" field.
+	DefaultPriorityTier int
+	// DefaultKeyHash holds the default value on creation for the "key_hash" field.
+	DefaultKeyHash string
+	// KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	KeyHashValidator func(string) error
+	// DefaultMaxRequestBytes holds the default value on creation for the "max_request_bytes" field.
+	DefaultMaxRequestBytes int64
+	// DefaultMaxResponseBytes holds the default value on creation for the "max_response_bytes" field.
+	DefaultMaxResponseBytes int64
+	// DefaultUsageQuotaRequests holds the default value on creation for the "usage_quota_requests" field.
+	DefaultUsageQuotaRequests int64
+	// DefaultUsageQuotaTokens holds the default value on creation for the "usage_quota_tokens" field.
+	DefaultUsageQuotaTokens int64
+	// DefaultUsageQuotaPeriod holds the default value on creation for the "usage_quota_period" field.
+	DefaultUsageQuotaPeriod string
+	// UsageQuotaPeriodValidator is a validator for the "usage_quota_period" field. It is called by the builders before save.
+	UsageQuotaPeriodValidator func(string) error
+	// DefaultUsageQuotaRequestsUsed holds the default value on creation for the "usage_quota_requests_used" field.
+	DefaultUsageQuotaRequestsUsed int64
+	// DefaultUsageQuotaTokensUsed holds the default value on creation for the "usage_quota_tokens_used" field.
+	DefaultUsageQuotaTokensUsed int64
 )
 
 // OrderOption defines the ordering options for the APIKey queries.
@@ -283,6 +371,86 @@ func ByWindow7dStart(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldWindow7dStart, opts...).ToFunc()
 }
 
+// ByRpmLimit orders the results by the rpm_limit field.
+func ByRpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRpmLimit, opts...).ToFunc()
+}
+
+// ByTpmLimit orders the results by the tpm_limit field.
+func ByTpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTpmLimit, opts...).ToFunc()
+}
+
+// ByAuditCaptureBody orders the results by the audit_capture_body field.
+func ByAuditCaptureBody(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAuditCaptureBody, opts...).ToFunc()
+}
+
+// ByUsageHeaders orders the results by the usage_headers field.
+func ByUsageHeaders(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageHeaders, opts...).ToFunc()
+}
+
+// ByIsSandbox orders the results by the is_sandbox field.
+func ByIsSandbox(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIsSandbox, opts...).ToFunc()
+}
+
+// ByPriorityTier orders the results by the priority_tier field.
+func ByPriorityTier(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPriorityTier, opts...).ToFunc()
+}
+
+// ByKeyHash orders the results by the key_hash field.
+func ByKeyHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKeyHash, opts...).ToFunc()
+}
+
+// ByMaxRequestBytes orders the results by the max_request_bytes field.
+func ByMaxRequestBytes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxRequestBytes, opts...).ToFunc()
+}
+
+// ByMaxResponseBytes orders the results by the max_response_bytes field.
+func ByMaxResponseBytes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxResponseBytes, opts...).ToFunc()
+}
+
+// ByUsageQuotaRequests orders the results by the usage_quota_requests field.
+func ByUsageQuotaRequests(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaRequests, opts...).ToFunc()
+}
+
+// ByUsageQuotaTokens orders the results by the usage_quota_tokens field.
+func ByUsageQuotaTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaTokens, opts...).ToFunc()
+}
+
+// ByUsageQuotaPeriod orders the results by the usage_quota_period field.
+func ByUsageQuotaPeriod(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaPeriod, opts...).ToFunc()
+}
+
+// ByUsageQuotaRequestsUsed orders the results by the usage_quota_requests_used field.
+func ByUsageQuotaRequestsUsed(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaRequestsUsed, opts...).ToFunc()
+}
+
+// ByUsageQuotaTokensUsed orders the results by the usage_quota_tokens_used field.
+func ByUsageQuotaTokensUsed(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaTokensUsed, opts...).ToFunc()
+}
+
+// ByUsageQuotaWindowStart orders the results by the usage_quota_window_start field.
+func ByUsageQuotaWindowStart(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageQuotaWindowStart, opts...).ToFunc()
+}
+
+// ByMaskResponseModel orders the results by the mask_response_model field.
+func ByMaskResponseModel(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaskResponseModel, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
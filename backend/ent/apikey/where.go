@@ -160,6 +160,86 @@ func Window7dStart(v time.Time) predicate.APIKey {
 	return predicate.APIKey(sql.FieldEQ(FieldWindow7dStart, v))
 }
 
+// RpmLimit applies equality check predicate on the "rpm_limit" field. It's identical to RpmLimitEQ.
+func RpmLimit(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldRpmLimit, v))
+}
+
+// TpmLimit applies equality check predicate on the "tpm_limit" field. It's identical to TpmLimitEQ.
+func TpmLimit(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldTpmLimit, v))
+}
+
+// AuditCaptureBody applies equality check predicate on the "audit_capture_body" field. It's identical to AuditCaptureBodyEQ.
+func AuditCaptureBody(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldAuditCaptureBody, v))
+}
+
+// UsageHeaders applies equality check predicate on the "usage_headers" field. It's identical to UsageHeadersEQ.
+func UsageHeaders(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageHeaders, v))
+}
+
+// IsSandbox applies equality check predicate on the "is_sandbox" field. It's identical to IsSandboxEQ.
+func IsSandbox(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldIsSandbox, v))
+}
+
+// PriorityTier applies equality check predicate on the "priority_tier" field. It's identical to PriorityTierEQ.
+func PriorityTier(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldPriorityTier, v))
+}
+
+// KeyHash applies equality check predicate on the "key_hash" field. It's identical to KeyHashEQ.
+func KeyHash(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldKeyHash, v))
+}
+
+// MaxRequestBytes applies equality check predicate on the "max_request_bytes" field. It's identical to MaxRequestBytesEQ.
+func MaxRequestBytes(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaxRequestBytes, v))
+}
+
+// MaxResponseBytes applies equality check predicate on the "max_response_bytes" field. It's identical to MaxResponseBytesEQ.
+func MaxResponseBytes(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaxResponseBytes, v))
+}
+
+// UsageQuotaRequests applies equality check predicate on the "usage_quota_requests" field. It's identical to UsageQuotaRequestsEQ.
+func UsageQuotaRequests(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaTokens applies equality check predicate on the "usage_quota_tokens" field. It's identical to UsageQuotaTokensEQ.
+func UsageQuotaTokens(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaPeriod applies equality check predicate on the "usage_quota_period" field. It's identical to UsageQuotaPeriodEQ.
+func UsageQuotaPeriod(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaRequestsUsed applies equality check predicate on the "usage_quota_requests_used" field. It's identical to UsageQuotaRequestsUsedEQ.
+func UsageQuotaRequestsUsed(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaTokensUsed applies equality check predicate on the "usage_quota_tokens_used" field. It's identical to UsageQuotaTokensUsedEQ.
+func UsageQuotaTokensUsed(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaWindowStart applies equality check predicate on the "usage_quota_window_start" field. It's identical to UsageQuotaWindowStartEQ.
+func UsageQuotaWindowStart(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaWindowStart, v))
+}
+
+// MaskResponseModel applies equality check predicate on the "mask_response_model" field. It's identical to MaskResponseModelEQ.
+func MaskResponseModel(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaskResponseModel, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.APIKey {
 	return predicate.APIKey(sql.FieldEQ(FieldCreatedAt, v))
@@ -1125,6 +1205,606 @@ func Window7dStartNotNil() predicate.APIKey {
 	return predicate.APIKey(sql.FieldNotNull(FieldWindow7dStart))
 }
 
+// RpmLimitEQ applies the EQ predicate on the "rpm_limit" field.
+func RpmLimitEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldRpmLimit, v))
+}
+
+// RpmLimitNEQ applies the NEQ predicate on the "rpm_limit" field.
+func RpmLimitNEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldRpmLimit, v))
+}
+
+// RpmLimitIn applies the In predicate on the "rpm_limit" field.
+func RpmLimitIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldRpmLimit, vs...))
+}
+
+// RpmLimitNotIn applies the NotIn predicate on the "rpm_limit" field.
+func RpmLimitNotIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldRpmLimit, vs...))
+}
+
+// RpmLimitGT applies the GT predicate on the "rpm_limit" field.
+func RpmLimitGT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldRpmLimit, v))
+}
+
+// RpmLimitGTE applies the GTE predicate on the "rpm_limit" field.
+func RpmLimitGTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldRpmLimit, v))
+}
+
+// RpmLimitLT applies the LT predicate on the "rpm_limit" field.
+func RpmLimitLT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldRpmLimit, v))
+}
+
+// RpmLimitLTE applies the LTE predicate on the "rpm_limit" field.
+func RpmLimitLTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldRpmLimit, v))
+}
+
+// TpmLimitEQ applies the EQ predicate on the "tpm_limit" field.
+func TpmLimitEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldTpmLimit, v))
+}
+
+// TpmLimitNEQ applies the NEQ predicate on the "tpm_limit" field.
+func TpmLimitNEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldTpmLimit, v))
+}
+
+// TpmLimitIn applies the In predicate on the "tpm_limit" field.
+func TpmLimitIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldTpmLimit, vs...))
+}
+
+// TpmLimitNotIn applies the NotIn predicate on the "tpm_limit" field.
+func TpmLimitNotIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldTpmLimit, vs...))
+}
+
+// TpmLimitGT applies the GT predicate on the "tpm_limit" field.
+func TpmLimitGT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldTpmLimit, v))
+}
+
+// TpmLimitGTE applies the GTE predicate on the "tpm_limit" field.
+func TpmLimitGTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldTpmLimit, v))
+}
+
+// TpmLimitLT applies the LT predicate on the "tpm_limit" field.
+func TpmLimitLT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldTpmLimit, v))
+}
+
+// TpmLimitLTE applies the LTE predicate on the "tpm_limit" field.
+func TpmLimitLTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldTpmLimit, v))
+}
+
+// AuditCaptureBodyEQ applies the EQ predicate on the "audit_capture_body" field.
+func AuditCaptureBodyEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldAuditCaptureBody, v))
+}
+
+// AuditCaptureBodyNEQ applies the NEQ predicate on the "audit_capture_body" field.
+func AuditCaptureBodyNEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldAuditCaptureBody, v))
+}
+
+// AllowedModelsIsNil applies the IsNil predicate on the "allowed_models" field.
+func AllowedModelsIsNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldIsNull(FieldAllowedModels))
+}
+
+// AllowedModelsNotNil applies the NotNil predicate on the "allowed_models" field.
+func AllowedModelsNotNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotNull(FieldAllowedModels))
+}
+
+// DeniedModelsIsNil applies the IsNil predicate on the "denied_models" field.
+func DeniedModelsIsNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldIsNull(FieldDeniedModels))
+}
+
+// DeniedModelsNotNil applies the NotNil predicate on the "denied_models" field.
+func DeniedModelsNotNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotNull(FieldDeniedModels))
+}
+
+// UsageHeadersEQ applies the EQ predicate on the "usage_headers" field.
+func UsageHeadersEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageHeaders, v))
+}
+
+// UsageHeadersNEQ applies the NEQ predicate on the "usage_headers" field.
+func UsageHeadersNEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageHeaders, v))
+}
+
+// IsSandboxEQ applies the EQ predicate on the "is_sandbox" field.
+func IsSandboxEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldIsSandbox, v))
+}
+
+// IsSandboxNEQ applies the NEQ predicate on the "is_sandbox" field.
+func IsSandboxNEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldIsSandbox, v))
+}
+
+// PriorityTierEQ applies the EQ predicate on the "priority_tier" field.
+func PriorityTierEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldPriorityTier, v))
+}
+
+// PriorityTierNEQ applies the NEQ predicate on the "priority_tier" field.
+func PriorityTierNEQ(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldPriorityTier, v))
+}
+
+// PriorityTierIn applies the In predicate on the "priority_tier" field.
+func PriorityTierIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldPriorityTier, vs...))
+}
+
+// PriorityTierNotIn applies the NotIn predicate on the "priority_tier" field.
+func PriorityTierNotIn(vs ...int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldPriorityTier, vs...))
+}
+
+// PriorityTierGT applies the GT predicate on the "priority_tier" field.
+func PriorityTierGT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldPriorityTier, v))
+}
+
+// PriorityTierGTE applies the GTE predicate on the "priority_tier" field.
+func PriorityTierGTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldPriorityTier, v))
+}
+
+// PriorityTierLT applies the LT predicate on the "priority_tier" field.
+func PriorityTierLT(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldPriorityTier, v))
+}
+
+// PriorityTierLTE applies the LTE predicate on the "priority_tier" field.
+func PriorityTierLTE(v int) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldPriorityTier, v))
+}
+
+// KeyHashEQ applies the EQ predicate on the "key_hash" field.
+func KeyHashEQ(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldKeyHash, v))
+}
+
+// KeyHashNEQ applies the NEQ predicate on the "key_hash" field.
+func KeyHashNEQ(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldKeyHash, v))
+}
+
+// KeyHashIn applies the In predicate on the "key_hash" field.
+func KeyHashIn(vs ...string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldKeyHash, vs...))
+}
+
+// KeyHashNotIn applies the NotIn predicate on the "key_hash" field.
+func KeyHashNotIn(vs ...string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldKeyHash, vs...))
+}
+
+// KeyHashGT applies the GT predicate on the "key_hash" field.
+func KeyHashGT(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldKeyHash, v))
+}
+
+// KeyHashGTE applies the GTE predicate on the "key_hash" field.
+func KeyHashGTE(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldKeyHash, v))
+}
+
+// KeyHashLT applies the LT predicate on the "key_hash" field.
+func KeyHashLT(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldKeyHash, v))
+}
+
+// KeyHashLTE applies the LTE predicate on the "key_hash" field.
+func KeyHashLTE(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldKeyHash, v))
+}
+
+// KeyHashContains applies the Contains predicate on the "key_hash" field.
+func KeyHashContains(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldContains(FieldKeyHash, v))
+}
+
+// KeyHashHasPrefix applies the HasPrefix predicate on the "key_hash" field.
+func KeyHashHasPrefix(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldHasPrefix(FieldKeyHash, v))
+}
+
+// KeyHashHasSuffix applies the HasSuffix predicate on the "key_hash" field.
+func KeyHashHasSuffix(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldHasSuffix(FieldKeyHash, v))
+}
+
+// KeyHashEqualFold applies the EqualFold predicate on the "key_hash" field.
+func KeyHashEqualFold(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEqualFold(FieldKeyHash, v))
+}
+
+// KeyHashContainsFold applies the ContainsFold predicate on the "key_hash" field.
+func KeyHashContainsFold(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldContainsFold(FieldKeyHash, v))
+}
+
+// MaxRequestBytesEQ applies the EQ predicate on the "max_request_bytes" field.
+func MaxRequestBytesEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaxRequestBytes, v))
+}
+
+// MaxRequestBytesNEQ applies the NEQ predicate on the "max_request_bytes" field.
+func MaxRequestBytesNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldMaxRequestBytes, v))
+}
+
+// MaxRequestBytesIn applies the In predicate on the "max_request_bytes" field.
+func MaxRequestBytesIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldMaxRequestBytes, vs...))
+}
+
+// MaxRequestBytesNotIn applies the NotIn predicate on the "max_request_bytes" field.
+func MaxRequestBytesNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldMaxRequestBytes, vs...))
+}
+
+// MaxRequestBytesGT applies the GT predicate on the "max_request_bytes" field.
+func MaxRequestBytesGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldMaxRequestBytes, v))
+}
+
+// MaxRequestBytesGTE applies the GTE predicate on the "max_request_bytes" field.
+func MaxRequestBytesGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldMaxRequestBytes, v))
+}
+
+// MaxRequestBytesLT applies the LT predicate on the "max_request_bytes" field.
+func MaxRequestBytesLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldMaxRequestBytes, v))
+}
+
+// MaxRequestBytesLTE applies the LTE predicate on the "max_request_bytes" field.
+func MaxRequestBytesLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldMaxRequestBytes, v))
+}
+
+// MaxResponseBytesEQ applies the EQ predicate on the "max_response_bytes" field.
+func MaxResponseBytesEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaxResponseBytes, v))
+}
+
+// MaxResponseBytesNEQ applies the NEQ predicate on the "max_response_bytes" field.
+func MaxResponseBytesNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldMaxResponseBytes, v))
+}
+
+// MaxResponseBytesIn applies the In predicate on the "max_response_bytes" field.
+func MaxResponseBytesIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldMaxResponseBytes, vs...))
+}
+
+// MaxResponseBytesNotIn applies the NotIn predicate on the "max_response_bytes" field.
+func MaxResponseBytesNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldMaxResponseBytes, vs...))
+}
+
+// MaxResponseBytesGT applies the GT predicate on the "max_response_bytes" field.
+func MaxResponseBytesGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldMaxResponseBytes, v))
+}
+
+// MaxResponseBytesGTE applies the GTE predicate on the "max_response_bytes" field.
+func MaxResponseBytesGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldMaxResponseBytes, v))
+}
+
+// MaxResponseBytesLT applies the LT predicate on the "max_response_bytes" field.
+func MaxResponseBytesLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldMaxResponseBytes, v))
+}
+
+// MaxResponseBytesLTE applies the LTE predicate on the "max_response_bytes" field.
+func MaxResponseBytesLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldMaxResponseBytes, v))
+}
+
+// UsageQuotaRequestsEQ applies the EQ predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaRequestsNEQ applies the NEQ predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaRequestsIn applies the In predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaRequests, vs...))
+}
+
+// UsageQuotaRequestsNotIn applies the NotIn predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaRequests, vs...))
+}
+
+// UsageQuotaRequestsGT applies the GT predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaRequestsGTE applies the GTE predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaRequestsLT applies the LT predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaRequestsLTE applies the LTE predicate on the "usage_quota_requests" field.
+func UsageQuotaRequestsLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaRequests, v))
+}
+
+// UsageQuotaTokensEQ applies the EQ predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaTokensNEQ applies the NEQ predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaTokensIn applies the In predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaTokens, vs...))
+}
+
+// UsageQuotaTokensNotIn applies the NotIn predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaTokens, vs...))
+}
+
+// UsageQuotaTokensGT applies the GT predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaTokensGTE applies the GTE predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaTokensLT applies the LT predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaTokensLTE applies the LTE predicate on the "usage_quota_tokens" field.
+func UsageQuotaTokensLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaTokens, v))
+}
+
+// UsageQuotaPeriodEQ applies the EQ predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodEQ(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodNEQ applies the NEQ predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodNEQ(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodIn applies the In predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodIn(vs ...string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaPeriod, vs...))
+}
+
+// UsageQuotaPeriodNotIn applies the NotIn predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodNotIn(vs ...string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaPeriod, vs...))
+}
+
+// UsageQuotaPeriodGT applies the GT predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodGT(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodGTE applies the GTE predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodGTE(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodLT applies the LT predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodLT(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodLTE applies the LTE predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodLTE(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodContains applies the Contains predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodContains(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldContains(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodHasPrefix applies the HasPrefix predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodHasPrefix(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldHasPrefix(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodHasSuffix applies the HasSuffix predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodHasSuffix(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldHasSuffix(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodEqualFold applies the EqualFold predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodEqualFold(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEqualFold(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaPeriodContainsFold applies the ContainsFold predicate on the "usage_quota_period" field.
+func UsageQuotaPeriodContainsFold(v string) predicate.APIKey {
+	return predicate.APIKey(sql.FieldContainsFold(FieldUsageQuotaPeriod, v))
+}
+
+// UsageQuotaRequestsUsedEQ applies the EQ predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaRequestsUsedNEQ applies the NEQ predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaRequestsUsedIn applies the In predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaRequestsUsed, vs...))
+}
+
+// UsageQuotaRequestsUsedNotIn applies the NotIn predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaRequestsUsed, vs...))
+}
+
+// UsageQuotaRequestsUsedGT applies the GT predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaRequestsUsedGTE applies the GTE predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaRequestsUsedLT applies the LT predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaRequestsUsedLTE applies the LTE predicate on the "usage_quota_requests_used" field.
+func UsageQuotaRequestsUsedLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaRequestsUsed, v))
+}
+
+// UsageQuotaTokensUsedEQ applies the EQ predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaTokensUsedNEQ applies the NEQ predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedNEQ(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaTokensUsedIn applies the In predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaTokensUsed, vs...))
+}
+
+// UsageQuotaTokensUsedNotIn applies the NotIn predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedNotIn(vs ...int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaTokensUsed, vs...))
+}
+
+// UsageQuotaTokensUsedGT applies the GT predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedGT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaTokensUsedGTE applies the GTE predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedGTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaTokensUsedLT applies the LT predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedLT(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaTokensUsedLTE applies the LTE predicate on the "usage_quota_tokens_used" field.
+func UsageQuotaTokensUsedLTE(v int64) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaTokensUsed, v))
+}
+
+// UsageQuotaWindowStartEQ applies the EQ predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartEQ(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartNEQ applies the NEQ predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartNEQ(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartIn applies the In predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartIn(vs ...time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldIn(FieldUsageQuotaWindowStart, vs...))
+}
+
+// UsageQuotaWindowStartNotIn applies the NotIn predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartNotIn(vs ...time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotIn(FieldUsageQuotaWindowStart, vs...))
+}
+
+// UsageQuotaWindowStartGT applies the GT predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartGT(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGT(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartGTE applies the GTE predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartGTE(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldGTE(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartLT applies the LT predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartLT(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLT(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartLTE applies the LTE predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartLTE(v time.Time) predicate.APIKey {
+	return predicate.APIKey(sql.FieldLTE(FieldUsageQuotaWindowStart, v))
+}
+
+// UsageQuotaWindowStartIsNil applies the IsNil predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartIsNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldIsNull(FieldUsageQuotaWindowStart))
+}
+
+// UsageQuotaWindowStartNotNil applies the NotNil predicate on the "usage_quota_window_start" field.
+func UsageQuotaWindowStartNotNil() predicate.APIKey {
+	return predicate.APIKey(sql.FieldNotNull(FieldUsageQuotaWindowStart))
+}
+
+// MaskResponseModelEQ applies the EQ predicate on the "mask_response_model" field.
+func MaskResponseModelEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldEQ(FieldMaskResponseModel, v))
+}
+
+// MaskResponseModelNEQ applies the NEQ predicate on the "mask_response_model" field.
+func MaskResponseModelNEQ(v bool) predicate.APIKey {
+	return predicate.APIKey(sql.FieldNEQ(FieldMaskResponseModel, v))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.APIKey {
 	return predicate.APIKey(func(s *sql.Selector) {
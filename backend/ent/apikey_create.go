@@ -307,6 +307,242 @@ func (_c *APIKeyCreate) SetNillableWindow7dStart(v *time.Time) *APIKeyCreate {
 	return _c
 }
 
+// SetRpmLimit sets the "rpm_limit" field.
+func (_c *APIKeyCreate) SetRpmLimit(v int) *APIKeyCreate {
+	_c.mutation.SetRpmLimit(v)
+	return _c
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableRpmLimit(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetRpmLimit(*v)
+	}
+	return _c
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_c *APIKeyCreate) SetTpmLimit(v int) *APIKeyCreate {
+	_c.mutation.SetTpmLimit(v)
+	return _c
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableTpmLimit(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetTpmLimit(*v)
+	}
+	return _c
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (_c *APIKeyCreate) SetAuditCaptureBody(v bool) *APIKeyCreate {
+	_c.mutation.SetAuditCaptureBody(v)
+	return _c
+}
+
+// SetNillableAuditCaptureBody sets the "audit_capture_body" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableAuditCaptureBody(v *bool) *APIKeyCreate {
+	if v != nil {
+		_c.SetAuditCaptureBody(*v)
+	}
+	return _c
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_c *APIKeyCreate) SetAllowedModels(v []string) *APIKeyCreate {
+	_c.mutation.SetAllowedModels(v)
+	return _c
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (_c *APIKeyCreate) SetDeniedModels(v []string) *APIKeyCreate {
+	_c.mutation.SetDeniedModels(v)
+	return _c
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (_c *APIKeyCreate) SetUsageHeaders(v bool) *APIKeyCreate {
+	_c.mutation.SetUsageHeaders(v)
+	return _c
+}
+
+// SetNillableUsageHeaders sets the "usage_headers" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageHeaders(v *bool) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageHeaders(*v)
+	}
+	return _c
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (_c *APIKeyCreate) SetIsSandbox(v bool) *APIKeyCreate {
+	_c.mutation.SetIsSandbox(v)
+	return _c
+}
+
+// SetNillableIsSandbox sets the "is_sandbox" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableIsSandbox(v *bool) *APIKeyCreate {
+	if v != nil {
+		_c.SetIsSandbox(*v)
+	}
+	return _c
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (_c *APIKeyCreate) SetPriorityTier(v int) *APIKeyCreate {
+	_c.mutation.SetPriorityTier(v)
+	return _c
+}
+
+// SetNillablePriorityTier sets the "priority_tier" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillablePriorityTier(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetPriorityTier(*v)
+	}
+	return _c
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_c *APIKeyCreate) SetKeyHash(v string) *APIKeyCreate {
+	_c.mutation.SetKeyHash(v)
+	return _c
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableKeyHash(v *string) *APIKeyCreate {
+	if v != nil {
+		_c.SetKeyHash(*v)
+	}
+	return _c
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (_c *APIKeyCreate) SetMaxRequestBytes(v int64) *APIKeyCreate {
+	_c.mutation.SetMaxRequestBytes(v)
+	return _c
+}
+
+// SetNillableMaxRequestBytes sets the "max_request_bytes" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableMaxRequestBytes(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetMaxRequestBytes(*v)
+	}
+	return _c
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (_c *APIKeyCreate) SetMaxResponseBytes(v int64) *APIKeyCreate {
+	_c.mutation.SetMaxResponseBytes(v)
+	return _c
+}
+
+// SetNillableMaxResponseBytes sets the "max_response_bytes" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableMaxResponseBytes(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetMaxResponseBytes(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (_c *APIKeyCreate) SetUsageQuotaRequests(v int64) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaRequests(v)
+	return _c
+}
+
+// SetNillableUsageQuotaRequests sets the "usage_quota_requests" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaRequests(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaRequests(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (_c *APIKeyCreate) SetUsageQuotaTokens(v int64) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaTokens(v)
+	return _c
+}
+
+// SetNillableUsageQuotaTokens sets the "usage_quota_tokens" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaTokens(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaTokens(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (_c *APIKeyCreate) SetUsageQuotaPeriod(v string) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaPeriod(v)
+	return _c
+}
+
+// SetNillableUsageQuotaPeriod sets the "usage_quota_period" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaPeriod(v *string) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaPeriod(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (_c *APIKeyCreate) SetUsageQuotaRequestsUsed(v int64) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaRequestsUsed(v)
+	return _c
+}
+
+// SetNillableUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaRequestsUsed(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaRequestsUsed(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (_c *APIKeyCreate) SetUsageQuotaTokensUsed(v int64) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaTokensUsed(v)
+	return _c
+}
+
+// SetNillableUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaTokensUsed(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaTokensUsed(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (_c *APIKeyCreate) SetUsageQuotaWindowStart(v time.Time) *APIKeyCreate {
+	_c.mutation.SetUsageQuotaWindowStart(v)
+	return _c
+}
+
+// SetNillableUsageQuotaWindowStart sets the "usage_quota_window_start" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsageQuotaWindowStart(v *time.Time) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsageQuotaWindowStart(*v)
+	}
+	return _c
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (_c *APIKeyCreate) SetMaskResponseModel(v bool) *APIKeyCreate {
+	_c.mutation.SetMaskResponseModel(v)
+	return _c
+}
+
+// SetNillableMaskResponseModel sets the "mask_response_model" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableMaskResponseModel(v *bool) *APIKeyCreate {
+	if v != nil {
+		_c.SetMaskResponseModel(*v)
+	}
+	return _c
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_c *APIKeyCreate) SetUser(v *User) *APIKeyCreate {
 	return _c.SetUserID(v.ID)
@@ -419,6 +655,66 @@ func (_c *APIKeyCreate) defaults() error {
 		v := apikey.DefaultUsage7d
 		_c.mutation.SetUsage7d(v)
 	}
+	if _, ok := _c.mutation.RpmLimit(); !ok {
+		v := apikey.DefaultRpmLimit
+		_c.mutation.SetRpmLimit(v)
+	}
+	if _, ok := _c.mutation.TpmLimit(); !ok {
+		v := apikey.DefaultTpmLimit
+		_c.mutation.SetTpmLimit(v)
+	}
+	if _, ok := _c.mutation.AuditCaptureBody(); !ok {
+		v := apikey.DefaultAuditCaptureBody
+		_c.mutation.SetAuditCaptureBody(v)
+	}
+	if _, ok := _c.mutation.UsageHeaders(); !ok {
+		v := apikey.DefaultUsageHeaders
+		_c.mutation.SetUsageHeaders(v)
+	}
+	if _, ok := _c.mutation.IsSandbox(); !ok {
+		v := apikey.DefaultIsSandbox
+		_c.mutation.SetIsSandbox(v)
+	}
+	if _, ok := _c.mutation.MaskResponseModel(); !ok {
+		v := apikey.DefaultMaskResponseModel
+		_c.mutation.SetMaskResponseModel(v)
+	}
+	if _, ok := _c.mutation.PriorityTier(); !ok {
+		v := apikey.DefaultPriorityTier
+		_c.mutation.SetPriorityTier(v)
+	}
+	if _, ok := _c.mutation.KeyHash(); !ok {
+		v := apikey.DefaultKeyHash
+		_c.mutation.SetKeyHash(v)
+	}
+	if _, ok := _c.mutation.MaxRequestBytes(); !ok {
+		v := apikey.DefaultMaxRequestBytes
+		_c.mutation.SetMaxRequestBytes(v)
+	}
+	if _, ok := _c.mutation.MaxResponseBytes(); !ok {
+		v := apikey.DefaultMaxResponseBytes
+		_c.mutation.SetMaxResponseBytes(v)
+	}
+	if _, ok := _c.mutation.UsageQuotaRequests(); !ok {
+		v := apikey.DefaultUsageQuotaRequests
+		_c.mutation.SetUsageQuotaRequests(v)
+	}
+	if _, ok := _c.mutation.UsageQuotaTokens(); !ok {
+		v := apikey.DefaultUsageQuotaTokens
+		_c.mutation.SetUsageQuotaTokens(v)
+	}
+	if _, ok := _c.mutation.UsageQuotaPeriod(); !ok {
+		v := apikey.DefaultUsageQuotaPeriod
+		_c.mutation.SetUsageQuotaPeriod(v)
+	}
+	if _, ok := _c.mutation.UsageQuotaRequestsUsed(); !ok {
+		v := apikey.DefaultUsageQuotaRequestsUsed
+		_c.mutation.SetUsageQuotaRequestsUsed(v)
+	}
+	if _, ok := _c.mutation.UsageQuotaTokensUsed(); !ok {
+		v := apikey.DefaultUsageQuotaTokensUsed
+		_c.mutation.SetUsageQuotaTokensUsed(v)
+	}
 	return nil
 }
 
@@ -481,6 +777,61 @@ func (_c *APIKeyCreate) check() error {
 	if _, ok := _c.mutation.Usage7d(); !ok {
 		return &ValidationError{Name: "usage_7d", err: errors.New(`ent: missing required field "APIKey.usage_7d"`)}
 	}
+	if _, ok := _c.mutation.RpmLimit(); !ok {
+		return &ValidationError{Name: "rpm_limit", err: errors.New(`ent: missing required field "APIKey.rpm_limit"`)}
+	}
+	if _, ok := _c.mutation.TpmLimit(); !ok {
+		return &ValidationError{Name: "tpm_limit", err: errors.New(`ent: missing required field "APIKey.tpm_limit"`)}
+	}
+	if _, ok := _c.mutation.AuditCaptureBody(); !ok {
+		return &ValidationError{Name: "audit_capture_body", err: errors.New(`ent: missing required field "APIKey.audit_capture_body"`)}
+	}
+	if _, ok := _c.mutation.UsageHeaders(); !ok {
+		return &ValidationError{Name: "usage_headers", err: errors.New(`ent: missing required field "APIKey.usage_headers"`)}
+	}
+	if _, ok := _c.mutation.IsSandbox(); !ok {
+		return &ValidationError{Name: "is_sandbox", err: errors.New(`ent: missing required field "APIKey.is_sandbox"`)}
+	}
+	if _, ok := _c.mutation.MaskResponseModel(); !ok {
+		return &ValidationError{Name: "mask_response_model", err: errors.New(`ent: missing required field "APIKey.mask_response_model"`)}
+	}
+	if _, ok := _c.mutation.PriorityTier(); !ok {
+		return &ValidationError{Name: "priority_tier", err: errors.New(`ent: missing required field "APIKey.priority_tier"`)}
+	}
+	if _, ok := _c.mutation.KeyHash(); !ok {
+		return &ValidationError{Name: "key_hash", err: errors.New(`ent: missing required field "APIKey.key_hash"`)}
+	}
+	if v, ok := _c.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MaxRequestBytes(); !ok {
+		return &ValidationError{Name: "max_request_bytes", err: errors.New(`ent: missing required field "APIKey.max_request_bytes"`)}
+	}
+	if _, ok := _c.mutation.MaxResponseBytes(); !ok {
+		return &ValidationError{Name: "max_response_bytes", err: errors.New(`ent: missing required field "APIKey.max_response_bytes"`)}
+	}
+	if _, ok := _c.mutation.UsageQuotaRequests(); !ok {
+		return &ValidationError{Name: "usage_quota_requests", err: errors.New(`ent: missing required field "APIKey.usage_quota_requests"`)}
+	}
+	if _, ok := _c.mutation.UsageQuotaTokens(); !ok {
+		return &ValidationError{Name: "usage_quota_tokens", err: errors.New(`ent: missing required field "APIKey.usage_quota_tokens"`)}
+	}
+	if _, ok := _c.mutation.UsageQuotaPeriod(); !ok {
+		return &ValidationError{Name: "usage_quota_period", err: errors.New(`ent: missing required field "APIKey.usage_quota_period"`)}
+	}
+	if v, ok := _c.mutation.UsageQuotaPeriod(); ok {
+		if err := apikey.UsageQuotaPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "usage_quota_period", err: fmt.Errorf(`ent: validator failed for field "APIKey.usage_quota_period": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.UsageQuotaRequestsUsed(); !ok {
+		return &ValidationError{Name: "usage_quota_requests_used", err: errors.New(`ent: missing required field "APIKey.usage_quota_requests_used"`)}
+	}
+	if _, ok := _c.mutation.UsageQuotaTokensUsed(); !ok {
+		return &ValidationError{Name: "usage_quota_tokens_used", err: errors.New(`ent: missing required field "APIKey.usage_quota_tokens_used"`)}
+	}
 	if len(_c.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "APIKey.user"`)}
 	}
@@ -595,6 +946,78 @@ func (_c *APIKeyCreate) createSpec() (*APIKey, *sqlgraph.CreateSpec) {
 		_spec.SetField(apikey.FieldWindow7dStart, field.TypeTime, value)
 		_node.Window7dStart = &value
 	}
+	if value, ok := _c.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+		_node.RpmLimit = value
+	}
+	if value, ok := _c.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+		_node.TpmLimit = value
+	}
+	if value, ok := _c.mutation.AuditCaptureBody(); ok {
+		_spec.SetField(apikey.FieldAuditCaptureBody, field.TypeBool, value)
+		_node.AuditCaptureBody = value
+	}
+	if value, ok := _c.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+		_node.AllowedModels = value
+	}
+	if value, ok := _c.mutation.DeniedModels(); ok {
+		_spec.SetField(apikey.FieldDeniedModels, field.TypeJSON, value)
+		_node.DeniedModels = value
+	}
+	if value, ok := _c.mutation.UsageHeaders(); ok {
+		_spec.SetField(apikey.FieldUsageHeaders, field.TypeBool, value)
+		_node.UsageHeaders = value
+	}
+	if value, ok := _c.mutation.IsSandbox(); ok {
+		_spec.SetField(apikey.FieldIsSandbox, field.TypeBool, value)
+		_node.IsSandbox = value
+	}
+	if value, ok := _c.mutation.PriorityTier(); ok {
+		_spec.SetField(apikey.FieldPriorityTier, field.TypeInt, value)
+		_node.PriorityTier = value
+	}
+	if value, ok := _c.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+		_node.KeyHash = value
+	}
+	if value, ok := _c.mutation.MaxRequestBytes(); ok {
+		_spec.SetField(apikey.FieldMaxRequestBytes, field.TypeInt64, value)
+		_node.MaxRequestBytes = value
+	}
+	if value, ok := _c.mutation.MaxResponseBytes(); ok {
+		_spec.SetField(apikey.FieldMaxResponseBytes, field.TypeInt64, value)
+		_node.MaxResponseBytes = value
+	}
+	if value, ok := _c.mutation.UsageQuotaRequests(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequests, field.TypeInt64, value)
+		_node.UsageQuotaRequests = value
+	}
+	if value, ok := _c.mutation.UsageQuotaTokens(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokens, field.TypeInt64, value)
+		_node.UsageQuotaTokens = value
+	}
+	if value, ok := _c.mutation.UsageQuotaPeriod(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaPeriod, field.TypeString, value)
+		_node.UsageQuotaPeriod = value
+	}
+	if value, ok := _c.mutation.UsageQuotaRequestsUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequestsUsed, field.TypeInt64, value)
+		_node.UsageQuotaRequestsUsed = value
+	}
+	if value, ok := _c.mutation.UsageQuotaTokensUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokensUsed, field.TypeInt64, value)
+		_node.UsageQuotaTokensUsed = value
+	}
+	if value, ok := _c.mutation.UsageQuotaWindowStart(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaWindowStart, field.TypeTime, value)
+		_node.UsageQuotaWindowStart = &value
+	}
+	if value, ok := _c.mutation.MaskResponseModel(); ok {
+		_spec.SetField(apikey.FieldMaskResponseModel, field.TypeBool, value)
+		_node.MaskResponseModel = value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -1063,94 +1486,382 @@ func (u *APIKeyUpsert) ClearWindow7dStart() *APIKeyUpsert {
 	return u
 }
 
-// UpdateNewValues updates the mutable fields using the new values that were set on create.
-// Using this option is equivalent to using:
-//
-//	client.APIKey.Create().
-//		OnConflict(
-//			sql.ResolveWithNewValues(),
-//		).
-//		Exec(ctx)
-func (u *APIKeyUpsertOne) UpdateNewValues() *APIKeyUpsertOne {
-	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
-	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
-		if _, exists := u.create.mutation.CreatedAt(); exists {
-			s.SetIgnore(apikey.FieldCreatedAt)
-		}
-	}))
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsert) SetRpmLimit(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldRpmLimit, v)
 	return u
 }
 
-// Ignore sets each column to itself in case of conflict.
-// Using this option is equivalent to using:
-//
-//	client.APIKey.Create().
-//	    OnConflict(sql.ResolveWithIgnore()).
-//	    Exec(ctx)
-func (u *APIKeyUpsertOne) Ignore() *APIKeyUpsertOne {
-	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateRpmLimit() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldRpmLimit)
 	return u
 }
 
-// DoNothing configures the conflict_action to `DO NOTHING`.
-// Supported only by SQLite and PostgreSQL.
-func (u *APIKeyUpsertOne) DoNothing() *APIKeyUpsertOne {
-	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsert) AddRpmLimit(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldRpmLimit, v)
 	return u
 }
 
-// Update allows overriding fields `UPDATE` values. See the APIKeyCreate.OnConflict
-// documentation for more info.
-func (u *APIKeyUpsertOne) Update(set func(*APIKeyUpsert)) *APIKeyUpsertOne {
-	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
-		set(&APIKeyUpsert{UpdateSet: update})
-	}))
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsert) SetTpmLimit(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldTpmLimit, v)
 	return u
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (u *APIKeyUpsertOne) SetUpdatedAt(v time.Time) *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.SetUpdatedAt(v)
-	})
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateTpmLimit() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldTpmLimit)
+	return u
 }
 
-// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateUpdatedAt() *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateUpdatedAt()
-	})
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsert) AddTpmLimit(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldTpmLimit, v)
+	return u
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (u *APIKeyUpsertOne) SetDeletedAt(v time.Time) *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.SetDeletedAt(v)
-	})
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (u *APIKeyUpsert) SetAuditCaptureBody(v bool) *APIKeyUpsert {
+	u.Set(apikey.FieldAuditCaptureBody, v)
+	return u
 }
 
-// UpdateDeletedAt sets the "deleted_at" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateDeletedAt() *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateDeletedAt()
-	})
+// UpdateAuditCaptureBody sets the "audit_capture_body" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateAuditCaptureBody() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldAuditCaptureBody)
+	return u
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (u *APIKeyUpsertOne) ClearDeletedAt() *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.ClearDeletedAt()
-	})
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsert) SetAllowedModels(v []string) *APIKeyUpsert {
+	u.Set(apikey.FieldAllowedModels, v)
+	return u
 }
 
-// SetUserID sets the "user_id" field.
-func (u *APIKeyUpsertOne) SetUserID(v int64) *APIKeyUpsertOne {
-	return u.Update(func(s *APIKeyUpsert) {
-		s.SetUserID(v)
-	})
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateAllowedModels() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldAllowedModels)
+	return u
 }
 
-// UpdateUserID sets the "user_id" field to the value that was provided on create.
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsert) ClearAllowedModels() *APIKeyUpsert {
+	u.SetNull(apikey.FieldAllowedModels)
+	return u
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (u *APIKeyUpsert) SetDeniedModels(v []string) *APIKeyUpsert {
+	u.Set(apikey.FieldDeniedModels, v)
+	return u
+}
+
+// UpdateDeniedModels sets the "denied_models" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateDeniedModels() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldDeniedModels)
+	return u
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (u *APIKeyUpsert) ClearDeniedModels() *APIKeyUpsert {
+	u.SetNull(apikey.FieldDeniedModels)
+	return u
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (u *APIKeyUpsert) SetUsageHeaders(v bool) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageHeaders, v)
+	return u
+}
+
+// UpdateUsageHeaders sets the "usage_headers" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageHeaders() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageHeaders)
+	return u
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (u *APIKeyUpsert) SetIsSandbox(v bool) *APIKeyUpsert {
+	u.Set(apikey.FieldIsSandbox, v)
+	return u
+}
+
+// UpdateIsSandbox sets the "is_sandbox" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateIsSandbox() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldIsSandbox)
+	return u
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (u *APIKeyUpsert) SetPriorityTier(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldPriorityTier, v)
+	return u
+}
+
+// UpdatePriorityTier sets the "priority_tier" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdatePriorityTier() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldPriorityTier)
+	return u
+}
+
+// AddPriorityTier adds v to the "priority_tier" field.
+func (u *APIKeyUpsert) AddPriorityTier(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldPriorityTier, v)
+	return u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsert) SetKeyHash(v string) *APIKeyUpsert {
+	u.Set(apikey.FieldKeyHash, v)
+	return u
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateKeyHash() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldKeyHash)
+	return u
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (u *APIKeyUpsert) SetMaxRequestBytes(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldMaxRequestBytes, v)
+	return u
+}
+
+// UpdateMaxRequestBytes sets the "max_request_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateMaxRequestBytes() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldMaxRequestBytes)
+	return u
+}
+
+// AddMaxRequestBytes adds v to the "max_request_bytes" field.
+func (u *APIKeyUpsert) AddMaxRequestBytes(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldMaxRequestBytes, v)
+	return u
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (u *APIKeyUpsert) SetMaxResponseBytes(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldMaxResponseBytes, v)
+	return u
+}
+
+// UpdateMaxResponseBytes sets the "max_response_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateMaxResponseBytes() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldMaxResponseBytes)
+	return u
+}
+
+// AddMaxResponseBytes adds v to the "max_response_bytes" field.
+func (u *APIKeyUpsert) AddMaxResponseBytes(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldMaxResponseBytes, v)
+	return u
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (u *APIKeyUpsert) SetUsageQuotaRequests(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaRequests, v)
+	return u
+}
+
+// UpdateUsageQuotaRequests sets the "usage_quota_requests" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaRequests() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaRequests)
+	return u
+}
+
+// AddUsageQuotaRequests adds v to the "usage_quota_requests" field.
+func (u *APIKeyUpsert) AddUsageQuotaRequests(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldUsageQuotaRequests, v)
+	return u
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (u *APIKeyUpsert) SetUsageQuotaTokens(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaTokens, v)
+	return u
+}
+
+// UpdateUsageQuotaTokens sets the "usage_quota_tokens" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaTokens() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaTokens)
+	return u
+}
+
+// AddUsageQuotaTokens adds v to the "usage_quota_tokens" field.
+func (u *APIKeyUpsert) AddUsageQuotaTokens(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldUsageQuotaTokens, v)
+	return u
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (u *APIKeyUpsert) SetUsageQuotaPeriod(v string) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaPeriod, v)
+	return u
+}
+
+// UpdateUsageQuotaPeriod sets the "usage_quota_period" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaPeriod() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaPeriod)
+	return u
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (u *APIKeyUpsert) SetUsageQuotaRequestsUsed(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaRequestsUsed, v)
+	return u
+}
+
+// UpdateUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaRequestsUsed() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaRequestsUsed)
+	return u
+}
+
+// AddUsageQuotaRequestsUsed adds v to the "usage_quota_requests_used" field.
+func (u *APIKeyUpsert) AddUsageQuotaRequestsUsed(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldUsageQuotaRequestsUsed, v)
+	return u
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsert) SetUsageQuotaTokensUsed(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaTokensUsed, v)
+	return u
+}
+
+// UpdateUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaTokensUsed() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaTokensUsed)
+	return u
+}
+
+// AddUsageQuotaTokensUsed adds v to the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsert) AddUsageQuotaTokensUsed(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldUsageQuotaTokensUsed, v)
+	return u
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (u *APIKeyUpsert) SetUsageQuotaWindowStart(v time.Time) *APIKeyUpsert {
+	u.Set(apikey.FieldUsageQuotaWindowStart, v)
+	return u
+}
+
+// UpdateUsageQuotaWindowStart sets the "usage_quota_window_start" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsageQuotaWindowStart() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsageQuotaWindowStart)
+	return u
+}
+
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (u *APIKeyUpsert) ClearUsageQuotaWindowStart() *APIKeyUpsert {
+	u.SetNull(apikey.FieldUsageQuotaWindowStart)
+	return u
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (u *APIKeyUpsert) SetMaskResponseModel(v bool) *APIKeyUpsert {
+	u.Set(apikey.FieldMaskResponseModel, v)
+	return u
+}
+
+// UpdateMaskResponseModel sets the "mask_response_model" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateMaskResponseModel() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldMaskResponseModel)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.APIKey.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *APIKeyUpsertOne) UpdateNewValues() *APIKeyUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(apikey.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.APIKey.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *APIKeyUpsertOne) Ignore() *APIKeyUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *APIKeyUpsertOne) DoNothing() *APIKeyUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the APIKeyCreate.OnConflict
+// documentation for more info.
+func (u *APIKeyUpsertOne) Update(set func(*APIKeyUpsert)) *APIKeyUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&APIKeyUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *APIKeyUpsertOne) SetUpdatedAt(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUpdatedAt() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (u *APIKeyUpsertOne) SetDeletedAt(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDeletedAt(v)
+	})
+}
+
+// UpdateDeletedAt sets the "deleted_at" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateDeletedAt() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDeletedAt()
+	})
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (u *APIKeyUpsertOne) ClearDeletedAt() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearDeletedAt()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *APIKeyUpsertOne) SetUserID(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
 func (u *APIKeyUpsertOne) UpdateUserID() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
 		s.UpdateUserID()
@@ -1384,154 +2095,490 @@ func (u *APIKeyUpsertOne) AddRateLimit1d(v float64) *APIKeyUpsertOne {
 // UpdateRateLimit1d sets the "rate_limit_1d" field to the value that was provided on create.
 func (u *APIKeyUpsertOne) UpdateRateLimit1d() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateRateLimit1d()
+		s.UpdateRateLimit1d()
+	})
+}
+
+// SetRateLimit7d sets the "rate_limit_7d" field.
+func (u *APIKeyUpsertOne) SetRateLimit7d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetRateLimit7d(v)
+	})
+}
+
+// AddRateLimit7d adds v to the "rate_limit_7d" field.
+func (u *APIKeyUpsertOne) AddRateLimit7d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddRateLimit7d(v)
+	})
+}
+
+// UpdateRateLimit7d sets the "rate_limit_7d" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateRateLimit7d() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateRateLimit7d()
+	})
+}
+
+// SetUsage5h sets the "usage_5h" field.
+func (u *APIKeyUpsertOne) SetUsage5h(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsage5h(v)
+	})
+}
+
+// AddUsage5h adds v to the "usage_5h" field.
+func (u *APIKeyUpsertOne) AddUsage5h(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsage5h(v)
+	})
+}
+
+// UpdateUsage5h sets the "usage_5h" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsage5h() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsage5h()
+	})
+}
+
+// SetUsage1d sets the "usage_1d" field.
+func (u *APIKeyUpsertOne) SetUsage1d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsage1d(v)
+	})
+}
+
+// AddUsage1d adds v to the "usage_1d" field.
+func (u *APIKeyUpsertOne) AddUsage1d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsage1d(v)
+	})
+}
+
+// UpdateUsage1d sets the "usage_1d" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsage1d() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsage1d()
+	})
+}
+
+// SetUsage7d sets the "usage_7d" field.
+func (u *APIKeyUpsertOne) SetUsage7d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsage7d(v)
+	})
+}
+
+// AddUsage7d adds v to the "usage_7d" field.
+func (u *APIKeyUpsertOne) AddUsage7d(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsage7d(v)
+	})
+}
+
+// UpdateUsage7d sets the "usage_7d" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsage7d() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsage7d()
+	})
+}
+
+// SetWindow5hStart sets the "window_5h_start" field.
+func (u *APIKeyUpsertOne) SetWindow5hStart(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetWindow5hStart(v)
+	})
+}
+
+// UpdateWindow5hStart sets the "window_5h_start" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateWindow5hStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateWindow5hStart()
+	})
+}
+
+// ClearWindow5hStart clears the value of the "window_5h_start" field.
+func (u *APIKeyUpsertOne) ClearWindow5hStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearWindow5hStart()
+	})
+}
+
+// SetWindow1dStart sets the "window_1d_start" field.
+func (u *APIKeyUpsertOne) SetWindow1dStart(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetWindow1dStart(v)
+	})
+}
+
+// UpdateWindow1dStart sets the "window_1d_start" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateWindow1dStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateWindow1dStart()
+	})
+}
+
+// ClearWindow1dStart clears the value of the "window_1d_start" field.
+func (u *APIKeyUpsertOne) ClearWindow1dStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearWindow1dStart()
+	})
+}
+
+// SetWindow7dStart sets the "window_7d_start" field.
+func (u *APIKeyUpsertOne) SetWindow7dStart(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetWindow7dStart(v)
+	})
+}
+
+// UpdateWindow7dStart sets the "window_7d_start" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateWindow7dStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateWindow7dStart()
+	})
+}
+
+// ClearWindow7dStart clears the value of the "window_7d_start" field.
+func (u *APIKeyUpsertOne) ClearWindow7dStart() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearWindow7dStart()
+	})
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsertOne) SetRpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetRpmLimit(v)
+	})
+}
+
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsertOne) AddRpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddRpmLimit(v)
+	})
+}
+
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateRpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateRpmLimit()
+	})
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsertOne) SetTpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetTpmLimit(v)
+	})
+}
+
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsertOne) AddTpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddTpmLimit(v)
+	})
+}
+
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateTpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateTpmLimit()
+	})
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (u *APIKeyUpsertOne) SetAuditCaptureBody(v bool) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAuditCaptureBody(v)
+	})
+}
+
+// UpdateAuditCaptureBody sets the "audit_capture_body" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateAuditCaptureBody() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAuditCaptureBody()
+	})
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsertOne) SetAllowedModels(v []string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAllowedModels(v)
+	})
+}
+
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateAllowedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAllowedModels()
+	})
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsertOne) ClearAllowedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearAllowedModels()
+	})
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (u *APIKeyUpsertOne) SetDeniedModels(v []string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDeniedModels(v)
+	})
+}
+
+// UpdateDeniedModels sets the "denied_models" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateDeniedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDeniedModels()
+	})
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (u *APIKeyUpsertOne) ClearDeniedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearDeniedModels()
+	})
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (u *APIKeyUpsertOne) SetUsageHeaders(v bool) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageHeaders(v)
+	})
+}
+
+// UpdateUsageHeaders sets the "usage_headers" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageHeaders() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageHeaders()
+	})
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (u *APIKeyUpsertOne) SetIsSandbox(v bool) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetIsSandbox(v)
+	})
+}
+
+// UpdateIsSandbox sets the "is_sandbox" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateIsSandbox() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateIsSandbox()
+	})
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (u *APIKeyUpsertOne) SetPriorityTier(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetPriorityTier(v)
+	})
+}
+
+// AddPriorityTier adds v to the "priority_tier" field.
+func (u *APIKeyUpsertOne) AddPriorityTier(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddPriorityTier(v)
+	})
+}
+
+// UpdatePriorityTier sets the "priority_tier" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdatePriorityTier() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdatePriorityTier()
+	})
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsertOne) SetKeyHash(v string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyHash(v)
+	})
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateKeyHash() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyHash()
+	})
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (u *APIKeyUpsertOne) SetMaxRequestBytes(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxRequestBytes(v)
+	})
+}
+
+// AddMaxRequestBytes adds v to the "max_request_bytes" field.
+func (u *APIKeyUpsertOne) AddMaxRequestBytes(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddMaxRequestBytes(v)
+	})
+}
+
+// UpdateMaxRequestBytes sets the "max_request_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateMaxRequestBytes() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaxRequestBytes()
+	})
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (u *APIKeyUpsertOne) SetMaxResponseBytes(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxResponseBytes(v)
 	})
 }
 
-// SetRateLimit7d sets the "rate_limit_7d" field.
-func (u *APIKeyUpsertOne) SetRateLimit7d(v float64) *APIKeyUpsertOne {
+// AddMaxResponseBytes adds v to the "max_response_bytes" field.
+func (u *APIKeyUpsertOne) AddMaxResponseBytes(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetRateLimit7d(v)
+		s.AddMaxResponseBytes(v)
 	})
 }
 
-// AddRateLimit7d adds v to the "rate_limit_7d" field.
-func (u *APIKeyUpsertOne) AddRateLimit7d(v float64) *APIKeyUpsertOne {
+// UpdateMaxResponseBytes sets the "max_response_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateMaxResponseBytes() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.AddRateLimit7d(v)
+		s.UpdateMaxResponseBytes()
 	})
 }
 
-// UpdateRateLimit7d sets the "rate_limit_7d" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateRateLimit7d() *APIKeyUpsertOne {
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaRequests(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateRateLimit7d()
+		s.SetUsageQuotaRequests(v)
 	})
 }
 
-// SetUsage5h sets the "usage_5h" field.
-func (u *APIKeyUpsertOne) SetUsage5h(v float64) *APIKeyUpsertOne {
+// AddUsageQuotaRequests adds v to the "usage_quota_requests" field.
+func (u *APIKeyUpsertOne) AddUsageQuotaRequests(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetUsage5h(v)
+		s.AddUsageQuotaRequests(v)
 	})
 }
 
-// AddUsage5h adds v to the "usage_5h" field.
-func (u *APIKeyUpsertOne) AddUsage5h(v float64) *APIKeyUpsertOne {
+// UpdateUsageQuotaRequests sets the "usage_quota_requests" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaRequests() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.AddUsage5h(v)
+		s.UpdateUsageQuotaRequests()
 	})
 }
 
-// UpdateUsage5h sets the "usage_5h" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateUsage5h() *APIKeyUpsertOne {
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaTokens(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateUsage5h()
+		s.SetUsageQuotaTokens(v)
 	})
 }
 
-// SetUsage1d sets the "usage_1d" field.
-func (u *APIKeyUpsertOne) SetUsage1d(v float64) *APIKeyUpsertOne {
+// AddUsageQuotaTokens adds v to the "usage_quota_tokens" field.
+func (u *APIKeyUpsertOne) AddUsageQuotaTokens(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetUsage1d(v)
+		s.AddUsageQuotaTokens(v)
 	})
 }
 
-// AddUsage1d adds v to the "usage_1d" field.
-func (u *APIKeyUpsertOne) AddUsage1d(v float64) *APIKeyUpsertOne {
+// UpdateUsageQuotaTokens sets the "usage_quota_tokens" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaTokens() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.AddUsage1d(v)
+		s.UpdateUsageQuotaTokens()
 	})
 }
 
-// UpdateUsage1d sets the "usage_1d" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateUsage1d() *APIKeyUpsertOne {
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaPeriod(v string) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateUsage1d()
+		s.SetUsageQuotaPeriod(v)
 	})
 }
 
-// SetUsage7d sets the "usage_7d" field.
-func (u *APIKeyUpsertOne) SetUsage7d(v float64) *APIKeyUpsertOne {
+// UpdateUsageQuotaPeriod sets the "usage_quota_period" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaPeriod() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetUsage7d(v)
+		s.UpdateUsageQuotaPeriod()
 	})
 }
 
-// AddUsage7d adds v to the "usage_7d" field.
-func (u *APIKeyUpsertOne) AddUsage7d(v float64) *APIKeyUpsertOne {
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaRequestsUsed(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.AddUsage7d(v)
+		s.SetUsageQuotaRequestsUsed(v)
 	})
 }
 
-// UpdateUsage7d sets the "usage_7d" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateUsage7d() *APIKeyUpsertOne {
+// AddUsageQuotaRequestsUsed adds v to the "usage_quota_requests_used" field.
+func (u *APIKeyUpsertOne) AddUsageQuotaRequestsUsed(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateUsage7d()
+		s.AddUsageQuotaRequestsUsed(v)
 	})
 }
 
-// SetWindow5hStart sets the "window_5h_start" field.
-func (u *APIKeyUpsertOne) SetWindow5hStart(v time.Time) *APIKeyUpsertOne {
+// UpdateUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaRequestsUsed() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetWindow5hStart(v)
+		s.UpdateUsageQuotaRequestsUsed()
 	})
 }
 
-// UpdateWindow5hStart sets the "window_5h_start" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateWindow5hStart() *APIKeyUpsertOne {
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaTokensUsed(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateWindow5hStart()
+		s.SetUsageQuotaTokensUsed(v)
 	})
 }
 
-// ClearWindow5hStart clears the value of the "window_5h_start" field.
-func (u *APIKeyUpsertOne) ClearWindow5hStart() *APIKeyUpsertOne {
+// AddUsageQuotaTokensUsed adds v to the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsertOne) AddUsageQuotaTokensUsed(v int64) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.ClearWindow5hStart()
+		s.AddUsageQuotaTokensUsed(v)
 	})
 }
 
-// SetWindow1dStart sets the "window_1d_start" field.
-func (u *APIKeyUpsertOne) SetWindow1dStart(v time.Time) *APIKeyUpsertOne {
+// UpdateUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaTokensUsed() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetWindow1dStart(v)
+		s.UpdateUsageQuotaTokensUsed()
 	})
 }
 
-// UpdateWindow1dStart sets the "window_1d_start" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateWindow1dStart() *APIKeyUpsertOne {
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (u *APIKeyUpsertOne) SetUsageQuotaWindowStart(v time.Time) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateWindow1dStart()
+		s.SetUsageQuotaWindowStart(v)
 	})
 }
 
-// ClearWindow1dStart clears the value of the "window_1d_start" field.
-func (u *APIKeyUpsertOne) ClearWindow1dStart() *APIKeyUpsertOne {
+// UpdateUsageQuotaWindowStart sets the "usage_quota_window_start" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsageQuotaWindowStart() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.ClearWindow1dStart()
+		s.UpdateUsageQuotaWindowStart()
 	})
 }
 
-// SetWindow7dStart sets the "window_7d_start" field.
-func (u *APIKeyUpsertOne) SetWindow7dStart(v time.Time) *APIKeyUpsertOne {
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (u *APIKeyUpsertOne) ClearUsageQuotaWindowStart() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.SetWindow7dStart(v)
+		s.ClearUsageQuotaWindowStart()
 	})
 }
 
-// UpdateWindow7dStart sets the "window_7d_start" field to the value that was provided on create.
-func (u *APIKeyUpsertOne) UpdateWindow7dStart() *APIKeyUpsertOne {
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (u *APIKeyUpsertOne) SetMaskResponseModel(v bool) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.UpdateWindow7dStart()
+		s.SetMaskResponseModel(v)
 	})
 }
 
-// ClearWindow7dStart clears the value of the "window_7d_start" field.
-func (u *APIKeyUpsertOne) ClearWindow7dStart() *APIKeyUpsertOne {
+// UpdateMaskResponseModel sets the "mask_response_model" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateMaskResponseModel() *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
-		s.ClearWindow7dStart()
+		s.UpdateMaskResponseModel()
 	})
 }
 
@@ -2173,6 +3220,342 @@ func (u *APIKeyUpsertBulk) ClearWindow7dStart() *APIKeyUpsertBulk {
 	})
 }
 
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsertBulk) SetRpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetRpmLimit(v)
+	})
+}
+
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsertBulk) AddRpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddRpmLimit(v)
+	})
+}
+
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateRpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateRpmLimit()
+	})
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsertBulk) SetTpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetTpmLimit(v)
+	})
+}
+
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsertBulk) AddTpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddTpmLimit(v)
+	})
+}
+
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateTpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateTpmLimit()
+	})
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (u *APIKeyUpsertBulk) SetAuditCaptureBody(v bool) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAuditCaptureBody(v)
+	})
+}
+
+// UpdateAuditCaptureBody sets the "audit_capture_body" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateAuditCaptureBody() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAuditCaptureBody()
+	})
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsertBulk) SetAllowedModels(v []string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAllowedModels(v)
+	})
+}
+
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateAllowedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAllowedModels()
+	})
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsertBulk) ClearAllowedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearAllowedModels()
+	})
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (u *APIKeyUpsertBulk) SetDeniedModels(v []string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDeniedModels(v)
+	})
+}
+
+// UpdateDeniedModels sets the "denied_models" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateDeniedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDeniedModels()
+	})
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (u *APIKeyUpsertBulk) ClearDeniedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearDeniedModels()
+	})
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (u *APIKeyUpsertBulk) SetUsageHeaders(v bool) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageHeaders(v)
+	})
+}
+
+// UpdateUsageHeaders sets the "usage_headers" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageHeaders() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageHeaders()
+	})
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (u *APIKeyUpsertBulk) SetIsSandbox(v bool) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetIsSandbox(v)
+	})
+}
+
+// UpdateIsSandbox sets the "is_sandbox" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateIsSandbox() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateIsSandbox()
+	})
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (u *APIKeyUpsertBulk) SetPriorityTier(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetPriorityTier(v)
+	})
+}
+
+// AddPriorityTier adds v to the "priority_tier" field.
+func (u *APIKeyUpsertBulk) AddPriorityTier(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddPriorityTier(v)
+	})
+}
+
+// UpdatePriorityTier sets the "priority_tier" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdatePriorityTier() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdatePriorityTier()
+	})
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsertBulk) SetKeyHash(v string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyHash(v)
+	})
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateKeyHash() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyHash()
+	})
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (u *APIKeyUpsertBulk) SetMaxRequestBytes(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxRequestBytes(v)
+	})
+}
+
+// AddMaxRequestBytes adds v to the "max_request_bytes" field.
+func (u *APIKeyUpsertBulk) AddMaxRequestBytes(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddMaxRequestBytes(v)
+	})
+}
+
+// UpdateMaxRequestBytes sets the "max_request_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateMaxRequestBytes() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaxRequestBytes()
+	})
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (u *APIKeyUpsertBulk) SetMaxResponseBytes(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxResponseBytes(v)
+	})
+}
+
+// AddMaxResponseBytes adds v to the "max_response_bytes" field.
+func (u *APIKeyUpsertBulk) AddMaxResponseBytes(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddMaxResponseBytes(v)
+	})
+}
+
+// UpdateMaxResponseBytes sets the "max_response_bytes" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateMaxResponseBytes() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaxResponseBytes()
+	})
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaRequests(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaRequests(v)
+	})
+}
+
+// AddUsageQuotaRequests adds v to the "usage_quota_requests" field.
+func (u *APIKeyUpsertBulk) AddUsageQuotaRequests(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsageQuotaRequests(v)
+	})
+}
+
+// UpdateUsageQuotaRequests sets the "usage_quota_requests" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaRequests() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaRequests()
+	})
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaTokens(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaTokens(v)
+	})
+}
+
+// AddUsageQuotaTokens adds v to the "usage_quota_tokens" field.
+func (u *APIKeyUpsertBulk) AddUsageQuotaTokens(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsageQuotaTokens(v)
+	})
+}
+
+// UpdateUsageQuotaTokens sets the "usage_quota_tokens" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaTokens() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaTokens()
+	})
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaPeriod(v string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaPeriod(v)
+	})
+}
+
+// UpdateUsageQuotaPeriod sets the "usage_quota_period" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaPeriod() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaPeriod()
+	})
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaRequestsUsed(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaRequestsUsed(v)
+	})
+}
+
+// AddUsageQuotaRequestsUsed adds v to the "usage_quota_requests_used" field.
+func (u *APIKeyUpsertBulk) AddUsageQuotaRequestsUsed(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsageQuotaRequestsUsed(v)
+	})
+}
+
+// UpdateUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaRequestsUsed() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaRequestsUsed()
+	})
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaTokensUsed(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaTokensUsed(v)
+	})
+}
+
+// AddUsageQuotaTokensUsed adds v to the "usage_quota_tokens_used" field.
+func (u *APIKeyUpsertBulk) AddUsageQuotaTokensUsed(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsageQuotaTokensUsed(v)
+	})
+}
+
+// UpdateUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaTokensUsed() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaTokensUsed()
+	})
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (u *APIKeyUpsertBulk) SetUsageQuotaWindowStart(v time.Time) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsageQuotaWindowStart(v)
+	})
+}
+
+// UpdateUsageQuotaWindowStart sets the "usage_quota_window_start" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsageQuotaWindowStart() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsageQuotaWindowStart()
+	})
+}
+
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (u *APIKeyUpsertBulk) ClearUsageQuotaWindowStart() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearUsageQuotaWindowStart()
+	})
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (u *APIKeyUpsertBulk) SetMaskResponseModel(v bool) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaskResponseModel(v)
+	})
+}
+
+// UpdateMaskResponseModel sets the "mask_response_model" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateMaskResponseModel() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaskResponseModel()
+	})
+}
+
 // Exec executes the query.
 func (u *APIKeyUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -438,6 +438,335 @@ func (_u *APIKeyUpdate) ClearWindow7dStart() *APIKeyUpdate {
 	return _u
 }
 
+// SetRpmLimit sets the "rpm_limit" field.
+func (_u *APIKeyUpdate) SetRpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.ResetRpmLimit()
+	_u.mutation.SetRpmLimit(v)
+	return _u
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableRpmLimit(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddRpmLimit adds value to the "rpm_limit" field.
+func (_u *APIKeyUpdate) AddRpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.AddRpmLimit(v)
+	return _u
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_u *APIKeyUpdate) SetTpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.ResetTpmLimit()
+	_u.mutation.SetTpmLimit(v)
+	return _u
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableTpmLimit(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddTpmLimit adds value to the "tpm_limit" field.
+func (_u *APIKeyUpdate) AddTpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.AddTpmLimit(v)
+	return _u
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (_u *APIKeyUpdate) SetAuditCaptureBody(v bool) *APIKeyUpdate {
+	_u.mutation.SetAuditCaptureBody(v)
+	return _u
+}
+
+// SetNillableAuditCaptureBody sets the "audit_capture_body" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableAuditCaptureBody(v *bool) *APIKeyUpdate {
+	if v != nil {
+		_u.SetAuditCaptureBody(*v)
+	}
+	return _u
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_u *APIKeyUpdate) SetAllowedModels(v []string) *APIKeyUpdate {
+	_u.mutation.SetAllowedModels(v)
+	return _u
+}
+
+// AppendAllowedModels appends value to the "allowed_models" field.
+func (_u *APIKeyUpdate) AppendAllowedModels(v []string) *APIKeyUpdate {
+	_u.mutation.AppendAllowedModels(v)
+	return _u
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (_u *APIKeyUpdate) ClearAllowedModels() *APIKeyUpdate {
+	_u.mutation.ClearAllowedModels()
+	return _u
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (_u *APIKeyUpdate) SetDeniedModels(v []string) *APIKeyUpdate {
+	_u.mutation.SetDeniedModels(v)
+	return _u
+}
+
+// AppendDeniedModels appends value to the "denied_models" field.
+func (_u *APIKeyUpdate) AppendDeniedModels(v []string) *APIKeyUpdate {
+	_u.mutation.AppendDeniedModels(v)
+	return _u
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (_u *APIKeyUpdate) ClearDeniedModels() *APIKeyUpdate {
+	_u.mutation.ClearDeniedModels()
+	return _u
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (_u *APIKeyUpdate) SetUsageHeaders(v bool) *APIKeyUpdate {
+	_u.mutation.SetUsageHeaders(v)
+	return _u
+}
+
+// SetNillableUsageHeaders sets the "usage_headers" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageHeaders(v *bool) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageHeaders(*v)
+	}
+	return _u
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (_u *APIKeyUpdate) SetIsSandbox(v bool) *APIKeyUpdate {
+	_u.mutation.SetIsSandbox(v)
+	return _u
+}
+
+// SetNillableIsSandbox sets the "is_sandbox" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableIsSandbox(v *bool) *APIKeyUpdate {
+	if v != nil {
+		_u.SetIsSandbox(*v)
+	}
+	return _u
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (_u *APIKeyUpdate) SetPriorityTier(v int) *APIKeyUpdate {
+	_u.mutation.ResetPriorityTier()
+	_u.mutation.SetPriorityTier(v)
+	return _u
+}
+
+// SetNillablePriorityTier sets the "priority_tier" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillablePriorityTier(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetPriorityTier(*v)
+	}
+	return _u
+}
+
+// AddPriorityTier adds value to the "priority_tier" field.
+func (_u *APIKeyUpdate) AddPriorityTier(v int) *APIKeyUpdate {
+	_u.mutation.AddPriorityTier(v)
+	return _u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_u *APIKeyUpdate) SetKeyHash(v string) *APIKeyUpdate {
+	_u.mutation.SetKeyHash(v)
+	return _u
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableKeyHash(v *string) *APIKeyUpdate {
+	if v != nil {
+		_u.SetKeyHash(*v)
+	}
+	return _u
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (_u *APIKeyUpdate) SetMaxRequestBytes(v int64) *APIKeyUpdate {
+	_u.mutation.ResetMaxRequestBytes()
+	_u.mutation.SetMaxRequestBytes(v)
+	return _u
+}
+
+// SetNillableMaxRequestBytes sets the "max_request_bytes" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableMaxRequestBytes(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetMaxRequestBytes(*v)
+	}
+	return _u
+}
+
+// AddMaxRequestBytes adds value to the "max_request_bytes" field.
+func (_u *APIKeyUpdate) AddMaxRequestBytes(v int64) *APIKeyUpdate {
+	_u.mutation.AddMaxRequestBytes(v)
+	return _u
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (_u *APIKeyUpdate) SetMaxResponseBytes(v int64) *APIKeyUpdate {
+	_u.mutation.ResetMaxResponseBytes()
+	_u.mutation.SetMaxResponseBytes(v)
+	return _u
+}
+
+// SetNillableMaxResponseBytes sets the "max_response_bytes" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableMaxResponseBytes(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetMaxResponseBytes(*v)
+	}
+	return _u
+}
+
+// AddMaxResponseBytes adds value to the "max_response_bytes" field.
+func (_u *APIKeyUpdate) AddMaxResponseBytes(v int64) *APIKeyUpdate {
+	_u.mutation.AddMaxResponseBytes(v)
+	return _u
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (_u *APIKeyUpdate) SetUsageQuotaRequests(v int64) *APIKeyUpdate {
+	_u.mutation.ResetUsageQuotaRequests()
+	_u.mutation.SetUsageQuotaRequests(v)
+	return _u
+}
+
+// SetNillableUsageQuotaRequests sets the "usage_quota_requests" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaRequests(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaRequests(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaRequests adds value to the "usage_quota_requests" field.
+func (_u *APIKeyUpdate) AddUsageQuotaRequests(v int64) *APIKeyUpdate {
+	_u.mutation.AddUsageQuotaRequests(v)
+	return _u
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (_u *APIKeyUpdate) SetUsageQuotaTokens(v int64) *APIKeyUpdate {
+	_u.mutation.ResetUsageQuotaTokens()
+	_u.mutation.SetUsageQuotaTokens(v)
+	return _u
+}
+
+// SetNillableUsageQuotaTokens sets the "usage_quota_tokens" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaTokens(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaTokens(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaTokens adds value to the "usage_quota_tokens" field.
+func (_u *APIKeyUpdate) AddUsageQuotaTokens(v int64) *APIKeyUpdate {
+	_u.mutation.AddUsageQuotaTokens(v)
+	return _u
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (_u *APIKeyUpdate) SetUsageQuotaPeriod(v string) *APIKeyUpdate {
+	_u.mutation.SetUsageQuotaPeriod(v)
+	return _u
+}
+
+// SetNillableUsageQuotaPeriod sets the "usage_quota_period" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaPeriod(v *string) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaPeriod(*v)
+	}
+	return _u
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (_u *APIKeyUpdate) SetUsageQuotaRequestsUsed(v int64) *APIKeyUpdate {
+	_u.mutation.ResetUsageQuotaRequestsUsed()
+	_u.mutation.SetUsageQuotaRequestsUsed(v)
+	return _u
+}
+
+// SetNillableUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaRequestsUsed(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaRequestsUsed(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaRequestsUsed adds value to the "usage_quota_requests_used" field.
+func (_u *APIKeyUpdate) AddUsageQuotaRequestsUsed(v int64) *APIKeyUpdate {
+	_u.mutation.AddUsageQuotaRequestsUsed(v)
+	return _u
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (_u *APIKeyUpdate) SetUsageQuotaTokensUsed(v int64) *APIKeyUpdate {
+	_u.mutation.ResetUsageQuotaTokensUsed()
+	_u.mutation.SetUsageQuotaTokensUsed(v)
+	return _u
+}
+
+// SetNillableUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaTokensUsed(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaTokensUsed(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaTokensUsed adds value to the "usage_quota_tokens_used" field.
+func (_u *APIKeyUpdate) AddUsageQuotaTokensUsed(v int64) *APIKeyUpdate {
+	_u.mutation.AddUsageQuotaTokensUsed(v)
+	return _u
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (_u *APIKeyUpdate) SetUsageQuotaWindowStart(v time.Time) *APIKeyUpdate {
+	_u.mutation.SetUsageQuotaWindowStart(v)
+	return _u
+}
+
+// SetNillableUsageQuotaWindowStart sets the "usage_quota_window_start" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsageQuotaWindowStart(v *time.Time) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsageQuotaWindowStart(*v)
+	}
+	return _u
+}
+
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (_u *APIKeyUpdate) ClearUsageQuotaWindowStart() *APIKeyUpdate {
+	_u.mutation.ClearUsageQuotaWindowStart()
+	return _u
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (_u *APIKeyUpdate) SetMaskResponseModel(v bool) *APIKeyUpdate {
+	_u.mutation.SetMaskResponseModel(v)
+	return _u
+}
+
+// SetNillableMaskResponseModel sets the "mask_response_model" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableMaskResponseModel(v *bool) *APIKeyUpdate {
+	if v != nil {
+		_u.SetMaskResponseModel(*v)
+	}
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdate) SetUser(v *User) *APIKeyUpdate {
 	return _u.SetUserID(v.ID)
@@ -560,6 +889,16 @@ func (_u *APIKeyUpdate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "APIKey.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.UsageQuotaPeriod(); ok {
+		if err := apikey.UsageQuotaPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "usage_quota_period", err: fmt.Errorf(`ent: validator failed for field "APIKey.usage_quota_period": %w`, err)}
+		}
+	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "APIKey.user"`)
 	}
@@ -696,6 +1035,106 @@ func (_u *APIKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.Window7dStartCleared() {
 		_spec.ClearField(apikey.FieldWindow7dStart, field.TypeTime)
 	}
+	if value, ok := _u.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRpmLimit(); ok {
+		_spec.AddField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTpmLimit(); ok {
+		_spec.AddField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AuditCaptureBody(); ok {
+		_spec.SetField(apikey.FieldAuditCaptureBody, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldAllowedModels, value)
+		})
+	}
+	if _u.mutation.AllowedModelsCleared() {
+		_spec.ClearField(apikey.FieldAllowedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DeniedModels(); ok {
+		_spec.SetField(apikey.FieldDeniedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDeniedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldDeniedModels, value)
+		})
+	}
+	if _u.mutation.DeniedModelsCleared() {
+		_spec.ClearField(apikey.FieldDeniedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UsageHeaders(); ok {
+		_spec.SetField(apikey.FieldUsageHeaders, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.IsSandbox(); ok {
+		_spec.SetField(apikey.FieldIsSandbox, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PriorityTier(); ok {
+		_spec.SetField(apikey.FieldPriorityTier, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPriorityTier(); ok {
+		_spec.AddField(apikey.FieldPriorityTier, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MaxRequestBytes(); ok {
+		_spec.SetField(apikey.FieldMaxRequestBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedMaxRequestBytes(); ok {
+		_spec.AddField(apikey.FieldMaxRequestBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.MaxResponseBytes(); ok {
+		_spec.SetField(apikey.FieldMaxResponseBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedMaxResponseBytes(); ok {
+		_spec.AddField(apikey.FieldMaxResponseBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaRequests(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequests, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaRequests(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaRequests, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaTokens(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaTokens(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaPeriod(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaPeriod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaRequestsUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequestsUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaRequestsUsed(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaRequestsUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaTokensUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokensUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaTokensUsed(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaTokensUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaWindowStart(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaWindowStart, field.TypeTime, value)
+	}
+	if _u.mutation.UsageQuotaWindowStartCleared() {
+		_spec.ClearField(apikey.FieldUsageQuotaWindowStart, field.TypeTime)
+	}
+	if value, ok := _u.mutation.MaskResponseModel(); ok {
+		_spec.SetField(apikey.FieldMaskResponseModel, field.TypeBool, value)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -1225,6 +1664,335 @@ func (_u *APIKeyUpdateOne) ClearWindow7dStart() *APIKeyUpdateOne {
 	return _u
 }
 
+// SetRpmLimit sets the "rpm_limit" field.
+func (_u *APIKeyUpdateOne) SetRpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetRpmLimit()
+	_u.mutation.SetRpmLimit(v)
+	return _u
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableRpmLimit(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddRpmLimit adds value to the "rpm_limit" field.
+func (_u *APIKeyUpdateOne) AddRpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.AddRpmLimit(v)
+	return _u
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_u *APIKeyUpdateOne) SetTpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetTpmLimit()
+	_u.mutation.SetTpmLimit(v)
+	return _u
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableTpmLimit(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddTpmLimit adds value to the "tpm_limit" field.
+func (_u *APIKeyUpdateOne) AddTpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.AddTpmLimit(v)
+	return _u
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (_u *APIKeyUpdateOne) SetAuditCaptureBody(v bool) *APIKeyUpdateOne {
+	_u.mutation.SetAuditCaptureBody(v)
+	return _u
+}
+
+// SetNillableAuditCaptureBody sets the "audit_capture_body" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableAuditCaptureBody(v *bool) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetAuditCaptureBody(*v)
+	}
+	return _u
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_u *APIKeyUpdateOne) SetAllowedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.SetAllowedModels(v)
+	return _u
+}
+
+// AppendAllowedModels appends value to the "allowed_models" field.
+func (_u *APIKeyUpdateOne) AppendAllowedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.AppendAllowedModels(v)
+	return _u
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (_u *APIKeyUpdateOne) ClearAllowedModels() *APIKeyUpdateOne {
+	_u.mutation.ClearAllowedModels()
+	return _u
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (_u *APIKeyUpdateOne) SetDeniedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.SetDeniedModels(v)
+	return _u
+}
+
+// AppendDeniedModels appends value to the "denied_models" field.
+func (_u *APIKeyUpdateOne) AppendDeniedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.AppendDeniedModels(v)
+	return _u
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (_u *APIKeyUpdateOne) ClearDeniedModels() *APIKeyUpdateOne {
+	_u.mutation.ClearDeniedModels()
+	return _u
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (_u *APIKeyUpdateOne) SetUsageHeaders(v bool) *APIKeyUpdateOne {
+	_u.mutation.SetUsageHeaders(v)
+	return _u
+}
+
+// SetNillableUsageHeaders sets the "usage_headers" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageHeaders(v *bool) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageHeaders(*v)
+	}
+	return _u
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (_u *APIKeyUpdateOne) SetIsSandbox(v bool) *APIKeyUpdateOne {
+	_u.mutation.SetIsSandbox(v)
+	return _u
+}
+
+// SetNillableIsSandbox sets the "is_sandbox" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableIsSandbox(v *bool) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetIsSandbox(*v)
+	}
+	return _u
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (_u *APIKeyUpdateOne) SetPriorityTier(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetPriorityTier()
+	_u.mutation.SetPriorityTier(v)
+	return _u
+}
+
+// SetNillablePriorityTier sets the "priority_tier" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillablePriorityTier(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetPriorityTier(*v)
+	}
+	return _u
+}
+
+// AddPriorityTier adds value to the "priority_tier" field.
+func (_u *APIKeyUpdateOne) AddPriorityTier(v int) *APIKeyUpdateOne {
+	_u.mutation.AddPriorityTier(v)
+	return _u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_u *APIKeyUpdateOne) SetKeyHash(v string) *APIKeyUpdateOne {
+	_u.mutation.SetKeyHash(v)
+	return _u
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableKeyHash(v *string) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetKeyHash(*v)
+	}
+	return _u
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (_u *APIKeyUpdateOne) SetMaxRequestBytes(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetMaxRequestBytes()
+	_u.mutation.SetMaxRequestBytes(v)
+	return _u
+}
+
+// SetNillableMaxRequestBytes sets the "max_request_bytes" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableMaxRequestBytes(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetMaxRequestBytes(*v)
+	}
+	return _u
+}
+
+// AddMaxRequestBytes adds value to the "max_request_bytes" field.
+func (_u *APIKeyUpdateOne) AddMaxRequestBytes(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddMaxRequestBytes(v)
+	return _u
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (_u *APIKeyUpdateOne) SetMaxResponseBytes(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetMaxResponseBytes()
+	_u.mutation.SetMaxResponseBytes(v)
+	return _u
+}
+
+// SetNillableMaxResponseBytes sets the "max_response_bytes" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableMaxResponseBytes(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetMaxResponseBytes(*v)
+	}
+	return _u
+}
+
+// AddMaxResponseBytes adds value to the "max_response_bytes" field.
+func (_u *APIKeyUpdateOne) AddMaxResponseBytes(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddMaxResponseBytes(v)
+	return _u
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaRequests(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetUsageQuotaRequests()
+	_u.mutation.SetUsageQuotaRequests(v)
+	return _u
+}
+
+// SetNillableUsageQuotaRequests sets the "usage_quota_requests" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaRequests(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaRequests(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaRequests adds value to the "usage_quota_requests" field.
+func (_u *APIKeyUpdateOne) AddUsageQuotaRequests(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddUsageQuotaRequests(v)
+	return _u
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaTokens(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetUsageQuotaTokens()
+	_u.mutation.SetUsageQuotaTokens(v)
+	return _u
+}
+
+// SetNillableUsageQuotaTokens sets the "usage_quota_tokens" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaTokens(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaTokens(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaTokens adds value to the "usage_quota_tokens" field.
+func (_u *APIKeyUpdateOne) AddUsageQuotaTokens(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddUsageQuotaTokens(v)
+	return _u
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaPeriod(v string) *APIKeyUpdateOne {
+	_u.mutation.SetUsageQuotaPeriod(v)
+	return _u
+}
+
+// SetNillableUsageQuotaPeriod sets the "usage_quota_period" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaPeriod(v *string) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaPeriod(*v)
+	}
+	return _u
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaRequestsUsed(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetUsageQuotaRequestsUsed()
+	_u.mutation.SetUsageQuotaRequestsUsed(v)
+	return _u
+}
+
+// SetNillableUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaRequestsUsed(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaRequestsUsed(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaRequestsUsed adds value to the "usage_quota_requests_used" field.
+func (_u *APIKeyUpdateOne) AddUsageQuotaRequestsUsed(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddUsageQuotaRequestsUsed(v)
+	return _u
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaTokensUsed(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetUsageQuotaTokensUsed()
+	_u.mutation.SetUsageQuotaTokensUsed(v)
+	return _u
+}
+
+// SetNillableUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaTokensUsed(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaTokensUsed(*v)
+	}
+	return _u
+}
+
+// AddUsageQuotaTokensUsed adds value to the "usage_quota_tokens_used" field.
+func (_u *APIKeyUpdateOne) AddUsageQuotaTokensUsed(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddUsageQuotaTokensUsed(v)
+	return _u
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (_u *APIKeyUpdateOne) SetUsageQuotaWindowStart(v time.Time) *APIKeyUpdateOne {
+	_u.mutation.SetUsageQuotaWindowStart(v)
+	return _u
+}
+
+// SetNillableUsageQuotaWindowStart sets the "usage_quota_window_start" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsageQuotaWindowStart(v *time.Time) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsageQuotaWindowStart(*v)
+	}
+	return _u
+}
+
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (_u *APIKeyUpdateOne) ClearUsageQuotaWindowStart() *APIKeyUpdateOne {
+	_u.mutation.ClearUsageQuotaWindowStart()
+	return _u
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (_u *APIKeyUpdateOne) SetMaskResponseModel(v bool) *APIKeyUpdateOne {
+	_u.mutation.SetMaskResponseModel(v)
+	return _u
+}
+
+// SetNillableMaskResponseModel sets the "mask_response_model" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableMaskResponseModel(v *bool) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetMaskResponseModel(*v)
+	}
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdateOne) SetUser(v *User) *APIKeyUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -1360,6 +2128,16 @@ func (_u *APIKeyUpdateOne) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "APIKey.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.UsageQuotaPeriod(); ok {
+		if err := apikey.UsageQuotaPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "usage_quota_period", err: fmt.Errorf(`ent: validator failed for field "APIKey.usage_quota_period": %w`, err)}
+		}
+	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "APIKey.user"`)
 	}
@@ -1513,6 +2291,106 @@ func (_u *APIKeyUpdateOne) sqlSave(ctx context.Context) (_node *APIKey, err erro
 	if _u.mutation.Window7dStartCleared() {
 		_spec.ClearField(apikey.FieldWindow7dStart, field.TypeTime)
 	}
+	if value, ok := _u.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRpmLimit(); ok {
+		_spec.AddField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTpmLimit(); ok {
+		_spec.AddField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AuditCaptureBody(); ok {
+		_spec.SetField(apikey.FieldAuditCaptureBody, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldAllowedModels, value)
+		})
+	}
+	if _u.mutation.AllowedModelsCleared() {
+		_spec.ClearField(apikey.FieldAllowedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DeniedModels(); ok {
+		_spec.SetField(apikey.FieldDeniedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDeniedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldDeniedModels, value)
+		})
+	}
+	if _u.mutation.DeniedModelsCleared() {
+		_spec.ClearField(apikey.FieldDeniedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UsageHeaders(); ok {
+		_spec.SetField(apikey.FieldUsageHeaders, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.IsSandbox(); ok {
+		_spec.SetField(apikey.FieldIsSandbox, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PriorityTier(); ok {
+		_spec.SetField(apikey.FieldPriorityTier, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPriorityTier(); ok {
+		_spec.AddField(apikey.FieldPriorityTier, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MaxRequestBytes(); ok {
+		_spec.SetField(apikey.FieldMaxRequestBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedMaxRequestBytes(); ok {
+		_spec.AddField(apikey.FieldMaxRequestBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.MaxResponseBytes(); ok {
+		_spec.SetField(apikey.FieldMaxResponseBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedMaxResponseBytes(); ok {
+		_spec.AddField(apikey.FieldMaxResponseBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaRequests(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequests, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaRequests(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaRequests, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaTokens(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaTokens(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaPeriod(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaPeriod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaRequestsUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaRequestsUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaRequestsUsed(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaRequestsUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaTokensUsed(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaTokensUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageQuotaTokensUsed(); ok {
+		_spec.AddField(apikey.FieldUsageQuotaTokensUsed, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.UsageQuotaWindowStart(); ok {
+		_spec.SetField(apikey.FieldUsageQuotaWindowStart, field.TypeTime, value)
+	}
+	if _u.mutation.UsageQuotaWindowStartCleared() {
+		_spec.ClearField(apikey.FieldUsageQuotaWindowStart, field.TypeTime)
+	}
+	if value, ok := _u.mutation.MaskResponseModel(); ok {
+		_spec.SetField(apikey.FieldMaskResponseModel, field.TypeBool, value)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
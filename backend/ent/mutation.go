@@ -98,51 +98,80 @@ const (
 // APIKeyMutation represents an operation that mutates the APIKey nodes in the graph.
 type APIKeyMutation struct {
 	config
-	op                 Op
-	typ                string
-	id                 *int64
-	created_at         *time.Time
-	updated_at         *time.Time
-	deleted_at         *time.Time
-	key                *string
-	name               *string
-	status             *string
-	last_used_at       *time.Time
-	ip_whitelist       *[]string
-	appendip_whitelist []string
-	ip_blacklist       *[]string
-	appendip_blacklist []string
-	quota              *float64
-	addquota           *float64
-	quota_used         *float64
-	addquota_used      *float64
-	expires_at         *time.Time
-	rate_limit_5h      *float64
-	addrate_limit_5h   *float64
-	rate_limit_1d      *float64
-	addrate_limit_1d   *float64
-	rate_limit_7d      *float64
-	addrate_limit_7d   *float64
-	usage_5h           *float64
-	addusage_5h        *float64
-	usage_1d           *float64
-	addusage_1d        *float64
-	usage_7d           *float64
-	addusage_7d        *float64
-	window_5h_start    *time.Time
-	window_1d_start    *time.Time
-	window_7d_start    *time.Time
-	clearedFields      map[string]struct{}
-	user               *int64
-	cleareduser        bool
-	group              *int64
-	clearedgroup       bool
-	usage_logs         map[int64]struct{}
-	removedusage_logs  map[int64]struct{}
-	clearedusage_logs  bool
-	done               bool
-	oldValue           func(context.Context) (*APIKey, error)
-	predicates         []predicate.APIKey
+	op                           Op
+	typ                          string
+	id                           *int64
+	created_at                   *time.Time
+	updated_at                   *time.Time
+	deleted_at                   *time.Time
+	key                          *string
+	name                         *string
+	status                       *string
+	last_used_at                 *time.Time
+	ip_whitelist                 *[]string
+	appendip_whitelist           []string
+	ip_blacklist                 *[]string
+	appendip_blacklist           []string
+	quota                        *float64
+	addquota                     *float64
+	quota_used                   *float64
+	addquota_used                *float64
+	expires_at                   *time.Time
+	rate_limit_5h                *float64
+	addrate_limit_5h             *float64
+	rate_limit_1d                *float64
+	addrate_limit_1d             *float64
+	rate_limit_7d                *float64
+	addrate_limit_7d             *float64
+	usage_5h                     *float64
+	addusage_5h                  *float64
+	usage_1d                     *float64
+	addusage_1d                  *float64
+	usage_7d                     *float64
+	addusage_7d                  *float64
+	window_5h_start              *time.Time
+	window_1d_start              *time.Time
+	window_7d_start              *time.Time
+	rpm_limit                    *int
+	addrpm_limit                 *int
+	tpm_limit                    *int
+	addtpm_limit                 *int
+	audit_capture_body           *bool
+	allowed_models               *[]string
+	appendallowed_models         []string
+	denied_models                *[]string
+	appenddenied_models          []string
+	usage_headers                *bool
+	is_sandbox                   *bool
+	priority_tier                *int
+	addpriority_tier             *int
+	key_hash                     *string
+	max_request_bytes            *int64
+	addmax_request_bytes         *int64
+	max_response_bytes           *int64
+	addmax_response_bytes        *int64
+	usage_quota_requests         *int64
+	addusage_quota_requests      *int64
+	usage_quota_tokens           *int64
+	addusage_quota_tokens        *int64
+	usage_quota_period           *string
+	usage_quota_requests_used    *int64
+	addusage_quota_requests_used *int64
+	usage_quota_tokens_used      *int64
+	addusage_quota_tokens_used   *int64
+	usage_quota_window_start     *time.Time
+	mask_response_model          *bool
+	clearedFields                map[string]struct{}
+	user                         *int64
+	cleareduser                  bool
+	group                        *int64
+	clearedgroup                 bool
+	usage_logs                   map[int64]struct{}
+	removedusage_logs            map[int64]struct{}
+	clearedusage_logs            bool
+	done                         bool
+	oldValue                     func(context.Context) (*APIKey, error)
+	predicates                   []predicate.APIKey
 }
 
 var _ ent.Mutation = (*APIKeyMutation)(nil)
@@ -1380,6 +1409,905 @@ func (m *APIKeyMutation) ResetWindow7dStart() {
 	delete(m.clearedFields, apikey.FieldWindow7dStart)
 }
 
+// SetRpmLimit sets the "rpm_limit" field.
+func (m *APIKeyMutation) SetRpmLimit(i int) {
+	m.rpm_limit = &i
+	m.addrpm_limit = nil
+}
+
+// RpmLimit returns the value of the "rpm_limit" field in the mutation.
+func (m *APIKeyMutation) RpmLimit() (r int, exists bool) {
+	v := m.rpm_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRpmLimit returns the old "rpm_limit" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldRpmLimit(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRpmLimit is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRpmLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRpmLimit: %w", err)
+	}
+	return oldValue.RpmLimit, nil
+}
+
+// AddRpmLimit adds i to the "rpm_limit" field.
+func (m *APIKeyMutation) AddRpmLimit(i int) {
+	if m.addrpm_limit != nil {
+		*m.addrpm_limit += i
+	} else {
+		m.addrpm_limit = &i
+	}
+}
+
+// AddedRpmLimit returns the value that was added to the "rpm_limit" field in this mutation.
+func (m *APIKeyMutation) AddedRpmLimit() (r int, exists bool) {
+	v := m.addrpm_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRpmLimit resets all changes to the "rpm_limit" field.
+func (m *APIKeyMutation) ResetRpmLimit() {
+	m.rpm_limit = nil
+	m.addrpm_limit = nil
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (m *APIKeyMutation) SetTpmLimit(i int) {
+	m.tpm_limit = &i
+	m.addtpm_limit = nil
+}
+
+// TpmLimit returns the value of the "tpm_limit" field in the mutation.
+func (m *APIKeyMutation) TpmLimit() (r int, exists bool) {
+	v := m.tpm_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTpmLimit returns the old "tpm_limit" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldTpmLimit(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTpmLimit is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTpmLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTpmLimit: %w", err)
+	}
+	return oldValue.TpmLimit, nil
+}
+
+// AddTpmLimit adds i to the "tpm_limit" field.
+func (m *APIKeyMutation) AddTpmLimit(i int) {
+	if m.addtpm_limit != nil {
+		*m.addtpm_limit += i
+	} else {
+		m.addtpm_limit = &i
+	}
+}
+
+// AddedTpmLimit returns the value that was added to the "tpm_limit" field in this mutation.
+func (m *APIKeyMutation) AddedTpmLimit() (r int, exists bool) {
+	v := m.addtpm_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTpmLimit resets all changes to the "tpm_limit" field.
+func (m *APIKeyMutation) ResetTpmLimit() {
+	m.tpm_limit = nil
+	m.addtpm_limit = nil
+}
+
+// SetAuditCaptureBody sets the "audit_capture_body" field.
+func (m *APIKeyMutation) SetAuditCaptureBody(b bool) {
+	m.audit_capture_body = &b
+}
+
+// AuditCaptureBody returns the value of the "audit_capture_body" field in the mutation.
+func (m *APIKeyMutation) AuditCaptureBody() (r bool, exists bool) {
+	v := m.audit_capture_body
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuditCaptureBody returns the old "audit_capture_body" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldAuditCaptureBody(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuditCaptureBody is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuditCaptureBody requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuditCaptureBody: %w", err)
+	}
+	return oldValue.AuditCaptureBody, nil
+}
+
+// ResetAuditCaptureBody resets all changes to the "audit_capture_body" field.
+func (m *APIKeyMutation) ResetAuditCaptureBody() {
+	m.audit_capture_body = nil
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (m *APIKeyMutation) SetAllowedModels(s []string) {
+	m.allowed_models = &s
+	m.appendallowed_models = nil
+}
+
+// AllowedModels returns the value of the "allowed_models" field in the mutation.
+func (m *APIKeyMutation) AllowedModels() (r []string, exists bool) {
+	v := m.allowed_models
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAllowedModels returns the old "allowed_models" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldAllowedModels(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAllowedModels is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAllowedModels requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAllowedModels: %w", err)
+	}
+	return oldValue.AllowedModels, nil
+}
+
+// AppendAllowedModels adds s to the "allowed_models" field.
+func (m *APIKeyMutation) AppendAllowedModels(s []string) {
+	m.appendallowed_models = append(m.appendallowed_models, s...)
+}
+
+// AppendedAllowedModels returns the list of values that were appended to the "allowed_models" field in this mutation.
+func (m *APIKeyMutation) AppendedAllowedModels() ([]string, bool) {
+	if len(m.appendallowed_models) == 0 {
+		return nil, false
+	}
+	return m.appendallowed_models, true
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (m *APIKeyMutation) ClearAllowedModels() {
+	m.allowed_models = nil
+	m.appendallowed_models = nil
+	m.clearedFields[apikey.FieldAllowedModels] = struct{}{}
+}
+
+// AllowedModelsCleared returns if the "allowed_models" field was cleared in this mutation.
+func (m *APIKeyMutation) AllowedModelsCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldAllowedModels]
+	return ok
+}
+
+// ResetAllowedModels resets all changes to the "allowed_models" field.
+func (m *APIKeyMutation) ResetAllowedModels() {
+	m.allowed_models = nil
+	m.appendallowed_models = nil
+	delete(m.clearedFields, apikey.FieldAllowedModels)
+}
+
+// SetDeniedModels sets the "denied_models" field.
+func (m *APIKeyMutation) SetDeniedModels(s []string) {
+	m.denied_models = &s
+	m.appenddenied_models = nil
+}
+
+// DeniedModels returns the value of the "denied_models" field in the mutation.
+func (m *APIKeyMutation) DeniedModels() (r []string, exists bool) {
+	v := m.denied_models
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeniedModels returns the old "denied_models" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldDeniedModels(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeniedModels is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeniedModels requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeniedModels: %w", err)
+	}
+	return oldValue.DeniedModels, nil
+}
+
+// AppendDeniedModels adds s to the "denied_models" field.
+func (m *APIKeyMutation) AppendDeniedModels(s []string) {
+	m.appenddenied_models = append(m.appenddenied_models, s...)
+}
+
+// AppendedDeniedModels returns the list of values that were appended to the "denied_models" field in this mutation.
+func (m *APIKeyMutation) AppendedDeniedModels() ([]string, bool) {
+	if len(m.appenddenied_models) == 0 {
+		return nil, false
+	}
+	return m.appenddenied_models, true
+}
+
+// ClearDeniedModels clears the value of the "denied_models" field.
+func (m *APIKeyMutation) ClearDeniedModels() {
+	m.denied_models = nil
+	m.appenddenied_models = nil
+	m.clearedFields[apikey.FieldDeniedModels] = struct{}{}
+}
+
+// DeniedModelsCleared returns if the "denied_models" field was cleared in this mutation.
+func (m *APIKeyMutation) DeniedModelsCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldDeniedModels]
+	return ok
+}
+
+// ResetDeniedModels resets all changes to the "denied_models" field.
+func (m *APIKeyMutation) ResetDeniedModels() {
+	m.denied_models = nil
+	m.appenddenied_models = nil
+	delete(m.clearedFields, apikey.FieldDeniedModels)
+}
+
+// SetUsageHeaders sets the "usage_headers" field.
+func (m *APIKeyMutation) SetUsageHeaders(b bool) {
+	m.usage_headers = &b
+}
+
+// UsageHeaders returns the value of the "usage_headers" field in the mutation.
+func (m *APIKeyMutation) UsageHeaders() (r bool, exists bool) {
+	v := m.usage_headers
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageHeaders returns the old "usage_headers" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageHeaders(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageHeaders is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageHeaders requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageHeaders: %w", err)
+	}
+	return oldValue.UsageHeaders, nil
+}
+
+// ResetUsageHeaders resets all changes to the "usage_headers" field.
+func (m *APIKeyMutation) ResetUsageHeaders() {
+	m.usage_headers = nil
+}
+
+// SetIsSandbox sets the "is_sandbox" field.
+func (m *APIKeyMutation) SetIsSandbox(b bool) {
+	m.is_sandbox = &b
+}
+
+// IsSandbox returns the value of the "is_sandbox" field in the mutation.
+func (m *APIKeyMutation) IsSandbox() (r bool, exists bool) {
+	v := m.is_sandbox
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIsSandbox returns the old "is_sandbox" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldIsSandbox(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIsSandbox is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIsSandbox requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIsSandbox: %w", err)
+	}
+	return oldValue.IsSandbox, nil
+}
+
+// ResetIsSandbox resets all changes to the "is_sandbox" field.
+func (m *APIKeyMutation) ResetIsSandbox() {
+	m.is_sandbox = nil
+}
+
+// SetPriorityTier sets the "priority_tier" field.
+func (m *APIKeyMutation) SetPriorityTier(i int) {
+	m.priority_tier = &i
+	m.addpriority_tier = nil
+}
+
+// PriorityTier returns the value of the "priority_tier" field in the mutation.
+func (m *APIKeyMutation) PriorityTier() (r int, exists bool) {
+	v := m.priority_tier
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPriorityTier returns the old "priority_tier" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldPriorityTier(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPriorityTier is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPriorityTier requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPriorityTier: %w", err)
+	}
+	return oldValue.PriorityTier, nil
+}
+
+// AddPriorityTier adds i to the "priority_tier" field.
+func (m *APIKeyMutation) AddPriorityTier(i int) {
+	if m.addpriority_tier != nil {
+		*m.addpriority_tier += i
+	} else {
+		m.addpriority_tier = &i
+	}
+}
+
+// AddedPriorityTier returns the value that was added to the "priority_tier" field in this mutation.
+func (m *APIKeyMutation) AddedPriorityTier() (r int, exists bool) {
+	v := m.addpriority_tier
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPriorityTier resets all changes to the "priority_tier" field.
+func (m *APIKeyMutation) ResetPriorityTier() {
+	m.priority_tier = nil
+	m.addpriority_tier = nil
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (m *APIKeyMutation) SetKeyHash(s string) {
+	m.key_hash = &s
+}
+
+// KeyHash returns the value of the "key_hash" field in the mutation.
+func (m *APIKeyMutation) KeyHash() (r string, exists bool) {
+	v := m.key_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKeyHash returns the old "key_hash" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldKeyHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKeyHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKeyHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKeyHash: %w", err)
+	}
+	return oldValue.KeyHash, nil
+}
+
+// ResetKeyHash resets all changes to the "key_hash" field.
+func (m *APIKeyMutation) ResetKeyHash() {
+	m.key_hash = nil
+}
+
+// SetMaxRequestBytes sets the "max_request_bytes" field.
+func (m *APIKeyMutation) SetMaxRequestBytes(i int64) {
+	m.max_request_bytes = &i
+	m.addmax_request_bytes = nil
+}
+
+// MaxRequestBytes returns the value of the "max_request_bytes" field in the mutation.
+func (m *APIKeyMutation) MaxRequestBytes() (r int64, exists bool) {
+	v := m.max_request_bytes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxRequestBytes returns the old "max_request_bytes" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldMaxRequestBytes(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxRequestBytes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxRequestBytes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxRequestBytes: %w", err)
+	}
+	return oldValue.MaxRequestBytes, nil
+}
+
+// AddMaxRequestBytes adds i to the "max_request_bytes" field.
+func (m *APIKeyMutation) AddMaxRequestBytes(i int64) {
+	if m.addmax_request_bytes != nil {
+		*m.addmax_request_bytes += i
+	} else {
+		m.addmax_request_bytes = &i
+	}
+}
+
+// AddedMaxRequestBytes returns the value that was added to the "max_request_bytes" field in this mutation.
+func (m *APIKeyMutation) AddedMaxRequestBytes() (r int64, exists bool) {
+	v := m.addmax_request_bytes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxRequestBytes resets all changes to the "max_request_bytes" field.
+func (m *APIKeyMutation) ResetMaxRequestBytes() {
+	m.max_request_bytes = nil
+	m.addmax_request_bytes = nil
+}
+
+// SetMaxResponseBytes sets the "max_response_bytes" field.
+func (m *APIKeyMutation) SetMaxResponseBytes(i int64) {
+	m.max_response_bytes = &i
+	m.addmax_response_bytes = nil
+}
+
+// MaxResponseBytes returns the value of the "max_response_bytes" field in the mutation.
+func (m *APIKeyMutation) MaxResponseBytes() (r int64, exists bool) {
+	v := m.max_response_bytes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxResponseBytes returns the old "max_response_bytes" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldMaxResponseBytes(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxResponseBytes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxResponseBytes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxResponseBytes: %w", err)
+	}
+	return oldValue.MaxResponseBytes, nil
+}
+
+// AddMaxResponseBytes adds i to the "max_response_bytes" field.
+func (m *APIKeyMutation) AddMaxResponseBytes(i int64) {
+	if m.addmax_response_bytes != nil {
+		*m.addmax_response_bytes += i
+	} else {
+		m.addmax_response_bytes = &i
+	}
+}
+
+// AddedMaxResponseBytes returns the value that was added to the "max_response_bytes" field in this mutation.
+func (m *APIKeyMutation) AddedMaxResponseBytes() (r int64, exists bool) {
+	v := m.addmax_response_bytes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxResponseBytes resets all changes to the "max_response_bytes" field.
+func (m *APIKeyMutation) ResetMaxResponseBytes() {
+	m.max_response_bytes = nil
+	m.addmax_response_bytes = nil
+}
+
+// SetUsageQuotaRequests sets the "usage_quota_requests" field.
+func (m *APIKeyMutation) SetUsageQuotaRequests(i int64) {
+	m.usage_quota_requests = &i
+	m.addusage_quota_requests = nil
+}
+
+// UsageQuotaRequests returns the value of the "usage_quota_requests" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaRequests() (r int64, exists bool) {
+	v := m.usage_quota_requests
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaRequests returns the old "usage_quota_requests" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaRequests(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaRequests is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaRequests requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaRequests: %w", err)
+	}
+	return oldValue.UsageQuotaRequests, nil
+}
+
+// AddUsageQuotaRequests adds i to the "usage_quota_requests" field.
+func (m *APIKeyMutation) AddUsageQuotaRequests(i int64) {
+	if m.addusage_quota_requests != nil {
+		*m.addusage_quota_requests += i
+	} else {
+		m.addusage_quota_requests = &i
+	}
+}
+
+// AddedUsageQuotaRequests returns the value that was added to the "usage_quota_requests" field in this mutation.
+func (m *APIKeyMutation) AddedUsageQuotaRequests() (r int64, exists bool) {
+	v := m.addusage_quota_requests
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUsageQuotaRequests resets all changes to the "usage_quota_requests" field.
+func (m *APIKeyMutation) ResetUsageQuotaRequests() {
+	m.usage_quota_requests = nil
+	m.addusage_quota_requests = nil
+}
+
+// SetUsageQuotaTokens sets the "usage_quota_tokens" field.
+func (m *APIKeyMutation) SetUsageQuotaTokens(i int64) {
+	m.usage_quota_tokens = &i
+	m.addusage_quota_tokens = nil
+}
+
+// UsageQuotaTokens returns the value of the "usage_quota_tokens" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaTokens() (r int64, exists bool) {
+	v := m.usage_quota_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaTokens returns the old "usage_quota_tokens" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaTokens: %w", err)
+	}
+	return oldValue.UsageQuotaTokens, nil
+}
+
+// AddUsageQuotaTokens adds i to the "usage_quota_tokens" field.
+func (m *APIKeyMutation) AddUsageQuotaTokens(i int64) {
+	if m.addusage_quota_tokens != nil {
+		*m.addusage_quota_tokens += i
+	} else {
+		m.addusage_quota_tokens = &i
+	}
+}
+
+// AddedUsageQuotaTokens returns the value that was added to the "usage_quota_tokens" field in this mutation.
+func (m *APIKeyMutation) AddedUsageQuotaTokens() (r int64, exists bool) {
+	v := m.addusage_quota_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUsageQuotaTokens resets all changes to the "usage_quota_tokens" field.
+func (m *APIKeyMutation) ResetUsageQuotaTokens() {
+	m.usage_quota_tokens = nil
+	m.addusage_quota_tokens = nil
+}
+
+// SetUsageQuotaPeriod sets the "usage_quota_period" field.
+func (m *APIKeyMutation) SetUsageQuotaPeriod(s string) {
+	m.usage_quota_period = &s
+}
+
+// UsageQuotaPeriod returns the value of the "usage_quota_period" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaPeriod() (r string, exists bool) {
+	v := m.usage_quota_period
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaPeriod returns the old "usage_quota_period" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaPeriod(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaPeriod is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaPeriod requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaPeriod: %w", err)
+	}
+	return oldValue.UsageQuotaPeriod, nil
+}
+
+// ResetUsageQuotaPeriod resets all changes to the "usage_quota_period" field.
+func (m *APIKeyMutation) ResetUsageQuotaPeriod() {
+	m.usage_quota_period = nil
+}
+
+// SetUsageQuotaRequestsUsed sets the "usage_quota_requests_used" field.
+func (m *APIKeyMutation) SetUsageQuotaRequestsUsed(i int64) {
+	m.usage_quota_requests_used = &i
+	m.addusage_quota_requests_used = nil
+}
+
+// UsageQuotaRequestsUsed returns the value of the "usage_quota_requests_used" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaRequestsUsed() (r int64, exists bool) {
+	v := m.usage_quota_requests_used
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaRequestsUsed returns the old "usage_quota_requests_used" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaRequestsUsed(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaRequestsUsed is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaRequestsUsed requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaRequestsUsed: %w", err)
+	}
+	return oldValue.UsageQuotaRequestsUsed, nil
+}
+
+// AddUsageQuotaRequestsUsed adds i to the "usage_quota_requests_used" field.
+func (m *APIKeyMutation) AddUsageQuotaRequestsUsed(i int64) {
+	if m.addusage_quota_requests_used != nil {
+		*m.addusage_quota_requests_used += i
+	} else {
+		m.addusage_quota_requests_used = &i
+	}
+}
+
+// AddedUsageQuotaRequestsUsed returns the value that was added to the "usage_quota_requests_used" field in this mutation.
+func (m *APIKeyMutation) AddedUsageQuotaRequestsUsed() (r int64, exists bool) {
+	v := m.addusage_quota_requests_used
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUsageQuotaRequestsUsed resets all changes to the "usage_quota_requests_used" field.
+func (m *APIKeyMutation) ResetUsageQuotaRequestsUsed() {
+	m.usage_quota_requests_used = nil
+	m.addusage_quota_requests_used = nil
+}
+
+// SetUsageQuotaTokensUsed sets the "usage_quota_tokens_used" field.
+func (m *APIKeyMutation) SetUsageQuotaTokensUsed(i int64) {
+	m.usage_quota_tokens_used = &i
+	m.addusage_quota_tokens_used = nil
+}
+
+// UsageQuotaTokensUsed returns the value of the "usage_quota_tokens_used" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaTokensUsed() (r int64, exists bool) {
+	v := m.usage_quota_tokens_used
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaTokensUsed returns the old "usage_quota_tokens_used" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaTokensUsed(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaTokensUsed is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaTokensUsed requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaTokensUsed: %w", err)
+	}
+	return oldValue.UsageQuotaTokensUsed, nil
+}
+
+// AddUsageQuotaTokensUsed adds i to the "usage_quota_tokens_used" field.
+func (m *APIKeyMutation) AddUsageQuotaTokensUsed(i int64) {
+	if m.addusage_quota_tokens_used != nil {
+		*m.addusage_quota_tokens_used += i
+	} else {
+		m.addusage_quota_tokens_used = &i
+	}
+}
+
+// AddedUsageQuotaTokensUsed returns the value that was added to the "usage_quota_tokens_used" field in this mutation.
+func (m *APIKeyMutation) AddedUsageQuotaTokensUsed() (r int64, exists bool) {
+	v := m.addusage_quota_tokens_used
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUsageQuotaTokensUsed resets all changes to the "usage_quota_tokens_used" field.
+func (m *APIKeyMutation) ResetUsageQuotaTokensUsed() {
+	m.usage_quota_tokens_used = nil
+	m.addusage_quota_tokens_used = nil
+}
+
+// SetUsageQuotaWindowStart sets the "usage_quota_window_start" field.
+func (m *APIKeyMutation) SetUsageQuotaWindowStart(t time.Time) {
+	m.usage_quota_window_start = &t
+}
+
+// UsageQuotaWindowStart returns the value of the "usage_quota_window_start" field in the mutation.
+func (m *APIKeyMutation) UsageQuotaWindowStart() (r time.Time, exists bool) {
+	v := m.usage_quota_window_start
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageQuotaWindowStart returns the old "usage_quota_window_start" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsageQuotaWindowStart(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageQuotaWindowStart is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageQuotaWindowStart requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageQuotaWindowStart: %w", err)
+	}
+	return oldValue.UsageQuotaWindowStart, nil
+}
+
+// ClearUsageQuotaWindowStart clears the value of the "usage_quota_window_start" field.
+func (m *APIKeyMutation) ClearUsageQuotaWindowStart() {
+	m.usage_quota_window_start = nil
+	m.clearedFields[apikey.FieldUsageQuotaWindowStart] = struct{}{}
+}
+
+// UsageQuotaWindowStartCleared returns if the "usage_quota_window_start" field was cleared in this mutation.
+func (m *APIKeyMutation) UsageQuotaWindowStartCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldUsageQuotaWindowStart]
+	return ok
+}
+
+// ResetUsageQuotaWindowStart resets all changes to the "usage_quota_window_start" field.
+func (m *APIKeyMutation) ResetUsageQuotaWindowStart() {
+	m.usage_quota_window_start = nil
+	delete(m.clearedFields, apikey.FieldUsageQuotaWindowStart)
+}
+
+// SetMaskResponseModel sets the "mask_response_model" field.
+func (m *APIKeyMutation) SetMaskResponseModel(b bool) {
+	m.mask_response_model = &b
+}
+
+// MaskResponseModel returns the value of the "mask_response_model" field in the mutation.
+func (m *APIKeyMutation) MaskResponseModel() (r bool, exists bool) {
+	v := m.mask_response_model
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaskResponseModel returns the old "mask_response_model" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldMaskResponseModel(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaskResponseModel is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaskResponseModel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaskResponseModel: %w", err)
+	}
+	return oldValue.MaskResponseModel, nil
+}
+
+// ResetMaskResponseModel resets all changes to the "mask_response_model" field.
+func (m *APIKeyMutation) ResetMaskResponseModel() {
+	m.mask_response_model = nil
+}
+
 // ClearUser clears the "user" edge to the User entity.
 func (m *APIKeyMutation) ClearUser() {
 	m.cleareduser = true
@@ -1522,7 +2450,7 @@ func (m *APIKeyMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *APIKeyMutation) Fields() []string {
-	fields := make([]string, 0, 23)
+	fields := make([]string, 0, 41)
 	if m.created_at != nil {
 		fields = append(fields, apikey.FieldCreatedAt)
 	}
@@ -1592,6 +2520,60 @@ func (m *APIKeyMutation) Fields() []string {
 	if m.window_7d_start != nil {
 		fields = append(fields, apikey.FieldWindow7dStart)
 	}
+	if m.rpm_limit != nil {
+		fields = append(fields, apikey.FieldRpmLimit)
+	}
+	if m.tpm_limit != nil {
+		fields = append(fields, apikey.FieldTpmLimit)
+	}
+	if m.audit_capture_body != nil {
+		fields = append(fields, apikey.FieldAuditCaptureBody)
+	}
+	if m.allowed_models != nil {
+		fields = append(fields, apikey.FieldAllowedModels)
+	}
+	if m.denied_models != nil {
+		fields = append(fields, apikey.FieldDeniedModels)
+	}
+	if m.usage_headers != nil {
+		fields = append(fields, apikey.FieldUsageHeaders)
+	}
+	if m.is_sandbox != nil {
+		fields = append(fields, apikey.FieldIsSandbox)
+	}
+	if m.priority_tier != nil {
+		fields = append(fields, apikey.FieldPriorityTier)
+	}
+	if m.key_hash != nil {
+		fields = append(fields, apikey.FieldKeyHash)
+	}
+	if m.max_request_bytes != nil {
+		fields = append(fields, apikey.FieldMaxRequestBytes)
+	}
+	if m.max_response_bytes != nil {
+		fields = append(fields, apikey.FieldMaxResponseBytes)
+	}
+	if m.usage_quota_requests != nil {
+		fields = append(fields, apikey.FieldUsageQuotaRequests)
+	}
+	if m.usage_quota_tokens != nil {
+		fields = append(fields, apikey.FieldUsageQuotaTokens)
+	}
+	if m.usage_quota_period != nil {
+		fields = append(fields, apikey.FieldUsageQuotaPeriod)
+	}
+	if m.usage_quota_requests_used != nil {
+		fields = append(fields, apikey.FieldUsageQuotaRequestsUsed)
+	}
+	if m.usage_quota_tokens_used != nil {
+		fields = append(fields, apikey.FieldUsageQuotaTokensUsed)
+	}
+	if m.usage_quota_window_start != nil {
+		fields = append(fields, apikey.FieldUsageQuotaWindowStart)
+	}
+	if m.mask_response_model != nil {
+		fields = append(fields, apikey.FieldMaskResponseModel)
+	}
 	return fields
 }
 
@@ -1646,6 +2628,42 @@ func (m *APIKeyMutation) Field(name string) (ent.Value, bool) {
 		return m.Window1dStart()
 	case apikey.FieldWindow7dStart:
 		return m.Window7dStart()
+	case apikey.FieldRpmLimit:
+		return m.RpmLimit()
+	case apikey.FieldTpmLimit:
+		return m.TpmLimit()
+	case apikey.FieldAuditCaptureBody:
+		return m.AuditCaptureBody()
+	case apikey.FieldAllowedModels:
+		return m.AllowedModels()
+	case apikey.FieldDeniedModels:
+		return m.DeniedModels()
+	case apikey.FieldUsageHeaders:
+		return m.UsageHeaders()
+	case apikey.FieldIsSandbox:
+		return m.IsSandbox()
+	case apikey.FieldPriorityTier:
+		return m.PriorityTier()
+	case apikey.FieldKeyHash:
+		return m.KeyHash()
+	case apikey.FieldMaxRequestBytes:
+		return m.MaxRequestBytes()
+	case apikey.FieldMaxResponseBytes:
+		return m.MaxResponseBytes()
+	case apikey.FieldUsageQuotaRequests:
+		return m.UsageQuotaRequests()
+	case apikey.FieldUsageQuotaTokens:
+		return m.UsageQuotaTokens()
+	case apikey.FieldUsageQuotaPeriod:
+		return m.UsageQuotaPeriod()
+	case apikey.FieldUsageQuotaRequestsUsed:
+		return m.UsageQuotaRequestsUsed()
+	case apikey.FieldUsageQuotaTokensUsed:
+		return m.UsageQuotaTokensUsed()
+	case apikey.FieldUsageQuotaWindowStart:
+		return m.UsageQuotaWindowStart()
+	case apikey.FieldMaskResponseModel:
+		return m.MaskResponseModel()
 	}
 	return nil, false
 }
@@ -1701,6 +2719,42 @@ func (m *APIKeyMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldWindow1dStart(ctx)
 	case apikey.FieldWindow7dStart:
 		return m.OldWindow7dStart(ctx)
+	case apikey.FieldRpmLimit:
+		return m.OldRpmLimit(ctx)
+	case apikey.FieldTpmLimit:
+		return m.OldTpmLimit(ctx)
+	case apikey.FieldAuditCaptureBody:
+		return m.OldAuditCaptureBody(ctx)
+	case apikey.FieldAllowedModels:
+		return m.OldAllowedModels(ctx)
+	case apikey.FieldDeniedModels:
+		return m.OldDeniedModels(ctx)
+	case apikey.FieldUsageHeaders:
+		return m.OldUsageHeaders(ctx)
+	case apikey.FieldIsSandbox:
+		return m.OldIsSandbox(ctx)
+	case apikey.FieldPriorityTier:
+		return m.OldPriorityTier(ctx)
+	case apikey.FieldKeyHash:
+		return m.OldKeyHash(ctx)
+	case apikey.FieldMaxRequestBytes:
+		return m.OldMaxRequestBytes(ctx)
+	case apikey.FieldMaxResponseBytes:
+		return m.OldMaxResponseBytes(ctx)
+	case apikey.FieldUsageQuotaRequests:
+		return m.OldUsageQuotaRequests(ctx)
+	case apikey.FieldUsageQuotaTokens:
+		return m.OldUsageQuotaTokens(ctx)
+	case apikey.FieldUsageQuotaPeriod:
+		return m.OldUsageQuotaPeriod(ctx)
+	case apikey.FieldUsageQuotaRequestsUsed:
+		return m.OldUsageQuotaRequestsUsed(ctx)
+	case apikey.FieldUsageQuotaTokensUsed:
+		return m.OldUsageQuotaTokensUsed(ctx)
+	case apikey.FieldUsageQuotaWindowStart:
+		return m.OldUsageQuotaWindowStart(ctx)
+	case apikey.FieldMaskResponseModel:
+		return m.OldMaskResponseModel(ctx)
 	}
 	return nil, fmt.Errorf("unknown APIKey field %s", name)
 }
@@ -1871,6 +2925,132 @@ func (m *APIKeyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetWindow7dStart(v)
 		return nil
+	case apikey.FieldRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRpmLimit(v)
+		return nil
+	case apikey.FieldTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTpmLimit(v)
+		return nil
+	case apikey.FieldAuditCaptureBody:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuditCaptureBody(v)
+		return nil
+	case apikey.FieldAllowedModels:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedModels(v)
+		return nil
+	case apikey.FieldDeniedModels:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeniedModels(v)
+		return nil
+	case apikey.FieldUsageHeaders:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageHeaders(v)
+		return nil
+	case apikey.FieldIsSandbox:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIsSandbox(v)
+		return nil
+	case apikey.FieldPriorityTier:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPriorityTier(v)
+		return nil
+	case apikey.FieldKeyHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKeyHash(v)
+		return nil
+	case apikey.FieldMaxRequestBytes:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxRequestBytes(v)
+		return nil
+	case apikey.FieldMaxResponseBytes:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxResponseBytes(v)
+		return nil
+	case apikey.FieldUsageQuotaRequests:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaRequests(v)
+		return nil
+	case apikey.FieldUsageQuotaTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaTokens(v)
+		return nil
+	case apikey.FieldUsageQuotaPeriod:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaPeriod(v)
+		return nil
+	case apikey.FieldUsageQuotaRequestsUsed:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaRequestsUsed(v)
+		return nil
+	case apikey.FieldUsageQuotaTokensUsed:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaTokensUsed(v)
+		return nil
+	case apikey.FieldUsageQuotaWindowStart:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageQuotaWindowStart(v)
+		return nil
+	case apikey.FieldMaskResponseModel:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaskResponseModel(v)
+		return nil
 	}
 	return fmt.Errorf("unknown APIKey field %s", name)
 }
@@ -1903,6 +3083,33 @@ func (m *APIKeyMutation) AddedFields() []string {
 	if m.addusage_7d != nil {
 		fields = append(fields, apikey.FieldUsage7d)
 	}
+	if m.addrpm_limit != nil {
+		fields = append(fields, apikey.FieldRpmLimit)
+	}
+	if m.addtpm_limit != nil {
+		fields = append(fields, apikey.FieldTpmLimit)
+	}
+	if m.addpriority_tier != nil {
+		fields = append(fields, apikey.FieldPriorityTier)
+	}
+	if m.addmax_request_bytes != nil {
+		fields = append(fields, apikey.FieldMaxRequestBytes)
+	}
+	if m.addmax_response_bytes != nil {
+		fields = append(fields, apikey.FieldMaxResponseBytes)
+	}
+	if m.addusage_quota_requests != nil {
+		fields = append(fields, apikey.FieldUsageQuotaRequests)
+	}
+	if m.addusage_quota_tokens != nil {
+		fields = append(fields, apikey.FieldUsageQuotaTokens)
+	}
+	if m.addusage_quota_requests_used != nil {
+		fields = append(fields, apikey.FieldUsageQuotaRequestsUsed)
+	}
+	if m.addusage_quota_tokens_used != nil {
+		fields = append(fields, apikey.FieldUsageQuotaTokensUsed)
+	}
 	return fields
 }
 
@@ -1927,6 +3134,24 @@ func (m *APIKeyMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedUsage1d()
 	case apikey.FieldUsage7d:
 		return m.AddedUsage7d()
+	case apikey.FieldRpmLimit:
+		return m.AddedRpmLimit()
+	case apikey.FieldTpmLimit:
+		return m.AddedTpmLimit()
+	case apikey.FieldPriorityTier:
+		return m.AddedPriorityTier()
+	case apikey.FieldMaxRequestBytes:
+		return m.AddedMaxRequestBytes()
+	case apikey.FieldMaxResponseBytes:
+		return m.AddedMaxResponseBytes()
+	case apikey.FieldUsageQuotaRequests:
+		return m.AddedUsageQuotaRequests()
+	case apikey.FieldUsageQuotaTokens:
+		return m.AddedUsageQuotaTokens()
+	case apikey.FieldUsageQuotaRequestsUsed:
+		return m.AddedUsageQuotaRequestsUsed()
+	case apikey.FieldUsageQuotaTokensUsed:
+		return m.AddedUsageQuotaTokensUsed()
 	}
 	return nil, false
 }
@@ -1992,6 +3217,69 @@ func (m *APIKeyMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddUsage7d(v)
 		return nil
+	case apikey.FieldRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRpmLimit(v)
+		return nil
+	case apikey.FieldTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTpmLimit(v)
+		return nil
+	case apikey.FieldPriorityTier:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPriorityTier(v)
+		return nil
+	case apikey.FieldMaxRequestBytes:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxRequestBytes(v)
+		return nil
+	case apikey.FieldMaxResponseBytes:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxResponseBytes(v)
+		return nil
+	case apikey.FieldUsageQuotaRequests:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsageQuotaRequests(v)
+		return nil
+	case apikey.FieldUsageQuotaTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsageQuotaTokens(v)
+		return nil
+	case apikey.FieldUsageQuotaRequestsUsed:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsageQuotaRequestsUsed(v)
+		return nil
+	case apikey.FieldUsageQuotaTokensUsed:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsageQuotaTokensUsed(v)
+		return nil
 	}
 	return fmt.Errorf("unknown APIKey numeric field %s", name)
 }
@@ -2027,6 +3315,15 @@ func (m *APIKeyMutation) ClearedFields() []string {
 	if m.FieldCleared(apikey.FieldWindow7dStart) {
 		fields = append(fields, apikey.FieldWindow7dStart)
 	}
+	if m.FieldCleared(apikey.FieldAllowedModels) {
+		fields = append(fields, apikey.FieldAllowedModels)
+	}
+	if m.FieldCleared(apikey.FieldDeniedModels) {
+		fields = append(fields, apikey.FieldDeniedModels)
+	}
+	if m.FieldCleared(apikey.FieldUsageQuotaWindowStart) {
+		fields = append(fields, apikey.FieldUsageQuotaWindowStart)
+	}
 	return fields
 }
 
@@ -2068,6 +3365,15 @@ func (m *APIKeyMutation) ClearField(name string) error {
 	case apikey.FieldWindow7dStart:
 		m.ClearWindow7dStart()
 		return nil
+	case apikey.FieldAllowedModels:
+		m.ClearAllowedModels()
+		return nil
+	case apikey.FieldDeniedModels:
+		m.ClearDeniedModels()
+		return nil
+	case apikey.FieldUsageQuotaWindowStart:
+		m.ClearUsageQuotaWindowStart()
+		return nil
 	}
 	return fmt.Errorf("unknown APIKey nullable field %s", name)
 }
@@ -2145,6 +3451,60 @@ func (m *APIKeyMutation) ResetField(name string) error {
 	case apikey.FieldWindow7dStart:
 		m.ResetWindow7dStart()
 		return nil
+	case apikey.FieldRpmLimit:
+		m.ResetRpmLimit()
+		return nil
+	case apikey.FieldTpmLimit:
+		m.ResetTpmLimit()
+		return nil
+	case apikey.FieldAuditCaptureBody:
+		m.ResetAuditCaptureBody()
+		return nil
+	case apikey.FieldAllowedModels:
+		m.ResetAllowedModels()
+		return nil
+	case apikey.FieldDeniedModels:
+		m.ResetDeniedModels()
+		return nil
+	case apikey.FieldUsageHeaders:
+		m.ResetUsageHeaders()
+		return nil
+	case apikey.FieldIsSandbox:
+		m.ResetIsSandbox()
+		return nil
+	case apikey.FieldPriorityTier:
+		m.ResetPriorityTier()
+		return nil
+	case apikey.FieldKeyHash:
+		m.ResetKeyHash()
+		return nil
+	case apikey.FieldMaxRequestBytes:
+		m.ResetMaxRequestBytes()
+		return nil
+	case apikey.FieldMaxResponseBytes:
+		m.ResetMaxResponseBytes()
+		return nil
+	case apikey.FieldUsageQuotaRequests:
+		m.ResetUsageQuotaRequests()
+		return nil
+	case apikey.FieldUsageQuotaTokens:
+		m.ResetUsageQuotaTokens()
+		return nil
+	case apikey.FieldUsageQuotaPeriod:
+		m.ResetUsageQuotaPeriod()
+		return nil
+	case apikey.FieldUsageQuotaRequestsUsed:
+		m.ResetUsageQuotaRequestsUsed()
+		return nil
+	case apikey.FieldUsageQuotaTokensUsed:
+		m.ResetUsageQuotaTokensUsed()
+		return nil
+	case apikey.FieldUsageQuotaWindowStart:
+		m.ResetUsageQuotaWindowStart()
+		return nil
+	case apikey.FieldMaskResponseModel:
+		m.ResetMaskResponseModel()
+		return nil
 	}
 	return fmt.Errorf("unknown APIKey field %s", name)
 }
@@ -2288,6 +3648,8 @@ type AccountMutation struct {
 	addconcurrency            *int
 	load_factor               *int
 	addload_factor            *int
+	weight                    *int
+	addweight                 *int
 	priority                  *int
 	addpriority               *int
 	rate_multiplier           *float64
@@ -2943,6 +4305,76 @@ func (m *AccountMutation) ResetLoadFactor() {
 	delete(m.clearedFields, account.FieldLoadFactor)
 }
 
+// SetWeight sets the "weight" field.
+func (m *AccountMutation) SetWeight(i int) {
+	m.weight = &i
+	m.addweight = nil
+}
+
+// Weight returns the value of the "weight" field in the mutation.
+func (m *AccountMutation) Weight() (r int, exists bool) {
+	v := m.weight
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldWeight returns the old "weight" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldWeight(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldWeight is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldWeight requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldWeight: %w", err)
+	}
+	return oldValue.Weight, nil
+}
+
+// AddWeight adds i to the "weight" field.
+func (m *AccountMutation) AddWeight(i int) {
+	if m.addweight != nil {
+		*m.addweight += i
+	} else {
+		m.addweight = &i
+	}
+}
+
+// AddedWeight returns the value that was added to the "weight" field in this mutation.
+func (m *AccountMutation) AddedWeight() (r int, exists bool) {
+	v := m.addweight
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearWeight clears the value of the "weight" field.
+func (m *AccountMutation) ClearWeight() {
+	m.weight = nil
+	m.addweight = nil
+	m.clearedFields[account.FieldWeight] = struct{}{}
+}
+
+// WeightCleared returns if the "weight" field was cleared in this mutation.
+func (m *AccountMutation) WeightCleared() bool {
+	_, ok := m.clearedFields[account.FieldWeight]
+	return ok
+}
+
+// ResetWeight resets all changes to the "weight" field.
+func (m *AccountMutation) ResetWeight() {
+	m.weight = nil
+	m.addweight = nil
+	delete(m.clearedFields, account.FieldWeight)
+}
+
 // SetPriority sets the "priority" field.
 func (m *AccountMutation) SetPriority(i int) {
 	m.priority = &i
@@ -3871,7 +5303,7 @@ func (m *AccountMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *AccountMutation) Fields() []string {
-	fields := make([]string, 0, 28)
+	fields := make([]string, 0, 29)
 	if m.created_at != nil {
 		fields = append(fields, account.FieldCreatedAt)
 	}
@@ -3908,6 +5340,9 @@ func (m *AccountMutation) Fields() []string {
 	if m.load_factor != nil {
 		fields = append(fields, account.FieldLoadFactor)
 	}
+	if m.weight != nil {
+		fields = append(fields, account.FieldWeight)
+	}
 	if m.priority != nil {
 		fields = append(fields, account.FieldPriority)
 	}
@@ -3988,6 +5423,8 @@ func (m *AccountMutation) Field(name string) (ent.Value, bool) {
 		return m.Concurrency()
 	case account.FieldLoadFactor:
 		return m.LoadFactor()
+	case account.FieldWeight:
+		return m.Weight()
 	case account.FieldPriority:
 		return m.Priority()
 	case account.FieldRateMultiplier:
@@ -4053,6 +5490,8 @@ func (m *AccountMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldConcurrency(ctx)
 	case account.FieldLoadFactor:
 		return m.OldLoadFactor(ctx)
+	case account.FieldWeight:
+		return m.OldWeight(ctx)
 	case account.FieldPriority:
 		return m.OldPriority(ctx)
 	case account.FieldRateMultiplier:
@@ -4178,6 +5617,13 @@ func (m *AccountMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetLoadFactor(v)
 		return nil
+	case account.FieldWeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetWeight(v)
+		return nil
 	case account.FieldPriority:
 		v, ok := value.(int)
 		if !ok {
@@ -4304,6 +5750,9 @@ func (m *AccountMutation) AddedFields() []string {
 	if m.addload_factor != nil {
 		fields = append(fields, account.FieldLoadFactor)
 	}
+	if m.addweight != nil {
+		fields = append(fields, account.FieldWeight)
+	}
 	if m.addpriority != nil {
 		fields = append(fields, account.FieldPriority)
 	}
@@ -4322,6 +5771,8 @@ func (m *AccountMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedConcurrency()
 	case account.FieldLoadFactor:
 		return m.AddedLoadFactor()
+	case account.FieldWeight:
+		return m.AddedWeight()
 	case account.FieldPriority:
 		return m.AddedPriority()
 	case account.FieldRateMultiplier:
@@ -4349,6 +5800,13 @@ func (m *AccountMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddLoadFactor(v)
 		return nil
+	case account.FieldWeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddWeight(v)
+		return nil
 	case account.FieldPriority:
 		v, ok := value.(int)
 		if !ok {
@@ -4383,6 +5841,9 @@ func (m *AccountMutation) ClearedFields() []string {
 	if m.FieldCleared(account.FieldLoadFactor) {
 		fields = append(fields, account.FieldLoadFactor)
 	}
+	if m.FieldCleared(account.FieldWeight) {
+		fields = append(fields, account.FieldWeight)
+	}
 	if m.FieldCleared(account.FieldErrorMessage) {
 		fields = append(fields, account.FieldErrorMessage)
 	}
@@ -4442,6 +5903,9 @@ func (m *AccountMutation) ClearField(name string) error {
 	case account.FieldLoadFactor:
 		m.ClearLoadFactor()
 		return nil
+	case account.FieldWeight:
+		m.ClearWeight()
+		return nil
 	case account.FieldErrorMessage:
 		m.ClearErrorMessage()
 		return nil
@@ -4519,6 +5983,9 @@ func (m *AccountMutation) ResetField(name string) error {
 	case account.FieldLoadFactor:
 		m.ResetLoadFactor()
 		return nil
+	case account.FieldWeight:
+		m.ResetWeight()
+		return nil
 	case account.FieldPriority:
 		m.ResetPriority()
 		return nil
@@ -14794,6 +16261,7 @@ type GroupMutation struct {
 	messages_dispatch_model_config          *domain.OpenAIMessagesDispatchModelConfig
 	rpm_limit                               *int
 	addrpm_limit                            *int
+	scheduling_strategy                     *string
 	clearedFields                           map[string]struct{}
 	api_keys                                map[int64]struct{}
 	removedapi_keys                         map[int64]struct{}
@@ -16602,6 +18070,42 @@ func (m *GroupMutation) ResetRpmLimit() {
 	m.addrpm_limit = nil
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (m *GroupMutation) SetSchedulingStrategy(s string) {
+	m.scheduling_strategy = &s
+}
+
+// SchedulingStrategy returns the value of the "scheduling_strategy" field in the mutation.
+func (m *GroupMutation) SchedulingStrategy() (r string, exists bool) {
+	v := m.scheduling_strategy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSchedulingStrategy returns the old "scheduling_strategy" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldSchedulingStrategy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSchedulingStrategy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSchedulingStrategy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSchedulingStrategy: %w", err)
+	}
+	return oldValue.SchedulingStrategy, nil
+}
+
+// ResetSchedulingStrategy resets all changes to the "scheduling_strategy" field.
+func (m *GroupMutation) ResetSchedulingStrategy() {
+	m.scheduling_strategy = nil
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
 func (m *GroupMutation) AddAPIKeyIDs(ids ...int64) {
 	if m.api_keys == nil {
@@ -16960,7 +18464,7 @@ func (m *GroupMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *GroupMutation) Fields() []string {
-	fields := make([]string, 0, 35)
+	fields := make([]string, 0, 36)
 	if m.created_at != nil {
 		fields = append(fields, group.FieldCreatedAt)
 	}
@@ -17066,6 +18570,9 @@ func (m *GroupMutation) Fields() []string {
 	if m.rpm_limit != nil {
 		fields = append(fields, group.FieldRpmLimit)
 	}
+	if m.scheduling_strategy != nil {
+		fields = append(fields, group.FieldSchedulingStrategy)
+	}
 	return fields
 }
 
@@ -17144,6 +18651,8 @@ func (m *GroupMutation) Field(name string) (ent.Value, bool) {
 		return m.MessagesDispatchModelConfig()
 	case group.FieldRpmLimit:
 		return m.RpmLimit()
+	case group.FieldSchedulingStrategy:
+		return m.SchedulingStrategy()
 	}
 	return nil, false
 }
@@ -17223,6 +18732,8 @@ func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, e
 		return m.OldMessagesDispatchModelConfig(ctx)
 	case group.FieldRpmLimit:
 		return m.OldRpmLimit(ctx)
+	case group.FieldSchedulingStrategy:
+		return m.OldSchedulingStrategy(ctx)
 	}
 	return nil, fmt.Errorf("unknown Group field %s", name)
 }
@@ -17477,6 +18988,13 @@ func (m *GroupMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetRpmLimit(v)
 		return nil
+	case group.FieldSchedulingStrategy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchedulingStrategy(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Group field %s", name)
 }
@@ -17859,6 +19377,9 @@ func (m *GroupMutation) ResetField(name string) error {
 	case group.FieldRpmLimit:
 		m.ResetRpmLimit()
 		return nil
+	case group.FieldSchedulingStrategy:
+		m.ResetSchedulingStrategy()
+		return nil
 	}
 	return fmt.Errorf("unknown Group field %s", name)
 }
@@ -33410,8 +34931,8 @@ func (m *UsageCleanupTaskMutation) ResetStatus() {
 }
 
 // SetFilters sets the "filters" field.
-func (m *UsageCleanupTaskMutation) SetFilters(jm json.RawMessage) {
-	m.filters = &jm
+func (m *UsageCleanupTaskMutation) SetFilters(j json.RawMessage) {
+	m.filters = &j
 	m.appendfilters = nil
 }
 
@@ -33441,9 +34962,9 @@ func (m *UsageCleanupTaskMutation) OldFilters(ctx context.Context) (v json.RawMe
 	return oldValue.Filters, nil
 }
 
-// AppendFilters adds jm to the "filters" field.
-func (m *UsageCleanupTaskMutation) AppendFilters(jm json.RawMessage) {
-	m.appendfilters = append(m.appendfilters, jm...)
+// AppendFilters adds j to the "filters" field.
+func (m *UsageCleanupTaskMutation) AppendFilters(j json.RawMessage) {
+	m.appendfilters = append(m.appendfilters, j...)
 }
 
 // AppendedFilters returns the list of values that were appended to the "filters" field in this mutation.
@@ -37704,6 +39225,9 @@ type UserMutation struct {
 	addtotal_recharged            *float64
 	rpm_limit                     *int
 	addrpm_limit                  *int
+	budget_limit                  *float64
+	addbudget_limit               *float64
+	budget_soft_limit             *bool
 	clearedFields                 map[string]struct{}
 	api_keys                      map[int64]struct{}
 	removedapi_keys               map[int64]struct{}
@@ -38851,6 +40375,98 @@ func (m *UserMutation) ResetRpmLimit() {
 	m.addrpm_limit = nil
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (m *UserMutation) SetBudgetLimit(f float64) {
+	m.budget_limit = &f
+	m.addbudget_limit = nil
+}
+
+// BudgetLimit returns the value of the "budget_limit" field in the mutation.
+func (m *UserMutation) BudgetLimit() (r float64, exists bool) {
+	v := m.budget_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBudgetLimit returns the old "budget_limit" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldBudgetLimit(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBudgetLimit is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBudgetLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBudgetLimit: %w", err)
+	}
+	return oldValue.BudgetLimit, nil
+}
+
+// AddBudgetLimit adds f to the "budget_limit" field.
+func (m *UserMutation) AddBudgetLimit(f float64) {
+	if m.addbudget_limit != nil {
+		*m.addbudget_limit += f
+	} else {
+		m.addbudget_limit = &f
+	}
+}
+
+// AddedBudgetLimit returns the value that was added to the "budget_limit" field in this mutation.
+func (m *UserMutation) AddedBudgetLimit() (r float64, exists bool) {
+	v := m.addbudget_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetBudgetLimit resets all changes to the "budget_limit" field.
+func (m *UserMutation) ResetBudgetLimit() {
+	m.budget_limit = nil
+	m.addbudget_limit = nil
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (m *UserMutation) SetBudgetSoftLimit(b bool) {
+	m.budget_soft_limit = &b
+}
+
+// BudgetSoftLimit returns the value of the "budget_soft_limit" field in the mutation.
+func (m *UserMutation) BudgetSoftLimit() (r bool, exists bool) {
+	v := m.budget_soft_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBudgetSoftLimit returns the old "budget_soft_limit" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldBudgetSoftLimit(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBudgetSoftLimit is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBudgetSoftLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBudgetSoftLimit: %w", err)
+	}
+	return oldValue.BudgetSoftLimit, nil
+}
+
+// ResetBudgetSoftLimit resets all changes to the "budget_soft_limit" field.
+func (m *UserMutation) ResetBudgetSoftLimit() {
+	m.budget_soft_limit = nil
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
 func (m *UserMutation) AddAPIKeyIDs(ids ...int64) {
 	if m.api_keys == nil {
@@ -39533,7 +41149,7 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 23)
+	fields := make([]string, 0, 25)
 	if m.created_at != nil {
 		fields = append(fields, user.FieldCreatedAt)
 	}
@@ -39603,6 +41219,12 @@ func (m *UserMutation) Fields() []string {
 	if m.rpm_limit != nil {
 		fields = append(fields, user.FieldRpmLimit)
 	}
+	if m.budget_limit != nil {
+		fields = append(fields, user.FieldBudgetLimit)
+	}
+	if m.budget_soft_limit != nil {
+		fields = append(fields, user.FieldBudgetSoftLimit)
+	}
 	return fields
 }
 
@@ -39657,6 +41279,10 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.TotalRecharged()
 	case user.FieldRpmLimit:
 		return m.RpmLimit()
+	case user.FieldBudgetLimit:
+		return m.BudgetLimit()
+	case user.FieldBudgetSoftLimit:
+		return m.BudgetSoftLimit()
 	}
 	return nil, false
 }
@@ -39712,6 +41338,10 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldTotalRecharged(ctx)
 	case user.FieldRpmLimit:
 		return m.OldRpmLimit(ctx)
+	case user.FieldBudgetLimit:
+		return m.OldBudgetLimit(ctx)
+	case user.FieldBudgetSoftLimit:
+		return m.OldBudgetSoftLimit(ctx)
 	}
 	return nil, fmt.Errorf("unknown User field %s", name)
 }
@@ -39882,6 +41512,20 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetRpmLimit(v)
 		return nil
+	case user.FieldBudgetLimit:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBudgetLimit(v)
+		return nil
+	case user.FieldBudgetSoftLimit:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBudgetSoftLimit(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -39905,6 +41549,9 @@ func (m *UserMutation) AddedFields() []string {
 	if m.addrpm_limit != nil {
 		fields = append(fields, user.FieldRpmLimit)
 	}
+	if m.addbudget_limit != nil {
+		fields = append(fields, user.FieldBudgetLimit)
+	}
 	return fields
 }
 
@@ -39923,6 +41570,8 @@ func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedTotalRecharged()
 	case user.FieldRpmLimit:
 		return m.AddedRpmLimit()
+	case user.FieldBudgetLimit:
+		return m.AddedBudgetLimit()
 	}
 	return nil, false
 }
@@ -39967,6 +41616,13 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddRpmLimit(v)
 		return nil
+	case user.FieldBudgetLimit:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddBudgetLimit(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User numeric field %s", name)
 }
@@ -40102,6 +41758,12 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldRpmLimit:
 		m.ResetRpmLimit()
 		return nil
+	case user.FieldBudgetLimit:
+		m.ResetBudgetLimit()
+		return nil
+	case user.FieldBudgetSoftLimit:
+		m.ResetBudgetSoftLimit()
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -140,6 +140,70 @@ func init() {
 	apikeyDescUsage7d := apikeyFields[16].Descriptor()
 	// apikey.DefaultUsage7d holds the default value on creation for the usage_7d field.
 	apikey.DefaultUsage7d = apikeyDescUsage7d.Default.(float64)
+	// apikeyDescRpmLimit is the schema descriptor for rpm_limit field.
+	apikeyDescRpmLimit := apikeyFields[20].Descriptor()
+	// apikey.DefaultRpmLimit holds the default value on creation for the rpm_limit field.
+	apikey.DefaultRpmLimit = apikeyDescRpmLimit.Default.(int)
+	// apikeyDescTpmLimit is the schema descriptor for tpm_limit field.
+	apikeyDescTpmLimit := apikeyFields[21].Descriptor()
+	// apikey.DefaultTpmLimit holds the default value on creation for the tpm_limit field.
+	apikey.DefaultTpmLimit = apikeyDescTpmLimit.Default.(int)
+	// apikeyDescAuditCaptureBody is the schema descriptor for audit_capture_body field.
+	apikeyDescAuditCaptureBody := apikeyFields[22].Descriptor()
+	// apikey.DefaultAuditCaptureBody holds the default value on creation for the audit_capture_body field.
+	apikey.DefaultAuditCaptureBody = apikeyDescAuditCaptureBody.Default.(bool)
+	// apikeyDescUsageHeaders is the schema descriptor for usage_headers field.
+	apikeyDescUsageHeaders := apikeyFields[25].Descriptor()
+	// apikey.DefaultUsageHeaders holds the default value on creation for the usage_headers field.
+	apikey.DefaultUsageHeaders = apikeyDescUsageHeaders.Default.(bool)
+	// apikeyDescIsSandbox is the schema descriptor for is_sandbox field.
+	apikeyDescIsSandbox := apikeyFields[26].Descriptor()
+	// apikey.DefaultIsSandbox holds the default value on creation for the is_sandbox field.
+	apikey.DefaultIsSandbox = apikeyDescIsSandbox.Default.(bool)
+	// apikeyDescPriorityTier is the schema descriptor for priority_tier field.
+	apikeyDescPriorityTier := apikeyFields[27].Descriptor()
+	// apikey.DefaultPriorityTier holds the default value on creation for the priority_tier field.
+	apikey.DefaultPriorityTier = apikeyDescPriorityTier.Default.(int)
+	// apikeyDescKeyHash is the schema descriptor for key_hash field.
+	apikeyDescKeyHash := apikeyFields[28].Descriptor()
+	// apikey.DefaultKeyHash holds the default value on creation for the key_hash field.
+	apikey.DefaultKeyHash = apikeyDescKeyHash.Default.(string)
+	// apikey.KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	apikey.KeyHashValidator = apikeyDescKeyHash.Validators[0].(func(string) error)
+	// apikeyDescMaxRequestBytes is the schema descriptor for max_request_bytes field.
+	apikeyDescMaxRequestBytes := apikeyFields[29].Descriptor()
+	// apikey.DefaultMaxRequestBytes holds the default value on creation for the max_request_bytes field.
+	apikey.DefaultMaxRequestBytes = apikeyDescMaxRequestBytes.Default.(int64)
+	// apikeyDescMaxResponseBytes is the schema descriptor for max_response_bytes field.
+	apikeyDescMaxResponseBytes := apikeyFields[30].Descriptor()
+	// apikey.DefaultMaxResponseBytes holds the default value on creation for the max_response_bytes field.
+	apikey.DefaultMaxResponseBytes = apikeyDescMaxResponseBytes.Default.(int64)
+	// apikeyDescUsageQuotaRequests is the schema descriptor for usage_quota_requests field.
+	apikeyDescUsageQuotaRequests := apikeyFields[31].Descriptor()
+	// apikey.DefaultUsageQuotaRequests holds the default value on creation for the usage_quota_requests field.
+	apikey.DefaultUsageQuotaRequests = apikeyDescUsageQuotaRequests.Default.(int64)
+	// apikeyDescUsageQuotaTokens is the schema descriptor for usage_quota_tokens field.
+	apikeyDescUsageQuotaTokens := apikeyFields[32].Descriptor()
+	// apikey.DefaultUsageQuotaTokens holds the default value on creation for the usage_quota_tokens field.
+	apikey.DefaultUsageQuotaTokens = apikeyDescUsageQuotaTokens.Default.(int64)
+	// apikeyDescUsageQuotaPeriod is the schema descriptor for usage_quota_period field.
+	apikeyDescUsageQuotaPeriod := apikeyFields[33].Descriptor()
+	// apikey.DefaultUsageQuotaPeriod holds the default value on creation for the usage_quota_period field.
+	apikey.DefaultUsageQuotaPeriod = apikeyDescUsageQuotaPeriod.Default.(string)
+	// apikey.UsageQuotaPeriodValidator is a validator for the "usage_quota_period" field. It is called by the builders before save.
+	apikey.UsageQuotaPeriodValidator = apikeyDescUsageQuotaPeriod.Validators[0].(func(string) error)
+	// apikeyDescUsageQuotaRequestsUsed is the schema descriptor for usage_quota_requests_used field.
+	apikeyDescUsageQuotaRequestsUsed := apikeyFields[34].Descriptor()
+	// apikey.DefaultUsageQuotaRequestsUsed holds the default value on creation for the usage_quota_requests_used field.
+	apikey.DefaultUsageQuotaRequestsUsed = apikeyDescUsageQuotaRequestsUsed.Default.(int64)
+	// apikeyDescUsageQuotaTokensUsed is the schema descriptor for usage_quota_tokens_used field.
+	apikeyDescUsageQuotaTokensUsed := apikeyFields[35].Descriptor()
+	// apikey.DefaultUsageQuotaTokensUsed holds the default value on creation for the usage_quota_tokens_used field.
+	apikey.DefaultUsageQuotaTokensUsed = apikeyDescUsageQuotaTokensUsed.Default.(int64)
+	// apikeyDescMaskResponseModel is the schema descriptor for mask_response_model field.
+	apikeyDescMaskResponseModel := apikeyFields[37].Descriptor()
+	// apikey.DefaultMaskResponseModel holds the default value on creation for the mask_response_model field.
+	apikey.DefaultMaskResponseModel = apikeyDescMaskResponseModel.Default.(bool)
 	accountMixin := schema.Account{}.Mixin()
 	accountMixinHooks1 := accountMixin[1].Hooks()
 	account.Hooks[0] = accountMixinHooks1[0]
@@ -226,29 +290,29 @@ func init() {
 	// account.DefaultConcurrency holds the default value on creation for the concurrency field.
 	account.DefaultConcurrency = accountDescConcurrency.Default.(int)
 	// accountDescPriority is the schema descriptor for priority field.
-	accountDescPriority := accountFields[9].Descriptor()
+	accountDescPriority := accountFields[10].Descriptor()
 	// account.DefaultPriority holds the default value on creation for the priority field.
 	account.DefaultPriority = accountDescPriority.Default.(int)
 	// accountDescRateMultiplier is the schema descriptor for rate_multiplier field.
-	accountDescRateMultiplier := accountFields[10].Descriptor()
+	accountDescRateMultiplier := accountFields[11].Descriptor()
 	// account.DefaultRateMultiplier holds the default value on creation for the rate_multiplier field.
 	account.DefaultRateMultiplier = accountDescRateMultiplier.Default.(float64)
 	// accountDescStatus is the schema descriptor for status field.
-	accountDescStatus := accountFields[11].Descriptor()
+	accountDescStatus := accountFields[12].Descriptor()
 	// account.DefaultStatus holds the default value on creation for the status field.
 	account.DefaultStatus = accountDescStatus.Default.(string)
 	// account.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	account.StatusValidator = accountDescStatus.Validators[0].(func(string) error)
 	// accountDescAutoPauseOnExpired is the schema descriptor for auto_pause_on_expired field.
-	accountDescAutoPauseOnExpired := accountFields[15].Descriptor()
+	accountDescAutoPauseOnExpired := accountFields[16].Descriptor()
 	// account.DefaultAutoPauseOnExpired holds the default value on creation for the auto_pause_on_expired field.
 	account.DefaultAutoPauseOnExpired = accountDescAutoPauseOnExpired.Default.(bool)
 	// accountDescSchedulable is the schema descriptor for schedulable field.
-	accountDescSchedulable := accountFields[16].Descriptor()
+	accountDescSchedulable := accountFields[17].Descriptor()
 	// account.DefaultSchedulable holds the default value on creation for the schedulable field.
 	account.DefaultSchedulable = accountDescSchedulable.Default.(bool)
 	// accountDescSessionWindowStatus is the schema descriptor for session_window_status field.
-	accountDescSessionWindowStatus := accountFields[24].Descriptor()
+	accountDescSessionWindowStatus := accountFields[25].Descriptor()
 	// account.SessionWindowStatusValidator is a validator for the "session_window_status" field. It is called by the builders before save.
 	account.SessionWindowStatusValidator = accountDescSessionWindowStatus.Validators[0].(func(string) error)
 	accountgroupFields := schema.AccountGroup{}.Fields()
@@ -865,6 +929,12 @@ func init() {
 	groupDescRpmLimit := groupFields[31].Descriptor()
 	// group.DefaultRpmLimit holds the default value on creation for the rpm_limit field.
 	group.DefaultRpmLimit = groupDescRpmLimit.Default.(int)
+	// groupDescSchedulingStrategy is the schema descriptor for scheduling_strategy field.
+	groupDescSchedulingStrategy := groupFields[32].Descriptor()
+	// group.DefaultSchedulingStrategy holds the default value on creation for the scheduling_strategy field.
+	group.DefaultSchedulingStrategy = groupDescSchedulingStrategy.Default.(string)
+	// group.SchedulingStrategyValidator is a validator for the "scheduling_strategy" field. It is called by the builders before save.
+	group.SchedulingStrategyValidator = groupDescSchedulingStrategy.Validators[0].(func(string) error)
 	idempotencyrecordMixin := schema.IdempotencyRecord{}.Mixin()
 	idempotencyrecordMixinFields0 := idempotencyrecordMixin[0].Fields()
 	_ = idempotencyrecordMixinFields0
@@ -1849,6 +1919,14 @@ func init() {
 	userDescRpmLimit := userFields[19].Descriptor()
 	// user.DefaultRpmLimit holds the default value on creation for the rpm_limit field.
 	user.DefaultRpmLimit = userDescRpmLimit.Default.(int)
+	// userDescBudgetLimit is the schema descriptor for budget_limit field.
+	userDescBudgetLimit := userFields[20].Descriptor()
+	// user.DefaultBudgetLimit holds the default value on creation for the budget_limit field.
+	user.DefaultBudgetLimit = userDescBudgetLimit.Default.(float64)
+	// userDescBudgetSoftLimit is the schema descriptor for budget_soft_limit field.
+	userDescBudgetSoftLimit := userFields[21].Descriptor()
+	// user.DefaultBudgetSoftLimit holds the default value on creation for the budget_soft_limit field.
+	user.DefaultBudgetSoftLimit = userDescBudgetSoftLimit.Default.(bool)
 	userallowedgroupFields := schema.UserAllowedGroup{}.Fields()
 	_ = userallowedgroupFields
 	// userallowedgroupDescCreatedAt is the schema descriptor for created_at field.
@@ -99,6 +99,10 @@ func (Account) Fields() []ent.Field {
 
 		field.Int("load_factor").Optional().Nillable(),
 
+		// weight: 同优先级账号间的调度权重，按比例分配新请求
+		// nil 表示默认权重 1；0 表示仅排空（不再接收新会话，已有粘性会话继续）
+		field.Int("weight").Optional().Nillable(),
+
 		// priority: 账户优先级，数值越小优先级越高
 		// 调度器会优先使用高优先级的账户
 		field.Int("priority").
@@ -115,6 +115,74 @@ func (APIKey) Fields() []ent.Field {
 			Optional().
 			Nillable().
 			Comment("Start time of the current 7d rate limit window"),
+		// Request/token throughput limits (token bucket, 0 = use global default)
+		field.Int("rpm_limit").
+			Default(0).
+			Comment("Requests per minute limit (0 = use global default)"),
+		field.Int("tpm_limit").
+			Default(0).
+			Comment("Tokens per minute limit (0 = use global default)"),
+		// Audit log body capture (opt-in, bodies are redacted by default)
+		field.Bool("audit_capture_body").
+			Default(false).
+			Comment("Capture request/response bodies in audit logs"),
+		// Model access control (empty allowlist = all models allowed; denylist wins)
+		field.JSON("allowed_models", []string{}).
+			Optional().
+			Comment("Models this key may request; empty means all models"),
+		field.JSON("denied_models", []string{}).
+			Optional().
+			Comment("Models this key may not request; takes precedence over allowed_models"),
+		// Billed usage response headers (opt-in, waits for billing before responding)
+		field.Bool("usage_headers").
+			Default(false).
+			Comment("Expose billed cost and token usage in response headers/trailers"),
+		// Sandbox key for integration testing (usage recorded but never charged)
+		field.Bool("is_sandbox").
+			Default(false).
+			Comment("Sandbox key: cost is computed but never charged or counted toward spend and budgets"),
+		// Gateway request queue priority (0 = default tier for backward compatibility)
+		field.Int("priority_tier").
+			Default(0).
+			Comment("Gateway queue priority tier (higher tiers are served first when the queue is full)"),
+		// Hashed key storage: key column then holds a lookup digest instead of the plaintext key
+		field.String("key_hash").
+			MaxLen(255).
+			Default("").
+			Sensitive().
+			Comment("Hash of the full key (bcrypt/argon2id); empty for legacy plaintext keys"),
+		// Request/response size limits (0 = use gateway.key_size_limits defaults)
+		field.Int64("max_request_bytes").
+			Default(0).
+			Comment("Max request body size in bytes (0 = use global default)"),
+		field.Int64("max_response_bytes").
+			Default(0).
+			Comment("Max response body size in bytes (0 = use global default)"),
+		// Scheduled usage quotas (request/token counts, reset on calendar boundaries)
+		field.Int64("usage_quota_requests").
+			Default(0).
+			Comment("Max requests per quota window (0 = unlimited)"),
+		field.Int64("usage_quota_tokens").
+			Default(0).
+			Comment("Max tokens per quota window (0 = unlimited)"),
+		field.String("usage_quota_period").
+			MaxLen(16).
+			Default("daily").
+			Comment("Quota reset schedule: hourly/daily/weekly/monthly (UTC calendar boundaries)"),
+		field.Int64("usage_quota_requests_used").
+			Default(0).
+			Comment("Requests consumed in the current quota window"),
+		field.Int64("usage_quota_tokens_used").
+			Default(0).
+			Comment("Tokens consumed in the current quota window"),
+		field.Time("usage_quota_window_start").
+			Optional().
+			Nillable().
+			Comment("Start time of the current quota window"),
+		// Response model masking (echo the client's requested model name instead of the upstream model)
+		field.Bool("mask_response_model").
+			Default(false).
+			Comment("Echo the requested model name in responses instead of the real upstream model"),
 	}
 }
 
@@ -163,6 +163,12 @@ func (Group) Fields() []ent.Field {
 		field.Int("rpm_limit").
 			Default(0).
 			Comment("分组 RPM 上限，0 表示不限制；设置后接管该分组用户的限流"),
+
+		// 账号调度策略 (added by migration 138)
+		field.String("scheduling_strategy").
+			MaxLen(20).
+			Default("load_balance").
+			Comment("账号调度策略：load_balance（负载均衡）/ cheapest（优先有效成本最低的账号）"),
 	}
 }
 
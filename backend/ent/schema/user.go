@@ -112,6 +112,14 @@ func (User) Fields() []ent.Field {
 		// 用户级每分钟请求数上限（0 = 不限制）。仅当所在分组未设置 rpm_limit 时作为兜底生效。
 		field.Int("rpm_limit").
 			Default(0),
+
+		// 月度预算（USD，0 = 不限制），按计费周期累计的实际费用超出后拒绝请求
+		field.Float("budget_limit").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Default(0),
+		// 软限制：超出预算仅记录告警，不拦截请求
+		field.Bool("budget_soft_limit").
+			Default(false),
 	}
 }
 
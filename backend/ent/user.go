@@ -63,6 +63,10 @@ type User struct {
 	TotalRecharged float64 `json:"total_recharged,omitempty"`
 	// RpmLimit holds the value of the "rpm_limit" field.
 	RpmLimit int `json:"rpm_limit,omitempty"`
+	// BudgetLimit holds the value of the "budget_limit" field.
+	BudgetLimit float64 `json:"budget_limit,omitempty"`
+	// BudgetSoftLimit holds the value of the "budget_soft_limit" field.
+	BudgetSoftLimit bool `json:"budget_soft_limit,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges        UserEdges `json:"edges"`
@@ -224,9 +228,9 @@ func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldTotpEnabled, user.FieldBalanceNotifyEnabled:
+		case user.FieldTotpEnabled, user.FieldBalanceNotifyEnabled, user.FieldBudgetSoftLimit:
 			values[i] = new(sql.NullBool)
-		case user.FieldBalance, user.FieldBalanceNotifyThreshold, user.FieldTotalRecharged:
+		case user.FieldBalance, user.FieldBalanceNotifyThreshold, user.FieldTotalRecharged, user.FieldBudgetLimit:
 			values[i] = new(sql.NullFloat64)
 		case user.FieldID, user.FieldConcurrency, user.FieldRpmLimit:
 			values[i] = new(sql.NullInt64)
@@ -399,6 +403,18 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.RpmLimit = int(value.Int64)
 			}
+		case user.FieldBudgetLimit:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field budget_limit", values[i])
+			} else if value.Valid {
+				_m.BudgetLimit = value.Float64
+			}
+		case user.FieldBudgetSoftLimit:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field budget_soft_limit", values[i])
+			} else if value.Valid {
+				_m.BudgetSoftLimit = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -580,6 +596,12 @@ func (_m *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("rpm_limit=")
 	builder.WriteString(fmt.Sprintf("%v", _m.RpmLimit))
+	builder.WriteString(", ")
+	builder.WriteString("budget_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.BudgetLimit))
+	builder.WriteString(", ")
+	builder.WriteString("budget_soft_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.BudgetSoftLimit))
 	builder.WriteByte(')')
 	return builder.String()
 }
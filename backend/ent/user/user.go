@@ -61,6 +61,10 @@ const (
 	FieldTotalRecharged = "total_recharged"
 	// FieldRpmLimit holds the string denoting the rpm_limit field in the database.
 	FieldRpmLimit = "rpm_limit"
+	// FieldBudgetLimit holds the string denoting the budget_limit field in the database.
+	FieldBudgetLimit = "budget_limit"
+	// FieldBudgetSoftLimit holds the string denoting the budget_soft_limit field in the database.
+	FieldBudgetSoftLimit = "budget_soft_limit"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -206,6 +210,8 @@ var Columns = []string{
 	FieldBalanceNotifyExtraEmails,
 	FieldTotalRecharged,
 	FieldRpmLimit,
+	FieldBudgetLimit,
+	FieldBudgetSoftLimit,
 }
 
 var (
@@ -276,6 +282,10 @@ var (
 	DefaultTotalRecharged float64
 	// DefaultRpmLimit holds the default value on creation for the "rpm_limit" field.
 	DefaultRpmLimit int
+	// DefaultBudgetLimit holds the default value on creation for the "budget_limit" field.
+	DefaultBudgetLimit float64
+	// DefaultBudgetSoftLimit holds the default value on creation for the "budget_soft_limit" field.
+	DefaultBudgetSoftLimit bool
 )
 
 // OrderOption defines the ordering options for the User queries.
@@ -401,6 +411,16 @@ func ByRpmLimit(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldRpmLimit, opts...).ToFunc()
 }
 
+// ByBudgetLimit orders the results by the budget_limit field.
+func ByBudgetLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBudgetLimit, opts...).ToFunc()
+}
+
+// ByBudgetSoftLimit orders the results by the budget_soft_limit field.
+func ByBudgetSoftLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBudgetSoftLimit, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
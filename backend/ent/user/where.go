@@ -170,6 +170,16 @@ func RpmLimit(v int) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldRpmLimit, v))
 }
 
+// BudgetLimit applies equality check predicate on the "budget_limit" field. It's identical to BudgetLimitEQ.
+func BudgetLimit(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldBudgetLimit, v))
+}
+
+// BudgetSoftLimit applies equality check predicate on the "budget_soft_limit" field. It's identical to BudgetSoftLimitEQ.
+func BudgetSoftLimit(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldBudgetSoftLimit, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldCreatedAt, v))
@@ -1340,6 +1350,56 @@ func RpmLimitLTE(v int) predicate.User {
 	return predicate.User(sql.FieldLTE(FieldRpmLimit, v))
 }
 
+// BudgetLimitEQ applies the EQ predicate on the "budget_limit" field.
+func BudgetLimitEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldBudgetLimit, v))
+}
+
+// BudgetLimitNEQ applies the NEQ predicate on the "budget_limit" field.
+func BudgetLimitNEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldBudgetLimit, v))
+}
+
+// BudgetLimitIn applies the In predicate on the "budget_limit" field.
+func BudgetLimitIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldIn(FieldBudgetLimit, vs...))
+}
+
+// BudgetLimitNotIn applies the NotIn predicate on the "budget_limit" field.
+func BudgetLimitNotIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldBudgetLimit, vs...))
+}
+
+// BudgetLimitGT applies the GT predicate on the "budget_limit" field.
+func BudgetLimitGT(v float64) predicate.User {
+	return predicate.User(sql.FieldGT(FieldBudgetLimit, v))
+}
+
+// BudgetLimitGTE applies the GTE predicate on the "budget_limit" field.
+func BudgetLimitGTE(v float64) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldBudgetLimit, v))
+}
+
+// BudgetLimitLT applies the LT predicate on the "budget_limit" field.
+func BudgetLimitLT(v float64) predicate.User {
+	return predicate.User(sql.FieldLT(FieldBudgetLimit, v))
+}
+
+// BudgetLimitLTE applies the LTE predicate on the "budget_limit" field.
+func BudgetLimitLTE(v float64) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldBudgetLimit, v))
+}
+
+// BudgetSoftLimitEQ applies the EQ predicate on the "budget_soft_limit" field.
+func BudgetSoftLimitEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldBudgetSoftLimit, v))
+}
+
+// BudgetSoftLimitNEQ applies the NEQ predicate on the "budget_soft_limit" field.
+func BudgetSoftLimitNEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldBudgetSoftLimit, v))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -339,6 +339,34 @@ func (_c *UserCreate) SetNillableRpmLimit(v *int) *UserCreate {
 	return _c
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (_c *UserCreate) SetBudgetLimit(v float64) *UserCreate {
+	_c.mutation.SetBudgetLimit(v)
+	return _c
+}
+
+// SetNillableBudgetLimit sets the "budget_limit" field if the given value is not nil.
+func (_c *UserCreate) SetNillableBudgetLimit(v *float64) *UserCreate {
+	if v != nil {
+		_c.SetBudgetLimit(*v)
+	}
+	return _c
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (_c *UserCreate) SetBudgetSoftLimit(v bool) *UserCreate {
+	_c.mutation.SetBudgetSoftLimit(v)
+	return _c
+}
+
+// SetNillableBudgetSoftLimit sets the "budget_soft_limit" field if the given value is not nil.
+func (_c *UserCreate) SetNillableBudgetSoftLimit(v *bool) *UserCreate {
+	if v != nil {
+		_c.SetBudgetSoftLimit(*v)
+	}
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *UserCreate) AddAPIKeyIDs(ids ...int64) *UserCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -622,6 +650,14 @@ func (_c *UserCreate) defaults() error {
 		v := user.DefaultRpmLimit
 		_c.mutation.SetRpmLimit(v)
 	}
+	if _, ok := _c.mutation.BudgetLimit(); !ok {
+		v := user.DefaultBudgetLimit
+		_c.mutation.SetBudgetLimit(v)
+	}
+	if _, ok := _c.mutation.BudgetSoftLimit(); !ok {
+		v := user.DefaultBudgetSoftLimit
+		_c.mutation.SetBudgetSoftLimit(v)
+	}
 	return nil
 }
 
@@ -708,6 +744,12 @@ func (_c *UserCreate) check() error {
 	if _, ok := _c.mutation.RpmLimit(); !ok {
 		return &ValidationError{Name: "rpm_limit", err: errors.New(`ent: missing required field "User.rpm_limit"`)}
 	}
+	if _, ok := _c.mutation.BudgetLimit(); !ok {
+		return &ValidationError{Name: "budget_limit", err: errors.New(`ent: missing required field "User.budget_limit"`)}
+	}
+	if _, ok := _c.mutation.BudgetSoftLimit(); !ok {
+		return &ValidationError{Name: "budget_soft_limit", err: errors.New(`ent: missing required field "User.budget_soft_limit"`)}
+	}
 	return nil
 }
 
@@ -827,6 +869,14 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldRpmLimit, field.TypeInt, value)
 		_node.RpmLimit = value
 	}
+	if value, ok := _c.mutation.BudgetLimit(); ok {
+		_spec.SetField(user.FieldBudgetLimit, field.TypeFloat64, value)
+		_node.BudgetLimit = value
+	}
+	if value, ok := _c.mutation.BudgetSoftLimit(); ok {
+		_spec.SetField(user.FieldBudgetSoftLimit, field.TypeBool, value)
+		_node.BudgetSoftLimit = value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1405,6 +1455,36 @@ func (u *UserUpsert) AddRpmLimit(v int) *UserUpsert {
 	return u
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (u *UserUpsert) SetBudgetLimit(v float64) *UserUpsert {
+	u.Set(user.FieldBudgetLimit, v)
+	return u
+}
+
+// UpdateBudgetLimit sets the "budget_limit" field to the value that was provided on create.
+func (u *UserUpsert) UpdateBudgetLimit() *UserUpsert {
+	u.SetExcluded(user.FieldBudgetLimit)
+	return u
+}
+
+// AddBudgetLimit adds v to the "budget_limit" field.
+func (u *UserUpsert) AddBudgetLimit(v float64) *UserUpsert {
+	u.Add(user.FieldBudgetLimit, v)
+	return u
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (u *UserUpsert) SetBudgetSoftLimit(v bool) *UserUpsert {
+	u.Set(user.FieldBudgetSoftLimit, v)
+	return u
+}
+
+// UpdateBudgetSoftLimit sets the "budget_soft_limit" field to the value that was provided on create.
+func (u *UserUpsert) UpdateBudgetSoftLimit() *UserUpsert {
+	u.SetExcluded(user.FieldBudgetSoftLimit)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1835,6 +1915,41 @@ func (u *UserUpsertOne) UpdateRpmLimit() *UserUpsertOne {
 	})
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (u *UserUpsertOne) SetBudgetLimit(v float64) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetBudgetLimit(v)
+	})
+}
+
+// AddBudgetLimit adds v to the "budget_limit" field.
+func (u *UserUpsertOne) AddBudgetLimit(v float64) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.AddBudgetLimit(v)
+	})
+}
+
+// UpdateBudgetLimit sets the "budget_limit" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateBudgetLimit() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateBudgetLimit()
+	})
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (u *UserUpsertOne) SetBudgetSoftLimit(v bool) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetBudgetSoftLimit(v)
+	})
+}
+
+// UpdateBudgetSoftLimit sets the "budget_soft_limit" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateBudgetSoftLimit() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateBudgetSoftLimit()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2431,6 +2546,41 @@ func (u *UserUpsertBulk) UpdateRpmLimit() *UserUpsertBulk {
 	})
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (u *UserUpsertBulk) SetBudgetLimit(v float64) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetBudgetLimit(v)
+	})
+}
+
+// AddBudgetLimit adds v to the "budget_limit" field.
+func (u *UserUpsertBulk) AddBudgetLimit(v float64) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.AddBudgetLimit(v)
+	})
+}
+
+// UpdateBudgetLimit sets the "budget_limit" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateBudgetLimit() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateBudgetLimit()
+	})
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (u *UserUpsertBulk) SetBudgetSoftLimit(v bool) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetBudgetSoftLimit(v)
+	})
+}
+
+// UpdateBudgetSoftLimit sets the "budget_soft_limit" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateBudgetSoftLimit() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateBudgetSoftLimit()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
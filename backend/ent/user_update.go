@@ -410,6 +410,41 @@ func (_u *UserUpdate) AddRpmLimit(v int) *UserUpdate {
 	return _u
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (_u *UserUpdate) SetBudgetLimit(v float64) *UserUpdate {
+	_u.mutation.ResetBudgetLimit()
+	_u.mutation.SetBudgetLimit(v)
+	return _u
+}
+
+// SetNillableBudgetLimit sets the "budget_limit" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableBudgetLimit(v *float64) *UserUpdate {
+	if v != nil {
+		_u.SetBudgetLimit(*v)
+	}
+	return _u
+}
+
+// AddBudgetLimit adds value to the "budget_limit" field.
+func (_u *UserUpdate) AddBudgetLimit(v float64) *UserUpdate {
+	_u.mutation.AddBudgetLimit(v)
+	return _u
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (_u *UserUpdate) SetBudgetSoftLimit(v bool) *UserUpdate {
+	_u.mutation.SetBudgetSoftLimit(v)
+	return _u
+}
+
+// SetNillableBudgetSoftLimit sets the "budget_soft_limit" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableBudgetSoftLimit(v *bool) *UserUpdate {
+	if v != nil {
+		_u.SetBudgetSoftLimit(*v)
+	}
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdate) AddAPIKeyIDs(ids ...int64) *UserUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -1035,6 +1070,15 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedRpmLimit(); ok {
 		_spec.AddField(user.FieldRpmLimit, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.BudgetLimit(); ok {
+		_spec.SetField(user.FieldBudgetLimit, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBudgetLimit(); ok {
+		_spec.AddField(user.FieldBudgetLimit, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BudgetSoftLimit(); ok {
+		_spec.SetField(user.FieldBudgetSoftLimit, field.TypeBool, value)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1978,6 +2022,41 @@ func (_u *UserUpdateOne) AddRpmLimit(v int) *UserUpdateOne {
 	return _u
 }
 
+// SetBudgetLimit sets the "budget_limit" field.
+func (_u *UserUpdateOne) SetBudgetLimit(v float64) *UserUpdateOne {
+	_u.mutation.ResetBudgetLimit()
+	_u.mutation.SetBudgetLimit(v)
+	return _u
+}
+
+// SetNillableBudgetLimit sets the "budget_limit" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableBudgetLimit(v *float64) *UserUpdateOne {
+	if v != nil {
+		_u.SetBudgetLimit(*v)
+	}
+	return _u
+}
+
+// AddBudgetLimit adds value to the "budget_limit" field.
+func (_u *UserUpdateOne) AddBudgetLimit(v float64) *UserUpdateOne {
+	_u.mutation.AddBudgetLimit(v)
+	return _u
+}
+
+// SetBudgetSoftLimit sets the "budget_soft_limit" field.
+func (_u *UserUpdateOne) SetBudgetSoftLimit(v bool) *UserUpdateOne {
+	_u.mutation.SetBudgetSoftLimit(v)
+	return _u
+}
+
+// SetNillableBudgetSoftLimit sets the "budget_soft_limit" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableBudgetSoftLimit(v *bool) *UserUpdateOne {
+	if v != nil {
+		_u.SetBudgetSoftLimit(*v)
+	}
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdateOne) AddAPIKeyIDs(ids ...int64) *UserUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -2633,6 +2712,15 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	if value, ok := _u.mutation.AddedRpmLimit(); ok {
 		_spec.AddField(user.FieldRpmLimit, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.BudgetLimit(); ok {
+		_spec.SetField(user.FieldBudgetLimit, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBudgetLimit(); ok {
+		_spec.AddField(user.FieldBudgetLimit, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BudgetSoftLimit(); ok {
+		_spec.SetField(user.FieldBudgetSoftLimit, field.TypeBool, value)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
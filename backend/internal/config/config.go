@@ -91,6 +91,11 @@ type Config struct {
 	Gemini                  GeminiConfig                  `mapstructure:"gemini"`
 	Update                  UpdateConfig                  `mapstructure:"update"`
 	Idempotency             IdempotencyConfig             `mapstructure:"idempotency"`
+	AccountHealthCheck      AccountHealthCheckConfig      `mapstructure:"account_health_check"`
+	AccountCircuitBreaker   AccountCircuitBreakerConfig   `mapstructure:"account_circuit_breaker"`
+	Metrics                 MetricsConfig                 `mapstructure:"metrics"`
+	Audit                   AuditConfig                   `mapstructure:"audit"`
+	SlowRequest             SlowRequestConfig             `mapstructure:"slow_request"`
 }
 
 type LogConfig struct {
@@ -154,6 +159,68 @@ type UpdateConfig struct {
 	ProxyURL string `mapstructure:"proxy_url"`
 }
 
+// MetricsConfig Prometheus 指标配置
+type MetricsConfig struct {
+	// Enabled 是否暴露 /metrics 端点
+	Enabled bool `mapstructure:"enabled"`
+	// AuthToken 非空时抓取需携带 Authorization: Bearer <token>
+	AuthToken string `mapstructure:"auth_token"`
+	// TokenBuckets 单次请求输入/输出 token 数直方图的桶上界（递增），为空时使用默认桶
+	TokenBuckets []float64 `mapstructure:"token_buckets"`
+}
+
+// AuditConfig 网关请求审计日志配置
+type AuditConfig struct {
+	// Enabled 是否记录请求级审计日志
+	Enabled bool `mapstructure:"enabled"`
+	// DBEnabled 写入 audit_logs 表
+	DBEnabled bool `mapstructure:"db_enabled"`
+	// FilePath 非空时同时以 JSON lines 追加写入该文件
+	FilePath string `mapstructure:"file_path"`
+	// MaxBodyBytes 开启正文记录的 Key 单条请求/响应正文保存上限（字节）
+	MaxBodyBytes int `mapstructure:"max_body_bytes"`
+	// QueueSize 异步写入队列容量，队列满时丢弃并计数
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// SlowRequestConfig 慢请求日志配置
+type SlowRequestConfig struct {
+	// ThresholdMs 请求耗时超过该值（毫秒）时以 WARN 级别记录，0 表示关闭
+	ThresholdMs int `mapstructure:"threshold_ms"`
+	// ModelThresholdsMs 按模型覆盖阈值（支持末尾 * 通配），值 <= 0 表示该模型不记录
+	ModelThresholdsMs map[string]int `mapstructure:"model_thresholds_ms"`
+	// BufferSize 管理端可查询的最近慢请求条数
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// AccountHealthCheckConfig 账号上游健康检查配置
+type AccountHealthCheckConfig struct {
+	// Enabled 是否启用后台健康检查（每次探测都会向上游发送一次最小请求，产生少量消耗）
+	Enabled bool `mapstructure:"enabled"`
+	// IntervalSeconds 探测周期（秒）
+	IntervalSeconds int `mapstructure:"interval_seconds"`
+	// FailureThreshold 连续失败多少次后标记为不健康并从调度中排除
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// TimeoutSeconds 单次探测超时（秒）
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// MaxWorkers 并发探测的账号数
+	MaxWorkers int `mapstructure:"max_workers"`
+}
+
+// AccountCircuitBreakerConfig 账号熔断配置：按真实请求的上游失败率熔断，与健康检查的主动探测相互独立
+type AccountCircuitBreakerConfig struct {
+	// Enabled 是否启用账号熔断
+	Enabled bool `mapstructure:"enabled"`
+	// WindowSeconds 失败率统计窗口（秒）
+	WindowSeconds int `mapstructure:"window_seconds"`
+	// MinRequests 窗口内至少多少次请求才计算失败率
+	MinRequests int `mapstructure:"min_requests"`
+	// FailureRateThreshold 失败率达到该值（0-1）时熔断
+	FailureRateThreshold float64 `mapstructure:"failure_rate_threshold"`
+	// CooldownSeconds 熔断后多久进入半开状态放行试探请求（秒）
+	CooldownSeconds int `mapstructure:"cooldown_seconds"`
+}
+
 type IdempotencyConfig struct {
 	// ObserveOnly 为 true 时处于观察期：未携带 Idempotency-Key 的请求继续放行。
 	ObserveOnly bool `mapstructure:"observe_only"`
@@ -171,6 +238,10 @@ type IdempotencyConfig struct {
 	CleanupIntervalSeconds int `mapstructure:"cleanup_interval_seconds"`
 	// CleanupBatchSize 每次清理的最大记录数。
 	CleanupBatchSize int `mapstructure:"cleanup_batch_size"`
+	// GatewayTTLSeconds 网关请求 Idempotency-Key 结果缓存 TTL（秒），0 表示关闭网关幂等。
+	GatewayTTLSeconds int `mapstructure:"gateway_ttl_seconds"`
+	// GatewayMaxResponseBytes 网关可缓存响应体的最大长度（字节），超出时不缓存。
+	GatewayMaxResponseBytes int `mapstructure:"gateway_max_response_bytes"`
 }
 
 type LinuxDoConnectConfig struct {
@@ -501,6 +572,67 @@ type PricingConfig struct {
 	UpdateIntervalHours int `mapstructure:"update_interval_hours"`
 	// 哈希校验间隔（分钟）
 	HashCheckIntervalMinutes int `mapstructure:"hash_check_interval_minutes"`
+	// 是否按 update_interval_hours 定时强制刷新价格数据（关闭时调度器以暂停状态启动，可在运行时恢复）
+	AutoRefreshEnabled bool `mapstructure:"auto_refresh_enabled"`
+	// 定时刷新的随机抖动上限（分钟），避免多实例同时拉取
+	AutoRefreshJitterMinutes int `mapstructure:"auto_refresh_jitter_minutes"`
+	// 管理员通过 URL 导入价格数据时允许的主机（支持 *.example.com，为空则不限制）
+	ImportAllowedHosts []string `mapstructure:"import_allowed_hosts"`
+	// 价格展示用汇率（币种代码 -> 1 USD 兑换的金额，如 eur: 0.92）
+	ExchangeRates map[string]float64 `mapstructure:"exchange_rates"`
+	// 严格模型匹配：关闭去日期/去厂商前缀等模糊匹配，未精确命中即视为无定价
+	StrictModelMatch bool `mapstructure:"strict_model_match"`
+	// 综合价格（blended cost）默认的输入/输出 token 比例
+	DefaultIORatio float64 `mapstructure:"default_io_ratio"`
+	// 价格数据变化时通知的 webhook 地址（为空则不发送）
+	WebhookURL string `mapstructure:"webhook_url"`
+	// webhook 签名共享密钥（HMAC-SHA256）
+	WebhookSecret string `mapstructure:"webhook_secret"`
+	// 全局加价：按上游价格的百分比加价（如 20 表示 +20%）
+	MarkupPercent float64 `mapstructure:"markup_percent"`
+	// 全局加价：每百万输入/输出 token 固定加价（USD）
+	MarkupFlatPerMTok float64 `mapstructure:"markup_flat_per_mtok"`
+	// 未知模型的默认价格（USD per token，均为 0 表示不启用；严格模型匹配时不生效）
+	DefaultInputCostPerToken  float64 `mapstructure:"default_input_cost_per_token"`
+	DefaultOutputCostPerToken float64 `mapstructure:"default_output_cost_per_token"`
+	// Batch API 请求的默认价格倍率（如 OpenAI batch 为 0.5），可按模型覆盖
+	BatchMultiplier float64 `mapstructure:"batch_multiplier"`
+	// 使用结构化输出（json_schema）的请求的价格倍率，1 表示不额外收费
+	StructuredOutputMultiplier float64 `mapstructure:"structured_output_multiplier"`
+	// 请求弃用模型时自动改写为价格数据中的替代模型（默认仅返回 X-Model-Deprecated 提示头）
+	DeprecationAutoroute bool `mapstructure:"deprecation_autoroute"`
+	// 单次计费请求的最低收费（USD，加价前），0 表示不限制，可按模型覆盖
+	MinCharge float64 `mapstructure:"min_charge"`
+	// 远程价格数据拉取失败时的最大尝试次数（含首次，4xx 响应不重试）
+	FetchRetryAttempts int `mapstructure:"fetch_retry_attempts"`
+	// 重试的基础退避时间（毫秒），第 n 次重试等待 base * 2^(n-1)
+	FetchRetryBaseDelayMs int `mapstructure:"fetch_retry_base_delay_ms"`
+	// 从 URL 下载价格数据时每个 Range 请求的块大小（字节），0 表示单次请求（中断后仍会尝试续传）
+	DownloadChunkBytes int64 `mapstructure:"download_chunk_bytes"`
+	// 下载中断后最多续传的次数
+	DownloadMaxResumes int `mapstructure:"download_max_resumes"`
+	// 远程哈希与下载内容不一致时拒绝更新（关闭时仅告警）
+	VerifyChecksum bool `mapstructure:"verify_checksum"`
+	// 返回给前端的费用展示精度（有效数字位数），仅影响价格查询/预估/消费接口的响应，内部计费保留完整精度；0 表示不舍入
+	DisplaySignificantDigits int `mapstructure:"display_significant_digits"`
+	// 多价格源：非空时取代 remote_url/hash_url，按 priority 合并（同一模型以高优先级为准）
+	Sources []PricingSourceConfig `mapstructure:"sources"`
+}
+
+// PricingSourceConfig 一个具名价格源（URL 或本地文件二选一）
+type PricingSourceConfig struct {
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+	File string `mapstructure:"file"`
+	// Priority 越大越优先；相同优先级时列表中靠前者优先
+	Priority int `mapstructure:"priority"`
+	// Enabled 未设置时视为启用
+	Enabled *bool `mapstructure:"enabled"`
+}
+
+// IsEnabled 价格源是否启用（未设置 enabled 时默认启用）
+func (p PricingSourceConfig) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
 }
 
 type ServerConfig struct {
@@ -513,6 +645,8 @@ type ServerConfig struct {
 	TrustedProxies     []string  `mapstructure:"trusted_proxies"`       // 可信代理列表（CIDR/IP）
 	MaxRequestBodySize int64     `mapstructure:"max_request_body_size"` // 全局最大请求体限制
 	H2C                H2CConfig `mapstructure:"h2c"`                   // HTTP/2 Cleartext 配置
+	// ShutdownGracePeriod 停机时等待进行中请求（含流式）完成的最长时间（秒），超时后强制断开
+	ShutdownGracePeriod int `mapstructure:"shutdown_grace_period"`
 }
 
 // H2CConfig HTTP/2 Cleartext 配置
@@ -536,6 +670,7 @@ type SecurityConfig struct {
 	CSP             CSPConfig            `mapstructure:"csp"`
 	ProxyFallback   ProxyFallbackConfig  `mapstructure:"proxy_fallback"`
 	ProxyProbe      ProxyProbeConfig     `mapstructure:"proxy_probe"`
+	APIKeyHashing   APIKeyHashingConfig  `mapstructure:"api_key_hashing"`
 }
 
 type URLAllowlistConfig struct {
@@ -574,8 +709,30 @@ type ProxyProbeConfig struct {
 	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"` // 已禁用：禁止跳过 TLS 证书验证
 }
 
+// APIKeyHashingConfig API Key 哈希存储配置。
+// 启用后新建的 Key 仅存储哈希，已有的明文 Key 在首次使用时自动重新哈希。
+type APIKeyHashingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Algorithm: bcrypt 或 argon2id（已存储的哈希按其自身格式校验，切换算法不影响旧 Key）
+	Algorithm  string `mapstructure:"algorithm"`
+	BcryptCost int    `mapstructure:"bcrypt_cost"`
+}
+
 type BillingConfig struct {
 	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// BudgetCycleDay: 用户月度预算的计费周期起始日（UTC，1-28），每月该日 00:00 重置已用预算
+	BudgetCycleDay int `mapstructure:"budget_cycle_day"`
+	// StreamReservation: 流式请求开始前按预估最大费用预留用户预算
+	StreamReservation StreamReservationConfig `mapstructure:"stream_reservation"`
+}
+
+// StreamReservationConfig 流式请求预算预留配置（仅对设置了月度预算的用户生效）
+type StreamReservationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TimeoutSeconds: 预留未结算时的最长保留时间，超时后视为遗弃的流并自动释放
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// DefaultMaxOutputTokens: 请求未指定 max_tokens 时用于预估的最大输出 token 数
+	DefaultMaxOutputTokens int `mapstructure:"default_max_output_tokens"`
 }
 
 type CircuitBreakerConfig struct {
@@ -603,6 +760,42 @@ type ImageConcurrencyConfig struct {
 	MaxWaitingRequests int `mapstructure:"max_waiting_requests"`
 }
 
+// PriorityQueueConfig 网关优先级排队配置：并发已满时请求按 API Key 优先级层级、再按到达顺序放行
+type PriorityQueueConfig struct {
+	// Enabled: 是否启用优先级排队，默认关闭以保持现有行为
+	Enabled bool `mapstructure:"enabled"`
+	// MaxConcurrentRequests: 当前进程允许同时处理的网关请求数，0表示不限制
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+	// MaxWaitSeconds: 请求在队列中的最长等待时间（秒），超时返回 429
+	MaxWaitSeconds int `mapstructure:"max_wait_seconds"`
+	// MaxQueueSize: 当前进程允许排队等待的请求数，0表示不限制；队列满时直接返回 429
+	MaxQueueSize int `mapstructure:"max_queue_size"`
+}
+
+// KeySizeLimitsConfig API Key 请求体/响应体大小上限的全局默认值
+type KeySizeLimitsConfig struct {
+	// DefaultMaxRequestBytes: 请求体上限（字节），0 表示仅受 gateway.max_body_size 限制
+	DefaultMaxRequestBytes int64 `mapstructure:"default_max_request_bytes"`
+	// DefaultMaxResponseBytes: 响应体上限（字节），超出时中止响应（Responses WebSocket 按每轮响应计量）；0 表示不限制
+	DefaultMaxResponseBytes int64 `mapstructure:"default_max_response_bytes"`
+}
+
+// ContextWindowCheckConfig 转发前按模型上下文窗口（价格数据中的 max_context_tokens）拦截明显超长的请求
+type ContextWindowCheckConfig struct {
+	// Enabled: 是否启用（默认关闭：输入 token 为 tokenizer 估算值，存在误差）
+	Enabled bool `mapstructure:"enabled"`
+	// Tolerance: 容差比例，估算值超过 max_context_tokens*(1+tolerance) 才拒绝
+	Tolerance float64 `mapstructure:"tolerance"`
+}
+
+// LoadHintsConfig 供外部自动扩缩容参考的网关负载指标（进行中请求、排队深度、账号并发利用率）
+type LoadHintsConfig struct {
+	// Enabled: 是否统计并通过 /metrics 与管理接口暴露负载指标
+	Enabled bool `mapstructure:"enabled"`
+	// CapacityCacheSeconds: 账号并发容量与占用的缓存时间（秒），避免频繁抓取时反复查询数据库与 Redis
+	CapacityCacheSeconds int `mapstructure:"capacity_cache_seconds"`
+}
+
 const (
 	ImageConcurrencyOverflowModeReject = "reject"
 	ImageConcurrencyOverflowModeWait   = "wait"
@@ -623,6 +816,8 @@ type GatewayConfig struct {
 	GeminiDebugResponseHeaders bool `mapstructure:"gemini_debug_response_headers"`
 	// ConnectionPoolIsolation: 上游连接池隔离策略（proxy/account/account_proxy）
 	ConnectionPoolIsolation string `mapstructure:"connection_pool_isolation"`
+	// UpstreamRequestIDHeader: 转发到上游时携带本地追踪 ID（X-Request-ID）的请求头名，留空表示不透传
+	UpstreamRequestIDHeader string `mapstructure:"upstream_request_id_header"`
 	// ForceCodexCLI: 强制将 OpenAI `/v1/responses` 请求按 Codex CLI 处理。
 	// 用于网关未透传/改写 User-Agent 时的兼容兜底（默认关闭，避免影响其他客户端）。
 	ForceCodexCLI bool `mapstructure:"force_codex_cli"`
@@ -642,6 +837,16 @@ type GatewayConfig struct {
 	OpenAIWS GatewayOpenAIWSConfig `mapstructure:"openai_ws"`
 	// ImageConcurrency: 图片生成独立并发限制配置（默认关闭）
 	ImageConcurrency ImageConcurrencyConfig `mapstructure:"image_concurrency"`
+	// PriorityQueue: 网关优先级排队配置（默认关闭）
+	PriorityQueue PriorityQueueConfig `mapstructure:"priority_queue"`
+	// KeySizeLimits: API Key 请求体/响应体大小上限的全局默认值（Key 未单独设置时生效）
+	KeySizeLimits KeySizeLimitsConfig `mapstructure:"key_size_limits"`
+	// ContextWindowCheck: 转发前的上下文窗口检查（默认关闭）
+	ContextWindowCheck ContextWindowCheckConfig `mapstructure:"context_window_check"`
+	// LoadHints: 自动扩缩容参考指标（默认开启）
+	LoadHints LoadHintsConfig `mapstructure:"load_hints"`
+	// MaskResponseModel: 对所有 API Key 在响应中回显请求的模型名、隐藏实际上游模型（默认关闭，也可按 Key 开启）
+	MaskResponseModel bool `mapstructure:"mask_response_model"`
 
 	// HTTP 上游连接池配置（性能优化：支持高并发场景调优）
 	// MaxIdleConns: 所有主机的最大空闲连接总数
@@ -677,6 +882,8 @@ type GatewayConfig struct {
 	ImageStreamDataIntervalTimeout int `mapstructure:"image_stream_data_interval_timeout"`
 	// ImageStreamKeepaliveInterval: 图片流式 keepalive 间隔（秒），0表示禁用
 	ImageStreamKeepaliveInterval int `mapstructure:"image_stream_keepalive_interval"`
+	// ModelTimeoutSeconds: 单次网关请求的默认总超时（秒，含流式），可在管理后台按模型覆盖；0表示不限制
+	ModelTimeoutSeconds int `mapstructure:"model_timeout_seconds"`
 	// MaxLineSize: 上游 SSE 单行最大字节数（0使用默认值）
 	MaxLineSize int `mapstructure:"max_line_size"`
 
@@ -691,6 +898,11 @@ type GatewayConfig struct {
 	// 是否允许对部分 400 错误触发 failover（默认关闭以避免改变语义）
 	FailoverOn400 bool `mapstructure:"failover_on_400"`
 
+	// 上游请求失败（连接错误/超时，尚未向客户端写入任何字节）时是否切换其他账号重试
+	FailoverOnRequestError bool `mapstructure:"failover_on_request_error"`
+	// 请求失败时的最大换号重试次数：本请求已切换账号次数达到该值后不再因请求失败切换（0 表示不重试）
+	MaxRequestErrorRetries int `mapstructure:"max_request_error_retries"`
+
 	// 账户切换最大次数（遇到上游错误时切换到其他账户的次数上限）
 	MaxAccountSwitches int `mapstructure:"max_account_switches"`
 	// Gemini 账户切换最大次数（Gemini 平台单独配置，因 API 限制更严格）
@@ -716,6 +928,26 @@ type GatewayConfig struct {
 	// UserMessageQueue: 用户消息串行队列配置
 	// 对 role:"user" 的真实用户消息实施账号级串行化 + RPM 自适应延迟
 	UserMessageQueue UserMessageQueueConfig `mapstructure:"user_message_queue"`
+
+	// ResponseCache: 确定性请求（temperature 为 0 或未设置、非流式）的响应缓存，默认关闭；
+	// 作用于 Anthropic Messages、OpenAI Chat Completions 与 Responses 接口
+	ResponseCache GatewayResponseCacheConfig `mapstructure:"response_cache"`
+}
+
+// GatewayResponseCacheConfig 响应缓存配置
+// 缓存键为 用户 + 分组 + 入站端点 + 模型 + 规范化请求体 的哈希，仅缓存成功的非流式响应（进程内 ristretto 缓存）。
+// 接入 Anthropic Messages（/v1/messages）、OpenAI Chat Completions（/v1/chat/completions）与 Responses（/v1/responses）接口；
+// Gemini 接口、Responses WebSocket 与图片生成请求不使用该缓存
+type GatewayResponseCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTLSeconds: 缓存有效期（秒）
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+	// MaxEntries: 最多缓存的响应数；容量满时按访问频率（TinyLFU）决定新条目是否写入，不保证淘汰最久未使用的条目
+	MaxEntries int `mapstructure:"max_entries"`
+	// MaxBodyBytes: 单个响应体上限（字节），超出时不缓存
+	MaxBodyBytes int `mapstructure:"max_body_bytes"`
+	// HitBillingRate: 命中缓存时按原始费用的比例计费（0 表示免费，1 表示全价）
+	HitBillingRate float64 `mapstructure:"hit_billing_rate"`
 }
 
 // UserMessageQueueConfig 用户消息串行队列配置
@@ -980,6 +1212,9 @@ type GatewaySchedulingConfig struct {
 	// 全量重建周期配置
 	// 全量重建周期（秒），0 表示禁用
 	FullRebuildIntervalSeconds int `mapstructure:"full_rebuild_interval_seconds"`
+
+	// 上游限额头剩余比例低于该值（remaining/limit）的账号在调度中降权，0 表示禁用
+	UpstreamRateLimitLowRatio float64 `mapstructure:"upstream_ratelimit_low_ratio"`
 }
 
 func (s *ServerConfig) Address() string {
@@ -1144,6 +1379,8 @@ type DefaultConfig struct {
 type RateLimitConfig struct {
 	OverloadCooldownMinutes int `mapstructure:"overload_cooldown_minutes"`  // 529过载冷却时间(分钟)
 	OAuth401CooldownMinutes int `mapstructure:"oauth_401_cooldown_minutes"` // OAuth 401临时不可调度冷却(分钟)
+	APIKeyDefaultRPM        int `mapstructure:"api_key_default_rpm"`        // API Key 未单独配置时的每分钟请求数上限(0=不限制)
+	APIKeyDefaultTPM        int `mapstructure:"api_key_default_tpm"`        // API Key 未单独配置时的每分钟 token 数上限(0=不限制)
 }
 
 // APIKeyAuthCacheConfig API Key 认证缓存配置
@@ -1417,6 +1654,7 @@ func setDefaults() {
 	viper.SetDefault("server.idle_timeout", 120)       // 120秒空闲超时
 	viper.SetDefault("server.trusted_proxies", []string{})
 	viper.SetDefault("server.max_request_body_size", int64(256*1024*1024))
+	viper.SetDefault("server.shutdown_grace_period", 30) // 30秒停机宽限期
 	// H2C 默认配置
 	viper.SetDefault("server.h2c.enabled", false)
 	viper.SetDefault("server.h2c.max_concurrent_streams", uint32(50))      // 50 个并发流
@@ -1472,6 +1710,9 @@ func setDefaults() {
 	viper.SetDefault("security.csp.enabled", true)
 	viper.SetDefault("security.csp.policy", DefaultCSPPolicy)
 	viper.SetDefault("security.proxy_probe.insecure_skip_verify", false)
+	viper.SetDefault("security.api_key_hashing.enabled", false)
+	viper.SetDefault("security.api_key_hashing.algorithm", "bcrypt")
+	viper.SetDefault("security.api_key_hashing.bcrypt_cost", 10)
 
 	// Security - disable direct fallback on proxy error
 	viper.SetDefault("security.proxy_fallback.allow_direct_on_error", false)
@@ -1481,6 +1722,10 @@ func setDefaults() {
 	viper.SetDefault("billing.circuit_breaker.failure_threshold", 5)
 	viper.SetDefault("billing.circuit_breaker.reset_timeout_seconds", 30)
 	viper.SetDefault("billing.circuit_breaker.half_open_requests", 3)
+	viper.SetDefault("billing.budget_cycle_day", 1)
+	viper.SetDefault("billing.stream_reservation.enabled", true)
+	viper.SetDefault("billing.stream_reservation.timeout_seconds", 600)
+	viper.SetDefault("billing.stream_reservation.default_max_output_tokens", 8192)
 
 	// Turnstile
 	viper.SetDefault("turnstile.required", false)
@@ -1604,6 +1849,8 @@ func setDefaults() {
 	// RateLimit
 	viper.SetDefault("rate_limit.overload_cooldown_minutes", 10)
 	viper.SetDefault("rate_limit.oauth_401_cooldown_minutes", 10)
+	viper.SetDefault("rate_limit.api_key_default_rpm", 0)
+	viper.SetDefault("rate_limit.api_key_default_tpm", 0)
 
 	// Pricing - 从 model-price-repo 同步模型定价和上下文窗口数据（固定到 commit，避免分支漂移）
 	viper.SetDefault("pricing.remote_url", "https://raw.githubusercontent.com/Wei-Shaw/model-price-repo/main/model_prices_and_context_window.json")
@@ -1612,6 +1859,28 @@ func setDefaults() {
 	viper.SetDefault("pricing.fallback_file", "./resources/model-pricing/model_prices_and_context_window.json")
 	viper.SetDefault("pricing.update_interval_hours", 24)
 	viper.SetDefault("pricing.hash_check_interval_minutes", 10)
+	viper.SetDefault("pricing.auto_refresh_enabled", true)
+	viper.SetDefault("pricing.auto_refresh_jitter_minutes", 30)
+	viper.SetDefault("pricing.import_allowed_hosts", []string{})
+	viper.SetDefault("pricing.strict_model_match", false)
+	viper.SetDefault("pricing.default_io_ratio", 3.0)
+	viper.SetDefault("pricing.webhook_url", "")
+	viper.SetDefault("pricing.webhook_secret", "")
+	viper.SetDefault("pricing.markup_percent", 0.0)
+	viper.SetDefault("pricing.markup_flat_per_mtok", 0.0)
+	viper.SetDefault("pricing.default_input_cost_per_token", 0.0)
+	viper.SetDefault("pricing.default_output_cost_per_token", 0.0)
+	viper.SetDefault("pricing.batch_multiplier", 0.5)
+	viper.SetDefault("pricing.structured_output_multiplier", 1.0)
+	viper.SetDefault("pricing.deprecation_autoroute", false)
+	viper.SetDefault("pricing.min_charge", 0.0)
+	viper.SetDefault("pricing.fetch_retry_attempts", 3)
+	viper.SetDefault("pricing.fetch_retry_base_delay_ms", 1000)
+	viper.SetDefault("pricing.download_chunk_bytes", 0)
+	viper.SetDefault("pricing.download_max_resumes", 3)
+	viper.SetDefault("pricing.verify_checksum", false)
+	viper.SetDefault("pricing.display_significant_digits", 6)
+	viper.SetDefault("pricing.sources", []map[string]any{})
 
 	// Timezone (default to Asia/Shanghai for Chinese users)
 	viper.SetDefault("timezone", "Asia/Shanghai")
@@ -1656,6 +1925,37 @@ func setDefaults() {
 	viper.SetDefault("usage_cleanup.task_timeout_seconds", 1800)
 
 	// Idempotency
+	// AccountHealthCheck
+	viper.SetDefault("account_health_check.enabled", false)
+	viper.SetDefault("account_health_check.interval_seconds", 300)
+	viper.SetDefault("account_health_check.failure_threshold", 3)
+	viper.SetDefault("account_health_check.timeout_seconds", 60)
+	viper.SetDefault("account_health_check.max_workers", 5)
+
+	// AccountCircuitBreaker
+	viper.SetDefault("account_circuit_breaker.enabled", true)
+	viper.SetDefault("account_circuit_breaker.window_seconds", 60)
+	viper.SetDefault("account_circuit_breaker.min_requests", 10)
+	viper.SetDefault("account_circuit_breaker.failure_rate_threshold", 0.5)
+	viper.SetDefault("account_circuit_breaker.cooldown_seconds", 30)
+
+	// Metrics
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.auth_token", "")
+	viper.SetDefault("metrics.token_buckets", []float64{256, 1024, 4096, 16384, 32768, 65536, 131072, 262144})
+
+	// Audit
+	viper.SetDefault("audit.enabled", false)
+	viper.SetDefault("audit.db_enabled", true)
+	viper.SetDefault("audit.file_path", "")
+	viper.SetDefault("audit.max_body_bytes", 65536)
+	viper.SetDefault("audit.queue_size", 10000)
+
+	// Slow request log
+	viper.SetDefault("slow_request.threshold_ms", 0)
+	viper.SetDefault("slow_request.model_thresholds_ms", map[string]int{})
+	viper.SetDefault("slow_request.buffer_size", 200)
+
 	viper.SetDefault("idempotency.observe_only", true)
 	viper.SetDefault("idempotency.default_ttl_seconds", 86400)
 	viper.SetDefault("idempotency.system_operation_ttl_seconds", 3600)
@@ -1664,6 +1964,8 @@ func setDefaults() {
 	viper.SetDefault("idempotency.max_stored_response_len", 64*1024)
 	viper.SetDefault("idempotency.cleanup_interval_seconds", 60)
 	viper.SetDefault("idempotency.cleanup_batch_size", 500)
+	viper.SetDefault("idempotency.gateway_ttl_seconds", 600)
+	viper.SetDefault("idempotency.gateway_max_response_bytes", 1024*1024)
 
 	// Gateway
 	viper.SetDefault("gateway.response_header_timeout", 600) // 600秒(10分钟)等待上游响应头，LLM高负载时可能排队较久
@@ -1671,6 +1973,8 @@ func setDefaults() {
 	viper.SetDefault("gateway.log_upstream_error_body_max_bytes", 2048)
 	viper.SetDefault("gateway.inject_beta_for_apikey", false)
 	viper.SetDefault("gateway.failover_on_400", false)
+	viper.SetDefault("gateway.failover_on_request_error", true)
+	viper.SetDefault("gateway.max_request_error_retries", 2)
 	viper.SetDefault("gateway.max_account_switches", 10)
 	viper.SetDefault("gateway.max_account_switches_gemini", 3)
 	viper.SetDefault("gateway.force_codex_cli", false)
@@ -1730,6 +2034,17 @@ func setDefaults() {
 	viper.SetDefault("gateway.image_concurrency.overflow_mode", ImageConcurrencyOverflowModeReject)
 	viper.SetDefault("gateway.image_concurrency.wait_timeout_seconds", 30)
 	viper.SetDefault("gateway.image_concurrency.max_waiting_requests", 100)
+	viper.SetDefault("gateway.priority_queue.enabled", false)
+	viper.SetDefault("gateway.priority_queue.max_concurrent_requests", 0)
+	viper.SetDefault("gateway.priority_queue.max_wait_seconds", 30)
+	viper.SetDefault("gateway.priority_queue.max_queue_size", 1000)
+	viper.SetDefault("gateway.key_size_limits.default_max_request_bytes", int64(0))
+	viper.SetDefault("gateway.key_size_limits.default_max_response_bytes", int64(256*1024*1024))
+	viper.SetDefault("gateway.context_window_check.enabled", false)
+	viper.SetDefault("gateway.context_window_check.tolerance", 0.05)
+	viper.SetDefault("gateway.load_hints.enabled", true)
+	viper.SetDefault("gateway.load_hints.capacity_cache_seconds", 15)
+	viper.SetDefault("gateway.mask_response_model", false)
 	viper.SetDefault("gateway.antigravity_fallback_cooldown_minutes", 1)
 	viper.SetDefault("gateway.antigravity_extra_retries", 10)
 	viper.SetDefault("gateway.max_body_size", int64(256*1024*1024))
@@ -1737,6 +2052,7 @@ func setDefaults() {
 	viper.SetDefault("gateway.proxy_probe_response_read_max_bytes", int64(1024*1024))
 	viper.SetDefault("gateway.gemini_debug_response_headers", false)
 	viper.SetDefault("gateway.connection_pool_isolation", ConnectionPoolIsolationAccountProxy)
+	viper.SetDefault("gateway.upstream_request_id_header", "X-Request-ID")
 	// HTTP 上游连接池配置（针对 5000+ 并发用户优化）
 	viper.SetDefault("gateway.max_idle_conns", 2560)          // 最大空闲连接总数（高并发场景可调大）
 	viper.SetDefault("gateway.max_idle_conns_per_host", 120)  // 每主机最大空闲连接（HTTP/2 场景默认）
@@ -1746,6 +2062,7 @@ func setDefaults() {
 	viper.SetDefault("gateway.client_idle_ttl_seconds", 900)
 	viper.SetDefault("gateway.concurrency_slot_ttl_minutes", 30) // 并发槽位过期时间（支持超长请求）
 	viper.SetDefault("gateway.stream_data_interval_timeout", 180)
+	viper.SetDefault("gateway.model_timeout_seconds", 0)
 	viper.SetDefault("gateway.stream_keepalive_interval", 10)
 	viper.SetDefault("gateway.image_stream_data_interval_timeout", 900)
 	viper.SetDefault("gateway.image_stream_keepalive_interval", 10)
@@ -1768,6 +2085,7 @@ func setDefaults() {
 	viper.SetDefault("gateway.scheduling.outbox_lag_rebuild_failures", 3)
 	viper.SetDefault("gateway.scheduling.outbox_backlog_rebuild_rows", 10000)
 	viper.SetDefault("gateway.scheduling.full_rebuild_interval_seconds", 300)
+	viper.SetDefault("gateway.scheduling.upstream_ratelimit_low_ratio", 0.1)
 	viper.SetDefault("gateway.usage_record.worker_count", 128)
 	viper.SetDefault("gateway.usage_record.queue_size", 16384)
 	viper.SetDefault("gateway.usage_record.task_timeout_seconds", 5)
@@ -1792,6 +2110,12 @@ func setDefaults() {
 	viper.SetDefault("gateway.user_message_queue.min_delay_ms", 200)
 	viper.SetDefault("gateway.user_message_queue.max_delay_ms", 2000)
 	viper.SetDefault("gateway.user_message_queue.cleanup_interval_seconds", 60)
+	// 响应缓存默认关闭
+	viper.SetDefault("gateway.response_cache.enabled", false)
+	viper.SetDefault("gateway.response_cache.ttl_seconds", 300)
+	viper.SetDefault("gateway.response_cache.max_entries", 1000)
+	viper.SetDefault("gateway.response_cache.max_body_bytes", 1024*1024)
+	viper.SetDefault("gateway.response_cache.hit_billing_rate", 0.0)
 
 	viper.SetDefault("gateway.tls_fingerprint.enabled", true)
 	viper.SetDefault("concurrency.ping_interval", 10)
@@ -1876,6 +2200,10 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Server.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("server.shutdown_grace_period must be non-negative")
+	}
+
 	if c.SubscriptionMaintenance.WorkerCount < 0 {
 		return fmt.Errorf("subscription_maintenance.worker_count must be non-negative")
 	}
@@ -1932,6 +2260,14 @@ func (c *Config) Validate() error {
 	if c.JWT.RefreshWindowMinutes < 0 {
 		return fmt.Errorf("jwt.refresh_window_minutes must be non-negative")
 	}
+	switch c.Security.APIKeyHashing.Algorithm {
+	case "", "bcrypt", "argon2id":
+	default:
+		return fmt.Errorf("security.api_key_hashing.algorithm must be one of: bcrypt, argon2id")
+	}
+	if c.Security.APIKeyHashing.BcryptCost != 0 && (c.Security.APIKeyHashing.BcryptCost < 4 || c.Security.APIKeyHashing.BcryptCost > 31) {
+		return fmt.Errorf("security.api_key_hashing.bcrypt_cost must be between 4 and 31")
+	}
 	if c.Security.CSP.Enabled && strings.TrimSpace(c.Security.CSP.Policy) == "" {
 		return fmt.Errorf("security.csp.policy is required when CSP is enabled")
 	}
@@ -2115,6 +2451,73 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("billing.circuit_breaker.half_open_requests must be positive")
 		}
 	}
+	if c.Billing.BudgetCycleDay < 0 || c.Billing.BudgetCycleDay > 28 {
+		return fmt.Errorf("billing.budget_cycle_day must be between 1 and 28")
+	}
+	if c.Billing.StreamReservation.TimeoutSeconds < 0 || c.Billing.StreamReservation.DefaultMaxOutputTokens < 0 {
+		return fmt.Errorf("billing.stream_reservation.timeout_seconds and default_max_output_tokens must be non-negative")
+	}
+	if c.Pricing.MarkupPercent < 0 || c.Pricing.MarkupFlatPerMTok < 0 {
+		return fmt.Errorf("pricing.markup_percent and pricing.markup_flat_per_mtok must be non-negative")
+	}
+	if c.Pricing.MinCharge < 0 {
+		return fmt.Errorf("pricing.min_charge must be non-negative")
+	}
+	if c.Pricing.DefaultInputCostPerToken < 0 || c.Pricing.DefaultOutputCostPerToken < 0 {
+		return fmt.Errorf("pricing.default_input_cost_per_token and pricing.default_output_cost_per_token must be non-negative")
+	}
+	if c.Pricing.BatchMultiplier <= 0 || c.Pricing.BatchMultiplier > 1 {
+		return fmt.Errorf("pricing.batch_multiplier must be in (0, 1]")
+	}
+	if c.Pricing.StructuredOutputMultiplier <= 0 {
+		return fmt.Errorf("pricing.structured_output_multiplier must be positive")
+	}
+	if c.Pricing.DisplaySignificantDigits < 0 || c.Pricing.DisplaySignificantDigits > 17 {
+		return fmt.Errorf("pricing.display_significant_digits must be between 0 and 17")
+	}
+	if c.Pricing.FetchRetryAttempts < 1 {
+		return fmt.Errorf("pricing.fetch_retry_attempts must be at least 1")
+	}
+	if c.Pricing.FetchRetryBaseDelayMs < 0 {
+		return fmt.Errorf("pricing.fetch_retry_base_delay_ms must be non-negative")
+	}
+	if c.Pricing.AutoRefreshJitterMinutes < 0 {
+		return fmt.Errorf("pricing.auto_refresh_jitter_minutes must be non-negative")
+	}
+	if c.Pricing.DownloadChunkBytes < 0 || c.Pricing.DownloadMaxResumes < 0 {
+		return fmt.Errorf("pricing.download_chunk_bytes and pricing.download_max_resumes must be non-negative")
+	}
+	if err := validatePricingSources(c.Pricing.Sources); err != nil {
+		return err
+	}
+	for i, bound := range c.Metrics.TokenBuckets {
+		if bound <= 0 || (i > 0 && bound <= c.Metrics.TokenBuckets[i-1]) {
+			return fmt.Errorf("metrics.token_buckets must be positive and strictly increasing")
+		}
+	}
+	if c.Audit.MaxBodyBytes < 0 {
+		return fmt.Errorf("audit.max_body_bytes must be non-negative")
+	}
+	if c.Audit.QueueSize < 0 {
+		return fmt.Errorf("audit.queue_size must be non-negative")
+	}
+	if c.SlowRequest.ThresholdMs < 0 {
+		return fmt.Errorf("slow_request.threshold_ms must be non-negative")
+	}
+	if c.SlowRequest.BufferSize < 0 {
+		return fmt.Errorf("slow_request.buffer_size must be non-negative")
+	}
+	if cb := c.AccountCircuitBreaker; cb.Enabled {
+		if cb.WindowSeconds <= 0 || cb.CooldownSeconds <= 0 {
+			return fmt.Errorf("account_circuit_breaker.window_seconds and account_circuit_breaker.cooldown_seconds must be positive")
+		}
+		if cb.MinRequests < 0 {
+			return fmt.Errorf("account_circuit_breaker.min_requests must be non-negative")
+		}
+		if cb.FailureRateThreshold <= 0 || cb.FailureRateThreshold > 1 {
+			return fmt.Errorf("account_circuit_breaker.failure_rate_threshold must be within (0, 1]")
+		}
+	}
 	if c.Database.MaxOpenConns <= 0 {
 		return fmt.Errorf("database.max_open_conns must be positive")
 	}
@@ -2282,6 +2685,12 @@ func (c *Config) Validate() error {
 	if c.Idempotency.CleanupBatchSize <= 0 {
 		return fmt.Errorf("idempotency.cleanup_batch_size must be positive")
 	}
+	if c.Idempotency.GatewayTTLSeconds < 0 {
+		return fmt.Errorf("idempotency.gateway_ttl_seconds must be non-negative")
+	}
+	if c.Idempotency.GatewayMaxResponseBytes <= 0 {
+		return fmt.Errorf("idempotency.gateway_max_response_bytes must be positive")
+	}
 	if c.Gateway.MaxBodySize <= 0 {
 		return fmt.Errorf("gateway.max_body_size must be positive")
 	}
@@ -2314,6 +2723,27 @@ func (c *Config) Validate() error {
 	if c.Gateway.ImageConcurrency.MaxWaitingRequests < 0 {
 		return fmt.Errorf("gateway.image_concurrency.max_waiting_requests must be non-negative")
 	}
+	if c.Gateway.PriorityQueue.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("gateway.priority_queue.max_concurrent_requests must be non-negative")
+	}
+	if c.Gateway.PriorityQueue.MaxWaitSeconds < 0 {
+		return fmt.Errorf("gateway.priority_queue.max_wait_seconds must be non-negative")
+	}
+	if c.Gateway.PriorityQueue.MaxQueueSize < 0 {
+		return fmt.Errorf("gateway.priority_queue.max_queue_size must be non-negative")
+	}
+	if c.Gateway.KeySizeLimits.DefaultMaxRequestBytes < 0 {
+		return fmt.Errorf("gateway.key_size_limits.default_max_request_bytes must be non-negative")
+	}
+	if c.Gateway.KeySizeLimits.DefaultMaxResponseBytes < 0 {
+		return fmt.Errorf("gateway.key_size_limits.default_max_response_bytes must be non-negative")
+	}
+	if c.Gateway.ContextWindowCheck.Tolerance < 0 {
+		return fmt.Errorf("gateway.context_window_check.tolerance must be non-negative")
+	}
+	if c.Gateway.LoadHints.CapacityCacheSeconds < 0 {
+		return fmt.Errorf("gateway.load_hints.capacity_cache_seconds must be non-negative")
+	}
 	if c.Gateway.MaxIdleConns <= 0 {
 		return fmt.Errorf("gateway.max_idle_conns must be positive")
 	}
@@ -2362,6 +2792,9 @@ func (c *Config) Validate() error {
 	if c.Gateway.ImageStreamKeepaliveInterval < 0 {
 		return fmt.Errorf("gateway.image_stream_keepalive_interval must be non-negative")
 	}
+	if c.Gateway.ModelTimeoutSeconds < 0 {
+		return fmt.Errorf("gateway.model_timeout_seconds must be non-negative")
+	}
 	if c.Gateway.ImageStreamKeepaliveInterval != 0 &&
 		(c.Gateway.ImageStreamKeepaliveInterval < 5 || c.Gateway.ImageStreamKeepaliveInterval > 60) {
 		return fmt.Errorf("gateway.image_stream_keepalive_interval must be 0 or between 5-60 seconds")
@@ -2553,6 +2986,20 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("gateway.usage_record.auto_scale_cooldown_seconds must be non-negative")
 		}
 	}
+	if c.Gateway.ResponseCache.Enabled {
+		if c.Gateway.ResponseCache.TTLSeconds <= 0 {
+			return fmt.Errorf("gateway.response_cache.ttl_seconds must be positive")
+		}
+		if c.Gateway.ResponseCache.MaxEntries <= 0 {
+			return fmt.Errorf("gateway.response_cache.max_entries must be positive")
+		}
+		if c.Gateway.ResponseCache.MaxBodyBytes <= 0 {
+			return fmt.Errorf("gateway.response_cache.max_body_bytes must be positive")
+		}
+	}
+	if c.Gateway.ResponseCache.HitBillingRate < 0 || c.Gateway.ResponseCache.HitBillingRate > 1 {
+		return fmt.Errorf("gateway.response_cache.hit_billing_rate must be between 0-1")
+	}
 	if c.Gateway.UserGroupRateCacheTTLSeconds <= 0 {
 		return fmt.Errorf("gateway.user_group_rate_cache_ttl_seconds must be positive")
 	}
@@ -2604,6 +3051,9 @@ func (c *Config) Validate() error {
 	if c.Gateway.Scheduling.FullRebuildIntervalSeconds < 0 {
 		return fmt.Errorf("gateway.scheduling.full_rebuild_interval_seconds must be non-negative")
 	}
+	if c.Gateway.Scheduling.UpstreamRateLimitLowRatio < 0 || c.Gateway.Scheduling.UpstreamRateLimitLowRatio >= 1 {
+		return fmt.Errorf("gateway.scheduling.upstream_ratelimit_low_ratio must be in [0, 1)")
+	}
 	if c.Gateway.Scheduling.OutboxLagWarnSeconds > 0 &&
 		c.Gateway.Scheduling.OutboxLagRebuildSeconds > 0 &&
 		c.Gateway.Scheduling.OutboxLagRebuildSeconds < c.Gateway.Scheduling.OutboxLagWarnSeconds {
@@ -2630,6 +3080,43 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validatePricingSources 校验多价格源配置：名称唯一且非空，url 与 file 二选一，至少启用一个
+func validatePricingSources(sources []PricingSourceConfig) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(sources))
+	enabled := 0
+	for i, src := range sources {
+		name := strings.TrimSpace(src.Name)
+		if name == "" {
+			return fmt.Errorf("pricing.sources[%d].name is required", i)
+		}
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("pricing.sources[%d].name %q is duplicated", i, name)
+		}
+		seen[key] = struct{}{}
+		hasURL := strings.TrimSpace(src.URL) != ""
+		hasFile := strings.TrimSpace(src.File) != ""
+		if hasURL == hasFile {
+			return fmt.Errorf("pricing.sources[%d] (%s) must set exactly one of url or file", i, name)
+		}
+		if hasURL {
+			if err := ValidateAbsoluteHTTPURL(src.URL); err != nil {
+				return fmt.Errorf("pricing.sources[%d].url invalid: %w", i, err)
+			}
+		}
+		if src.IsEnabled() {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		return fmt.Errorf("pricing.sources must contain at least one enabled source")
+	}
+	return nil
+}
+
 func normalizeStringSlice(values []string) []string {
 	if len(values) == 0 {
 		return values
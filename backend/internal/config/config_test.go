@@ -1856,3 +1856,36 @@ func TestLoad_DefaultGatewayImageStreamConfig(t *testing.T) {
 		t.Fatalf("image stream timeout = %d, want greater than ordinary stream timeout %d", cfg.Gateway.ImageStreamDataIntervalTimeout, cfg.Gateway.StreamDataIntervalTimeout)
 	}
 }
+
+func TestValidatePricingSources(t *testing.T) {
+	resetViperWithJWTSecret(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.Empty(t, cfg.Pricing.Sources)
+
+	disabled := false
+	cases := []struct {
+		name    string
+		sources []PricingSourceConfig
+		wantErr string
+	}{
+		{"valid", []PricingSourceConfig{{Name: "a", URL: "https://example.com/p.json"}, {Name: "b", File: "./p.json", Priority: 10}}, ""},
+		{"missing name", []PricingSourceConfig{{URL: "https://example.com/p.json"}}, "pricing.sources[0].name"},
+		{"duplicate name", []PricingSourceConfig{{Name: "a", File: "x"}, {Name: "A", File: "y"}}, "duplicated"},
+		{"url and file", []PricingSourceConfig{{Name: "a", URL: "https://example.com/p.json", File: "x"}}, "exactly one of url or file"},
+		{"invalid url", []PricingSourceConfig{{Name: "a", URL: "ftp://example.com/p.json"}}, "pricing.sources[0].url"},
+		{"none enabled", []PricingSourceConfig{{Name: "a", File: "x", Enabled: &disabled}}, "at least one enabled"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg.Pricing.Sources = tc.sources
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
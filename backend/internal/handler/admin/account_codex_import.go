@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -112,33 +113,33 @@ type codexAccountIndex struct {
 func (h *AccountHandler) ImportCodexSession(c *gin.Context) {
 	var req CodexSessionImportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if req.Concurrency != nil && *req.Concurrency < 0 {
-		response.BadRequest(c, "concurrency must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "concurrency must be >= 0")
 		return
 	}
 	if req.Priority != nil && *req.Priority < 0 {
-		response.BadRequest(c, "priority must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "priority must be >= 0")
 		return
 	}
 	if req.RateMultiplier != nil && *req.RateMultiplier < 0 {
-		response.BadRequest(c, "rate_multiplier must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "rate_multiplier must be >= 0")
 		return
 	}
 	if req.LoadFactor != nil && *req.LoadFactor > 10000 {
-		response.BadRequest(c, "load_factor must be <= 10000")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "load_factor must be <= 10000")
 		return
 	}
 
 	entries, err := parseCodexSessionImportEntries(req)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 	if len(entries) == 0 {
-		response.BadRequest(c, "请输入 accessToken 或 Codex session JSON")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "请输入 accessToken 或 Codex session JSON")
 		return
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -88,7 +89,7 @@ func (h *AccountHandler) ExportData(c *gin.Context) {
 
 	selectedIDs, err := parseAccountIDs(c)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -100,7 +101,7 @@ func (h *AccountHandler) ExportData(c *gin.Context) {
 
 	includeProxies, err := parseIncludeProxies(c)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -175,12 +176,12 @@ func (h *AccountHandler) ExportData(c *gin.Context) {
 func (h *AccountHandler) ImportData(c *gin.Context) {
 	var req DataImportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	if err := validateDataHeader(req.Data); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
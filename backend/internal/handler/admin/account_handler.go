@@ -20,6 +20,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/antigravity"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/geminicli"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
@@ -106,6 +107,7 @@ type CreateAccountRequest struct {
 	Priority                int            `json:"priority"`
 	RateMultiplier          *float64       `json:"rate_multiplier"`
 	LoadFactor              *int           `json:"load_factor"`
+	Weight                  *int           `json:"weight"` // 同优先级调度权重（默认 1，0 表示仅排空）
 	GroupIDs                []int64        `json:"group_ids"`
 	ExpiresAt               *int64         `json:"expires_at"`
 	AutoPauseOnExpired      *bool          `json:"auto_pause_on_expired"`
@@ -125,6 +127,7 @@ type UpdateAccountRequest struct {
 	Priority                *int           `json:"priority"`
 	RateMultiplier          *float64       `json:"rate_multiplier"`
 	LoadFactor              *int           `json:"load_factor"`
+	Weight                  *int           `json:"weight"` // 负数表示恢复默认权重
 	Status                  string         `json:"status" binding:"omitempty,oneof=active inactive error"`
 	GroupIDs                *[]int64       `json:"group_ids"`
 	ExpiresAt               *int64         `json:"expires_at"`
@@ -142,6 +145,7 @@ type BulkUpdateAccountsRequest struct {
 	Priority                *int                      `json:"priority"`
 	RateMultiplier          *float64                  `json:"rate_multiplier"`
 	LoadFactor              *int                      `json:"load_factor"`
+	Weight                  *int                      `json:"weight"`
 	Status                  string                    `json:"status" binding:"omitempty,oneof=active inactive error"`
 	Schedulable             *bool                     `json:"schedulable"`
 	GroupIDs                *[]int64                  `json:"group_ids"`
@@ -254,7 +258,7 @@ func (h *AccountHandler) ListIDs(c *gin.Context) {
 		} else {
 			parsedGroupID, parseErr := strconv.ParseInt(groupIDStr, 10, 64)
 			if parseErr != nil || parsedGroupID < 0 {
-				response.BadRequest(c, "invalid group filter")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "invalid group filter")
 				return
 			}
 			groupID = parsedGroupID
@@ -310,6 +314,10 @@ func (h *AccountHandler) List(c *gin.Context) {
 		search = search[:100]
 	}
 	lite := parseBoolQueryWithDefault(c.Query("lite"), false)
+	listCtx := c.Request.Context()
+	if parseBoolQueryWithDefault(c.Query("include_deleted"), false) {
+		listCtx = context.WithValue(listCtx, ctxkey.IncludeDeletedAccounts, true)
+	}
 
 	var groupID int64
 	if groupIDStr := c.Query("group"); groupIDStr != "" {
@@ -329,7 +337,7 @@ func (h *AccountHandler) List(c *gin.Context) {
 		}
 	}
 
-	accounts, total, err := h.adminService.ListAccounts(c.Request.Context(), page, pageSize, platform, accountType, status, search, groupID, privacyMode, sortBy, sortOrder)
+	accounts, total, err := h.adminService.ListAccounts(listCtx, page, pageSize, platform, accountType, status, search, groupID, privacyMode, sortBy, sortOrder)
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
@@ -524,7 +532,7 @@ func ifNoneMatchMatched(ifNoneMatch, etag string) bool {
 func (h *AccountHandler) GetByID(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -542,7 +550,7 @@ func (h *AccountHandler) GetByID(c *gin.Context) {
 func (h *AccountHandler) CheckMixedChannel(c *gin.Context) {
 	var req CheckMixedChannelRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -586,11 +594,11 @@ func (h *AccountHandler) CheckMixedChannel(c *gin.Context) {
 func (h *AccountHandler) Create(c *gin.Context) {
 	var req CreateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if req.RateMultiplier != nil && *req.RateMultiplier < 0 {
-		response.BadRequest(c, "rate_multiplier must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "rate_multiplier must be >= 0")
 		return
 	}
 	// base_rpm 输入校验：负值归零，超过 10000 截断
@@ -616,6 +624,7 @@ func (h *AccountHandler) Create(c *gin.Context) {
 			Priority:              req.Priority,
 			RateMultiplier:        req.RateMultiplier,
 			LoadFactor:            req.LoadFactor,
+			Weight:                req.Weight,
 			GroupIDs:              req.GroupIDs,
 			ExpiresAt:             req.ExpiresAt,
 			AutoPauseOnExpired:    req.AutoPauseOnExpired,
@@ -664,17 +673,17 @@ func (h *AccountHandler) Create(c *gin.Context) {
 func (h *AccountHandler) Update(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	var req UpdateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if req.RateMultiplier != nil && *req.RateMultiplier < 0 {
-		response.BadRequest(c, "rate_multiplier must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "rate_multiplier must be >= 0")
 		return
 	}
 	// base_rpm 输入校验：负值归零，超过 10000 截断
@@ -694,6 +703,7 @@ func (h *AccountHandler) Update(c *gin.Context) {
 		Priority:              req.Priority,    // 指针类型，nil 表示未提供
 		RateMultiplier:        req.RateMultiplier,
 		LoadFactor:            req.LoadFactor,
+		Weight:                req.Weight,
 		Status:                req.Status,
 		GroupIDs:              req.GroupIDs,
 		ExpiresAt:             req.ExpiresAt,
@@ -754,10 +764,28 @@ func (h *AccountHandler) scheduleOpenAIResponsesProbe(account *service.Account)
 func (h *AccountHandler) Delete(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
+	// 排空中的账号需等在途请求全部结束后才允许删除
+	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if account.IsDraining() {
+		inFlight, err := h.accountInFlightCount(c.Request.Context(), accountID)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+		if inFlight > 0 {
+			response.ErrorWithCode(c, http.StatusConflict, response.CodeConflict, fmt.Sprintf("Account is draining with %d in-flight requests", inFlight))
+			return
+		}
+	}
+
 	err = h.adminService.DeleteAccount(c.Request.Context(), accountID)
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -767,11 +795,103 @@ func (h *AccountHandler) Delete(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Account deleted successfully"})
 }
 
+// Restore handles restoring a soft-deleted account
+// POST /api/v1/admin/accounts/:id/restore
+func (h *AccountHandler) Restore(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
+		return
+	}
+
+	account, err := h.adminService.RestoreAccount(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.AccountFromService(account))
+}
+
+// AccountDrainStatus 账号排空状态
+type AccountDrainStatus struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Draining  bool   `json:"draining"`
+	InFlight  int    `json:"in_flight"`
+	CanDelete bool   `json:"can_delete"`
+}
+
+// Drain handles putting an account into draining state
+// POST /api/v1/admin/accounts/:id/drain
+func (h *AccountHandler) Drain(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
+		return
+	}
+
+	account, err := h.adminService.DrainAccount(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.respondDrainStatus(c, account)
+}
+
+// GetDrainStatus handles getting an account's draining state and in-flight request count
+// GET /api/v1/admin/accounts/:id/drain
+func (h *AccountHandler) GetDrainStatus(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
+		return
+	}
+
+	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.respondDrainStatus(c, account)
+}
+
+func (h *AccountHandler) respondDrainStatus(c *gin.Context, account *service.Account) {
+	inFlight, err := h.accountInFlightCount(c.Request.Context(), account.ID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, AccountDrainStatus{
+		AccountID: account.ID,
+		Status:    account.Status,
+		Draining:  account.IsDraining(),
+		InFlight:  inFlight,
+		CanDelete: !account.IsDraining() || inFlight == 0,
+	})
+}
+
+// accountInFlightCount 返回账号当前占用的并发槽位数（即在途请求数）
+func (h *AccountHandler) accountInFlightCount(ctx context.Context, accountID int64) (int, error) {
+	if h.concurrencyService == nil {
+		return 0, nil
+	}
+	counts, err := h.concurrencyService.GetAccountConcurrencyBatch(ctx, []int64{accountID})
+	if err != nil {
+		return 0, fmt.Errorf("get account in-flight count: %w", err)
+	}
+	return counts[accountID], nil
+}
+
 // TestAccountRequest represents the request body for testing an account
 type TestAccountRequest struct {
 	ModelID string `json:"model_id"`
 	Prompt  string `json:"prompt"`
 	Mode    string `json:"mode"`
+	// Stream=false returns a single JSON summary (upstream status, latency, usage, error) instead of SSE
+	Stream *bool `json:"stream"`
 }
 
 type SyncFromCRSRequest struct {
@@ -793,7 +913,7 @@ type PreviewFromCRSRequest struct {
 func (h *AccountHandler) Test(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -801,6 +921,11 @@ func (h *AccountHandler) Test(c *gin.Context) {
 	// Allow empty body, model_id is optional
 	_ = c.ShouldBindJSON(&req)
 
+	if req.Stream != nil && !*req.Stream {
+		h.testSync(c, accountID, req)
+		return
+	}
+
 	// Use AccountTestService to test the account with SSE streaming
 	if err := h.accountTestService.TestAccountConnection(c, accountID, req.ModelID, req.Prompt, req.Mode); err != nil {
 		// Error already sent via SSE, just log
@@ -814,12 +939,24 @@ func (h *AccountHandler) Test(c *gin.Context) {
 	}
 }
 
+// testSync forces one request through the account and responds with a JSON summary.
+// The request is tagged as a test and never billed to any user.
+func (h *AccountHandler) testSync(c *gin.Context, accountID int64, req TestAccountRequest) {
+	summary := h.accountTestService.RunTestSync(c.Request.Context(), accountID, req.ModelID, req.Prompt, req.Mode)
+	if summary.Success && h.rateLimitService != nil {
+		if _, err := h.rateLimitService.RecoverAccountAfterSuccessfulTest(c.Request.Context(), accountID); err != nil {
+			_ = c.Error(err)
+		}
+	}
+	response.Success(c, summary)
+}
+
 // TestChat handles testing account connectivity via ChatGPT conversation API with SSE streaming
 // POST /api/v1/admin/accounts/:id/test-chat
 func (h *AccountHandler) TestChat(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -842,12 +979,12 @@ func (h *AccountHandler) TestChat(c *gin.Context) {
 func (h *AccountHandler) RecoverState(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	if h.rateLimitService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Rate limit service unavailable")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Rate limit service unavailable")
 		return
 	}
 
@@ -872,7 +1009,7 @@ func (h *AccountHandler) RecoverState(c *gin.Context) {
 func (h *AccountHandler) SyncFromCRS(c *gin.Context) {
 	var req SyncFromCRSRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -891,7 +1028,7 @@ func (h *AccountHandler) SyncFromCRS(c *gin.Context) {
 	})
 	if err != nil {
 		// Provide detailed error message for CRS sync failures
-		response.InternalError(c, "CRS sync failed: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "CRS sync failed: "+err.Error())
 		return
 	}
 
@@ -903,7 +1040,7 @@ func (h *AccountHandler) SyncFromCRS(c *gin.Context) {
 func (h *AccountHandler) PreviewFromCRS(c *gin.Context) {
 	var req PreviewFromCRSRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -913,7 +1050,7 @@ func (h *AccountHandler) PreviewFromCRS(c *gin.Context) {
 		Password: req.Password,
 	})
 	if err != nil {
-		response.InternalError(c, "CRS preview failed: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "CRS preview failed: "+err.Error())
 		return
 	}
 
@@ -1047,14 +1184,14 @@ func (h *AccountHandler) refreshSingleAccount(ctx context.Context, account *serv
 func (h *AccountHandler) Refresh(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	// Get account
 	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
 	if err != nil {
-		response.NotFound(c, "Account not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Account not found")
 		return
 	}
 
@@ -1080,7 +1217,7 @@ func (h *AccountHandler) Refresh(c *gin.Context) {
 func (h *AccountHandler) GetStats(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1111,7 +1248,7 @@ func (h *AccountHandler) GetStats(c *gin.Context) {
 func (h *AccountHandler) ClearError(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1139,11 +1276,11 @@ func (h *AccountHandler) BatchClearError(c *gin.Context) {
 		AccountIDs []int64 `json:"account_ids"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if len(req.AccountIDs) == 0 {
-		response.BadRequest(c, "account_ids is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "account_ids is required")
 		return
 	}
 
@@ -1207,11 +1344,11 @@ func (h *AccountHandler) BatchRefresh(c *gin.Context) {
 		AccountIDs []int64 `json:"account_ids"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if len(req.AccountIDs) == 0 {
-		response.BadRequest(c, "account_ids is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "account_ids is required")
 		return
 	}
 
@@ -1301,7 +1438,7 @@ func (h *AccountHandler) BatchCreate(c *gin.Context) {
 		Accounts []CreateAccountRequest `json:"accounts" binding:"required,min=1"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1424,7 +1561,7 @@ type BatchUpdateCredentialsRequest struct {
 func (h *AccountHandler) BatchUpdateCredentials(c *gin.Context) {
 	var req BatchUpdateCredentialsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1432,14 +1569,14 @@ func (h *AccountHandler) BatchUpdateCredentials(c *gin.Context) {
 	if req.Field == "intercept_warmup_requests" {
 		// Must be boolean
 		if _, ok := req.Value.(bool); !ok {
-			response.BadRequest(c, "intercept_warmup_requests must be boolean")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "intercept_warmup_requests must be boolean")
 			return
 		}
 	} else {
 		// account_uuid and org_uuid can be string or null
 		if req.Value != nil {
 			if _, ok := req.Value.(string); !ok {
-				response.BadRequest(c, req.Field+" must be string or null")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, req.Field+" must be string or null")
 				return
 			}
 		}
@@ -1456,7 +1593,7 @@ func (h *AccountHandler) BatchUpdateCredentials(c *gin.Context) {
 	for _, accountID := range req.AccountIDs {
 		account, err := h.adminService.GetAccount(ctx, accountID)
 		if err != nil {
-			response.Error(c, 404, fmt.Sprintf("Account %d not found", accountID))
+			response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, fmt.Sprintf("Account %d not found", accountID))
 			return
 		}
 		if account.Credentials == nil {
@@ -1506,15 +1643,15 @@ func (h *AccountHandler) BatchUpdateCredentials(c *gin.Context) {
 func (h *AccountHandler) BulkUpdate(c *gin.Context) {
 	var req BulkUpdateAccountsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if req.RateMultiplier != nil && *req.RateMultiplier < 0 {
-		response.BadRequest(c, "rate_multiplier must be >= 0")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "rate_multiplier must be >= 0")
 		return
 	}
 	if len(req.AccountIDs) == 0 && req.Filters == nil {
-		response.BadRequest(c, "account_ids or filters is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "account_ids or filters is required")
 		return
 	}
 	// base_rpm 输入校验：负值归零，超过 10000 截断
@@ -1529,6 +1666,7 @@ func (h *AccountHandler) BulkUpdate(c *gin.Context) {
 		req.Priority != nil ||
 		req.RateMultiplier != nil ||
 		req.LoadFactor != nil ||
+		req.Weight != nil ||
 		req.Status != "" ||
 		req.Schedulable != nil ||
 		req.GroupIDs != nil ||
@@ -1536,7 +1674,7 @@ func (h *AccountHandler) BulkUpdate(c *gin.Context) {
 		len(req.Extra) > 0
 
 	if !hasUpdates {
-		response.BadRequest(c, "No updates provided")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "No updates provided")
 		return
 	}
 
@@ -1549,6 +1687,7 @@ func (h *AccountHandler) BulkUpdate(c *gin.Context) {
 		Priority:              req.Priority,
 		RateMultiplier:        req.RateMultiplier,
 		LoadFactor:            req.LoadFactor,
+		Weight:                req.Weight,
 		Status:                req.Status,
 		Schedulable:           req.Schedulable,
 		GroupIDs:              req.GroupIDs,
@@ -1647,7 +1786,7 @@ type ExchangeCodeRequest struct {
 func (h *OAuthHandler) ExchangeCode(c *gin.Context) {
 	var req ExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1669,7 +1808,7 @@ func (h *OAuthHandler) ExchangeCode(c *gin.Context) {
 func (h *OAuthHandler) ExchangeSetupTokenCode(c *gin.Context) {
 	var req ExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1697,7 +1836,7 @@ type CookieAuthRequest struct {
 func (h *OAuthHandler) CookieAuth(c *gin.Context) {
 	var req CookieAuthRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1719,7 +1858,7 @@ func (h *OAuthHandler) CookieAuth(c *gin.Context) {
 func (h *OAuthHandler) SetupTokenCookieAuth(c *gin.Context) {
 	var req CookieAuthRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1738,14 +1877,19 @@ func (h *OAuthHandler) SetupTokenCookieAuth(c *gin.Context) {
 
 // GetUsage handles getting account usage information
 // GET /api/v1/admin/accounts/:id/usage?source=passive|active
+// GET /api/v1/admin/accounts/:id/usage?from=YYYY-MM-DD&to=YYYY-MM-DD 返回该账号实际承接的用量与费用归属
 func (h *AccountHandler) GetUsage(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	source := c.DefaultQuery("source", "active")
+	if source == "attribution" || c.Query("from") != "" || c.Query("to") != "" {
+		h.getUsageAttribution(c, accountID)
+		return
+	}
 
 	var usage *service.UsageInfo
 	if source == "passive" {
@@ -1761,12 +1905,39 @@ func (h *AccountHandler) GetUsage(c *gin.Context) {
 	response.Success(c, usage)
 }
 
+// getUsageAttribution 返回账号在日期区间内的请求数、token 与费用（UTC，含两端；默认本月 1 日至今天）
+func (h *AccountHandler) getUsageAttribution(c *gin.Context, accountID int64) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	var err error
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		if from, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		if to, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	attribution, err := h.accountUsageService.GetUsageAttribution(c.Request.Context(), accountID, from, to)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, attribution)
+}
+
 // ClearRateLimit handles clearing account rate limit status
 // POST /api/v1/admin/accounts/:id/clear-rate-limit
 func (h *AccountHandler) ClearRateLimit(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1790,12 +1961,12 @@ func (h *AccountHandler) ClearRateLimit(c *gin.Context) {
 func (h *AccountHandler) ResetQuota(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	if err := h.adminService.ResetAccountQuota(c.Request.Context(), accountID); err != nil {
-		response.InternalError(c, "Failed to reset account quota: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to reset account quota: "+err.Error())
 		return
 	}
 
@@ -1813,7 +1984,7 @@ func (h *AccountHandler) ResetQuota(c *gin.Context) {
 func (h *AccountHandler) GetTempUnschedulable(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1839,7 +2010,7 @@ func (h *AccountHandler) GetTempUnschedulable(c *gin.Context) {
 func (h *AccountHandler) ClearTempUnschedulable(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1856,7 +2027,7 @@ func (h *AccountHandler) ClearTempUnschedulable(c *gin.Context) {
 func (h *AccountHandler) GetTodayStats(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -1879,7 +2050,7 @@ type BatchTodayStatsRequest struct {
 func (h *AccountHandler) GetBatchTodayStats(c *gin.Context) {
 	var req BatchTodayStatsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1930,13 +2101,13 @@ type SetSchedulableRequest struct {
 func (h *AccountHandler) SetSchedulable(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	var req SetSchedulableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -1954,13 +2125,13 @@ func (h *AccountHandler) SetSchedulable(c *gin.Context) {
 func (h *AccountHandler) GetAvailableModels(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
 	if err != nil {
-		response.NotFound(c, "Account not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Account not found")
 		return
 	}
 
@@ -2093,16 +2264,16 @@ func (h *AccountHandler) GetAvailableModels(c *gin.Context) {
 func (h *AccountHandler) SetPrivacy(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 	account, err := h.adminService.GetAccount(c.Request.Context(), accountID)
 	if err != nil {
-		response.NotFound(c, "Account not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Account not found")
 		return
 	}
 	if account.Type != service.AccountTypeOAuth {
-		response.BadRequest(c, "Only OAuth accounts support privacy setting")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only OAuth accounts support privacy setting")
 		return
 	}
 	var mode string
@@ -2112,11 +2283,11 @@ func (h *AccountHandler) SetPrivacy(c *gin.Context) {
 	case service.PlatformAntigravity:
 		mode = h.adminService.ForceAntigravityPrivacy(c.Request.Context(), account)
 	default:
-		response.BadRequest(c, "Only OpenAI and Antigravity OAuth accounts support privacy setting")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only OpenAI and Antigravity OAuth accounts support privacy setting")
 		return
 	}
 	if mode == "" {
-		response.BadRequest(c, "Cannot set privacy: missing access_token")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Cannot set privacy: missing access_token")
 		return
 	}
 	// 从 DB 重新读取以确保返回最新状态
@@ -2138,25 +2309,25 @@ func (h *AccountHandler) SetPrivacy(c *gin.Context) {
 func (h *AccountHandler) RefreshTier(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
 	ctx := c.Request.Context()
 	account, err := h.adminService.GetAccount(ctx, accountID)
 	if err != nil {
-		response.NotFound(c, "Account not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Account not found")
 		return
 	}
 
 	if account.Platform != service.PlatformGemini || account.Type != service.AccountTypeOAuth {
-		response.BadRequest(c, "Only Gemini OAuth accounts support tier refresh")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only Gemini OAuth accounts support tier refresh")
 		return
 	}
 
 	oauthType, _ := account.Credentials["oauth_type"].(string)
 	if oauthType != "google_one" {
-		response.BadRequest(c, "Only google_one OAuth accounts support tier refresh")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only google_one OAuth accounts support tier refresh")
 		return
 	}
 
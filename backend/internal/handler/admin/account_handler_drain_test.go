@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type drainAdminService struct {
+	*stubAdminService
+	account service.Account
+	deleted []int64
+}
+
+func (s *drainAdminService) GetAccount(_ context.Context, id int64) (*service.Account, error) {
+	acc := s.account
+	acc.ID = id
+	return &acc, nil
+}
+
+func (s *drainAdminService) DrainAccount(_ context.Context, id int64) (*service.Account, error) {
+	s.account.Status = service.StatusDraining
+	acc := s.account
+	acc.ID = id
+	return &acc, nil
+}
+
+func (s *drainAdminService) DeleteAccount(_ context.Context, id int64) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+type drainConcurrencyCache struct {
+	service.ConcurrencyCache
+	counts map[int64]int
+}
+
+func (c *drainConcurrencyCache) GetAccountConcurrencyBatch(_ context.Context, accountIDs []int64) (map[int64]int, error) {
+	out := make(map[int64]int, len(accountIDs))
+	for _, id := range accountIDs {
+		out[id] = c.counts[id]
+	}
+	return out, nil
+}
+
+func setupDrainRouter(adminSvc service.AdminService, cache *drainConcurrencyCache) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountHandler(adminSvc, nil, nil, nil, nil, nil, nil, nil, service.NewConcurrencyService(cache), nil, nil, nil, nil)
+	router.POST("/api/v1/admin/accounts/:id/drain", handler.Drain)
+	router.GET("/api/v1/admin/accounts/:id/drain", handler.GetDrainStatus)
+	router.DELETE("/api/v1/admin/accounts/:id", handler.Delete)
+	return router
+}
+
+func TestAccountHandlerDrain_BlocksDeleteUntilInFlightZero(t *testing.T) {
+	svc := &drainAdminService{
+		stubAdminService: newStubAdminService(),
+		account:          service.Account{Name: "acc", Status: service.StatusActive},
+	}
+	cache := &drainConcurrencyCache{counts: map[int64]int{7: 2}}
+	router := setupDrainRouter(svc, cache)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/7/drain", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Code int                `json:"code"`
+		Data AccountDrainStatus `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, AccountDrainStatus{AccountID: 7, Status: service.StatusDraining, Draining: true, InFlight: 2, CanDelete: false}, resp.Data)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/accounts/7", nil))
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Empty(t, svc.deleted)
+
+	cache.counts[7] = 0
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/7/drain", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.True(t, resp.Data.CanDelete)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/accounts/7", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []int64{7}, svc.deleted)
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type restoreAdminService struct {
+	*stubAdminService
+	restored []int64
+}
+
+func (s *restoreAdminService) RestoreAccount(_ context.Context, id int64) (*service.Account, error) {
+	if id == 404 {
+		return nil, service.ErrAccountNotFound
+	}
+	s.restored = append(s.restored, id)
+	return &service.Account{ID: id, Name: "restored", Status: service.StatusActive}, nil
+}
+
+func setupRestoreRouter(adminSvc service.AdminService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountHandler(adminSvc, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router.GET("/api/v1/admin/accounts", handler.List)
+	router.POST("/api/v1/admin/accounts/:id/restore", handler.Restore)
+	return router
+}
+
+func TestAccountHandlerRestore(t *testing.T) {
+	adminSvc := &restoreAdminService{stubAdminService: newStubAdminService()}
+	router := setupRestoreRouter(adminSvc)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/7/restore", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Code int `json:"code"`
+		Data struct {
+			ID        int64   `json:"id"`
+			Name      string  `json:"name"`
+			DeletedAt *string `json:"deleted_at"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 0, resp.Code)
+	require.Equal(t, int64(7), resp.Data.ID)
+	require.Nil(t, resp.Data.DeletedAt)
+	require.Equal(t, []int64{7}, adminSvc.restored)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/404/restore", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/abc/restore", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAccountHandlerListIncludeDeleted(t *testing.T) {
+	adminSvc := newStubAdminService()
+	router := setupRestoreRouter(adminSvc)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, adminSvc.lastListAccounts.includeDeleted)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts?include_deleted=true", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, adminSvc.lastListAccounts.includeDeleted)
+}
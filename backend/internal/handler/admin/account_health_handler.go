@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetHealth 返回各可调度账号的上游健康状态
+// GET /api/v1/admin/accounts/health
+// 列表包含全部可调度账号，与 account_health_check 是否启用无关；未被探测过的账号视为健康，last_check_at 为空。
+// 每个账号附带当前配置的并发上限与实时占用的并发槽位/排队数；saturated_count 为已达并发上限的账号数。
+// upstream_rate_limits 为各账号最近一次上游响应携带的限额头（剩余量与重置时间）。
+// circuit_breakers 为按真实请求失败率统计的账号熔断状态；熔断只在内存中排除账号，
+// 不改变账号的 status/schedulable，被手动禁用的账号不会出现在本接口中。
+// 配置了日额度（quota_daily_limit）的 apikey/bedrock 账号附带当前周期花费与上限；
+// daily_spend_cap_reached_count 为已达日花费上限、在周期重置前不再被调度的账号数。
+func (h *AccountHandler) GetHealth(c *gin.Context) {
+	accounts, err := h.adminService.ListSchedulableAccounts(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	statuses := service.BuildAccountHealthStatuses(accounts)
+	h.fillHealthConcurrency(c.Request.Context(), statuses)
+	fillHealthDailySpend(statuses, accounts)
+
+	unhealthy, saturated, capReached := 0, 0, 0
+	for _, status := range statuses {
+		if !status.Healthy {
+			unhealthy++
+		}
+		if status.MaxConcurrency > 0 && status.CurrentConcurrency >= status.MaxConcurrency {
+			saturated++
+		}
+		if status.DailySpendCapReached {
+			capReached++
+		}
+	}
+	breakers := service.ListAccountCircuitBreakers()
+	circuitOpen := 0
+	for _, breaker := range breakers {
+		if breaker.State == service.CircuitStateOpen {
+			circuitOpen++
+		}
+	}
+	response.Success(c, gin.H{
+		"accounts":                      statuses,
+		"total":                         len(statuses),
+		"unhealthy_count":               unhealthy,
+		"saturated_count":               saturated,
+		"upstream_rate_limits":          service.ListAccountUpstreamRateLimits(),
+		"circuit_breakers":              breakers,
+		"circuit_open_count":            circuitOpen,
+		"daily_spend_cap_reached_count": capReached,
+	})
+}
+
+// fillHealthDailySpend 按账号当前的日额度用量填充日花费与上限
+func fillHealthDailySpend(statuses []service.AccountHealthStatus, accounts []service.Account) {
+	byID := make(map[int64]*service.Account, len(accounts))
+	for i := range accounts {
+		byID[accounts[i].ID] = &accounts[i]
+	}
+	for i := range statuses {
+		statuses[i].FillDailySpend(byID[statuses[i].AccountID])
+	}
+}
+
+// fillHealthConcurrency 按并发槽位填充各账号的实时并发与排队数（查询失败时保持为 0）
+func (h *AccountHandler) fillHealthConcurrency(ctx context.Context, statuses []service.AccountHealthStatus) {
+	if h.concurrencyService == nil || len(statuses) == 0 {
+		return
+	}
+	accounts := make([]service.AccountWithConcurrency, 0, len(statuses))
+	for _, status := range statuses {
+		accounts = append(accounts, service.AccountWithConcurrency{ID: status.AccountID, MaxConcurrency: status.MaxConcurrency})
+	}
+	loads, err := h.concurrencyService.GetAccountsLoadBatch(ctx, accounts)
+	if err != nil {
+		return
+	}
+	for i := range statuses {
+		if load := loads[statuses[i].AccountID]; load != nil {
+			statuses[i].CurrentConcurrency = load.CurrentConcurrency
+			statuses[i].WaitingCount = load.WaitingCount
+		}
+	}
+}
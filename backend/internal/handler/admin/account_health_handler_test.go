@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type healthConcurrencyCache struct {
+	service.ConcurrencyCache
+	loads map[int64]*service.AccountLoadInfo
+}
+
+func (c *healthConcurrencyCache) GetAccountsLoadBatch(_ context.Context, accounts []service.AccountWithConcurrency) (map[int64]*service.AccountLoadInfo, error) {
+	out := make(map[int64]*service.AccountLoadInfo, len(accounts))
+	for _, account := range accounts {
+		if load := c.loads[account.ID]; load != nil {
+			out[account.ID] = load
+		}
+	}
+	return out, nil
+}
+
+func setupHealthRouter(adminSvc service.AdminService, cache *healthConcurrencyCache) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountHandler(adminSvc, nil, nil, nil, nil, nil, nil, nil, service.NewConcurrencyService(cache), nil, nil, nil, nil)
+	router.GET("/api/v1/admin/accounts/health", handler.GetHealth)
+	return router
+}
+
+type healthResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		Accounts                  []service.AccountHealthStatus `json:"accounts"`
+		Total                     int                           `json:"total"`
+		SaturatedCount            int                           `json:"saturated_count"`
+		DailySpendCapReachedCount int                           `json:"daily_spend_cap_reached_count"`
+	} `json:"data"`
+}
+
+func TestAccountHandlerGetHealth_ListsUnprobedAccountsWithLiveConcurrency(t *testing.T) {
+	svc := newStubAdminService()
+	svc.accounts = []service.Account{
+		{ID: 9002, Name: "second", Platform: service.PlatformOpenAI, Concurrency: 5, Schedulable: true},
+		{ID: 9001, Name: "first", Platform: service.PlatformAnthropic, Concurrency: 2, Schedulable: true},
+	}
+	cache := &healthConcurrencyCache{loads: map[int64]*service.AccountLoadInfo{
+		9001: {AccountID: 9001, CurrentConcurrency: 2, WaitingCount: 3},
+		9002: {AccountID: 9002, CurrentConcurrency: 1},
+	}}
+	router := setupHealthRouter(svc, cache)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/health", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp healthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 2, resp.Data.Total)
+	require.Equal(t, 1, resp.Data.SaturatedCount)
+
+	first := resp.Data.Accounts[0]
+	require.Equal(t, int64(9001), first.AccountID)
+	require.Equal(t, "first", first.Name)
+	require.True(t, first.Healthy)
+	require.Nil(t, first.LastCheckAt)
+	require.Equal(t, 2, first.MaxConcurrency)
+	require.Equal(t, 2, first.CurrentConcurrency)
+	require.Equal(t, 3, first.WaitingCount)
+
+	second := resp.Data.Accounts[1]
+	require.Equal(t, int64(9002), second.AccountID)
+	require.Equal(t, 5, second.MaxConcurrency)
+	require.Equal(t, 1, second.CurrentConcurrency)
+}
+
+func TestAccountHandlerGetHealth_FillsDailySpendForUnprobedAccounts(t *testing.T) {
+	svc := newStubAdminService()
+	svc.accounts = []service.Account{
+		{ID: 9101, Name: "capped", Platform: service.PlatformOpenAI, Type: service.AccountTypeAPIKey, Status: service.StatusActive, Schedulable: true, Extra: map[string]any{
+			"quota_daily_limit": 10.0,
+			"quota_daily_used":  12.5,
+			"quota_daily_start": time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}},
+		{ID: 9102, Name: "oauth", Platform: service.PlatformAnthropic, Type: service.AccountTypeOAuth, Status: service.StatusActive, Schedulable: true},
+	}
+	router := setupHealthRouter(svc, &healthConcurrencyCache{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/health", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp healthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Data.DailySpendCapReachedCount)
+
+	capped := resp.Data.Accounts[0]
+	require.Equal(t, int64(9101), capped.AccountID)
+	require.Nil(t, capped.LastCheckAt)
+	require.NotNil(t, capped.DailySpend)
+	require.InDelta(t, 12.5, *capped.DailySpend, 1e-9)
+	require.InDelta(t, 10.0, *capped.DailySpendCap, 1e-9)
+	require.True(t, capped.DailySpendCapReached)
+
+	require.Nil(t, resp.Data.Accounts[1].DailySpend)
+}
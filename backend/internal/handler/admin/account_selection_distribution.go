@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSelectionDistribution 返回负载均衡在各账号间的实际分配情况，用于核对权重是否生效
+// GET /api/v1/admin/accounts/selection-distribution?group_id=
+// 统计为当前实例进程内数据，粘性会话命中不计入。
+func (h *AccountHandler) GetSelectionDistribution(c *gin.Context) {
+	var groupID *int64
+	if raw := strings.TrimSpace(c.Query("group_id")); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
+			return
+		}
+		groupID = &id
+	}
+
+	groups, since := service.GetAccountSelectionDistribution(groupID)
+	response.Success(c, gin.H{
+		"groups": groups,
+		"since":  since.UTC().Format(time.RFC3339),
+	})
+}
+
+// ResetSelectionDistribution 清空账号选中分布统计
+// POST /api/v1/admin/accounts/selection-distribution/reset
+func (h *AccountHandler) ResetSelectionDistribution(c *gin.Context) {
+	service.ResetAccountSelectionDistribution()
+	response.Success(c, gin.H{"message": "Selection distribution reset"})
+}
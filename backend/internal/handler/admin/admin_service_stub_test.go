@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 )
 
@@ -34,15 +35,16 @@ type stubAdminService struct {
 		groupIDs  []int64
 	}
 	lastListAccounts struct {
-		platform    string
-		accountType string
-		status      string
-		search      string
-		groupID     int64
-		privacyMode string
-		sortBy      string
-		sortOrder   string
-		calls       int
+		platform       string
+		accountType    string
+		status         string
+		search         string
+		groupID        int64
+		privacyMode    string
+		sortBy         string
+		sortOrder      string
+		includeDeleted bool
+		calls          int
 	}
 	lastListUsers struct {
 		page      int
@@ -308,6 +310,7 @@ func (s *stubAdminService) ListAccounts(ctx context.Context, page, pageSize int,
 	s.lastListAccounts.privacyMode = privacyMode
 	s.lastListAccounts.sortBy = sortBy
 	s.lastListAccounts.sortOrder = sortOrder
+	s.lastListAccounts.includeDeleted, _ = ctx.Value(ctxkey.IncludeDeletedAccounts).(bool)
 	s.lastListAccounts.calls++
 	return s.accounts, int64(len(s.accounts)), nil
 }
@@ -321,6 +324,10 @@ func (s *stubAdminService) GetAccount(ctx context.Context, id int64) (*service.A
 	return &account, nil
 }
 
+func (s *stubAdminService) ListSchedulableAccounts(ctx context.Context) ([]service.Account, error) {
+	return s.accounts, nil
+}
+
 func (s *stubAdminService) GetAccountsByIDs(ctx context.Context, ids []int64) ([]*service.Account, error) {
 	out := make([]*service.Account, 0, len(ids))
 	for _, id := range ids {
@@ -353,6 +360,16 @@ func (s *stubAdminService) DeleteAccount(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (s *stubAdminService) RestoreAccount(ctx context.Context, id int64) (*service.Account, error) {
+	account := service.Account{ID: id, Name: "account", Status: service.StatusActive}
+	return &account, nil
+}
+
+func (s *stubAdminService) DrainAccount(ctx context.Context, id int64) (*service.Account, error) {
+	account := service.Account{ID: id, Name: "account", Status: service.StatusDraining}
+	return &account, nil
+}
+
 func (s *stubAdminService) RefreshAccountCredentials(ctx context.Context, id int64) (*service.Account, error) {
 	account := service.Account{ID: id, Name: "account", Status: service.StatusActive}
 	return &account, nil
@@ -589,6 +606,122 @@ func (s *stubAdminService) AdminResetAPIKeyRateLimitUsage(ctx context.Context, k
 	return nil, service.ErrAPIKeyNotFound
 }
 
+func (s *stubAdminService) AdminUpdateAPIKeyThroughputLimits(ctx context.Context, keyID int64, rpmLimit, tpmLimit *int) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			if rpmLimit != nil {
+				s.apiKeys[i].RPMLimit = *rpmLimit
+			}
+			if tpmLimit != nil {
+				s.apiKeys[i].TPMLimit = *tpmLimit
+			}
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeyAuditCapture(ctx context.Context, keyID int64, enabled bool) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			s.apiKeys[i].AuditCaptureBody = enabled
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeyMaskResponseModel(ctx context.Context, keyID int64, enabled bool) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			s.apiKeys[i].MaskResponseModel = enabled
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeySandbox(ctx context.Context, keyID int64, sandbox bool) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			s.apiKeys[i].IsSandbox = sandbox
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeyPriorityTier(ctx context.Context, keyID int64, tier int) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			s.apiKeys[i].PriorityTier = tier
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeySizeLimits(ctx context.Context, keyID int64, maxRequestBytes, maxResponseBytes *int64) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			if maxRequestBytes != nil {
+				s.apiKeys[i].MaxRequestBytes = *maxRequestBytes
+			}
+			if maxResponseBytes != nil {
+				s.apiKeys[i].MaxResponseBytes = *maxResponseBytes
+			}
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeyUsageQuota(ctx context.Context, keyID int64, requests, tokens *int64, period *string, reset bool) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			if requests != nil {
+				s.apiKeys[i].UsageQuotaRequests = *requests
+			}
+			if tokens != nil {
+				s.apiKeys[i].UsageQuotaTokens = *tokens
+			}
+			if period != nil {
+				s.apiKeys[i].UsageQuotaPeriod = *period
+			}
+			if reset {
+				s.apiKeys[i].UsageQuotaRequestsUsed = 0
+				s.apiKeys[i].UsageQuotaTokensUsed = 0
+				s.apiKeys[i].UsageQuotaWindowStart = nil
+			}
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
+func (s *stubAdminService) AdminUpdateAPIKeyModelAccess(ctx context.Context, keyID int64, allowedModels, deniedModels *[]string) (*service.APIKey, error) {
+	for i := range s.apiKeys {
+		if s.apiKeys[i].ID == keyID {
+			if allowedModels != nil {
+				s.apiKeys[i].AllowedModels = *allowedModels
+			}
+			if deniedModels != nil {
+				s.apiKeys[i].DeniedModels = *deniedModels
+			}
+			k := s.apiKeys[i]
+			return &k, nil
+		}
+	}
+	return nil, service.ErrAPIKeyNotFound
+}
+
 func (s *stubAdminService) ResetAccountQuota(ctx context.Context, id int64) error {
 	return nil
 }
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -61,13 +62,13 @@ type UpdateAffiliateUserRequest struct {
 func (h *AffiliateHandler) UpdateUserSettings(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil || userID <= 0 {
-		response.BadRequest(c, "Invalid user_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 		return
 	}
 
 	var req UpdateAffiliateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -103,7 +104,7 @@ func (h *AffiliateHandler) UpdateUserSettings(c *gin.Context) {
 func (h *AffiliateHandler) ClearUserSettings(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil || userID <= 0 {
-		response.BadRequest(c, "Invalid user_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 		return
 	}
 	if err := h.affiliateService.AdminSetUserRebateRate(c.Request.Context(), userID, nil); err != nil {
@@ -135,15 +136,15 @@ type BatchSetRateRequest struct {
 func (h *AffiliateHandler) BatchSetRate(c *gin.Context) {
 	var req BatchSetRateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if len(req.UserIDs) == 0 {
-		response.BadRequest(c, "user_ids cannot be empty")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "user_ids cannot be empty")
 		return
 	}
 	if !req.Clear && req.AffRebateRatePercent == nil {
-		response.BadRequest(c, "aff_rebate_rate_percent is required unless clear=true")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "aff_rebate_rate_percent is required unless clear=true")
 		return
 	}
 	rate := req.AffRebateRatePercent
@@ -190,7 +191,7 @@ func (h *AffiliateHandler) LookupUsers(c *gin.Context) {
 func (h *AffiliateHandler) GetUserOverview(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
 	if err != nil || userID <= 0 {
-		response.BadRequest(c, "Invalid user_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 		return
 	}
 	overview, err := h.affiliateService.AdminGetUserOverview(c.Request.Context(), userID)
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -87,7 +88,7 @@ func (h *AnnouncementHandler) List(c *gin.Context) {
 func (h *AnnouncementHandler) GetByID(c *gin.Context) {
 	announcementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || announcementID <= 0 {
-		response.BadRequest(c, "Invalid announcement ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid announcement ID")
 		return
 	}
 
@@ -105,13 +106,13 @@ func (h *AnnouncementHandler) GetByID(c *gin.Context) {
 func (h *AnnouncementHandler) Create(c *gin.Context) {
 	var req CreateAnnouncementRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	subject, ok := middleware2.GetAuthSubjectFromContext(c)
 	if !ok {
-		response.Unauthorized(c, "User not found in context")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "User not found in context")
 		return
 	}
 
@@ -147,19 +148,19 @@ func (h *AnnouncementHandler) Create(c *gin.Context) {
 func (h *AnnouncementHandler) Update(c *gin.Context) {
 	announcementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || announcementID <= 0 {
-		response.BadRequest(c, "Invalid announcement ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid announcement ID")
 		return
 	}
 
 	var req UpdateAnnouncementRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	subject, ok := middleware2.GetAuthSubjectFromContext(c)
 	if !ok {
-		response.Unauthorized(c, "User not found in context")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "User not found in context")
 		return
 	}
 
@@ -208,7 +209,7 @@ func (h *AnnouncementHandler) Update(c *gin.Context) {
 func (h *AnnouncementHandler) Delete(c *gin.Context) {
 	announcementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || announcementID <= 0 {
-		response.BadRequest(c, "Invalid announcement ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid announcement ID")
 		return
 	}
 
@@ -225,7 +226,7 @@ func (h *AnnouncementHandler) Delete(c *gin.Context) {
 func (h *AnnouncementHandler) ListReadStatus(c *gin.Context) {
 	announcementID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || announcementID <= 0 {
-		response.BadRequest(c, "Invalid announcement ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid announcement ID")
 		return
 	}
 
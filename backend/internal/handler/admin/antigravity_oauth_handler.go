@@ -4,6 +4,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
 type AntigravityOAuthHandler struct {
@@ -23,13 +24,13 @@ type AntigravityGenerateAuthURLRequest struct {
 func (h *AntigravityOAuthHandler) GenerateAuthURL(c *gin.Context) {
 	var req AntigravityGenerateAuthURLRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求无效: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "请求无效: "+err.Error())
 		return
 	}
 
 	result, err := h.antigravityOAuthService.GenerateAuthURL(c.Request.Context(), req.ProxyID)
 	if err != nil {
-		response.InternalError(c, "生成授权链接失败: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "生成授权链接失败: "+err.Error())
 		return
 	}
 
@@ -48,7 +49,7 @@ type AntigravityExchangeCodeRequest struct {
 func (h *AntigravityOAuthHandler) ExchangeCode(c *gin.Context) {
 	var req AntigravityExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求无效: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "请求无效: "+err.Error())
 		return
 	}
 
@@ -59,7 +60,7 @@ func (h *AntigravityOAuthHandler) ExchangeCode(c *gin.Context) {
 		ProxyID:   req.ProxyID,
 	})
 	if err != nil {
-		response.BadRequest(c, "Token 交换失败: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Token 交换失败: "+err.Error())
 		return
 	}
 
@@ -77,7 +78,7 @@ type AntigravityRefreshTokenRequest struct {
 func (h *AntigravityOAuthHandler) RefreshToken(c *gin.Context) {
 	var req AntigravityRefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "请求无效: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "请求无效: "+err.Error())
 		return
 	}
 
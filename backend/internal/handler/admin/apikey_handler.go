@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
@@ -26,6 +27,22 @@ func NewAdminAPIKeyHandler(adminService service.AdminService) *AdminAPIKeyHandle
 type AdminUpdateAPIKeyGroupRequest struct {
 	GroupID             *int64 `json:"group_id"`               // nil=不修改, 0=解绑, >0=绑定到目标分组
 	ResetRateLimitUsage *bool  `json:"reset_rate_limit_usage"` // true=重置 5h/1d/7d 限速用量
+	RPMLimit            *int   `json:"rpm_limit"`              // nil=不修改, 0=使用全局默认值
+	TPMLimit            *int   `json:"tpm_limit"`              // nil=不修改, 0=使用全局默认值
+	AuditCaptureBody    *bool  `json:"audit_capture_body"`     // nil=不修改, true=审计日志记录请求/响应正文
+	IsSandbox           *bool  `json:"is_sandbox"`             // nil=不修改, true=沙盒 Key（不扣费、不计入消费汇总与预算）
+	PriorityTier        *int   `json:"priority_tier"`          // nil=不修改, 网关排队优先级（越大越优先，0=默认）
+	MaskResponseModel   *bool  `json:"mask_response_model"`    // nil=不修改, true=响应中回显请求的模型名（隐藏实际上游模型）
+	MaxRequestBytes     *int64 `json:"max_request_bytes"`      // nil=不修改, 请求体上限（字节，0=使用全局默认值）
+	MaxResponseBytes    *int64 `json:"max_response_bytes"`     // nil=不修改, 响应体上限（字节，0=使用全局默认值）
+	// 周期额度：nil=不修改, 0=不限制；重置周期为 hourly/daily/weekly/monthly，修改周期会清零本周期用量
+	UsageQuotaRequests *int64  `json:"usage_quota_requests"`
+	UsageQuotaTokens   *int64  `json:"usage_quota_tokens"`
+	UsageQuotaPeriod   *string `json:"usage_quota_period"`
+	ResetUsageQuota    *bool   `json:"reset_usage_quota"` // true=清零本周期请求数/token 用量
+	// 模型访问控制：nil=不修改, []=清空；禁止列表优先于允许列表
+	AllowedModels *[]string `json:"allowed_models"`
+	DeniedModels  *[]string `json:"denied_models"`
 }
 
 // UpdateGroup handles updating an API key's admin-managed fields.
@@ -33,13 +50,13 @@ type AdminUpdateAPIKeyGroupRequest struct {
 func (h *AdminAPIKeyHandler) UpdateGroup(c *gin.Context) {
 	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid API key ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid API key ID")
 		return
 	}
 
 	var req AdminUpdateAPIKeyGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -52,6 +69,71 @@ func (h *AdminAPIKeyHandler) UpdateGroup(c *gin.Context) {
 		}
 	}
 
+	if req.RPMLimit != nil || req.TPMLimit != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyThroughputLimits(c.Request.Context(), keyID, req.RPMLimit, req.TPMLimit)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.AuditCaptureBody != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyAuditCapture(c.Request.Context(), keyID, *req.AuditCaptureBody)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.IsSandbox != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeySandbox(c.Request.Context(), keyID, *req.IsSandbox)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.PriorityTier != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyPriorityTier(c.Request.Context(), keyID, *req.PriorityTier)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.MaskResponseModel != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyMaskResponseModel(c.Request.Context(), keyID, *req.MaskResponseModel)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.MaxRequestBytes != nil || req.MaxResponseBytes != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeySizeLimits(c.Request.Context(), keyID, req.MaxRequestBytes, req.MaxResponseBytes)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	resetUsageQuota := req.ResetUsageQuota != nil && *req.ResetUsageQuota
+	if req.UsageQuotaRequests != nil || req.UsageQuotaTokens != nil || req.UsageQuotaPeriod != nil || resetUsageQuota {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyUsageQuota(c.Request.Context(), keyID, req.UsageQuotaRequests, req.UsageQuotaTokens, req.UsageQuotaPeriod, resetUsageQuota)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	if req.AllowedModels != nil || req.DeniedModels != nil {
+		resetKey, err = h.adminService.AdminUpdateAPIKeyModelAccess(c.Request.Context(), keyID, req.AllowedModels, req.DeniedModels)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
 	result, err := h.adminService.AdminUpdateAPIKeyGroupID(c.Request.Context(), keyID, req.GroupID)
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -240,3 +240,27 @@ type failingUpdateGroupService struct {
 func (f *failingUpdateGroupService) AdminUpdateAPIKeyGroupID(_ context.Context, _ int64, _ *int64) (*service.AdminUpdateAPIKeyGroupIDResult, error) {
 	return nil, f.err
 }
+
+func TestAdminAPIKeyHandler_UpdateModelAccess(t *testing.T) {
+	svc := newStubAdminService()
+	router := setupAPIKeyHandler(svc)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/api-keys/10", bytes.NewBufferString(`{"allowed_models":["claude-*"],"denied_models":["claude-opus-4"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data struct {
+			APIKey struct {
+				AllowedModels []string `json:"allowed_models"`
+				DeniedModels  []string `json:"denied_models"`
+			} `json:"api_key"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, []string{"claude-*"}, resp.Data.APIKey.AllowedModels)
+	require.Equal(t, []string{"claude-opus-4"}, resp.Data.APIKey.DeniedModels)
+}
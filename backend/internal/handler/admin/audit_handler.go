@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles admin audit log queries
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler creates a new admin audit handler
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// List returns paginated request audit logs.
+// GET /api/v1/admin/audit?user_id=&api_key_id=&model=&start_time=&end_time=&time_range=&page=&page_size=
+func (h *AuditHandler) List(c *gin.Context) {
+	page, pageSize := response.ParsePagination(c)
+	if pageSize > 200 {
+		pageSize = 200
+	}
+
+	start, end, err := parseOpsTimeRange(c, "24h")
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	filter := &service.AuditLogFilter{
+		StartTime: &start,
+		EndTime:   &end,
+		Model:     strings.TrimSpace(c.Query("model")),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	if v := strings.TrimSpace(c.Query("user_id")); v != "" {
+		id, parseErr := strconv.ParseInt(v, 10, 64)
+		if parseErr != nil || id <= 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
+			return
+		}
+		filter.UserID = &id
+	}
+	if v := strings.TrimSpace(c.Query("api_key_id")); v != "" {
+		id, parseErr := strconv.ParseInt(v, 10, 64)
+		if parseErr != nil || id <= 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid api_key_id")
+			return
+		}
+		filter.APIKeyID = &id
+	}
+
+	result, err := h.auditService.ListAuditLogs(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrAuditQueryUnavailable) {
+			response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Audit log query not available")
+			return
+		}
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Paginated(c, result.Logs, int64(result.Total), result.Page, result.PageSize)
+}
+
+// Replay re-issues a captured request through the current gateway routing and returns
+// the new result next to the original. Replays run as sandbox requests and are never billed.
+// POST /api/v1/admin/audit/:id/replay
+func (h *AuditHandler) Replay(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid audit log ID")
+		return
+	}
+
+	result, err := h.auditService.ReplayAuditLog(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrAuditQueryUnavailable) {
+			response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Audit log query not available")
+			return
+		}
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
 type BackupHandler struct {
@@ -33,7 +34,7 @@ func (h *BackupHandler) GetS3Config(c *gin.Context) {
 func (h *BackupHandler) UpdateS3Config(c *gin.Context) {
 	var req service.BackupS3Config
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	cfg, err := h.backupService.UpdateS3Config(c.Request.Context(), req)
@@ -47,7 +48,7 @@ func (h *BackupHandler) UpdateS3Config(c *gin.Context) {
 func (h *BackupHandler) TestS3Connection(c *gin.Context) {
 	var req service.BackupS3Config
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	err := h.backupService.TestS3Connection(c.Request.Context(), req)
@@ -72,7 +73,7 @@ func (h *BackupHandler) GetSchedule(c *gin.Context) {
 func (h *BackupHandler) UpdateSchedule(c *gin.Context) {
 	var req service.BackupScheduleConfig
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	cfg, err := h.backupService.UpdateSchedule(c.Request.Context(), req)
@@ -121,7 +122,7 @@ func (h *BackupHandler) ListBackups(c *gin.Context) {
 func (h *BackupHandler) GetBackup(c *gin.Context) {
 	backupID := c.Param("id")
 	if backupID == "" {
-		response.BadRequest(c, "backup ID is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "backup ID is required")
 		return
 	}
 	record, err := h.backupService.GetBackupRecord(c.Request.Context(), backupID)
@@ -135,7 +136,7 @@ func (h *BackupHandler) GetBackup(c *gin.Context) {
 func (h *BackupHandler) DeleteBackup(c *gin.Context) {
 	backupID := c.Param("id")
 	if backupID == "" {
-		response.BadRequest(c, "backup ID is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "backup ID is required")
 		return
 	}
 	if err := h.backupService.DeleteBackup(c.Request.Context(), backupID); err != nil {
@@ -148,7 +149,7 @@ func (h *BackupHandler) DeleteBackup(c *gin.Context) {
 func (h *BackupHandler) GetDownloadURL(c *gin.Context) {
 	backupID := c.Param("id")
 	if backupID == "" {
-		response.BadRequest(c, "backup ID is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "backup ID is required")
 		return
 	}
 	url, err := h.backupService.GetBackupDownloadURL(c.Request.Context(), backupID)
@@ -168,20 +169,20 @@ type RestoreBackupRequest struct {
 func (h *BackupHandler) RestoreBackup(c *gin.Context) {
 	backupID := c.Param("id")
 	if backupID == "" {
-		response.BadRequest(c, "backup ID is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "backup ID is required")
 		return
 	}
 
 	var req RestoreBackupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "password is required for restore operation")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "password is required for restore operation")
 		return
 	}
 
 	// 从上下文获取当前管理员用户 ID
 	sub, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok {
-		response.Unauthorized(c, "unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "unauthorized")
 		return
 	}
 
@@ -192,7 +193,7 @@ func (h *BackupHandler) RestoreBackup(c *gin.Context) {
 		return
 	}
 	if !user.CheckPassword(req.Password) {
-		response.BadRequest(c, "incorrect admin password")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "incorrect admin password")
 		return
 	}
 
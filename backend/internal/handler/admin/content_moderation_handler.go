@@ -2,6 +2,7 @@ package admin
 
 import (
 	"encoding/json"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -101,7 +102,7 @@ func (h *ContentModerationHandler) GetConfig(c *gin.Context) {
 func (h *ContentModerationHandler) UpdateConfig(c *gin.Context) {
 	var req contentModerationConfigRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	cfg, err := h.service.UpdateConfig(c.Request.Context(), service.UpdateContentModerationConfigInput{
@@ -149,7 +150,7 @@ func (h *ContentModerationHandler) UpdateConfig(c *gin.Context) {
 func (h *ContentModerationHandler) TestAPIKeys(c *gin.Context) {
 	var req contentModerationAPIKeyTestRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	result, err := h.service.TestAPIKeys(c.Request.Context(), service.TestContentModerationAPIKeysInput{
@@ -193,7 +194,7 @@ func (h *ContentModerationHandler) ListLogs(c *gin.Context) {
 	if raw := strings.TrimSpace(c.Query("group_id")); raw != "" {
 		groupID, err := strconv.ParseInt(raw, 10, 64)
 		if err != nil || groupID <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &groupID
@@ -201,7 +202,7 @@ func (h *ContentModerationHandler) ListLogs(c *gin.Context) {
 	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
 		t, _, err := parseContentModerationDate(raw)
 		if err != nil {
-			response.BadRequest(c, "Invalid from")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid from")
 			return
 		}
 		filter.From = &t
@@ -209,7 +210,7 @@ func (h *ContentModerationHandler) ListLogs(c *gin.Context) {
 	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
 		t, dateOnly, err := parseContentModerationDate(raw)
 		if err != nil {
-			response.BadRequest(c, "Invalid to")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid to")
 			return
 		}
 		if dateOnly {
@@ -228,7 +229,7 @@ func (h *ContentModerationHandler) ListLogs(c *gin.Context) {
 func (h *ContentModerationHandler) UnbanUser(c *gin.Context) {
 	userID, err := strconv.ParseInt(strings.TrimSpace(c.Param("user_id")), 10, 64)
 	if err != nil || userID <= 0 {
-		response.BadRequest(c, "Invalid user_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 		return
 	}
 	result, err := h.service.UnbanUser(c.Request.Context(), userID)
@@ -242,7 +243,7 @@ func (h *ContentModerationHandler) UnbanUser(c *gin.Context) {
 func (h *ContentModerationHandler) DeleteFlaggedHash(c *gin.Context) {
 	var req contentModerationHashRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	result, err := h.service.DeleteFlaggedInputHash(c.Request.Context(), req.InputHash)
@@ -3,6 +3,7 @@ package admin
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -69,7 +70,7 @@ func parseTimeRange(c *gin.Context) (time.Time, time.Time) {
 func (h *DashboardHandler) GetStats(c *gin.Context) {
 	stats, err := h.dashboardService.GetDashboardStats(c.Request.Context())
 	if err != nil {
-		response.Error(c, 500, "Failed to get dashboard statistics")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get dashboard statistics")
 		return
 	}
 
@@ -137,36 +138,36 @@ type DashboardAggregationBackfillRequest struct {
 // POST /api/v1/admin/dashboard/aggregation/backfill
 func (h *DashboardHandler) BackfillAggregation(c *gin.Context) {
 	if h.aggregationService == nil {
-		response.InternalError(c, "Aggregation service not available")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Aggregation service not available")
 		return
 	}
 
 	var req DashboardAggregationBackfillRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 	start, err := time.Parse(time.RFC3339, req.Start)
 	if err != nil {
-		response.BadRequest(c, "Invalid start time")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid start time")
 		return
 	}
 	end, err := time.Parse(time.RFC3339, req.End)
 	if err != nil {
-		response.BadRequest(c, "Invalid end time")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid end time")
 		return
 	}
 
 	if err := h.aggregationService.TriggerBackfill(start, end); err != nil {
 		if errors.Is(err, service.ErrDashboardBackfillDisabled) {
-			response.Forbidden(c, "Backfill is disabled")
+			response.ErrorWithCode(c, http.StatusForbidden, response.CodeForbidden, "Backfill is disabled")
 			return
 		}
 		if errors.Is(err, service.ErrDashboardBackfillTooLarge) {
-			response.BadRequest(c, "Backfill range too large")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Backfill range too large")
 			return
 		}
-		response.InternalError(c, "Failed to trigger backfill")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to trigger backfill")
 		return
 	}
 
@@ -227,7 +228,7 @@ func (h *DashboardHandler) GetUsageTrend(c *gin.Context) {
 	if requestTypeStr := strings.TrimSpace(c.Query("request_type")); requestTypeStr != "" {
 		parsed, err := service.ParseUsageRequestType(requestTypeStr)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -236,7 +237,7 @@ func (h *DashboardHandler) GetUsageTrend(c *gin.Context) {
 		if streamVal, err := strconv.ParseBool(streamStr); err == nil {
 			stream = &streamVal
 		} else {
-			response.BadRequest(c, "Invalid stream value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stream value, use true or false")
 			return
 		}
 	}
@@ -245,14 +246,14 @@ func (h *DashboardHandler) GetUsageTrend(c *gin.Context) {
 			bt := int8(v)
 			billingType = &bt
 		} else {
-			response.BadRequest(c, "Invalid billing_type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid billing_type")
 			return
 		}
 	}
 
 	trend, hit, err := h.getUsageTrendCached(c.Request.Context(), startTime, endTime, granularity, userID, apiKeyID, accountID, groupID, model, requestType, stream, billingType)
 	if err != nil {
-		response.Error(c, 500, "Failed to get usage trend")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get usage trend")
 		return
 	}
 	c.Header("X-Snapshot-Cache", cacheStatusValue(hit))
@@ -300,7 +301,7 @@ func (h *DashboardHandler) GetModelStats(c *gin.Context) {
 	}
 	if rawModelSource := strings.TrimSpace(c.Query("model_source")); rawModelSource != "" {
 		if !usagestats.IsValidModelSource(rawModelSource) {
-			response.BadRequest(c, "Invalid model_source, use requested/upstream/mapping")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid model_source, use requested/upstream/mapping")
 			return
 		}
 		modelSource = rawModelSource
@@ -308,7 +309,7 @@ func (h *DashboardHandler) GetModelStats(c *gin.Context) {
 	if requestTypeStr := strings.TrimSpace(c.Query("request_type")); requestTypeStr != "" {
 		parsed, err := service.ParseUsageRequestType(requestTypeStr)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -317,7 +318,7 @@ func (h *DashboardHandler) GetModelStats(c *gin.Context) {
 		if streamVal, err := strconv.ParseBool(streamStr); err == nil {
 			stream = &streamVal
 		} else {
-			response.BadRequest(c, "Invalid stream value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stream value, use true or false")
 			return
 		}
 	}
@@ -326,14 +327,14 @@ func (h *DashboardHandler) GetModelStats(c *gin.Context) {
 			bt := int8(v)
 			billingType = &bt
 		} else {
-			response.BadRequest(c, "Invalid billing_type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid billing_type")
 			return
 		}
 	}
 
 	stats, hit, err := h.getModelStatsCached(c.Request.Context(), startTime, endTime, userID, apiKeyID, accountID, groupID, modelSource, requestType, stream, billingType)
 	if err != nil {
-		response.Error(c, 500, "Failed to get model statistics")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get model statistics")
 		return
 	}
 	c.Header("X-Snapshot-Cache", cacheStatusValue(hit))
@@ -379,7 +380,7 @@ func (h *DashboardHandler) GetGroupStats(c *gin.Context) {
 	if requestTypeStr := strings.TrimSpace(c.Query("request_type")); requestTypeStr != "" {
 		parsed, err := service.ParseUsageRequestType(requestTypeStr)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -388,7 +389,7 @@ func (h *DashboardHandler) GetGroupStats(c *gin.Context) {
 		if streamVal, err := strconv.ParseBool(streamStr); err == nil {
 			stream = &streamVal
 		} else {
-			response.BadRequest(c, "Invalid stream value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stream value, use true or false")
 			return
 		}
 	}
@@ -397,14 +398,14 @@ func (h *DashboardHandler) GetGroupStats(c *gin.Context) {
 			bt := int8(v)
 			billingType = &bt
 		} else {
-			response.BadRequest(c, "Invalid billing_type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid billing_type")
 			return
 		}
 	}
 
 	stats, hit, err := h.getGroupStatsCached(c.Request.Context(), startTime, endTime, userID, apiKeyID, accountID, groupID, requestType, stream, billingType)
 	if err != nil {
-		response.Error(c, 500, "Failed to get group statistics")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get group statistics")
 		return
 	}
 	c.Header("X-Snapshot-Cache", cacheStatusValue(hit))
@@ -430,7 +431,7 @@ func (h *DashboardHandler) GetAPIKeyUsageTrend(c *gin.Context) {
 
 	trend, hit, err := h.getAPIKeyUsageTrendCached(c.Request.Context(), startTime, endTime, granularity, limit)
 	if err != nil {
-		response.Error(c, 500, "Failed to get API key usage trend")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get API key usage trend")
 		return
 	}
 	c.Header("X-Snapshot-Cache", cacheStatusValue(hit))
@@ -457,7 +458,7 @@ func (h *DashboardHandler) GetUserUsageTrend(c *gin.Context) {
 
 	trend, hit, err := h.getUserUsageTrendCached(c.Request.Context(), startTime, endTime, granularity, limit)
 	if err != nil {
-		response.Error(c, 500, "Failed to get user usage trend")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get user usage trend")
 		return
 	}
 	c.Header("X-Snapshot-Cache", cacheStatusValue(hit))
@@ -514,7 +515,7 @@ func (h *DashboardHandler) GetUserSpendingRanking(c *gin.Context) {
 
 	ranking, err := h.dashboardService.GetUserSpendingRanking(c.Request.Context(), startTime, endTime, limit)
 	if err != nil {
-		response.Error(c, 500, "Failed to get user spending ranking")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get user spending ranking")
 		return
 	}
 
@@ -536,7 +537,7 @@ func (h *DashboardHandler) GetUserSpendingRanking(c *gin.Context) {
 func (h *DashboardHandler) GetBatchUsersUsage(c *gin.Context) {
 	var req BatchUsersUsageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -560,7 +561,7 @@ func (h *DashboardHandler) GetBatchUsersUsage(c *gin.Context) {
 
 	stats, err := h.dashboardService.GetBatchUserUsageStats(c.Request.Context(), userIDs, time.Time{}, time.Time{})
 	if err != nil {
-		response.Error(c, 500, "Failed to get user usage stats")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get user usage stats")
 		return
 	}
 
@@ -580,7 +581,7 @@ type BatchAPIKeysUsageRequest struct {
 func (h *DashboardHandler) GetBatchAPIKeysUsage(c *gin.Context) {
 	var req BatchAPIKeysUsageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -604,7 +605,7 @@ func (h *DashboardHandler) GetBatchAPIKeysUsage(c *gin.Context) {
 
 	stats, err := h.dashboardService.GetBatchAPIKeyUsageStats(c.Request.Context(), apiKeyIDs, time.Time{}, time.Time{})
 	if err != nil {
-		response.Error(c, 500, "Failed to get API key usage stats")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get API key usage stats")
 		return
 	}
 
@@ -629,7 +630,7 @@ func (h *DashboardHandler) GetUserBreakdown(c *gin.Context) {
 	dim.Model = c.Query("model")
 	rawModelSource := strings.TrimSpace(c.DefaultQuery("model_source", usagestats.ModelSourceRequested))
 	if !usagestats.IsValidModelSource(rawModelSource) {
-		response.BadRequest(c, "Invalid model_source, use requested/upstream/mapping")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid model_source, use requested/upstream/mapping")
 		return
 	}
 	dim.ModelType = rawModelSource
@@ -681,7 +682,7 @@ func (h *DashboardHandler) GetUserBreakdown(c *gin.Context) {
 		c.Request.Context(), startTime, endTime, dim, limit,
 	)
 	if err != nil {
-		response.Error(c, 500, "Failed to get user breakdown stats")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get user breakdown stats")
 		return
 	}
 
@@ -88,7 +88,7 @@ func (h *DashboardHandler) GetSnapshotV2(c *gin.Context) {
 
 	filters, err := parseDashboardSnapshotV2Filters(c)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -129,7 +129,7 @@ func (h *DashboardHandler) GetSnapshotV2(c *gin.Context) {
 		)
 	})
 	if err != nil {
-		response.Error(c, 500, err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		return
 	}
 	if cached.ETag != "" {
@@ -137,7 +137,7 @@ func (h *DataManagementHandler) GetConfig(c *gin.Context) {
 func (h *DataManagementHandler) UpdateConfig(c *gin.Context) {
 	var req service.DataManagementConfig
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -155,7 +155,7 @@ func (h *DataManagementHandler) UpdateConfig(c *gin.Context) {
 func (h *DataManagementHandler) TestS3(c *gin.Context) {
 	var req TestS3ConnectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -183,7 +183,7 @@ func (h *DataManagementHandler) TestS3(c *gin.Context) {
 func (h *DataManagementHandler) CreateBackupJob(c *gin.Context) {
 	var req CreateBackupJobRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -215,11 +215,11 @@ func (h *DataManagementHandler) CreateBackupJob(c *gin.Context) {
 func (h *DataManagementHandler) ListSourceProfiles(c *gin.Context) {
 	sourceType := strings.TrimSpace(c.Param("source_type"))
 	if sourceType == "" {
-		response.BadRequest(c, "Invalid source_type")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid source_type")
 		return
 	}
 	if sourceType != "postgres" && sourceType != "redis" {
-		response.BadRequest(c, "source_type must be postgres or redis")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "source_type must be postgres or redis")
 		return
 	}
 
@@ -237,13 +237,13 @@ func (h *DataManagementHandler) ListSourceProfiles(c *gin.Context) {
 func (h *DataManagementHandler) CreateSourceProfile(c *gin.Context) {
 	sourceType := strings.TrimSpace(c.Param("source_type"))
 	if sourceType != "postgres" && sourceType != "redis" {
-		response.BadRequest(c, "source_type must be postgres or redis")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "source_type must be postgres or redis")
 		return
 	}
 
 	var req CreateSourceProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -267,18 +267,18 @@ func (h *DataManagementHandler) CreateSourceProfile(c *gin.Context) {
 func (h *DataManagementHandler) UpdateSourceProfile(c *gin.Context) {
 	sourceType := strings.TrimSpace(c.Param("source_type"))
 	if sourceType != "postgres" && sourceType != "redis" {
-		response.BadRequest(c, "source_type must be postgres or redis")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "source_type must be postgres or redis")
 		return
 	}
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
 	var req UpdateSourceProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -301,12 +301,12 @@ func (h *DataManagementHandler) UpdateSourceProfile(c *gin.Context) {
 func (h *DataManagementHandler) DeleteSourceProfile(c *gin.Context) {
 	sourceType := strings.TrimSpace(c.Param("source_type"))
 	if sourceType != "postgres" && sourceType != "redis" {
-		response.BadRequest(c, "source_type must be postgres or redis")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "source_type must be postgres or redis")
 		return
 	}
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
@@ -323,12 +323,12 @@ func (h *DataManagementHandler) DeleteSourceProfile(c *gin.Context) {
 func (h *DataManagementHandler) SetActiveSourceProfile(c *gin.Context) {
 	sourceType := strings.TrimSpace(c.Param("source_type"))
 	if sourceType != "postgres" && sourceType != "redis" {
-		response.BadRequest(c, "source_type must be postgres or redis")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "source_type must be postgres or redis")
 		return
 	}
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
@@ -359,7 +359,7 @@ func (h *DataManagementHandler) ListS3Profiles(c *gin.Context) {
 func (h *DataManagementHandler) CreateS3Profile(c *gin.Context) {
 	var req CreateS3ProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -393,13 +393,13 @@ func (h *DataManagementHandler) CreateS3Profile(c *gin.Context) {
 func (h *DataManagementHandler) UpdateS3Profile(c *gin.Context) {
 	var req UpdateS3ProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
@@ -432,7 +432,7 @@ func (h *DataManagementHandler) UpdateS3Profile(c *gin.Context) {
 func (h *DataManagementHandler) DeleteS3Profile(c *gin.Context) {
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
@@ -449,7 +449,7 @@ func (h *DataManagementHandler) DeleteS3Profile(c *gin.Context) {
 func (h *DataManagementHandler) SetActiveS3Profile(c *gin.Context) {
 	profileID := strings.TrimSpace(c.Param("profile_id"))
 	if profileID == "" {
-		response.BadRequest(c, "Invalid profile_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile_id")
 		return
 	}
 
@@ -473,7 +473,7 @@ func (h *DataManagementHandler) ListBackupJobs(c *gin.Context) {
 	if raw := strings.TrimSpace(c.Query("page_size")); raw != "" {
 		v, err := strconv.Atoi(raw)
 		if err != nil || v <= 0 {
-			response.BadRequest(c, "Invalid page_size")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid page_size")
 			return
 		}
 		pageSize = int32(v)
@@ -495,7 +495,7 @@ func (h *DataManagementHandler) ListBackupJobs(c *gin.Context) {
 func (h *DataManagementHandler) GetBackupJob(c *gin.Context) {
 	jobID := strings.TrimSpace(c.Param("job_id"))
 	if jobID == "" {
-		response.BadRequest(c, "Invalid backup job ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid backup job ID")
 		return
 	}
 
@@ -560,7 +560,7 @@ func (h *DataManagementHandler) GetPostgresInfo(c *gin.Context) {
 
 func (h *DataManagementHandler) ExportPostgres(c *gin.Context) {
 	if err := h.pgBackupService.CheckTools(); err != nil {
-		response.Error(c, http.StatusServiceUnavailable, "pg_dump is not available: "+err.Error())
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "pg_dump is not available: "+err.Error())
 		return
 	}
 
@@ -577,19 +577,19 @@ func (h *DataManagementHandler) ExportPostgres(c *gin.Context) {
 
 func (h *DataManagementHandler) RestorePostgres(c *gin.Context) {
 	if err := h.pgBackupService.CheckTools(); err != nil {
-		response.Error(c, http.StatusServiceUnavailable, "pg_restore is not available: "+err.Error())
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "pg_restore is not available: "+err.Error())
 		return
 	}
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		response.BadRequest(c, "Missing file: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Missing file: "+err.Error())
 		return
 	}
 	defer func() { _ = file.Close() }()
 
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".dump") {
-		response.BadRequest(c, "Only .dump files (pg_dump custom format) are accepted")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only .dump files (pg_dump custom format) are accepted")
 		return
 	}
 
@@ -597,12 +597,12 @@ func (h *DataManagementHandler) RestorePostgres(c *gin.Context) {
 	dbName := h.pgBackupService.GetInfo().DBName
 	expected := "RESTORE " + dbName
 	if confirm != expected {
-		response.BadRequest(c, "Confirmation text must be exactly: "+expected)
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Confirmation text must be exactly: "+expected)
 		return
 	}
 
 	if err := h.pgBackupService.Restore(c.Request.Context(), io.Reader(file)); err != nil {
-		response.InternalError(c, "Restore failed: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Restore failed: "+err.Error())
 		return
 	}
 
@@ -615,7 +615,7 @@ func (h *DataManagementHandler) RestorePostgres(c *gin.Context) {
 
 func (h *DataManagementHandler) InitRestoreUpload(c *gin.Context) {
 	if err := h.pgBackupService.CheckTools(); err != nil {
-		response.Error(c, http.StatusServiceUnavailable, "pg_restore is not available: "+err.Error())
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "pg_restore is not available: "+err.Error())
 		return
 	}
 
@@ -624,17 +624,17 @@ func (h *DataManagementHandler) InitRestoreUpload(c *gin.Context) {
 		TotalSize int64  `json:"total_size" binding:"required,gt=0"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if !strings.HasSuffix(strings.ToLower(req.Filename), ".dump") {
-		response.BadRequest(c, "Only .dump files (pg_dump custom format) are accepted")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only .dump files (pg_dump custom format) are accepted")
 		return
 	}
 
 	uploadID, chunkCount, err := h.pgBackupService.InitUpload(req.Filename, req.TotalSize)
 	if err != nil {
-		response.InternalError(c, "Failed to init upload: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to init upload: "+err.Error())
 		return
 	}
 
@@ -648,7 +648,7 @@ func (h *DataManagementHandler) InitRestoreUpload(c *gin.Context) {
 func (h *DataManagementHandler) UploadRestoreChunk(c *gin.Context) {
 	uploadID := strings.TrimSpace(c.Param("upload_id"))
 	if uploadID == "" {
-		response.BadRequest(c, "Missing upload_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Missing upload_id")
 		return
 	}
 	indexStr := strings.TrimSpace(c.PostForm("index"))
@@ -657,20 +657,20 @@ func (h *DataManagementHandler) UploadRestoreChunk(c *gin.Context) {
 	}
 	index, err := strconv.Atoi(indexStr)
 	if err != nil {
-		response.BadRequest(c, "Invalid chunk index")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid chunk index")
 		return
 	}
 
 	file, _, err := c.Request.FormFile("chunk")
 	if err != nil {
-		response.BadRequest(c, "Missing chunk file: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Missing chunk file: "+err.Error())
 		return
 	}
 	defer func() { _ = file.Close() }()
 
 	allDone, err := h.pgBackupService.SaveChunk(uploadID, index, file)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -687,7 +687,7 @@ func (h *DataManagementHandler) UploadRestoreChunk(c *gin.Context) {
 func (h *DataManagementHandler) CompleteRestoreUpload(c *gin.Context) {
 	uploadID := strings.TrimSpace(c.Param("upload_id"))
 	if uploadID == "" {
-		response.BadRequest(c, "Missing upload_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Missing upload_id")
 		return
 	}
 
@@ -695,21 +695,21 @@ func (h *DataManagementHandler) CompleteRestoreUpload(c *gin.Context) {
 		Confirm string `json:"confirm" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	if err := h.pgBackupService.CompleteUpload(c.Request.Context(), uploadID, req.Confirm); err != nil {
 		errMsg := err.Error()
 		if strings.Contains(errMsg, "confirmation text") {
-			response.BadRequest(c, errMsg)
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, errMsg)
 			return
 		}
 		if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "incomplete") {
-			response.BadRequest(c, errMsg)
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, errMsg)
 			return
 		}
-		response.InternalError(c, "Restore failed: "+errMsg)
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Restore failed: "+errMsg)
 		return
 	}
 
@@ -723,7 +723,7 @@ func (h *DataManagementHandler) CompleteRestoreUpload(c *gin.Context) {
 func (h *DataManagementHandler) AbortRestoreUpload(c *gin.Context) {
 	uploadID := strings.TrimSpace(c.Param("upload_id"))
 	if uploadID == "" {
-		response.BadRequest(c, "Missing upload_id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Missing upload_id")
 		return
 	}
 	h.pgBackupService.AbortUpload(uploadID)
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/Wei-Shaw/sub2api/internal/model"
@@ -69,7 +70,7 @@ func (h *ErrorPassthroughHandler) List(c *gin.Context) {
 func (h *ErrorPassthroughHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid rule ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid rule ID")
 		return
 	}
 
@@ -79,7 +80,7 @@ func (h *ErrorPassthroughHandler) GetByID(c *gin.Context) {
 		return
 	}
 	if rule == nil {
-		response.NotFound(c, "Rule not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Rule not found")
 		return
 	}
 
@@ -91,7 +92,7 @@ func (h *ErrorPassthroughHandler) GetByID(c *gin.Context) {
 func (h *ErrorPassthroughHandler) Create(c *gin.Context) {
 	var req CreateErrorPassthroughRuleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -145,7 +146,7 @@ func (h *ErrorPassthroughHandler) Create(c *gin.Context) {
 	created, err := h.service.Create(c.Request.Context(), rule)
 	if err != nil {
 		if _, ok := err.(*model.ValidationError); ok {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 			return
 		}
 		response.ErrorFrom(c, err)
@@ -160,13 +161,13 @@ func (h *ErrorPassthroughHandler) Create(c *gin.Context) {
 func (h *ErrorPassthroughHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid rule ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid rule ID")
 		return
 	}
 
 	var req UpdateErrorPassthroughRuleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -177,7 +178,7 @@ func (h *ErrorPassthroughHandler) Update(c *gin.Context) {
 		return
 	}
 	if existing == nil {
-		response.NotFound(c, "Rule not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Rule not found")
 		return
 	}
 
@@ -254,7 +255,7 @@ func (h *ErrorPassthroughHandler) Update(c *gin.Context) {
 	updated, err := h.service.Update(c.Request.Context(), rule)
 	if err != nil {
 		if _, ok := err.(*model.ValidationError); ok {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 			return
 		}
 		response.ErrorFrom(c, err)
@@ -269,7 +270,7 @@ func (h *ErrorPassthroughHandler) Update(c *gin.Context) {
 func (h *ErrorPassthroughHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid rule ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid rule ID")
 		return
 	}
 
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayLoadHandler exposes gateway load hints for external autoscalers
+type GatewayLoadHandler struct {
+	loadService *service.GatewayLoadService
+}
+
+// NewGatewayLoadHandler creates a new admin gateway load handler
+func NewGatewayLoadHandler(loadService *service.GatewayLoadService) *GatewayLoadHandler {
+	return &GatewayLoadHandler{loadService: loadService}
+}
+
+// Status returns in-flight requests, queue depth, utilization and per-model saturation
+// GET /api/v1/admin/gateway-load
+func (h *GatewayLoadHandler) Status(c *gin.Context) {
+	response.Success(c, h.loadService.Snapshot(c.Request.Context()))
+}
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
@@ -40,7 +41,7 @@ type GeminiGenerateAuthURLRequest struct {
 func (h *GeminiOAuthHandler) GenerateAuthURL(c *gin.Context) {
 	var req GeminiGenerateAuthURLRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -50,7 +51,7 @@ func (h *GeminiOAuthHandler) GenerateAuthURL(c *gin.Context) {
 		oauthType = "code_assist"
 	}
 	if oauthType != "code_assist" && oauthType != "google_one" && oauthType != "ai_studio" {
-		response.BadRequest(c, "Invalid oauth_type: must be 'code_assist', 'google_one', or 'ai_studio'")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid oauth_type: must be 'code_assist', 'google_one', or 'ai_studio'")
 		return
 	}
 
@@ -66,10 +67,10 @@ func (h *GeminiOAuthHandler) GenerateAuthURL(c *gin.Context) {
 			strings.Contains(msg, "requires a custom OAuth Client") ||
 			strings.Contains(msg, "GEMINI_CLI_OAUTH_CLIENT_SECRET_MISSING") ||
 			strings.Contains(msg, "built-in Gemini CLI OAuth client_secret is not configured") {
-			response.BadRequest(c, "Failed to generate auth URL: "+msg)
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Failed to generate auth URL: "+msg)
 			return
 		}
-		response.InternalError(c, "Failed to generate auth URL: "+msg)
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to generate auth URL: "+msg)
 		return
 	}
 
@@ -93,7 +94,7 @@ type GeminiExchangeCodeRequest struct {
 func (h *GeminiOAuthHandler) ExchangeCode(c *gin.Context) {
 	var req GeminiExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -103,7 +104,7 @@ func (h *GeminiOAuthHandler) ExchangeCode(c *gin.Context) {
 		oauthType = "code_assist"
 	}
 	if oauthType != "code_assist" && oauthType != "google_one" && oauthType != "ai_studio" {
-		response.BadRequest(c, "Invalid oauth_type: must be 'code_assist', 'google_one', or 'ai_studio'")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid oauth_type: must be 'code_assist', 'google_one', or 'ai_studio'")
 		return
 	}
 
@@ -116,7 +117,7 @@ func (h *GeminiOAuthHandler) ExchangeCode(c *gin.Context) {
 		TierID:    req.TierID,
 	})
 	if err != nil {
-		response.BadRequest(c, "Failed to exchange code: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Failed to exchange code: "+err.Error())
 		return
 	}
 
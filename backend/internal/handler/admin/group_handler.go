@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -116,6 +117,8 @@ type CreateGroupRequest struct {
 	MessagesDispatchModelConfig service.OpenAIMessagesDispatchModelConfig `json:"messages_dispatch_model_config"`
 	// 分组 RPM 上限（0 = 不限制）
 	RPMLimit int `json:"rpm_limit"`
+	// 账号调度策略：load_balance（默认）/ cheapest
+	SchedulingStrategy string `json:"scheduling_strategy" binding:"omitempty,oneof=load_balance cheapest latency"`
 	// 从指定分组复制账号（创建后自动绑定）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -157,6 +160,8 @@ type UpdateGroupRequest struct {
 	MessagesDispatchModelConfig *service.OpenAIMessagesDispatchModelConfig `json:"messages_dispatch_model_config"`
 	// 分组 RPM 上限（0 = 不限制）；nil 表示未提供不改动
 	RPMLimit *int `json:"rpm_limit"`
+	// 账号调度策略：load_balance / cheapest；nil 表示未提供不改动
+	SchedulingStrategy *string `json:"scheduling_strategy" binding:"omitempty,oneof=load_balance cheapest latency"`
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -227,7 +232,7 @@ func (h *GroupHandler) GetAll(c *gin.Context) {
 func (h *GroupHandler) GetByID(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -245,7 +250,7 @@ func (h *GroupHandler) GetByID(c *gin.Context) {
 func (h *GroupHandler) Create(c *gin.Context) {
 	var req CreateGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -279,6 +284,7 @@ func (h *GroupHandler) Create(c *gin.Context) {
 		OpenAIForceCodex:                req.OpenAIForceCodex,
 		MessagesDispatchModelConfig:     req.MessagesDispatchModelConfig,
 		RPMLimit:                        req.RPMLimit,
+		SchedulingStrategy:              req.SchedulingStrategy,
 		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
@@ -294,13 +300,13 @@ func (h *GroupHandler) Create(c *gin.Context) {
 func (h *GroupHandler) Update(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
 	var req UpdateGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -335,6 +341,7 @@ func (h *GroupHandler) Update(c *gin.Context) {
 		OpenAIForceCodex:                req.OpenAIForceCodex,
 		MessagesDispatchModelConfig:     req.MessagesDispatchModelConfig,
 		RPMLimit:                        req.RPMLimit,
+		SchedulingStrategy:              req.SchedulingStrategy,
 		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
@@ -350,7 +357,7 @@ func (h *GroupHandler) Update(c *gin.Context) {
 func (h *GroupHandler) Delete(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -368,7 +375,7 @@ func (h *GroupHandler) Delete(c *gin.Context) {
 func (h *GroupHandler) GetStats(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -391,7 +398,7 @@ func (h *GroupHandler) GetUsageSummary(c *gin.Context) {
 
 	results, err := h.dashboardService.GetGroupUsageSummary(c.Request.Context(), todayStart)
 	if err != nil {
-		response.Error(c, 500, "Failed to get group usage summary")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get group usage summary")
 		return
 	}
 
@@ -403,7 +410,7 @@ func (h *GroupHandler) GetUsageSummary(c *gin.Context) {
 func (h *GroupHandler) GetCapacitySummary(c *gin.Context) {
 	results, err := h.groupCapacityService.GetAllGroupCapacity(c.Request.Context())
 	if err != nil {
-		response.Error(c, 500, "Failed to get group capacity summary")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get group capacity summary")
 		return
 	}
 	response.Success(c, results)
@@ -414,7 +421,7 @@ func (h *GroupHandler) GetCapacitySummary(c *gin.Context) {
 func (h *GroupHandler) GetGroupAPIKeys(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -438,7 +445,7 @@ func (h *GroupHandler) GetGroupAPIKeys(c *gin.Context) {
 func (h *GroupHandler) GetGroupRateMultipliers(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -459,7 +466,7 @@ func (h *GroupHandler) GetGroupRateMultipliers(c *gin.Context) {
 func (h *GroupHandler) ClearGroupRateMultipliers(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -481,13 +488,13 @@ type BatchSetGroupRateMultipliersRequest struct {
 func (h *GroupHandler) BatchSetGroupRateMultipliers(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
 	var req BatchSetGroupRateMultipliersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -509,13 +516,13 @@ type BatchSetGroupRPMOverridesRequest struct {
 func (h *GroupHandler) BatchSetGroupRPMOverrides(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
 	var req BatchSetGroupRPMOverridesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -532,7 +539,7 @@ func (h *GroupHandler) BatchSetGroupRPMOverrides(c *gin.Context) {
 func (h *GroupHandler) ClearGroupRPMOverrides(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid group ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group ID")
 		return
 	}
 
@@ -557,7 +564,7 @@ type UpdateSortOrderRequest struct {
 func (h *GroupHandler) UpdateSortOrder(c *gin.Context) {
 	var req UpdateSortOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler handles the gateway maintenance mode toggle
+type MaintenanceHandler struct {
+	maintenanceService *service.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new admin maintenance handler
+func NewMaintenanceHandler(maintenanceService *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenanceService: maintenanceService}
+}
+
+// UpdateMaintenanceRequest 维护模式切换请求
+type UpdateMaintenanceRequest struct {
+	Enabled           bool    `json:"enabled"`
+	Message           string  `json:"message"`
+	RetryAfterSeconds int     `json:"retry_after_seconds"`
+	BypassAPIKeyIDs   []int64 `json:"bypass_api_key_ids"`
+	Persist           bool    `json:"persist"`
+}
+
+// Get returns the current maintenance mode state
+// GET /api/v1/admin/maintenance
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	response.Success(c, h.maintenanceService.State())
+}
+
+// Update toggles maintenance mode
+// PUT /api/v1/admin/maintenance
+func (h *MaintenanceHandler) Update(c *gin.Context) {
+	var req UpdateMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	state, err := h.maintenanceService.Update(c.Request.Context(), service.MaintenanceState{
+		Enabled:           req.Enabled,
+		Message:           req.Message,
+		RetryAfterSeconds: req.RetryAfterSeconds,
+		BypassAPIKeyIDs:   req.BypassAPIKeyIDs,
+		Persist:           req.Persist,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, state)
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModelAccountsHandler handles model-to-account lookups
+type ModelAccountsHandler struct {
+	modelAccountsService *service.ModelAccountsService
+}
+
+// NewModelAccountsHandler creates a new admin model accounts handler
+func NewModelAccountsHandler(modelAccountsService *service.ModelAccountsService) *ModelAccountsHandler {
+	return &ModelAccountsHandler{modelAccountsService: modelAccountsService}
+}
+
+// ListAccounts 列出能服务指定模型的全部账号及其健康、权重、实时并发与不可调度原因。
+// 模型所属厂商或账号平台被禁用时 provider_disabled 为 true，账号计为不可用。
+// GET /api/v1/admin/models/:model/accounts
+func (h *ModelAccountsHandler) ListAccounts(c *gin.Context) {
+	model := strings.TrimSpace(c.Param("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model is required")
+		return
+	}
+
+	report, err := h.modelAccountsService.ListAccountsForModel(c.Request.Context(), model)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, report)
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -73,7 +74,7 @@ type OpenAIExchangeCodeRequest struct {
 func (h *OpenAIOAuthHandler) ExchangeCode(c *gin.Context) {
 	var req OpenAIExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -105,7 +106,7 @@ type OpenAIRefreshTokenRequest struct {
 func (h *OpenAIOAuthHandler) RefreshToken(c *gin.Context) {
 	var req OpenAIRefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	refreshToken := strings.TrimSpace(req.RefreshToken)
@@ -113,7 +114,7 @@ func (h *OpenAIOAuthHandler) RefreshToken(c *gin.Context) {
 		refreshToken = strings.TrimSpace(req.RT)
 	}
 	if refreshToken == "" {
-		response.BadRequest(c, "refresh_token is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "refresh_token is required")
 		return
 	}
 
@@ -146,7 +147,7 @@ func (h *OpenAIOAuthHandler) RefreshToken(c *gin.Context) {
 func (h *OpenAIOAuthHandler) RefreshAccountToken(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid account ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account ID")
 		return
 	}
 
@@ -159,13 +160,13 @@ func (h *OpenAIOAuthHandler) RefreshAccountToken(c *gin.Context) {
 
 	platform := oauthPlatformFromPath(c)
 	if account.Platform != platform {
-		response.BadRequest(c, "Account platform does not match OAuth endpoint")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Account platform does not match OAuth endpoint")
 		return
 	}
 
 	// Only refresh OAuth-based accounts
 	if !account.IsOAuth() {
-		response.BadRequest(c, "Cannot refresh non-OAuth account credentials")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Cannot refresh non-OAuth account credentials")
 		return
 	}
 
@@ -212,7 +213,7 @@ func (h *OpenAIOAuthHandler) CreateAccountFromOAuth(c *gin.Context) {
 		GroupIDs    []int64 `json:"group_ids"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
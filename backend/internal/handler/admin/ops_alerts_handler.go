@@ -238,7 +238,7 @@ func validateOpsAlertRulePayload(raw map[string]json.RawMessage) (*opsAlertRuleV
 // GET /api/v1/admin/ops/alert-rules
 func (h *OpsHandler) ListAlertRules(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -258,7 +258,7 @@ func (h *OpsHandler) ListAlertRules(c *gin.Context) {
 // POST /api/v1/admin/ops/alert-rules
 func (h *OpsHandler) CreateAlertRule(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -268,18 +268,18 @@ func (h *OpsHandler) CreateAlertRule(c *gin.Context) {
 
 	var raw map[string]json.RawMessage
 	if err := c.ShouldBindBodyWith(&raw, binding.JSON); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 	validated, err := validateOpsAlertRulePayload(raw)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
 	var rule service.OpsAlertRule
 	if err := c.ShouldBindBodyWith(&rule, binding.JSON); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
@@ -306,7 +306,7 @@ func (h *OpsHandler) CreateAlertRule(c *gin.Context) {
 // PUT /api/v1/admin/ops/alert-rules/:id
 func (h *OpsHandler) UpdateAlertRule(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -316,24 +316,24 @@ func (h *OpsHandler) UpdateAlertRule(c *gin.Context) {
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid rule ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid rule ID")
 		return
 	}
 
 	var raw map[string]json.RawMessage
 	if err := c.ShouldBindBodyWith(&raw, binding.JSON); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 	validated, err := validateOpsAlertRulePayload(raw)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
 	var rule service.OpsAlertRule
 	if err := c.ShouldBindBodyWith(&rule, binding.JSON); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
@@ -361,7 +361,7 @@ func (h *OpsHandler) UpdateAlertRule(c *gin.Context) {
 // DELETE /api/v1/admin/ops/alert-rules/:id
 func (h *OpsHandler) DeleteAlertRule(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -371,7 +371,7 @@ func (h *OpsHandler) DeleteAlertRule(c *gin.Context) {
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid rule ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid rule ID")
 		return
 	}
 
@@ -386,7 +386,7 @@ func (h *OpsHandler) DeleteAlertRule(c *gin.Context) {
 // GET /api/v1/admin/ops/alert-events/:id
 func (h *OpsHandler) GetAlertEvent(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -396,7 +396,7 @@ func (h *OpsHandler) GetAlertEvent(c *gin.Context) {
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid event ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid event ID")
 		return
 	}
 
@@ -412,7 +412,7 @@ func (h *OpsHandler) GetAlertEvent(c *gin.Context) {
 // PUT /api/v1/admin/ops/alert-events/:id/status
 func (h *OpsHandler) UpdateAlertEventStatus(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -422,7 +422,7 @@ func (h *OpsHandler) UpdateAlertEventStatus(c *gin.Context) {
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid event ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid event ID")
 		return
 	}
 
@@ -430,16 +430,16 @@ func (h *OpsHandler) UpdateAlertEventStatus(c *gin.Context) {
 		Status string `json:"status"`
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 	payload.Status = strings.TrimSpace(payload.Status)
 	if payload.Status == "" {
-		response.BadRequest(c, "Invalid status")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid status")
 		return
 	}
 	if payload.Status != service.OpsAlertStatusResolved && payload.Status != service.OpsAlertStatusManualResolved {
-		response.BadRequest(c, "Invalid status")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid status")
 		return
 	}
 
@@ -461,7 +461,7 @@ func (h *OpsHandler) UpdateAlertEventStatus(c *gin.Context) {
 // POST /api/v1/admin/ops/alert-silences
 func (h *OpsHandler) CreateAlertSilence(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -478,12 +478,12 @@ func (h *OpsHandler) CreateAlertSilence(c *gin.Context) {
 		Reason   string  `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 	until, err := time.Parse(time.RFC3339, strings.TrimSpace(payload.Until))
 	if err != nil {
-		response.BadRequest(c, "Invalid until")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid until")
 		return
 	}
 
@@ -513,7 +513,7 @@ func (h *OpsHandler) CreateAlertSilence(c *gin.Context) {
 
 func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -525,7 +525,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
 		n, err := strconv.Atoi(raw)
 		if err != nil || n <= 0 {
-			response.BadRequest(c, "Invalid limit")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid limit")
 			return
 		}
 		limit = n
@@ -547,7 +547,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 			b := false
 			filter.EmailSent = &b
 		default:
-			response.BadRequest(c, "Invalid email_sent")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid email_sent")
 			return
 		}
 	}
@@ -556,7 +556,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	rawTS := strings.TrimSpace(c.Query("before_fired_at"))
 	rawID := strings.TrimSpace(c.Query("before_id"))
 	if (rawTS == "") != (rawID == "") {
-		response.BadRequest(c, "before_fired_at and before_id must be provided together")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "before_fired_at and before_id must be provided together")
 		return
 	}
 	if rawTS != "" {
@@ -565,7 +565,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 			if t2, err2 := time.Parse(time.RFC3339, rawTS); err2 == nil {
 				ts = t2
 			} else {
-				response.BadRequest(c, "Invalid before_fired_at")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid before_fired_at")
 				return
 			}
 		}
@@ -574,7 +574,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	if rawID != "" {
 		id, err := strconv.ParseInt(rawID, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid before_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid before_id")
 			return
 		}
 		filter.BeforeID = &id
@@ -587,7 +587,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -599,7 +599,7 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 			filter.EndTime = &endTime
 		}
 	} else {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -16,7 +16,7 @@ import (
 // GET /api/v1/admin/ops/dashboard/overview
 func (h *OpsHandler) GetDashboardOverview(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -26,7 +26,7 @@ func (h *OpsHandler) GetDashboardOverview(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -39,7 +39,7 @@ func (h *OpsHandler) GetDashboardOverview(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -57,7 +57,7 @@ func (h *OpsHandler) GetDashboardOverview(c *gin.Context) {
 // GET /api/v1/admin/ops/dashboard/throughput-trend
 func (h *OpsHandler) GetDashboardThroughputTrend(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -67,7 +67,7 @@ func (h *OpsHandler) GetDashboardThroughputTrend(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -80,7 +80,7 @@ func (h *OpsHandler) GetDashboardThroughputTrend(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -99,7 +99,7 @@ func (h *OpsHandler) GetDashboardThroughputTrend(c *gin.Context) {
 // GET /api/v1/admin/ops/dashboard/latency-histogram
 func (h *OpsHandler) GetDashboardLatencyHistogram(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -109,7 +109,7 @@ func (h *OpsHandler) GetDashboardLatencyHistogram(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -122,7 +122,7 @@ func (h *OpsHandler) GetDashboardLatencyHistogram(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -140,7 +140,7 @@ func (h *OpsHandler) GetDashboardLatencyHistogram(c *gin.Context) {
 // GET /api/v1/admin/ops/dashboard/error-trend
 func (h *OpsHandler) GetDashboardErrorTrend(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -150,7 +150,7 @@ func (h *OpsHandler) GetDashboardErrorTrend(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -163,7 +163,7 @@ func (h *OpsHandler) GetDashboardErrorTrend(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -182,7 +182,7 @@ func (h *OpsHandler) GetDashboardErrorTrend(c *gin.Context) {
 // GET /api/v1/admin/ops/dashboard/error-distribution
 func (h *OpsHandler) GetDashboardErrorDistribution(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -192,7 +192,7 @@ func (h *OpsHandler) GetDashboardErrorDistribution(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -205,7 +205,7 @@ func (h *OpsHandler) GetDashboardErrorDistribution(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -223,7 +223,7 @@ func (h *OpsHandler) GetDashboardErrorDistribution(c *gin.Context) {
 // GET /api/v1/admin/ops/dashboard/openai-token-stats
 func (h *OpsHandler) GetDashboardOpenAITokenStats(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -233,7 +233,7 @@ func (h *OpsHandler) GetDashboardOpenAITokenStats(c *gin.Context) {
 
 	filter, err := parseOpsOpenAITokenStatsFilter(c)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -23,7 +23,7 @@ type OpsHandler struct {
 // GET /api/v1/admin/ops/errors/:id
 func (h *OpsHandler) GetErrorLogByID(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -34,7 +34,7 @@ func (h *OpsHandler) GetErrorLogByID(c *gin.Context) {
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
@@ -78,7 +78,7 @@ func NewOpsHandler(opsService *service.OpsService) *OpsHandler {
 // GET /api/v1/admin/ops/errors
 func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -94,7 +94,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -125,7 +125,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -133,7 +133,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		filter.AccountID = &id
@@ -148,7 +148,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 			b := false
 			filter.Resolved = &b
 		default:
-			response.BadRequest(c, "Invalid resolved")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid resolved")
 			return
 		}
 	}
@@ -162,7 +162,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 			}
 			n, err := strconv.Atoi(p)
 			if err != nil || n < 0 {
-				response.BadRequest(c, "Invalid status_codes")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid status_codes")
 				return
 			}
 			out = append(out, n)
@@ -182,7 +182,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 // GET /api/v1/admin/ops/request-errors
 func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -196,7 +196,7 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 	}
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -226,7 +226,7 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -234,7 +234,7 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		filter.AccountID = &id
@@ -249,7 +249,7 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 			b := false
 			filter.Resolved = &b
 		default:
-			response.BadRequest(c, "Invalid resolved")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid resolved")
 			return
 		}
 	}
@@ -263,7 +263,7 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 			}
 			n, err := strconv.Atoi(p)
 			if err != nil || n < 0 {
-				response.BadRequest(c, "Invalid status_codes")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid status_codes")
 				return
 			}
 			out = append(out, n)
@@ -290,7 +290,7 @@ func (h *OpsHandler) GetRequestError(c *gin.Context) {
 // GET /api/v1/admin/ops/request-errors/:id/upstream-errors
 func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -301,7 +301,7 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
@@ -329,7 +329,7 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 	// are discoverable even when UI defaults to 1h elsewhere.
 	startTime, endTime, err := parseOpsTimeRange(c, "30d")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -388,7 +388,7 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 // POST /api/v1/admin/ops/request-errors/:id/retry-client
 func (h *OpsHandler) RetryRequestErrorClient(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -398,14 +398,14 @@ func (h *OpsHandler) RetryRequestErrorClient(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
@@ -421,7 +421,7 @@ func (h *OpsHandler) RetryRequestErrorClient(c *gin.Context) {
 // POST /api/v1/admin/ops/request-errors/:id/upstream-errors/:idx/retry
 func (h *OpsHandler) RetryRequestErrorUpstreamEvent(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -431,21 +431,21 @@ func (h *OpsHandler) RetryRequestErrorUpstreamEvent(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
 	idxStr := strings.TrimSpace(c.Param("idx"))
 	idx, err := strconv.Atoi(idxStr)
 	if err != nil || idx < 0 {
-		response.BadRequest(c, "Invalid upstream idx")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid upstream idx")
 		return
 	}
 
@@ -467,7 +467,7 @@ func (h *OpsHandler) ResolveRequestError(c *gin.Context) {
 // GET /api/v1/admin/ops/upstream-errors
 func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -481,7 +481,7 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 	}
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -505,7 +505,7 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -513,7 +513,7 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		filter.AccountID = &id
@@ -528,7 +528,7 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 			b := false
 			filter.Resolved = &b
 		default:
-			response.BadRequest(c, "Invalid resolved")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid resolved")
 			return
 		}
 	}
@@ -542,7 +542,7 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 			}
 			n, err := strconv.Atoi(p)
 			if err != nil || n < 0 {
-				response.BadRequest(c, "Invalid status_codes")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid status_codes")
 				return
 			}
 			out = append(out, n)
@@ -568,7 +568,7 @@ func (h *OpsHandler) GetUpstreamError(c *gin.Context) {
 // POST /api/v1/admin/ops/upstream-errors/:id/retry
 func (h *OpsHandler) RetryUpstreamError(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -578,14 +578,14 @@ func (h *OpsHandler) RetryUpstreamError(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
@@ -609,7 +609,7 @@ func (h *OpsHandler) ResolveUpstreamError(c *gin.Context) {
 // GET /api/v1/admin/ops/requests
 func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -624,7 +624,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -645,7 +645,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("user_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid user_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 			return
 		}
 		filter.UserID = &id
@@ -653,7 +653,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("api_key_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid api_key_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid api_key_id")
 			return
 		}
 		filter.APIKeyID = &id
@@ -661,7 +661,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		filter.AccountID = &id
@@ -669,7 +669,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		filter.GroupID = &id
@@ -678,7 +678,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("min_duration_ms")); v != "" {
 		parsed, err := strconv.Atoi(v)
 		if err != nil || parsed < 0 {
-			response.BadRequest(c, "Invalid min_duration_ms")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid min_duration_ms")
 			return
 		}
 		filter.MinDurationMs = &parsed
@@ -686,7 +686,7 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("max_duration_ms")); v != "" {
 		parsed, err := strconv.Atoi(v)
 		if err != nil || parsed < 0 {
-			response.BadRequest(c, "Invalid max_duration_ms")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid max_duration_ms")
 			return
 		}
 		filter.MaxDurationMs = &parsed
@@ -696,10 +696,10 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	if err != nil {
 		// Invalid sort/kind/platform etc should be a bad request; keep it simple.
 		if strings.Contains(strings.ToLower(err.Error()), "invalid") {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 			return
 		}
-		response.Error(c, http.StatusInternalServerError, "Failed to list request details")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to list request details")
 		return
 	}
 
@@ -720,7 +720,7 @@ type opsResolveRequest struct {
 // POST /api/v1/admin/ops/errors/:id/retry
 func (h *OpsHandler) RetryErrorRequest(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -730,20 +730,20 @@ func (h *OpsHandler) RetryErrorRequest(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
 	req := opsRetryRequest{Mode: service.OpsRetryModeClient}
 	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.Mode) == "" {
@@ -756,7 +756,7 @@ func (h *OpsHandler) RetryErrorRequest(c *gin.Context) {
 	// Legacy endpoint safety: only allow retrying the client request here.
 	// Upstream retries must go through the split endpoints.
 	if strings.EqualFold(strings.TrimSpace(req.Mode), service.OpsRetryModeUpstream) {
-		response.BadRequest(c, "upstream retry is not supported on this endpoint")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "upstream retry is not supported on this endpoint")
 		return
 	}
 
@@ -773,7 +773,7 @@ func (h *OpsHandler) RetryErrorRequest(c *gin.Context) {
 // GET /api/v1/admin/ops/errors/:id/retries
 func (h *OpsHandler) ListRetryAttempts(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -784,7 +784,7 @@ func (h *OpsHandler) ListRetryAttempts(c *gin.Context) {
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
@@ -792,7 +792,7 @@ func (h *OpsHandler) ListRetryAttempts(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("limit")); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil || n <= 0 {
-			response.BadRequest(c, "Invalid limit")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid limit")
 			return
 		}
 		limit = n
@@ -810,7 +810,7 @@ func (h *OpsHandler) ListRetryAttempts(c *gin.Context) {
 // PUT /api/v1/admin/ops/errors/:id/resolve
 func (h *OpsHandler) UpdateErrorResolution(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -820,20 +820,20 @@ func (h *OpsHandler) UpdateErrorResolution(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(c, "Invalid error id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid error id")
 		return
 	}
 
 	var req opsResolveRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	uid := subject.UserID
@@ -13,7 +13,7 @@ import (
 // GET /api/v1/admin/ops/email-notification/config
 func (h *OpsHandler) GetEmailNotificationConfig(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -23,7 +23,7 @@ func (h *OpsHandler) GetEmailNotificationConfig(c *gin.Context) {
 
 	cfg, err := h.opsService.GetEmailNotificationConfig(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get email notification config")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get email notification config")
 		return
 	}
 	response.Success(c, cfg)
@@ -33,7 +33,7 @@ func (h *OpsHandler) GetEmailNotificationConfig(c *gin.Context) {
 // PUT /api/v1/admin/ops/email-notification/config
 func (h *OpsHandler) UpdateEmailNotificationConfig(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -43,14 +43,14 @@ func (h *OpsHandler) UpdateEmailNotificationConfig(c *gin.Context) {
 
 	var req service.OpsEmailNotificationConfigUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	updated, err := h.opsService.UpdateEmailNotificationConfig(c.Request.Context(), &req)
 	if err != nil {
 		// Most failures here are validation errors from request payload; treat as 400.
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -60,7 +60,7 @@ func (h *OpsHandler) UpdateEmailNotificationConfig(c *gin.Context) {
 // GET /api/v1/admin/ops/runtime/alert
 func (h *OpsHandler) GetAlertRuntimeSettings(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -70,7 +70,7 @@ func (h *OpsHandler) GetAlertRuntimeSettings(c *gin.Context) {
 
 	cfg, err := h.opsService.GetOpsAlertRuntimeSettings(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get alert runtime settings")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get alert runtime settings")
 		return
 	}
 	response.Success(c, cfg)
@@ -80,7 +80,7 @@ func (h *OpsHandler) GetAlertRuntimeSettings(c *gin.Context) {
 // PUT /api/v1/admin/ops/runtime/alert
 func (h *OpsHandler) UpdateAlertRuntimeSettings(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -90,13 +90,13 @@ func (h *OpsHandler) UpdateAlertRuntimeSettings(c *gin.Context) {
 
 	var req service.OpsAlertRuntimeSettings
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	updated, err := h.opsService.UpdateOpsAlertRuntimeSettings(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -106,7 +106,7 @@ func (h *OpsHandler) UpdateAlertRuntimeSettings(c *gin.Context) {
 // GET /api/v1/admin/ops/runtime/logging
 func (h *OpsHandler) GetRuntimeLogConfig(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -116,7 +116,7 @@ func (h *OpsHandler) GetRuntimeLogConfig(c *gin.Context) {
 
 	cfg, err := h.opsService.GetRuntimeLogConfig(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get runtime log config")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get runtime log config")
 		return
 	}
 	response.Success(c, cfg)
@@ -126,7 +126,7 @@ func (h *OpsHandler) GetRuntimeLogConfig(c *gin.Context) {
 // PUT /api/v1/admin/ops/runtime/logging
 func (h *OpsHandler) UpdateRuntimeLogConfig(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -136,19 +136,19 @@ func (h *OpsHandler) UpdateRuntimeLogConfig(c *gin.Context) {
 
 	var req service.OpsRuntimeLogConfig
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	updated, err := h.opsService.UpdateRuntimeLogConfig(c.Request.Context(), &req, subject.UserID)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -158,7 +158,7 @@ func (h *OpsHandler) UpdateRuntimeLogConfig(c *gin.Context) {
 // POST /api/v1/admin/ops/runtime/logging/reset
 func (h *OpsHandler) ResetRuntimeLogConfig(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -168,13 +168,13 @@ func (h *OpsHandler) ResetRuntimeLogConfig(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	updated, err := h.opsService.ResetRuntimeLogConfig(c.Request.Context(), subject.UserID)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -184,7 +184,7 @@ func (h *OpsHandler) ResetRuntimeLogConfig(c *gin.Context) {
 // GET /api/v1/admin/ops/advanced-settings
 func (h *OpsHandler) GetAdvancedSettings(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -194,7 +194,7 @@ func (h *OpsHandler) GetAdvancedSettings(c *gin.Context) {
 
 	cfg, err := h.opsService.GetOpsAdvancedSettings(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get advanced settings")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get advanced settings")
 		return
 	}
 	response.Success(c, cfg)
@@ -204,7 +204,7 @@ func (h *OpsHandler) GetAdvancedSettings(c *gin.Context) {
 // PUT /api/v1/admin/ops/advanced-settings
 func (h *OpsHandler) UpdateAdvancedSettings(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -214,13 +214,13 @@ func (h *OpsHandler) UpdateAdvancedSettings(c *gin.Context) {
 
 	var req service.OpsAdvancedSettings
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	updated, err := h.opsService.UpdateOpsAdvancedSettings(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -230,7 +230,7 @@ func (h *OpsHandler) UpdateAdvancedSettings(c *gin.Context) {
 // GET /api/v1/admin/ops/settings/metric-thresholds
 func (h *OpsHandler) GetMetricThresholds(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -240,7 +240,7 @@ func (h *OpsHandler) GetMetricThresholds(c *gin.Context) {
 
 	cfg, err := h.opsService.GetMetricThresholds(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get metric thresholds")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to get metric thresholds")
 		return
 	}
 	response.Success(c, cfg)
@@ -250,7 +250,7 @@ func (h *OpsHandler) GetMetricThresholds(c *gin.Context) {
 // PUT /api/v1/admin/ops/settings/metric-thresholds
 func (h *OpsHandler) UpdateMetricThresholds(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -260,13 +260,13 @@ func (h *OpsHandler) UpdateMetricThresholds(c *gin.Context) {
 
 	var req service.OpsMetricThresholds
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
 	updated, err := h.opsService.UpdateMetricThresholds(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 	response.Success(c, updated)
@@ -31,7 +31,7 @@ type opsSystemLogCleanupRequest struct {
 // GET /api/v1/admin/ops/system-logs
 func (h *OpsHandler) ListSystemLogs(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -46,7 +46,7 @@ func (h *OpsHandler) ListSystemLogs(c *gin.Context) {
 
 	start, end, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -66,7 +66,7 @@ func (h *OpsHandler) ListSystemLogs(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("user_id")); v != "" {
 		id, parseErr := strconv.ParseInt(v, 10, 64)
 		if parseErr != nil || id <= 0 {
-			response.BadRequest(c, "Invalid user_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 			return
 		}
 		filter.UserID = &id
@@ -74,7 +74,7 @@ func (h *OpsHandler) ListSystemLogs(c *gin.Context) {
 	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
 		id, parseErr := strconv.ParseInt(v, 10, 64)
 		if parseErr != nil || id <= 0 {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		filter.AccountID = &id
@@ -92,7 +92,7 @@ func (h *OpsHandler) ListSystemLogs(c *gin.Context) {
 // POST /api/v1/admin/ops/system-logs/cleanup
 func (h *OpsHandler) CleanupSystemLogs(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -102,13 +102,13 @@ func (h *OpsHandler) CleanupSystemLogs(c *gin.Context) {
 
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req opsSystemLogCleanupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request body")
 		return
 	}
 
@@ -128,12 +128,12 @@ func (h *OpsHandler) CleanupSystemLogs(c *gin.Context) {
 	}
 	start, err := parseTS(req.StartTime)
 	if err != nil {
-		response.BadRequest(c, "Invalid start_time")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid start_time")
 		return
 	}
 	end, err := parseTS(req.EndTime)
 	if err != nil {
-		response.BadRequest(c, "Invalid end_time")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid end_time")
 		return
 	}
 
@@ -163,7 +163,7 @@ func (h *OpsHandler) CleanupSystemLogs(c *gin.Context) {
 // GET /api/v1/admin/ops/system-logs/health
 func (h *OpsHandler) GetSystemLogIngestionHealth(c *gin.Context) {
 	if h.opsService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Ops service not available")
 		return
 	}
 	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTokenHistograms returns per-model distributions of input/output tokens per billed request
+// (fixed buckets from metrics.token_buckets, counted since process start; same data as sub2api_request_tokens).
+// GET /api/v1/admin/ops/token-histograms?model=xxx
+func (h *OpsHandler) GetTokenHistograms(c *gin.Context) {
+	model := strings.ToLower(strings.TrimSpace(c.Query("model")))
+	histograms := metrics.TokenHistograms()
+	if model != "" {
+		filtered := histograms[:0]
+		for _, item := range histograms {
+			if item.Model == model {
+				filtered = append(filtered, item)
+			}
+		}
+		histograms = filtered
+	}
+	response.Success(c, gin.H{"histograms": histograms})
+}
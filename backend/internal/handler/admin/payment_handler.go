@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 
 	dbent "github.com/Wei-Shaw/sub2api/ent"
@@ -153,7 +154,7 @@ func (h *PaymentHandler) ProcessRefund(c *gin.Context) {
 
 	var req AdminProcessRefundRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -193,7 +194,7 @@ func (h *PaymentHandler) ListPlans(c *gin.Context) {
 func (h *PaymentHandler) CreatePlan(c *gin.Context) {
 	var req service.CreatePlanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	plan, err := h.configService.CreatePlan(c.Request.Context(), req)
@@ -213,7 +214,7 @@ func (h *PaymentHandler) UpdatePlan(c *gin.Context) {
 	}
 	var req service.UpdatePlanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	plan, err := h.configService.UpdatePlan(c.Request.Context(), id, req)
@@ -256,7 +257,7 @@ func (h *PaymentHandler) ListProviders(c *gin.Context) {
 func (h *PaymentHandler) CreateProvider(c *gin.Context) {
 	var req service.CreateProviderInstanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	inst, err := h.configService.CreateProviderInstance(c.Request.Context(), req)
@@ -277,7 +278,7 @@ func (h *PaymentHandler) UpdateProvider(c *gin.Context) {
 	}
 	var req service.UpdateProviderInstanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	inst, err := h.configService.UpdateProviderInstance(c.Request.Context(), id, req)
@@ -309,7 +310,7 @@ func (h *PaymentHandler) DeleteProvider(c *gin.Context) {
 func parseIDParam(c *gin.Context, paramName string) (int64, bool) {
 	id, err := strconv.ParseInt(c.Param(paramName), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid "+paramName)
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid "+paramName)
 		return 0, false
 	}
 	return id, true
@@ -333,7 +334,7 @@ func (h *PaymentHandler) GetConfig(c *gin.Context) {
 func (h *PaymentHandler) UpdateConfig(c *gin.Context) {
 	var req service.UpdatePaymentConfigRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if err := h.configService.UpdatePaymentConfig(c.Request.Context(), req); err != nil {
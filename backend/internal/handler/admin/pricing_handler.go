@@ -1,10 +1,15 @@
 package admin
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -25,26 +30,184 @@ func NewPricingHandler(billingService *service.BillingService) *PricingHandler {
 
 // ModelPricingItem 模型价格条目（用于列表展示）
 type ModelPricingItem struct {
-	Model                       string  `json:"model"`
-	InputCostPerToken           float64 `json:"input_cost_per_token"`
-	OutputCostPerToken          float64 `json:"output_cost_per_token"`
-	InputCostPerMTok            float64 `json:"input_cost_per_mtok"`
-	OutputCostPerMTok           float64 `json:"output_cost_per_mtok"`
-	CacheCreationInputTokenCost float64 `json:"cache_creation_input_token_cost,omitempty"`
-	CacheReadInputTokenCost     float64 `json:"cache_read_input_token_cost,omitempty"`
-	Provider                    string  `json:"provider"`
-	Mode                        string  `json:"mode"`
-	SupportsPromptCaching       bool    `json:"supports_prompt_caching"`
-	OutputCostPerImage          float64 `json:"output_cost_per_image,omitempty"`
-}
-
-// ListPricing 获取所有模型价格列表
+	Model                       string    `json:"model"`
+	InputCostPerToken           float64   `json:"input_cost_per_token"`
+	OutputCostPerToken          float64   `json:"output_cost_per_token"`
+	InputCostPerMTok            float64   `json:"input_cost_per_mtok"`
+	OutputCostPerMTok           float64   `json:"output_cost_per_mtok"`
+	BlendedCostPerMTok          float64   `json:"blended_cost_per_mtok"`
+	CacheCreationInputTokenCost float64   `json:"cache_creation_input_token_cost,omitempty"`
+	CacheReadInputTokenCost     float64   `json:"cache_read_input_token_cost,omitempty"`
+	Provider                    string    `json:"provider"`
+	Mode                        string    `json:"mode"`
+	SupportsPromptCaching       bool      `json:"supports_prompt_caching"`
+	OutputCostPerImage          float64   `json:"output_cost_per_image,omitempty"`
+	Overridden                  bool      `json:"overridden"`
+	Disabled                    bool      `json:"disabled"`
+	IsFree                      bool      `json:"is_free"`
+	LastUpdated                 time.Time `json:"last_updated"`
+	Aliases                     []string  `json:"aliases,omitempty"`
+	// 弃用状态（显式标记或弃用日期已到）及建议的替代模型
+	Deprecated      bool   `json:"deprecated"`
+	Replacement     string `json:"replacement,omitempty"`
+	DeprecationDate string `json:"deprecation_date,omitempty"`
+	// 上游标价与折扣、加价后向用户收取的价格（每百万 token，已按 currency 换算）
+	BaseCost     PricingCostPerMTok `json:"base_cost"`
+	ChargedCost  PricingCostPerMTok `json:"charged_cost"`
+	MarkupSource string             `json:"markup_source"` // none / global / provider / model
+	// 生效的承诺用量折扣百分比及来源 none / provider / model（收费价格 = 标价 × 折扣后再加价）
+	CommittedDiscount       float64 `json:"committed_discount_percent"`
+	CommittedDiscountSource string  `json:"committed_discount_source"`
+	// 生效的请求超时（秒，0 表示不限制）及来源 none / default / model
+	TimeoutSeconds int    `json:"timeout_seconds"`
+	TimeoutSource  string `json:"timeout_source"`
+	// batch API 请求的价格倍率及来源 default / model，以及按倍率折扣后的上游/收费价格
+	BatchMultiplier       float64            `json:"batch_multiplier"`
+	BatchMultiplierSource string             `json:"batch_multiplier_source"`
+	BatchBaseCost         PricingCostPerMTok `json:"batch_base_cost"`
+	BatchChargedCost      PricingCostPerMTok `json:"batch_charged_cost"`
+	// 模型能力标记（supports_vision 等，未知时省略）
+	service.ModelCapabilities
+}
+
+// PricingCostPerMTok 每百万 token 价格
+type PricingCostPerMTok struct {
+	Input   float64 `json:"input_per_mtok"`
+	Output  float64 `json:"output_per_mtok"`
+	Blended float64 `json:"blended_per_mtok"`
+}
+
+const (
+	defaultPricingPageSize = 50
+	maxPricingPageSize     = 1000
+)
+
+// pricingListResult 按查询参数筛选后的价格列表
+type pricingListResult struct {
+	items     []ModelPricingItem
+	providers []service.PricingProviderStatus // 全部厂商及启用状态（不受筛选影响）
+	currency  string
+	rate      float64
+	ioRatio   float64
+}
+
+// ListPricing 获取所有模型价格列表（支持 page / page_size 分页）
 // GET /api/v1/admin/pricing
+// 可选 currency 参数（如 EUR）将每百万 token 价格按已配置汇率换算
 func (h *PricingHandler) ListPricing(c *gin.Context) {
+	page, pageSize := parsePricingPagination(c)
+	result, ok := h.filterPricingList(c)
+	if !ok {
+		return
+	}
+	items := result.items
+
+	// 先筛选后分页，total 反映筛选后的数量
+	total := len(items)
+	totalPages := (total + pageSize - 1) / pageSize
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := min(start+pageSize, total)
+
+	response.Success(c, gin.H{
+		"items":         items[start:end],
+		"total":         total,
+		"page":          page,
+		"page_size":     pageSize,
+		"total_pages":   totalPages,
+		"providers":     result.providers,
+		"currency":      result.currency,
+		"exchange_rate": result.rate,
+		"io_ratio":      result.ioRatio,
+	})
+}
+
+// filterPricingList 按 search / provider / stale_after / is_free / deprecated / 能力标记 / currency / io_ratio 参数筛选价格列表
+// （按厂商、模型名排序）；参数无效时写入错误响应并返回 false
+func (h *PricingHandler) filterPricingList(c *gin.Context) (*pricingListResult, bool) {
 	search := strings.ToLower(strings.TrimSpace(c.Query("search")))
 	provider := strings.ToLower(strings.TrimSpace(c.Query("provider")))
 
+	// stale_after：仅返回超过该时长未变化的模型（如 720h、30d）
+	var staleBefore time.Time
+	if raw := strings.TrimSpace(c.Query("stale_after")); raw != "" {
+		staleAfter, err := parseStaleAfter(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stale_after: "+err.Error())
+			return nil, false
+		}
+		staleBefore = time.Now().Add(-staleAfter)
+	}
+
+	// is_free：true 仅返回免费模型，false 仅返回收费模型
+	var isFree *bool
+	if raw := strings.TrimSpace(c.Query("is_free")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid is_free: must be true or false")
+			return nil, false
+		}
+		isFree = &v
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(c.DefaultQuery("currency", service.BaseCurrency)))
+	rate, ok := h.billingService.GetExchangeRate(currency)
+	if !ok {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeCurrencyNotConfigured, "No exchange rate configured for currency: "+currency)
+		return nil, false
+	}
+
+	// io_ratio：每个输出 token 对应的输入 token 数，用于计算综合价格
+	ioRatio := h.billingService.DefaultIORatio()
+	if raw := strings.TrimSpace(c.Query("io_ratio")); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v <= 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid io_ratio: must be a positive number")
+			return nil, false
+		}
+		ioRatio = v
+	}
+
+	// deprecated：true 仅返回已弃用模型，false 仅返回未弃用模型
+	var deprecated *bool
+	if raw := strings.TrimSpace(c.Query("deprecated")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid deprecated: must be true or false")
+			return nil, false
+		}
+		deprecated = &v
+	}
+
+	// supports_vision / supports_function_calling / supports_streaming：按能力筛选
+	// （true 仅返回明确支持的模型，false 返回不支持或未知的模型）
+	var capabilityFilters [3]*bool
+	for i, name := range []string{"supports_vision", "supports_function_calling", "supports_streaming"} {
+		raw := strings.TrimSpace(c.Query(name))
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid "+name+": must be true or false")
+			return nil, false
+		}
+		capabilityFilters[i] = &v
+	}
+
+	// include_aliases=true：附带指向每个规范模型的别名
+	var aliasesByModel map[string][]string
+	if includeAliases, _ := strconv.ParseBool(c.Query("include_aliases")); includeAliases {
+		aliasesByModel = h.billingService.ModelAliasesByTarget()
+	}
+
 	allPricing := h.billingService.GetAllPricing()
+	now := time.Now()
 
 	items := make([]ModelPricingItem, 0, len(allPricing))
 	providers := make(map[string]bool)
@@ -59,19 +222,90 @@ func (h *PricingHandler) ListPricing(c *gin.Context) {
 		if provider != "" && strings.ToLower(pricing.Provider) != provider {
 			continue
 		}
+		if !staleBefore.IsZero() && !pricing.LastUpdated.Before(staleBefore) {
+			continue
+		}
+		if isFree != nil && pricing.IsFree != *isFree {
+			continue
+		}
+		isDeprecated := pricing.IsDeprecated(now)
+		if deprecated != nil && isDeprecated != *deprecated {
+			continue
+		}
+		if !pricing.Matches(capabilityFilters[0], capabilityFilters[1], capabilityFilters[2]) {
+			continue
+		}
 
+		inputMTok := pricing.InputCostPerToken * 1_000_000 * rate
+		outputMTok := pricing.OutputCostPerToken * 1_000_000 * rate
+		markup, markupSource := h.billingService.EffectiveMarkup(model)
+		if pricing.IsFree {
+			// 免费模型计费时不加价
+			markup, markupSource = service.PricingMarkup{}, service.MarkupSourceNone
+		}
+		discountPercent, discountSource := h.billingService.EffectiveCommittedDiscount(model)
+		if pricing.IsFree {
+			discountPercent, discountSource = 0, service.CommittedDiscountSourceNone
+		}
+		// 计费链路：标价 → 承诺用量折扣 → 加价
+		discountFactor := 1 - discountPercent/100
+		chargedInputMTok := markup.ApplyToPrice(pricing.InputCostPerToken*discountFactor, true) * 1_000_000 * rate
+		chargedOutputMTok := markup.ApplyToPrice(pricing.OutputCostPerToken*discountFactor, true) * 1_000_000 * rate
+		timeoutSeconds, timeoutSource := h.billingService.EffectiveModelTimeout(model)
+		batchMultiplier, batchSource := h.billingService.EffectiveBatchMultiplier(model)
+		batchInputMTok := inputMTok * batchMultiplier
+		batchOutputMTok := outputMTok * batchMultiplier
+		batchChargedInputMTok := markup.ApplyToPrice(pricing.InputCostPerToken*batchMultiplier*discountFactor, true) * 1_000_000 * rate
+		batchChargedOutputMTok := markup.ApplyToPrice(pricing.OutputCostPerToken*batchMultiplier*discountFactor, true) * 1_000_000 * rate
 		items = append(items, ModelPricingItem{
 			Model:                       model,
 			InputCostPerToken:           pricing.InputCostPerToken,
 			OutputCostPerToken:          pricing.OutputCostPerToken,
-			InputCostPerMTok:            pricing.InputCostPerToken * 1_000_000,
-			OutputCostPerMTok:           pricing.OutputCostPerToken * 1_000_000,
+			InputCostPerMTok:            inputMTok,
+			OutputCostPerMTok:           outputMTok,
+			BlendedCostPerMTok:          service.BlendedCost(inputMTok, outputMTok, ioRatio),
 			CacheCreationInputTokenCost: pricing.CacheCreationInputTokenCost,
 			CacheReadInputTokenCost:     pricing.CacheReadInputTokenCost,
 			Provider:                    pricing.Provider,
 			Mode:                        pricing.Mode,
 			SupportsPromptCaching:       pricing.SupportsPromptCaching,
 			OutputCostPerImage:          pricing.OutputCostPerImage,
+			Overridden:                  pricing.Overridden,
+			Disabled:                    pricing.Disabled,
+			IsFree:                      pricing.IsFree,
+			LastUpdated:                 pricing.LastUpdated,
+			Aliases:                     aliasesByModel[model],
+			Deprecated:                  isDeprecated,
+			Replacement:                 pricing.Replacement,
+			DeprecationDate:             pricing.DeprecationDate,
+			BaseCost: PricingCostPerMTok{
+				Input:   inputMTok,
+				Output:  outputMTok,
+				Blended: service.BlendedCost(inputMTok, outputMTok, ioRatio),
+			},
+			ChargedCost: PricingCostPerMTok{
+				Input:   chargedInputMTok,
+				Output:  chargedOutputMTok,
+				Blended: service.BlendedCost(chargedInputMTok, chargedOutputMTok, ioRatio),
+			},
+			MarkupSource:            markupSource,
+			CommittedDiscount:       discountPercent,
+			CommittedDiscountSource: discountSource,
+			TimeoutSeconds:          timeoutSeconds,
+			TimeoutSource:           timeoutSource,
+			BatchMultiplier:         batchMultiplier,
+			BatchMultiplierSource:   batchSource,
+			BatchBaseCost: PricingCostPerMTok{
+				Input:   batchInputMTok,
+				Output:  batchOutputMTok,
+				Blended: service.BlendedCost(batchInputMTok, batchOutputMTok, ioRatio),
+			},
+			BatchChargedCost: PricingCostPerMTok{
+				Input:   batchChargedInputMTok,
+				Output:  batchChargedOutputMTok,
+				Blended: service.BlendedCost(batchChargedInputMTok, batchChargedOutputMTok, ioRatio),
+			},
+			ModelCapabilities: pricing.ModelCapabilities,
 		})
 	}
 
@@ -84,17 +318,94 @@ func (h *PricingHandler) ListPricing(c *gin.Context) {
 
 	providerList := make([]string, 0, len(providers))
 	for p := range providers {
-		if p != "" {
-			providerList = append(providerList, p)
+		providerList = append(providerList, p)
+	}
+
+	return &pricingListResult{
+		items:     items,
+		providers: h.billingService.ProviderStatuses(providerList),
+		currency:  currency,
+		rate:      rate,
+		ioRatio:   ioRatio,
+	}, true
+}
+
+// ExportPricing 导出筛选后的价格列表（format=csv|json，默认 csv）
+// GET /api/v1/admin/pricing/export
+// 支持与列表相同的 search / provider / stale_after / is_free / currency 参数
+func (h *PricingHandler) ExportPricing(c *gin.Context) {
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "csv")))
+	if format != "csv" && format != "json" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid format: must be csv or json")
+		return
+	}
+
+	result, ok := h.filterPricingList(c)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("pricing_%s.%s", time.Now().Format("2006-01-02"), format)
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+
+	if format == "json" {
+		c.JSON(http.StatusOK, result.items)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"model", "provider", "mode", "input_cost_per_mtok", "output_cost_per_mtok", "supports_prompt_caching"})
+	for _, item := range result.items {
+		if err := writer.Write([]string{
+			item.Model,
+			item.Provider,
+			item.Mode,
+			strconv.FormatFloat(item.InputCostPerMTok, 'f', -1, 64),
+			strconv.FormatFloat(item.OutputCostPerMTok, 'f', -1, 64),
+			strconv.FormatBool(item.SupportsPromptCaching),
+		}); err != nil {
+			// 响应头已发送，只能中止写入
+			_ = c.Error(err)
+			return
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// parseStaleAfter 解析时长参数，在 time.ParseDuration 基础上支持天（d）单位
+func parseStaleAfter(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid day count %q", raw)
 		}
+		return time.Duration(n) * 24 * time.Hour, nil
 	}
-	sort.Strings(providerList)
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration must be non-negative")
+	}
+	return d, nil
+}
 
-	response.Success(c, gin.H{
-		"items":     items,
-		"total":     len(items),
-		"providers": providerList,
-	})
+// parsePricingPagination 解析价格列表分页参数（默认第 1 页、每页 50 条）
+func parsePricingPagination(c *gin.Context) (page, pageSize int) {
+	page, pageSize = 1, defaultPricingPageSize
+	if v, err := strconv.Atoi(c.Query("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(c.Query("page_size")); err == nil && v > 0 {
+		pageSize = min(v, maxPricingPageSize)
+	}
+	return page, pageSize
 }
 
 // GetStatus 获取价格服务状态
@@ -110,86 +421,1422 @@ func (h *PricingHandler) GetStatus(c *gin.Context) {
 }
 
 // ForceUpdate 强制更新价格数据
-// POST /api/v1/admin/pricing/update
+// POST /api/v1/admin/pricing/update[?dry_run=true]
+// dry_run 时仅拉取远程数据并返回与当前数据的差异，不做任何修改
 func (h *PricingHandler) ForceUpdate(c *gin.Context) {
+	dryRun := false
+	if raw := strings.TrimSpace(c.Query("dry_run")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid dry_run parameter")
+			return
+		}
+		dryRun = parsed
+	}
+	if dryRun {
+		diff, err := h.billingService.DryRunForceUpdatePricing()
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingFetchFailed, "Failed to fetch pricing: "+err.Error())
+			return
+		}
+		response.Success(c, gin.H{
+			"message":       "Dry run: no changes were applied",
+			"dry_run":       true,
+			"applied":       false,
+			"added":         diff.Added,
+			"removed":       diff.Removed,
+			"changed":       diff.Changed,
+			"added_count":   len(diff.Added),
+			"removed_count": len(diff.Removed),
+			"changed_count": len(diff.Changed),
+		})
+		return
+	}
+
 	if err := h.billingService.ForceUpdatePricing(); err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to update pricing: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to update pricing: "+err.Error())
 		return
 	}
 
 	status := h.billingService.GetPricingServiceStatus()
 	response.Success(c, gin.H{
 		"message": "Pricing data updated successfully",
+		"dry_run": false,
 		"status":  status,
 	})
 }
 
+// SetAutoRefreshIntervalRequest 修改定时刷新间隔请求
+type SetAutoRefreshIntervalRequest struct {
+	IntervalHours int `json:"interval_hours" binding:"required"`
+}
+
+// GetAutoRefresh 获取价格数据定时刷新状态
+// GET /api/v1/admin/pricing/auto-refresh
+func (h *PricingHandler) GetAutoRefresh(c *gin.Context) {
+	response.Success(c, h.billingService.GetPricingAutoRefreshStatus())
+}
+
+// SetAutoRefreshInterval 运行时修改定时刷新间隔（不持久化，重启后恢复配置值）
+// PUT /api/v1/admin/pricing/auto-refresh
+func (h *PricingHandler) SetAutoRefreshInterval(c *gin.Context) {
+	var req SetAutoRefreshIntervalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	if err := h.billingService.SetPricingAutoRefreshInterval(req.IntervalHours); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set auto refresh interval: "+err.Error())
+		return
+	}
+	response.Success(c, h.billingService.GetPricingAutoRefreshStatus())
+}
+
+// PauseAutoRefresh 暂停定时刷新
+// POST /api/v1/admin/pricing/auto-refresh/pause
+func (h *PricingHandler) PauseAutoRefresh(c *gin.Context) {
+	h.billingService.SetPricingAutoRefreshPaused(true)
+	response.Success(c, h.billingService.GetPricingAutoRefreshStatus())
+}
+
+// ResumeAutoRefresh 恢复定时刷新（下次刷新时间从当前时刻重新计算）
+// POST /api/v1/admin/pricing/auto-refresh/resume
+func (h *PricingHandler) ResumeAutoRefresh(c *gin.Context) {
+	h.billingService.SetPricingAutoRefreshPaused(false)
+	response.Success(c, h.billingService.GetPricingAutoRefreshStatus())
+}
+
 // LookupModel 查询单个模型价格
-// GET /api/v1/admin/pricing/lookup?model=xxx
+// GET /api/v1/admin/pricing/lookup?model=xxx[&strict=true][&batch=true][&as_of=2024-05-01]
+// match_type 标识结果为精确匹配（exact）还是近似匹配（fuzzy）；
+// as_of 查询该时刻生效的历史目录价格（日期按 UTC 当日 00:00，或 RFC3339 时间），未指定时返回当前价格
 func (h *PricingHandler) LookupModel(c *gin.Context) {
 	model := strings.TrimSpace(c.Query("model"))
 	if model == "" {
-		response.Error(c, http.StatusBadRequest, "model parameter is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model parameter is required")
+		return
+	}
+	if rawAsOf := strings.TrimSpace(c.Query("as_of")); rawAsOf != "" {
+		asOf, err := parsePricingAsOf(rawAsOf)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "as_of must be a date (YYYY-MM-DD) or RFC3339 time")
+			return
+		}
+		h.lookupModelAsOf(c, model, asOf)
 		return
 	}
 
-	pricing, err := h.billingService.GetModelPricing(model)
+	strict := c.Query("strict") == "true" || !h.billingService.FuzzyModelMatchingEnabled()
+	pricing, matchType, err := h.billingService.MatchModelPricing(model, strict)
+	if errors.Is(err, service.ErrPricingModelDisabled) || errors.Is(err, service.ErrPricingProviderDisabled) {
+		response.ErrorFrom(c, err)
+		return
+	}
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Model pricing not found: "+err.Error())
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodePricingNotFound, "Model pricing not found: "+err.Error())
 		return
 	}
 
-	response.Success(c, gin.H{
-		"model": model,
-		"pricing": gin.H{
-			"input_cost_per_token":            pricing.InputPricePerToken,
-			"output_cost_per_token":           pricing.OutputPricePerToken,
-			"input_cost_per_mtok":             pricing.InputPricePerToken * 1_000_000,
-			"output_cost_per_mtok":            pricing.OutputPricePerToken * 1_000_000,
-			"cache_creation_input_token_cost": pricing.CacheCreationPricePerToken,
-			"cache_read_input_token_cost":     pricing.CacheReadPricePerToken,
-		},
-	})
+	digits := h.billingService.CostDisplaySignificantDigits()
+	result := gin.H{
+		"model":        model,
+		"match_type":   matchType,
+		"overridden":   h.billingService.GetPricingOverride(model) != nil,
+		"pricing":      lookupPricingResponse(pricing, digits),
+		"capabilities": pricing.Capabilities,
+	}
+	setDisplayPrecision(result, digits)
+	// batch=true：返回按 batch 倍率折扣后的价格
+	if batch, _ := strconv.ParseBool(c.Query("batch")); batch {
+		multiplier, _ := h.billingService.EffectiveBatchMultiplier(model)
+		discounted := *pricing
+		discounted.InputPricePerToken *= multiplier
+		discounted.OutputPricePerToken *= multiplier
+		discounted.CacheCreationPricePerToken *= multiplier
+		discounted.CacheReadPricePerToken *= multiplier
+		result["pricing"] = lookupPricingResponse(&discounted, digits)
+		result["batch"] = true
+		result["batch_multiplier"] = multiplier
+	}
+
+	response.Success(c, result)
 }
 
-// UploadPricing 手动上传价格JSON文件
-// POST /api/v1/admin/pricing/upload
-func (h *PricingHandler) UploadPricing(c *gin.Context) {
-	file, header, err := c.Request.FormFile("file")
+// lookupModelAsOf 返回模型在 asOf 时刻生效的历史价格（不含管理员覆盖与加价）
+func (h *PricingHandler) lookupModelAsOf(c *gin.Context, model string, asOf time.Time) {
+	historical, err := h.billingService.ModelPricingAsOf(model, asOf)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "No file uploaded")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodePricingNotFound, "Model pricing history not found: "+model)
+		return
+	}
+	digits := h.billingService.CostDisplaySignificantDigits()
+	result := gin.H{
+		"model":          model,
+		"matched_model":  historical.Model,
+		"as_of":          asOf,
+		"effective_from": historical.EffectiveFrom,
+		// as_of 早于最早记录的版本时返回最早的已知价格
+		"before_history": asOf.Before(historical.EffectiveFrom),
+		"pricing":        lookupPricingResponse(historical.Pricing, digits),
+		"capabilities":   historical.Pricing.Capabilities,
+	}
+	setDisplayPrecision(result, digits)
+	response.Success(c, result)
+}
+
+// setDisplayPrecision 价格已舍入展示时在响应中标明有效数字位数
+func setDisplayPrecision(result gin.H, digits int) {
+	if digits > 0 {
+		result["display_significant_digits"] = digits
+	}
+}
+
+// parsePricingAsOf 解析 as_of 参数：YYYY-MM-DD（UTC 当日 00:00）或 RFC3339 时间
+func parsePricingAsOf(raw string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// lookupPricingResponse 构造单模型价格查询的 pricing 对象，价格按 digits 位有效数字舍入展示
+func lookupPricingResponse(pricing *service.ModelPricing, digits int) gin.H {
+	return gin.H{
+		"input_cost_per_token":            service.RoundCostForDisplay(pricing.InputPricePerToken, digits),
+		"output_cost_per_token":           service.RoundCostForDisplay(pricing.OutputPricePerToken, digits),
+		"input_cost_per_mtok":             service.RoundCostForDisplay(pricing.InputPricePerToken*1_000_000, digits),
+		"output_cost_per_mtok":            service.RoundCostForDisplay(pricing.OutputPricePerToken*1_000_000, digits),
+		"cache_creation_input_token_cost": service.RoundCostForDisplay(pricing.CacheCreationPricePerToken, digits),
+		"cache_read_input_token_cost":     service.RoundCostForDisplay(pricing.CacheReadPricePerToken, digits),
+		"is_free":                         pricing.IsFree,
+		"is_default":                      pricing.IsDefault,
+	}
+}
+
+// maxBatchLookupModels 批量查询单次最多模型数量
+const maxBatchLookupModels = 500
+
+// BatchLookupRequest 批量查询模型价格请求
+type BatchLookupRequest struct {
+	Models []string `json:"models" binding:"required"`
+}
+
+// BatchLookupModels 批量查询模型价格
+// POST /api/v1/admin/pricing/lookup/batch
+func (h *PricingHandler) BatchLookupModels(c *gin.Context) {
+	var req BatchLookupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	if len(req.Models) == 0 {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "models must not be empty")
+		return
+	}
+	if len(req.Models) > maxBatchLookupModels {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, fmt.Sprintf("too many models (max %d)", maxBatchLookupModels))
 		return
 	}
+
+	digits := h.billingService.CostDisplaySignificantDigits()
+	results := make(map[string]gin.H, len(req.Models))
+	notFound := make([]string, 0)
+	seen := make(map[string]struct{}, len(req.Models))
+	for _, model := range req.Models {
+		model = strings.TrimSpace(model)
+		if model == "" {
+			continue
+		}
+		// 重复模型名只查询一次
+		if _, ok := seen[model]; ok {
+			continue
+		}
+		seen[model] = struct{}{}
+
+		pricing, err := h.billingService.GetModelPricing(model)
+		if err != nil {
+			notFound = append(notFound, model)
+			continue
+		}
+		results[model] = lookupPricingResponse(pricing, digits)
+	}
+
+	result := gin.H{
+		"pricing":   results,
+		"not_found": notFound,
+	}
+	setDisplayPrecision(result, digits)
+	response.Success(c, result)
+}
+
+// readPricingUpload 读取上传的价格JSON文件（已写入错误响应时返回 false）
+func readPricingUpload(c *gin.Context) ([]byte, bool) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeNoFileUploaded, "No file uploaded")
+		return nil, false
+	}
 	defer func() { _ = file.Close() }()
 
 	// 限制文件大小 50MB
-	if header.Size > 50*1024*1024 {
-		response.Error(c, http.StatusBadRequest, "File too large (max 50MB)")
-		return
+	if header.Size > service.MaxPricingUploadBytes {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeFileTooLarge, "File too large (max 50MB)")
+		return nil, false
 	}
 
 	// 检查文件扩展名
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".json") {
-		response.Error(c, http.StatusBadRequest, "Only JSON files are accepted")
-		return
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidFileType, "Only JSON files are accepted")
+		return nil, false
 	}
 
 	body, err := io.ReadAll(file)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to read file: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to read file: "+err.Error())
+		return nil, false
+	}
+	return body, true
+}
+
+// 价格上传模式
+const (
+	pricingUploadModeReplace = "replace" // 替换全部价格数据（默认）
+	pricingUploadModeMerge   = "merge"   // 仅新增/更新上传文件中的模型
+)
+
+// UploadPricing 手动上传价格JSON文件
+// POST /api/v1/admin/pricing/upload（表单字段 mode=replace|merge，默认 replace；
+// strict=true 时文件中存在重复模型即拒绝导入）
+func (h *PricingHandler) UploadPricing(c *gin.Context) {
+	body, ok := readPricingUpload(c)
+	if !ok {
+		return
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(c.PostForm("mode")))
+	if mode == "" {
+		mode = pricingUploadModeReplace
+	}
+	strict := false
+	if raw := strings.TrimSpace(c.PostForm("strict")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid strict: must be a boolean")
+			return
+		}
+		strict = v
+	}
+
+	switch mode {
+	case pricingUploadModeReplace:
+		result, err := h.billingService.ImportPricingData(body, strict)
+		if respondPricingUploadError(c, err) {
+			return
+		}
+		response.Success(c, gin.H{
+			"message":         "Pricing data imported successfully",
+			"mode":            mode,
+			"model_count":     result.Total,
+			"collisions":      result.Collisions,
+			"collision_count": len(result.Collisions),
+			"status":          h.billingService.GetPricingServiceStatus(),
+		})
+	case pricingUploadModeMerge:
+		result, err := h.billingService.MergePricingData(body, strict)
+		if respondPricingUploadError(c, err) {
+			return
+		}
+		response.Success(c, gin.H{
+			"message":         "Pricing data merged successfully",
+			"mode":            mode,
+			"model_count":     result.Total,
+			"added":           result.Added,
+			"updated":         result.Updated,
+			"untouched":       result.Untouched,
+			"collisions":      result.Collisions,
+			"collision_count": len(result.Collisions),
+			"status":          h.billingService.GetPricingServiceStatus(),
+		})
+	default:
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid mode: must be replace or merge")
+	}
+}
+
+// ImportPricingURLRequest 从 URL 导入价格数据请求
+type ImportPricingURLRequest struct {
+	URL    string `json:"url" binding:"required"`
+	Strict bool   `json:"strict"`
+	// SHA256 可选，下载内容的十六进制 SHA-256；不一致时拒绝导入
+	SHA256 string `json:"sha256"`
+}
+
+// ImportPricingURL 从远程 URL 拉取价格 JSON 并以替换模式导入
+// POST /api/v1/admin/pricing/import-url
+func (h *PricingHandler) ImportPricingURL(c *gin.Context) {
+	var req ImportPricingURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
-	count, err := h.billingService.ImportPricingData(body)
+	url, err := h.billingService.ValidatePricingImportURL(req.URL)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidURL, "Invalid url: "+err.Error())
+		return
+	}
+	body, err := h.billingService.FetchPricingImportURL(c.Request.Context(), url)
+	if errors.Is(err, service.ErrPricingImportTooLarge) {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeFileTooLarge, "File too large (max 50MB)")
+		return
+	}
+	if errors.Is(err, service.ErrPricingDataIncomplete) {
+		response.ErrorWithCode(c, http.StatusBadGateway, response.CodePricingDataIncomplete, "Pricing source returned incomplete data: "+err.Error())
+		return
+	}
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Failed to import pricing data: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadGateway, response.CodePricingFetchFailed, "Failed to fetch pricing data: "+err.Error())
+		return
+	}
+	if err := service.VerifyPricingChecksum(body, req.SHA256); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingChecksumMismatch, err.Error())
 		return
 	}
 
-	status := h.billingService.GetPricingServiceStatus()
+	result, err := h.billingService.ImportPricingData(body, req.Strict)
+	if respondPricingUploadError(c, err) {
+		return
+	}
+	response.Success(c, gin.H{
+		"message":         "Pricing data imported successfully",
+		"mode":            pricingUploadModeReplace,
+		"model_count":     result.Total,
+		"collisions":      result.Collisions,
+		"collision_count": len(result.Collisions),
+		"status":          h.billingService.GetPricingServiceStatus(),
+	})
+}
+
+// respondPricingUploadError 写入导入失败响应（校验错误逐条返回），已写入时返回 true
+func respondPricingUploadError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, service.ErrPricingDataIncomplete) {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingDataIncomplete, "Pricing data is incomplete (truncated): "+err.Error())
+		return true
+	}
+	var validationErrs service.PricingValidationErrors
+	if errors.As(err, &validationErrs) {
+		response.ErrorWithCodeAndData(c, http.StatusBadRequest, response.CodeInvalidPricingData, "Invalid pricing data", gin.H{
+			"errors":      validationErrs,
+			"error_count": len(validationErrs),
+		})
+		return true
+	}
+	var collisionErr *service.PricingCollisionError
+	if errors.As(err, &collisionErr) {
+		response.ErrorWithCodeAndData(c, http.StatusBadRequest, response.CodeDuplicateModels, "Pricing data contains duplicate models", gin.H{
+			"collisions":      collisionErr.Collisions,
+			"collision_count": len(collisionErr.Collisions),
+		})
+		return true
+	}
+	response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidJSON, "Failed to import pricing data: "+err.Error())
+	return true
+}
+
+// SetPricingOverrideRequest 设置价格覆盖请求（per-token，USD）
+type SetPricingOverrideRequest struct {
+	Model              string   `json:"model" binding:"required"`
+	InputCostPerToken  *float64 `json:"input_cost_per_token" binding:"required"`
+	OutputCostPerToken *float64 `json:"output_cost_per_token" binding:"required"`
+}
+
+// SetOverride 设置模型价格覆盖（价格数据更新后依然生效）
+// POST /api/v1/admin/pricing/override
+func (h *PricingHandler) SetOverride(c *gin.Context) {
+	var req SetPricingOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	override, err := h.billingService.SetPricingOverride(req.Model, *req.InputCostPerToken, *req.OutputCostPerToken)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set pricing override: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"model":    strings.ToLower(strings.TrimSpace(req.Model)),
+		"override": override,
+	})
+}
+
+// RemoveOverride 删除模型价格覆盖
+// DELETE /api/v1/admin/pricing/override?model=xxx
+func (h *PricingHandler) RemoveOverride(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model parameter is required")
+		return
+	}
+
+	removed, err := h.billingService.RemovePricingOverride(model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove pricing override: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeOverrideNotFound, "Pricing override not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Pricing override removed"})
+}
+
+// ClonePricingRequest 复制模型价格请求
+type ClonePricingRequest struct {
+	From      string `json:"from" binding:"required"`
+	To        string `json:"to" binding:"required"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+// ClonePricing 以已有模型的全部定价字段创建新模型的价格覆盖（价格数据更新后依然生效）
+// POST /api/v1/admin/pricing/clone
+func (h *PricingHandler) ClonePricing(c *gin.Context) {
+	var req ClonePricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	override, err := h.billingService.ClonePricing(req.From, req.To, req.Overwrite)
+	switch {
+	case errors.Is(err, service.ErrPricingCloneSourceNotFound):
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodePricingNotFound, "Source model pricing not found")
+		return
+	case errors.Is(err, service.ErrPricingCloneTargetExists):
+		response.ErrorWithCode(c, http.StatusConflict, response.CodeConflict, "Target model already has pricing, set overwrite=true to replace it")
+		return
+	case err != nil:
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to clone pricing: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"model":    strings.ToLower(strings.TrimSpace(req.To)),
+		"override": override,
+	})
+}
+
+// ModelToggleRequest 启用/禁用模型请求
+type ModelToggleRequest struct {
+	Model string `json:"model" binding:"required"`
+}
+
+// DisableModel 禁用模型（价格查询返回 model disabled，列表中标记 disabled）
+// POST /api/v1/admin/pricing/disable
+func (h *PricingHandler) DisableModel(c *gin.Context) {
+	var req ModelToggleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	changed, err := h.billingService.DisableModel(req.Model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to disable model: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"model":    strings.ToLower(strings.TrimSpace(req.Model)),
+		"disabled": true,
+		"changed":  changed,
+	})
+}
+
+// EnableModel 取消模型禁用
+// POST /api/v1/admin/pricing/enable
+func (h *PricingHandler) EnableModel(c *gin.Context) {
+	var req ModelToggleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	changed, err := h.billingService.EnableModel(req.Model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to enable model: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"model":    strings.ToLower(strings.TrimSpace(req.Model)),
+		"disabled": false,
+		"changed":  changed,
+	})
+}
+
+// ModelBulkToggleRequest 按模式批量启用/禁用模型请求
+type ModelBulkToggleRequest struct {
+	Pattern  string `json:"pattern" binding:"required"` // glob 模式，如 gpt-3.5*
+	Provider string `json:"provider"`                   // 可选：仅匹配该厂商的模型
+}
+
+// BulkDisableModels 按 glob 模式批量禁用模型
+// POST /api/v1/admin/pricing/bulk-disable
+func (h *PricingHandler) BulkDisableModels(c *gin.Context) {
+	h.bulkToggleModels(c, true)
+}
+
+// BulkEnableModels 按 glob 模式批量取消模型禁用
+// POST /api/v1/admin/pricing/bulk-enable
+func (h *PricingHandler) BulkEnableModels(c *gin.Context) {
+	h.bulkToggleModels(c, false)
+}
+
+func (h *PricingHandler) bulkToggleModels(c *gin.Context, disabled bool) {
+	var req ModelBulkToggleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	models, err := h.billingService.SetModelsDisabledByPattern(req.Pattern, req.Provider, disabled)
+	if errors.Is(err, service.ErrPricingInvalidModelPattern) {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to update models: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"pattern":  strings.ToLower(strings.TrimSpace(req.Pattern)),
+		"provider": strings.ToLower(strings.TrimSpace(req.Provider)),
+		"disabled": disabled,
+		"count":    len(models),
+		"models":   models,
+	})
+}
+
+// ProviderToggleRequest 启用/禁用厂商请求
+type ProviderToggleRequest struct {
+	Provider string `json:"provider" binding:"required"`
+}
+
+// DisableProvider 禁用厂商（其模型价格查询返回 provider disabled，同名平台账号不再被调度）
+// POST /api/v1/admin/pricing/providers/disable
+func (h *PricingHandler) DisableProvider(c *gin.Context) {
+	var req ProviderToggleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	changed, err := h.billingService.DisableProvider(req.Provider)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to disable provider: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"provider": strings.ToLower(strings.TrimSpace(req.Provider)),
+		"enabled":  false,
+		"changed":  changed,
+	})
+}
+
+// EnableProvider 取消厂商禁用
+// POST /api/v1/admin/pricing/providers/enable
+func (h *PricingHandler) EnableProvider(c *gin.Context) {
+	var req ProviderToggleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	changed, err := h.billingService.EnableProvider(req.Provider)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to enable provider: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"provider": strings.ToLower(strings.TrimSpace(req.Provider)),
+		"enabled":  true,
+		"changed":  changed,
+	})
+}
+
+// SetModelAliasRequest 设置模型别名请求
+type SetModelAliasRequest struct {
+	Alias string `json:"alias" binding:"required"`
+	Model string `json:"model" binding:"required"`
+}
+
+// ListModelAliases 获取全部模型别名
+// GET /api/v1/admin/pricing/aliases
+func (h *PricingHandler) ListModelAliases(c *gin.Context) {
+	response.Success(c, h.billingService.ListModelAliases())
+}
+
+// SetModelAlias 创建或更新模型别名（价格数据更新后依然生效）
+// POST /api/v1/admin/pricing/aliases
+func (h *PricingHandler) SetModelAlias(c *gin.Context) {
+	var req SetModelAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	alias, err := h.billingService.SetModelAlias(req.Alias, req.Model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set model alias: "+err.Error())
+		return
+	}
+
+	response.Success(c, alias)
+}
+
+// RemoveModelAlias 删除模型别名
+// DELETE /api/v1/admin/pricing/aliases?alias=xxx
+func (h *PricingHandler) RemoveModelAlias(c *gin.Context) {
+	alias := strings.TrimSpace(c.Query("alias"))
+	if alias == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "alias parameter is required")
+		return
+	}
+
+	removed, err := h.billingService.RemoveModelAlias(alias)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove model alias: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeAliasNotFound, "Model alias not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Model alias removed"})
+}
+
+// SetMarkupRequest 设置加价请求（model 与 provider 均为空时设置全局加价，二者不能同时指定）
+type SetMarkupRequest struct {
+	Model       string   `json:"model"`
+	Provider    string   `json:"provider"`
+	Percent     *float64 `json:"percent" binding:"required"`
+	FlatPerMTok *float64 `json:"flat_per_mtok"`
+}
+
+// GetMarkup 获取全局、厂商级与模型级加价
+// GET /api/v1/admin/pricing/markup
+func (h *PricingHandler) GetMarkup(c *gin.Context) {
+	response.Success(c, gin.H{
+		"global":    h.billingService.GetGlobalMarkup(),
+		"providers": h.billingService.ListProviderMarkups(),
+		"models":    h.billingService.ListModelMarkups(),
+	})
+}
+
+// SetMarkup 设置全局、厂商级或模型级加价（持久化，价格数据更新后依然生效；生效顺序 模型级 → 厂商级 → 全局）
+// PUT /api/v1/admin/pricing/markup
+func (h *PricingHandler) SetMarkup(c *gin.Context) {
+	var req SetMarkupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	flat := 0.0
+	if req.FlatPerMTok != nil {
+		flat = *req.FlatPerMTok
+	}
+
+	model := strings.ToLower(strings.TrimSpace(req.Model))
+	provider := strings.ToLower(strings.TrimSpace(req.Provider))
+	if model != "" && provider != "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Only one of model or provider may be specified")
+		return
+	}
+	var markup *service.PricingMarkup
+	var err error
+	switch {
+	case model != "":
+		markup, err = h.billingService.SetModelMarkup(model, *req.Percent, flat)
+	case provider != "":
+		markup, err = h.billingService.SetProviderMarkup(provider, *req.Percent, flat)
+	default:
+		markup, err = h.billingService.SetGlobalMarkup(*req.Percent, flat)
+	}
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set markup: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"model":    model,
+		"provider": provider,
+		"markup":   markup,
+	})
+}
+
+// RemoveModelMarkup 删除模型级或厂商级加价（模型回退到厂商级/全局加价）
+// DELETE /api/v1/admin/pricing/markup?model=xxx 或 ?provider=xxx
+func (h *PricingHandler) RemoveModelMarkup(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	provider := strings.TrimSpace(c.Query("provider"))
+	if (model == "") == (provider == "") {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Exactly one of model or provider parameter is required")
+		return
+	}
+
+	var (
+		removed bool
+		err     error
+	)
+	if model != "" {
+		removed, err = h.billingService.RemoveModelMarkup(model)
+	} else {
+		removed, err = h.billingService.RemoveProviderMarkup(provider)
+	}
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove markup: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeMarkupNotFound, "Markup not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Markup removed"})
+}
+
+// SetDefaultPricingRequest 设置未知模型默认价格请求（per-token，USD）
+type SetDefaultPricingRequest struct {
+	InputCostPerToken  *float64 `json:"input_cost_per_token" binding:"required"`
+	OutputCostPerToken *float64 `json:"output_cost_per_token" binding:"required"`
+}
+
+// GetDefaultPricing 获取未知模型的默认价格及来源（none / config / admin）
+// GET /api/v1/admin/pricing/default
+func (h *PricingHandler) GetDefaultPricing(c *gin.Context) {
+	pricing, source := h.billingService.GetDefaultPricing()
+	response.Success(c, gin.H{
+		"pricing": pricing,
+		"source":  source,
+	})
+}
+
+// SetDefaultPricing 设置未知模型的默认价格（持久化，覆盖配置文件）
+// PUT /api/v1/admin/pricing/default
+func (h *PricingHandler) SetDefaultPricing(c *gin.Context) {
+	var req SetDefaultPricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	pricing, err := h.billingService.SetDefaultPricing(*req.InputCostPerToken, *req.OutputCostPerToken)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Failed to set default pricing: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"pricing": pricing,
+		"source":  service.DefaultPricingSourceAdmin,
+	})
+}
+
+// RemoveDefaultPricing 删除管理员设置的默认价格（回退到配置文件）
+// DELETE /api/v1/admin/pricing/default
+func (h *PricingHandler) RemoveDefaultPricing(c *gin.Context) {
+	removed, err := h.billingService.RemoveDefaultPricing()
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove default pricing: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodePricingNotFound, "Default pricing not set")
+		return
+	}
+
+	pricing, source := h.billingService.GetDefaultPricing()
+	response.Success(c, gin.H{
+		"pricing": pricing,
+		"source":  source,
+	})
+}
+
+// SetModelTimeoutRequest 设置模型级请求超时请求
+type SetModelTimeoutRequest struct {
+	Model          string `json:"model" binding:"required"`
+	TimeoutSeconds int    `json:"timeout_seconds" binding:"required"`
+}
+
+// ListModelTimeouts 获取全局默认请求超时与模型级超时
+// GET /api/v1/admin/pricing/timeouts
+func (h *PricingHandler) ListModelTimeouts(c *gin.Context) {
+	response.Success(c, gin.H{
+		"default_timeout_seconds": h.billingService.DefaultModelTimeoutSeconds(),
+		"models":                  h.billingService.ListModelTimeouts(),
+	})
+}
+
+// SetModelTimeout 设置模型级请求超时（持久化，立即生效）
+// PUT /api/v1/admin/pricing/timeouts
+func (h *PricingHandler) SetModelTimeout(c *gin.Context) {
+	var req SetModelTimeoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	model := strings.ToLower(strings.TrimSpace(req.Model))
+	if err := h.billingService.SetModelTimeout(model, req.TimeoutSeconds); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set model timeout: "+err.Error())
+		return
+	}
+
+	response.Success(c, service.ModelTimeoutEntry{Model: model, TimeoutSeconds: req.TimeoutSeconds})
+}
+
+// RemoveModelTimeout 删除模型级请求超时（回退到全局默认）
+// DELETE /api/v1/admin/pricing/timeouts?model=xxx
+func (h *PricingHandler) RemoveModelTimeout(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model parameter is required")
+		return
+	}
+
+	removed, err := h.billingService.RemoveModelTimeout(model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove model timeout: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeTimeoutNotFound, "Model timeout not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Model timeout removed"})
+}
+
+// SetModelBatchMultiplierRequest 设置模型级 batch 倍率请求
+type SetModelBatchMultiplierRequest struct {
+	Model      string  `json:"model" binding:"required"`
+	Multiplier float64 `json:"multiplier" binding:"required"`
+}
+
+// ListBatchMultipliers 获取全局默认 batch 倍率与模型级倍率
+// GET /api/v1/admin/pricing/batch-multipliers
+func (h *PricingHandler) ListBatchMultipliers(c *gin.Context) {
+	response.Success(c, gin.H{
+		"default_multiplier": h.billingService.DefaultBatchMultiplier(),
+		"models":             h.billingService.ListModelBatchMultipliers(),
+	})
+}
+
+// SetModelBatchMultiplier 设置模型级 batch 倍率（持久化，立即生效）
+// PUT /api/v1/admin/pricing/batch-multipliers
+func (h *PricingHandler) SetModelBatchMultiplier(c *gin.Context) {
+	var req SetModelBatchMultiplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	model := strings.ToLower(strings.TrimSpace(req.Model))
+	if err := h.billingService.SetModelBatchMultiplier(model, req.Multiplier); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Failed to set batch multiplier: "+err.Error())
+		return
+	}
+
+	response.Success(c, service.ModelBatchMultiplierEntry{Model: model, Multiplier: req.Multiplier})
+}
+
+// RemoveModelBatchMultiplier 删除模型级 batch 倍率（回退到全局默认）
+// DELETE /api/v1/admin/pricing/batch-multipliers?model=xxx
+func (h *PricingHandler) RemoveModelBatchMultiplier(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model parameter is required")
+		return
+	}
+
+	removed, err := h.billingService.RemoveModelBatchMultiplier(model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove batch multiplier: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeBatchMultiplierNotFound, "Batch multiplier not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Batch multiplier removed"})
+}
+
+// SetCommittedDiscountRequest 设置承诺用量折扣请求（model 与 provider 二选一）
+type SetCommittedDiscountRequest struct {
+	Model    string   `json:"model"`
+	Provider string   `json:"provider"`
+	Percent  *float64 `json:"percent" binding:"required"`
+}
+
+// ListCommittedDiscounts 获取厂商级与模型级承诺用量折扣
+// GET /api/v1/admin/pricing/committed-discounts
+func (h *PricingHandler) ListCommittedDiscounts(c *gin.Context) {
+	response.Success(c, h.billingService.ListCommittedDiscounts())
+}
+
+// SetCommittedDiscount 设置厂商级或模型级承诺用量折扣（持久化，立即生效；模型级优先于厂商级）
+// PUT /api/v1/admin/pricing/committed-discounts
+func (h *PricingHandler) SetCommittedDiscount(c *gin.Context) {
+	var req SetCommittedDiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	model := strings.ToLower(strings.TrimSpace(req.Model))
+	provider := strings.ToLower(strings.TrimSpace(req.Provider))
+	if (model == "") == (provider == "") {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Exactly one of model or provider is required")
+		return
+	}
+
+	var err error
+	entry := service.CommittedDiscountEntry{Model: model, Provider: provider, Percent: *req.Percent}
+	if model != "" {
+		err = h.billingService.SetModelCommittedDiscount(model, *req.Percent)
+	} else {
+		err = h.billingService.SetProviderCommittedDiscount(provider, *req.Percent)
+	}
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Failed to set committed discount: "+err.Error())
+		return
+	}
+
+	response.Success(c, entry)
+}
+
+// RemoveCommittedDiscount 删除厂商级或模型级承诺用量折扣
+// DELETE /api/v1/admin/pricing/committed-discounts?model=xxx 或 ?provider=xxx
+func (h *PricingHandler) RemoveCommittedDiscount(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	provider := strings.TrimSpace(c.Query("provider"))
+	if (model == "") == (provider == "") {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Exactly one of model or provider parameter is required")
+		return
+	}
+
+	var (
+		removed bool
+		err     error
+	)
+	if model != "" {
+		removed, err = h.billingService.RemoveModelCommittedDiscount(model)
+	} else {
+		removed, err = h.billingService.RemoveProviderCommittedDiscount(provider)
+	}
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove committed discount: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeCommittedDiscountNotFound, "Committed discount not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Committed discount removed"})
+}
+
+// SetModelMinChargeRequest 设置模型级最低收费请求
+type SetModelMinChargeRequest struct {
+	Model     string   `json:"model" binding:"required"`
+	MinCharge *float64 `json:"min_charge" binding:"required"`
+}
+
+// ListMinCharges 获取全局默认最低收费与模型级最低收费
+// GET /api/v1/admin/pricing/min-charges
+func (h *PricingHandler) ListMinCharges(c *gin.Context) {
+	response.Success(c, gin.H{
+		"default_min_charge": h.billingService.DefaultMinCharge(),
+		"models":             h.billingService.ListModelMinCharges(),
+	})
+}
+
+// SetModelMinCharge 设置模型级最低收费（持久化，立即生效；0 表示该模型不收取最低费用）
+// PUT /api/v1/admin/pricing/min-charges
+func (h *PricingHandler) SetModelMinCharge(c *gin.Context) {
+	var req SetModelMinChargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	model := strings.ToLower(strings.TrimSpace(req.Model))
+	if err := h.billingService.SetModelMinCharge(model, *req.MinCharge); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Failed to set min charge: "+err.Error())
+		return
+	}
+
+	response.Success(c, service.ModelMinChargeEntry{Model: model, MinCharge: *req.MinCharge})
+}
+
+// RemoveModelMinCharge 删除模型级最低收费（回退到全局默认）
+// DELETE /api/v1/admin/pricing/min-charges?model=xxx
+func (h *PricingHandler) RemoveModelMinCharge(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model parameter is required")
+		return
+	}
+
+	removed, err := h.billingService.RemoveModelMinCharge(model)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodePricingUpdateFailed, "Failed to remove min charge: "+err.Error())
+		return
+	}
+	if !removed {
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeMinChargeNotFound, "Min charge not found")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Min charge removed"})
+}
+
+// SetExchangeRateRequest 设置汇率请求
+type SetExchangeRateRequest struct {
+	Currency string  `json:"currency" binding:"required"`
+	Rate     float64 `json:"rate" binding:"required"`
+}
+
+// ListExchangeRates 获取已配置的汇率
+// GET /api/v1/admin/pricing/exchange-rates
+func (h *PricingHandler) ListExchangeRates(c *gin.Context) {
+	response.Success(c, gin.H{
+		"base_currency": service.BaseCurrency,
+		"rates":         h.billingService.GetExchangeRates(),
+	})
+}
+
+// SetExchangeRate 设置币种汇率（1 USD 兑换的目标币种金额）
+// POST /api/v1/admin/pricing/exchange-rates
+func (h *PricingHandler) SetExchangeRate(c *gin.Context) {
+	var req SetExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.billingService.SetExchangeRate(req.Currency, req.Rate); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodePricingUpdateFailed, "Failed to set exchange rate: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"currency": strings.ToUpper(strings.TrimSpace(req.Currency)),
+		"rate":     req.Rate,
+	})
+}
+
+// EstimateCostRequest 费用预估请求
+type EstimateCostRequest struct {
+	Model               string `json:"model" binding:"required"`
+	InputTokens         int    `json:"input_tokens"`
+	OutputTokens        int    `json:"output_tokens"`
+	CacheReadTokens     int    `json:"cache_read_tokens"`
+	CacheCreationTokens int    `json:"cache_creation_tokens"`
+	Batch               bool   `json:"batch"`  // 按 batch API 折扣价预估
+	Images              int    `json:"images"` // 生成图片数量（按每张图片价格计费）
+	// 缓存命中率 / 缓存创建占比（0~1）：设置时 input_tokens 为全部输入 token，按比例拆分计费
+	CacheHitRatio      *float64 `json:"cache_hit_ratio"`
+	CacheCreationRatio *float64 `json:"cache_creation_ratio"`
+}
+
+// EstimateCost 预估一次假设请求的费用
+// POST /api/v1/admin/pricing/estimate
+func (h *PricingHandler) EstimateCost(c *gin.Context) {
+	var req EstimateCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	estimate, err := h.billingService.EstimateCost(service.CostEstimateInput{
+		Model:               req.Model,
+		InputTokens:         req.InputTokens,
+		OutputTokens:        req.OutputTokens,
+		CacheReadTokens:     req.CacheReadTokens,
+		CacheCreationTokens: req.CacheCreationTokens,
+		Batch:               req.Batch,
+		Images:              req.Images,
+		CacheHitRatio:       req.CacheHitRatio,
+		CacheCreationRatio:  req.CacheCreationRatio,
+	})
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Failed to estimate cost: "+err.Error())
+		return
+	}
+
+	estimate.RoundForDisplay(h.billingService.CostDisplaySignificantDigits())
+	response.Success(c, estimate)
+}
+
+// pricingCompareDefaultLimit / pricingCompareMaxLimit 费用对比返回条数的默认值与上限
+const (
+	pricingCompareDefaultLimit = 50
+	pricingCompareMaxLimit     = 500
+)
+
+// CompareCostRequest 跨模型费用对比请求
+type CompareCostRequest struct {
+	InputTokens         int    `json:"input_tokens"`
+	OutputTokens        int    `json:"output_tokens"`
+	CacheReadTokens     int    `json:"cache_read_tokens"`
+	CacheCreationTokens int    `json:"cache_creation_tokens"`
+	Provider            string `json:"provider"`
+	Limit               int    `json:"limit"`
+}
+
+// CompareCost 按同一用量对比所有模型的费用（按总费用升序）
+// POST /api/v1/admin/pricing/compare
+func (h *PricingHandler) CompareCost(c *gin.Context) {
+	var req CompareCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	if req.Limit < 0 {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid limit: must be non-negative")
+		return
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = pricingCompareDefaultLimit
+	}
+	if limit > pricingCompareMaxLimit {
+		limit = pricingCompareMaxLimit
+	}
+
+	items, total, err := h.billingService.CompareModelCosts(service.CostCompareInput{
+		InputTokens:         req.InputTokens,
+		OutputTokens:        req.OutputTokens,
+		CacheReadTokens:     req.CacheReadTokens,
+		CacheCreationTokens: req.CacheCreationTokens,
+		Provider:            req.Provider,
+		Limit:               limit,
+	})
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Failed to compare cost: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items": items,
+		"total": total,
+		"limit": limit,
+	})
+}
+
+// GetValueRanking 按每美元可购买的输出 / 输入 token 数分别降序排列模型（limit 默认 50、上限 500），用于向用户推荐性价比模型
+// GET /api/v1/admin/pricing/value?provider=openai&limit=20
+// 免费及无 per-token 价格的模型不参与排名，在 excluded 中标注原因（free / unpriced）。
+func (h *PricingHandler) GetValueRanking(c *gin.Context) {
+	limit := pricingCompareDefaultLimit
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid limit: must be non-negative")
+			return
+		}
+		if v > 0 {
+			limit = min(v, pricingCompareMaxLimit)
+		}
+	}
+
+	ranking := h.billingService.RankModelValue(c.Query("provider"), limit)
+	response.Success(c, gin.H{
+		"by_output": ranking.ByOutput,
+		"by_input":  ranking.ByInput,
+		"excluded":  ranking.Excluded,
+		"limit":     limit,
+	})
+}
+
+// DiffPricing 预览上传的价格文件与当前数据的差异（不导入）
+// POST /api/v1/admin/pricing/diff
+func (h *PricingHandler) DiffPricing(c *gin.Context) {
+	body, ok := readPricingUpload(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.billingService.DiffPricingData(body)
+	if err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidJSON, "Failed to parse pricing data: "+err.Error())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"added":         diff.Added,
+		"removed":       diff.Removed,
+		"changed":       diff.Changed,
+		"added_count":   len(diff.Added),
+		"removed_count": len(diff.Removed),
+		"changed_count": len(diff.Changed),
+	})
+}
+
+// ProviderPricingStats 单个厂商的价格聚合统计（成本单位：USD / 1M tokens）
+type ProviderPricingStats struct {
+	Provider                string  `json:"provider"`
+	ModelCount              int     `json:"model_count"`
+	MinInputCostPerMTok     float64 `json:"min_input_cost_per_mtok"`
+	MaxInputCostPerMTok     float64 `json:"max_input_cost_per_mtok"`
+	AvgInputCostPerMTok     float64 `json:"avg_input_cost_per_mtok"`
+	MinOutputCostPerMTok    float64 `json:"min_output_cost_per_mtok"`
+	MaxOutputCostPerMTok    float64 `json:"max_output_cost_per_mtok"`
+	AvgOutputCostPerMTok    float64 `json:"avg_output_cost_per_mtok"`
+	PromptCachingModelCount int     `json:"prompt_caching_model_count"`
+}
+
+// unknownPricingProvider 价格数据未标注厂商时的归类名称
+const unknownPricingProvider = "unknown"
+
+// GetProviderStats 按厂商聚合价格统计（按厂商名排序）
+// GET /api/v1/admin/pricing/providers/stats
+func (h *PricingHandler) GetProviderStats(c *gin.Context) {
+	statsByProvider := make(map[string]*ProviderPricingStats)
+	for _, pricing := range h.billingService.GetAllPricing() {
+		provider := pricing.Provider
+		if provider == "" {
+			provider = unknownPricingProvider
+		}
+		inputMTok := pricing.InputCostPerToken * 1_000_000
+		outputMTok := pricing.OutputCostPerToken * 1_000_000
+
+		stats, ok := statsByProvider[provider]
+		if !ok {
+			stats = &ProviderPricingStats{
+				Provider:             provider,
+				MinInputCostPerMTok:  inputMTok,
+				MaxInputCostPerMTok:  inputMTok,
+				MinOutputCostPerMTok: outputMTok,
+				MaxOutputCostPerMTok: outputMTok,
+			}
+			statsByProvider[provider] = stats
+		}
+		stats.ModelCount++
+		stats.MinInputCostPerMTok = min(stats.MinInputCostPerMTok, inputMTok)
+		stats.MaxInputCostPerMTok = max(stats.MaxInputCostPerMTok, inputMTok)
+		stats.MinOutputCostPerMTok = min(stats.MinOutputCostPerMTok, outputMTok)
+		stats.MaxOutputCostPerMTok = max(stats.MaxOutputCostPerMTok, outputMTok)
+		// 先累加总和，最后再求平均
+		stats.AvgInputCostPerMTok += inputMTok
+		stats.AvgOutputCostPerMTok += outputMTok
+		if pricing.SupportsPromptCaching {
+			stats.PromptCachingModelCount++
+		}
+	}
+
+	result := make([]ProviderPricingStats, 0, len(statsByProvider))
+	for _, stats := range statsByProvider {
+		stats.AvgInputCostPerMTok /= float64(stats.ModelCount)
+		stats.AvgOutputCostPerMTok /= float64(stats.ModelCount)
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+
+	response.Success(c, gin.H{
+		"providers": result,
+		"total":     len(result),
+	})
+}
+
+// PricingCoverageCounts 价格数据完整度计数
+type PricingCoverageCounts struct {
+	TotalModels          int     `json:"total_models"`
+	CompletePricing      int     `json:"complete_pricing"`       // 输入与输出价格均已设置（或显式标记为免费）
+	MissingInputCost     int     `json:"missing_input_cost"`     // 输入价格缺失（<= 0）
+	MissingOutputCost    int     `json:"missing_output_cost"`    // 输出价格缺失（<= 0，按张计费的图片模型除外）
+	SupportsCaching      int     `json:"supports_caching"`       // 支持 prompt caching
+	DisabledModels       int     `json:"disabled_models"`        // 已被管理员禁用
+	OverriddenModels     int     `json:"overridden_models"`      // 存在管理员覆盖价格
+	CompletePricingRatio float64 `json:"complete_pricing_ratio"` // CompletePricing / TotalModels
+}
+
+// ProviderPricingCoverage 单个厂商的价格数据完整度
+type ProviderPricingCoverage struct {
+	Provider string `json:"provider"`
+	PricingCoverageCounts
+}
+
+// add 计入一个模型
+func (c *PricingCoverageCounts) add(pricing *service.ModelPricingInfo) {
+	c.TotalModels++
+	missingInput := pricing.InputCostPerToken <= 0
+	missingOutput := pricing.OutputCostPerToken <= 0 && pricing.OutputCostPerImage <= 0
+	if missingInput {
+		c.MissingInputCost++
+	}
+	if missingOutput {
+		c.MissingOutputCost++
+	}
+	if pricing.IsFree || (!missingInput && !missingOutput) {
+		c.CompletePricing++
+	}
+	if pricing.SupportsPromptCaching {
+		c.SupportsCaching++
+	}
+	if pricing.Disabled {
+		c.DisabledModels++
+	}
+	if pricing.Overridden {
+		c.OverriddenModels++
+	}
+}
+
+func (c *PricingCoverageCounts) finish() {
+	if c.TotalModels > 0 {
+		c.CompletePricingRatio = float64(c.CompletePricing) / float64(c.TotalModels)
+	}
+}
+
+// GetPricingCoverage 价格目录规模与完整度统计，用于导入后发现不完整的条目（厂商按名称排序）
+// GET /api/v1/admin/pricing/coverage
+func (h *PricingHandler) GetPricingCoverage(c *gin.Context) {
+	var total PricingCoverageCounts
+	byProvider := make(map[string]*ProviderPricingCoverage)
+	for _, pricing := range h.billingService.GetAllPricing() {
+		provider := pricing.Provider
+		if provider == "" {
+			provider = unknownPricingProvider
+		}
+		coverage, ok := byProvider[provider]
+		if !ok {
+			coverage = &ProviderPricingCoverage{Provider: provider}
+			byProvider[provider] = coverage
+		}
+		coverage.add(pricing)
+		total.add(pricing)
+	}
+	total.finish()
+
+	providers := make([]ProviderPricingCoverage, 0, len(byProvider))
+	for _, coverage := range byProvider {
+		coverage.finish()
+		providers = append(providers, *coverage)
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Provider < providers[j].Provider })
+
 	response.Success(c, gin.H{
-		"message":     "Pricing data imported successfully",
-		"model_count": count,
-		"status":      status,
+		"summary":   total,
+		"providers": providers,
 	})
 }
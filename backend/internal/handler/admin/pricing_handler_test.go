@@ -0,0 +1,845 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// newPricingHandlerWithModels 构造带有 n 个 openai 模型与 1 个 anthropic 模型的价格处理器
+func newPricingHandlerWithModels(t *testing.T, n int) *PricingHandler {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+
+	entries := make([]string, 0, n+1)
+	for i := 0; i < n; i++ {
+		entries = append(entries, fmt.Sprintf(`"model-%03d":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat"}`, i))
+	}
+	entries = append(entries, `"claude-x":{"input_cost_per_token":3e-6,"output_cost_per_token":1.5e-5,"litellm_provider":"anthropic","mode":"chat"}`)
+
+	pricingSvc := service.NewPricingService(cfg, nil)
+	_, err := pricingSvc.ImportPricingData([]byte("{"+strings.Join(entries, ",")+"}"), false)
+	require.NoError(t, err)
+
+	return NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+}
+
+type stubPricingRemoteClient struct {
+	body string
+}
+
+func (c *stubPricingRemoteClient) FetchPricingJSON(context.Context, string) ([]byte, error) {
+	return []byte(c.body), nil
+}
+
+func (c *stubPricingRemoteClient) FetchHashText(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func doPricingRequest(t *testing.T, handler gin.HandlerFunc, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		c.Request.Header.Set("Content-Type", "application/json")
+	}
+	handler(c)
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp.Data
+}
+
+func TestListPricing_PaginatesAfterFiltering(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 120)
+
+	code, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?provider=openai&page=3&page_size=50", "")
+	require.Equal(t, http.StatusOK, code)
+	require.EqualValues(t, 120, data["total"])
+	require.EqualValues(t, 3, data["page"])
+	require.EqualValues(t, 3, data["total_pages"])
+	items := data["items"].([]any)
+	require.Len(t, items, 20)
+	require.Equal(t, "model-100", items[0].(map[string]any)["model"])
+}
+
+func TestListPricing_DefaultPageSize(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 60)
+
+	_, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/", "")
+	require.EqualValues(t, 61, data["total"])
+	require.EqualValues(t, 50, data["page_size"])
+	require.Len(t, data["items"].([]any), 50)
+	// 排序稳定：anthropic 在 openai 之前
+	require.Equal(t, "claude-x", data["items"].([]any)[0].(map[string]any)["model"])
+}
+
+func TestListPricing_UnknownCurrencyRejected(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?currency=EUR", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	require.NoError(t, h.billingService.SetExchangeRate("EUR", 0.5))
+	code, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?currency=eur&search=claude-x", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "EUR", data["currency"])
+	item := data["items"].([]any)[0].(map[string]any)
+	require.InDelta(t, 1.5, item["input_cost_per_mtok"], 1e-9)
+}
+
+func TestGetProviderStats_AggregatesPerProvider(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 3)
+
+	code, data := doPricingRequest(t, h.GetProviderStats, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+
+	providers := data["providers"].([]any)
+	require.Len(t, providers, 2)
+	anthropic := providers[0].(map[string]any)
+	openai := providers[1].(map[string]any)
+	require.Equal(t, "anthropic", anthropic["provider"])
+	require.Equal(t, "openai", openai["provider"])
+	require.InDelta(t, 3, openai["model_count"], 0)
+	require.InDelta(t, 1.0, openai["avg_input_cost_per_mtok"], 1e-9)
+	require.InDelta(t, 15.0, anthropic["max_output_cost_per_mtok"], 1e-9)
+}
+
+// doPricingUpload 以 multipart 表单上传价格文件，fields 为附加表单字段
+func doPricingUpload(t *testing.T, handler gin.HandlerFunc, content string, fields map[string]string) (int, map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", "pricing.json")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	for k, v := range fields {
+		require.NoError(t, writer.WriteField(k, v))
+	}
+	require.NoError(t, writer.Close())
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", &buf)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	handler(c)
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp.Data
+}
+
+func TestUploadPricing_ReturnsAllValidationErrors(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, data := doPricingUpload(t, h.UploadPricing, `{
+		"a": {"input_cost_per_token": -1, "litellm_provider": "openai"},
+		"b": {"input_cost_per_token": 1e-6}
+	}`, nil)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.InDelta(t, 2, data["error_count"], 0)
+	require.Len(t, data["errors"].([]any), 2)
+}
+
+func TestUploadPricing_MergeModeKeepsExistingModels(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 2)
+
+	code, data := doPricingUpload(t, h.UploadPricing, `{
+		"model-000": {"input_cost_per_token": 5e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"model-001": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai", "mode": "chat"},
+		"brand-new": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"}
+	}`, map[string]string{"mode": "merge"})
+	require.Equal(t, http.StatusOK, code)
+	require.InDelta(t, 1, data["added"], 0)
+	require.InDelta(t, 1, data["updated"], 0)
+	require.InDelta(t, 2, data["untouched"], 0)
+	require.InDelta(t, 4, data["model_count"], 0)
+
+	all := h.billingService.GetAllPricing()
+	require.Contains(t, all, "claude-x")
+	require.InDelta(t, 5e-6, all["model-000"].InputCostPerToken, 1e-12)
+}
+
+func TestUploadPricing_RejectsUnknownMode(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingUpload(t, h.UploadPricing, `{}`, map[string]string{"mode": "append"})
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestExportPricing_CSVHonorsFilters(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 2)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?format=csv&provider=anthropic", nil)
+	h.ExportPricing(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Equal(t, []string{
+		"model,provider,mode,input_cost_per_mtok,output_cost_per_mtok,supports_prompt_caching",
+		"claude-x,anthropic,chat,3,15,false",
+	}, lines)
+}
+
+func TestExportPricing_JSONArray(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 2)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?format=json&search=model-", nil)
+	h.ExportPricing(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var items []ModelPricingItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	require.Len(t, items, 2)
+}
+
+func TestListPricing_BlendedCost(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?provider=anthropic", "")
+	require.Equal(t, http.StatusOK, code)
+	require.InDelta(t, 3, data["io_ratio"], 0)
+	item := data["items"].([]any)[0].(map[string]any)
+	require.InDelta(t, 6.0, item["blended_cost_per_mtok"], 1e-9)
+
+	_, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?provider=anthropic&io_ratio=1", "")
+	item = data["items"].([]any)[0].(map[string]any)
+	require.InDelta(t, 9.0, item["blended_cost_per_mtok"], 1e-9)
+
+	code, _ = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?io_ratio=-2", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestCountTokens_UsesModelTokenizer(t *testing.T) {
+	billing := service.NewBillingService(&config.Config{}, nil)
+	billing.SetTokenCounter(service.NewTokenCounter())
+	h := NewPricingHandler(billing)
+
+	code, data := doPricingRequest(t, h.CountTokens, http.MethodGet, "/?model=gpt-4o&text=hello%20world", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, service.TokenizerO200K, data["tokenizer"])
+	require.EqualValues(t, 2, data["tokens"])
+	require.EqualValues(t, 11, data["chars"])
+
+	code, _ = doPricingRequest(t, h.CountTokens, http.MethodGet, "/?text=hello", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestModelAliasCRUD_AndListPricingIncludesAliases(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.SetModelAlias, http.MethodPost, "/", `{"alias":"Claude","model":"claude-x"}`)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.SetModelAlias, http.MethodPost, "/", `{"alias":"claude-x","model":"claude-x"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	_, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?include_aliases=true", "")
+	first := data["items"].([]any)[0].(map[string]any)
+	require.Equal(t, "claude-x", first["model"])
+	require.Equal(t, []any{"claude"}, first["aliases"])
+
+	_, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/", "")
+	_, hasAliases := data["items"].([]any)[0].(map[string]any)["aliases"]
+	require.False(t, hasAliases)
+
+	code, _ = doPricingRequest(t, h.RemoveModelAlias, http.MethodDelete, "/?alias=claude", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.RemoveModelAlias, http.MethodDelete, "/?alias=claude", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestPricingMarkup_ListPricingShowsBaseAndChargedCost(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.SetMarkup, http.MethodPut, "/", `{"model":"claude-x","percent":50}`)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.SetMarkup, http.MethodPut, "/", `{"percent":-1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	_, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?search=claude-x", "")
+	item := data["items"].([]any)[0].(map[string]any)
+	require.Equal(t, "model", item["markup_source"])
+	require.InDelta(t, 3.0, item["base_cost"].(map[string]any)["input_per_mtok"], 1e-9)
+	require.InDelta(t, 4.5, item["charged_cost"].(map[string]any)["input_per_mtok"], 1e-9)
+	require.InDelta(t, 22.5, item["charged_cost"].(map[string]any)["output_per_mtok"], 1e-9)
+
+	_, data = doPricingRequest(t, h.GetMarkup, http.MethodGet, "/", "")
+	require.Len(t, data["models"], 1)
+
+	code, _ = doPricingRequest(t, h.RemoveModelMarkup, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.RemoveModelMarkup, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	_, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?search=claude-x", "")
+	item = data["items"].([]any)[0].(map[string]any)
+	require.Equal(t, "none", item["markup_source"])
+}
+
+func TestPricingTimeouts_SetListRemove(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.SetModelTimeout, http.MethodPut, "/", `{"model":"Claude-X","timeout_seconds":600}`)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.SetModelTimeout, http.MethodPut, "/", `{"model":"claude-x","timeout_seconds":-5}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	_, data := doPricingRequest(t, h.ListModelTimeouts, http.MethodGet, "/", "")
+	require.EqualValues(t, 0, data["default_timeout_seconds"])
+	require.Len(t, data["models"], 1)
+
+	_, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?search=claude-x", "")
+	item := data["items"].([]any)[0].(map[string]any)
+	require.EqualValues(t, 600, item["timeout_seconds"])
+	require.Equal(t, "model", item["timeout_source"])
+
+	code, _ = doPricingRequest(t, h.RemoveModelTimeout, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.RemoveModelTimeout, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestCompareCost_SortedByTotalCostWithLimit(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 3)
+
+	code, data := doPricingRequest(t, h.CompareCost, http.MethodPost, "/", `{"input_tokens":1000,"output_tokens":1000,"limit":2}`)
+	require.Equal(t, http.StatusOK, code)
+	require.EqualValues(t, 4, data["total"])
+	items := data["items"].([]any)
+	require.Len(t, items, 2)
+	require.Equal(t, "model-000", items[0].(map[string]any)["model"])
+
+	_, data = doPricingRequest(t, h.CompareCost, http.MethodPost, "/", `{"input_tokens":1000,"provider":"anthropic"}`)
+	require.EqualValues(t, 1, data["total"])
+	require.Equal(t, "claude-x", data["items"].([]any)[0].(map[string]any)["model"])
+
+	code, _ = doPricingRequest(t, h.CompareCost, http.MethodPost, "/", `{"input_tokens":-1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestUploadPricing_StrictRejectsDuplicateModels(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+	upload := `{
+		"dup": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"dup": {"input_cost_per_token": 3e-6, "output_cost_per_token": 4e-6, "litellm_provider": "azure"}
+	}`
+
+	code, data := doPricingUpload(t, h.UploadPricing, upload, map[string]string{"strict": "true"})
+	require.Equal(t, http.StatusBadRequest, code)
+	require.InDelta(t, 1, data["collision_count"], 0)
+	require.NotContains(t, h.billingService.GetAllPricing(), "dup")
+
+	code, data = doPricingUpload(t, h.UploadPricing, upload, map[string]string{"mode": "merge"})
+	require.Equal(t, http.StatusOK, code)
+	require.InDelta(t, 1, data["collision_count"], 0)
+	collision := data["collisions"].([]any)[0].(map[string]any)
+	require.Equal(t, "azure", collision["kept_provider"])
+	require.InDelta(t, 3e-6, h.billingService.GetAllPricing()["dup"].InputCostPerToken, 1e-12)
+
+	code, _ = doPricingUpload(t, h.UploadPricing, upload, map[string]string{"strict": "maybe"})
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestForceUpdate_DryRunReturnsDiffWithoutApplying(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.RemoteURL = "https://example.com/model_prices.json"
+	remote := &stubPricingRemoteClient{body: `{"claude-x":{"input_cost_per_token":4e-6,"output_cost_per_token":1.5e-5,"litellm_provider":"anthropic"},"gpt-new":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai"}}`}
+	pricingSvc := service.NewPricingService(cfg, remote)
+	_, err := pricingSvc.ImportPricingData([]byte(`{"claude-x":{"input_cost_per_token":3e-6,"output_cost_per_token":1.5e-5,"litellm_provider":"anthropic"},"gpt-old":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai"}}`), false)
+	require.NoError(t, err)
+	h := NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+
+	code, data := doPricingRequest(t, h.ForceUpdate, http.MethodPost, "/?dry_run=true", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["dry_run"])
+	require.Equal(t, false, data["applied"])
+	require.EqualValues(t, 1, data["added_count"])
+	require.EqualValues(t, 1, data["removed_count"])
+	require.EqualValues(t, 1, data["changed_count"])
+
+	// 当前数据保持不变
+	require.NotNil(t, pricingSvc.GetModelPricing("gpt-old"))
+	require.InDelta(t, 3e-6, pricingSvc.GetModelPricing("claude-x").InputCostPerToken, 1e-15)
+
+	code, _ = doPricingRequest(t, h.ForceUpdate, http.MethodPost, "/?dry_run=maybe", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, data = doPricingRequest(t, h.ForceUpdate, http.MethodPost, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, false, data["dry_run"])
+	require.InDelta(t, 4e-6, pricingSvc.GetModelPricing("claude-x").InputCostPerToken, 1e-15)
+}
+
+func TestListPricing_FiltersByIsFree(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	pricingSvc := service.NewPricingService(cfg, nil)
+	_, err := pricingSvc.ImportPricingData([]byte(`{
+		"paid-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat"},
+		"promo-model":{"is_free":true,"litellm_provider":"openai","mode":"chat"}
+	}`), false)
+	require.NoError(t, err)
+	h := NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+
+	code, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?is_free=true", "")
+	require.Equal(t, http.StatusOK, code)
+	items := data["items"].([]any)
+	require.Len(t, items, 1)
+	item := items[0].(map[string]any)
+	require.Equal(t, "promo-model", item["model"])
+	require.Equal(t, true, item["is_free"])
+
+	code, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?is_free=false", "")
+	require.Equal(t, http.StatusOK, code)
+	items = data["items"].([]any)
+	require.Len(t, items, 1)
+	require.Equal(t, "paid-model", items[0].(map[string]any)["model"])
+
+	code, _ = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?is_free=maybe", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAutoRefresh_PauseResumeAndInterval(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.SetAutoRefreshInterval, http.MethodPut, "/", `{"interval_hours":0}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, data := doPricingRequest(t, h.SetAutoRefreshInterval, http.MethodPut, "/", `{"interval_hours":12}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(12), data["interval_hours"])
+
+	code, data = doPricingRequest(t, h.ResumeAutoRefresh, http.MethodPost, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, false, data["paused"])
+
+	code, data = doPricingRequest(t, h.PauseAutoRefresh, http.MethodPost, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["paused"])
+	require.Nil(t, data["next_run_at"])
+}
+
+func newPricingHandlerForImportURL(t *testing.T, allowedHosts ...string) *PricingHandler {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.ImportAllowedHosts = allowedHosts
+	cfg.Security.URLAllowlist.AllowPrivateHosts = true
+	return NewPricingHandler(service.NewBillingService(cfg, service.NewPricingService(cfg, nil)))
+}
+
+func TestImportPricingURL_ImportsRemoteJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"gpt-x":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat"}}`))
+	}))
+	defer srv.Close()
+	h := newPricingHandlerForImportURL(t)
+
+	code, data := doPricingRequest(t, h.ImportPricingURL, http.MethodPost, "/", `{"url":"`+srv.URL+`/prices.json"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(1), data["model_count"])
+	require.Equal(t, "replace", data["mode"])
+	require.NotNil(t, data["status"])
+}
+
+func TestImportPricingURL_RejectsDisallowedURLs(t *testing.T) {
+	h := newPricingHandlerForImportURL(t, "prices.example.com")
+
+	for _, target := range []string{"ftp://prices.example.com/p.json", "https://evil.example.org/p.json"} {
+		code, _ := doPricingRequest(t, h.ImportPricingURL, http.MethodPost, "/", `{"url":"`+target+`"}`)
+		require.Equal(t, http.StatusBadRequest, code, target)
+	}
+}
+
+func TestImportPricingURL_RejectsOversizedBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(service.MaxPricingUploadBytes+1))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	h := newPricingHandlerForImportURL(t)
+
+	code, _ := doPricingRequest(t, h.ImportPricingURL, http.MethodPost, "/", `{"url":"`+srv.URL+`"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestProviderToggle_ReflectedInListPricing(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+	t.Cleanup(func() { _, _ = h.billingService.EnableProvider("anthropic") })
+
+	code, data := doPricingRequest(t, h.DisableProvider, http.MethodPost, "/", `{"provider":"Anthropic"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["changed"])
+
+	code, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	providers := data["providers"].([]any)
+	require.Len(t, providers, 2)
+	require.Equal(t, "anthropic", providers[0].(map[string]any)["name"])
+	require.Equal(t, false, providers[0].(map[string]any)["enabled"])
+	require.Equal(t, true, providers[1].(map[string]any)["enabled"])
+
+	code, _ = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x", "")
+	require.Equal(t, http.StatusForbidden, code)
+
+	code, data = doPricingRequest(t, h.EnableProvider, http.MethodPost, "/", `{"provider":"anthropic"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["enabled"])
+}
+
+func TestPricingErrors_IncludeMachineReadableCode(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		handler gin.HandlerFunc
+		target  string
+		status  int
+		reason  string
+	}{
+		{h.LookupModel, "/?model=no-such-model&strict=true", http.StatusNotFound, "PRICING_NOT_FOUND"},
+		{h.LookupModel, "/", http.StatusBadRequest, "MISSING_PARAMETER"},
+		{h.ListPricing, "/?is_free=maybe", http.StatusBadRequest, "INVALID_PARAMETER"},
+		{h.RemoveOverride, "/?model=no-such-model", http.StatusNotFound, "OVERRIDE_NOT_FOUND"},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, tc.target, nil)
+		tc.handler(c)
+
+		var resp struct {
+			Code   int    `json:"code"`
+			Reason string `json:"reason"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, tc.status, w.Code, tc.target)
+		require.Equal(t, tc.status, resp.Code, tc.target)
+		require.Equal(t, tc.reason, resp.Reason, tc.target)
+	}
+}
+
+func TestSetAutoRefreshInterval_ErrorsIncludeMachineReadableCode(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		body   string
+		reason string
+	}{
+		{`{"interval_hours":0}`, "INVALID_REQUEST"},
+		{`{"interval_hours":-1}`, "PRICING_UPDATE_FAILED"},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tc.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		h.SetAutoRefreshInterval(c)
+
+		var resp struct {
+			Code   int    `json:"code"`
+			Reason string `json:"reason"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, http.StatusBadRequest, resp.Code, tc.body)
+		require.Equal(t, tc.reason, resp.Reason, tc.body)
+	}
+}
+
+func TestDefaultPricing_LookupUnknownModel(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=brand-new-model", "")
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = doPricingRequest(t, h.RemoveDefaultPricing, http.MethodDelete, "/", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, _ = doPricingRequest(t, h.SetDefaultPricing, http.MethodPut, "/", `{"input_cost_per_token":-1,"output_cost_per_token":1e-6}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, data := doPricingRequest(t, h.SetDefaultPricing, http.MethodPut, "/", `{"input_cost_per_token":1e-6,"output_cost_per_token":4e-6}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "admin", data["source"])
+
+	code, data = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=brand-new-model", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["pricing"].(map[string]any)["is_default"])
+	require.Equal(t, "default", data["match_type"])
+	code, _ = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=brand-new-model&strict=true", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, data = doPricingRequest(t, h.RemoveDefaultPricing, http.MethodDelete, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "none", data["source"])
+}
+
+func TestBatchPricing_ListLookupAndEstimate(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, _ := doPricingRequest(t, h.SetModelBatchMultiplier, http.MethodPut, "/", `{"model":"claude-x","multiplier":2}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doPricingRequest(t, h.SetModelBatchMultiplier, http.MethodPut, "/", `{"model":"claude-x","multiplier":0.4}`)
+	require.Equal(t, http.StatusOK, code)
+
+	_, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?search=claude-x", "")
+	item := data["items"].([]any)[0].(map[string]any)
+	require.InDelta(t, 0.4, item["batch_multiplier"], 1e-9)
+	require.Equal(t, "model", item["batch_multiplier_source"])
+	require.InDelta(t, 1.2, item["batch_base_cost"].(map[string]any)["input_per_mtok"], 1e-9)
+	require.InDelta(t, 6.0, item["batch_base_cost"].(map[string]any)["output_per_mtok"], 1e-9)
+
+	_, data = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x&batch=true", "")
+	require.Equal(t, true, data["batch"])
+	require.InDelta(t, 1.2, data["pricing"].(map[string]any)["input_cost_per_mtok"], 1e-9)
+
+	_, data = doPricingRequest(t, h.EstimateCost, http.MethodPost, "/", `{"model":"claude-x","input_tokens":1000000,"batch":true}`)
+	require.InDelta(t, 1.2, data["total_cost"], 1e-9)
+
+	_, data = doPricingRequest(t, h.ListBatchMultipliers, http.MethodGet, "/", "")
+	require.InDelta(t, 0.5, data["default_multiplier"], 1e-9)
+	require.Len(t, data["models"], 1)
+
+	code, _ = doPricingRequest(t, h.RemoveModelBatchMultiplier, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doPricingRequest(t, h.RemoveModelBatchMultiplier, http.MethodDelete, "/?model=claude-x", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestBatchLookupModels_SplitsFoundAndNotFound(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 2)
+	t.Cleanup(func() { _, _ = h.billingService.EnableModel("model-001") })
+
+	code, _ := doPricingRequest(t, h.DisableModel, http.MethodPost, "/", `{"model":"model-001"}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, data := doPricingRequest(t, h.BatchLookupModels, http.MethodPost, "/",
+		`{"models":["claude-x","model-000"," claude-x ","no-such-model","model-001",""]}`)
+	require.Equal(t, http.StatusOK, code)
+
+	pricing := data["pricing"].(map[string]any)
+	require.Len(t, pricing, 2)
+	claude := pricing["claude-x"].(map[string]any)
+	require.InDelta(t, 3.0, claude["input_cost_per_mtok"], 1e-9)
+	require.InDelta(t, 15.0, claude["output_cost_per_mtok"], 1e-9)
+	require.Contains(t, pricing, "model-000")
+	// 未收录与已禁用的模型都归入 not_found，重复与空白模型名只出现一次
+	require.Equal(t, []any{"no-such-model", "model-001"}, data["not_found"])
+
+	code, _ = doPricingRequest(t, h.BatchLookupModels, http.MethodPost, "/", `{"models":[]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	models := make([]string, maxBatchLookupModels+1)
+	for i := range models {
+		models[i] = fmt.Sprintf("model-%03d", i)
+	}
+	body, err := json.Marshal(BatchLookupRequest{Models: models})
+	require.NoError(t, err)
+	code, _ = doPricingRequest(t, h.BatchLookupModels, http.MethodPost, "/", string(body))
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// 恰好达到上限时仍受理
+	body, err = json.Marshal(BatchLookupRequest{Models: models[:maxBatchLookupModels]})
+	require.NoError(t, err)
+	code, data = doPricingRequest(t, h.BatchLookupModels, http.MethodPost, "/", string(body))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data["pricing"], 1)
+	require.Len(t, data["not_found"], maxBatchLookupModels-1)
+}
+
+func TestListPricing_FiltersByCapability(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	pricingSvc := service.NewPricingService(cfg, nil)
+	_, err := pricingSvc.ImportPricingData([]byte(`{
+		"vision-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","supports_vision":true,"max_input_tokens":128000},
+		"text-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","supports_vision":false}
+	}`), false)
+	require.NoError(t, err)
+	h := NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+
+	_, data := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?supports_vision=true", "")
+	require.EqualValues(t, 1, data["total"])
+	item := data["items"].([]any)[0].(map[string]any)
+	require.Equal(t, "vision-model", item["model"])
+	require.EqualValues(t, 128000, item["max_context_tokens"])
+
+	_, data = doPricingRequest(t, h.ListPricing, http.MethodGet, "/?supports_vision=false", "")
+	require.EqualValues(t, 1, data["total"])
+	item = data["items"].([]any)[0].(map[string]any)
+	require.Equal(t, false, item["supports_vision"])
+	require.NotContains(t, item, "supports_function_calling")
+
+	code, _ := doPricingRequest(t, h.ListPricing, http.MethodGet, "/?supports_streaming=maybe", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	_, data = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=vision-model", "")
+	require.Equal(t, true, data["capabilities"].(map[string]any)["supports_vision"])
+}
+
+func TestImportPricingURL_VerifiesChecksum(t *testing.T) {
+	body := []byte(`{"gpt-x":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat"}}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+	h := newPricingHandlerForImportURL(t)
+
+	code, _ := doPricingRequest(t, h.ImportPricingURL, http.MethodPost, "/", `{"url":"`+srv.URL+`","sha256":"`+strings.Repeat("0", 64)+`"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	sum := sha256.Sum256(body)
+	code, data := doPricingRequest(t, h.ImportPricingURL, http.MethodPost, "/", `{"url":"`+srv.URL+`","sha256":"`+hex.EncodeToString(sum[:])+`"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(1), data["model_count"])
+}
+
+func TestLookupModel_AsOfReturnsHistoricalPricing(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 1)
+
+	code, data := doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x&as_of=2000-01-01", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "claude-x", data["matched_model"])
+	require.Equal(t, true, data["before_history"])
+	require.InDelta(t, 3e-6, data["pricing"].(map[string]any)["input_cost_per_token"], 1e-12)
+
+	code, data = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x&as_of="+time.Now().Add(time.Hour).UTC().Format(time.RFC3339), "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, false, data["before_history"])
+
+	code, _ = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x&as_of=yesterday", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=no-such-model&as_of=2024-05-01", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestClonePricing(t *testing.T) {
+	h := newPricingHandlerWithModels(t, 2)
+
+	code, _ := doPricingRequest(t, h.ClonePricing, http.MethodPost, "/", `{"from":"no-such-model","to":"new-model"}`)
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = doPricingRequest(t, h.ClonePricing, http.MethodPost, "/", `{"from":"claude-x","to":"model-001"}`)
+	require.Equal(t, http.StatusConflict, code)
+
+	code, data := doPricingRequest(t, h.ClonePricing, http.MethodPost, "/", `{"from":"claude-x","to":"Claude-X-New"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "claude-x-new", data["model"])
+	code, data = doPricingRequest(t, h.LookupModel, http.MethodGet, "/?model=claude-x-new&strict=true", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, data["overridden"])
+	require.InDelta(t, 1.5e-5, data["pricing"].(map[string]any)["output_cost_per_token"], 1e-12)
+
+	code, _ = doPricingRequest(t, h.ClonePricing, http.MethodPost, "/", `{"from":"claude-x","to":"model-001","overwrite":true}`)
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestGetValueRanking_RanksByTokensPerDollar(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	pricingSvc := service.NewPricingService(cfg, nil)
+	_, err := pricingSvc.ImportPricingData([]byte(`{
+		"cheap-out":{"input_cost_per_token":4e-6,"output_cost_per_token":1e-6,"litellm_provider":"openai","mode":"chat"},
+		"cheap-in":{"input_cost_per_token":5e-7,"output_cost_per_token":8e-6,"litellm_provider":"openai","mode":"chat"},
+		"claude-x":{"input_cost_per_token":3e-6,"output_cost_per_token":1.5e-5,"litellm_provider":"anthropic","mode":"chat"},
+		"promo-model":{"is_free":true,"litellm_provider":"openai","mode":"chat"},
+		"unpriced-model":{"input_cost_per_token":0,"output_cost_per_token":0,"litellm_provider":"openai","mode":"chat"}
+	}`), false)
+	require.NoError(t, err)
+	h := NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+
+	code, data := doPricingRequest(t, h.GetValueRanking, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	byOutput := data["by_output"].([]any)
+	require.Len(t, byOutput, 3)
+	first := byOutput[0].(map[string]any)
+	require.Equal(t, "cheap-out", first["model"])
+	require.EqualValues(t, 1, first["rank"])
+	require.InDelta(t, 1e6, first["tokens_per_dollar"], 1e-3)
+	require.Equal(t, "claude-x", byOutput[2].(map[string]any)["model"])
+	require.Equal(t, "cheap-in", data["by_input"].([]any)[0].(map[string]any)["model"])
+
+	excluded := data["excluded"].([]any)
+	require.Len(t, excluded, 2)
+	require.Equal(t, "unpriced-model", excluded[1].(map[string]any)["model"])
+	require.Equal(t, "unpriced", excluded[1].(map[string]any)["reason"])
+	require.Equal(t, "free", excluded[0].(map[string]any)["reason"])
+
+	_, data = doPricingRequest(t, h.GetValueRanking, http.MethodGet, "/?provider=anthropic&limit=1", "")
+	require.Len(t, data["by_output"].([]any), 1)
+	require.Equal(t, "claude-x", data["by_input"].([]any)[0].(map[string]any)["model"])
+	require.Empty(t, data["excluded"])
+
+	code, _ = doPricingRequest(t, h.GetValueRanking, http.MethodGet, "/?limit=-1", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestGetPricingCoverage_CountsIncompleteEntries(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	pricingSvc := service.NewPricingService(cfg, nil)
+	_, err := pricingSvc.ImportPricingData([]byte(`{
+		"gpt-x":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","cache_read_input_token_cost":1e-7},
+		"gpt-no-output":{"input_cost_per_token":1e-6,"litellm_provider":"openai","mode":"chat"},
+		"image-x":{"input_cost_per_token":1e-6,"output_cost_per_image":0.04,"litellm_provider":"openai","mode":"image_generation"},
+		"claude-x":{"input_cost_per_token":3e-6,"output_cost_per_token":1.5e-5,"litellm_provider":"anthropic","mode":"chat","supports_prompt_caching":true},
+		"mistral-x":{"output_cost_per_token":2e-6,"litellm_provider":"mistral","mode":"chat"}
+	}`), false)
+	require.NoError(t, err)
+	h := NewPricingHandler(service.NewBillingService(cfg, pricingSvc))
+
+	code, data := doPricingRequest(t, h.GetPricingCoverage, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+
+	summary := data["summary"].(map[string]any)
+	require.EqualValues(t, 5, summary["total_models"])
+	require.EqualValues(t, 3, summary["complete_pricing"])
+	require.EqualValues(t, 1, summary["missing_input_cost"])
+	require.EqualValues(t, 1, summary["missing_output_cost"])
+	require.InDelta(t, 0.6, summary["complete_pricing_ratio"], 1e-9)
+
+	providers := data["providers"].([]any)
+	require.Len(t, providers, 3)
+	anthropic := providers[0].(map[string]any)
+	require.Equal(t, "anthropic", anthropic["provider"])
+	require.EqualValues(t, 1, anthropic["supports_caching"])
+	mistral := providers[1].(map[string]any)
+	require.Equal(t, "mistral", mistral["provider"])
+	require.EqualValues(t, 1, mistral["missing_input_cost"])
+	require.EqualValues(t, 0, mistral["complete_pricing"])
+	openai := providers[2].(map[string]any)
+	require.Equal(t, "openai", openai["provider"])
+	require.EqualValues(t, 3, openai["total_models"])
+	// 按张计费的图片模型不计为缺少输出价格
+	require.EqualValues(t, 1, openai["missing_output_cost"])
+}
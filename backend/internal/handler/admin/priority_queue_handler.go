@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriorityQueueHandler exposes the gateway priority queue status
+type PriorityQueueHandler struct {
+	queue *service.GatewayPriorityQueue
+}
+
+// NewPriorityQueueHandler creates a new admin priority queue handler
+func NewPriorityQueueHandler(queue *service.GatewayPriorityQueue) *PriorityQueueHandler {
+	return &PriorityQueueHandler{queue: queue}
+}
+
+// Status returns active requests and queue depth per tier
+// GET /api/v1/admin/priority-queue
+func (h *PriorityQueueHandler) Status(c *gin.Context) {
+	response.Success(c, h.queue.Status())
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -79,7 +80,7 @@ func (h *PromoHandler) List(c *gin.Context) {
 func (h *PromoHandler) GetByID(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid promo code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid promo code ID")
 		return
 	}
 
@@ -97,7 +98,7 @@ func (h *PromoHandler) GetByID(c *gin.Context) {
 func (h *PromoHandler) Create(c *gin.Context) {
 	var req CreatePromoCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -127,13 +128,13 @@ func (h *PromoHandler) Create(c *gin.Context) {
 func (h *PromoHandler) Update(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid promo code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid promo code ID")
 		return
 	}
 
 	var req UpdatePromoCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -169,7 +170,7 @@ func (h *PromoHandler) Update(c *gin.Context) {
 func (h *PromoHandler) Delete(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid promo code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid promo code ID")
 		return
 	}
 
@@ -187,7 +188,7 @@ func (h *PromoHandler) Delete(c *gin.Context) {
 func (h *PromoHandler) GetUsages(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid promo code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid promo code ID")
 		return
 	}
 
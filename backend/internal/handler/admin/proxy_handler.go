@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -111,7 +112,7 @@ func (h *ProxyHandler) GetAll(c *gin.Context) {
 func (h *ProxyHandler) GetByID(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -129,7 +130,7 @@ func (h *ProxyHandler) GetByID(c *gin.Context) {
 func (h *ProxyHandler) Create(c *gin.Context) {
 	var req CreateProxyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -154,13 +155,13 @@ func (h *ProxyHandler) Create(c *gin.Context) {
 func (h *ProxyHandler) Update(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
 	var req UpdateProxyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -186,7 +187,7 @@ func (h *ProxyHandler) Update(c *gin.Context) {
 func (h *ProxyHandler) Delete(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -208,7 +209,7 @@ func (h *ProxyHandler) BatchDelete(c *gin.Context) {
 
 	var req BatchDeleteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -226,7 +227,7 @@ func (h *ProxyHandler) BatchDelete(c *gin.Context) {
 func (h *ProxyHandler) Test(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -244,7 +245,7 @@ func (h *ProxyHandler) Test(c *gin.Context) {
 func (h *ProxyHandler) CheckQuality(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -262,7 +263,7 @@ func (h *ProxyHandler) CheckQuality(c *gin.Context) {
 func (h *ProxyHandler) GetStats(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -282,7 +283,7 @@ func (h *ProxyHandler) GetStats(c *gin.Context) {
 func (h *ProxyHandler) GetProxyAccounts(c *gin.Context) {
 	proxyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid proxy ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid proxy ID")
 		return
 	}
 
@@ -318,7 +319,7 @@ type BatchCreateRequest struct {
 func (h *ProxyHandler) BatchCreate(c *gin.Context) {
 	var req BatchCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
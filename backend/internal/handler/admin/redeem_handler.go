@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -85,7 +86,7 @@ func (h *RedeemHandler) List(c *gin.Context) {
 func (h *RedeemHandler) GetByID(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid redeem code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid redeem code ID")
 		return
 	}
 
@@ -103,7 +104,7 @@ func (h *RedeemHandler) GetByID(c *gin.Context) {
 func (h *RedeemHandler) Generate(c *gin.Context) {
 	var req GenerateRedeemCodesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -131,13 +132,13 @@ func (h *RedeemHandler) Generate(c *gin.Context) {
 // POST /api/v1/admin/redeem-codes/create-and-redeem
 func (h *RedeemHandler) CreateAndRedeem(c *gin.Context) {
 	if h.redeemService == nil {
-		response.InternalError(c, "redeem service not configured")
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "redeem service not configured")
 		return
 	}
 
 	var req CreateAndRedeemCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	req.Code = strings.TrimSpace(req.Code)
@@ -149,11 +150,11 @@ func (h *RedeemHandler) CreateAndRedeem(c *gin.Context) {
 
 	if req.Type == "subscription" {
 		if req.GroupID == nil {
-			response.BadRequest(c, "group_id is required for subscription type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "group_id is required for subscription type")
 			return
 		}
 		if req.ValidityDays == 0 {
-			response.BadRequest(c, "validity_days must not be zero for subscription type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "validity_days must not be zero for subscription type")
 			return
 		}
 	}
@@ -225,7 +226,7 @@ func (h *RedeemHandler) resolveCreateAndRedeemExisting(ctx context.Context, exis
 func (h *RedeemHandler) Delete(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid redeem code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid redeem code ID")
 		return
 	}
 
@@ -245,7 +246,7 @@ func (h *RedeemHandler) BatchDelete(c *gin.Context) {
 		IDs []int64 `json:"ids" binding:"required,min=1"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -266,7 +267,7 @@ func (h *RedeemHandler) BatchDelete(c *gin.Context) {
 func (h *RedeemHandler) Expire(c *gin.Context) {
 	codeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid redeem code ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid redeem code ID")
 		return
 	}
 
@@ -322,7 +323,7 @@ func (h *RedeemHandler) Export(c *gin.Context) {
 
 	// Write header
 	if err := writer.Write([]string{"id", "code", "type", "value", "status", "used_by", "used_by_email", "used_at", "created_at"}); err != nil {
-		response.InternalError(c, "Failed to export redeem codes: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to export redeem codes: "+err.Error())
 		return
 	}
 
@@ -351,14 +352,14 @@ func (h *RedeemHandler) Export(c *gin.Context) {
 			usedAt,
 			code.CreatedAt.Format("2006-01-02 15:04:05"),
 		}); err != nil {
-			response.InternalError(c, "Failed to export redeem codes: "+err.Error())
+			response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to export redeem codes: "+err.Error())
 			return
 		}
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		response.InternalError(c, "Failed to export redeem codes: "+err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to export redeem codes: "+err.Error())
 		return
 	}
 
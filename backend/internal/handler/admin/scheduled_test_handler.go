@@ -40,13 +40,13 @@ type updateScheduledTestPlanRequest struct {
 func (h *ScheduledTestHandler) ListByAccount(c *gin.Context) {
 	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "invalid account id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "invalid account id")
 		return
 	}
 
 	plans, err := h.scheduledTestSvc.ListPlansByAccount(c.Request.Context(), accountID)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, plans)
@@ -56,7 +56,7 @@ func (h *ScheduledTestHandler) ListByAccount(c *gin.Context) {
 func (h *ScheduledTestHandler) Create(c *gin.Context) {
 	var req createScheduledTestPlanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
@@ -76,7 +76,7 @@ func (h *ScheduledTestHandler) Create(c *gin.Context) {
 
 	created, err := h.scheduledTestSvc.CreatePlan(c.Request.Context(), plan)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, created)
@@ -86,19 +86,19 @@ func (h *ScheduledTestHandler) Create(c *gin.Context) {
 func (h *ScheduledTestHandler) Update(c *gin.Context) {
 	planID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "invalid plan id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "invalid plan id")
 		return
 	}
 
 	existing, err := h.scheduledTestSvc.GetPlan(c.Request.Context(), planID)
 	if err != nil {
-		response.NotFound(c, "plan not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "plan not found")
 		return
 	}
 
 	var req updateScheduledTestPlanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 		return
 	}
 
@@ -120,7 +120,7 @@ func (h *ScheduledTestHandler) Update(c *gin.Context) {
 
 	updated, err := h.scheduledTestSvc.UpdatePlan(c.Request.Context(), existing)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, updated)
@@ -130,12 +130,12 @@ func (h *ScheduledTestHandler) Update(c *gin.Context) {
 func (h *ScheduledTestHandler) Delete(c *gin.Context) {
 	planID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "invalid plan id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "invalid plan id")
 		return
 	}
 
 	if err := h.scheduledTestSvc.DeletePlan(c.Request.Context(), planID); err != nil {
-		response.InternalError(c, err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "deleted"})
@@ -145,7 +145,7 @@ func (h *ScheduledTestHandler) Delete(c *gin.Context) {
 func (h *ScheduledTestHandler) ListResults(c *gin.Context) {
 	planID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "invalid plan id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "invalid plan id")
 		return
 	}
 
@@ -156,7 +156,7 @@ func (h *ScheduledTestHandler) ListResults(c *gin.Context) {
 
 	results, err := h.scheduledTestSvc.ListResults(c.Request.Context(), planID, limit)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, results)
@@ -586,7 +586,7 @@ type UpdateSettingsRequest struct {
 func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	var req UpdateSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -700,13 +700,13 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	if req.TurnstileEnabled {
 		// 检查必填字段
 		if req.TurnstileSiteKey == "" {
-			response.BadRequest(c, "Turnstile Site Key is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Turnstile Site Key is required when enabled")
 			return
 		}
 		// 如果未提供 secret key，使用已保存的值（留空保留当前值）
 		if req.TurnstileSecretKey == "" {
 			if previousSettings.TurnstileSecretKey == "" {
-				response.BadRequest(c, "Turnstile Secret Key is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Turnstile Secret Key is required when enabled")
 				return
 			}
 			req.TurnstileSecretKey = previousSettings.TurnstileSecretKey
@@ -728,7 +728,7 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	if req.TotpEnabled && !previousSettings.TotpEnabled {
 		// 尝试启用 TOTP，检查加密密钥是否已手动配置
 		if !h.settingService.IsTotpEncryptionKeyConfigured() {
-			response.BadRequest(c, "Cannot enable TOTP: TOTP_ENCRYPTION_KEY environment variable must be configured first. Generate a key with 'openssl rand -hex 32' and set it in your environment.")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Cannot enable TOTP: TOTP_ENCRYPTION_KEY environment variable must be configured first. Generate a key with 'openssl rand -hex 32' and set it in your environment.")
 			return
 		}
 	}
@@ -741,7 +741,7 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		loginAgreementMode = "modal"
 	case "checkbox":
 	default:
-		response.BadRequest(c, "Login agreement mode must be modal or checkbox")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Login agreement mode must be modal or checkbox")
 		return
 	}
 	loginAgreementUpdatedAt := strings.TrimSpace(req.LoginAgreementUpdatedAt)
@@ -754,20 +754,20 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	}
 	for _, doc := range loginAgreementDocuments {
 		if strings.TrimSpace(doc.Title) == "" {
-			response.BadRequest(c, "Login agreement document title is required")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Login agreement document title is required")
 			return
 		}
 		if len(doc.Title) > 80 {
-			response.BadRequest(c, "Login agreement document title is too long (max 80 characters)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Login agreement document title is too long (max 80 characters)")
 			return
 		}
 		if len(doc.ContentMD) > 200*1024 {
-			response.BadRequest(c, "Login agreement document content is too large (max 200KB)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Login agreement document content is too large (max 200KB)")
 			return
 		}
 	}
 	if req.LoginAgreementEnabled && len(loginAgreementDocuments) == 0 {
-		response.BadRequest(c, "Login agreement documents are required when enabled")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Login agreement documents are required when enabled")
 		return
 	}
 
@@ -778,22 +778,22 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		req.LinuxDoConnectRedirectURL = strings.TrimSpace(req.LinuxDoConnectRedirectURL)
 
 		if req.LinuxDoConnectClientID == "" {
-			response.BadRequest(c, "LinuxDo Client ID is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "LinuxDo Client ID is required when enabled")
 			return
 		}
 		if req.LinuxDoConnectRedirectURL == "" {
-			response.BadRequest(c, "LinuxDo Redirect URL is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "LinuxDo Redirect URL is required when enabled")
 			return
 		}
 		if err := config.ValidateAbsoluteHTTPURL(req.LinuxDoConnectRedirectURL); err != nil {
-			response.BadRequest(c, "LinuxDo Redirect URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "LinuxDo Redirect URL must be an absolute http(s) URL")
 			return
 		}
 
 		// 如果未提供 client_secret，则保留现有值（如有）。
 		if req.LinuxDoConnectClientSecret == "" {
 			if previousSettings.LinuxDoConnectClientSecret == "" {
-				response.BadRequest(c, "LinuxDo Client Secret is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "LinuxDo Client Secret is required when enabled")
 				return
 			}
 			req.LinuxDoConnectClientSecret = previousSettings.LinuxDoConnectClientSecret
@@ -824,14 +824,14 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		}
 
 		if req.WeChatConnectMPEnabled && req.WeChatConnectMobileEnabled {
-			response.BadRequest(c, "WeChat Official Account and Mobile App cannot be enabled at the same time")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "WeChat Official Account and Mobile App cannot be enabled at the same time")
 			return
 		}
 		if req.WeChatConnectMode != "" {
 			switch req.WeChatConnectMode {
 			case "open", "mp", "mobile":
 			default:
-				response.BadRequest(c, "WeChat mode must be open, mp, or mobile")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "WeChat mode must be open, mp, or mobile")
 				return
 			}
 		}
@@ -874,31 +874,31 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 
 		if req.WeChatConnectOpenEnabled {
 			if req.WeChatConnectOpenAppID == "" {
-				response.BadRequest(c, "WeChat PC App ID is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat PC App ID is required when enabled")
 				return
 			}
 			if req.WeChatConnectOpenAppSecret == "" {
-				response.BadRequest(c, "WeChat PC App Secret is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat PC App Secret is required when enabled")
 				return
 			}
 		}
 		if req.WeChatConnectMPEnabled {
 			if req.WeChatConnectMPAppID == "" {
-				response.BadRequest(c, "WeChat Official Account App ID is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat Official Account App ID is required when enabled")
 				return
 			}
 			if req.WeChatConnectMPAppSecret == "" {
-				response.BadRequest(c, "WeChat Official Account App Secret is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat Official Account App Secret is required when enabled")
 				return
 			}
 		}
 		if req.WeChatConnectMobileEnabled {
 			if req.WeChatConnectMobileAppID == "" {
-				response.BadRequest(c, "WeChat Mobile App ID is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat Mobile App ID is required when enabled")
 				return
 			}
 			if req.WeChatConnectMobileAppSecret == "" {
-				response.BadRequest(c, "WeChat Mobile App Secret is required when enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat Mobile App Secret is required when enabled")
 				return
 			}
 		}
@@ -912,18 +912,18 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		}
 		if req.WeChatConnectOpenEnabled || req.WeChatConnectMPEnabled {
 			if req.WeChatConnectRedirectURL == "" {
-				response.BadRequest(c, "WeChat Redirect URL is required when web oauth is enabled")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "WeChat Redirect URL is required when web oauth is enabled")
 				return
 			}
 			if err := config.ValidateAbsoluteHTTPURL(req.WeChatConnectRedirectURL); err != nil {
-				response.BadRequest(c, "WeChat Redirect URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "WeChat Redirect URL must be an absolute http(s) URL")
 				return
 			}
 			if req.WeChatConnectFrontendRedirectURL == "" {
 				req.WeChatConnectFrontendRedirectURL = "/auth/wechat/callback"
 			}
 			if err := config.ValidateFrontendRedirectURL(req.WeChatConnectFrontendRedirectURL); err != nil {
-				response.BadRequest(c, "WeChat Frontend Redirect URL is invalid")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "WeChat Frontend Redirect URL is invalid")
 				return
 			}
 		}
@@ -983,85 +983,85 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		}
 
 		if req.OIDCConnectClientID == "" {
-			response.BadRequest(c, "OIDC Client ID is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Client ID is required when enabled")
 			return
 		}
 		if req.OIDCConnectIssuerURL == "" {
-			response.BadRequest(c, "OIDC Issuer URL is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Issuer URL is required when enabled")
 			return
 		}
 		if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectIssuerURL); err != nil {
-			response.BadRequest(c, "OIDC Issuer URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Issuer URL must be an absolute http(s) URL")
 			return
 		}
 		if req.OIDCConnectDiscoveryURL != "" {
 			if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectDiscoveryURL); err != nil {
-				response.BadRequest(c, "OIDC Discovery URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Discovery URL must be an absolute http(s) URL")
 				return
 			}
 		}
 		if req.OIDCConnectAuthorizeURL != "" {
 			if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectAuthorizeURL); err != nil {
-				response.BadRequest(c, "OIDC Authorize URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Authorize URL must be an absolute http(s) URL")
 				return
 			}
 		}
 		if req.OIDCConnectTokenURL != "" {
 			if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectTokenURL); err != nil {
-				response.BadRequest(c, "OIDC Token URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Token URL must be an absolute http(s) URL")
 				return
 			}
 		}
 		if req.OIDCConnectUserInfoURL != "" {
 			if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectUserInfoURL); err != nil {
-				response.BadRequest(c, "OIDC UserInfo URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC UserInfo URL must be an absolute http(s) URL")
 				return
 			}
 		}
 		if req.OIDCConnectRedirectURL == "" {
-			response.BadRequest(c, "OIDC Redirect URL is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Redirect URL is required when enabled")
 			return
 		}
 		if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectRedirectURL); err != nil {
-			response.BadRequest(c, "OIDC Redirect URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Redirect URL must be an absolute http(s) URL")
 			return
 		}
 		if req.OIDCConnectFrontendRedirectURL == "" {
-			response.BadRequest(c, "OIDC Frontend Redirect URL is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Frontend Redirect URL is required when enabled")
 			return
 		}
 		if err := config.ValidateFrontendRedirectURL(req.OIDCConnectFrontendRedirectURL); err != nil {
-			response.BadRequest(c, "OIDC Frontend Redirect URL is invalid")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Frontend Redirect URL is invalid")
 			return
 		}
 		if !scopesContainOpenID(req.OIDCConnectScopes) {
-			response.BadRequest(c, "OIDC scopes must contain openid")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC scopes must contain openid")
 			return
 		}
 		switch req.OIDCConnectTokenAuthMethod {
 		case "", "client_secret_post", "client_secret_basic", "none":
 		default:
-			response.BadRequest(c, "OIDC Token Auth Method must be one of client_secret_post/client_secret_basic/none")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC Token Auth Method must be one of client_secret_post/client_secret_basic/none")
 			return
 		}
 		if req.OIDCConnectClockSkewSeconds < 0 || req.OIDCConnectClockSkewSeconds > 600 {
-			response.BadRequest(c, "OIDC clock skew seconds must be between 0 and 600")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC clock skew seconds must be between 0 and 600")
 			return
 		}
 		if oidcValidateIDToken && req.OIDCConnectAllowedSigningAlgs == "" {
-			response.BadRequest(c, "OIDC Allowed Signing Algs is required when validate_id_token=true")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Allowed Signing Algs is required when validate_id_token=true")
 			return
 		}
 		if req.OIDCConnectJWKSURL != "" {
 			if err := config.ValidateAbsoluteHTTPURL(req.OIDCConnectJWKSURL); err != nil {
-				response.BadRequest(c, "OIDC JWKS URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "OIDC JWKS URL must be an absolute http(s) URL")
 				return
 			}
 		}
 		if req.OIDCConnectTokenAuthMethod == "" || req.OIDCConnectTokenAuthMethod == "client_secret_post" || req.OIDCConnectTokenAuthMethod == "client_secret_basic" {
 			if req.OIDCConnectClientSecret == "" {
 				if previousSettings.OIDCConnectClientSecret == "" {
-					response.BadRequest(c, "OIDC Client Secret is required when enabled")
+					response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "OIDC Client Secret is required when enabled")
 					return
 				}
 				req.OIDCConnectClientSecret = previousSettings.OIDCConnectClientSecret
@@ -1083,16 +1083,16 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	// - 禁用时允许为空；若提供了 URL 也做基本校验，避免误配置
 	if purchaseEnabled {
 		if purchaseURL == "" {
-			response.BadRequest(c, "Purchase Subscription URL is required when enabled")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Purchase Subscription URL is required when enabled")
 			return
 		}
 		if err := config.ValidateAbsoluteHTTPURL(purchaseURL); err != nil {
-			response.BadRequest(c, "Purchase Subscription URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Purchase Subscription URL must be an absolute http(s) URL")
 			return
 		}
 	} else if purchaseURL != "" {
 		if err := config.ValidateAbsoluteHTTPURL(purchaseURL); err != nil {
-			response.BadRequest(c, "Purchase Subscription URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Purchase Subscription URL must be an absolute http(s) URL")
 			return
 		}
 	}
@@ -1101,7 +1101,7 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	req.FrontendURL = strings.TrimSpace(req.FrontendURL)
 	if req.FrontendURL != "" {
 		if err := config.ValidateAbsoluteHTTPURL(req.FrontendURL); err != nil {
-			response.BadRequest(c, "Frontend URL must be an absolute http(s) URL")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Frontend URL must be an absolute http(s) URL")
 			return
 		}
 	}
@@ -1119,16 +1119,16 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	if req.CustomMenuItems != nil {
 		items := *req.CustomMenuItems
 		if len(items) > maxCustomMenuItems {
-			response.BadRequest(c, "Too many custom menu items (max 20)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Too many custom menu items (max 20)")
 			return
 		}
 		for i, item := range items {
 			if strings.TrimSpace(item.Label) == "" {
-				response.BadRequest(c, "Custom menu item label is required")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Custom menu item label is required")
 				return
 			}
 			if len(item.Label) > maxMenuItemLabelLen {
-				response.BadRequest(c, "Custom menu item label is too long (max 50 characters)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item label is too long (max 50 characters)")
 				return
 			}
 			urlTrimmed := strings.TrimSpace(item.URL)
@@ -1136,44 +1136,44 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 				// Markdown page mode: URL = "md:<slug>"
 				slug := strings.TrimPrefix(urlTrimmed, "md:")
 				if slug == "" {
-					response.BadRequest(c, "Custom menu item markdown slug cannot be empty (use md:slug format)")
+					response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Custom menu item markdown slug cannot be empty (use md:slug format)")
 					return
 				}
 			} else {
 				if urlTrimmed == "" {
-					response.BadRequest(c, "Custom menu item URL is required (use md:slug for markdown pages)")
+					response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Custom menu item URL is required (use md:slug for markdown pages)")
 					return
 				}
 				if len(item.URL) > maxMenuItemURLLen {
-					response.BadRequest(c, "Custom menu item URL is too long (max 2048 characters)")
+					response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item URL is too long (max 2048 characters)")
 					return
 				}
 				if err := config.ValidateAbsoluteHTTPURL(urlTrimmed); err != nil {
-					response.BadRequest(c, "Custom menu item URL must be an absolute http(s) URL or md:<slug>")
+					response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item URL must be an absolute http(s) URL or md:<slug>")
 					return
 				}
 			}
 			if item.Visibility != "user" && item.Visibility != "admin" {
-				response.BadRequest(c, "Custom menu item visibility must be 'user' or 'admin'")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item visibility must be 'user' or 'admin'")
 				return
 			}
 			if len(item.IconSVG) > maxMenuItemIconSVGLen {
-				response.BadRequest(c, "Custom menu item icon SVG is too large (max 10KB)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item icon SVG is too large (max 10KB)")
 				return
 			}
 			// Auto-generate ID if missing
 			if strings.TrimSpace(item.ID) == "" {
 				id, err := generateMenuItemID()
 				if err != nil {
-					response.Error(c, http.StatusInternalServerError, "Failed to generate menu item ID")
+					response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to generate menu item ID")
 					return
 				}
 				items[i].ID = id
 			} else if len(item.ID) > maxMenuItemIDLen {
-				response.BadRequest(c, "Custom menu item ID is too long (max 32 characters)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item ID is too long (max 32 characters)")
 				return
 			} else if !menuItemIDPattern.MatchString(item.ID) {
-				response.BadRequest(c, "Custom menu item ID contains invalid characters (only a-z, A-Z, 0-9, - and _ are allowed)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom menu item ID contains invalid characters (only a-z, A-Z, 0-9, - and _ are allowed)")
 				return
 			}
 		}
@@ -1181,14 +1181,14 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		seen := make(map[string]struct{}, len(items))
 		for _, item := range items {
 			if _, exists := seen[item.ID]; exists {
-				response.BadRequest(c, "Duplicate custom menu item ID: "+item.ID)
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Duplicate custom menu item ID: "+item.ID)
 				return
 			}
 			seen[item.ID] = struct{}{}
 		}
 		menuBytes, err := json.Marshal(items)
 		if err != nil {
-			response.BadRequest(c, "Failed to serialize custom menu items")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Failed to serialize custom menu items")
 			return
 		}
 		customMenuJSON = string(menuBytes)
@@ -1206,38 +1206,38 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	if req.CustomEndpoints != nil {
 		endpoints := *req.CustomEndpoints
 		if len(endpoints) > maxCustomEndpoints {
-			response.BadRequest(c, "Too many custom endpoints (max 10)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Too many custom endpoints (max 10)")
 			return
 		}
 		for _, ep := range endpoints {
 			if strings.TrimSpace(ep.Name) == "" {
-				response.BadRequest(c, "Custom endpoint name is required")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Custom endpoint name is required")
 				return
 			}
 			if len(ep.Name) > maxEndpointNameLen {
-				response.BadRequest(c, "Custom endpoint name is too long (max 50 characters)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom endpoint name is too long (max 50 characters)")
 				return
 			}
 			if strings.TrimSpace(ep.Endpoint) == "" {
-				response.BadRequest(c, "Custom endpoint URL is required")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "Custom endpoint URL is required")
 				return
 			}
 			if len(ep.Endpoint) > maxEndpointURLLen {
-				response.BadRequest(c, "Custom endpoint URL is too long (max 2048 characters)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom endpoint URL is too long (max 2048 characters)")
 				return
 			}
 			if err := config.ValidateAbsoluteHTTPURL(strings.TrimSpace(ep.Endpoint)); err != nil {
-				response.BadRequest(c, "Custom endpoint URL must be an absolute http(s) URL")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom endpoint URL must be an absolute http(s) URL")
 				return
 			}
 			if len(ep.Description) > maxEndpointDescriptionLen {
-				response.BadRequest(c, "Custom endpoint description is too long (max 200 characters)")
+				response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Custom endpoint description is too long (max 200 characters)")
 				return
 			}
 		}
 		endpointBytes, err := json.Marshal(endpoints)
 		if err != nil {
-			response.BadRequest(c, "Failed to serialize custom endpoints")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Failed to serialize custom endpoints")
 			return
 		}
 		customEndpointsJSON = string(endpointBytes)
@@ -1265,27 +1265,27 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	// 验证最低版本号格式（空字符串=禁用，或合法 semver）
 	if req.MinClaudeCodeVersion != "" {
 		if !semverPattern.MatchString(req.MinClaudeCodeVersion) {
-			response.Error(c, http.StatusBadRequest, "min_claude_code_version must be empty or a valid semver (e.g. 2.1.63)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "min_claude_code_version must be empty or a valid semver (e.g. 2.1.63)")
 			return
 		}
 	}
 	if req.OpenAIUsageWindowYellow5HPercent <= 0 || req.OpenAIUsageWindowYellow5HPercent > 100 {
-		response.Error(c, http.StatusBadRequest, "openai_usage_window_yellow_5h_percent must be in (0,100]")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "openai_usage_window_yellow_5h_percent must be in (0,100]")
 		return
 	}
 	if req.OpenAIUsageWindowYellow7DPercent <= 0 || req.OpenAIUsageWindowYellow7DPercent > 100 {
-		response.Error(c, http.StatusBadRequest, "openai_usage_window_yellow_7d_percent must be in (0,100]")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "openai_usage_window_yellow_7d_percent must be in (0,100]")
 		return
 	}
 	if req.OpenAIUsageWindowSnapshotStaleSeconds <= 0 {
-		response.Error(c, http.StatusBadRequest, "openai_usage_window_snapshot_stale_seconds must be positive")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "openai_usage_window_snapshot_stale_seconds must be positive")
 		return
 	}
 
 	// 验证最高版本号格式（空字符串=禁用，或合法 semver）
 	if req.MaxClaudeCodeVersion != "" {
 		if !semverPattern.MatchString(req.MaxClaudeCodeVersion) {
-			response.Error(c, http.StatusBadRequest, "max_claude_code_version must be empty or a valid semver (e.g. 3.0.0)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "max_claude_code_version must be empty or a valid semver (e.g. 3.0.0)")
 			return
 		}
 	}
@@ -1293,7 +1293,7 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	// 交叉验证：如果同时设置了最低和最高版本号，最高版本号必须 >= 最低版本号
 	if req.MinClaudeCodeVersion != "" && req.MaxClaudeCodeVersion != "" {
 		if service.CompareVersions(req.MaxClaudeCodeVersion, req.MinClaudeCodeVersion) < 0 {
-			response.Error(c, http.StatusBadRequest, "max_claude_code_version must be greater than or equal to min_claude_code_version")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "max_claude_code_version must be greater than or equal to min_claude_code_version")
 			return
 		}
 	}
@@ -1602,7 +1602,7 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 	// Update OpenAI fast policy (stored under dedicated key, only when provided).
 	if req.OpenAIFastPolicySettings != nil {
 		if err := h.settingService.SetOpenAIFastPolicySettings(c.Request.Context(), openaiFastPolicySettingsFromDTO(req.OpenAIFastPolicySettings)); err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 			return
 		}
 	}
@@ -2472,7 +2472,7 @@ type TestSMTPRequest struct {
 func (h *SettingHandler) TestSMTPConnection(c *gin.Context) {
 	var req TestSMTPRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2502,7 +2502,7 @@ func (h *SettingHandler) TestSMTPConnection(c *gin.Context) {
 		password = savedConfig.Password
 	}
 	if req.SMTPHost == "" {
-		response.BadRequest(c, "SMTP host is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "SMTP host is required")
 		return
 	}
 
@@ -2516,7 +2516,7 @@ func (h *SettingHandler) TestSMTPConnection(c *gin.Context) {
 
 	err := h.emailService.TestSMTPConnectionWithConfig(config)
 	if err != nil {
-		response.BadRequest(c, "SMTP connection test failed: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "SMTP connection test failed: "+err.Error())
 		return
 	}
 
@@ -2540,7 +2540,7 @@ type SendTestEmailRequest struct {
 func (h *SettingHandler) SendTestEmail(c *gin.Context) {
 	var req SendTestEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2578,7 +2578,7 @@ func (h *SettingHandler) SendTestEmail(c *gin.Context) {
 		req.SMTPFromName = savedConfig.FromName
 	}
 	if req.SMTPHost == "" {
-		response.BadRequest(c, "SMTP host is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "SMTP host is required")
 		return
 	}
 
@@ -2627,7 +2627,7 @@ func (h *SettingHandler) SendTestEmail(c *gin.Context) {
 `
 
 	if err := h.emailService.SendEmailWithConfig(config, req.Email, subject, body); err != nil {
-		response.BadRequest(c, "Failed to send test email: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, "Failed to send test email: "+err.Error())
 		return
 	}
 
@@ -2700,7 +2700,7 @@ type UpdateOverloadCooldownSettingsRequest struct {
 func (h *SettingHandler) UpdateOverloadCooldownSettings(c *gin.Context) {
 	var req UpdateOverloadCooldownSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2710,7 +2710,7 @@ func (h *SettingHandler) UpdateOverloadCooldownSettings(c *gin.Context) {
 	}
 
 	if err := h.settingService.SetOverloadCooldownSettings(c.Request.Context(), settings); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -2752,7 +2752,7 @@ type UpdateRateLimit429CooldownSettingsRequest struct {
 func (h *SettingHandler) UpdateRateLimit429CooldownSettings(c *gin.Context) {
 	var req UpdateRateLimit429CooldownSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2762,7 +2762,7 @@ func (h *SettingHandler) UpdateRateLimit429CooldownSettings(c *gin.Context) {
 	}
 
 	if err := h.settingService.SetRateLimit429CooldownSettings(c.Request.Context(), settings); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -2832,7 +2832,7 @@ type UpdateRectifierSettingsRequest struct {
 func (h *SettingHandler) UpdateRectifierSettings(c *gin.Context) {
 	var req UpdateRectifierSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2840,7 +2840,7 @@ func (h *SettingHandler) UpdateRectifierSettings(c *gin.Context) {
 	const maxPatterns = 50
 	const maxPatternLen = 500
 	if len(req.APIKeySignaturePatterns) > maxPatterns {
-		response.BadRequest(c, "Too many signature patterns (max 50)")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Too many signature patterns (max 50)")
 		return
 	}
 	var cleanedPatterns []string
@@ -2850,7 +2850,7 @@ func (h *SettingHandler) UpdateRectifierSettings(c *gin.Context) {
 			continue
 		}
 		if len(p) > maxPatternLen {
-			response.BadRequest(c, "Signature pattern too long (max 500 characters)")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Signature pattern too long (max 500 characters)")
 			return
 		}
 		cleanedPatterns = append(cleanedPatterns, p)
@@ -2865,7 +2865,7 @@ func (h *SettingHandler) UpdateRectifierSettings(c *gin.Context) {
 	}
 
 	if err := h.settingService.SetRectifierSettings(c.Request.Context(), settings); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -2915,7 +2915,7 @@ type UpdateBetaPolicySettingsRequest struct {
 func (h *SettingHandler) UpdateBetaPolicySettings(c *gin.Context) {
 	var req UpdateBetaPolicySettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2926,7 +2926,7 @@ func (h *SettingHandler) UpdateBetaPolicySettings(c *gin.Context) {
 
 	settings := &service.BetaPolicySettings{Rules: rules}
 	if err := h.settingService.SetBetaPolicySettings(c.Request.Context(), settings); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -2958,7 +2958,7 @@ type UpdateStreamTimeoutSettingsRequest struct {
 func (h *SettingHandler) UpdateStreamTimeoutSettings(c *gin.Context) {
 	var req UpdateStreamTimeoutSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -2971,7 +2971,7 @@ func (h *SettingHandler) UpdateStreamTimeoutSettings(c *gin.Context) {
 	}
 
 	if err := h.settingService.SetStreamTimeoutSettings(c.Request.Context(), settings); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
 		return
 	}
 
@@ -3007,7 +3007,7 @@ func (h *SettingHandler) GetWebSearchEmulationConfig(c *gin.Context) {
 func (h *SettingHandler) UpdateWebSearchEmulationConfig(c *gin.Context) {
 	var cfg service.WebSearchEmulationConfig
 	if err := c.ShouldBindJSON(&cfg); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -3032,11 +3032,11 @@ func (h *SettingHandler) ResetWebSearchUsage(c *gin.Context) {
 		ProviderType string `json:"provider_type"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if req.ProviderType == "" {
-		response.BadRequest(c, "provider_type is required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "provider_type is required")
 		return
 	}
 	if err := service.ResetWebSearchUsage(c.Request.Context(), req.ProviderType); err != nil {
@@ -3053,7 +3053,7 @@ func (h *SettingHandler) TestWebSearchEmulation(c *gin.Context) {
 		Query string `json:"query"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.Query) == "" {
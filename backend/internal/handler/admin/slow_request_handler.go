@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SlowRequestHandler handles admin queries of recent slow gateway requests
+type SlowRequestHandler struct {
+	slowRequestLogger *service.SlowRequestLogger
+}
+
+// NewSlowRequestHandler creates a new admin slow request handler
+func NewSlowRequestHandler(slowRequestLogger *service.SlowRequestLogger) *SlowRequestHandler {
+	return &SlowRequestHandler{slowRequestLogger: slowRequestLogger}
+}
+
+// List returns the most recent slow requests, newest first.
+// GET /api/v1/admin/slow-requests?limit=
+func (h *SlowRequestHandler) List(c *gin.Context) {
+	limit := 100
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid limit")
+			return
+		}
+		limit = n
+	}
+	response.Success(c, h.slowRequestLogger.Recent(limit))
+}
@@ -44,7 +44,7 @@ func (h *SystemHandler) CheckUpdates(c *gin.Context) {
 	force := c.Query("force") == "true"
 	info, err := h.updateSvc.CheckUpdate(c.Request.Context(), force)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		response.ErrorWithCode(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		return
 	}
 	response.Success(c, info)
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/Wei-Shaw/sub2api/internal/model"
@@ -67,7 +68,7 @@ func (h *TLSFingerprintProfileHandler) List(c *gin.Context) {
 func (h *TLSFingerprintProfileHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid profile ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile ID")
 		return
 	}
 
@@ -77,7 +78,7 @@ func (h *TLSFingerprintProfileHandler) GetByID(c *gin.Context) {
 		return
 	}
 	if profile == nil {
-		response.NotFound(c, "Profile not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Profile not found")
 		return
 	}
 
@@ -89,7 +90,7 @@ func (h *TLSFingerprintProfileHandler) GetByID(c *gin.Context) {
 func (h *TLSFingerprintProfileHandler) Create(c *gin.Context) {
 	var req CreateTLSFingerprintProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -114,7 +115,7 @@ func (h *TLSFingerprintProfileHandler) Create(c *gin.Context) {
 	created, err := h.service.Create(c.Request.Context(), profile)
 	if err != nil {
 		if _, ok := err.(*model.ValidationError); ok {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 			return
 		}
 		response.ErrorFrom(c, err)
@@ -129,13 +130,13 @@ func (h *TLSFingerprintProfileHandler) Create(c *gin.Context) {
 func (h *TLSFingerprintProfileHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid profile ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile ID")
 		return
 	}
 
 	var req UpdateTLSFingerprintProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -145,7 +146,7 @@ func (h *TLSFingerprintProfileHandler) Update(c *gin.Context) {
 		return
 	}
 	if existing == nil {
-		response.NotFound(c, "Profile not found")
+		response.ErrorWithCode(c, http.StatusNotFound, response.CodeNotFound, "Profile not found")
 		return
 	}
 
@@ -206,7 +207,7 @@ func (h *TLSFingerprintProfileHandler) Update(c *gin.Context) {
 	updated, err := h.service.Update(c.Request.Context(), profile)
 	if err != nil {
 		if _, ok := err.(*model.ValidationError); ok {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, err.Error())
 			return
 		}
 		response.ErrorFrom(c, err)
@@ -221,7 +222,7 @@ func (h *TLSFingerprintProfileHandler) Update(c *gin.Context) {
 func (h *TLSFingerprintProfileHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid profile ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid profile ID")
 		return
 	}
 
@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// maxTokenCountTextBytes 调试接口单次计数文本的长度上限
+const maxTokenCountTextBytes = 1 << 20
+
+// TokenCountResult token 计数结果
+type TokenCountResult struct {
+	Model     string `json:"model"`
+	Tokenizer string `json:"tokenizer"`
+	Tokens    int    `json:"tokens"`
+	Chars     int    `json:"chars"`
+}
+
+// CountTokens 按模型对应的 tokenizer 计算文本 token 数（用于排查用量估算）
+// GET /api/v1/admin/tokens/count?model=&text=
+func (h *PricingHandler) CountTokens(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "model is required")
+		return
+	}
+	text := c.Query("text")
+	if len(text) > maxTokenCountTextBytes {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "text is too long")
+		return
+	}
+
+	tokens, tokenizer := h.billingService.CountTokens(model, text)
+	response.Success(c, TokenCountResult{
+		Model:     model,
+		Tokenizer: tokenizer,
+		Tokens:    tokens,
+		Chars:     len([]rune(text)),
+	})
+}
@@ -25,6 +25,7 @@ type UsageHandler struct {
 	apiKeyService  *service.APIKeyService
 	adminService   service.AdminService
 	cleanupService *service.UsageCleanupService
+	reportService  *service.UsageReportService
 }
 
 // NewUsageHandler creates a new admin usage handler
@@ -33,12 +34,14 @@ func NewUsageHandler(
 	apiKeyService *service.APIKeyService,
 	adminService service.AdminService,
 	cleanupService *service.UsageCleanupService,
+	reportService *service.UsageReportService,
 ) *UsageHandler {
 	return &UsageHandler{
 		usageService:   usageService,
 		apiKeyService:  apiKeyService,
 		adminService:   adminService,
 		cleanupService: cleanupService,
+		reportService:  reportService,
 	}
 }
 
@@ -65,7 +68,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if exactTotalRaw := strings.TrimSpace(c.Query("exact_total")); exactTotalRaw != "" {
 		parsed, err := strconv.ParseBool(exactTotalRaw)
 		if err != nil {
-			response.BadRequest(c, "Invalid exact_total value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid exact_total value, use true or false")
 			return
 		}
 		exactTotal = parsed
@@ -76,7 +79,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if userIDStr := c.Query("user_id"); userIDStr != "" {
 		id, err := strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid user_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 			return
 		}
 		userID = id
@@ -85,7 +88,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if apiKeyIDStr := c.Query("api_key_id"); apiKeyIDStr != "" {
 		id, err := strconv.ParseInt(apiKeyIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid api_key_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid api_key_id")
 			return
 		}
 		apiKeyID = id
@@ -94,7 +97,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if accountIDStr := c.Query("account_id"); accountIDStr != "" {
 		id, err := strconv.ParseInt(accountIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		accountID = id
@@ -103,7 +106,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if groupIDStr := c.Query("group_id"); groupIDStr != "" {
 		id, err := strconv.ParseInt(groupIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		groupID = id
@@ -117,7 +120,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if requestTypeStr := strings.TrimSpace(c.Query("request_type")); requestTypeStr != "" {
 		parsed, err := service.ParseUsageRequestType(requestTypeStr)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -125,7 +128,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	} else if streamStr := c.Query("stream"); streamStr != "" {
 		val, err := strconv.ParseBool(streamStr)
 		if err != nil {
-			response.BadRequest(c, "Invalid stream value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stream value, use true or false")
 			return
 		}
 		stream = &val
@@ -135,7 +138,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if billingTypeStr := c.Query("billing_type"); billingTypeStr != "" {
 		val, err := strconv.ParseInt(billingTypeStr, 10, 8)
 		if err != nil {
-			response.BadRequest(c, "Invalid billing_type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid billing_type")
 			return
 		}
 		bt := int8(val)
@@ -148,7 +151,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
 		t, err := timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
 		if err != nil {
-			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid start_date format, use YYYY-MM-DD")
 			return
 		}
 		startTime = &t
@@ -157,7 +160,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 	if endDateStr := c.Query("end_date"); endDateStr != "" {
 		t, err := timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
 		if err != nil {
-			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid end_date format, use YYYY-MM-DD")
 			return
 		}
 		// Use half-open range [start, end), move to next calendar day start (DST-safe).
@@ -207,7 +210,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if userIDStr := c.Query("user_id"); userIDStr != "" {
 		id, err := strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid user_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 			return
 		}
 		userID = id
@@ -216,7 +219,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if apiKeyIDStr := c.Query("api_key_id"); apiKeyIDStr != "" {
 		id, err := strconv.ParseInt(apiKeyIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid api_key_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid api_key_id")
 			return
 		}
 		apiKeyID = id
@@ -225,7 +228,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if accountIDStr := c.Query("account_id"); accountIDStr != "" {
 		id, err := strconv.ParseInt(accountIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid account_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid account_id")
 			return
 		}
 		accountID = id
@@ -234,7 +237,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if groupIDStr := c.Query("group_id"); groupIDStr != "" {
 		id, err := strconv.ParseInt(groupIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid group_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid group_id")
 			return
 		}
 		groupID = id
@@ -248,7 +251,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if requestTypeStr := strings.TrimSpace(c.Query("request_type")); requestTypeStr != "" {
 		parsed, err := service.ParseUsageRequestType(requestTypeStr)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -256,7 +259,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	} else if streamStr := c.Query("stream"); streamStr != "" {
 		val, err := strconv.ParseBool(streamStr)
 		if err != nil {
-			response.BadRequest(c, "Invalid stream value, use true or false")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid stream value, use true or false")
 			return
 		}
 		stream = &val
@@ -266,7 +269,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 	if billingTypeStr := c.Query("billing_type"); billingTypeStr != "" {
 		val, err := strconv.ParseInt(billingTypeStr, 10, 8)
 		if err != nil {
-			response.BadRequest(c, "Invalid billing_type")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid billing_type")
 			return
 		}
 		bt := int8(val)
@@ -285,12 +288,12 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 		var err error
 		startTime, err = timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
 		if err != nil {
-			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid start_date format, use YYYY-MM-DD")
 			return
 		}
 		endTime, err = timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
 		if err != nil {
-			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid end_date format, use YYYY-MM-DD")
 			return
 		}
 		// 与 SQL 条件 created_at < end 对齐，使用次日 00:00 作为上边界（DST-safe）。
@@ -377,7 +380,7 @@ func (h *UsageHandler) SearchAPIKeys(c *gin.Context) {
 	if userIDStr != "" {
 		id, err := strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
-			response.BadRequest(c, "Invalid user_id")
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user_id")
 			return
 		}
 		userID = id
@@ -412,7 +415,7 @@ func (h *UsageHandler) SearchAPIKeys(c *gin.Context) {
 // GET /api/v1/admin/usage/cleanup-tasks
 func (h *UsageHandler) ListCleanupTasks(c *gin.Context) {
 	if h.cleanupService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Usage cleanup service unavailable")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Usage cleanup service unavailable")
 		return
 	}
 	operator := int64(0)
@@ -440,35 +443,35 @@ func (h *UsageHandler) ListCleanupTasks(c *gin.Context) {
 // POST /api/v1/admin/usage/cleanup-tasks
 func (h *UsageHandler) CreateCleanupTask(c *gin.Context) {
 	if h.cleanupService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Usage cleanup service unavailable")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Usage cleanup service unavailable")
 		return
 	}
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Unauthorized(c, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req CreateUsageCleanupTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	req.StartDate = strings.TrimSpace(req.StartDate)
 	req.EndDate = strings.TrimSpace(req.EndDate)
 	if req.StartDate == "" || req.EndDate == "" {
-		response.BadRequest(c, "start_date and end_date are required")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "start_date and end_date are required")
 		return
 	}
 
 	startTime, err := timezone.ParseInUserLocation("2006-01-02", req.StartDate, req.Timezone)
 	if err != nil {
-		response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid start_date format, use YYYY-MM-DD")
 		return
 	}
 	endTime, err := timezone.ParseInUserLocation("2006-01-02", req.EndDate, req.Timezone)
 	if err != nil {
-		response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid end_date format, use YYYY-MM-DD")
 		return
 	}
 	endTime = endTime.Add(24*time.Hour - time.Nanosecond)
@@ -478,7 +481,7 @@ func (h *UsageHandler) CreateCleanupTask(c *gin.Context) {
 	if req.RequestType != nil {
 		parsed, err := service.ParseUsageRequestType(*req.RequestType)
 		if err != nil {
-			response.BadRequest(c, err.Error())
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, err.Error())
 			return
 		}
 		value := int16(parsed)
@@ -569,18 +572,18 @@ func (h *UsageHandler) CreateCleanupTask(c *gin.Context) {
 // POST /api/v1/admin/usage/cleanup-tasks/:id/cancel
 func (h *UsageHandler) CancelCleanupTask(c *gin.Context) {
 	if h.cleanupService == nil {
-		response.Error(c, http.StatusServiceUnavailable, "Usage cleanup service unavailable")
+		response.ErrorWithCode(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, "Usage cleanup service unavailable")
 		return
 	}
 	subject, ok := middleware.GetAuthSubjectFromContext(c)
 	if !ok || subject.UserID <= 0 {
-		response.Unauthorized(c, "Unauthorized")
+		response.ErrorWithCode(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized")
 		return
 	}
 	idStr := strings.TrimSpace(c.Param("id"))
 	taskID, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || taskID <= 0 {
-		response.BadRequest(c, "Invalid task id")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid task id")
 		return
 	}
 	logger.LegacyPrintf("handler.admin.usage", "[UsageCleanup] 请求取消清理任务: task=%d operator=%d", taskID, subject.UserID)
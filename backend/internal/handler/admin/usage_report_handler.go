@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// usageReportFlushEvery 流式输出时每写入多少行刷新一次响应
+const usageReportFlushEvery = 500
+
+// Report 导出按维度聚合的用量对账报表（format=csv|json，默认 csv）
+// GET /api/v1/admin/usage/report?from=YYYY-MM-DD&to=YYYY-MM-DD&group_by=model,day
+// 日期按 UTC 计算且包含两端，未指定时默认为本月 1 日至今天；group_by 可组合 model / provider / user / day。
+// 默认不含沙盒 Key 的用量，include_sandbox=true 时计入。
+// 结果逐行流式写出，不在内存中缓冲整个报表。
+func (h *UsageHandler) Report(c *gin.Context) {
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "csv")))
+	if format != "csv" && format != "json" {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid format: must be csv or json")
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	var err error
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		if from, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		if to, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	groupBy, err := service.ParseUsageReportGroupBy(c.Query("group_by"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	includeSandbox, _ := strconv.ParseBool(c.DefaultQuery("include_sandbox", "false"))
+
+	query := service.UsageReportQuery{From: from, To: to, GroupBy: groupBy, IncludeSandbox: includeSandbox}
+	w := &usageReportWriter{c: c, format: format, query: query}
+	if err := h.reportService.StreamReport(c.Request.Context(), query, w.write); err != nil {
+		if !w.started {
+			response.ErrorFrom(c, err)
+			return
+		}
+		// 响应头已发送，只能中止写入
+		_ = c.Error(err)
+		return
+	}
+	w.finish()
+}
+
+// usageReportWriter 在收到第一行时才发送响应头，使查询失败仍能返回正常的错误响应
+type usageReportWriter struct {
+	c       *gin.Context
+	format  string
+	query   service.UsageReportQuery
+	csv     *csv.Writer
+	started bool
+	rows    int
+}
+
+func (w *usageReportWriter) start() {
+	w.started = true
+	filename := fmt.Sprintf("usage_report_%s_%s.%s", w.query.From.Format(time.DateOnly), w.query.To.Format(time.DateOnly), w.format)
+	w.c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	if w.format == "json" {
+		w.c.Header("Content-Type", "application/json; charset=utf-8")
+		w.c.Status(http.StatusOK)
+		_, _ = w.c.Writer.WriteString("[")
+		return
+	}
+
+	w.c.Header("Content-Type", "text/csv; charset=utf-8")
+	w.c.Status(http.StatusOK)
+	w.csv = csv.NewWriter(w.c.Writer)
+	header := make([]string, 0, 12)
+	for _, dim := range w.query.GroupBy {
+		if dim == service.UsageReportByUser {
+			header = append(header, "user_id", "user_email")
+			continue
+		}
+		header = append(header, string(dim))
+	}
+	header = append(header, "requests", "input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens", "total_cost", "actual_cost")
+	_ = w.csv.Write(header)
+}
+
+func (w *usageReportWriter) write(row service.UsageReportRow) error {
+	if !w.started {
+		w.start()
+	}
+	if w.format == "json" {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if w.rows > 0 {
+			_, _ = w.c.Writer.WriteString(",")
+		}
+		if _, err := w.c.Writer.Write(data); err != nil {
+			return err
+		}
+	} else {
+		if err := w.csv.Write(w.csvRecord(row)); err != nil {
+			return err
+		}
+	}
+
+	w.rows++
+	if w.rows%usageReportFlushEvery == 0 {
+		if w.csv != nil {
+			w.csv.Flush()
+		}
+		w.c.Writer.Flush()
+	}
+	return nil
+}
+
+func (w *usageReportWriter) csvRecord(row service.UsageReportRow) []string {
+	record := make([]string, 0, 12)
+	for _, dim := range w.query.GroupBy {
+		switch dim {
+		case service.UsageReportByDay:
+			record = append(record, row.Day)
+		case service.UsageReportByModel:
+			record = append(record, row.Model)
+		case service.UsageReportByProvider:
+			record = append(record, row.Provider)
+		case service.UsageReportByUser:
+			record = append(record, strconv.FormatInt(row.UserID, 10), row.UserEmail)
+		}
+	}
+	return append(record,
+		strconv.FormatInt(row.Requests, 10),
+		strconv.FormatInt(row.InputTokens, 10),
+		strconv.FormatInt(row.OutputTokens, 10),
+		strconv.FormatInt(row.CacheCreationTokens, 10),
+		strconv.FormatInt(row.CacheReadTokens, 10),
+		strconv.FormatFloat(row.TotalCost, 'f', -1, 64),
+		strconv.FormatFloat(row.ActualCost, 'f', -1, 64),
+	)
+}
+
+// finish 写出结尾（无数据时也输出表头或空数组）
+func (w *usageReportWriter) finish() {
+	if !w.started {
+		w.start()
+	}
+	if w.format == "json" {
+		_, _ = w.c.Writer.WriteString("]")
+		return
+	}
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		_ = w.c.Error(err)
+	}
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type usageReportRepoStub struct {
+	rows  []service.UsageReportRow
+	err   error
+	query service.UsageReportQuery
+}
+
+func (s *usageReportRepoStub) StreamUsageReport(_ context.Context, query service.UsageReportQuery, fn func(service.UsageReportRow) error) error {
+	s.query = query
+	if s.err != nil {
+		return s.err
+	}
+	for _, row := range s.rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doUsageReportRequest(t *testing.T, repo *usageReportRepoStub, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	handler := NewUsageHandler(nil, nil, nil, nil, service.NewUsageReportService(repo))
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	handler.Report(c)
+	return w
+}
+
+func TestUsageReport_CSVGroupedByModelAndDay(t *testing.T) {
+	repo := &usageReportRepoStub{rows: []service.UsageReportRow{
+		{Model: "claude-x", Day: "2026-03-01", Requests: 2, InputTokens: 100, OutputTokens: 50, TotalCost: 0.5, ActualCost: 0.4},
+		{Model: "gpt-x", Day: "2026-03-02", Requests: 1, InputTokens: 10, OutputTokens: 5, TotalCost: 0.1, ActualCost: 0.1},
+	}}
+	w := doUsageReportRequest(t, repo, "/?from=2026-03-01&to=2026-03-31&group_by=model,day")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Disposition"), "usage_report_2026-03-01_2026-03-31.csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "model,day,requests,input_tokens,output_tokens,cache_creation_tokens,cache_read_tokens,total_cost,actual_cost", lines[0])
+	require.Equal(t, "claude-x,2026-03-01,2,100,50,0,0,0.5,0.4", lines[1])
+
+	require.Equal(t, []service.UsageReportDimension{service.UsageReportByModel, service.UsageReportByDay}, repo.query.GroupBy)
+	require.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), repo.query.To)
+}
+
+func TestUsageReport_JSONUserAndProvider(t *testing.T) {
+	repo := &usageReportRepoStub{rows: []service.UsageReportRow{
+		{UserID: 7, UserEmail: "a@example.com", Provider: "anthropic", Requests: 3},
+	}}
+	w := doUsageReportRequest(t, repo, "/?from=2026-03-01&to=2026-03-02&group_by=user,provider&format=json")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rows))
+	require.Len(t, rows, 1)
+	require.EqualValues(t, 7, rows[0]["user_id"])
+	require.Equal(t, "anthropic", rows[0]["provider"])
+	require.NotContains(t, rows[0], "model")
+
+	// 无数据时输出空数组
+	w = doUsageReportRequest(t, &usageReportRepoStub{}, "/?from=2026-03-01&to=2026-03-02&format=json")
+	require.Equal(t, "[]", w.Body.String())
+}
+
+func TestUsageReport_RejectsInvalidParams(t *testing.T) {
+	for _, target := range []string{
+		"/?group_by=model,account",
+		"/?from=2026-03-05&to=2026-03-01",
+		"/?from=2024-01-01&to=2026-01-01",
+		"/?from=03/01/2026",
+		"/?format=xml",
+	} {
+		w := doUsageReportRequest(t, &usageReportRepoStub{}, target)
+		require.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+
+	w := doUsageReportRequest(t, &usageReportRepoStub{err: errors.New("db down")}, "/?from=2026-03-01&to=2026-03-02")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"encoding/json"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -155,7 +156,7 @@ func (h *UserAttributeHandler) ListDefinitions(c *gin.Context) {
 func (h *UserAttributeHandler) CreateDefinition(c *gin.Context) {
 	var req CreateAttributeDefinitionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -183,13 +184,13 @@ func (h *UserAttributeHandler) CreateDefinition(c *gin.Context) {
 func (h *UserAttributeHandler) UpdateDefinition(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid attribute ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid attribute ID")
 		return
 	}
 
 	var req UpdateAttributeDefinitionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -221,7 +222,7 @@ func (h *UserAttributeHandler) UpdateDefinition(c *gin.Context) {
 func (h *UserAttributeHandler) DeleteDefinition(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid attribute ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid attribute ID")
 		return
 	}
 
@@ -238,7 +239,7 @@ func (h *UserAttributeHandler) DeleteDefinition(c *gin.Context) {
 func (h *UserAttributeHandler) ReorderDefinitions(c *gin.Context) {
 	var req ReorderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -261,7 +262,7 @@ func (h *UserAttributeHandler) ReorderDefinitions(c *gin.Context) {
 func (h *UserAttributeHandler) GetUserAttributes(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -284,13 +285,13 @@ func (h *UserAttributeHandler) GetUserAttributes(c *gin.Context) {
 func (h *UserAttributeHandler) UpdateUserAttributes(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
 	var req UpdateUserAttributesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -327,7 +328,7 @@ func (h *UserAttributeHandler) UpdateUserAttributes(c *gin.Context) {
 func (h *UserAttributeHandler) GetBatchUserAttributes(c *gin.Context) {
 	var req BatchGetUserAttributesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
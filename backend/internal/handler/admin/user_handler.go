@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -22,13 +23,15 @@ type UserWithConcurrency struct {
 type UserHandler struct {
 	adminService       service.AdminService
 	concurrencyService *service.ConcurrencyService
+	userSpendService   *service.UserSpendService
 }
 
 // NewUserHandler creates a new admin user handler
-func NewUserHandler(adminService service.AdminService, concurrencyService *service.ConcurrencyService) *UserHandler {
+func NewUserHandler(adminService service.AdminService, concurrencyService *service.ConcurrencyService, userSpendService *service.UserSpendService) *UserHandler {
 	return &UserHandler{
 		adminService:       adminService,
 		concurrencyService: concurrencyService,
+		userSpendService:   userSpendService,
 	}
 }
 
@@ -43,6 +46,9 @@ type CreateUserRequest struct {
 	Concurrency   int     `json:"concurrency"`
 	RPMLimit      int     `json:"rpm_limit"`
 	AllowedGroups []int64 `json:"allowed_groups"`
+	// BudgetLimit 月度预算（USD，0 = 不限制）
+	BudgetLimit     float64 `json:"budget_limit" binding:"gte=0"`
+	BudgetSoftLimit bool    `json:"budget_soft_limit"`
 }
 
 // UpdateUserRequest represents admin update user request
@@ -61,6 +67,9 @@ type UpdateUserRequest struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]*rate，nil 表示删除该分组的专属倍率
 	GroupRates map[int64]*float64 `json:"group_rates"`
+	// BudgetLimit 月度预算（USD，0 = 不限制），nil 表示不修改
+	BudgetLimit     *float64 `json:"budget_limit" binding:"omitempty,gte=0"`
+	BudgetSoftLimit *bool    `json:"budget_soft_limit"`
 }
 
 // UpdateBalanceRequest represents balance update request
@@ -179,7 +188,7 @@ func parseAttributeFilters(c *gin.Context) map[int64]string {
 func (h *UserHandler) GetByID(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -197,13 +206,13 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 func (h *UserHandler) BindAuthIdentity(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
 	var req BindUserAuthIdentityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -236,20 +245,22 @@ func (h *UserHandler) BindAuthIdentity(c *gin.Context) {
 func (h *UserHandler) Create(c *gin.Context) {
 	var req CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	user, err := h.adminService.CreateUser(c.Request.Context(), &service.CreateUserInput{
-		Email:         req.Email,
-		Password:      req.Password,
-		Username:      req.Username,
-		Notes:         req.Notes,
-		Role:          req.Role,
-		Balance:       req.Balance,
-		Concurrency:   req.Concurrency,
-		RPMLimit:      req.RPMLimit,
-		AllowedGroups: req.AllowedGroups,
+		Email:           req.Email,
+		Password:        req.Password,
+		Username:        req.Username,
+		Notes:           req.Notes,
+		Role:            req.Role,
+		Balance:         req.Balance,
+		Concurrency:     req.Concurrency,
+		RPMLimit:        req.RPMLimit,
+		AllowedGroups:   req.AllowedGroups,
+		BudgetLimit:     req.BudgetLimit,
+		BudgetSoftLimit: req.BudgetSoftLimit,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -264,29 +275,31 @@ func (h *UserHandler) Create(c *gin.Context) {
 func (h *UserHandler) Update(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
 	// 使用指针类型直接传递，nil 表示未提供该字段
 	user, err := h.adminService.UpdateUser(c.Request.Context(), userID, &service.UpdateUserInput{
-		Email:         req.Email,
-		Password:      req.Password,
-		Username:      req.Username,
-		Notes:         req.Notes,
-		Role:          req.Role,
-		Balance:       req.Balance,
-		Concurrency:   req.Concurrency,
-		RPMLimit:      req.RPMLimit,
-		Status:        req.Status,
-		AllowedGroups: req.AllowedGroups,
-		GroupRates:    req.GroupRates,
+		Email:           req.Email,
+		Password:        req.Password,
+		Username:        req.Username,
+		Notes:           req.Notes,
+		Role:            req.Role,
+		Balance:         req.Balance,
+		Concurrency:     req.Concurrency,
+		RPMLimit:        req.RPMLimit,
+		Status:          req.Status,
+		AllowedGroups:   req.AllowedGroups,
+		GroupRates:      req.GroupRates,
+		BudgetLimit:     req.BudgetLimit,
+		BudgetSoftLimit: req.BudgetSoftLimit,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -301,7 +314,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 func (h *UserHandler) Delete(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -319,13 +332,13 @@ func (h *UserHandler) Delete(c *gin.Context) {
 func (h *UserHandler) UpdateBalance(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
 	var req UpdateBalanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -350,7 +363,7 @@ func (h *UserHandler) UpdateBalance(c *gin.Context) {
 func (h *UserHandler) GetUserAPIKeys(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -376,7 +389,7 @@ func (h *UserHandler) GetUserAPIKeys(c *gin.Context) {
 func (h *UserHandler) GetUserUsage(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -398,7 +411,7 @@ func (h *UserHandler) GetUserUsage(c *gin.Context) {
 func (h *UserHandler) GetBalanceHistory(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -443,13 +456,13 @@ type ReplaceGroupRequest struct {
 func (h *UserHandler) ReplaceGroup(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
 	var req ReplaceGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 
@@ -469,7 +482,7 @@ func (h *UserHandler) ReplaceGroup(c *gin.Context) {
 func (h *UserHandler) GetUserRPMStatus(c *gin.Context) {
 	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
 		return
 	}
 
@@ -494,15 +507,15 @@ type BatchUpdateConcurrencyRequest struct {
 func (h *UserHandler) BatchUpdateConcurrency(c *gin.Context) {
 	var req BatchUpdateConcurrencyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request: "+err.Error())
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
 		return
 	}
 	if !req.All && len(req.UserIDs) == 0 {
-		response.BadRequest(c, "user_ids is required unless all=true")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeMissingParameter, "user_ids is required unless all=true")
 		return
 	}
 	if len(req.UserIDs) > 500 {
-		response.BadRequest(c, "user_ids cannot exceed 500")
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "user_ids cannot exceed 500")
 		return
 	}
 
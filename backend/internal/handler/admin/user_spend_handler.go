@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserSpend 返回用户在日期区间内的消费合计、日明细与月汇总
+// GET /api/v1/admin/users/:id/spend?from=YYYY-MM-DD&to=YYYY-MM-DD
+// 日期按 UTC 计算且包含两端；未指定时默认为本月 1 日至今天。
+// 默认不含沙盒 Key 的用量，include_sandbox=true 时计入。费用按 pricing.display_significant_digits 舍入展示。
+func (h *UserHandler) GetUserSpend(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid user ID")
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		if from, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		if to, err = time.Parse(time.DateOnly, raw); err != nil {
+			response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidParameter, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	includeSandbox, _ := strconv.ParseBool(c.DefaultQuery("include_sandbox", "false"))
+
+	summary, err := h.userSpendService.GetUserSpend(c.Request.Context(), userID, from, to, includeSandbox)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	summary.RoundForDisplay(h.userSpendService.CostDisplaySignificantDigits())
+	response.Success(c, summary)
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WarmupHandler handles post-deploy warmup requests
+type WarmupHandler struct {
+	warmupService *service.WarmupService
+}
+
+// NewWarmupHandler creates a new admin warmup handler
+func NewWarmupHandler(warmupService *service.WarmupService) *WarmupHandler {
+	return &WarmupHandler{warmupService: warmupService}
+}
+
+// WarmupRequest 预热请求（请求体可省略）
+type WarmupRequest struct {
+	// AccountLimit 探测的可调度账号数量，0 使用默认值 5，最大 50
+	AccountLimit int  `json:"account_limit"`
+	SkipAccounts bool `json:"skip_accounts"`
+}
+
+// Warmup 预加载 tokenizer、填充内存定价缓存并探测部分上游账号，返回各步骤耗时。
+// 部署后切流量前调用；部分步骤失败时仍返回 200，由 success 字段与各步骤 error 判断。
+// POST /api/v1/admin/warmup
+func (h *WarmupHandler) Warmup(c *gin.Context) {
+	var req WarmupRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request: "+err.Error())
+		return
+	}
+	if _, err := service.NormalizeWarmupAccountLimit(req.AccountLimit); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, response.CodeBadRequest, err.Error())
+		return
+	}
+
+	report := h.warmupService.Run(c.Request.Context(), service.WarmupOptions{
+		AccountLimit: req.AccountLimit,
+		SkipAccounts: req.SkipAccounts,
+	})
+	response.Success(c, report)
+}
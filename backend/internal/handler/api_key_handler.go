@@ -60,6 +60,8 @@ type UpdateAPIKeyRequest struct {
 	RateLimit1d         *float64 `json:"rate_limit_1d"`
 	RateLimit7d         *float64 `json:"rate_limit_7d"`
 	ResetRateLimitUsage *bool    `json:"reset_rate_limit_usage"` // 重置限速用量
+
+	UsageHeaders *bool `json:"usage_headers"` // nil=不修改, true=响应头返回计费金额与 token 用量
 }
 
 // List handles listing user's API keys with pagination
@@ -213,6 +215,7 @@ func (h *APIKeyHandler) Update(c *gin.Context) {
 		RateLimit1d:         req.RateLimit1d,
 		RateLimit7d:         req.RateLimit7d,
 		ResetRateLimitUsage: req.ResetRateLimitUsage,
+		UsageHeaders:        req.UsageHeaders,
 	}
 	if req.Name != "" {
 		svcReq.Name = &req.Name
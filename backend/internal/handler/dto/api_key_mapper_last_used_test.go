@@ -38,3 +38,17 @@ func TestAPIKeyFromService_MapsNilLastUsedAt(t *testing.T) {
 	require.NotNil(t, out)
 	require.Nil(t, out.LastUsedAt)
 }
+
+func TestAPIKeyFromService_BlanksLookupDigestOfHashedKey(t *testing.T) {
+	out := APIKeyFromService(&service.APIKey{
+		ID:      1,
+		Key:     "kh:0123456789abcdef0123456789abcdef",
+		KeyHash: "$2a$10$hash",
+	})
+	require.True(t, out.KeyHashed)
+	require.Empty(t, out.Key)
+
+	out = APIKeyFromService(&service.APIKey{ID: 2, Key: "sk-plain"})
+	require.False(t, out.KeyHashed)
+	require.Equal(t, "sk-plain", out.Key)
+}
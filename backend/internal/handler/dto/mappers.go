@@ -66,10 +66,12 @@ func UserFromServiceAdmin(u *service.User) *AdminUser {
 		return nil
 	}
 	return &AdminUser{
-		User:       *base,
-		Notes:      u.Notes,
-		LastUsedAt: u.LastUsedAt,
-		GroupRates: u.GroupRates,
+		User:            *base,
+		Notes:           u.Notes,
+		LastUsedAt:      u.LastUsedAt,
+		GroupRates:      u.GroupRates,
+		BudgetLimit:     u.BudgetLimit,
+		BudgetSoftLimit: u.BudgetSoftLimit,
 	}
 }
 
@@ -78,32 +80,54 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 		return nil
 	}
 	out := &APIKey{
-		ID:            k.ID,
-		UserID:        k.UserID,
-		Key:           k.Key,
-		Name:          k.Name,
-		GroupID:       k.GroupID,
-		Status:        k.Status,
-		IPWhitelist:   k.IPWhitelist,
-		IPBlacklist:   k.IPBlacklist,
-		LastUsedAt:    k.LastUsedAt,
-		Quota:         k.Quota,
-		QuotaUsed:     k.QuotaUsed,
-		ExpiresAt:     k.ExpiresAt,
-		CreatedAt:     k.CreatedAt,
-		UpdatedAt:     k.UpdatedAt,
-		RateLimit5h:   k.RateLimit5h,
-		RateLimit1d:   k.RateLimit1d,
-		RateLimit7d:   k.RateLimit7d,
-		Usage5h:       k.EffectiveUsage5h(),
-		Usage1d:       k.EffectiveUsage1d(),
-		Usage7d:       k.EffectiveUsage7d(),
-		Window5hStart: k.Window5hStart,
-		Window1dStart: k.Window1dStart,
-		Window7dStart: k.Window7dStart,
-		User:          UserFromServiceShallow(k.User),
-		Group:         GroupFromServiceShallow(k.Group),
-	}
+		ID:               k.ID,
+		UserID:           k.UserID,
+		Key:              k.Key,
+		Name:             k.Name,
+		GroupID:          k.GroupID,
+		Status:           k.Status,
+		IPWhitelist:      k.IPWhitelist,
+		IPBlacklist:      k.IPBlacklist,
+		LastUsedAt:       k.LastUsedAt,
+		Quota:            k.Quota,
+		QuotaUsed:        k.QuotaUsed,
+		ExpiresAt:        k.ExpiresAt,
+		CreatedAt:        k.CreatedAt,
+		UpdatedAt:        k.UpdatedAt,
+		RateLimit5h:      k.RateLimit5h,
+		RateLimit1d:      k.RateLimit1d,
+		RateLimit7d:      k.RateLimit7d,
+		Usage5h:          k.EffectiveUsage5h(),
+		Usage1d:          k.EffectiveUsage1d(),
+		Usage7d:          k.EffectiveUsage7d(),
+		Window5hStart:    k.Window5hStart,
+		Window1dStart:    k.Window1dStart,
+		Window7dStart:    k.Window7dStart,
+		RPMLimit:         k.RPMLimit,
+		TPMLimit:         k.TPMLimit,
+		AuditCaptureBody: k.AuditCaptureBody,
+		AllowedModels:    k.AllowedModels,
+		DeniedModels:     k.DeniedModels,
+		UsageHeaders:     k.UsageHeaders,
+		IsSandbox:        k.IsSandbox,
+		PriorityTier:     k.PriorityTier,
+		MaxRequestBytes:  k.MaxRequestBytes,
+		MaxResponseBytes: k.MaxResponseBytes,
+		KeyHashed:        k.KeyHash != "",
+		User:             UserFromServiceShallow(k.User),
+		Group:            GroupFromServiceShallow(k.Group),
+
+		UsageQuotaRequests: k.UsageQuotaRequests,
+		UsageQuotaTokens:   k.UsageQuotaTokens,
+		UsageQuotaPeriod:   k.UsageQuotaPeriod,
+		UsageQuotaResetAt:  k.UsageQuotaResetAt(),
+		MaskResponseModel:  k.MaskResponseModel,
+	}
+	// 哈希存储的 Key 列中只有查找摘要，不能作为 Key 使用，不返回给前端
+	if out.KeyHashed {
+		out.Key = ""
+	}
+	out.UsageQuotaRequestsUsed, out.UsageQuotaTokensUsed = k.EffectiveUsageQuotaUsed()
 	if k.Window5hStart != nil && !service.IsWindowExpired(k.Window5hStart, service.RateLimitWindow5h) {
 		t := k.Window5hStart.Add(service.RateLimitWindow5h)
 		out.Reset5hAt = &t
@@ -153,6 +177,7 @@ func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
 		ActiveAccountCount:          g.ActiveAccountCount,
 		RateLimitedAccountCount:     g.RateLimitedAccountCount,
 		SortOrder:                   g.SortOrder,
+		SchedulingStrategy:          g.SchedulingStrategy,
 	}
 	if len(g.AccountGroups) > 0 {
 		out.AccountGroups = make([]AccountGroup, 0, len(g.AccountGroups))
@@ -206,10 +231,11 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		Platform:                a.Platform,
 		Type:                    a.Type,
 		Credentials:             a.Credentials,
-		Extra:                   a.Extra,
+		Extra:                   service.MaskAccountCustomHeaders(a.Extra),
 		ProxyID:                 a.ProxyID,
 		Concurrency:             a.Concurrency,
 		LoadFactor:              a.LoadFactor,
+		Weight:                  a.EffectiveWeight(),
 		Priority:                a.Priority,
 		RateMultiplier:          a.BillingRateMultiplier(),
 		Status:                  a.Status,
@@ -219,6 +245,7 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		AutoPauseOnExpired:      a.AutoPauseOnExpired,
 		CreatedAt:               a.CreatedAt,
 		UpdatedAt:               a.UpdatedAt,
+		DeletedAt:               a.DeletedAt,
 		Schedulable:             a.Schedulable,
 		RateLimitedAt:           a.RateLimitedAt,
 		RateLimitResetAt:        a.RateLimitResetAt,
@@ -296,10 +323,7 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		}
 		if limit := a.GetQuotaDailyLimit(); limit > 0 {
 			out.QuotaDailyLimit = &limit
-			used := a.GetQuotaDailyUsed()
-			if a.IsDailyQuotaPeriodExpired() {
-				used = 0
-			}
+			used := a.GetQuotaDailySpend()
 			out.QuotaDailyUsed = &used
 		}
 		if limit := a.GetQuotaWeeklyLimit(); limit > 0 {
@@ -605,6 +629,8 @@ func usageLogFromServiceUser(l *service.UsageLog) UsageLog {
 		UserAgent:             l.UserAgent,
 		CacheTTLOverridden:    l.CacheTTLOverridden,
 		BillingMode:           l.BillingMode,
+		AbortReason:           l.AbortReason,
+		TraceID:               l.TraceID,
 		CreatedAt:             l.CreatedAt,
 		User:                  UserFromServiceShallow(l.User),
 		APIKey:                APIKeyFromService(l.APIKey),
@@ -43,6 +43,9 @@ type AdminUser struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]rateMultiplier
 	GroupRates map[int64]float64 `json:"group_rates,omitempty"`
+	// BudgetLimit 月度预算（USD，0 = 不限制）；BudgetSoftLimit 为 true 时超出仅告警
+	BudgetLimit     float64 `json:"budget_limit"`
+	BudgetSoftLimit bool    `json:"budget_soft_limit"`
 }
 
 type APIKey struct {
@@ -62,18 +65,40 @@ type APIKey struct {
 	UpdatedAt   time.Time  `json:"updated_at"`
 
 	// Rate limit fields
-	RateLimit5h   float64    `json:"rate_limit_5h"`
-	RateLimit1d   float64    `json:"rate_limit_1d"`
-	RateLimit7d   float64    `json:"rate_limit_7d"`
-	Usage5h       float64    `json:"usage_5h"`
-	Usage1d       float64    `json:"usage_1d"`
-	Usage7d       float64    `json:"usage_7d"`
-	Window5hStart *time.Time `json:"window_5h_start"`
-	Window1dStart *time.Time `json:"window_1d_start"`
-	Window7dStart *time.Time `json:"window_7d_start"`
-	Reset5hAt     *time.Time `json:"reset_5h_at,omitempty"`
-	Reset1dAt     *time.Time `json:"reset_1d_at,omitempty"`
-	Reset7dAt     *time.Time `json:"reset_7d_at,omitempty"`
+	RateLimit5h      float64    `json:"rate_limit_5h"`
+	RateLimit1d      float64    `json:"rate_limit_1d"`
+	RateLimit7d      float64    `json:"rate_limit_7d"`
+	Usage5h          float64    `json:"usage_5h"`
+	Usage1d          float64    `json:"usage_1d"`
+	Usage7d          float64    `json:"usage_7d"`
+	Window5hStart    *time.Time `json:"window_5h_start"`
+	Window1dStart    *time.Time `json:"window_1d_start"`
+	Window7dStart    *time.Time `json:"window_7d_start"`
+	RPMLimit         int        `json:"rpm_limit"`          // 每分钟请求数上限（0 = 全局默认）
+	TPMLimit         int        `json:"tpm_limit"`          // 每分钟 token 数上限（0 = 全局默认）
+	AuditCaptureBody bool       `json:"audit_capture_body"` // 审计日志是否记录请求/响应正文
+	AllowedModels    []string   `json:"allowed_models"`     // 允许的模型（空 = 全部允许）
+	DeniedModels     []string   `json:"denied_models"`      // 禁止的模型（优先于允许列表）
+	UsageHeaders     bool       `json:"usage_headers"`      // 响应头返回计费金额与 token 用量
+	IsSandbox        bool       `json:"is_sandbox"`         // 沙盒 Key：计算费用但不扣费、不计入消费汇总
+	PriorityTier     int        `json:"priority_tier"`      // 网关排队优先级（越大越优先）
+	MaxRequestBytes  int64      `json:"max_request_bytes"`  // 请求体上限（字节，0 = 全局默认）
+	MaxResponseBytes int64      `json:"max_response_bytes"` // 响应体上限（字节，0 = 全局默认）
+	KeyHashed        bool       `json:"key_hashed"`         // 哈希存储：key 为空，完整 Key 只在创建时返回
+	Reset5hAt        *time.Time `json:"reset_5h_at,omitempty"`
+	Reset1dAt        *time.Time `json:"reset_1d_at,omitempty"`
+	Reset7dAt        *time.Time `json:"reset_7d_at,omitempty"`
+
+	// 周期额度（0 = 不限制），已用量为当前周期的值
+	UsageQuotaRequests     int64      `json:"usage_quota_requests"`
+	UsageQuotaTokens       int64      `json:"usage_quota_tokens"`
+	UsageQuotaPeriod       string     `json:"usage_quota_period"`
+	UsageQuotaRequestsUsed int64      `json:"usage_quota_requests_used"`
+	UsageQuotaTokensUsed   int64      `json:"usage_quota_tokens_used"`
+	UsageQuotaResetAt      *time.Time `json:"usage_quota_reset_at,omitempty"`
+
+	// 响应中回显请求的模型名（隐藏实际上游模型）
+	MaskResponseModel bool `json:"mask_response_model"`
 
 	User  *User  `json:"user,omitempty"`
 	Group *Group `json:"group,omitempty"`
@@ -147,6 +172,9 @@ type AdminGroup struct {
 
 	// 分组排序
 	SortOrder int `json:"sort_order"`
+
+	// 账号调度策略：load_balance / cheapest
+	SchedulingStrategy string `json:"scheduling_strategy"`
 }
 
 type Account struct {
@@ -160,6 +188,7 @@ type Account struct {
 	ProxyID            *int64         `json:"proxy_id"`
 	Concurrency        int            `json:"concurrency"`
 	LoadFactor         *int           `json:"load_factor,omitempty"`
+	Weight             int            `json:"weight"` // 生效的调度权重（未设置时为 1）
 	Priority           int            `json:"priority"`
 	RateMultiplier     float64        `json:"rate_multiplier"`
 	Status             string         `json:"status"`
@@ -169,6 +198,7 @@ type Account struct {
 	AutoPauseOnExpired bool           `json:"auto_pause_on_expired"`
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          *time.Time     `json:"deleted_at,omitempty"`
 
 	Schedulable bool `json:"schedulable"`
 
@@ -414,6 +444,11 @@ type UsageLog struct {
 	// BillingMode 计费模式：token/image
 	BillingMode *string `json:"billing_mode,omitempty"`
 
+	// AbortReason 响应被网关中止的原因（如超出 API Key 响应体大小上限）
+	AbortReason *string `json:"abort_reason,omitempty"`
+	// TraceID 请求追踪 ID（与响应头 X-Request-ID 一致）
+	TraceID *string `json:"trace_id,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 
 	User         *User             `json:"user,omitempty"`
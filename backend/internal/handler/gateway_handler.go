@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -52,6 +53,7 @@ type GatewayHandler struct {
 	maxAccountSwitchesGemini  int
 	cfg                       *config.Config
 	settingService            *service.SettingService
+	responseCache             *service.ResponseCacheService
 }
 
 // NewGatewayHandler creates a new GatewayHandler
@@ -70,6 +72,7 @@ func NewGatewayHandler(
 	userMsgQueueService *service.UserMessageQueueService,
 	cfg *config.Config,
 	settingService *service.SettingService,
+	responseCache *service.ResponseCacheService,
 ) *GatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 10
@@ -107,6 +110,7 @@ func NewGatewayHandler(
 		maxAccountSwitchesGemini:  maxAccountSwitchesGemini,
 		cfg:                       cfg,
 		settingService:            settingService,
+		responseCache:             responseCache,
 	}
 }
 
@@ -259,6 +263,27 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("gateway.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.handleStreamingAwareError(c, status, code, message, streamStarted)
+			return
+		}
+		defer releaseReservation()
+	}
+
+	// 确定性请求命中响应缓存时直接返回，不再选择账号转发
+	responseCapture, served := h.serveOrCaptureResponseCache(c, apiKey, subscription, parsedReq, channelMapping, reqModel, body)
+	if served {
+		return
+	}
+
 	// 设置请求所属分组 ID（用于渠道级功能判断，如 WebSearch 模拟）
 	parsedReq.GroupID = apiKey.GroupID
 
@@ -352,6 +377,13 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				}
 			}
 			account := selection.Account
+			if apiKey.Group.IsCheapestScheduling() {
+				cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+				setRoutingCostHeaders(c, cost, ok)
+			} else if apiKey.Group.IsLatencyScheduling() {
+				latency, ok := service.AccountAverageLatencyMs(account.ID)
+				setRoutingLatencyHeaders(c, latency, ok)
+			}
 			setOpsSelectedAccount(c, account.ID, account.Platform)
 
 			// 检查请求拦截（预热请求、SUGGESTION MODE等）
@@ -503,9 +535,10 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			if result.ReasoningEffort == nil {
 				result.ReasoningEffort = service.NormalizeClaudeOutputEffort(parsedReq.OutputEffort)
 			}
+			h.storeResponseCache(responseCapture, result, account)
 
 			// 使用量记录通过有界 worker 池提交，避免请求热路径创建无界 goroutine。
-			h.submitUsageRecordTask(func(ctx context.Context) {
+			h.submitUsageRecordTask(withAuditUsage(c, func(ctx context.Context) {
 				if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
 					Result:             result,
 					ParsedRequest:      parsedReq,
@@ -531,7 +564,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 						zap.Int64("account_id", account.ID),
 					).Error("gateway.record_usage_failed", zap.Error(err))
 				}
-			})
+			}))
 			return
 		}
 	}
@@ -594,6 +627,13 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				}
 			}
 			account := selection.Account
+			if apiKey.Group.IsCheapestScheduling() {
+				cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+				setRoutingCostHeaders(c, cost, ok)
+			} else if apiKey.Group.IsLatencyScheduling() {
+				latency, ok := service.AccountAverageLatencyMs(account.ID)
+				setRoutingLatencyHeaders(c, latency, ok)
+			}
 			setOpsSelectedAccount(c, account.ID, account.Platform)
 
 			// [DEBUG-STICKY] 打印账号选择结果
@@ -891,9 +931,10 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			if result.ReasoningEffort == nil {
 				result.ReasoningEffort = service.NormalizeClaudeOutputEffort(parsedReq.OutputEffort)
 			}
+			h.storeResponseCache(responseCapture, result, account)
 
 			// 使用量记录通过有界 worker 池提交，避免请求热路径创建无界 goroutine。
-			h.submitUsageRecordTask(func(ctx context.Context) {
+			h.submitUsageRecordTask(withAuditUsage(c, func(ctx context.Context) {
 				if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
 					Result:             result,
 					ParsedRequest:      parsedReq,
@@ -919,7 +960,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 						zap.Int64("account_id", account.ID),
 					).Error("gateway.record_usage_failed", zap.Error(err))
 				}
-			})
+			}))
 			return
 		}
 		if !retryWithFallback {
@@ -931,7 +972,8 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 // Models handles listing available models
 // GET /v1/models
 // Returns models based on account configurations (model_mapping whitelist)
-// Falls back to default models if no whitelist is configured
+// Falls back to the pricing catalog (excluding disabled models/providers) if no whitelist is configured,
+// and to default models if the catalog is empty. Every source is filtered by the key's allow/deny lists.
 func (h *GatewayHandler) Models(c *gin.Context) {
 	apiKey, _ := middleware2.GetAPIKeyFromContext(c)
 
@@ -953,6 +995,9 @@ func (h *GatewayHandler) Models(c *gin.Context) {
 		// Build model list from whitelist
 		models := make([]claude.Model, 0, len(availableModels))
 		for _, modelID := range availableModels {
+			if !apiKey.IsModelAllowed(modelID) {
+				continue
+			}
 			models = append(models, claude.Model{
 				ID:          modelID,
 				Type:        "model",
@@ -967,21 +1012,76 @@ func (h *GatewayHandler) Models(c *gin.Context) {
 		return
 	}
 
+	// Build OpenAI-compatible objects from the pricing catalog
+	if catalog := h.gatewayService.ListCatalogModels(apiKey, catalogProviderForPlatform(platform)); len(catalog) > 0 {
+		models := make([]openai.Model, 0, len(catalog))
+		for _, m := range catalog {
+			models = append(models, catalogModelToOpenAI(m))
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"object": "list",
+			"data":   models,
+		})
+		return
+	}
+
 	// Fallback to default models
 	if platform == "openai" {
+		models := make([]openai.Model, 0, len(openai.DefaultModels))
+		for _, m := range openai.DefaultModels {
+			if apiKey.IsModelAllowed(m.ID) {
+				models = append(models, m)
+			}
+		}
 		c.JSON(http.StatusOK, gin.H{
 			"object": "list",
-			"data":   openai.DefaultModels,
+			"data":   models,
 		})
 		return
 	}
 
+	models := make([]claude.Model, 0, len(claude.DefaultModels))
+	for _, m := range claude.DefaultModels {
+		if apiKey.IsModelAllowed(m.ID) {
+			models = append(models, m)
+		}
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"object": "list",
-		"data":   claude.DefaultModels,
+		"data":   models,
 	})
 }
 
+// catalogProviderForPlatform 分组平台与价格目录厂商同名时按厂商过滤，其余平台（如 antigravity）返回全部模型
+func catalogProviderForPlatform(platform string) string {
+	switch platform {
+	case service.PlatformAnthropic, service.PlatformOpenAI, service.PlatformGemini:
+		return platform
+	default:
+		return ""
+	}
+}
+
+// catalogModelToOpenAI 转换为 OpenAI 兼容的模型对象（同时带上 Anthropic 客户端使用的 type/display_name）
+func catalogModelToOpenAI(m service.CatalogModel) openai.Model {
+	var created int64
+	if !m.Created.IsZero() {
+		created = m.Created.Unix()
+	}
+	ownedBy := m.Provider
+	if ownedBy == "" {
+		ownedBy = "system"
+	}
+	return openai.Model{
+		ID:          m.ID,
+		Object:      "model",
+		Created:     created,
+		OwnedBy:     ownedBy,
+		Type:        "model",
+		DisplayName: m.ID,
+	}
+}
+
 // AntigravityModels 返回 Antigravity 支持的全部模型
 // GET /antigravity/models
 func (h *GatewayHandler) AntigravityModels(c *gin.Context) {
@@ -1445,13 +1545,7 @@ func (h *GatewayHandler) checkClaudeCodeVersion(c *gin.Context) bool {
 
 // errorResponse 返回Claude API格式的错误响应
 func (h *GatewayHandler) errorResponse(c *gin.Context, status int, errType, message string) {
-	c.JSON(status, gin.H{
-		"type": "error",
-		"error": gin.H{
-			"type":    errType,
-			"message": message,
-		},
-	})
+	c.JSON(status, middleware2.AnthropicErrorBody(c, errType, message))
 }
 
 // CountTokens handles token counting endpoint
@@ -1788,6 +1882,15 @@ func billingErrorDetails(err error) (status int, code, message string, retryAfte
 		msg := pkgerrors.Message(err)
 		return http.StatusTooManyRequests, "rate_limit_exceeded", msg, 0
 	}
+	// API Key RPM/TPM 令牌桶超限：Retry-After 取令牌补足所需时间（向上取整，至少 1 秒）。
+	if errors.Is(err, service.ErrAPIKeyRPMExceeded) || errors.Is(err, service.ErrAPIKeyTPMExceeded) {
+		msg := pkgerrors.Message(err)
+		retrySeconds := 1
+		if wait, ok := service.RetryAfterFromError(err); ok {
+			retrySeconds = max(1, int(math.Ceil(wait.Seconds())))
+		}
+		return http.StatusTooManyRequests, "rate_limit_exceeded", msg, retrySeconds
+	}
 	// 用户/分组 RPM 超限统一映射为 HTTP 429；保留与其它 rate_limit 一致的错误码便于客户端分类。
 	// 返回 Retry-After 秒数（当前分钟剩余秒数），让 SDK 自动退避。
 	if errors.Is(err, service.ErrGroupRPMExceeded) || errors.Is(err, service.ErrUserRPMExceeded) {
@@ -1795,6 +1898,22 @@ func billingErrorDetails(err error) (status int, code, message string, retryAfte
 		retrySeconds := 60 - int(time.Now().Unix()%60)
 		return http.StatusTooManyRequests, "rate_limit_exceeded", msg, retrySeconds
 	}
+	// API Key 周期额度超限映射为 HTTP 429，消息中透出请求数/token 数的已用与剩余额度，Retry-After 为距周期重置的秒数。
+	if quotaErr, ok := service.APIKeyUsageQuotaExceededFromError(err); ok {
+		msg := fmt.Sprintf("API key %s usage quota exceeded: requests %s, tokens %s, resets at %s",
+			quotaErr.Period,
+			formatUsageQuota(quotaErr.RequestsUsed, quotaErr.RequestsLimit, quotaErr.RemainingRequests()),
+			formatUsageQuota(quotaErr.TokensUsed, quotaErr.TokensLimit, quotaErr.RemainingTokens()),
+			quotaErr.ResetAt.UTC().Format(time.RFC3339))
+		retrySeconds := max(1, int(math.Ceil(time.Until(quotaErr.ResetAt).Seconds())))
+		return http.StatusTooManyRequests, "quota_exceeded", msg, retrySeconds
+	}
+	// 用户月度预算超限映射为 HTTP 402，消息中透出本周期已用/剩余额度与重置时间。
+	if budgetErr, ok := service.UserBudgetExceededFromError(err); ok {
+		msg := fmt.Sprintf("Monthly budget exceeded: spent $%.4f of $%.4f, remaining $%.4f, resets at %s",
+			budgetErr.Spent, budgetErr.Limit, budgetErr.Remaining(), budgetErr.ResetAt.UTC().Format(time.RFC3339))
+		return http.StatusPaymentRequired, "budget_exceeded", msg, 0
+	}
 	msg := pkgerrors.Message(err)
 	if msg == "" {
 		logger.L().With(
@@ -1806,6 +1925,13 @@ func billingErrorDetails(err error) (status int, code, message string, retryAfte
 	return http.StatusForbidden, "billing_error", msg, 0
 }
 
+func formatUsageQuota(used, limit, remaining int64) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d used (unlimited)", used)
+	}
+	return fmt.Sprintf("%d/%d used, %d remaining", used, limit, remaining)
+}
+
 func (h *GatewayHandler) metadataBridgeEnabled() bool {
 	if h == nil || h.cfg == nil {
 		return true
@@ -1852,6 +1978,33 @@ func (h *GatewayHandler) submitUsageRecordTask(task service.UsageRecordTask) {
 	task(ctx)
 }
 
+// withAuditUsage 让异步用量记录任务沿用请求的审计记录与用量响应头汇总补齐 token/费用，并按实际费用结算流式预算预留；
+// 响应因超出大小上限被中止时一并带上中止原因。均未开启时原样返回 task。
+func withAuditUsage(c *gin.Context, task service.UsageRecordTask) service.UsageRecordTask {
+	if c == nil || c.Request == nil || task == nil {
+		return task
+	}
+	entry := service.AuditEntryFromContext(c.Request.Context())
+	report := service.UsageHeaderReportFromContext(c.Request.Context())
+	reservation := service.BudgetReservationFromContext(c.Request.Context())
+	sizeGuard := service.ResponseSizeGuardFromContext(c.Request.Context())
+	if entry == nil && report == nil && reservation == nil && sizeGuard == nil {
+		return task
+	}
+	entry.HoldUsage()
+	report.Hold()
+	reservation.Hold()
+	return func(ctx context.Context) {
+		defer entry.ReleaseUsage()
+		defer report.Release()
+		defer reservation.Release()
+		ctx = service.WithAuditEntry(ctx, entry)
+		ctx = service.WithBudgetReservation(ctx, reservation)
+		ctx = service.WithResponseSizeGuard(ctx, sizeGuard)
+		task(service.WithUsageHeaderReport(ctx, report))
+	}
+}
+
 // getUserMsgQueueMode 获取当前请求的 UMQ 模式
 // 返回 "serialize" | "throttle" | ""
 func (h *GatewayHandler) getUserMsgQueueMode(account *service.Account, parsed *service.ParsedRequest) string {
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/stretchr/testify/require"
@@ -52,3 +53,39 @@ func TestBillingErrorDetails_UnknownErrorFallsBackTo403(t *testing.T) {
 	require.Equal(t, "billing_error", code)
 	require.NotEmpty(t, msg)
 }
+
+func TestBillingErrorDetails_UserBudgetExceededMapsTo402(t *testing.T) {
+	err := &service.UserBudgetExceededError{
+		Limit:     10,
+		Spent:     9.5,
+		Projected: 10.2,
+		ResetAt:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	status, code, msg, retryAfter := billingErrorDetails(err)
+	require.Equal(t, http.StatusPaymentRequired, status)
+	require.Equal(t, "budget_exceeded", code)
+	require.Contains(t, msg, "remaining $0.5000")
+	require.Contains(t, msg, "2026-11-01T00:00:00Z")
+	require.Equal(t, 0, retryAfter)
+}
+
+func TestBillingErrorDetails_APIKeyUsageQuotaExceededMapsTo429(t *testing.T) {
+	resetAt := time.Now().Add(90 * time.Minute).UTC().Truncate(time.Second)
+	err := &service.APIKeyUsageQuotaExceededError{
+		Period:        service.UsageQuotaPeriodDaily,
+		RequestsLimit: 100,
+		RequestsUsed:  40,
+		TokensLimit:   1_000_000,
+		TokensUsed:    1_000_250,
+		ResetAt:       resetAt,
+	}
+	require.ErrorIs(t, err, service.ErrAPIKeyTokenQuotaExceeded)
+
+	status, code, msg, retryAfter := billingErrorDetails(err)
+	require.Equal(t, http.StatusTooManyRequests, status)
+	require.Equal(t, "quota_exceeded", code)
+	require.Contains(t, msg, "requests 40/100 used, 60 remaining")
+	require.Contains(t, msg, "tokens 1000250/1000000 used, 0 remaining")
+	require.Contains(t, msg, resetAt.Format(time.RFC3339))
+	require.InDelta(t, 90*60, retryAfter, 2)
+}
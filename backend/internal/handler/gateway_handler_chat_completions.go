@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/apicompat"
 	pkghttputil "github.com/Wei-Shaw/sub2api/internal/pkg/httputil"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
@@ -16,10 +18,11 @@ import (
 	"go.uber.org/zap"
 )
 
-// ChatCompletions handles OpenAI Chat Completions API endpoint for Anthropic platform groups.
+// ChatCompletions handles OpenAI Chat Completions API endpoint for Anthropic and Gemini platform groups.
 // POST /v1/chat/completions
 // This converts Chat Completions requests to Anthropic format (via Responses format chain),
-// forwards to Anthropic upstream, and converts responses back to Chat Completions format.
+// or to Gemini generateContent format for Gemini accounts, forwards upstream, and converts
+// responses back to Chat Completions format.
 func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 	streamStarted := false
 
@@ -78,6 +81,12 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 	reqStream := gjson.GetBytes(body, "stream").Bool()
 	reqLog = reqLog.With(zap.String("model", reqModel), zap.Bool("stream", reqStream))
 
+	// stop 需转换为 Anthropic stop_sequences，格式错误时在调度前直接拒绝
+	if _, err := apicompat.ParseChatStopSequences(json.RawMessage(gjson.GetBytes(body, "stop").Raw)); err != nil {
+		h.chatCompletionsErrorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
 	setOpsRequestContext(c, reqModel, reqStream, body)
 	setOpsEndpointContext(c, "", int16(service.RequestTypeFromLegacy(reqStream, false)))
 
@@ -150,6 +159,21 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("gateway.cc.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.chatCompletionsErrorResponse(c, status, code, message)
+			return
+		}
+		defer releaseReservation()
+	}
+
 	// Parse request for session hash
 	parsedReq, _ := service.ParseGatewayRequest(body, "chat_completions")
 	if parsedReq == nil {
@@ -188,6 +212,13 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 			}
 		}
 		account := selection.Account
+		if apiKey.Group.IsCheapestScheduling() {
+			cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+			setRoutingCostHeaders(c, cost, ok)
+		} else if apiKey.Group.IsLatencyScheduling() {
+			latency, ok := service.AccountAverageLatencyMs(account.ID)
+			setRoutingLatencyHeaders(c, latency, ok)
+		}
 		setOpsSelectedAccount(c, account.ID, account.Platform)
 
 		// 4. Acquire account concurrency slot
@@ -219,7 +250,17 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 		if channelMapping.Mapped {
 			forwardBody = h.gatewayService.ReplaceModelInBody(body, channelMapping.MappedModel)
 		}
-		result, err := h.gatewayService.ForwardAsChatCompletions(c.Request.Context(), c, account, forwardBody, parsedReq)
+		requestCtx := c.Request.Context()
+		if fs.SwitchCount > 0 {
+			requestCtx = service.WithAccountSwitchCount(requestCtx, fs.SwitchCount, h.metadataBridgeEnabled())
+		}
+		var result *service.ForwardResult
+		if account.Platform == service.PlatformGemini {
+			// Gemini 账号：直接转换为 generateContent 请求，不经 Anthropic 格式中转
+			result, err = h.geminiCompatService.ForwardAsChatCompletions(requestCtx, c, account, forwardBody)
+		} else {
+			result, err = h.gatewayService.ForwardAsChatCompletions(requestCtx, c, account, forwardBody, parsedReq)
+		}
 
 		if accountReleaseFunc != nil {
 			accountReleaseFunc()
@@ -258,7 +299,7 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 		inboundEndpoint := GetInboundEndpoint(c)
 		upstreamEndpoint := GetUpstreamEndpoint(c, account.Platform)
 
-		h.submitUsageRecordTask(func(ctx context.Context) {
+		h.submitUsageRecordTask(withAuditUsage(c, func(ctx context.Context) {
 			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
 				Result:             result,
 				APIKey:             apiKey,
@@ -278,7 +319,7 @@ func (h *GatewayHandler) ChatCompletions(c *gin.Context) {
 					zap.Error(err),
 				)
 			}
-		})
+		}))
 		return
 	}
 }
@@ -155,6 +155,21 @@ func (h *GatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("gateway.responses.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.responsesErrorResponse(c, status, code, message)
+			return
+		}
+		defer releaseReservation()
+	}
+
 	// Parse request for session hash
 	parsedReq, _ := service.ParseGatewayRequest(body, "responses")
 	if parsedReq == nil {
@@ -193,6 +208,13 @@ func (h *GatewayHandler) Responses(c *gin.Context) {
 			}
 		}
 		account := selection.Account
+		if apiKey.Group.IsCheapestScheduling() {
+			cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+			setRoutingCostHeaders(c, cost, ok)
+		} else if apiKey.Group.IsLatencyScheduling() {
+			latency, ok := service.AccountAverageLatencyMs(account.ID)
+			setRoutingLatencyHeaders(c, latency, ok)
+		}
 		setOpsSelectedAccount(c, account.ID, account.Platform)
 
 		// 4. Acquire account concurrency slot
@@ -224,7 +246,11 @@ func (h *GatewayHandler) Responses(c *gin.Context) {
 		if channelMapping.Mapped {
 			forwardBody = h.gatewayService.ReplaceModelInBody(body, channelMapping.MappedModel)
 		}
-		result, err := h.gatewayService.ForwardAsResponses(c.Request.Context(), c, account, forwardBody, parsedReq)
+		requestCtx := c.Request.Context()
+		if fs.SwitchCount > 0 {
+			requestCtx = service.WithAccountSwitchCount(requestCtx, fs.SwitchCount, h.metadataBridgeEnabled())
+		}
+		result, err := h.gatewayService.ForwardAsResponses(requestCtx, c, account, forwardBody, parsedReq)
 
 		if accountReleaseFunc != nil {
 			accountReleaseFunc()
@@ -264,7 +290,7 @@ func (h *GatewayHandler) Responses(c *gin.Context) {
 		inboundEndpoint := GetInboundEndpoint(c)
 		upstreamEndpoint := GetUpstreamEndpoint(c, account.Platform)
 
-		h.submitUsageRecordTask(func(ctx context.Context) {
+		h.submitUsageRecordTask(withAuditUsage(c, func(ctx context.Context) {
 			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
 				Result:             result,
 				APIKey:             apiKey,
@@ -284,7 +310,7 @@ func (h *GatewayHandler) Responses(c *gin.Context) {
 					zap.Error(err),
 				)
 			}
-		})
+		}))
 		return
 	}
 }
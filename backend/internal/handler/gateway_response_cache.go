@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// responseCacheCapture 未命中响应缓存的请求：记录缓存键并捕获写给客户端的响应
+type responseCacheCapture struct {
+	key    string
+	writer *responseCaptureWriter
+}
+
+// responseCaptureWriter 透传响应的同时复制正文；一旦 Flush（流式）或超出长度上限即放弃缓存
+type responseCaptureWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	limit    int
+	streamed bool
+	overflow bool
+}
+
+func (w *responseCaptureWriter) capture(n int, b []byte) {
+	if w.overflow || w.streamed {
+		return
+	}
+	if w.limit > 0 && w.buf.Len()+n > w.limit {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	w.buf.Write(b[:n])
+}
+
+func (w *responseCaptureWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.capture(n, b)
+	return n, err
+}
+
+func (w *responseCaptureWriter) WriteString(s string) (int, error) {
+	n, err := w.ResponseWriter.WriteString(s)
+	w.capture(n, []byte(s))
+	return n, err
+}
+
+func (w *responseCaptureWriter) Flush() {
+	w.streamed = true
+	w.buf.Reset()
+	w.ResponseWriter.Flush()
+}
+
+// lookupResponseCache 计算请求的缓存键（用户 + 分组 + 入站端点 + 规范化请求体）并查找缓存条目。
+// 请求不可缓存（未启用、流式、temperature 非 0 等）时 key 为空。
+func lookupResponseCache(c *gin.Context, cache *service.ResponseCacheService, apiKey *service.APIKey, body []byte) (string, *service.ResponseCacheEntry) {
+	if !cache.Enabled() || apiKey == nil || apiKey.User == nil {
+		return "", nil
+	}
+	key, ok := service.ResponseCacheKey(apiKey.User.ID, apiKey.GroupID, GetInboundEndpoint(c), body)
+	if !ok {
+		return "", nil
+	}
+	return key, cache.Get(key)
+}
+
+// writeResponseCacheHit 将缓存响应原样写回客户端
+func writeResponseCacheHit(c *gin.Context, entry *service.ResponseCacheEntry) {
+	if entry.ContentType != "" {
+		c.Header("Content-Type", entry.ContentType)
+	}
+	c.Header(service.ResponseCacheHeader, "HIT")
+	c.Status(entry.StatusCode)
+	_, _ = c.Writer.Write(entry.Body)
+}
+
+// startResponseCapture 未命中缓存时包装 c.Writer 捕获响应；key 为空（不可缓存）时返回 nil
+func startResponseCapture(c *gin.Context, cache *service.ResponseCacheService, key string) *responseCacheCapture {
+	if key == "" {
+		return nil
+	}
+	writer := &responseCaptureWriter{ResponseWriter: c.Writer, limit: cache.MaxBodyBytes()}
+	c.Writer = writer
+	c.Header(service.ResponseCacheHeader, "MISS")
+	return &responseCacheCapture{key: key, writer: writer}
+}
+
+// cacheableEntry 捕获到完整的成功非流式响应时返回待缓存的条目（不含转发结果），否则返回 nil
+func (capture *responseCacheCapture) cacheableEntry(account *service.Account) *service.ResponseCacheEntry {
+	if capture == nil || account == nil {
+		return nil
+	}
+	w := capture.writer
+	if w.streamed || w.overflow || w.Status() != http.StatusOK {
+		return nil
+	}
+	accountCopy := *account
+	return &service.ResponseCacheEntry{
+		StatusCode:  w.Status(),
+		ContentType: w.Header().Get("Content-Type"),
+		Body:        bytes.Clone(w.buf.Bytes()),
+		Account:     &accountCopy,
+	}
+}
+
+// serveOrCaptureResponseCache 查找确定性请求的响应缓存。
+// 命中时直接返回缓存响应并按 hit_billing_rate 记录用量，返回 served=true；
+// 未命中时包装 c.Writer 捕获响应，转发成功后由 storeResponseCache 写入缓存。
+func (h *GatewayHandler) serveOrCaptureResponseCache(
+	c *gin.Context,
+	apiKey *service.APIKey,
+	subscription *service.UserSubscription,
+	parsedReq *service.ParsedRequest,
+	channelMapping service.ChannelMappingResult,
+	reqModel string,
+	body []byte,
+) (*responseCacheCapture, bool) {
+	key, entry := lookupResponseCache(c, h.responseCache, apiKey, body)
+	if entry != nil && entry.Account != nil {
+		writeResponseCacheHit(c, entry)
+
+		// 用量按原始请求的 token 计算；request_id 使用本次请求的标识，避免与原始请求去重
+		result := entry.Result
+		result.RequestID = ""
+		result.Duration = 0
+		result.FirstTokenMs = nil
+		account := entry.Account
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+		requestPayloadHash := service.HashUsageRequestPayload(body)
+		inboundEndpoint := GetInboundEndpoint(c)
+		h.submitUsageRecordTask(withAuditUsage(c, func(ctx context.Context) {
+			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+				Result:             &result,
+				ParsedRequest:      parsedReq,
+				APIKey:             apiKey,
+				User:               apiKey.User,
+				Account:            account,
+				Subscription:       subscription,
+				InboundEndpoint:    inboundEndpoint,
+				UserAgent:          userAgent,
+				IPAddress:          clientIP,
+				RequestPayloadHash: requestPayloadHash,
+				ResponseCacheHit:   true,
+				APIKeyService:      h.apiKeyService,
+				ChannelUsageFields: channelMapping.ToUsageFields(reqModel, result.UpstreamModel),
+			}); err != nil {
+				logger.L().With(
+					zap.String("component", "handler.gateway.response_cache"),
+					zap.Int64("api_key_id", apiKey.ID),
+					zap.Int64("account_id", account.ID),
+				).Error("gateway.record_usage_failed", zap.Error(err))
+			}
+		}))
+		return nil, true
+	}
+	return startResponseCapture(c, h.responseCache, key), false
+}
+
+// storeResponseCache 转发成功后缓存捕获到的响应；需在提交用量记录前调用（用量记录会异步修改 result）
+func (h *GatewayHandler) storeResponseCache(capture *responseCacheCapture, result *service.ForwardResult, account *service.Account) {
+	entry := capture.cacheableEntry(account)
+	if entry == nil || result == nil {
+		return
+	}
+	entry.Result = *result
+	h.responseCache.Put(capture.key, entry)
+}
+
+// serveOrCaptureResponseCache 查找 OpenAI Chat Completions / Responses 请求的响应缓存，行为与 Anthropic Messages 一致：
+// 命中时直接返回缓存响应并按 hit_billing_rate 记录用量，返回 served=true；
+// 未命中时包装 c.Writer 捕获响应，转发成功后由 storeResponseCache 写入缓存。
+func (h *OpenAIGatewayHandler) serveOrCaptureResponseCache(
+	c *gin.Context,
+	apiKey *service.APIKey,
+	subscription *service.UserSubscription,
+	channelMapping service.ChannelMappingResult,
+	reqModel string,
+	body []byte,
+) (*responseCacheCapture, bool) {
+	key, entry := lookupResponseCache(c, h.responseCache, apiKey, body)
+	if entry != nil && entry.Account != nil && entry.OpenAIResult != nil {
+		writeResponseCacheHit(c, entry)
+
+		// 用量按原始请求的 token 计算；request_id 使用本次请求的标识，避免与原始请求去重
+		result := *entry.OpenAIResult
+		result.RequestID = ""
+		result.Duration = 0
+		result.FirstTokenMs = nil
+		account := entry.Account
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+		requestPayloadHash := service.HashUsageRequestPayload(body)
+		inboundEndpoint := GetInboundEndpoint(c)
+		h.submitOpenAIUsageRecordTask(&result, withAuditUsage(c, func(ctx context.Context) {
+			if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+				Result:             &result,
+				APIKey:             apiKey,
+				User:               apiKey.User,
+				Account:            account,
+				Subscription:       subscription,
+				InboundEndpoint:    inboundEndpoint,
+				UserAgent:          userAgent,
+				IPAddress:          clientIP,
+				RequestPayloadHash: requestPayloadHash,
+				ResponseCacheHit:   true,
+				APIKeyService:      h.apiKeyService,
+				ChannelUsageFields: channelMapping.ToUsageFields(reqModel, result.UpstreamModel),
+			}); err != nil {
+				logger.L().With(
+					zap.String("component", "handler.openai_gateway.response_cache"),
+					zap.Int64("api_key_id", apiKey.ID),
+					zap.Int64("account_id", account.ID),
+				).Error("openai.record_usage_failed", zap.Error(err))
+			}
+		}))
+		return nil, true
+	}
+	return startResponseCapture(c, h.responseCache, key), false
+}
+
+// storeResponseCache 转发成功后缓存捕获到的 OpenAI 响应；需在提交用量记录前调用（用量记录会异步修改 result）
+func (h *OpenAIGatewayHandler) storeResponseCache(capture *responseCacheCapture, result *service.OpenAIForwardResult, account *service.Account) {
+	entry := capture.cacheableEntry(account)
+	if entry == nil || result == nil {
+		return
+	}
+	resultCopy := *result
+	resultCopy.ResponseHeaders = nil
+	entry.OpenAIResult = &resultCopy
+	h.responseCache.Put(capture.key, entry)
+}
@@ -37,6 +37,13 @@ type AdminHandlers struct {
 	ContentModeration      *admin.ContentModerationHandler
 	Payment                *admin.PaymentHandler
 	Affiliate              *admin.AffiliateHandler
+	Audit                  *admin.AuditHandler
+	Maintenance            *admin.MaintenanceHandler
+	SlowRequest            *admin.SlowRequestHandler
+	PriorityQueue          *admin.PriorityQueueHandler
+	Warmup                 *admin.WarmupHandler
+	ModelAccounts          *admin.ModelAccountsHandler
+	GatewayLoad            *admin.GatewayLoadHandler
 }
 
 // Handlers contains all HTTP handlers
@@ -57,6 +64,7 @@ type Handlers struct {
 	Payment          *PaymentHandler
 	PaymentWebhook   *PaymentWebhookHandler
 	AvailableChannel *AvailableChannelHandler
+	Health           *HealthHandler
 }
 
 // BuildInfo contains build-time information
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler 提供 Kubernetes 存活 / 就绪探针（无需认证，响应不使用统一的 code/data 包装）
+type HealthHandler struct {
+	readinessService *service.ReadinessService
+}
+
+// NewHealthHandler 创建探针处理器
+func NewHealthHandler(readinessService *service.ReadinessService) *HealthHandler {
+	return &HealthHandler{readinessService: readinessService}
+}
+
+// Liveness 进程存活即返回 200，不检查任何依赖
+// GET /healthz
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness 启动预热完成、数据库可达、价格数据已加载且至少有一个可调度账号时返回 200，否则返回 503
+// GET /readyz
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	if h.readinessService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not_ready"})
+		return
+	}
+	report := h.readinessService.Check(c.Request.Context())
+	status, code := "ready", http.StatusOK
+	if !report.Ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{"status": status, "checks": report.Checks})
+}
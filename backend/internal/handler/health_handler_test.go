@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_LivenessAndReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// 未启动预热、无数据库与账号仓储：存活但不就绪
+	h := NewHealthHandler(service.NewReadinessService(nil, nil, nil, nil))
+	r := gin.New()
+	r.GET("/healthz", h.Liveness)
+	r.GET("/readyz", h.Readiness)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var resp struct {
+		Status string                   `json:"status"`
+		Checks []service.ReadinessCheck `json:"checks"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "not_ready", resp.Status)
+	require.Len(t, resp.Checks, 4)
+	for _, check := range resp.Checks {
+		require.False(t, check.Ready, check.Name)
+	}
+}
@@ -116,6 +116,27 @@ func (h *OpenAIGatewayHandler) ChatCompletions(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("openai_chat_completions.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.handleStreamingAwareError(c, status, code, message, streamStarted)
+			return
+		}
+		defer releaseReservation()
+	}
+
+	// 确定性请求命中响应缓存时直接返回，不再选择账号转发
+	responseCapture, served := h.serveOrCaptureResponseCache(c, apiKey, subscription, channelMapping, reqModel, body)
+	if served {
+		return
+	}
+
 	sessionHash := h.gatewayService.GenerateSessionHash(c, body)
 	promptCacheKey := h.gatewayService.ExtractSessionID(c, body)
 
@@ -159,6 +180,13 @@ func (h *OpenAIGatewayHandler) ChatCompletions(c *gin.Context) {
 			return
 		}
 		account := selection.Account
+		if apiKey.Group.IsCheapestScheduling() {
+			cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+			setRoutingCostHeaders(c, cost, ok)
+		} else if apiKey.Group.IsLatencyScheduling() {
+			latency, ok := service.AccountAverageLatencyMs(account.ID)
+			setRoutingLatencyHeaders(c, latency, ok)
+		}
 		sessionHash = ensureOpenAIPoolModeSessionHash(sessionHash, account)
 		reqLog.Debug("openai_chat_completions.account_selected", zap.Int64("account_id", account.ID), zap.String("account_name", account.Name))
 		_ = scheduleDecision
@@ -176,7 +204,7 @@ func (h *OpenAIGatewayHandler) ChatCompletions(c *gin.Context) {
 		if channelMapping.Mapped {
 			forwardBody = h.gatewayService.ReplaceModelInBody(body, channelMapping.MappedModel)
 		}
-		result, err := h.gatewayService.ForwardAsChatCompletions(c.Request.Context(), c, account, forwardBody, promptCacheKey, "")
+		result, err := h.gatewayService.ForwardAsChatCompletions(h.forwardRequestContext(c, switchCount), c, account, forwardBody, promptCacheKey, "")
 
 		forwardDurationMs := time.Since(forwardStart).Milliseconds()
 		if accountReleaseFunc != nil {
@@ -257,8 +285,9 @@ func (h *OpenAIGatewayHandler) ChatCompletions(c *gin.Context) {
 		clientIP := ip.GetClientIP(c)
 		inboundEndpoint := GetInboundEndpoint(c)
 		upstreamEndpoint := resolveRawCCUpstreamEndpoint(c, account)
+		h.storeResponseCache(responseCapture, result, account)
 
-		h.submitOpenAIUsageRecordTask(result, func(ctx context.Context) {
+		h.submitOpenAIUsageRecordTask(result, withAuditUsage(c, func(ctx context.Context) {
 			if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
 				Result:             result,
 				APIKey:             apiKey,
@@ -281,7 +310,7 @@ func (h *OpenAIGatewayHandler) ChatCompletions(c *gin.Context) {
 					zap.Int64("account_id", account.ID),
 				).Error("openai_chat_completions.record_usage_failed", zap.Error(err))
 			}
-		})
+		}))
 		reqLog.Debug("openai_chat_completions.request_completed",
 			zap.Int64("account_id", account.ID),
 			zap.Int("switch_count", switchCount),
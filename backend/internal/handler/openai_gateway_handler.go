@@ -9,6 +9,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
@@ -37,6 +38,7 @@ type OpenAIGatewayHandler struct {
 	imageLimiter             *imageConcurrencyLimiter
 	maxAccountSwitches       int
 	cfg                      *config.Config
+	responseCache            *service.ResponseCacheService
 }
 
 func resolveOpenAIMessagesDispatchMappedModel(apiKey *service.APIKey, requestedModel string) string {
@@ -56,6 +58,7 @@ func NewOpenAIGatewayHandler(
 	errorPassthroughService *service.ErrorPassthroughService,
 	contentModerationService *service.ContentModerationService,
 	cfg *config.Config,
+	responseCache *service.ResponseCacheService,
 ) *OpenAIGatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 3
@@ -76,6 +79,7 @@ func NewOpenAIGatewayHandler(
 		imageLimiter:             &imageConcurrencyLimiter{},
 		maxAccountSwitches:       maxAccountSwitches,
 		cfg:                      cfg,
+		responseCache:            responseCache,
 	}
 }
 
@@ -253,6 +257,31 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("openai.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.handleStreamingAwareError(c, status, code, message, streamStarted)
+			return
+		}
+		defer releaseReservation()
+	}
+
+	// 确定性请求命中响应缓存时直接返回，不再选择账号转发（图片生成请求不缓存）
+	var responseCapture *responseCacheCapture
+	if !imageIntent {
+		var served bool
+		responseCapture, served = h.serveOrCaptureResponseCache(c, apiKey, subscription, channelMapping, reqModel, body)
+		if served {
+			return
+		}
+	}
+
 	// Generate session hash (header first; fallback to prompt_cache_key)
 	sessionHash := h.gatewayService.GenerateSessionHash(c, sessionHashBody)
 	requireCompact := isOpenAIRemoteCompactPath(c)
@@ -313,6 +342,13 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 			zap.Float64("load_skew", scheduleDecision.LoadSkew),
 		)
 		account := selection.Account
+		if apiKey.Group.IsCheapestScheduling() {
+			cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+			setRoutingCostHeaders(c, cost, ok)
+		} else if apiKey.Group.IsLatencyScheduling() {
+			latency, ok := service.AccountAverageLatencyMs(account.ID)
+			setRoutingLatencyHeaders(c, latency, ok)
+		}
 		sessionHash = ensureOpenAIPoolModeSessionHash(sessionHash, account)
 		reqLog.Debug("openai.account_selected", zap.Int64("account_id", account.ID), zap.String("account_name", account.Name))
 		setOpsSelectedAccount(c, account.ID, account.Platform)
@@ -330,7 +366,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		if channelMapping.Mapped {
 			forwardBody = h.gatewayService.ReplaceModelInBody(body, channelMapping.MappedModel)
 		}
-		result, err := h.gatewayService.Forward(c.Request.Context(), c, account, forwardBody)
+		result, err := h.gatewayService.Forward(h.forwardRequestContext(c, switchCount), c, account, forwardBody)
 		forwardDurationMs := time.Since(forwardStart).Milliseconds()
 		if accountReleaseFunc != nil {
 			accountReleaseFunc()
@@ -420,9 +456,10 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		requestPayloadHash := service.HashUsageRequestPayload(body)
 		inboundEndpoint := GetInboundEndpoint(c)
 		upstreamEndpoint := GetUpstreamEndpoint(c, account.Platform)
+		h.storeResponseCache(responseCapture, result, account)
 
 		// 使用量记录通过有界 worker 池提交，避免请求热路径创建无界 goroutine。
-		h.submitOpenAIUsageRecordTask(result, func(ctx context.Context) {
+		h.submitOpenAIUsageRecordTask(result, withAuditUsage(c, func(ctx context.Context) {
 			if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
 				Result:             result,
 				APIKey:             apiKey,
@@ -446,7 +483,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 					zap.Int64("account_id", account.ID),
 				).Error("openai.record_usage_failed", zap.Error(err))
 			}
-		})
+		}))
 		reqLog.Debug("openai.request_completed",
 			zap.Int64("account_id", account.ID),
 			zap.Int("switch_count", switchCount),
@@ -519,9 +556,9 @@ func (h *OpenAIGatewayHandler) logOpenAIRemoteCompactOutcome(c *gin.Context, sta
 			}
 		}
 		if c.Writer != nil {
-			if upstreamRequestID := strings.TrimSpace(c.Writer.Header().Get("x-request-id")); upstreamRequestID != "" {
+			if upstreamRequestID := strings.TrimSpace(c.Writer.Header().Get("X-Upstream-Request-ID")); upstreamRequestID != "" {
 				fields = append(fields, zap.String("upstream_request_id", upstreamRequestID))
-			} else if upstreamRequestID := strings.TrimSpace(c.Writer.Header().Get("X-Request-Id")); upstreamRequestID != "" {
+			} else if upstreamRequestID := strings.TrimSpace(c.Writer.Header().Get("X-Request-Id")); upstreamRequestID != "" && upstreamRequestID != middleware2.GetRequestIDFromContext(c) {
 				fields = append(fields, zap.String("upstream_request_id", upstreamRequestID))
 			}
 		}
@@ -643,6 +680,21 @@ func (h *OpenAIGatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 流式请求按预估最大费用预留月度预算，超出时在开始流式前拒绝
+	if reqStream {
+		releaseReservation, err := reserveStreamBudget(c, h.billingCacheService, apiKey, reqModel, body)
+		if err != nil {
+			reqLog.Info("openai_messages.stream_budget_reservation_rejected", zap.Error(err))
+			status, code, message, retryAfter := billingErrorDetails(err)
+			if retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+			}
+			h.anthropicStreamingAwareError(c, status, code, message, streamStarted)
+			return
+		}
+		defer releaseReservation()
+	}
+
 	sessionHash := h.gatewayService.GenerateSessionHash(c, body)
 	promptCacheKey := h.gatewayService.ExtractSessionID(c, body)
 	sessionHash, promptCacheKey = resolveOpenAIMessagesMetadataSession(sessionHash, promptCacheKey, reqModel, body)
@@ -694,6 +746,13 @@ func (h *OpenAIGatewayHandler) Messages(c *gin.Context) {
 			return
 		}
 		account := selection.Account
+		if apiKey.Group.IsCheapestScheduling() {
+			cost, ok := h.gatewayService.AccountRoutingCost(account, reqModel)
+			setRoutingCostHeaders(c, cost, ok)
+		} else if apiKey.Group.IsLatencyScheduling() {
+			latency, ok := service.AccountAverageLatencyMs(account.ID)
+			setRoutingLatencyHeaders(c, latency, ok)
+		}
 		sessionHash = ensureOpenAIPoolModeSessionHash(sessionHash, account)
 		reqLog.Debug("openai_messages.account_selected", zap.Int64("account_id", account.ID), zap.String("account_name", account.Name))
 		_ = scheduleDecision
@@ -713,7 +772,7 @@ func (h *OpenAIGatewayHandler) Messages(c *gin.Context) {
 		if channelMappingMsg.Mapped {
 			forwardBody = h.gatewayService.ReplaceModelInBody(body, channelMappingMsg.MappedModel)
 		}
-		result, err := h.gatewayService.ForwardAsAnthropic(c.Request.Context(), c, account, forwardBody, promptCacheKey, defaultMappedModel)
+		result, err := h.gatewayService.ForwardAsAnthropic(h.forwardRequestContext(c, switchCount), c, account, forwardBody, promptCacheKey, defaultMappedModel)
 
 		forwardDurationMs := time.Since(forwardStart).Milliseconds()
 		if accountReleaseFunc != nil {
@@ -796,7 +855,7 @@ func (h *OpenAIGatewayHandler) Messages(c *gin.Context) {
 		inboundEndpoint := GetInboundEndpoint(c)
 		upstreamEndpoint := GetUpstreamEndpoint(c, account.Platform)
 
-		h.submitOpenAIUsageRecordTask(result, func(ctx context.Context) {
+		h.submitOpenAIUsageRecordTask(result, withAuditUsage(c, func(ctx context.Context) {
 			if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
 				Result:             result,
 				APIKey:             apiKey,
@@ -820,7 +879,7 @@ func (h *OpenAIGatewayHandler) Messages(c *gin.Context) {
 					zap.Int64("account_id", account.ID),
 				).Error("openai_messages.record_usage_failed", zap.Error(err))
 			}
-		})
+		}))
 		reqLog.Debug("openai_messages.request_completed",
 			zap.Int64("account_id", account.ID),
 			zap.Int("switch_count", switchCount),
@@ -845,13 +904,7 @@ func resolveOpenAIMessagesMetadataSession(sessionHash, promptCacheKey, reqModel
 
 // anthropicErrorResponse writes an error in Anthropic Messages API format.
 func (h *OpenAIGatewayHandler) anthropicErrorResponse(c *gin.Context, status int, errType, message string) {
-	c.JSON(status, gin.H{
-		"type": "error",
-		"error": gin.H{
-			"type":    errType,
-			"message": message,
-		},
-	})
+	c.JSON(status, middleware2.AnthropicErrorBody(c, errType, message))
 }
 
 // anthropicStreamingAwareError handles errors that may occur during streaming,
@@ -1157,6 +1210,10 @@ func (h *OpenAIGatewayHandler) ResponsesWebSocket(c *gin.Context) {
 		closeOpenAIClientWS(wsConn, coderws.StatusPolicyViolation, "previous_response_id must be a response.id (resp_*), not a message id")
 		return
 	}
+	if reason := openAIWSModelNotAllowedReason(apiKey, reqModel); reason != "" {
+		closeOpenAIClientWS(wsConn, coderws.StatusPolicyViolation, reason)
+		return
+	}
 	reqLog = reqLog.With(
 		zap.Bool("ws_ingress", true),
 		zap.String("model", reqModel),
@@ -1285,6 +1342,11 @@ func (h *OpenAIGatewayHandler) ResponsesWebSocket(c *gin.Context) {
 		zap.Int("candidate_count", scheduleDecision.CandidateCount),
 	)
 
+	frameFilter := newOpenAIWSClientFrameFilter(ctx, wsConn, h.cfg, apiKey, reqModel)
+	if frameFilter.sizeGuard != nil {
+		// 用量记录任务据此在使用日志中记录中止原因
+		c.Request = c.Request.WithContext(service.WithResponseSizeGuard(c.Request.Context(), frameFilter.sizeGuard))
+	}
 	hooks := &service.OpenAIWSIngressHooks{
 		InitialRequestModel: reqModel,
 		BeforeRequest: func(turn int, payload []byte, originalModel string) error {
@@ -1301,6 +1363,10 @@ func (h *OpenAIGatewayHandler) ResponsesWebSocket(c *gin.Context) {
 			if model == "" {
 				model = reqModel
 			}
+			if reason := openAIWSModelNotAllowedReason(apiKey, model); reason != "" {
+				return service.NewOpenAIWSClientCloseError(coderws.StatusPolicyViolation, reason, nil)
+			}
+			frameFilter.startTurn(model)
 			if decision := h.checkContentModeration(c, reqLog, apiKey, subject, service.ContentModerationProtocolOpenAIResponses, model, payload); decision != nil && decision.Blocked {
 				writeContentModerationWSError(ctx, wsConn, decision)
 				return service.NewOpenAIWSClientCloseError(coderws.StatusPolicyViolation, decision.Message, nil)
@@ -1359,7 +1425,7 @@ func (h *OpenAIGatewayHandler) ResponsesWebSocket(c *gin.Context) {
 			h.gatewayService.ReportOpenAIAccountScheduleResult(account.ID, true, result.FirstTokenMs)
 			inboundEndpoint := GetInboundEndpoint(c)
 			upstreamEndpoint := GetUpstreamEndpoint(c, account.Platform)
-			h.submitOpenAIUsageRecordTask(result, func(taskCtx context.Context) {
+			h.submitOpenAIUsageRecordTask(result, withAuditUsage(c, func(taskCtx context.Context) {
 				if err := h.gatewayService.RecordUsage(taskCtx, &service.OpenAIRecordUsageInput{
 					Result:             result,
 					APIKey:             apiKey,
@@ -1380,10 +1446,14 @@ func (h *OpenAIGatewayHandler) ResponsesWebSocket(c *gin.Context) {
 						zap.Error(err),
 					)
 				}
-			})
+			}))
 		},
 	}
 
+	if frameFilter.enabled() {
+		hooks.BeforeClientWrite = frameFilter.beforeClientWrite
+	}
+
 	// 应用渠道模型映射到 WebSocket 首条消息
 	wsFirstMessage := firstMessage
 	if channelMappingWS.Mapped {
@@ -1676,6 +1746,17 @@ func (h *OpenAIGatewayHandler) handleStreamingAwareError(c *gin.Context, status
 	h.errorResponse(c, status, errType, message)
 }
 
+// forwardRequestContext 已切换过账号时将切换次数写入请求 context，
+// service 据此判断请求失败时是否还能继续换号重试。
+func (h *OpenAIGatewayHandler) forwardRequestContext(c *gin.Context, switchCount int) context.Context {
+	ctx := c.Request.Context()
+	if switchCount > 0 {
+		bridge := h.cfg == nil || h.cfg.Gateway.OpenAIWS.MetadataBridgeEnabled
+		ctx = service.WithAccountSwitchCount(ctx, switchCount, bridge)
+	}
+	return ctx
+}
+
 // ensureForwardErrorResponse 在 Forward 返回错误但尚未写响应时补写统一错误响应。
 func (h *OpenAIGatewayHandler) ensureForwardErrorResponse(c *gin.Context, streamStarted bool) bool {
 	if c == nil || c.Writer == nil || c.Writer.Written() {
@@ -1751,6 +1832,87 @@ func closeOpenAIClientWS(conn *coderws.Conn, status coderws.StatusCode, reason s
 	_ = conn.CloseNow()
 }
 
+// openAIWSModelNotAllowedReason 模型不被 API Key 的允许/禁止列表放行时返回关闭原因，否则返回空。
+// WebSocket 入口是 GET 升级请求，不经过 RequireModelAccess 中间件，需对首帧及后续每个 response.create 自行校验。
+func openAIWSModelNotAllowedReason(apiKey *service.APIKey, model string) string {
+	if apiKey == nil || apiKey.IsModelAllowed(model) {
+		return ""
+	}
+	return fmt.Sprintf("Model %s is not allowed for this API key", model)
+}
+
+// openAIWSClientFrameFilter 处理 Responses WebSocket 的下行事件。WebSocket 入口是 GET 升级请求，
+// 不经过 ModelMask 与 APIKeyResponseSizeLimit 中间件，在此逐帧完成同样的处理：
+// 开启 mask_response_model 时把事件中的模型名改写为客户端本轮请求的模型名；
+// 设置了响应体上限时按每轮响应累计写出的字节数（与 HTTP 每个请求的响应上限一致），超限后发送 error 事件并结束会话。
+type openAIWSClientFrameFilter struct {
+	ctx          context.Context
+	conn         *coderws.Conn
+	maskModel    bool
+	requestModel atomic.Value // string
+	sizeGuard    *service.ResponseSizeGuard
+	written      atomic.Int64
+}
+
+func newOpenAIWSClientFrameFilter(ctx context.Context, conn *coderws.Conn, cfg *config.Config, apiKey *service.APIKey, requestModel string) *openAIWSClientFrameFilter {
+	f := &openAIWSClientFrameFilter{ctx: ctx, conn: conn, maskModel: middleware2.ResponseModelMaskEnabled(cfg, apiKey)}
+	f.requestModel.Store(requestModel)
+	if _, maxBytes := service.ResolveAPIKeySizeLimits(apiKey, cfg); maxBytes > 0 {
+		f.sizeGuard = service.NewResponseSizeGuard(maxBytes)
+	}
+	return f
+}
+
+// startTurn 客户端发起新一轮 response.create 时更新回显的模型名并重新计量响应大小
+func (f *openAIWSClientFrameFilter) startTurn(model string) {
+	if model != "" {
+		f.requestModel.Store(model)
+	}
+	f.written.Store(0)
+}
+
+// enabled 无需处理下行事件时返回 false，避免逐帧调用
+func (f *openAIWSClientFrameFilter) enabled() bool {
+	return f.maskModel || f.sizeGuard != nil
+}
+
+// beforeClientWrite 用作 OpenAIWSIngressHooks.BeforeClientWrite；passthrough 模式下与 BeforeRequest 并发调用，
+// 状态因此原子读写。按改写前的字节数计量，与 HTTP 路径的中间件顺序一致
+func (f *openAIWSClientFrameFilter) beforeClientWrite(payload []byte) ([]byte, error) {
+	if f.sizeGuard != nil && f.written.Add(int64(len(payload))) > f.sizeGuard.Limit() {
+		message := f.sizeGuard.Abort()
+		writeResponseTooLargeWSError(f.ctx, f.conn, message)
+		return nil, service.NewOpenAIWSClientCloseError(coderws.StatusPolicyViolation, message, nil)
+	}
+	if f.maskModel {
+		model, _ := f.requestModel.Load().(string)
+		payload = middleware2.MaskModelInJSON(payload, model)
+	}
+	return payload, nil
+}
+
+// writeResponseTooLargeWSError 以 Responses error 事件告知客户端响应因超出 API Key 上限被中止
+func writeResponseTooLargeWSError(ctx context.Context, conn *coderws.Conn, message string) {
+	if conn == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	payload, _ := json.Marshal(gin.H{
+		"event_id": "evt_response_too_large",
+		"type":     "error",
+		"error": gin.H{
+			"type":    "invalid_request_error",
+			"code":    "response_too_large",
+			"message": message,
+		},
+	})
+	writeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	_ = conn.Write(writeCtx, coderws.MessageText, payload)
+}
+
 func writeContentModerationWSError(ctx context.Context, conn *coderws.Conn, decision *service.ContentModerationDecision) {
 	if conn == nil || decision == nil {
 		return
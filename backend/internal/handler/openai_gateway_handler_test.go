@@ -647,6 +647,110 @@ func TestOpenAIResponsesWebSocket_PreviousResponseIDKindLoggedBeforeAcquireFailu
 	require.Contains(t, strings.ToLower(closeErr.Reason), "failed to acquire user concurrency slot")
 }
 
+func TestOpenAIResponsesWebSocket_RejectsModelNotAllowedForAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := newOpenAIHandlerForPreviousResponseIDValidation(t, nil)
+	groupID := int64(2)
+	apiKey := &service.APIKey{
+		ID:            101,
+		GroupID:       &groupID,
+		User:          &service.User{ID: 1},
+		AllowedModels: []string{"gpt-5*"},
+		DeniedModels:  []string{"gpt-5.1"},
+	}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(middleware.ContextKeyAPIKey), apiKey)
+		c.Set(string(middleware.ContextKeyUser), middleware.AuthSubject{UserID: 1, Concurrency: 1})
+		c.Next()
+	})
+	router.GET("/openai/v1/responses", h.ResponsesWebSocket)
+	wsServer := httptest.NewServer(router)
+	defer wsServer.Close()
+
+	dialCtx, cancelDial := context.WithTimeout(context.Background(), 3*time.Second)
+	clientConn, _, err := coderws.Dial(dialCtx, "ws"+strings.TrimPrefix(wsServer.URL, "http")+"/openai/v1/responses", nil)
+	cancelDial()
+	require.NoError(t, err)
+	defer func() {
+		_ = clientConn.CloseNow()
+	}()
+
+	writeCtx, cancelWrite := context.WithTimeout(context.Background(), 3*time.Second)
+	err = clientConn.Write(writeCtx, coderws.MessageText, []byte(`{"type":"response.create","model":"gpt-5.1","stream":false}`))
+	cancelWrite()
+	require.NoError(t, err)
+
+	readCtx, cancelRead := context.WithTimeout(context.Background(), 3*time.Second)
+	_, _, err = clientConn.Read(readCtx)
+	cancelRead()
+	require.Error(t, err)
+	var closeErr coderws.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	require.Equal(t, coderws.StatusPolicyViolation, closeErr.Code)
+	require.Contains(t, closeErr.Reason, "gpt-5.1 is not allowed")
+}
+
+func TestOpenAIWSModelNotAllowedReason(t *testing.T) {
+	apiKey := &service.APIKey{AllowedModels: []string{"gpt-5*"}}
+
+	// 后续 turn 的 response.create 同样按模型校验
+	require.Empty(t, openAIWSModelNotAllowedReason(apiKey, "gpt-5.4"))
+	require.Contains(t, openAIWSModelNotAllowedReason(apiKey, "o3"), "Model o3 is not allowed")
+	require.Empty(t, openAIWSModelNotAllowedReason(&service.APIKey{}, "o3"))
+}
+
+func TestOpenAIWSClientFrameFilter_MasksResponseModel(t *testing.T) {
+	filter := newOpenAIWSClientFrameFilter(context.Background(), nil, &config.Config{}, &service.APIKey{MaskResponseModel: true}, "acme-large")
+	require.True(t, filter.enabled())
+
+	out, err := filter.beforeClientWrite([]byte(`{"type":"response.created","response":{"id":"resp_1","model":"gpt-5.1"}}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"response.created","response":{"id":"resp_1","model":"acme-large"}}`, string(out))
+
+	// 后续 turn 回显该轮请求的模型名
+	filter.startTurn("acme-small")
+	out, err = filter.beforeClientWrite([]byte(`{"type":"response.completed","response":{"model":"gpt-5.1-mini"}}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"response.completed","response":{"model":"acme-small"}}`, string(out))
+
+	cfg := &config.Config{}
+	cfg.Gateway.MaskResponseModel = true
+	require.True(t, newOpenAIWSClientFrameFilter(context.Background(), nil, cfg, &service.APIKey{}, "acme-large").enabled())
+	require.False(t, newOpenAIWSClientFrameFilter(context.Background(), nil, &config.Config{}, &service.APIKey{}, "acme-large").enabled())
+}
+
+func TestOpenAIWSClientFrameFilter_AbortsSessionOverResponseLimit(t *testing.T) {
+	filter := newOpenAIWSClientFrameFilter(context.Background(), nil, &config.Config{}, &service.APIKey{MaxResponseBytes: 64}, "gpt-5.1")
+	require.True(t, filter.enabled())
+
+	event := []byte(`{"type":"response.output_text.delta","delta":"0123456789"}`)
+	out, err := filter.beforeClientWrite(event)
+	require.NoError(t, err)
+	require.Equal(t, event, out)
+
+	// 每轮响应单独计量
+	filter.startTurn("gpt-5.1")
+	_, err = filter.beforeClientWrite(event)
+	require.NoError(t, err)
+	_, err = filter.beforeClientWrite(event)
+	var closeErr *service.OpenAIWSClientCloseError
+	require.ErrorAs(t, err, &closeErr)
+	require.Equal(t, coderws.StatusPolicyViolation, closeErr.StatusCode())
+	require.Contains(t, filter.sizeGuard.AbortReason(), "64 bytes")
+
+	// 异步用量记录任务带上中止原因
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/responses", nil)
+	c.Request = c.Request.WithContext(service.WithResponseSizeGuard(c.Request.Context(), filter.sizeGuard))
+	var taskGuard *service.ResponseSizeGuard
+	withAuditUsage(c, func(ctx context.Context) {
+		taskGuard = service.ResponseSizeGuardFromContext(ctx)
+	})(context.Background())
+	require.Same(t, filter.sizeGuard, taskGuard)
+}
+
 type contentModerationHandlerSettingRepo struct {
 	values map[string]string
 }
@@ -1190,6 +1294,7 @@ func runOpenAIResponsesWebSocketUsageLogCase(t *testing.T, tc openAIResponsesWSU
 		channelSvc,
 		nil,
 		nil,
+		nil,
 	)
 
 	cache := &concurrencyCacheMock{
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/tlsfingerprint"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	openAIResponseCacheResponsesBody = `{"id":"resp_cache","object":"response","model":"gpt-5.4","status":"completed","output":[{"type":"message","id":"msg_1","role":"assistant","status":"completed","content":[{"type":"output_text","text":"cached","annotations":[]}]}],"usage":{"input_tokens":3,"output_tokens":2,"total_tokens":5}}`
+	openAIResponseCacheChatBody      = `{"id":"chatcmpl_cache","object":"chat.completion","created":1,"model":"gpt-5.4","choices":[{"index":0,"message":{"role":"assistant","content":"cached"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`
+)
+
+// openAIResponseCacheHTTPUpstream 直接用默认 HTTP 客户端请求测试上游
+type openAIResponseCacheHTTPUpstream struct{}
+
+func (openAIResponseCacheHTTPUpstream) Do(req *http.Request, _ string, _ int64, _ int) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func (openAIResponseCacheHTTPUpstream) DoWithTLS(req *http.Request, _ string, _ int64, _ int, _ *tlsfingerprint.Profile) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+type openAIResponseCacheTestEnv struct {
+	router        *gin.Engine
+	usageLogs     chan *service.UsageLog
+	upstreamCalls *atomic.Int32
+}
+
+func newOpenAIResponseCacheTestEnv(t *testing.T, accountExtra map[string]any) *openAIResponseCacheTestEnv {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var upstreamCalls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if gjson.GetBytes(body, "stream").Bool() {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "event: response.completed\ndata: {\"type\":\"response.completed\",\"response\":"+openAIResponseCacheResponsesBody+"}\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/chat/completions") {
+			_, _ = io.WriteString(w, openAIResponseCacheChatBody)
+			return
+		}
+		_, _ = io.WriteString(w, openAIResponseCacheResponsesBody)
+	}))
+	t.Cleanup(upstream.Close)
+
+	groupID := int64(4301)
+	account := service.Account{
+		ID:          9931,
+		Name:        "openai-response-cache",
+		Platform:    service.PlatformOpenAI,
+		Type:        service.AccountTypeAPIKey,
+		Status:      service.StatusActive,
+		Schedulable: true,
+		Concurrency: 1,
+		Credentials: map[string]any{
+			"api_key":  "sk-test",
+			"base_url": upstream.URL,
+		},
+		Extra: accountExtra,
+	}
+
+	cfg := &config.Config{}
+	cfg.RunMode = config.RunModeSimple
+	cfg.Default.RateMultiplier = 1
+	cfg.Security.URLAllowlist.Enabled = false
+	cfg.Security.URLAllowlist.AllowInsecureHTTP = true
+	cfg.Gateway.ResponseCache = config.GatewayResponseCacheConfig{Enabled: true, TTLSeconds: 60, MaxEntries: 10, MaxBodyBytes: 1 << 20}
+
+	usageRepo := &openAIWSUsageHandlerUsageLogRepoStub{created: make(chan *service.UsageLog, 4)}
+	billingCacheSvc := service.NewBillingCacheService(nil, nil, nil, nil, nil, nil, cfg)
+	gatewaySvc := service.NewOpenAIGatewayService(
+		&openAIWSUsageHandlerAccountRepoStub{account: account},
+		usageRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		cfg,
+		nil,
+		nil,
+		service.NewBillingService(cfg, nil),
+		nil,
+		billingCacheSvc,
+		openAIResponseCacheHTTPUpstream{},
+		&service.DeferredService{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	cache := &concurrencyCacheMock{
+		acquireUserSlotFn: func(ctx context.Context, userID int64, maxConcurrency int, requestID string) (bool, error) {
+			return true, nil
+		},
+		acquireAccountSlotFn: func(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+			return true, nil
+		},
+	}
+	h := &OpenAIGatewayHandler{
+		gatewayService:      gatewaySvc,
+		billingCacheService: billingCacheSvc,
+		apiKeyService:       &service.APIKeyService{},
+		concurrencyHelper:   NewConcurrencyHelper(service.NewConcurrencyService(cache), SSEPingFormatNone, time.Second),
+		cfg:                 cfg,
+		responseCache:       service.NewResponseCacheService(cfg),
+	}
+
+	apiKey := &service.APIKey{
+		ID:      1811,
+		GroupID: &groupID,
+		User:    &service.User{ID: 1711, Status: service.StatusActive},
+	}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(middleware.ContextKeyAPIKey), apiKey)
+		c.Set(string(middleware.ContextKeyUser), middleware.AuthSubject{UserID: apiKey.User.ID, Concurrency: 1})
+		c.Next()
+	})
+	router.POST("/v1/chat/completions", h.ChatCompletions)
+	router.POST("/v1/responses", h.Responses)
+
+	return &openAIResponseCacheTestEnv{router: router, usageLogs: usageRepo.created, upstreamCalls: &upstreamCalls}
+}
+
+func (env *openAIResponseCacheTestEnv) do(path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	env.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (env *openAIResponseCacheTestEnv) waitUsageLog(t *testing.T) *service.UsageLog {
+	t.Helper()
+	select {
+	case log := <-env.usageLogs:
+		require.NotNil(t, log)
+		return log
+	case <-time.After(3 * time.Second):
+		t.Fatal("等待 usage log 写入超时")
+		return nil
+	}
+}
+
+func (env *openAIResponseCacheTestEnv) waitCached() {
+	// ristretto 异步写入，短暂等待确保条目可读
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestOpenAIResponseCache_ChatCompletionsServesRepeatedRequestFromCache(t *testing.T) {
+	env := newOpenAIResponseCacheTestEnv(t, nil)
+	body := `{"model":"gpt-5.4","messages":[{"role":"user","content":"hi"}],"temperature":0}`
+
+	first := env.do("/v1/chat/completions", body)
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	require.Equal(t, "MISS", first.Header().Get(service.ResponseCacheHeader))
+	env.waitUsageLog(t)
+	env.waitCached()
+
+	second := env.do("/v1/chat/completions", body)
+	require.Equal(t, http.StatusOK, second.Code)
+	require.Equal(t, "HIT", second.Header().Get(service.ResponseCacheHeader))
+	require.Equal(t, first.Body.String(), second.Body.String())
+	require.Equal(t, int32(1), env.upstreamCalls.Load(), "命中缓存不应再请求上游")
+
+	hitLog := env.waitUsageLog(t)
+	require.Equal(t, int64(9931), hitLog.AccountID)
+	require.Equal(t, 3, hitLog.InputTokens)
+	require.Equal(t, 2, hitLog.OutputTokens)
+	require.Zero(t, hitLog.ActualCost, "hit_billing_rate 为 0 时命中缓存不计费")
+}
+
+func TestOpenAIResponseCache_ResponsesServesRepeatedRequestFromCache(t *testing.T) {
+	env := newOpenAIResponseCacheTestEnv(t, nil)
+	body := `{"model":"gpt-5.4","input":"hi"}`
+
+	first := env.do("/v1/responses", body)
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	require.Equal(t, "MISS", first.Header().Get(service.ResponseCacheHeader))
+	env.waitUsageLog(t)
+	env.waitCached()
+
+	second := env.do("/v1/responses", body)
+	require.Equal(t, http.StatusOK, second.Code)
+	require.Equal(t, "HIT", second.Header().Get(service.ResponseCacheHeader))
+	require.Equal(t, first.Body.String(), second.Body.String())
+	require.Equal(t, int32(1), env.upstreamCalls.Load(), "命中缓存不应再请求上游")
+
+	hitLog := env.waitUsageLog(t)
+	require.Equal(t, 3, hitLog.InputTokens)
+	require.Equal(t, 2, hitLog.OutputTokens)
+	require.Zero(t, hitLog.ActualCost)
+
+	// 缓存键区分入站端点：相同请求体的 Chat Completions 请求不会命中 Responses 的缓存
+	chat := env.do("/v1/chat/completions", body)
+	require.NotEqual(t, "HIT", chat.Header().Get(service.ResponseCacheHeader))
+}
+
+func TestOpenAIResponseCache_StreamingRequestsBypassCache(t *testing.T) {
+	env := newOpenAIResponseCacheTestEnv(t, nil)
+	body := `{"model":"gpt-5.4","input":"hi","stream":true}`
+
+	for i := 0; i < 2; i++ {
+		rec := env.do("/v1/responses", body)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Header().Get(service.ResponseCacheHeader))
+		env.waitUsageLog(t)
+	}
+	require.Equal(t, int32(2), env.upstreamCalls.Load())
+}
@@ -553,9 +553,10 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			fallbackPlatform := guessPlatformFromPath(c.Request.URL.Path)
 			platform := resolveOpsPlatform(apiKey, fallbackPlatform)
 
-			requestID := c.Writer.Header().Get("X-Request-Id")
+			// 上游 request id 由 RequestLogger 移至 X-Upstream-Request-ID，X-Request-Id 为本地追踪 ID
+			requestID := c.Writer.Header().Get("X-Upstream-Request-ID")
 			if requestID == "" {
-				requestID = c.Writer.Header().Get("x-request-id")
+				requestID = c.Writer.Header().Get("X-Request-Id")
 			}
 
 			// Best-effort backfill single upstream fields from the last event (if present).
@@ -768,9 +769,10 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 		fallbackPlatform := guessPlatformFromPath(c.Request.URL.Path)
 		platform := resolveOpsPlatform(apiKey, fallbackPlatform)
 
-		requestID := c.Writer.Header().Get("X-Request-Id")
+		// 上游 request id 由 RequestLogger 移至 X-Upstream-Request-ID，X-Request-Id 为本地追踪 ID
+		requestID := c.Writer.Header().Get("X-Upstream-Request-ID")
 		if requestID == "" {
-			requestID = c.Writer.Header().Get("x-request-id")
+			requestID = c.Writer.Header().Get("X-Request-Id")
 		}
 
 		normalizedType := normalizeOpsErrorType(parsed.ErrorType, parsed.Code)
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	routingStrategyHeader = "X-Routing-Strategy"
+	routingCostHeader     = "X-Routing-Cost-Per-Mtok"
+	routingLatencyHeader  = "X-Routing-Latency-Ms"
+)
+
+// setRoutingCostHeaders cheapest 调度策略下通过响应头透出选中账号的有效成本（USD / 百万 token）。
+// failover 换号时会覆盖上一次的值；账号无定价时移除成本头。
+func setRoutingCostHeaders(c *gin.Context, cost float64, ok bool) {
+	c.Header(routingStrategyHeader, service.GroupSchedulingStrategyCheapest)
+	if !ok {
+		c.Writer.Header().Del(routingCostHeader)
+		return
+	}
+	c.Header(routingCostHeader, strconv.FormatFloat(cost, 'f', 6, 64))
+}
+
+// setRoutingLatencyHeaders latency 调度策略下通过响应头透出选中账号的平均延迟（毫秒）。
+// failover 换号时会覆盖上一次的值；账号尚无延迟数据时移除延迟头。
+func setRoutingLatencyHeaders(c *gin.Context, latencyMs float64, ok bool) {
+	c.Header(routingStrategyHeader, service.GroupSchedulingStrategyLatency)
+	if !ok {
+		c.Writer.Header().Del(routingLatencyHeader)
+		return
+	}
+	c.Header(routingLatencyHeader, strconv.FormatFloat(latencyMs, 'f', 0, 64))
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// streamReservationBytesPerToken 预估输入 token 数时按每 token 约 4 字节粗略换算
+const streamReservationBytesPerToken = 4
+
+// maxOutputTokenPaths 各协议请求体中最大输出 token 的字段
+var maxOutputTokenPaths = []string{
+	"max_tokens",
+	"max_completion_tokens",
+	"max_output_tokens",
+	"generationConfig.maxOutputTokens",
+}
+
+// reserveStreamBudget 流式请求开始前按预估最大费用预留用户月度预算，并将预留放入请求 context 供用量记录任务结算。
+// 返回的 release 须在 handler 结束时调用（未预留时为空操作）；返回错误时调用方按计费错误响应。
+func reserveStreamBudget(c *gin.Context, billingCache *service.BillingCacheService, apiKey *service.APIKey, model string, body []byte) (func(), error) {
+	noop := func() {}
+	if billingCache == nil || apiKey == nil || apiKey.User == nil || apiKey.User.BudgetLimit <= 0 {
+		return noop, nil
+	}
+	estimate, err := billingCache.EstimateStreamMaxCost(model, apiKey.Group, len(body)/streamReservationBytesPerToken, requestMaxOutputTokens(body))
+	if err != nil {
+		// 无法预估（如模型缺少价格）时不预留，仅依赖常规预算检查
+		return noop, nil
+	}
+	reservation, err := billingCache.ReserveStreamBudget(c.Request.Context(), apiKey.User, apiKey, estimate)
+	if err != nil {
+		return noop, err
+	}
+	if reservation == nil {
+		return noop, nil
+	}
+	c.Request = c.Request.WithContext(service.WithBudgetReservation(c.Request.Context(), reservation))
+	return reservation.Release, nil
+}
+
+// requestMaxOutputTokens 读取请求体中的最大输出 token 数，未指定时返回 0
+func requestMaxOutputTokens(body []byte) int {
+	for _, path := range maxOutputTokenPaths {
+		if v := gjson.GetBytes(body, path); v.Exists() && v.Int() > 0 {
+			return int(v.Int())
+		}
+	}
+	return 0
+}
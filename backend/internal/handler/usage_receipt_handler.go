@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Receipt 返回当前用户的月度账单（按模型列出请求数、token 与费用，附合计）
+// GET /api/v1/user/receipts?month=YYYY-MM&format=json|csv
+// 月份按 UTC 计算，未指定时为当月；format 默认 json，csv 以附件形式下载，最后一行为合计。
+func (h *UsageHandler) Receipt(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "json")))
+	if format != "csv" && format != "json" {
+		response.BadRequest(c, "Invalid format: must be csv or json")
+		return
+	}
+	month, err := service.ParseUserReceiptMonth(c.Query("month"), time.Now())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	receipt, err := h.usageService.GetUserReceipt(c.Request.Context(), subject.UserID, month)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if format == "json" {
+		response.Success(c, receipt)
+		return
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"model", "requests", "input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens", "total_tokens", "cost"})
+	for _, line := range receipt.Lines {
+		_ = w.Write([]string{
+			line.Model,
+			strconv.FormatInt(line.Requests, 10),
+			strconv.FormatInt(line.InputTokens, 10),
+			strconv.FormatInt(line.OutputTokens, 10),
+			strconv.FormatInt(line.CacheCreationTokens, 10),
+			strconv.FormatInt(line.CacheReadTokens, 10),
+			strconv.FormatInt(line.TotalTokens, 10),
+			strconv.FormatFloat(line.Cost, 'f', -1, 64),
+		})
+	}
+	_ = w.Write([]string{
+		"TOTAL",
+		strconv.FormatInt(receipt.Requests, 10),
+		"", "", "", "",
+		strconv.FormatInt(receipt.TotalTokens, 10),
+		strconv.FormatFloat(receipt.TotalCost, 'f', -1, 64),
+	})
+	w.Flush()
+
+	filename := fmt.Sprintf("receipt_%s.csv", receipt.Month)
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
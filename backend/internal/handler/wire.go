@@ -40,6 +40,13 @@ func ProvideAdminHandlers(
 	contentModerationHandler *admin.ContentModerationHandler,
 	paymentHandler *admin.PaymentHandler,
 	affiliateHandler *admin.AffiliateHandler,
+	auditHandler *admin.AuditHandler,
+	maintenanceHandler *admin.MaintenanceHandler,
+	slowRequestHandler *admin.SlowRequestHandler,
+	priorityQueueHandler *admin.PriorityQueueHandler,
+	warmupHandler *admin.WarmupHandler,
+	modelAccountsHandler *admin.ModelAccountsHandler,
+	gatewayLoadHandler *admin.GatewayLoadHandler,
 ) *AdminHandlers {
 	return &AdminHandlers{
 		Dashboard:              dashboardHandler,
@@ -73,6 +80,13 @@ func ProvideAdminHandlers(
 		ContentModeration:      contentModerationHandler,
 		Payment:                paymentHandler,
 		Affiliate:              affiliateHandler,
+		Audit:                  auditHandler,
+		Maintenance:            maintenanceHandler,
+		SlowRequest:            slowRequestHandler,
+		PriorityQueue:          priorityQueueHandler,
+		Warmup:                 warmupHandler,
+		ModelAccounts:          modelAccountsHandler,
+		GatewayLoad:            gatewayLoadHandler,
 	}
 }
 
@@ -104,6 +118,7 @@ func ProvideHandlers(
 	paymentHandler *PaymentHandler,
 	paymentWebhookHandler *PaymentWebhookHandler,
 	availableChannelHandler *AvailableChannelHandler,
+	healthHandler *HealthHandler,
 	_ *service.IdempotencyCoordinator,
 	_ *service.IdempotencyCleanupService,
 ) *Handlers {
@@ -124,6 +139,7 @@ func ProvideHandlers(
 		Payment:          paymentHandler,
 		PaymentWebhook:   paymentWebhookHandler,
 		AvailableChannel: availableChannelHandler,
+		Health:           healthHandler,
 	}
 }
 
@@ -145,6 +161,7 @@ var ProviderSet = wire.NewSet(
 	NewPaymentHandler,
 	NewPaymentWebhookHandler,
 	NewAvailableChannelHandler,
+	NewHealthHandler,
 
 	// Admin handlers
 	admin.NewDashboardHandler,
@@ -178,6 +195,13 @@ var ProviderSet = wire.NewSet(
 	admin.NewContentModerationHandler,
 	admin.NewPaymentHandler,
 	admin.NewAffiliateHandler,
+	admin.NewAuditHandler,
+	admin.NewMaintenanceHandler,
+	admin.NewSlowRequestHandler,
+	admin.NewPriorityQueueHandler,
+	admin.NewWarmupHandler,
+	admin.NewModelAccountsHandler,
+	admin.NewGatewayLoadHandler,
 
 	// AdminHandlers and Handlers constructors
 	ProvideAdminHandlers,
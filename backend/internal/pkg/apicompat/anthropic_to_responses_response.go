@@ -154,6 +154,9 @@ type AnthropicEventToResponsesState struct {
 	InputTokens          int
 	OutputTokens         int
 	CacheReadInputTokens int
+
+	// stop_reason from message_delta
+	StopReason string
 }
 
 // NewAnthropicEventToResponsesState returns an initialised stream state.
@@ -200,7 +203,8 @@ func FinalizeAnthropicResponsesStream(state *AnthropicEventToResponsesState) []R
 	events = append(events, closeCurrentResponsesItem(state)...)
 
 	// Emit response.completed
-	events = append(events, makeResponsesCompletedEvent(state, "completed", nil))
+	status, incompleteDetails := anthropicStreamResponsesStatus(state)
+	events = append(events, makeResponsesCompletedEvent(state, status, incompleteDetails))
 	state.CompletedSent = true
 	return events
 }
@@ -396,6 +400,9 @@ func anthToResHandleMessageDelta(evt *AnthropicStreamEvent, state *AnthropicEven
 			state.CacheReadInputTokens = evt.Usage.CacheReadInputTokens
 		}
 	}
+	if evt.Delta != nil && evt.Delta.StopReason != "" {
+		state.StopReason = evt.Delta.StopReason
+	}
 
 	return nil
 }
@@ -411,8 +418,7 @@ func anthToResHandleMessageStop(state *AnthropicEventToResponsesState) []Respons
 	events = append(events, closeCurrentResponsesItem(state)...)
 
 	// Determine status
-	status := "completed"
-	var incompleteDetails *ResponsesIncompleteDetails
+	status, incompleteDetails := anthropicStreamResponsesStatus(state)
 
 	// Emit response.completed
 	events = append(events, makeResponsesCompletedEvent(state, status, incompleteDetails))
@@ -422,6 +428,16 @@ func anthToResHandleMessageStop(state *AnthropicEventToResponsesState) []Respons
 
 // --- helper functions ---
 
+// anthropicStreamResponsesStatus maps the stop_reason seen in message_delta to
+// the Responses status, mirroring the non-streaming conversion.
+func anthropicStreamResponsesStatus(state *AnthropicEventToResponsesState) (string, *ResponsesIncompleteDetails) {
+	status := anthropicStopReasonToResponsesStatus(state.StopReason, nil)
+	if status == "incomplete" {
+		return status, &ResponsesIncompleteDetails{Reason: "max_output_tokens"}
+	}
+	return status, nil
+}
+
 func closeCurrentResponsesItem(state *AnthropicEventToResponsesState) []ResponsesStreamEvent {
 	if state.CurrentItemType == "" {
 		return nil
@@ -0,0 +1,136 @@
+package apicompat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// ChatCompletionsToAnthropicRequest tests
+// ---------------------------------------------------------------------------
+
+func TestChatCompletionsToAnthropicRequest_SystemRolesAndStop(t *testing.T) {
+	maxTokens := 512
+	req := &ChatCompletionsRequest{
+		Model:     "claude-sonnet-4",
+		MaxTokens: &maxTokens,
+		Messages: []ChatMessage{
+			{Role: "system", Content: json.RawMessage(`"You are terse."`)},
+			{Role: "user", Content: json.RawMessage(`"Weather?"`)},
+			{Role: "assistant", ToolCalls: []ChatToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: ChatFunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: json.RawMessage(`"sunny"`)},
+		},
+		Stop: json.RawMessage(`"END"`),
+	}
+
+	out, err := ChatCompletionsToAnthropicRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "claude-sonnet-4", out.Model)
+	assert.Equal(t, 512, out.MaxTokens)
+	assert.Equal(t, []string{"END"}, out.StopSeqs)
+	assert.Contains(t, string(out.System), "You are terse.")
+
+	require.Len(t, out.Messages, 3)
+	assert.Equal(t, "user", out.Messages[0].Role)
+	assert.Equal(t, "assistant", out.Messages[1].Role)
+	assert.Contains(t, string(out.Messages[1].Content), `"tool_use"`)
+	assert.Equal(t, "user", out.Messages[2].Role)
+	assert.Contains(t, string(out.Messages[2].Content), `"tool_result"`)
+}
+
+func TestParseChatStopSequences(t *testing.T) {
+	seqs, err := ParseChatStopSequences(nil)
+	require.NoError(t, err)
+	assert.Nil(t, seqs)
+
+	seqs, err = ParseChatStopSequences(json.RawMessage(`["a", " ", "b"]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, seqs)
+
+	seqs, err = ParseChatStopSequences(json.RawMessage(`"  "`))
+	require.NoError(t, err)
+	assert.Nil(t, seqs)
+
+	_, err = ParseChatStopSequences(json.RawMessage(`123`))
+	require.Error(t, err)
+	_, err = ParseChatStopSequences(json.RawMessage(`["1","2","3","4","5"]`))
+	require.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// Anthropic stream → Chat Completions chunks (chained via Responses)
+// ---------------------------------------------------------------------------
+
+func anthropicStreamToChatChunks(t *testing.T, events []string) []ChatCompletionsChunk {
+	t.Helper()
+	anthState := NewAnthropicEventToResponsesState()
+	ccState := NewResponsesEventToChatState()
+	ccState.IncludeUsage = true
+
+	var chunks []ChatCompletionsChunk
+	for _, raw := range events {
+		var evt AnthropicStreamEvent
+		require.NoError(t, json.Unmarshal([]byte(raw), &evt))
+		for _, resEvt := range AnthropicEventToResponsesEvents(&evt, anthState) {
+			chunks = append(chunks, ResponsesEventToChatChunks(&resEvt, ccState)...)
+		}
+	}
+	for _, resEvt := range FinalizeAnthropicResponsesStream(anthState) {
+		chunks = append(chunks, ResponsesEventToChatChunks(&resEvt, ccState)...)
+	}
+	return append(chunks, FinalizeResponsesChatStream(ccState)...)
+}
+
+func chatFinishReason(chunks []ChatCompletionsChunk) string {
+	for _, chunk := range chunks {
+		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil {
+				return *choice.FinishReason
+			}
+		}
+	}
+	return ""
+}
+
+func TestAnthropicStreamToChat_StopReasons(t *testing.T) {
+	cases := map[string]string{
+		"end_turn":      "stop",
+		"stop_sequence": "stop",
+		"max_tokens":    "length",
+	}
+	for stopReason, want := range cases {
+		chunks := anthropicStreamToChatChunks(t, []string{
+			`{"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4","content":[],"usage":{"input_tokens":12,"output_tokens":0}}}`,
+			`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+			`{"type":"content_block_stop","index":0}`,
+			`{"type":"message_delta","delta":{"stop_reason":"` + stopReason + `"},"usage":{"output_tokens":5}}`,
+			`{"type":"message_stop"}`,
+		})
+		assert.Equal(t, want, chatFinishReason(chunks), stopReason)
+
+		last := chunks[len(chunks)-1]
+		require.NotNil(t, last.Usage, stopReason)
+		assert.Equal(t, 12, last.Usage.PromptTokens)
+		assert.Equal(t, 5, last.Usage.CompletionTokens)
+	}
+}
+
+func TestAnthropicStreamToChat_ToolUseFinishReason(t *testing.T) {
+	chunks := anthropicStreamToChatChunks(t, []string{
+		`{"type":"message_start","message":{"id":"msg_2","type":"message","role":"assistant","model":"claude-sonnet-4","content":[],"usage":{"input_tokens":3}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{}}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"city\":\"Paris\"}"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":9}}`,
+		`{"type":"message_stop"}`,
+	})
+	assert.Equal(t, "tool_calls", chatFinishReason(chunks))
+}
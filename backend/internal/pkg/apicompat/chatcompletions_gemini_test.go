@@ -0,0 +1,216 @@
+package apicompat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// ChatCompletionsToGeminiRequest tests
+// ---------------------------------------------------------------------------
+
+func TestChatCompletionsToGeminiRequest_RolesSystemAndConfig(t *testing.T) {
+	maxTokens := 256
+	temp := 0.3
+	req := &ChatCompletionsRequest{
+		Model:       "gemini-2.5-pro",
+		MaxTokens:   &maxTokens,
+		Temperature: &temp,
+		Stop:        json.RawMessage(`["END"]`),
+		Messages: []ChatMessage{
+			{Role: "system", Content: json.RawMessage(`"Be terse."`)},
+			{Role: "developer", Content: json.RawMessage(`"Answer in English."`)},
+			{Role: "user", Content: json.RawMessage(`"Hi"`)},
+			{Role: "user", Content: json.RawMessage(`[{"type":"text","text":"look"},{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA"}}]`)},
+			{Role: "assistant", Content: json.RawMessage(`"Hello!"`)},
+		},
+	}
+
+	out, err := ChatCompletionsToGeminiRequest(req)
+	require.NoError(t, err)
+
+	require.NotNil(t, out.SystemInstruction)
+	require.Len(t, out.SystemInstruction.Parts, 2)
+	assert.Equal(t, "Be terse.", out.SystemInstruction.Parts[0].Text)
+	assert.Equal(t, "Answer in English.", out.SystemInstruction.Parts[1].Text)
+
+	// 相邻的 user 消息合并为一个回合，assistant 映射为 model
+	require.Len(t, out.Contents, 2)
+	assert.Equal(t, "user", out.Contents[0].Role)
+	require.Len(t, out.Contents[0].Parts, 3)
+	assert.Equal(t, "Hi", out.Contents[0].Parts[0].Text)
+	require.NotNil(t, out.Contents[0].Parts[2].InlineData)
+	assert.Equal(t, "image/png", out.Contents[0].Parts[2].InlineData.MimeType)
+	assert.Equal(t, "AAAA", out.Contents[0].Parts[2].InlineData.Data)
+	assert.Equal(t, "model", out.Contents[1].Role)
+	assert.Equal(t, "Hello!", out.Contents[1].Parts[0].Text)
+
+	require.NotNil(t, out.GenerationConfig)
+	assert.Equal(t, 256, *out.GenerationConfig.MaxOutputTokens)
+	assert.Equal(t, 0.3, *out.GenerationConfig.Temperature)
+	assert.Equal(t, []string{"END"}, out.GenerationConfig.StopSequences)
+}
+
+func TestChatCompletionsToGeminiRequest_ToolCallsAndResults(t *testing.T) {
+	req := &ChatCompletionsRequest{
+		Model: "gemini-2.5-flash",
+		Messages: []ChatMessage{
+			{Role: "user", Content: json.RawMessage(`"Weather in Paris?"`)},
+			{Role: "assistant", ToolCalls: []ChatToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: ChatFunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: json.RawMessage(`"sunny"`)},
+		},
+		Tools: []ChatTool{{
+			Type: "function",
+			Function: &ChatFunction{
+				Name:       "get_weather",
+				Parameters: json.RawMessage(`{"type":"object","additionalProperties":false,"properties":{"city":{"type":"string"},"strict":{"type":"boolean"}}}`),
+			},
+		}},
+		ToolChoice: json.RawMessage(`{"type":"function","function":{"name":"get_weather"}}`),
+	}
+
+	out, err := ChatCompletionsToGeminiRequest(req)
+	require.NoError(t, err)
+	require.Len(t, out.Contents, 3)
+
+	call := out.Contents[1].Parts[0].FunctionCall
+	require.NotNil(t, call)
+	assert.Equal(t, "get_weather", call.Name)
+	assert.JSONEq(t, `{"city":"Paris"}`, string(call.Args))
+
+	assert.Equal(t, "user", out.Contents[2].Role)
+	result := out.Contents[2].Parts[0].FunctionResponse
+	require.NotNil(t, result)
+	assert.Equal(t, "get_weather", result.Name)
+	assert.JSONEq(t, `{"content":"sunny"}`, string(result.Response))
+
+	require.Len(t, out.Tools, 1)
+	params, err := json.Marshal(out.Tools[0].FunctionDeclarations[0].Parameters)
+	require.NoError(t, err)
+	// 不支持的 schema 关键字被移除，同名属性保留
+	assert.JSONEq(t, `{"type":"object","properties":{"city":{"type":"string"},"strict":{"type":"boolean"}}}`, string(params))
+
+	require.NotNil(t, out.ToolConfig)
+	assert.Equal(t, "ANY", out.ToolConfig.FunctionCallingConfig.Mode)
+	assert.Equal(t, []string{"get_weather"}, out.ToolConfig.FunctionCallingConfig.AllowedFunctionNames)
+}
+
+func TestChatCompletionsToGeminiRequest_Errors(t *testing.T) {
+	_, err := ChatCompletionsToGeminiRequest(&ChatCompletionsRequest{
+		Messages: []ChatMessage{{Role: "system", Content: json.RawMessage(`"only system"`)}},
+	})
+	require.Error(t, err)
+
+	_, err = ChatCompletionsToGeminiRequest(&ChatCompletionsRequest{
+		Messages: []ChatMessage{
+			{Role: "user", Content: json.RawMessage(`"hi"`)},
+			{Role: "tool", ToolCallID: "unknown", Content: json.RawMessage(`"x"`)},
+		},
+	})
+	require.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// GeminiToChatCompletions tests
+// ---------------------------------------------------------------------------
+
+func TestGeminiToChatCompletions_TextToolCallsAndUsage(t *testing.T) {
+	resp := &GeminiResponse{
+		Candidates: []GeminiCandidate{{
+			Content: GeminiContent{Role: "model", Parts: []GeminiPart{
+				{Text: "thinking...", Thought: true},
+				{Text: "Let me check."},
+				{FunctionCall: &GeminiFunctionCall{Name: "get_weather", Args: json.RawMessage(`{"city":"Paris"}`)}},
+			}},
+			FinishReason: "STOP",
+		}},
+		UsageMetadata: &GeminiUsageMetadata{
+			PromptTokenCount:        100,
+			CandidatesTokenCount:    20,
+			ThoughtsTokenCount:      5,
+			CachedContentTokenCount: 40,
+		},
+	}
+
+	out := GeminiToChatCompletions(resp, "gemini-2.5-pro")
+	assert.Equal(t, "chat.completion", out.Object)
+	assert.Equal(t, "gemini-2.5-pro", out.Model)
+	require.Len(t, out.Choices, 1)
+	choice := out.Choices[0]
+	assert.Equal(t, "tool_calls", choice.FinishReason)
+	assert.JSONEq(t, `"Let me check."`, string(choice.Message.Content))
+	assert.Equal(t, "thinking...", choice.Message.ReasoningContent)
+	require.Len(t, choice.Message.ToolCalls, 1)
+	assert.Equal(t, "get_weather", choice.Message.ToolCalls[0].Function.Name)
+	assert.NotEmpty(t, choice.Message.ToolCalls[0].ID)
+
+	require.NotNil(t, out.Usage)
+	assert.Equal(t, 100, out.Usage.PromptTokens)
+	assert.Equal(t, 25, out.Usage.CompletionTokens)
+	assert.Equal(t, 125, out.Usage.TotalTokens)
+	assert.Equal(t, 40, out.Usage.PromptTokensDetails.CachedTokens)
+}
+
+func TestGeminiToChatCompletions_FinishReasons(t *testing.T) {
+	for reason, want := range map[string]string{
+		"STOP":       "stop",
+		"MAX_TOKENS": "length",
+		"SAFETY":     "content_filter",
+		"":           "stop",
+	} {
+		resp := &GeminiResponse{Candidates: []GeminiCandidate{{
+			Content:      GeminiContent{Parts: []GeminiPart{{Text: "x"}}},
+			FinishReason: reason,
+		}}}
+		assert.Equal(t, want, GeminiToChatCompletions(resp, "m").Choices[0].FinishReason, reason)
+	}
+}
+
+func TestGeminiStreamToChat(t *testing.T) {
+	state := NewGeminiStreamToChatState("gemini-2.5-flash", true)
+
+	chunks := GeminiChunkToChatChunks(&GeminiResponse{Candidates: []GeminiCandidate{{
+		Content: GeminiContent{Parts: []GeminiPart{{Text: "Hel"}}},
+	}}}, state)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "assistant", chunks[0].Choices[0].Delta.Role)
+	assert.Equal(t, "Hel", *chunks[1].Choices[0].Delta.Content)
+
+	chunks = GeminiChunkToChatChunks(&GeminiResponse{
+		Candidates: []GeminiCandidate{{
+			Content:      GeminiContent{Parts: []GeminiPart{{Text: "lo"}}},
+			FinishReason: "MAX_TOKENS",
+		}},
+		UsageMetadata: &GeminiUsageMetadata{PromptTokenCount: 7, CandidatesTokenCount: 2},
+	}, state)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "lo", *chunks[0].Choices[0].Delta.Content)
+
+	final := FinalizeGeminiChatStream(state)
+	require.Len(t, final, 2)
+	assert.Equal(t, "length", *final[0].Choices[0].FinishReason)
+	require.NotNil(t, final[1].Usage)
+	assert.Equal(t, 9, final[1].Usage.TotalTokens)
+	assert.Nil(t, FinalizeGeminiChatStream(state))
+}
+
+func TestGeminiStreamToChat_IncludeUsageWithoutUsageMetadata(t *testing.T) {
+	state := NewGeminiStreamToChatState("gemini-2.5-flash", true)
+	GeminiChunkToChatChunks(&GeminiResponse{Candidates: []GeminiCandidate{{
+		Content:      GeminiContent{Parts: []GeminiPart{{Text: "hi"}}},
+		FinishReason: "STOP",
+	}}}, state)
+
+	final := FinalizeGeminiChatStream(state)
+	require.Len(t, final, 2)
+	require.NotNil(t, final[1].Usage)
+	assert.Empty(t, final[1].Choices)
+	assert.Equal(t, 0, final[1].Usage.TotalTokens)
+}
@@ -763,6 +763,28 @@ func TestResponsesEventToChatChunks_ResponseDoneIncomplete(t *testing.T) {
 	assert.Nil(t, FinalizeResponsesChatStream(state))
 }
 
+func TestResponsesEventToChatChunks_IncludeUsageWithoutUpstreamUsage(t *testing.T) {
+	state := NewResponsesEventToChatState()
+	state.Model = "gpt-4o"
+	state.IncludeUsage = true
+
+	chunks := ResponsesEventToChatChunks(&ResponsesStreamEvent{
+		Type:     "response.completed",
+		Response: &ResponsesResponse{Status: "completed"},
+	}, state)
+	// no upstream usage: a zero usage chunk is still emitted
+	require.Len(t, chunks, 2)
+	require.NotNil(t, chunks[1].Usage)
+	assert.Empty(t, chunks[1].Choices)
+	assert.Equal(t, 0, chunks[1].Usage.TotalTokens)
+
+	state = NewResponsesEventToChatState()
+	state.IncludeUsage = true
+	chunks = FinalizeResponsesChatStream(state)
+	require.Len(t, chunks, 2)
+	require.NotNil(t, chunks[1].Usage)
+}
+
 func TestResponsesEventToChatChunks_CompletedWithToolCalls(t *testing.T) {
 	state := NewResponsesEventToChatState()
 	state.Model = "gpt-4o"
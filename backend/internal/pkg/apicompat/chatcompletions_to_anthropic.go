@@ -0,0 +1,66 @@
+package apicompat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxAnthropicStopSequences caps custom stop sequences; OpenAI accepts at most
+// 4, Anthropic is more lenient, so the OpenAI limit is kept.
+const maxAnthropicStopSequences = 4
+
+// ChatCompletionsToAnthropicRequest converts a Chat Completions request into an
+// Anthropic Messages request. System/developer messages become the top-level
+// system prompt, tool messages become tool_result blocks and assistant
+// tool_calls become tool_use blocks (via the Responses format chain). Fields
+// the Responses format cannot carry, such as stop, are applied directly.
+func ChatCompletionsToAnthropicRequest(req *ChatCompletionsRequest) (*AnthropicRequest, error) {
+	responsesReq, err := ChatCompletionsToResponses(req)
+	if err != nil {
+		return nil, fmt.Errorf("convert chat completions to responses: %w", err)
+	}
+	out, err := ResponsesToAnthropicRequest(responsesReq)
+	if err != nil {
+		return nil, fmt.Errorf("convert responses to anthropic: %w", err)
+	}
+
+	stopSeqs, err := ParseChatStopSequences(req.Stop)
+	if err != nil {
+		return nil, err
+	}
+	out.StopSeqs = stopSeqs
+	return out, nil
+}
+
+// ParseChatStopSequences parses the Chat Completions stop field (string or
+// []string) into Anthropic stop_sequences. Whitespace-only sequences are
+// dropped because Anthropic rejects them.
+func ParseChatStopSequences(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var values []string
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		values = []string{single}
+	} else if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("stop must be a string or an array of strings")
+	}
+
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	if len(out) > maxAnthropicStopSequences {
+		return nil, fmt.Errorf("stop accepts at most %d sequences", maxAnthropicStopSequences)
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
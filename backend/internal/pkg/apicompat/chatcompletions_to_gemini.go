@@ -0,0 +1,444 @@
+package apicompat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Gemini generateContent types
+// ---------------------------------------------------------------------------
+
+// GeminiRequest is the request body for Gemini generateContent /
+// streamGenerateContent.
+type GeminiRequest struct {
+	Contents          []GeminiContent         `json:"contents"`
+	SystemInstruction *GeminiContent          `json:"systemInstruction,omitempty"`
+	GenerationConfig  *GeminiGenerationConfig `json:"generationConfig,omitempty"`
+	Tools             []GeminiTool            `json:"tools,omitempty"`
+	ToolConfig        *GeminiToolConfig       `json:"toolConfig,omitempty"`
+}
+
+// GeminiContent is a single turn ("user" | "model") or the system instruction.
+type GeminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []GeminiPart `json:"parts"`
+}
+
+// GeminiPart is one part of a Gemini content. Exactly one field is set.
+type GeminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	Thought          bool                    `json:"thought,omitempty"`
+	InlineData       *GeminiBlob             `json:"inlineData,omitempty"`
+	FileData         *GeminiFileData         `json:"fileData,omitempty"`
+	FunctionCall     *GeminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *GeminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// GeminiBlob carries inline base64 data (e.g. images from data URIs).
+type GeminiBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+// GeminiFileData references remote file content by URI.
+type GeminiFileData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+// GeminiFunctionCall is a function call emitted by the model.
+type GeminiFunctionCall struct {
+	ID   string          `json:"id,omitempty"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// GeminiFunctionResponse is the result of a function call sent back to the model.
+type GeminiFunctionResponse struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Response json.RawMessage `json:"response"`
+}
+
+// GeminiGenerationConfig holds sampling parameters.
+type GeminiGenerationConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"topP,omitempty"`
+	MaxOutputTokens *int     `json:"maxOutputTokens,omitempty"`
+	StopSequences   []string `json:"stopSequences,omitempty"`
+}
+
+// GeminiTool declares functions the model may call.
+type GeminiTool struct {
+	FunctionDeclarations []GeminiFunctionDeclaration `json:"functionDeclarations,omitempty"`
+}
+
+// GeminiFunctionDeclaration describes a single callable function.
+type GeminiFunctionDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters,omitempty"`
+}
+
+// GeminiToolConfig controls function calling behavior.
+type GeminiToolConfig struct {
+	FunctionCallingConfig *GeminiFunctionCallingConfig `json:"functionCallingConfig,omitempty"`
+}
+
+// GeminiFunctionCallingConfig is the Gemini equivalent of tool_choice.
+type GeminiFunctionCallingConfig struct {
+	Mode                 string   `json:"mode"` // "AUTO" | "ANY" | "NONE"
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+// GeminiResponse is a generateContent response or a single streamGenerateContent chunk.
+type GeminiResponse struct {
+	Candidates    []GeminiCandidate    `json:"candidates,omitempty"`
+	UsageMetadata *GeminiUsageMetadata `json:"usageMetadata,omitempty"`
+	ModelVersion  string               `json:"modelVersion,omitempty"`
+	ResponseID    string               `json:"responseId,omitempty"`
+}
+
+// GeminiCandidate is one generated candidate.
+type GeminiCandidate struct {
+	Content      GeminiContent `json:"content"`
+	FinishReason string        `json:"finishReason,omitempty"`
+	Index        int           `json:"index,omitempty"`
+}
+
+// GeminiUsageMetadata holds Gemini token counts. promptTokenCount includes
+// cachedContentTokenCount; thoughtsTokenCount is billed as output.
+type GeminiUsageMetadata struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	TotalTokenCount         int `json:"totalTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount,omitempty"`
+	ThoughtsTokenCount      int `json:"thoughtsTokenCount,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Request: ChatCompletionsRequest → GeminiRequest
+// ---------------------------------------------------------------------------
+
+// ChatCompletionsToGeminiRequest converts a Chat Completions request into a
+// Gemini generateContent request. System/developer messages become
+// systemInstruction, assistant turns become "model" turns, assistant
+// tool_calls become functionCall parts and tool results become
+// functionResponse parts (resolved to the function name by tool_call_id).
+// Adjacent turns with the same role are merged because Gemini expects roles
+// to alternate.
+func ChatCompletionsToGeminiRequest(req *ChatCompletionsRequest) (*GeminiRequest, error) {
+	out := &GeminiRequest{}
+
+	var systemParts []GeminiPart
+	if strings.TrimSpace(req.Instructions) != "" {
+		systemParts = append(systemParts, GeminiPart{Text: req.Instructions})
+	}
+
+	toolNames := make(map[string]string)
+	for i, m := range req.Messages {
+		switch m.Role {
+		case "system", "developer":
+			text, err := parseChatContent(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			if text != "" {
+				systemParts = append(systemParts, GeminiPart{Text: text})
+			}
+		case "user":
+			parts, err := chatUserContentToGeminiParts(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			out.appendContent("user", parts)
+		case "assistant":
+			parts, err := chatAssistantToGeminiParts(m, toolNames)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			out.appendContent("model", parts)
+		case "tool", "function":
+			part, err := chatToolResultToGeminiPart(m, toolNames)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			out.appendContent("user", []GeminiPart{part})
+		default:
+			return nil, fmt.Errorf("messages[%d]: unsupported role %q", i, m.Role)
+		}
+	}
+	if len(out.Contents) == 0 {
+		return nil, fmt.Errorf("messages must contain at least one user or assistant message")
+	}
+	if len(systemParts) > 0 {
+		out.SystemInstruction = &GeminiContent{Parts: systemParts}
+	}
+
+	stopSeqs, err := ParseChatStopSequences(req.Stop)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &GeminiGenerationConfig{
+		Temperature:   req.Temperature,
+		TopP:          req.TopP,
+		StopSequences: stopSeqs,
+	}
+	if req.MaxCompletionTokens != nil && *req.MaxCompletionTokens > 0 {
+		cfg.MaxOutputTokens = req.MaxCompletionTokens
+	} else if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		cfg.MaxOutputTokens = req.MaxTokens
+	}
+	if cfg.Temperature != nil || cfg.TopP != nil || cfg.MaxOutputTokens != nil || len(cfg.StopSequences) > 0 {
+		out.GenerationConfig = cfg
+	}
+
+	out.Tools = convertChatToolsToGemini(req.Tools, req.Functions)
+	toolChoice := req.ToolChoice
+	if len(toolChoice) == 0 && len(req.FunctionCall) > 0 {
+		toolChoice = req.FunctionCall
+	}
+	out.ToolConfig = convertChatToolChoiceToGemini(toolChoice)
+	return out, nil
+}
+
+// appendContent appends parts as a turn, merging into the previous turn when
+// the role is unchanged.
+func (r *GeminiRequest) appendContent(role string, parts []GeminiPart) {
+	if len(parts) == 0 {
+		return
+	}
+	if n := len(r.Contents); n > 0 && r.Contents[n-1].Role == role {
+		r.Contents[n-1].Parts = append(r.Contents[n-1].Parts, parts...)
+		return
+	}
+	r.Contents = append(r.Contents, GeminiContent{Role: role, Parts: parts})
+}
+
+func chatUserContentToGeminiParts(raw json.RawMessage) ([]GeminiPart, error) {
+	content, err := parseChatMessageContent(raw)
+	if err != nil {
+		return nil, err
+	}
+	if content.Text != nil {
+		if *content.Text == "" {
+			return nil, nil
+		}
+		return []GeminiPart{{Text: *content.Text}}, nil
+	}
+
+	var parts []GeminiPart
+	for _, p := range content.Parts {
+		switch p.Type {
+		case "text":
+			if p.Text != "" {
+				parts = append(parts, GeminiPart{Text: p.Text})
+			}
+		case "image_url":
+			if p.ImageURL == nil || p.ImageURL.URL == "" || isEmptyBase64DataURI(p.ImageURL.URL) {
+				continue
+			}
+			parts = append(parts, chatImageURLToGeminiPart(p.ImageURL.URL))
+		}
+	}
+	return parts, nil
+}
+
+// chatImageURLToGeminiPart converts base64 data URIs into inlineData and
+// remote URLs into fileData.
+func chatImageURLToGeminiPart(url string) GeminiPart {
+	if rest, ok := strings.CutPrefix(url, "data:"); ok {
+		if meta, data, found := strings.Cut(rest, ","); found && strings.HasSuffix(meta, ";base64") {
+			return GeminiPart{InlineData: &GeminiBlob{
+				MimeType: strings.TrimSuffix(meta, ";base64"),
+				Data:     data,
+			}}
+		}
+	}
+	return GeminiPart{FileData: &GeminiFileData{FileURI: url}}
+}
+
+func chatAssistantToGeminiParts(m ChatMessage, toolNames map[string]string) ([]GeminiPart, error) {
+	var parts []GeminiPart
+	text, err := parseAssistantContent(m.Content)
+	if err != nil {
+		return nil, err
+	}
+	if text != "" {
+		parts = append(parts, GeminiPart{Text: text})
+	}
+
+	calls := m.ToolCalls
+	if len(calls) == 0 && m.FunctionCall != nil {
+		// Legacy function_call: the function name doubles as the call id.
+		calls = []ChatToolCall{{ID: m.FunctionCall.Name, Function: *m.FunctionCall}}
+	}
+	for _, tc := range calls {
+		if tc.Function.Name == "" {
+			continue
+		}
+		if tc.ID != "" {
+			toolNames[tc.ID] = tc.Function.Name
+		}
+		args := json.RawMessage(`{}`)
+		if strings.TrimSpace(tc.Function.Arguments) != "" {
+			if !json.Valid([]byte(tc.Function.Arguments)) {
+				return nil, fmt.Errorf("tool call %q arguments must be valid JSON", tc.Function.Name)
+			}
+			args = json.RawMessage(tc.Function.Arguments)
+		}
+		parts = append(parts, GeminiPart{FunctionCall: &GeminiFunctionCall{
+			Name: tc.Function.Name,
+			Args: args,
+		}})
+	}
+	return parts, nil
+}
+
+// chatToolResultToGeminiPart converts a tool (or legacy function) result
+// message into a functionResponse part. Gemini identifies results by function
+// name, so tool_call_id is resolved against earlier assistant tool_calls.
+func chatToolResultToGeminiPart(m ChatMessage, toolNames map[string]string) (GeminiPart, error) {
+	output, err := parseChatContent(m.Content)
+	if err != nil {
+		return GeminiPart{}, err
+	}
+	name := m.Name
+	if n, ok := toolNames[m.ToolCallID]; ok {
+		name = n
+	}
+	if name == "" {
+		return GeminiPart{}, fmt.Errorf("tool result %q does not match any previous tool call", m.ToolCallID)
+	}
+
+	// Gemini expects an object; JSON object outputs are passed through,
+	// anything else is wrapped as {"content": ...}.
+	response := json.RawMessage(output)
+	if trimmed := strings.TrimSpace(output); !strings.HasPrefix(trimmed, "{") || !json.Valid([]byte(trimmed)) {
+		response, _ = json.Marshal(map[string]string{"content": output})
+	}
+	return GeminiPart{FunctionResponse: &GeminiFunctionResponse{Name: name, Response: response}}, nil
+}
+
+func convertChatToolsToGemini(tools []ChatTool, functions []ChatFunction) []GeminiTool {
+	var decls []GeminiFunctionDeclaration
+	add := func(fn ChatFunction) {
+		if fn.Name == "" {
+			return
+		}
+		var params any = map[string]any{"type": "object", "properties": map[string]any{}}
+		if len(fn.Parameters) > 0 && string(fn.Parameters) != "null" {
+			var parsed any
+			if err := json.Unmarshal(fn.Parameters, &parsed); err == nil {
+				params = parsed
+			}
+		}
+		decls = append(decls, GeminiFunctionDeclaration{
+			Name:        fn.Name,
+			Description: fn.Description,
+			Parameters:  cleanGeminiSchema(params),
+		})
+	}
+	for _, t := range tools {
+		if t.Type == "function" && t.Function != nil {
+			add(*t.Function)
+		}
+	}
+	for _, fn := range functions {
+		add(fn)
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	return []GeminiTool{{FunctionDeclarations: decls}}
+}
+
+// convertChatToolChoiceToGemini maps tool_choice ("none" | "auto" |
+// "required" | {"type":"function","function":{"name":...}}) and the legacy
+// function_call field onto Gemini's functionCallingConfig.
+func convertChatToolChoiceToGemini(raw json.RawMessage) *GeminiToolConfig {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var mode string
+	if err := json.Unmarshal(raw, &mode); err == nil {
+		switch mode {
+		case "none":
+			return &GeminiToolConfig{FunctionCallingConfig: &GeminiFunctionCallingConfig{Mode: "NONE"}}
+		case "required":
+			return &GeminiToolConfig{FunctionCallingConfig: &GeminiFunctionCallingConfig{Mode: "ANY"}}
+		default:
+			return nil
+		}
+	}
+	var named struct {
+		Name     string `json:"name"`
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &named); err != nil {
+		return nil
+	}
+	name := named.Function.Name
+	if name == "" {
+		name = named.Name
+	}
+	if name == "" {
+		return nil
+	}
+	return &GeminiToolConfig{FunctionCallingConfig: &GeminiFunctionCallingConfig{
+		Mode:                 "ANY",
+		AllowedFunctionNames: []string{name},
+	}}
+}
+
+// geminiUnsupportedSchemaKeys lists JSON Schema keywords Gemini function
+// declarations reject.
+var geminiUnsupportedSchemaKeys = map[string]struct{}{
+	"$schema":              {},
+	"$id":                  {},
+	"$ref":                 {},
+	"additionalProperties": {},
+	"patternProperties":    {},
+	"minLength":            {},
+	"maxLength":            {},
+	"minItems":             {},
+	"maxItems":             {},
+	"strict":               {},
+}
+
+// cleanGeminiSchema strips unsupported JSON Schema keywords recursively.
+// Property names under "properties" are kept even when they collide with a
+// keyword.
+func cleanGeminiSchema(schema any) any {
+	switch v := schema.(type) {
+	case map[string]any:
+		cleaned := make(map[string]any, len(v))
+		for key, value := range v {
+			if _, skip := geminiUnsupportedSchemaKeys[key]; skip {
+				continue
+			}
+			if props, ok := value.(map[string]any); ok && key == "properties" {
+				cleanedProps := make(map[string]any, len(props))
+				for name, prop := range props {
+					cleanedProps[name] = cleanGeminiSchema(prop)
+				}
+				cleaned[key] = cleanedProps
+				continue
+			}
+			cleaned[key] = cleanGeminiSchema(value)
+		}
+		return cleaned
+	case []any:
+		cleaned := make([]any, len(v))
+		for i, item := range v {
+			cleaned[i] = cleanGeminiSchema(item)
+		}
+		return cleaned
+	default:
+		return v
+	}
+}
@@ -0,0 +1,244 @@
+package apicompat
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Non-streaming: GeminiResponse → ChatCompletionsResponse
+// ---------------------------------------------------------------------------
+
+// GeminiToChatCompletions converts a Gemini generateContent response into a
+// Chat Completions response. Text parts of the first candidate are
+// concatenated into choices[0].message.content, thought parts into
+// reasoning_content and functionCall parts become tool_calls.
+func GeminiToChatCompletions(resp *GeminiResponse, model string) *ChatCompletionsResponse {
+	out := &ChatCompletionsResponse{
+		ID:      generateChatCmplID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   model,
+	}
+
+	var contentText, reasoningText string
+	var toolCalls []ChatToolCall
+	var finishReason string
+	if len(resp.Candidates) > 0 {
+		cand := resp.Candidates[0]
+		finishReason = cand.FinishReason
+		for _, part := range cand.Content.Parts {
+			switch {
+			case part.FunctionCall != nil:
+				toolCalls = append(toolCalls, geminiFunctionCallToChat(part.FunctionCall))
+			case part.Thought:
+				reasoningText += part.Text
+			default:
+				contentText += part.Text
+			}
+		}
+	}
+
+	msg := ChatMessage{Role: "assistant"}
+	if len(toolCalls) > 0 {
+		msg.ToolCalls = toolCalls
+	}
+	if contentText != "" || len(toolCalls) == 0 {
+		raw, _ := json.Marshal(contentText)
+		msg.Content = raw
+	}
+	if reasoningText != "" {
+		msg.ReasoningContent = reasoningText
+	}
+
+	out.Choices = []ChatChoice{{
+		Index:        0,
+		Message:      msg,
+		FinishReason: geminiFinishReasonToChat(finishReason, len(toolCalls) > 0),
+	}}
+	out.Usage = GeminiUsageToChat(resp.UsageMetadata)
+	return out
+}
+
+// GeminiUsageToChat converts Gemini usageMetadata into Chat Completions usage.
+// Thinking tokens are billed as output and therefore counted as completion
+// tokens; cached tokens are reported in prompt_tokens_details.
+func GeminiUsageToChat(u *GeminiUsageMetadata) *ChatUsage {
+	if u == nil {
+		return nil
+	}
+	completion := u.CandidatesTokenCount + u.ThoughtsTokenCount
+	usage := &ChatUsage{
+		PromptTokens:     u.PromptTokenCount,
+		CompletionTokens: completion,
+		TotalTokens:      u.PromptTokenCount + completion,
+	}
+	if u.CachedContentTokenCount > 0 {
+		usage.PromptTokensDetails = &ChatTokenDetails{CachedTokens: u.CachedContentTokenCount}
+	}
+	return usage
+}
+
+// geminiFinishReasonToChat maps Gemini finishReason onto Chat Completions
+// finish_reason.
+func geminiFinishReasonToChat(reason string, sawToolCall bool) string {
+	switch reason {
+	case "MAX_TOKENS":
+		return "length"
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY":
+		return "content_filter"
+	default:
+		if sawToolCall {
+			return "tool_calls"
+		}
+		return "stop"
+	}
+}
+
+func geminiFunctionCallToChat(fc *GeminiFunctionCall) ChatToolCall {
+	id := fc.ID
+	if id == "" {
+		id = generateToolCallID()
+	}
+	args := "{}"
+	if len(fc.Args) > 0 && string(fc.Args) != "null" {
+		args = string(fc.Args)
+	}
+	return ChatToolCall{
+		ID:   id,
+		Type: "function",
+		Function: ChatFunctionCall{
+			Name:      fc.Name,
+			Arguments: args,
+		},
+	}
+}
+
+// generateToolCallID returns a "call_" prefixed random hex ID for function
+// calls Gemini returned without an id.
+func generateToolCallID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "call_" + hex.EncodeToString(b)
+}
+
+// ---------------------------------------------------------------------------
+// Streaming: GeminiResponse chunks → []ChatCompletionsChunk (stateful converter)
+// ---------------------------------------------------------------------------
+
+// GeminiStreamToChatState tracks state for converting a sequence of Gemini
+// streamGenerateContent chunks into Chat Completions SSE chunks.
+type GeminiStreamToChatState struct {
+	ID                string
+	Model             string
+	Created           int64
+	SentRole          bool
+	SawToolCall       bool
+	Finalized         bool
+	NextToolCallIndex int
+	FinishReason      string // last Gemini finishReason seen
+	IncludeUsage      bool
+	Usage             *ChatUsage
+}
+
+// NewGeminiStreamToChatState returns an initialised stream state.
+func NewGeminiStreamToChatState(model string, includeUsage bool) *GeminiStreamToChatState {
+	return &GeminiStreamToChatState{
+		ID:           generateChatCmplID(),
+		Model:        model,
+		Created:      time.Now().Unix(),
+		IncludeUsage: includeUsage,
+	}
+}
+
+// GeminiChunkToChatChunks converts a single Gemini stream chunk into zero or
+// more Chat Completions chunks. Usage and finishReason are recorded in state
+// and emitted by FinalizeGeminiChatStream, since Gemini may report usage on
+// the same chunk as (or after) the finish reason.
+func GeminiChunkToChatChunks(resp *GeminiResponse, state *GeminiStreamToChatState) []ChatCompletionsChunk {
+	if resp.UsageMetadata != nil {
+		state.Usage = GeminiUsageToChat(resp.UsageMetadata)
+	}
+
+	var chunks []ChatCompletionsChunk
+	if !state.SentRole {
+		state.SentRole = true
+		chunks = append(chunks, state.deltaChunk(ChatDelta{Role: "assistant"}))
+	}
+	if len(resp.Candidates) == 0 {
+		return chunks
+	}
+
+	cand := resp.Candidates[0]
+	for _, part := range cand.Content.Parts {
+		switch {
+		case part.FunctionCall != nil:
+			state.SawToolCall = true
+			idx := state.NextToolCallIndex
+			state.NextToolCallIndex++
+			tc := geminiFunctionCallToChat(part.FunctionCall)
+			tc.Index = &idx
+			chunks = append(chunks, state.deltaChunk(ChatDelta{ToolCalls: []ChatToolCall{tc}}))
+		case part.Text == "":
+			continue
+		case part.Thought:
+			text := part.Text
+			chunks = append(chunks, state.deltaChunk(ChatDelta{ReasoningContent: &text}))
+		default:
+			text := part.Text
+			chunks = append(chunks, state.deltaChunk(ChatDelta{Content: &text}))
+		}
+	}
+	if cand.FinishReason != "" {
+		state.FinishReason = cand.FinishReason
+	}
+	return chunks
+}
+
+// FinalizeGeminiChatStream emits the finish chunk and, when requested via
+// stream_options.include_usage, the trailing usage chunk. It is idempotent.
+func FinalizeGeminiChatStream(state *GeminiStreamToChatState) []ChatCompletionsChunk {
+	if state.Finalized {
+		return nil
+	}
+	state.Finalized = true
+
+	var chunks []ChatCompletionsChunk
+	if !state.SentRole {
+		state.SentRole = true
+		chunks = append(chunks, state.deltaChunk(ChatDelta{Role: "assistant"}))
+	}
+	finishReason := geminiFinishReasonToChat(state.FinishReason, state.SawToolCall)
+	empty := ""
+	chunks = append(chunks, ChatCompletionsChunk{
+		ID:      state.ID,
+		Object:  "chat.completion.chunk",
+		Created: state.Created,
+		Model:   state.Model,
+		Choices: []ChatChunkChoice{{
+			Index:        0,
+			Delta:        ChatDelta{Content: &empty},
+			FinishReason: &finishReason,
+		}},
+	})
+	if state.IncludeUsage {
+		chunks = append(chunks, makeChatUsageChunk(state.ID, state.Created, state.Model, state.Usage))
+	}
+	return chunks
+}
+
+func (state *GeminiStreamToChatState) deltaChunk(delta ChatDelta) ChatCompletionsChunk {
+	return ChatCompletionsChunk{
+		ID:      state.ID,
+		Object:  "chat.completion.chunk",
+		Created: state.Created,
+		Model:   state.Model,
+		Choices: []ChatChunkChoice{{
+			Index:        0,
+			Delta:        delta,
+			FinishReason: nil,
+		}},
+	}
+}
@@ -186,15 +186,8 @@ func FinalizeResponsesChatStream(state *ResponsesEventToChatState) []ChatComplet
 
 	chunks := []ChatCompletionsChunk{makeChatFinishChunk(state, finishReason)}
 
-	if state.IncludeUsage && state.Usage != nil {
-		chunks = append(chunks, ChatCompletionsChunk{
-			ID:      state.ID,
-			Object:  "chat.completion.chunk",
-			Created: state.Created,
-			Model:   state.Model,
-			Choices: []ChatChunkChoice{},
-			Usage:   state.Usage,
-		})
+	if state.IncludeUsage {
+		chunks = append(chunks, makeChatUsageChunk(state.ID, state.Created, state.Model, state.Usage))
 	}
 
 	return chunks
@@ -326,15 +319,8 @@ func resToChatHandleCompleted(evt *ResponsesStreamEvent, state *ResponsesEventTo
 	var chunks []ChatCompletionsChunk
 	chunks = append(chunks, makeChatFinishChunk(state, finishReason))
 
-	if state.IncludeUsage && state.Usage != nil {
-		chunks = append(chunks, ChatCompletionsChunk{
-			ID:      state.ID,
-			Object:  "chat.completion.chunk",
-			Created: state.Created,
-			Model:   state.Model,
-			Choices: []ChatChunkChoice{},
-			Usage:   state.Usage,
-		})
+	if state.IncludeUsage {
+		chunks = append(chunks, makeChatUsageChunk(state.ID, state.Created, state.Model, state.Usage))
 	}
 
 	return chunks
@@ -354,6 +340,23 @@ func makeChatDeltaChunk(state *ResponsesEventToChatState, delta ChatDelta) ChatC
 	}
 }
 
+// makeChatUsageChunk builds the trailing stream_options.include_usage chunk.
+// Upstreams that report no usage still get a chunk (with zero counts) so that
+// clients waiting for it are not left hanging.
+func makeChatUsageChunk(id string, created int64, model string, usage *ChatUsage) ChatCompletionsChunk {
+	if usage == nil {
+		usage = &ChatUsage{}
+	}
+	return ChatCompletionsChunk{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: created,
+		Model:   model,
+		Choices: []ChatChunkChoice{},
+		Usage:   usage,
+	}
+}
+
 func makeChatFinishChunk(state *ResponsesEventToChatState, finishReason string) ChatCompletionsChunk {
 	empty := ""
 	return ChatCompletionsChunk{
@@ -55,4 +55,28 @@ const (
 
 	// ClaudeCodeVersion stores the extracted Claude Code version from User-Agent (e.g. "2.1.22")
 	ClaudeCodeVersion Key = "ctx_claude_code_version"
+
+	// AuditEntry 当前请求的审计日志记录（由审计中间件设置，异步用量记录任务可沿用以补齐 token/费用）
+	AuditEntry Key = "ctx_audit_entry"
+
+	// ModelTimeout 当前请求的模型级超时状态（由模型超时中间件设置，上游请求上下文脱离取消时沿用其截止时间）
+	ModelTimeout Key = "ctx_model_timeout"
+
+	// UsageHeaderReport 当前请求的计费用量汇总（由用量响应头中间件设置，异步用量记录任务补齐后写入响应头/trailer）
+	UsageHeaderReport Key = "ctx_usage_header_report"
+
+	// BudgetReservation 当前流式请求的用户预算预留（由网关 handler 设置，异步用量记录任务按实际费用结算）
+	BudgetReservation Key = "ctx_budget_reservation"
+
+	// ResponseSizeGuard 当前请求的响应体大小限制状态（由 API Key 响应体大小限制中间件设置，超限中止时写入使用日志）
+	ResponseSizeGuard Key = "ctx_response_size_guard"
+
+	// IncludeDeletedAccounts 标识账号列表查询是否包含已软删除的账号（由管理后台 include_deleted 参数设置）
+	IncludeDeletedAccounts Key = "ctx_include_deleted_accounts"
+
+	// StructuredOutput 标识当前请求使用结构化输出（json_schema），由结构化输出中间件设置，用量计费时应用对应倍率
+	StructuredOutput Key = "ctx_structured_output"
+
+	// AuditReplay 管理员重放审计记录中的请求（由审计重放在进程内发起，API Key 认证中间件据此按 Key ID 加载并以沙盒方式执行）
+	AuditReplay Key = "ctx_audit_replay"
 )
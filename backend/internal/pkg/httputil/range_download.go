@@ -0,0 +1,222 @@
+package httputil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrDownloadTooLarge 下载内容超过 MaxBytes
+	ErrDownloadTooLarge = errors.New("download exceeds size limit")
+	// ErrDownloadIncomplete 下载中断且无法续传，或收到的长度与服务端声明不一致
+	ErrDownloadIncomplete = errors.New("download incomplete")
+)
+
+// DownloadStatusError 服务端返回了非预期的状态码
+type DownloadStatusError struct {
+	StatusCode int
+}
+
+func (e *DownloadStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+// RangeDownloadOptions 可续传下载参数
+type RangeDownloadOptions struct {
+	// ChunkBytes > 0 时按块发送 Range 请求；<= 0 时单次请求，仅在中断后以 Range 续传
+	ChunkBytes int64
+	// MaxBytes > 0 时限制下载总长度
+	MaxBytes int64
+	// MaxResumes 连接中断后最多续传的次数
+	MaxResumes int
+}
+
+// DownloadWithResume 下载 url 的完整内容，支持按块 Range 请求与中断续传。
+// 续传时通过 If-Range 携带首个响应的 ETag/Last-Modified，资源在下载期间变化时服务端返回完整内容并从头开始。
+// 只有在收到全部字节（与 Content-Length/Content-Range 声明一致）时才返回数据，否则返回 ErrDownloadIncomplete。
+func DownloadWithResume(ctx context.Context, client *http.Client, url string, opts RangeDownloadOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	total := int64(-1)
+	validator := ""
+	resumes := 0
+
+	retry := func(cause error) error {
+		if resumes >= opts.MaxResumes {
+			return fmt.Errorf("%w after %d resume(s) at %d bytes: %v", ErrDownloadIncomplete, resumes, buf.Len(), cause)
+		}
+		resumes++
+		return nil
+	}
+
+	for {
+		offset := int64(buf.Len())
+		if total >= 0 && offset >= total {
+			break
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if offset > 0 || opts.ChunkBytes > 0 {
+			end := ""
+			if opts.ChunkBytes > 0 {
+				end = strconv.FormatInt(offset+opts.ChunkBytes-1, 10)
+			}
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%s", offset, end))
+			if validator != "" {
+				req.Header.Set("If-Range", validator)
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			// 首个请求失败交由调用方处理（如整体重试）；已有部分数据时续传
+			if offset == 0 || ctx.Err() != nil {
+				return nil, err
+			}
+			if rerr := retry(err); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+
+		var done bool
+		switch resp.StatusCode {
+		case http.StatusOK:
+			// 服务端不支持 Range，或 If-Range 校验失败（资源已变化）：从头读取完整内容
+			buf.Reset()
+			total = resp.ContentLength
+			if opts.MaxBytes > 0 && total > opts.MaxBytes {
+				_ = resp.Body.Close()
+				return nil, ErrDownloadTooLarge
+			}
+			validator = rangeValidator(resp)
+			resumable := strings.EqualFold(resp.Header.Get("Accept-Ranges"), "bytes") && validator != ""
+			err = readDownloadBody(&buf, resp.Body, opts.MaxBytes)
+			_ = resp.Body.Close()
+			if errors.Is(err, ErrDownloadTooLarge) {
+				return nil, err
+			}
+			if err != nil {
+				if !resumable {
+					return nil, fmt.Errorf("%w: %v", ErrDownloadIncomplete, err)
+				}
+				if rerr := retry(err); rerr != nil {
+					return nil, rerr
+				}
+				continue
+			}
+			done = opts.ChunkBytes <= 0 || total < 0
+		case http.StatusPartialContent:
+			start, size, perr := parseContentRange(resp.Header.Get("Content-Range"))
+			if perr != nil || start != offset {
+				_ = resp.Body.Close()
+				return nil, fmt.Errorf("%w: unexpected Content-Range %q", ErrDownloadIncomplete, resp.Header.Get("Content-Range"))
+			}
+			if size >= 0 {
+				if opts.MaxBytes > 0 && size > opts.MaxBytes {
+					_ = resp.Body.Close()
+					return nil, ErrDownloadTooLarge
+				}
+				total = size
+			}
+			if validator == "" {
+				validator = rangeValidator(resp)
+			}
+			before := buf.Len()
+			err = readDownloadBody(&buf, resp.Body, opts.MaxBytes)
+			_ = resp.Body.Close()
+			if errors.Is(err, ErrDownloadTooLarge) {
+				return nil, err
+			}
+			if err != nil {
+				if rerr := retry(err); rerr != nil {
+					return nil, rerr
+				}
+				continue
+			}
+			got := int64(buf.Len() - before)
+			if got == 0 {
+				return nil, fmt.Errorf("%w: empty range response at %d bytes", ErrDownloadIncomplete, offset)
+			}
+			// 总长度未知时，以短于块大小的响应作为结束
+			done = total < 0 && (opts.ChunkBytes <= 0 || got < opts.ChunkBytes)
+		case http.StatusRequestedRangeNotSatisfiable:
+			_ = resp.Body.Close()
+			if total < 0 && offset > 0 {
+				// 上一块恰好读到末尾
+				done = true
+				break
+			}
+			return nil, &DownloadStatusError{StatusCode: resp.StatusCode}
+		default:
+			_ = resp.Body.Close()
+			return nil, &DownloadStatusError{StatusCode: resp.StatusCode}
+		}
+		if done {
+			break
+		}
+	}
+
+	if total >= 0 && int64(buf.Len()) != total {
+		return nil, fmt.Errorf("%w: got %d of %d bytes", ErrDownloadIncomplete, buf.Len(), total)
+	}
+	return buf.Bytes(), nil
+}
+
+// readDownloadBody 追加读取响应体，超过 maxBytes 时返回 ErrDownloadTooLarge
+func readDownloadBody(buf *bytes.Buffer, body io.Reader, maxBytes int64) error {
+	if maxBytes <= 0 {
+		_, err := buf.ReadFrom(body)
+		return err
+	}
+	remaining := maxBytes - int64(buf.Len())
+	n, err := buf.ReadFrom(io.LimitReader(body, remaining+1))
+	if n > remaining {
+		return ErrDownloadTooLarge
+	}
+	return err
+}
+
+// rangeValidator 返回可用于 If-Range 的校验值（弱 ETag 不可用于 If-Range）
+func rangeValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// parseContentRange 解析 "bytes start-end/total"，total 为 * 时返回 -1
+func parseContentRange(v string) (start, total int64, err error) {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "bytes ") {
+		return 0, 0, fmt.Errorf("invalid content range")
+	}
+	rangePart, totalPart, ok := strings.Cut(strings.TrimPrefix(v, "bytes "), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid content range")
+	}
+	startPart, _, ok := strings.Cut(rangePart, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid content range")
+	}
+	start, err = strconv.ParseInt(strings.TrimSpace(startPart), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if strings.TrimSpace(totalPart) == "*" {
+		return start, -1, nil
+	}
+	total, err = strconv.ParseInt(strings.TrimSpace(totalPart), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, total, nil
+}
@@ -0,0 +1,94 @@
+package httputil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var rangePayload = []byte(strings.Repeat("0123456789abcdef", 64))
+
+func serveRangePayload(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", `"v1"`)
+	http.ServeContent(w, r, "pricing.json", time.Unix(0, 0), bytes.NewReader(rangePayload))
+}
+
+func TestDownloadWithResume_Chunked(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		serveRangePayload(w, r)
+	}))
+	defer srv.Close()
+
+	body, err := DownloadWithResume(context.Background(), srv.Client(), srv.URL, RangeDownloadOptions{ChunkBytes: 300})
+	require.NoError(t, err)
+	require.Equal(t, rangePayload, body)
+	require.Equal(t, int32(4), requests.Load())
+}
+
+func TestDownloadWithResume_ResumesInterruptedBody(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(rangePayload)))
+			_, _ = w.Write(rangePayload[:100])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		require.Equal(t, "bytes=100-", r.Header.Get("Range"))
+		require.Equal(t, `"v1"`, r.Header.Get("If-Range"))
+		serveRangePayload(w, r)
+	}))
+	defer srv.Close()
+
+	body, err := DownloadWithResume(context.Background(), srv.Client(), srv.URL, RangeDownloadOptions{MaxResumes: 2})
+	require.NoError(t, err)
+	require.Equal(t, rangePayload, body)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestDownloadWithResume_IncompleteWithoutRangeSupport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(rangePayload)))
+		_, _ = w.Write(rangePayload[:100])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer srv.Close()
+
+	_, err := DownloadWithResume(context.Background(), srv.Client(), srv.URL, RangeDownloadOptions{MaxResumes: 3})
+	require.True(t, errors.Is(err, ErrDownloadIncomplete), "err=%v", err)
+}
+
+func TestDownloadWithResume_LimitsAndStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		serveRangePayload(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := DownloadWithResume(context.Background(), srv.Client(), srv.URL, RangeDownloadOptions{MaxBytes: 100})
+	require.ErrorIs(t, err, ErrDownloadTooLarge)
+	_, err = DownloadWithResume(context.Background(), srv.Client(), srv.URL, RangeDownloadOptions{ChunkBytes: 64, MaxBytes: 100})
+	require.ErrorIs(t, err, ErrDownloadTooLarge)
+
+	_, err = DownloadWithResume(context.Background(), srv.Client(), srv.URL+"/missing", RangeDownloadOptions{})
+	var statusErr *DownloadStatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GatewayLoad 网关负载快照，供外部自动扩缩容参考
+type GatewayLoad struct {
+	InFlight           int64
+	QueueDepth         int64
+	Capacity           int64
+	InUse              int64
+	UtilizationPercent float64
+	Models             []ModelLoad
+}
+
+// ModelLoad 单个模型的负载
+type ModelLoad struct {
+	Model             string
+	InFlight          int64
+	Capacity          int64
+	InUse             int64
+	SaturationPercent float64
+}
+
+// GatewayLoadProvider 抓取时返回当前负载快照
+type GatewayLoadProvider func() GatewayLoad
+
+var gatewayLoadProvider atomic.Pointer[GatewayLoadProvider]
+
+// SetGatewayLoadProvider 设置负载快照来源；未设置时不导出负载指标
+func SetGatewayLoadProvider(provider GatewayLoadProvider) {
+	if provider == nil {
+		gatewayLoadProvider.Store(nil)
+		return
+	}
+	gatewayLoadProvider.Store(&provider)
+}
+
+// gatewayLoadCollector 在抓取时读取负载快照并导出为 gauge
+type gatewayLoadCollector struct {
+	inFlight        *prometheus.Desc
+	queueDepth      *prometheus.Desc
+	capacity        *prometheus.Desc
+	inUse           *prometheus.Desc
+	utilization     *prometheus.Desc
+	modelInFlight   *prometheus.Desc
+	modelCapacity   *prometheus.Desc
+	modelSaturation *prometheus.Desc
+}
+
+func newGatewayLoadCollector() *gatewayLoadCollector {
+	name := func(n string) string { return prometheus.BuildFQName(namespace, "gateway", n) }
+	return &gatewayLoadCollector{
+		inFlight:        prometheus.NewDesc(name("in_flight_requests"), "Gateway requests currently in flight on this instance.", nil, nil),
+		queueDepth:      prometheus.NewDesc(name("queue_depth"), "Gateway requests on this instance waiting for a priority queue or concurrency slot.", nil, nil),
+		capacity:        prometheus.NewDesc(name("account_capacity"), "Total concurrency of schedulable accounts.", nil, nil),
+		inUse:           prometheus.NewDesc(name("account_slots_in_use"), "Account concurrency slots currently held across all instances.", nil, nil),
+		utilization:     prometheus.NewDesc(name("utilization_percent"), "Account slots in use relative to total account concurrency capacity.", nil, nil),
+		modelInFlight:   prometheus.NewDesc(name("model_in_flight_requests"), "Gateway requests currently in flight on this instance by model.", []string{"model"}, nil),
+		modelCapacity:   prometheus.NewDesc(name("model_account_capacity"), "Total concurrency of schedulable accounts that can serve the model.", []string{"model"}, nil),
+		modelSaturation: prometheus.NewDesc(name("model_saturation_percent"), "Slots in use relative to capacity of the accounts that can serve the model.", []string{"model"}, nil),
+	}
+}
+
+func (c *gatewayLoadCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.inFlight
+	ch <- c.queueDepth
+	ch <- c.capacity
+	ch <- c.inUse
+	ch <- c.utilization
+	ch <- c.modelInFlight
+	ch <- c.modelCapacity
+	ch <- c.modelSaturation
+}
+
+func (c *gatewayLoadCollector) Collect(ch chan<- prometheus.Metric) {
+	provider := gatewayLoadProvider.Load()
+	if provider == nil {
+		return
+	}
+	load := (*provider)()
+	ch <- prometheus.MustNewConstMetric(c.inFlight, prometheus.GaugeValue, float64(load.InFlight))
+	ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(load.QueueDepth))
+	ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(load.Capacity))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(load.InUse))
+	ch <- prometheus.MustNewConstMetric(c.utilization, prometheus.GaugeValue, load.UtilizationPercent)
+	for _, m := range load.Models {
+		ch <- prometheus.MustNewConstMetric(c.modelInFlight, prometheus.GaugeValue, float64(m.InFlight), m.Model)
+		ch <- prometheus.MustNewConstMetric(c.modelCapacity, prometheus.GaugeValue, float64(m.Capacity), m.Model)
+		ch <- prometheus.MustNewConstMetric(c.modelSaturation, prometheus.GaugeValue, m.SaturationPercent, m.Model)
+	}
+}
@@ -0,0 +1,247 @@
+// Package metrics 提供网关的 Prometheus 指标（请求数、上游延迟、token 用量与分布、费用）。
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sub2api"
+
+// 标签取值上限：超出的模型统一归入 OtherLabel，避免标签基数爆炸
+const (
+	OtherLabel   = "other"
+	UnknownLabel = "unknown"
+
+	maxModelLabels = 256
+)
+
+// Token 类型标签
+const (
+	TokenTypeInput         = "input"
+	TokenTypeOutput        = "output"
+	TokenTypeCacheCreation = "cache_creation"
+	TokenTypeCacheRead     = "cache_read"
+)
+
+var knownProviders = map[string]struct{}{
+	"anthropic":   {},
+	"openai":      {},
+	"gemini":      {},
+	"antigravity": {},
+}
+
+var (
+	registry = prometheus.NewRegistry()
+
+	requestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Total number of gateway requests.",
+	})
+	requestsByLabel = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_by_model_total",
+		Help:      "Gateway requests by model, provider and HTTP status.",
+	}, []string{"model", "provider", "status"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "End-to-end gateway request duration, including streaming.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"provider"})
+	upstreamLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_latency_seconds",
+		Help:      "Upstream request duration of billed requests.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"model", "provider"})
+	upstreamFirstToken = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_first_token_seconds",
+		Help:      "Time to first token of billed streaming requests.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"model", "provider"})
+	tokensTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tokens_total",
+		Help:      "Billed tokens by model, provider and token type.",
+	}, []string{"model", "provider", "type"})
+	costTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cost_usd_total",
+		Help:      "Accumulated actual cost in USD (after rate multipliers).",
+	}, []string{"model", "provider"})
+	priorityQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "priority_queue_depth",
+		Help:      "Gateway requests waiting in the priority queue by API key tier.",
+	}, []string{"tier"})
+	priorityQueueActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "priority_queue_active",
+		Help:      "Gateway requests holding a priority queue slot.",
+	})
+	priorityQueueRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "priority_queue_rejected_total",
+		Help:      "Gateway requests rejected by the priority queue (full or wait timeout) by tier.",
+	}, []string{"tier"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestsByLabel,
+		requestDuration,
+		upstreamLatency,
+		upstreamFirstToken,
+		tokensTotal,
+		costTotal,
+		priorityQueueDepth,
+		priorityQueueActive,
+		priorityQueueRejected,
+		requestTokens,
+		newGatewayLoadCollector(),
+	)
+}
+
+// Handler 返回 /metrics 的 HTTP 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ModelFilter 判断模型名是否为已知模型（通常由定价数据提供）
+type ModelFilter func(model string) bool
+
+var (
+	modelFilter atomic.Pointer[ModelFilter]
+
+	modelLabelsMu sync.RWMutex
+	modelLabels   = make(map[string]struct{}, maxModelLabels)
+)
+
+// SetModelFilter 设置已知模型判定函数；未设置时只按数量上限限制模型标签
+func SetModelFilter(filter ModelFilter) {
+	if filter == nil {
+		modelFilter.Store(nil)
+		return
+	}
+	modelFilter.Store(&filter)
+}
+
+// ModelLabel 规范化模型标签：未知模型或超出数量上限的模型归入 OtherLabel
+func ModelLabel(model string) string {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return UnknownLabel
+	}
+
+	modelLabelsMu.RLock()
+	_, seen := modelLabels[model]
+	modelLabelsMu.RUnlock()
+	if seen {
+		return model
+	}
+
+	if filter := modelFilter.Load(); filter != nil && !(*filter)(model) {
+		return OtherLabel
+	}
+
+	modelLabelsMu.Lock()
+	defer modelLabelsMu.Unlock()
+	if _, ok := modelLabels[model]; ok {
+		return model
+	}
+	if len(modelLabels) >= maxModelLabels {
+		return OtherLabel
+	}
+	modelLabels[model] = struct{}{}
+	return model
+}
+
+// ProviderLabel 规范化上游平台标签
+func ProviderLabel(provider string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return UnknownLabel
+	}
+	if _, ok := knownProviders[provider]; !ok {
+		return OtherLabel
+	}
+	return provider
+}
+
+// ObserveRequest 记录一次网关请求
+func ObserveRequest(model, provider string, status int, duration time.Duration) {
+	providerLabel := ProviderLabel(provider)
+	requestsTotal.Inc()
+	requestsByLabel.WithLabelValues(ModelLabel(model), providerLabel, strconv.Itoa(status)).Inc()
+	requestDuration.WithLabelValues(providerLabel).Observe(duration.Seconds())
+}
+
+// Usage 一次计费请求的用量
+type Usage struct {
+	Model               string
+	Provider            string
+	InputTokens         int
+	OutputTokens        int
+	CacheCreationTokens int
+	CacheReadTokens     int
+	ActualCost          float64
+	DurationMs          *int
+	FirstTokenMs        *int
+}
+
+// ObserveUsage 记录一次计费请求的 token 用量、费用与上游延迟
+func ObserveUsage(u Usage) {
+	model := ModelLabel(u.Model)
+	provider := ProviderLabel(u.Provider)
+
+	addTokens(model, provider, TokenTypeInput, u.InputTokens)
+	addTokens(model, provider, TokenTypeOutput, u.OutputTokens)
+	addTokens(model, provider, TokenTypeCacheCreation, u.CacheCreationTokens)
+	addTokens(model, provider, TokenTypeCacheRead, u.CacheReadTokens)
+	requestTokens.observe(model, TokenTypeInput, u.InputTokens)
+	requestTokens.observe(model, TokenTypeOutput, u.OutputTokens)
+	if u.ActualCost > 0 {
+		costTotal.WithLabelValues(model, provider).Add(u.ActualCost)
+	}
+	if u.DurationMs != nil && *u.DurationMs >= 0 {
+		upstreamLatency.WithLabelValues(model, provider).Observe(float64(*u.DurationMs) / 1000)
+	}
+	if u.FirstTokenMs != nil && *u.FirstTokenMs >= 0 {
+		upstreamFirstToken.WithLabelValues(model, provider).Observe(float64(*u.FirstTokenMs) / 1000)
+	}
+}
+
+func addTokens(model, provider, tokenType string, n int) {
+	if n > 0 {
+		tokensTotal.WithLabelValues(model, provider, tokenType).Add(float64(n))
+	}
+}
+
+// SetPriorityQueueDepth 记录某个优先级层级当前排队的请求数
+func SetPriorityQueueDepth(tier, depth int) {
+	priorityQueueDepth.WithLabelValues(strconv.Itoa(tier)).Set(float64(depth))
+}
+
+// SetPriorityQueueActive 记录当前占用排队槽位的请求数
+func SetPriorityQueueActive(active int) {
+	priorityQueueActive.Set(float64(active))
+}
+
+// ObservePriorityQueueRejected 记录一次被优先级队列拒绝（队列已满或等待超时）的请求
+func ObservePriorityQueueRejected(tier int) {
+	priorityQueueRejected.WithLabelValues(strconv.Itoa(tier)).Inc()
+}
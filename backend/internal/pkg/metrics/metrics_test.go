@@ -0,0 +1,135 @@
+//go:build unit
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func resetModelLabels(t *testing.T) {
+	t.Helper()
+	modelLabelsMu.Lock()
+	modelLabels = make(map[string]struct{}, maxModelLabels)
+	modelLabelsMu.Unlock()
+	SetModelFilter(nil)
+	t.Cleanup(func() { SetModelFilter(nil) })
+}
+
+func TestModelLabel_FilterAndCap(t *testing.T) {
+	resetModelLabels(t)
+
+	require.Equal(t, UnknownLabel, ModelLabel("  "))
+	require.Equal(t, "claude-sonnet-4-5", ModelLabel(" Claude-Sonnet-4-5 "))
+
+	SetModelFilter(func(model string) bool { return strings.HasPrefix(model, "gpt-") })
+	require.Equal(t, "gpt-5", ModelLabel("gpt-5"))
+	require.Equal(t, OtherLabel, ModelLabel("random-model"))
+	// 已记录的标签不受过滤器变化影响
+	require.Equal(t, "claude-sonnet-4-5", ModelLabel("claude-sonnet-4-5"))
+
+	SetModelFilter(nil)
+	for i := len(modelLabels); i < maxModelLabels; i++ {
+		ModelLabel(fmt.Sprintf("model-%d", i))
+	}
+	require.Equal(t, OtherLabel, ModelLabel("one-too-many"))
+	require.Equal(t, "gpt-5", ModelLabel("gpt-5"))
+}
+
+func TestProviderLabel(t *testing.T) {
+	require.Equal(t, "anthropic", ProviderLabel("Anthropic"))
+	require.Equal(t, UnknownLabel, ProviderLabel(""))
+	require.Equal(t, OtherLabel, ProviderLabel("unknown-vendor"))
+}
+
+func TestObserveUsage_TokensCostAndLatency(t *testing.T) {
+	resetModelLabels(t)
+	duration, firstToken := 1500, 300
+
+	before := testutil.ToFloat64(tokensTotal.WithLabelValues("gpt-5.1", "openai", TokenTypeOutput))
+	ObserveUsage(Usage{
+		Model:        "gpt-5.1",
+		Provider:     "openai",
+		InputTokens:  100,
+		OutputTokens: 40,
+		ActualCost:   0.25,
+		DurationMs:   &duration,
+		FirstTokenMs: &firstToken,
+	})
+
+	require.InDelta(t, before+40, testutil.ToFloat64(tokensTotal.WithLabelValues("gpt-5.1", "openai", TokenTypeOutput)), 1e-9)
+	require.InDelta(t, 100, testutil.ToFloat64(tokensTotal.WithLabelValues("gpt-5.1", "openai", TokenTypeInput)), 1e-9)
+	require.InDelta(t, 0.25, testutil.ToFloat64(costTotal.WithLabelValues("gpt-5.1", "openai")), 1e-9)
+	require.Equal(t, 1, testutil.CollectAndCount(upstreamLatency, namespace+"_upstream_latency_seconds"))
+}
+
+func TestHandler_ExposesRequestMetrics(t *testing.T) {
+	resetModelLabels(t)
+	ObserveRequest("claude-opus-4", "anthropic", 200, 2*time.Second)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `sub2api_requests_by_model_total{model="claude-opus-4",provider="anthropic",status="200"} 1`)
+	require.Contains(t, body, "sub2api_requests_total")
+	require.Contains(t, body, "sub2api_request_duration_seconds_bucket")
+}
+
+func TestTokenHistograms_FixedBucketsAndExport(t *testing.T) {
+	resetModelLabels(t)
+	SetTokenBuckets([]float64{100, 1000})
+	t.Cleanup(func() { SetTokenBuckets(nil) })
+
+	ObserveUsage(Usage{Model: "claude-haiku-4", Provider: "anthropic", InputTokens: 100, OutputTokens: 5000})
+	ObserveUsage(Usage{Model: "claude-haiku-4", Provider: "anthropic", InputTokens: 500, OutputTokens: 20})
+
+	histograms := TokenHistograms()
+	require.Len(t, histograms, 2)
+	input := histograms[0]
+	require.Equal(t, TokenTypeInput, input.Type)
+	require.Equal(t, uint64(2), input.Count)
+	require.InDelta(t, 600, input.Sum, 1e-9)
+	require.Equal(t, []TokenBucketCount{{Le: "100", Count: 1}, {Le: "1000", Count: 1}, {Le: "+Inf", Count: 0}}, input.Buckets)
+	require.Equal(t, []TokenBucketCount{{Le: "100", Count: 1}, {Le: "1000", Count: 0}, {Le: "+Inf", Count: 1}}, histograms[1].Buckets)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Contains(t, rec.Body.String(), `sub2api_request_tokens_bucket{model="claude-haiku-4",type="output",le="1000"} 1`)
+	require.Contains(t, rec.Body.String(), `sub2api_request_tokens_count{model="claude-haiku-4",type="input"} 2`)
+}
+
+func TestGatewayLoadCollector_ExportsProviderSnapshot(t *testing.T) {
+	SetGatewayLoadProvider(func() GatewayLoad {
+		return GatewayLoad{
+			InFlight:           7,
+			QueueDepth:         2,
+			Capacity:           40,
+			InUse:              30,
+			UtilizationPercent: 75,
+			Models:             []ModelLoad{{Model: "gpt-4o", InFlight: 5, Capacity: 20, InUse: 18, SaturationPercent: 90}},
+		}
+	})
+	t.Cleanup(func() { SetGatewayLoadProvider(nil) })
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	require.Contains(t, body, "sub2api_gateway_in_flight_requests 7")
+	require.Contains(t, body, "sub2api_gateway_queue_depth 2")
+	require.Contains(t, body, "sub2api_gateway_account_capacity 40")
+	require.Contains(t, body, "sub2api_gateway_utilization_percent 75")
+	require.Contains(t, body, `sub2api_gateway_model_saturation_percent{model="gpt-4o"} 90`)
+
+	SetGatewayLoadProvider(nil)
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.NotContains(t, rec.Body.String(), "sub2api_gateway_in_flight_requests")
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultTokenBuckets 单次请求 token 数直方图的默认桶上界
+var DefaultTokenBuckets = []float64{256, 1024, 4096, 16384, 32768, 65536, 131072, 262144}
+
+// TokenBucketCount 直方图的一个桶：Le 为上界（最后一个桶为 "+Inf"），Count 为落入该桶（不累计）的请求数
+type TokenBucketCount struct {
+	Le    string `json:"le"`
+	Count uint64 `json:"count"`
+}
+
+// TokenSizeHistogram 某模型单次请求输入或输出 token 数的分布
+type TokenSizeHistogram struct {
+	Model   string             `json:"model"`
+	Type    string             `json:"type"` // input / output
+	Count   uint64             `json:"count"`
+	Sum     float64            `json:"sum"`
+	Buckets []TokenBucketCount `json:"buckets"`
+}
+
+type tokenHistogramKey struct {
+	model     string
+	tokenType string
+}
+
+type tokenHistogramData struct {
+	counts []uint64 // 与 buckets 对齐，额外一个 +Inf 桶
+	count  uint64
+	sum    float64
+}
+
+// tokenHistograms 固定桶的 token 数直方图：每个模型/类型只保存桶计数，内存与请求量无关。
+// 模型标签经 ModelLabel 限制数量，同时作为 Prometheus 采集器导出。
+type tokenHistograms struct {
+	mu      sync.RWMutex
+	buckets []float64
+	data    map[tokenHistogramKey]*tokenHistogramData
+	desc    *prometheus.Desc
+}
+
+var requestTokens = &tokenHistograms{
+	buckets: DefaultTokenBuckets,
+	data:    make(map[tokenHistogramKey]*tokenHistogramData),
+	desc: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "request_tokens"),
+		"Distribution of input/output tokens per billed request.",
+		[]string{"model", "type"}, nil,
+	),
+}
+
+// SetTokenBuckets 设置 token 数直方图的桶上界（需递增且为正）并清空已有统计；为空时使用默认桶
+func SetTokenBuckets(buckets []float64) {
+	if len(buckets) == 0 {
+		buckets = DefaultTokenBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	requestTokens.mu.Lock()
+	defer requestTokens.mu.Unlock()
+	requestTokens.buckets = buckets
+	requestTokens.data = make(map[tokenHistogramKey]*tokenHistogramData)
+}
+
+func (h *tokenHistograms) observe(model, tokenType string, n int) {
+	if n < 0 {
+		return
+	}
+	v := float64(n)
+	key := tokenHistogramKey{model: model, tokenType: tokenType}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	d := h.data[key]
+	if d == nil {
+		d = &tokenHistogramData{counts: make([]uint64, len(h.buckets)+1)}
+		h.data[key] = d
+	}
+	d.counts[sort.SearchFloat64s(h.buckets, v)]++
+	d.count++
+	d.sum += v
+}
+
+// TokenHistograms 返回各模型单次请求输入/输出 token 数的分布（按模型、类型排序）
+func TokenHistograms() []TokenSizeHistogram {
+	h := requestTokens
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]TokenSizeHistogram, 0, len(h.data))
+	for key, d := range h.data {
+		item := TokenSizeHistogram{
+			Model:   key.model,
+			Type:    key.tokenType,
+			Count:   d.count,
+			Sum:     d.sum,
+			Buckets: make([]TokenBucketCount, 0, len(d.counts)),
+		}
+		for i, n := range d.counts {
+			le := "+Inf"
+			if i < len(h.buckets) {
+				le = strconv.FormatFloat(h.buckets[i], 'f', -1, 64)
+			}
+			item.Buckets = append(item.Buckets, TokenBucketCount{Le: le, Count: n})
+		}
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Model != out[j].Model {
+			return out[i].Model < out[j].Model
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out
+}
+
+// Describe 实现 prometheus.Collector
+func (h *tokenHistograms) Describe(ch chan<- *prometheus.Desc) {
+	ch <- h.desc
+}
+
+// Collect 实现 prometheus.Collector：按 Prometheus 约定输出累计桶计数
+func (h *tokenHistograms) Collect(ch chan<- prometheus.Metric) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for key, d := range h.data {
+		cumulative := make(map[float64]uint64, len(h.buckets))
+		var acc uint64
+		for i, bound := range h.buckets {
+			acc += d.counts[i]
+			cumulative[bound] = acc
+		}
+		ch <- prometheus.MustNewConstHistogram(h.desc, d.count, d.sum, cumulative, key.model, key.tokenType)
+	}
+}
@@ -0,0 +1,105 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode 机器可读的错误码。
+//
+// 错误码写入响应信封的 reason 字段：信封中的 code 字段历来为 HTTP 状态码（成功时为 0），
+// 前端以 code === 0 判断成功，因此不复用 code 承载错误码。客户端应按 reason 分支，message 仅供展示。
+// 管理端接口的错误路径统一通过 ErrorWithCode / ErrorWithCodeAndData 返回错误码；
+// ErrorFrom 转换的业务错误（ApplicationError）同样在 reason 中携带其原因码。
+// 字段说明与错误码列表见 docs/ADMIN_API_ERROR_CODES.md。
+type ErrorCode string
+
+// 通用错误码：未显式指定时按 HTTP 状态码填充
+const (
+	CodeBadRequest         ErrorCode = "BAD_REQUEST"
+	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeNotFound           ErrorCode = "NOT_FOUND"
+	CodeConflict           ErrorCode = "CONFLICT"
+	CodeTooManyRequests    ErrorCode = "TOO_MANY_REQUESTS"
+	CodeInternalError      ErrorCode = "INTERNAL_ERROR"
+	CodeBadGateway         ErrorCode = "BAD_GATEWAY"
+	CodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+)
+
+// 请求参数相关错误码
+const (
+	CodeInvalidRequest   ErrorCode = "INVALID_REQUEST"   // 请求体无法解析或缺少必填字段
+	CodeInvalidParameter ErrorCode = "INVALID_PARAMETER" // 查询/表单参数取值非法
+	CodeMissingParameter ErrorCode = "MISSING_PARAMETER" // 缺少必填的参数或字段
+)
+
+// 价格管理相关错误码
+const (
+	CodePricingNotFound           ErrorCode = "PRICING_NOT_FOUND"
+	CodePricingUpdateFailed       ErrorCode = "PRICING_UPDATE_FAILED"
+	CodePricingFetchFailed        ErrorCode = "PRICING_FETCH_FAILED" // 拉取远程价格数据失败
+	CodePricingChecksumMismatch   ErrorCode = "PRICING_CHECKSUM_MISMATCH"
+	CodePricingDataIncomplete     ErrorCode = "PRICING_DATA_INCOMPLETE" // 价格数据被截断
+	CodeNoFileUploaded            ErrorCode = "NO_FILE_UPLOADED"
+	CodeFileTooLarge              ErrorCode = "FILE_TOO_LARGE"
+	CodeInvalidFileType           ErrorCode = "INVALID_FILE_TYPE"
+	CodeInvalidJSON               ErrorCode = "INVALID_JSON"
+	CodeInvalidPricingData        ErrorCode = "INVALID_PRICING_DATA" // 逐条校验未通过
+	CodeDuplicateModels           ErrorCode = "DUPLICATE_MODELS"
+	CodeInvalidURL                ErrorCode = "INVALID_URL"
+	CodeCurrencyNotConfigured     ErrorCode = "CURRENCY_NOT_CONFIGURED"
+	CodeOverrideNotFound          ErrorCode = "OVERRIDE_NOT_FOUND"
+	CodeAliasNotFound             ErrorCode = "ALIAS_NOT_FOUND"
+	CodeMarkupNotFound            ErrorCode = "MARKUP_NOT_FOUND"
+	CodeTimeoutNotFound           ErrorCode = "TIMEOUT_NOT_FOUND"
+	CodeBatchMultiplierNotFound   ErrorCode = "BATCH_MULTIPLIER_NOT_FOUND"
+	CodeCommittedDiscountNotFound ErrorCode = "COMMITTED_DISCOUNT_NOT_FOUND"
+	CodeMinChargeNotFound         ErrorCode = "MIN_CHARGE_NOT_FOUND"
+)
+
+// defaultErrorCode 按 HTTP 状态码推导通用错误码（无对应时返回空）
+func defaultErrorCode(statusCode int) ErrorCode {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusInternalServerError:
+		return CodeInternalError
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	default:
+		return ""
+	}
+}
+
+// ErrorWithCode 返回带机器可读错误码的错误响应
+func ErrorWithCode(c *gin.Context, statusCode int, code ErrorCode, message string) {
+	c.JSON(statusCode, Response{
+		Code:    statusCode,
+		Message: message,
+		Reason:  string(code),
+	})
+}
+
+// ErrorWithCodeAndData 返回带错误码与结构化数据的错误响应
+func ErrorWithCodeAndData(c *gin.Context, statusCode int, code ErrorCode, message string, data any) {
+	c.JSON(statusCode, Response{
+		Code:    statusCode,
+		Message: message,
+		Reason:  string(code),
+		Data:    data,
+	})
+}
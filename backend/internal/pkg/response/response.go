@@ -56,14 +56,9 @@ func Accepted(c *gin.Context, data any) {
 	})
 }
 
-// Error 返回错误响应
+// Error 返回错误响应（reason 按状态码填充通用错误码）
 func Error(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, Response{
-		Code:     statusCode,
-		Message:  message,
-		Reason:   "",
-		Metadata: nil,
-	})
+	ErrorWithCode(c, statusCode, defaultErrorCode(statusCode), message)
 }
 
 // ErrorWithDetails returns an error response compatible with the existing envelope while
@@ -77,6 +72,11 @@ func ErrorWithDetails(c *gin.Context, statusCode int, message, reason string, me
 	})
 }
 
+// ErrorWithData 返回携带结构化数据的错误响应（如逐条校验错误）
+func ErrorWithData(c *gin.Context, statusCode int, message string, data any) {
+	ErrorWithCodeAndData(c, statusCode, defaultErrorCode(statusCode), message, data)
+}
+
 // ErrorFrom converts an ApplicationError (or any error) into the envelope-compatible error response.
 // It returns true if an error was written.
 func ErrorFrom(c *gin.Context, err error) bool {
@@ -305,16 +305,19 @@ func TestError(t *testing.T) {
 		name       string
 		statusCode int
 		message    string
+		wantReason string
 	}{
 		{
 			name:       "400错误",
 			statusCode: http.StatusBadRequest,
 			message:    "bad request",
+			wantReason: "BAD_REQUEST",
 		},
 		{
 			name:       "500错误",
 			statusCode: http.StatusInternalServerError,
 			message:    "internal error",
+			wantReason: "INTERNAL_ERROR",
 		},
 		{
 			name:       "自定义状态码",
@@ -335,7 +338,7 @@ func TestError(t *testing.T) {
 			got := parseResponseBody(t, w)
 			require.Equal(t, tt.statusCode, got.Code)
 			require.Equal(t, tt.message, got.Message)
-			require.Empty(t, got.Reason)
+			require.Equal(t, tt.wantReason, got.Reason)
 			require.Nil(t, got.Metadata)
 			require.Nil(t, got.Data)
 		})
@@ -354,6 +357,22 @@ func TestBadRequest(t *testing.T) {
 	got := parseResponseBody(t, w)
 	require.Equal(t, http.StatusBadRequest, got.Code)
 	require.Equal(t, "参数无效", got.Message)
+	require.Equal(t, "BAD_REQUEST", got.Reason)
+}
+
+func TestErrorWithCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	ErrorWithCode(c, http.StatusNotFound, CodePricingNotFound, "Model pricing not found")
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	got := parseResponseBody(t, w)
+	require.Equal(t, http.StatusNotFound, got.Code)
+	require.Equal(t, "Model pricing not found", got.Message)
+	require.Equal(t, "PRICING_NOT_FOUND", got.Reason)
 }
 
 func TestUnauthorized(t *testing.T) {
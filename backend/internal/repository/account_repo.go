@@ -25,6 +25,8 @@ import (
 	dbgroup "github.com/Wei-Shaw/sub2api/ent/group"
 	dbpredicate "github.com/Wei-Shaw/sub2api/ent/predicate"
 	dbproxy "github.com/Wei-Shaw/sub2api/ent/proxy"
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -101,6 +103,9 @@ func (r *accountRepository) Create(ctx context.Context, account *service.Account
 	if account.LoadFactor != nil {
 		builder.SetLoadFactor(*account.LoadFactor)
 	}
+	if account.Weight != nil {
+		builder.SetWeight(*account.Weight)
+	}
 
 	if account.ProxyID != nil {
 		builder.SetProxyID(*account.ProxyID)
@@ -340,6 +345,11 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 	} else {
 		builder.ClearLoadFactor()
 	}
+	if account.Weight != nil {
+		builder.SetWeight(*account.Weight)
+	} else {
+		builder.ClearWeight()
+	}
 
 	if account.ProxyID != nil {
 		builder.SetProxyID(*account.ProxyID)
@@ -456,6 +466,32 @@ func (r *accountRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore 清除账号的 deleted_at。删除时已解除的分组绑定不会恢复，需要管理员重新分配。
+func (r *accountRepository) Restore(ctx context.Context, id int64) error {
+	skipCtx := mixins.SkipSoftDelete(ctx)
+	exists, err := r.client.Account.Query().Where(dbaccount.IDEQ(id)).Exist(skipCtx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return service.ErrAccountNotFound
+	}
+	affected, err := r.client.Account.Update().
+		Where(dbaccount.IDEQ(id), dbaccount.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Save(skipCtx)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return nil
+	}
+	if err := enqueueSchedulerOutbox(ctx, r.sql, service.SchedulerOutboxEventAccountChanged, &id, nil, nil); err != nil {
+		logger.LegacyPrintf("repository.account", "[SchedulerOutbox] enqueue account restore failed: account=%d err=%v", id, err)
+	}
+	return nil
+}
+
 func (r *accountRepository) List(ctx context.Context, params pagination.PaginationParams) ([]service.Account, *pagination.PaginationResult, error) {
 	return r.ListWithFilters(ctx, params, "", "", "", "", 0, "")
 }
@@ -570,7 +606,13 @@ func (r *accountRepository) ListWithFilters(ctx context.Context, params paginati
 		}))
 	}
 
-	total, err := q.Count(ctx)
+	// 默认隐藏软删除账号；管理后台 include_deleted 时跳过软删除过滤
+	queryCtx := ctx
+	if includeDeleted, _ := ctx.Value(ctxkey.IncludeDeletedAccounts).(bool); includeDeleted {
+		queryCtx = mixins.SkipSoftDelete(ctx)
+	}
+
+	total, err := q.Count(queryCtx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -582,7 +624,7 @@ func (r *accountRepository) ListWithFilters(ctx context.Context, params paginati
 		accountsQuery = accountsQuery.Order(order)
 	}
 
-	accounts, err := accountsQuery.All(ctx)
+	accounts, err := accountsQuery.All(queryCtx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1540,6 +1582,15 @@ func (r *accountRepository) BulkUpdate(ctx context.Context, ids []int64, updates
 			idx++
 		}
 	}
+	if updates.Weight != nil {
+		if *updates.Weight < 0 {
+			setClauses = append(setClauses, "weight = NULL")
+		} else {
+			setClauses = append(setClauses, "weight = $"+itoa(idx))
+			args = append(args, *updates.Weight)
+			idx++
+		}
+	}
 	if updates.Status != nil {
 		setClauses = append(setClauses, "status = $"+itoa(idx))
 		args = append(args, *updates.Status)
@@ -1863,6 +1914,7 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 		Priority:                m.Priority,
 		RateMultiplier:          &rateMultiplier,
 		LoadFactor:              m.LoadFactor,
+		Weight:                  m.Weight,
 		Status:                  m.Status,
 		ErrorMessage:            derefString(m.ErrorMessage),
 		LastUsedAt:              m.LastUsedAt,
@@ -1870,6 +1922,7 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 		AutoPauseOnExpired:      m.AutoPauseOnExpired,
 		CreatedAt:               m.CreatedAt,
 		UpdatedAt:               m.UpdatedAt,
+		DeletedAt:               m.DeletedAt,
 		Schedulable:             m.Schedulable,
 		RateLimitedAt:           m.RateLimitedAt,
 		RateLimitResetAt:        m.RateLimitResetAt,
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type accountUsageAttributionRepository struct {
+	sql sqlExecutor
+}
+
+// NewAccountUsageAttributionRepository 创建账号日用量汇总仓储。
+func NewAccountUsageAttributionRepository(sqlDB *sql.DB) service.AccountUsageAttributionRepository {
+	return &accountUsageAttributionRepository{sql: sqlDB}
+}
+
+func (r *accountUsageAttributionRepository) ListAccountDailyUsage(ctx context.Context, accountID int64, from, to time.Time) (results []service.AccountDailyUsage, err error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT
+			TO_CHAR(bucket_date, 'YYYY-MM-DD') AS date,
+			request_count,
+			input_tokens,
+			output_tokens,
+			cache_creation_tokens,
+			cache_read_tokens,
+			total_cost,
+			actual_cost
+		FROM account_usage_daily
+		WHERE account_id = $1 AND bucket_date >= $2::date AND bucket_date <= $3::date
+		ORDER BY bucket_date ASC
+	`, accountID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+			results = nil
+		}
+	}()
+
+	results = make([]service.AccountDailyUsage, 0)
+	for rows.Next() {
+		var row service.AccountDailyUsage
+		if err = rows.Scan(&row.Date, &row.Requests, &row.InputTokens, &row.OutputTokens, &row.CacheCreationTokens, &row.CacheReadTokens, &row.TotalCost, &row.ActualCost); err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -41,6 +41,7 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 	builder := r.client.APIKey.Create().
 		SetUserID(key.UserID).
 		SetKey(key.Key).
+		SetKeyHash(key.KeyHash).
 		SetName(key.Name).
 		SetStatus(key.Status).
 		SetNillableGroupID(key.GroupID).
@@ -50,7 +51,21 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 		SetNillableExpiresAt(key.ExpiresAt).
 		SetRateLimit5h(key.RateLimit5h).
 		SetRateLimit1d(key.RateLimit1d).
-		SetRateLimit7d(key.RateLimit7d)
+		SetRateLimit7d(key.RateLimit7d).
+		SetRpmLimit(key.RPMLimit).
+		SetTpmLimit(key.TPMLimit).
+		SetAuditCaptureBody(key.AuditCaptureBody).
+		SetUsageHeaders(key.UsageHeaders).
+		SetIsSandbox(key.IsSandbox).
+		SetPriorityTier(key.PriorityTier).
+		SetMaskResponseModel(key.MaskResponseModel).
+		SetMaxRequestBytes(key.MaxRequestBytes).
+		SetMaxResponseBytes(key.MaxResponseBytes).
+		SetUsageQuotaRequests(key.UsageQuotaRequests).
+		SetUsageQuotaTokens(key.UsageQuotaTokens)
+	if key.UsageQuotaPeriod != "" {
+		builder.SetUsageQuotaPeriod(key.UsageQuotaPeriod)
+	}
 
 	if len(key.IPWhitelist) > 0 {
 		builder.SetIPWhitelist(key.IPWhitelist)
@@ -58,6 +73,12 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 	if len(key.IPBlacklist) > 0 {
 		builder.SetIPBlacklist(key.IPBlacklist)
 	}
+	if len(key.AllowedModels) > 0 {
+		builder.SetAllowedModels(key.AllowedModels)
+	}
+	if len(key.DeniedModels) > 0 {
+		builder.SetDeniedModels(key.DeniedModels)
+	}
 
 	created, err := builder.Save(ctx)
 	if err == nil {
@@ -135,6 +156,21 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 			apikey.FieldRateLimit5h,
 			apikey.FieldRateLimit1d,
 			apikey.FieldRateLimit7d,
+			apikey.FieldRpmLimit,
+			apikey.FieldTpmLimit,
+			apikey.FieldAuditCaptureBody,
+			apikey.FieldAllowedModels,
+			apikey.FieldDeniedModels,
+			apikey.FieldUsageHeaders,
+			apikey.FieldIsSandbox,
+			apikey.FieldPriorityTier,
+			apikey.FieldMaskResponseModel,
+			apikey.FieldMaxRequestBytes,
+			apikey.FieldMaxResponseBytes,
+			apikey.FieldUsageQuotaRequests,
+			apikey.FieldUsageQuotaTokens,
+			apikey.FieldUsageQuotaPeriod,
+			apikey.FieldKeyHash,
 		).
 		WithUser(func(q *dbent.UserQuery) {
 			q.Select(
@@ -154,6 +190,8 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				user.FieldLastLoginAt,
 				user.FieldLastActiveAt,
 				user.FieldRpmLimit,
+				user.FieldBudgetLimit,
+				user.FieldBudgetSoftLimit,
 			)
 		}).
 		WithGroup(func(q *dbent.GroupQuery) {
@@ -184,6 +222,7 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				group.FieldDefaultMappedModel,
 				group.FieldMessagesDispatchModelConfig,
 				group.FieldRpmLimit,
+				group.FieldSchedulingStrategy,
 			)
 		}).
 		Only(ctx)
@@ -216,7 +255,23 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 		SetUsage5h(key.Usage5h).
 		SetUsage1d(key.Usage1d).
 		SetUsage7d(key.Usage7d).
+		SetRpmLimit(key.RPMLimit).
+		SetTpmLimit(key.TPMLimit).
+		SetAuditCaptureBody(key.AuditCaptureBody).
+		SetUsageHeaders(key.UsageHeaders).
+		SetIsSandbox(key.IsSandbox).
+		SetPriorityTier(key.PriorityTier).
+		SetMaskResponseModel(key.MaskResponseModel).
+		SetMaxRequestBytes(key.MaxRequestBytes).
+		SetMaxResponseBytes(key.MaxResponseBytes).
+		SetUsageQuotaRequests(key.UsageQuotaRequests).
+		SetUsageQuotaTokens(key.UsageQuotaTokens).
+		SetUsageQuotaRequestsUsed(key.UsageQuotaRequestsUsed).
+		SetUsageQuotaTokensUsed(key.UsageQuotaTokensUsed).
 		SetUpdatedAt(now)
+	if key.UsageQuotaPeriod != "" {
+		builder.SetUsageQuotaPeriod(key.UsageQuotaPeriod)
+	}
 	if key.GroupID != nil {
 		builder.SetGroupID(*key.GroupID)
 	} else {
@@ -246,6 +301,11 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 	} else {
 		builder.ClearWindow7dStart()
 	}
+	if key.UsageQuotaWindowStart != nil {
+		builder.SetUsageQuotaWindowStart(*key.UsageQuotaWindowStart)
+	} else {
+		builder.ClearUsageQuotaWindowStart()
+	}
 
 	// IP 限制字段
 	if len(key.IPWhitelist) > 0 {
@@ -259,6 +319,18 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 		builder.ClearIPBlacklist()
 	}
 
+	// 模型访问控制字段
+	if len(key.AllowedModels) > 0 {
+		builder.SetAllowedModels(key.AllowedModels)
+	} else {
+		builder.ClearAllowedModels()
+	}
+	if len(key.DeniedModels) > 0 {
+		builder.SetDeniedModels(key.DeniedModels)
+	} else {
+		builder.ClearDeniedModels()
+	}
+
 	affected, err := builder.Save(ctx)
 	if err != nil {
 		return err
@@ -273,6 +345,24 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 	return nil
 }
 
+// UpdateKeyHash 将明文存储的 Key 改为哈希存储。仅在 key_hash 仍为空时更新，
+// 并发的多个请求同时重新哈希时只有一个生效。
+func (r *apiKeyRepository) UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error {
+	client := clientFromContext(ctx, r.client)
+	affected, err := client.APIKey.Update().
+		Where(apikey.IDEQ(id), apikey.DeletedAtIsNil(), apikey.KeyHashEQ("")).
+		SetKey(key).
+		SetKeyHash(keyHash).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
 func (r *apiKeyRepository) Delete(ctx context.Context, id int64) error {
 	// 存在唯一键约束 生成tombstone key 用来释放原key，长度远小于 128，满足 schema 限制
 	tombstoneKey := fmt.Sprintf("__deleted__%d__%d", id, time.Now().UnixNano())
@@ -613,6 +703,49 @@ func (r *apiKeyRepository) GetRateLimitData(ctx context.Context, id int64) (resu
 	return data, rows.Err()
 }
 
+// GetUsageQuotaState 返回 API Key 周期额度的持久化用量
+func (r *apiKeyRepository) GetUsageQuotaState(ctx context.Context, id int64) (*service.APIKeyUsageQuotaState, error) {
+	var (
+		state       service.APIKeyUsageQuotaState
+		windowStart sql.NullTime
+	)
+	query := `
+		SELECT usage_quota_window_start, usage_quota_requests_used, usage_quota_tokens_used
+		FROM api_keys
+		WHERE id = $1 AND deleted_at IS NULL`
+	if err := scanSingleRow(ctx, r.sql, query, []any{id}, &windowStart, &state.RequestsUsed, &state.TokensUsed); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, service.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	if windowStart.Valid {
+		t := windowStart.Time
+		state.WindowStart = &t
+	}
+	return &state, nil
+}
+
+// IncrementUsageQuota 原子累加周期额度用量：存储中的周期早于 windowStart 时先清零；
+// 晚于 windowStart（跨周期的迟到写入）时丢弃本次增量。
+func (r *apiKeyRepository) IncrementUsageQuota(ctx context.Context, id int64, windowStart time.Time, requests, tokens int64) error {
+	_, err := r.sql.ExecContext(ctx, `
+		UPDATE api_keys SET
+			usage_quota_requests_used = CASE
+				WHEN usage_quota_window_start IS NULL OR usage_quota_window_start < $1 THEN $2
+				WHEN usage_quota_window_start > $1 THEN usage_quota_requests_used
+				ELSE usage_quota_requests_used + $2 END,
+			usage_quota_tokens_used = CASE
+				WHEN usage_quota_window_start IS NULL OR usage_quota_window_start < $1 THEN $3
+				WHEN usage_quota_window_start > $1 THEN usage_quota_tokens_used
+				ELSE usage_quota_tokens_used + $3 END,
+			usage_quota_window_start = GREATEST(COALESCE(usage_quota_window_start, $1), $1),
+			updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL`,
+		windowStart, requests, tokens, id)
+	return err
+}
+
 func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 	if m == nil {
 		return nil
@@ -621,6 +754,7 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 		ID:            m.ID,
 		UserID:        m.UserID,
 		Key:           m.Key,
+		KeyHash:       m.KeyHash,
 		Name:          m.Name,
 		Status:        m.Status,
 		IPWhitelist:   m.IPWhitelist,
@@ -641,6 +775,26 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 		Window5hStart: m.Window5hStart,
 		Window1dStart: m.Window1dStart,
 		Window7dStart: m.Window7dStart,
+		RPMLimit:      m.RpmLimit,
+		TPMLimit:      m.TpmLimit,
+
+		AuditCaptureBody: m.AuditCaptureBody,
+		AllowedModels:    m.AllowedModels,
+		DeniedModels:     m.DeniedModels,
+		UsageHeaders:     m.UsageHeaders,
+		IsSandbox:        m.IsSandbox,
+		PriorityTier:     m.PriorityTier,
+		MaxRequestBytes:  m.MaxRequestBytes,
+		MaxResponseBytes: m.MaxResponseBytes,
+
+		MaskResponseModel: m.MaskResponseModel,
+
+		UsageQuotaRequests:     m.UsageQuotaRequests,
+		UsageQuotaTokens:       m.UsageQuotaTokens,
+		UsageQuotaPeriod:       m.UsageQuotaPeriod,
+		UsageQuotaRequestsUsed: m.UsageQuotaRequestsUsed,
+		UsageQuotaTokensUsed:   m.UsageQuotaTokensUsed,
+		UsageQuotaWindowStart:  m.UsageQuotaWindowStart,
 	}
 	if m.Edges.User != nil {
 		out.User = userEntityToService(m.Edges.User)
@@ -676,6 +830,8 @@ func userEntityToService(u *dbent.User) *service.User {
 		BalanceNotifyThreshold:     u.BalanceNotifyThreshold,
 		TotalRecharged:             u.TotalRecharged,
 		RPMLimit:                   u.RpmLimit,
+		BudgetLimit:                u.BudgetLimit,
+		BudgetSoftLimit:            u.BudgetSoftLimit,
 		CreatedAt:                  u.CreatedAt,
 		UpdatedAt:                  u.UpdatedAt,
 	}
@@ -725,6 +881,7 @@ func groupEntityToService(g *dbent.Group) *service.Group {
 		OpenAIForceCodex:                g.OpenaiForceCodex,
 		MessagesDispatchModelConfig:     g.MessagesDispatchModelConfig,
 		RPMLimit:                        g.RpmLimit,
+		SchedulingStrategy:              g.SchedulingStrategy,
 		CreatedAt:                       g.CreatedAt,
 		UpdatedAt:                       g.UpdatedAt,
 	}
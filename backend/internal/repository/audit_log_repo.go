@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+type auditLogRepository struct {
+	db *sql.DB
+}
+
+// NewAuditLogRepository 创建审计日志仓储。
+func NewAuditLogRepository(db *sql.DB) service.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) WriteAuditLogs(ctx context.Context, logs []*service.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(
+		"audit_logs",
+		"created_at",
+		"request_id",
+		"trace_id",
+		"user_id",
+		"api_key_id",
+		"account_id",
+		"model",
+		"platform",
+		"method",
+		"path",
+		"status_code",
+		"duration_ms",
+		"input_tokens",
+		"output_tokens",
+		"cache_creation_tokens",
+		"cache_read_tokens",
+		"total_cost",
+		"actual_cost",
+		"client_ip",
+		"request_body",
+		"response_body",
+		"body_truncated",
+		"request_body_redacted",
+		"replay_of_id",
+	))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, log := range logs {
+		if log == nil {
+			continue
+		}
+		createdAt := log.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now().UTC()
+		}
+		if _, err := stmt.ExecContext(
+			ctx,
+			createdAt.UTC(),
+			opsNullString(truncateAuditField(log.RequestID, 128)),
+			opsNullString(truncateAuditField(log.TraceID, 128)),
+			opsNullInt64(&log.UserID),
+			opsNullInt64(&log.APIKeyID),
+			opsNullInt64(&log.AccountID),
+			opsNullString(truncateAuditField(log.Model, 128)),
+			opsNullString(truncateAuditField(log.Platform, 32)),
+			truncateAuditField(log.Method, 16),
+			truncateAuditField(log.Path, 256),
+			log.StatusCode,
+			log.DurationMs,
+			log.InputTokens,
+			log.OutputTokens,
+			log.CacheCreationTokens,
+			log.CacheReadTokens,
+			log.TotalCost,
+			log.ActualCost,
+			opsNullString(truncateAuditField(log.ClientIP, 64)),
+			auditNullBody(log.RequestBody),
+			auditNullBody(log.ResponseBody),
+			log.BodyTruncated,
+			log.RequestBodyRedacted,
+			opsNullInt64(&log.ReplayOfID),
+		); err != nil {
+			_ = stmt.Close()
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		_ = stmt.Close()
+		_ = tx.Rollback()
+		return err
+	}
+	if err := stmt.Close(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *auditLogRepository) ListAuditLogs(ctx context.Context, filter *service.AuditLogFilter) (*service.AuditLogList, error) {
+	if filter == nil {
+		filter = &service.AuditLogFilter{}
+	}
+	page := filter.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 200 {
+		pageSize = 200
+	}
+
+	where, args := buildAuditLogsWhere(filter)
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_logs a "+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * pageSize
+	argsWithLimit := append(args, pageSize, offset)
+	query := "SELECT " + auditLogColumns + " FROM audit_logs a " + where + `
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, argsWithLimit...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	logs := make([]*service.AuditLog, 0, pageSize)
+	for rows.Next() {
+		item, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &service.AuditLogList{
+		Logs:     logs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (r *auditLogRepository) GetAuditLog(ctx context.Context, id int64) (*service.AuditLog, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+auditLogColumns+" FROM audit_logs a WHERE a.id = $1", id)
+	log, err := scanAuditLog(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, service.ErrAuditLogNotFound
+	}
+	return log, err
+}
+
+const auditLogColumns = `
+  a.id,
+  a.created_at,
+  COALESCE(a.request_id, ''),
+  COALESCE(a.trace_id, ''),
+  COALESCE(a.user_id, 0),
+  COALESCE(a.api_key_id, 0),
+  COALESCE(a.account_id, 0),
+  COALESCE(a.model, ''),
+  COALESCE(a.platform, ''),
+  a.method,
+  a.path,
+  a.status_code,
+  a.duration_ms,
+  a.input_tokens,
+  a.output_tokens,
+  a.cache_creation_tokens,
+  a.cache_read_tokens,
+  a.total_cost,
+  a.actual_cost,
+  COALESCE(a.client_ip, ''),
+  a.request_body,
+  a.response_body,
+  a.body_truncated,
+  a.request_body_redacted,
+  COALESCE(a.replay_of_id, 0)
+`
+
+func scanAuditLog(row scannable) (*service.AuditLog, error) {
+	item := &service.AuditLog{}
+	var requestBody, responseBody sql.NullString
+	if err := row.Scan(
+		&item.ID,
+		&item.CreatedAt,
+		&item.RequestID,
+		&item.TraceID,
+		&item.UserID,
+		&item.APIKeyID,
+		&item.AccountID,
+		&item.Model,
+		&item.Platform,
+		&item.Method,
+		&item.Path,
+		&item.StatusCode,
+		&item.DurationMs,
+		&item.InputTokens,
+		&item.OutputTokens,
+		&item.CacheCreationTokens,
+		&item.CacheReadTokens,
+		&item.TotalCost,
+		&item.ActualCost,
+		&item.ClientIP,
+		&requestBody,
+		&responseBody,
+		&item.BodyTruncated,
+		&item.RequestBodyRedacted,
+		&item.ReplayOfID,
+	); err != nil {
+		return nil, err
+	}
+	if requestBody.Valid {
+		item.RequestBody = &requestBody.String
+	}
+	if responseBody.Valid {
+		item.ResponseBody = &responseBody.String
+	}
+	return item, nil
+}
+
+func buildAuditLogsWhere(filter *service.AuditLogFilter) (string, []any) {
+	clauses := []string{"1=1"}
+	args := make([]any, 0, 5)
+	if filter.StartTime != nil && !filter.StartTime.IsZero() {
+		args = append(args, filter.StartTime.UTC())
+		clauses = append(clauses, "a.created_at >= $"+itoa(len(args)))
+	}
+	if filter.EndTime != nil && !filter.EndTime.IsZero() {
+		args = append(args, filter.EndTime.UTC())
+		clauses = append(clauses, "a.created_at < $"+itoa(len(args)))
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		clauses = append(clauses, "a.user_id = $"+itoa(len(args)))
+	}
+	if filter.APIKeyID != nil {
+		args = append(args, *filter.APIKeyID)
+		clauses = append(clauses, "a.api_key_id = $"+itoa(len(args)))
+	}
+	if model := strings.TrimSpace(filter.Model); model != "" {
+		args = append(args, model)
+		clauses = append(clauses, "a.model = $"+itoa(len(args)))
+	}
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func truncateAuditField(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	s = s[:maxLen]
+	// 避免截断后留下不完整的 UTF-8 字符
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// auditNullBody PostgreSQL text 不允许 NUL 字符
+func auditNullBody(body *string) any {
+	if body == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strings.ReplaceAll(*body, "\x00", ""), Valid: true}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+const (
+	gatewayIdempotencyKeyPrefix      = "gateway:idempotency:"
+	gatewayIdempotencyInFlightPrefix = "gateway:idempotency:inflight:"
+)
+
+// GatewayIdempotencyCache implements service.GatewayIdempotencyCache using Redis
+type GatewayIdempotencyCache struct {
+	rdb *redis.Client
+}
+
+// NewGatewayIdempotencyCache creates a new gateway idempotency cache
+func NewGatewayIdempotencyCache(rdb *redis.Client) service.GatewayIdempotencyCache {
+	return &GatewayIdempotencyCache{rdb: rdb}
+}
+
+// GetGatewayResponse retrieves a cached gateway response
+func (c *GatewayIdempotencyCache) GetGatewayResponse(ctx context.Context, key string) (*service.GatewayIdempotentResponse, error) {
+	data, err := c.rdb.Get(ctx, gatewayIdempotencyKeyPrefix+key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get gateway idempotent response: %w", err)
+	}
+
+	var resp service.GatewayIdempotentResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal gateway idempotent response: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetGatewayResponse stores a completed gateway response
+func (c *GatewayIdempotencyCache) SetGatewayResponse(ctx context.Context, key string, resp *service.GatewayIdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal gateway idempotent response: %w", err)
+	}
+	if err := c.rdb.Set(ctx, gatewayIdempotencyKeyPrefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("set gateway idempotent response: %w", err)
+	}
+	return nil
+}
+
+// AcquireGatewayInFlight marks a key as being processed; returns false if it is already marked
+func (c *GatewayIdempotencyCache) AcquireGatewayInFlight(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	acquired, err := c.rdb.SetNX(ctx, gatewayIdempotencyInFlightPrefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("acquire gateway idempotency in-flight marker: %w", err)
+	}
+	return acquired, nil
+}
+
+// ReleaseGatewayInFlight clears the in-flight marker of a key
+func (c *GatewayIdempotencyCache) ReleaseGatewayInFlight(ctx context.Context, key string) error {
+	if err := c.rdb.Del(ctx, gatewayIdempotencyInFlightPrefix+key).Err(); err != nil {
+		return fmt.Errorf("release gateway idempotency in-flight marker: %w", err)
+	}
+	return nil
+}
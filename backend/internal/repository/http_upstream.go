@@ -18,6 +18,7 @@ import (
 	"github.com/andybalholm/brotli"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/proxyurl"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/proxyutil"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/tlsfingerprint"
@@ -138,8 +139,11 @@ func (s *httpUpstreamService) Do(req *http.Request, proxyURL string, accountID i
 		return nil, err
 	}
 
+	s.propagateRequestID(req)
+
 	// 执行请求
 	resp, err := entry.client.Do(req)
+	recordAccountUpstreamOutcome(accountID, resp, err)
 	if err != nil {
 		// 请求失败，立即减少计数
 		atomic.AddInt64(&entry.inFlight, -1)
@@ -189,7 +193,10 @@ func (s *httpUpstreamService) DoWithTLS(req *http.Request, proxyURL string, acco
 		return nil, err
 	}
 
+	s.propagateRequestID(req)
+
 	resp, err := entry.client.Do(req)
+	recordAccountUpstreamOutcome(accountID, resp, err)
 	if err != nil {
 		atomic.AddInt64(&entry.inFlight, -1)
 		atomic.StoreInt64(&entry.lastUsed, time.Now().UnixNano())
@@ -207,6 +214,29 @@ func (s *httpUpstreamService) DoWithTLS(req *http.Request, proxyURL string, acco
 	return resp, nil
 }
 
+// propagateRequestID 将请求上下文中的追踪 ID 写入上游请求头，便于跨服务关联日志；调用方已设置时不覆盖
+func (s *httpUpstreamService) propagateRequestID(req *http.Request) {
+	if s.cfg == nil || req == nil {
+		return
+	}
+	header := strings.TrimSpace(s.cfg.Gateway.UpstreamRequestIDHeader)
+	if header == "" || req.Header.Get(header) != "" {
+		return
+	}
+	if requestID, _ := req.Context().Value(ctxkey.RequestID).(string); requestID != "" {
+		req.Header.Set(header, requestID)
+	}
+}
+
+// recordAccountUpstreamOutcome 将上游响应状态计入账号熔断统计
+func recordAccountUpstreamOutcome(accountID int64, resp *http.Response, err error) {
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	service.RecordAccountUpstreamOutcome(accountID, statusCode, err)
+}
+
 // acquireClientWithTLS 获取或创建带 TLS 指纹的客户端
 func (s *httpUpstreamService) acquireClientWithTLS(proxyURL string, accountID int64, accountConcurrency int, profile *tlsfingerprint.Profile) (*upstreamClientEntry, error) {
 	return s.getClientEntryWithTLS(proxyURL, accountID, accountConcurrency, profile, true, true)
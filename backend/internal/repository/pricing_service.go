@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,11 +11,13 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httputil"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 )
 
 type pricingRemoteClient struct {
 	httpClient *http.Client
+	download   httputil.RangeDownloadOptions
 }
 
 // pricingRemoteClientError 代理初始化失败时的错误占位客户端
@@ -32,11 +35,11 @@ func (c *pricingRemoteClientError) FetchHashText(_ context.Context, _ string) (s
 }
 
 // NewPricingRemoteClient 创建定价数据远程客户端
-// proxyURL 为空时直连，支持 http/https/socks5/socks5h 协议
+// proxyURL 为空时直连，支持 http/https/socks5/socks5h 协议；download 控制分块下载与中断续传
 // 代理配置失败时行为由 allowDirectOnProxyError 控制：
 //   - false（默认）：返回错误占位客户端，禁止回退到直连
 //   - true：回退到直连（仅限管理员显式开启）
-func NewPricingRemoteClient(proxyURL string, allowDirectOnProxyError bool) service.PricingRemoteClient {
+func NewPricingRemoteClient(proxyURL string, allowDirectOnProxyError bool, download httputil.RangeDownloadOptions) service.PricingRemoteClient {
 	// 安全说明：httpclient.GetClient 的错误链（url.Parse / proxyutil）不含明文代理凭据，
 	// 但仍通过 slog 仅在服务端日志记录，不会暴露给 HTTP 响应。
 	sharedClient, err := httpclient.GetClient(httpclient.Options{
@@ -52,26 +55,18 @@ func NewPricingRemoteClient(proxyURL string, allowDirectOnProxyError bool) servi
 	}
 	return &pricingRemoteClient{
 		httpClient: sharedClient,
+		download:   download,
 	}
 }
 
+// FetchPricingJSON 下载价格数据；中断时以 Range 请求续传，未完整收到全部字节时返回错误
 func (c *pricingRemoteClient) FetchPricingJSON(ctx context.Context, url string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	body, err := httputil.DownloadWithResume(ctx, c.httpClient, url, c.download)
+	var statusErr *httputil.DownloadStatusError
+	if errors.As(err, &statusErr) {
+		return nil, &service.PricingFetchStatusError{StatusCode: statusErr.StatusCode}
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
-	}
-
-	return io.ReadAll(resp.Body)
+	return body, err
 }
 
 func (c *pricingRemoteClient) FetchHashText(ctx context.Context, url string) (string, error) {
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httputil"
+	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -19,7 +21,7 @@ type PricingServiceSuite struct {
 
 func (s *PricingServiceSuite) SetupTest() {
 	s.ctx = context.Background()
-	client, ok := NewPricingRemoteClient("", false).(*pricingRemoteClient)
+	client, ok := NewPricingRemoteClient("", false, httputil.RangeDownloadOptions{}).(*pricingRemoteClient)
 	require.True(s.T(), ok, "type assertion failed")
 	s.client = client
 }
@@ -57,6 +59,9 @@ func (s *PricingServiceSuite) TestFetchPricingJSON_NonOKStatus() {
 
 	_, err := s.client.FetchPricingJSON(s.ctx, s.srv.URL+"/err")
 	require.Error(s.T(), err, "expected error for non-200 status")
+	var statusErr *service.PricingFetchStatusError
+	require.ErrorAs(s.T(), err, &statusErr)
+	require.Equal(s.T(), http.StatusInternalServerError, statusErr.StatusCode)
 }
 
 func (s *PricingServiceSuite) TestFetchHashText_ParsesFields() {
@@ -141,7 +146,7 @@ func (s *PricingServiceSuite) TestFetchPricingJSON_ContextCancel() {
 }
 
 func TestNewPricingRemoteClient_InvalidProxy_NoFallback(t *testing.T) {
-	client := NewPricingRemoteClient("://bad", false)
+	client := NewPricingRemoteClient("://bad", false, httputil.RangeDownloadOptions{})
 	_, ok := client.(*pricingRemoteClientError)
 	require.True(t, ok, "should return error client when proxy is invalid and fallback disabled")
 
@@ -151,7 +156,7 @@ func TestNewPricingRemoteClient_InvalidProxy_NoFallback(t *testing.T) {
 }
 
 func TestNewPricingRemoteClient_InvalidProxy_WithFallback(t *testing.T) {
-	client := NewPricingRemoteClient("://bad", true)
+	client := NewPricingRemoteClient("://bad", true, httputil.RangeDownloadOptions{})
 	_, ok := client.(*pricingRemoteClient)
 	require.True(t, ok, "should fallback to direct client when allowed")
 }
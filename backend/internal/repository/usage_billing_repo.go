@@ -142,9 +142,82 @@ func (r *usageBillingRepository) applyUsageBillingEffects(ctx context.Context, t
 		result.QuotaState = quotaState
 	}
 
+	if cmd.UserID > 0 && cmd.Sandbox {
+		if err := incrementUserDailySandboxSpend(ctx, tx, cmd.UserID, cmd.SpendTotalCost, cmd.SpendActualCost); err != nil {
+			return err
+		}
+	} else if cmd.UserID > 0 {
+		if err := incrementUserDailySpend(ctx, tx, cmd); err != nil {
+			return err
+		}
+	}
+
+	// 用量归属于实际承接请求的账号：故障转移时 cmd.AccountID 为最终成功的账号，失败的尝试不计入
+	if cmd.AccountID > 0 {
+		if err := incrementAccountDailyUsage(ctx, tx, cmd); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// incrementUserDailySpend 将本次请求费用与缓存命中节省累加到用户当日（UTC）消费汇总，与扣费在同一事务内、受幂等键保护
+func incrementUserDailySpend(ctx context.Context, tx *sql.Tx, cmd *service.UsageBillingCommand) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_spend_daily (
+			user_id, bucket_date, request_count, total_cost, actual_cost,
+			cache_read_tokens, cache_read_cost, cache_savings, updated_at
+		)
+		VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id, bucket_date) DO UPDATE SET
+			request_count = user_spend_daily.request_count + 1,
+			total_cost = user_spend_daily.total_cost + EXCLUDED.total_cost,
+			actual_cost = user_spend_daily.actual_cost + EXCLUDED.actual_cost,
+			cache_read_tokens = user_spend_daily.cache_read_tokens + EXCLUDED.cache_read_tokens,
+			cache_read_cost = user_spend_daily.cache_read_cost + EXCLUDED.cache_read_cost,
+			cache_savings = user_spend_daily.cache_savings + EXCLUDED.cache_savings,
+			updated_at = NOW()
+	`, cmd.UserID, cmd.SpendTotalCost, cmd.SpendActualCost, cmd.CacheReadTokens, cmd.SpendCacheReadCost, cmd.SpendCacheSavings)
+	return err
+}
+
+// incrementUserDailySandboxSpend 沙盒 Key 的费用累加到独立的沙盒列，不影响消费合计与预算检查
+func incrementUserDailySandboxSpend(ctx context.Context, tx *sql.Tx, userID int64, totalCost, actualCost float64) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_spend_daily (user_id, bucket_date, sandbox_request_count, sandbox_total_cost, sandbox_actual_cost, updated_at)
+		VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1, $2, $3, NOW())
+		ON CONFLICT (user_id, bucket_date) DO UPDATE SET
+			sandbox_request_count = user_spend_daily.sandbox_request_count + 1,
+			sandbox_total_cost = user_spend_daily.sandbox_total_cost + EXCLUDED.sandbox_total_cost,
+			sandbox_actual_cost = user_spend_daily.sandbox_actual_cost + EXCLUDED.sandbox_actual_cost,
+			updated_at = NOW()
+	`, userID, totalCost, actualCost)
+	return err
+}
+
+// incrementAccountDailyUsage 将本次请求的 token 与费用累加到账号当日（UTC）用量汇总（含沙盒请求）
+func incrementAccountDailyUsage(ctx context.Context, tx *sql.Tx, cmd *service.UsageBillingCommand) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO account_usage_daily (
+			account_id, bucket_date, request_count,
+			input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens,
+			total_cost, actual_cost, updated_at
+		)
+		VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (account_id, bucket_date) DO UPDATE SET
+			request_count = account_usage_daily.request_count + 1,
+			input_tokens = account_usage_daily.input_tokens + EXCLUDED.input_tokens,
+			output_tokens = account_usage_daily.output_tokens + EXCLUDED.output_tokens,
+			cache_creation_tokens = account_usage_daily.cache_creation_tokens + EXCLUDED.cache_creation_tokens,
+			cache_read_tokens = account_usage_daily.cache_read_tokens + EXCLUDED.cache_read_tokens,
+			total_cost = account_usage_daily.total_cost + EXCLUDED.total_cost,
+			actual_cost = account_usage_daily.actual_cost + EXCLUDED.actual_cost,
+			updated_at = NOW()
+	`, cmd.AccountID, cmd.InputTokens, cmd.OutputTokens, cmd.CacheCreationTokens, cmd.CacheReadTokens, cmd.SpendTotalCost, cmd.SpendActualCost)
+	return err
+}
+
 func incrementUsageBillingSubscription(ctx context.Context, tx *sql.Tx, subscriptionID int64, costUSD float64) error {
 	const updateSQL = `
 		UPDATE user_subscriptions us
@@ -86,6 +86,9 @@ func TestUsageLogRepositoryCreateSyncRequestTypeAndLegacyFields(t *testing.T) {
 			sqlmock.AnyArg(), // billing_tier
 			sqlmock.AnyArg(), // billing_mode
 			sqlmock.AnyArg(), // account_stats_cost
+			false,            // is_sandbox
+			sqlmock.AnyArg(), // abort_reason
+			sqlmock.AnyArg(), // trace_id
 			createdAt,
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(99), createdAt))
@@ -165,6 +168,9 @@ func TestUsageLogRepositoryCreate_PersistsServiceTier(t *testing.T) {
 			sqlmock.AnyArg(), // billing_tier
 			sqlmock.AnyArg(), // billing_mode
 			sqlmock.AnyArg(), // account_stats_cost
+			false,            // is_sandbox
+			sqlmock.AnyArg(), // abort_reason
+			sqlmock.AnyArg(), // trace_id
 			createdAt,
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(100), createdAt))
@@ -577,6 +583,9 @@ func TestScanUsageLogRequestTypeAndLegacyFallback(t *testing.T) {
 			sql.NullString{},  // billing_tier
 			sql.NullString{},  // billing_mode
 			sql.NullFloat64{}, // account_stats_cost
+			false,             // is_sandbox
+			sql.NullString{},  // abort_reason
+			sql.NullString{},  // trace_id
 			now,
 		}})
 		require.NoError(t, err)
@@ -625,6 +634,9 @@ func TestScanUsageLogRequestTypeAndLegacyFallback(t *testing.T) {
 			sql.NullString{},  // billing_tier
 			sql.NullString{},  // billing_mode
 			sql.NullFloat64{}, // account_stats_cost
+			false,             // is_sandbox
+			sql.NullString{},  // abort_reason
+			sql.NullString{},  // trace_id
 			now,
 		}})
 		require.NoError(t, err)
@@ -673,6 +685,9 @@ func TestScanUsageLogRequestTypeAndLegacyFallback(t *testing.T) {
 			sql.NullString{},  // billing_tier
 			sql.NullString{},  // billing_mode
 			sql.NullFloat64{}, // account_stats_cost
+			false,             // is_sandbox
+			sql.NullString{},  // abort_reason
+			sql.NullString{},  // trace_id
 			now,
 		}})
 		require.NoError(t, err)
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type usageReportRepository struct {
+	sql sqlExecutor
+}
+
+// NewUsageReportRepository 创建用量报表仓储。
+func NewUsageReportRepository(sqlDB *sql.DB) service.UsageReportRepository {
+	return &usageReportRepository{sql: sqlDB}
+}
+
+// usageReportDimensionColumns 各分组维度对应的 SELECT / GROUP BY 表达式
+var usageReportDimensionColumns = map[service.UsageReportDimension][]string{
+	service.UsageReportByDay:      {"TO_CHAR(ul.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')"},
+	service.UsageReportByModel:    {"ul.model"},
+	service.UsageReportByProvider: {"COALESCE(a.platform, '')"},
+	service.UsageReportByUser:     {"ul.user_id", "COALESCE(u.email, '')"},
+}
+
+// buildUsageReportQuery 按分组维度拼接聚合 SQL（维度来自固定白名单，参数 $1/$2 为时间区间）
+func buildUsageReportQuery(dims []service.UsageReportDimension, includeSandbox bool) string {
+	keys := make([]string, 0, len(dims)+1)
+	for _, dim := range dims {
+		keys = append(keys, usageReportDimensionColumns[dim]...)
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteString(", ")
+	}
+	b.WriteString(`COUNT(*),
+		COALESCE(SUM(ul.input_tokens), 0),
+		COALESCE(SUM(ul.output_tokens), 0),
+		COALESCE(SUM(ul.cache_creation_tokens), 0),
+		COALESCE(SUM(ul.cache_read_tokens), 0),
+		COALESCE(SUM(ul.total_cost), 0),
+		COALESCE(SUM(ul.actual_cost), 0)
+	FROM usage_logs ul`)
+	for _, dim := range dims {
+		switch dim {
+		case service.UsageReportByProvider:
+			b.WriteString(" LEFT JOIN accounts a ON a.id = ul.account_id")
+		case service.UsageReportByUser:
+			b.WriteString(" LEFT JOIN users u ON u.id = ul.user_id")
+		}
+	}
+	b.WriteString(" WHERE ul.created_at >= $1 AND ul.created_at < $2")
+	if !includeSandbox {
+		b.WriteString(" AND NOT ul.is_sandbox")
+	}
+	b.WriteString(" GROUP BY ")
+	b.WriteString(strings.Join(keys, ", "))
+	b.WriteString(" ORDER BY ")
+	b.WriteString(strings.Join(keys, ", "))
+	return b.String()
+}
+
+func (r *usageReportRepository) StreamUsageReport(ctx context.Context, query service.UsageReportQuery, fn func(service.UsageReportRow) error) (err error) {
+	// to 为包含当日的 UTC 日期，SQL 上边界取次日 00:00
+	rows, err := r.sql.QueryContext(ctx, buildUsageReportQuery(query.GroupBy, query.IncludeSandbox), query.From, query.To.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	for rows.Next() {
+		var row service.UsageReportRow
+		dest := make([]any, 0, len(query.GroupBy)+8)
+		for _, dim := range query.GroupBy {
+			switch dim {
+			case service.UsageReportByDay:
+				dest = append(dest, &row.Day)
+			case service.UsageReportByModel:
+				dest = append(dest, &row.Model)
+			case service.UsageReportByProvider:
+				dest = append(dest, &row.Provider)
+			case service.UsageReportByUser:
+				dest = append(dest, &row.UserID, &row.UserEmail)
+			}
+		}
+		dest = append(dest,
+			&row.Requests,
+			&row.InputTokens,
+			&row.OutputTokens,
+			&row.CacheCreationTokens,
+			&row.CacheReadTokens,
+			&row.TotalCost,
+			&row.ActualCost,
+		)
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
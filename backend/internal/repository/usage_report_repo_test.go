@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageReportRepositoryStreamsRowsInGroupOrder(t *testing.T) {
+	db, mock := newSQLMock(t)
+	repo := &usageReportRepository{sql: db}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT ul\.user_id, COALESCE\(u\.email, ''\), TO_CHAR\(ul\.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'\), COUNT\(\*\).*`+
+		`FROM usage_logs ul LEFT JOIN users u ON u\.id = ul\.user_id WHERE ul\.created_at >= \$1 AND ul\.created_at < \$2 AND NOT ul\.is_sandbox `+
+		`GROUP BY ul\.user_id, COALESCE\(u\.email, ''\), TO_CHAR.* ORDER BY ul\.user_id`).
+		WithArgs(from, to.AddDate(0, 0, 1)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "day", "requests", "input", "output", "cache_creation", "cache_read", "total_cost", "actual_cost"}).
+			AddRow(int64(7), "a@example.com", "2026-03-01", int64(2), int64(100), int64(50), int64(0), int64(10), 0.5, 0.4).
+			AddRow(int64(8), "", "2026-03-02", int64(1), int64(10), int64(5), int64(0), int64(0), 0.1, 0.1))
+
+	var rows []service.UsageReportRow
+	err := repo.StreamUsageReport(context.Background(), service.UsageReportQuery{
+		From:    from,
+		To:      to,
+		GroupBy: []service.UsageReportDimension{service.UsageReportByUser, service.UsageReportByDay},
+	}, func(row service.UsageReportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, service.UsageReportRow{
+		Day: "2026-03-01", UserID: 7, UserEmail: "a@example.com",
+		Requests: 2, InputTokens: 100, OutputTokens: 50, CacheReadTokens: 10, TotalCost: 0.5, ActualCost: 0.4,
+	}, rows[0])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildUsageReportQueryJoinsAccountsForProvider(t *testing.T) {
+	query := buildUsageReportQuery([]service.UsageReportDimension{service.UsageReportByProvider, service.UsageReportByModel}, false)
+	require.Contains(t, query, "LEFT JOIN accounts a ON a.id = ul.account_id")
+	require.NotContains(t, query, "LEFT JOIN users")
+	require.Contains(t, query, "GROUP BY COALESCE(a.platform, ''), ul.model")
+	require.Contains(t, query, "AND NOT ul.is_sandbox")
+
+	withSandbox := buildUsageReportQuery([]service.UsageReportDimension{service.UsageReportByModel}, true)
+	require.NotContains(t, withSandbox, "is_sandbox")
+}
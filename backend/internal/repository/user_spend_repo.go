@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type userSpendRepository struct {
+	sql sqlExecutor
+}
+
+// NewUserSpendRepository 创建用户日消费汇总仓储。
+func NewUserSpendRepository(sqlDB *sql.DB) service.UserSpendRepository {
+	return &userSpendRepository{sql: sqlDB}
+}
+
+func (r *userSpendRepository) ListUserDailySpend(ctx context.Context, userID int64, from, to time.Time) (results []service.UserDailySpend, err error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT
+			TO_CHAR(bucket_date, 'YYYY-MM-DD') AS date,
+			request_count,
+			total_cost,
+			actual_cost,
+			cache_read_tokens,
+			cache_read_cost,
+			cache_savings,
+			sandbox_request_count,
+			sandbox_total_cost,
+			sandbox_actual_cost
+		FROM user_spend_daily
+		WHERE user_id = $1 AND bucket_date >= $2::date AND bucket_date <= $3::date
+		ORDER BY bucket_date ASC
+	`, userID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+			results = nil
+		}
+	}()
+
+	results = make([]service.UserDailySpend, 0)
+	for rows.Next() {
+		var row service.UserDailySpend
+		if err = rows.Scan(&row.Date, &row.Requests, &row.TotalCost, &row.ActualCost, &row.CacheReadTokens, &row.CacheReadCostUSD, &row.CacheSavingsUSD, &row.SandboxRequests, &row.SandboxTotalCost, &row.SandboxActualCost); err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *userSpendRepository) SumUserSpendSince(ctx context.Context, userID int64, from time.Time) (requests int64, actualCost float64, err error) {
+	err = scanSingleRow(ctx, r.sql, `
+		SELECT COALESCE(SUM(request_count), 0), COALESCE(SUM(actual_cost), 0)
+		FROM user_spend_daily
+		WHERE user_id = $1 AND bucket_date >= $2::date
+	`, []any{userID, from.Format(time.DateOnly)}, &requests, &actualCost)
+	if err != nil {
+		return 0, 0, err
+	}
+	return requests, actualCost, nil
+}
@@ -219,6 +219,23 @@ func TestAPIContracts(t *testing.T) {
 					"window_5h_start": null,
 					"window_1d_start": null,
 					"window_7d_start": null,
+					"rpm_limit": 0,
+					"tpm_limit": 0,
+					"audit_capture_body": false,
+					"allowed_models": null,
+					"denied_models": null,
+					"usage_headers": false,
+					"is_sandbox": false,
+					"priority_tier": 0,
+					"key_hashed": false,
+					"max_request_bytes": 0,
+					"max_response_bytes": 0,
+					"usage_quota_requests": 0,
+					"usage_quota_tokens": 0,
+					"usage_quota_period": "",
+					"usage_quota_requests_used": 0,
+					"usage_quota_tokens_used": 0,
+					"mask_response_model": false,
 					"expires_at": null,
 					"created_at": "2025-01-02T03:04:05Z",
 					"updated_at": "2025-01-02T03:04:05Z"
@@ -268,6 +285,23 @@ func TestAPIContracts(t *testing.T) {
 							"window_5h_start": null,
 							"window_1d_start": null,
 							"window_7d_start": null,
+							"rpm_limit": 0,
+							"tpm_limit": 0,
+							"audit_capture_body": false,
+							"allowed_models": null,
+							"denied_models": null,
+							"usage_headers": false,
+							"is_sandbox": false,
+							"priority_tier": 0,
+							"key_hashed": false,
+							"max_request_bytes": 0,
+							"max_response_bytes": 0,
+							"usage_quota_requests": 0,
+							"usage_quota_tokens": 0,
+							"usage_quota_period": "",
+							"usage_quota_requests_used": 0,
+							"usage_quota_tokens_used": 0,
+							"mask_response_model": false,
 							"expires_at": null,
 							"created_at": "2025-01-02T03:04:05Z",
 							"updated_at": "2025-01-02T03:04:05Z"
@@ -1568,6 +1602,10 @@ func (s *stubAccountRepo) Delete(ctx context.Context, id int64) error {
 	return errors.New("not implemented")
 }
 
+func (s *stubAccountRepo) Restore(ctx context.Context, id int64) error {
+	return errors.New("not implemented")
+}
+
 func (s *stubAccountRepo) List(ctx context.Context, params pagination.PaginationParams) ([]service.Account, *pagination.PaginationResult, error) {
 	return nil, nil, errors.New("not implemented")
 }
@@ -1991,6 +2029,10 @@ func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*serv
 	return r.GetByKey(ctx, key)
 }
 
+func (r *stubApiKeyRepo) UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error {
+	return errors.New("not implemented")
+}
+
 func (r *stubApiKeyRepo) Update(ctx context.Context, key *service.APIKey) error {
 	if key == nil {
 		return errors.New("nil key")
@@ -25,6 +25,7 @@ import (
 var ProviderSet = wire.NewSet(
 	ProvideRouter,
 	ProvideHTTPServer,
+	ProvideInFlightTracker,
 )
 
 // ProvideRouter 提供路由器
@@ -37,7 +38,13 @@ func ProvideRouter(
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
+	auditService *service.AuditService,
+	billingService *service.BillingService,
 	settingService *service.SettingService,
+	gatewayIdempotency *service.GatewayIdempotencyService,
+	maintenanceService *service.MaintenanceService,
+	priorityQueue *service.GatewayPriorityQueue,
+	gatewayLoadService *service.GatewayLoadService,
 	redisClient *redis.Client,
 ) *gin.Engine {
 	if cfg.Server.Mode == "release" {
@@ -95,11 +102,11 @@ func ProvideRouter(
 		service.SetWebSearchManager(websearch.NewManager(configs, redisClient))
 	})
 
-	return SetupRouter(r, handlers, jwtAuth, adminAuth, apiKeyAuth, apiKeyService, subscriptionService, opsService, settingService, cfg, redisClient)
+	return SetupRouter(r, handlers, jwtAuth, adminAuth, apiKeyAuth, apiKeyService, subscriptionService, opsService, auditService, billingService, settingService, gatewayIdempotency, maintenanceService, priorityQueue, gatewayLoadService, cfg, redisClient)
 }
 
 // ProvideHTTPServer 提供 HTTP 服务器
-func ProvideHTTPServer(cfg *config.Config, router *gin.Engine) *http.Server {
+func ProvideHTTPServer(cfg *config.Config, router *gin.Engine, inFlight *InFlightTracker) *http.Server {
 	httpHandler := http.Handler(router)
 
 	globalMaxSize := cfg.Server.MaxRequestBodySize
@@ -130,6 +137,9 @@ func ProvideHTTPServer(cfg *config.Config, router *gin.Engine) *http.Server {
 		)
 	}
 
+	// 最外层统计进行中请求，供停机时等待排空
+	httpHandler = inFlight.Wrap(httpHandler)
+
 	return &http.Server{
 		Addr:    cfg.Server.Address(),
 		Handler: httpHandler,
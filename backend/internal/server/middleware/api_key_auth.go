@@ -27,6 +27,12 @@ func NewAPIKeyAuthMiddleware(apiKeyService *service.APIKeyService, subscriptionS
 // /v1/usage 端点只需鉴权，不需要计费执行（允许过期/配额耗尽的 Key 查询自身用量）。
 func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// 管理员重放审计记录：请求由进程内发起，无明文 Key
+		if replay := service.AuditReplayFromContext(c.Request.Context()); replay != nil {
+			authenticateAuditReplay(c, apiKeyService, subscriptionService, replay)
+			return
+		}
+
 		// ── 1. 提取 API Key ──────────────────────────────────────────
 
 		queryKey := strings.TrimSpace(c.Query("key"))
@@ -220,6 +226,46 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 	}
 }
 
+// authenticateAuditReplay 按审计记录的 Key ID 加载 API Key，仍要求 Key 与用户处于可用状态。
+// 重放以沙盒方式执行（不扣费、不计入消费汇总），因此跳过余额/额度/订阅限额检查与 IP 限制。
+func authenticateAuditReplay(c *gin.Context, apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, replay *service.AuditReplay) {
+	apiKey, err := apiKeyService.GetByID(c.Request.Context(), replay.APIKeyID)
+	if err != nil {
+		if errors.Is(err, service.ErrAPIKeyNotFound) {
+			AbortWithError(c, 401, "INVALID_API_KEY", "Invalid API key")
+			return
+		}
+		AbortWithError(c, 500, "INTERNAL_ERROR", "Failed to validate API key")
+		return
+	}
+	if !apiKey.IsActive() &&
+		apiKey.Status != service.StatusAPIKeyExpired &&
+		apiKey.Status != service.StatusAPIKeyQuotaExhausted {
+		AbortWithError(c, 401, "API_KEY_DISABLED", "API key is disabled")
+		return
+	}
+	if apiKey.User == nil || !apiKey.User.IsActive() {
+		AbortWithError(c, 401, "USER_INACTIVE", "User account is not active")
+		return
+	}
+
+	replayKey := *apiKey
+	replayKey.IsSandbox = true
+	if replayKey.Group != nil && replayKey.Group.IsSubscriptionType() && subscriptionService != nil {
+		if subscription, subErr := subscriptionService.GetActiveSubscription(c.Request.Context(), replayKey.User.ID, replayKey.Group.ID); subErr == nil {
+			c.Set(string(ContextKeySubscription), subscription)
+		}
+	}
+	c.Set(string(ContextKeyAPIKey), &replayKey)
+	c.Set(string(ContextKeyUser), AuthSubject{
+		UserID:      replayKey.User.ID,
+		Concurrency: replayKey.User.Concurrency,
+	})
+	c.Set(string(ContextKeyUserRole), replayKey.User.Role)
+	setGroupContext(c, replayKey.Group)
+	c.Next()
+}
+
 // GetAPIKeyFromContext 从上下文中获取API key
 func GetAPIKeyFromContext(c *gin.Context) (*service.APIKey, bool) {
 	value, exists := c.Get(string(ContextKeyAPIKey))
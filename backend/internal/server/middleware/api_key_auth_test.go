@@ -492,6 +492,55 @@ func TestAPIKeyAuthTouchesLastUsedInStandardMode(t *testing.T) {
 	require.Equal(t, 1, touchCalls)
 }
 
+func TestAPIKeyAuthAuditReplayLoadsKeyByIDAsSandbox(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := &service.User{ID: 9, Role: service.RoleUser, Status: service.StatusActive, Concurrency: 3}
+	apiKey := &service.APIKey{ID: 102, UserID: user.ID, Key: "replay-key", Status: service.StatusActive, User: user}
+	touchCalls := 0
+	apiKeyRepo := &stubApiKeyRepo{
+		getByID: func(ctx context.Context, id int64) (*service.APIKey, error) {
+			if id != apiKey.ID {
+				return nil, service.ErrAPIKeyNotFound
+			}
+			clone := *apiKey
+			return &clone, nil
+		},
+		updateLastUsed: func(ctx context.Context, id int64, usedAt time.Time) error {
+			touchCalls++
+			return nil
+		},
+	}
+
+	cfg := &config.Config{RunMode: config.RunModeStandard}
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, nil, nil, nil, nil, nil, cfg)
+	router := gin.New()
+	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, nil, cfg)))
+	var seen *service.APIKey
+	router.GET("/t", func(c *gin.Context) {
+		seen, _ = GetAPIKeyFromContext(c)
+		c.Status(http.StatusOK)
+	})
+
+	// 余额为 0 仍可重放：重放不扣费
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/t", nil)
+	req = req.WithContext(service.WithAuditReplay(req.Context(), &service.AuditReplay{OriginalID: 1, APIKeyID: apiKey.ID}))
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, seen)
+	require.True(t, seen.IsSandbox)
+	require.False(t, apiKey.IsSandbox)
+	require.Zero(t, touchCalls)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/t", nil)
+	req = req.WithContext(service.WithAuditReplay(req.Context(), &service.AuditReplay{OriginalID: 1, APIKeyID: 404}))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 func newAuthTestRouter(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, cfg *config.Config) *gin.Engine {
 	router := gin.New()
 	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, cfg)))
@@ -503,6 +552,7 @@ func newAuthTestRouter(apiKeyService *service.APIKeyService, subscriptionService
 
 type stubApiKeyRepo struct {
 	getByKey       func(ctx context.Context, key string) (*service.APIKey, error)
+	getByID        func(ctx context.Context, id int64) (*service.APIKey, error)
 	updateLastUsed func(ctx context.Context, id int64, usedAt time.Time) error
 }
 
@@ -511,6 +561,9 @@ func (r *stubApiKeyRepo) Create(ctx context.Context, key *service.APIKey) error
 }
 
 func (r *stubApiKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey, error) {
+	if r.getByID != nil {
+		return r.getByID(ctx, id)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -529,6 +582,10 @@ func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*serv
 	return r.GetByKey(ctx, key)
 }
 
+func (r *stubApiKeyRepo) UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error {
+	return errors.New("not implemented")
+}
+
 func (r *stubApiKeyRepo) Update(ctx context.Context, key *service.APIKey) error {
 	return errors.New("not implemented")
 }
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/googleapi"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// errResponseTooLarge 响应超出 API Key 大小上限后对 handler 的写入返回该错误，
+// 各网关按客户端断开处理：停止写出，继续读取上游以获得完整 usage 计费。
+var errResponseTooLarge = errors.New("response exceeds api key size limit")
+
+// APIKeyRequestSizeLimit 按 API Key 的请求体大小上限（未设置时使用全局默认值）拒绝超大请求，需紧跟 API Key 认证之后。
+// Content-Length 已超限时直接返回 413；其余情况限制读取，由 handler 在转发前按读取错误返回 413。
+func APIKeyRequestSizeLimit(cfg *config.Config, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, _ := GetAPIKeyFromContext(c)
+		maxBytes, _ := service.ResolveAPIKeySizeLimits(apiKey, cfg)
+		if maxBytes <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > maxBytes {
+			writeError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large, limit is %s", formatSizeLimit(maxBytes)))
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// APIKeyResponseSizeLimit 按 API Key 的响应体大小上限（未设置时使用全局默认值）中止超大响应，需紧挨在 ModelFallback 之前（handler 前的倒数第二个中间件）。
+// 尚未写出任何内容时返回错误响应；流式响应中途超限时按入站协议追加 SSE error 事件后停止写出，中止原因记录到使用日志。
+// WebSocket 升级请求跳过，由 Responses WebSocket handler 按整个会话的下行字节数限制。
+func APIKeyResponseSizeLimit(cfg *config.Config, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, _ := GetAPIKeyFromContext(c)
+		_, maxBytes := service.ResolveAPIKeySizeLimits(apiKey, cfg)
+		if maxBytes <= 0 || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		guard := service.NewResponseSizeGuard(maxBytes)
+		c.Request = c.Request.WithContext(service.WithResponseSizeGuard(c.Request.Context(), guard))
+		writer := &responseSizeLimitWriter{ResponseWriter: c.Writer, c: c, guard: guard, writeError: writeError}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+	}
+}
+
+// responseSizeLimitWriter 统计写出字节数，超过上限后拒绝后续写入
+type responseSizeLimitWriter struct {
+	gin.ResponseWriter
+	c          *gin.Context
+	guard      *service.ResponseSizeGuard
+	writeError GatewayErrorWriter
+	written    int64
+	exceeded   bool
+}
+
+func (w *responseSizeLimitWriter) Write(b []byte) (int, error) {
+	if err := w.reserve(len(b)); err != nil {
+		return 0, err
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *responseSizeLimitWriter) WriteString(s string) (int, error) {
+	if err := w.reserve(len(s)); err != nil {
+		return 0, err
+	}
+	n, err := w.ResponseWriter.WriteString(s)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *responseSizeLimitWriter) reserve(n int) error {
+	if w.exceeded {
+		return errResponseTooLarge
+	}
+	if w.written+int64(n) <= w.guard.Limit() {
+		return nil
+	}
+	w.exceeded = true
+	w.abort(w.guard.Abort())
+	return errResponseTooLarge
+}
+
+// abort 向客户端说明响应被中止：未写出内容时改为错误响应，SSE 流追加 error 事件
+func (w *responseSizeLimitWriter) abort(message string) {
+	if !w.ResponseWriter.Written() {
+		header := w.ResponseWriter.Header()
+		header.Del("Content-Length")
+		header.Del("Content-Type")
+		header.Del("Content-Encoding")
+		w.c.Writer = w.ResponseWriter
+		w.writeError(w.c, http.StatusRequestEntityTooLarge, message)
+		w.c.Writer = w
+		return
+	}
+	if !strings.Contains(w.ResponseWriter.Header().Get("Content-Type"), "text/event-stream") {
+		return
+	}
+	_, _ = w.ResponseWriter.WriteString(responseTooLargeSSEEvent(w.c.Request.URL.Path, message))
+	w.ResponseWriter.Flush()
+}
+
+// responseTooLargeSSEEvent 按入站端点的协议构造流中途超限的 SSE error 事件：
+// Gemini 使用 google.rpc.Status 格式，Anthropic Messages 使用 type=error 事件，
+// 其余（Chat Completions / Responses）使用 OpenAI 的 error 对象
+func responseTooLargeSSEEvent(path, message string) string {
+	switch {
+	case strings.Contains(path, "/v1beta/"):
+		payload, _ := json.Marshal(gin.H{
+			"error": gin.H{
+				"code":    http.StatusRequestEntityTooLarge,
+				"message": message,
+				"status":  googleapi.HTTPStatusToGoogleStatus(http.StatusRequestEntityTooLarge),
+			},
+		})
+		return "data: " + string(payload) + "\n\n"
+	case strings.HasSuffix(path, "/messages"):
+		payload, _ := json.Marshal(gin.H{
+			"type":  "error",
+			"error": gin.H{"type": "response_too_large", "message": message},
+		})
+		return "event: error\ndata: " + string(payload) + "\n\n"
+	default:
+		payload, _ := json.Marshal(gin.H{
+			"error": gin.H{"type": "response_too_large", "code": "response_too_large", "message": message},
+		})
+		return "event: error\ndata: " + string(payload) + "\n\n"
+	}
+}
+
+func formatSizeLimit(limit int64) string {
+	const mb = 1024 * 1024
+	if limit >= mb {
+		return fmt.Sprintf("%dMB", limit/mb)
+	}
+	return fmt.Sprintf("%dB", limit)
+}
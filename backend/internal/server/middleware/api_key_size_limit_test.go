@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newSizeLimitTestRouter(apiKey *service.APIKey, cfg *config.Config, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), apiKey)
+		c.Next()
+	})
+	r.Use(APIKeyRequestSizeLimit(cfg, AnthropicErrorWriter))
+	r.Use(APIKeyResponseSizeLimit(cfg, AnthropicErrorWriter))
+	r.POST("/v1/messages", handler)
+	return r
+}
+
+func TestAPIKeyRequestSizeLimit_RejectsOversizedBody(t *testing.T) {
+	r := newSizeLimitTestRouter(&service.APIKey{MaxRequestBytes: 16}, &config.Config{}, func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var maxErr *http.MaxBytesError
+			require.True(t, errors.As(err, &maxErr))
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"claude"}`)))
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	require.Contains(t, w.Body.String(), "Request body too large")
+
+	// 未声明 Content-Length 时由读取限制兜底
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"claude"}`))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKeyResponseSizeLimit_NonStreamingReturnsError(t *testing.T) {
+	var writeErr error
+	r := newSizeLimitTestRouter(&service.APIKey{}, &config.Config{Gateway: config.GatewayConfig{
+		KeySizeLimits: config.KeySizeLimitsConfig{DefaultMaxResponseBytes: 8},
+	}}, func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		_, writeErr = c.Writer.Write([]byte(`{"content":"way too long"}`))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{}`)))
+	require.ErrorIs(t, writeErr, errResponseTooLarge)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	require.Contains(t, w.Body.String(), "response exceeded the API key limit of 8 bytes")
+	require.NotContains(t, w.Body.String(), "way too long")
+}
+
+func TestAPIKeyResponseSizeLimit_StreamAbortRecordedInUsage(t *testing.T) {
+	var guard *service.ResponseSizeGuard
+	var writeErrs []error
+	r := newSizeLimitTestRouter(&service.APIKey{MaxResponseBytes: 32}, &config.Config{}, func(c *gin.Context) {
+		guard = service.ResponseSizeGuardFromContext(c.Request.Context())
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			_, err := c.Writer.WriteString("data: {\"delta\":\"chunk\"}\n\n")
+			writeErrs = append(writeErrs, err)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, writeErrs[0])
+	require.ErrorIs(t, writeErrs[1], errResponseTooLarge)
+	require.ErrorIs(t, writeErrs[2], errResponseTooLarge)
+	require.Equal(t, 1, strings.Count(w.Body.String(), `"delta"`))
+	require.Contains(t, w.Body.String(), "event: error\ndata: ")
+	require.Contains(t, w.Body.String(), `"type":"response_too_large"`)
+
+	require.NotNil(t, guard)
+	usageLog := &service.UsageLog{}
+	guard.SetUsage(usageLog)
+	require.NotNil(t, usageLog.AbortReason)
+	require.Contains(t, *usageLog.AbortReason, "32 bytes")
+}
+
+func TestAPIKeyResponseSizeLimit_UnderLimitPassesThrough(t *testing.T) {
+	var guard *service.ResponseSizeGuard
+	r := newSizeLimitTestRouter(&service.APIKey{MaxResponseBytes: 1024}, &config.Config{}, func(c *gin.Context) {
+		guard = service.ResponseSizeGuardFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"ok":true}`, w.Body.String())
+
+	usageLog := &service.UsageLog{}
+	guard.SetUsage(usageLog)
+	require.Nil(t, usageLog.AbortReason)
+}
+
+func TestAPIKeyResponseSizeLimit_StreamAbortUsesInboundProtocol(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), &service.APIKey{MaxResponseBytes: 32})
+		c.Next()
+	})
+	r.Use(APIKeyResponseSizeLimit(&config.Config{}, AnthropicErrorWriter))
+	stream := func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		for i := 0; i < 2; i++ {
+			_, _ = c.Writer.WriteString("data: {\"delta\":\"chunk\"}\n\n")
+		}
+	}
+	r.POST("/v1/chat/completions", stream)
+	r.POST("/v1/responses", stream)
+	r.POST("/v1beta/models/*modelAction", stream)
+
+	for _, path := range []string{"/v1/chat/completions", "/v1/responses"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`)))
+		require.Contains(t, w.Body.String(), `event: error`+"\n"+`data: {"error":{"code":"response_too_large","message":`, path)
+		require.Contains(t, w.Body.String(), `"type":"response_too_large"`, path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1beta/models/gemini-2.5-pro:streamGenerateContent", strings.NewReader(`{}`)))
+	require.Contains(t, w.Body.String(), `data: {"error":{"code":413,`)
+	require.NotContains(t, w.Body.String(), "event: error")
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogger 记录网关请求审计日志，需放在 API Key 认证之后。
+// 请求/响应正文默认不记录，仅对开启 audit_capture_body 的 Key 截断并脱敏保存。
+func AuditLogger(auditService *service.AuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auditService.Enabled() {
+			c.Next()
+			return
+		}
+		apiKey, _ := GetAPIKeyFromContext(c)
+		startTime := time.Now()
+		entry := auditService.Begin(startTime, apiKey != nil && apiKey.AuditCaptureBody)
+		c.Request = c.Request.WithContext(service.WithAuditEntry(c.Request.Context(), entry))
+
+		var requestBody []byte
+		var capture *auditCaptureWriter
+		if entry.CaptureBody() {
+			if c.Request.Body != nil {
+				body, err := io.ReadAll(c.Request.Body)
+				_ = c.Request.Body.Close()
+				// 读取失败时仍把已读部分交还给 handler，由其按原有逻辑报错
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				if err == nil {
+					requestBody = body
+				}
+			}
+			capture = &auditCaptureWriter{ResponseWriter: c.Writer, limit: auditService.MaxBodyBytes()}
+			c.Writer = capture
+		}
+
+		c.Next()
+
+		ctx := c.Request.Context()
+		entry.Finish(func(log *service.AuditLog) {
+			log.Method = c.Request.Method
+			log.Path = c.Request.URL.Path
+			log.StatusCode = c.Writer.Status()
+			log.DurationMs = time.Since(startTime).Milliseconds()
+			log.ClientIP = ip.GetClientIP(c)
+			if requestID, _ := ctx.Value(ctxkey.ClientRequestID).(string); requestID != "" {
+				log.RequestID = requestID
+			}
+			if traceID, _ := ctx.Value(ctxkey.RequestID).(string); traceID != "" {
+				log.TraceID = traceID
+			}
+			if apiKey != nil {
+				log.APIKeyID = apiKey.ID
+				log.UserID = apiKey.UserID
+			}
+			if model, _ := ctx.Value(ctxkey.Model).(string); model != "" {
+				log.Model = model
+			}
+			if platform, _ := ctx.Value(ctxkey.Platform).(string); platform != "" {
+				log.Platform = platform
+			} else if apiKey != nil && apiKey.Group != nil {
+				log.Platform = apiKey.Group.Platform
+			}
+			if accountID, _ := ctx.Value(ctxkey.AccountID).(int64); accountID > 0 {
+				log.AccountID = accountID
+			}
+			if replay := service.AuditReplayFromContext(ctx); replay != nil {
+				log.ReplayOfID = replay.OriginalID
+			}
+			if capture != nil {
+				var reqTruncated, respTruncated bool
+				log.RequestBody, reqTruncated, log.RequestBodyRedacted = auditService.CapturedRequestBody(requestBody)
+				log.ResponseBody, respTruncated = auditService.CapturedBody(capture.buf.Bytes())
+				log.BodyTruncated = reqTruncated || respTruncated || capture.truncated
+			}
+		})
+	}
+}
+
+// auditCaptureWriter 在透传响应的同时保存前 limit 字节
+type auditCaptureWriter struct {
+	gin.ResponseWriter
+	limit     int
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (w *auditCaptureWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditCaptureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *auditCaptureWriter) capture(b []byte) {
+	remaining := w.limit - w.buf.Len()
+	if remaining <= 0 {
+		w.truncated = w.truncated || len(b) > 0
+		return
+	}
+	if len(b) > remaining {
+		b = b[:remaining]
+		w.truncated = true
+	}
+	_, _ = w.buf.Write(b)
+}
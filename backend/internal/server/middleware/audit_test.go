@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type auditRepoStub struct {
+	mu   sync.Mutex
+	logs []*service.AuditLog
+}
+
+func (r *auditRepoStub) WriteAuditLogs(_ context.Context, logs []*service.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, logs...)
+	return nil
+}
+
+func (r *auditRepoStub) ListAuditLogs(context.Context, *service.AuditLogFilter) (*service.AuditLogList, error) {
+	return &service.AuditLogList{}, nil
+}
+
+func (r *auditRepoStub) GetAuditLog(context.Context, int64) (*service.AuditLog, error) {
+	return nil, service.ErrAuditLogNotFound
+}
+
+func runAuditRequest(t *testing.T, apiKey *service.APIKey, body string) *service.AuditLog {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repo := &auditRepoStub{}
+	auditSvc := service.NewAuditService(&config.Config{Audit: config.AuditConfig{
+		Enabled:      true,
+		DBEnabled:    true,
+		MaxBodyBytes: 16,
+		QueueSize:    8,
+	}}, repo)
+	auditSvc.Start()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), apiKey)
+		c.Next()
+	})
+	r.Use(AuditLogger(auditSvc))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		reqBody, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(reqBody), "handler must still see the full request body")
+
+		ctx := context.WithValue(c.Request.Context(), ctxkey.Model, "claude-audit-test")
+		ctx = context.WithValue(ctx, ctxkey.AccountID, int64(9))
+		c.Request = c.Request.WithContext(ctx)
+		c.String(http.StatusOK, `{"content":"response body text"}`)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `{"content":"response body text"}`, rec.Body.String())
+
+	auditSvc.Stop()
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	require.Len(t, repo.logs, 1)
+	return repo.logs[0]
+}
+
+func TestAuditLogger_RecordsMetadataWithoutBody(t *testing.T) {
+	apiKey := &service.APIKey{ID: 3, UserID: 5, Group: &service.Group{Platform: service.PlatformAnthropic}}
+	log := runAuditRequest(t, apiKey, `{"model":"claude"}`)
+
+	require.Equal(t, http.MethodPost, log.Method)
+	require.Equal(t, "/v1/messages", log.Path)
+	require.Equal(t, http.StatusOK, log.StatusCode)
+	require.Equal(t, int64(3), log.APIKeyID)
+	require.Equal(t, int64(5), log.UserID)
+	require.Equal(t, int64(9), log.AccountID)
+	require.Equal(t, "claude-audit-test", log.Model)
+	require.Equal(t, service.PlatformAnthropic, log.Platform)
+	require.Nil(t, log.RequestBody)
+	require.Nil(t, log.ResponseBody)
+	require.False(t, log.BodyTruncated)
+}
+
+func TestAuditLogger_CapturesTruncatedBodyWhenOptedIn(t *testing.T) {
+	apiKey := &service.APIKey{ID: 3, UserID: 5, AuditCaptureBody: true}
+	log := runAuditRequest(t, apiKey, `{"model":"claude"}`)
+
+	require.NotNil(t, log.RequestBody)
+	require.NotNil(t, log.ResponseBody)
+	require.Equal(t, `{"model":"claude`, *log.RequestBody)
+	require.Equal(t, `{"content":"resp`, *log.ResponseBody)
+	require.True(t, log.BodyTruncated)
+}
+
+func TestAuditLogger_DisabledPassesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(AuditLogger(service.NewAuditService(&config.Config{}, nil)))
+	r.GET("/v1/models", func(c *gin.Context) {
+		require.Nil(t, service.AuditEntryFromContext(c.Request.Context()))
+		c.Status(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextWindowLimit 转发前估算请求输入 token，明显超出模型上下文窗口（max_context_tokens）时直接返回 400，
+// 省去一次注定失败的上游请求。需开启 gateway.context_window_check，并放在 ModelAlias 之后以使用规范模型名。
+func ContextWindowLimit(billingService *service.BillingService, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !billingService.ContextWindowCheckEnabled() || c.Request.Method != http.MethodPost || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" {
+			c.Next()
+			return
+		}
+		body, ok := peekRequestBody(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		if exceeded := billingService.CheckContextWindow(model, body); exceeded != nil {
+			writeError(c, http.StatusBadRequest, exceeded.Error())
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newContextWindowTestRouter(t *testing.T, enabled bool, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Gateway.ContextWindowCheck.Enabled = enabled
+	pricing := service.NewPricingService(cfg, nil)
+	_, err := pricing.ImportPricingData([]byte(`{"small-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","max_input_tokens":50}}`), false)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(ContextWindowLimit(service.NewBillingService(cfg, pricing), AnthropicErrorWriter))
+	r.POST("/v1/messages", handler)
+	return r
+}
+
+func contextWindowTestBody(words int) string {
+	return `{"model":"small-model","messages":[{"role":"user","content":"` + strings.Repeat("word ", words) + `"}]}`
+}
+
+func TestContextWindowLimit_RejectsOversizedPrompt(t *testing.T) {
+	r := newContextWindowTestRouter(t, true, func(c *gin.Context) {
+		t.Fatal("handler should not be called")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(contextWindowTestBody(80)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Contains(t, resp.Error.Message, "100 tokens")
+	require.Contains(t, resp.Error.Message, "50 tokens")
+	require.Contains(t, resp.Error.Message, "small-model")
+}
+
+func TestContextWindowLimit_PassesWithinWindowAndWhenDisabled(t *testing.T) {
+	handler := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `"model":"small-model"`)
+		c.Status(http.StatusNoContent)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		enabled bool
+		words   int
+	}{
+		{name: "within window", enabled: true, words: 10},
+		{name: "disabled", enabled: false, words: 80},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newContextWindowTestRouter(t, tc.enabled, handler)
+			req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(contextWindowTestBody(tc.words)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusNoContent, w.Code)
+		})
+	}
+}
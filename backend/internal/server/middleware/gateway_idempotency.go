@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// gatewayIdempotencyReplayedHeader 标记响应来自幂等缓存（与管理接口的幂等响应头一致）
+const gatewayIdempotencyReplayedHeader = "X-Idempotency-Replayed"
+
+// GatewayIdempotency 支持网关请求的 Idempotency-Key 头：同一 API Key 在 TTL 内重复提交时直接返回已缓存的响应，
+// 不再转发上游，也不重复计费；首个请求仍在处理时重复提交返回 409。
+// 仅缓存完整结束的 2xx 非流式响应；错误、流式（已 Flush）与 WebSocket 响应不缓存。
+// 存储不可用时放行请求。需放在 API Key 认证之后、UsageHeaders 之前，以便连同用量响应头一起缓存。
+func GatewayIdempotency(svc *service.GatewayIdempotencyService, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader("Idempotency-Key")
+		apiKey, ok := GetAPIKeyFromContext(c)
+		if rawKey == "" || !ok || !svc.Enabled() || c.Request.Method != http.MethodPost || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		key, err := service.NormalizeIdempotencyKey(rawKey)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "Invalid Idempotency-Key header")
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, err = io.ReadAll(c.Request.Body)
+			_ = c.Request.Body.Close()
+			if err != nil {
+				// 读取失败（如超过 body 限制）时把错误原样留给 handler 处理
+				c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err: err}))
+				c.Next()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		ctx := c.Request.Context()
+		fingerprint := service.GatewayIdempotencyFingerprint(c.Request.Method, c.Request.URL.Path, body)
+		cached, err := svc.Lookup(ctx, apiKey.ID, key, fingerprint)
+		if errors.Is(err, service.ErrIdempotencyKeyConflict) {
+			writeError(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logger.LegacyPrintf("middleware.idempotency", "[GatewayIdempotency] lookup failed, processing request: api_key_id=%d err=%v", apiKey.ID, err)
+		}
+		if cached != nil {
+			writeIdempotentReplay(c, cached)
+			return
+		}
+
+		// 标记处理中：首个请求仍在处理时（常见于客户端超时重试），重复请求不转发上游，避免重复计费
+		err = svc.AcquireInFlight(ctx, apiKey.ID, key)
+		if errors.Is(err, service.ErrIdempotencyInProgress) {
+			// 首个请求可能恰好在查询之后完成
+			if cached, _ := svc.Lookup(ctx, apiKey.ID, key, fingerprint); cached != nil {
+				writeIdempotentReplay(c, cached)
+				return
+			}
+			writeError(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logger.LegacyPrintf("middleware.idempotency", "[GatewayIdempotency] acquire in-flight marker failed, processing request: api_key_id=%d err=%v", apiKey.ID, err)
+		} else {
+			// 结果缓存之后再清除标记；错误、流式与 panic 同样清除，允许客户端重试。客户端断开不影响清除
+			defer func() {
+				if err := svc.ReleaseInFlight(context.WithoutCancel(ctx), apiKey.ID, key); err != nil {
+					logger.LegacyPrintf("middleware.idempotency", "[GatewayIdempotency] release in-flight marker failed: api_key_id=%d err=%v", apiKey.ID, err)
+				}
+			}()
+		}
+
+		writer := &idempotencyCaptureWriter{ResponseWriter: c.Writer, limit: svc.MaxResponseBytes()}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.streamed || writer.hijacked || writer.overflow {
+			return
+		}
+		resp := &service.GatewayIdempotentResponse{
+			Fingerprint: fingerprint,
+			StatusCode:  writer.Status(),
+			Headers:     idempotentResponseHeaders(writer.Header()),
+			Body:        writer.buf.Bytes(),
+		}
+		if err := svc.Store(ctx, apiKey.ID, key, resp); err != nil {
+			logger.LegacyPrintf("middleware.idempotency", "[GatewayIdempotency] store failed: api_key_id=%d err=%v", apiKey.ID, err)
+		}
+	}
+}
+
+// writeIdempotentReplay 原样返回已缓存的结果
+func writeIdempotentReplay(c *gin.Context, cached *service.GatewayIdempotentResponse) {
+	for name, value := range cached.Headers {
+		c.Header(name, value)
+	}
+	c.Header(gatewayIdempotencyReplayedHeader, "true")
+	c.Status(cached.StatusCode)
+	_, _ = c.Writer.Write(cached.Body)
+	c.Abort()
+}
+
+// idempotentResponseHeaders 缓存重放所需的响应头：内容类型与用量头
+func idempotentResponseHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(service.UsageHeaderNames)+1)
+	names := append([]string{"Content-Type"}, service.UsageHeaderNames...)
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			out[name] = value
+		}
+	}
+	return out
+}
+
+// idempotencyCaptureWriter 透传响应的同时复制正文；一旦 Flush（流式）或超出长度上限即放弃缓存
+type idempotencyCaptureWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	limit    int
+	streamed bool
+	hijacked bool
+	overflow bool
+}
+
+func (w *idempotencyCaptureWriter) capture(n int, b []byte) {
+	if w.overflow || w.streamed {
+		return
+	}
+	if w.limit > 0 && w.buf.Len()+n > w.limit {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	w.buf.Write(b[:n])
+}
+
+func (w *idempotencyCaptureWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.capture(n, b)
+	return n, err
+}
+
+func (w *idempotencyCaptureWriter) WriteString(s string) (int, error) {
+	n, err := w.ResponseWriter.WriteString(s)
+	w.capture(n, []byte(s))
+	return n, err
+}
+
+func (w *idempotencyCaptureWriter) Flush() {
+	w.streamed = true
+	w.buf.Reset()
+	w.ResponseWriter.Flush()
+}
+
+func (w *idempotencyCaptureWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return w.ResponseWriter.Hijack()
+}
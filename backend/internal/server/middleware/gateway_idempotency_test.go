@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryGatewayIdempotencyCache struct {
+	mu       sync.Mutex
+	entries  map[string]*service.GatewayIdempotentResponse
+	inFlight map[string]bool
+}
+
+func (m *memoryGatewayIdempotencyCache) GetGatewayResponse(_ context.Context, key string) (*service.GatewayIdempotentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entries[key], nil
+}
+
+func (m *memoryGatewayIdempotencyCache) SetGatewayResponse(_ context.Context, key string, resp *service.GatewayIdempotentResponse, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = resp
+	return nil
+}
+
+func (m *memoryGatewayIdempotencyCache) AcquireGatewayInFlight(_ context.Context, key string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inFlight[key] {
+		return false, nil
+	}
+	m.inFlight[key] = true
+	return true, nil
+}
+
+func (m *memoryGatewayIdempotencyCache) ReleaseGatewayInFlight(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inFlight, key)
+	return nil
+}
+
+func newGatewayIdempotencyRouter(handler gin.HandlerFunc) (*gin.Engine, *memoryGatewayIdempotencyCache) {
+	gin.SetMode(gin.TestMode)
+	cache := &memoryGatewayIdempotencyCache{entries: map[string]*service.GatewayIdempotentResponse{}, inFlight: map[string]bool{}}
+	cfg := &config.Config{}
+	cfg.Idempotency.GatewayTTLSeconds = 60
+	cfg.Idempotency.GatewayMaxResponseBytes = 1024
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), &service.APIKey{ID: 7})
+		c.Next()
+	})
+	r.Use(GatewayIdempotency(service.NewGatewayIdempotencyService(cache, cfg), AnthropicErrorWriter))
+	r.POST("/v1/messages", handler)
+	return r, cache
+}
+
+func postWithIdempotencyKey(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGatewayIdempotency_ReplaysCompletedResponse(t *testing.T) {
+	calls := 0
+	r, _ := newGatewayIdempotencyRouter(func(c *gin.Context) {
+		calls++
+		c.Header(service.UsageHeaderCostUSD, "0.001000")
+		c.JSON(http.StatusOK, gin.H{"id": "msg_1"})
+	})
+
+	first := postWithIdempotencyKey(r, "retry-1", `{"model":"claude-sonnet-4"}`)
+	require.Equal(t, http.StatusOK, first.Code)
+	require.Empty(t, first.Header().Get("X-Idempotency-Replayed"))
+
+	second := postWithIdempotencyKey(r, "retry-1", `{"model":"claude-sonnet-4"}`)
+	require.Equal(t, http.StatusOK, second.Code)
+	require.JSONEq(t, `{"id":"msg_1"}`, second.Body.String())
+	require.Equal(t, "true", second.Header().Get("X-Idempotency-Replayed"))
+	require.Equal(t, "0.001000", second.Header().Get(service.UsageHeaderCostUSD))
+	require.Equal(t, 1, calls)
+
+	conflict := postWithIdempotencyKey(r, "retry-1", `{"model":"claude-opus-4"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, conflict.Code)
+	require.Equal(t, 1, calls)
+}
+
+func TestGatewayIdempotency_SkipsErrorsAndStreams(t *testing.T) {
+	r, cache := newGatewayIdempotencyRouter(func(c *gin.Context) {
+		if c.Query("stream") != "" {
+			c.Status(http.StatusOK)
+			_, _ = c.Writer.WriteString("data: hello\n\n")
+			c.Writer.Flush()
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream"})
+	})
+
+	require.Equal(t, http.StatusBadGateway, postWithIdempotencyKey(r, "err-1", `{}`).Code)
+	require.Empty(t, cache.entries)
+	// 失败后清除处理中标记，客户端可以重试
+	require.Empty(t, cache.inFlight)
+	require.Equal(t, http.StatusBadGateway, postWithIdempotencyKey(r, "err-1", `{}`).Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages?stream=1", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "stream-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Empty(t, cache.entries)
+}
+
+func TestGatewayIdempotency_RejectsDuplicateWhileInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+	r, cache := newGatewayIdempotencyRouter(func(c *gin.Context) {
+		calls++
+		close(started)
+		<-release
+		c.JSON(http.StatusOK, gin.H{"id": "msg_1"})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postWithIdempotencyKey(r, "slow-1", `{"model":"claude-sonnet-4"}`)
+	}()
+	<-started
+
+	// 首个请求仍在处理时重试：不转发，返回 409
+	duplicate := postWithIdempotencyKey(r, "slow-1", `{"model":"claude-sonnet-4"}`)
+	require.Equal(t, http.StatusConflict, duplicate.Code)
+
+	close(release)
+	first := <-done
+	require.Equal(t, http.StatusOK, first.Code)
+	require.Empty(t, cache.inFlight)
+
+	// 完成后重试返回缓存结果
+	replay := postWithIdempotencyKey(r, "slow-1", `{"model":"claude-sonnet-4"}`)
+	require.Equal(t, "true", replay.Header().Get("X-Idempotency-Replayed"))
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayLoad 统计进行中的网关请求（按模型），供自动扩缩容参考指标使用。
+// 需放在 ModelAlias 之后以按规范模型名统计；GET 请求（WebSocket 除外）不计入。
+func GatewayLoad(loadService *service.GatewayLoadService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !loadService.Enabled() || (c.Request.Method == http.MethodGet && !isWebSocketUpgrade(c)) {
+			c.Next()
+			return
+		}
+		done := loadService.Track(peekRequestModel(c))
+		defer done()
+		c.Next()
+	}
+}
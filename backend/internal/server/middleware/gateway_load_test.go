@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayLoad_TracksInFlightRequestsByModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Gateway.LoadHints.Enabled = true
+	loadService := service.NewGatewayLoadService(cfg, nil, nil, nil)
+
+	var during *service.GatewayLoadSnapshot
+	r := gin.New()
+	r.Use(GatewayLoad(loadService))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		during = loadService.Snapshot(context.Background())
+		c.Status(http.StatusOK)
+	})
+	r.GET("/v1/models", func(c *gin.Context) {
+		during = loadService.Snapshot(context.Background())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"claude-sonnet-4-5"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, int64(1), during.InFlight)
+	require.Equal(t, "claude-sonnet-4-5", during.Models[0].Model)
+	require.Equal(t, int64(0), loadService.Snapshot(context.Background()).InFlight)
+
+	// 非 WebSocket 的 GET 请求不计入
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	require.Equal(t, int64(0), during.InFlight)
+}
@@ -22,7 +22,7 @@ func Logger() gin.HandlerFunc {
 		c.Next()
 
 		// 跳过健康检查等高频探针路径的日志
-		if path == "/health" || path == "/setup/status" {
+		if path == "/health" || path == "/healthz" || path == "/readyz" || path == "/setup/status" || path == "/metrics" {
 			return
 		}
 
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayMaintenance 维护模式开启时以 503 + Retry-After 拒绝网关请求，放行列表中的 API Key 除外。
+// 需放在 API Key 认证之后；管理接口不经过该中间件，维护期间仍可访问。
+func GatewayMaintenance(svc *service.MaintenanceService, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var apiKeyID int64
+		if apiKey, ok := GetAPIKeyFromContext(c); ok {
+			apiKeyID = apiKey.ID
+		}
+		reject, message, retryAfter := svc.ShouldReject(apiKeyID)
+		if !reject {
+			c.Next()
+			return
+		}
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+		writeError(c, http.StatusServiceUnavailable, message)
+		c.Abort()
+	}
+}
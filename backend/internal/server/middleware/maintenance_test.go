@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayMaintenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewMaintenanceService(nil)
+
+	newRouter := func(apiKeyID int64) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(string(ContextKeyAPIKey), &service.APIKey{ID: apiKeyID})
+			c.Next()
+		})
+		r.Use(GatewayMaintenance(svc, AnthropicErrorWriter))
+		r.POST("/v1/messages", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return r
+	}
+	do := func(apiKeyID int64) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		newRouter(apiKeyID).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/messages", nil))
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, do(1).Code)
+
+	_, err := svc.Update(context.Background(), service.MaintenanceState{
+		Enabled:           true,
+		Message:           "Upgrading, back soon",
+		RetryAfterSeconds: 120,
+		BypassAPIKeyIDs:   []int64{2},
+	})
+	require.NoError(t, err)
+
+	rec := do(1)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "120", rec.Header().Get("Retry-After"))
+	require.Contains(t, rec.Body.String(), "Upgrading, back soon")
+
+	require.Equal(t, http.StatusOK, do(2).Code)
+}
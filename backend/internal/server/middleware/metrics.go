@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayMetrics 记录网关请求的 Prometheus 指标（按模型/上游平台/状态码计数及请求耗时）。
+// 模型与平台取自 handler 写入 request context 的值；未选中账号时回落到分组平台。
+func GatewayMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startTime := time.Now()
+		c.Next()
+
+		ctx := c.Request.Context()
+		model, _ := ctx.Value(ctxkey.Model).(string)
+		platform, _ := ctx.Value(ctxkey.Platform).(string)
+		if platform == "" {
+			if apiKey, ok := GetAPIKeyFromContext(c); ok && apiKey.Group != nil {
+				platform = apiKey.Group.Platform
+			}
+		}
+		metrics.ObserveRequest(model, platform, c.Writer.Status(), time.Since(startTime))
+	}
+}
+
+// MetricsAuth 校验 /metrics 抓取请求的 Bearer token；token 为空时不校验
+func MetricsAuth(token string) gin.HandlerFunc {
+	token = strings.TrimSpace(token)
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), []byte(token)) != 1 {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
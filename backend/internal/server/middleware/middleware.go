@@ -84,10 +84,19 @@ type GatewayErrorWriter func(c *gin.Context, status int, message string)
 
 // AnthropicErrorWriter 按 Anthropic API 规范输出错误
 func AnthropicErrorWriter(c *gin.Context, status int, message string) {
-	c.JSON(status, gin.H{
+	c.JSON(status, AnthropicErrorBody(c, "permission_error", message))
+}
+
+// AnthropicErrorBody 构造 Anthropic 格式的错误响应体，存在追踪 ID 时附带顶层 request_id 字段
+func AnthropicErrorBody(c *gin.Context, errType, message string) gin.H {
+	body := gin.H{
 		"type":  "error",
-		"error": gin.H{"type": "permission_error", "message": message},
-	})
+		"error": gin.H{"type": errType, "message": message},
+	}
+	if requestID := GetRequestIDFromContext(c); requestID != "" {
+		body["request_id"] = requestID
+	}
+	return body
 }
 
 // GoogleErrorWriter 按 Google API 规范输出错误
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireModelAccess 在路由调度前检查 API Key 的模型允许/禁止列表，
+// 请求不被允许的模型时返回 403（禁止列表优先；允许列表为空表示不限制）。
+// 需放在 API Key 认证与 ModelAlias 之后。Responses WebSocket（GET 升级请求）的模型在首帧中，由 handler 逐帧校验。
+func RequireModelAccess(writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, ok := GetAPIKeyFromContext(c)
+		if !ok || !apiKey.HasModelAccessRules() || c.Request.Method == http.MethodGet {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" || apiKey.IsModelAllowed(model) {
+			c.Next()
+			return
+		}
+		writeError(c, http.StatusForbidden, fmt.Sprintf("Model %s is not allowed for this API key", model))
+		c.Abort()
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newModelAccessTestRouter(t *testing.T, apiKey *service.APIKey) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), apiKey)
+		c.Next()
+	})
+	r.Use(RequireModelAccess(AnthropicErrorWriter))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		// 中间件读取请求体后需原样交还给 handler
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `"model"`)
+		c.Status(http.StatusOK)
+	})
+	r.POST("/v1beta/models/*modelAction", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func doModelAccessRequest(r *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequireModelAccess_AllowlistAndDenylist(t *testing.T) {
+	r := newModelAccessTestRouter(t, &service.APIKey{
+		AllowedModels: []string{"claude-*"},
+		DeniedModels:  []string{"claude-opus-4"},
+	})
+
+	w := doModelAccessRequest(r, "/v1/messages", `{"model":"claude-sonnet-4"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = doModelAccessRequest(r, "/v1/messages", `{"model":"claude-opus-4"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "claude-opus-4")
+
+	w = doModelAccessRequest(r, "/v1/messages", `{"model":"gpt-5"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "gpt-5")
+
+	w = doModelAccessRequest(r, "/v1beta/models/gemini-2.5-pro:generateContent", `{}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "gemini-2.5-pro")
+}
+
+func TestRequireModelAccess_NoRulesAllowsAll(t *testing.T) {
+	r := newModelAccessTestRouter(t, &service.APIKey{})
+
+	w := doModelAccessRequest(r, "/v1/messages", `{"model":"anything"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+}
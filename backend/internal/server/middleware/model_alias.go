@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// ModelAlias 在 handler 解析请求前把别名模型名改写为规范模型名，
+// 使账号调度、模型映射与计费都基于规范名。支持 JSON 请求体的 model 字段
+// 与 Gemini 路径中的 /models/{model}:{action}。
+func ModelAlias(billingService *service.BillingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !billingService.HasModelAliases() {
+			c.Next()
+			return
+		}
+		rewriteGeminiModelParam(c, billingService)
+		if c.Request.Body != nil && strings.Contains(c.GetHeader("Content-Type"), "json") {
+			rewriteModelInJSONBody(c, billingService)
+		}
+		c.Next()
+	}
+}
+
+func rewriteModelInJSONBody(c *gin.Context, billingService *service.BillingService) {
+	body, err := io.ReadAll(c.Request.Body)
+	_ = c.Request.Body.Close()
+	if err != nil {
+		// 读取失败（如超过 body 限制）时把错误原样留给 handler 处理
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err: err}))
+		return
+	}
+
+	if current := gjson.GetBytes(body, "model"); current.Type == gjson.String {
+		if canonical, ok := billingService.ResolveModelAlias(current.String()); ok {
+			body = service.ReplaceModelInBody(body, canonical)
+			c.Request.ContentLength = int64(len(body))
+			c.Request.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+}
+
+func rewriteGeminiModelParam(c *gin.Context, billingService *service.BillingService) {
+	for i, param := range c.Params {
+		if param.Key != "modelAction" {
+			continue
+		}
+		rest := strings.TrimPrefix(param.Value, "/")
+		end := strings.IndexAny(rest, ":/")
+		if end <= 0 {
+			return
+		}
+		if canonical, ok := billingService.ResolveModelAlias(rest[:end]); ok {
+			c.Params[i].Value = "/" + canonical + rest[end:]
+		}
+		return
+	}
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
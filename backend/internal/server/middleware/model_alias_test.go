@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newModelAliasTestRouter(t *testing.T, seen *string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	billing := service.NewBillingService(&config.Config{}, nil)
+	_, err := billing.SetModelAlias("sonnet", "claude-sonnet-4")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(ModelAlias(billing))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, len(body), c.Request.ContentLength)
+		*seen = string(body)
+		c.Status(http.StatusOK)
+	})
+	r.POST("/v1beta/models/*modelAction", func(c *gin.Context) {
+		*seen = c.Param("modelAction")
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestModelAlias_RewritesJSONBodyModel(t *testing.T) {
+	var seen string
+	r := newModelAliasTestRouter(t, &seen)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"Sonnet","max_tokens":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.JSONEq(t, `{"model":"claude-sonnet-4","max_tokens":1}`, seen)
+
+	// 非别名保持原样
+	req = httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"claude-opus-4"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, `{"model":"claude-opus-4"}`, seen)
+}
+
+func TestModelAlias_RewritesGeminiPathModel(t *testing.T) {
+	var seen string
+	r := newModelAliasTestRouter(t, &seen)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1beta/models/sonnet:generateContent", nil))
+	require.Equal(t, "/claude-sonnet-4:generateContent", seen)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1beta/models/gemini-2.5-pro:generateContent", nil))
+	require.Equal(t, "/gemini-2.5-pro:generateContent", seen)
+}
+
+func TestModelAlias_NoAliasesPassesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ModelAlias(nil))
+	var seen string
+	r.POST("/v1/messages", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		seen = string(body)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"sonnet"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, `{"model":"sonnet"}`, seen)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ModelDeprecatedHeader 请求弃用模型时返回的提示头，格式：<model>[; replacement=<model>][; routed=true]
+const ModelDeprecatedHeader = "X-Model-Deprecated"
+
+// ModelDeprecation 请求的模型在价格数据中被标记为弃用时返回 X-Model-Deprecated 提示头并照常服务；
+// 开启 pricing.deprecation_autoroute 且存在替代模型时，把请求改写为替代模型。
+// 需放在 ModelAlias 之后、其它读取模型名的中间件之前。
+func ModelDeprecation(billingService *service.BillingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" {
+			c.Next()
+			return
+		}
+		replacement, deprecated := billingService.ModelDeprecation(model)
+		if !deprecated {
+			c.Next()
+			return
+		}
+
+		notice := model
+		if replacement != "" {
+			notice += "; replacement=" + replacement
+			if billingService.DeprecationAutorouteEnabled() && routeToReplacementModel(c, model, replacement) {
+				notice += "; routed=true"
+			}
+		}
+		c.Header(ModelDeprecatedHeader, notice)
+		c.Next()
+	}
+}
+
+// routeToReplacementModel 把 Gemini 路径参数或 JSON 请求体中的模型改写为替代模型
+func routeToReplacementModel(c *gin.Context, model, replacement string) bool {
+	if replaceGeminiModelParam(c, model, replacement) {
+		c.Set(peekedRequestModelKey, replacement)
+		return true
+	}
+	body, ok := peekRequestBody(c)
+	if !ok || len(body) == 0 {
+		return false
+	}
+	setRequestBody(c, service.ReplaceModelInBody(body, replacement))
+	c.Set(peekedRequestModelKey, replacement)
+	return true
+}
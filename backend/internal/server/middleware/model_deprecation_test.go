@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newModelDeprecationTestRouter(t *testing.T, autoroute bool, seen *string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.DeprecationAutoroute = autoroute
+	pricing := service.NewPricingService(cfg, nil)
+	_, err := pricing.ImportPricingData([]byte(`{
+		"old-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","deprecated":true,"replacement":"new-model"},
+		"sunset-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","deprecation_date":"2020-01-01"},
+		"new-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat"}
+	}`), false)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(ModelDeprecation(service.NewBillingService(cfg, pricing)))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, len(body), c.Request.ContentLength)
+		*seen = string(body)
+		c.Status(http.StatusOK)
+	})
+	r.POST("/v1beta/models/*modelAction", func(c *gin.Context) {
+		*seen = c.Param("modelAction")
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func serveModelDeprecationRequest(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestModelDeprecation_WarnsAndStillServesDeprecatedModel(t *testing.T) {
+	var seen string
+	r := newModelDeprecationTestRouter(t, false, &seen)
+
+	w := serveModelDeprecationRequest(r, `{"model":"old-model"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "old-model; replacement=new-model", w.Header().Get(ModelDeprecatedHeader))
+	require.Equal(t, `{"model":"old-model"}`, seen)
+
+	// 弃用日期已到、无替代模型
+	w = serveModelDeprecationRequest(r, `{"model":"sunset-model"}`)
+	require.Equal(t, "sunset-model", w.Header().Get(ModelDeprecatedHeader))
+
+	w = serveModelDeprecationRequest(r, `{"model":"new-model"}`)
+	require.Empty(t, w.Header().Get(ModelDeprecatedHeader))
+}
+
+func TestModelDeprecation_AutoroutesToReplacement(t *testing.T) {
+	var seen string
+	r := newModelDeprecationTestRouter(t, true, &seen)
+
+	w := serveModelDeprecationRequest(r, `{"model":"old-model","max_tokens":1}`)
+	require.Equal(t, "old-model; replacement=new-model; routed=true", w.Header().Get(ModelDeprecatedHeader))
+	require.JSONEq(t, `{"model":"new-model","max_tokens":1}`, seen)
+
+	// 无替代模型时照常服务
+	w = serveModelDeprecationRequest(r, `{"model":"sunset-model"}`)
+	require.Equal(t, "sunset-model", w.Header().Get(ModelDeprecatedHeader))
+	require.Equal(t, `{"model":"sunset-model"}`, seen)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1beta/models/old-model:generateContent", nil))
+	require.Equal(t, "/new-model:generateContent", seen)
+	require.Contains(t, w.Header().Get(ModelDeprecatedHeader), "routed=true")
+}
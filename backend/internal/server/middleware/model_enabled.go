@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireModelEnabled 在路由调度前拦截管理员已在价格目录中禁用的模型或厂商，返回 403
+// （错误信息带 PRICING_MODEL_DISABLED / PRICING_PROVIDER_DISABLED 原因码）。需放在 ModelAlias 之后以按规范模型名判断。
+func RequireModelEnabled(billingService *service.BillingService, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if billingService == nil || c.Request.Method != http.MethodPost || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" {
+			c.Next()
+			return
+		}
+		if err := billingService.CheckModelEnabled(model); err != nil {
+			writeError(c, http.StatusForbidden, fmt.Sprintf("%s: model %s is disabled", infraerrors.Reason(err), model))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireModelEnabled_RejectsDisabledModelAfterAlias(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	billing := service.NewBillingService(&config.Config{}, nil)
+	_, err := billing.SetModelAlias("acme-large", "claude-sonnet-4")
+	require.NoError(t, err)
+	_, err = billing.DisableModel("claude-sonnet-4")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(ModelAlias(billing), RequireModelEnabled(billing, AnthropicErrorWriter))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, tc := range []struct {
+		model string
+		want  int
+	}{
+		{model: "claude-sonnet-4", want: http.StatusForbidden},
+		{model: "acme-large", want: http.StatusForbidden},
+		{model: "claude-haiku-4", want: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"`+tc.model+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		require.Equal(t, tc.want, w.Code, tc.model)
+		if tc.want == http.StatusForbidden {
+			require.Contains(t, w.Body.String(), "PRICING_MODEL_DISABLED")
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"go.uber.org/zap"
+)
+
+const (
+	// ModelFallbackHeader 请求级后备模型链，逗号分隔，如 "gpt-4o, claude-3-5-sonnet"
+	ModelFallbackHeader = "X-Model-Fallback"
+	// ModelServedHeader 携带后备模型链的请求实际由哪个模型服务
+	ModelServedHeader = "X-Model-Served"
+
+	// modelFallbackBodyField 请求体中的后备模型链（字符串数组），优先于请求头，转发上游前移除
+	modelFallbackBodyField = "fallback_models"
+	// maxModelFallbacks 单个请求最多尝试的后备模型数
+	maxModelFallbacks = 3
+)
+
+// ModelFallback 按请求携带的后备模型链依次重试：当前模型在开始写出响应前以可重试错误失败
+// （429、5xx、529，含无可用账号的 503）时，把请求改写为下一个模型并重新执行 handler，
+// 计费与使用日志随之记录实际服务的模型。不在 API Key 允许列表内或已被禁用的候选模型会被跳过。
+// 开启模型名屏蔽时，X-Model-Served 与响应中回显的是客户端填写的后备模型名。
+// 只重新执行 handler，需放在 handler 之前的最后一个中间件。
+func ModelFallback(billingService *service.BillingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		var bodyFallbacks []string
+		if c.Request.Body != nil && strings.Contains(c.GetHeader("Content-Type"), "json") {
+			peeked, ok := peekRequestBody(c)
+			if !ok {
+				c.Next()
+				return
+			}
+			body = peeked
+			if field := gjson.GetBytes(body, modelFallbackBodyField); field.Exists() {
+				bodyFallbacks = parseModelFallbackField(field)
+				if stripped, err := sjson.DeleteBytes(body, modelFallbackBodyField); err == nil {
+					body = stripped
+				}
+				setRequestBody(c, body)
+			}
+		}
+
+		model := peekRequestModel(c)
+		candidates := modelFallbackCandidates(c, billingService, model, bodyFallbacks)
+		if len(candidates) == 0 {
+			c.Next()
+			return
+		}
+
+		origWriter := c.Writer
+		headerSnapshot := origWriter.Header().Clone()
+		writer := &modelFallbackWriter{ResponseWriter: origWriter}
+		c.Writer = writer
+		defer func() { c.Writer = origWriter }()
+
+		for attempt := 0; ; attempt++ {
+			writer.canFallback = attempt < len(candidates)
+			served := model
+			if masked, ok := maskedResponseModel(c); ok {
+				served = masked
+			}
+			c.Header(ModelServedHeader, served)
+			if attempt == 0 {
+				c.Next()
+			} else {
+				c.Handler()(c)
+			}
+			if !writer.suppressed {
+				return
+			}
+
+			next := candidates[attempt]
+			logger.FromContext(c.Request.Context()).Warn("gateway.model_fallback",
+				zap.String("model", model),
+				zap.String("fallback_model", next.model),
+				zap.Int("status_code", writer.status),
+				zap.Int("attempt", attempt+1),
+			)
+			restoreResponseHeader(origWriter.Header(), headerSnapshot)
+			writer.suppressed, writer.status = false, 0
+			if !replaceGeminiModelParam(c, model, next.model) && body != nil {
+				body = service.ReplaceModelInBody(body, next.model)
+			}
+			if body != nil {
+				setRequestBody(c, body)
+			}
+			c.Set(peekedRequestModelKey, next.model)
+			if _, ok := maskedResponseModel(c); ok {
+				c.Set(maskedResponseModelKey, next.requested)
+			}
+			model = next.model
+		}
+	}
+}
+
+// modelFallbackCandidate 后备模型：model 为解析别名后的规范名，requested 为客户端填写的名称
+type modelFallbackCandidate struct {
+	model     string
+	requested string
+}
+
+// modelFallbackCandidates 解析后备模型链：解析别名、去重、跳过主模型与 API Key 不允许的模型
+func modelFallbackCandidates(c *gin.Context, billingService *service.BillingService, primary string, fromBody []string) []modelFallbackCandidate {
+	if primary == "" {
+		return nil
+	}
+	raw := fromBody
+	if len(raw) == 0 {
+		raw = strings.Split(c.GetHeader(ModelFallbackHeader), ",")
+	}
+	apiKey, _ := GetAPIKeyFromContext(c)
+	seen := map[string]struct{}{primary: {}}
+	var candidates []modelFallbackCandidate
+	for _, requested := range raw {
+		requested = strings.TrimSpace(requested)
+		if requested == "" {
+			continue
+		}
+		model := requested
+		if canonical, ok := billingService.ResolveModelAlias(model); ok {
+			model = canonical
+		}
+		if _, dup := seen[model]; dup {
+			continue
+		}
+		seen[model] = struct{}{}
+		if apiKey != nil && !apiKey.IsModelAllowed(model) {
+			continue
+		}
+		if billingService.CheckModelEnabled(model) != nil {
+			continue
+		}
+		candidates = append(candidates, modelFallbackCandidate{model: model, requested: requested})
+		if len(candidates) == maxModelFallbacks {
+			break
+		}
+	}
+	return candidates
+}
+
+// parseModelFallbackField 请求体字段支持字符串数组或逗号分隔的字符串
+func parseModelFallbackField(field gjson.Result) []string {
+	if field.Type == gjson.String {
+		return strings.Split(field.String(), ",")
+	}
+	var models []string
+	for _, item := range field.Array() {
+		if item.Type == gjson.String {
+			models = append(models, item.String())
+		}
+	}
+	return models
+}
+
+func restoreResponseHeader(header, snapshot http.Header) {
+	for key := range header {
+		delete(header, key)
+	}
+	for key, values := range snapshot {
+		header[key] = values
+	}
+}
+
+// isModelFallbackStatus 换用后备模型可能成功的错误状态码
+func isModelFallbackStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout, 529:
+		return true
+	default:
+		return false
+	}
+}
+
+// modelFallbackWriter 在尚未写出任何内容时拦截可重试的错误响应，丢弃其响应体，
+// 由 ModelFallback 换用下一个模型重试；已开始写出（含流式响应）后一律透传。
+type modelFallbackWriter struct {
+	gin.ResponseWriter
+	canFallback bool
+	suppressed  bool
+	status      int
+}
+
+func (w *modelFallbackWriter) WriteHeader(code int) {
+	if w.suppressed {
+		return
+	}
+	if w.canFallback && !w.ResponseWriter.Written() && isModelFallbackStatus(code) {
+		w.suppressed = true
+		w.status = code
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *modelFallbackWriter) WriteHeaderNow() {
+	if !w.suppressed {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *modelFallbackWriter) Write(b []byte) (int, error) {
+	if w.suppressed {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *modelFallbackWriter) WriteString(s string) (int, error) {
+	if w.suppressed {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *modelFallbackWriter) Flush() {
+	if !w.suppressed {
+		w.ResponseWriter.Flush()
+	}
+}
+
+func (w *modelFallbackWriter) Status() int {
+	if w.suppressed {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *modelFallbackWriter) Written() bool {
+	return w.suppressed || w.ResponseWriter.Written()
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// newModelFallbackTestRouter handler 按模型返回 statuses 中配置的状态码（未配置时 200），并记录每次尝试收到的请求体
+func newModelFallbackTestRouter(t *testing.T, apiKey *service.APIKey, statuses map[string]int, attempts *[]string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if apiKey != nil {
+			c.Set(string(ContextKeyAPIKey), apiKey)
+		}
+		c.Next()
+	})
+	r.Use(ModelFallback(nil))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, len(body), c.Request.ContentLength)
+		*attempts = append(*attempts, string(body))
+		model := gjson.GetBytes(body, "model").String()
+		c.Header("X-Attempt-Model", model)
+		status := statuses[model]
+		if status == 0 {
+			status = http.StatusOK
+		}
+		c.JSON(status, gin.H{"model": model})
+	})
+	r.POST("/v1beta/models/*modelAction", func(c *gin.Context) {
+		*attempts = append(*attempts, c.Param("modelAction"))
+		if status := statuses[requestModelFromGeminiParam(c)]; status != 0 {
+			c.Status(status)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func serveModelFallbackRequest(r *gin.Engine, path, body, fallback string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if fallback != "" {
+		req.Header.Set(ModelFallbackHeader, fallback)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestModelFallback_RetriesNextModelOnRetriableError(t *testing.T) {
+	var attempts []string
+	r := newModelFallbackTestRouter(t, nil, map[string]int{"gpt-4o": http.StatusServiceUnavailable}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"gpt-4o","max_tokens":8}`, "claude-3-5-sonnet")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"model":"claude-3-5-sonnet"}`, w.Body.String())
+	require.Equal(t, "claude-3-5-sonnet", w.Header().Get(ModelServedHeader))
+	require.Equal(t, "claude-3-5-sonnet", w.Header().Get("X-Attempt-Model"))
+	require.Len(t, attempts, 2)
+	require.Equal(t, "claude-3-5-sonnet", gjson.Get(attempts[1], "model").String())
+	require.Equal(t, int64(8), gjson.Get(attempts[1], "max_tokens").Int())
+}
+
+func TestModelFallback_BodyFieldIsStrippedBeforeForwarding(t *testing.T) {
+	var attempts []string
+	r := newModelFallbackTestRouter(t, nil, map[string]int{"a": http.StatusTooManyRequests, "b": http.StatusBadGateway}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"a","fallback_models":["b","c"]}`, "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "c", w.Header().Get(ModelServedHeader))
+	require.Len(t, attempts, 3)
+	for _, body := range attempts {
+		require.False(t, gjson.Get(body, "fallback_models").Exists())
+	}
+}
+
+func TestModelFallback_NonRetriableErrorIsReturned(t *testing.T) {
+	var attempts []string
+	r := newModelFallbackTestRouter(t, nil, map[string]int{"a": http.StatusBadRequest}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"a"}`, "b")
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Len(t, attempts, 1)
+}
+
+func TestModelFallback_LastErrorPassesThroughWhenChainExhausted(t *testing.T) {
+	var attempts []string
+	r := newModelFallbackTestRouter(t, nil, map[string]int{"a": http.StatusServiceUnavailable, "b": http.StatusTooManyRequests}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"a"}`, "b")
+
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, `{"model":"b"}`, w.Body.String())
+	require.Equal(t, "b", w.Header().Get("X-Attempt-Model"))
+	require.Len(t, attempts, 2)
+}
+
+func TestModelFallback_SkipsModelsNotAllowedForKey(t *testing.T) {
+	var attempts []string
+	apiKey := &service.APIKey{AllowedModels: []string{"a", "c"}}
+	r := newModelFallbackTestRouter(t, apiKey, map[string]int{"a": http.StatusServiceUnavailable}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"a"}`, "b, a, c")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "c", w.Header().Get(ModelServedHeader))
+	require.Len(t, attempts, 2)
+}
+
+func TestModelFallback_NoFallbackAfterResponseStarted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	r := gin.New()
+	r.Use(ModelFallback(nil))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		calls++
+		c.Writer.WriteHeader(http.StatusOK)
+		_, _ = c.Writer.WriteString("data: {}\n\n")
+		c.Writer.Flush()
+		// 流已开始后的错误不再触发后备模型
+		c.Status(http.StatusBadGateway)
+	})
+
+	w := serveModelFallbackRequest(r, "/v1/messages", `{"model":"a"}`, "b")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, calls)
+}
+
+func TestModelFallback_RewritesGeminiPathModel(t *testing.T) {
+	var attempts []string
+	r := newModelFallbackTestRouter(t, nil, map[string]int{"gemini-2.5-pro": http.StatusServiceUnavailable}, &attempts)
+
+	w := serveModelFallbackRequest(r, "/v1beta/models/gemini-2.5-pro:streamGenerateContent", `{"contents":[]}`, "gemini-2.5-flash")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"/gemini-2.5-pro:streamGenerateContent", "/gemini-2.5-flash:streamGenerateContent"}, attempts)
+	require.Equal(t, "gemini-2.5-flash", w.Header().Get(ModelServedHeader))
+}
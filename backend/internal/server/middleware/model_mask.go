@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// maskedResponseModelKey 开启模型名屏蔽时，响应中回显的客户端请求模型名
+const maskedResponseModelKey = "middleware_masked_response_model"
+
+// modelMaskFields 响应中携带模型名的字段：OpenAI/Chat 的 model、Anthropic message_start 的 message.model、
+// Responses 流事件的 response.model 与 Gemini 的 modelVersion
+var modelMaskFields = []string{"model", "message.model", "response.model", "modelVersion"}
+
+// ModelMask 对开启 mask_response_model 的 API Key（或全局开启时的全部 Key）把响应中的模型名
+// 改写为客户端请求的模型名（如别名），隐藏实际服务的上游模型。只改写响应，计费仍按实际模型。
+// 需放在 ModelAlias 之前以记录别名解析前的模型名。JSON 响应缓冲后改写，SSE 按行改写，
+// 压缩或其它类型的响应原样透传。WebSocket 升级请求跳过，由 handler 逐帧改写。
+func ModelMask(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		apiKey, _ := GetAPIKeyFromContext(c)
+		if !ResponseModelMaskEnabled(cfg, apiKey) {
+			c.Next()
+			return
+		}
+		requested := requestModelFromGeminiParam(c)
+		if requested == "" && c.Request.Body != nil && strings.Contains(c.GetHeader("Content-Type"), "json") {
+			requested = peekModelInJSONBody(c)
+		}
+		if requested == "" {
+			c.Next()
+			return
+		}
+		c.Set(maskedResponseModelKey, requested)
+
+		writer := &modelMaskWriter{ResponseWriter: c.Writer, c: c}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+		writer.finish()
+	}
+}
+
+// ResponseModelMaskEnabled 全局开启 mask_response_model 或 API Key 单独开启时返回 true
+func ResponseModelMaskEnabled(cfg *config.Config, apiKey *service.APIKey) bool {
+	if cfg != nil && cfg.Gateway.MaskResponseModel {
+		return true
+	}
+	return apiKey != nil && apiKey.MaskResponseModel
+}
+
+// maskedResponseModel 返回响应中应回显的模型名；未开启屏蔽时返回 false
+func maskedResponseModel(c *gin.Context) (string, bool) {
+	value, ok := c.Get(maskedResponseModelKey)
+	if !ok {
+		return "", false
+	}
+	model, _ := value.(string)
+	return model, model != ""
+}
+
+// MaskModelInJSON 把 JSON 对象（或 Gemini 的对象数组）中存在的模型名字段改写为 model。
+// Responses WebSocket 不经过 ModelMask，由 handler 对每个下行事件调用
+func MaskModelInJSON(data []byte, model string) []byte {
+	if !bytes.Contains(data, []byte(`"model`)) {
+		return data
+	}
+	parsed := gjson.ParseBytes(data)
+	prefixes := []string{""}
+	if parsed.IsArray() {
+		prefixes = prefixes[:0]
+		for i := range parsed.Array() {
+			prefixes = append(prefixes, strconv.Itoa(i)+".")
+		}
+	} else if !parsed.IsObject() {
+		return data
+	}
+	for _, prefix := range prefixes {
+		for _, field := range modelMaskFields {
+			path := prefix + field
+			if current := gjson.GetBytes(data, path); current.Type != gjson.String || current.String() == model {
+				continue
+			}
+			if updated, err := sjson.SetBytes(data, path, model); err == nil {
+				data = updated
+			}
+		}
+	}
+	return data
+}
+
+// maskModelInSSELine 改写单行 SSE 的 data 负载，其余行原样返回
+func maskModelInSSELine(line []byte, model string) []byte {
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return line
+	}
+	start := len(line) - len(bytes.TrimLeft(line[len("data:"):], " "))
+	end := len(bytes.TrimRight(line, "\r\n"))
+	if start >= end || line[start] != '{' {
+		return line
+	}
+	masked := MaskModelInJSON(line[start:end], model)
+	out := make([]byte, 0, start+len(masked)+len(line)-end)
+	out = append(out, line[:start]...)
+	out = append(out, masked...)
+	return append(out, line[end:]...)
+}
+
+type modelMaskMode int
+
+const (
+	modelMaskUndecided modelMaskMode = iota
+	modelMaskPassthrough
+	modelMaskSSE
+	modelMaskBuffer
+)
+
+// modelMaskWriter 首次写出时按 Content-Type 决定改写方式：JSON 缓冲到请求结束后整体改写，
+// SSE 逐个完整行改写（不完整的行暂存到下一次写入），其余透传。
+type modelMaskWriter struct {
+	gin.ResponseWriter
+	c    *gin.Context
+	mode modelMaskMode
+	buf  bytes.Buffer
+}
+
+func (w *modelMaskWriter) decide() {
+	if w.mode != modelMaskUndecided {
+		return
+	}
+	header := w.ResponseWriter.Header()
+	contentType := header.Get("Content-Type")
+	switch {
+	case header.Get("Content-Encoding") != "" && !strings.EqualFold(header.Get("Content-Encoding"), "identity"):
+		w.mode = modelMaskPassthrough
+	case strings.Contains(contentType, "text/event-stream"):
+		w.mode = modelMaskSSE
+	case strings.Contains(contentType, "json"):
+		w.mode = modelMaskBuffer
+		// 改写后长度会变化
+		header.Del("Content-Length")
+	default:
+		w.mode = modelMaskPassthrough
+	}
+}
+
+func (w *modelMaskWriter) WriteHeaderNow() {
+	w.decide()
+	if w.mode != modelMaskBuffer {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *modelMaskWriter) Write(b []byte) (int, error) {
+	w.decide()
+	switch w.mode {
+	case modelMaskBuffer:
+		return w.buf.Write(b)
+	case modelMaskSSE:
+		w.buf.Write(b)
+		if err := w.writeCompleteLines(); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	default:
+		return w.ResponseWriter.Write(b)
+	}
+}
+
+func (w *modelMaskWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush 缓冲模式下忽略，JSON 响应在请求结束后一次写出
+func (w *modelMaskWriter) Flush() {
+	w.decide()
+	if w.mode == modelMaskBuffer {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *modelMaskWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+func (w *modelMaskWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.mode = modelMaskPassthrough
+	return w.ResponseWriter.Hijack()
+}
+
+// writeCompleteLines 改写并写出缓冲区中已完整的行
+func (w *modelMaskWriter) writeCompleteLines() error {
+	data := w.buf.Bytes()
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return nil
+	}
+	model, _ := maskedResponseModel(w.c)
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(data[:end+1], []byte("\n")) {
+		out.Write(maskModelInSSELine(line, model))
+	}
+	rest := append([]byte(nil), data[end+1:]...)
+	w.buf.Reset()
+	w.buf.Write(rest)
+	_, err := w.ResponseWriter.Write(out.Bytes())
+	return err
+}
+
+// finish 写出缓冲的 JSON 响应或流末尾不完整的行
+func (w *modelMaskWriter) finish() {
+	if w.buf.Len() == 0 {
+		return
+	}
+	model, _ := maskedResponseModel(w.c)
+	data := w.buf.Bytes()
+	if w.mode == modelMaskSSE {
+		data = maskModelInSSELine(data, model)
+	} else {
+		data = MaskModelInJSON(data, model)
+	}
+	w.buf.Reset()
+	_, _ = w.ResponseWriter.Write(data)
+}
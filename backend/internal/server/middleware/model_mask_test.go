@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// newModelMaskTestRouter 经别名解析后，handler 以 upstream 作为实际模型写出响应
+func newModelMaskTestRouter(t *testing.T, cfg *config.Config, apiKey *service.APIKey, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	billing := service.NewBillingService(&config.Config{}, nil)
+	_, err := billing.SetModelAlias("acme-large", "claude-sonnet-4")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if apiKey != nil {
+			c.Set(string(ContextKeyAPIKey), apiKey)
+		}
+		c.Next()
+	})
+	r.Use(ModelMask(cfg), ModelAlias(billing))
+	r.POST("/v1/messages", handler)
+	r.POST("/v1beta/models/*modelAction", handler)
+	return r
+}
+
+func serveModelMaskRequest(r *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestModelMask_RewritesJSONResponseForKey(t *testing.T) {
+	var seenModel string
+	r := newModelMaskTestRouter(t, &config.Config{}, &service.APIKey{MaskResponseModel: true}, func(c *gin.Context) {
+		seenModel = peekRequestModel(c)
+		c.Header("Content-Length", "999")
+		c.JSON(http.StatusOK, gin.H{"id": "msg_1", "model": "claude-sonnet-4-20250514", "usage": gin.H{"input_tokens": 3}})
+	})
+
+	w := serveModelMaskRequest(r, "/v1/messages", `{"model":"acme-large"}`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "claude-sonnet-4", seenModel)
+	require.JSONEq(t, `{"id":"msg_1","model":"acme-large","usage":{"input_tokens":3}}`, w.Body.String())
+	require.Empty(t, w.Header().Get("Content-Length"))
+}
+
+func TestModelMask_DisabledLeavesResponseUntouched(t *testing.T) {
+	r := newModelMaskTestRouter(t, &config.Config{}, &service.APIKey{}, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"model": "claude-sonnet-4-20250514"})
+	})
+
+	w := serveModelMaskRequest(r, "/v1/messages", `{"model":"acme-large"}`)
+
+	require.JSONEq(t, `{"model":"claude-sonnet-4-20250514"}`, w.Body.String())
+}
+
+func TestModelMask_RewritesSSEAcrossPartialWrites(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.MaskResponseModel = true
+	r := newModelMaskTestRouter(t, cfg, nil, func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		_, _ = c.Writer.WriteString("event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"model\":\"claude-")
+		c.Writer.Flush()
+		_, _ = c.Writer.WriteString("sonnet-4-20250514\"}}\n\n: ping\n\n")
+		_, _ = c.Writer.WriteString("data: {\"type\":\"response.created\",\"response\":{\"model\":\"gpt-5\"}}")
+	})
+
+	w := serveModelMaskRequest(r, "/v1/messages", `{"model":"acme-large"}`)
+
+	require.Equal(t, "event: message_start\n"+
+		"data: {\"type\":\"message_start\",\"message\":{\"model\":\"acme-large\"}}\n\n"+
+		": ping\n\n"+
+		"data: {\"type\":\"response.created\",\"response\":{\"model\":\"acme-large\"}}", w.Body.String())
+}
+
+func TestModelMask_RewritesGeminiModelVersion(t *testing.T) {
+	r := newModelMaskTestRouter(t, &config.Config{}, &service.APIKey{MaskResponseModel: true}, func(c *gin.Context) {
+		require.Equal(t, "/claude-sonnet-4:generateContent", c.Param("modelAction"))
+		c.Data(http.StatusOK, "application/json", []byte(`[{"candidates":[],"modelVersion":"gemini-2.5-pro-001"},{"modelVersion":"gemini-2.5-pro-001"}]`))
+	})
+
+	w := serveModelMaskRequest(r, "/v1beta/models/acme-large:generateContent", `{"contents":[]}`)
+
+	require.JSONEq(t, `[{"candidates":[],"modelVersion":"acme-large"},{"modelVersion":"acme-large"}]`, w.Body.String())
+}
+
+func TestModelMask_CompressedResponsePassesThrough(t *testing.T) {
+	r := newModelMaskTestRouter(t, &config.Config{}, &service.APIKey{MaskResponseModel: true}, func(c *gin.Context) {
+		c.Header("Content-Encoding", "gzip")
+		c.Data(http.StatusOK, "application/json", []byte("gzipped"))
+	})
+
+	w := serveModelMaskRequest(r, "/v1/messages", `{"model":"acme-large"}`)
+
+	require.Equal(t, "gzipped", w.Body.String())
+}
+
+func TestModelMask_FallbackEchoesRequestedFallbackName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	billing := service.NewBillingService(&config.Config{}, nil)
+	_, err := billing.SetModelAlias("acme-small", "claude-haiku-4")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), &service.APIKey{MaskResponseModel: true})
+		c.Next()
+	})
+	r.Use(ModelMask(nil), ModelAlias(billing), ModelFallback(billing))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		model := peekRequestModel(c)
+		if model == "claude-sonnet-4" {
+			c.Status(http.StatusServiceUnavailable)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"model": model})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"claude-sonnet-4"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ModelFallbackHeader, "acme-small")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "acme-small", w.Header().Get(ModelServedHeader))
+	require.JSONEq(t, `{"model":"acme-small"}`, w.Body.String())
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ModelTimeout 按模型为网关请求设置总超时（含流式），需放在 ModelAlias 之后以使用规范模型名。
+// 超时后取消上游请求；若尚未向客户端写出任何内容，返回带模型名的 504。
+func ModelTimeout(billingService *service.BillingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !billingService.HasModelTimeouts() || c.Request.Method == http.MethodGet || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" {
+			c.Next()
+			return
+		}
+		seconds, _ := billingService.EffectiveModelTimeout(model)
+		if seconds <= 0 {
+			c.Next()
+			return
+		}
+
+		timeout := time.Duration(seconds) * time.Second
+		ctx, cancel := service.WithModelTimeout(c.Request.Context(), model, timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		writer := &modelTimeoutWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if _, _, exceeded := service.ModelTimeoutExceeded(ctx); !exceeded || c.Writer.Written() {
+			return
+		}
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"type": "error",
+			"error": gin.H{
+				"type":    "timeout_error",
+				"message": fmt.Sprintf("Upstream request for model %s timed out after %ds", model, seconds),
+			},
+		})
+	}
+}
+
+// modelTimeoutWriter 超时后若客户端尚未收到任何内容，丢弃 handler 的错误响应，
+// 由中间件统一返回 504；已开始写出（如流式）的响应保持不变。
+type modelTimeoutWriter struct {
+	gin.ResponseWriter
+	ctx context.Context
+
+	mu         sync.Mutex
+	suppressed bool
+}
+
+func (w *modelTimeoutWriter) suppress() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.suppressed {
+		return true
+	}
+	if _, _, exceeded := service.ModelTimeoutExceeded(w.ctx); exceeded && !w.ResponseWriter.Written() {
+		w.suppressed = true
+	}
+	return w.suppressed
+}
+
+func (w *modelTimeoutWriter) WriteHeader(code int) {
+	if w.suppress() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *modelTimeoutWriter) WriteHeaderNow() {
+	if w.suppress() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *modelTimeoutWriter) Write(b []byte) (int, error) {
+	if w.suppress() {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *modelTimeoutWriter) WriteString(s string) (int, error) {
+	if w.suppress() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *modelTimeoutWriter) Flush() {
+	if w.suppress() {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
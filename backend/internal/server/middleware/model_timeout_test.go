@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newModelTimeoutTestRouter(t *testing.T, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	billing := service.NewBillingService(&config.Config{}, nil)
+	require.NoError(t, billing.SetModelTimeout("slow-model", 1))
+
+	r := gin.New()
+	r.Use(ModelTimeout(billing))
+	r.POST("/v1/messages", handler)
+	return r
+}
+
+func TestModelTimeout_Returns504WithModelName(t *testing.T) {
+	r := newModelTimeoutTestRouter(t, func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"model":"slow-model"}`, string(body))
+
+		// 模拟上游请求被超时取消后 handler 写出的 502
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream failed"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"slow-model"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusGatewayTimeout, w.Code)
+	var resp struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "timeout_error", resp.Error.Type)
+	require.Contains(t, resp.Error.Message, "slow-model")
+	require.Contains(t, resp.Error.Message, "1s")
+}
+
+func TestModelTimeout_SkipsModelsWithoutTimeout(t *testing.T) {
+	r := newModelTimeoutTestRouter(t, func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		require.False(t, hasDeadline)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"fast-model"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestModelTimeout_KeepsStartedStream(t *testing.T) {
+	r := newModelTimeoutTestRouter(t, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+		_, _ = c.Writer.WriteString("data: partial\n\n")
+		c.Writer.Flush()
+		<-c.Request.Context().Done()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(`{"model":"slow-model","stream":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "data: partial\n\n", w.Body.String())
+}
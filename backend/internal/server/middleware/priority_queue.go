@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayPriorityQueue 并发已满时按 API Key 的优先级层级排队，等待超时或队列已满时返回 429。
+// 需放在 API Key 认证之后；槽位在后续处理（含流式响应）全部结束后释放。
+func GatewayPriorityQueue(queue *service.GatewayPriorityQueue, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !queue.Enabled() {
+			c.Next()
+			return
+		}
+		tier := 0
+		if apiKey, ok := GetAPIKeyFromContext(c); ok {
+			tier = apiKey.PriorityTier
+		}
+		release, err := queue.Acquire(c.Request.Context(), tier)
+		if err != nil {
+			if errors.Is(err, service.ErrPriorityQueueFull) || errors.Is(err, service.ErrPriorityQueueTimeout) {
+				writeError(c, http.StatusTooManyRequests, "Server is busy, please retry later.")
+			}
+			// 客户端已断开时无需响应
+			c.Abort()
+			return
+		}
+		defer release()
+		c.Next()
+	}
+}
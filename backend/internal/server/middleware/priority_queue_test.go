@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayPriorityQueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Gateway.PriorityQueue = config.PriorityQueueConfig{Enabled: true, MaxConcurrentRequests: 1, MaxWaitSeconds: 1}
+	queue := service.NewGatewayPriorityQueue(cfg)
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), &service.APIKey{ID: 1})
+		c.Next()
+	})
+	r.Use(GatewayPriorityQueue(queue, AnthropicErrorWriter))
+	r.POST("/v1/messages", func(c *gin.Context) {
+		if c.Query("block") == "1" {
+			close(entered)
+			<-unblock
+		}
+		c.Status(http.StatusOK)
+	})
+	do := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec
+	}
+
+	done := make(chan int)
+	go func() { done <- do("/v1/messages?block=1").Code }()
+	<-entered
+
+	// 并发已满，等待超过 max_wait_seconds 后返回 429
+	rec := do("/v1/messages")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Contains(t, rec.Body.String(), "Server is busy")
+
+	close(unblock)
+	select {
+	case code := <-done:
+		require.Equal(t, http.StatusOK, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked request did not finish")
+	}
+	require.Equal(t, http.StatusOK, do("/v1/messages").Code)
+	require.Zero(t, queue.Status().Active)
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -112,6 +113,77 @@ func TestRequestLogger_KeepIncomingRequestID(t *testing.T) {
 	}
 }
 
+func TestRequestLogger_ReplaceInvalidIncomingRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestLogger())
+	r.GET("/t", func(c *gin.Context) {
+		c.String(http.StatusOK, GetRequestIDFromContext(c))
+	})
+
+	for _, incoming := range []string{"bad id", "rid<script>", strings.Repeat("a", 129)} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/t", nil)
+		req.Header.Set(requestIDHeader, incoming)
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get(requestIDHeader)
+		if got == "" || got == incoming {
+			t.Fatalf("incoming=%q header=%q, want generated id", incoming, got)
+		}
+		if w.Body.String() != got {
+			t.Fatalf("context request_id=%q, header=%q", w.Body.String(), got)
+		}
+	}
+}
+
+func TestRequestLogger_PinRequestIDOverUpstreamHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestLogger())
+	r.GET("/t", func(c *gin.Context) {
+		// 模拟网关把上游响应的 x-request-id 复制到客户端响应
+		c.Header("x-request-id", "upstream-req-1")
+		c.Writer.WriteHeaderNow()
+		_, _ = c.Writer.Write([]byte("data: {}\n\n"))
+		c.Writer.Flush()
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/t", nil)
+	req.Header.Set(requestIDHeader, "client-trace:1")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(requestIDHeader); got != "client-trace:1" {
+		t.Fatalf("header=%q, want client-trace:1", got)
+	}
+	if got := w.Header().Get(upstreamRequestIDHeader); got != "upstream-req-1" {
+		t.Fatalf("upstream header=%q, want upstream-req-1", got)
+	}
+}
+
+func TestAnthropicErrorWriter_IncludesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestLogger())
+	r.GET("/t", func(c *gin.Context) {
+		AnthropicErrorWriter(c, http.StatusForbidden, "denied")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/t", nil)
+	req.Header.Set(requestIDHeader, "rid-err")
+	r.ServeHTTP(w, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body["request_id"] != "rid-err" {
+		t.Fatalf("request_id=%v, want rid-err", body["request_id"])
+	}
+}
+
 func TestLogger_AccessLogIncludesCoreFields(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	sink := initMiddlewareTestLogger(t)
@@ -11,9 +11,15 @@ import (
 	"go.uber.org/zap"
 )
 
-const requestIDHeader = "X-Request-ID"
+const (
+	requestIDHeader = "X-Request-ID"
+	// upstreamRequestIDHeader 网关转发时上游返回的 x-request-id 改用该 header 返回给客户端
+	upstreamRequestIDHeader = "X-Upstream-Request-ID"
+	maxRequestIDLength      = 128
+)
 
 // RequestLogger 在请求入口注入 request-scoped logger。
+// 客户端携带合法的 X-Request-ID 时沿用，否则生成 UUID；该 ID 始终通过 X-Request-ID 响应头返回（含流式与错误响应）。
 func RequestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if c.Request == nil {
@@ -21,11 +27,12 @@ func RequestLogger() gin.HandlerFunc {
 			return
 		}
 
-		requestID := strings.TrimSpace(c.GetHeader(requestIDHeader))
+		requestID := normalizeRequestID(c.GetHeader(requestIDHeader))
 		if requestID == "" {
 			requestID = uuid.NewString()
 		}
 		c.Header(requestIDHeader, requestID)
+		c.Writer = &requestIDWriter{ResponseWriter: c.Writer, requestID: requestID}
 
 		ctx := context.WithValue(c.Request.Context(), ctxkey.RequestID, requestID)
 		clientRequestID, _ := ctx.Value(ctxkey.ClientRequestID).(string)
@@ -43,3 +50,71 @@ func RequestLogger() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// GetRequestIDFromContext 返回本次请求的追踪 ID（X-Request-ID），未经过 RequestLogger 时返回空串
+func GetRequestIDFromContext(c *gin.Context) string {
+	if c == nil || c.Request == nil {
+		return ""
+	}
+	requestID, _ := c.Request.Context().Value(ctxkey.RequestID).(string)
+	return requestID
+}
+
+// normalizeRequestID 校验客户端传入的请求 ID：1-128 个字母、数字或 - _ . : 字符，不合法时返回空串
+func normalizeRequestID(raw string) string {
+	id := strings.TrimSpace(raw)
+	if id == "" || len(id) > maxRequestIDLength {
+		return ""
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return ""
+		}
+	}
+	return id
+}
+
+// requestIDWriter 在响应头写出前把 X-Request-ID 固定为本次请求的 ID；
+// 网关复制到响应上的上游 x-request-id 改由 X-Upstream-Request-ID 返回。
+type requestIDWriter struct {
+	gin.ResponseWriter
+	requestID string
+}
+
+func (w *requestIDWriter) pin() {
+	if w.ResponseWriter.Written() {
+		return
+	}
+	header := w.ResponseWriter.Header()
+	current := header.Get(requestIDHeader)
+	if current == w.requestID {
+		return
+	}
+	if current != "" && header.Get(upstreamRequestIDHeader) == "" {
+		header.Set(upstreamRequestIDHeader, current)
+	}
+	header.Set(requestIDHeader, w.requestID)
+}
+
+func (w *requestIDWriter) WriteHeaderNow() {
+	w.pin()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *requestIDWriter) Write(b []byte) (int, error) {
+	w.pin()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *requestIDWriter) WriteString(s string) (int, error) {
+	w.pin()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *requestIDWriter) Flush() {
+	w.pin()
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// peekedRequestModelKey 缓存已解析的请求模型名，避免多个中间件重复读取请求体
+const peekedRequestModelKey = "middleware_peeked_request_model"
+
+// peekRequestModel 获取请求的目标模型（Gemini 路径参数或 JSON 请求体的 model 字段），
+// 读取后恢复请求体供 handler 使用。需放在 ModelAlias 之后以得到规范模型名。
+func peekRequestModel(c *gin.Context) string {
+	if cached, ok := c.Get(peekedRequestModelKey); ok {
+		model, _ := cached.(string)
+		return model
+	}
+	model := requestModelFromGeminiParam(c)
+	if model == "" && c.Request.Body != nil && strings.Contains(c.GetHeader("Content-Type"), "json") {
+		model = peekModelInJSONBody(c)
+	}
+	c.Set(peekedRequestModelKey, model)
+	return model
+}
+
+func isWebSocketUpgrade(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("Upgrade"), "websocket")
+}
+
+func requestModelFromGeminiParam(c *gin.Context) string {
+	rest := strings.TrimPrefix(c.Param("modelAction"), "/")
+	end := strings.IndexAny(rest, ":/")
+	if end <= 0 {
+		return ""
+	}
+	return rest[:end]
+}
+
+func peekModelInJSONBody(c *gin.Context) string {
+	body, ok := peekRequestBody(c)
+	if !ok {
+		return ""
+	}
+	return gjson.GetBytes(body, "model").String()
+}
+
+// peekRequestBody 读取完整请求体并恢复供 handler 使用；读取失败时返回 false
+func peekRequestBody(c *gin.Context) ([]byte, bool) {
+	if c.Request.Body == nil {
+		return nil, false
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	_ = c.Request.Body.Close()
+	if err != nil {
+		// 读取失败（如超过 body 限制）时把错误原样留给 handler 处理
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err: err}))
+		return nil, false
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, true
+}
+
+// setRequestBody 替换请求体并同步 Content-Length
+func setRequestBody(c *gin.Context, body []byte) {
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	c.Request.Header.Set("Content-Length", strconv.Itoa(len(body)))
+}
+
+// replaceGeminiModelParam 把 Gemini 路径参数中的 model 改写为 replacement；路径中不是该模型时返回 false
+func replaceGeminiModelParam(c *gin.Context, model, replacement string) bool {
+	if requestModelFromGeminiParam(c) != model {
+		return false
+	}
+	for i, param := range c.Params {
+		if param.Key == "modelAction" {
+			c.Params[i].Value = "/" + replacement + strings.TrimPrefix(strings.TrimPrefix(param.Value, "/"), model)
+			return true
+		}
+	}
+	return false
+}
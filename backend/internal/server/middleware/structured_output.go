@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// StructuredOutputGuard 检测请求是否使用结构化输出（json_schema）：模型价格数据明确声明不支持时直接返回 400，
+// 否则在配置了 pricing.structured_output_multiplier 时标记请求以便计费应用倍率。放在 ModelAlias 之后以使用规范模型名。
+func StructuredOutputGuard(billingService *service.BillingService, writeError GatewayErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if billingService == nil || c.Request.Method != http.MethodPost || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		model := peekRequestModel(c)
+		if model == "" {
+			c.Next()
+			return
+		}
+		unsupported := billingService.ModelLacksStructuredOutput(model)
+		if !unsupported && billingService.StructuredOutputMultiplier() == 1 {
+			c.Next()
+			return
+		}
+		body, ok := peekRequestBody(c)
+		if !ok || !service.RequestUsesStructuredOutput(body) {
+			c.Next()
+			return
+		}
+		if unsupported {
+			writeError(c, http.StatusBadRequest, (&service.StructuredOutputUnsupportedError{Model: model}).Error())
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(service.WithStructuredOutputRequest(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newStructuredOutputTestRouter(t *testing.T, multiplier float64, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.StructuredOutputMultiplier = multiplier
+	pricing := service.NewPricingService(cfg, nil)
+	_, err := pricing.ImportPricingData([]byte(`{
+		"schema-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","supports_response_schema":true},
+		"no-schema-model":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai","mode":"chat","supports_response_schema":false}
+	}`), false)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(StructuredOutputGuard(service.NewBillingService(cfg, pricing), AnthropicErrorWriter))
+	r.POST("/v1/chat/completions", handler)
+	return r
+}
+
+func structuredOutputTestBody(model string) string {
+	return `{"model":"` + model + `","messages":[{"role":"user","content":"hi"}],"response_format":{"type":"json_schema","json_schema":{"name":"x","schema":{"type":"object"}}}}`
+}
+
+func TestStructuredOutputGuard_RejectsUnsupportedModel(t *testing.T) {
+	r := newStructuredOutputTestRouter(t, 1, func(c *gin.Context) {
+		t.Fatal("handler should not be called")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(structuredOutputTestBody("no-schema-model")))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Contains(t, resp.Error.Message, "no-schema-model")
+	require.Contains(t, resp.Error.Message, "structured outputs")
+}
+
+func TestStructuredOutputGuard_MarksRequestWhenMultiplierConfigured(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		multiplier float64
+		want       bool
+	}{
+		{name: "multiplier configured", multiplier: 1.2, want: true},
+		{name: "no multiplier", multiplier: 1, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newStructuredOutputTestRouter(t, tc.multiplier, func(c *gin.Context) {
+				marked, _ := c.Request.Context().Value(ctxkey.StructuredOutput).(bool)
+				require.Equal(t, tc.want, marked)
+				c.Status(http.StatusNoContent)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(structuredOutputTestBody("schema-model")))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusNoContent, w.Code)
+		})
+	}
+}
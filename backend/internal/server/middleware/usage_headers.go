@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// usageHeadersWaitTimeout 写出前等待异步用量记录完成的最长时间，超时后不返回用量头
+const usageHeadersWaitTimeout = 5 * time.Second
+
+// UsageHeaders 对开启 usage_headers 的 API Key（以及沙盒 Key）返回本次请求的计费金额与 token 用量，需放在 API Key 认证之后。
+// 非流式响应先缓冲，待计费完成后以响应头返回；handler 一旦 Flush（流式）即改为透传，
+// 并在流结束、计费完成后以 HTTP trailer 返回同名字段。
+func UsageHeaders() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, _ := GetAPIKeyFromContext(c)
+		if apiKey == nil || !(apiKey.UsageHeaders || apiKey.IsSandbox) || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+		report := service.NewUsageHeaderReport()
+		c.Request = c.Request.WithContext(service.WithUsageHeaderReport(c.Request.Context(), report))
+		writer := &usageHeadersWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.hijacked {
+			return
+		}
+		var headers map[string]string
+		if report.Wait(usageHeadersWaitTimeout) {
+			headers = report.Headers()
+		}
+		if masked, ok := maskedResponseModel(c); ok && headers != nil {
+			headers[service.UsageHeaderModelUsed] = masked
+		}
+		// 流式响应已声明 Trailer，此处设置的值由 net/http 随结束块写出
+		for name, value := range headers {
+			c.Writer.Header().Set(name, value)
+		}
+		if !writer.streaming {
+			writer.commit()
+		}
+	}
+}
+
+// usageHeadersWriter 缓冲非流式响应以便在计费完成后补充响应头；首次 Flush 时切换为透传并声明 trailer。
+type usageHeadersWriter struct {
+	gin.ResponseWriter
+	status    int
+	written   bool
+	buf       bytes.Buffer
+	streaming bool
+	hijacked  bool
+}
+
+func (w *usageHeadersWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *usageHeadersWriter) WriteHeaderNow() {
+	if w.streaming {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.written = true
+}
+
+func (w *usageHeadersWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.written = true
+	return w.buf.Write(b)
+}
+
+func (w *usageHeadersWriter) WriteString(s string) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.WriteString(s)
+	}
+	w.written = true
+	return w.buf.WriteString(s)
+}
+
+func (w *usageHeadersWriter) Status() int {
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *usageHeadersWriter) Size() int {
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *usageHeadersWriter) Written() bool {
+	if w.streaming {
+		return w.ResponseWriter.Written()
+	}
+	return w.written
+}
+
+func (w *usageHeadersWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.ResponseWriter.Header().Set("Trailer", strings.Join(service.UsageHeaderNames, ", "))
+		w.ResponseWriter.WriteHeader(w.status)
+		w.commit()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *usageHeadersWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return w.ResponseWriter.Hijack()
+}
+
+// commit 将缓冲的状态码与正文写到底层 writer
+func (w *usageHeadersWriter) commit() {
+	if !w.written {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newUsageHeadersRouter(apiKey *service.APIKey, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyAPIKey), apiKey)
+		c.Next()
+	})
+	r.Use(UsageHeaders())
+	r.POST("/v1/messages", handler)
+	return r
+}
+
+// recordUsageAsync 模拟 handler 提交的异步用量记录任务
+func recordUsageAsync(c *gin.Context, usageLog *service.UsageLog) {
+	report := service.UsageHeaderReportFromContext(c.Request.Context())
+	report.Hold()
+	go func() {
+		defer report.Release()
+		ctx := service.WithUsageHeaderReport(context.Background(), report)
+		service.UsageHeaderReportFromContext(ctx).SetUsage(usageLog)
+	}()
+}
+
+func TestUsageHeaders_NonStreamingSetsHeadersAfterBilling(t *testing.T) {
+	r := newUsageHeadersRouter(&service.APIKey{ID: 1, UsageHeaders: true}, func(c *gin.Context) {
+		recordUsageAsync(c, &service.UsageLog{Model: "claude-sonnet-4", InputTokens: 12, OutputTokens: 34, ActualCost: 0.0015})
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/messages", nil))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.JSONEq(t, `{"ok":true}`, rec.Body.String())
+	require.Equal(t, "0.001500", rec.Header().Get(service.UsageHeaderCostUSD))
+	require.Equal(t, "12", rec.Header().Get(service.UsageHeaderInputTokens))
+	require.Equal(t, "34", rec.Header().Get(service.UsageHeaderOutputTokens))
+	require.Equal(t, "claude-sonnet-4", rec.Header().Get(service.UsageHeaderModelUsed))
+}
+
+func TestUsageHeaders_StreamingSendsTrailers(t *testing.T) {
+	r := newUsageHeadersRouter(&service.APIKey{ID: 1, UsageHeaders: true}, func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		_, _ = c.Writer.WriteString("data: hello\n\n")
+		c.Writer.Flush()
+		recordUsageAsync(c, &service.UsageLog{Model: "gpt-5", InputTokens: 5, OutputTokens: 7, ActualCost: 0.25})
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/v1/messages", "application/json", nil)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Equal(t, "data: hello\n\n", string(body))
+	require.Empty(t, resp.Header.Get(service.UsageHeaderCostUSD))
+	require.Equal(t, "0.250000", resp.Trailer.Get(service.UsageHeaderCostUSD))
+	require.Equal(t, "5", resp.Trailer.Get(service.UsageHeaderInputTokens))
+	require.Equal(t, "7", resp.Trailer.Get(service.UsageHeaderOutputTokens))
+	require.Equal(t, "gpt-5", resp.Trailer.Get(service.UsageHeaderModelUsed))
+}
+
+func TestUsageHeaders_DisabledKeyPassesThrough(t *testing.T) {
+	r := newUsageHeadersRouter(&service.APIKey{ID: 1}, func(c *gin.Context) {
+		require.Nil(t, service.UsageHeaderReportFromContext(c.Request.Context()))
+		c.String(http.StatusOK, "ok")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/messages", nil))
+
+	require.Equal(t, "ok", rec.Body.String())
+	require.Empty(t, rec.Header().Get(service.UsageHeaderCostUSD))
+}
+
+func TestUsageHeaders_NoUsageRecordedOmitsHeaders(t *testing.T) {
+	r := newUsageHeadersRouter(&service.APIKey{ID: 1, UsageHeaders: true}, func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad"})
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/messages", nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.JSONEq(t, `{"error":"bad"}`, rec.Body.String())
+	require.Empty(t, rec.Header().Get(service.UsageHeaderCostUSD))
+}
@@ -29,7 +29,13 @@ func SetupRouter(
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
+	auditService *service.AuditService,
+	billingService *service.BillingService,
 	settingService *service.SettingService,
+	gatewayIdempotency *service.GatewayIdempotencyService,
+	maintenanceService *service.MaintenanceService,
+	priorityQueue *service.GatewayPriorityQueue,
+	gatewayLoadService *service.GatewayLoadService,
 	cfg *config.Config,
 	redisClient *redis.Client,
 ) *gin.Engine {
@@ -81,7 +87,9 @@ func SetupRouter(
 	}
 
 	// 注册路由
-	registerRoutes(r, handlers, jwtAuth, adminAuth, apiKeyAuth, apiKeyService, subscriptionService, opsService, settingService, cfg, redisClient)
+	registerRoutes(r, handlers, jwtAuth, adminAuth, apiKeyAuth, apiKeyService, subscriptionService, opsService, auditService, billingService, settingService, gatewayIdempotency, maintenanceService, priorityQueue, gatewayLoadService, cfg, redisClient)
+	// 审计重放经完整路由重新发出请求
+	auditService.SetReplayHandler(r)
 
 	return r
 }
@@ -96,12 +104,19 @@ func registerRoutes(
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
+	auditService *service.AuditService,
+	billingService *service.BillingService,
 	settingService *service.SettingService,
+	gatewayIdempotency *service.GatewayIdempotencyService,
+	maintenanceService *service.MaintenanceService,
+	priorityQueue *service.GatewayPriorityQueue,
+	gatewayLoadService *service.GatewayLoadService,
 	cfg *config.Config,
 	redisClient *redis.Client,
 ) {
 	// 通用路由（健康检查、状态等）
-	routes.RegisterCommonRoutes(r)
+	routes.RegisterCommonRoutes(r, h.Health)
+	routes.RegisterMetricsRoutes(r, cfg)
 
 	// API v1
 	v1 := r.Group("/api/v1")
@@ -110,7 +125,7 @@ func registerRoutes(
 	routes.RegisterAuthRoutes(v1, h, jwtAuth, redisClient, settingService)
 	routes.RegisterUserRoutes(v1, h, jwtAuth, settingService)
 	routes.RegisterAdminRoutes(v1, h, adminAuth)
-	routes.RegisterGatewayRoutes(r, h, apiKeyAuth, apiKeyService, subscriptionService, opsService, settingService, cfg)
+	routes.RegisterGatewayRoutes(r, h, apiKeyAuth, apiKeyService, subscriptionService, opsService, auditService, billingService, settingService, gatewayIdempotency, maintenanceService, priorityQueue, gatewayLoadService, cfg)
 	routes.RegisterPaymentRoutes(v1, h.Payment, h.PaymentWebhook, h.Admin.Payment, jwtAuth, adminAuth, settingService)
 
 	handler.RegisterPageRoutes(v1, cfg.Pricing.DataDir, gin.HandlerFunc(jwtAuth), gin.HandlerFunc(adminAuth), settingService)
@@ -100,6 +100,27 @@ func RegisterAdminRoutes(
 
 		// 邀请返利（专属用户管理）
 		registerAffiliateRoutes(admin, h)
+
+		// 请求审计日志
+		admin.GET("/audit", h.Admin.Audit.List)
+		admin.POST("/audit/:id/replay", h.Admin.Audit.Replay)
+
+		// 网关维护模式
+		admin.GET("/maintenance", h.Admin.Maintenance.Get)
+		admin.PUT("/maintenance", h.Admin.Maintenance.Update)
+		admin.GET("/slow-requests", h.Admin.SlowRequest.List)
+
+		// 网关优先级排队状态
+		admin.GET("/priority-queue", h.Admin.PriorityQueue.Status)
+
+		// 自动扩缩容参考指标（进行中请求、排队深度、利用率、按模型饱和度）
+		admin.GET("/gateway-load", h.Admin.GatewayLoad.Status)
+
+		// 部署后预热（tokenizer / 定价缓存 / 上游账号）
+		admin.POST("/warmup", h.Admin.Warmup.Warmup)
+
+		// 按模型反查可服务的账号
+		admin.GET("/models/:model/accounts", h.Admin.ModelAccounts.ListAccounts)
 	}
 }
 
@@ -132,6 +153,7 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		ops.GET("/user-concurrency", h.Admin.Ops.GetUserConcurrencyStats)
 		ops.GET("/account-availability", h.Admin.Ops.GetAccountAvailability)
 		ops.GET("/realtime-traffic", h.Admin.Ops.GetRealtimeTrafficSummary)
+		ops.GET("/token-histograms", h.Admin.Ops.GetTokenHistograms)
 
 		// Alerts (rules + events)
 		ops.GET("/alert-rules", h.Admin.Ops.ListAlertRules)
@@ -245,6 +267,7 @@ func registerUserManagementRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		users.POST("/:id/balance", h.Admin.User.UpdateBalance)
 		users.GET("/:id/api-keys", h.Admin.User.GetUserAPIKeys)
 		users.GET("/:id/usage", h.Admin.User.GetUserUsage)
+		users.GET("/:id/spend", h.Admin.User.GetUserSpend)
 		users.GET("/:id/balance-history", h.Admin.User.GetBalanceHistory)
 		users.POST("/:id/replace-group", h.Admin.User.ReplaceGroup)
 		users.GET("/:id/rpm-status", h.Admin.User.GetUserRPMStatus)
@@ -283,6 +306,9 @@ func registerAccountRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		accounts.GET("", h.Admin.Account.List)
 		accounts.GET("/ids", h.Admin.Account.ListIDs)
+		accounts.GET("/health", h.Admin.Account.GetHealth)
+		accounts.GET("/selection-distribution", h.Admin.Account.GetSelectionDistribution)
+		accounts.POST("/selection-distribution/reset", h.Admin.Account.ResetSelectionDistribution)
 		accounts.GET("/:id", h.Admin.Account.GetByID)
 		accounts.POST("", h.Admin.Account.Create)
 		accounts.POST("/check-mixed-channel", h.Admin.Account.CheckMixedChannel)
@@ -291,6 +317,9 @@ func registerAccountRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		accounts.POST("/sync/crs/preview", h.Admin.Account.PreviewFromCRS)
 		accounts.PUT("/:id", h.Admin.Account.Update)
 		accounts.DELETE("/:id", h.Admin.Account.Delete)
+		accounts.POST("/:id/restore", h.Admin.Account.Restore)
+		accounts.POST("/:id/drain", h.Admin.Account.Drain)
+		accounts.GET("/:id/drain", h.Admin.Account.GetDrainStatus)
 		accounts.POST("/:id/test", h.Admin.Account.Test)
 		accounts.POST("/:id/test-chat", h.Admin.Account.TestChat)
 		accounts.POST("/:id/recover-state", h.Admin.Account.RecoverState)
@@ -544,6 +573,7 @@ func registerUsageRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		usage.GET("", h.Admin.Usage.List)
 		usage.GET("/stats", h.Admin.Usage.Stats)
+		usage.GET("/report", h.Admin.Usage.Report)
 		usage.GET("/search-users", h.Admin.Usage.SearchUsers)
 		usage.GET("/search-api-keys", h.Admin.Usage.SearchAPIKeys)
 		usage.GET("/cleanup-tasks", h.Admin.Usage.ListCleanupTasks)
@@ -569,10 +599,58 @@ func registerPricingRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		pricing.GET("", h.Admin.Pricing.ListPricing)
 		pricing.GET("/status", h.Admin.Pricing.GetStatus)
+		pricing.GET("/providers/stats", h.Admin.Pricing.GetProviderStats)
+		pricing.GET("/coverage", h.Admin.Pricing.GetPricingCoverage)
+		pricing.POST("/providers/disable", h.Admin.Pricing.DisableProvider)
+		pricing.POST("/providers/enable", h.Admin.Pricing.EnableProvider)
+		pricing.GET("/export", h.Admin.Pricing.ExportPricing)
 		pricing.POST("/update", h.Admin.Pricing.ForceUpdate)
+		pricing.GET("/auto-refresh", h.Admin.Pricing.GetAutoRefresh)
+		pricing.PUT("/auto-refresh", h.Admin.Pricing.SetAutoRefreshInterval)
+		pricing.POST("/auto-refresh/pause", h.Admin.Pricing.PauseAutoRefresh)
+		pricing.POST("/auto-refresh/resume", h.Admin.Pricing.ResumeAutoRefresh)
 		pricing.POST("/upload", h.Admin.Pricing.UploadPricing)
+		pricing.POST("/import-url", h.Admin.Pricing.ImportPricingURL)
+		pricing.POST("/diff", h.Admin.Pricing.DiffPricing)
 		pricing.GET("/lookup", h.Admin.Pricing.LookupModel)
+		pricing.POST("/lookup/batch", h.Admin.Pricing.BatchLookupModels)
+		pricing.POST("/estimate", h.Admin.Pricing.EstimateCost)
+		pricing.POST("/compare", h.Admin.Pricing.CompareCost)
+		pricing.GET("/value", h.Admin.Pricing.GetValueRanking)
+		pricing.POST("/override", h.Admin.Pricing.SetOverride)
+		pricing.DELETE("/override", h.Admin.Pricing.RemoveOverride)
+		pricing.POST("/clone", h.Admin.Pricing.ClonePricing)
+		pricing.POST("/disable", h.Admin.Pricing.DisableModel)
+		pricing.POST("/enable", h.Admin.Pricing.EnableModel)
+		pricing.POST("/bulk-disable", h.Admin.Pricing.BulkDisableModels)
+		pricing.POST("/bulk-enable", h.Admin.Pricing.BulkEnableModels)
+		pricing.GET("/aliases", h.Admin.Pricing.ListModelAliases)
+		pricing.POST("/aliases", h.Admin.Pricing.SetModelAlias)
+		pricing.DELETE("/aliases", h.Admin.Pricing.RemoveModelAlias)
+		pricing.GET("/markup", h.Admin.Pricing.GetMarkup)
+		pricing.PUT("/markup", h.Admin.Pricing.SetMarkup)
+		pricing.DELETE("/markup", h.Admin.Pricing.RemoveModelMarkup)
+		pricing.GET("/default", h.Admin.Pricing.GetDefaultPricing)
+		pricing.PUT("/default", h.Admin.Pricing.SetDefaultPricing)
+		pricing.DELETE("/default", h.Admin.Pricing.RemoveDefaultPricing)
+		pricing.GET("/timeouts", h.Admin.Pricing.ListModelTimeouts)
+		pricing.PUT("/timeouts", h.Admin.Pricing.SetModelTimeout)
+		pricing.DELETE("/timeouts", h.Admin.Pricing.RemoveModelTimeout)
+		pricing.GET("/batch-multipliers", h.Admin.Pricing.ListBatchMultipliers)
+		pricing.PUT("/batch-multipliers", h.Admin.Pricing.SetModelBatchMultiplier)
+		pricing.DELETE("/batch-multipliers", h.Admin.Pricing.RemoveModelBatchMultiplier)
+		pricing.GET("/committed-discounts", h.Admin.Pricing.ListCommittedDiscounts)
+		pricing.PUT("/committed-discounts", h.Admin.Pricing.SetCommittedDiscount)
+		pricing.DELETE("/committed-discounts", h.Admin.Pricing.RemoveCommittedDiscount)
+		pricing.GET("/min-charges", h.Admin.Pricing.ListMinCharges)
+		pricing.PUT("/min-charges", h.Admin.Pricing.SetModelMinCharge)
+		pricing.DELETE("/min-charges", h.Admin.Pricing.RemoveModelMinCharge)
+		pricing.GET("/exchange-rates", h.Admin.Pricing.ListExchangeRates)
+		pricing.POST("/exchange-rates", h.Admin.Pricing.SetExchangeRate)
 	}
+
+	// token 计数调试
+	admin.GET("/tokens/count", h.Admin.Pricing.CountTokens)
 }
 
 func registerScheduledTestRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
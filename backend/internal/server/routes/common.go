@@ -3,16 +3,26 @@ package routes
 import (
 	"net/http"
 
+	"github.com/Wei-Shaw/sub2api/internal/handler"
+
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterCommonRoutes 注册通用路由（健康检查、状态等）
-func RegisterCommonRoutes(r *gin.Engine) {
+func RegisterCommonRoutes(r *gin.Engine, health *handler.HealthHandler) {
 	// 健康检查
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Kubernetes 存活 / 就绪探针（无需认证）
+	if health != nil {
+		r.GET("/healthz", health.Liveness)
+		r.HEAD("/healthz", health.Liveness)
+		r.GET("/readyz", health.Readiness)
+		r.HEAD("/readyz", health.Readiness)
+	}
+
 	// Claude Code 遥测日志（忽略，直接返回200）
 	r.POST("/api/event_logging/batch", func(c *gin.Context) {
 		c.Status(http.StatusOK)
@@ -19,26 +19,77 @@ func RegisterGatewayRoutes(
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
+	auditService *service.AuditService,
+	billingService *service.BillingService,
 	settingService *service.SettingService,
+	gatewayIdempotency *service.GatewayIdempotencyService,
+	maintenanceService *service.MaintenanceService,
+	priorityQueue *service.GatewayPriorityQueue,
+	gatewayLoadService *service.GatewayLoadService,
 	cfg *config.Config,
 ) {
 	bodyLimit := middleware.RequestBodyLimit(cfg.Gateway.MaxBodySize)
 	clientRequestID := middleware.ClientRequestID()
 	opsErrorLogger := handler.OpsErrorLoggerMiddleware(opsService)
+	gatewayMetrics := middleware.GatewayMetrics()
+	auditLogger := middleware.AuditLogger(auditService)
+	usageHeaders := middleware.UsageHeaders()
+	modelMask := middleware.ModelMask(cfg)
+	modelAlias := middleware.ModelAlias(billingService)
+	modelDeprecation := middleware.ModelDeprecation(billingService)
+	modelTimeout := middleware.ModelTimeout(billingService)
+	modelFallback := middleware.ModelFallback(billingService)
+	gatewayLoad := middleware.GatewayLoad(gatewayLoadService)
 	endpointNorm := handler.InboundEndpointMiddleware()
 
 	// 未分组 Key 拦截中间件（按协议格式区分错误响应）
 	requireGroupAnthropic := middleware.RequireGroupAssignment(settingService, middleware.AnthropicErrorWriter)
 	requireGroupGoogle := middleware.RequireGroupAssignment(settingService, middleware.GoogleErrorWriter)
 
+	// 维护模式 503（按协议格式区分错误响应）
+	maintenanceAnthropic := middleware.GatewayMaintenance(maintenanceService, middleware.AnthropicErrorWriter)
+	maintenanceGoogle := middleware.GatewayMaintenance(maintenanceService, middleware.GoogleErrorWriter)
+
+	// 并发已满时按 API Key 优先级层级排队（按协议格式区分错误响应）
+	priorityQueueAnthropic := middleware.GatewayPriorityQueue(priorityQueue, middleware.AnthropicErrorWriter)
+	priorityQueueGoogle := middleware.GatewayPriorityQueue(priorityQueue, middleware.GoogleErrorWriter)
+
+	// API Key 请求/响应体大小上限（按协议格式区分错误响应）
+	requestSizeAnthropic := middleware.APIKeyRequestSizeLimit(cfg, middleware.AnthropicErrorWriter)
+	requestSizeGoogle := middleware.APIKeyRequestSizeLimit(cfg, middleware.GoogleErrorWriter)
+	responseSizeAnthropic := middleware.APIKeyResponseSizeLimit(cfg, middleware.AnthropicErrorWriter)
+	responseSizeGoogle := middleware.APIKeyResponseSizeLimit(cfg, middleware.GoogleErrorWriter)
+
+	// 网关请求 Idempotency-Key 幂等（按协议格式区分错误响应）
+	idempotencyAnthropic := middleware.GatewayIdempotency(gatewayIdempotency, middleware.AnthropicErrorWriter)
+	idempotencyGoogle := middleware.GatewayIdempotency(gatewayIdempotency, middleware.GoogleErrorWriter)
+
+	// 价格目录中已禁用的模型（按协议格式区分错误响应）
+	modelEnabledAnthropic := middleware.RequireModelEnabled(billingService, middleware.AnthropicErrorWriter)
+	modelEnabledGoogle := middleware.RequireModelEnabled(billingService, middleware.GoogleErrorWriter)
+
+	// API Key 模型允许/禁止列表（按协议格式区分错误响应）
+	modelAccessAnthropic := middleware.RequireModelAccess(middleware.AnthropicErrorWriter)
+	modelAccessGoogle := middleware.RequireModelAccess(middleware.GoogleErrorWriter)
+
+	// 模型上下文窗口检查（按协议格式区分错误响应，需开启 gateway.context_window_check）
+	contextWindowAnthropic := middleware.ContextWindowLimit(billingService, middleware.AnthropicErrorWriter)
+	contextWindowGoogle := middleware.ContextWindowLimit(billingService, middleware.GoogleErrorWriter)
+	structuredOutputAnthropic := middleware.StructuredOutputGuard(billingService, middleware.AnthropicErrorWriter)
+	structuredOutputGoogle := middleware.StructuredOutputGuard(billingService, middleware.GoogleErrorWriter)
+
 	// API网关（Claude API兼容）
 	gateway := r.Group("/v1")
 	gateway.Use(bodyLimit)
 	gateway.Use(clientRequestID)
+	gateway.Use(gatewayMetrics)
 	gateway.Use(opsErrorLogger)
 	gateway.Use(endpointNorm)
 	gateway.Use(gin.HandlerFunc(apiKeyAuth))
-	gateway.Use(requireGroupAnthropic)
+	gateway.Use(requestSizeAnthropic)
+	gateway.Use(auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders)
+	gateway.Use(modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad)
+	gateway.Use(requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback)
 	{
 		// /v1/messages: auto-route based on group platform
 		gateway.POST("/messages", func(c *gin.Context) {
@@ -118,10 +169,14 @@ func RegisterGatewayRoutes(
 	gemini := r.Group("/v1beta")
 	gemini.Use(bodyLimit)
 	gemini.Use(clientRequestID)
+	gemini.Use(gatewayMetrics)
 	gemini.Use(opsErrorLogger)
 	gemini.Use(endpointNorm)
 	gemini.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, cfg))
-	gemini.Use(requireGroupGoogle)
+	gemini.Use(requestSizeGoogle)
+	gemini.Use(auditLogger, maintenanceGoogle, idempotencyGoogle, usageHeaders)
+	gemini.Use(modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad)
+	gemini.Use(requireGroupGoogle, modelEnabledGoogle, modelAccessGoogle, contextWindowGoogle, structuredOutputGoogle, priorityQueueGoogle, responseSizeGoogle, modelFallback)
 	{
 		gemini.GET("/models", h.Gateway.GeminiV1BetaListModels)
 		gemini.GET("/models/:model", h.Gateway.GeminiV1BetaGetModel)
@@ -137,25 +192,25 @@ func RegisterGatewayRoutes(
 		}
 		h.Gateway.Responses(c)
 	}
-	r.POST("/responses", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, responsesHandler)
-	r.POST("/responses/*subpath", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, responsesHandler)
-	r.GET("/responses", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, h.OpenAIGateway.ResponsesWebSocket)
+	r.POST("/responses", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, responsesHandler)
+	r.POST("/responses/*subpath", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, responsesHandler)
+	r.GET("/responses", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, h.OpenAIGateway.ResponsesWebSocket)
 	codexDirect := r.Group("/backend-api/codex")
-	codexDirect.Use(bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic)
+	codexDirect.Use(bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback)
 	{
 		codexDirect.POST("/responses", responsesHandler)
 		codexDirect.POST("/responses/*subpath", responsesHandler)
 		codexDirect.GET("/responses", h.OpenAIGateway.ResponsesWebSocket)
 	}
 	// OpenAI Chat Completions API（不带v1前缀的别名）— auto-route based on group platform
-	r.POST("/chat/completions", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, func(c *gin.Context) {
+	r.POST("/chat/completions", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, func(c *gin.Context) {
 		if getGroupPlatform(c) == service.PlatformOpenAI {
 			h.OpenAIGateway.ChatCompletions(c)
 			return
 		}
 		h.Gateway.ChatCompletions(c)
 	})
-	r.POST("/images/generations", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, func(c *gin.Context) {
+	r.POST("/images/generations", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, func(c *gin.Context) {
 		if getGroupPlatform(c) != service.PlatformOpenAI {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{
@@ -167,7 +222,7 @@ func RegisterGatewayRoutes(
 		}
 		h.OpenAIGateway.Images(c)
 	})
-	r.POST("/images/edits", bodyLimit, clientRequestID, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requireGroupAnthropic, func(c *gin.Context) {
+	r.POST("/images/edits", bodyLimit, clientRequestID, gatewayMetrics, opsErrorLogger, endpointNorm, gin.HandlerFunc(apiKeyAuth), requestSizeAnthropic, auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders, modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad, requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback, func(c *gin.Context) {
 		if getGroupPlatform(c) != service.PlatformOpenAI {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{
@@ -187,11 +242,15 @@ func RegisterGatewayRoutes(
 	antigravityV1 := r.Group("/antigravity/v1")
 	antigravityV1.Use(bodyLimit)
 	antigravityV1.Use(clientRequestID)
+	antigravityV1.Use(gatewayMetrics)
 	antigravityV1.Use(opsErrorLogger)
 	antigravityV1.Use(endpointNorm)
 	antigravityV1.Use(middleware.ForcePlatform(service.PlatformAntigravity))
 	antigravityV1.Use(gin.HandlerFunc(apiKeyAuth))
-	antigravityV1.Use(requireGroupAnthropic)
+	antigravityV1.Use(requestSizeAnthropic)
+	antigravityV1.Use(auditLogger, maintenanceAnthropic, idempotencyAnthropic, usageHeaders)
+	antigravityV1.Use(modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad)
+	antigravityV1.Use(requireGroupAnthropic, modelEnabledAnthropic, modelAccessAnthropic, contextWindowAnthropic, structuredOutputAnthropic, priorityQueueAnthropic, responseSizeAnthropic, modelFallback)
 	{
 		antigravityV1.POST("/messages", h.Gateway.Messages)
 		antigravityV1.POST("/messages/count_tokens", h.Gateway.CountTokens)
@@ -202,11 +261,15 @@ func RegisterGatewayRoutes(
 	antigravityV1Beta := r.Group("/antigravity/v1beta")
 	antigravityV1Beta.Use(bodyLimit)
 	antigravityV1Beta.Use(clientRequestID)
+	antigravityV1Beta.Use(gatewayMetrics)
 	antigravityV1Beta.Use(opsErrorLogger)
 	antigravityV1Beta.Use(endpointNorm)
 	antigravityV1Beta.Use(middleware.ForcePlatform(service.PlatformAntigravity))
 	antigravityV1Beta.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, cfg))
-	antigravityV1Beta.Use(requireGroupGoogle)
+	antigravityV1Beta.Use(requestSizeGoogle)
+	antigravityV1Beta.Use(auditLogger, maintenanceGoogle, idempotencyGoogle, usageHeaders)
+	antigravityV1Beta.Use(modelMask, modelAlias, modelDeprecation, modelTimeout, gatewayLoad)
+	antigravityV1Beta.Use(requireGroupGoogle, modelEnabledGoogle, modelAccessGoogle, contextWindowGoogle, structuredOutputGoogle, priorityQueueGoogle, responseSizeGoogle, modelFallback)
 	{
 		antigravityV1Beta.GET("/models", h.Gateway.GeminiV1BetaListModels)
 		antigravityV1Beta.GET("/models/:model", h.Gateway.GeminiV1BetaGetModel)
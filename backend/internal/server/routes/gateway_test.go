@@ -15,6 +15,10 @@ import (
 )
 
 func newGatewayRoutesTestRouter() *gin.Engine {
+	return newGatewayRoutesTestRouterWithQueue(nil)
+}
+
+func newGatewayRoutesTestRouterWithQueue(priorityQueue *service.GatewayPriorityQueue) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
@@ -36,6 +40,12 @@ func newGatewayRoutesTestRouter() *gin.Engine {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		priorityQueue,
+		nil,
 		&config.Config{},
 	)
 
@@ -77,3 +87,24 @@ func TestGatewayRoutesOpenAIImagesPathsAreRegistered(t *testing.T) {
 		require.NotEqual(t, http.StatusNotFound, w.Code, "path=%s should hit OpenAI images handler", path)
 	}
 }
+
+func TestGatewayRoutesPriorityQueueTakesOneSlotPerRequest(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.PriorityQueue = config.PriorityQueueConfig{Enabled: true, MaxConcurrentRequests: 1}
+	router := newGatewayRoutesTestRouterWithQueue(service.NewGatewayPriorityQueue(cfg))
+
+	// 只有一个槽位且不排队：同一请求重复经过排队中间件会被 429 拒绝
+	for _, path := range []string{
+		"/v1/chat/completions",
+		"/chat/completions",
+		"/responses",
+		"/backend-api/codex/responses",
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"model":"gpt-5"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		require.NotEqual(t, http.StatusTooManyRequests, w.Code, "path=%s", path)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMetricsRoutes 应用指标配置并注册 Prometheus 指标端点（metrics.enabled 关闭时不注册端点）
+func RegisterMetricsRoutes(r *gin.Engine, cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	metrics.SetTokenBuckets(cfg.Metrics.TokenBuckets)
+	if !cfg.Metrics.Enabled {
+		return
+	}
+	r.GET("/metrics", middleware.MetricsAuth(cfg.Metrics.AuthToken), gin.WrapH(metrics.Handler()))
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// inFlightPollInterval 等待进行中请求归零时的轮询间隔
+const inFlightPollInterval = 50 * time.Millisecond
+
+// InFlightTracker 统计正在处理的 HTTP 请求数（含流式响应与已升级的 WebSocket 连接）
+type InFlightTracker struct {
+	active atomic.Int64
+}
+
+// ProvideInFlightTracker 提供进行中请求计数器
+func ProvideInFlightTracker() *InFlightTracker {
+	return &InFlightTracker{}
+}
+
+// Wrap 在 handler 执行期间计入进行中请求
+func (t *InFlightTracker) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.active.Add(1)
+		defer t.active.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Active 返回当前进行中的请求数
+func (t *InFlightTracker) Active() int64 {
+	return t.active.Load()
+}
+
+// Wait 等待进行中请求全部完成，ctx 结束时返回其错误
+func (t *InFlightTracker) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(inFlightPollInterval)
+	defer ticker.Stop()
+	for t.Active() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// GracefulShutdown 停止接收新请求，并在宽限期内等待进行中的请求完成。
+// http.Server.Shutdown 不会等待已被劫持的连接（WebSocket），因此还需等待计数器归零；
+// 超过宽限期后记录仍活跃的请求数并强制关闭所有连接。
+func GracefulShutdown(srv *http.Server, tracker *InFlightTracker, grace time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	log.Printf("Shutting down server: draining %d in-flight request(s), grace period %s", tracker.Active(), grace)
+	err := srv.Shutdown(ctx)
+	if err == nil {
+		err = tracker.Wait(ctx)
+	}
+	if err != nil {
+		log.Printf("Shutdown grace period exceeded with %d request(s) still active, forcing close", tracker.Active())
+		_ = srv.Close()
+		return err
+	}
+	return nil
+}
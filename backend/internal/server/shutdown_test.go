@@ -0,0 +1,71 @@
+//go:build unit
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func startTrackedServer(t *testing.T, handler http.Handler) (*http.Server, *InFlightTracker, string) {
+	t.Helper()
+	tracker := ProvideInFlightTracker()
+	srv := &http.Server{Handler: tracker.Wrap(handler)}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	return srv, tracker, "http://" + ln.Addr().String()
+}
+
+func TestGracefulShutdown_WaitsForInFlightRequest(t *testing.T) {
+	started := make(chan struct{})
+	srv, tracker, url := startTrackedServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	}))
+
+	respCh := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			respCh <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		respCh <- resp.StatusCode
+	}()
+	<-started
+	require.Equal(t, int64(1), tracker.Active())
+
+	require.NoError(t, GracefulShutdown(srv, tracker, 5*time.Second))
+	require.Equal(t, http.StatusOK, <-respCh)
+	require.Zero(t, tracker.Active())
+}
+
+func TestGracefulShutdown_ForcesCloseAfterGracePeriod(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv, tracker, url := startTrackedServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	err := GracefulShutdown(srv, tracker, 100*time.Millisecond)
+	require.Error(t, err)
+}
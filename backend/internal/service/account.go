@@ -31,6 +31,7 @@ type Account struct {
 	// 使用指针用于兼容旧版本调度缓存（Redis）中缺字段的情况：nil 表示按 1.0 处理。
 	RateMultiplier     *float64
 	LoadFactor         *int // 调度负载因子；nil 表示使用 Concurrency
+	Weight             *int // 同优先级调度权重；nil 表示 1，0 表示仅排空
 	Status             string
 	ErrorMessage       string
 	LastUsedAt         *time.Time
@@ -38,6 +39,7 @@ type Account struct {
 	AutoPauseOnExpired bool
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	DeletedAt          *time.Time // 软删除时间；nil 表示未删除
 
 	Schedulable bool
 
@@ -77,6 +79,11 @@ func (a *Account) IsActive() bool {
 	return a.Status == StatusActive
 }
 
+// IsDraining 排空中的账号不再被调度新请求，仅等待在途请求完成
+func (a *Account) IsDraining() bool {
+	return a.Status == StatusDraining
+}
+
 // BillingRateMultiplier 返回账号计费倍率。
 // - nil 表示未配置/旧缓存缺字段，按 1.0 处理
 // - 允许 0，表示该账号计费为 0
@@ -104,6 +111,22 @@ func (a *Account) EffectiveLoadFactor() int {
 	return 1
 }
 
+// EffectiveWeight 返回同优先级账号间的调度权重（未设置时为 1）
+func (a *Account) EffectiveWeight() int {
+	if a == nil || a.Weight == nil {
+		return 1
+	}
+	if *a.Weight < 0 {
+		return 0
+	}
+	return *a.Weight
+}
+
+// IsDrainOnly 权重为 0 的账号只服务已有粘性会话，不再被分配新请求
+func (a *Account) IsDrainOnly() bool {
+	return a.EffectiveWeight() == 0
+}
+
 func (a *Account) IsSchedulable() bool {
 	if !a.IsActive() || !a.Schedulable {
 		return false
@@ -1542,6 +1565,14 @@ func (a *Account) GetQuotaDailyUsed() float64 {
 	return a.getExtraFloat64("quota_daily_used")
 }
 
+// GetQuotaDailySpend 获取当前日额度周期内的已用额度（美元），周期已过期时为 0
+func (a *Account) GetQuotaDailySpend() float64 {
+	if a.IsDailyQuotaPeriodExpired() {
+		return 0
+	}
+	return a.GetQuotaDailyUsed()
+}
+
 // GetQuotaWeeklyLimit 获取周额度限制（美元），0 表示未启用
 func (a *Account) GetQuotaWeeklyLimit() float64 {
 	return a.getExtraFloat64("quota_weekly_limit")
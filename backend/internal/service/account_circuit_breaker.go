@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// 账号熔断状态
+const (
+	CircuitStateClosed   = "closed"
+	CircuitStateOpen     = "open"
+	CircuitStateHalfOpen = "half_open"
+)
+
+// AccountCircuitBreakerStatus 账号熔断状态快照。
+// 熔断只在进程内存中把账号排除出调度，不修改账号的 status/schedulable，与管理员手动禁用互不影响。
+type AccountCircuitBreakerStatus struct {
+	AccountID int64  `json:"account_id"`
+	State     string `json:"state"`
+	// 当前统计窗口内的请求数、失败数与失败率
+	Requests    int     `json:"requests"`
+	Failures    int     `json:"failures"`
+	FailureRate float64 `json:"failure_rate"`
+	LastError   string  `json:"last_error,omitempty"`
+	// OpenedAt 最近一次熔断时间；HalfOpenAt 熔断中的账号预计进入半开状态的时间
+	OpenedAt   *time.Time `json:"opened_at,omitempty"`
+	HalfOpenAt *time.Time `json:"half_open_at,omitempty"`
+}
+
+type accountCircuitBreaker struct {
+	status      AccountCircuitBreakerStatus
+	windowStart time.Time
+}
+
+// accountCircuitBreakerRegistry 进程内的账号熔断状态表：网关请求结果写入，调度读取
+type accountCircuitBreakerRegistry struct {
+	mu       sync.Mutex
+	cfg      config.AccountCircuitBreakerConfig
+	breakers map[int64]*accountCircuitBreaker
+	now      func() time.Time
+}
+
+func newAccountCircuitBreakerRegistry() *accountCircuitBreakerRegistry {
+	return &accountCircuitBreakerRegistry{breakers: make(map[int64]*accountCircuitBreaker), now: time.Now}
+}
+
+// defaultAccountCircuitBreakerRegistry 未配置时处于关闭状态，不影响调度
+var defaultAccountCircuitBreakerRegistry = newAccountCircuitBreakerRegistry()
+
+func (r *accountCircuitBreakerRegistry) configure(cfg config.AccountCircuitBreakerConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cfg = cfg
+}
+
+// refreshLocked 熔断冷却结束后进入半开状态
+func (r *accountCircuitBreakerRegistry) refreshLocked(b *accountCircuitBreaker, now time.Time) {
+	if b.status.State == CircuitStateOpen && b.status.HalfOpenAt != nil && !now.Before(*b.status.HalfOpenAt) {
+		b.status.State = CircuitStateHalfOpen
+		log.Printf("[CircuitBreaker] account=%d half-open after cool-down", b.status.AccountID)
+	}
+}
+
+// allow 账号是否可参与调度：熔断中的账号在冷却结束前被排除，半开状态放行试探请求
+func (r *accountCircuitBreakerRegistry) allow(accountID int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.cfg.Enabled {
+		return true
+	}
+	b := r.breakers[accountID]
+	if b == nil {
+		return true
+	}
+	r.refreshLocked(b, r.now())
+	return b.status.State != CircuitStateOpen
+}
+
+// record 记录一次上游请求结果：
+//   - 关闭状态：窗口内请求数达到 min_requests 且失败率达到阈值时熔断
+//   - 半开状态：试探成功即恢复，失败则重新熔断
+//   - 熔断状态：冷却期内完成的在途请求不计入
+func (r *accountCircuitBreakerRegistry) record(accountID int64, failed bool, errMsg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.cfg.Enabled {
+		return
+	}
+	now := r.now()
+	b := r.breakers[accountID]
+	if b == nil {
+		b = &accountCircuitBreaker{status: AccountCircuitBreakerStatus{AccountID: accountID, State: CircuitStateClosed}, windowStart: now}
+		r.breakers[accountID] = b
+	}
+	r.refreshLocked(b, now)
+	if failed {
+		b.status.LastError = errMsg
+	}
+
+	switch b.status.State {
+	case CircuitStateOpen:
+		return
+	case CircuitStateHalfOpen:
+		if failed {
+			r.openLocked(b, now)
+			log.Printf("[CircuitBreaker] account=%d re-opened: half-open probe failed: %s", accountID, errMsg)
+			return
+		}
+		b.status.State = CircuitStateClosed
+		b.status.OpenedAt = nil
+		b.status.HalfOpenAt = nil
+		r.resetWindowLocked(b, now)
+		log.Printf("[CircuitBreaker] account=%d closed: half-open probe succeeded", accountID)
+		return
+	}
+
+	if now.Sub(b.windowStart) >= time.Duration(r.cfg.WindowSeconds)*time.Second {
+		r.resetWindowLocked(b, now)
+	}
+	b.status.Requests++
+	if failed {
+		b.status.Failures++
+	}
+	b.status.FailureRate = float64(b.status.Failures) / float64(b.status.Requests)
+	if failed && b.status.Requests >= max(r.cfg.MinRequests, 1) && b.status.FailureRate >= r.cfg.FailureRateThreshold {
+		log.Printf("[CircuitBreaker] account=%d opened: %d/%d requests failed: %s", accountID, b.status.Failures, b.status.Requests, errMsg)
+		r.openLocked(b, now)
+	}
+}
+
+func (r *accountCircuitBreakerRegistry) openLocked(b *accountCircuitBreaker, now time.Time) {
+	halfOpenAt := now.Add(time.Duration(r.cfg.CooldownSeconds) * time.Second)
+	b.status.State = CircuitStateOpen
+	b.status.OpenedAt = &now
+	b.status.HalfOpenAt = &halfOpenAt
+	r.resetWindowLocked(b, now)
+}
+
+func (r *accountCircuitBreakerRegistry) resetWindowLocked(b *accountCircuitBreaker, now time.Time) {
+	b.windowStart = now
+	b.status.Requests = 0
+	b.status.Failures = 0
+	b.status.FailureRate = 0
+}
+
+// halfOpen 健康检查探测成功时提前结束冷却，由下一次真实请求确认恢复
+func (r *accountCircuitBreakerRegistry) halfOpen(accountID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakers[accountID]
+	if b == nil || b.status.State != CircuitStateOpen {
+		return
+	}
+	b.status.State = CircuitStateHalfOpen
+	log.Printf("[CircuitBreaker] account=%d half-open after successful health probe", accountID)
+}
+
+func (r *accountCircuitBreakerRegistry) state(accountID int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakers[accountID]
+	if !r.cfg.Enabled || b == nil {
+		return CircuitStateClosed
+	}
+	r.refreshLocked(b, r.now())
+	return b.status.State
+}
+
+// retain 删除不在 ids 中的账号（已删除或被手动停止调度），重新启用后从关闭状态开始
+func (r *accountCircuitBreakerRegistry) retain(ids map[int64]struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.breakers {
+		if _, ok := ids[id]; !ok {
+			delete(r.breakers, id)
+		}
+	}
+}
+
+func (r *accountCircuitBreakerRegistry) list() []AccountCircuitBreakerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	out := make([]AccountCircuitBreakerStatus, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		r.refreshLocked(b, now)
+		out = append(out, b.status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AccountID < out[j].AccountID })
+	return out
+}
+
+// RecordAccountUpstreamOutcome 记录账号一次上游请求的结果，供熔断统计失败率。
+// 网络错误与 5xx 计为失败；客户端取消的请求不计入；4xx（含 429）由限流/错误处理逻辑负责，计为成功。
+func RecordAccountUpstreamOutcome(accountID int64, statusCode int, err error) {
+	if accountID <= 0 {
+		return
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		defaultAccountCircuitBreakerRegistry.record(accountID, true, err.Error())
+		return
+	}
+	if statusCode >= http.StatusInternalServerError {
+		defaultAccountCircuitBreakerRegistry.record(accountID, true, fmt.Sprintf("upstream status %d", statusCode))
+		return
+	}
+	defaultAccountCircuitBreakerRegistry.record(accountID, false, "")
+}
+
+// isAccountCircuitOpen 调度路径使用：熔断中的账号在冷却结束前不参与选择
+func isAccountCircuitOpen(accountID int64) bool {
+	return !defaultAccountCircuitBreakerRegistry.allow(accountID)
+}
+
+// ListAccountCircuitBreakers 返回所有已记录账号的熔断状态（按账号 ID 排序）
+func ListAccountCircuitBreakers() []AccountCircuitBreakerStatus {
+	return defaultAccountCircuitBreakerRegistry.list()
+}
@@ -0,0 +1,91 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newCircuitBreakerTestRegistry(now *time.Time) *accountCircuitBreakerRegistry {
+	r := newAccountCircuitBreakerRegistry()
+	r.now = func() time.Time { return *now }
+	r.configure(config.AccountCircuitBreakerConfig{
+		Enabled:              true,
+		WindowSeconds:        60,
+		MinRequests:          4,
+		FailureRateThreshold: 0.5,
+		CooldownSeconds:      30,
+	})
+	return r
+}
+
+func TestAccountCircuitBreaker_OpensOnFailureRateAndRecovers(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newCircuitBreakerTestRegistry(&now)
+
+	r.record(1, false, "")
+	r.record(1, true, "upstream status 502")
+	r.record(1, false, "")
+	require.True(t, r.allow(1), "未达到 min_requests 不应熔断")
+
+	r.record(1, true, "upstream status 503")
+	require.False(t, r.allow(1))
+	require.Equal(t, CircuitStateOpen, r.state(1))
+
+	// 冷却期内完成的在途请求不影响状态
+	r.record(1, false, "")
+	require.False(t, r.allow(1))
+
+	now = now.Add(30 * time.Second)
+	require.True(t, r.allow(1))
+	require.Equal(t, CircuitStateHalfOpen, r.state(1))
+
+	// 半开试探失败重新熔断
+	r.record(1, true, "dial tcp: timeout")
+	require.False(t, r.allow(1))
+
+	now = now.Add(30 * time.Second)
+	r.record(1, false, "")
+	require.Equal(t, CircuitStateClosed, r.state(1))
+
+	statuses := r.list()
+	require.Len(t, statuses, 1)
+	require.Nil(t, statuses[0].OpenedAt)
+	require.Equal(t, "dial tcp: timeout", statuses[0].LastError)
+}
+
+func TestAccountCircuitBreaker_WindowResetsAndDisabledIsNoop(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newCircuitBreakerTestRegistry(&now)
+
+	r.record(2, true, "e")
+	r.record(2, true, "e")
+	r.record(2, true, "e")
+	now = now.Add(time.Minute)
+	r.record(2, true, "e")
+	require.True(t, r.allow(2), "窗口过期后重新计数")
+
+	r.halfOpen(2)
+	require.Equal(t, CircuitStateClosed, r.state(2))
+
+	r.record(2, true, "e")
+	r.record(2, true, "e")
+	r.record(2, true, "e")
+	require.False(t, r.allow(2))
+	r.halfOpen(2)
+	require.True(t, r.allow(2), "健康检查探测成功后提前半开")
+
+	r.retain(map[int64]struct{}{})
+	require.Empty(t, r.list())
+
+	r.configure(config.AccountCircuitBreakerConfig{})
+	for i := 0; i < 10; i++ {
+		r.record(3, true, "e")
+	}
+	require.True(t, r.allow(3))
+	require.Equal(t, CircuitStateClosed, r.state(3))
+}
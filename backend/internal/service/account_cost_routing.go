@@ -0,0 +1,110 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// 分组账号调度策略
+const (
+	GroupSchedulingStrategyLoadBalance = "load_balance"
+	GroupSchedulingStrategyCheapest    = "cheapest"
+	GroupSchedulingStrategyLatency     = "latency"
+)
+
+func normalizeGroupSchedulingStrategy(strategy string) string {
+	switch strings.TrimSpace(strategy) {
+	case GroupSchedulingStrategyCheapest:
+		return GroupSchedulingStrategyCheapest
+	case GroupSchedulingStrategyLatency:
+		return GroupSchedulingStrategyLatency
+	}
+	return GroupSchedulingStrategyLoadBalance
+}
+
+// accountRoutingCost 计算账号服务请求模型的有效成本（USD / 百万 token）：
+// 账号映射后上游模型的综合单价 × 账号计费倍率。无定价时返回 false。
+func accountRoutingCost(billing *BillingService, account *Account, requestedModel string) (float64, bool) {
+	if billing == nil || account == nil || requestedModel == "" {
+		return 0, false
+	}
+	pricing, err := billing.GetModelPricing(account.GetMappedModel(requestedModel))
+	if err != nil || pricing == nil {
+		return 0, false
+	}
+	blended := BlendedCost(pricing.InputPricePerToken*1e6, pricing.OutputPricePerToken*1e6, billing.DefaultIORatio())
+	return blended * account.BillingRateMultiplier(), true
+}
+
+// routingCostTable 按账号 ID 缓存单次调度内的有效成本，无定价的账号视为成本无穷大（排在最后）
+type routingCostTable map[int64]float64
+
+func buildRoutingCostTable(billing *BillingService, accounts []*Account, requestedModel string) routingCostTable {
+	costs := make(routingCostTable, len(accounts))
+	for _, acc := range accounts {
+		cost, ok := accountRoutingCost(billing, acc, requestedModel)
+		if !ok {
+			cost = math.Inf(1)
+		}
+		costs[acc.ID] = cost
+	}
+	return costs
+}
+
+// buildRoutingLatencyTable 按健康检查测得的平均延迟（毫秒）构建调度表，复用成本表的过滤与排序：
+// 无延迟数据的账号视为无穷大（排在最后）。所有账号都没有延迟数据时返回 nil，回退为负载均衡（加权轮询/LRU）。
+func buildRoutingLatencyTable(accounts []*Account) routingCostTable {
+	latencies := make(routingCostTable, len(accounts))
+	known := false
+	for _, acc := range accounts {
+		latency, ok := AccountAverageLatencyMs(acc.ID)
+		if !ok {
+			latency = math.Inf(1)
+		} else {
+			known = true
+		}
+		latencies[acc.ID] = latency
+	}
+	if !known {
+		return nil
+	}
+	return latencies
+}
+
+// filterByMinRoutingCost 过滤出有效成本最低的账号集合
+func filterByMinRoutingCost(accounts []accountWithLoad, costs routingCostTable) []accountWithLoad {
+	if len(accounts) == 0 {
+		return accounts
+	}
+	minCost := costs[accounts[0].account.ID]
+	for _, acc := range accounts[1:] {
+		if c := costs[acc.account.ID]; c < minCost {
+			minCost = c
+		}
+	}
+	result := make([]accountWithLoad, 0, len(accounts))
+	for _, acc := range accounts {
+		if costs[acc.account.ID] == minCost {
+			result = append(result, acc)
+		}
+	}
+	return result
+}
+
+// sortAccountsByRoutingCost 按有效成本稳定排序，成本相同时保留原有顺序
+func sortAccountsByRoutingCost(accounts []*Account, costs routingCostTable) {
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return costs[accounts[i].ID] < costs[accounts[j].ID]
+	})
+}
+
+// AccountRoutingCost 返回账号服务请求模型的有效成本（USD / 百万 token），用于响应头透出
+func (s *GatewayService) AccountRoutingCost(account *Account, requestedModel string) (float64, bool) {
+	return accountRoutingCost(s.billingService, account, requestedModel)
+}
+
+// AccountRoutingCost 返回账号服务请求模型的有效成本（USD / 百万 token），用于响应头透出
+func (s *OpenAIGatewayService) AccountRoutingCost(account *Account, requestedModel string) (float64, bool) {
+	return accountRoutingCost(s.billingService, account, requestedModel)
+}
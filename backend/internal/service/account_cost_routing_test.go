@@ -0,0 +1,72 @@
+//go:build unit
+
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func float64PtrRouting(v float64) *float64 { return &v }
+
+func TestAccountRoutingCost_UsesMappedModelAndRateMultiplier(t *testing.T) {
+	billing := NewBillingService(&config.Config{}, nil)
+
+	direct := &Account{ID: 1, Platform: PlatformAnthropic}
+	cost, ok := accountRoutingCost(billing, direct, "claude-sonnet-4")
+	require.True(t, ok)
+	// (3*3 + 15) / 4 = 6 USD/MTok
+	require.InDelta(t, 6.0, cost, 1e-9)
+
+	mapped := &Account{
+		ID:             2,
+		Platform:       PlatformAnthropic,
+		Credentials:    map[string]any{"model_mapping": map[string]any{"claude-sonnet-4": "claude-3-5-haiku"}},
+		RateMultiplier: float64PtrRouting(0.5),
+	}
+	cost, ok = accountRoutingCost(billing, mapped, "claude-sonnet-4")
+	require.True(t, ok)
+	// haiku: (3*1 + 5) / 4 = 2，倍率 0.5
+	require.InDelta(t, 1.0, cost, 1e-9)
+
+	_, ok = accountRoutingCost(billing, direct, "")
+	require.False(t, ok)
+}
+
+func TestFilterByMinRoutingCost(t *testing.T) {
+	costs := routingCostTable{1: 6, 2: 1, 3: 1, 4: math.Inf(1)}
+	accounts := []accountWithLoad{
+		{account: &Account{ID: 1}},
+		{account: &Account{ID: 2}},
+		{account: &Account{ID: 3}},
+		{account: &Account{ID: 4}},
+	}
+	cheapest := filterByMinRoutingCost(accounts, costs)
+	require.Len(t, cheapest, 2)
+	require.Equal(t, int64(2), cheapest[0].account.ID)
+	require.Equal(t, int64(3), cheapest[1].account.ID)
+
+	// 最便宜的账号被移除后回落到次便宜的账号
+	cheapest = filterByMinRoutingCost(accounts[:1], costs)
+	require.Equal(t, int64(1), cheapest[0].account.ID)
+	cheapest = filterByMinRoutingCost([]accountWithLoad{accounts[0], accounts[3]}, costs)
+	require.Len(t, cheapest, 1)
+	require.Equal(t, int64(1), cheapest[0].account.ID)
+}
+
+func TestSortAccountsByRoutingCost_Stable(t *testing.T) {
+	accounts := []*Account{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	sortAccountsByRoutingCost(accounts, routingCostTable{1: 5, 2: math.Inf(1), 3: 2, 4: 2})
+	require.Equal(t, []int64{3, 4, 1, 2}, []int64{accounts[0].ID, accounts[1].ID, accounts[2].ID, accounts[3].ID})
+}
+
+func TestNormalizeGroupSchedulingStrategy(t *testing.T) {
+	require.Equal(t, GroupSchedulingStrategyLoadBalance, normalizeGroupSchedulingStrategy(""))
+	require.Equal(t, GroupSchedulingStrategyLoadBalance, normalizeGroupSchedulingStrategy("random"))
+	require.Equal(t, GroupSchedulingStrategyCheapest, normalizeGroupSchedulingStrategy("cheapest"))
+	require.True(t, (&Group{SchedulingStrategy: GroupSchedulingStrategyCheapest}).IsCheapestScheduling())
+	require.False(t, (*Group)(nil).IsCheapestScheduling())
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"golang.org/x/net/http/httpguts"
+)
+
+// accountCustomHeadersKey Extra 中保存账号自定义上游请求头的键（header 名 -> 值）
+const accountCustomHeadersKey = "custom_headers"
+
+// accountCustomHeaderForbidden 由代理自身管理、不允许通过自定义请求头覆盖的 header（认证头由账号凭证决定）
+var accountCustomHeaderForbidden = map[string]struct{}{
+	"Host":                {},
+	"Content-Length":      {},
+	"Content-Type":        {},
+	"Transfer-Encoding":   {},
+	"Connection":          {},
+	"Keep-Alive":          {},
+	"Upgrade":             {},
+	"Te":                  {},
+	"Trailer":             {},
+	"Proxy-Authorization": {},
+	"Proxy-Connection":    {},
+	"Authorization":       {},
+	"X-Api-Key":           {},
+	"X-Goog-Api-Key":      {},
+}
+
+// accountCustomHeaderSensitiveMarkers header 名包含这些片段时视为敏感，管理端响应中脱敏显示
+var accountCustomHeaderSensitiveMarkers = []string{"key", "token", "secret", "auth", "cookie", "password", "session"}
+
+// GetCustomHeaders 返回账号配置的自定义上游请求头（header 名已规范化）
+func (a *Account) GetCustomHeaders() map[string]string {
+	if a == nil || a.Extra == nil {
+		return nil
+	}
+	raw, ok := a.Extra[accountCustomHeadersKey].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(raw))
+	for name, value := range raw {
+		if s, ok := value.(string); ok {
+			headers[http.CanonicalHeaderKey(strings.TrimSpace(name))] = s
+		}
+	}
+	return headers
+}
+
+// applyAccountCustomHeaders 在出站请求上注入账号自定义请求头，覆盖同名的默认值
+func applyAccountCustomHeaders(req *http.Request, account *Account) {
+	if req == nil {
+		return
+	}
+	for name, value := range account.GetCustomHeaders() {
+		if _, forbidden := accountCustomHeaderForbidden[name]; forbidden {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+}
+
+// ValidateAccountCustomHeaders 校验 Extra 中的自定义请求头：header 名与值必须合法，且不能覆盖代理管理的 header
+func ValidateAccountCustomHeaders(extra map[string]any) error {
+	raw, ok := extra[accountCustomHeadersKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	headers, ok := raw.(map[string]any)
+	if !ok {
+		return infraerrors.BadRequest("INVALID_CUSTOM_HEADERS", "custom_headers must be an object of header name to value")
+	}
+	seen := make(map[string]struct{}, len(headers))
+	for name, value := range headers {
+		trimmed := strings.TrimSpace(name)
+		if !httpguts.ValidHeaderFieldName(trimmed) {
+			return infraerrors.BadRequest("INVALID_CUSTOM_HEADERS", fmt.Sprintf("invalid header name %q", name))
+		}
+		canonical := http.CanonicalHeaderKey(trimmed)
+		if _, forbidden := accountCustomHeaderForbidden[canonical]; forbidden {
+			return infraerrors.BadRequest("INVALID_CUSTOM_HEADERS", fmt.Sprintf("header %s is managed by the proxy and cannot be overridden", canonical))
+		}
+		if _, dup := seen[canonical]; dup {
+			return infraerrors.BadRequest("INVALID_CUSTOM_HEADERS", fmt.Sprintf("duplicate header %s", canonical))
+		}
+		seen[canonical] = struct{}{}
+		s, ok := value.(string)
+		if !ok || !httpguts.ValidHeaderFieldValue(s) {
+			return infraerrors.BadRequest("INVALID_CUSTOM_HEADERS", fmt.Sprintf("invalid value for header %s", canonical))
+		}
+	}
+	return nil
+}
+
+func isSensitiveCustomHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range accountCustomHeaderSensitiveMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskAccountCustomHeaders 返回敏感自定义请求头值已脱敏的 Extra 副本，供管理端响应使用；无需脱敏时原样返回
+func MaskAccountCustomHeaders(extra map[string]any) map[string]any {
+	headers, ok := extra[accountCustomHeadersKey].(map[string]any)
+	if !ok || len(headers) == 0 {
+		return extra
+	}
+	masked := make(map[string]any, len(headers))
+	changed := false
+	for name, value := range headers {
+		if s, ok := value.(string); ok && isSensitiveCustomHeader(name) {
+			masked[name] = maskSecretTail(s)
+			changed = true
+			continue
+		}
+		masked[name] = value
+	}
+	if !changed {
+		return extra
+	}
+	out := make(map[string]any, len(extra))
+	for k, v := range extra {
+		out[k] = v
+	}
+	out[accountCustomHeadersKey] = masked
+	return out
+}
+
+// restoreMaskedCustomHeaders 管理端回传的脱敏值与原值脱敏结果一致时保留原值，避免编辑账号时把脱敏串写入上游请求头
+func restoreMaskedCustomHeaders(input, existing map[string]any) {
+	next, ok := input[accountCustomHeadersKey].(map[string]any)
+	if !ok {
+		return
+	}
+	prev, _ := existing[accountCustomHeadersKey].(map[string]any)
+	for name, value := range next {
+		s, ok := value.(string)
+		if !ok || !isSensitiveCustomHeader(name) {
+			continue
+		}
+		if old, ok := prev[name].(string); ok && old != s && maskSecretTail(old) == s {
+			next[name] = old
+		}
+	}
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAccountCustomHeaders(t *testing.T) {
+	require.NoError(t, ValidateAccountCustomHeaders(nil))
+	require.NoError(t, ValidateAccountCustomHeaders(map[string]any{
+		accountCustomHeadersKey: map[string]any{"anthropic-beta": "context-1m-2025-08-07", "OpenAI-Organization": "org-1"},
+	}))
+
+	for _, headers := range []any{
+		"anthropic-beta: x",
+		map[string]any{"bad header": "x"},
+		map[string]any{"authorization": "Bearer x"},
+		map[string]any{"X-Org": "a\nb"},
+		map[string]any{"X-Org": 1},
+		map[string]any{"x-org": "a", "X-Org": "b"},
+	} {
+		err := ValidateAccountCustomHeaders(map[string]any{accountCustomHeadersKey: headers})
+		require.Error(t, err, "%v", headers)
+		require.Equal(t, "INVALID_CUSTOM_HEADERS", infraerrors.Reason(err))
+	}
+}
+
+func TestApplyAccountCustomHeaders_OverridesDefaults(t *testing.T) {
+	account := &Account{Extra: map[string]any{
+		accountCustomHeadersKey: map[string]any{"anthropic-beta": "custom-beta", "x-org-id": "org-1"},
+	}}
+	req, err := http.NewRequest(http.MethodPost, "https://api.example.com/v1/messages", nil)
+	require.NoError(t, err)
+	req.Header.Set("anthropic-beta", "default-beta")
+
+	applyAccountCustomHeaders(req, account)
+	require.Equal(t, "custom-beta", req.Header.Get("anthropic-beta"))
+	require.Equal(t, "org-1", req.Header.Get("X-Org-Id"))
+
+	applyAccountCustomHeaders(req, &Account{})
+	require.Equal(t, "custom-beta", req.Header.Get("anthropic-beta"))
+}
+
+func TestMaskAccountCustomHeaders_RestoresOnUpdate(t *testing.T) {
+	extra := map[string]any{
+		"privacy_mode": "set",
+		accountCustomHeadersKey: map[string]any{
+			"x-upstream-token": "tok-secret-123456",
+			"anthropic-beta":   "context-1m",
+		},
+	}
+
+	masked := MaskAccountCustomHeaders(extra)
+	maskedHeaders := masked[accountCustomHeadersKey].(map[string]any)
+	require.Equal(t, "********3456", maskedHeaders["x-upstream-token"])
+	require.Equal(t, "context-1m", maskedHeaders["anthropic-beta"])
+	require.Equal(t, "set", masked["privacy_mode"])
+	// 原 Extra 不受影响
+	require.Equal(t, "tok-secret-123456", extra[accountCustomHeadersKey].(map[string]any)["x-upstream-token"])
+
+	input := map[string]any{accountCustomHeadersKey: map[string]any{
+		"x-upstream-token": "********3456",
+		"anthropic-beta":   "context-1m,other",
+	}}
+	restoreMaskedCustomHeaders(input, extra)
+	headers := input[accountCustomHeadersKey].(map[string]any)
+	require.Equal(t, "tok-secret-123456", headers["x-upstream-token"])
+	require.Equal(t, "context-1m,other", headers["anthropic-beta"])
+}
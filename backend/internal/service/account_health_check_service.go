@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+const (
+	accountHealthDefaultInterval  = 5 * time.Minute
+	accountHealthDefaultThreshold = 3
+	accountHealthDefaultTimeout   = time.Minute
+	accountHealthDefaultWorkers   = 5
+
+	// accountHealthLatencyAlpha 平均延迟的指数滑动平均系数（新样本权重）
+	accountHealthLatencyAlpha = 0.3
+)
+
+// AccountHealthStatus 账号上游健康检查状态
+type AccountHealthStatus struct {
+	AccountID           int64      `json:"account_id"`
+	Name                string     `json:"name"`
+	Platform            string     `json:"platform"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastCheckAt         *time.Time `json:"last_check_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastLatencyMs       int64      `json:"last_latency_ms"`
+	LastError           string     `json:"last_error,omitempty"`
+	// AvgLatencyMs 成功探测延迟的指数滑动平均（毫秒），latency 调度策略据此选择账号；0 表示尚无数据
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	// 并发上限（账号配置）与实时占用，后者由调用方按并发槽位填充
+	MaxConcurrency     int `json:"max_concurrency"`
+	CurrentConcurrency int `json:"current_concurrency"`
+	WaitingCount       int `json:"waiting_count"`
+	// CircuitState 账号熔断状态（closed/open/half_open），与健康检查的 healthy 相互独立
+	CircuitState string `json:"circuit_state"`
+	// 日花费上限（apikey/bedrock 账号的 quota_daily_limit）与当前周期已用额度（美元），由调用方填充；
+	// 未设置上限时为空。达到上限的账号在周期重置前不再被调度
+	DailySpend           *float64 `json:"daily_spend,omitempty"`
+	DailySpendCap        *float64 `json:"daily_spend_cap,omitempty"`
+	DailySpendCapReached bool     `json:"daily_spend_cap_reached"`
+}
+
+// FillDailySpend 按账号的日额度配置填充日花费与上限
+func (s *AccountHealthStatus) FillDailySpend(account *Account) {
+	s.DailySpend, s.DailySpendCap, s.DailySpendCapReached = nil, nil, false
+	if account == nil || !account.IsAPIKeyOrBedrock() {
+		return
+	}
+	limit := account.GetQuotaDailyLimit()
+	if limit <= 0 {
+		return
+	}
+	spend := account.GetQuotaDailySpend()
+	s.DailySpend = &spend
+	s.DailySpendCap = &limit
+	s.DailySpendCapReached = spend >= limit
+}
+
+// accountHealthRegistry 进程内的账号健康状态表，供调度器排除不健康账号
+type accountHealthRegistry struct {
+	mu       sync.RWMutex
+	statuses map[int64]*AccountHealthStatus
+}
+
+func newAccountHealthRegistry() *accountHealthRegistry {
+	return &accountHealthRegistry{statuses: make(map[int64]*AccountHealthStatus)}
+}
+
+// defaultAccountHealthRegistry 健康检查写入、调度读取的共享状态
+var defaultAccountHealthRegistry = newAccountHealthRegistry()
+
+// record 记录一次探测结果；连续失败达到 threshold 次后标记为不健康，任意一次成功即恢复
+func (r *accountHealthRegistry) record(account *Account, result *ScheduledTestResult, threshold int) AccountHealthStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.statuses[account.ID]
+	if status == nil {
+		status = &AccountHealthStatus{AccountID: account.ID, Healthy: true}
+		r.statuses[account.ID] = status
+	}
+	status.Name = account.Name
+	status.Platform = account.Platform
+	status.MaxConcurrency = account.Concurrency
+	checkedAt := result.FinishedAt
+	status.LastCheckAt = &checkedAt
+	status.LastLatencyMs = result.LatencyMs
+
+	if result.Status == "success" {
+		finishedAt := result.FinishedAt
+		status.LastSuccessAt = &finishedAt
+		status.ConsecutiveFailures = 0
+		status.LastError = ""
+		status.Healthy = true
+		if result.LatencyMs > 0 {
+			if status.AvgLatencyMs <= 0 {
+				status.AvgLatencyMs = float64(result.LatencyMs)
+			} else {
+				status.AvgLatencyMs += accountHealthLatencyAlpha * (float64(result.LatencyMs) - status.AvgLatencyMs)
+			}
+		}
+	} else {
+		status.ConsecutiveFailures++
+		status.LastError = result.ErrorMessage
+		if status.ConsecutiveFailures >= threshold {
+			status.Healthy = false
+		}
+	}
+	return *status
+}
+
+// isUnhealthy 账号是否已被健康检查标记为不健康（未探测过的账号视为健康）
+func (r *accountHealthRegistry) isUnhealthy(accountID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := r.statuses[accountID]
+	return status != nil && !status.Healthy
+}
+
+// averageLatency 账号健康检查测得的平均延迟（毫秒）；未探测或尚无成功样本时返回 false
+func (r *accountHealthRegistry) averageLatency(accountID int64) (float64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := r.statuses[accountID]
+	if status == nil || status.AvgLatencyMs <= 0 {
+		return 0, false
+	}
+	return status.AvgLatencyMs, true
+}
+
+// retain 删除不在 ids 中的账号（已删除或不再可调度）
+func (r *accountHealthRegistry) retain(ids map[int64]struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.statuses {
+		if _, ok := ids[id]; !ok {
+			delete(r.statuses, id)
+		}
+	}
+}
+
+func (r *accountHealthRegistry) get(accountID int64) (AccountHealthStatus, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := r.statuses[accountID]
+	if status == nil {
+		return AccountHealthStatus{}, false
+	}
+	return *status, true
+}
+
+func (r *accountHealthRegistry) list() []AccountHealthStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]AccountHealthStatus, 0, len(r.statuses))
+	for _, status := range r.statuses {
+		out = append(out, *status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AccountID < out[j].AccountID })
+	return out
+}
+
+// isAccountMarkedUnhealthy 调度路径使用：健康检查判定为不健康的账号不参与选择
+func isAccountMarkedUnhealthy(accountID int64) bool {
+	return defaultAccountHealthRegistry.isUnhealthy(accountID)
+}
+
+// AccountAverageLatencyMs 返回账号健康检查测得的平均延迟（毫秒），用于 latency 调度与响应头透出
+func AccountAverageLatencyMs(accountID int64) (float64, bool) {
+	return defaultAccountHealthRegistry.averageLatency(accountID)
+}
+
+// BuildAccountHealthStatuses 按给定账号列表生成健康状态（按账号 ID 排序）。
+// 名称、平台与并发上限取自账号当前配置；未被健康检查探测过的账号视为健康且不含探测数据（last_check_at 为空）。
+func BuildAccountHealthStatuses(accounts []Account) []AccountHealthStatus {
+	statuses := make([]AccountHealthStatus, 0, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		status, ok := defaultAccountHealthRegistry.get(account.ID)
+		if !ok {
+			status = AccountHealthStatus{AccountID: account.ID, Healthy: true}
+		}
+		status.Name = account.Name
+		status.Platform = account.Platform
+		status.MaxConcurrency = account.Concurrency
+		status.CircuitState = defaultAccountCircuitBreakerRegistry.state(account.ID)
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].AccountID < statuses[j].AccountID })
+	return statuses
+}
+
+// AccountHealthCheckService 后台周期性向每个可调度账号的上游发送最小请求，记录成功与延迟。
+// 连续失败达到阈值的账号会被调度器排除，直到后续探测成功。
+// 探测成功时熔断中的账号提前进入半开状态；不再可调度（被手动停止调度或删除）的账号同时清除熔断状态。
+type AccountHealthCheckService struct {
+	accountRepo AccountRepository
+	registry    *accountHealthRegistry
+	breakers    *accountCircuitBreakerRegistry
+	probe       func(ctx context.Context, accountID int64) (*ScheduledTestResult, error)
+
+	interval  time.Duration
+	threshold int
+	timeout   time.Duration
+	workers   int
+
+	running  atomic.Bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewAccountHealthCheckService 创建账号健康检查服务
+func NewAccountHealthCheckService(accountRepo AccountRepository, accountTestSvc *AccountTestService, cfg *config.Config) *AccountHealthCheckService {
+	s := &AccountHealthCheckService{
+		accountRepo: accountRepo,
+		registry:    defaultAccountHealthRegistry,
+		breakers:    defaultAccountCircuitBreakerRegistry,
+		interval:    accountHealthDefaultInterval,
+		threshold:   accountHealthDefaultThreshold,
+		timeout:     accountHealthDefaultTimeout,
+		workers:     accountHealthDefaultWorkers,
+		stopCh:      make(chan struct{}),
+	}
+	if accountTestSvc != nil {
+		s.probe = func(ctx context.Context, accountID int64) (*ScheduledTestResult, error) {
+			return accountTestSvc.RunTestBackground(ctx, accountID, "")
+		}
+	}
+	if cfg != nil {
+		s.breakers.configure(cfg.AccountCircuitBreaker)
+		hc := cfg.AccountHealthCheck
+		if !hc.Enabled {
+			s.interval = 0
+		} else if hc.IntervalSeconds > 0 {
+			s.interval = time.Duration(hc.IntervalSeconds) * time.Second
+		}
+		if hc.FailureThreshold > 0 {
+			s.threshold = hc.FailureThreshold
+		}
+		if hc.TimeoutSeconds > 0 {
+			s.timeout = time.Duration(hc.TimeoutSeconds) * time.Second
+		}
+		if hc.MaxWorkers > 0 {
+			s.workers = hc.MaxWorkers
+		}
+	}
+	return s
+}
+
+// Start 启动后台探测（未启用或缺少依赖时不启动）
+func (s *AccountHealthCheckService) Start() {
+	if s == nil || s.accountRepo == nil || s.probe == nil || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		log.Printf("[AccountHealth] started (interval=%s threshold=%d)", s.interval, s.threshold)
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台探测并等待当前轮次结束
+func (s *AccountHealthCheckService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+// runOnce 探测一轮所有可调度账号；上一轮未结束时跳过
+func (s *AccountHealthCheckService) runOnce() {
+	if !s.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer s.running.Store(false)
+
+	listCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	accounts, err := s.accountRepo.ListSchedulable(listCtx)
+	cancel()
+	if err != nil {
+		log.Printf("[AccountHealth] List schedulable accounts failed: %v", err)
+		return
+	}
+
+	ids := make(map[int64]struct{}, len(accounts))
+	for i := range accounts {
+		ids[accounts[i].ID] = struct{}{}
+	}
+	s.registry.retain(ids)
+	if s.breakers != nil {
+		s.breakers.retain(ids)
+	}
+
+	sem := make(chan struct{}, s.workers)
+	var wg sync.WaitGroup
+	for i := range accounts {
+		select {
+		case <-s.stopCh:
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(account *Account) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.checkAccount(account)
+		}(&accounts[i])
+	}
+	wg.Wait()
+}
+
+func (s *AccountHealthCheckService) checkAccount(account *Account) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	result, err := s.probe(ctx, account.ID)
+	if err != nil || result == nil {
+		errMsg := "probe returned no result"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		finishedAt := time.Now()
+		result = &ScheduledTestResult{
+			Status:       "failed",
+			ErrorMessage: errMsg,
+			LatencyMs:    finishedAt.Sub(startedAt).Milliseconds(),
+			StartedAt:    startedAt,
+			FinishedAt:   finishedAt,
+		}
+	}
+
+	wasUnhealthy := s.registry.isUnhealthy(account.ID)
+	status := s.registry.record(account, result, s.threshold)
+	if status.Healthy && s.breakers != nil {
+		s.breakers.halfOpen(account.ID)
+	}
+	switch {
+	case wasUnhealthy && status.Healthy:
+		log.Printf("[AccountHealth] account=%d recovered (latency=%dms)", account.ID, status.LastLatencyMs)
+	case !wasUnhealthy && !status.Healthy:
+		log.Printf("[AccountHealth] account=%d marked unhealthy after %d consecutive failures: %s", account.ID, status.ConsecutiveFailures, status.LastError)
+	}
+}
@@ -0,0 +1,198 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// healthCheckAccountRepoStub 仅实现健康检查需要的 ListSchedulable
+type healthCheckAccountRepoStub struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (s *healthCheckAccountRepoStub) ListSchedulable(_ context.Context) ([]Account, error) {
+	return append([]Account(nil), s.accounts...), nil
+}
+
+func newHealthCheckTestService(accounts []Account, probe func(accountID int64) (*ScheduledTestResult, error)) *AccountHealthCheckService {
+	return &AccountHealthCheckService{
+		accountRepo: &healthCheckAccountRepoStub{accounts: accounts},
+		registry:    newAccountHealthRegistry(),
+		probe: func(_ context.Context, accountID int64) (*ScheduledTestResult, error) {
+			return probe(accountID)
+		},
+		threshold: 2,
+		timeout:   time.Second,
+		workers:   2,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+func TestAccountHealthCheck_MarksUnhealthyAfterThresholdAndRecovers(t *testing.T) {
+	var mu sync.Mutex
+	failing := map[int64]bool{2: true}
+	svc := newHealthCheckTestService([]Account{{ID: 1, Name: "ok", Concurrency: 4}, {ID: 2, Name: "bad"}}, func(accountID int64) (*ScheduledTestResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if failing[accountID] {
+			return &ScheduledTestResult{Status: "failed", ErrorMessage: "upstream 500", LatencyMs: 5, FinishedAt: now}, nil
+		}
+		return &ScheduledTestResult{Status: "success", LatencyMs: 12, FinishedAt: now}, nil
+	})
+
+	svc.runOnce()
+	require.False(t, svc.registry.isUnhealthy(2), "单次失败不应立即标记")
+
+	svc.runOnce()
+	require.True(t, svc.registry.isUnhealthy(2))
+	require.False(t, svc.registry.isUnhealthy(1))
+
+	statuses := svc.registry.list()
+	require.Len(t, statuses, 2)
+	require.Equal(t, int64(12), statuses[0].LastLatencyMs)
+	require.Equal(t, 4, statuses[0].MaxConcurrency)
+	require.NotNil(t, statuses[0].LastSuccessAt)
+	require.Equal(t, 2, statuses[1].ConsecutiveFailures)
+	require.Equal(t, "upstream 500", statuses[1].LastError)
+
+	mu.Lock()
+	failing[2] = false
+	mu.Unlock()
+	svc.runOnce()
+	require.False(t, svc.registry.isUnhealthy(2))
+	require.Equal(t, 0, svc.registry.list()[1].ConsecutiveFailures)
+}
+
+func TestAccountHealthCheck_ProbeErrorCountsAsFailure(t *testing.T) {
+	svc := newHealthCheckTestService([]Account{{ID: 3}}, func(int64) (*ScheduledTestResult, error) {
+		return nil, errors.New("boom")
+	})
+	svc.runOnce()
+	svc.runOnce()
+
+	require.True(t, svc.registry.isUnhealthy(3))
+	require.Equal(t, "boom", svc.registry.list()[0].LastError)
+}
+
+func TestAccountHealthCheck_DropsAccountsNoLongerSchedulable(t *testing.T) {
+	repo := &healthCheckAccountRepoStub{accounts: []Account{{ID: 1}, {ID: 2}}}
+	svc := newHealthCheckTestService(nil, func(int64) (*ScheduledTestResult, error) {
+		return &ScheduledTestResult{Status: "failed", FinishedAt: time.Now()}, nil
+	})
+	svc.accountRepo = repo
+	svc.runOnce()
+	svc.runOnce()
+	require.True(t, svc.registry.isUnhealthy(2))
+
+	repo.accounts = []Account{{ID: 1}}
+	svc.runOnce()
+	require.False(t, svc.registry.isUnhealthy(2))
+	require.Len(t, svc.registry.list(), 1)
+}
+
+func TestAccountHealthRegistry_AverageLatency(t *testing.T) {
+	r := newAccountHealthRegistry()
+	account := &Account{ID: 1}
+	_, ok := r.averageLatency(1)
+	require.False(t, ok)
+
+	r.record(account, &ScheduledTestResult{Status: "success", LatencyMs: 100, FinishedAt: time.Now()}, 3)
+	avg, ok := r.averageLatency(1)
+	require.True(t, ok)
+	require.InDelta(t, 100, avg, 1e-9)
+
+	r.record(account, &ScheduledTestResult{Status: "success", LatencyMs: 200, FinishedAt: time.Now()}, 3)
+	avg, _ = r.averageLatency(1)
+	require.InDelta(t, 130, avg, 1e-9)
+
+	// 失败探测不影响平均延迟
+	r.record(account, &ScheduledTestResult{Status: "failed", LatencyMs: 5000, FinishedAt: time.Now()}, 3)
+	avg, _ = r.averageLatency(1)
+	require.InDelta(t, 130, avg, 1e-9)
+}
+
+func TestBuildRoutingLatencyTable(t *testing.T) {
+	const fastID, slowID, unknownID = int64(910001), int64(910002), int64(910003)
+	t.Cleanup(func() {
+		defaultAccountHealthRegistry.retain(map[int64]struct{}{})
+	})
+	accounts := []*Account{{ID: fastID}, {ID: slowID}, {ID: unknownID}}
+	require.Nil(t, buildRoutingLatencyTable(accounts))
+
+	now := time.Now()
+	defaultAccountHealthRegistry.record(&Account{ID: fastID}, &ScheduledTestResult{Status: "success", LatencyMs: 50, FinishedAt: now}, 3)
+	defaultAccountHealthRegistry.record(&Account{ID: slowID}, &ScheduledTestResult{Status: "success", LatencyMs: 300, FinishedAt: now}, 3)
+
+	table := buildRoutingLatencyTable(accounts)
+	require.InDelta(t, 50, table[fastID], 1e-9)
+	require.InDelta(t, 300, table[slowID], 1e-9)
+	require.True(t, math.IsInf(table[unknownID], 1))
+	require.Equal(t, GroupSchedulingStrategyLatency, normalizeGroupSchedulingStrategy("latency"))
+}
+
+func TestBuildAccountHealthStatuses_UsesCurrentAccountConfig(t *testing.T) {
+	const probedID, unprobedID = int64(920001), int64(920002)
+	t.Cleanup(func() {
+		defaultAccountHealthRegistry.retain(map[int64]struct{}{})
+	})
+	defaultAccountHealthRegistry.record(&Account{ID: probedID, Name: "old", Concurrency: 2}, &ScheduledTestResult{Status: "success", LatencyMs: 40, FinishedAt: time.Now()}, 3)
+
+	statuses := BuildAccountHealthStatuses([]Account{
+		{ID: unprobedID, Name: "fresh", Platform: PlatformOpenAI, Concurrency: 3},
+		{ID: probedID, Name: "renamed", Platform: PlatformAnthropic, Concurrency: 8},
+	})
+	require.Len(t, statuses, 2)
+
+	probed := statuses[0]
+	require.Equal(t, probedID, probed.AccountID)
+	require.Equal(t, "renamed", probed.Name)
+	require.Equal(t, 8, probed.MaxConcurrency, "并发上限应取账号当前配置而非探测时的快照")
+	require.NotNil(t, probed.LastCheckAt)
+	require.Equal(t, int64(40), probed.LastLatencyMs)
+
+	unprobed := statuses[1]
+	require.Equal(t, unprobedID, unprobed.AccountID)
+	require.True(t, unprobed.Healthy)
+	require.Nil(t, unprobed.LastCheckAt)
+	require.Equal(t, 3, unprobed.MaxConcurrency)
+	require.Equal(t, CircuitStateClosed, unprobed.CircuitState)
+}
+
+func TestAccountHealthStatus_FillDailySpend(t *testing.T) {
+	periodStart := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	capped := &Account{ID: 1, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Extra: map[string]any{
+		"quota_daily_limit": 20.0,
+		"quota_daily_used":  20.5,
+		"quota_daily_start": periodStart,
+	}}
+	var status AccountHealthStatus
+	status.FillDailySpend(capped)
+	require.InDelta(t, 20.5, *status.DailySpend, 1e-9)
+	require.InDelta(t, 20.0, *status.DailySpendCap, 1e-9)
+	require.True(t, status.DailySpendCapReached)
+	require.False(t, capped.IsSchedulable())
+
+	// 周期已过期时花费归零，账号恢复调度
+	capped.Extra["quota_daily_start"] = time.Now().Add(-25 * time.Hour).Format(time.RFC3339)
+	status.FillDailySpend(capped)
+	require.Zero(t, *status.DailySpend)
+	require.False(t, status.DailySpendCapReached)
+	require.True(t, capped.IsSchedulable())
+
+	// 未设置上限或非按量计费账号不填充
+	status.FillDailySpend(&Account{ID: 2, Type: AccountTypeAPIKey})
+	require.Nil(t, status.DailySpend)
+	status.FillDailySpend(&Account{ID: 3, Type: AccountTypeOAuth, Extra: map[string]any{"quota_daily_limit": 5.0}})
+	require.Nil(t, status.DailySpendCap)
+	require.False(t, status.DailySpendCapReached)
+}
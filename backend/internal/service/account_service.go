@@ -35,6 +35,8 @@ type AccountRepository interface {
 	ListCRSAccountIDs(ctx context.Context) (map[string]int64, error)
 	Update(ctx context.Context, account *Account) error
 	Delete(ctx context.Context, id int64) error
+	// Restore 恢复软删除的账号（清除 deleted_at）；账号未删除时为 no-op，不存在时返回 ErrAccountNotFound
+	Restore(ctx context.Context, id int64) error
 
 	List(ctx context.Context, params pagination.PaginationParams) ([]Account, *pagination.PaginationResult, error)
 	ListWithFilters(ctx context.Context, params pagination.PaginationParams, platform, accountType, status, search string, groupID int64, privacyMode string) ([]Account, *pagination.PaginationResult, error)
@@ -86,6 +88,7 @@ type AccountBulkUpdate struct {
 	Priority       *int
 	RateMultiplier *float64
 	LoadFactor     *int
+	Weight         *int // 负数表示清除（恢复默认权重）
 	Status         *string
 	Schedulable    *bool
 	Credentials    map[string]any
@@ -75,6 +75,10 @@ func (s *accountRepoStub) Delete(ctx context.Context, id int64) error {
 
 // 以下是接口要求实现但本测试不关心的方法
 
+func (s *accountRepoStub) Restore(ctx context.Context, id int64) error {
+	panic("unexpected Restore call")
+}
+
 func (s *accountRepoStub) List(ctx context.Context, params pagination.PaginationParams) ([]Account, *pagination.PaginationResult, error) {
 	panic("unexpected List call")
 }
@@ -302,3 +302,62 @@ func TestAccountTestService_OpenAI401SetsPermanentErrorOnly(t *testing.T) {
 	require.Zero(t, repo.clearedErrorID)
 	require.Nil(t, account.RateLimitResetAt)
 }
+
+func TestAccountTestService_RunTestSyncReportsStatusLatencyAndUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resp := newJSONResponse(http.StatusOK, "")
+	resp.Body = io.NopCloser(strings.NewReader(`data: {"type":"response.output_text.delta","delta":"pong"}
+
+data: {"type":"response.completed","response":{"usage":{"input_tokens":12,"output_tokens":3}}}
+
+`))
+	account := &Account{
+		ID:          91,
+		Platform:    PlatformOpenAI,
+		Type:        AccountTypeOAuth,
+		Concurrency: 1,
+		Credentials: map[string]any{"access_token": "test-token"},
+	}
+	repo := &openAIAccountTestRepo{mockAccountRepoForGemini: mockAccountRepoForGemini{accountsByID: map[int64]*Account{91: account}}}
+	upstream := &queuedHTTPUpstream{responses: []*http.Response{resp}}
+	svc := &AccountTestService{accountRepo: repo, httpUpstream: upstream}
+
+	summary := svc.RunTestSync(context.Background(), 91, "gpt-5.4", "", "")
+	require.True(t, summary.Success)
+	require.Equal(t, int64(91), summary.AccountID)
+	require.Equal(t, "gpt-5.4", summary.Model)
+	require.Equal(t, http.StatusOK, summary.UpstreamStatus)
+	require.GreaterOrEqual(t, summary.LatencyMs, int64(0))
+	require.Equal(t, &AccountTestUsage{InputTokens: 12, OutputTokens: 3}, summary.Usage)
+	require.Equal(t, "pong", summary.ResponseText)
+	require.Empty(t, summary.Error)
+	require.True(t, summary.Test)
+	require.False(t, summary.Billed)
+}
+
+func TestAccountTestService_RunTestSyncReportsUpstreamError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	account := &Account{
+		ID:          92,
+		Platform:    PlatformOpenAI,
+		Type:        AccountTypeOAuth,
+		Concurrency: 1,
+		Credentials: map[string]any{"access_token": "test-token"},
+	}
+	repo := &openAIAccountTestRepo{mockAccountRepoForGemini: mockAccountRepoForGemini{accountsByID: map[int64]*Account{92: account}}}
+	upstream := &queuedHTTPUpstream{responses: []*http.Response{newJSONResponse(http.StatusBadGateway, `{"error":{"message":"bad gateway"}}`)}}
+	svc := &AccountTestService{accountRepo: repo, httpUpstream: upstream}
+
+	summary := svc.RunTestSync(context.Background(), 92, "gpt-5.4", "", "")
+	require.False(t, summary.Success)
+	require.Equal(t, http.StatusBadGateway, summary.UpstreamStatus)
+	require.Contains(t, summary.Error, "502")
+	require.Nil(t, summary.Usage)
+
+	summary = svc.RunTestSync(context.Background(), 404, "", "", "")
+	require.False(t, summary.Success)
+	require.Zero(t, summary.UpstreamStatus)
+	require.Equal(t, "Account not found", summary.Error)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// Gin context keys used to collect upstream details while an account test runs.
+const (
+	accountTestUpstreamStatusKey = "account_test_upstream_status"
+	accountTestUsageKey          = "account_test_usage"
+)
+
+// AccountTestUsage is the token usage reported by the upstream during an account test.
+type AccountTestUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// AccountTestSummary is the non-streaming result of forcing one request through a single account.
+// Account tests bypass the gateway entirely: no usage log is written and nothing is charged
+// to any user, so Test is always true and Billed is always false.
+type AccountTestSummary struct {
+	AccountID      int64             `json:"account_id"`
+	Model          string            `json:"model,omitempty"`
+	Success        bool              `json:"success"`
+	UpstreamStatus int               `json:"upstream_status,omitempty"`
+	LatencyMs      int64             `json:"latency_ms"`
+	Usage          *AccountTestUsage `json:"usage,omitempty"`
+	ResponseText   string            `json:"response_text,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	Test           bool              `json:"test"`
+	Billed         bool              `json:"billed"`
+}
+
+// recordTestUpstreamStatus remembers the HTTP status returned by the upstream for the current test.
+func recordTestUpstreamStatus(c *gin.Context, status int) {
+	c.Set(accountTestUpstreamStatusKey, status)
+}
+
+// recordTestStreamUsage extracts token usage from a Claude / OpenAI Responses / Gemini stream event.
+// Values reported across several events (e.g. Claude message_start + message_delta) are merged.
+func recordTestStreamUsage(c *gin.Context, payload string) {
+	var input, output int64
+	switch {
+	case gjson.Get(payload, "message.usage").Exists():
+		input = gjson.Get(payload, "message.usage.input_tokens").Int()
+		output = gjson.Get(payload, "message.usage.output_tokens").Int()
+	case gjson.Get(payload, "response.usage").Exists():
+		input = gjson.Get(payload, "response.usage.input_tokens").Int()
+		output = gjson.Get(payload, "response.usage.output_tokens").Int()
+	case gjson.Get(payload, "usageMetadata").Exists():
+		input = gjson.Get(payload, "usageMetadata.promptTokenCount").Int()
+		output = gjson.Get(payload, "usageMetadata.candidatesTokenCount").Int()
+	case gjson.Get(payload, "response.usageMetadata").Exists():
+		input = gjson.Get(payload, "response.usageMetadata.promptTokenCount").Int()
+		output = gjson.Get(payload, "response.usageMetadata.candidatesTokenCount").Int()
+	case gjson.Get(payload, "usage").Exists():
+		input = gjson.Get(payload, "usage.input_tokens").Int()
+		output = gjson.Get(payload, "usage.output_tokens").Int()
+	default:
+		return
+	}
+	if input <= 0 && output <= 0 {
+		return
+	}
+
+	usage := &AccountTestUsage{}
+	if v, ok := c.Get(accountTestUsageKey); ok {
+		if existing, ok := v.(*AccountTestUsage); ok {
+			usage = existing
+		}
+	}
+	if input > 0 {
+		usage.InputTokens = int(input)
+	}
+	if output > 0 {
+		usage.OutputTokens = int(output)
+	}
+	c.Set(accountTestUsageKey, usage)
+}
+
+// RunTestSync forces a single test request through the given account (bypassing account selection)
+// and returns a JSON-friendly summary instead of streaming SSE events.
+func (s *AccountTestService) RunTestSync(ctx context.Context, accountID int64, modelID, prompt, mode string) *AccountTestSummary {
+	startedAt := time.Now()
+
+	w := httptest.NewRecorder()
+	ginCtx, _ := gin.CreateTestContext(w)
+	ginCtx.Request = (&http.Request{}).WithContext(ctx)
+
+	testErr := s.TestAccountConnection(ginCtx, accountID, modelID, prompt, mode)
+
+	summary := &AccountTestSummary{
+		AccountID: accountID,
+		LatencyMs: time.Since(startedAt).Milliseconds(),
+		Test:      true,
+	}
+	body := w.Body.String()
+	summary.ResponseText, summary.Error = parseTestSSEOutput(body)
+	summary.Model = parseTestSSEModel(body)
+	if summary.Error == "" && testErr != nil {
+		summary.Error = testErr.Error()
+	}
+	summary.Success = summary.Error == ""
+	if v, ok := ginCtx.Get(accountTestUpstreamStatusKey); ok {
+		summary.UpstreamStatus, _ = v.(int)
+	}
+	if v, ok := ginCtx.Get(accountTestUsageKey); ok {
+		summary.Usage, _ = v.(*AccountTestUsage)
+	}
+	return summary
+}
+
+// parseTestSSEModel extracts the tested model from the test_start event in captured SSE output.
+func parseTestSSEModel(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event TestEvent
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			continue
+		}
+		if event.Type == "test_start" {
+			return event.Model
+		}
+	}
+	return ""
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UpstreamRateLimitBucket 上游返回的单个限额维度（请求数 / token 数）
+type UpstreamRateLimitBucket struct {
+	Limit     int64      `json:"limit,omitempty"`
+	Remaining int64      `json:"remaining"`
+	ResetAt   *time.Time `json:"reset_at,omitempty"`
+}
+
+// AccountUpstreamRateLimit 账号最近一次上游响应中的限额头快照
+type AccountUpstreamRateLimit struct {
+	AccountID    int64                    `json:"account_id"`
+	Requests     *UpstreamRateLimitBucket `json:"requests,omitempty"`
+	Tokens       *UpstreamRateLimitBucket `json:"tokens,omitempty"`
+	InputTokens  *UpstreamRateLimitBucket `json:"input_tokens,omitempty"`
+	OutputTokens *UpstreamRateLimitBucket `json:"output_tokens,omitempty"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+// buckets 返回已记录的所有维度
+func (r *AccountUpstreamRateLimit) buckets() []*UpstreamRateLimitBucket {
+	out := make([]*UpstreamRateLimitBucket, 0, 4)
+	for _, b := range []*UpstreamRateLimitBucket{r.Requests, r.Tokens, r.InputTokens, r.OutputTokens} {
+		if b != nil {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// IsLow 任一维度在重置前的剩余量低于 limit*ratio 时视为配额偏低；ratio<=0 表示不判断
+func (r *AccountUpstreamRateLimit) IsLow(ratio float64, now time.Time) bool {
+	if r == nil || ratio <= 0 {
+		return false
+	}
+	for _, b := range r.buckets() {
+		if b.Limit <= 0 {
+			continue
+		}
+		if b.ResetAt != nil && !now.Before(*b.ResetAt) {
+			continue
+		}
+		if float64(b.Remaining) < float64(b.Limit)*ratio {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseUpstreamRateLimitHeaders 解析上游限额响应头：
+//   - Anthropic: anthropic-ratelimit-{requests,tokens,input-tokens,output-tokens}-{limit,remaining,reset}（reset 为 RFC3339）
+//   - OpenAI: x-ratelimit-{limit,remaining,reset}-{requests,tokens}（reset 为时长，如 "6m0s"）
+//
+// 未携带任何剩余量头时返回 nil。
+func ParseUpstreamRateLimitHeaders(headers http.Header, now time.Time) *AccountUpstreamRateLimit {
+	if len(headers) == 0 {
+		return nil
+	}
+	snapshot := &AccountUpstreamRateLimit{UpdatedAt: now}
+	anthropic := func(dim string) *UpstreamRateLimitBucket {
+		prefix := "anthropic-ratelimit-" + dim + "-"
+		return parseUpstreamRateLimitBucket(headers, prefix+"limit", prefix+"remaining", prefix+"reset", now)
+	}
+	openai := func(dim string) *UpstreamRateLimitBucket {
+		return parseUpstreamRateLimitBucket(headers, "x-ratelimit-limit-"+dim, "x-ratelimit-remaining-"+dim, "x-ratelimit-reset-"+dim, now)
+	}
+	snapshot.Requests = anthropic("requests")
+	if snapshot.Requests == nil {
+		snapshot.Requests = openai("requests")
+	}
+	snapshot.Tokens = anthropic("tokens")
+	if snapshot.Tokens == nil {
+		snapshot.Tokens = openai("tokens")
+	}
+	snapshot.InputTokens = anthropic("input-tokens")
+	snapshot.OutputTokens = anthropic("output-tokens")
+	if len(snapshot.buckets()) == 0 {
+		return nil
+	}
+	return snapshot
+}
+
+func parseUpstreamRateLimitBucket(headers http.Header, limitKey, remainingKey, resetKey string, now time.Time) *UpstreamRateLimitBucket {
+	remainingStr := strings.TrimSpace(headers.Get(remainingKey))
+	if remainingStr == "" {
+		return nil
+	}
+	remaining, err := strconv.ParseInt(remainingStr, 10, 64)
+	if err != nil {
+		return nil
+	}
+	bucket := &UpstreamRateLimitBucket{Remaining: remaining}
+	if limit, err := strconv.ParseInt(strings.TrimSpace(headers.Get(limitKey)), 10, 64); err == nil {
+		bucket.Limit = limit
+	}
+	if resetStr := strings.TrimSpace(headers.Get(resetKey)); resetStr != "" {
+		if t, err := time.Parse(time.RFC3339, resetStr); err == nil {
+			bucket.ResetAt = &t
+		} else if d, err := time.ParseDuration(resetStr); err == nil {
+			t := now.Add(d)
+			bucket.ResetAt = &t
+		}
+	}
+	return bucket
+}
+
+// upstreamRateLimitRegistry 进程内的账号上游限额快照，供健康接口展示与调度降权
+type upstreamRateLimitRegistry struct {
+	mu        sync.RWMutex
+	snapshots map[int64]*AccountUpstreamRateLimit
+}
+
+func newUpstreamRateLimitRegistry() *upstreamRateLimitRegistry {
+	return &upstreamRateLimitRegistry{snapshots: make(map[int64]*AccountUpstreamRateLimit)}
+}
+
+// defaultUpstreamRateLimitRegistry 网关响应写入、调度读取的共享状态
+var defaultUpstreamRateLimitRegistry = newUpstreamRateLimitRegistry()
+
+// record 合并最新快照：本次响应携带的维度覆盖旧值，未携带的维度保留
+func (r *upstreamRateLimitRegistry) record(accountID int64, snapshot *AccountUpstreamRateLimit) {
+	if accountID <= 0 || snapshot == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.snapshots[accountID]
+	if current == nil {
+		current = &AccountUpstreamRateLimit{AccountID: accountID}
+		r.snapshots[accountID] = current
+	}
+	if snapshot.Requests != nil {
+		current.Requests = snapshot.Requests
+	}
+	if snapshot.Tokens != nil {
+		current.Tokens = snapshot.Tokens
+	}
+	if snapshot.InputTokens != nil {
+		current.InputTokens = snapshot.InputTokens
+	}
+	if snapshot.OutputTokens != nil {
+		current.OutputTokens = snapshot.OutputTokens
+	}
+	current.UpdatedAt = snapshot.UpdatedAt
+}
+
+func (r *upstreamRateLimitRegistry) get(accountID int64) *AccountUpstreamRateLimit {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot := r.snapshots[accountID]
+	if snapshot == nil {
+		return nil
+	}
+	out := *snapshot
+	return &out
+}
+
+func (r *upstreamRateLimitRegistry) isLow(accountID int64, ratio float64, now time.Time) bool {
+	if ratio <= 0 {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.snapshots[accountID].IsLow(ratio, now)
+}
+
+func (r *upstreamRateLimitRegistry) list() []AccountUpstreamRateLimit {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]AccountUpstreamRateLimit, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		out = append(out, *snapshot)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AccountID < out[j].AccountID })
+	return out
+}
+
+// recordUpstreamRateLimitHeaders 从上游响应头记录账号限额快照（无相关头时忽略）
+func recordUpstreamRateLimitHeaders(account *Account, headers http.Header) {
+	if account == nil {
+		return
+	}
+	defaultUpstreamRateLimitRegistry.record(account.ID, ParseUpstreamRateLimitHeaders(headers, time.Now()))
+}
+
+// GetAccountUpstreamRateLimit 返回账号最近记录的上游限额快照，未记录时返回 nil
+func GetAccountUpstreamRateLimit(accountID int64) *AccountUpstreamRateLimit {
+	return defaultUpstreamRateLimitRegistry.get(accountID)
+}
+
+// ListAccountUpstreamRateLimits 返回所有已记录账号的上游限额快照（按账号 ID 排序）
+func ListAccountUpstreamRateLimits() []AccountUpstreamRateLimit {
+	return defaultUpstreamRateLimitRegistry.list()
+}
+
+// isAccountLowOnUpstreamRateLimit 调度路径使用：上游剩余配额低于阈值的账号降权
+func isAccountLowOnUpstreamRateLimit(accountID int64, ratio float64) bool {
+	return defaultUpstreamRateLimitRegistry.isLow(accountID, ratio, time.Now())
+}
+
+// filterByUpstreamRateLimitHeadroom 过滤出上游剩余配额充足的账号；全部偏低时原样返回（降权而非排除）
+func filterByUpstreamRateLimitHeadroom(accounts []accountWithLoad, ratio float64) []accountWithLoad {
+	if ratio <= 0 || len(accounts) == 0 {
+		return accounts
+	}
+	result := make([]accountWithLoad, 0, len(accounts))
+	for _, acc := range accounts {
+		if !isAccountLowOnUpstreamRateLimit(acc.account.ID, ratio) {
+			result = append(result, acc)
+		}
+	}
+	if len(result) == 0 {
+		return accounts
+	}
+	return result
+}
+
+// moveUpstreamRateLimitLowToEnd 稳定地把上游剩余配额偏低的账号移到末尾
+func moveUpstreamRateLimitLowToEnd(accounts []accountWithLoad, ratio float64) []accountWithLoad {
+	if ratio <= 0 || len(accounts) == 0 {
+		return accounts
+	}
+	ordered := make([]accountWithLoad, 0, len(accounts))
+	var low []accountWithLoad
+	for _, acc := range accounts {
+		if isAccountLowOnUpstreamRateLimit(acc.account.ID, ratio) {
+			low = append(low, acc)
+			continue
+		}
+		ordered = append(ordered, acc)
+	}
+	return append(ordered, low...)
+}
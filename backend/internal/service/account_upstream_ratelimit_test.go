@@ -0,0 +1,91 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpstreamRateLimitHeaders_Anthropic(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	headers := http.Header{}
+	headers.Set("anthropic-ratelimit-requests-limit", "1000")
+	headers.Set("anthropic-ratelimit-requests-remaining", "42")
+	headers.Set("anthropic-ratelimit-requests-reset", "2026-01-01T00:01:00Z")
+	headers.Set("anthropic-ratelimit-input-tokens-limit", "400000")
+	headers.Set("anthropic-ratelimit-input-tokens-remaining", "399000")
+
+	snapshot := ParseUpstreamRateLimitHeaders(headers, now)
+	require.NotNil(t, snapshot)
+	require.Equal(t, int64(1000), snapshot.Requests.Limit)
+	require.Equal(t, int64(42), snapshot.Requests.Remaining)
+	require.Equal(t, now.Add(time.Minute), *snapshot.Requests.ResetAt)
+	require.Equal(t, int64(399000), snapshot.InputTokens.Remaining)
+	require.Nil(t, snapshot.InputTokens.ResetAt)
+	require.Nil(t, snapshot.Tokens)
+
+	require.True(t, snapshot.IsLow(0.1, now))
+	require.False(t, snapshot.IsLow(0.01, now))
+	// 已过重置时间的维度不再视为偏低
+	require.False(t, snapshot.IsLow(0.1, now.Add(2*time.Minute)))
+	require.False(t, snapshot.IsLow(0, now))
+}
+
+func TestParseUpstreamRateLimitHeaders_OpenAI(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	headers := http.Header{}
+	headers.Set("x-ratelimit-limit-tokens", "150000")
+	headers.Set("x-ratelimit-remaining-tokens", "149984")
+	headers.Set("x-ratelimit-reset-tokens", "6m0s")
+
+	snapshot := ParseUpstreamRateLimitHeaders(headers, now)
+	require.NotNil(t, snapshot)
+	require.Nil(t, snapshot.Requests)
+	require.Equal(t, int64(149984), snapshot.Tokens.Remaining)
+	require.Equal(t, now.Add(6*time.Minute), *snapshot.Tokens.ResetAt)
+
+	require.Nil(t, ParseUpstreamRateLimitHeaders(http.Header{"Content-Type": {"application/json"}}, now))
+}
+
+func TestUpstreamRateLimitRegistry_MergesAndDeprioritizes(t *testing.T) {
+	registry := newUpstreamRateLimitRegistry()
+	now := time.Now()
+	registry.record(1, &AccountUpstreamRateLimit{Requests: &UpstreamRateLimitBucket{Limit: 100, Remaining: 5}, UpdatedAt: now})
+	registry.record(1, &AccountUpstreamRateLimit{Tokens: &UpstreamRateLimitBucket{Limit: 1000, Remaining: 900}, UpdatedAt: now})
+
+	got := registry.get(1)
+	require.NotNil(t, got)
+	require.Equal(t, int64(5), got.Requests.Remaining, "未携带的维度应保留")
+	require.Equal(t, int64(900), got.Tokens.Remaining)
+	require.True(t, registry.isLow(1, 0.1, now))
+	require.False(t, registry.isLow(2, 0.1, now))
+}
+
+func TestFilterByUpstreamRateLimitHeadroom(t *testing.T) {
+	saved := defaultUpstreamRateLimitRegistry
+	defaultUpstreamRateLimitRegistry = newUpstreamRateLimitRegistry()
+	t.Cleanup(func() { defaultUpstreamRateLimitRegistry = saved })
+
+	defaultUpstreamRateLimitRegistry.record(1, &AccountUpstreamRateLimit{Requests: &UpstreamRateLimitBucket{Limit: 100, Remaining: 1}})
+	accounts := []accountWithLoad{
+		{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{AccountID: 1}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{AccountID: 2}},
+	}
+
+	filtered := filterByUpstreamRateLimitHeadroom(accounts, 0.1)
+	require.Len(t, filtered, 1)
+	require.Equal(t, int64(2), filtered[0].account.ID)
+
+	ordered := moveUpstreamRateLimitLowToEnd(accounts, 0.1)
+	require.Equal(t, int64(2), ordered[0].account.ID)
+	require.Equal(t, int64(1), ordered[1].account.ID)
+
+	// 全部偏低时不排除任何账号
+	require.Len(t, filterByUpstreamRateLimitHeadroom(accounts[:1], 0.1), 1)
+	// 阈值为 0 时关闭降权
+	require.Len(t, filterByUpstreamRateLimitHeadroom(accounts, 0), 2)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// accountUsageAttributionMaxRangeDays 单次查询允许的最大天数
+const accountUsageAttributionMaxRangeDays = 366
+
+var ErrAccountUsageInvalidRange = infraerrors.BadRequest("ACCOUNT_USAGE_INVALID_RANGE", "invalid account usage date range")
+
+// AccountDailyUsage 账号单日承接的请求用量与费用（UTC 日期）
+type AccountDailyUsage struct {
+	Date                string  `json:"date"`
+	Requests            int64   `json:"requests"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	TotalCost           float64 `json:"total_cost"`
+	ActualCost          float64 `json:"actual_cost"`
+}
+
+// AccountUsageAttribution 账号在 [From, To] 日期区间内实际承接的用量合计与日明细
+type AccountUsageAttribution struct {
+	AccountID           int64               `json:"account_id"`
+	From                string              `json:"from"`
+	To                  string              `json:"to"`
+	Requests            int64               `json:"requests"`
+	InputTokens         int64               `json:"input_tokens"`
+	OutputTokens        int64               `json:"output_tokens"`
+	CacheCreationTokens int64               `json:"cache_creation_tokens"`
+	CacheReadTokens     int64               `json:"cache_read_tokens"`
+	TotalTokens         int64               `json:"total_tokens"`
+	TotalCost           float64             `json:"total_cost"`
+	ActualCost          float64             `json:"actual_cost"`
+	Daily               []AccountDailyUsage `json:"daily"`
+}
+
+// AccountUsageAttributionRepository 读取计费时累加的账号日用量汇总
+type AccountUsageAttributionRepository interface {
+	// ListAccountDailyUsage 返回 [from, to] 区间（UTC 日期，含两端）内有用量的日汇总，按日期升序
+	ListAccountDailyUsage(ctx context.Context, accountID int64, from, to time.Time) ([]AccountDailyUsage, error)
+}
+
+// GetUsageAttribution 返回账号在 [from, to] 日期区间（UTC，含两端）内实际承接的请求数、token 与费用。
+// 用量归属于最终成功响应的账号，故障转移中失败的尝试不计入；费用为请求发生时的计费结果。
+func (s *AccountUsageService) GetUsageAttribution(ctx context.Context, accountID int64, from, to time.Time) (*AccountUsageAttribution, error) {
+	from = truncateToUTCDate(from)
+	to = truncateToUTCDate(to)
+	if to.Before(from) || to.Sub(from) >= accountUsageAttributionMaxRangeDays*24*time.Hour {
+		return nil, ErrAccountUsageInvalidRange
+	}
+
+	summary := &AccountUsageAttribution{
+		AccountID: accountID,
+		From:      from.Format(time.DateOnly),
+		To:        to.Format(time.DateOnly),
+		Daily:     make([]AccountDailyUsage, 0),
+	}
+	if s.attributionRepo == nil {
+		return summary, nil
+	}
+	daily, err := s.attributionRepo.ListAccountDailyUsage(ctx, accountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range daily {
+		summary.Requests += day.Requests
+		summary.InputTokens += day.InputTokens
+		summary.OutputTokens += day.OutputTokens
+		summary.CacheCreationTokens += day.CacheCreationTokens
+		summary.CacheReadTokens += day.CacheReadTokens
+		summary.TotalCost += day.TotalCost
+		summary.ActualCost += day.ActualCost
+		summary.Daily = append(summary.Daily, day)
+	}
+	summary.TotalTokens = summary.InputTokens + summary.OutputTokens + summary.CacheCreationTokens + summary.CacheReadTokens
+	return summary, nil
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type accountUsageAttributionRepoStub struct {
+	rows     []AccountDailyUsage
+	gotID    int64
+	gotFrom  time.Time
+	gotTo    time.Time
+	queryCnt int
+}
+
+func (s *accountUsageAttributionRepoStub) ListAccountDailyUsage(_ context.Context, accountID int64, from, to time.Time) ([]AccountDailyUsage, error) {
+	s.queryCnt++
+	s.gotID, s.gotFrom, s.gotTo = accountID, from, to
+	return s.rows, nil
+}
+
+func TestAccountUsageService_GetUsageAttributionTotals(t *testing.T) {
+	repo := &accountUsageAttributionRepoStub{rows: []AccountDailyUsage{
+		{Date: "2026-03-01", Requests: 3, InputTokens: 100, OutputTokens: 50, CacheReadTokens: 10, TotalCost: 0.5, ActualCost: 0.6},
+		{Date: "2026-03-02", Requests: 1, InputTokens: 20, OutputTokens: 5, CacheCreationTokens: 7, TotalCost: 0.1, ActualCost: 0.1},
+	}}
+	svc := &AccountUsageService{attributionRepo: repo}
+
+	from := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	summary, err := svc.GetUsageAttribution(context.Background(), 42, from, to)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(42), repo.gotID)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), repo.gotFrom)
+	require.Equal(t, "2026-03-01", summary.From)
+	require.Equal(t, "2026-03-31", summary.To)
+	require.Equal(t, int64(4), summary.Requests)
+	require.Equal(t, int64(120), summary.InputTokens)
+	require.Equal(t, int64(55), summary.OutputTokens)
+	require.Equal(t, int64(192), summary.TotalTokens)
+	require.InDelta(t, 0.6, summary.TotalCost, 1e-9)
+	require.InDelta(t, 0.7, summary.ActualCost, 1e-9)
+	require.Len(t, summary.Daily, 2)
+}
+
+func TestAccountUsageService_GetUsageAttributionRejectsInvalidRange(t *testing.T) {
+	repo := &accountUsageAttributionRepoStub{}
+	svc := &AccountUsageService{attributionRepo: repo}
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.GetUsageAttribution(context.Background(), 1, day, day.AddDate(0, 0, -1))
+	require.ErrorIs(t, err, ErrAccountUsageInvalidRange)
+	_, err = svc.GetUsageAttribution(context.Background(), 1, day, day.AddDate(0, 0, accountUsageAttributionMaxRangeDays))
+	require.ErrorIs(t, err, ErrAccountUsageInvalidRange)
+	require.Zero(t, repo.queryCnt)
+}
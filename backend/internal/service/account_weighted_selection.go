@@ -0,0 +1,206 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxAccountWeight 账号调度权重上限
+const maxAccountWeight = 1000
+
+// accountWeightedRoundRobin 平滑加权轮询（nginx smooth weighted round-robin）。
+// 按分组维护每个账号的当前权重：每次选择时所有候选累加自身权重，取当前权重最大者并减去总权重。
+// 状态仅在进程内有效，多实例部署时每个实例各自按权重分配。
+type accountWeightedRoundRobin struct {
+	mu      sync.Mutex
+	current map[int64]map[int64]int // groupID -> accountID -> current weight
+}
+
+func newAccountWeightedRoundRobin() *accountWeightedRoundRobin {
+	return &accountWeightedRoundRobin{current: make(map[int64]map[int64]int)}
+}
+
+// defaultAccountWeightedRR 网关共享的加权轮询状态
+var defaultAccountWeightedRR = newAccountWeightedRoundRobin()
+
+// pick 从候选中按权重选出一个账号（权重为 0 的账号不参与）
+func (w *accountWeightedRoundRobin) pick(groupID int64, accounts []accountWithLoad) *accountWithLoad {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := w.current[groupID]
+	if current == nil {
+		current = make(map[int64]int)
+		w.current[groupID] = current
+	}
+
+	total := 0
+	bestIdx := -1
+	for i := range accounts {
+		weight := accounts[i].account.EffectiveWeight()
+		if weight <= 0 {
+			continue
+		}
+		id := accounts[i].account.ID
+		current[id] += weight
+		total += weight
+		if bestIdx < 0 || current[id] > current[accounts[bestIdx].account.ID] {
+			bestIdx = i
+		}
+	}
+	if bestIdx < 0 {
+		return nil
+	}
+	current[accounts[bestIdx].account.ID] -= total
+	return &accounts[bestIdx]
+}
+
+// hasNonUniformWeights 候选账号权重是否不完全相同（全部相同时沿用负载率 + LRU 选择）
+func hasNonUniformWeights(accounts []accountWithLoad) bool {
+	for i := 1; i < len(accounts); i++ {
+		if accounts[i].account.EffectiveWeight() != accounts[0].account.EffectiveWeight() {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountSelectionCount 单个账号的负载均衡选中次数
+type AccountSelectionCount struct {
+	AccountID     int64   `json:"account_id"`
+	Name          string  `json:"name"`
+	Platform      string  `json:"platform"`
+	Priority      int     `json:"priority"`
+	Weight        int     `json:"weight"`
+	Selections    int64   `json:"selections"`
+	Share         float64 `json:"share"`          // 实际占比（同分组、同优先级内）
+	ExpectedShare float64 `json:"expected_share"` // 按权重计算的期望占比（同分组、同优先级内）
+}
+
+// AccountSelectionDistribution 某个分组的选中分布
+type AccountSelectionDistribution struct {
+	GroupID  int64                   `json:"group_id"`
+	Total    int64                   `json:"total"`
+	Accounts []AccountSelectionCount `json:"accounts"`
+}
+
+type accountSelectionEntry struct {
+	name     string
+	platform string
+	priority int
+	weight   int
+	count    int64
+}
+
+// accountSelectionStats 记录新请求（非粘性会话）在各账号间的分配情况，用于核对权重是否生效
+type accountSelectionStats struct {
+	mu      sync.Mutex
+	groups  map[int64]map[int64]*accountSelectionEntry
+	startAt time.Time
+}
+
+func newAccountSelectionStats() *accountSelectionStats {
+	return &accountSelectionStats{
+		groups:  make(map[int64]map[int64]*accountSelectionEntry),
+		startAt: time.Now(),
+	}
+}
+
+var defaultAccountSelectionStats = newAccountSelectionStats()
+
+func (s *accountSelectionStats) record(groupID int64, account *Account) {
+	if account == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := s.groups[groupID]
+	if group == nil {
+		group = make(map[int64]*accountSelectionEntry)
+		s.groups[groupID] = group
+	}
+	entry := group[account.ID]
+	if entry == nil {
+		entry = &accountSelectionEntry{}
+		group[account.ID] = entry
+	}
+	// 账号信息以最近一次选中为准（权重/优先级可能被管理员修改）
+	entry.name = account.Name
+	entry.platform = account.Platform
+	entry.priority = account.Priority
+	entry.weight = account.EffectiveWeight()
+	entry.count++
+}
+
+func (s *accountSelectionStats) snapshot(groupID *int64) ([]AccountSelectionDistribution, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]AccountSelectionDistribution, 0, len(s.groups))
+	for gid, group := range s.groups {
+		if groupID != nil && gid != *groupID {
+			continue
+		}
+		dist := AccountSelectionDistribution{GroupID: gid, Accounts: make([]AccountSelectionCount, 0, len(group))}
+
+		// 占比按优先级分层计算：不同优先级之间本来就不按权重分配
+		selectionsByPriority := make(map[int]int64)
+		weightsByPriority := make(map[int]int)
+		for _, entry := range group {
+			selectionsByPriority[entry.priority] += entry.count
+			weightsByPriority[entry.priority] += entry.weight
+		}
+		for id, entry := range group {
+			item := AccountSelectionCount{
+				AccountID:  id,
+				Name:       entry.name,
+				Platform:   entry.platform,
+				Priority:   entry.priority,
+				Weight:     entry.weight,
+				Selections: entry.count,
+			}
+			if total := selectionsByPriority[entry.priority]; total > 0 {
+				item.Share = float64(entry.count) / float64(total)
+			}
+			if total := weightsByPriority[entry.priority]; total > 0 {
+				item.ExpectedShare = float64(entry.weight) / float64(total)
+			}
+			dist.Total += entry.count
+			dist.Accounts = append(dist.Accounts, item)
+		}
+		sort.Slice(dist.Accounts, func(i, j int) bool {
+			a, b := dist.Accounts[i], dist.Accounts[j]
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+			return a.AccountID < b.AccountID
+		})
+		out = append(out, dist)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GroupID < out[j].GroupID })
+	return out, s.startAt
+}
+
+func (s *accountSelectionStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = make(map[int64]map[int64]*accountSelectionEntry)
+	s.startAt = time.Now()
+}
+
+// recordAccountSelection 记录一次负载均衡选中（粘性会话命中不计入）
+func recordAccountSelection(groupID *int64, account *Account) {
+	defaultAccountSelectionStats.record(derefGroupID(groupID), account)
+}
+
+// GetAccountSelectionDistribution 返回进程启动（或上次重置）以来的账号选中分布；groupID 为 nil 时返回全部分组
+func GetAccountSelectionDistribution(groupID *int64) ([]AccountSelectionDistribution, time.Time) {
+	return defaultAccountSelectionStats.snapshot(groupID)
+}
+
+// ResetAccountSelectionDistribution 清空账号选中分布统计
+func ResetAccountSelectionDistribution() {
+	defaultAccountSelectionStats.reset()
+}
@@ -0,0 +1,95 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func weightedTestAccount(id int64, weight *int) accountWithLoad {
+	return accountWithLoad{
+		account:  &Account{ID: id, Priority: 1, Weight: weight},
+		loadInfo: &AccountLoadInfo{AccountID: id},
+	}
+}
+
+func TestAccountWeightedRoundRobin_ProportionalAndSmooth(t *testing.T) {
+	rr := newAccountWeightedRoundRobin()
+	candidates := []accountWithLoad{
+		weightedTestAccount(1, intPtrHelper(5)),
+		weightedTestAccount(2, intPtrHelper(1)),
+		weightedTestAccount(3, intPtrHelper(1)),
+	}
+
+	counts := map[int64]int{}
+	var sequence []int64
+	for i := 0; i < 70; i++ {
+		selected := rr.pick(1, candidates)
+		require.NotNil(t, selected)
+		counts[selected.account.ID]++
+		if i < 7 {
+			sequence = append(sequence, selected.account.ID)
+		}
+	}
+	require.Equal(t, map[int64]int{1: 50, 2: 10, 3: 10}, counts)
+	// 平滑轮询：高权重账号不会连续占满一个周期
+	require.Equal(t, []int64{1, 1, 2, 1, 3, 1, 1}, sequence)
+}
+
+func TestAccountWeightedRoundRobin_SkipsDrainOnly(t *testing.T) {
+	rr := newAccountWeightedRoundRobin()
+	candidates := []accountWithLoad{
+		weightedTestAccount(1, intPtrHelper(0)),
+		weightedTestAccount(2, nil),
+	}
+	for i := 0; i < 5; i++ {
+		require.Equal(t, int64(2), rr.pick(1, candidates).account.ID)
+	}
+	require.Nil(t, rr.pick(1, candidates[:1]))
+}
+
+func TestHasNonUniformWeights(t *testing.T) {
+	require.False(t, hasNonUniformWeights([]accountWithLoad{
+		weightedTestAccount(1, nil),
+		weightedTestAccount(2, intPtrHelper(1)),
+	}))
+	require.True(t, hasNonUniformWeights([]accountWithLoad{
+		weightedTestAccount(1, nil),
+		weightedTestAccount(2, intPtrHelper(3)),
+	}))
+}
+
+func TestAccountSelectionStats_SharePerPriority(t *testing.T) {
+	stats := newAccountSelectionStats()
+	heavy := &Account{ID: 1, Name: "heavy", Priority: 1, Weight: intPtrHelper(3)}
+	light := &Account{ID: 2, Name: "light", Priority: 1}
+	backup := &Account{ID: 3, Name: "backup", Priority: 2}
+	for i := 0; i < 3; i++ {
+		stats.record(7, heavy)
+	}
+	stats.record(7, light)
+	stats.record(7, backup)
+	stats.record(8, light)
+
+	groupID := int64(7)
+	groups, _ := stats.snapshot(&groupID)
+	require.Len(t, groups, 1)
+	dist := groups[0]
+	require.Equal(t, int64(5), dist.Total)
+	require.Len(t, dist.Accounts, 3)
+
+	require.Equal(t, int64(1), dist.Accounts[0].AccountID)
+	require.InDelta(t, 0.75, dist.Accounts[0].Share, 1e-9)
+	require.InDelta(t, 0.75, dist.Accounts[0].ExpectedShare, 1e-9)
+	require.InDelta(t, 0.25, dist.Accounts[1].ExpectedShare, 1e-9)
+	require.InDelta(t, 1.0, dist.Accounts[2].Share, 1e-9)
+
+	all, _ := stats.snapshot(nil)
+	require.Len(t, all, 2)
+
+	stats.reset()
+	all, _ = stats.snapshot(nil)
+	require.Empty(t, all)
+}
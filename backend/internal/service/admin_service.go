@@ -62,6 +62,14 @@ type AdminService interface {
 	// API Key management (admin)
 	AdminUpdateAPIKeyGroupID(ctx context.Context, keyID int64, groupID *int64) (*AdminUpdateAPIKeyGroupIDResult, error)
 	AdminResetAPIKeyRateLimitUsage(ctx context.Context, keyID int64) (*APIKey, error)
+	AdminUpdateAPIKeyThroughputLimits(ctx context.Context, keyID int64, rpmLimit, tpmLimit *int) (*APIKey, error)
+	AdminUpdateAPIKeyAuditCapture(ctx context.Context, keyID int64, enabled bool) (*APIKey, error)
+	AdminUpdateAPIKeySandbox(ctx context.Context, keyID int64, sandbox bool) (*APIKey, error)
+	AdminUpdateAPIKeyPriorityTier(ctx context.Context, keyID int64, tier int) (*APIKey, error)
+	AdminUpdateAPIKeyMaskResponseModel(ctx context.Context, keyID int64, enabled bool) (*APIKey, error)
+	AdminUpdateAPIKeySizeLimits(ctx context.Context, keyID int64, maxRequestBytes, maxResponseBytes *int64) (*APIKey, error)
+	AdminUpdateAPIKeyUsageQuota(ctx context.Context, keyID int64, requests, tokens *int64, period *string, reset bool) (*APIKey, error)
+	AdminUpdateAPIKeyModelAccess(ctx context.Context, keyID int64, allowedModels, deniedModels *[]string) (*APIKey, error)
 
 	// ReplaceUserGroup 替换用户的专属分组：授予新分组权限、迁移 Key、移除旧分组权限
 	ReplaceUserGroup(ctx context.Context, userID, oldGroupID, newGroupID int64) (*ReplaceUserGroupResult, error)
@@ -71,9 +79,15 @@ type AdminService interface {
 	ListAllAccountIDs(ctx context.Context, platform, accountType, status, search string, groupID int64, privacyMode string) ([]Account, error)
 	GetAccount(ctx context.Context, id int64) (*Account, error)
 	GetAccountsByIDs(ctx context.Context, ids []int64) ([]*Account, error)
+	// ListSchedulableAccounts 返回当前可调度的账号（健康面板使用，与健康检查是否启用无关）
+	ListSchedulableAccounts(ctx context.Context) ([]Account, error)
 	CreateAccount(ctx context.Context, input *CreateAccountInput) (*Account, error)
 	UpdateAccount(ctx context.Context, id int64, input *UpdateAccountInput) (*Account, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	// RestoreAccount 恢复软删除的账号，保留原有用量归属
+	RestoreAccount(ctx context.Context, id int64) (*Account, error)
+	// DrainAccount 将账号置为排空状态：停止调度新请求，已在途的请求继续完成
+	DrainAccount(ctx context.Context, id int64) (*Account, error)
 	RefreshAccountCredentials(ctx context.Context, id int64) (*Account, error)
 	ClearAccountError(ctx context.Context, id int64) (*Account, error)
 	SetAccountError(ctx context.Context, id int64, errorMsg string) error
@@ -126,6 +140,9 @@ type CreateUserInput struct {
 	Concurrency   int
 	RPMLimit      int
 	AllowedGroups []int64
+	// BudgetLimit 月度预算（USD，0 = 不限制）
+	BudgetLimit     float64
+	BudgetSoftLimit bool
 }
 
 type UpdateUserInput struct {
@@ -142,6 +159,9 @@ type UpdateUserInput struct {
 	// GroupRates 用户专属分组倍率配置
 	// map[groupID]*rate，nil 表示删除该分组的专属倍率
 	GroupRates map[int64]*float64
+	// BudgetLimit / BudgetSoftLimit 月度预算配置，nil 表示不修改
+	BudgetLimit     *float64
+	BudgetSoftLimit *bool
 }
 
 type AdminBindAuthIdentityInput struct {
@@ -218,6 +238,8 @@ type CreateGroupInput struct {
 	MessagesDispatchModelConfig OpenAIMessagesDispatchModelConfig
 	// RPMLimit 分组 RPM 上限（0 = 不限制）
 	RPMLimit int
+	// SchedulingStrategy 账号调度策略（空值为 load_balance）
+	SchedulingStrategy string
 	// 从指定分组复制账号（创建分组后在同一事务内绑定）
 	CopyAccountsFromGroupIDs []int64
 }
@@ -259,6 +281,8 @@ type UpdateGroupInput struct {
 	MessagesDispatchModelConfig *OpenAIMessagesDispatchModelConfig
 	// RPMLimit 分组 RPM 上限（0 = 不限制），nil 表示未提供不改动。
 	RPMLimit *int
+	// SchedulingStrategy 账号调度策略，nil 表示未提供不改动
+	SchedulingStrategy *string
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64
 }
@@ -275,6 +299,7 @@ type CreateAccountInput struct {
 	Priority           int
 	RateMultiplier     *float64 // 账号计费倍率（>=0，允许 0）
 	LoadFactor         *int
+	Weight             *int // nil 或负数表示默认权重，0 表示仅排空
 	GroupIDs           []int64
 	ExpiresAt          *int64
 	AutoPauseOnExpired *bool
@@ -296,6 +321,7 @@ type UpdateAccountInput struct {
 	Priority              *int     // 使用指针区分"未提供"和"设置为0"
 	RateMultiplier        *float64 // 账号计费倍率（>=0，允许 0）
 	LoadFactor            *int
+	Weight                *int // nil 表示不修改，负数表示恢复默认权重
 	Status                string
 	GroupIDs              *[]int64
 	ExpiresAt             *int64
@@ -313,6 +339,7 @@ type BulkUpdateAccountsInput struct {
 	Priority       *int
 	RateMultiplier *float64 // 账号计费倍率（>=0，允许 0）
 	LoadFactor     *int
+	Weight         *int
 	Status         string
 	Schedulable    *bool
 	GroupIDs       *[]int64
@@ -671,15 +698,17 @@ func (s *adminServiceImpl) CreateUser(ctx context.Context, input *CreateUserInpu
 		role = RoleAdmin
 	}
 	user := &User{
-		Email:         input.Email,
-		Username:      input.Username,
-		Notes:         input.Notes,
-		Role:          role,
-		Balance:       input.Balance,
-		Concurrency:   input.Concurrency,
-		RPMLimit:      input.RPMLimit,
-		Status:        StatusActive,
-		AllowedGroups: input.AllowedGroups,
+		Email:           input.Email,
+		Username:        input.Username,
+		Notes:           input.Notes,
+		Role:            role,
+		Balance:         input.Balance,
+		Concurrency:     input.Concurrency,
+		RPMLimit:        input.RPMLimit,
+		Status:          StatusActive,
+		AllowedGroups:   input.AllowedGroups,
+		BudgetLimit:     input.BudgetLimit,
+		BudgetSoftLimit: input.BudgetSoftLimit,
 	}
 	if err := user.SetPassword(input.Password); err != nil {
 		return nil, err
@@ -732,6 +761,7 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 	oldStatus := user.Status
 	oldRole := user.Role
 	oldRPMLimit := user.RPMLimit
+	oldBudgetLimit, oldBudgetSoftLimit := user.BudgetLimit, user.BudgetSoftLimit
 
 	if input.Email != "" {
 		user.Email = input.Email
@@ -769,6 +799,16 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 		user.AllowedGroups = *input.AllowedGroups
 	}
 
+	if input.BudgetLimit != nil {
+		if *input.BudgetLimit < 0 {
+			return nil, errors.New("budget_limit must be >= 0")
+		}
+		user.BudgetLimit = *input.BudgetLimit
+	}
+	if input.BudgetSoftLimit != nil {
+		user.BudgetSoftLimit = *input.BudgetSoftLimit
+	}
+
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
@@ -783,7 +823,9 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 	if s.authCacheInvalidator != nil {
 		// RPMLimit 直接参与 billing_cache_service.checkRPM 的三级级联，
 		// 不失效缓存会让修改在一个 L2 TTL 内失去效果。
-		if user.Concurrency != oldConcurrency || user.Status != oldStatus || user.Role != oldRole || user.RPMLimit != oldRPMLimit {
+		// 预算配置同样来自 auth cache snapshot。
+		budgetChanged := user.BudgetLimit != oldBudgetLimit || user.BudgetSoftLimit != oldBudgetSoftLimit
+		if user.Concurrency != oldConcurrency || user.Status != oldStatus || user.Role != oldRole || user.RPMLimit != oldRPMLimit || budgetChanged {
 			s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, user.ID)
 		}
 	}
@@ -1706,6 +1748,7 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		OpenAIForceCodex:                input.OpenAIForceCodex,
 		MessagesDispatchModelConfig:     normalizeOpenAIMessagesDispatchModelConfig(input.MessagesDispatchModelConfig),
 		RPMLimit:                        input.RPMLimit,
+		SchedulingStrategy:              normalizeGroupSchedulingStrategy(input.SchedulingStrategy),
 	}
 	sanitizeGroupMessagesDispatchFields(group)
 	if err := s.groupRepo.Create(ctx, group); err != nil {
@@ -1961,6 +2004,9 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 	if input.RPMLimit != nil {
 		group.RPMLimit = *input.RPMLimit
 	}
+	if input.SchedulingStrategy != nil {
+		group.SchedulingStrategy = normalizeGroupSchedulingStrategy(*input.SchedulingStrategy)
+	}
 	sanitizeGroupMessagesDispatchFields(group)
 
 	if err := s.groupRepo.Update(ctx, group); err != nil {
@@ -2280,6 +2326,194 @@ func (s *adminServiceImpl) AdminResetAPIKeyRateLimitUsage(ctx context.Context, k
 	return apiKey, nil
 }
 
+// AdminUpdateAPIKeyThroughputLimits 管理员设置 API Key 的 RPM/TPM 限制（nil 不修改，0 使用全局默认值）
+func (s *adminServiceImpl) AdminUpdateAPIKeyThroughputLimits(ctx context.Context, keyID int64, rpmLimit, tpmLimit *int) (*APIKey, error) {
+	if (rpmLimit != nil && *rpmLimit < 0) || (tpmLimit != nil && *tpmLimit < 0) {
+		return nil, infraerrors.BadRequest("INVALID_THROUGHPUT_LIMIT", "rpm_limit and tpm_limit must be non-negative")
+	}
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if rpmLimit != nil {
+		apiKey.RPMLimit = *rpmLimit
+	}
+	if tpmLimit != nil {
+		apiKey.TPMLimit = *tpmLimit
+	}
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key throughput limits: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeyAuditCapture 管理员设置 API Key 是否在审计日志中记录请求/响应正文
+func (s *adminServiceImpl) AdminUpdateAPIKeyAuditCapture(ctx context.Context, keyID int64, enabled bool) (*APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.AuditCaptureBody = enabled
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key audit capture: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeySandbox 管理员设置 API Key 是否为沙盒 Key（计算费用但不扣费、不计入消费汇总与预算）
+func (s *adminServiceImpl) AdminUpdateAPIKeySandbox(ctx context.Context, keyID int64, sandbox bool) (*APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.IsSandbox = sandbox
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key sandbox: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeyMaskResponseModel 管理员设置 API Key 是否在响应中回显请求的模型名（隐藏实际上游模型）
+func (s *adminServiceImpl) AdminUpdateAPIKeyMaskResponseModel(ctx context.Context, keyID int64, enabled bool) (*APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.MaskResponseModel = enabled
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key mask response model: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeyPriorityTier 管理员设置 API Key 的网关排队优先级
+func (s *adminServiceImpl) AdminUpdateAPIKeyPriorityTier(ctx context.Context, keyID int64, tier int) (*APIKey, error) {
+	if tier < 0 {
+		return nil, infraerrors.BadRequest("INVALID_PRIORITY_TIER", "priority_tier must be non-negative")
+	}
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.PriorityTier = tier
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key priority tier: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeySizeLimits 管理员设置 API Key 的请求体/响应体大小上限（nil 不修改，0 使用全局默认值）
+func (s *adminServiceImpl) AdminUpdateAPIKeySizeLimits(ctx context.Context, keyID int64, maxRequestBytes, maxResponseBytes *int64) (*APIKey, error) {
+	if (maxRequestBytes != nil && *maxRequestBytes < 0) || (maxResponseBytes != nil && *maxResponseBytes < 0) {
+		return nil, infraerrors.BadRequest("INVALID_SIZE_LIMIT", "max_request_bytes and max_response_bytes must be non-negative")
+	}
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if maxRequestBytes != nil {
+		apiKey.MaxRequestBytes = *maxRequestBytes
+	}
+	if maxResponseBytes != nil {
+		apiKey.MaxResponseBytes = *maxResponseBytes
+	}
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key size limits: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeyUsageQuota 管理员设置 API Key 的周期请求数/token 数额度（nil 不修改，0 不限制）。
+// 修改重置周期或 reset=true 时清零本周期用量。
+func (s *adminServiceImpl) AdminUpdateAPIKeyUsageQuota(ctx context.Context, keyID int64, requests, tokens *int64, period *string, reset bool) (*APIKey, error) {
+	if (requests != nil && *requests < 0) || (tokens != nil && *tokens < 0) {
+		return nil, infraerrors.BadRequest("INVALID_USAGE_QUOTA", "usage_quota_requests and usage_quota_tokens must be non-negative")
+	}
+	if period != nil && !IsValidUsageQuotaPeriod(*period) {
+		return nil, infraerrors.BadRequest("INVALID_USAGE_QUOTA_PERIOD", "usage_quota_period must be one of hourly, daily, weekly, monthly")
+	}
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if requests != nil {
+		apiKey.UsageQuotaRequests = *requests
+	}
+	if tokens != nil {
+		apiKey.UsageQuotaTokens = *tokens
+	}
+	if period != nil && *period != apiKey.UsageQuotaPeriod {
+		apiKey.UsageQuotaPeriod = *period
+		reset = true
+	}
+	if reset {
+		apiKey.UsageQuotaRequestsUsed = 0
+		apiKey.UsageQuotaTokensUsed = 0
+		apiKey.UsageQuotaWindowStart = nil
+	}
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key usage quota: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	if s.billingCacheService != nil {
+		s.billingCacheService.InvalidateAPIKeyUsageQuota(apiKey.ID)
+	}
+	return apiKey, nil
+}
+
+// AdminUpdateAPIKeyModelAccess 管理员设置 API Key 的模型允许/禁止列表（nil 不修改，空数组清空）
+func (s *adminServiceImpl) AdminUpdateAPIKeyModelAccess(ctx context.Context, keyID int64, allowedModels, deniedModels *[]string) (*APIKey, error) {
+	var allowed, denied []string
+	var err error
+	if allowedModels != nil {
+		if allowed, err = normalizeModelAccessList("allowed_models", *allowedModels); err != nil {
+			return nil, err
+		}
+	}
+	if deniedModels != nil {
+		if denied, err = normalizeModelAccessList("denied_models", *deniedModels); err != nil {
+			return nil, err
+		}
+	}
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if allowedModels != nil {
+		apiKey.AllowedModels = allowed
+	}
+	if deniedModels != nil {
+		apiKey.DeniedModels = denied
+	}
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("update api key model access: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	}
+	return apiKey, nil
+}
+
 // ReplaceUserGroup 替换用户的专属分组
 func (s *adminServiceImpl) ReplaceUserGroup(ctx context.Context, userID, oldGroupID, newGroupID int64) (*ReplaceUserGroupResult, error) {
 	if oldGroupID == newGroupID {
@@ -2384,6 +2618,14 @@ func (s *adminServiceImpl) GetAccountsByIDs(ctx context.Context, ids []int64) ([
 	return accounts, nil
 }
 
+func (s *adminServiceImpl) ListSchedulableAccounts(ctx context.Context) ([]Account, error) {
+	accounts, err := s.accountRepo.ListSchedulable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedulable accounts: %w", err)
+	}
+	return accounts, nil
+}
+
 func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccountInput) (*Account, error) {
 	// 绑定分组
 	groupIDs := input.GroupIDs
@@ -2426,6 +2668,9 @@ func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccou
 		if err := ValidateQuotaResetConfig(account.Extra); err != nil {
 			return nil, err
 		}
+		if err := ValidateAccountCustomHeaders(account.Extra); err != nil {
+			return nil, err
+		}
 		ComputeQuotaResetAt(account.Extra)
 	}
 	if input.ExpiresAt != nil && *input.ExpiresAt > 0 {
@@ -2449,6 +2694,12 @@ func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccou
 		}
 		account.LoadFactor = input.LoadFactor
 	}
+	if input.Weight != nil && *input.Weight >= 0 {
+		if *input.Weight > maxAccountWeight {
+			return nil, fmt.Errorf("weight must be <= %d", maxAccountWeight)
+		}
+		account.Weight = input.Weight
+	}
 	if err := s.accountRepo.Create(ctx, account); err != nil {
 		return nil, err
 	}
@@ -2516,6 +2767,7 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 				input.Extra[key] = v
 			}
 		}
+		restoreMaskedCustomHeaders(input.Extra, account.Extra)
 		account.Extra = input.Extra
 		if account.Platform == PlatformAntigravity && wasOveragesEnabled && !account.IsOveragesEnabled() {
 			delete(account.Extra, "antigravity_credits_overages") // 清理旧版 overages 运行态
@@ -2532,6 +2784,9 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 		if err := ValidateQuotaResetConfig(account.Extra); err != nil {
 			return nil, err
 		}
+		if err := ValidateAccountCustomHeaders(account.Extra); err != nil {
+			return nil, err
+		}
 		ComputeQuotaResetAt(account.Extra)
 	}
 	if input.ProxyID != nil {
@@ -2566,6 +2821,15 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 			account.LoadFactor = input.LoadFactor
 		}
 	}
+	if input.Weight != nil {
+		if *input.Weight < 0 {
+			account.Weight = nil // 负数表示恢复默认权重
+		} else if *input.Weight > maxAccountWeight {
+			return nil, fmt.Errorf("weight must be <= %d", maxAccountWeight)
+		} else {
+			account.Weight = input.Weight
+		}
+	}
 	if input.Status != "" {
 		account.Status = input.Status
 	}
@@ -2674,6 +2938,9 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 			return nil, errors.New("rate_multiplier must be >= 0")
 		}
 	}
+	if err := ValidateAccountCustomHeaders(input.Extra); err != nil {
+		return nil, err
+	}
 
 	// Prepare bulk updates for columns and JSONB fields.
 	repoUpdates := AccountBulkUpdate{
@@ -2704,6 +2971,12 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 			repoUpdates.LoadFactor = input.LoadFactor
 		}
 	}
+	if input.Weight != nil {
+		if *input.Weight > maxAccountWeight {
+			return nil, fmt.Errorf("weight must be <= %d", maxAccountWeight)
+		}
+		repoUpdates.Weight = input.Weight // 负数在仓储层清除为默认权重
+	}
 	if input.Status != "" {
 		repoUpdates.Status = &input.Status
 	}
@@ -2796,6 +3069,28 @@ func (s *adminServiceImpl) DeleteAccount(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (s *adminServiceImpl) RestoreAccount(ctx context.Context, id int64) (*Account, error) {
+	if err := s.accountRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.GetByID(ctx, id)
+}
+
+func (s *adminServiceImpl) DrainAccount(ctx context.Context, id int64) (*Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.IsDraining() {
+		return account, nil
+	}
+	account.Status = StatusDraining
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
 func (s *adminServiceImpl) RefreshAccountCredentials(ctx context.Context, id int64) (*Account, error) {
 	account, err := s.accountRepo.GetByID(ctx, id)
 	if err != nil {
@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type accountRepoStubForDrain struct {
+	mockAccountRepoForGemini
+	account     *Account
+	updateCalls int
+}
+
+func (r *accountRepoStubForDrain) GetByID(ctx context.Context, id int64) (*Account, error) {
+	return r.account, nil
+}
+
+func (r *accountRepoStubForDrain) Update(ctx context.Context, account *Account) error {
+	r.updateCalls++
+	r.account = account
+	return nil
+}
+
+func TestAdminService_DrainAccount_StopsScheduling(t *testing.T) {
+	repo := &accountRepoStubForDrain{
+		account: &Account{ID: 5, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true},
+	}
+	require.True(t, repo.account.IsSchedulable())
+	svc := &adminServiceImpl{accountRepo: repo}
+
+	updated, err := svc.DrainAccount(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, StatusDraining, updated.Status)
+	require.True(t, updated.IsDraining())
+	require.False(t, updated.IsSchedulable())
+	require.Equal(t, 1, repo.updateCalls)
+
+	// 重复排空不再写库
+	_, err = svc.DrainAccount(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, repo.updateCalls)
+}
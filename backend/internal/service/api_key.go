@@ -60,6 +60,42 @@ type APIKey struct {
 	Window5hStart *time.Time // Start of current 5h window
 	Window1dStart *time.Time // Start of current 1d window
 	Window7dStart *time.Time // Start of current 7d window
+
+	// Throughput limits (token bucket, 0 = use global default)
+	RPMLimit int // Requests per minute
+	TPMLimit int // Tokens per minute
+
+	// AuditCaptureBody 审计日志记录请求/响应正文（默认脱敏不记录）
+	AuditCaptureBody bool
+
+	// 模型访问控制：AllowedModels 为空表示允许所有模型，DeniedModels 优先（支持末尾 * 通配）
+	AllowedModels []string
+	DeniedModels  []string
+
+	// UsageHeaders 在响应头（流式为 trailer）中返回本次请求的计费金额与 token 用量
+	UsageHeaders bool
+
+	// IsSandbox 沙盒 Key：请求照常转发并计算费用，但不扣费，也不计入消费汇总与预算（仅管理员可设置）
+	IsSandbox bool
+
+	// MaskResponseModel 响应中回显客户端请求的模型名，隐藏实际上游模型（计费仍按实际模型，仅管理员可设置）
+	MaskResponseModel bool
+
+	// PriorityTier 网关排队优先级：并发已满时高层级请求先于低层级放行（默认 0）
+	PriorityTier int
+	// MaxRequestBytes / MaxResponseBytes 请求体/响应体大小上限（字节，0 表示使用全局默认值）
+	MaxRequestBytes  int64
+	MaxResponseBytes int64
+	// KeyHash 哈希存储时完整 Key 的 bcrypt/argon2id 哈希，此时 Key 字段为查找摘要；明文存储的旧 Key 为空
+	KeyHash string
+
+	// 周期额度：每个 UsageQuotaPeriod 周期的请求数/token 数上限（0 表示不限制），按 UTC 日历边界重置
+	UsageQuotaRequests     int64
+	UsageQuotaTokens       int64
+	UsageQuotaPeriod       string
+	UsageQuotaRequestsUsed int64      // 当前周期已用请求数
+	UsageQuotaTokensUsed   int64      // 当前周期已用 token 数
+	UsageQuotaWindowStart  *time.Time // 当前周期开始时间
 }
 
 func (k *APIKey) IsActive() bool {
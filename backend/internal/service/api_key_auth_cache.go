@@ -26,6 +26,35 @@ type APIKeyAuthSnapshot struct {
 	RateLimit5h float64 `json:"rate_limit_5h"`
 	RateLimit1d float64 `json:"rate_limit_1d"`
 	RateLimit7d float64 `json:"rate_limit_7d"`
+
+	// Throughput limits (token bucket, 0 = use global default)
+	RPMLimit int `json:"rpm_limit"`
+	TPMLimit int `json:"tpm_limit"`
+
+	AuditCaptureBody bool `json:"audit_capture_body"`
+
+	AllowedModels []string `json:"allowed_models,omitempty"`
+	DeniedModels  []string `json:"denied_models,omitempty"`
+
+	UsageHeaders bool `json:"usage_headers"`
+
+	IsSandbox bool `json:"is_sandbox"`
+
+	PriorityTier int `json:"priority_tier,omitempty"`
+
+	MaskResponseModel bool `json:"mask_response_model,omitempty"`
+
+	// 请求体/响应体大小上限（0 表示使用全局默认值）
+	MaxRequestBytes  int64 `json:"max_request_bytes,omitempty"`
+	MaxResponseBytes int64 `json:"max_response_bytes,omitempty"`
+
+	// 周期额度上限与重置周期（已用量不缓存，由 BillingCacheService 维护）
+	UsageQuotaRequests int64  `json:"usage_quota_requests,omitempty"`
+	UsageQuotaTokens   int64  `json:"usage_quota_tokens,omitempty"`
+	UsageQuotaPeriod   string `json:"usage_quota_period,omitempty"`
+
+	// KeyHash 哈希存储的 Key 在命中缓存时同样需要校验（明文存储的 Key 为空）
+	KeyHash string `json:"key_hash,omitempty"`
 }
 
 // APIKeyAuthUserSnapshot 用户快照
@@ -48,6 +77,10 @@ type APIKeyAuthUserSnapshot struct {
 	// RPMLimit 用户级每分钟请求数上限（0 = 不限制）；用于 billing_cache_service.checkRPM 兜底判断。
 	RPMLimit int `json:"rpm_limit"`
 
+	// BudgetLimit / BudgetSoftLimit 用户月度预算；用于 billing_cache_service.checkUserBudget。
+	BudgetLimit     float64 `json:"budget_limit,omitempty"`
+	BudgetSoftLimit bool    `json:"budget_soft_limit,omitempty"`
+
 	// UserGroupRPMOverride 该 API Key 对应的 (user, group) 专属 RPM 覆盖值。
 	// nil = 无 override（回退到 group/user 级）；0 = 不限流；>0 = 专属上限。
 	UserGroupRPMOverride *int `json:"user_group_rpm_override,omitempty"`
@@ -91,6 +124,9 @@ type APIKeyAuthGroupSnapshot struct {
 
 	// RPMLimit 分组级每分钟请求数上限（0 = 不限制）；用于 billing_cache_service.checkRPM 级联判断。
 	RPMLimit int `json:"rpm_limit"`
+
+	// SchedulingStrategy 账号调度策略，用于 cheapest 策略下输出路由成本响应头
+	SchedulingStrategy string `json:"scheduling_strategy,omitempty"`
 }
 
 // APIKeyAuthCacheEntry 缓存条目，支持负缓存
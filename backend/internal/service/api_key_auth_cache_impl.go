@@ -14,7 +14,7 @@ import (
 	"github.com/dgraph-io/ristretto"
 )
 
-const apiKeyAuthSnapshotVersion = 9 // v9: added API Key name for audit logs
+const apiKeyAuthSnapshotVersion = 18 // v18: added response model masking
 
 type apiKeyAuthCacheConfig struct {
 	l1Size        int
@@ -104,8 +104,10 @@ func (s *APIKeyService) StartAuthCacheInvalidationSubscriber(ctx context.Context
 	}
 }
 
+// authCacheKey 由 Key 的查找摘要推导缓存键：请求中的明文 Key 与数据库中存储的值（明文或查找摘要）得到相同的键，
+// 以便按存储值失效缓存
 func (s *APIKeyService) authCacheKey(key string) string {
-	sum := sha256.Sum256([]byte(key))
+	sum := sha256.Sum256([]byte(apiKeyLookupID(key)))
 	return hex.EncodeToString(sum[:])
 }
 
@@ -164,7 +166,7 @@ func (s *APIKeyService) deleteAuthCache(ctx context.Context, cacheKey string) {
 }
 
 func (s *APIKeyService) loadAuthCacheEntry(ctx context.Context, key, cacheKey string) (*APIKeyAuthCacheEntry, error) {
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.getByKeyForAuth(ctx, key)
 	if err != nil {
 		if errors.Is(err, ErrAPIKeyNotFound) {
 			entry := &APIKeyAuthCacheEntry{NotFound: true}
@@ -198,6 +200,9 @@ func (s *APIKeyService) applyAuthCacheEntry(key string, entry *APIKeyAuthCacheEn
 	if entry.Snapshot.Version != apiKeyAuthSnapshotVersion {
 		return nil, false, nil
 	}
+	if entry.Snapshot.KeyHash != "" && !s.verifyKeyHash(key, entry.Snapshot.KeyHash) {
+		return nil, true, ErrAPIKeyNotFound
+	}
 	return s.snapshotToAPIKey(key, entry.Snapshot), true, nil
 }
 
@@ -220,6 +225,24 @@ func (s *APIKeyService) snapshotFromAPIKey(ctx context.Context, apiKey *APIKey)
 		RateLimit5h: apiKey.RateLimit5h,
 		RateLimit1d: apiKey.RateLimit1d,
 		RateLimit7d: apiKey.RateLimit7d,
+		RPMLimit:    apiKey.RPMLimit,
+		TPMLimit:    apiKey.TPMLimit,
+
+		AuditCaptureBody: apiKey.AuditCaptureBody,
+		AllowedModels:    apiKey.AllowedModels,
+		DeniedModels:     apiKey.DeniedModels,
+		UsageHeaders:     apiKey.UsageHeaders,
+		IsSandbox:        apiKey.IsSandbox,
+		PriorityTier:     apiKey.PriorityTier,
+		MaxRequestBytes:  apiKey.MaxRequestBytes,
+		MaxResponseBytes: apiKey.MaxResponseBytes,
+		KeyHash:          apiKey.KeyHash,
+
+		MaskResponseModel: apiKey.MaskResponseModel,
+
+		UsageQuotaRequests: apiKey.UsageQuotaRequests,
+		UsageQuotaTokens:   apiKey.UsageQuotaTokens,
+		UsageQuotaPeriod:   apiKey.UsageQuotaPeriod,
 		User: APIKeyAuthUserSnapshot{
 			ID:                         apiKey.User.ID,
 			Status:                     apiKey.User.Status,
@@ -234,6 +257,8 @@ func (s *APIKeyService) snapshotFromAPIKey(ctx context.Context, apiKey *APIKey)
 			BalanceNotifyExtraEmails:   apiKey.User.BalanceNotifyExtraEmails,
 			TotalRecharged:             apiKey.User.TotalRecharged,
 			RPMLimit:                   apiKey.User.RPMLimit,
+			BudgetLimit:                apiKey.User.BudgetLimit,
+			BudgetSoftLimit:            apiKey.User.BudgetSoftLimit,
 		},
 	}
 
@@ -274,6 +299,7 @@ func (s *APIKeyService) snapshotFromAPIKey(ctx context.Context, apiKey *APIKey)
 			OpenAIForceCodex:                apiKey.Group.OpenAIForceCodex,
 			MessagesDispatchModelConfig:     apiKey.Group.MessagesDispatchModelConfig,
 			RPMLimit:                        apiKey.Group.RPMLimit,
+			SchedulingStrategy:              apiKey.Group.SchedulingStrategy,
 		}
 	}
 	return snapshot
@@ -298,6 +324,24 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 		RateLimit5h: snapshot.RateLimit5h,
 		RateLimit1d: snapshot.RateLimit1d,
 		RateLimit7d: snapshot.RateLimit7d,
+		RPMLimit:    snapshot.RPMLimit,
+		TPMLimit:    snapshot.TPMLimit,
+
+		AuditCaptureBody: snapshot.AuditCaptureBody,
+		AllowedModels:    snapshot.AllowedModels,
+		DeniedModels:     snapshot.DeniedModels,
+		UsageHeaders:     snapshot.UsageHeaders,
+		IsSandbox:        snapshot.IsSandbox,
+		PriorityTier:     snapshot.PriorityTier,
+		MaxRequestBytes:  snapshot.MaxRequestBytes,
+		MaxResponseBytes: snapshot.MaxResponseBytes,
+		KeyHash:          snapshot.KeyHash,
+
+		MaskResponseModel: snapshot.MaskResponseModel,
+
+		UsageQuotaRequests: snapshot.UsageQuotaRequests,
+		UsageQuotaTokens:   snapshot.UsageQuotaTokens,
+		UsageQuotaPeriod:   snapshot.UsageQuotaPeriod,
 		User: &User{
 			ID:                         snapshot.User.ID,
 			Status:                     snapshot.User.Status,
@@ -312,6 +356,8 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 			BalanceNotifyExtraEmails:   snapshot.User.BalanceNotifyExtraEmails,
 			TotalRecharged:             snapshot.User.TotalRecharged,
 			RPMLimit:                   snapshot.User.RPMLimit,
+			BudgetLimit:                snapshot.User.BudgetLimit,
+			BudgetSoftLimit:            snapshot.User.BudgetSoftLimit,
 			UserGroupRPMOverride:       snapshot.User.UserGroupRPMOverride,
 		},
 	}
@@ -345,6 +391,7 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 			OpenAIForceCodex:                snapshot.Group.OpenAIForceCodex,
 			MessagesDispatchModelConfig:     snapshot.Group.MessagesDispatchModelConfig,
 			RPMLimit:                        snapshot.Group.RPMLimit,
+			SchedulingStrategy:              snapshot.Group.SchedulingStrategy,
 		}
 	}
 	s.compileAPIKeyIPRules(apiKey)
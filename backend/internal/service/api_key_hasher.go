@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/dgraph-io/ristretto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// apiKeyLookupPrefix 哈希存储的 Key 在 key 列中保存的查找摘要前缀。
+// 明文 Key 只允许字母、数字、下划线和连字符，不会与该前缀冲突。
+const apiKeyLookupPrefix = "kh:"
+
+// apiKeyLookupID 返回 Key 的查找摘要：哈希存储的 Key 以它作为 key 列的值用于定位记录，
+// 完整 Key 再由 key_hash 校验。对查找摘要本身调用时原样返回，因此认证缓存的键
+// 可以同时由请求中的明文 Key 与数据库中存储的值推导出来。
+func apiKeyLookupID(key string) string {
+	if strings.HasPrefix(key, apiKeyLookupPrefix) {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return apiKeyLookupPrefix + hex.EncodeToString(sum[:16])
+}
+
+// APIKeyHasher API Key 哈希存储的可插拔实现
+type APIKeyHasher interface {
+	// Algorithm 算法名称（bcrypt / argon2id）
+	Algorithm() string
+	// Hash 计算 Key 的哈希（自描述格式，包含算法与参数）
+	Hash(key string) (string, error)
+}
+
+// NewAPIKeyHasher 按配置创建 API Key 哈希实现；未启用哈希存储时返回 nil
+func NewAPIKeyHasher(cfg *config.Config) APIKeyHasher {
+	if cfg == nil || !cfg.Security.APIKeyHashing.Enabled {
+		return nil
+	}
+	if cfg.Security.APIKeyHashing.Algorithm == "argon2id" {
+		return argon2idAPIKeyHasher{}
+	}
+	cost := cfg.Security.APIKeyHashing.BcryptCost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return bcryptAPIKeyHasher{cost: cost}
+}
+
+// verifyAPIKeyHash 按哈希自身的格式校验 Key，切换算法后已存储的哈希依然有效
+func verifyAPIKeyHash(key, hash string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		return verifyArgon2idAPIKey(key, hash)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), bcryptAPIKeyInput(key)) == nil
+}
+
+// bcryptMaxInputBytes bcrypt 只接受不超过 72 字节的输入
+const bcryptMaxInputBytes = 72
+
+// bcryptAPIKeyInput 超过 bcrypt 长度上限的 Key（自定义 Key 或旧的明文 Key）先做 SHA-256 再参与 bcrypt；
+// 不超过上限的 Key 原样使用，已存储的哈希保持有效
+func bcryptAPIKeyInput(key string) []byte {
+	if len(key) <= bcryptMaxInputBytes {
+		return []byte(key)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return []byte(hex.EncodeToString(sum[:]))
+}
+
+type bcryptAPIKeyHasher struct {
+	cost int
+}
+
+func (bcryptAPIKeyHasher) Algorithm() string { return "bcrypt" }
+
+func (h bcryptAPIKeyHasher) Hash(key string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword(bcryptAPIKeyInput(key), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("bcrypt api key: %w", err)
+	}
+	return string(hash), nil
+}
+
+// argon2id 参数（RFC 9106 推荐的低内存配置）
+const (
+	apiKeyArgon2Time    = 3
+	apiKeyArgon2Memory  = 64 * 1024
+	apiKeyArgon2Threads = 4
+	apiKeyArgon2KeyLen  = 32
+	apiKeyArgon2SaltLen = 16
+)
+
+type argon2idAPIKeyHasher struct{}
+
+func (argon2idAPIKeyHasher) Algorithm() string { return "argon2id" }
+
+func (argon2idAPIKeyHasher) Hash(key string) (string, error) {
+	salt := make([]byte, apiKeyArgon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	sum := argon2.IDKey([]byte(key), salt, apiKeyArgon2Time, apiKeyArgon2Memory, apiKeyArgon2Threads, apiKeyArgon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, apiKeyArgon2Memory, apiKeyArgon2Time, apiKeyArgon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(sum)), nil
+}
+
+// verifyArgon2idAPIKey 校验 PHC 格式的 argon2id 哈希（$argon2id$v=19$m=..,t=..,p=..$salt$hash）
+func verifyArgon2idAPIKey(key, encoded string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+	got := argon2.IDKey([]byte(key), salt, iterations, memory, threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// getByKeyForAuth 按请求中的明文 Key 查询认证所需字段。
+// 哈希存储的 Key 通过查找摘要定位并校验哈希；明文存储的旧 Key 在启用哈希后首次使用时重新哈希存储。
+func (s *APIKeyService) getByKeyForAuth(ctx context.Context, key string) (*APIKey, error) {
+	// 查找摘要只用于存储，不能直接作为 Key 使用
+	if strings.HasPrefix(key, apiKeyLookupPrefix) {
+		return nil, ErrAPIKeyNotFound
+	}
+	lookupID := apiKeyLookupID(key)
+
+	// 启用哈希时优先按摘要查询（多数 Key 已哈希），否则优先按明文查询
+	first, second := key, lookupID
+	if s.hasher != nil {
+		first, second = lookupID, key
+	}
+	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, first)
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		apiKey, err = s.apiKeyRepo.GetByKeyForAuth(ctx, second)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.KeyHash != "" {
+		if !s.verifyKeyHash(key, apiKey.KeyHash) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return apiKey, nil
+	}
+	if s.hasher != nil {
+		s.rehashLegacyKey(ctx, apiKey, key, lookupID)
+	}
+	return apiKey, nil
+}
+
+// rehashLegacyKey 将明文存储的旧 Key 改为哈希存储；失败只记录日志，不影响本次认证
+func (s *APIKeyService) rehashLegacyKey(ctx context.Context, apiKey *APIKey, key, lookupID string) {
+	hash, err := s.hasher.Hash(key)
+	if err != nil {
+		slog.Warn("failed to hash legacy api key", "api_key_id", apiKey.ID, "error", err)
+		return
+	}
+	if err := s.apiKeyRepo.UpdateKeyHash(ctx, apiKey.ID, lookupID, hash); err != nil {
+		slog.Warn("failed to store legacy api key hash", "api_key_id", apiKey.ID, "error", err)
+		return
+	}
+	apiKey.KeyHash = hash
+	s.rememberKeyHash(key, hash)
+}
+
+// keyHashMemoSize 进程内缓存的已校验 Key 数量上限
+const keyHashMemoSize = 10000
+
+// initKeyHashMemo 创建已校验 Key 的有界缓存；创建失败时每次都完整校验
+func (s *APIKeyService) initKeyHashMemo() {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: keyHashMemoSize * 10,
+		MaxCost:     keyHashMemoSize,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return
+	}
+	s.keyHashVerified = cache
+}
+
+// verifyKeyHash 校验 Key 与哈希是否匹配。bcrypt/argon2id 计算代价较高，
+// 校验通过的结果缓存在进程内的有界缓存中，常用 Key 只需完整计算一次。
+func (s *APIKeyService) verifyKeyHash(key, hash string) bool {
+	if s.keyHashVerified != nil {
+		if v, ok := s.keyHashVerified.Get(keyHashMemoKey(key)); ok && v.(string) == hash {
+			return true
+		}
+	}
+	if !verifyAPIKeyHash(key, hash) {
+		return false
+	}
+	s.rememberKeyHash(key, hash)
+	return true
+}
+
+// rememberKeyHash 记录校验通过的 Key 与哈希
+func (s *APIKeyService) rememberKeyHash(key, hash string) {
+	if s.keyHashVerified != nil {
+		s.keyHashVerified.Set(keyHashMemoKey(key), hash, 1)
+	}
+}
+
+func keyHashMemoKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
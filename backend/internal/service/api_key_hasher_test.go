@@ -0,0 +1,142 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashingConfig(algorithm string) *config.Config {
+	return &config.Config{Security: config.SecurityConfig{APIKeyHashing: config.APIKeyHashingConfig{
+		Enabled:    true,
+		Algorithm:  algorithm,
+		BcryptCost: bcrypt.MinCost,
+	}}}
+}
+
+func TestAPIKeyHasher_RoundTrip(t *testing.T) {
+	require.Nil(t, NewAPIKeyHasher(&config.Config{}))
+
+	for _, algorithm := range []string{"bcrypt", "argon2id"} {
+		hasher := NewAPIKeyHasher(hashingConfig(algorithm))
+		require.NotNil(t, hasher)
+		require.Equal(t, algorithm, hasher.Algorithm())
+
+		hash, err := hasher.Hash("sk-test-key-123456")
+		require.NoError(t, err)
+		require.True(t, verifyAPIKeyHash("sk-test-key-123456", hash), algorithm)
+		require.False(t, verifyAPIKeyHash("sk-test-key-654321", hash), algorithm)
+	}
+	require.False(t, verifyAPIKeyHash("sk-test", "$argon2id$garbage"))
+}
+
+func TestAPIKeyHasher_BcryptAcceptsKeysLongerThan72Bytes(t *testing.T) {
+	hasher := NewAPIKeyHasher(hashingConfig("bcrypt"))
+	long := "sk-" + strings.Repeat("a", 100)
+
+	hash, err := hasher.Hash(long)
+	require.NoError(t, err)
+	require.True(t, verifyAPIKeyHash(long, hash))
+	// 只有前 72 字节相同的 Key 不能通过校验
+	require.False(t, verifyAPIKeyHash(long[:80], hash))
+
+	// 不超过 72 字节的 Key 仍直接参与 bcrypt，已存储的哈希保持有效
+	legacy, err := bcrypt.GenerateFromPassword([]byte("sk-test-key-123456"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.True(t, verifyAPIKeyHash("sk-test-key-123456", string(legacy)))
+}
+
+func TestAPIKeyLookupID_Idempotent(t *testing.T) {
+	id := apiKeyLookupID("sk-test-key-123456")
+	require.True(t, strings.HasPrefix(id, apiKeyLookupPrefix))
+	require.Equal(t, id, apiKeyLookupID(id))
+	require.NotEqual(t, id, apiKeyLookupID("sk-test-key-654321"))
+
+	svc := &APIKeyService{}
+	require.Equal(t, svc.authCacheKey("sk-test-key-123456"), svc.authCacheKey(id))
+}
+
+func TestAPIKeyService_GetByKey_VerifiesHashedKey(t *testing.T) {
+	cfg := hashingConfig("bcrypt")
+	hash, err := NewAPIKeyHasher(cfg).Hash("sk-hashed-key-0001")
+	require.NoError(t, err)
+
+	var lookups []string
+	repo := &authRepoStub{
+		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+			lookups = append(lookups, key)
+			if key != apiKeyLookupID("sk-hashed-key-0001") {
+				return nil, ErrAPIKeyNotFound
+			}
+			return &APIKey{
+				ID:      3,
+				UserID:  7,
+				Status:  StatusActive,
+				KeyHash: hash,
+				User:    &User{ID: 7, Status: StatusActive, Role: RoleUser},
+			}, nil
+		},
+	}
+	svc := NewAPIKeyService(repo, nil, nil, nil, nil, nil, cfg)
+
+	apiKey, err := svc.GetByKey(context.Background(), "sk-hashed-key-0001")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), apiKey.ID)
+	require.Equal(t, "sk-hashed-key-0001", apiKey.Key)
+	require.Equal(t, []string{apiKeyLookupID("sk-hashed-key-0001")}, lookups)
+
+	// 缓存命中时同样校验哈希
+	_, err = svc.GetByKey(context.Background(), "sk-hashed-key-0001")
+	require.NoError(t, err)
+
+	// 查找摘要不能直接作为 Key 使用
+	_, err = svc.GetByKey(context.Background(), apiKeyLookupID("sk-hashed-key-0001"))
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyService_GetByKey_RejectsHashMismatch(t *testing.T) {
+	cfg := hashingConfig("bcrypt")
+	hash, err := NewAPIKeyHasher(cfg).Hash("sk-other-key-0001")
+	require.NoError(t, err)
+
+	repo := &authRepoStub{
+		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+			return &APIKey{ID: 3, Status: StatusActive, KeyHash: hash, User: &User{ID: 7, Status: StatusActive}}, nil
+		},
+	}
+	svc := NewAPIKeyService(repo, nil, nil, nil, nil, nil, cfg)
+
+	_, err = svc.GetByKey(context.Background(), "sk-hashed-key-0001")
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyService_GetByKey_RehashesLegacyKeyOnFirstUse(t *testing.T) {
+	cfg := hashingConfig("argon2id")
+	var storedKey, storedHash string
+	repo := &authRepoStub{
+		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+			if key != "sk-legacy-key-0001" {
+				return nil, ErrAPIKeyNotFound
+			}
+			return &APIKey{ID: 4, UserID: 7, Status: StatusActive, User: &User{ID: 7, Status: StatusActive, Role: RoleUser}}, nil
+		},
+		updateKeyHash: func(ctx context.Context, id int64, key, keyHash string) error {
+			require.Equal(t, int64(4), id)
+			storedKey, storedHash = key, keyHash
+			return nil
+		},
+	}
+	svc := NewAPIKeyService(repo, nil, nil, nil, nil, nil, cfg)
+
+	apiKey, err := svc.GetByKey(context.Background(), "sk-legacy-key-0001")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), apiKey.ID)
+	require.Equal(t, apiKeyLookupID("sk-legacy-key-0001"), storedKey)
+	require.True(t, verifyAPIKeyHash("sk-legacy-key-0001", storedHash))
+}
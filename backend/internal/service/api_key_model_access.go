@@ -0,0 +1,64 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// maxAPIKeyModelAccessEntries 单个列表允许的最大条目数
+const maxAPIKeyModelAccessEntries = 200
+
+// HasModelAccessRules 是否配置了模型访问控制
+func (k *APIKey) HasModelAccessRules() bool {
+	return k != nil && (len(k.AllowedModels) > 0 || len(k.DeniedModels) > 0)
+}
+
+// IsModelAllowed 检查 API Key 是否允许请求该模型（大小写不敏感，支持末尾 * 通配）。
+// 命中禁止列表时拒绝（优先于允许列表）；允许列表为空时允许所有模型。
+func (k *APIKey) IsModelAllowed(model string) bool {
+	if !k.HasModelAccessRules() {
+		return true
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	for _, pattern := range k.DeniedModels {
+		if matchModelPattern(strings.ToLower(pattern), model) {
+			return false
+		}
+	}
+	if len(k.AllowedModels) == 0 {
+		return true
+	}
+	for _, pattern := range k.AllowedModels {
+		if matchModelPattern(strings.ToLower(pattern), model) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeModelAccessList 规范化模型列表：去空白、转小写、去重并排序；* 仅允许出现在末尾
+func normalizeModelAccessList(field string, models []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(models))
+	out := make([]string, 0, len(models))
+	for _, model := range models {
+		model = strings.ToLower(strings.TrimSpace(model))
+		if model == "" {
+			continue
+		}
+		if idx := strings.Index(model, "*"); idx >= 0 && idx != len(model)-1 {
+			return nil, infraerrors.BadRequest("INVALID_MODEL_PATTERN", field+": wildcard * is only allowed at the end: "+model)
+		}
+		if _, ok := seen[model]; ok {
+			continue
+		}
+		seen[model] = struct{}{}
+		out = append(out, model)
+	}
+	if len(out) > maxAPIKeyModelAccessEntries {
+		return nil, infraerrors.BadRequest("TOO_MANY_MODELS", field+" exceeds the maximum number of entries")
+	}
+	sort.Strings(out)
+	return out, nil
+}
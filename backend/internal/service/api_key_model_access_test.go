@@ -0,0 +1,65 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyIsModelAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		model   string
+		want    bool
+	}{
+		{name: "no rules", model: "claude-opus-4", want: true},
+		{name: "allowlist hit", allowed: []string{"claude-sonnet-4"}, model: "Claude-Sonnet-4", want: true},
+		{name: "allowlist miss", allowed: []string{"claude-sonnet-4"}, model: "claude-opus-4", want: false},
+		{name: "allowlist wildcard", allowed: []string{"claude-*"}, model: "claude-opus-4", want: true},
+		{name: "denylist only", denied: []string{"claude-opus-*"}, model: "claude-opus-4", want: false},
+		{name: "denylist only other model", denied: []string{"claude-opus-*"}, model: "gpt-5", want: true},
+		{name: "denylist wins over allowlist", allowed: []string{"claude-*"}, denied: []string{"claude-opus-4"}, model: "claude-opus-4", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := &APIKey{AllowedModels: tt.allowed, DeniedModels: tt.denied}
+			require.Equal(t, tt.want, key.IsModelAllowed(tt.model))
+		})
+	}
+}
+
+func TestAdminService_AdminUpdateAPIKeyModelAccess(t *testing.T) {
+	existing := &APIKey{ID: 1, Key: "sk-test", AllowedModels: []string{"gpt-5"}, DeniedModels: []string{"o3"}}
+	apiKeyRepo := &apiKeyRepoStubForGroupUpdate{key: existing}
+	cache := &authCacheInvalidatorStub{}
+	svc := &adminServiceImpl{apiKeyRepo: apiKeyRepo, authCacheInvalidator: cache}
+
+	allowed := []string{" Claude-* ", "claude-*", ""}
+	got, err := svc.AdminUpdateAPIKeyModelAccess(context.Background(), 1, &allowed, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"claude-*"}, got.AllowedModels)
+	// 未传入的列表保持不变
+	require.Equal(t, []string{"o3"}, got.DeniedModels)
+	require.Equal(t, []string{"claude-*"}, apiKeyRepo.updated.AllowedModels)
+	require.Equal(t, []string{"sk-test"}, cache.keys)
+
+	empty := []string{}
+	got, err = svc.AdminUpdateAPIKeyModelAccess(context.Background(), 1, nil, &empty)
+	require.NoError(t, err)
+	require.Empty(t, got.DeniedModels)
+}
+
+func TestAdminService_AdminUpdateAPIKeyModelAccess_RejectsInvalidPattern(t *testing.T) {
+	apiKeyRepo := &apiKeyRepoStubForGroupUpdate{key: &APIKey{ID: 1, Key: "sk-test"}}
+	svc := &adminServiceImpl{apiKeyRepo: apiKeyRepo}
+
+	denied := []string{"claude-*-opus"}
+	_, err := svc.AdminUpdateAPIKeyModelAccess(context.Background(), 1, nil, &denied)
+	require.Error(t, err)
+	require.Nil(t, apiKeyRepo.updated)
+}
@@ -53,6 +53,8 @@ type APIKeyRepository interface {
 	GetByKey(ctx context.Context, key string) (*APIKey, error)
 	// GetByKeyForAuth 认证专用查询，返回最小字段集
 	GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error)
+	// UpdateKeyHash 将明文存储的 Key 改为哈希存储（key 列写入查找摘要）
+	UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error
 	Update(ctx context.Context, key *APIKey) error
 	Delete(ctx context.Context, id int64) error
 
@@ -184,6 +186,8 @@ type UpdateAPIKeyRequest struct {
 	RateLimit1d         *float64 `json:"rate_limit_1d"`
 	RateLimit7d         *float64 `json:"rate_limit_7d"`
 	ResetRateLimitUsage *bool    `json:"reset_rate_limit_usage"` // Reset all usage counters to 0
+
+	UsageHeaders *bool `json:"usage_headers"` // Expose billed usage in response headers (nil = no change)
 }
 
 // APIKeyService API Key服务
@@ -206,6 +210,8 @@ type APIKeyService struct {
 	authGroup             singleflight.Group
 	lastUsedTouchL1       sync.Map // keyID -> nextAllowedAt(time.Time)
 	lastUsedTouchSF       singleflight.Group
+	hasher                APIKeyHasher     // nil 表示明文存储
+	keyHashVerified       *ristretto.Cache // sha256(key) -> 已校验通过的哈希（有界）
 }
 
 // NewAPIKeyService 创建API Key服务实例
@@ -226,8 +232,10 @@ func NewAPIKeyService(
 		userGroupRateRepo: userGroupRateRepo,
 		cache:             cache,
 		cfg:               cfg,
+		hasher:            NewAPIKeyHasher(cfg),
 	}
 	svc.initAuthCache(cfg)
+	svc.initKeyHashMemo()
 	return svc
 }
 
@@ -376,6 +384,10 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 
 		// 检查Key是否已存在
 		exists, err := s.apiKeyRepo.ExistsByKey(ctx, *req.CustomKey)
+		if err == nil && !exists {
+			// 同一个 Key 可能已以哈希形式存储
+			exists, err = s.apiKeyRepo.ExistsByKey(ctx, apiKeyLookupID(*req.CustomKey))
+		}
 		if err != nil {
 			return nil, fmt.Errorf("check key exists: %w", err)
 		}
@@ -417,11 +429,23 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		apiKey.ExpiresAt = &expiresAt
 	}
 
+	// 哈希存储：key 列只保存查找摘要，明文 Key 仅在本次创建响应中返回
+	if s.hasher != nil {
+		hash, err := s.hasher.Hash(key)
+		if err != nil {
+			return nil, fmt.Errorf("hash api key: %w", err)
+		}
+		apiKey.Key = apiKeyLookupID(key)
+		apiKey.KeyHash = hash
+	}
+
 	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
 		return nil, fmt.Errorf("create api key: %w", err)
 	}
 
 	s.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	apiKey.Key = key
+	apiKey.KeyHash = ""
 	s.compileAPIKeyIPRules(apiKey)
 
 	return apiKey, nil
@@ -501,7 +525,7 @@ func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, erro
 		}
 	}
 
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.getByKeyForAuth(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("get api key: %w", err)
 	}
@@ -621,6 +645,10 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 		apiKey.Window7dStart = nil
 	}
 
+	if req.UsageHeaders != nil {
+		apiKey.UsageHeaders = *req.UsageHeaders
+	}
+
 	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
 		return nil, fmt.Errorf("update api key: %w", err)
 	}
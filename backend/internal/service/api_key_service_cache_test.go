@@ -20,6 +20,7 @@ type authRepoStub struct {
 	getByKeyForAuth   func(ctx context.Context, key string) (*APIKey, error)
 	listKeysByUserID  func(ctx context.Context, userID int64) ([]string, error)
 	listKeysByGroupID func(ctx context.Context, groupID int64) ([]string, error)
+	updateKeyHash     func(ctx context.Context, id int64, key, keyHash string) error
 }
 
 func (s *authRepoStub) Create(ctx context.Context, key *APIKey) error {
@@ -45,6 +46,13 @@ func (s *authRepoStub) GetByKeyForAuth(ctx context.Context, key string) (*APIKey
 	return s.getByKeyForAuth(ctx, key)
 }
 
+func (s *authRepoStub) UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error {
+	if s.updateKeyHash == nil {
+		panic("unexpected UpdateKeyHash call")
+	}
+	return s.updateKeyHash(ctx, id, key, keyHash)
+}
+
 func (s *authRepoStub) Update(ctx context.Context, key *APIKey) error {
 	panic("unexpected Update call")
 }
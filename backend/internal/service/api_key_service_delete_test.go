@@ -68,6 +68,10 @@ func (s *apiKeyRepoStub) GetByKeyForAuth(ctx context.Context, key string) (*APIK
 	panic("unexpected GetByKeyForAuth call")
 }
 
+func (s *apiKeyRepoStub) UpdateKeyHash(ctx context.Context, id int64, key, keyHash string) error {
+	panic("unexpected UpdateKeyHash call")
+}
+
 func (s *apiKeyRepoStub) Update(ctx context.Context, key *APIKey) error {
 	panic("unexpected Update call")
 }
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// ResolveAPIKeySizeLimits 返回 API Key 生效的请求体/响应体大小上限（字节，0 表示不额外限制）。
+// Key 未单独设置时使用 gateway.key_size_limits 的全局默认值；请求体同时仍受 gateway.max_body_size 限制。
+func ResolveAPIKeySizeLimits(apiKey *APIKey, cfg *config.Config) (maxRequestBytes, maxResponseBytes int64) {
+	if cfg != nil {
+		maxRequestBytes = cfg.Gateway.KeySizeLimits.DefaultMaxRequestBytes
+		maxResponseBytes = cfg.Gateway.KeySizeLimits.DefaultMaxResponseBytes
+	}
+	if apiKey != nil {
+		if apiKey.MaxRequestBytes > 0 {
+			maxRequestBytes = apiKey.MaxRequestBytes
+		}
+		if apiKey.MaxResponseBytes > 0 {
+			maxResponseBytes = apiKey.MaxResponseBytes
+		}
+	}
+	return maxRequestBytes, maxResponseBytes
+}
+
+// ResponseSizeGuard 单个请求的响应体大小限制状态。
+// 响应超限被中止后，异步用量记录任务据此在使用日志中记录中止原因；上游已生成的 token 照常计费。
+type ResponseSizeGuard struct {
+	mu          sync.Mutex
+	limit       int64
+	abortReason string
+}
+
+// NewResponseSizeGuard 创建响应体大小限制状态
+func NewResponseSizeGuard(limit int64) *ResponseSizeGuard {
+	return &ResponseSizeGuard{limit: limit}
+}
+
+// WithResponseSizeGuard 将响应体大小限制状态放入请求上下文
+func WithResponseSizeGuard(ctx context.Context, guard *ResponseSizeGuard) context.Context {
+	if guard == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.ResponseSizeGuard, guard)
+}
+
+// ResponseSizeGuardFromContext 取出当前请求的响应体大小限制状态，未限制时返回 nil
+func ResponseSizeGuardFromContext(ctx context.Context) *ResponseSizeGuard {
+	if ctx == nil {
+		return nil
+	}
+	guard, _ := ctx.Value(ctxkey.ResponseSizeGuard).(*ResponseSizeGuard)
+	return guard
+}
+
+// Limit 响应体大小上限（字节）
+func (g *ResponseSizeGuard) Limit() int64 {
+	if g == nil {
+		return 0
+	}
+	return g.limit
+}
+
+// Abort 标记响应因超限被中止，返回面向客户端的错误信息
+func (g *ResponseSizeGuard) Abort() string {
+	reason := fmt.Sprintf("response exceeded the API key limit of %d bytes and was aborted", g.limit)
+	g.mu.Lock()
+	g.abortReason = reason
+	g.mu.Unlock()
+	return reason
+}
+
+// AbortReason 响应被中止的原因，未中止时为空
+func (g *ResponseSizeGuard) AbortReason() string {
+	if g == nil {
+		return ""
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.abortReason
+}
+
+// SetUsage 将中止原因写入使用日志
+func (g *ResponseSizeGuard) SetUsage(usageLog *UsageLog) {
+	if usageLog == nil {
+		return
+	}
+	if reason := g.AbortReason(); reason != "" {
+		usageLog.AbortReason = &reason
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// API Key 吞吐量（RPM/TPM）超限错误。gateway_handler 负责映射为 HTTP 429 + Retry-After。
+var (
+	ErrAPIKeyRPMExceeded = infraerrors.TooManyRequests("API_KEY_RPM_EXCEEDED", "api key requests-per-minute limit exceeded")
+	ErrAPIKeyTPMExceeded = infraerrors.TooManyRequests("API_KEY_TPM_EXCEEDED", "api key tokens-per-minute limit exceeded")
+)
+
+// APIKeyThroughputLimitError 携带建议重试时间的吞吐量超限错误。
+// Unwrap 返回对应哨兵错误，errors.Is(err, ErrAPIKeyRPMExceeded) 等判断仍然有效。
+type APIKeyThroughputLimitError struct {
+	err        error
+	RetryAfter time.Duration
+}
+
+func (e *APIKeyThroughputLimitError) Error() string { return e.err.Error() }
+
+func (e *APIKeyThroughputLimitError) Unwrap() error { return e.err }
+
+// RetryAfterFromError 提取吞吐量超限错误的建议重试时间
+func RetryAfterFromError(err error) (time.Duration, bool) {
+	var limitErr *APIKeyThroughputLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// TokenBucketLimit 令牌桶参数
+type TokenBucketLimit struct {
+	Capacity        float64 // 桶容量（允许的突发量）
+	RefillPerSecond float64 // 每秒补充的令牌数
+}
+
+// perMinuteBucket 以"每分钟 n 个"构造令牌桶：容量 n，每秒补充 n/60
+func perMinuteBucket(n int) TokenBucketLimit {
+	return TokenBucketLimit{Capacity: float64(n), RefillPerSecond: float64(n) / 60}
+}
+
+// TokenBucketStore 令牌桶状态存储。
+// 默认使用进程内实现；多实例部署时可替换为共享实现（如 Redis），使限流状态跨实例生效。
+type TokenBucketStore interface {
+	// Take 尝试取走 cost 个令牌。令牌不足（或桶已透支）时不扣减，返回 false 与建议等待时长。
+	// cost 为 0 时仅检查桶是否仍有余量。
+	Take(ctx context.Context, key string, limit TokenBucketLimit, cost float64) (bool, time.Duration, error)
+	// Charge 无条件扣减 cost 个令牌（允许透支），用于请求完成后按实际用量记账。
+	Charge(ctx context.Context, key string, limit TokenBucketLimit, cost float64) error
+}
+
+// memoryTokenBucketIdleTTL 空闲桶的清理时间
+const memoryTokenBucketIdleTTL = 10 * time.Minute
+
+type memoryTokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MemoryTokenBucketStore 进程内令牌桶存储
+type MemoryTokenBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryTokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryTokenBucketStore 创建进程内令牌桶存储
+func NewMemoryTokenBucketStore() *MemoryTokenBucketStore {
+	return &MemoryTokenBucketStore{
+		buckets: make(map[string]*memoryTokenBucket),
+		now:     time.Now,
+	}
+}
+
+// refillLocked 按经过时间补充令牌并返回桶（调用方需持有 mu）
+func (s *MemoryTokenBucketStore) refillLocked(key string, limit TokenBucketLimit, now time.Time) *memoryTokenBucket {
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, b := range s.buckets {
+			if now.Sub(b.last) > memoryTokenBucketIdleTTL {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryTokenBucket{tokens: limit.Capacity, last: now}
+		s.buckets[key] = b
+		return b
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(limit.Capacity, b.tokens+elapsed*limit.RefillPerSecond)
+	}
+	b.last = now
+	return b
+}
+
+// Take 实现 TokenBucketStore
+func (s *MemoryTokenBucketStore) Take(_ context.Context, key string, limit TokenBucketLimit, cost float64) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.refillLocked(key, limit, s.now())
+	if b.tokens > 0 && b.tokens >= cost {
+		b.tokens -= cost
+		return true, 0, nil
+	}
+	// 至少需要补充到 max(cost, 1) 个令牌才能再次放行
+	need := math.Max(cost, 1) - b.tokens
+	if limit.RefillPerSecond <= 0 {
+		return false, time.Minute, nil
+	}
+	return false, time.Duration(need / limit.RefillPerSecond * float64(time.Second)), nil
+}
+
+// Charge 实现 TokenBucketStore
+func (s *MemoryTokenBucketStore) Charge(_ context.Context, key string, limit TokenBucketLimit, cost float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.refillLocked(key, limit, s.now())
+	b.tokens -= cost
+	return nil
+}
+
+// SetTokenBucketStore 替换 API Key 吞吐量限流的状态存储（默认进程内）
+func (s *BillingCacheService) SetTokenBucketStore(store TokenBucketStore) {
+	if s == nil || store == nil {
+		return
+	}
+	s.tokenBuckets = store
+}
+
+// apiKeyThroughputLimits 返回 API Key 生效的 RPM/TPM 限制（Key 未配置时回落到全局默认值）
+func (s *BillingCacheService) apiKeyThroughputLimits(apiKey *APIKey) (rpm, tpm int) {
+	rpm, tpm = apiKey.RPMLimit, apiKey.TPMLimit
+	if s.cfg != nil {
+		if rpm <= 0 {
+			rpm = s.cfg.RateLimit.APIKeyDefaultRPM
+		}
+		if tpm <= 0 {
+			tpm = s.cfg.RateLimit.APIKeyDefaultTPM
+		}
+	}
+	return rpm, tpm
+}
+
+func apiKeyRPMBucketKey(keyID int64) string { return "rpm:k:" + strconv.FormatInt(keyID, 10) }
+
+func apiKeyTPMBucketKey(keyID int64) string { return "tpm:k:" + strconv.FormatInt(keyID, 10) }
+
+// checkAPIKeyThroughput 令牌桶检查 API Key 的 RPM/TPM。
+// TPM 在请求前只检查余量（实际 token 数在请求完成后通过 RecordAPIKeyTokenUsage 记账），
+// 因此先查 TPM 再扣 RPM，避免被 TPM 拒绝的请求占用 RPM 配额。存储故障 fail-open。
+func (s *BillingCacheService) checkAPIKeyThroughput(ctx context.Context, apiKey *APIKey) error {
+	if s == nil || s.tokenBuckets == nil || apiKey == nil {
+		return nil
+	}
+	rpm, tpm := s.apiKeyThroughputLimits(apiKey)
+
+	if tpm > 0 {
+		ok, retryAfter, err := s.tokenBuckets.Take(ctx, apiKeyTPMBucketKey(apiKey.ID), perMinuteBucket(tpm), 0)
+		if err != nil {
+			logger.LegacyPrintf("service.billing_cache", "Warning: tpm bucket check failed for api key=%d: %v", apiKey.ID, err)
+		} else if !ok {
+			return &APIKeyThroughputLimitError{err: ErrAPIKeyTPMExceeded, RetryAfter: retryAfter}
+		}
+	}
+	if rpm > 0 {
+		ok, retryAfter, err := s.tokenBuckets.Take(ctx, apiKeyRPMBucketKey(apiKey.ID), perMinuteBucket(rpm), 1)
+		if err != nil {
+			logger.LegacyPrintf("service.billing_cache", "Warning: rpm bucket check failed for api key=%d: %v", apiKey.ID, err)
+		} else if !ok {
+			return &APIKeyThroughputLimitError{err: ErrAPIKeyRPMExceeded, RetryAfter: retryAfter}
+		}
+	}
+	return nil
+}
+
+// RecordAPIKeyTokenUsage 请求完成后按实际 token 数扣减 API Key 的 TPM 令牌桶，并累加周期额度的 token 用量
+func (s *BillingCacheService) RecordAPIKeyTokenUsage(ctx context.Context, apiKey *APIKey, tokens int) {
+	s.recordAPIKeyUsageQuotaTokens(ctx, apiKey, tokens)
+	if s == nil || s.tokenBuckets == nil || apiKey == nil || tokens <= 0 {
+		return
+	}
+	_, tpm := s.apiKeyThroughputLimits(apiKey)
+	if tpm <= 0 {
+		return
+	}
+	if err := s.tokenBuckets.Charge(ctx, apiKeyTPMBucketKey(apiKey.ID), perMinuteBucket(tpm), float64(tokens)); err != nil {
+		logger.LegacyPrintf("service.billing_cache", "Warning: tpm bucket charge failed for api key=%d: %v", apiKey.ID, err)
+	}
+}
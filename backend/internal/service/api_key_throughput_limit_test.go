@@ -0,0 +1,113 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// newThroughputTestService 构造使用可控时钟的令牌桶存储的 BillingCacheService
+func newThroughputTestService(t *testing.T, cfg *config.Config) (*BillingCacheService, *time.Time) {
+	t.Helper()
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	svc := NewBillingCacheService(nil, nil, nil, nil, nil, nil, cfg)
+	t.Cleanup(svc.Stop)
+
+	now := time.Unix(1_700_000_000, 0)
+	store := NewMemoryTokenBucketStore()
+	store.now = func() time.Time { return now }
+	svc.SetTokenBucketStore(store)
+	return svc, &now
+}
+
+func TestMemoryTokenBucketStore_RefillsOverTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	store := NewMemoryTokenBucketStore()
+	store.now = func() time.Time { return now }
+	limit := perMinuteBucket(60) // 每秒补充 1 个
+	ctx := context.Background()
+
+	ok, _, err := store.Take(ctx, "k", limit, 60)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, wait, err := store.Take(ctx, "k", limit, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, time.Second, wait)
+
+	now = now.Add(2 * time.Second)
+	ok, _, err = store.Take(ctx, "k", limit, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestMemoryTokenBucketStore_ChargeOverdraws(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	store := NewMemoryTokenBucketStore()
+	store.now = func() time.Time { return now }
+	limit := perMinuteBucket(600) // 每秒补充 10 个
+	ctx := context.Background()
+
+	require.NoError(t, store.Charge(ctx, "k", limit, 700))
+	ok, wait, err := store.Take(ctx, "k", limit, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+	// 透支 100 个，需补充到 1 个：101 / 10 = 10.1s
+	require.Equal(t, 10100*time.Millisecond, wait)
+}
+
+func TestCheckAPIKeyThroughput_RPMExceeded(t *testing.T) {
+	svc, _ := newThroughputTestService(t, nil)
+	apiKey := &APIKey{ID: 1, RPMLimit: 2}
+	ctx := context.Background()
+
+	require.NoError(t, svc.checkAPIKeyThroughput(ctx, apiKey))
+	require.NoError(t, svc.checkAPIKeyThroughput(ctx, apiKey))
+
+	err := svc.checkAPIKeyThroughput(ctx, apiKey)
+	require.ErrorIs(t, err, ErrAPIKeyRPMExceeded)
+	retryAfter, ok := RetryAfterFromError(err)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Second, retryAfter)
+}
+
+func TestCheckAPIKeyThroughput_TPMUsageBlocksNextRequest(t *testing.T) {
+	svc, now := newThroughputTestService(t, nil)
+	apiKey := &APIKey{ID: 2, TPMLimit: 1000}
+	ctx := context.Background()
+
+	require.NoError(t, svc.checkAPIKeyThroughput(ctx, apiKey))
+	svc.RecordAPIKeyTokenUsage(ctx, apiKey, 1500)
+
+	err := svc.checkAPIKeyThroughput(ctx, apiKey)
+	require.True(t, errors.Is(err, ErrAPIKeyTPMExceeded))
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, svc.checkAPIKeyThroughput(ctx, apiKey))
+}
+
+func TestCheckAPIKeyThroughput_FallsBackToConfigDefault(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.RateLimit.APIKeyDefaultRPM = 1
+	svc, _ := newThroughputTestService(t, cfg)
+	ctx := context.Background()
+
+	limited := &APIKey{ID: 3}
+	require.NoError(t, svc.checkAPIKeyThroughput(ctx, limited))
+	require.ErrorIs(t, svc.checkAPIKeyThroughput(ctx, limited), ErrAPIKeyRPMExceeded)
+
+	// Key 级配置覆盖全局默认值
+	overridden := &APIKey{ID: 4, RPMLimit: 5}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, svc.checkAPIKeyThroughput(ctx, overridden))
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// API Key 周期额度重置周期（UTC 日历边界：整点 / 零点 / 周一零点 / 每月 1 日零点）
+const (
+	UsageQuotaPeriodHourly  = "hourly"
+	UsageQuotaPeriodDaily   = "daily"
+	UsageQuotaPeriodWeekly  = "weekly"
+	UsageQuotaPeriodMonthly = "monthly"
+)
+
+// API Key 周期额度超限错误。gateway_handler 负责映射为 HTTP 429 + Retry-After（距周期重置的秒数）。
+var (
+	ErrAPIKeyRequestQuotaExceeded = infraerrors.TooManyRequests("API_KEY_REQUEST_QUOTA_EXCEEDED", "api key request quota exceeded")
+	ErrAPIKeyTokenQuotaExceeded   = infraerrors.TooManyRequests("API_KEY_TOKEN_QUOTA_EXCEEDED", "api key token quota exceeded")
+)
+
+// usageQuotaSyncInterval 进程内计数与持久化存储的同步间隔（多实例部署时各实例定期读取全局用量）
+const usageQuotaSyncInterval = 15 * time.Second
+
+// IsValidUsageQuotaPeriod 判断额度重置周期是否合法
+func IsValidUsageQuotaPeriod(period string) bool {
+	switch period {
+	case UsageQuotaPeriodHourly, UsageQuotaPeriodDaily, UsageQuotaPeriodWeekly, UsageQuotaPeriodMonthly:
+		return true
+	}
+	return false
+}
+
+// UsageQuotaWindowBounds 返回 now 所在额度周期 [start, end)，非法周期按 daily 处理
+func UsageQuotaWindowBounds(period string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	switch period {
+	case UsageQuotaPeriodHourly:
+		start := now.Truncate(time.Hour)
+		return start, start.Add(time.Hour)
+	case UsageQuotaPeriodWeekly:
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case UsageQuotaPeriodMonthly:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+}
+
+// HasUsageQuota 是否配置了请求数或 token 数周期额度
+func (k *APIKey) HasUsageQuota() bool {
+	return k.UsageQuotaRequests > 0 || k.UsageQuotaTokens > 0
+}
+
+// EffectiveUsageQuotaUsed 返回当前周期的已用请求数/token 数，持久化的周期已过期时为 0
+func (k *APIKey) EffectiveUsageQuotaUsed() (requests, tokens int64) {
+	start, _ := UsageQuotaWindowBounds(k.UsageQuotaPeriod, time.Now())
+	if k.UsageQuotaWindowStart == nil || !k.UsageQuotaWindowStart.Equal(start) {
+		return 0, 0
+	}
+	return k.UsageQuotaRequestsUsed, k.UsageQuotaTokensUsed
+}
+
+// UsageQuotaResetAt 返回当前额度周期的重置时间，未配置额度时为 nil
+func (k *APIKey) UsageQuotaResetAt() *time.Time {
+	if !k.HasUsageQuota() {
+		return nil
+	}
+	_, end := UsageQuotaWindowBounds(k.UsageQuotaPeriod, time.Now())
+	return &end
+}
+
+// APIKeyUsageQuotaState 持久化存储中的周期额度用量
+type APIKeyUsageQuotaState struct {
+	WindowStart  *time.Time
+	RequestsUsed int64
+	TokensUsed   int64
+}
+
+// apiKeyUsageQuotaStore 周期额度用量的持久化存储（由 API Key 仓储实现），重启后据此恢复用量
+type apiKeyUsageQuotaStore interface {
+	GetUsageQuotaState(ctx context.Context, id int64) (*APIKeyUsageQuotaState, error)
+	// IncrementUsageQuota 累加 windowStart 周期的用量；存储中的周期早于 windowStart 时先清零
+	IncrementUsageQuota(ctx context.Context, id int64, windowStart time.Time, requests, tokens int64) error
+}
+
+// APIKeyUsageQuotaExceededError 携带周期额度用量的超限错误。
+// Unwrap 返回对应哨兵错误（请求数额度优先），errors.Is(err, ErrAPIKeyTokenQuotaExceeded) 等判断仍然有效。
+type APIKeyUsageQuotaExceededError struct {
+	Period        string
+	RequestsLimit int64 // 0 表示不限制
+	RequestsUsed  int64
+	TokensLimit   int64 // 0 表示不限制
+	TokensUsed    int64
+	ResetAt       time.Time
+}
+
+func (e *APIKeyUsageQuotaExceededError) Error() string { return e.Unwrap().Error() }
+
+func (e *APIKeyUsageQuotaExceededError) Unwrap() error {
+	if e.RequestsLimit > 0 && e.RequestsUsed >= e.RequestsLimit {
+		return ErrAPIKeyRequestQuotaExceeded
+	}
+	return ErrAPIKeyTokenQuotaExceeded
+}
+
+// RemainingRequests 本周期剩余请求数（-1 表示不限制）
+func (e *APIKeyUsageQuotaExceededError) RemainingRequests() int64 {
+	return usageQuotaRemaining(e.RequestsLimit, e.RequestsUsed)
+}
+
+// RemainingTokens 本周期剩余 token 数（-1 表示不限制）
+func (e *APIKeyUsageQuotaExceededError) RemainingTokens() int64 {
+	return usageQuotaRemaining(e.TokensLimit, e.TokensUsed)
+}
+
+func usageQuotaRemaining(limit, used int64) int64 {
+	if limit <= 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// APIKeyUsageQuotaExceededFromError 提取周期额度超限错误的用量信息
+func APIKeyUsageQuotaExceededFromError(err error) (*APIKeyUsageQuotaExceededError, bool) {
+	var quotaErr *APIKeyUsageQuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return quotaErr, true
+	}
+	return nil, false
+}
+
+// apiKeyUsageQuotaCounter 单个 API Key 当前周期的进程内用量
+type apiKeyUsageQuotaCounter struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    int64
+	tokens      int64
+	syncedAt    time.Time
+}
+
+// usageQuotaCounter 返回 keyID 当前周期的计数器：首次使用、周期切换或超过同步间隔时从持久化存储重新加载。
+// 存储读取失败时沿用进程内计数（fail-open）。
+func (s *BillingCacheService) usageQuotaCounter(ctx context.Context, keyID int64, windowStart, now time.Time) *apiKeyUsageQuotaCounter {
+	value, _ := s.usageQuotaCounters.LoadOrStore(keyID, &apiKeyUsageQuotaCounter{})
+	counter := value.(*apiKeyUsageQuotaCounter)
+
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	if counter.windowStart.Equal(windowStart) && now.Sub(counter.syncedAt) < usageQuotaSyncInterval {
+		return counter
+	}
+	if !counter.windowStart.Equal(windowStart) {
+		counter.windowStart, counter.requests, counter.tokens = windowStart, 0, 0
+	}
+	counter.syncedAt = now
+	if s.usageQuotaStore == nil {
+		return counter
+	}
+	state, err := s.usageQuotaStore.GetUsageQuotaState(ctx, keyID)
+	if err != nil {
+		logger.LegacyPrintf("service.billing_cache", "Warning: usage quota state lookup failed for api key=%d: %v", keyID, err)
+		return counter
+	}
+	if state.WindowStart != nil && state.WindowStart.Equal(windowStart) {
+		counter.requests, counter.tokens = state.RequestsUsed, state.TokensUsed
+	} else {
+		counter.requests, counter.tokens = 0, 0
+	}
+	return counter
+}
+
+// checkAPIKeyUsageQuota 检查 API Key 本周期的请求数/token 数额度，放行时计入一次请求。
+// token 额度在请求前只检查是否已用尽（实际 token 数在请求完成后通过 RecordAPIKeyTokenUsage 记账）。
+func (s *BillingCacheService) checkAPIKeyUsageQuota(ctx context.Context, apiKey *APIKey) error {
+	if s == nil || apiKey == nil || !apiKey.HasUsageQuota() {
+		return nil
+	}
+	now := time.Now()
+	windowStart, windowEnd := UsageQuotaWindowBounds(apiKey.UsageQuotaPeriod, now)
+	counter := s.usageQuotaCounter(ctx, apiKey.ID, windowStart, now)
+
+	counter.mu.Lock()
+	exceeded := (apiKey.UsageQuotaRequests > 0 && counter.requests >= apiKey.UsageQuotaRequests) ||
+		(apiKey.UsageQuotaTokens > 0 && counter.tokens >= apiKey.UsageQuotaTokens)
+	if !exceeded {
+		counter.requests++
+	}
+	requests, tokens := counter.requests, counter.tokens
+	counter.mu.Unlock()
+
+	if exceeded {
+		return &APIKeyUsageQuotaExceededError{
+			Period:        apiKey.UsageQuotaPeriod,
+			RequestsLimit: apiKey.UsageQuotaRequests,
+			RequestsUsed:  requests,
+			TokensLimit:   apiKey.UsageQuotaTokens,
+			TokensUsed:    tokens,
+			ResetAt:       windowEnd,
+		}
+	}
+	s.persistUsageQuota(apiKey.ID, windowStart, 1, 0)
+	return nil
+}
+
+// recordAPIKeyUsageQuotaTokens 请求完成后按实际 token 数累加 API Key 本周期的 token 用量
+func (s *BillingCacheService) recordAPIKeyUsageQuotaTokens(ctx context.Context, apiKey *APIKey, tokens int) {
+	if s == nil || apiKey == nil || !apiKey.HasUsageQuota() || tokens <= 0 {
+		return
+	}
+	now := time.Now()
+	windowStart, _ := UsageQuotaWindowBounds(apiKey.UsageQuotaPeriod, now)
+	counter := s.usageQuotaCounter(ctx, apiKey.ID, windowStart, now)
+	counter.mu.Lock()
+	counter.tokens += int64(tokens)
+	counter.mu.Unlock()
+	s.persistUsageQuota(apiKey.ID, windowStart, 0, int64(tokens))
+}
+
+// persistUsageQuota 通过缓存写入工作池异步持久化用量，队列满时同步写入，保证重启后用量不丢失
+func (s *BillingCacheService) persistUsageQuota(keyID int64, windowStart time.Time, requests, tokens int64) {
+	if s.usageQuotaStore == nil {
+		return
+	}
+	task := cacheWriteTask{
+		kind:             cacheWriteIncrementUsageQuota,
+		apiKeyID:         keyID,
+		quotaWindowStart: windowStart,
+		quotaRequests:    requests,
+		quotaTokens:      tokens,
+	}
+	if s.enqueueCacheWrite(task) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	s.writeUsageQuota(ctx, task)
+}
+
+func (s *BillingCacheService) writeUsageQuota(ctx context.Context, task cacheWriteTask) {
+	if s.usageQuotaStore == nil {
+		return
+	}
+	if err := s.usageQuotaStore.IncrementUsageQuota(ctx, task.apiKeyID, task.quotaWindowStart, task.quotaRequests, task.quotaTokens); err != nil {
+		logger.LegacyPrintf("service.billing_cache", "Warning: persist usage quota failed for api key=%d: %v", task.apiKeyID, err)
+	}
+}
+
+// InvalidateAPIKeyUsageQuota 丢弃进程内计数，下次请求从持久化存储重新加载（管理员修改或重置额度后调用）
+func (s *BillingCacheService) InvalidateAPIKeyUsageQuota(keyID int64) {
+	if s == nil {
+		return
+	}
+	s.usageQuotaCounters.Delete(keyID)
+}
@@ -0,0 +1,159 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// usageQuotaStoreStub 模拟持久化存储：按周期累加，周期切换时清零
+type usageQuotaStoreStub struct {
+	mu     sync.Mutex
+	states map[int64]*APIKeyUsageQuotaState
+}
+
+func newUsageQuotaStoreStub() *usageQuotaStoreStub {
+	return &usageQuotaStoreStub{states: map[int64]*APIKeyUsageQuotaState{}}
+}
+
+func (s *usageQuotaStoreStub) GetUsageQuotaState(_ context.Context, id int64) (*APIKeyUsageQuotaState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[id]
+	if !ok {
+		return &APIKeyUsageQuotaState{}, nil
+	}
+	cp := *state
+	return &cp, nil
+}
+
+func (s *usageQuotaStoreStub) IncrementUsageQuota(_ context.Context, id int64, windowStart time.Time, requests, tokens int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[id]
+	if !ok || state.WindowStart == nil || state.WindowStart.Before(windowStart) {
+		start := windowStart
+		s.states[id] = &APIKeyUsageQuotaState{WindowStart: &start, RequestsUsed: requests, TokensUsed: tokens}
+		return nil
+	}
+	if state.WindowStart.Equal(windowStart) {
+		state.RequestsUsed += requests
+		state.TokensUsed += tokens
+	}
+	return nil
+}
+
+func newUsageQuotaTestService(t *testing.T, store apiKeyUsageQuotaStore) *BillingCacheService {
+	t.Helper()
+	svc := NewBillingCacheService(nil, nil, nil, nil, nil, nil, &config.Config{})
+	t.Cleanup(svc.Stop)
+	svc.usageQuotaStore = store
+	return svc
+}
+
+func TestUsageQuotaWindowBounds(t *testing.T) {
+	now := time.Date(2026, 3, 18, 15, 42, 7, 0, time.UTC) // 周三
+
+	start, end := UsageQuotaWindowBounds(UsageQuotaPeriodHourly, now)
+	require.Equal(t, time.Date(2026, 3, 18, 15, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 3, 18, 16, 0, 0, 0, time.UTC), end)
+
+	start, end = UsageQuotaWindowBounds(UsageQuotaPeriodDaily, now)
+	require.Equal(t, time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 3, 19, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = UsageQuotaWindowBounds(UsageQuotaPeriodWeekly, now)
+	require.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = UsageQuotaWindowBounds(UsageQuotaPeriodMonthly, now)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// 非法周期按 daily 处理
+	start, _ = UsageQuotaWindowBounds("", now)
+	require.Equal(t, time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestCheckAPIKeyUsageQuota_RequestQuotaExceeded(t *testing.T) {
+	store := newUsageQuotaStoreStub()
+	svc := newUsageQuotaTestService(t, store)
+	apiKey := &APIKey{ID: 1, UsageQuotaRequests: 2, UsageQuotaPeriod: UsageQuotaPeriodDaily}
+	ctx := context.Background()
+
+	require.NoError(t, svc.checkAPIKeyUsageQuota(ctx, apiKey))
+	require.NoError(t, svc.checkAPIKeyUsageQuota(ctx, apiKey))
+
+	err := svc.checkAPIKeyUsageQuota(ctx, apiKey)
+	require.ErrorIs(t, err, ErrAPIKeyRequestQuotaExceeded)
+	quotaErr, ok := APIKeyUsageQuotaExceededFromError(err)
+	require.True(t, ok)
+	require.Equal(t, int64(2), quotaErr.RequestsUsed)
+	require.Equal(t, int64(0), quotaErr.RemainingRequests())
+	require.Equal(t, int64(-1), quotaErr.RemainingTokens())
+	_, end := UsageQuotaWindowBounds(UsageQuotaPeriodDaily, time.Now())
+	require.Equal(t, end, quotaErr.ResetAt)
+
+	// 被拒绝的请求不计入用量
+	svc.Stop()
+	state, _ := store.GetUsageQuotaState(ctx, 1)
+	require.Equal(t, int64(2), state.RequestsUsed)
+}
+
+func TestCheckAPIKeyUsageQuota_TokenQuotaExceededAfterRecord(t *testing.T) {
+	svc := newUsageQuotaTestService(t, newUsageQuotaStoreStub())
+	apiKey := &APIKey{ID: 2, UsageQuotaTokens: 1000, UsageQuotaPeriod: UsageQuotaPeriodHourly}
+	ctx := context.Background()
+
+	require.NoError(t, svc.checkAPIKeyUsageQuota(ctx, apiKey))
+	svc.RecordAPIKeyTokenUsage(ctx, apiKey, 1200)
+
+	err := svc.checkAPIKeyUsageQuota(ctx, apiKey)
+	require.True(t, errors.Is(err, ErrAPIKeyTokenQuotaExceeded))
+	quotaErr, ok := APIKeyUsageQuotaExceededFromError(err)
+	require.True(t, ok)
+	require.Equal(t, int64(1200), quotaErr.TokensUsed)
+	require.Equal(t, int64(0), quotaErr.RemainingTokens())
+	require.Equal(t, int64(-1), quotaErr.RemainingRequests())
+}
+
+func TestCheckAPIKeyUsageQuota_RestoresUsageFromStore(t *testing.T) {
+	store := newUsageQuotaStoreStub()
+	windowStart, _ := UsageQuotaWindowBounds(UsageQuotaPeriodDaily, time.Now())
+	require.NoError(t, store.IncrementUsageQuota(context.Background(), 3, windowStart, 5, 0))
+
+	// 新实例（模拟重启）从持久化存储恢复本周期用量
+	svc := newUsageQuotaTestService(t, store)
+	apiKey := &APIKey{ID: 3, UsageQuotaRequests: 5, UsageQuotaPeriod: UsageQuotaPeriodDaily}
+	require.ErrorIs(t, svc.checkAPIKeyUsageQuota(context.Background(), apiKey), ErrAPIKeyRequestQuotaExceeded)
+
+	// 管理员重置后重新加载
+	store.states[3] = &APIKeyUsageQuotaState{}
+	svc.InvalidateAPIKeyUsageQuota(3)
+	require.NoError(t, svc.checkAPIKeyUsageQuota(context.Background(), apiKey))
+}
+
+func TestCheckAPIKeyUsageQuota_StaleWindowIgnored(t *testing.T) {
+	store := newUsageQuotaStoreStub()
+	windowStart, _ := UsageQuotaWindowBounds(UsageQuotaPeriodDaily, time.Now())
+	require.NoError(t, store.IncrementUsageQuota(context.Background(), 4, windowStart.AddDate(0, 0, -1), 10, 0))
+
+	svc := newUsageQuotaTestService(t, store)
+	apiKey := &APIKey{ID: 4, UsageQuotaRequests: 5, UsageQuotaPeriod: UsageQuotaPeriodDaily}
+	require.NoError(t, svc.checkAPIKeyUsageQuota(context.Background(), apiKey))
+}
+
+func TestCheckAPIKeyUsageQuota_NoQuotaIsNoop(t *testing.T) {
+	store := newUsageQuotaStoreStub()
+	svc := newUsageQuotaTestService(t, store)
+	require.NoError(t, svc.checkAPIKeyUsageQuota(context.Background(), &APIKey{ID: 5}))
+	svc.Stop()
+	require.Empty(t, store.states)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// auditUsageWaitTimeout 请求结束后等待异步用量记录补齐 token/费用的最长时间，超时后按已有信息写出
+const auditUsageWaitTimeout = 30 * time.Second
+
+// AuditLog 网关请求审计记录
+type AuditLog struct {
+	ID                  int64     `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	RequestID           string    `json:"request_id"`
+	TraceID             string    `json:"trace_id"`
+	UserID              int64     `json:"user_id"`
+	APIKeyID            int64     `json:"api_key_id"`
+	AccountID           int64     `json:"account_id"`
+	Model               string    `json:"model"`
+	Platform            string    `json:"platform"`
+	Method              string    `json:"method"`
+	Path                string    `json:"path"`
+	StatusCode          int       `json:"status_code"`
+	DurationMs          int64     `json:"duration_ms"`
+	InputTokens         int       `json:"input_tokens"`
+	OutputTokens        int       `json:"output_tokens"`
+	CacheCreationTokens int       `json:"cache_creation_tokens"`
+	CacheReadTokens     int       `json:"cache_read_tokens"`
+	TotalCost           float64   `json:"total_cost"`
+	ActualCost          float64   `json:"actual_cost"`
+	ClientIP            string    `json:"client_ip"`
+	RequestBody         *string   `json:"request_body,omitempty"`
+	ResponseBody        *string   `json:"response_body,omitempty"`
+	BodyTruncated       bool      `json:"body_truncated"`
+	// RequestBodyRedacted 保存的请求正文有字段被脱敏，无法原样重放
+	RequestBodyRedacted bool `json:"request_body_redacted"`
+	// ReplayOfID 管理员重放产生的记录指向原始记录 ID
+	ReplayOfID int64 `json:"replay_of_id,omitempty"`
+}
+
+// AuditLogFilter 审计日志查询条件，时间范围为 [StartTime, EndTime)
+type AuditLogFilter struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+	UserID    *int64
+	APIKeyID  *int64
+	Model     string
+
+	Page     int
+	PageSize int
+}
+
+// AuditLogList 审计日志分页结果
+type AuditLogList struct {
+	Logs     []*AuditLog `json:"logs"`
+	Total    int         `json:"total"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+}
+
+// AuditLogSink 审计日志写入目标（数据库、JSON lines 文件等）
+type AuditLogSink interface {
+	WriteAuditLogs(ctx context.Context, logs []*AuditLog) error
+}
+
+// AuditLogRepository 审计日志持久化仓储，同时作为数据库写入目标
+type AuditLogRepository interface {
+	AuditLogSink
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter) (*AuditLogList, error)
+	GetAuditLog(ctx context.Context, id int64) (*AuditLog, error)
+}
+
+// AuditEntry 单个请求的审计记录。审计中间件在请求结束时调用 Finish，
+// 异步用量记录任务通过 HoldUsage/ReleaseUsage 声明仍需补齐 token/费用，两者都完成后才写出。
+type AuditEntry struct {
+	svc         *AuditService
+	captureBody bool
+
+	mu       sync.Mutex
+	log      AuditLog
+	pending  int
+	finished bool
+	written  bool
+}
+
+// WithAuditEntry 将审计记录放入 context
+func WithAuditEntry(ctx context.Context, entry *AuditEntry) context.Context {
+	if entry == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.AuditEntry, entry)
+}
+
+// AuditEntryFromContext 取出当前请求的审计记录，未开启审计时返回 nil
+func AuditEntryFromContext(ctx context.Context) *AuditEntry {
+	if ctx == nil {
+		return nil
+	}
+	entry, _ := ctx.Value(ctxkey.AuditEntry).(*AuditEntry)
+	return entry
+}
+
+// CaptureBody 是否记录请求/响应正文
+func (e *AuditEntry) CaptureBody() bool {
+	return e != nil && e.captureBody
+}
+
+// HoldUsage 声明有一个异步用量记录任务将补齐 token/费用
+func (e *AuditEntry) HoldUsage() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	e.pending++
+	e.mu.Unlock()
+}
+
+// ReleaseUsage 用量记录任务结束（无论成功与否）
+func (e *AuditEntry) ReleaseUsage() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	if e.pending > 0 {
+		e.pending--
+	}
+	ready := e.finished && e.pending == 0
+	e.mu.Unlock()
+	if ready {
+		e.write()
+	}
+}
+
+// SetUsage 累加计费用量（WebSocket 多轮请求会多次调用）
+func (e *AuditEntry) SetUsage(usageLog *UsageLog) {
+	if e == nil || usageLog == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.log.InputTokens += usageLog.InputTokens
+	e.log.OutputTokens += usageLog.OutputTokens
+	e.log.CacheCreationTokens += usageLog.CacheCreationTokens
+	e.log.CacheReadTokens += usageLog.CacheReadTokens
+	e.log.TotalCost += usageLog.TotalCost
+	e.log.ActualCost += usageLog.ActualCost
+	if e.log.Model == "" {
+		e.log.Model = usageLog.Model
+	}
+	if e.log.AccountID == 0 {
+		e.log.AccountID = usageLog.AccountID
+	}
+	if e.log.RequestID == "" {
+		e.log.RequestID = usageLog.RequestID
+	}
+}
+
+// Finish 请求结束时填充请求级字段；仍有用量任务未完成时最多等待 auditUsageWaitTimeout
+func (e *AuditEntry) Finish(fill func(log *AuditLog)) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	if fill != nil {
+		fill(&e.log)
+	}
+	e.finished = true
+	ready := e.pending == 0
+	e.mu.Unlock()
+	if ready {
+		e.write()
+		return
+	}
+	time.AfterFunc(auditUsageWaitTimeout, e.write)
+}
+
+func (e *AuditEntry) write() {
+	e.mu.Lock()
+	if e.written {
+		e.mu.Unlock()
+		return
+	}
+	e.written = true
+	log := e.log
+	e.mu.Unlock()
+	e.svc.enqueue(&log)
+}
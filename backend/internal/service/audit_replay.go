@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuditReplayUnavailable     = infraerrors.ServiceUnavailable("AUDIT_REPLAY_UNAVAILABLE", "audit replay is not available")
+	ErrAuditReplayUnsupported     = infraerrors.BadRequest("AUDIT_REPLAY_UNSUPPORTED", "only POST requests made with an API key can be replayed")
+	ErrAuditReplayBodyNotCaptured = infraerrors.BadRequest("AUDIT_REPLAY_BODY_NOT_CAPTURED", "request body was not captured for this audit log; enable audit_capture_body on the API key")
+	ErrAuditReplayBodyRedacted    = infraerrors.BadRequest("AUDIT_REPLAY_BODY_REDACTED", "stored request body has redacted fields and cannot be replayed")
+	ErrAuditReplayBodyIncomplete  = infraerrors.BadRequest("AUDIT_REPLAY_BODY_INCOMPLETE", "stored request body is truncated or not JSON and cannot be replayed")
+)
+
+// auditRedactedValue 脱敏后 JSON 字段的取值；迁移前的记录没有 request_body_redacted 标记，据此兜底判断
+const auditRedactedValue = `"***"`
+
+// AuditReplay 标记管理员重放的请求：API Key 认证中间件按 APIKeyID 加载 Key 并以沙盒方式执行（不扣费），
+// 审计中间件把新记录关联到原始记录
+type AuditReplay struct {
+	OriginalID int64
+	APIKeyID   int64
+}
+
+// WithAuditReplay 将重放标记放入 context
+func WithAuditReplay(ctx context.Context, replay *AuditReplay) context.Context {
+	if replay == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.AuditReplay, replay)
+}
+
+// AuditReplayFromContext 取出重放标记，非重放请求返回 nil
+func AuditReplayFromContext(ctx context.Context) *AuditReplay {
+	if ctx == nil {
+		return nil
+	}
+	replay, _ := ctx.Value(ctxkey.AuditReplay).(*AuditReplay)
+	return replay
+}
+
+// AuditReplayResult 原始请求与重放结果并列返回
+type AuditReplayResult struct {
+	Original *AuditLog          `json:"original"`
+	Replay   *AuditReplayOutput `json:"replay"`
+}
+
+// AuditReplayOutput 重放结果；响应正文按审计正文规则截断并脱敏。
+// 重放本身也会写入审计日志（replay_of_id 指向原始记录），可按 RequestID 查到 token 用量与费用。
+type AuditReplayOutput struct {
+	RequestID     string  `json:"request_id"`
+	StatusCode    int     `json:"status_code"`
+	DurationMs    int64   `json:"duration_ms"`
+	ResponseBody  *string `json:"response_body,omitempty"`
+	BodyTruncated bool    `json:"body_truncated"`
+}
+
+// SetReplayHandler 设置重放请求的入口，传入网关路由
+func (s *AuditService) SetReplayHandler(handler http.Handler) {
+	if s != nil {
+		s.replayHandler = handler
+	}
+}
+
+// ReplayAuditLog 按当前路由重新发出审计记录中保存的请求并返回新结果。
+// 重放使用原 API Key 的当前配置，但以沙盒方式执行：费用照常计算，不向客户扣费，也不计入消费汇总。
+// 原请求的请求头与查询参数未被记录，重放只携带保存的 JSON 正文。
+func (s *AuditService) ReplayAuditLog(ctx context.Context, id int64) (*AuditReplayResult, error) {
+	if s == nil || s.replayHandler == nil {
+		return nil, ErrAuditReplayUnavailable
+	}
+	original, err := s.GetAuditLog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := replayableRequestBody(original)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID := uuid.New().String()
+	replayCtx := WithAuditReplay(ctx, &AuditReplay{OriginalID: original.ID, APIKeyID: original.APIKeyID})
+	replayCtx = context.WithValue(replayCtx, ctxkey.ClientRequestID, requestID)
+	req, err := http.NewRequestWithContext(replayCtx, http.MethodPost, original.Path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// 多保留几个字节，由 CapturedBody 在完整字符边界截断
+	recorder := &auditReplayRecorder{header: http.Header{}, limit: s.maxBodyBytes + utf8.UTFMax}
+	startTime := time.Now()
+	s.replayHandler.ServeHTTP(recorder, req)
+
+	output := &AuditReplayOutput{
+		RequestID:  requestID,
+		StatusCode: recorder.statusCode(),
+		DurationMs: time.Since(startTime).Milliseconds(),
+	}
+	var truncated bool
+	output.ResponseBody, truncated = s.CapturedBody(recorder.body.Bytes())
+	output.BodyTruncated = truncated || recorder.truncated
+	return &AuditReplayResult{Original: original, Replay: output}, nil
+}
+
+// replayableRequestBody 校验保存的请求正文可以原样重放
+func replayableRequestBody(log *AuditLog) ([]byte, error) {
+	if log.Method != http.MethodPost || log.APIKeyID <= 0 {
+		return nil, ErrAuditReplayUnsupported
+	}
+	if log.RequestBody == nil || strings.TrimSpace(*log.RequestBody) == "" {
+		return nil, ErrAuditReplayBodyNotCaptured
+	}
+	body := []byte(*log.RequestBody)
+	if log.RequestBodyRedacted || bytes.Contains(body, []byte(auditRedactedValue)) {
+		return nil, ErrAuditReplayBodyRedacted
+	}
+	if !json.Valid(body) {
+		return nil, ErrAuditReplayBodyIncomplete
+	}
+	return body, nil
+}
+
+// auditReplayRecorder 收集重放响应，正文只保留前 limit 字节；流式响应在结束后整体返回
+type auditReplayRecorder struct {
+	header    http.Header
+	status    int
+	limit     int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (r *auditReplayRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *auditReplayRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+}
+
+func (r *auditReplayRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	remaining := r.limit - r.body.Len()
+	if len(b) > remaining {
+		r.truncated = true
+		if remaining > 0 {
+			r.body.Write(b[:remaining])
+		}
+		return len(b), nil
+	}
+	r.body.Write(b)
+	return len(b), nil
+}
+
+func (r *auditReplayRecorder) Flush() {}
+
+func (r *auditReplayRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
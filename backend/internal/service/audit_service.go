@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/util/logredact"
+)
+
+// ErrAuditQueryUnavailable 未配置数据库写入目标时无法查询审计日志
+var ErrAuditQueryUnavailable = errors.New("audit log query unavailable")
+
+// ErrAuditLogNotFound 审计日志不存在
+var ErrAuditLogNotFound = infraerrors.NotFound("AUDIT_LOG_NOT_FOUND", "audit log not found")
+
+const (
+	auditBatchSize     = 200
+	auditFlushInterval = time.Second
+	auditSinkTimeout   = 10 * time.Second
+)
+
+// auditRedactKeys 正文中除 logredact 默认字段外额外脱敏的字段
+var auditRedactKeys = []string{"api_key", "apikey", "x-api-key", "authorization", "secret", "token"}
+
+// AuditService 异步批量写出网关请求审计日志，并提供查询
+type AuditService struct {
+	repo         AuditLogRepository
+	sinks        []AuditLogSink
+	fileSink     *AuditFileSink
+	enabled      bool
+	maxBodyBytes int
+
+	// replayHandler 重放请求的入口（网关路由），路由初始化后注入
+	replayHandler http.Handler
+
+	queue  chan *AuditLog
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	droppedCount uint64
+	writeFailed  uint64
+}
+
+// NewAuditService 创建审计日志服务。audit.enabled 关闭时不记录，但仍可查询历史数据。
+func NewAuditService(cfg *config.Config, repo AuditLogRepository) *AuditService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &AuditService{
+		repo:   repo,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if cfg == nil || !cfg.Audit.Enabled {
+		return s
+	}
+
+	if cfg.Audit.DBEnabled && repo != nil {
+		s.sinks = append(s.sinks, repo)
+	}
+	if cfg.Audit.FilePath != "" {
+		fileSink, err := NewAuditFileSink(cfg.Audit.FilePath)
+		if err != nil {
+			logger.LegacyPrintf("service.audit", "Warning: open audit log file %s failed: %v", cfg.Audit.FilePath, err)
+		} else {
+			s.fileSink = fileSink
+			s.sinks = append(s.sinks, fileSink)
+		}
+	}
+	queueSize := cfg.Audit.QueueSize
+	if queueSize <= 0 {
+		queueSize = 10000
+	}
+	s.queue = make(chan *AuditLog, queueSize)
+	s.maxBodyBytes = cfg.Audit.MaxBodyBytes
+	s.enabled = len(s.sinks) > 0
+	return s
+}
+
+// Enabled 是否记录审计日志
+func (s *AuditService) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// Begin 为一个请求创建审计记录；未开启审计时返回 nil（AuditEntry 的方法均为 nil-safe）
+func (s *AuditService) Begin(createdAt time.Time, captureBody bool) *AuditEntry {
+	if !s.Enabled() {
+		return nil
+	}
+	return &AuditEntry{
+		svc:         s,
+		captureBody: captureBody && s.maxBodyBytes > 0,
+		log:         AuditLog{CreatedAt: createdAt.UTC()},
+	}
+}
+
+// CapturedBody 脱敏并截断正文；返回 nil 表示无内容
+func (s *AuditService) CapturedBody(raw []byte) (*string, bool) {
+	if s == nil || len(raw) == 0 {
+		return nil, false
+	}
+	truncated := false
+	if s.maxBodyBytes > 0 && len(raw) > s.maxBodyBytes {
+		raw = raw[:s.maxBodyBytes]
+		// 避免在多字节字符中间截断
+		for len(raw) > 0 && !utf8.Valid(raw) {
+			raw = raw[:len(raw)-1]
+		}
+		truncated = true
+	}
+	body := logredact.RedactText(string(raw), auditRedactKeys...)
+	return &body, truncated
+}
+
+// CapturedRequestBody 同 CapturedBody，并返回是否有字段被脱敏（被脱敏的请求无法原样重放）
+func (s *AuditService) CapturedRequestBody(raw []byte) (body *string, truncated, redacted bool) {
+	body, truncated = s.CapturedBody(raw)
+	if body == nil || truncated || !json.Valid(raw) {
+		return body, truncated, false
+	}
+	// 脱敏会重新序列化 JSON，与未脱敏的重新序列化结果比较
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return body, truncated, false
+	}
+	normalized, err := json.Marshal(value)
+	return body, truncated, err != nil || string(normalized) != *body
+}
+
+// MaxBodyBytes 单条正文保存上限
+func (s *AuditService) MaxBodyBytes() int {
+	if s == nil {
+		return 0
+	}
+	return s.maxBodyBytes
+}
+
+// ListAuditLogs 分页查询审计日志
+func (s *AuditService) ListAuditLogs(ctx context.Context, filter *AuditLogFilter) (*AuditLogList, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrAuditQueryUnavailable
+	}
+	return s.repo.ListAuditLogs(ctx, filter)
+}
+
+// GetAuditLog 查询单条审计日志
+func (s *AuditService) GetAuditLog(ctx context.Context, id int64) (*AuditLog, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrAuditQueryUnavailable
+	}
+	return s.repo.GetAuditLog(ctx, id)
+}
+
+// Start 启动后台写入
+func (s *AuditService) Start() {
+	if !s.Enabled() {
+		return
+	}
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop 停止后台写入并刷出队列中的记录
+func (s *AuditService) Stop() {
+	if s == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	if s.fileSink != nil {
+		if err := s.fileSink.Close(); err != nil {
+			logger.LegacyPrintf("service.audit", "Warning: close audit log file failed: %v", err)
+		}
+	}
+}
+
+func (s *AuditService) enqueue(log *AuditLog) {
+	if !s.Enabled() || log == nil {
+		return
+	}
+	select {
+	case <-s.ctx.Done():
+		return
+	default:
+	}
+	select {
+	case s.queue <- log:
+	default:
+		if n := atomic.AddUint64(&s.droppedCount, 1); n == 1 || n%1000 == 0 {
+			logger.LegacyPrintf("service.audit", "Warning: audit log queue full, dropped=%d", n)
+		}
+	}
+}
+
+func (s *AuditService) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*AuditLog, 0, auditBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.flush(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			for {
+				select {
+				case item := <-s.queue:
+					batch = append(batch, item)
+					if len(batch) >= auditBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case item := <-s.queue:
+			batch = append(batch, item)
+			if len(batch) >= auditBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *AuditService) flush(batch []*AuditLog) {
+	for _, sink := range s.sinks {
+		ctx, cancel := context.WithTimeout(context.Background(), auditSinkTimeout)
+		err := sink.WriteAuditLogs(ctx, batch)
+		cancel()
+		if err != nil {
+			n := atomic.AddUint64(&s.writeFailed, uint64(len(batch)))
+			logger.LegacyPrintf("service.audit", "Warning: write %d audit logs to %T failed (total_failed=%d): %v", len(batch), sink, n, err)
+		}
+	}
+}
+
+// AuditFileSink 以 JSON lines 追加写入审计日志文件
+type AuditFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewAuditFileSink 打开（必要时创建）审计日志文件
+func NewAuditFileSink(path string) (*AuditFileSink, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create audit log dir: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditFileSink{file: file}, nil
+}
+
+// WriteAuditLogs 写入一批审计日志，每条一行 JSON
+func (f *AuditFileSink) WriteAuditLogs(_ context.Context, logs []*AuditLog) error {
+	buf := make([]byte, 0, 512*len(logs))
+	for _, log := range logs {
+		line, err := json.Marshal(log)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err := f.file.Write(buf)
+	return err
+}
+
+// Close 关闭文件
+func (f *AuditFileSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
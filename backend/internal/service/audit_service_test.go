@@ -0,0 +1,308 @@
+//go:build unit
+
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+type auditSinkStub struct {
+	mu   sync.Mutex
+	logs []*AuditLog
+}
+
+func (s *auditSinkStub) WriteAuditLogs(_ context.Context, logs []*AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, logs...)
+	return nil
+}
+
+func (s *auditSinkStub) ListAuditLogs(_ context.Context, _ *AuditLogFilter) (*AuditLogList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &AuditLogList{Logs: s.logs, Total: len(s.logs), Page: 1, PageSize: 50}, nil
+}
+
+func (s *auditSinkStub) GetAuditLog(_ context.Context, id int64) (*AuditLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, log := range s.logs {
+		if log.ID == id {
+			return log, nil
+		}
+	}
+	return nil, ErrAuditLogNotFound
+}
+
+func newTestAuditService(t *testing.T, repo AuditLogRepository, maxBodyBytes int) *AuditService {
+	t.Helper()
+	cfg := &config.Config{Audit: config.AuditConfig{
+		Enabled:      true,
+		DBEnabled:    true,
+		MaxBodyBytes: maxBodyBytes,
+		QueueSize:    16,
+	}}
+	return NewAuditService(cfg, repo)
+}
+
+func drainAuditQueue(s *AuditService) []*AuditLog {
+	var out []*AuditLog
+	for {
+		select {
+		case log := <-s.queue:
+			out = append(out, log)
+		default:
+			return out
+		}
+	}
+}
+
+func TestAuditService_DisabledReturnsNilEntry(t *testing.T) {
+	svc := NewAuditService(&config.Config{}, &auditSinkStub{})
+	require.False(t, svc.Enabled())
+
+	entry := svc.Begin(time.Now(), true)
+	require.Nil(t, entry)
+	require.False(t, entry.CaptureBody())
+	entry.HoldUsage()
+	entry.SetUsage(&UsageLog{InputTokens: 1})
+	entry.ReleaseUsage()
+	entry.Finish(nil)
+
+	// 关闭记录时历史数据仍可查询
+	_, err := svc.ListAuditLogs(context.Background(), &AuditLogFilter{})
+	require.NoError(t, err)
+}
+
+func TestAuditService_ListWithoutRepo(t *testing.T) {
+	svc := NewAuditService(&config.Config{}, nil)
+	_, err := svc.ListAuditLogs(context.Background(), &AuditLogFilter{})
+	require.ErrorIs(t, err, ErrAuditQueryUnavailable)
+}
+
+func TestAuditEntry_WaitsForUsageBeforeWrite(t *testing.T) {
+	svc := newTestAuditService(t, &auditSinkStub{}, 1024)
+	entry := svc.Begin(time.Now(), false)
+	require.NotNil(t, entry)
+
+	entry.HoldUsage()
+	entry.Finish(func(log *AuditLog) {
+		log.Method = "POST"
+		log.Path = "/v1/messages"
+		log.StatusCode = 200
+		log.Model = "claude-sonnet-4"
+	})
+	require.Empty(t, drainAuditQueue(svc), "must wait for pending usage")
+
+	entry.SetUsage(&UsageLog{
+		RequestID:    "req-1",
+		Model:        "ignored-model",
+		AccountID:    7,
+		InputTokens:  10,
+		OutputTokens: 20,
+		TotalCost:    0.5,
+		ActualCost:   0.4,
+	})
+	entry.ReleaseUsage()
+
+	logs := drainAuditQueue(svc)
+	require.Len(t, logs, 1)
+	log := logs[0]
+	require.Equal(t, "POST", log.Method)
+	require.Equal(t, "claude-sonnet-4", log.Model)
+	require.Equal(t, "req-1", log.RequestID)
+	require.Equal(t, int64(7), log.AccountID)
+	require.Equal(t, 10, log.InputTokens)
+	require.Equal(t, 20, log.OutputTokens)
+	require.InDelta(t, 0.5, log.TotalCost, 1e-9)
+	require.InDelta(t, 0.4, log.ActualCost, 1e-9)
+
+	// 重复释放不会重复写出
+	entry.ReleaseUsage()
+	require.Empty(t, drainAuditQueue(svc))
+}
+
+func TestAuditEntry_UsageAccumulates(t *testing.T) {
+	svc := newTestAuditService(t, &auditSinkStub{}, 1024)
+	entry := svc.Begin(time.Now(), false)
+
+	entry.HoldUsage()
+	entry.HoldUsage()
+	entry.SetUsage(&UsageLog{InputTokens: 3, OutputTokens: 4, TotalCost: 1})
+	entry.ReleaseUsage()
+	entry.SetUsage(&UsageLog{InputTokens: 5, OutputTokens: 6, TotalCost: 2})
+	entry.ReleaseUsage()
+	require.Empty(t, drainAuditQueue(svc), "must wait for request finish")
+
+	entry.Finish(nil)
+	logs := drainAuditQueue(svc)
+	require.Len(t, logs, 1)
+	require.Equal(t, 8, logs[0].InputTokens)
+	require.Equal(t, 10, logs[0].OutputTokens)
+	require.InDelta(t, 3.0, logs[0].TotalCost, 1e-9)
+}
+
+func TestAuditService_CapturedBody(t *testing.T) {
+	svc := newTestAuditService(t, &auditSinkStub{}, 64)
+
+	body, truncated := svc.CapturedBody(nil)
+	require.Nil(t, body)
+	require.False(t, truncated)
+
+	body, truncated = svc.CapturedBody([]byte(`{"api_key":"sk-secret-value","model":"gpt-4o"}`))
+	require.NotNil(t, body)
+	require.False(t, truncated)
+	require.NotContains(t, *body, "sk-secret-value")
+	require.Contains(t, *body, "gpt-4o")
+
+	body, truncated = svc.CapturedBody([]byte(strings.Repeat("中", 30)))
+	require.NotNil(t, body)
+	require.True(t, truncated)
+	require.LessOrEqual(t, len(*body), 64)
+	require.Equal(t, strings.Repeat("中", 21), *body)
+}
+
+func TestAuditService_CaptureBodyRequiresLimit(t *testing.T) {
+	svc := newTestAuditService(t, &auditSinkStub{}, 0)
+	require.False(t, svc.Begin(time.Now(), true).CaptureBody())
+
+	svc = newTestAuditService(t, &auditSinkStub{}, 128)
+	require.True(t, svc.Begin(time.Now(), true).CaptureBody())
+	require.False(t, svc.Begin(time.Now(), false).CaptureBody())
+}
+
+func TestAuditService_StopFlushesQueue(t *testing.T) {
+	sink := &auditSinkStub{}
+	svc := newTestAuditService(t, sink, 1024)
+	svc.Start()
+
+	for i := 0; i < 3; i++ {
+		svc.Begin(time.Now(), false).Finish(func(log *AuditLog) { log.Path = "/v1/messages" })
+	}
+	svc.Stop()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.logs, 3)
+}
+
+func TestAuditFileSink_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	sink, err := NewAuditFileSink(path)
+	require.NoError(t, err)
+
+	body := "hello"
+	require.NoError(t, sink.WriteAuditLogs(context.Background(), []*AuditLog{
+		{Method: "POST", Path: "/v1/messages", StatusCode: 200, RequestBody: &body},
+		{Method: "POST", Path: "/v1/chat/completions", StatusCode: 429},
+	}))
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &m))
+		lines = append(lines, m)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 2)
+	require.Equal(t, "hello", lines[0]["request_body"])
+	_, hasBody := lines[1]["request_body"]
+	require.False(t, hasBody)
+	require.EqualValues(t, 429, lines[1]["status_code"])
+}
+
+func TestAuditService_CapturedRequestBodyReportsRedaction(t *testing.T) {
+	svc := newTestAuditService(t, &auditSinkStub{}, 256)
+
+	body, truncated, redacted := svc.CapturedRequestBody([]byte(`{"model":"gpt-4o", "messages":[]}`))
+	require.NotNil(t, body)
+	require.False(t, truncated)
+	require.False(t, redacted)
+
+	body, _, redacted = svc.CapturedRequestBody([]byte(`{"model":"gpt-4o","metadata":{"password":"hunter2"}}`))
+	require.True(t, redacted)
+	require.NotContains(t, *body, "hunter2")
+}
+
+func TestAuditService_ReplayAuditLog(t *testing.T) {
+	requestBody := `{"messages":[],"model":"claude-sonnet-4"}`
+	repo := &auditSinkStub{logs: []*AuditLog{{ID: 7, APIKeyID: 3, Method: http.MethodPost, Path: "/v1/messages", StatusCode: 529, RequestBody: &requestBody}}}
+	svc := newTestAuditService(t, repo, 1024)
+
+	_, err := svc.ReplayAuditLog(context.Background(), 7)
+	require.ErrorIs(t, err, ErrAuditReplayUnavailable)
+
+	var seen *http.Request
+	var seenBody string
+	svc.SetReplayHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+		raw, _ := io.ReadAll(r.Body)
+		seenBody = string(raw)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"msg_1","access_token":"secret"}`))
+	}))
+
+	result, err := svc.ReplayAuditLog(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/messages", seen.URL.Path)
+	require.Equal(t, requestBody, seenBody)
+	replay := AuditReplayFromContext(seen.Context())
+	require.Equal(t, &AuditReplay{OriginalID: 7, APIKeyID: 3}, replay)
+	require.Equal(t, result.Replay.RequestID, seen.Context().Value(ctxkey.ClientRequestID))
+
+	require.Equal(t, 529, result.Original.StatusCode)
+	require.Equal(t, http.StatusOK, result.Replay.StatusCode)
+	require.NotNil(t, result.Replay.ResponseBody)
+	require.Contains(t, *result.Replay.ResponseBody, "msg_1")
+	require.NotContains(t, *result.Replay.ResponseBody, "secret")
+
+	_, err = svc.ReplayAuditLog(context.Background(), 99)
+	require.ErrorIs(t, err, ErrAuditLogNotFound)
+}
+
+func TestAuditService_ReplayRejectsUnreplayableBodies(t *testing.T) {
+	redactedBody := `{"model":"gpt-4o","password":"***"}`
+	truncatedBody := `{"model":"gpt-4o","messages":[{"ro`
+	repo := &auditSinkStub{logs: []*AuditLog{
+		{ID: 1, APIKeyID: 3, Method: http.MethodPost, Path: "/v1/messages"},
+		{ID: 2, APIKeyID: 3, Method: http.MethodPost, Path: "/v1/messages", RequestBody: &redactedBody},
+		{ID: 3, APIKeyID: 3, Method: http.MethodPost, Path: "/v1/messages", RequestBody: &truncatedBody, BodyTruncated: true},
+		{ID: 4, APIKeyID: 3, Method: http.MethodGet, Path: "/v1/models"},
+	}}
+	svc := newTestAuditService(t, repo, 1024)
+	svc.SetReplayHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("unreplayable request must not be issued")
+	}))
+
+	for id, want := range map[int64]error{
+		1: ErrAuditReplayBodyNotCaptured,
+		2: ErrAuditReplayBodyRedacted,
+		3: ErrAuditReplayBodyIncomplete,
+		4: ErrAuditReplayUnsupported,
+	} {
+		_, err := svc.ReplayAuditLog(context.Background(), id)
+		require.ErrorIs(t, err, want, "audit log %d", id)
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+// defaultPricingIORatio 未配置时的默认输入/输出 token 比例（3 个输入 token 对应 1 个输出 token）
+const defaultPricingIORatio = 3.0
+
+// DefaultIORatio 计算综合价格时使用的默认输入/输出 token 比例
+func (s *BillingService) DefaultIORatio() float64 {
+	if s.cfg != nil && s.cfg.Pricing.DefaultIORatio > 0 {
+		return s.cfg.Pricing.DefaultIORatio
+	}
+	return defaultPricingIORatio
+}
+
+// BlendedCost 按输入/输出 token 比例计算综合单价：
+// (ratio * input + output) / (ratio + 1)，ratio 非正时退化为输入、输出价格的平均值
+func BlendedCost(inputCost, outputCost, ioRatio float64) float64 {
+	if ioRatio <= 0 {
+		ioRatio = 1
+	}
+	return (ioRatio*inputCost + outputCost) / (ioRatio + 1)
+}
@@ -44,6 +44,7 @@ const (
 	cacheWriteUpdateSubscriptionUsage
 	cacheWriteDeductBalance
 	cacheWriteUpdateRateLimitUsage
+	cacheWriteIncrementUsageQuota
 )
 
 // 异步缓存写入工作池配置
@@ -76,6 +77,10 @@ type cacheWriteTask struct {
 	balance          float64
 	amount           float64
 	subscriptionData *subscriptionCacheData
+	// API Key 周期额度用量（cacheWriteIncrementUsageQuota）
+	quotaWindowStart time.Time
+	quotaRequests    int64
+	quotaTokens      int64
 }
 
 // apiKeyRateLimitLoader defines the interface for loading rate limit data from DB.
@@ -94,6 +99,13 @@ type BillingCacheService struct {
 	userGroupRateRepo     UserGroupRateRepository
 	cfg                   *config.Config
 	circuitBreaker        *billingCircuitBreaker
+	tokenBuckets          TokenBucketStore // API Key RPM/TPM 令牌桶状态
+	usageQuotaStore       apiKeyUsageQuotaStore
+	usageQuotaCounters    sync.Map // apiKeyID -> *apiKeyUsageQuotaCounter
+	spendRepo             UserSpendRepository
+	budgetSpendCache      sync.Map // userID -> *userBudgetSpend
+	budgetReservations    sync.Map // userID -> *userBudgetReservations
+	costEstimator         streamCostEstimator
 
 	cacheWriteChan     chan cacheWriteTask
 	cacheWriteWg       sync.WaitGroup
@@ -126,6 +138,10 @@ func NewBillingCacheService(
 		userRPMCache:          userRPMCache,
 		userGroupRateRepo:     userGroupRateRepo,
 		cfg:                   cfg,
+		tokenBuckets:          NewMemoryTokenBucketStore(),
+	}
+	if store, ok := apiKeyRepo.(apiKeyUsageQuotaStore); ok {
+		svc.usageQuotaStore = store
 	}
 	svc.circuitBreaker = newBillingCircuitBreaker(cfg.Billing.CircuitBreaker)
 	svc.startCacheWriteWorkers()
@@ -218,6 +234,8 @@ func (s *BillingCacheService) cacheWriteWorker(ch <-chan cacheWriteTask) {
 					logger.LegacyPrintf("service.billing_cache", "Warning: update rate limit usage cache failed for api key %d: %v", task.apiKeyID, err)
 				}
 			}
+		case cacheWriteIncrementUsageQuota:
+			s.writeUsageQuota(ctx, task)
 		}
 		cancel()
 	}
@@ -236,6 +254,8 @@ func cacheWriteKindName(kind cacheWriteKind) string {
 		return "deduct_balance"
 	case cacheWriteUpdateRateLimitUsage:
 		return "update_rate_limit_usage"
+	case cacheWriteIncrementUsageQuota:
+		return "increment_usage_quota"
 	default:
 		return "unknown"
 	}
@@ -671,15 +691,23 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 		return ErrBillingServiceUnavailable
 	}
 
-	// 判断计费模式
-	isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
+	// 沙盒 Key 不产生实际扣费：跳过余额/订阅额度与月度预算检查，限流仍然生效
+	if apiKey == nil || !apiKey.IsSandbox {
+		// 判断计费模式
+		isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
 
-	if isSubscriptionMode {
-		if err := s.checkSubscriptionEligibility(ctx, user.ID, group, subscription); err != nil {
-			return err
+		if isSubscriptionMode {
+			if err := s.checkSubscriptionEligibility(ctx, user.ID, group, subscription); err != nil {
+				return err
+			}
+		} else {
+			if err := s.checkBalanceEligibility(ctx, user.ID); err != nil {
+				return err
+			}
 		}
-	} else {
-		if err := s.checkBalanceEligibility(ctx, user.ID); err != nil {
+
+		// 用户月度预算（两种计费模式均生效）
+		if err := s.checkUserBudget(ctx, user); err != nil {
 			return err
 		}
 	}
@@ -696,7 +724,13 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 		return err
 	}
 
-	return nil
+	// API Key 级 RPM/TPM 令牌桶（Key 配置优先，未配置回落到全局默认值）
+	if err := s.checkAPIKeyThroughput(ctx, apiKey); err != nil {
+		return err
+	}
+
+	// API Key 周期额度（请求数/token 数），放行时计入一次请求，因此放在所有检查之后
+	return s.checkAPIKeyUsageQuota(ctx, apiKey)
 }
 
 // checkRPM 执行并行 RPM 限流，所有适用的限制同时生效，任一超限即拒绝：
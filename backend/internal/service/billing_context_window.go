@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// contextWindowPromptFields 各协议（Anthropic / Chat Completions / Responses / Gemini）请求体中计入输入 token 的顶层字段
+var contextWindowPromptFields = []string{
+	"system", "instructions", "systemInstruction", "system_instruction",
+	"messages", "input", "prompt", "contents", "tools",
+}
+
+// contextWindowSkipKeys 不计入估算的字段：图片/文件等二进制内容、签名与结构性标识
+var contextWindowSkipKeys = map[string]bool{
+	"data": true, "url": true, "image_url": true, "file_data": true,
+	"inlineData": true, "inline_data": true, "signature": true, "encrypted_content": true,
+	"type": true, "role": true, "id": true, "tool_call_id": true, "tool_use_id": true,
+	"cache_control": true, "media_type": true, "mimeType": true,
+}
+
+// ContextWindowExceededError 估算输入 token 超出模型上下文窗口
+type ContextWindowExceededError struct {
+	Model            string
+	EstimatedTokens  int
+	MaxContextTokens int
+}
+
+func (e *ContextWindowExceededError) Error() string {
+	return fmt.Sprintf("Estimated input of %d tokens exceeds the maximum context length of %d tokens for model %s",
+		e.EstimatedTokens, e.MaxContextTokens, e.Model)
+}
+
+// ContextWindowCheckEnabled 是否启用转发前的上下文窗口检查
+func (s *BillingService) ContextWindowCheckEnabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Gateway.ContextWindowCheck.Enabled
+}
+
+// CheckContextWindow 估算请求体的输入 token，超过模型 max_context_tokens*(1+tolerance) 时返回错误。
+// 未启用、模型无价格数据或未声明 max_context_tokens 时不检查。
+func (s *BillingService) CheckContextWindow(model string, body []byte) *ContextWindowExceededError {
+	if !s.ContextWindowCheckEnabled() || model == "" || len(body) == 0 {
+		return nil
+	}
+	pricing, err := s.GetModelPricing(model)
+	if err != nil || pricing == nil || pricing.Capabilities.MaxContextTokens == nil {
+		return nil
+	}
+	maxTokens := *pricing.Capabilities.MaxContextTokens
+	limit := float64(maxTokens) * (1 + s.cfg.Gateway.ContextWindowCheck.Tolerance)
+	// 每个 token 至少占 1 字节（Claude 估算另有 claudeTokenRatio 放大），请求体足够小时无需分词
+	if float64(len(body))*claudeTokenRatio <= limit {
+		return nil
+	}
+	estimated, _ := s.CountTokens(model, requestPromptText(body))
+	if float64(estimated) <= limit {
+		return nil
+	}
+	return &ContextWindowExceededError{Model: model, EstimatedTokens: estimated, MaxContextTokens: maxTokens}
+}
+
+// requestPromptText 提取请求体中计入输入 token 的文本（跳过图片等二进制内容）
+func requestPromptText(body []byte) string {
+	var b strings.Builder
+	var walk func(v gjson.Result)
+	walk = func(v gjson.Result) {
+		switch {
+		case v.Type == gjson.String:
+			if s := v.String(); s != "" {
+				if b.Len() > 0 {
+					b.WriteByte('\n')
+				}
+				b.WriteString(s)
+			}
+		case v.IsObject():
+			v.ForEach(func(key, value gjson.Result) bool {
+				if !contextWindowSkipKeys[key.String()] {
+					walk(value)
+				}
+				return true
+			})
+		case v.IsArray():
+			v.ForEach(func(_, value gjson.Result) bool {
+				walk(value)
+				return true
+			})
+		}
+	}
+	for _, field := range contextWindowPromptFields {
+		walk(gjson.GetBytes(body, field))
+	}
+	return b.String()
+}
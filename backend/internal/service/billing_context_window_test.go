@@ -0,0 +1,69 @@
+//go:build unit
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newContextWindowTestBillingService(enabled bool) *BillingService {
+	maxContext := 50
+	cfg := &config.Config{}
+	cfg.Gateway.ContextWindowCheck.Enabled = enabled
+	cfg.Gateway.ContextWindowCheck.Tolerance = 0.1
+	return NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"small-model": {InputCostPerToken: 1e-6, ModelCapabilities: ModelCapabilities{MaxContextTokens: &maxContext}},
+		"gpt-4o":      {InputCostPerToken: 1e-6},
+	}})
+}
+
+func TestCheckContextWindow(t *testing.T) {
+	svc := newContextWindowTestBillingService(true)
+	// 启发式估算：英文约 4 字符 / token，400 字符约 100 token
+	long := []byte(`{"model":"small-model","messages":[{"role":"user","content":"` + strings.Repeat("word ", 80) + `"}]}`)
+
+	exceeded := svc.CheckContextWindow("small-model", long)
+	require.NotNil(t, exceeded)
+	require.Equal(t, "small-model", exceeded.Model)
+	require.Equal(t, 50, exceeded.MaxContextTokens)
+	require.Equal(t, 100, exceeded.EstimatedTokens)
+	require.Contains(t, exceeded.Error(), "100 tokens")
+	require.Contains(t, exceeded.Error(), "50 tokens")
+
+	// 容差内（50*1.1=55）放行
+	within := []byte(`{"model":"small-model","messages":[{"role":"user","content":"` + strings.Repeat("word ", 42) + `"}]}`)
+	require.Nil(t, svc.CheckContextWindow("small-model", within))
+
+	// 未声明 max_context_tokens 的模型与未知模型不检查
+	require.Nil(t, svc.CheckContextWindow("gpt-4o", long))
+	require.Nil(t, svc.CheckContextWindow("unknown-model-xyz", long))
+
+	// 未启用时不检查
+	require.Nil(t, newContextWindowTestBillingService(false).CheckContextWindow("small-model", long))
+}
+
+func TestCheckContextWindow_IgnoresBinaryContent(t *testing.T) {
+	svc := newContextWindowTestBillingService(true)
+	body := []byte(`{"model":"small-model","messages":[{"role":"user","content":[` +
+		`{"type":"text","text":"describe this"},` +
+		`{"type":"image","source":{"type":"base64","media_type":"image/png","data":"` + strings.Repeat("QUJD", 500) + `"}}]}]}`)
+	require.Nil(t, svc.CheckContextWindow("small-model", body))
+}
+
+func TestRequestPromptText(t *testing.T) {
+	// Anthropic：system + messages 内容块
+	text := requestPromptText([]byte(`{"system":[{"type":"text","text":"be brief"}],"messages":[{"role":"user","content":[{"type":"text","text":"hi"},{"type":"tool_result","tool_use_id":"t1","content":"42"}]}]}`))
+	require.Equal(t, "be brief\nhi\n42", text)
+
+	// Responses：instructions + input
+	text = requestPromptText([]byte(`{"instructions":"sys","input":[{"role":"user","content":[{"type":"input_text","text":"q"},{"type":"input_image","image_url":"data:image/png;base64,AAAA"}]}]}`))
+	require.Equal(t, "sys\nq", text)
+
+	// Gemini：systemInstruction + contents
+	text = requestPromptText([]byte(`{"systemInstruction":{"parts":[{"text":"sys"}]},"contents":[{"role":"user","parts":[{"text":"q"},{"inlineData":{"mimeType":"image/png","data":"AAAA"}}]}]}`))
+	require.Equal(t, "sys\nq", text)
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// CostEstimateInput 假设请求的 token 用量（用于费用预估）
+type CostEstimateInput struct {
+	Model               string
+	InputTokens         int
+	OutputTokens        int
+	CacheReadTokens     int
+	CacheCreationTokens int
+	Batch               bool // 按 batch API 折扣价预估
+	Images              int  // 生成图片数量：按每张图片价格计费，输出 token 不再计入
+	// 缓存命中率 / 缓存创建占比（0~1，之和不超过 1）：设置任一项时 InputTokens 视为全部输入 token，
+	// 按比例拆分为缓存读取、缓存创建与普通输入，不能与 CacheReadTokens / CacheCreationTokens 同时使用
+	CacheHitRatio      *float64
+	CacheCreationRatio *float64
+}
+
+// CostEstimateBreakdown 预估费用各组成部分（USD）
+type CostEstimateBreakdown struct {
+	InputCost         float64 `json:"input_cost"`
+	OutputCost        float64 `json:"output_cost"`
+	CacheReadCost     float64 `json:"cache_read_cost"`
+	CacheCreationCost float64 `json:"cache_creation_cost"`
+	ImageCost         float64 `json:"image_cost"`
+	MinChargeTopUp    float64 `json:"min_charge_top_up"` // 补足最低收费的差额（已含加价），各项之和等于 TotalCost
+}
+
+// CostEstimate 费用预估结果
+type CostEstimate struct {
+	Model     string           `json:"model"`
+	MatchType PricingMatchType `json:"match_type"`
+	IsFree    bool             `json:"is_free"` // 显式标记的免费模型（费用为 0 并非缺少价格）
+	Batch     bool             `json:"batch"`
+	Images    int              `json:"images,omitempty"`
+	// 生效的 batch 倍率（仅 batch 预估时返回）
+	BatchMultiplier float64               `json:"batch_multiplier,omitempty"`
+	Breakdown       CostEstimateBreakdown `json:"breakdown"`
+	TotalCost       float64               `json:"total_cost"` // 向用户计费的费用（已含加价）
+	BaseCost        float64               `json:"base_cost"`  // 上游原始费用（已扣除承诺用量折扣，未加价）
+	// 计费链路各阶段：标价 → 承诺用量折扣 → 最低收费 → 加价
+	Stages   CostEstimateStages `json:"stages"`
+	Warnings []string           `json:"warnings"`
+	// 按缓存命中率拆分输入 token 时的混合输入费用（仅设置 cache_hit_ratio / cache_creation_ratio 时返回）
+	CacheMix *CostEstimateCacheMix `json:"cache_mix,omitempty"`
+	// 费用已按该有效数字位数舍入展示（0 表示完整精度）
+	DisplaySignificantDigits int `json:"display_significant_digits,omitempty"`
+}
+
+// CostEstimateCacheMix 输入 token 在普通输入、缓存读取与缓存创建之间的拆分，以及加权后的输入费用
+type CostEstimateCacheMix struct {
+	TotalInputTokens    int     `json:"total_input_tokens"`
+	CacheHitRatio       float64 `json:"cache_hit_ratio"`
+	CacheCreationRatio  float64 `json:"cache_creation_ratio"`
+	InputTokens         int     `json:"input_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	// 输入 + 缓存读取 + 缓存创建费用（已含加价）及折算的每百万输入 token 价格
+	BlendedInputCost        float64 `json:"blended_input_cost"`
+	BlendedInputCostPerMTok float64 `json:"blended_input_cost_per_mtok"`
+}
+
+// CostEstimateStages 预估费用的计费链路明细，便于核对：
+// ListCost - CommittedDiscountAmount = DiscountedCost；DiscountedCost + MinChargeTopUp + MarkupAmount = TotalCost
+type CostEstimateStages struct {
+	ListCost                 float64 `json:"list_cost"` // 上游标价费用（batch 预估时已按 batch 倍率折扣）
+	CommittedDiscountPercent float64 `json:"committed_discount_percent"`
+	CommittedDiscountSource  string  `json:"committed_discount_source"` // none / provider / model
+	CommittedDiscountAmount  float64 `json:"committed_discount_amount"`
+	DiscountedCost           float64 `json:"discounted_cost"`
+	MinCharge                float64 `json:"min_charge"`
+	MinChargeSource          string  `json:"min_charge_source"` // none / default / model
+	MinChargeApplied         bool    `json:"min_charge_applied"`
+	MinChargeTopUp           float64 `json:"min_charge_top_up"` // 补足最低收费的差额（加价前）
+	MarkupPercent            float64 `json:"markup_percent"`
+	MarkupFlatPerMTok        float64 `json:"markup_flat_per_mtok"`
+	MarkupSource             string  `json:"markup_source"`             // none / global / provider / model
+	MarkupProvider           string  `json:"markup_provider,omitempty"` // 继承厂商级加价时的厂商
+	MarkupAmount             float64 `json:"markup_amount"`
+	TotalCost                float64 `json:"total_cost"`
+}
+
+// EstimateCost 按模型的 per-token 价格（含缓存价格）预估一次假设请求的费用。
+// 依次应用承诺用量折扣、最低收费与模型加价，不应用分组/用户倍率；Batch 为 true 时上游费用先按 batch 倍率折扣。
+// Images > 0 时按图片生成计费：每张图片价格 × 数量，加上输入与缓存 token 费用（与实际计费一致）。
+func (s *BillingService) EstimateCost(input CostEstimateInput) (*CostEstimate, error) {
+	model := strings.ToLower(strings.TrimSpace(input.Model))
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if input.InputTokens < 0 || input.OutputTokens < 0 || input.CacheReadTokens < 0 || input.CacheCreationTokens < 0 {
+		return nil, fmt.Errorf("token counts must be non-negative")
+	}
+	if input.Images < 0 {
+		return nil, fmt.Errorf("images must be non-negative")
+	}
+	cacheMix, err := splitEstimateInputByCacheRatio(&input)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, matchType, err := s.MatchModelPricing(model, !s.FuzzyModelMatchingEnabled())
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &CostEstimate{
+		Model:     model,
+		MatchType: matchType,
+		IsFree:    pricing.IsFree,
+		Batch:     input.Batch,
+		Images:    input.Images,
+		CacheMix:  cacheMix,
+		Warnings:  make([]string, 0),
+	}
+	if pricing.IsDefault {
+		estimate.Warnings = append(estimate.Warnings,
+			fmt.Sprintf("model %s is not in the pricing catalog; default pricing applied", model))
+	}
+	if !pricing.IsFree && (input.CacheReadTokens > 0 || input.CacheCreationTokens > 0) && !modelSupportsPromptCaching(pricing) {
+		estimate.Warnings = append(estimate.Warnings,
+			fmt.Sprintf("model %s does not support prompt caching; cache tokens are billed as regular input", model))
+	}
+
+	tokens := UsageTokens{
+		InputTokens:         input.InputTokens,
+		OutputTokens:        input.OutputTokens,
+		CacheReadTokens:     input.CacheReadTokens,
+		CacheCreationTokens: input.CacheCreationTokens,
+	}
+	imageUnitPrice := 0.0
+	if input.Images > 0 {
+		if input.OutputTokens > 0 {
+			estimate.Warnings = append(estimate.Warnings,
+				fmt.Sprintf("model %s is billed per generated image; output_tokens are ignored", model))
+			tokens.OutputTokens = 0
+		}
+		imageUnitPrice = pricing.OutputPricePerImage
+		if imageUnitPrice <= 0 {
+			imageUnitPrice = s.getDefaultImagePrice(model, "")
+			estimate.Warnings = append(estimate.Warnings,
+				fmt.Sprintf("model %s has no per-image price; default image price applied", model))
+		}
+	}
+
+	bd := s.computeTokenBreakdown(pricing, tokens, 1.0, "", true)
+	if input.Images > 0 {
+		bd.ImageOutputCost = imageUnitPrice * float64(input.Images)
+		bd.TotalCost += bd.ImageOutputCost
+		bd.ActualCost = bd.TotalCost
+	}
+	if input.Batch {
+		estimate.BatchMultiplier, _ = s.EffectiveBatchMultiplier(model)
+		s.applyBatchDiscount(model, bd)
+	}
+	s.applyMarkup(model, bd, tokens, 1.0, false)
+
+	estimate.Breakdown = CostEstimateBreakdown{
+		InputCost:         bd.InputCost,
+		OutputCost:        bd.OutputCost,
+		CacheReadCost:     bd.CacheReadCost,
+		CacheCreationCost: bd.CacheCreationCost,
+		ImageCost:         bd.ImageOutputCost,
+		MinChargeTopUp:    bd.MinChargeTopUp,
+	}
+	estimate.TotalCost = bd.TotalCost
+	estimate.BaseCost = bd.BaseCost
+	if cacheMix != nil {
+		cacheMix.BlendedInputCost = bd.InputCost + bd.CacheReadCost + bd.CacheCreationCost
+		if cacheMix.TotalInputTokens > 0 {
+			cacheMix.BlendedInputCostPerMTok = cacheMix.BlendedInputCost / float64(cacheMix.TotalInputTokens) * 1_000_000
+		}
+	}
+	estimate.Stages = CostEstimateStages{
+		ListCost:                bd.ListCost,
+		CommittedDiscountSource: CommittedDiscountSourceNone,
+		CommittedDiscountAmount: bd.ListCost - bd.BaseCost,
+		DiscountedCost:          bd.BaseCost,
+		MinChargeSource:         MinChargeSourceNone,
+		MarkupSource:            MarkupSourceNone,
+		MarkupAmount:            bd.TotalCost - bd.BaseCost,
+		TotalCost:               bd.TotalCost,
+	}
+	if !pricing.IsFree {
+		// 免费模型不应用折扣与加价
+		estimate.Stages.CommittedDiscountPercent, estimate.Stages.CommittedDiscountSource = s.EffectiveCommittedDiscount(model)
+		markup, markupSource, markupProvider := s.effectiveMarkup(model)
+		estimate.Stages.MarkupPercent = markup.Percent
+		estimate.Stages.MarkupFlatPerMTok = markup.FlatPerMTok
+		estimate.Stages.MarkupSource = markupSource
+		estimate.Stages.MarkupProvider = markupProvider
+		estimate.Stages.MinCharge, estimate.Stages.MinChargeSource = s.EffectiveMinCharge(model)
+		if bd.MinChargeTopUp > 0 {
+			estimate.Stages.MinChargeApplied = true
+			estimate.Stages.MinChargeTopUp = estimate.Stages.MinCharge - bd.BaseCost
+			estimate.Stages.MarkupAmount -= estimate.Stages.MinChargeTopUp
+		}
+	}
+	return estimate, nil
+}
+
+// splitEstimateInputByCacheRatio 按缓存命中率与缓存创建占比把 InputTokens 拆分为普通输入、缓存读取与缓存创建 token
+// （就地修改 input）；未设置比例时返回 nil
+func splitEstimateInputByCacheRatio(input *CostEstimateInput) (*CostEstimateCacheMix, error) {
+	if input.CacheHitRatio == nil && input.CacheCreationRatio == nil {
+		return nil, nil
+	}
+	if input.CacheReadTokens > 0 || input.CacheCreationTokens > 0 {
+		return nil, fmt.Errorf("cache_hit_ratio and cache_creation_ratio cannot be combined with cache_read_tokens or cache_creation_tokens")
+	}
+	mix := &CostEstimateCacheMix{TotalInputTokens: input.InputTokens}
+	if input.CacheHitRatio != nil {
+		mix.CacheHitRatio = *input.CacheHitRatio
+	}
+	if input.CacheCreationRatio != nil {
+		mix.CacheCreationRatio = *input.CacheCreationRatio
+	}
+	if mix.CacheHitRatio < 0 || mix.CacheHitRatio > 1 || mix.CacheCreationRatio < 0 || mix.CacheCreationRatio > 1 {
+		return nil, fmt.Errorf("cache_hit_ratio and cache_creation_ratio must be between 0 and 1")
+	}
+	if mix.CacheHitRatio+mix.CacheCreationRatio > 1 {
+		return nil, fmt.Errorf("cache_hit_ratio + cache_creation_ratio must not exceed 1")
+	}
+
+	total := input.InputTokens
+	mix.CacheReadTokens = int(math.Round(float64(total) * mix.CacheHitRatio))
+	mix.CacheCreationTokens = min(int(math.Round(float64(total)*mix.CacheCreationRatio)), total-mix.CacheReadTokens)
+	mix.InputTokens = total - mix.CacheReadTokens - mix.CacheCreationTokens
+
+	input.InputTokens = mix.InputTokens
+	input.CacheReadTokens = mix.CacheReadTokens
+	input.CacheCreationTokens = mix.CacheCreationTokens
+	return mix, nil
+}
+
+// modelSupportsPromptCaching 判断缓存 token 是否按缓存价格计费（与 computeTokenBreakdown 一致）
+func modelSupportsPromptCaching(pricing *ModelPricing) bool {
+	return pricing != nil && !pricing.PromptCachingUnsupported
+}
+
+// CostCompareInput 跨模型费用对比的假设用量与筛选条件
+type CostCompareInput struct {
+	InputTokens         int
+	OutputTokens        int
+	CacheReadTokens     int
+	CacheCreationTokens int
+	Provider            string // 为空时不按厂商筛选
+	Limit               int    // <= 0 表示不限制
+}
+
+// CostComparison 单个模型在同一用量下的预估费用
+type CostComparison struct {
+	Model                 string                `json:"model"`
+	Provider              string                `json:"provider"`
+	SupportsPromptCaching bool                  `json:"supports_prompt_caching"`
+	IsFree                bool                  `json:"is_free"`
+	Breakdown             CostEstimateBreakdown `json:"breakdown"`
+	TotalCost             float64               `json:"total_cost"`
+	BaseCost              float64               `json:"base_cost"`
+	Warnings              []string              `json:"warnings"`
+}
+
+// CompareModelCosts 对所有模型按同一用量逐个预估费用，按 TotalCost 升序返回，
+// 同时返回截断前的匹配数量。已禁用及没有 per-token 价格的模型（如纯图片模型）不参与对比，
+// 显式标记为免费的模型以 0 费用参与。
+func (s *BillingService) CompareModelCosts(input CostCompareInput) ([]CostComparison, int, error) {
+	if input.InputTokens < 0 || input.OutputTokens < 0 || input.CacheReadTokens < 0 || input.CacheCreationTokens < 0 {
+		return nil, 0, fmt.Errorf("token counts must be non-negative")
+	}
+	provider := strings.ToLower(strings.TrimSpace(input.Provider))
+
+	results := make([]CostComparison, 0)
+	for model, info := range s.GetAllPricing() {
+		if info.Disabled || (!info.IsFree && info.InputCostPerToken <= 0 && info.OutputCostPerToken <= 0) {
+			continue
+		}
+		if provider != "" && strings.ToLower(info.Provider) != provider {
+			continue
+		}
+		estimate, err := s.EstimateCost(CostEstimateInput{
+			Model:               model,
+			InputTokens:         input.InputTokens,
+			OutputTokens:        input.OutputTokens,
+			CacheReadTokens:     input.CacheReadTokens,
+			CacheCreationTokens: input.CacheCreationTokens,
+		})
+		if err != nil {
+			continue
+		}
+		results = append(results, CostComparison{
+			Model:                 model,
+			Provider:              info.Provider,
+			SupportsPromptCaching: info.SupportsPromptCaching,
+			IsFree:                estimate.IsFree,
+			Breakdown:             estimate.Breakdown,
+			TotalCost:             estimate.TotalCost,
+			BaseCost:              estimate.BaseCost,
+			Warnings:              estimate.Warnings,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].TotalCost != results[j].TotalCost {
+			return results[i].TotalCost < results[j].TotalCost
+		}
+		return results[i].Model < results[j].Model
+	})
+
+	total := len(results)
+	if input.Limit > 0 && len(results) > input.Limit {
+		results = results[:input.Limit]
+	}
+	return results, total, nil
+}
+
+// 模型未参与性价比排名的原因
+const (
+	ModelValueExcludedFree     = "free"
+	ModelValueExcludedUnpriced = "unpriced"
+)
+
+// ModelValueEntry 单个模型每美元可购买的 token 数（按上游原始 per-token 价格）
+type ModelValueEntry struct {
+	Rank            int     `json:"rank"`
+	Model           string  `json:"model"`
+	Provider        string  `json:"provider"`
+	CostPerToken    float64 `json:"cost_per_token"`
+	TokensPerDollar float64 `json:"tokens_per_dollar"`
+}
+
+// ModelValueExclusion 未参与排名的模型及原因（free / unpriced）
+type ModelValueExclusion struct {
+	Model    string `json:"model"`
+	Provider string `json:"provider"`
+	Reason   string `json:"reason"`
+}
+
+// ModelValueRanking 按输出/输入 token 每美元数量分别降序排列的模型性价比排名
+type ModelValueRanking struct {
+	ByOutput []ModelValueEntry     `json:"by_output"`
+	ByInput  []ModelValueEntry     `json:"by_input"`
+	Excluded []ModelValueExclusion `json:"excluded"`
+}
+
+// RankModelValue 计算模型性价比排名（tokens_per_dollar = 1 / cost_per_token）。
+// 已禁用的模型不参与；免费及输入输出均无 per-token 价格的模型单独列在 Excluded 中；
+// 仅有一侧价格的模型只出现在对应的排名里。limit <= 0 表示不截断。
+func (s *BillingService) RankModelValue(provider string, limit int) ModelValueRanking {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	ranking := ModelValueRanking{
+		ByOutput: make([]ModelValueEntry, 0),
+		ByInput:  make([]ModelValueEntry, 0),
+		Excluded: make([]ModelValueExclusion, 0),
+	}
+	for model, info := range s.GetAllPricing() {
+		if info.Disabled {
+			continue
+		}
+		if provider != "" && strings.ToLower(info.Provider) != provider {
+			continue
+		}
+		switch {
+		case info.IsFree:
+			ranking.Excluded = append(ranking.Excluded, ModelValueExclusion{Model: model, Provider: info.Provider, Reason: ModelValueExcludedFree})
+			continue
+		case info.InputCostPerToken <= 0 && info.OutputCostPerToken <= 0:
+			ranking.Excluded = append(ranking.Excluded, ModelValueExclusion{Model: model, Provider: info.Provider, Reason: ModelValueExcludedUnpriced})
+			continue
+		}
+		if info.OutputCostPerToken > 0 {
+			ranking.ByOutput = append(ranking.ByOutput, ModelValueEntry{
+				Model:           model,
+				Provider:        info.Provider,
+				CostPerToken:    info.OutputCostPerToken,
+				TokensPerDollar: 1 / info.OutputCostPerToken,
+			})
+		}
+		if info.InputCostPerToken > 0 {
+			ranking.ByInput = append(ranking.ByInput, ModelValueEntry{
+				Model:           model,
+				Provider:        info.Provider,
+				CostPerToken:    info.InputCostPerToken,
+				TokensPerDollar: 1 / info.InputCostPerToken,
+			})
+		}
+	}
+
+	ranking.ByOutput = rankModelValueEntries(ranking.ByOutput, limit)
+	ranking.ByInput = rankModelValueEntries(ranking.ByInput, limit)
+	sort.Slice(ranking.Excluded, func(i, j int) bool { return ranking.Excluded[i].Model < ranking.Excluded[j].Model })
+	return ranking
+}
+
+// rankModelValueEntries 按每美元 token 数降序排列（相同时按模型名），填充名次并截断
+func rankModelValueEntries(entries []ModelValueEntry, limit int) []ModelValueEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].TokensPerDollar != entries[j].TokensPerDollar {
+			return entries[i].TokensPerDollar > entries[j].TokensPerDollar
+		}
+		return entries[i].Model < entries[j].Model
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries
+}
@@ -0,0 +1,193 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost_BreakdownIncludesCacheRates(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"claude-sonnet-4": {
+			InputCostPerToken:           3e-6,
+			OutputCostPerToken:          15e-6,
+			CacheCreationInputTokenCost: 3.75e-6,
+			CacheReadInputTokenCost:     0.3e-6,
+			SupportsPromptCaching:       true,
+		},
+	})
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{
+		Model:               "claude-sonnet-4",
+		InputTokens:         1000,
+		OutputTokens:        500,
+		CacheReadTokens:     2000,
+		CacheCreationTokens: 400,
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 1000*3e-6, estimate.Breakdown.InputCost, 1e-12)
+	require.InDelta(t, 500*15e-6, estimate.Breakdown.OutputCost, 1e-12)
+	require.InDelta(t, 2000*0.3e-6, estimate.Breakdown.CacheReadCost, 1e-12)
+	require.InDelta(t, 400*3.75e-6, estimate.Breakdown.CacheCreationCost, 1e-12)
+	require.InDelta(t, 1000*3e-6+500*15e-6+2000*0.3e-6+400*3.75e-6, estimate.TotalCost, 1e-12)
+	require.Empty(t, estimate.Warnings)
+}
+
+func TestEstimateCost_WarnsWhenCachingUnsupported(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"plain-model": {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6},
+	})
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "plain-model", InputTokens: 10, CacheReadTokens: 10})
+	require.NoError(t, err)
+	require.Len(t, estimate.Warnings, 1)
+}
+
+func TestEstimateCost_RejectsNegativeTokens(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, err := svc.EstimateCost(CostEstimateInput{Model: "claude-sonnet-4", InputTokens: -1})
+	require.Error(t, err)
+}
+
+func TestCompareModelCosts_SortedFilteredAndLimited(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"expensive":   {InputCostPerToken: 10e-6, OutputCostPerToken: 30e-6, LiteLLMProvider: "openai"},
+		"cheap":       {InputCostPerToken: 0.1e-6, OutputCostPerToken: 0.4e-6, LiteLLMProvider: "openai"},
+		"middle":      {InputCostPerToken: 3e-6, OutputCostPerToken: 15e-6, LiteLLMProvider: "anthropic", SupportsPromptCaching: true},
+		"image-only":  {OutputCostPerImage: 0.04, LiteLLMProvider: "openai"},
+		"disabled-md": {InputCostPerToken: 1e-9, OutputCostPerToken: 1e-9, LiteLLMProvider: "openai"},
+	})
+	_, err := svc.DisableModel("disabled-md")
+	require.NoError(t, err)
+
+	input := CostCompareInput{InputTokens: 1000, OutputTokens: 1000}
+	results, total, err := svc.CompareModelCosts(input)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"cheap", "middle", "expensive"}, []string{results[0].Model, results[1].Model, results[2].Model})
+	require.InDelta(t, 1000*0.1e-6+1000*0.4e-6, results[0].TotalCost, 1e-12)
+	require.True(t, results[1].SupportsPromptCaching)
+	require.Equal(t, "anthropic", results[1].Provider)
+
+	input.Provider = "OpenAI"
+	input.Limit = 1
+	results, total, err = svc.CompareModelCosts(input)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, results, 1)
+	require.Equal(t, "cheap", results[0].Model)
+
+	_, _, err = svc.CompareModelCosts(CostCompareInput{InputTokens: -1})
+	require.Error(t, err)
+}
+
+func TestEstimateCost_FreeModelIsZeroWithoutMarkup(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"promo-model": {IsFree: true, LiteLLMProvider: "openai"},
+		"paid-model":  {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6, LiteLLMProvider: "openai"},
+	})
+	_, err := svc.SetGlobalMarkup(10, 0.5)
+	require.NoError(t, err)
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "promo-model", InputTokens: 1000, OutputTokens: 500, CacheReadTokens: 100})
+	require.NoError(t, err)
+	require.True(t, estimate.IsFree)
+	require.Zero(t, estimate.TotalCost)
+	require.Zero(t, estimate.BaseCost)
+	require.Empty(t, estimate.Warnings)
+
+	pricing, err := svc.GetModelPricing("promo-model")
+	require.NoError(t, err)
+	require.True(t, pricing.IsFree)
+
+	items, total, err := svc.CompareModelCosts(CostCompareInput{InputTokens: 1000, OutputTokens: 500})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "promo-model", items[0].Model)
+	require.True(t, items[0].IsFree)
+	require.False(t, items[1].IsFree)
+}
+
+func TestFreeModel_OverrideTakesPrecedence(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"promo-model": {IsFree: true},
+	})
+	_, err := svc.SetPricingOverride("promo-model", 1e-6, 2e-6)
+	require.NoError(t, err)
+
+	pricing, err := svc.GetModelPricing("promo-model")
+	require.NoError(t, err)
+	require.False(t, pricing.IsFree)
+	require.InDelta(t, 1e-6, pricing.InputPricePerToken, 1e-12)
+	require.False(t, svc.GetAllPricing()["promo-model"].IsFree)
+}
+
+func TestEstimateCost_PerImageModel(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"dall-e-3":    {InputCostPerToken: 5e-6, OutputCostPerImage: 0.04},
+		"text-only-1": {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6},
+	})
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "dall-e-3", InputTokens: 100, Images: 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, estimate.Images)
+	require.InDelta(t, 0.08, estimate.Breakdown.ImageCost, 1e-12)
+	require.InDelta(t, 100*5e-6, estimate.Breakdown.InputCost, 1e-12)
+	require.InDelta(t, 0.08+100*5e-6, estimate.TotalCost, 1e-12)
+	require.Empty(t, estimate.Warnings)
+
+	estimate, err = svc.EstimateCost(CostEstimateInput{Model: "text-only-1", OutputTokens: 10, Images: 1})
+	require.NoError(t, err)
+	require.Zero(t, estimate.Breakdown.OutputCost)
+	require.Len(t, estimate.Warnings, 2)
+
+	_, err = svc.EstimateCost(CostEstimateInput{Model: "dall-e-3", Images: -1})
+	require.Error(t, err)
+}
+
+func TestEstimateCost_SplitsInputByCacheHitRatio(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"claude-sonnet-4": {
+			InputCostPerToken:           3e-6,
+			OutputCostPerToken:          15e-6,
+			CacheCreationInputTokenCost: 3.75e-6,
+			CacheReadInputTokenCost:     0.3e-6,
+			SupportsPromptCaching:       true,
+		},
+	})
+	hit, creation := 0.8, 0.05
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{
+		Model:              "claude-sonnet-4",
+		InputTokens:        1_000_000,
+		CacheHitRatio:      &hit,
+		CacheCreationRatio: &creation,
+	})
+	require.NoError(t, err)
+	mix := estimate.CacheMix
+	require.NotNil(t, mix)
+	require.Equal(t, 800_000, mix.CacheReadTokens)
+	require.Equal(t, 50_000, mix.CacheCreationTokens)
+	require.Equal(t, 150_000, mix.InputTokens)
+
+	blended := 150_000*3e-6 + 800_000*0.3e-6 + 50_000*3.75e-6
+	require.InDelta(t, blended, mix.BlendedInputCost, 1e-9)
+	require.InDelta(t, blended, mix.BlendedInputCostPerMTok, 1e-9)
+	require.InDelta(t, 800_000*0.3e-6, estimate.Breakdown.CacheReadCost, 1e-9)
+	require.InDelta(t, blended, estimate.TotalCost, 1e-9)
+}
+
+func TestEstimateCost_RejectsInvalidCacheRatio(t *testing.T) {
+	svc := newTestBillingService()
+	over, half := 1.2, 0.6
+
+	_, err := svc.EstimateCost(CostEstimateInput{Model: "claude-sonnet-4", InputTokens: 10, CacheHitRatio: &over})
+	require.Error(t, err)
+	_, err = svc.EstimateCost(CostEstimateInput{Model: "claude-sonnet-4", InputTokens: 10, CacheHitRatio: &half, CacheCreationRatio: &half})
+	require.Error(t, err)
+	_, err = svc.EstimateCost(CostEstimateInput{Model: "claude-sonnet-4", InputTokens: 10, CacheReadTokens: 5, CacheHitRatio: &half})
+	require.Error(t, err)
+}
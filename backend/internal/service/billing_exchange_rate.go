@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// BaseCurrency 价格数据的基准币种（LiteLLM 价格均为 USD）
+const BaseCurrency = "USD"
+
+// normalizeCurrencyCode 标准化币种代码（去空格、转大写）
+func normalizeCurrencyCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// initExchangeRates 从配置加载初始汇率
+func (s *BillingService) initExchangeRates() {
+	if s.cfg == nil {
+		return
+	}
+	for code, rate := range s.cfg.Pricing.ExchangeRates {
+		if err := s.SetExchangeRate(code, rate); err != nil {
+			log.Printf("[Billing] Skipping configured exchange rate %q: %v", code, err)
+		}
+	}
+}
+
+// SetExchangeRate 设置币种汇率（1 USD 兑换的目标币种金额）
+func (s *BillingService) SetExchangeRate(code string, rate float64) error {
+	code = normalizeCurrencyCode(code)
+	if code == "" {
+		return fmt.Errorf("currency code is required")
+	}
+	if code == BaseCurrency {
+		return fmt.Errorf("exchange rate for %s is fixed at 1", BaseCurrency)
+	}
+	if rate <= 0 {
+		return fmt.Errorf("exchange rate must be positive")
+	}
+
+	s.adminMu.Lock()
+	s.exchangeRates[code] = rate
+	s.adminMu.Unlock()
+	return nil
+}
+
+// GetExchangeRate 获取币种汇率；基准币种恒为 1，未配置的币种返回 false
+func (s *BillingService) GetExchangeRate(code string) (float64, bool) {
+	code = normalizeCurrencyCode(code)
+	if code == "" || code == BaseCurrency {
+		return 1, true
+	}
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	rate, ok := s.exchangeRates[code]
+	return rate, ok
+}
+
+// GetExchangeRates 获取所有已配置汇率的副本
+func (s *BillingService) GetExchangeRates() map[string]float64 {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make(map[string]float64, len(s.exchangeRates))
+	for code, rate := range s.exchangeRates {
+		result[code] = rate
+	}
+	return result
+}
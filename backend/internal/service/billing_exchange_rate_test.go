@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeRate_BaseCurrencyAlwaysOne(t *testing.T) {
+	svc := newTestBillingService()
+
+	rate, ok := svc.GetExchangeRate("usd")
+	require.True(t, ok)
+	require.Equal(t, 1.0, rate)
+	require.Error(t, svc.SetExchangeRate("USD", 2))
+}
+
+func TestExchangeRate_SetAndGet(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, ok := svc.GetExchangeRate("EUR")
+	require.False(t, ok)
+
+	require.NoError(t, svc.SetExchangeRate(" eur ", 0.92))
+	rate, ok := svc.GetExchangeRate("EUR")
+	require.True(t, ok)
+	require.Equal(t, 0.92, rate)
+
+	require.Error(t, svc.SetExchangeRate("EUR", 0))
+	require.Error(t, svc.SetExchangeRate("", 1.1))
+}
+
+func TestExchangeRate_LoadedFromConfig(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.ExchangeRates = map[string]float64{"eur": 0.9, "bad": -1}
+
+	svc := NewBillingService(cfg, nil)
+	rate, ok := svc.GetExchangeRate("EUR")
+	require.True(t, ok)
+	require.Equal(t, 0.9, rate)
+	_, ok = svc.GetExchangeRate("BAD")
+	require.False(t, ok)
+}
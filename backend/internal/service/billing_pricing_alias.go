@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ModelAlias 模型别名：客户端请求的别名（如 gpt4、sonnet）在计费与路由前解析为规范模型名
+type ModelAlias struct {
+	Alias     string    `json:"alias"`
+	Model     string    `json:"model"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetModelAlias 设置（或替换）模型别名并持久化。
+// 别名只解析一层：目标模型不能是别名，已被其他别名指向的模型也不能再作为别名。
+func (s *BillingService) SetModelAlias(alias, model string) (*ModelAlias, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	model = strings.ToLower(strings.TrimSpace(model))
+	if alias == "" || model == "" {
+		return nil, fmt.Errorf("alias and model are required")
+	}
+	if alias == model {
+		return nil, fmt.Errorf("alias must differ from model")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	if _, ok := s.aliases[model]; ok {
+		return nil, fmt.Errorf("model %s is itself an alias", model)
+	}
+	for other, existing := range s.aliases {
+		if existing.Model == alias && other != alias {
+			return nil, fmt.Errorf("alias %s is the target of alias %s", alias, other)
+		}
+	}
+
+	entry := &ModelAlias{Alias: alias, Model: model, UpdatedAt: time.Now()}
+	prev, existed := s.aliases[alias]
+	s.aliases[alias] = entry
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.aliases[alias] = prev
+		} else {
+			delete(s.aliases, alias)
+		}
+		return nil, err
+	}
+
+	cloned := *entry
+	return &cloned, nil
+}
+
+// RemoveModelAlias 删除模型别名，返回是否存在该别名
+func (s *BillingService) RemoveModelAlias(alias string) (bool, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.aliases[alias]
+	if !ok {
+		return false, nil
+	}
+	delete(s.aliases, alias)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.aliases[alias] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ListModelAliases 列出全部模型别名（按别名排序）
+func (s *BillingService) ListModelAliases() []ModelAlias {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ModelAlias, 0, len(s.aliases))
+	for _, entry := range s.aliases {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Alias < result[j].Alias })
+	return result
+}
+
+// HasModelAliases 是否配置了模型别名（供热路径提前跳过）
+func (s *BillingService) HasModelAliases() bool {
+	if s == nil {
+		return false
+	}
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	return len(s.aliases) > 0
+}
+
+// ResolveModelAlias 将别名解析为规范模型名；非别名原样返回
+func (s *BillingService) ResolveModelAlias(model string) (string, bool) {
+	if s == nil {
+		return model, false
+	}
+	key := strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	if entry, ok := s.aliases[key]; ok {
+		return entry.Model, true
+	}
+	return model, false
+}
+
+// ModelAliasesByTarget 规范模型名 -> 指向它的别名列表（已排序）
+func (s *BillingService) ModelAliasesByTarget() map[string][]string {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make(map[string][]string)
+	for alias, entry := range s.aliases {
+		result[entry.Model] = append(result[entry.Model], alias)
+	}
+	for model := range result {
+		sort.Strings(result[model])
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetModelPricing_ResolvesAlias(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+	svc.SetFuzzyModelMatching(false)
+
+	_, err := svc.GetModelPricing("gpt4")
+	require.Error(t, err)
+
+	_, err = svc.SetModelAlias("GPT4", "gpt-4o")
+	require.NoError(t, err)
+
+	pricing, matchType, err := svc.MatchModelPricing("gpt4", true)
+	require.NoError(t, err)
+	require.Equal(t, PricingMatchExact, matchType)
+	require.InDelta(t, 1e-5, pricing.OutputPricePerToken, 1e-12)
+
+	canonical, ok := svc.ResolveModelAlias("Gpt4")
+	require.True(t, ok)
+	require.Equal(t, "gpt-4o", canonical)
+	canonical, ok = svc.ResolveModelAlias("gpt-4o")
+	require.False(t, ok)
+	require.Equal(t, "gpt-4o", canonical)
+}
+
+func TestGetModelPricing_AliasOfDisabledModel(t *testing.T) {
+	svc := newTestBillingService()
+	_, err := svc.SetModelAlias("sonnet", "claude-sonnet-4")
+	require.NoError(t, err)
+	_, err = svc.DisableModel("claude-sonnet-4")
+	require.NoError(t, err)
+
+	_, err = svc.GetModelPricing("sonnet")
+	require.ErrorIs(t, err, ErrPricingModelDisabled)
+}
+
+func TestSetModelAlias_RejectsInvalidInput(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, err := svc.SetModelAlias("", "gpt-4o")
+	require.Error(t, err)
+	_, err = svc.SetModelAlias("gpt-4o", "GPT-4o")
+	require.Error(t, err)
+
+	_, err = svc.SetModelAlias("gpt4", "gpt-4o")
+	require.NoError(t, err)
+	// 不允许别名链
+	_, err = svc.SetModelAlias("g4", "gpt4")
+	require.Error(t, err)
+	_, err = svc.SetModelAlias("gpt-4o", "gpt-4o-2024-08-06")
+	require.Error(t, err)
+	// 同一别名可以改指向
+	_, err = svc.SetModelAlias("gpt4", "gpt-4.1")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"gpt-4.1": {"gpt4"}}, svc.ModelAliasesByTarget())
+}
+
+func TestModelAlias_SurvivesPricingRefreshAndRestart(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+
+	pricingSvc := NewPricingService(cfg, nil)
+	svc := NewBillingService(cfg, pricingSvc)
+	_, err := svc.SetModelAlias("sonnet", "claude-sonnet-4")
+	require.NoError(t, err)
+	_, err = svc.SetModelAlias("gpt4", "gpt-4o")
+	require.NoError(t, err)
+
+	_, err = svc.ImportPricingData([]byte(`{"gpt-4o":{"input_cost_per_token":2.5e-6,"output_cost_per_token":1e-5,"litellm_provider":"openai","mode":"chat"}}`), false)
+	require.NoError(t, err)
+	pricing, err := svc.GetModelPricing("gpt4")
+	require.NoError(t, err)
+	require.InDelta(t, 2.5e-6, pricing.InputPricePerToken, 1e-12)
+
+	restarted := NewBillingService(cfg, nil)
+	aliases := restarted.ListModelAliases()
+	require.Len(t, aliases, 2)
+	require.Equal(t, "gpt4", aliases[0].Alias)
+	require.Equal(t, "sonnet", aliases[1].Alias)
+
+	removed, err := restarted.RemoveModelAlias("gpt4")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = restarted.RemoveModelAlias("gpt4")
+	require.NoError(t, err)
+	require.False(t, removed)
+	require.Len(t, NewBillingService(cfg, nil).ListModelAliases(), 1)
+}
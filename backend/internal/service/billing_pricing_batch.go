@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultBatchMultiplier 未配置时的 batch 价格倍率（OpenAI batch API 约为标准价格的一半）
+const defaultBatchMultiplier = 0.5
+
+// BatchMultiplier 来源
+const (
+	BatchMultiplierSourceDefault = "default"
+	BatchMultiplierSourceModel   = "model"
+)
+
+// ModelBatchMultiplierEntry 模型级 batch 倍率列表项
+type ModelBatchMultiplierEntry struct {
+	Model      string  `json:"model"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// DefaultBatchMultiplier 全局默认 batch 价格倍率
+func (s *BillingService) DefaultBatchMultiplier() float64 {
+	if s == nil || s.cfg == nil || s.cfg.Pricing.BatchMultiplier <= 0 {
+		return defaultBatchMultiplier
+	}
+	return s.cfg.Pricing.BatchMultiplier
+}
+
+func validateBatchMultiplier(multiplier float64) error {
+	if multiplier <= 0 || multiplier > 1 {
+		return fmt.Errorf("batch multiplier must be in (0, 1]")
+	}
+	return nil
+}
+
+// SetModelBatchMultiplier 设置（或替换）模型级 batch 倍率并持久化
+func (s *BillingService) SetModelBatchMultiplier(model string, multiplier float64) error {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	if err := validateBatchMultiplier(multiplier); err != nil {
+		return err
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.modelBatchMultipliers[model]
+	s.modelBatchMultipliers[model] = multiplier
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.modelBatchMultipliers[model] = prev
+		} else {
+			delete(s.modelBatchMultipliers, model)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveModelBatchMultiplier 删除模型级 batch 倍率（回退到全局默认），返回是否存在
+func (s *BillingService) RemoveModelBatchMultiplier(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.modelBatchMultipliers[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.modelBatchMultipliers, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.modelBatchMultipliers[model] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ListModelBatchMultipliers 列出全部模型级 batch 倍率（按模型名排序）
+func (s *BillingService) ListModelBatchMultipliers() []ModelBatchMultiplierEntry {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ModelBatchMultiplierEntry, 0, len(s.modelBatchMultipliers))
+	for model, multiplier := range s.modelBatchMultipliers {
+		result = append(result, ModelBatchMultiplierEntry{Model: model, Multiplier: multiplier})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Model < result[j].Model })
+	return result
+}
+
+// EffectiveBatchMultiplier 获取模型实际生效的 batch 倍率及来源（别名按规范模型名查找）
+func (s *BillingService) EffectiveBatchMultiplier(model string) (float64, string) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	model, _ = s.ResolveModelAlias(model)
+
+	s.adminMu.RLock()
+	multiplier, ok := s.modelBatchMultipliers[model]
+	s.adminMu.RUnlock()
+	if ok {
+		return multiplier, BatchMultiplierSourceModel
+	}
+	return s.DefaultBatchMultiplier(), BatchMultiplierSourceDefault
+}
+
+// CalculateBatchCost 计算 batch API 请求的费用：上游费用按 batch 倍率折扣后再加价
+func (s *BillingService) CalculateBatchCost(model string, tokens UsageTokens, rateMultiplier float64) (*CostBreakdown, error) {
+	pricing, err := s.GetModelPricing(model)
+	if err != nil {
+		return nil, err
+	}
+	bd := s.computeTokenBreakdown(pricing, tokens, rateMultiplier, "", true)
+	s.applyBatchDiscount(model, bd)
+	s.applyMarkup(model, bd, tokens, rateMultiplier, false)
+	return bd, nil
+}
+
+// applyBatchDiscount 按 batch 倍率缩放上游费用（需在 applyMarkup 之前调用，使 BaseCost 反映折扣后的上游成本）
+func (s *BillingService) applyBatchDiscount(model string, bd *CostBreakdown) {
+	if bd == nil || bd.free {
+		return
+	}
+	multiplier, _ := s.EffectiveBatchMultiplier(model)
+	if multiplier == 1 {
+		return
+	}
+	bd.InputCost *= multiplier
+	bd.OutputCost *= multiplier
+	bd.ImageOutputCost *= multiplier
+	bd.CacheCreationCost *= multiplier
+	bd.CacheReadCost *= multiplier
+	bd.CacheReadFullCost *= multiplier
+	bd.TotalCost *= multiplier
+	bd.ActualCost *= multiplier
+}
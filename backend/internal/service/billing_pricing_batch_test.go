@@ -0,0 +1,71 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveBatchMultiplier_ModelOverridesDefault(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6},
+	})
+
+	multiplier, source := svc.EffectiveBatchMultiplier("gpt-4o")
+	require.Equal(t, 0.5, multiplier)
+	require.Equal(t, BatchMultiplierSourceDefault, source)
+
+	require.Error(t, svc.SetModelBatchMultiplier("gpt-4o", 0))
+	require.Error(t, svc.SetModelBatchMultiplier("gpt-4o", 1.5))
+	require.NoError(t, svc.SetModelBatchMultiplier("GPT-4o", 0.25))
+	multiplier, source = svc.EffectiveBatchMultiplier("gpt-4o")
+	require.Equal(t, 0.25, multiplier)
+	require.Equal(t, BatchMultiplierSourceModel, source)
+	require.Len(t, svc.ListModelBatchMultipliers(), 1)
+
+	removed, err := svc.RemoveModelBatchMultiplier("gpt-4o")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = svc.RemoveModelBatchMultiplier("gpt-4o")
+	require.NoError(t, err)
+	require.False(t, removed)
+}
+
+func TestEstimateCost_BatchAppliesDiscountBeforeMarkup(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.BatchMultiplier = 0.5
+	cfg.Pricing.MarkupPercent = 10
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6},
+	}})
+
+	regular, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 1000, OutputTokens: 500})
+	require.NoError(t, err)
+	batch, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 1000, OutputTokens: 500, Batch: true})
+	require.NoError(t, err)
+
+	require.True(t, batch.Batch)
+	require.Equal(t, 0.5, batch.BatchMultiplier)
+	require.InDelta(t, regular.BaseCost*0.5, batch.BaseCost, 1e-12)
+	require.InDelta(t, regular.TotalCost*0.5, batch.TotalCost, 1e-12)
+	require.InDelta(t, batch.BaseCost*1.1, batch.TotalCost, 1e-12)
+}
+
+func TestCalculateCostUnified_BatchWithoutResolver(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6},
+	})
+	require.NoError(t, svc.SetModelBatchMultiplier("gpt-4o", 0.4))
+	tokens := UsageTokens{InputTokens: 1000, OutputTokens: 100}
+
+	regular, err := svc.CalculateCostUnified(CostInput{Model: "gpt-4o", Tokens: tokens, RateMultiplier: 2})
+	require.NoError(t, err)
+	batch, err := svc.CalculateCostUnified(CostInput{Model: "gpt-4o", Tokens: tokens, RateMultiplier: 2, Batch: true})
+	require.NoError(t, err)
+
+	require.InDelta(t, regular.TotalCost*0.4, batch.TotalCost, 1e-12)
+	require.InDelta(t, regular.ActualCost*0.4, batch.ActualCost, 1e-12)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// pricingCacheMaxEntries 缓存条目上限（模型名来自客户端请求，需防止无界增长），超出后整体清空重建
+	pricingCacheMaxEntries = 4096
+	// pricingCacheMaxAttempts 解析期间价格数据发生变化时的最大重试次数，避免返回新旧混杂的定价
+	pricingCacheMaxAttempts = 3
+)
+
+type pricingCacheKey struct {
+	model  string
+	strict bool
+}
+
+// pricingCacheEntry 缓存的解析结果及其对应的价格数据版本
+type pricingCacheEntry struct {
+	pricing     ModelPricing
+	matchType   PricingMatchType
+	dataVersion uint64
+}
+
+// pricingCache BillingService 内存定价缓存（按模型名），价格数据/管理员状态变化时失效。
+// 条目整体替换且读取时返回副本，调用方修改定价不会影响缓存。零值可直接使用。
+type pricingCache struct {
+	mu      sync.RWMutex
+	entries map[pricingCacheKey]*pricingCacheEntry
+	gen     uint64 // 管理员价格状态版本，invalidate 时递增
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// generation 当前管理员价格状态版本
+func (c *pricingCache) generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gen
+}
+
+// get 命中且价格数据版本一致时返回定价副本
+func (c *pricingCache) get(key pricingCacheKey, dataVersion uint64) (*ModelPricing, PricingMatchType, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || entry.dataVersion != dataVersion {
+		c.misses.Add(1)
+		return nil, "", false
+	}
+	c.hits.Add(1)
+	pricing := entry.pricing
+	return &pricing, entry.matchType, true
+}
+
+// put 写入解析结果；解析期间发生过 invalidate（gen 变化）时丢弃，避免缓存旧状态
+func (c *pricingCache) put(key pricingCacheKey, gen, dataVersion uint64, pricing *ModelPricing, matchType PricingMatchType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen != gen {
+		return
+	}
+	if _, exists := c.entries[key]; c.entries == nil || (!exists && len(c.entries) >= pricingCacheMaxEntries) {
+		c.entries = make(map[pricingCacheKey]*pricingCacheEntry)
+	}
+	c.entries[key] = &pricingCacheEntry{pricing: *pricing, matchType: matchType, dataVersion: dataVersion}
+}
+
+// invalidate 清空缓存并递增版本
+func (c *pricingCache) invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[pricingCacheKey]*pricingCacheEntry)
+	c.gen++
+	c.mu.Unlock()
+}
+
+// stats 返回缓存统计（用于价格服务状态展示）
+func (c *pricingCache) stats() map[string]any {
+	c.mu.RLock()
+	size := len(c.entries)
+	c.mu.RUnlock()
+	return map[string]any{
+		"cache_entries": size,
+		"cache_hits":    c.hits.Load(),
+		"cache_misses":  c.misses.Load(),
+	}
+}
+
+// pricingDataVersion 动态价格数据版本（未启用价格服务时恒为 0）
+func (s *BillingService) pricingDataVersion() uint64 {
+	if s.pricingService == nil {
+		return 0
+	}
+	return s.pricingService.DataVersion()
+}
+
+// WarmupPricingCache 按当前匹配模式解析价格数据中全部未禁用模型，预先填充内存定价缓存，返回写入的模型数
+func (s *BillingService) WarmupPricingCache() int {
+	strict := !s.FuzzyModelMatchingEnabled()
+	warmed := 0
+	for model, info := range s.GetAllPricing() {
+		if info.Disabled || warmed >= pricingCacheMaxEntries {
+			continue
+		}
+		if _, _, err := s.MatchModelPricing(model, strict); err == nil {
+			warmed++
+		}
+	}
+	return warmed
+}
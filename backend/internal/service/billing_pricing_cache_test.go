@@ -0,0 +1,112 @@
+//go:build unit
+
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingCache_CountsHitsAndMisses(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+
+	_, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	_, err = svc.GetModelPricing("GPT-4o")
+	require.NoError(t, err)
+
+	status := svc.GetPricingServiceStatus()
+	require.Equal(t, uint64(1), status["cache_hits"])
+	require.Equal(t, uint64(1), status["cache_misses"])
+	require.Equal(t, 1, status["cache_entries"])
+}
+
+func TestPricingCache_ReturnsCopies(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+
+	pricing, err := svc.GetModelPricingWithChannel("gpt-4o", &ChannelModelPricing{InputPrice: ptrFloat64(9e-6)})
+	require.NoError(t, err)
+	require.InDelta(t, 9e-6, pricing.InputPricePerToken, 1e-12)
+
+	pricing, err = svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.InDelta(t, 2.5e-6, pricing.InputPricePerToken, 1e-12, "channel override must not leak into the cache")
+}
+
+func TestPricingCache_InvalidatedOnPricingDataReplace(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+	_, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+
+	svc.pricingService.mu.Lock()
+	svc.pricingService.replacePricingDataLocked(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 5e-6, OutputCostPerToken: 2e-5},
+	}, time.Now())
+	svc.pricingService.mu.Unlock()
+
+	pricing, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.InDelta(t, 5e-6, pricing.InputPricePerToken, 1e-12)
+}
+
+func TestPricingCache_InvalidatedOnAdminStateChange(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+	_, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+
+	_, err = svc.SetPricingOverride("gpt-4o", 1e-6, 2e-6)
+	require.NoError(t, err)
+	pricing, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.InDelta(t, 1e-6, pricing.InputPricePerToken, 1e-12)
+
+	_, err = svc.DisableModel("gpt-4o")
+	require.NoError(t, err)
+	_, err = svc.GetModelPricing("gpt-4o")
+	require.ErrorIs(t, err, ErrPricingModelDisabled)
+}
+
+func TestPricingCache_ConcurrentReadsDuringUpdate(t *testing.T) {
+	oldPricing := &LiteLLMModelPricing{InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6}
+	newPricing := &LiteLLMModelPricing{InputCostPerToken: 3e-6, OutputCostPerToken: 6e-6}
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{"gpt-4o": oldPricing})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				pricing, err := svc.GetModelPricing("gpt-4o")
+				require.NoError(t, err)
+				// 输入/输出价格必须来自同一版本的数据
+				require.InDelta(t, pricing.InputPricePerToken*2, pricing.OutputPricePerToken, 1e-12)
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		next := oldPricing
+		if i%2 == 0 {
+			next = newPricing
+		}
+		svc.pricingService.mu.Lock()
+		svc.pricingService.replacePricingDataLocked(map[string]*LiteLLMModelPricing{"gpt-4o": next}, time.Now())
+		svc.pricingService.mu.Unlock()
+	}
+	wg.Wait()
+
+	pricing, err := svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.InDelta(t, 1e-6, pricing.InputPricePerToken, 1e-12)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrPricingCloneSourceNotFound 克隆源模型没有价格数据或覆盖
+	ErrPricingCloneSourceNotFound = errors.New("source model pricing not found")
+	// ErrPricingCloneTargetExists 目标模型已存在价格数据（未指定 overwrite）
+	ErrPricingCloneTargetExists = errors.New("target model already has pricing")
+)
+
+// ClonePricing 将源模型的全部定价字段复制为目标模型的价格覆盖（价格数据更新后依然生效）。
+// 目标模型已在价格数据中存在时需指定 overwrite；目标已有的覆盖直接替换。
+func (s *BillingService) ClonePricing(from, to string, overwrite bool) (*PricingOverride, error) {
+	from = strings.ToLower(strings.TrimSpace(from))
+	to = strings.ToLower(strings.TrimSpace(to))
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("from and to are required")
+	}
+	if from == to {
+		return nil, fmt.Errorf("from and to must be different models")
+	}
+
+	source := s.exactModelPricing(from)
+	if source == nil {
+		return nil, ErrPricingCloneSourceNotFound
+	}
+	if !overwrite && s.pricingService != nil && s.pricingService.GetExactModelPricing(to) != nil {
+		return nil, ErrPricingCloneTargetExists
+	}
+
+	override := &PricingOverride{
+		InputCostPerToken:  source.InputPricePerToken,
+		OutputCostPerToken: source.OutputPricePerToken,
+		ClonedFrom:         from,
+		UpdatedAt:          time.Now(),
+	}
+	// 源模型的完整价格数据（缓存、priority、长上下文、图片等）作为覆盖的基础
+	if sourceOverride := s.GetPricingOverride(from); sourceOverride != nil && sourceOverride.Base != nil {
+		override.Base = sourceOverride.Base
+	} else if s.pricingService != nil {
+		if entry := s.pricingService.GetExactModelPricing(from); entry != nil {
+			cloned := *entry
+			override.Base = &cloned
+		}
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.overrides[to]
+	s.overrides[to] = override
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.overrides[to] = prev
+		} else {
+			delete(s.overrides, to)
+		}
+		return nil, err
+	}
+
+	cloned := *override
+	return &cloned, nil
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClonePricing_CopiesAllPricingFields(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {
+			InputCostPerToken:               2.5e-6,
+			InputCostPerTokenPriority:       5e-6,
+			OutputCostPerToken:              1e-5,
+			CacheReadInputTokenCost:         1.25e-6,
+			CacheReadInputTokenCostPriority: 2.5e-6,
+			SupportsPromptCaching:           true,
+			LiteLLMProvider:                 "openai",
+		},
+	})
+
+	override, err := svc.ClonePricing("GPT-4o", "gpt-4o-mini-new", false)
+	require.NoError(t, err)
+	require.Equal(t, "gpt-4o", override.ClonedFrom)
+
+	pricing, matchType, err := svc.MatchModelPricing("gpt-4o-mini-new", true)
+	require.NoError(t, err)
+	require.Equal(t, PricingMatchExact, matchType)
+	require.InDelta(t, 2.5e-6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 5e-6, pricing.InputPricePerTokenPriority, 1e-12)
+	require.InDelta(t, 1.25e-6, pricing.CacheReadPricePerToken, 1e-12)
+	require.InDelta(t, 2.5e-6, pricing.CacheReadPricePerTokenPriority, 1e-12)
+
+	// 调整克隆模型价格后其余字段保留
+	_, err = svc.SetPricingOverride("gpt-4o-mini-new", 1e-6, 4e-6)
+	require.NoError(t, err)
+	pricing, _, err = svc.MatchModelPricing("gpt-4o-mini-new", true)
+	require.NoError(t, err)
+	require.InDelta(t, 1e-6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 1e-6, pricing.InputPricePerTokenPriority, 1e-12)
+	require.InDelta(t, 1.25e-6, pricing.CacheReadPricePerToken, 1e-12)
+}
+
+func TestClonePricing_Errors(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":      {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+		"gpt-4o-mini": {InputCostPerToken: 1.5e-7, OutputCostPerToken: 6e-7},
+	})
+
+	_, err := svc.ClonePricing("no-such-model", "new-model", false)
+	require.ErrorIs(t, err, ErrPricingCloneSourceNotFound)
+
+	_, err = svc.ClonePricing("gpt-4o", "gpt-4o-mini", false)
+	require.ErrorIs(t, err, ErrPricingCloneTargetExists)
+
+	_, err = svc.ClonePricing("gpt-4o", "gpt-4o-mini", true)
+	require.NoError(t, err)
+	pricing, _, err := svc.MatchModelPricing("gpt-4o-mini", true)
+	require.NoError(t, err)
+	require.InDelta(t, 1e-5, pricing.OutputPricePerToken, 1e-12)
+
+	_, err = svc.ClonePricing("gpt-4o", "gpt-4o", true)
+	require.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CommittedDiscount 来源
+const (
+	CommittedDiscountSourceNone     = "none"
+	CommittedDiscountSourceProvider = "provider"
+	CommittedDiscountSourceModel    = "model"
+)
+
+// CommittedDiscountEntry 承诺用量折扣列表项（Model 与 Provider 二选一）
+type CommittedDiscountEntry struct {
+	Model    string  `json:"model,omitempty"`
+	Provider string  `json:"provider,omitempty"`
+	Percent  float64 `json:"percent"`
+}
+
+// CommittedDiscountList 全部厂商级与模型级承诺用量折扣
+type CommittedDiscountList struct {
+	Providers []CommittedDiscountEntry `json:"providers"`
+	Models    []CommittedDiscountEntry `json:"models"`
+}
+
+func validateCommittedDiscount(percent float64) error {
+	if percent < 0 || percent >= 100 {
+		return fmt.Errorf("committed discount percent must be in [0, 100)")
+	}
+	return nil
+}
+
+// SetModelCommittedDiscount 设置（或替换）模型级承诺用量折扣并持久化
+func (s *BillingService) SetModelCommittedDiscount(model string, percent float64) error {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	return s.setCommittedDiscount(s.modelCommittedDiscounts, model, percent)
+}
+
+// SetProviderCommittedDiscount 设置（或替换）厂商级承诺用量折扣并持久化
+func (s *BillingService) SetProviderCommittedDiscount(provider string, percent float64) error {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return fmt.Errorf("provider is required")
+	}
+	return s.setCommittedDiscount(s.providerCommittedDiscounts, provider, percent)
+}
+
+func (s *BillingService) setCommittedDiscount(discounts map[string]float64, key string, percent float64) error {
+	if err := validateCommittedDiscount(percent); err != nil {
+		return err
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := discounts[key]
+	discounts[key] = percent
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			discounts[key] = prev
+		} else {
+			delete(discounts, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveModelCommittedDiscount 删除模型级承诺用量折扣（回退到厂商级），返回是否存在
+func (s *BillingService) RemoveModelCommittedDiscount(model string) (bool, error) {
+	return s.removeCommittedDiscount(s.modelCommittedDiscounts, strings.ToLower(strings.TrimSpace(model)))
+}
+
+// RemoveProviderCommittedDiscount 删除厂商级承诺用量折扣，返回是否存在
+func (s *BillingService) RemoveProviderCommittedDiscount(provider string) (bool, error) {
+	return s.removeCommittedDiscount(s.providerCommittedDiscounts, strings.ToLower(strings.TrimSpace(provider)))
+}
+
+func (s *BillingService) removeCommittedDiscount(discounts map[string]float64, key string) (bool, error) {
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := discounts[key]
+	if !ok {
+		return false, nil
+	}
+	delete(discounts, key)
+	if err := s.persistPricingStateLocked(); err != nil {
+		discounts[key] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ListCommittedDiscounts 列出全部厂商级与模型级承诺用量折扣（按名称排序）
+func (s *BillingService) ListCommittedDiscounts() CommittedDiscountList {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := CommittedDiscountList{
+		Providers: make([]CommittedDiscountEntry, 0, len(s.providerCommittedDiscounts)),
+		Models:    make([]CommittedDiscountEntry, 0, len(s.modelCommittedDiscounts)),
+	}
+	for provider, percent := range s.providerCommittedDiscounts {
+		result.Providers = append(result.Providers, CommittedDiscountEntry{Provider: provider, Percent: percent})
+	}
+	for model, percent := range s.modelCommittedDiscounts {
+		result.Models = append(result.Models, CommittedDiscountEntry{Model: model, Percent: percent})
+	}
+	sort.Slice(result.Providers, func(i, j int) bool { return result.Providers[i].Provider < result.Providers[j].Provider })
+	sort.Slice(result.Models, func(i, j int) bool { return result.Models[i].Model < result.Models[j].Model })
+	return result
+}
+
+// EffectiveCommittedDiscount 获取模型实际生效的承诺用量折扣百分比及来源：
+// 模型级优先，其次按价格数据中的所属厂商查找（别名按规范模型名查找）
+func (s *BillingService) EffectiveCommittedDiscount(model string) (float64, string) {
+	if s == nil {
+		return 0, CommittedDiscountSourceNone
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	model, _ = s.ResolveModelAlias(model)
+
+	s.adminMu.RLock()
+	percent, ok := s.modelCommittedDiscounts[model]
+	noProviders := len(s.providerCommittedDiscounts) == 0
+	s.adminMu.RUnlock()
+	if ok {
+		return percent, CommittedDiscountSourceModel
+	}
+	if noProviders {
+		return 0, CommittedDiscountSourceNone
+	}
+	provider := strings.ToLower(s.modelProvider(model))
+	if provider == "" {
+		return 0, CommittedDiscountSourceNone
+	}
+	s.adminMu.RLock()
+	percent, ok = s.providerCommittedDiscounts[provider]
+	s.adminMu.RUnlock()
+	if ok {
+		return percent, CommittedDiscountSourceProvider
+	}
+	return 0, CommittedDiscountSourceNone
+}
+
+// applyCommittedDiscount 按承诺用量折扣缩放上游标价费用，ListCost 记录折扣前的标价费用。
+// 需在加价之前调用：计费链路为 标价 → 承诺折扣 → 加价；免费模型不处理。
+func (s *BillingService) applyCommittedDiscount(model string, bd *CostBreakdown) {
+	bd.ListCost = bd.TotalCost
+	if s == nil || bd.free {
+		return
+	}
+	percent, _ := s.EffectiveCommittedDiscount(model)
+	if percent == 0 {
+		return
+	}
+	factor := 1 - percent/100
+	bd.InputCost *= factor
+	bd.OutputCost *= factor
+	bd.ImageOutputCost *= factor
+	bd.CacheCreationCost *= factor
+	bd.CacheReadCost *= factor
+	bd.CacheReadFullCost *= factor
+	bd.TotalCost *= factor
+	bd.ActualCost *= factor
+}
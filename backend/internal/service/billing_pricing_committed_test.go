@@ -0,0 +1,86 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveCommittedDiscount_ModelOverridesProvider(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":          {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6, LiteLLMProvider: "openai"},
+		"claude-sonnet-4": {InputCostPerToken: 3e-6, OutputCostPerToken: 1.5e-5, LiteLLMProvider: "anthropic"},
+	})
+
+	percent, source := svc.EffectiveCommittedDiscount("gpt-4o")
+	require.Zero(t, percent)
+	require.Equal(t, CommittedDiscountSourceNone, source)
+
+	require.Error(t, svc.SetProviderCommittedDiscount("openai", -1))
+	require.Error(t, svc.SetProviderCommittedDiscount("openai", 100))
+	require.NoError(t, svc.SetProviderCommittedDiscount("OpenAI", 20))
+	require.NoError(t, svc.SetModelCommittedDiscount("GPT-4o", 30))
+
+	percent, source = svc.EffectiveCommittedDiscount("gpt-4o")
+	require.Equal(t, 30.0, percent)
+	require.Equal(t, CommittedDiscountSourceModel, source)
+	percent, source = svc.EffectiveCommittedDiscount("claude-sonnet-4")
+	require.Zero(t, percent)
+	require.Equal(t, CommittedDiscountSourceNone, source)
+
+	removed, err := svc.RemoveModelCommittedDiscount("gpt-4o")
+	require.NoError(t, err)
+	require.True(t, removed)
+	percent, source = svc.EffectiveCommittedDiscount("gpt-4o")
+	require.Equal(t, 20.0, percent)
+	require.Equal(t, CommittedDiscountSourceProvider, source)
+
+	list := svc.ListCommittedDiscounts()
+	require.Len(t, list.Providers, 1)
+	require.Empty(t, list.Models)
+	removed, err = svc.RemoveProviderCommittedDiscount("openai")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = svc.RemoveProviderCommittedDiscount("openai")
+	require.NoError(t, err)
+	require.False(t, removed)
+}
+
+func TestEstimateCost_CommittedDiscountBeforeMarkup(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MarkupPercent = 10
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6, LiteLLMProvider: "openai"},
+	}})
+	require.NoError(t, svc.SetProviderCommittedDiscount("openai", 25))
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 1000, OutputTokens: 500})
+	require.NoError(t, err)
+
+	listCost := 1000*2e-6 + 500*8e-6
+	stages := estimate.Stages
+	require.InDelta(t, listCost, stages.ListCost, 1e-12)
+	require.Equal(t, 25.0, stages.CommittedDiscountPercent)
+	require.Equal(t, CommittedDiscountSourceProvider, stages.CommittedDiscountSource)
+	require.InDelta(t, listCost*0.25, stages.CommittedDiscountAmount, 1e-12)
+	require.InDelta(t, listCost*0.75, stages.DiscountedCost, 1e-12)
+	require.Equal(t, 10.0, stages.MarkupPercent)
+	require.Equal(t, MarkupSourceGlobal, stages.MarkupSource)
+	require.InDelta(t, listCost*0.75*0.1, stages.MarkupAmount, 1e-12)
+	require.InDelta(t, listCost*0.75*1.1, estimate.TotalCost, 1e-12)
+	require.InDelta(t, stages.DiscountedCost, estimate.BaseCost, 1e-12)
+
+	// 折扣持久化后重启仍生效，实际计费与预估一致
+	restarted := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6, LiteLLMProvider: "openai"},
+	}})
+	cost, err := restarted.CalculateCost("gpt-4o", UsageTokens{InputTokens: 1000, OutputTokens: 500}, 2)
+	require.NoError(t, err)
+	require.InDelta(t, estimate.TotalCost, cost.TotalCost, 1e-12)
+	require.InDelta(t, estimate.TotalCost*2, cost.ActualCost, 1e-12)
+	require.InDelta(t, listCost*0.75, cost.UpstreamCost(), 1e-12)
+}
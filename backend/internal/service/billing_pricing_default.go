@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// 默认价格来源
+const (
+	DefaultPricingSourceNone   = "none"
+	DefaultPricingSourceConfig = "config"
+	DefaultPricingSourceAdmin  = "admin"
+)
+
+// GetDefaultPricing 获取未知模型的默认价格（管理员设置优先，否则使用配置文件）及其来源
+func (s *BillingService) GetDefaultPricing() (PricingOverride, string) {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	return s.defaultPricingLocked()
+}
+
+func (s *BillingService) defaultPricingLocked() (PricingOverride, string) {
+	if s.defaultPricing != nil {
+		return *s.defaultPricing, DefaultPricingSourceAdmin
+	}
+	if s.cfg != nil && (s.cfg.Pricing.DefaultInputCostPerToken > 0 || s.cfg.Pricing.DefaultOutputCostPerToken > 0) {
+		return PricingOverride{
+			InputCostPerToken:  s.cfg.Pricing.DefaultInputCostPerToken,
+			OutputCostPerToken: s.cfg.Pricing.DefaultOutputCostPerToken,
+		}, DefaultPricingSourceConfig
+	}
+	return PricingOverride{}, DefaultPricingSourceNone
+}
+
+// SetDefaultPricing 设置未知模型的默认价格并持久化（覆盖配置文件中的默认值）
+func (s *BillingService) SetDefaultPricing(inputCostPerToken, outputCostPerToken float64) (*PricingOverride, error) {
+	if inputCostPerToken < 0 || outputCostPerToken < 0 {
+		return nil, fmt.Errorf("cost per token must be non-negative")
+	}
+	if inputCostPerToken == 0 && outputCostPerToken == 0 {
+		return nil, fmt.Errorf("default pricing must not be zero")
+	}
+	pricing := &PricingOverride{
+		InputCostPerToken:  inputCostPerToken,
+		OutputCostPerToken: outputCostPerToken,
+		UpdatedAt:          time.Now(),
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev := s.defaultPricing
+	s.defaultPricing = pricing
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.defaultPricing = prev
+		return nil, err
+	}
+	cloned := *pricing
+	return &cloned, nil
+}
+
+// RemoveDefaultPricing 删除管理员设置的默认价格（回退到配置文件），返回是否存在
+func (s *BillingService) RemoveDefaultPricing() (bool, error) {
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev := s.defaultPricing
+	if prev == nil {
+		return false, nil
+	}
+	s.defaultPricing = nil
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.defaultPricing = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// defaultModelPricing 构造默认价格；未配置时返回 nil。
+// 缓存创建/读取按输入价格计费，宁可多收也不少收。
+func (s *BillingService) defaultModelPricing() *ModelPricing {
+	pricing, source := s.GetDefaultPricing()
+	if source == DefaultPricingSourceNone {
+		return nil
+	}
+	return &ModelPricing{
+		InputPricePerToken:          pricing.InputCostPerToken,
+		InputPricePerTokenPriority:  pricing.InputCostPerToken,
+		OutputPricePerToken:         pricing.OutputCostPerToken,
+		OutputPricePerTokenPriority: pricing.OutputCostPerToken,
+		CacheCreationPricePerToken:  pricing.InputCostPerToken,
+		CacheReadPricePerToken:      pricing.InputCostPerToken,
+		IsDefault:                   true,
+	}
+}
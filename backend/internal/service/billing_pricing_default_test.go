@@ -0,0 +1,76 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPricing_UsedForUnknownModels(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DefaultInputCostPerToken = 1e-5
+	cfg.Pricing.DefaultOutputCostPerToken = 5e-5
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	}})
+
+	pricing, matchType, err := svc.MatchModelPricing("brand-new-model", false)
+	require.NoError(t, err)
+	require.Equal(t, PricingMatchDefault, matchType)
+	require.True(t, pricing.IsDefault)
+	require.InDelta(t, 1e-5, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 5e-5, pricing.OutputPricePerToken, 1e-12)
+
+	pricing, err = svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.False(t, pricing.IsDefault)
+
+	cost, err := svc.CalculateCost("brand-new-model", UsageTokens{InputTokens: 1000, OutputTokens: 100}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 1000*1e-5+100*5e-5, cost.TotalCost, 1e-12)
+}
+
+func TestDefaultPricing_StrictStillNotFound(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DefaultInputCostPerToken = 1e-5
+	cfg.Pricing.StrictModelMatch = true
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{}})
+
+	_, err := svc.GetModelPricing("brand-new-model")
+	require.Error(t, err)
+	_, _, err = svc.MatchModelPricing("brand-new-model", true)
+	require.Error(t, err)
+}
+
+func TestDefaultPricing_AdminSettingOverridesConfigAndPersists(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewBillingService(cfg, nil)
+
+	_, source := svc.GetDefaultPricing()
+	require.Equal(t, DefaultPricingSourceNone, source)
+	_, err := svc.GetModelPricing("brand-new-model")
+	require.Error(t, err)
+
+	_, err = svc.SetDefaultPricing(0, 0)
+	require.Error(t, err)
+	_, err = svc.SetDefaultPricing(2e-6, 8e-6)
+	require.NoError(t, err)
+
+	restarted := NewBillingService(cfg, nil)
+	pricing, source := restarted.GetDefaultPricing()
+	require.Equal(t, DefaultPricingSourceAdmin, source)
+	require.InDelta(t, 8e-6, pricing.OutputCostPerToken, 1e-12)
+	modelPricing, err := restarted.GetModelPricing("brand-new-model")
+	require.NoError(t, err)
+	require.True(t, modelPricing.IsDefault)
+
+	removed, err := restarted.RemoveDefaultPricing()
+	require.NoError(t, err)
+	require.True(t, removed)
+	_, err = restarted.GetModelPricing("brand-new-model")
+	require.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// ErrPricingModelDisabled 模型已被管理员从价格目录中禁用
+var ErrPricingModelDisabled = infraerrors.Forbidden("PRICING_MODEL_DISABLED", "model disabled")
+
+// ErrPricingInvalidModelPattern 批量启用/禁用的模型模式为空或不是合法的 glob
+var ErrPricingInvalidModelPattern = infraerrors.BadRequest("PRICING_INVALID_MODEL_PATTERN", "invalid model pattern")
+
+// DisableModel 将模型标记为禁用（软禁用，价格数据保留），返回是否发生变化
+func (s *BillingService) DisableModel(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return false, fmt.Errorf("model is required")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	if _, ok := s.disabledModels[model]; ok {
+		return false, nil
+	}
+	s.disabledModels[model] = time.Now()
+	if err := s.persistPricingStateLocked(); err != nil {
+		delete(s.disabledModels, model)
+		return false, err
+	}
+	return true, nil
+}
+
+// EnableModel 取消模型禁用，返回是否发生变化
+func (s *BillingService) EnableModel(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return false, fmt.Errorf("model is required")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	disabledAt, ok := s.disabledModels[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.disabledModels, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.disabledModels[model] = disabledAt
+		return false, err
+	}
+	return true, nil
+}
+
+// IsModelDisabled 模型是否已被禁用
+func (s *BillingService) IsModelDisabled(model string) bool {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	_, ok := s.disabledModels[model]
+	return ok
+}
+
+// CheckModelEnabled 校验请求模型（别名先解析为规范模型名）及其所属厂商未被禁用，供网关在路由调度前拦截；
+// 模型已禁用时返回 ErrPricingModelDisabled，厂商已禁用时返回 ErrPricingProviderDisabled
+func (s *BillingService) CheckModelEnabled(model string) error {
+	if s == nil || strings.TrimSpace(model) == "" {
+		return nil
+	}
+	model, _ = s.ResolveModelAlias(model)
+	model = strings.ToLower(strings.TrimSpace(model))
+	if s.IsModelDisabled(model) {
+		return ErrPricingModelDisabled
+	}
+	if s.isModelProviderDisabled(model) {
+		return ErrPricingProviderDisabled
+	}
+	return nil
+}
+
+// SetModelsDisabledByPattern 按 glob 模式（如 gpt-3.5*，支持 * ? [...]，不区分大小写）批量禁用/启用价格目录中的模型；
+// provider 非空时只匹配该厂商的模型。返回状态实际发生变化的模型（按名称排序），全部变更一次持久化，失败时整体回滚。
+func (s *BillingService) SetModelsDisabledByPattern(pattern, provider string, disabled bool) ([]string, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+		return nil, ErrPricingInvalidModelPattern
+	}
+
+	// 候选模型：价格目录中的模型（含覆盖价格）；启用时还包括已不在目录中的禁用模型（厂商未知）
+	candidates := make(map[string]string)
+	for model, info := range s.GetAllPricing() {
+		candidates[model] = strings.ToLower(info.Provider)
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	if !disabled {
+		for model := range s.disabledModels {
+			if _, ok := candidates[model]; !ok {
+				candidates[model] = ""
+			}
+		}
+	}
+
+	now := time.Now()
+	changed := make([]string, 0)
+	previous := make(map[string]time.Time)
+	for model, modelProvider := range candidates {
+		if provider != "" && modelProvider != provider {
+			continue
+		}
+		if ok, _ := path.Match(pattern, model); !ok {
+			continue
+		}
+		disabledAt, isDisabled := s.disabledModels[model]
+		if isDisabled == disabled {
+			continue
+		}
+		if disabled {
+			s.disabledModels[model] = now
+		} else {
+			previous[model] = disabledAt
+			delete(s.disabledModels, model)
+		}
+		changed = append(changed, model)
+	}
+	if len(changed) == 0 {
+		return changed, nil
+	}
+	if err := s.persistPricingStateLocked(); err != nil {
+		for _, model := range changed {
+			if disabled {
+				delete(s.disabledModels, model)
+			} else {
+				s.disabledModels[model] = previous[model]
+			}
+		}
+		return nil, err
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
@@ -0,0 +1,93 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableModel_ExcludedFromLookup(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+	})
+
+	changed, err := svc.DisableModel("GPT-4o")
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	_, err = svc.GetModelPricing("gpt-4o")
+	require.ErrorIs(t, err, ErrPricingModelDisabled)
+	require.True(t, svc.GetAllPricing()["gpt-4o"].Disabled)
+
+	changed, err = svc.EnableModel("gpt-4o")
+	require.NoError(t, err)
+	require.True(t, changed)
+	_, err = svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+}
+
+func TestDisableModel_StillBilledAtCatalogPrice(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+	})
+	_, err := svc.SetModelAlias("acme-fast", "gpt-4o")
+	require.NoError(t, err)
+	_, err = svc.DisableModel("gpt-4o")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, svc.CheckModelEnabled("gpt-4o"), ErrPricingModelDisabled)
+	require.ErrorIs(t, svc.CheckModelEnabled("ACME-FAST"), ErrPricingModelDisabled)
+	require.NoError(t, svc.CheckModelEnabled("gpt-4o-mini"))
+
+	// 禁用前已放行的请求按目录价格计费，而不是 0 元
+	cost, err := svc.CalculateCost("gpt-4o", UsageTokens{InputTokens: 1000, OutputTokens: 100}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 1000*2.5e-6+100*1e-5, cost.ActualCost, 1e-12)
+}
+
+func TestDisableModel_PersistsAcrossRestart(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+
+	_, err := NewBillingService(cfg, nil).DisableModel("claude-sonnet-4")
+	require.NoError(t, err)
+
+	restarted := NewBillingService(cfg, nil)
+	require.True(t, restarted.IsModelDisabled("claude-sonnet-4"))
+	_, err = restarted.GetModelPricing("claude-sonnet-4")
+	require.ErrorIs(t, err, ErrPricingModelDisabled)
+}
+
+func TestSetModelsDisabledByPattern(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-3.5-turbo":      {InputCostPerToken: 5e-7, LiteLLMProvider: "openai"},
+		"gpt-3.5-turbo-0125": {InputCostPerToken: 5e-7, LiteLLMProvider: "openai"},
+		"gpt-4o":             {InputCostPerToken: 2.5e-6, LiteLLMProvider: "openai"},
+		"gpt-3.5-clone":      {InputCostPerToken: 5e-7, LiteLLMProvider: "azure"},
+	})
+
+	models, err := svc.SetModelsDisabledByPattern("GPT-3.5*", "openai", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpt-3.5-turbo", "gpt-3.5-turbo-0125"}, models)
+	require.True(t, svc.IsModelDisabled("gpt-3.5-turbo"))
+	require.False(t, svc.IsModelDisabled("gpt-3.5-clone"))
+	require.False(t, svc.IsModelDisabled("gpt-4o"))
+
+	// 已禁用的模型不重复计入
+	models, err = svc.SetModelsDisabledByPattern("gpt-3.5*", "", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpt-3.5-clone"}, models)
+
+	models, err = svc.SetModelsDisabledByPattern("gpt-3.5-turbo*", "", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpt-3.5-turbo", "gpt-3.5-turbo-0125"}, models)
+	require.True(t, svc.IsModelDisabled("gpt-3.5-clone"))
+
+	_, err = svc.SetModelsDisabledByPattern("gpt-[", "", true)
+	require.ErrorIs(t, err, ErrPricingInvalidModelPattern)
+	_, err = svc.SetModelsDisabledByPattern(" ", "", true)
+	require.ErrorIs(t, err, ErrPricingInvalidModelPattern)
+}
@@ -0,0 +1,27 @@
+package service
+
+import "time"
+
+// HistoricalModelPricing 某一时刻生效的模型目录价格
+type HistoricalModelPricing struct {
+	Model         string // 命中的价格目录条目
+	Pricing       *ModelPricing
+	EffectiveFrom time.Time // 该版本的生效时间
+}
+
+// ModelPricingAsOf 返回模型在 asOf 时刻生效的目录价格（不含管理员覆盖与加价）。
+// 早于最早记录的版本时返回最早的已知版本；没有任何历史时返回 ErrPricingHistoryNotFound。
+func (s *BillingService) ModelPricingAsOf(model string, asOf time.Time) (*HistoricalModelPricing, error) {
+	if s.pricingService == nil {
+		return nil, ErrPricingHistoryNotFound
+	}
+	matched, version, err := s.pricingService.GetModelPricingAsOf(model, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoricalModelPricing{
+		Model:         matched,
+		Pricing:       s.applyModelSpecificPricingPolicy(matched, litellmToModelPricing(version.Pricing)),
+		EffectiveFrom: version.EffectiveFrom,
+	}, nil
+}
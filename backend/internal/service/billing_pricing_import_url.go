@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httputil"
+	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+)
+
+const (
+	// MaxPricingUploadBytes 价格数据上传/URL 导入的大小上限（50MB）
+	MaxPricingUploadBytes = 50 * 1024 * 1024
+
+	pricingImportURLTimeout = 30 * time.Second
+)
+
+var (
+	// ErrPricingImportTooLarge 远程价格数据超过大小上限
+	ErrPricingImportTooLarge = errors.New("pricing data too large (max 50MB)")
+	// ErrPricingChecksumMismatch 下载内容的 SHA-256 与期望值不一致
+	ErrPricingChecksumMismatch = errors.New("pricing data checksum mismatch")
+)
+
+// ValidatePricingImportURL 校验价格导入 URL：仅允许 http/https，配置了 pricing.import_allowed_hosts 时限制主机
+func (s *BillingService) ValidatePricingImportURL(raw string) (string, error) {
+	opts := urlvalidator.ValidationOptions{}
+	if s.cfg != nil {
+		opts.AllowedHosts = s.cfg.Pricing.ImportAllowedHosts
+		opts.AllowPrivate = s.cfg.Security.URLAllowlist.AllowPrivateHosts
+	}
+	return urlvalidator.ValidateHTTPURL(raw, true, opts)
+}
+
+// FetchPricingImportURL 下载远程价格 JSON（url 需先经 ValidatePricingImportURL 校验）
+// 按 pricing.download_chunk_bytes 分块下载，连接中断时续传；未完整下载时返回 ErrPricingDataIncomplete。
+func (s *BillingService) FetchPricingImportURL(ctx context.Context, url string) ([]byte, error) {
+	client := &http.Client{
+		Timeout: pricingImportURLTimeout,
+		// 重定向目标同样需要通过 scheme/主机校验，避免绕过 allowlist
+		CheckRedirect: func(next *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many redirects")
+			}
+			_, err := s.ValidatePricingImportURL(next.URL.String())
+			return err
+		},
+	}
+	opts := httputil.RangeDownloadOptions{MaxBytes: MaxPricingUploadBytes}
+	if s.cfg != nil {
+		opts.ChunkBytes = s.cfg.Pricing.DownloadChunkBytes
+		opts.MaxResumes = s.cfg.Pricing.DownloadMaxResumes
+	}
+	// DownloadWithResume 按 Content-Length/Content-Range 校验收到的字节数，长度不足即视为截断，无需等到解析时才发现
+	body, err := httputil.DownloadWithResume(ctx, client, url, opts)
+	if errors.Is(err, httputil.ErrDownloadTooLarge) {
+		return nil, ErrPricingImportTooLarge
+	}
+	if errors.Is(err, httputil.ErrDownloadIncomplete) {
+		return nil, fmt.Errorf("%w: %v", ErrPricingDataIncomplete, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("%w: empty response body", ErrPricingDataIncomplete)
+	}
+	return body, nil
+}
+
+// VerifyPricingChecksum 校验价格数据的 SHA-256（expected 为空时跳过，大小写不敏感）
+func VerifyPricingChecksum(body []byte, expected string) error {
+	expected = strings.TrimSpace(expected)
+	if expected == "" {
+		return nil
+	}
+	sum := sha256.Sum256(body)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%w: expected=%s actual=%s", ErrPricingChecksumMismatch, expected, actual)
+	}
+	return nil
+}
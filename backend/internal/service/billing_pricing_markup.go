@@ -0,0 +1,291 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Markup 来源
+const (
+	MarkupSourceNone     = "none"
+	MarkupSourceGlobal   = "global"
+	MarkupSourceProvider = "provider"
+	MarkupSourceModel    = "model"
+)
+
+// PricingMarkup 转售加价：按上游价格的百分比加价，外加每百万输入/输出 token 的固定加价（USD）
+type PricingMarkup struct {
+	Percent     float64   `json:"percent"`
+	FlatPerMTok float64   `json:"flat_per_mtok"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// IsZero 是否不加价
+func (m PricingMarkup) IsZero() bool {
+	return m.Percent == 0 && m.FlatPerMTok == 0
+}
+
+// ApplyToPrice 计算单个 token 加价后的价格；includeFlat 控制是否叠加固定加价（仅输入/输出 token）
+func (m PricingMarkup) ApplyToPrice(pricePerToken float64, includeFlat bool) float64 {
+	charged := pricePerToken * (1 + m.Percent/100)
+	if includeFlat {
+		charged += m.FlatPerMTok / 1_000_000
+	}
+	return charged
+}
+
+func validatePricingMarkup(percent, flatPerMTok float64) error {
+	if percent < 0 || flatPerMTok < 0 {
+		return fmt.Errorf("markup percent and flat_per_mtok must be non-negative")
+	}
+	return nil
+}
+
+// GetGlobalMarkup 获取全局加价（管理员设置优先，否则使用配置文件）
+func (s *BillingService) GetGlobalMarkup() PricingMarkup {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	return s.globalMarkupLocked()
+}
+
+func (s *BillingService) globalMarkupLocked() PricingMarkup {
+	if s.globalMarkup != nil {
+		return *s.globalMarkup
+	}
+	if s.cfg == nil {
+		return PricingMarkup{}
+	}
+	return PricingMarkup{Percent: s.cfg.Pricing.MarkupPercent, FlatPerMTok: s.cfg.Pricing.MarkupFlatPerMTok}
+}
+
+// SetGlobalMarkup 设置全局加价并持久化（覆盖配置文件中的默认值）
+func (s *BillingService) SetGlobalMarkup(percent, flatPerMTok float64) (*PricingMarkup, error) {
+	if err := validatePricingMarkup(percent, flatPerMTok); err != nil {
+		return nil, err
+	}
+	markup := &PricingMarkup{Percent: percent, FlatPerMTok: flatPerMTok, UpdatedAt: time.Now()}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev := s.globalMarkup
+	s.globalMarkup = markup
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.globalMarkup = prev
+		return nil, err
+	}
+	cloned := *markup
+	return &cloned, nil
+}
+
+// SetModelMarkup 设置（或替换）模型级加价并持久化
+func (s *BillingService) SetModelMarkup(model string, percent, flatPerMTok float64) (*PricingMarkup, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if err := validatePricingMarkup(percent, flatPerMTok); err != nil {
+		return nil, err
+	}
+	markup := &PricingMarkup{Percent: percent, FlatPerMTok: flatPerMTok, UpdatedAt: time.Now()}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.modelMarkups[model]
+	s.modelMarkups[model] = markup
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.modelMarkups[model] = prev
+		} else {
+			delete(s.modelMarkups, model)
+		}
+		return nil, err
+	}
+	cloned := *markup
+	return &cloned, nil
+}
+
+// SetProviderMarkup 设置（或替换）厂商级加价并持久化；该厂商下未单独设置加价的模型继承此加价
+func (s *BillingService) SetProviderMarkup(provider string, percent, flatPerMTok float64) (*PricingMarkup, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return nil, fmt.Errorf("provider is required")
+	}
+	if err := validatePricingMarkup(percent, flatPerMTok); err != nil {
+		return nil, err
+	}
+	markup := &PricingMarkup{Percent: percent, FlatPerMTok: flatPerMTok, UpdatedAt: time.Now()}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.providerMarkups[provider]
+	s.providerMarkups[provider] = markup
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.providerMarkups[provider] = prev
+		} else {
+			delete(s.providerMarkups, provider)
+		}
+		return nil, err
+	}
+	cloned := *markup
+	return &cloned, nil
+}
+
+// RemoveProviderMarkup 删除厂商级加价（回退到全局加价），返回是否存在
+func (s *BillingService) RemoveProviderMarkup(provider string) (bool, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.providerMarkups[provider]
+	if !ok {
+		return false, nil
+	}
+	delete(s.providerMarkups, provider)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.providerMarkups[provider] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveModelMarkup 删除模型级加价（回退到厂商级/全局加价），返回是否存在
+func (s *BillingService) RemoveModelMarkup(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.modelMarkups[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.modelMarkups, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.modelMarkups[model] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ModelMarkupEntry 模型级加价列表项
+type ModelMarkupEntry struct {
+	Model string `json:"model"`
+	PricingMarkup
+}
+
+// ListModelMarkups 列出全部模型级加价（按模型名排序）
+func (s *BillingService) ListModelMarkups() []ModelMarkupEntry {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ModelMarkupEntry, 0, len(s.modelMarkups))
+	for model, markup := range s.modelMarkups {
+		result = append(result, ModelMarkupEntry{Model: model, PricingMarkup: *markup})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Model < result[j].Model })
+	return result
+}
+
+// ProviderMarkupEntry 厂商级加价列表项
+type ProviderMarkupEntry struct {
+	Provider string `json:"provider"`
+	PricingMarkup
+}
+
+// ListProviderMarkups 列出全部厂商级加价（按厂商名排序）
+func (s *BillingService) ListProviderMarkups() []ProviderMarkupEntry {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ProviderMarkupEntry, 0, len(s.providerMarkups))
+	for provider, markup := range s.providerMarkups {
+		result = append(result, ProviderMarkupEntry{Provider: provider, PricingMarkup: *markup})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// EffectiveMarkup 获取模型实际生效的加价及来源：模型级 → 厂商级 → 全局（别名按规范模型名查找）。
+// 厂商按当前价格数据中的所属厂商查找，价格数据刷新后继承关系随之更新。
+func (s *BillingService) EffectiveMarkup(model string) (PricingMarkup, string) {
+	markup, source, _ := s.effectiveMarkup(model)
+	return markup, source
+}
+
+// effectiveMarkup 同 EffectiveMarkup，额外返回继承加价的厂商（仅来源为 provider 时非空）
+func (s *BillingService) effectiveMarkup(model string) (PricingMarkup, string, string) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	model, _ = s.ResolveModelAlias(model)
+
+	s.adminMu.RLock()
+	markup, ok := s.modelMarkups[model]
+	noProviders := len(s.providerMarkups) == 0
+	s.adminMu.RUnlock()
+	if ok {
+		return *markup, MarkupSourceModel, ""
+	}
+	if !noProviders {
+		if provider := strings.ToLower(s.modelProvider(model)); provider != "" {
+			s.adminMu.RLock()
+			markup, ok = s.providerMarkups[provider]
+			s.adminMu.RUnlock()
+			if ok {
+				return *markup, MarkupSourceProvider, provider
+			}
+		}
+	}
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	global := s.globalMarkupLocked()
+	if global.IsZero() {
+		return global, MarkupSourceNone, ""
+	}
+	return global, MarkupSourceGlobal, ""
+}
+
+// applyMarkup 先扣除承诺用量折扣并补足最低收费，再在此之上叠加加价：各费用项按百分比放大，输入/输出 token 另计固定加价。
+// 按次/图片计费（perRequest）只应用百分比；免费模型不加价。BaseCost 始终记录折扣后、补足最低收费与加价前的上游成本。
+func (s *BillingService) applyMarkup(model string, bd *CostBreakdown, tokens UsageTokens, rateMultiplier float64, perRequest bool) {
+	if bd == nil {
+		return
+	}
+	s.applyCommittedDiscount(model, bd)
+	bd.BaseCost = bd.TotalCost
+	bd.baseCostSet = true
+	if s == nil || bd.free {
+		return
+	}
+	s.applyMinCharge(model, bd, rateMultiplier)
+	markup, _ := s.EffectiveMarkup(model)
+	if markup.IsZero() {
+		return
+	}
+	if rateMultiplier < 0 {
+		rateMultiplier = 0
+	}
+
+	factor := 1 + markup.Percent/100
+	if perRequest {
+		bd.TotalCost *= factor
+		bd.MinChargeTopUp *= factor
+		bd.ActualCost = bd.TotalCost * rateMultiplier
+		return
+	}
+
+	flatPerToken := markup.FlatPerMTok / 1_000_000
+	textOutputTokens := tokens.OutputTokens - tokens.ImageOutputTokens
+	if textOutputTokens < 0 {
+		textOutputTokens = 0
+	}
+	bd.InputCost = bd.InputCost*factor + float64(tokens.InputTokens)*flatPerToken
+	bd.OutputCost = bd.OutputCost*factor + float64(textOutputTokens)*flatPerToken
+	bd.ImageOutputCost = bd.ImageOutputCost*factor + float64(tokens.ImageOutputTokens)*flatPerToken
+	bd.CacheCreationCost *= factor
+	bd.CacheReadCost *= factor
+	bd.CacheReadFullCost *= factor
+	bd.MinChargeTopUp *= factor
+	bd.TotalCost = bd.InputCost + bd.OutputCost + bd.ImageOutputCost +
+		bd.CacheCreationCost + bd.CacheReadCost + bd.MinChargeTopUp
+	bd.ActualCost = bd.TotalCost * rateMultiplier
+}
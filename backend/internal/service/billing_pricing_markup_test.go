@@ -0,0 +1,174 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateCost_NoMarkupKeepsBaseCost(t *testing.T) {
+	svc := newTestBillingService()
+
+	cost, err := svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 1000, OutputTokens: 500}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, cost.TotalCost, cost.BaseCost, 1e-12)
+	require.InDelta(t, cost.TotalCost, cost.UpstreamCost(), 1e-12)
+}
+
+func TestCalculateCost_GlobalMarkupFromConfig(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MarkupPercent = 20
+	cfg.Pricing.MarkupFlatPerMTok = 1
+	svc := NewBillingService(cfg, nil)
+
+	tokens := UsageTokens{InputTokens: 1_000_000, OutputTokens: 1_000_000, CacheReadTokens: 1_000_000}
+	cost, err := svc.CalculateCost("claude-sonnet-4", tokens, 2.0)
+	require.NoError(t, err)
+
+	// 基础：输入 $3 + 输出 $15 + 缓存读取 $0.3
+	require.InDelta(t, 18.3, cost.BaseCost, 1e-9)
+	require.InDelta(t, 18.3, cost.UpstreamCost(), 1e-9)
+	// 百分比作用于所有费用项，固定加价只作用于输入/输出
+	require.InDelta(t, 3*1.2+1, cost.InputCost, 1e-9)
+	require.InDelta(t, 15*1.2+1, cost.OutputCost, 1e-9)
+	require.InDelta(t, 0.3*1.2, cost.CacheReadCost, 1e-9)
+	require.InDelta(t, 18.3*1.2+2, cost.TotalCost, 1e-9)
+	require.InDelta(t, (18.3*1.2+2)*2, cost.ActualCost, 1e-9)
+
+	markup, source := svc.EffectiveMarkup("claude-sonnet-4")
+	require.Equal(t, MarkupSourceGlobal, source)
+	require.InDelta(t, 20, markup.Percent, 0)
+}
+
+func TestCalculateCost_ModelMarkupOverridesGlobal(t *testing.T) {
+	svc := newTestBillingService()
+	_, err := svc.SetGlobalMarkup(10, 0)
+	require.NoError(t, err)
+	_, err = svc.SetModelMarkup("Claude-Sonnet-4", 50, 0)
+	require.NoError(t, err)
+
+	tokens := UsageTokens{InputTokens: 1_000_000}
+	cost, err := svc.CalculateCost("claude-sonnet-4", tokens, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 4.5, cost.TotalCost, 1e-9)
+
+	// 别名使用规范模型的加价
+	_, err = svc.SetModelAlias("sonnet", "claude-sonnet-4")
+	require.NoError(t, err)
+	_, source := svc.EffectiveMarkup("sonnet")
+	require.Equal(t, MarkupSourceModel, source)
+
+	removed, err := svc.RemoveModelMarkup("claude-sonnet-4")
+	require.NoError(t, err)
+	require.True(t, removed)
+	cost, err = svc.CalculateCost("claude-sonnet-4", tokens, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 3.3, cost.TotalCost, 1e-9)
+	require.InDelta(t, 3.0, cost.BaseCost, 1e-9)
+}
+
+func TestCalculateImageCost_AppliesMarkupPercentOnly(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MarkupPercent = 50
+	cfg.Pricing.MarkupFlatPerMTok = 5
+	svc := NewBillingService(cfg, nil)
+
+	price := 0.2
+	cost := svc.CalculateImageCost("gemini-3-pro-image", "2K", 2, &ImagePriceConfig{Price2K: &price}, 1.0)
+	require.InDelta(t, 0.4, cost.BaseCost, 1e-9)
+	require.InDelta(t, 0.6, cost.TotalCost, 1e-9)
+	require.InDelta(t, 0.6, cost.ActualCost, 1e-9)
+}
+
+func TestEstimateCost_UsesChargedCost(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+	_, err := svc.SetModelMarkup("gpt-4o", 100, 0)
+	require.NoError(t, err)
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 1000, OutputTokens: 1000})
+	require.NoError(t, err)
+	require.InDelta(t, 1000*2.5e-6+1000*1e-5, estimate.BaseCost, 1e-12)
+	require.InDelta(t, 2*(1000*2.5e-6+1000*1e-5), estimate.TotalCost, 1e-12)
+	require.InDelta(t, 2*1000*2.5e-6, estimate.Breakdown.InputCost, 1e-12)
+}
+
+func TestPricingMarkup_RejectsNegativeAndPersists(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.MarkupPercent = 5
+
+	svc := NewBillingService(cfg, nil)
+	_, err := svc.SetGlobalMarkup(-1, 0)
+	require.Error(t, err)
+	_, err = svc.SetModelMarkup("gpt-4o", 0, -1)
+	require.Error(t, err)
+	_, err = svc.SetModelMarkup("", 10, 0)
+	require.Error(t, err)
+
+	require.InDelta(t, 5, svc.GetGlobalMarkup().Percent, 0)
+	_, err = svc.SetGlobalMarkup(15, 0.5)
+	require.NoError(t, err)
+	_, err = svc.SetModelMarkup("gpt-4o", 30, 0)
+	require.NoError(t, err)
+
+	restarted := NewBillingService(cfg, nil)
+	global := restarted.GetGlobalMarkup()
+	require.InDelta(t, 15, global.Percent, 0)
+	require.InDelta(t, 0.5, global.FlatPerMTok, 0)
+	models := restarted.ListModelMarkups()
+	require.Len(t, models, 1)
+	require.Equal(t, "gpt-4o", models[0].Model)
+	require.InDelta(t, 30, models[0].Percent, 0)
+}
+
+func TestCostBreakdown_UpstreamCostFallsBackToTotal(t *testing.T) {
+	bd := &CostBreakdown{TotalCost: 1.5}
+	require.InDelta(t, 1.5, bd.UpstreamCost(), 0)
+	var nilBD *CostBreakdown
+	require.Zero(t, nilBD.UpstreamCost())
+}
+
+func TestEffectiveMarkup_ProviderInheritance(t *testing.T) {
+	pricingSvc := &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o":      {InputCostPerToken: 1e-6, LiteLLMProvider: "openai"},
+		"gpt-4o-mini": {InputCostPerToken: 1e-6, LiteLLMProvider: "openai"},
+	}}
+	svc := NewBillingService(&config.Config{}, pricingSvc)
+	_, err := svc.SetGlobalMarkup(10, 0)
+	require.NoError(t, err)
+	_, err = svc.SetProviderMarkup("OpenAI", 30, 0)
+	require.NoError(t, err)
+	_, err = svc.SetModelMarkup("gpt-4o-mini", 50, 0)
+	require.NoError(t, err)
+
+	markup, source := svc.EffectiveMarkup("gpt-4o")
+	require.Equal(t, MarkupSourceProvider, source)
+	require.InDelta(t, 30, markup.Percent, 0)
+	_, source = svc.EffectiveMarkup("gpt-4o-mini")
+	require.Equal(t, MarkupSourceModel, source)
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 1_000_000})
+	require.NoError(t, err)
+	require.Equal(t, MarkupSourceProvider, estimate.Stages.MarkupSource)
+	require.Equal(t, "openai", estimate.Stages.MarkupProvider)
+	require.InDelta(t, 1.3, estimate.TotalCost, 1e-9)
+
+	// 价格数据刷新后模型归属变化，继承关系随之更新
+	pricingSvc.mu.Lock()
+	pricingSvc.pricingData["gpt-4o"] = &LiteLLMModelPricing{InputCostPerToken: 1e-6, LiteLLMProvider: "azure"}
+	pricingSvc.mu.Unlock()
+	svc.pricingCache.invalidate()
+	cost, err := svc.CalculateCost("gpt-4o", UsageTokens{InputTokens: 1_000_000}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 1.1, cost.TotalCost, 1e-9)
+
+	removed, err := svc.RemoveProviderMarkup("openai")
+	require.NoError(t, err)
+	require.True(t, removed)
+	require.Empty(t, svc.ListProviderMarkups())
+}
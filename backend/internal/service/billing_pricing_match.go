@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PricingMatchType 模型定价的匹配方式
+type PricingMatchType string
+
+const (
+	// PricingMatchExact 模型名精确命中价格数据或管理员覆盖
+	PricingMatchExact PricingMatchType = "exact"
+	// PricingMatchFuzzy 通过去日期后缀/厂商前缀、模型系列等规则近似匹配
+	PricingMatchFuzzy PricingMatchType = "fuzzy"
+	// PricingMatchDefault 未匹配到任何价格，使用配置的默认价格
+	PricingMatchDefault PricingMatchType = "default"
+)
+
+// pricingDateSuffixPattern 匹配模型名末尾的日期版本（-2024-08-06 / -20240806 / @20240806）
+var pricingDateSuffixPattern = regexp.MustCompile(`(?:-\d{4}-\d{2}-\d{2}|-\d{8}|@\d{8})$`)
+
+// normalizeModelNameForFuzzyMatch 去掉厂商前缀（如 anthropic/）与日期后缀
+// gpt-4o-2024-08-06 -> gpt-4o
+// anthropic/claude-3-5-sonnet-20241022 -> claude-3-5-sonnet
+func normalizeModelNameForFuzzyMatch(model string) string {
+	model = strings.ToLower(strings.TrimSpace(model))
+	model = lastSegment(model)
+	return pricingDateSuffixPattern.ReplaceAllString(model, "")
+}
+
+// FuzzyModelMatchingEnabled 是否启用模糊模型匹配
+func (s *BillingService) FuzzyModelMatchingEnabled() bool {
+	return !s.fuzzyMatchDisabled.Load()
+}
+
+// SetFuzzyModelMatching 运行时开启/关闭模糊模型匹配
+func (s *BillingService) SetFuzzyModelMatching(enabled bool) {
+	s.fuzzyMatchDisabled.Store(!enabled)
+}
+
+// MatchModelPricing 查询模型定价并返回匹配方式。
+// 匹配顺序：管理员覆盖 -> 价格数据精确命中 -> 去日期/厂商前缀后命中 -> 系列/回退价格 -> 默认价格（如已配置）。
+// 别名先解析为规范模型名再匹配（别名命中视为精确匹配）。
+// strict 为 true 时只允许前两步的精确匹配；已禁用的模型返回 ErrPricingModelDisabled，
+// 所属厂商已禁用时返回 ErrPricingProviderDisabled。
+// 结果按 (模型名, strict) 缓存在内存中，价格数据或管理员价格状态变化后失效。
+func (s *BillingService) MatchModelPricing(model string, strict bool) (*ModelPricing, PricingMatchType, error) {
+	// 标准化模型名称（转小写）
+	model = strings.ToLower(strings.TrimSpace(model))
+	key := pricingCacheKey{model: model, strict: strict}
+
+	var (
+		pricing   *ModelPricing
+		matchType PricingMatchType
+		err       error
+	)
+	for attempt := 0; attempt < pricingCacheMaxAttempts; attempt++ {
+		gen, dataVersion := s.pricingCache.generation(), s.pricingDataVersion()
+		if cached, cachedType, ok := s.pricingCache.get(key, dataVersion); ok {
+			return cached, cachedType, nil
+		}
+		pricing, matchType, err = s.matchModelPricingUncached(model, strict)
+		// 解析期间价格数据或管理员状态发生变化时重新解析，避免返回新旧混杂的定价
+		if gen != s.pricingCache.generation() || dataVersion != s.pricingDataVersion() {
+			continue
+		}
+		if err == nil {
+			s.pricingCache.put(key, gen, dataVersion, pricing, matchType)
+		}
+		break
+	}
+	return pricing, matchType, err
+}
+
+// matchModelPricingUncached MatchModelPricing 的实际解析逻辑（model 已标准化）
+func (s *BillingService) matchModelPricingUncached(model string, strict bool) (*ModelPricing, PricingMatchType, error) {
+	model, _ = s.ResolveModelAlias(model)
+	if s.IsModelDisabled(model) {
+		return nil, "", ErrPricingModelDisabled
+	}
+	if s.isModelProviderDisabled(model) {
+		return nil, "", ErrPricingProviderDisabled
+	}
+	return s.matchCatalogPricing(model, strict)
+}
+
+// billingModelPricing 计费使用的模型定价。禁用模型/厂商由网关在路由前拦截新请求，
+// 已放行（如禁用前已在途）的请求仍按目录价格计费，不因禁用状态记为 0 元。
+func (s *BillingService) billingModelPricing(model string) (*ModelPricing, error) {
+	pricing, err := s.GetModelPricing(model)
+	if !errors.Is(err, ErrPricingModelDisabled) && !errors.Is(err, ErrPricingProviderDisabled) {
+		return pricing, err
+	}
+	resolved, _ := s.ResolveModelAlias(strings.ToLower(strings.TrimSpace(model)))
+	pricing, _, err = s.matchCatalogPricing(strings.ToLower(resolved), !s.FuzzyModelMatchingEnabled())
+	return pricing, err
+}
+
+// matchCatalogPricing 按价格目录匹配定价，不检查禁用状态（model 已标准化并完成别名解析）
+func (s *BillingService) matchCatalogPricing(model string, strict bool) (*ModelPricing, PricingMatchType, error) {
+	// 1. 精确匹配
+	if pricing := s.exactModelPricing(model); pricing != nil {
+		return pricing, PricingMatchExact, nil
+	}
+	if strict {
+		return nil, "", fmt.Errorf("pricing not found for model: %s", model)
+	}
+
+	// 2. 去掉日期后缀与厂商前缀后再精确匹配
+	if normalized := normalizeModelNameForFuzzyMatch(model); normalized != "" && normalized != model {
+		if pricing := s.exactModelPricing(normalized); pricing != nil {
+			return pricing, PricingMatchFuzzy, nil
+		}
+	}
+
+	// 3. 动态价格的模糊匹配与硬编码回退价格
+	pricing, err := s.resolveModelPricing(model)
+	if err != nil {
+		// 4. 未知模型按默认价格计费，避免上游新增模型在目录更新前被少收
+		if fallback := s.defaultModelPricing(); fallback != nil {
+			return fallback, PricingMatchDefault, nil
+		}
+		return nil, "", err
+	}
+	return pricing, PricingMatchFuzzy, nil
+}
+
+// exactModelPricing 按模型名精确匹配（管理员覆盖叠加在价格数据之上）
+func (s *BillingService) exactModelPricing(model string) *ModelPricing {
+	var base *ModelPricing
+	if s.pricingService != nil {
+		if litellmPricing := s.pricingService.GetExactModelPricing(model); litellmPricing != nil {
+			base = s.applyModelSpecificPricingPolicy(model, litellmToModelPricing(litellmPricing))
+		}
+	}
+
+	if override := s.GetPricingOverride(model); override != nil {
+		if override.Base != nil {
+			base = s.applyModelSpecificPricingPolicy(model, litellmToModelPricing(override.Base))
+		} else if base == nil {
+			// 覆盖项不在价格数据中时，沿用模糊/回退价格作为未覆盖字段的基础
+			base, _ = s.resolveModelPricing(model)
+		}
+		return applyPricingOverride(base, override)
+	}
+	return base
+}
@@ -0,0 +1,61 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBillingServiceWithPricing(data map[string]*LiteLLMModelPricing) *BillingService {
+	pricingSvc := &PricingService{pricingData: data}
+	return NewBillingService(&config.Config{}, pricingSvc)
+}
+
+func TestNormalizeModelNameForFuzzyMatch(t *testing.T) {
+	cases := map[string]string{
+		"gpt-4o-2024-08-06":                    "gpt-4o",
+		"anthropic/claude-3-5-sonnet-20241022": "claude-3-5-sonnet",
+		"claude-3-5-sonnet@20241022":           "claude-3-5-sonnet",
+		"GPT-4o":                               "gpt-4o",
+		"gpt-4o":                               "gpt-4o",
+	}
+	for input, expected := range cases {
+		require.Equal(t, expected, normalizeModelNameForFuzzyMatch(input), input)
+	}
+}
+
+func TestMatchModelPricing_ExactAndFuzzy(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+	})
+
+	pricing, matchType, err := svc.MatchModelPricing("gpt-4o", false)
+	require.NoError(t, err)
+	require.Equal(t, PricingMatchExact, matchType)
+	require.InDelta(t, 2.5e-6, pricing.InputPricePerToken, 1e-12)
+
+	pricing, matchType, err = svc.MatchModelPricing("openai/gpt-4o-2024-08-06", false)
+	require.NoError(t, err)
+	require.Equal(t, PricingMatchFuzzy, matchType)
+	require.InDelta(t, 1e-5, pricing.OutputPricePerToken, 1e-12)
+}
+
+func TestMatchModelPricing_StrictRejectsFuzzy(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5},
+	})
+
+	_, _, err := svc.MatchModelPricing("gpt-4o-2024-08-06", true)
+	require.Error(t, err)
+
+	svc.SetFuzzyModelMatching(false)
+	_, err = svc.GetModelPricing("gpt-4o-2024-08-06")
+	require.Error(t, err)
+
+	svc.SetFuzzyModelMatching(true)
+	_, err = svc.GetModelPricing("gpt-4o-2024-08-06")
+	require.NoError(t, err)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MinCharge 来源
+const (
+	MinChargeSourceNone    = "none"
+	MinChargeSourceDefault = "default"
+	MinChargeSourceModel   = "model"
+)
+
+// ModelMinChargeEntry 模型级最低收费列表项
+type ModelMinChargeEntry struct {
+	Model     string  `json:"model"`
+	MinCharge float64 `json:"min_charge"`
+}
+
+// DefaultMinCharge 全局默认最低收费（USD，0 表示不限制）
+func (s *BillingService) DefaultMinCharge() float64 {
+	if s == nil || s.cfg == nil || s.cfg.Pricing.MinCharge <= 0 {
+		return 0
+	}
+	return s.cfg.Pricing.MinCharge
+}
+
+func validateMinCharge(minCharge float64) error {
+	if minCharge < 0 {
+		return fmt.Errorf("min charge must be non-negative")
+	}
+	return nil
+}
+
+// SetModelMinCharge 设置（或替换）模型级最低收费并持久化；0 表示该模型不限制（覆盖全局默认）
+func (s *BillingService) SetModelMinCharge(model string, minCharge float64) error {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	if err := validateMinCharge(minCharge); err != nil {
+		return err
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.modelMinCharges[model]
+	s.modelMinCharges[model] = minCharge
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.modelMinCharges[model] = prev
+		} else {
+			delete(s.modelMinCharges, model)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveModelMinCharge 删除模型级最低收费（回退到全局默认），返回是否存在
+func (s *BillingService) RemoveModelMinCharge(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.modelMinCharges[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.modelMinCharges, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.modelMinCharges[model] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ListModelMinCharges 列出全部模型级最低收费（按模型名排序）
+func (s *BillingService) ListModelMinCharges() []ModelMinChargeEntry {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ModelMinChargeEntry, 0, len(s.modelMinCharges))
+	for model, minCharge := range s.modelMinCharges {
+		result = append(result, ModelMinChargeEntry{Model: model, MinCharge: minCharge})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Model < result[j].Model })
+	return result
+}
+
+// EffectiveMinCharge 获取模型实际生效的最低收费及来源（别名按规范模型名查找）
+func (s *BillingService) EffectiveMinCharge(model string) (float64, string) {
+	if s == nil {
+		return 0, MinChargeSourceNone
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	model, _ = s.ResolveModelAlias(model)
+
+	s.adminMu.RLock()
+	minCharge, ok := s.modelMinCharges[model]
+	s.adminMu.RUnlock()
+	if ok {
+		return minCharge, MinChargeSourceModel
+	}
+	if minCharge = s.DefaultMinCharge(); minCharge > 0 {
+		return minCharge, MinChargeSourceDefault
+	}
+	return 0, MinChargeSourceNone
+}
+
+// applyMinCharge 计费费用（加价前、未乘倍率）低于最低收费时补足差额，差额记入 MinChargeTopUp。
+// 仅对产生费用的请求生效：费用为 0（无 token 用量）或免费模型不收取最低费用。
+func (s *BillingService) applyMinCharge(model string, bd *CostBreakdown, rateMultiplier float64) {
+	if s == nil || bd.free || bd.TotalCost <= 0 {
+		return
+	}
+	minCharge, _ := s.EffectiveMinCharge(model)
+	if bd.TotalCost >= minCharge {
+		return
+	}
+	if rateMultiplier < 0 {
+		rateMultiplier = 0
+	}
+	bd.MinChargeTopUp = minCharge - bd.TotalCost
+	bd.TotalCost = minCharge
+	bd.ActualCost = bd.TotalCost * rateMultiplier
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveMinCharge_ModelOverridesDefault(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MinCharge = 0.001
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{}})
+
+	minCharge, source := svc.EffectiveMinCharge("gpt-4o")
+	require.Equal(t, 0.001, minCharge)
+	require.Equal(t, MinChargeSourceDefault, source)
+
+	require.Error(t, svc.SetModelMinCharge("gpt-4o", -1))
+	require.NoError(t, svc.SetModelMinCharge("GPT-4o", 0))
+	minCharge, source = svc.EffectiveMinCharge("gpt-4o")
+	require.Zero(t, minCharge)
+	require.Equal(t, MinChargeSourceModel, source)
+	require.Equal(t, []ModelMinChargeEntry{{Model: "gpt-4o", MinCharge: 0}}, svc.ListModelMinCharges())
+
+	removed, err := svc.RemoveModelMinCharge("gpt-4o")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = svc.RemoveModelMinCharge("gpt-4o")
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	cfg.Pricing.MinCharge = 0
+	minCharge, source = svc.EffectiveMinCharge("gpt-4o")
+	require.Zero(t, minCharge)
+	require.Equal(t, MinChargeSourceNone, source)
+}
+
+func TestCalculateCost_MinChargeAppliedBeforeMarkup(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MarkupPercent = 10
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6, LiteLLMProvider: "openai"},
+	}})
+	require.NoError(t, svc.SetModelMinCharge("gpt-4o", 0.01))
+
+	tokens := UsageTokens{InputTokens: 100, OutputTokens: 50}
+	tokenCost := 100*2e-6 + 50*8e-6
+	cost, err := svc.CalculateCost("gpt-4o", tokens, 2)
+	require.NoError(t, err)
+	require.InDelta(t, tokenCost, cost.UpstreamCost(), 1e-12)
+	require.InDelta(t, (0.01-tokenCost)*1.1, cost.MinChargeTopUp, 1e-12)
+	require.InDelta(t, 0.01*1.1, cost.TotalCost, 1e-12)
+	require.InDelta(t, 0.01*1.1*2, cost.ActualCost, 1e-12)
+
+	// 无 token 用量不收取最低费用
+	cost, err = svc.CalculateCost("gpt-4o", UsageTokens{}, 1)
+	require.NoError(t, err)
+	require.Zero(t, cost.TotalCost)
+	require.Zero(t, cost.MinChargeTopUp)
+
+	// 费用高于最低收费时不补足
+	cost, err = svc.CalculateCost("gpt-4o", UsageTokens{InputTokens: 10000, OutputTokens: 1000}, 1)
+	require.NoError(t, err)
+	require.Zero(t, cost.MinChargeTopUp)
+	require.InDelta(t, (10000*2e-6+1000*8e-6)*1.1, cost.TotalCost, 1e-12)
+}
+
+func TestEstimateCost_MinChargeStages(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.MarkupPercent = 10
+	cfg.Pricing.MinCharge = 0.01
+	svc := NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6, LiteLLMProvider: "openai"},
+	}})
+
+	estimate, err := svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 100, OutputTokens: 50})
+	require.NoError(t, err)
+
+	tokenCost := 100*2e-6 + 50*8e-6
+	stages := estimate.Stages
+	require.Equal(t, 0.01, stages.MinCharge)
+	require.Equal(t, MinChargeSourceDefault, stages.MinChargeSource)
+	require.True(t, stages.MinChargeApplied)
+	require.InDelta(t, 0.01-tokenCost, stages.MinChargeTopUp, 1e-12)
+	require.InDelta(t, 0.001, stages.MarkupAmount, 1e-12)
+	require.InDelta(t, 0.011, estimate.TotalCost, 1e-12)
+	require.InDelta(t, tokenCost, estimate.BaseCost, 1e-12)
+	breakdown := estimate.Breakdown
+	require.InDelta(t, estimate.TotalCost,
+		breakdown.InputCost+breakdown.OutputCost+breakdown.MinChargeTopUp, 1e-12)
+
+	estimate, err = svc.EstimateCost(CostEstimateInput{Model: "gpt-4o", InputTokens: 10000, OutputTokens: 1000})
+	require.NoError(t, err)
+	require.False(t, estimate.Stages.MinChargeApplied)
+	require.Zero(t, estimate.Stages.MinChargeTopUp)
+}
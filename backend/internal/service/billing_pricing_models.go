@@ -0,0 +1,41 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// CatalogModel 价格目录中对客户端可见的模型
+type CatalogModel struct {
+	ID       string
+	Provider string
+	Created  time.Time // 价格数据最后更新时间，未知时为零值
+}
+
+// ListCatalogModels 返回价格目录中可用的模型（排除已禁用模型及已禁用厂商的模型），
+// 仅保留 apiKey 允许访问的模型；provider 非空时只返回该厂商的模型。按模型名排序。
+func (s *BillingService) ListCatalogModels(apiKey *APIKey, provider string) []CatalogModel {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+
+	allPricing := s.GetAllPricing()
+	out := make([]CatalogModel, 0, len(allPricing))
+	for model, info := range allPricing {
+		if info.Disabled {
+			continue
+		}
+		modelProvider := strings.ToLower(info.Provider)
+		if provider != "" && modelProvider != provider {
+			continue
+		}
+		if modelProvider != "" && s.IsProviderDisabled(modelProvider) {
+			continue
+		}
+		if !apiKey.IsModelAllowed(model) {
+			continue
+		}
+		out = append(out, CatalogModel{ID: model, Provider: modelProvider, Created: info.LastUpdated})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
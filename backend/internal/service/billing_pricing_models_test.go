@@ -0,0 +1,48 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func catalogModelIDs(models []CatalogModel) []string {
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func TestListCatalogModels_ExcludesDisabledAndFiltersByKey(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":          {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+		"gpt-4o-mini":     {InputCostPerToken: 1.5e-7, OutputCostPerToken: 6e-7, LiteLLMProvider: "openai"},
+		"o1":              {InputCostPerToken: 1.5e-5, OutputCostPerToken: 6e-5, LiteLLMProvider: "openai"},
+		"claude-sonnet-4": {InputCostPerToken: 3e-6, OutputCostPerToken: 1.5e-5, LiteLLMProvider: "anthropic"},
+	})
+	_, err := svc.DisableModel("o1")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"claude-sonnet-4", "gpt-4o", "gpt-4o-mini"}, catalogModelIDs(svc.ListCatalogModels(nil, "")))
+	require.Equal(t, []string{"gpt-4o", "gpt-4o-mini"}, catalogModelIDs(svc.ListCatalogModels(nil, "OpenAI")))
+
+	key := &APIKey{AllowedModels: []string{"gpt-4o*", "claude-*"}, DeniedModels: []string{"gpt-4o-mini"}}
+	models := svc.ListCatalogModels(key, "")
+	require.Equal(t, []string{"claude-sonnet-4", "gpt-4o"}, catalogModelIDs(models))
+	require.Equal(t, "anthropic", models[0].Provider)
+}
+
+func TestListCatalogModels_ExcludesDisabledProvider(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":          {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+		"claude-sonnet-4": {InputCostPerToken: 3e-6, OutputCostPerToken: 1.5e-5, LiteLLMProvider: "anthropic"},
+	})
+	_, err := svc.DisableProvider("openai")
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = svc.EnableProvider("openai") })
+
+	require.Equal(t, []string{"claude-sonnet-4"}, catalogModelIDs(svc.ListCatalogModels(nil, "")))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PricingOverride 管理员手动设置的模型价格覆盖（per-token，USD）。
+// 覆盖价格在读取时叠加到远程/上传的价格数据之上，因此不受价格数据更新影响。
+type PricingOverride struct {
+	InputCostPerToken  float64   `json:"input_cost_per_token"`
+	OutputCostPerToken float64   `json:"output_cost_per_token"`
+	UpdatedAt          time.Time `json:"updated_at"`
+
+	// 通过克隆创建的覆盖：记录源模型及其完整价格数据，作为未覆盖字段的基础
+	ClonedFrom string               `json:"cloned_from,omitempty"`
+	Base       *LiteLLMModelPricing `json:"base,omitempty"`
+}
+
+// SetPricingOverride 设置（或替换）模型价格覆盖并持久化
+func (s *BillingService) SetPricingOverride(model string, inputCostPerToken, outputCostPerToken float64) (*PricingOverride, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if inputCostPerToken < 0 || outputCostPerToken < 0 {
+		return nil, fmt.Errorf("cost per token must be non-negative")
+	}
+
+	override := &PricingOverride{
+		InputCostPerToken:  inputCostPerToken,
+		OutputCostPerToken: outputCostPerToken,
+		UpdatedAt:          time.Now(),
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.overrides[model]
+	if existed && prev.Base != nil {
+		// 调整克隆模型的价格时保留克隆来源的其余定价字段
+		override.ClonedFrom = prev.ClonedFrom
+		override.Base = prev.Base
+	}
+	s.overrides[model] = override
+	if err := s.persistPricingStateLocked(); err != nil {
+		// 持久化失败时回滚内存状态，保持内存与磁盘一致
+		if existed {
+			s.overrides[model] = prev
+		} else {
+			delete(s.overrides, model)
+		}
+		return nil, err
+	}
+
+	cloned := *override
+	return &cloned, nil
+}
+
+// RemovePricingOverride 删除模型价格覆盖，返回是否存在该覆盖
+func (s *BillingService) RemovePricingOverride(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.overrides[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.overrides, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.overrides[model] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// GetPricingOverride 获取模型价格覆盖（不存在时返回 nil）
+func (s *BillingService) GetPricingOverride(model string) *PricingOverride {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	override, ok := s.overrides[model]
+	if !ok {
+		return nil
+	}
+	cloned := *override
+	return &cloned
+}
+
+// applyPricingOverride 将覆盖价格叠加到基础定价上；base 为 nil 时仅使用覆盖价格
+func applyPricingOverride(base *ModelPricing, override *PricingOverride) *ModelPricing {
+	pricing := &ModelPricing{}
+	if base != nil {
+		cloned := *base
+		pricing = &cloned
+	}
+	// 与渠道覆盖一致：priority 价格同步覆盖，避免 priority tier 仍按旧价计费；
+	// 克隆覆盖未调整的价格保留源模型的 priority 价格
+	if override.Base == nil || pricing.InputPricePerToken != override.InputCostPerToken {
+		pricing.InputPricePerTokenPriority = override.InputCostPerToken
+	}
+	if override.Base == nil || pricing.OutputPricePerToken != override.OutputCostPerToken {
+		pricing.OutputPricePerTokenPriority = override.OutputCostPerToken
+	}
+	pricing.InputPricePerToken = override.InputCostPerToken
+	pricing.OutputPricePerToken = override.OutputCostPerToken
+	pricing.IsFree = false // 覆盖价格优先于免费标记
+	return pricing
+}
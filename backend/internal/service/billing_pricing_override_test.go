@@ -0,0 +1,70 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetModelPricing_OverrideTakesPrecedenceOverFallback(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, err := svc.SetPricingOverride("Claude-Sonnet-4", 1e-6, 2e-6)
+	require.NoError(t, err)
+
+	pricing, err := svc.GetModelPricing("claude-sonnet-4")
+	require.NoError(t, err)
+	require.InDelta(t, 1e-6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 2e-6, pricing.OutputPricePerToken, 1e-12)
+	// 未覆盖的字段保留基础定价
+	require.InDelta(t, 0.3e-6, pricing.CacheReadPricePerToken, 1e-12)
+}
+
+func TestGetModelPricing_OverrideForUnknownModel(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, err := svc.GetModelPricing("my-private-model")
+	require.Error(t, err)
+
+	_, err = svc.SetPricingOverride("my-private-model", 1e-6, 3e-6)
+	require.NoError(t, err)
+
+	pricing, err := svc.GetModelPricing("my-private-model")
+	require.NoError(t, err)
+	require.InDelta(t, 3e-6, pricing.OutputPricePerToken, 1e-12)
+
+	all := svc.GetAllPricing()
+	require.Contains(t, all, "my-private-model")
+	require.True(t, all["my-private-model"].Overridden)
+}
+
+func TestSetPricingOverride_RejectsInvalidInput(t *testing.T) {
+	svc := newTestBillingService()
+
+	_, err := svc.SetPricingOverride("", 1e-6, 1e-6)
+	require.Error(t, err)
+	_, err = svc.SetPricingOverride("gpt-4o", -1, 1e-6)
+	require.Error(t, err)
+}
+
+func TestPricingOverride_PersistsAcrossRestart(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+
+	svc := NewBillingService(cfg, nil)
+	_, err := svc.SetPricingOverride("gpt-4o", 2e-6, 8e-6)
+	require.NoError(t, err)
+
+	restarted := NewBillingService(cfg, nil)
+	override := restarted.GetPricingOverride("gpt-4o")
+	require.NotNil(t, override)
+	require.InDelta(t, 8e-6, override.OutputCostPerToken, 1e-12)
+
+	removed, err := restarted.RemovePricingOverride("gpt-4o")
+	require.NoError(t, err)
+	require.True(t, removed)
+	require.Nil(t, NewBillingService(cfg, nil).GetPricingOverride("gpt-4o"))
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// ErrPricingProviderDisabled 模型所属厂商已被管理员整体禁用
+var ErrPricingProviderDisabled = infraerrors.Forbidden("PRICING_PROVIDER_DISABLED", "provider disabled")
+
+// PricingProviderStatus 厂商启用状态
+type PricingProviderStatus struct {
+	Name       string     `json:"name"`
+	Enabled    bool       `json:"enabled"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+// disabledProviderSet 已禁用厂商集合，由 BillingService 在状态变化时发布，供调度路径无锁读取
+var disabledProviderSet atomic.Pointer[map[string]struct{}]
+
+// isAccountProviderDisabled 调度路径使用：账号平台与已禁用厂商同名时不参与选择
+func isAccountProviderDisabled(account *Account) bool {
+	set := disabledProviderSet.Load()
+	if set == nil || account == nil {
+		return false
+	}
+	_, ok := (*set)[strings.ToLower(account.Platform)]
+	return ok
+}
+
+// DisableProvider 禁用厂商：其模型查询定价返回 ErrPricingProviderDisabled，同名平台账号不再被调度。返回是否发生变化
+func (s *BillingService) DisableProvider(provider string) (bool, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return false, fmt.Errorf("provider is required")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	if _, ok := s.disabledProviders[provider]; ok {
+		return false, nil
+	}
+	s.disabledProviders[provider] = time.Now()
+	if err := s.persistPricingStateLocked(); err != nil {
+		delete(s.disabledProviders, provider)
+		return false, err
+	}
+	s.publishDisabledProvidersLocked()
+	return true, nil
+}
+
+// EnableProvider 取消厂商禁用，返回是否发生变化
+func (s *BillingService) EnableProvider(provider string) (bool, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return false, fmt.Errorf("provider is required")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	disabledAt, ok := s.disabledProviders[provider]
+	if !ok {
+		return false, nil
+	}
+	delete(s.disabledProviders, provider)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.disabledProviders[provider] = disabledAt
+		return false, err
+	}
+	s.publishDisabledProvidersLocked()
+	return true, nil
+}
+
+// IsProviderDisabled 厂商是否已被禁用
+func (s *BillingService) IsProviderDisabled(provider string) bool {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	_, ok := s.disabledProviders[provider]
+	return ok
+}
+
+// ProviderStatuses 返回给定厂商及全部已禁用厂商的启用状态（按名称排序）
+func (s *BillingService) ProviderStatuses(providers []string) []PricingProviderStatus {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+
+	seen := make(map[string]struct{}, len(providers)+len(s.disabledProviders))
+	out := make([]PricingProviderStatus, 0, len(providers)+len(s.disabledProviders))
+	add := func(name string) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return
+		}
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		status := PricingProviderStatus{Name: name, Enabled: true}
+		if disabledAt, ok := s.disabledProviders[name]; ok {
+			status.Enabled = false
+			status.DisabledAt = &disabledAt
+		}
+		out = append(out, status)
+	}
+	for _, name := range providers {
+		add(name)
+	}
+	for name := range s.disabledProviders {
+		add(name)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// isModelProviderDisabled 模型在价格数据中所属的厂商是否已被禁用（无禁用厂商时直接返回）
+func (s *BillingService) isModelProviderDisabled(model string) bool {
+	s.adminMu.RLock()
+	none := len(s.disabledProviders) == 0
+	s.adminMu.RUnlock()
+	if none {
+		return false
+	}
+	provider := s.modelProvider(model)
+	return provider != "" && s.IsProviderDisabled(provider)
+}
+
+// modelProvider 按价格数据查找模型所属厂商（精确 -> 去日期/厂商前缀 -> 模糊），未知时返回空
+func (s *BillingService) modelProvider(model string) string {
+	if s.pricingService == nil {
+		return ""
+	}
+	if pricing := s.pricingService.GetExactModelPricing(model); pricing != nil {
+		return pricing.LiteLLMProvider
+	}
+	if normalized := normalizeModelNameForFuzzyMatch(model); normalized != "" && normalized != model {
+		if pricing := s.pricingService.GetExactModelPricing(normalized); pricing != nil {
+			return pricing.LiteLLMProvider
+		}
+	}
+	if pricing := s.pricingService.GetModelPricing(model); pricing != nil {
+		return pricing.LiteLLMProvider
+	}
+	return ""
+}
+
+// publishDisabledProvidersLocked 将已禁用厂商同步给调度路径（调用方需持有 adminMu）
+func (s *BillingService) publishDisabledProvidersLocked() {
+	set := make(map[string]struct{}, len(s.disabledProviders))
+	for name := range s.disabledProviders {
+		set[name] = struct{}{}
+	}
+	disabledProviderSet.Store(&set)
+}
@@ -0,0 +1,67 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableProvider_BlocksProviderModels(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":          {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+		"claude-sonnet-4": {InputCostPerToken: 3e-6, OutputCostPerToken: 1.5e-5, LiteLLMProvider: "anthropic"},
+	})
+	t.Cleanup(func() { _, _ = svc.EnableProvider("openai") })
+
+	changed, err := svc.DisableProvider("OpenAI")
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	_, err = svc.GetModelPricing("gpt-4o")
+	require.ErrorIs(t, err, ErrPricingProviderDisabled)
+	_, err = svc.GetModelPricing("gpt-4o-2024-08-06")
+	require.ErrorIs(t, err, ErrPricingProviderDisabled)
+	_, err = svc.GetModelPricing("claude-sonnet-4")
+	require.NoError(t, err)
+
+	require.True(t, isAccountProviderDisabled(&Account{Platform: PlatformOpenAI}))
+	require.False(t, isAccountProviderDisabled(&Account{Platform: PlatformAnthropic}))
+
+	// 网关入口拦截新请求，已放行的请求仍按目录价格计费
+	require.ErrorIs(t, svc.CheckModelEnabled("gpt-4o"), ErrPricingProviderDisabled)
+	require.NoError(t, svc.CheckModelEnabled("claude-sonnet-4"))
+	cost, err := svc.CalculateCost("gpt-4o", UsageTokens{InputTokens: 1000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 1000*2.5e-6, cost.ActualCost, 1e-12)
+
+	changed, err = svc.EnableProvider("openai")
+	require.NoError(t, err)
+	require.True(t, changed)
+	_, err = svc.GetModelPricing("gpt-4o")
+	require.NoError(t, err)
+	require.False(t, isAccountProviderDisabled(&Account{Platform: PlatformOpenAI}))
+}
+
+func TestDisableProvider_PersistsAcrossRestart(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+
+	svc := NewBillingService(cfg, nil)
+	_, err := svc.DisableProvider("anthropic")
+	require.NoError(t, err)
+
+	restarted := NewBillingService(cfg, nil)
+	t.Cleanup(func() { _, _ = restarted.EnableProvider("anthropic") })
+	require.True(t, restarted.IsProviderDisabled("anthropic"))
+	require.True(t, isAccountProviderDisabled(&Account{Platform: PlatformAnthropic}))
+
+	statuses := restarted.ProviderStatuses([]string{"openai"})
+	require.Len(t, statuses, 2)
+	require.Equal(t, "anthropic", statuses[0].Name)
+	require.False(t, statuses[0].Enabled)
+	require.NotNil(t, statuses[0].DisabledAt)
+	require.True(t, statuses[1].Enabled)
+}
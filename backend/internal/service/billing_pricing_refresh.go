@@ -0,0 +1,235 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+const (
+	pricingAutoRefreshDefaultInterval = 24 * time.Hour
+	pricingAutoRefreshDefaultJitter   = 30 * time.Minute
+)
+
+// PricingAutoRefreshStatus 价格数据定时刷新状态
+type PricingAutoRefreshStatus struct {
+	Running       bool       `json:"running"` // 调度器是否已启动
+	Paused        bool       `json:"paused"`
+	IntervalHours int        `json:"interval_hours"`
+	JitterMinutes int        `json:"jitter_minutes"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// pricingAutoRefresh 定时刷新调度状态；间隔与暂停状态可在运行时修改（不持久化）
+type pricingAutoRefresh struct {
+	mu            sync.Mutex
+	started       bool
+	paused        bool
+	interval      time.Duration
+	jitter        time.Duration
+	nextRunAt     time.Time // 零值表示需要重新计算
+	lastSuccessAt time.Time
+	lastFailureAt time.Time
+	lastError     string
+
+	wakeCh   chan struct{} // 间隔或暂停状态变化时唤醒调度循环
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newPricingAutoRefresh(cfg *config.Config) *pricingAutoRefresh {
+	r := &pricingAutoRefresh{
+		interval: pricingAutoRefreshDefaultInterval,
+		jitter:   pricingAutoRefreshDefaultJitter,
+		wakeCh:   make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+	}
+	if cfg != nil {
+		if cfg.Pricing.UpdateIntervalHours > 0 {
+			r.interval = time.Duration(cfg.Pricing.UpdateIntervalHours) * time.Hour
+		}
+		r.jitter = time.Duration(cfg.Pricing.AutoRefreshJitterMinutes) * time.Minute
+		r.paused = !cfg.Pricing.AutoRefreshEnabled
+	}
+	return r
+}
+
+// schedule 返回距离下次刷新的等待时间；暂停时 active 为 false
+func (r *pricingAutoRefresh) schedule(now time.Time) (wait time.Duration, active bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paused {
+		r.nextRunAt = time.Time{}
+		return 0, false
+	}
+	if r.nextRunAt.IsZero() {
+		next := now.Add(r.interval)
+		if r.jitter > 0 {
+			next = next.Add(rand.N(r.jitter))
+		}
+		r.nextRunAt = next
+	}
+	return r.nextRunAt.Sub(now), true
+}
+
+// record 记录一次定时刷新的结果并重新计算下次刷新时间
+func (r *pricingAutoRefresh) record(err error, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.lastFailureAt = at
+		r.lastError = err.Error()
+	} else {
+		r.lastSuccessAt = at
+		r.lastError = ""
+	}
+	r.nextRunAt = time.Time{}
+}
+
+// wake 通知调度循环重新计算下次刷新时间
+func (r *pricingAutoRefresh) wake() {
+	select {
+	case r.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *pricingAutoRefresh) status() PricingAutoRefreshStatus {
+	if r == nil {
+		return PricingAutoRefreshStatus{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := PricingAutoRefreshStatus{
+		Running:       r.started,
+		Paused:        r.paused,
+		IntervalHours: int(r.interval / time.Hour),
+		JitterMinutes: int(r.jitter / time.Minute),
+		LastError:     r.lastError,
+	}
+	if !r.nextRunAt.IsZero() {
+		next := r.nextRunAt
+		status.NextRunAt = &next
+	}
+	if !r.lastSuccessAt.IsZero() {
+		at := r.lastSuccessAt
+		status.LastSuccessAt = &at
+	}
+	if !r.lastFailureAt.IsZero() {
+		at := r.lastFailureAt
+		status.LastFailureAt = &at
+	}
+	return status
+}
+
+// StartPricingAutoRefresh 启动价格数据定时刷新（未启用价格服务时不启动）
+func (s *BillingService) StartPricingAutoRefresh() {
+	r := s.autoRefresh
+	if r == nil || s.pricingService == nil {
+		return
+	}
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return
+	}
+	r.started = true
+	interval, jitter, paused := r.interval, r.jitter, r.paused
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go s.pricingAutoRefreshLoop()
+	log.Printf("[Billing] Pricing auto refresh started (interval=%s jitter=%s paused=%v)", interval, jitter, paused)
+}
+
+// StopPricingAutoRefresh 停止定时刷新并等待进行中的刷新结束
+func (s *BillingService) StopPricingAutoRefresh() {
+	r := s.autoRefresh
+	if r == nil {
+		return
+	}
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	r.wg.Wait()
+}
+
+func (s *BillingService) pricingAutoRefreshLoop() {
+	r := s.autoRefresh
+	defer r.wg.Done()
+	for {
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if wait, active := r.schedule(time.Now()); active {
+			timer = time.NewTimer(wait)
+			fire = timer.C
+		}
+
+		select {
+		case <-fire:
+			s.runScheduledPricingRefresh()
+		case <-r.wakeCh:
+		case <-r.stopCh:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// runScheduledPricingRefresh 执行一次定时刷新；已有刷新（如手动 ForceUpdate）进行中时跳过本轮
+func (s *BillingService) runScheduledPricingRefresh() {
+	if !s.refreshMu.TryLock() {
+		log.Printf("[Billing] Scheduled pricing refresh skipped: another refresh is in progress")
+		s.autoRefresh.mu.Lock()
+		s.autoRefresh.nextRunAt = time.Time{}
+		s.autoRefresh.mu.Unlock()
+		return
+	}
+	defer s.refreshMu.Unlock()
+
+	err := s.refreshPricing()
+	s.autoRefresh.record(err, time.Now())
+	if err != nil {
+		log.Printf("[Billing] Scheduled pricing refresh failed: %v", err)
+	}
+}
+
+// GetPricingAutoRefreshStatus 获取定时刷新状态
+func (s *BillingService) GetPricingAutoRefreshStatus() PricingAutoRefreshStatus {
+	return s.autoRefresh.status()
+}
+
+// SetPricingAutoRefreshInterval 运行时修改定时刷新间隔（小时），下次刷新时间随之重新计算
+func (s *BillingService) SetPricingAutoRefreshInterval(hours int) error {
+	if hours <= 0 {
+		return fmt.Errorf("interval_hours must be positive")
+	}
+	r := s.autoRefresh
+	r.mu.Lock()
+	r.interval = time.Duration(hours) * time.Hour
+	r.nextRunAt = time.Time{}
+	r.mu.Unlock()
+	r.wake()
+	return nil
+}
+
+// SetPricingAutoRefreshPaused 运行时暂停/恢复定时刷新
+func (s *BillingService) SetPricingAutoRefreshPaused(paused bool) {
+	r := s.autoRefresh
+	r.mu.Lock()
+	r.paused = paused
+	r.nextRunAt = time.Time{}
+	r.mu.Unlock()
+	r.wake()
+}
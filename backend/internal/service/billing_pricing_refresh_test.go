@@ -0,0 +1,99 @@
+//go:build unit
+
+package service
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingAutoRefresh_RunsOnIntervalAndRecordsResult(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{})
+	var calls atomic.Int32
+	svc.refreshPricing = func() error {
+		if calls.Add(1) == 1 {
+			return errors.New("upstream unavailable")
+		}
+		return nil
+	}
+	svc.autoRefresh.interval = 10 * time.Millisecond
+	svc.autoRefresh.jitter = 0
+	svc.SetPricingAutoRefreshPaused(false)
+
+	svc.StartPricingAutoRefresh()
+	defer svc.StopPricingAutoRefresh()
+
+	require.Eventually(t, func() bool {
+		status := svc.GetPricingAutoRefreshStatus()
+		return status.LastFailureAt != nil && status.LastSuccessAt != nil
+	}, 2*time.Second, 5*time.Millisecond)
+
+	status := svc.GetPricingAutoRefreshStatus()
+	require.True(t, status.Running)
+	require.Empty(t, status.LastError, "a later success clears the last error")
+}
+
+func TestPricingAutoRefresh_PausedDoesNotRun(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{})
+	var calls atomic.Int32
+	svc.refreshPricing = func() error {
+		calls.Add(1)
+		return nil
+	}
+	svc.autoRefresh.interval = 5 * time.Millisecond
+	svc.autoRefresh.jitter = 0
+	svc.SetPricingAutoRefreshPaused(true)
+
+	svc.StartPricingAutoRefresh()
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, calls.Load())
+	require.Nil(t, svc.GetPricingAutoRefreshStatus().NextRunAt)
+
+	svc.SetPricingAutoRefreshPaused(false)
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, 2*time.Second, 5*time.Millisecond)
+	svc.StopPricingAutoRefresh()
+}
+
+func TestPricingAutoRefresh_SkipsWhileManualUpdateRunning(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{})
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	svc.refreshPricing = func() error {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- svc.ForceUpdatePricing() }()
+	<-started
+
+	svc.runScheduledPricingRefresh()
+	require.Equal(t, int32(1), calls.Load(), "scheduled refresh must not overlap a manual update")
+	require.Nil(t, svc.GetPricingAutoRefreshStatus().LastSuccessAt)
+
+	close(release)
+	require.NoError(t, <-done)
+
+	svc.runScheduledPricingRefresh()
+	require.Equal(t, int32(2), calls.Load())
+	require.NotNil(t, svc.GetPricingAutoRefreshStatus().LastSuccessAt)
+}
+
+func TestSetPricingAutoRefreshInterval(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{})
+
+	require.Error(t, svc.SetPricingAutoRefreshInterval(0))
+	require.NoError(t, svc.SetPricingAutoRefreshInterval(6))
+	require.Equal(t, 6, svc.GetPricingAutoRefreshStatus().IntervalHours)
+
+	status := svc.GetPricingServiceStatus()
+	require.Equal(t, 6, status["auto_refresh"].(PricingAutoRefreshStatus).IntervalHours)
+}
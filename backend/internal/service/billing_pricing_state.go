@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// pricingStateFileName 管理员价格状态文件名（与 model_pricing.json 同目录）
+const pricingStateFileName = "pricing_admin_state.json"
+
+// pricingAdminState 管理员维护的价格状态，独立于远程价格数据持久化，
+// 保证 ForceUpdate / ImportPricingData 不会清除这些手动配置。
+type pricingAdminState struct {
+	Overrides             map[string]*PricingOverride `json:"overrides,omitempty"`
+	DisabledModels        map[string]time.Time        `json:"disabled_models,omitempty"`
+	DisabledProviders     map[string]time.Time        `json:"disabled_providers,omitempty"`
+	Aliases               map[string]*ModelAlias      `json:"aliases,omitempty"`
+	GlobalMarkup          *PricingMarkup              `json:"global_markup,omitempty"`
+	DefaultPricing        *PricingOverride            `json:"default_pricing,omitempty"`
+	ModelMarkups          map[string]*PricingMarkup   `json:"model_markups,omitempty"`
+	ProviderMarkups       map[string]*PricingMarkup   `json:"provider_markups,omitempty"`
+	ModelTimeouts         map[string]int              `json:"model_timeouts,omitempty"`
+	ModelBatchMultipliers map[string]float64          `json:"model_batch_multipliers,omitempty"`
+	// 承诺用量折扣百分比
+	ModelCommittedDiscounts    map[string]float64 `json:"model_committed_discounts,omitempty"`
+	ProviderCommittedDiscounts map[string]float64 `json:"provider_committed_discounts,omitempty"`
+	// 模型级最低收费（USD）
+	ModelMinCharges map[string]float64 `json:"model_min_charges,omitempty"`
+}
+
+// pricingStateFilePath 获取状态文件路径；未配置数据目录时返回空（仅内存生效）
+func (s *BillingService) pricingStateFilePath() string {
+	if s.cfg == nil || strings.TrimSpace(s.cfg.Pricing.DataDir) == "" {
+		return ""
+	}
+	return filepath.Join(s.cfg.Pricing.DataDir, pricingStateFileName)
+}
+
+// loadPricingState 启动时从数据目录加载管理员价格状态
+func (s *BillingService) loadPricingState() {
+	path := s.pricingStateFilePath()
+	if path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[Billing] Failed to read pricing state file: %v", err)
+		}
+		return
+	}
+
+	var state pricingAdminState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("[Billing] Failed to parse pricing state file: %v", err)
+		return
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	for model, override := range state.Overrides {
+		if override == nil {
+			continue
+		}
+		s.overrides[strings.ToLower(model)] = override
+	}
+	for model, disabledAt := range state.DisabledModels {
+		s.disabledModels[strings.ToLower(model)] = disabledAt
+	}
+	for provider, disabledAt := range state.DisabledProviders {
+		s.disabledProviders[strings.ToLower(provider)] = disabledAt
+	}
+	for alias, entry := range state.Aliases {
+		if entry == nil || entry.Model == "" {
+			continue
+		}
+		s.aliases[strings.ToLower(alias)] = entry
+	}
+	s.globalMarkup = state.GlobalMarkup
+	s.defaultPricing = state.DefaultPricing
+	for model, markup := range state.ModelMarkups {
+		if markup == nil {
+			continue
+		}
+		s.modelMarkups[strings.ToLower(model)] = markup
+	}
+	for provider, markup := range state.ProviderMarkups {
+		if markup == nil {
+			continue
+		}
+		s.providerMarkups[strings.ToLower(provider)] = markup
+	}
+	for model, seconds := range state.ModelTimeouts {
+		if seconds <= 0 {
+			continue
+		}
+		s.modelTimeouts[strings.ToLower(model)] = seconds
+	}
+	for model, multiplier := range state.ModelBatchMultipliers {
+		if validateBatchMultiplier(multiplier) != nil {
+			continue
+		}
+		s.modelBatchMultipliers[strings.ToLower(model)] = multiplier
+	}
+	for model, percent := range state.ModelCommittedDiscounts {
+		if validateCommittedDiscount(percent) != nil {
+			continue
+		}
+		s.modelCommittedDiscounts[strings.ToLower(model)] = percent
+	}
+	for provider, percent := range state.ProviderCommittedDiscounts {
+		if validateCommittedDiscount(percent) != nil {
+			continue
+		}
+		s.providerCommittedDiscounts[strings.ToLower(provider)] = percent
+	}
+	for model, minCharge := range state.ModelMinCharges {
+		if validateMinCharge(minCharge) != nil {
+			continue
+		}
+		s.modelMinCharges[strings.ToLower(model)] = minCharge
+	}
+}
+
+// persistPricingStateLocked 将管理员价格状态写入磁盘并使内存定价缓存失效（调用方需持有 adminMu）
+func (s *BillingService) persistPricingStateLocked() error {
+	s.pricingCache.invalidate()
+	path := s.pricingStateFilePath()
+	if path == "" {
+		return nil
+	}
+
+	state := pricingAdminState{
+		Overrides:             s.overrides,
+		DisabledModels:        s.disabledModels,
+		DisabledProviders:     s.disabledProviders,
+		Aliases:               s.aliases,
+		GlobalMarkup:          s.globalMarkup,
+		DefaultPricing:        s.defaultPricing,
+		ModelMarkups:          s.modelMarkups,
+		ProviderMarkups:       s.providerMarkups,
+		ModelTimeouts:         s.modelTimeouts,
+		ModelBatchMultipliers: s.modelBatchMultipliers,
+
+		ModelCommittedDiscounts:    s.modelCommittedDiscounts,
+		ProviderCommittedDiscounts: s.providerCommittedDiscounts,
+		ModelMinCharges:            s.modelMinCharges,
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal pricing state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create pricing data dir: %w", err)
+	}
+	// 先写临时文件再重命名，避免进程中断时留下半截文件
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write pricing state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("replace pricing state: %w", err)
+	}
+	return nil
+}
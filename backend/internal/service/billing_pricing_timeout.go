@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// ErrModelTimeout 请求超过模型级超时
+var ErrModelTimeout = errors.New("model request timeout exceeded")
+
+// ModelTimeout 来源
+const (
+	ModelTimeoutSourceNone    = "none"
+	ModelTimeoutSourceDefault = "default"
+	ModelTimeoutSourceModel   = "model"
+)
+
+// ModelTimeoutEntry 模型级超时列表项
+type ModelTimeoutEntry struct {
+	Model          string `json:"model"`
+	TimeoutSeconds int    `json:"timeout_seconds"`
+}
+
+// DefaultModelTimeoutSeconds 全局默认请求超时（秒），0 表示不限制
+func (s *BillingService) DefaultModelTimeoutSeconds() int {
+	if s == nil || s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Gateway.ModelTimeoutSeconds
+}
+
+// HasModelTimeouts 是否配置了任何请求超时（全局默认或模型级）
+func (s *BillingService) HasModelTimeouts() bool {
+	if s == nil {
+		return false
+	}
+	if s.DefaultModelTimeoutSeconds() > 0 {
+		return true
+	}
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	return len(s.modelTimeouts) > 0
+}
+
+// SetModelTimeout 设置（或替换）模型级请求超时并持久化
+func (s *BillingService) SetModelTimeout(model string, seconds int) error {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	if seconds <= 0 {
+		return fmt.Errorf("timeout_seconds must be positive")
+	}
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, existed := s.modelTimeouts[model]
+	s.modelTimeouts[model] = seconds
+	if err := s.persistPricingStateLocked(); err != nil {
+		if existed {
+			s.modelTimeouts[model] = prev
+		} else {
+			delete(s.modelTimeouts, model)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveModelTimeout 删除模型级请求超时（回退到全局默认），返回是否存在
+func (s *BillingService) RemoveModelTimeout(model string) (bool, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+
+	s.adminMu.Lock()
+	defer s.adminMu.Unlock()
+	prev, ok := s.modelTimeouts[model]
+	if !ok {
+		return false, nil
+	}
+	delete(s.modelTimeouts, model)
+	if err := s.persistPricingStateLocked(); err != nil {
+		s.modelTimeouts[model] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// ListModelTimeouts 列出全部模型级请求超时（按模型名排序）
+func (s *BillingService) ListModelTimeouts() []ModelTimeoutEntry {
+	s.adminMu.RLock()
+	defer s.adminMu.RUnlock()
+	result := make([]ModelTimeoutEntry, 0, len(s.modelTimeouts))
+	for model, seconds := range s.modelTimeouts {
+		result = append(result, ModelTimeoutEntry{Model: model, TimeoutSeconds: seconds})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Model < result[j].Model })
+	return result
+}
+
+// EffectiveModelTimeout 获取模型实际生效的请求超时（秒）及来源（别名按规范模型名查找）
+func (s *BillingService) EffectiveModelTimeout(model string) (int, string) {
+	if s == nil {
+		return 0, ModelTimeoutSourceNone
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	model, _ = s.ResolveModelAlias(model)
+
+	s.adminMu.RLock()
+	seconds, ok := s.modelTimeouts[model]
+	s.adminMu.RUnlock()
+	if ok {
+		return seconds, ModelTimeoutSourceModel
+	}
+	if seconds := s.DefaultModelTimeoutSeconds(); seconds > 0 {
+		return seconds, ModelTimeoutSourceDefault
+	}
+	return 0, ModelTimeoutSourceNone
+}
+
+// modelTimeoutState 请求级模型超时状态
+type modelTimeoutState struct {
+	model   string
+	timeout time.Duration
+	ctx     context.Context // 带截止时间的请求上下文
+}
+
+// WithModelTimeout 为请求上下文设置模型级超时；timeout<=0 时原样返回
+func WithModelTimeout(ctx context.Context, model string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	deadlineCtx, cancel := context.WithTimeoutCause(ctx, timeout, ErrModelTimeout)
+	state := &modelTimeoutState{model: model, timeout: timeout, ctx: deadlineCtx}
+	return context.WithValue(deadlineCtx, ctxkey.ModelTimeout, state), cancel
+}
+
+// ModelTimeoutExceeded 请求是否因模型级超时而结束，返回模型名与超时时长
+func ModelTimeoutExceeded(ctx context.Context) (string, time.Duration, bool) {
+	state, ok := ctx.Value(ctxkey.ModelTimeout).(*modelTimeoutState)
+	if !ok || state == nil {
+		return "", 0, false
+	}
+	if !errors.Is(context.Cause(state.ctx), ErrModelTimeout) {
+		return "", 0, false
+	}
+	return state.model, state.timeout, true
+}
+
+// keepModelTimeout 上游上下文脱离客户端取消后仍需遵守模型级超时：
+// 仅在超时触发时取消 detached，客户端断开不影响
+func keepModelTimeout(ctx, detached context.Context) context.Context {
+	state, ok := ctx.Value(ctxkey.ModelTimeout).(*modelTimeoutState)
+	if !ok || state == nil {
+		return detached
+	}
+	out, cancel := context.WithCancelCause(detached)
+	context.AfterFunc(state.ctx, func() {
+		if errors.Is(context.Cause(state.ctx), ErrModelTimeout) {
+			cancel(ErrModelTimeout)
+		}
+	})
+	return out
+}
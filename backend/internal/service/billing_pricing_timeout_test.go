@@ -0,0 +1,110 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveModelTimeout_ModelOverridesDefault(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.ModelTimeoutSeconds = 60
+	svc := NewBillingService(cfg, nil)
+
+	seconds, source := svc.EffectiveModelTimeout("claude-sonnet-4")
+	require.Equal(t, 60, seconds)
+	require.Equal(t, ModelTimeoutSourceDefault, source)
+
+	require.NoError(t, svc.SetModelTimeout("O3-Pro", 900))
+	_, err := svc.SetModelAlias("o3p", "o3-pro")
+	require.NoError(t, err)
+
+	seconds, source = svc.EffectiveModelTimeout("o3-pro")
+	require.Equal(t, 900, seconds)
+	require.Equal(t, ModelTimeoutSourceModel, source)
+	// 别名按规范模型名查找
+	seconds, _ = svc.EffectiveModelTimeout("o3p")
+	require.Equal(t, 900, seconds)
+
+	removed, err := svc.RemoveModelTimeout("o3-pro")
+	require.NoError(t, err)
+	require.True(t, removed)
+	seconds, source = svc.EffectiveModelTimeout("o3-pro")
+	require.Equal(t, 60, seconds)
+	require.Equal(t, ModelTimeoutSourceDefault, source)
+
+	removed, err = svc.RemoveModelTimeout("o3-pro")
+	require.NoError(t, err)
+	require.False(t, removed)
+}
+
+func TestSetModelTimeout_Validation(t *testing.T) {
+	svc := newTestBillingService()
+	require.False(t, svc.HasModelTimeouts())
+
+	require.Error(t, svc.SetModelTimeout("", 10))
+	require.Error(t, svc.SetModelTimeout("gpt-5", 0))
+	require.Error(t, svc.SetModelTimeout("gpt-5", -1))
+
+	seconds, source := svc.EffectiveModelTimeout("gpt-5")
+	require.Zero(t, seconds)
+	require.Equal(t, ModelTimeoutSourceNone, source)
+}
+
+func TestModelTimeouts_PersistedAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = dir
+	svc := NewBillingService(cfg, nil)
+	require.NoError(t, svc.SetModelTimeout("o3-pro", 600))
+	_, err := os.Stat(filepath.Join(dir, pricingStateFileName))
+	require.NoError(t, err)
+
+	reloaded := NewBillingService(cfg, nil)
+	require.True(t, reloaded.HasModelTimeouts())
+	require.Equal(t, []ModelTimeoutEntry{{Model: "o3-pro", TimeoutSeconds: 600}}, reloaded.ListModelTimeouts())
+}
+
+func TestDetachUpstreamContext_KeepsModelTimeout(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	defer cancelParent()
+	ctx, cancel := WithModelTimeout(parent, "o3-pro", 20*time.Millisecond)
+	defer cancel()
+
+	upstreamCtx, release := detachUpstreamContext(ctx)
+	release()
+
+	select {
+	case <-upstreamCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("detached upstream context should be canceled by model timeout")
+	}
+	require.ErrorIs(t, context.Cause(upstreamCtx), ErrModelTimeout)
+
+	model, timeout, exceeded := ModelTimeoutExceeded(ctx)
+	require.True(t, exceeded)
+	require.Equal(t, "o3-pro", model)
+	require.Equal(t, 20*time.Millisecond, timeout)
+}
+
+func TestDetachUpstreamContext_ClientCancelDoesNotAbortUpstream(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := WithModelTimeout(parent, "o3-pro", time.Minute)
+	defer cancel()
+
+	upstreamCtx, release := detachStreamUpstreamContext(ctx, true)
+	release()
+	cancelParent()
+
+	<-ctx.Done()
+	require.NoError(t, upstreamCtx.Err())
+	_, _, exceeded := ModelTimeoutExceeded(ctx)
+	require.False(t, exceeded)
+}
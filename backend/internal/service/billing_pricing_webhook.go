@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+)
+
+const (
+	// PricingWebhookSignatureHeader 请求体的 HMAC-SHA256 签名（sha256=<hex>）
+	PricingWebhookSignatureHeader = "X-Pricing-Signature"
+
+	pricingWebhookMaxAttempts = 3
+	pricingWebhookTimeout     = 10 * time.Second
+)
+
+// pricingWebhookRetryBaseDelay 重试退避基础时间（第 n 次重试等待 base * 2^(n-1)）
+var pricingWebhookRetryBaseDelay = time.Second
+
+// PricingDiffSummary 价格变更摘要（仅模型名，避免 webhook 负载过大）
+type PricingDiffSummary struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// PricingWebhookPayload 价格变更 webhook 负载
+type PricingWebhookPayload struct {
+	Event        string             `json:"event"`
+	Source       string             `json:"source"` // force_update / import / merge
+	ChangedCount int                `json:"changed_count"`
+	Summary      PricingDiffSummary `json:"summary"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// signPricingWebhook 使用共享密钥计算请求体签名
+func signPricingWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyPricingChanged 价格数据变化后异步推送 webhook（未配置 URL 或无变化时跳过）
+func (s *BillingService) notifyPricingChanged(source string, diff *PricingDiff) {
+	if s.cfg == nil || strings.TrimSpace(s.cfg.Pricing.WebhookURL) == "" || !diff.HasChanges() {
+		return
+	}
+
+	changed := make([]string, 0, len(diff.Changed))
+	for _, change := range diff.Changed {
+		changed = append(changed, change.Model)
+	}
+	payload := PricingWebhookPayload{
+		Event:        "pricing.updated",
+		Source:       source,
+		ChangedCount: len(diff.Added) + len(diff.Removed) + len(diff.Changed),
+		Summary: PricingDiffSummary{
+			Added:   diff.Added,
+			Removed: diff.Removed,
+			Changed: changed,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+
+	go func() {
+		if err := s.sendPricingWebhook(payload); err != nil {
+			log.Printf("[Billing] Pricing webhook failed: %v", err)
+		}
+	}()
+}
+
+// sendPricingWebhook 发送 webhook，失败时按指数退避重试（最多 3 次）
+func (s *BillingService) sendPricingWebhook(payload PricingWebhookPayload) error {
+	webhookURL, err := urlvalidator.ValidateURLFormat(s.cfg.Pricing.WebhookURL, s.cfg.Security.URLAllowlist.AllowInsecureHTTP)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	signature := signPricingWebhook(s.cfg.Pricing.WebhookSecret, body)
+	client := &http.Client{Timeout: pricingWebhookTimeout}
+
+	var lastErr error
+	for attempt := 1; attempt <= pricingWebhookMaxAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(pricingWebhookRetryBaseDelay * time.Duration(1<<(attempt-2)))
+		}
+		lastErr = postPricingWebhook(client, webhookURL, body, signature)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", pricingWebhookMaxAttempts, lastErr)
+}
+
+func postPricingWebhook(client *http.Client, webhookURL string, body []byte, signature string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pricingWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PricingWebhookSignatureHeader, signature)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
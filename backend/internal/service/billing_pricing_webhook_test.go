@@ -0,0 +1,83 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookTestBillingService(t *testing.T, url string) *BillingService {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Pricing.WebhookURL = url
+	cfg.Pricing.WebhookSecret = "s3cret"
+	cfg.Security.URLAllowlist.AllowInsecureHTTP = true
+	return &BillingService{cfg: cfg}
+}
+
+func TestNotifyPricingChanged_SignsAndRetries(t *testing.T) {
+	oldDelay := pricingWebhookRetryBaseDelay
+	pricingWebhookRetryBaseDelay = time.Millisecond
+	t.Cleanup(func() { pricingWebhookRetryBaseDelay = oldDelay })
+
+	var attempts atomic.Int32
+	received := make(chan PricingWebhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(PricingWebhookSignatureHeader) != signPricingWebhook("s3cret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload PricingWebhookPayload
+		_ = json.Unmarshal(body, &payload)
+		received <- payload
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestBillingService(t, server.URL)
+	svc.notifyPricingChanged("import", &PricingDiff{
+		Added:   []string{"new-model"},
+		Changed: []PricingModelChange{{Model: "gpt-4o"}},
+	})
+
+	select {
+	case payload := <-received:
+		require.Equal(t, "import", payload.Source)
+		require.Equal(t, 2, payload.ChangedCount)
+		require.Equal(t, []string{"gpt-4o"}, payload.Summary.Changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestSendPricingWebhook_GivesUpAfterMaxAttempts(t *testing.T) {
+	oldDelay := pricingWebhookRetryBaseDelay
+	pricingWebhookRetryBaseDelay = time.Millisecond
+	t.Cleanup(func() { pricingWebhookRetryBaseDelay = oldDelay })
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	svc := newWebhookTestBillingService(t, server.URL)
+	err := svc.sendPricingWebhook(PricingWebhookPayload{Event: "pricing.updated"})
+	require.Error(t, err)
+	require.Equal(t, int32(pricingWebhookMaxAttempts), attempts.Load())
+}
@@ -6,6 +6,9 @@ import (
 
 	"log"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 )
@@ -57,6 +60,13 @@ type ModelPricing struct {
 	LongContextInputMultiplier     float64 // 长上下文整次会话输入倍率
 	LongContextOutputMultiplier    float64 // 长上下文整次会话输出倍率
 	ImageOutputPricePerToken       float64 // 图片输出 token 价格 (USD)
+	OutputPricePerImage            float64 // 图片生成模型每张图片价格 (USD)
+	PromptCachingUnsupported       bool    // 模型不支持 prompt caching：缓存创建/读取 token 按普通输入计费
+	IsFree                         bool    // 显式标记的免费模型：价格为 0 且不应用加价（区别于缺少价格数据）
+	IsDefault                      bool    // 未匹配到任何价格数据时使用的默认价格
+
+	// 模型能力标记（来自价格数据，回退/默认价格为空）
+	Capabilities ModelCapabilities
 }
 
 const (
@@ -105,9 +115,35 @@ type CostBreakdown struct {
 	ImageOutputCost   float64
 	CacheCreationCost float64
 	CacheReadCost     float64
-	TotalCost         float64
+	CacheReadFullCost float64 // 缓存读取 token 按普通输入价格计算的费用，用于统计缓存节省
+	TotalCost         float64 // 向用户计费的费用（已含加价，未乘倍率）
 	ActualCost        float64 // 应用倍率后的实际费用
+	BaseCost          float64 // 上游原始费用（已扣除承诺用量折扣，未加价、未乘倍率）
+	ListCost          float64 // 上游标价费用（未扣除承诺用量折扣）
+	MinChargeTopUp    float64 // 补足最低收费的差额（已含加价，未乘倍率），已计入 TotalCost
 	BillingMode       string  // 计费模式（"token"/"per_request"/"image"），由 CalculateCostUnified 填充
+
+	baseCostSet bool
+	free        bool // 按免费模型计费，不应用加价
+}
+
+// CacheSavings 缓存命中相对按普通输入价格计费节省的费用（未乘倍率）
+func (c *CostBreakdown) CacheSavings() float64 {
+	if c == nil || c.CacheReadFullCost <= c.CacheReadCost {
+		return 0
+	}
+	return c.CacheReadFullCost - c.CacheReadCost
+}
+
+// UpstreamCost 上游原始费用；未经过 BillingService 计算的明细回退到 TotalCost
+func (c *CostBreakdown) UpstreamCost() float64 {
+	if c == nil {
+		return 0
+	}
+	if c.baseCostSet {
+		return c.BaseCost
+	}
+	return c.TotalCost
 }
 
 // BillingService 计费服务
@@ -115,18 +151,68 @@ type BillingService struct {
 	cfg            *config.Config
 	pricingService *PricingService
 	fallbackPrices map[string]*ModelPricing // 硬编码回退价格
+
+	// 管理员维护的价格状态（持久化到 pricing_admin_state.json）
+	adminMu               sync.RWMutex
+	overrides             map[string]*PricingOverride // 手动价格覆盖（key 为小写模型名）
+	disabledModels        map[string]time.Time        // 已禁用模型 -> 禁用时间
+	disabledProviders     map[string]time.Time        // 已禁用厂商（小写）-> 禁用时间
+	aliases               map[string]*ModelAlias      // 模型别名（key 为小写别名）
+	globalMarkup          *PricingMarkup              // 管理员设置的全局加价（nil 时使用配置文件）
+	defaultPricing        *PricingOverride            // 管理员设置的未知模型默认价格（nil 时使用配置文件）
+	modelMarkups          map[string]*PricingMarkup   // 模型级加价（key 为小写模型名）
+	providerMarkups       map[string]*PricingMarkup   // 厂商级加价（key 为小写厂商名），未设置模型级加价的模型继承
+	modelTimeouts         map[string]int              // 模型级请求超时秒数（key 为小写模型名）
+	modelBatchMultipliers map[string]float64          // 模型级 batch 价格倍率（key 为小写模型名）
+	// 承诺用量折扣百分比（key 为小写模型名/厂商名），在加价之前扣减上游成本
+	modelCommittedDiscounts    map[string]float64
+	providerCommittedDiscounts map[string]float64
+	modelMinCharges            map[string]float64 // 模型级最低收费（key 为小写模型名），覆盖配置文件中的全局默认
+
+	exchangeRates map[string]float64 // 展示用汇率（key 为大写币种代码）
+
+	fuzzyMatchDisabled atomic.Bool // 关闭模糊匹配后仅允许精确模型名
+
+	tokenCounter *TokenCounter // 上游未返回 usage 时估算 token
+
+	pricingCache pricingCache // 内存定价缓存（价格数据与管理员状态为准，变化后失效）
+
+	refreshMu      sync.Mutex          // 串行化价格数据刷新（定时刷新与手动 ForceUpdate 不会重叠）
+	refreshPricing func() error        // 实际刷新逻辑（默认 forceUpdatePricingLocked）
+	autoRefresh    *pricingAutoRefresh // 定时刷新调度状态
 }
 
 // NewBillingService 创建计费服务实例
 func NewBillingService(cfg *config.Config, pricingService *PricingService) *BillingService {
 	s := &BillingService{
-		cfg:            cfg,
-		pricingService: pricingService,
-		fallbackPrices: make(map[string]*ModelPricing),
-	}
+		cfg:                   cfg,
+		pricingService:        pricingService,
+		fallbackPrices:        make(map[string]*ModelPricing),
+		overrides:             make(map[string]*PricingOverride),
+		disabledModels:        make(map[string]time.Time),
+		disabledProviders:     make(map[string]time.Time),
+		aliases:               make(map[string]*ModelAlias),
+		modelMarkups:          make(map[string]*PricingMarkup),
+		providerMarkups:       make(map[string]*PricingMarkup),
+		modelTimeouts:         make(map[string]int),
+		modelBatchMultipliers: make(map[string]float64),
+		exchangeRates:         make(map[string]float64),
+		autoRefresh:           newPricingAutoRefresh(cfg),
+
+		modelCommittedDiscounts:    make(map[string]float64),
+		providerCommittedDiscounts: make(map[string]float64),
+		modelMinCharges:            make(map[string]float64),
+	}
+	s.refreshPricing = s.forceUpdatePricingLocked
 
 	// 初始化硬编码回退价格（当动态价格不可用时使用）
 	s.initFallbackPricing()
+	s.loadPricingState()
+	s.adminMu.Lock()
+	s.publishDisabledProvidersLocked()
+	s.adminMu.Unlock()
+	s.initExchangeRates()
+	s.fuzzyMatchDisabled.Store(cfg != nil && cfg.Pricing.StrictModelMatch)
 
 	return s
 }
@@ -314,37 +400,19 @@ func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	return nil
 }
 
-// GetModelPricing 获取模型价格配置
+// GetModelPricing 获取模型价格配置（管理员覆盖价格优先）
 func (s *BillingService) GetModelPricing(model string) (*ModelPricing, error) {
-	// 标准化模型名称（转小写）
-	model = strings.ToLower(model)
+	pricing, _, err := s.MatchModelPricing(model, !s.FuzzyModelMatchingEnabled())
+	return pricing, err
+}
 
+// resolveModelPricing 从动态价格或硬编码回退价格解析模型定价（不含管理员覆盖）
+func (s *BillingService) resolveModelPricing(model string) (*ModelPricing, error) {
 	// 1. 优先从动态价格服务获取
 	if s.pricingService != nil {
 		litellmPricing := s.pricingService.GetModelPricing(model)
 		if litellmPricing != nil {
-			// 启用 5m/1h 分类计费的条件：
-			// 1. 存在 1h 价格
-			// 2. 1h 价格 > 5m 价格（防止 LiteLLM 数据错误导致少收费）
-			price5m := litellmPricing.CacheCreationInputTokenCost
-			price1h := litellmPricing.CacheCreationInputTokenCostAbove1hr
-			enableBreakdown := price1h > 0 && price1h > price5m
-			return s.applyModelSpecificPricingPolicy(model, &ModelPricing{
-				InputPricePerToken:             litellmPricing.InputCostPerToken,
-				InputPricePerTokenPriority:     litellmPricing.InputCostPerTokenPriority,
-				OutputPricePerToken:            litellmPricing.OutputCostPerToken,
-				OutputPricePerTokenPriority:    litellmPricing.OutputCostPerTokenPriority,
-				CacheCreationPricePerToken:     litellmPricing.CacheCreationInputTokenCost,
-				CacheReadPricePerToken:         litellmPricing.CacheReadInputTokenCost,
-				CacheReadPricePerTokenPriority: litellmPricing.CacheReadInputTokenCostPriority,
-				CacheCreation5mPrice:           price5m,
-				CacheCreation1hPrice:           price1h,
-				SupportsCacheBreakdown:         enableBreakdown,
-				LongContextInputThreshold:      litellmPricing.LongContextInputTokenThreshold,
-				LongContextInputMultiplier:     litellmPricing.LongContextInputCostMultiplier,
-				LongContextOutputMultiplier:    litellmPricing.LongContextOutputCostMultiplier,
-				ImageOutputPricePerToken:       litellmPricing.OutputCostPerImageToken,
-			}), nil
+			return s.applyModelSpecificPricingPolicy(model, litellmToModelPricing(litellmPricing)), nil
 		}
 	}
 
@@ -361,13 +429,18 @@ func (s *BillingService) GetModelPricing(model string) (*ModelPricing, error) {
 // GetModelPricingWithChannel 获取模型定价，渠道配置的价格覆盖默认值
 // 仅覆盖渠道中非 nil 的价格字段，nil 字段使用默认定价
 func (s *BillingService) GetModelPricingWithChannel(model string, channelPricing *ChannelModelPricing) (*ModelPricing, error) {
-	pricing, err := s.GetModelPricing(model)
+	pricing, err := s.billingModelPricing(model)
 	if err != nil {
 		return nil, err
 	}
 	if channelPricing == nil {
 		return pricing, nil
 	}
+	// 渠道显式配置的价格优先于免费标记
+	if channelPricing.InputPrice != nil || channelPricing.OutputPrice != nil ||
+		channelPricing.CacheWritePrice != nil || channelPricing.CacheReadPrice != nil {
+		pricing.IsFree = false
+	}
 	if channelPricing.InputPrice != nil {
 		pricing.InputPricePerToken = *channelPricing.InputPrice
 		pricing.InputPricePerTokenPriority = *channelPricing.InputPrice
@@ -380,10 +453,12 @@ func (s *BillingService) GetModelPricingWithChannel(model string, channelPricing
 		pricing.CacheCreationPricePerToken = *channelPricing.CacheWritePrice
 		pricing.CacheCreation5mPrice = *channelPricing.CacheWritePrice
 		pricing.CacheCreation1hPrice = *channelPricing.CacheWritePrice
+		pricing.PromptCachingUnsupported = false
 	}
 	if channelPricing.CacheReadPrice != nil {
 		pricing.CacheReadPricePerToken = *channelPricing.CacheReadPrice
 		pricing.CacheReadPricePerTokenPriority = *channelPricing.CacheReadPrice
+		pricing.PromptCachingUnsupported = false
 	}
 	if channelPricing.ImageOutputPrice != nil {
 		pricing.ImageOutputPricePerToken = *channelPricing.ImageOutputPrice
@@ -391,6 +466,40 @@ func (s *BillingService) GetModelPricingWithChannel(model string, channelPricing
 	return pricing, nil
 }
 
+// litellmToModelPricing 将 LiteLLM 价格数据转换为计费定价（免费模型忽略其中的价格字段）
+func litellmToModelPricing(litellmPricing *LiteLLMModelPricing) *ModelPricing {
+	if litellmPricing.IsFree {
+		return &ModelPricing{IsFree: true, Capabilities: litellmPricing.ModelCapabilities}
+	}
+	// 启用 5m/1h 分类计费的条件：
+	// 1. 存在 1h 价格
+	// 2. 1h 价格 > 5m 价格（防止 LiteLLM 数据错误导致少收费）
+	price5m := litellmPricing.CacheCreationInputTokenCost
+	price1h := litellmPricing.CacheCreationInputTokenCostAbove1hr
+	enableBreakdown := price1h > 0 && price1h > price5m
+	return &ModelPricing{
+		InputPricePerToken:             litellmPricing.InputCostPerToken,
+		InputPricePerTokenPriority:     litellmPricing.InputCostPerTokenPriority,
+		OutputPricePerToken:            litellmPricing.OutputCostPerToken,
+		OutputPricePerTokenPriority:    litellmPricing.OutputCostPerTokenPriority,
+		CacheCreationPricePerToken:     litellmPricing.CacheCreationInputTokenCost,
+		CacheReadPricePerToken:         litellmPricing.CacheReadInputTokenCost,
+		CacheReadPricePerTokenPriority: litellmPricing.CacheReadInputTokenCostPriority,
+		CacheCreation5mPrice:           price5m,
+		CacheCreation1hPrice:           price1h,
+		SupportsCacheBreakdown:         enableBreakdown,
+		LongContextInputThreshold:      litellmPricing.LongContextInputTokenThreshold,
+		LongContextInputMultiplier:     litellmPricing.LongContextInputCostMultiplier,
+		LongContextOutputMultiplier:    litellmPricing.LongContextOutputCostMultiplier,
+		ImageOutputPricePerToken:       litellmPricing.OutputCostPerImageToken,
+		OutputPricePerImage:            litellmPricing.OutputCostPerImage,
+		// 部分条目未标注 supports_prompt_caching 但配置了缓存价格，此时仍按缓存价格计费
+		PromptCachingUnsupported: !litellmPricing.SupportsPromptCaching &&
+			litellmPricing.CacheCreationInputTokenCost <= 0 && litellmPricing.CacheReadInputTokenCost <= 0,
+		Capabilities: litellmPricing.ModelCapabilities,
+	}
+}
+
 // --- 统一计费入口 ---
 
 // CostInput 统一计费输入
@@ -405,6 +514,7 @@ type CostInput struct {
 	ServiceTier    string                // "priority","flex","" 等
 	Resolver       *ModelPricingResolver // 定价解析器
 	Resolved       *ResolvedPricing      // 可选：预解析的定价结果（避免重复 Resolve 调用）
+	Batch          bool                  // batch API 请求：token 费用按 batch 倍率折扣
 }
 
 // CalculateCostUnified 统一计费入口，支持三种计费模式。
@@ -412,6 +522,9 @@ type CostInput struct {
 func (s *BillingService) CalculateCostUnified(input CostInput) (*CostBreakdown, error) {
 	if input.Resolver == nil {
 		// 无 Resolver，回退到旧路径
+		if input.Batch {
+			return s.CalculateBatchCost(input.Model, input.Tokens, input.RateMultiplier)
+		}
 		return s.calculateCostInternal(input.Model, input.Tokens, input.RateMultiplier, input.ServiceTier, nil)
 	}
 
@@ -460,7 +573,12 @@ func (s *BillingService) calculateTokenCost(resolved *ResolvedPricing, input Cos
 	// 长上下文定价仅在无区间定价时应用（区间定价已包含上下文分层）
 	applyLongCtx := len(resolved.Intervals) == 0
 
-	return s.computeTokenBreakdown(pricing, input.Tokens, input.RateMultiplier, input.ServiceTier, applyLongCtx), nil
+	bd := s.computeTokenBreakdown(pricing, input.Tokens, input.RateMultiplier, input.ServiceTier, applyLongCtx)
+	if input.Batch {
+		s.applyBatchDiscount(input.Model, bd)
+	}
+	s.applyMarkup(input.Model, bd, input.Tokens, input.RateMultiplier, false)
+	return bd, nil
 }
 
 // computeTokenBreakdown 是 token 计费的核心逻辑，由 calculateTokenCost 和 calculateCostInternal 共用。
@@ -499,7 +617,9 @@ func (s *BillingService) computeTokenBreakdown(
 		outputPrice *= pricing.LongContextOutputMultiplier
 	}
 
-	bd := &CostBreakdown{}
+	tokens = foldUnsupportedCacheTokens(pricing, tokens)
+
+	bd := &CostBreakdown{free: pricing.IsFree}
 	bd.InputCost = float64(tokens.InputTokens) * inputPrice
 
 	// 分离图片输出 token 与文本输出 token
@@ -522,6 +642,7 @@ func (s *BillingService) computeTokenBreakdown(
 	bd.CacheCreationCost = s.computeCacheCreationCost(pricing, tokens)
 
 	bd.CacheReadCost = float64(tokens.CacheReadTokens) * cacheReadPrice
+	bd.CacheReadFullCost = float64(tokens.CacheReadTokens) * inputPrice
 
 	if tierMultiplier != 1.0 {
 		bd.InputCost *= tierMultiplier
@@ -529,6 +650,7 @@ func (s *BillingService) computeTokenBreakdown(
 		bd.ImageOutputCost *= tierMultiplier
 		bd.CacheCreationCost *= tierMultiplier
 		bd.CacheReadCost *= tierMultiplier
+		bd.CacheReadFullCost *= tierMultiplier
 	}
 
 	bd.TotalCost = bd.InputCost + bd.OutputCost + bd.ImageOutputCost +
@@ -538,6 +660,19 @@ func (s *BillingService) computeTokenBreakdown(
 	return bd
 }
 
+// foldUnsupportedCacheTokens 模型不支持 prompt caching 时，将上游上报的缓存创建/读取 token 计入普通输入
+func foldUnsupportedCacheTokens(pricing *ModelPricing, tokens UsageTokens) UsageTokens {
+	if !pricing.PromptCachingUnsupported {
+		return tokens
+	}
+	tokens.InputTokens += tokens.CacheCreationTokens + tokens.CacheReadTokens
+	tokens.CacheCreationTokens = 0
+	tokens.CacheReadTokens = 0
+	tokens.CacheCreation5mTokens = 0
+	tokens.CacheCreation1hTokens = 0
+	return tokens
+}
+
 // computeCacheCreationCost 计算缓存创建费用（支持 5m/1h 分类或标准计费）。
 func (s *BillingService) computeCacheCreationCost(pricing *ModelPricing, tokens UsageTokens) float64 {
 	if pricing.SupportsCacheBreakdown && (pricing.CacheCreation5mPrice > 0 || pricing.CacheCreation1hPrice > 0) {
@@ -577,10 +712,12 @@ func (s *BillingService) calculatePerRequestCost(resolved *ResolvedPricing, inpu
 	totalCost := unitPrice * float64(count)
 	actualCost := totalCost * input.RateMultiplier
 
-	return &CostBreakdown{
+	bd := &CostBreakdown{
 		TotalCost:  totalCost,
 		ActualCost: actualCost,
-	}, nil
+	}
+	s.applyMarkup(input.Model, bd, input.Tokens, input.RateMultiplier, true)
+	return bd, nil
 }
 
 // CalculateCost 计算使用费用
@@ -598,14 +735,16 @@ func (s *BillingService) calculateCostInternal(model string, tokens UsageTokens,
 	if channelPricing != nil {
 		pricing, err = s.GetModelPricingWithChannel(model, channelPricing)
 	} else {
-		pricing, err = s.GetModelPricing(model)
+		pricing, err = s.billingModelPricing(model)
 	}
 	if err != nil {
 		return nil, err
 	}
 
 	// 旧路径始终检查长上下文定价（无区间定价概念）
-	return s.computeTokenBreakdown(pricing, tokens, rateMultiplier, serviceTier, true), nil
+	bd := s.computeTokenBreakdown(pricing, tokens, rateMultiplier, serviceTier, true)
+	s.applyMarkup(model, bd, tokens, rateMultiplier, false)
+	return bd, nil
 }
 
 func (s *BillingService) applyModelSpecificPricingPolicy(model string, pricing *ModelPricing) *ModelPricing {
@@ -728,8 +867,13 @@ func (s *BillingService) CalculateCostWithLongContext(model string, tokens Usage
 		ImageOutputCost:   inRangeCost.ImageOutputCost,
 		CacheCreationCost: inRangeCost.CacheCreationCost,
 		CacheReadCost:     inRangeCost.CacheReadCost + outRangeCost.CacheReadCost,
+		CacheReadFullCost: inRangeCost.CacheReadFullCost + outRangeCost.CacheReadFullCost,
 		TotalCost:         inRangeCost.TotalCost + outRangeCost.TotalCost,
 		ActualCost:        inRangeCost.ActualCost + outRangeCost.ActualCost,
+		BaseCost:          inRangeCost.BaseCost + outRangeCost.BaseCost,
+		ListCost:          inRangeCost.ListCost + outRangeCost.ListCost,
+		MinChargeTopUp:    inRangeCost.MinChargeTopUp + outRangeCost.MinChargeTopUp,
+		baseCostSet:       true,
 	}, nil
 }
 
@@ -768,32 +912,90 @@ func (s *BillingService) GetEstimatedCost(model string, estimatedInputTokens, es
 	return breakdown.ActualCost, nil
 }
 
-// GetPricingServiceStatus 获取价格服务状态
+// GetPricingServiceStatus 获取价格服务状态（含内存定价缓存命中统计）
 func (s *BillingService) GetPricingServiceStatus() map[string]any {
-	if s.pricingService != nil {
-		return s.pricingService.GetStatus()
-	}
-	return map[string]any{
+	status := map[string]any{
 		"model_count":  len(s.fallbackPrices),
 		"last_updated": "using fallback",
 		"local_hash":   "N/A",
 	}
+	if s.pricingService != nil {
+		status = s.pricingService.GetStatus()
+	}
+	for k, v := range s.pricingCache.stats() {
+		status[k] = v
+	}
+	status["auto_refresh"] = s.autoRefresh.status()
+	return status
 }
 
 // ForceUpdatePricing 强制更新价格数据
+// 管理员覆盖价格独立存储并在读取时叠加，更新后自动重新生效。
+// 与定时刷新互斥：定时刷新进行中时等待其结束后再执行。
 func (s *BillingService) ForceUpdatePricing() error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	return s.refreshPricing()
+}
+
+// forceUpdatePricingLocked 拉取远程价格数据并替换（调用方需持有 refreshMu）
+func (s *BillingService) forceUpdatePricingLocked() error {
 	if s.pricingService != nil {
-		return s.pricingService.ForceUpdate()
+		before := s.pricingService.ListAllPricing()
+		if err := s.pricingService.ForceUpdate(); err != nil {
+			return err
+		}
+		s.pricingCache.invalidate()
+		s.notifyPricingChanged("force_update", diffPricingData(before, s.pricingService.ListAllPricing()))
+		return nil
 	}
 	return fmt.Errorf("pricing service not initialized")
 }
 
-// ImportPricingData 从上传的JSON数据导入价格
-func (s *BillingService) ImportPricingData(data []byte) (int, error) {
+// DryRunForceUpdatePricing 预览强制更新将带来的价格变化（不修改价格数据）
+func (s *BillingService) DryRunForceUpdatePricing() (*PricingDiff, error) {
+	if s.pricingService != nil {
+		return s.pricingService.DryRunForceUpdate()
+	}
+	return nil, fmt.Errorf("pricing service not initialized")
+}
+
+// ImportPricingData 从上传的JSON数据导入价格（strict 时拒绝包含重复模型的文件）
+func (s *BillingService) ImportPricingData(data []byte, strict bool) (*PricingImportResult, error) {
+	if s.pricingService != nil {
+		before := s.pricingService.ListAllPricing()
+		result, err := s.pricingService.ImportPricingData(data, strict)
+		if err != nil {
+			return nil, err
+		}
+		s.pricingCache.invalidate()
+		s.notifyPricingChanged("import", diffPricingData(before, s.pricingService.ListAllPricing()))
+		return result, nil
+	}
+	return nil, fmt.Errorf("pricing service not initialized")
+}
+
+// MergePricingData 以合并模式导入上传的价格数据（未出现的模型保持不变）
+func (s *BillingService) MergePricingData(data []byte, strict bool) (*PricingMergeResult, error) {
+	if s.pricingService != nil {
+		before := s.pricingService.ListAllPricing()
+		result, err := s.pricingService.MergePricingData(data, strict)
+		if err != nil {
+			return nil, err
+		}
+		s.pricingCache.invalidate()
+		s.notifyPricingChanged("merge", diffPricingData(before, s.pricingService.ListAllPricing()))
+		return result, nil
+	}
+	return nil, fmt.Errorf("pricing service not initialized")
+}
+
+// DiffPricingData 对比上传的价格数据与当前数据（不导入、不修改状态）
+func (s *BillingService) DiffPricingData(data []byte) (*PricingDiff, error) {
 	if s.pricingService != nil {
-		return s.pricingService.ImportPricingData(data)
+		return s.pricingService.DiffPricingData(data)
 	}
-	return 0, fmt.Errorf("pricing service not initialized")
+	return nil, fmt.Errorf("pricing service not initialized")
 }
 
 // GetAllPricing 获取所有价格数据（用于管理后台展示）
@@ -802,8 +1004,10 @@ func (s *BillingService) GetAllPricing() map[string]*ModelPricingInfo {
 
 	if s.pricingService != nil {
 		allPricing := s.pricingService.ListAllPricing()
+		updatedTimes := s.pricingService.GetModelUpdatedTimes()
 		for model, pricing := range allPricing {
 			result[model] = &ModelPricingInfo{
+				LastUpdated:                 updatedTimes[model],
 				InputCostPerToken:           pricing.InputCostPerToken,
 				OutputCostPerToken:          pricing.OutputCostPerToken,
 				CacheCreationInputTokenCost: pricing.CacheCreationInputTokenCost,
@@ -812,23 +1016,67 @@ func (s *BillingService) GetAllPricing() map[string]*ModelPricingInfo {
 				Mode:                        pricing.Mode,
 				SupportsPromptCaching:       pricing.SupportsPromptCaching,
 				OutputCostPerImage:          pricing.OutputCostPerImage,
+				IsFree:                      pricing.IsFree,
+				ModelCapabilities:           pricing.ModelCapabilities,
+				ModelDeprecation:            pricing.ModelDeprecation,
+			}
+		}
+	}
+
+	// 叠加管理员覆盖价格（覆盖项可能不在价格数据中，此时单独列出）
+	s.adminMu.RLock()
+	for model, override := range s.overrides {
+		info, ok := result[model]
+		if !ok {
+			info = &ModelPricingInfo{}
+			if base := override.Base; base != nil {
+				info.CacheCreationInputTokenCost = base.CacheCreationInputTokenCost
+				info.CacheReadInputTokenCost = base.CacheReadInputTokenCost
+				info.Provider = base.LiteLLMProvider
+				info.Mode = base.Mode
+				info.SupportsPromptCaching = base.SupportsPromptCaching
+				info.OutputCostPerImage = base.OutputCostPerImage
+				info.ModelCapabilities = base.ModelCapabilities
+				info.ModelDeprecation = base.ModelDeprecation
 			}
+			result[model] = info
+		}
+		info.InputCostPerToken = override.InputCostPerToken
+		info.OutputCostPerToken = override.OutputCostPerToken
+		info.Overridden = true
+		info.IsFree = false // 覆盖价格优先于免费标记
+		if override.UpdatedAt.After(info.LastUpdated) {
+			info.LastUpdated = override.UpdatedAt
+		}
+	}
+	for model := range s.disabledModels {
+		if info, ok := result[model]; ok {
+			info.Disabled = true
 		}
 	}
+	s.adminMu.RUnlock()
 
 	return result
 }
 
 // ModelPricingInfo 价格信息（用于API返回）
 type ModelPricingInfo struct {
-	InputCostPerToken           float64 `json:"input_cost_per_token"`
-	OutputCostPerToken          float64 `json:"output_cost_per_token"`
-	CacheCreationInputTokenCost float64 `json:"cache_creation_input_token_cost,omitempty"`
-	CacheReadInputTokenCost     float64 `json:"cache_read_input_token_cost,omitempty"`
-	Provider                    string  `json:"provider"`
-	Mode                        string  `json:"mode"`
-	SupportsPromptCaching       bool    `json:"supports_prompt_caching"`
-	OutputCostPerImage          float64 `json:"output_cost_per_image,omitempty"`
+	InputCostPerToken           float64   `json:"input_cost_per_token"`
+	OutputCostPerToken          float64   `json:"output_cost_per_token"`
+	CacheCreationInputTokenCost float64   `json:"cache_creation_input_token_cost,omitempty"`
+	CacheReadInputTokenCost     float64   `json:"cache_read_input_token_cost,omitempty"`
+	Provider                    string    `json:"provider"`
+	Mode                        string    `json:"mode"`
+	SupportsPromptCaching       bool      `json:"supports_prompt_caching"`
+	OutputCostPerImage          float64   `json:"output_cost_per_image,omitempty"`
+	Overridden                  bool      `json:"overridden"`   // 是否存在管理员覆盖价格
+	Disabled                    bool      `json:"disabled"`     // 是否已被管理员禁用
+	IsFree                      bool      `json:"is_free"`      // 是否显式标记为免费模型
+	LastUpdated                 time.Time `json:"last_updated"` // 该模型价格最近一次变化的时间
+	// 模型能力标记（未知时省略）
+	ModelCapabilities
+	// 弃用标记与替代模型（未弃用时省略）
+	ModelDeprecation
 }
 
 // GetPricingConfig 获取价格配置
@@ -839,6 +1087,8 @@ func (s *BillingService) GetPricingConfig() map[string]any {
 		"data_dir":                    s.cfg.Pricing.DataDir,
 		"update_interval_hours":       s.cfg.Pricing.UpdateIntervalHours,
 		"hash_check_interval_minutes": s.cfg.Pricing.HashCheckIntervalMinutes,
+		"auto_refresh_enabled":        s.cfg.Pricing.AutoRefreshEnabled,
+		"auto_refresh_jitter_minutes": s.cfg.Pricing.AutoRefreshJitterMinutes,
 	}
 }
 
@@ -856,59 +1106,86 @@ type ImagePriceConfig struct {
 // groupConfig: 分组配置的价格（可能为 nil，表示使用默认值）
 // rateMultiplier: 费率倍数
 func (s *BillingService) CalculateImageCost(model string, imageSize string, imageCount int, groupConfig *ImagePriceConfig, rateMultiplier float64) *CostBreakdown {
+	return s.CalculateImageCostWithTokens(model, imageSize, imageCount, UsageTokens{}, groupConfig, rateMultiplier)
+}
+
+// CalculateImageCostWithTokens 计算图片生成费用。
+// 分组配置了该尺寸的图片价格时按张一口价计费（与原有行为一致，不计 token）；
+// 否则按模型的每张图片价格 × 图片数量，加上提示词（输入与缓存）token 费用，图片费用记在 ImageOutputCost。
+// 输出 token 已按张计费，不再重复计入。
+func (s *BillingService) CalculateImageCostWithTokens(model string, imageSize string, imageCount int, tokens UsageTokens, groupConfig *ImagePriceConfig, rateMultiplier float64) *CostBreakdown {
 	if imageCount <= 0 {
 		return &CostBreakdown{}
 	}
 
-	// 获取单价
-	unitPrice := s.getImageUnitPrice(model, imageSize, groupConfig)
-
-	// 计算总费用
-	totalCost := unitPrice * float64(imageCount)
-
 	// 应用倍率（保存时强制 > 0；负数按 0 处理避免按 1x 误扣）
 	if rateMultiplier < 0 {
 		rateMultiplier = 0
 	}
-	actualCost := totalCost * rateMultiplier
 
-	return &CostBreakdown{
-		TotalCost:   totalCost,
-		ActualCost:  actualCost,
-		BillingMode: string(BillingModeImage),
+	if groupPrice := groupImageUnitPrice(imageSize, groupConfig); groupPrice != nil {
+		totalCost := *groupPrice * float64(imageCount)
+		bd := &CostBreakdown{
+			TotalCost:   totalCost,
+			ActualCost:  totalCost * rateMultiplier,
+			BillingMode: string(BillingModeImage),
+		}
+		s.applyMarkup(model, bd, UsageTokens{}, rateMultiplier, true)
+		return bd
 	}
-}
 
-// getImageUnitPrice 获取图片单价
-func (s *BillingService) getImageUnitPrice(model string, imageSize string, groupConfig *ImagePriceConfig) float64 {
-	// 优先使用分组配置的价格
-	if groupConfig != nil {
-		switch imageSize {
-		case "1K":
-			if groupConfig.Price1K != nil {
-				return *groupConfig.Price1K
-			}
-		case "2K":
-			if groupConfig.Price2K != nil {
-				return *groupConfig.Price2K
-			}
-		case "4K":
-			if groupConfig.Price4K != nil {
-				return *groupConfig.Price4K
-			}
+	bd := &CostBreakdown{
+		ImageOutputCost: s.getDefaultImagePrice(model, imageSize) * float64(imageCount),
+		BillingMode:     string(BillingModeImage),
+	}
+
+	promptTokens := UsageTokens{
+		InputTokens:           tokens.InputTokens,
+		CacheCreationTokens:   tokens.CacheCreationTokens,
+		CacheReadTokens:       tokens.CacheReadTokens,
+		CacheCreation5mTokens: tokens.CacheCreation5mTokens,
+		CacheCreation1hTokens: tokens.CacheCreation1hTokens,
+	}
+	if promptTokens != (UsageTokens{}) {
+		if pricing, err := s.billingModelPricing(model); err == nil {
+			tokenCost := s.computeTokenBreakdown(pricing, promptTokens, rateMultiplier, "", false)
+			bd.InputCost = tokenCost.InputCost
+			bd.CacheCreationCost = tokenCost.CacheCreationCost
+			bd.CacheReadCost = tokenCost.CacheReadCost
+			bd.CacheReadFullCost = tokenCost.CacheReadFullCost
 		}
 	}
 
-	// 回退到 LiteLLM 默认价格
-	return s.getDefaultImagePrice(model, imageSize)
+	bd.TotalCost = bd.InputCost + bd.ImageOutputCost + bd.CacheCreationCost + bd.CacheReadCost
+	bd.ActualCost = bd.TotalCost * rateMultiplier
+	s.applyMarkup(model, bd, promptTokens, rateMultiplier, false)
+	return bd
+}
+
+// groupImageUnitPrice 分组为该尺寸配置的图片单价（未配置时返回 nil）
+func groupImageUnitPrice(imageSize string, groupConfig *ImagePriceConfig) *float64 {
+	if groupConfig == nil {
+		return nil
+	}
+	switch imageSize {
+	case "1K":
+		return groupConfig.Price1K
+	case "2K":
+		return groupConfig.Price2K
+	case "4K":
+		return groupConfig.Price4K
+	}
+	return nil
 }
 
 // getDefaultImagePrice 获取 LiteLLM 默认图片价格
 func (s *BillingService) getDefaultImagePrice(model string, imageSize string) float64 {
 	basePrice := 0.0
 
-	// 从 PricingService 获取 output_cost_per_image
-	if s.pricingService != nil {
+	// 优先使用计费定价（含管理员覆盖），再回退到 PricingService 的 output_cost_per_image
+	if pricing, err := s.billingModelPricing(model); err == nil && pricing.OutputPricePerImage > 0 {
+		basePrice = pricing.OutputPricePerImage
+	} else if s.pricingService != nil {
 		pricing := s.pricingService.GetModelPricing(model)
 		if pricing != nil && pricing.OutputCostPerImage > 0 {
 			basePrice = pricing.OutputCostPerImage
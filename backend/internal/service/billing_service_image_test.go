@@ -148,3 +148,24 @@ func TestGetDefaultImagePrice_FallbackHardcoded(t *testing.T) {
 	cost = svc.CalculateImageCost("gemini-3-pro-image", "2K", 1, nil, 1.0)
 	require.InDelta(t, 0.201, cost.TotalCost, 0.0001)
 }
+
+// TestCalculateImageCostWithTokens_PerImageModelPricing 按模型每张图片价格计费，并计入提示词 token 费用
+func TestCalculateImageCostWithTokens_PerImageModelPricing(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"dall-e-3": {InputCostPerToken: 5e-6, OutputCostPerImage: 0.04, LiteLLMProvider: "openai"},
+	})
+
+	cost := svc.CalculateImageCostWithTokens("dall-e-3", "1K", 3, UsageTokens{InputTokens: 200, OutputTokens: 4000}, nil, 2.0)
+	require.InDelta(t, 0.12, cost.ImageOutputCost, 1e-12)
+	require.InDelta(t, 200*5e-6, cost.InputCost, 1e-12)
+	require.Zero(t, cost.OutputCost)
+	require.InDelta(t, 0.12+200*5e-6, cost.TotalCost, 1e-12)
+	require.InDelta(t, (0.12+200*5e-6)*2, cost.ActualCost, 1e-12)
+	require.Equal(t, string(BillingModeImage), cost.BillingMode)
+
+	// 分组配置的图片价格为按张一口价，不再叠加 token 费用
+	groupPrice := 0.05
+	cost = svc.CalculateImageCostWithTokens("dall-e-3", "1K", 2, UsageTokens{InputTokens: 200}, &ImagePriceConfig{Price1K: &groupPrice}, 1.0)
+	require.InDelta(t, 0.10, cost.TotalCost, 1e-12)
+	require.Zero(t, cost.InputCost)
+}
@@ -53,6 +53,10 @@ func TestCalculateCost_WithCacheTokens(t *testing.T) {
 
 	expectedTotal := cost.InputCost + cost.OutputCost + expectedCacheCreation + expectedCacheRead
 	require.InDelta(t, expectedTotal, cost.TotalCost, 1e-10)
+
+	// 缓存节省：缓存读取 token 按输入价计费的差额
+	require.InDelta(t, 3000*3e-6, cost.CacheReadFullCost, 1e-10)
+	require.InDelta(t, 3000*(3e-6-0.3e-6), cost.CacheSavings(), 1e-10)
 }
 
 func TestCalculateCost_RateMultiplier(t *testing.T) {
@@ -818,3 +822,55 @@ func TestGetModelPricingWithChannel_UnknownModelReturnsError(t *testing.T) {
 	require.Nil(t, pricing)
 	require.Contains(t, err.Error(), "pricing not found")
 }
+
+func TestCalculateCost_CachedRequestCostsLessThanUncached(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"claude-sonnet-4": {
+			InputCostPerToken:           3e-6,
+			OutputCostPerToken:          15e-6,
+			CacheCreationInputTokenCost: 3.75e-6,
+			CacheReadInputTokenCost:     0.3e-6,
+			SupportsPromptCaching:       true,
+		},
+	})
+
+	// 同样 10000 token 的提示词：未命中缓存 vs 9000 token 命中缓存
+	uncached, err := svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 10000, OutputTokens: 200}, 1.0)
+	require.NoError(t, err)
+	cached, err := svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 1000, CacheReadTokens: 9000, OutputTokens: 200}, 1.0)
+	require.NoError(t, err)
+
+	require.InDelta(t, 9000*0.3e-6, cached.CacheReadCost, 1e-12)
+	require.InDelta(t, 1000*3e-6, cached.InputCost, 1e-12)
+	require.Less(t, cached.TotalCost, uncached.TotalCost)
+
+	created, err := svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 1000, CacheCreationTokens: 9000, OutputTokens: 200}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 9000*3.75e-6, created.CacheCreationCost, 1e-12)
+}
+
+func TestCalculateCost_CacheTokensBilledAsInputWithoutPromptCaching(t *testing.T) {
+	svc := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"plain-model": {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6},
+		// 未标注 supports_prompt_caching 但配置了缓存价格：按缓存价格计费
+		"priced-cache": {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6, CacheReadInputTokenCost: 0.1e-6},
+	})
+
+	cost, err := svc.CalculateCost("plain-model", UsageTokens{InputTokens: 100, CacheReadTokens: 300, CacheCreationTokens: 600}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 1000*1e-6, cost.InputCost, 1e-12)
+	require.Zero(t, cost.CacheReadCost)
+	require.Zero(t, cost.CacheCreationCost)
+	require.InDelta(t, 1000*1e-6, cost.TotalCost, 1e-12)
+
+	cost, err = svc.CalculateCost("priced-cache", UsageTokens{InputTokens: 100, CacheReadTokens: 300}, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, 100*1e-6, cost.InputCost, 1e-12)
+	require.InDelta(t, 300*0.1e-6, cost.CacheReadCost, 1e-12)
+
+	// 渠道配置缓存价格时以渠道为准
+	cacheRead := 0.2e-6
+	pricing, err := svc.GetModelPricingWithChannel("plain-model", &ChannelModelPricing{CacheReadPrice: &cacheRead})
+	require.NoError(t, err)
+	require.False(t, pricing.PromptCachingUnsupported)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/tidwall/gjson"
+)
+
+// structuredOutputFormatPaths 各协议声明响应格式的字段（Chat Completions / Responses / Anthropic）
+var structuredOutputFormatPaths = []string{"response_format.type", "text.format.type", "output_format.type"}
+
+// structuredOutputSchemaPaths Gemini 声明响应 JSON Schema 的字段
+var structuredOutputSchemaPaths = []string{
+	"generationConfig.responseSchema", "generationConfig.responseJsonSchema",
+	"generation_config.response_schema", "generation_config.response_json_schema",
+}
+
+// StructuredOutputUnsupportedError 请求使用结构化输出，但模型价格数据声明不支持
+type StructuredOutputUnsupportedError struct {
+	Model string
+}
+
+func (e *StructuredOutputUnsupportedError) Error() string {
+	return fmt.Sprintf("Model %s does not support structured outputs (json_schema response format); "+
+		"remove the schema or use a model that supports structured outputs", e.Model)
+}
+
+// RequestUsesStructuredOutput 请求体是否要求按 JSON Schema 输出
+func RequestUsesStructuredOutput(body []byte) bool {
+	for _, path := range structuredOutputFormatPaths {
+		if gjson.GetBytes(body, path).String() == "json_schema" {
+			return true
+		}
+	}
+	for _, path := range structuredOutputSchemaPaths {
+		if v := gjson.GetBytes(body, path); v.Exists() && v.Type != gjson.Null {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelLacksStructuredOutput 模型价格数据是否明确声明不支持结构化输出（未知时返回 false，照常转发）
+func (s *BillingService) ModelLacksStructuredOutput(model string) bool {
+	if s == nil || model == "" {
+		return false
+	}
+	pricing, err := s.GetModelPricing(model)
+	if err != nil || pricing == nil || pricing.Capabilities.SupportsStructuredOutput == nil {
+		return false
+	}
+	return !*pricing.Capabilities.SupportsStructuredOutput
+}
+
+// StructuredOutputMultiplier 结构化输出请求的价格倍率，未配置时为 1
+func (s *BillingService) StructuredOutputMultiplier() float64 {
+	if s == nil || s.cfg == nil || s.cfg.Pricing.StructuredOutputMultiplier <= 0 {
+		return 1
+	}
+	return s.cfg.Pricing.StructuredOutputMultiplier
+}
+
+// CheckStructuredOutput 请求使用结构化输出而模型明确不支持时返回错误
+func (s *BillingService) CheckStructuredOutput(model string, body []byte) *StructuredOutputUnsupportedError {
+	if !s.ModelLacksStructuredOutput(model) || !RequestUsesStructuredOutput(body) {
+		return nil
+	}
+	return &StructuredOutputUnsupportedError{Model: model}
+}
+
+// WithStructuredOutputRequest 标记当前请求使用结构化输出
+func WithStructuredOutputRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxkey.StructuredOutput, true)
+}
+
+func isStructuredOutputRequest(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(ctxkey.StructuredOutput).(bool)
+	return v
+}
+
+// applyStructuredOutputMultiplier 对结构化输出请求按倍率缩放向用户计费的费用。
+// 上游不额外收费，BaseCost/ListCost 保持不变。
+func (s *BillingService) applyStructuredOutputMultiplier(ctx context.Context, bd *CostBreakdown) {
+	if bd == nil || bd.free || !isStructuredOutputRequest(ctx) {
+		return
+	}
+	multiplier := s.StructuredOutputMultiplier()
+	if multiplier == 1 {
+		return
+	}
+	if !bd.baseCostSet {
+		bd.BaseCost = bd.TotalCost
+		bd.baseCostSet = true
+	}
+	bd.InputCost *= multiplier
+	bd.OutputCost *= multiplier
+	bd.ImageOutputCost *= multiplier
+	bd.CacheCreationCost *= multiplier
+	bd.CacheReadCost *= multiplier
+	bd.CacheReadFullCost *= multiplier
+	bd.MinChargeTopUp *= multiplier
+	bd.TotalCost *= multiplier
+	bd.ActualCost *= multiplier
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newStructuredOutputTestBillingService(multiplier float64) *BillingService {
+	yes, no := true, false
+	cfg := &config.Config{}
+	cfg.Pricing.StructuredOutputMultiplier = multiplier
+	return NewBillingService(cfg, &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"schema-model":    {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6, ModelCapabilities: ModelCapabilities{SupportsStructuredOutput: &yes}},
+		"no-schema-model": {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6, ModelCapabilities: ModelCapabilities{SupportsStructuredOutput: &no}},
+		"plain-model":     {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6},
+	}})
+}
+
+func TestRequestUsesStructuredOutput(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "chat completions json_schema", body: `{"response_format":{"type":"json_schema","json_schema":{"name":"x","schema":{}}}}`, want: true},
+		{name: "chat completions json_object", body: `{"response_format":{"type":"json_object"}}`, want: false},
+		{name: "responses text.format", body: `{"text":{"format":{"type":"json_schema","schema":{}}}}`, want: true},
+		{name: "anthropic output_format", body: `{"output_format":{"type":"json_schema","schema":{}}}`, want: true},
+		{name: "gemini responseSchema", body: `{"generationConfig":{"responseMimeType":"application/json","responseSchema":{"type":"OBJECT"}}}`, want: true},
+		{name: "gemini null schema", body: `{"generationConfig":{"responseSchema":null}}`, want: false},
+		{name: "plain", body: `{"messages":[{"role":"user","content":"json_schema"}]}`, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, RequestUsesStructuredOutput([]byte(tc.body)))
+		})
+	}
+}
+
+func TestCheckStructuredOutput(t *testing.T) {
+	svc := newStructuredOutputTestBillingService(1)
+	body := []byte(`{"response_format":{"type":"json_schema","json_schema":{"name":"x","schema":{}}}}`)
+
+	err := svc.CheckStructuredOutput("no-schema-model", body)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "no-schema-model")
+
+	// 支持或未声明能力的模型照常转发
+	require.Nil(t, svc.CheckStructuredOutput("schema-model", body))
+	require.Nil(t, svc.CheckStructuredOutput("plain-model", body))
+	require.Nil(t, svc.CheckStructuredOutput("unknown-model-xyz", body))
+	require.Nil(t, svc.CheckStructuredOutput("no-schema-model", []byte(`{"messages":[]}`)))
+}
+
+func TestApplyStructuredOutputMultiplier(t *testing.T) {
+	svc := newStructuredOutputTestBillingService(1.5)
+	tokens := UsageTokens{InputTokens: 1000, OutputTokens: 500}
+
+	plain, err := svc.CalculateCost("schema-model", tokens, 1.0)
+	require.NoError(t, err)
+	svc.applyStructuredOutputMultiplier(context.Background(), plain)
+
+	structured, err := svc.CalculateCost("schema-model", tokens, 1.0)
+	require.NoError(t, err)
+	svc.applyStructuredOutputMultiplier(WithStructuredOutputRequest(context.Background()), structured)
+
+	require.InDelta(t, plain.ActualCost*1.5, structured.ActualCost, 1e-12)
+	require.InDelta(t, plain.OutputCost*1.5, structured.OutputCost, 1e-12)
+	// 上游成本不受倍率影响
+	require.InDelta(t, plain.UpstreamCost(), structured.UpstreamCost(), 1e-12)
+
+	// 倍率为 1 时不调整
+	same, err := newStructuredOutputTestBillingService(1).CalculateCost("schema-model", tokens, 1.0)
+	require.NoError(t, err)
+	before := same.ActualCost
+	newStructuredOutputTestBillingService(1).applyStructuredOutputMultiplier(WithStructuredOutputRequest(context.Background()), same)
+	require.InDelta(t, before, same.ActualCost, 1e-12)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+const (
+	defaultStreamReservationTimeout         = 10 * time.Minute
+	defaultStreamReservationMaxOutputTokens = 8192
+)
+
+// streamCostEstimator 按模型价格预估请求费用（由 BillingService 实现）
+type streamCostEstimator interface {
+	EstimateCost(input CostEstimateInput) (*CostEstimate, error)
+}
+
+// BudgetReservation 流式请求开始前对用户月度预算的预留。
+// 预留在结算前按预估最大费用计入已用预算；结算后按实际费用计入，直到预算快照刷新（已包含该笔费用）为止。
+// 引用计数归零且未结算时释放；请求被遗弃（引用未归还）时在超时后自动失效。
+type BudgetReservation struct {
+	ledger *userBudgetReservations
+
+	// 以下字段受 ledger.mu 保护
+	amount    float64
+	expiresAt time.Time
+	settledAt time.Time
+	refs      int
+	released  bool
+}
+
+// userBudgetReservations 单个用户的未结清预留
+type userBudgetReservations struct {
+	mu      sync.Mutex
+	entries []*BudgetReservation
+}
+
+// outstanding 返回仍需计入预算的预留总额，并清理已失效的预留。
+// spendLoadedAt 为预算快照的加载时间：在此之前结算的预留已体现在快照中，不再重复计入。
+func (l *userBudgetReservations) outstanding(now, spendLoadedAt time.Time) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.outstandingLocked(now, spendLoadedAt)
+}
+
+func (l *userBudgetReservations) outstandingLocked(now, spendLoadedAt time.Time) float64 {
+	total := 0.0
+	kept := l.entries[:0]
+	for _, r := range l.entries {
+		switch {
+		case r.released:
+			continue
+		case r.settledAt.IsZero() && !now.Before(r.expiresAt):
+			// 遗弃的流：超时未结算，自动释放
+			r.released = true
+			continue
+		case !r.settledAt.IsZero() && spendLoadedAt.After(r.settledAt):
+			continue
+		case !r.settledAt.IsZero() && now.Sub(r.settledAt) > 2*userBudgetSpendCacheTTL:
+			continue
+		}
+		total += r.amount
+		kept = append(kept, r)
+	}
+	clear(l.entries[len(kept):])
+	l.entries = kept
+	return total
+}
+
+// Hold 增加一次引用（如异步用量记录任务），须与 Release 成对调用。nil-safe。
+func (r *BudgetReservation) Hold() {
+	if r == nil {
+		return
+	}
+	r.ledger.mu.Lock()
+	r.refs++
+	r.ledger.mu.Unlock()
+}
+
+// Release 归还一次引用；全部归还且尚未结算时释放预留。nil-safe。
+func (r *BudgetReservation) Release() {
+	if r == nil {
+		return
+	}
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	if r.refs > 0 {
+		r.refs--
+	}
+	if r.refs == 0 && r.settledAt.IsZero() {
+		r.released = true
+	}
+}
+
+// Settle 按实际费用结算预留，差额随之释放。nil-safe，重复调用以首次为准。
+func (r *BudgetReservation) Settle(actualCost float64) {
+	if r == nil {
+		return
+	}
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	if r.released || !r.settledAt.IsZero() {
+		return
+	}
+	r.amount = math.Max(0, actualCost)
+	r.settledAt = time.Now()
+}
+
+// Amount 返回当前计入预算的金额（结算前为预估值）
+func (r *BudgetReservation) Amount() float64 {
+	if r == nil {
+		return 0
+	}
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	return r.amount
+}
+
+// WithBudgetReservation 将预算预留放入 context
+func WithBudgetReservation(ctx context.Context, r *BudgetReservation) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.BudgetReservation, r)
+}
+
+// BudgetReservationFromContext 取出当前请求的预算预留，无预留时返回 nil
+func BudgetReservationFromContext(ctx context.Context) *BudgetReservation {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(ctxkey.BudgetReservation).(*BudgetReservation)
+	return r
+}
+
+// SetStreamCostEstimator 注入流式预留的费用预估器，未注入时不预留
+func (s *BillingCacheService) SetStreamCostEstimator(estimator streamCostEstimator) {
+	s.costEstimator = estimator
+}
+
+// EstimateStreamMaxCost 按输入 token 与最大输出 token 预估一次请求的最大费用（含分组倍率）。
+// maxOutputTokens <= 0 时使用 billing.stream_reservation.default_max_output_tokens。
+func (s *BillingCacheService) EstimateStreamMaxCost(model string, group *Group, inputTokens, maxOutputTokens int) (float64, error) {
+	if s == nil || s.costEstimator == nil {
+		return 0, nil
+	}
+	if maxOutputTokens <= 0 {
+		maxOutputTokens = s.cfg.Billing.StreamReservation.DefaultMaxOutputTokens
+		if maxOutputTokens <= 0 {
+			maxOutputTokens = defaultStreamReservationMaxOutputTokens
+		}
+	}
+	estimate, err := s.costEstimator.EstimateCost(CostEstimateInput{
+		Model:        model,
+		InputTokens:  max(0, inputTokens),
+		OutputTokens: maxOutputTokens,
+	})
+	if err != nil {
+		return 0, err
+	}
+	cost := estimate.TotalCost
+	if group != nil && group.RateMultiplier > 0 {
+		cost *= group.RateMultiplier
+	}
+	return cost, nil
+}
+
+// ReserveStreamBudget 流式请求开始前按预估最大费用预留用户月度预算。
+// 预估值计入后超出硬限制时返回 UserBudgetExceededError（请求应在开始流式前被拒绝）；软限制仅记录告警。
+// 未开启预留、未设置预算、沙盒 Key 或预估为 0 时返回 nil 预留；预算查询失败时 fail-open。
+// 返回的预留持有一次引用，调用方须在请求结束时 Release。
+func (s *BillingCacheService) ReserveStreamBudget(ctx context.Context, user *User, apiKey *APIKey, estimate float64) (*BudgetReservation, error) {
+	if s == nil || s.spendRepo == nil || user == nil || user.BudgetLimit <= 0 || estimate <= 0 {
+		return nil, nil
+	}
+	if s.cfg.RunMode == config.RunModeSimple || !s.cfg.Billing.StreamReservation.Enabled {
+		return nil, nil
+	}
+	if apiKey != nil && apiKey.IsSandbox {
+		return nil, nil
+	}
+
+	now := time.Now()
+	cycleStart, cycleEnd := budgetCycleBounds(now, s.cfg.Billing.BudgetCycleDay)
+	spend, err := s.loadUserBudgetSpend(user.ID, cycleStart, now)
+	if err != nil {
+		logger.LegacyPrintf("service.billing_cache", "Warning: budget spend lookup failed for user=%d: %v", user.ID, err)
+		return nil, nil
+	}
+
+	ledger := s.userReservations(user.ID)
+	// 检查与登记在同一把锁内完成，避免并发流同时通过检查
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	outstanding := ledger.outstandingLocked(now, spend.loadedAt)
+
+	projected := spend.actualCost + outstanding + estimate
+	if projected > user.BudgetLimit {
+		if !user.BudgetSoftLimit {
+			return nil, &UserBudgetExceededError{
+				Limit:     user.BudgetLimit,
+				Spent:     spend.actualCost + outstanding,
+				Projected: projected,
+				ResetAt:   cycleEnd,
+			}
+		}
+		if spend.softWarned.CompareAndSwap(false, true) {
+			logger.LegacyPrintf("service.billing_cache", "Warning: user=%d stream reservation over soft budget: spent=%.4f reserved=%.4f estimate=%.4f limit=%.4f",
+				user.ID, spend.actualCost, outstanding, estimate, user.BudgetLimit)
+		}
+	}
+
+	timeout := time.Duration(s.cfg.Billing.StreamReservation.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultStreamReservationTimeout
+	}
+	r := &BudgetReservation{
+		ledger:    ledger,
+		amount:    estimate,
+		expiresAt: now.Add(timeout),
+		refs:      1,
+	}
+	ledger.entries = append(ledger.entries, r)
+	return r, nil
+}
+
+// reservedBudget 返回用户仍需计入预算的预留总额
+func (s *BillingCacheService) reservedBudget(userID int64, now, spendLoadedAt time.Time) float64 {
+	v, ok := s.budgetReservations.Load(userID)
+	if !ok {
+		return 0
+	}
+	return v.(*userBudgetReservations).outstanding(now, spendLoadedAt)
+}
+
+func (s *BillingCacheService) userReservations(userID int64) *userBudgetReservations {
+	v, _ := s.budgetReservations.LoadOrStore(userID, &userBudgetReservations{})
+	return v.(*userBudgetReservations)
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fixedCostEstimator struct {
+	costPerOutputToken float64
+}
+
+func (e fixedCostEstimator) EstimateCost(input CostEstimateInput) (*CostEstimate, error) {
+	return &CostEstimate{Model: input.Model, TotalCost: float64(input.OutputTokens) * e.costPerOutputToken}, nil
+}
+
+func newReservationTestService(spent float64) *BillingCacheService {
+	svc := newBudgetTestService(&userSpendRepoStub{rows: []UserDailySpend{{Requests: 1, ActualCost: spent}}}, 1)
+	svc.cfg.Billing.StreamReservation.Enabled = true
+	svc.cfg.Billing.StreamReservation.TimeoutSeconds = 600
+	return svc
+}
+
+func TestReserveStreamBudget_RejectsWhenEstimateExceedsBudget(t *testing.T) {
+	svc := newReservationTestService(8)
+	user := &User{ID: 7, BudgetLimit: 10}
+
+	first, err := svc.ReserveStreamBudget(context.Background(), user, nil, 1.5)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	// 8 + 1.5（进行中）+ 1 > 10
+	_, err = svc.ReserveStreamBudget(context.Background(), user, nil, 1)
+	require.ErrorIs(t, err, ErrUserBudgetExceeded)
+	budgetErr, ok := UserBudgetExceededFromError(err)
+	require.True(t, ok)
+	require.InDelta(t, 9.5, budgetErr.Spent, 1e-9)
+	require.InDelta(t, 10.5, budgetErr.Projected, 1e-9)
+
+	// 软限制、沙盒 Key 与未设置预算的用户不拒绝
+	soft, err := svc.ReserveStreamBudget(context.Background(), &User{ID: 7, BudgetLimit: 10, BudgetSoftLimit: true}, nil, 5)
+	require.NoError(t, err)
+	require.NotNil(t, soft)
+	sandbox, err := svc.ReserveStreamBudget(context.Background(), user, &APIKey{IsSandbox: true}, 5)
+	require.NoError(t, err)
+	require.Nil(t, sandbox)
+	unlimited, err := svc.ReserveStreamBudget(context.Background(), &User{ID: 8}, nil, 5)
+	require.NoError(t, err)
+	require.Nil(t, unlimited)
+}
+
+func TestBudgetReservation_SettleAndRelease(t *testing.T) {
+	svc := newReservationTestService(0)
+	user := &User{ID: 7, BudgetLimit: 10}
+
+	r, err := svc.ReserveStreamBudget(context.Background(), user, nil, 4)
+	require.NoError(t, err)
+	now := time.Now()
+	require.InDelta(t, 4, svc.reservedBudget(user.ID, now, now.Add(-time.Minute)), 1e-9)
+
+	// 用量记录任务持有引用并按实际费用结算，handler 先结束也不会提前释放
+	r.Hold()
+	r.Release()
+	require.InDelta(t, 4, svc.reservedBudget(user.ID, now, now.Add(-time.Minute)), 1e-9)
+	r.Settle(1.25)
+	r.Release()
+	require.InDelta(t, 1.25, r.Amount(), 1e-9)
+	require.InDelta(t, 1.25, svc.reservedBudget(user.ID, time.Now(), now.Add(-time.Minute)), 1e-9)
+
+	// 结算后加载的预算快照已包含该笔费用，不再重复计入
+	require.Zero(t, svc.reservedBudget(user.ID, time.Now(), time.Now().Add(time.Second)))
+
+	// 未结算即结束（上游失败）时释放全部预留
+	failed, err := svc.ReserveStreamBudget(context.Background(), user, nil, 3)
+	require.NoError(t, err)
+	failed.Release()
+	require.Zero(t, svc.reservedBudget(user.ID, time.Now(), now.Add(-time.Minute)))
+}
+
+func TestBudgetReservation_ExpiresWhenAbandoned(t *testing.T) {
+	svc := newReservationTestService(2)
+	svc.cfg.Billing.StreamReservation.TimeoutSeconds = 1
+	user := &User{ID: 7, BudgetLimit: 10}
+
+	// 预留计入常规预算检查：已用 2 + 预估单次 2 + 预留 7 > 10
+	r, err := svc.ReserveStreamBudget(context.Background(), user, nil, 7)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	require.ErrorIs(t, svc.checkUserBudget(context.Background(), user), ErrUserBudgetExceeded)
+
+	// 超时后视为遗弃的流，预留自动失效
+	later := time.Now().Add(2 * time.Second)
+	require.Zero(t, svc.reservedBudget(user.ID, later, time.Now()))
+	r.Settle(7)
+	require.Zero(t, svc.reservedBudget(user.ID, later, time.Now()))
+}
+
+func TestEstimateStreamMaxCost_UsesDefaultMaxTokensAndGroupRate(t *testing.T) {
+	svc := newReservationTestService(0)
+	svc.cfg.Billing.StreamReservation.DefaultMaxOutputTokens = 1000
+	svc.SetStreamCostEstimator(fixedCostEstimator{costPerOutputToken: 0.001})
+
+	cost, err := svc.EstimateStreamMaxCost("claude-sonnet-4", &Group{RateMultiplier: 2}, 100, 0)
+	require.NoError(t, err)
+	require.InDelta(t, 2, cost, 1e-9)
+
+	cost, err = svc.EstimateStreamMaxCost("claude-sonnet-4", nil, 100, 500)
+	require.NoError(t, err)
+	require.InDelta(t, 0.5, cost, 1e-9)
+}
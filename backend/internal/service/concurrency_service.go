@@ -86,6 +86,9 @@ const (
 // ConcurrencyService manages concurrent request limiting for accounts and users
 type ConcurrencyService struct {
 	cache ConcurrencyCache
+
+	// slotWaiters 当前进程内正在等待用户/账号并发槽位的请求数
+	slotWaiters atomic.Int64
 }
 
 // NewConcurrencyService creates a new ConcurrencyService
@@ -365,3 +368,17 @@ func (s *ConcurrencyService) GetAccountConcurrencyBatch(ctx context.Context, acc
 
 	return s.cache.GetAccountConcurrencyBatch(redisCtx, accountIDs)
 }
+
+// TrackSlotWait 记录一个正在等待并发槽位的请求，返回的 done 须在等待结束时调用
+func (s *ConcurrencyService) TrackSlotWait() (done func()) {
+	s.slotWaiters.Add(1)
+	return func() { s.slotWaiters.Add(-1) }
+}
+
+// SlotWaiters 返回当前进程内正在等待并发槽位的请求数
+func (s *ConcurrencyService) SlotWaiters() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.slotWaiters.Load()
+}
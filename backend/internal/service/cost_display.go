@@ -0,0 +1,83 @@
+package service
+
+import (
+	"math"
+	"strconv"
+)
+
+// RoundCostForDisplay 将费用按有效数字位数舍入用于展示（消除 0.0000027500000001 之类的浮点误差）；
+// digits <= 0 时原样返回。仅用于接口响应，计费与存储始终使用完整精度。
+func RoundCostForDisplay(v float64, digits int) float64 {
+	if digits <= 0 || v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(v, 'g', digits, 64), 64)
+	if err != nil {
+		return v
+	}
+	return rounded
+}
+
+// CostDisplaySignificantDigits 接口返回费用的展示精度（有效数字位数），0 表示不舍入
+func (s *BillingService) CostDisplaySignificantDigits() int {
+	if s == nil || s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Pricing.DisplaySignificantDigits
+}
+
+// RoundForDisplay 按展示精度舍入预估结果中的各项费用，并在响应中标记精度
+func (e *CostEstimate) RoundForDisplay(digits int) {
+	if e == nil || digits <= 0 {
+		return
+	}
+	e.DisplaySignificantDigits = digits
+	b := &e.Breakdown
+	b.InputCost = RoundCostForDisplay(b.InputCost, digits)
+	b.OutputCost = RoundCostForDisplay(b.OutputCost, digits)
+	b.CacheReadCost = RoundCostForDisplay(b.CacheReadCost, digits)
+	b.CacheCreationCost = RoundCostForDisplay(b.CacheCreationCost, digits)
+	b.ImageCost = RoundCostForDisplay(b.ImageCost, digits)
+	b.MinChargeTopUp = RoundCostForDisplay(b.MinChargeTopUp, digits)
+	e.TotalCost = RoundCostForDisplay(e.TotalCost, digits)
+	e.BaseCost = RoundCostForDisplay(e.BaseCost, digits)
+	st := &e.Stages
+	st.ListCost = RoundCostForDisplay(st.ListCost, digits)
+	st.CommittedDiscountAmount = RoundCostForDisplay(st.CommittedDiscountAmount, digits)
+	st.DiscountedCost = RoundCostForDisplay(st.DiscountedCost, digits)
+	st.MinCharge = RoundCostForDisplay(st.MinCharge, digits)
+	st.MinChargeTopUp = RoundCostForDisplay(st.MinChargeTopUp, digits)
+	st.MarkupFlatPerMTok = RoundCostForDisplay(st.MarkupFlatPerMTok, digits)
+	st.MarkupAmount = RoundCostForDisplay(st.MarkupAmount, digits)
+	st.TotalCost = RoundCostForDisplay(st.TotalCost, digits)
+	if m := e.CacheMix; m != nil {
+		m.BlendedInputCost = RoundCostForDisplay(m.BlendedInputCost, digits)
+		m.BlendedInputCostPerMTok = RoundCostForDisplay(m.BlendedInputCostPerMTok, digits)
+	}
+}
+
+// RoundForDisplay 按展示精度舍入消费汇总中的各项费用，并在响应中标记精度
+func (s *UserSpendSummary) RoundForDisplay(digits int) {
+	if s == nil || digits <= 0 {
+		return
+	}
+	s.DisplaySignificantDigits = digits
+	s.TotalCost = RoundCostForDisplay(s.TotalCost, digits)
+	s.ActualCost = RoundCostForDisplay(s.ActualCost, digits)
+	s.CacheReadCostUSD = RoundCostForDisplay(s.CacheReadCostUSD, digits)
+	s.CacheSavingsUSD = RoundCostForDisplay(s.CacheSavingsUSD, digits)
+	for i := range s.Daily {
+		d := &s.Daily[i]
+		d.TotalCost = RoundCostForDisplay(d.TotalCost, digits)
+		d.ActualCost = RoundCostForDisplay(d.ActualCost, digits)
+		d.CacheReadCostUSD = RoundCostForDisplay(d.CacheReadCostUSD, digits)
+		d.CacheSavingsUSD = RoundCostForDisplay(d.CacheSavingsUSD, digits)
+	}
+	for i := range s.Monthly {
+		m := &s.Monthly[i]
+		m.TotalCost = RoundCostForDisplay(m.TotalCost, digits)
+		m.ActualCost = RoundCostForDisplay(m.ActualCost, digits)
+		m.CacheReadCostUSD = RoundCostForDisplay(m.CacheReadCostUSD, digits)
+		m.CacheSavingsUSD = RoundCostForDisplay(m.CacheSavingsUSD, digits)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundCostForDisplay(t *testing.T) {
+	require.Equal(t, 0.00000275, RoundCostForDisplay(0.0000027500000001, 6))
+	require.Equal(t, 1.23457, RoundCostForDisplay(1.234567, 6))
+	require.Equal(t, 0.0000027500000001, RoundCostForDisplay(0.0000027500000001, 0))
+	require.Zero(t, RoundCostForDisplay(0, 6))
+}
+
+func TestCostEstimate_RoundForDisplay(t *testing.T) {
+	estimate := &CostEstimate{TotalCost: 0.30000000000000004, Breakdown: CostEstimateBreakdown{InputCost: 0.1 + 0.2}}
+	estimate.RoundForDisplay(6)
+	require.Equal(t, 0.3, estimate.TotalCost)
+	require.Equal(t, 0.3, estimate.Breakdown.InputCost)
+	require.Equal(t, 6, estimate.DisplaySignificantDigits)
+
+	full := &CostEstimate{TotalCost: 0.30000000000000004}
+	full.RoundForDisplay(0)
+	require.Equal(t, 0.30000000000000004, full.TotalCost)
+	require.Zero(t, full.DisplaySignificantDigits)
+}
+
+func TestUserSpendSummary_RoundForDisplay(t *testing.T) {
+	summary := &UserSpendSummary{
+		ActualCost: 0.1 + 0.2,
+		Daily:      []UserDailySpend{{ActualCost: 0.1 + 0.2}},
+		Monthly:    []UserMonthlySpend{{ActualCost: 0.1 + 0.2}},
+	}
+	summary.RoundForDisplay(6)
+	require.Equal(t, 0.3, summary.ActualCost)
+	require.Equal(t, 0.3, summary.Daily[0].ActualCost)
+	require.Equal(t, 0.3, summary.Monthly[0].ActualCost)
+	require.Equal(t, 6, summary.DisplaySignificantDigits)
+}
@@ -10,6 +10,7 @@ const (
 	StatusUnused   = domain.StatusUnused
 	StatusUsed     = domain.StatusUsed
 	StatusExpired  = domain.StatusExpired
+	StatusDraining = domain.StatusDraining
 )
 
 // Role constants
@@ -366,6 +367,9 @@ const (
 	// SettingKeyBackendModeEnabled Backend 模式：禁用用户注册和自助服务，仅管理员可登录
 	SettingKeyBackendModeEnabled = "backend_mode_enabled"
 
+	// SettingKeyMaintenanceMode 持久化的网关维护模式状态（JSON，仅在开启时选择持久化才写入）
+	SettingKeyMaintenanceMode = "maintenance_mode"
+
 	// Gateway Forwarding Behavior
 	// SettingKeyEnableFingerprintUnification 是否统一 OAuth 账号的 X-Stainless-* 指纹头（默认 true）
 	SettingKeyEnableFingerprintUnification = "enable_fingerprint_unification"
@@ -44,15 +44,10 @@ func (s *GatewayService) ForwardAsChatCompletions(
 	clientStream := ccReq.Stream
 	includeUsage := ccReq.StreamOptions != nil && ccReq.StreamOptions.IncludeUsage
 
-	// 2. Convert CC → Responses → Anthropic (chained conversion)
-	responsesReq, err := apicompat.ChatCompletionsToResponses(&ccReq)
+	// 2. Convert CC → Anthropic (chained via Responses, plus CC-only fields like stop)
+	anthropicReq, err := apicompat.ChatCompletionsToAnthropicRequest(&ccReq)
 	if err != nil {
-		return nil, fmt.Errorf("convert chat completions to responses: %w", err)
-	}
-
-	anthropicReq, err := apicompat.ResponsesToAnthropicRequest(responsesReq)
-	if err != nil {
-		return nil, fmt.Errorf("convert responses to anthropic: %w", err)
+		return nil, err
 	}
 
 	// 3. Force upstream streaming
@@ -126,6 +121,7 @@ func (s *GatewayService) ForwardAsChatCompletions(
 	}
 
 	// 11. Send request
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 	if err != nil {
 		if resp != nil && resp.Body != nil {
@@ -141,6 +137,9 @@ func (s *GatewayService) ForwardAsChatCompletions(
 			Kind:               "request_error",
 			Message:            safeErr,
 		})
+		if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+			return nil, failoverErr
+		}
 		writeGatewayCCError(c, http.StatusBadGateway, "server_error", "Upstream request failed")
 		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
 	}
@@ -368,6 +367,8 @@ func (s *GatewayService) handleCCStreamingFromAnthropic(
 	ccState.IncludeUsage = includeUsage
 
 	var usage ClaudeUsage
+	var finalUsageSeen bool
+	var estimator streamUsageEstimator
 	var firstTokenMs *int
 	firstChunk := true
 
@@ -379,6 +380,13 @@ func (s *GatewayService) handleCCStreamingFromAnthropic(
 	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
 
 	resultWithUsage := func() *ForwardResult {
+		// 流在 message_delta 之前中断（上游断流或客户端断开）时按已下发内容估算输出 token
+		if applyAnthropicStreamOutputEstimate(&usage, finalUsageSeen, &estimator, s.billingService.tokenCountFor(mappedModel)) {
+			logger.L().Info("forward_as_cc stream: final usage missing, billing with estimated output tokens",
+				zap.String("request_id", requestID),
+				zap.Int("estimated_output_tokens", usage.OutputTokens),
+			)
+		}
 		return &ForwardResult{
 			RequestID:       requestID,
 			Usage:           usage,
@@ -415,11 +423,13 @@ func (s *GatewayService) handleCCStreamingFromAnthropic(
 		// Extract usage from message_delta
 		if event.Type == "message_delta" && event.Usage != nil {
 			mergeAnthropicUsage(&usage, *event.Usage)
+			finalUsageSeen = true
 		}
 		// Also capture usage from message_start (carries cache fields)
 		if event.Type == "message_start" && event.Message != nil {
 			mergeAnthropicUsage(&usage, event.Message.Usage)
 		}
+		estimator.observeAnthropicEvent(event)
 
 		// Chain: Anthropic event → Responses events → CC chunks
 		responsesEvents := apicompat.AnthropicEventToResponsesEvents(event, anthState)
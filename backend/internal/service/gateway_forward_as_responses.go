@@ -123,6 +123,7 @@ func (s *GatewayService) ForwardAsResponses(
 	}
 
 	// 11. Send request
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 	if err != nil {
 		if resp != nil && resp.Body != nil {
@@ -138,6 +139,9 @@ func (s *GatewayService) ForwardAsResponses(
 			Kind:               "request_error",
 			Message:            safeErr,
 		})
+		if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+			return nil, failoverErr
+		}
 		writeResponsesError(c, http.StatusBadGateway, "server_error", "Upstream request failed")
 		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
 	}
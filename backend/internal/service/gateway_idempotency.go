@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// GatewayIdempotentResponse 已成功完成的网关请求结果，重复请求时原样返回
+type GatewayIdempotentResponse struct {
+	Fingerprint string            `json:"fingerprint"`
+	StatusCode  int               `json:"status_code"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        []byte            `json:"body"`
+}
+
+// GatewayIdempotencyCache 网关 Idempotency-Key → 结果的存储（默认 Redis 实现，可替换）
+type GatewayIdempotencyCache interface {
+	// GetGatewayResponse 未命中时返回 nil, nil
+	GetGatewayResponse(ctx context.Context, key string) (*GatewayIdempotentResponse, error)
+	SetGatewayResponse(ctx context.Context, key string, resp *GatewayIdempotentResponse, ttl time.Duration) error
+	// AcquireGatewayInFlight 原子地标记 key 正在处理（SETNX），已被标记时返回 false
+	AcquireGatewayInFlight(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// ReleaseGatewayInFlight 清除处理中标记
+	ReleaseGatewayInFlight(ctx context.Context, key string) error
+}
+
+// GatewayIdempotencyService 网关请求幂等：同一 API Key 在 TTL 内重复提交相同 Idempotency-Key 时
+// 直接返回已缓存的成功结果，不再转发上游，也不会重复计费。
+type GatewayIdempotencyService struct {
+	cache    GatewayIdempotencyCache
+	ttl      time.Duration
+	maxBytes int
+}
+
+// NewGatewayIdempotencyService 创建网关幂等服务
+func NewGatewayIdempotencyService(cache GatewayIdempotencyCache, cfg *config.Config) *GatewayIdempotencyService {
+	s := &GatewayIdempotencyService{cache: cache}
+	if cfg != nil {
+		s.ttl = time.Duration(cfg.Idempotency.GatewayTTLSeconds) * time.Second
+		s.maxBytes = cfg.Idempotency.GatewayMaxResponseBytes
+	}
+	return s
+}
+
+// Enabled 是否启用网关幂等
+func (s *GatewayIdempotencyService) Enabled() bool {
+	return s != nil && s.cache != nil && s.ttl > 0
+}
+
+// MaxResponseBytes 可缓存响应体的最大长度
+func (s *GatewayIdempotencyService) MaxResponseBytes() int {
+	return s.maxBytes
+}
+
+// Lookup 查找已缓存的结果；同一 key 对应的请求内容不同时返回 ErrIdempotencyKeyConflict
+func (s *GatewayIdempotencyService) Lookup(ctx context.Context, apiKeyID int64, idempotencyKey, fingerprint string) (*GatewayIdempotentResponse, error) {
+	cached, err := s.cache.GetGatewayResponse(ctx, gatewayIdempotencyCacheKey(apiKeyID, idempotencyKey))
+	if err != nil || cached == nil {
+		return nil, err
+	}
+	if cached.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyConflict
+	}
+	return cached, nil
+}
+
+// Store 缓存已完成的成功响应；非 2xx 或超过长度上限的响应不缓存
+func (s *GatewayIdempotencyService) Store(ctx context.Context, apiKeyID int64, idempotencyKey string, resp *GatewayIdempotentResponse) error {
+	if resp == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil
+	}
+	if s.maxBytes > 0 && len(resp.Body) > s.maxBytes {
+		return nil
+	}
+	return s.cache.SetGatewayResponse(ctx, gatewayIdempotencyCacheKey(apiKeyID, idempotencyKey), resp, s.ttl)
+}
+
+// AcquireInFlight 标记请求开始处理；同一 key 的请求仍在处理中时返回 ErrIdempotencyInProgress。
+// 标记与结果使用相同 TTL，进程异常退出未清除时到期自动失效。
+func (s *GatewayIdempotencyService) AcquireInFlight(ctx context.Context, apiKeyID int64, idempotencyKey string) error {
+	acquired, err := s.cache.AcquireGatewayInFlight(ctx, gatewayIdempotencyCacheKey(apiKeyID, idempotencyKey), s.ttl)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrIdempotencyInProgress
+	}
+	return nil
+}
+
+// ReleaseInFlight 请求处理结束（成功结果已缓存或失败）后清除处理中标记，失败的请求可以重试
+func (s *GatewayIdempotencyService) ReleaseInFlight(ctx context.Context, apiKeyID int64, idempotencyKey string) error {
+	return s.cache.ReleaseGatewayInFlight(ctx, gatewayIdempotencyCacheKey(apiKeyID, idempotencyKey))
+}
+
+// GatewayIdempotencyFingerprint 以请求方法、路径与请求体生成指纹，用于识别 key 被复用于不同请求
+func GatewayIdempotencyFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(strings.ToUpper(method) + "\n" + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func gatewayIdempotencyCacheKey(apiKeyID int64, idempotencyKey string) string {
+	return strconv.FormatInt(apiKeyID, 10) + ":" + HashIdempotencyKey(idempotencyKey)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+)
+
+const gatewayLoadQueryTimeout = 3 * time.Second
+
+// GatewayLoadSnapshot 网关负载快照，供外部自动扩缩容参考（本服务不做扩缩容）。
+// InFlight / QueueDepth 为当前进程的统计；账号容量与槽位占用为全部实例共享的数据。
+type GatewayLoadSnapshot struct {
+	Enabled bool `json:"enabled"`
+	// InFlight 当前进程内进行中的网关请求数
+	InFlight int64 `json:"in_flight"`
+	// QueueDepth 当前进程内等待优先级队列或并发槽位的请求数
+	QueueDepth int64 `json:"queue_depth"`
+	// Capacity 可调度账号的并发上限之和
+	Capacity int64 `json:"capacity"`
+	// InUse 可调度账号当前占用的并发槽位之和（全部实例）
+	InUse int64 `json:"in_use"`
+	// UtilizationPercent InUse 相对 Capacity 的百分比
+	UtilizationPercent float64         `json:"utilization_percent"`
+	Models             []ModelLoadInfo `json:"models"`
+	CollectedAt        time.Time       `json:"collected_at"`
+}
+
+// ModelLoadInfo 单个模型的负载：饱和度按能服务该模型的账号计算
+type ModelLoadInfo struct {
+	Model             string  `json:"model"`
+	InFlight          int64   `json:"in_flight"`
+	Capacity          int64   `json:"capacity"`
+	InUse             int64   `json:"in_use"`
+	SaturationPercent float64 `json:"saturation_percent"`
+}
+
+// gatewayCapacity 缓存的账号容量与槽位占用
+type gatewayCapacity struct {
+	accounts    []Account
+	inUse       map[int64]int
+	collectedAt time.Time
+}
+
+// GatewayLoadService 统计进行中的网关请求并结合账号并发容量计算利用率与按模型饱和度，
+// 通过 /metrics（sub2api_gateway_*）与管理接口暴露。
+type GatewayLoadService struct {
+	cfg                *config.Config
+	accountRepo        AccountRepository
+	concurrencyService *ConcurrencyService
+	priorityQueue      *GatewayPriorityQueue
+
+	inFlight      atomic.Int64
+	modelMu       sync.Mutex
+	modelInFlight map[string]int64
+
+	capacityMu sync.Mutex
+	capacity   *gatewayCapacity
+}
+
+// NewGatewayLoadService 创建网关负载统计服务
+func NewGatewayLoadService(cfg *config.Config, accountRepo AccountRepository, concurrencyService *ConcurrencyService, priorityQueue *GatewayPriorityQueue) *GatewayLoadService {
+	return &GatewayLoadService{
+		cfg:                cfg,
+		accountRepo:        accountRepo,
+		concurrencyService: concurrencyService,
+		priorityQueue:      priorityQueue,
+		modelInFlight:      make(map[string]int64),
+	}
+}
+
+// Enabled 是否启用负载统计
+func (s *GatewayLoadService) Enabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Gateway.LoadHints.Enabled
+}
+
+// Track 记录一个进行中的网关请求，返回的 done 须在请求结束时调用。
+// 模型名经 metrics.ModelLabel 规范化，未知模型归入 other。
+func (s *GatewayLoadService) Track(model string) (done func()) {
+	if !s.Enabled() {
+		return func() {}
+	}
+	label := metrics.ModelLabel(model)
+	s.inFlight.Add(1)
+	s.modelMu.Lock()
+	s.modelInFlight[label]++
+	s.modelMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.inFlight.Add(-1)
+			s.modelMu.Lock()
+			s.modelInFlight[label]--
+			s.modelMu.Unlock()
+		})
+	}
+}
+
+// Snapshot 返回当前负载快照；账号容量与槽位占用按 capacity_cache_seconds 缓存
+func (s *GatewayLoadService) Snapshot(ctx context.Context) *GatewayLoadSnapshot {
+	snapshot := &GatewayLoadSnapshot{Enabled: s.Enabled(), Models: []ModelLoadInfo{}, CollectedAt: time.Now()}
+	if !snapshot.Enabled {
+		return snapshot
+	}
+	snapshot.InFlight = s.inFlight.Load()
+	snapshot.QueueDepth = int64(s.priorityQueue.Status().Waiting) + s.concurrencyService.SlotWaiters()
+
+	// Account.IsModelSupported 会写入账号内的映射缓存，遍历缓存账号期间持有锁
+	s.capacityMu.Lock()
+	defer s.capacityMu.Unlock()
+	capacity := s.loadCapacityLocked(ctx)
+	snapshot.CollectedAt = capacity.collectedAt
+	for i := range capacity.accounts {
+		acc := &capacity.accounts[i]
+		snapshot.Capacity += int64(acc.Concurrency)
+		snapshot.InUse += int64(capacity.inUse[acc.ID])
+	}
+	snapshot.UtilizationPercent = loadPercent(snapshot.InUse, snapshot.Capacity)
+
+	s.modelMu.Lock()
+	models := make(map[string]int64, len(s.modelInFlight))
+	for model, n := range s.modelInFlight {
+		models[model] = n
+	}
+	s.modelMu.Unlock()
+	for model, n := range models {
+		info := ModelLoadInfo{Model: model, InFlight: n}
+		if model != metrics.OtherLabel && model != metrics.UnknownLabel {
+			for i := range capacity.accounts {
+				acc := &capacity.accounts[i]
+				if acc.IsModelSupported(model) {
+					info.Capacity += int64(acc.Concurrency)
+					info.InUse += int64(capacity.inUse[acc.ID])
+				}
+			}
+		}
+		info.SaturationPercent = loadPercent(info.InUse, info.Capacity)
+		snapshot.Models = append(snapshot.Models, info)
+	}
+	sort.Slice(snapshot.Models, func(i, j int) bool { return snapshot.Models[i].Model < snapshot.Models[j].Model })
+	return snapshot
+}
+
+// loadCapacityLocked 返回可调度账号（并发上限大于 0）及其当前槽位占用，查询失败时沿用上次结果
+func (s *GatewayLoadService) loadCapacityLocked(ctx context.Context) *gatewayCapacity {
+	ttl := time.Duration(s.cfg.Gateway.LoadHints.CapacityCacheSeconds) * time.Second
+	if s.capacity != nil && time.Since(s.capacity.collectedAt) < ttl {
+		return s.capacity
+	}
+
+	next := &gatewayCapacity{inUse: map[int64]int{}, collectedAt: time.Now()}
+	if s.accountRepo != nil {
+		queryCtx, cancel := context.WithTimeout(ctx, gatewayLoadQueryTimeout)
+		defer cancel()
+		accounts, err := s.accountRepo.ListSchedulable(queryCtx)
+		if err != nil {
+			logger.LegacyPrintf("service.gateway_load", "Warning: list schedulable accounts failed: %v", err)
+			if s.capacity != nil {
+				return s.capacity
+			}
+		}
+		ids := make([]int64, 0, len(accounts))
+		for i := range accounts {
+			if accounts[i].IsSchedulable() && accounts[i].Concurrency > 0 {
+				next.accounts = append(next.accounts, accounts[i])
+				ids = append(ids, accounts[i].ID)
+			}
+		}
+		if s.concurrencyService != nil {
+			inUse, err := s.concurrencyService.GetAccountConcurrencyBatch(queryCtx, ids)
+			if err != nil {
+				logger.LegacyPrintf("service.gateway_load", "Warning: get account concurrency failed: %v", err)
+			} else {
+				next.inUse = inUse
+			}
+		}
+	}
+	s.capacity = next
+	return next
+}
+
+// ExportMetrics 把负载快照注册为 /metrics 的 sub2api_gateway_* 指标来源
+func (s *GatewayLoadService) ExportMetrics() {
+	if !s.Enabled() {
+		return
+	}
+	metrics.SetGatewayLoadProvider(func() metrics.GatewayLoad {
+		snapshot := s.Snapshot(context.Background())
+		load := metrics.GatewayLoad{
+			InFlight:           snapshot.InFlight,
+			QueueDepth:         snapshot.QueueDepth,
+			Capacity:           snapshot.Capacity,
+			InUse:              snapshot.InUse,
+			UtilizationPercent: snapshot.UtilizationPercent,
+		}
+		for _, m := range snapshot.Models {
+			load.Models = append(load.Models, metrics.ModelLoad{
+				Model:             m.Model,
+				InFlight:          m.InFlight,
+				Capacity:          m.Capacity,
+				InUse:             m.InUse,
+				SaturationPercent: m.SaturationPercent,
+			})
+		}
+		return load
+	})
+}
+
+func loadPercent(used, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(used) / float64(capacity) * 100
+}
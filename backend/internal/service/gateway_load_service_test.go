@@ -0,0 +1,80 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newGatewayLoadTestService(accounts []Account, slotsInUse int) *GatewayLoadService {
+	cfg := &config.Config{}
+	cfg.Gateway.LoadHints.Enabled = true
+	cfg.Gateway.LoadHints.CapacityCacheSeconds = 60
+	concurrency := NewConcurrencyService(&stubConcurrencyCacheForTest{concurrency: slotsInUse})
+	return NewGatewayLoadService(cfg, &healthCheckAccountRepoStub{accounts: accounts}, concurrency, nil)
+}
+
+func TestGatewayLoad_UtilizationAndModelSaturation(t *testing.T) {
+	accounts := []Account{
+		{ID: 1, Status: StatusActive, Schedulable: true, Concurrency: 10},
+		{ID: 2, Status: StatusActive, Schedulable: true, Concurrency: 6, Credentials: map[string]any{
+			"model_mapping": map[string]any{"gpt-4o": "gpt-4o"},
+		}},
+		// 不可调度或未设置并发上限的账号不计入容量
+		{ID: 3, Status: StatusActive, Schedulable: false, Concurrency: 50},
+		{ID: 4, Status: StatusActive, Schedulable: true, Concurrency: 0},
+	}
+	svc := newGatewayLoadTestService(accounts, 4)
+
+	doneA := svc.Track("gpt-4o")
+	doneB := svc.Track("gpt-4o")
+	doneC := svc.Track("claude-sonnet-4-5")
+	defer doneC()
+
+	snapshot := svc.Snapshot(context.Background())
+	require.True(t, snapshot.Enabled)
+	require.Equal(t, int64(3), snapshot.InFlight)
+	require.Equal(t, int64(16), snapshot.Capacity)
+	require.Equal(t, int64(8), snapshot.InUse)
+	require.InDelta(t, 50.0, snapshot.UtilizationPercent, 1e-9)
+
+	require.Len(t, snapshot.Models, 2)
+	claude, gpt := snapshot.Models[0], snapshot.Models[1]
+	require.Equal(t, "claude-sonnet-4-5", claude.Model)
+	require.Equal(t, int64(1), claude.InFlight)
+	require.Equal(t, int64(10), claude.Capacity)
+	require.InDelta(t, 40.0, claude.SaturationPercent, 1e-9)
+	require.Equal(t, "gpt-4o", gpt.Model)
+	require.Equal(t, int64(2), gpt.InFlight)
+	require.Equal(t, int64(16), gpt.Capacity)
+
+	doneA()
+	doneA() // 重复调用只生效一次
+	doneB()
+	snapshot = svc.Snapshot(context.Background())
+	require.Equal(t, int64(1), snapshot.InFlight)
+	require.Equal(t, int64(0), snapshot.Models[1].InFlight)
+}
+
+func TestGatewayLoad_QueueDepthCountsSlotWaiters(t *testing.T) {
+	svc := newGatewayLoadTestService(nil, 0)
+	done := svc.concurrencyService.TrackSlotWait()
+	require.Equal(t, int64(1), svc.Snapshot(context.Background()).QueueDepth)
+	done()
+	snapshot := svc.Snapshot(context.Background())
+	require.Equal(t, int64(0), snapshot.QueueDepth)
+	require.Zero(t, snapshot.UtilizationPercent)
+}
+
+func TestGatewayLoad_DisabledIsNoop(t *testing.T) {
+	svc := NewGatewayLoadService(&config.Config{}, nil, nil, nil)
+	svc.Track("gpt-4o")()
+	snapshot := svc.Snapshot(context.Background())
+	require.False(t, snapshot.Enabled)
+	require.Zero(t, snapshot.InFlight)
+	require.Empty(t, snapshot.Models)
+}
@@ -88,7 +88,8 @@ func (m *mockAccountRepoForPlatform) ListCRSAccountIDs(ctx context.Context) (map
 func (m *mockAccountRepoForPlatform) Update(ctx context.Context, account *Account) error {
 	return nil
 }
-func (m *mockAccountRepoForPlatform) Delete(ctx context.Context, id int64) error { return nil }
+func (m *mockAccountRepoForPlatform) Delete(ctx context.Context, id int64) error  { return nil }
+func (m *mockAccountRepoForPlatform) Restore(ctx context.Context, id int64) error { return nil }
 func (m *mockAccountRepoForPlatform) List(ctx context.Context, params pagination.PaginationParams) ([]Account, *pagination.PaginationResult, error) {
 	return nil, nil, nil
 }
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+)
+
+var (
+	// ErrPriorityQueueFull 排队请求数已达上限
+	ErrPriorityQueueFull = errors.New("priority queue is full")
+	// ErrPriorityQueueTimeout 排队等待超过 max_wait_seconds
+	ErrPriorityQueueTimeout = errors.New("priority queue wait timeout")
+)
+
+// PriorityQueueTierDepth 某个优先级层级当前排队的请求数
+type PriorityQueueTierDepth struct {
+	Tier    int `json:"tier"`
+	Waiting int `json:"waiting"`
+}
+
+// PriorityQueueStatus 优先级队列的当前状态
+type PriorityQueueStatus struct {
+	Enabled               bool                     `json:"enabled"`
+	MaxConcurrentRequests int                      `json:"max_concurrent_requests"`
+	MaxWaitSeconds        int                      `json:"max_wait_seconds"`
+	MaxQueueSize          int                      `json:"max_queue_size"`
+	Active                int                      `json:"active"`
+	Waiting               int                      `json:"waiting"`
+	Tiers                 []PriorityQueueTierDepth `json:"tiers"`
+}
+
+// priorityWaiter 排队中的一个请求
+type priorityWaiter struct {
+	tier    int
+	seq     uint64
+	ready   chan struct{}
+	index   int
+	granted bool
+}
+
+// priorityWaiterHeap 按层级降序、到达顺序升序排列的最小堆
+type priorityWaiterHeap []*priorityWaiter
+
+func (h priorityWaiterHeap) Len() int { return len(h) }
+
+func (h priorityWaiterHeap) Less(i, j int) bool {
+	if h[i].tier != h[j].tier {
+		return h[i].tier > h[j].tier
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityWaiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityWaiterHeap) Push(x any) {
+	w := x.(*priorityWaiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *priorityWaiterHeap) Pop() any {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
+
+// GatewayPriorityQueue 网关前置的进程内优先级队列。
+// 并发未满时请求直接放行；已满时按 API Key 的优先级层级（越大越优先）、再按到达顺序排队，
+// 槽位释放时直接移交给队首请求。等待超过 max_wait_seconds 或队列已满时拒绝。
+type GatewayPriorityQueue struct {
+	cfg *config.Config
+
+	mu      sync.Mutex
+	active  int
+	seq     uint64
+	waiters priorityWaiterHeap
+	depth   map[int]int
+}
+
+// NewGatewayPriorityQueue 创建网关优先级队列
+func NewGatewayPriorityQueue(cfg *config.Config) *GatewayPriorityQueue {
+	return &GatewayPriorityQueue{cfg: cfg, depth: make(map[int]int)}
+}
+
+func (q *GatewayPriorityQueue) settings() config.PriorityQueueConfig {
+	if q == nil || q.cfg == nil {
+		return config.PriorityQueueConfig{}
+	}
+	return q.cfg.Gateway.PriorityQueue
+}
+
+// Enabled 是否启用排队（未启用或未设置并发上限时所有请求直接放行）
+func (q *GatewayPriorityQueue) Enabled() bool {
+	s := q.settings()
+	return s.Enabled && s.MaxConcurrentRequests > 0
+}
+
+// Acquire 获取一个并发槽位，必要时按层级排队等待。
+// 成功时返回的 release 须在请求结束时调用；队列已满返回 ErrPriorityQueueFull，
+// 等待超时返回 ErrPriorityQueueTimeout，ctx 取消时返回 ctx.Err()。
+func (q *GatewayPriorityQueue) Acquire(ctx context.Context, tier int) (func(), error) {
+	if !q.Enabled() {
+		return func() {}, nil
+	}
+	s := q.settings()
+
+	q.mu.Lock()
+	if q.active < s.MaxConcurrentRequests && len(q.waiters) == 0 {
+		q.active++
+		metrics.SetPriorityQueueActive(q.active)
+		q.mu.Unlock()
+		return q.releaseFunc(), nil
+	}
+	if s.MaxWaitSeconds <= 0 {
+		q.mu.Unlock()
+		metrics.ObservePriorityQueueRejected(tier)
+		return nil, ErrPriorityQueueTimeout
+	}
+	if s.MaxQueueSize > 0 && len(q.waiters) >= s.MaxQueueSize {
+		q.mu.Unlock()
+		metrics.ObservePriorityQueueRejected(tier)
+		return nil, ErrPriorityQueueFull
+	}
+	q.seq++
+	w := &priorityWaiter{tier: tier, seq: q.seq, ready: make(chan struct{})}
+	heap.Push(&q.waiters, w)
+	q.adjustDepthLocked(tier, 1)
+	q.mu.Unlock()
+
+	timer := time.NewTimer(time.Duration(s.MaxWaitSeconds) * time.Second)
+	defer timer.Stop()
+
+	var waitErr error
+	select {
+	case <-w.ready:
+		return q.releaseFunc(), nil
+	case <-timer.C:
+		waitErr = ErrPriorityQueueTimeout
+	case <-ctx.Done():
+		waitErr = ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if w.granted {
+		// 超时与放行同时发生：槽位已移交给本请求，照常放行
+		return q.releaseFunc(), nil
+	}
+	heap.Remove(&q.waiters, w.index)
+	q.adjustDepthLocked(tier, -1)
+	if errors.Is(waitErr, ErrPriorityQueueTimeout) {
+		metrics.ObservePriorityQueueRejected(tier)
+	}
+	return nil, waitErr
+}
+
+// releaseFunc 返回只生效一次的槽位释放函数：有排队请求时将槽位移交给队首，否则归还
+func (q *GatewayPriorityQueue) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			if len(q.waiters) > 0 {
+				next := heap.Pop(&q.waiters).(*priorityWaiter)
+				next.granted = true
+				q.adjustDepthLocked(next.tier, -1)
+				close(next.ready)
+				return
+			}
+			if q.active > 0 {
+				q.active--
+			}
+			metrics.SetPriorityQueueActive(q.active)
+		})
+	}
+}
+
+func (q *GatewayPriorityQueue) adjustDepthLocked(tier, delta int) {
+	n := q.depth[tier] + delta
+	if n <= 0 {
+		delete(q.depth, tier)
+		n = 0
+	} else {
+		q.depth[tier] = n
+	}
+	metrics.SetPriorityQueueDepth(tier, n)
+}
+
+// Status 返回当前并发与各层级排队深度（层级按优先级降序）
+func (q *GatewayPriorityQueue) Status() PriorityQueueStatus {
+	s := q.settings()
+	status := PriorityQueueStatus{
+		Enabled:               q.Enabled(),
+		MaxConcurrentRequests: s.MaxConcurrentRequests,
+		MaxWaitSeconds:        s.MaxWaitSeconds,
+		MaxQueueSize:          s.MaxQueueSize,
+		Tiers:                 []PriorityQueueTierDepth{},
+	}
+	if q == nil {
+		return status
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	status.Active = q.active
+	status.Waiting = len(q.waiters)
+	for tier, n := range q.depth {
+		status.Tiers = append(status.Tiers, PriorityQueueTierDepth{Tier: tier, Waiting: n})
+	}
+	sort.Slice(status.Tiers, func(i, j int) bool { return status.Tiers[i].Tier > status.Tiers[j].Tier })
+	return status
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPriorityQueue(maxConcurrent, maxWaitSeconds, maxQueueSize int) *GatewayPriorityQueue {
+	cfg := &config.Config{}
+	cfg.Gateway.PriorityQueue = config.PriorityQueueConfig{
+		Enabled:               true,
+		MaxConcurrentRequests: maxConcurrent,
+		MaxWaitSeconds:        maxWaitSeconds,
+		MaxQueueSize:          maxQueueSize,
+	}
+	return NewGatewayPriorityQueue(cfg)
+}
+
+// waitForQueued 等待排队请求数达到 n
+func waitForQueued(t *testing.T, q *GatewayPriorityQueue, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return q.Status().Waiting == n }, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestGatewayPriorityQueue_OrdersByTierThenArrival(t *testing.T) {
+	q := newTestPriorityQueue(1, 10, 0)
+	release, err := q.Acquire(context.Background(), 0)
+	require.NoError(t, err)
+
+	order := make(chan string, 4)
+	enqueue := func(name string, tier int) {
+		go func() {
+			r, err := q.Acquire(context.Background(), tier)
+			if err != nil {
+				order <- "error:" + name
+				return
+			}
+			order <- name
+			r()
+		}()
+	}
+	enqueue("free-1", 0)
+	waitForQueued(t, q, 1)
+	enqueue("premium-1", 2)
+	waitForQueued(t, q, 2)
+	enqueue("free-2", 0)
+	waitForQueued(t, q, 3)
+	enqueue("premium-2", 2)
+	waitForQueued(t, q, 4)
+
+	status := q.Status()
+	require.Equal(t, 1, status.Active)
+	require.Equal(t, []PriorityQueueTierDepth{{Tier: 2, Waiting: 2}, {Tier: 0, Waiting: 2}}, status.Tiers)
+
+	release()
+	var got []string
+	for range 4 {
+		select {
+		case name := <-order:
+			got = append(got, name)
+		case <-time.After(2 * time.Second):
+			t.Fatal("queued request was not released")
+		}
+	}
+	require.Equal(t, []string{"premium-1", "premium-2", "free-1", "free-2"}, got)
+	require.Zero(t, q.Status().Active)
+	require.Empty(t, q.Status().Tiers)
+}
+
+func TestGatewayPriorityQueue_RejectsWhenFullOrTimedOut(t *testing.T) {
+	q := newTestPriorityQueue(1, 1, 1)
+	release, err := q.Acquire(context.Background(), 0)
+	require.NoError(t, err)
+	defer release()
+
+	timedOut := make(chan error, 1)
+	go func() {
+		_, err := q.Acquire(context.Background(), 0)
+		timedOut <- err
+	}()
+	waitForQueued(t, q, 1)
+
+	_, err = q.Acquire(context.Background(), 5)
+	require.ErrorIs(t, err, ErrPriorityQueueFull)
+
+	require.ErrorIs(t, <-timedOut, ErrPriorityQueueTimeout)
+	require.Zero(t, q.Status().Waiting)
+
+	// 客户端取消时返回 ctx 错误
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = q.Acquire(ctx, 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGatewayPriorityQueue_DisabledPassesThrough(t *testing.T) {
+	q := NewGatewayPriorityQueue(&config.Config{})
+	for range 3 {
+		release, err := q.Acquire(context.Background(), 0)
+		require.NoError(t, err)
+		release()
+	}
+	require.False(t, q.Status().Enabled)
+}
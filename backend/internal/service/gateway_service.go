@@ -28,6 +28,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
 	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
@@ -570,6 +571,7 @@ type GatewayService struct {
 	debugGatewayBodyFile  atomic.Pointer[os.File] // non-nil when SUB2API_DEBUG_GATEWAY_BODY is set
 	tlsFPProfileService   *TLSFingerprintProfileService
 	balanceNotifyService  *BalanceNotifyService
+	slowRequestLogger     *SlowRequestLogger
 }
 
 // NewGatewayService creates a new GatewayService
@@ -600,6 +602,7 @@ func NewGatewayService(
 	channelService *ChannelService,
 	resolver *ModelPricingResolver,
 	balanceNotifyService *BalanceNotifyService,
+	slowRequestLogger *SlowRequestLogger,
 ) *GatewayService {
 	userGroupRateTTL := resolveUserGroupRateCacheTTL(cfg)
 	modelsListTTL := resolveModelsListCacheTTL(cfg)
@@ -635,6 +638,7 @@ func NewGatewayService(
 		channelService:       channelService,
 		resolver:             resolver,
 		balanceNotifyService: balanceNotifyService,
+		slowRequestLogger:    slowRequestLogger,
 	}
 	svc.userGroupRateResolver = newUserGroupRateResolver(
 		userGroupRateRepo,
@@ -1602,6 +1606,10 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				modelScopeSkippedIDs = append(modelScopeSkippedIDs, account.ID)
 				continue
 			}
+			if account.IsDrainOnly() {
+				filteredUnsched++
+				continue
+			}
 			// 配额检查
 			if !s.isAccountSchedulableForQuota(account) {
 				continue
@@ -1944,6 +1952,10 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 		if !s.isAccountSchedulableForSelection(acc) {
 			continue
 		}
+		// 权重为 0 的账号仅服务已有粘性会话，不再分配新请求
+		if acc.IsDrainOnly() {
+			continue
+		}
 		if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
 			continue
 		}
@@ -1972,6 +1984,14 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 		return nil, ErrNoAvailableAccounts
 	}
 
+	// cheapest 策略：按请求模型的有效成本排序候选账号；latency 策略：按健康检查测得的平均延迟排序
+	var routingCosts routingCostTable
+	if group.IsCheapestScheduling() {
+		routingCosts = buildRoutingCostTable(s.billingService, candidates, requestedModel)
+	} else if group.IsLatencyScheduling() {
+		routingCosts = buildRoutingLatencyTable(candidates)
+	}
+
 	accountLoads := make([]AccountWithConcurrency, 0, len(candidates))
 	for _, acc := range candidates {
 		accountLoads = append(accountLoads, AccountWithConcurrency{
@@ -2002,14 +2022,25 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			}
 		}
 
-		// 分层过滤选择：优先级 → 负载率 → LRU
+		// 分层过滤选择：[有效成本/延迟] → 优先级 → 权重（加权轮询）或 负载率 → LRU
 		for len(available) > 0 {
-			// 1. 取优先级最小的集合
-			candidates := filterByMinPriority(available)
-			// 2. 取负载率最低的集合
-			candidates = filterByMinLoadRate(candidates)
-			// 3. LRU 选择最久未用的账号
-			selected := selectByLRU(candidates, preferOAuth)
+			// 1. 取优先级最小的集合（cheapest/latency 策略下先取有效成本/平均延迟最低的集合）
+			// 上游剩余配额偏低的账号降权：有充足配额的账号时仅在其中选择
+			pool := filterByUpstreamRateLimitHeadroom(available, cfg.UpstreamRateLimitLowRatio)
+			if routingCosts != nil {
+				pool = filterByMinRoutingCost(pool, routingCosts)
+			}
+			candidates := filterByMinPriority(pool)
+			var selected *accountWithLoad
+			if hasNonUniformWeights(candidates) {
+				// 2a. 同优先级账号权重不同：按权重平滑轮询（满载账号已在上面过滤）
+				selected = defaultAccountWeightedRR.pick(derefGroupID(groupID), candidates)
+			} else {
+				// 2b. 取负载率最低的集合
+				candidates = filterByMinLoadRate(candidates)
+				// 3. LRU 选择最久未用的账号
+				selected = selectByLRU(candidates, preferOAuth)
+			}
 			if selected == nil {
 				break
 			}
@@ -2023,6 +2054,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 					if sessionHash != "" && s.cache != nil {
 						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.account.ID, stickySessionTTL)
 					}
+					recordAccountSelection(groupID, selected.account)
 					return s.newSelectionResult(ctx, selected.account, true, result.ReleaseFunc, nil)
 				}
 			}
@@ -2041,6 +2073,9 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 
 	// ============ Layer 3: 兜底排队 ============
 	s.sortCandidatesForFallback(candidates, preferOAuth, cfg.FallbackSelectionMode)
+	if routingCosts != nil {
+		sortAccountsByRoutingCost(candidates, routingCosts)
+	}
 	for _, acc := range candidates {
 		// 会话数量限制检查（等待计划也需要占用会话配额）
 		if !s.checkAndRegisterSession(ctx, acc, sessionHash) {
@@ -2348,7 +2383,7 @@ func (s *GatewayService) isAccountSchedulableForSelection(account *Account) bool
 	if account == nil {
 		return false
 	}
-	return account.IsSchedulable()
+	return account.IsSchedulable() && !isAccountMarkedUnhealthy(account.ID) && !isAccountCircuitOpen(account.ID) && !isAccountProviderDisabled(account)
 }
 
 func (s *GatewayService) isAccountSchedulableForModelSelection(ctx context.Context, account *Account, requestedModel string) bool {
@@ -3073,6 +3108,10 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			if !s.isAccountSchedulableForSelection(acc) {
 				continue
 			}
+			// 权重为 0 的账号仅服务已有粘性会话，不再分配新请求
+			if acc.IsDrainOnly() {
+				continue
+			}
 			// require_privacy_set: 跳过 privacy 未设置的账号并标记异常
 			if schedGroup != nil && schedGroup.RequirePrivacySet && !acc.IsPrivacySet() {
 				_ = s.accountRepo.SetError(ctx, acc.ID,
@@ -3184,6 +3223,10 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		if !s.isAccountSchedulableForSelection(acc) {
 			continue
 		}
+		// 权重为 0 的账号仅服务已有粘性会话，不再分配新请求
+		if acc.IsDrainOnly() {
+			continue
+		}
 		// require_privacy_set: 跳过 privacy 未设置的账号并标记异常
 		if schedGroup != nil && schedGroup.RequirePrivacySet && !acc.IsPrivacySet() {
 			_ = s.accountRepo.SetError(ctx, acc.ID,
@@ -3329,6 +3372,10 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			if !s.isAccountSchedulableForSelection(acc) {
 				continue
 			}
+			// 权重为 0 的账号仅服务已有粘性会话，不再分配新请求
+			if acc.IsDrainOnly() {
+				continue
+			}
 			// require_privacy_set: 跳过 privacy 未设置的账号并标记异常
 			if schedGroup != nil && schedGroup.RequirePrivacySet && !acc.IsPrivacySet() {
 				_ = s.accountRepo.SetError(ctx, acc.ID,
@@ -3441,6 +3488,10 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		if !s.isAccountSchedulableForSelection(acc) {
 			continue
 		}
+		// 权重为 0 的账号仅服务已有粘性会话，不再分配新请求
+		if acc.IsDrainOnly() {
+			continue
+		}
 		// require_privacy_set: 跳过 privacy 未设置的账号并标记异常
 		if schedGroup != nil && schedGroup.RequirePrivacySet && !acc.IsPrivacySet() {
 			_ = s.accountRepo.SetError(ctx, acc.ID,
@@ -3616,6 +3667,9 @@ func (s *GatewayService) diagnoseSelectionFailure(
 	if !s.isAccountSchedulableForSelection(acc) {
 		return selectionFailureDiagnosis{Category: "unschedulable", Detail: "generic_unschedulable"}
 	}
+	if acc.IsDrainOnly() {
+		return selectionFailureDiagnosis{Category: "unschedulable", Detail: "drain_only"}
+	}
 	if isPlatformFilteredForSelection(acc, platform, allowMixedScheduling) {
 		return selectionFailureDiagnosis{
 			Category: "platform_filtered",
@@ -3710,6 +3764,11 @@ func (s *GatewayService) isModelSupportedByAccountWithContext(ctx context.Contex
 
 // isModelSupportedByAccount 根据账户平台检查模型支持（无 context，用于非 Antigravity 平台）
 func (s *GatewayService) isModelSupportedByAccount(account *Account, requestedModel string) bool {
+	return accountSupportsModel(account, requestedModel)
+}
+
+// accountSupportsModel 按账号平台的映射规则判断账号能否服务 requestedModel（与调度路径一致）
+func accountSupportsModel(account *Account, requestedModel string) bool {
 	if account.Platform == PlatformAntigravity {
 		if strings.TrimSpace(requestedModel) == "" {
 			return true
@@ -4514,6 +4573,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		}
 
 		// 发送请求
+		applyAccountCustomHeaders(upstreamReq, account)
 		resp, err = s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, tlsProfile)
 		if err != nil {
 			if resp != nil && resp.Body != nil {
@@ -4531,6 +4591,9 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 				Kind:               "request_error",
 				Message:            safeErr,
 			})
+			if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+				return nil, failoverErr
+			}
 			c.JSON(http.StatusBadGateway, gin.H{
 				"type": "error",
 				"error": gin.H{
@@ -4592,6 +4655,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 					retryReq, buildErr := s.buildUpstreamRequest(retryCtx, c, account, filteredBody, token, tokenType, reqModel, reqStream, shouldMimicClaudeCode)
 					releaseRetryCtx()
 					if buildErr == nil {
+						applyAccountCustomHeaders(retryReq, account)
 						retryResp, retryErr := s.httpUpstream.DoWithTLS(retryReq, proxyURL, account.ID, account.Concurrency, tlsProfile)
 						if retryErr == nil {
 							if retryResp.StatusCode < 400 {
@@ -4627,6 +4691,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 									retryReq2, buildErr2 := s.buildUpstreamRequest(retryCtx2, c, account, filteredBody2, token, tokenType, reqModel, reqStream, shouldMimicClaudeCode)
 									releaseRetryCtx2()
 									if buildErr2 == nil {
+										applyAccountCustomHeaders(retryReq2, account)
 										retryResp2, retryErr2 := s.httpUpstream.DoWithTLS(retryReq2, proxyURL, account.ID, account.Concurrency, tlsProfile)
 										if retryErr2 == nil {
 											resp = retryResp2
@@ -4698,6 +4763,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 						budgetRetryReq, buildErr := s.buildUpstreamRequest(budgetRetryCtx, c, account, rectifiedBody, token, tokenType, reqModel, reqStream, shouldMimicClaudeCode)
 						releaseBudgetRetryCtx()
 						if buildErr == nil {
+							applyAccountCustomHeaders(budgetRetryReq, account)
 							budgetRetryResp, retryErr := s.httpUpstream.DoWithTLS(budgetRetryReq, proxyURL, account.ID, account.Concurrency, tlsProfile)
 							if retryErr == nil {
 								resp = budgetRetryResp
@@ -5004,6 +5070,7 @@ func (s *GatewayService) forwardAnthropicAPIKeyPassthroughWithInput(
 			return nil, err
 		}
 
+		applyAccountCustomHeaders(upstreamReq, account)
 		resp, err = s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 		if err != nil {
 			if resp != nil && resp.Body != nil {
@@ -5021,6 +5088,9 @@ func (s *GatewayService) forwardAnthropicAPIKeyPassthroughWithInput(
 				Kind:               "request_error",
 				Message:            safeErr,
 			})
+			if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+				return nil, failoverErr
+			}
 			c.JSON(http.StatusBadGateway, gin.H{
 				"type": "error",
 				"error": gin.H{
@@ -5251,6 +5321,7 @@ func (s *GatewayService) handleStreamingResponseAnthropicAPIKeyPassthrough(
 	if s.rateLimitService != nil {
 		s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
 	}
+	recordUpstreamRateLimitHeaders(account, resp.Header)
 
 	writeAnthropicPassthroughResponseHeaders(c.Writer.Header(), resp.Header, s.responseHeaderFilter)
 
@@ -5547,6 +5618,7 @@ func (s *GatewayService) handleNonStreamingResponseAnthropicAPIKeyPassthrough(
 	if s.rateLimitService != nil {
 		s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
 	}
+	recordUpstreamRateLimitHeaders(account, resp.Header)
 
 	body, err := ReadUpstreamResponseBody(resp.Body, s.cfg, c, anthropicTooLargeError)
 	if err != nil {
@@ -5720,6 +5792,7 @@ func (s *GatewayService) executeBedrockUpstream(
 			return nil, err
 		}
 
+		applyAccountCustomHeaders(upstreamReq, account)
 		resp, err = s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, nil)
 		if err != nil {
 			if resp != nil && resp.Body != nil {
@@ -5736,6 +5809,9 @@ func (s *GatewayService) executeBedrockUpstream(
 				Kind:               "request_error",
 				Message:            safeErr,
 			})
+			if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+				return nil, failoverErr
+			}
 			c.JSON(http.StatusBadGateway, gin.H{
 				"type": "error",
 				"error": gin.H{
@@ -7124,6 +7200,7 @@ type streamingResult struct {
 func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, startTime time.Time, originalModel, mappedModel string, mimicClaudeCode bool) (*streamingResult, error) {
 	// 更新5h窗口状态
 	s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
+	recordUpstreamRateLimitHeaders(account, resp.Header)
 
 	if s.responseHeaderFilter != nil {
 		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.responseHeaderFilter)
@@ -7746,6 +7823,7 @@ func (s *GatewayService) resolveCacheTTLUsageOverrideTarget(ctx context.Context,
 func (s *GatewayService) handleNonStreamingResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, originalModel, mappedModel string) (*ClaudeUsage, error) {
 	// 更新5h窗口状态
 	s.rateLimitService.UpdateSessionWindow(ctx, account, resp.Header)
+	recordUpstreamRateLimitHeaders(account, resp.Header)
 
 	body, err := ReadUpstreamResponseBody(resp.Body, s.cfg, c, anthropicTooLargeError)
 	if err != nil {
@@ -7859,6 +7937,7 @@ type RecordUsageInput struct {
 	IPAddress          string             // 请求的客户端 IP 地址
 	RequestPayloadHash string             // 请求体语义哈希，用于降低 request_id 误复用时的静默误去重风险
 	ForceCacheBilling  bool               // 强制缓存计费：将 input_tokens 转为 cache_read 计费（用于粘性会话切换）
+	ResponseCacheHit   bool               // 命中响应缓存：按 hit_billing_rate 折算计费，不计入账号成本
 	APIKeyService      APIKeyQuotaUpdater // 可选：用于更新API Key配额
 
 	ChannelUsageFields // 渠道映射信息（由 handler 在 Forward 前解析）
@@ -7891,12 +7970,17 @@ type postUsageBillingParams struct {
 	APIKeyService         APIKeyQuotaUpdater
 }
 
+// isSandbox 沙盒 Key 的请求照常计算费用，但不扣余额/订阅额度，也不计入 Key 额度与限速用量
+func (p *postUsageBillingParams) isSandbox() bool {
+	return p.APIKey != nil && p.APIKey.IsSandbox
+}
+
 func (p *postUsageBillingParams) shouldDeductAPIKeyQuota() bool {
-	return p.Cost.ActualCost > 0 && p.APIKey.Quota > 0 && p.APIKeyService != nil
+	return !p.isSandbox() && p.Cost.ActualCost > 0 && p.APIKey.Quota > 0 && p.APIKeyService != nil
 }
 
 func (p *postUsageBillingParams) shouldUpdateRateLimits() bool {
-	return p.Cost.ActualCost > 0 && p.APIKey.HasRateLimits() && p.APIKeyService != nil
+	return !p.isSandbox() && p.Cost.ActualCost > 0 && p.APIKey.HasRateLimits() && p.APIKeyService != nil
 }
 
 func (p *postUsageBillingParams) shouldUpdateAccountQuota() bool {
@@ -7912,7 +7996,8 @@ func postUsageBilling(ctx context.Context, p *postUsageBillingParams, deps *bill
 
 	cost := p.Cost
 
-	if p.IsSubscriptionBill {
+	// 沙盒 Key 不扣余额/订阅额度
+	if p.IsSubscriptionBill && !p.isSandbox() {
 		// Subscription usage tracked by ActualCost so group rate multiplier
 		// consumes the quota at the expected speed.
 		if cost.ActualCost > 0 {
@@ -7920,7 +8005,7 @@ func postUsageBilling(ctx context.Context, p *postUsageBillingParams, deps *bill
 				slog.Error("increment subscription usage failed", "subscription_id", p.Subscription.ID, "error", err)
 			}
 		}
-	} else {
+	} else if !p.isSandbox() {
 		if cost.ActualCost > 0 {
 			if err := deps.userRepo.DeductBalance(billingCtx, p.User.ID, cost.ActualCost); err != nil {
 				slog.Error("deduct balance failed", "user_id", p.User.ID, "error", err)
@@ -7941,7 +8026,7 @@ func postUsageBilling(ctx context.Context, p *postUsageBillingParams, deps *bill
 	}
 
 	if p.shouldUpdateAccountQuota() {
-		accountCost := cost.TotalCost * p.AccountRateMultiplier
+		accountCost := cost.UpstreamCost() * p.AccountRateMultiplier
 		if err := deps.accountRepo.IncrementQuotaUsed(billingCtx, p.Account.ID, accountCost); err != nil {
 			slog.Error("increment account quota used failed", "account_id", p.Account.ID, "cost", accountCost, "error", err)
 		}
@@ -8019,7 +8104,10 @@ func buildUsageBillingCommand(requestID string, usageLog *UsageLog, p *postUsage
 	// user-specific) rate multiplier consumes subscription quota at the expected
 	// speed. TotalCost remains the raw (pre-multiplier) value; downstream guards
 	// on "> 0" still correctly skip free subscriptions (RateMultiplier == 0).
-	if p.IsSubscriptionBill && p.Subscription != nil && p.Cost.TotalCost > 0 {
+	if p.isSandbox() {
+		// 沙盒 Key 只记录用量：不扣费，消费计入沙盒汇总
+		cmd.Sandbox = true
+	} else if p.IsSubscriptionBill && p.Subscription != nil && p.Cost.TotalCost > 0 {
 		cmd.SubscriptionID = &p.Subscription.ID
 		cmd.SubscriptionCost = p.Cost.ActualCost
 	} else if p.Cost.ActualCost > 0 {
@@ -8033,8 +8121,12 @@ func buildUsageBillingCommand(requestID string, usageLog *UsageLog, p *postUsage
 		cmd.APIKeyRateLimitCost = p.Cost.ActualCost
 	}
 	if p.shouldUpdateAccountQuota() {
-		cmd.AccountQuotaCost = p.Cost.TotalCost * p.AccountRateMultiplier
+		cmd.AccountQuotaCost = p.Cost.UpstreamCost() * p.AccountRateMultiplier
 	}
+	cmd.SpendTotalCost = p.Cost.TotalCost
+	cmd.SpendActualCost = p.Cost.ActualCost
+	cmd.SpendCacheReadCost = p.Cost.CacheReadCost
+	cmd.SpendCacheSavings = p.Cost.CacheSavings()
 
 	cmd.Normalize()
 	return cmd
@@ -8047,6 +8139,10 @@ func applyUsageBilling(ctx context.Context, requestID string, usageLog *UsageLog
 
 	cmd := buildUsageBillingCommand(requestID, usageLog, p)
 	if cmd == nil || cmd.RequestID == "" || repo == nil {
+		deps.billingCacheService.RecordAPIKeyTokenUsage(ctx, p.APIKey, usageLogThroughputTokens(usageLog))
+		observeUsageMetrics(usageLog, p)
+		observeSlowRequest(usageLog, p, deps)
+		settleBudgetReservation(ctx, p)
 		postUsageBilling(ctx, p, deps)
 		return true, nil
 	}
@@ -8064,6 +8160,12 @@ func applyUsageBilling(ctx context.Context, requestID string, usageLog *UsageLog
 		return false, nil
 	}
 
+	// 仅在首次计费成功时记账 TPM，幂等重放不会重复扣减
+	deps.billingCacheService.RecordAPIKeyTokenUsage(billingCtx, p.APIKey, usageLogThroughputTokens(usageLog))
+	observeUsageMetrics(usageLog, p)
+	observeSlowRequest(usageLog, p, deps)
+	settleBudgetReservation(ctx, p)
+
 	if result.APIKeyQuotaExhausted {
 		if invalidator, ok := p.APIKeyService.(apiKeyAuthCacheInvalidator); ok && p.APIKey != nil && p.APIKey.Key != "" {
 			invalidator.InvalidateAuthCacheByKey(billingCtx, p.APIKey.Key)
@@ -8074,11 +8176,68 @@ func applyUsageBilling(ctx context.Context, requestID string, usageLog *UsageLog
 	return true, nil
 }
 
+// settleBudgetReservation 按实际费用结算流式请求的预算预留
+func settleBudgetReservation(ctx context.Context, p *postUsageBillingParams) {
+	if p.Cost == nil {
+		return
+	}
+	BudgetReservationFromContext(ctx).Settle(p.Cost.ActualCost)
+}
+
+// observeSlowRequest 耗时超过阈值时记录慢请求日志
+func observeSlowRequest(usageLog *UsageLog, p *postUsageBillingParams, deps *billingDeps) {
+	provider := ""
+	if p.Account != nil {
+		provider = p.Account.Platform
+	}
+	deps.slowRequestLogger.Observe(usageLog, provider)
+}
+
+// observeUsageMetrics 将计费用量写入 Prometheus 指标（仅内存计数，不阻塞计费）
+func observeUsageMetrics(usageLog *UsageLog, p *postUsageBillingParams) {
+	if usageLog == nil {
+		return
+	}
+	u := metrics.Usage{
+		Model:               usageLog.Model,
+		InputTokens:         usageLog.InputTokens,
+		OutputTokens:        usageLog.OutputTokens,
+		CacheCreationTokens: usageLog.CacheCreationTokens,
+		CacheReadTokens:     usageLog.CacheReadTokens,
+		DurationMs:          usageLog.DurationMs,
+		FirstTokenMs:        usageLog.FirstTokenMs,
+	}
+	if p != nil {
+		if p.Account != nil {
+			u.Provider = p.Account.Platform
+		}
+		if p.Cost != nil {
+			u.ActualCost = p.Cost.ActualCost
+		}
+	}
+	metrics.ObserveUsage(u)
+}
+
+// usageLogThroughputTokens 计入 TPM 的 token 数：输入 + 输出 + 缓存写入。
+// 缓存读取不计入，避免命中 prompt caching 的请求反而更快触发限流。
+func usageLogThroughputTokens(usageLog *UsageLog) int {
+	if usageLog == nil {
+		return 0
+	}
+	return usageLog.InputTokens + usageLog.OutputTokens + usageLog.CacheCreationTokens
+}
+
 func finalizePostUsageBilling(p *postUsageBillingParams, deps *billingDeps, result *UsageBillingApplyResult) {
 	if p == nil || p.Cost == nil || deps == nil {
 		return
 	}
 
+	if p.isSandbox() {
+		deps.deferredService.ScheduleLastUsedUpdate(p.Account.ID)
+		go notifyAccountQuota(p, deps, result)
+		return
+	}
+
 	if p.IsSubscriptionBill {
 		if p.Cost.ActualCost > 0 && p.User != nil && p.APIKey != nil && p.APIKey.GroupID != nil {
 			deps.billingCacheService.QueueUpdateSubscriptionUsage(p.User.ID, *p.APIKey.GroupID, p.Cost.ActualCost)
@@ -8158,7 +8317,7 @@ func notifyAccountQuota(p *postUsageBillingParams, deps *billingDeps, result *Us
 		)
 		return
 	}
-	accountCost := p.Cost.TotalCost * p.AccountRateMultiplier
+	accountCost := p.Cost.UpstreamCost() * p.AccountRateMultiplier
 	var quotaState *AccountQuotaState
 	if result != nil {
 		quotaState = result.QuotaState
@@ -8186,14 +8345,14 @@ func detachStreamUpstreamContext(ctx context.Context, stream bool) (context.Cont
 	if !stream {
 		return ctx, func() {}
 	}
-	return context.WithoutCancel(ctx), func() {}
+	return keepModelTimeout(ctx, context.WithoutCancel(ctx)), func() {}
 }
 
 func detachUpstreamContext(ctx context.Context) (context.Context, context.CancelFunc) {
 	if ctx == nil {
 		return context.Background(), func() {}
 	}
-	return context.WithoutCancel(ctx), func() {}
+	return keepModelTimeout(ctx, context.WithoutCancel(ctx)), func() {}
 }
 
 // billingDeps 扣费逻辑依赖的服务（由各 gateway service 提供）
@@ -8204,6 +8363,7 @@ type billingDeps struct {
 	billingCacheService  *BillingCacheService
 	deferredService      *DeferredService
 	balanceNotifyService *BalanceNotifyService
+	slowRequestLogger    *SlowRequestLogger
 }
 
 func (s *GatewayService) billingDeps() *billingDeps {
@@ -8214,13 +8374,24 @@ func (s *GatewayService) billingDeps() *billingDeps {
 		billingCacheService:  s.billingCacheService,
 		deferredService:      s.deferredService,
 		balanceNotifyService: s.balanceNotifyService,
+		slowRequestLogger:    s.slowRequestLogger,
 	}
 }
 
 func writeUsageLogBestEffort(ctx context.Context, repo UsageLogRepository, usageLog *UsageLog, logKey string) {
+	// 响应因超出 API Key 大小上限被中止时记录原因
+	ResponseSizeGuardFromContext(ctx).SetUsage(usageLog)
+	// 补齐当前请求审计记录的 token/费用（未开启审计时为 nil）
+	AuditEntryFromContext(ctx).SetUsage(usageLog)
+	UsageHeaderReportFromContext(ctx).SetUsage(usageLog)
 	if repo == nil || usageLog == nil {
 		return
 	}
+	if usageLog.TraceID == nil && ctx != nil {
+		if traceID, _ := ctx.Value(ctxkey.RequestID).(string); traceID != "" {
+			usageLog.TraceID = &traceID
+		}
+	}
 	usageCtx, cancel := detachedBillingContext(ctx)
 	defer cancel()
 
@@ -8270,6 +8441,7 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		IPAddress:          input.IPAddress,
 		RequestPayloadHash: input.RequestPayloadHash,
 		ForceCacheBilling:  input.ForceCacheBilling,
+		ResponseCacheHit:   input.ResponseCacheHit,
 		APIKeyService:      input.APIKeyService,
 		ChannelUsageFields: input.ChannelUsageFields,
 	}, &recordUsageOpts{
@@ -8332,6 +8504,7 @@ type recordUsageCoreInput struct {
 	IPAddress          string
 	RequestPayloadHash string
 	ForceCacheBilling  bool
+	ResponseCacheHit   bool
 	APIKeyService      APIKeyQuotaUpdater
 	ChannelUsageFields
 }
@@ -8392,6 +8565,13 @@ func (s *GatewayService) recordUsageCore(ctx context.Context, input *recordUsage
 
 	// 计算费用
 	cost := s.calculateRecordUsageCost(ctx, result, apiKey, billingModel, multiplier, imageMultiplier, opts)
+	if input.ResponseCacheHit {
+		hitRate := 0.0
+		if s.cfg != nil {
+			hitRate = s.cfg.Gateway.ResponseCache.HitBillingRate
+		}
+		applyResponseCacheHitCost(cost, hitRate)
+	}
 
 	// 判断计费方式：订阅模式 vs 余额模式
 	isSubscriptionBilling := subscription != nil && apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
@@ -8405,8 +8585,8 @@ func (s *GatewayService) recordUsageCore(ctx context.Context, input *recordUsage
 	usageLog := s.buildRecordUsageLog(ctx, input, result, apiKey, user, account, subscription,
 		requestedModel, multiplier, imageMultiplier, accountRateMultiplier, billingType, cacheTTLOverridden, cost, opts)
 
-	// 计算账号统计定价费用（使用最终上游模型匹配自定义规则）
-	if apiKey.GroupID != nil {
+	// 计算账号统计定价费用（使用最终上游模型匹配自定义规则）；命中响应缓存时未消耗账号
+	if apiKey.GroupID != nil && !input.ResponseCacheHit {
 		applyAccountStatsCost(ctx, usageLog, s.channelService, s.billingService,
 			account.ID, *apiKey.GroupID, result.UpstreamModel, result.Model,
 			// Anthropic's input_tokens excludes cache_read and cache_creation (billed separately);
@@ -8418,7 +8598,7 @@ func (s *GatewayService) recordUsageCore(ctx context.Context, input *recordUsage
 				CacheReadTokens:     result.Usage.CacheReadInputTokens,
 				ImageOutputTokens:   result.Usage.ImageOutputTokens,
 			},
-			cost.TotalCost,
+			cost.UpstreamCost(),
 		)
 	}
 
@@ -8483,7 +8663,7 @@ func (s *GatewayService) resolveChannelPricing(ctx context.Context, billingModel
 	return nil
 }
 
-// calculateImageCost 计算图片生成费用：渠道级别定价优先，否则走按次计费。
+// calculateImageCost 计算图片生成费用：渠道级别定价优先，否则按张计费并计入提示词 token 费用。
 func (s *GatewayService) calculateImageCost(
 	ctx context.Context,
 	result *ForwardResult,
@@ -8524,7 +8704,14 @@ func (s *GatewayService) calculateImageCost(
 			Price4K: apiKey.Group.ImagePrice4K,
 		}
 	}
-	return s.billingService.CalculateImageCost(billingModel, result.ImageSize, result.ImageCount, groupConfig, multiplier)
+	tokens := UsageTokens{
+		InputTokens:           result.Usage.InputTokens,
+		CacheCreationTokens:   result.Usage.CacheCreationInputTokens,
+		CacheReadTokens:       result.Usage.CacheReadInputTokens,
+		CacheCreation5mTokens: result.Usage.CacheCreation5mTokens,
+		CacheCreation1hTokens: result.Usage.CacheCreation1hTokens,
+	}
+	return s.billingService.CalculateImageCostWithTokens(billingModel, result.ImageSize, result.ImageCount, tokens, groupConfig, multiplier)
 }
 
 // calculateTokenCost 计算 Token 计费：根据 opts 决定走普通/长上下文/渠道统一计费。
@@ -8575,6 +8762,7 @@ func (s *GatewayService) calculateTokenCost(
 		logger.LegacyPrintf("service.gateway", "Calculate cost failed: %v", err)
 		return &CostBreakdown{ActualCost: 0}
 	}
+	s.billingService.applyStructuredOutputMultiplier(ctx, cost)
 	return cost
 }
 
@@ -8626,6 +8814,7 @@ func (s *GatewayService) buildRecordUsageLog(
 		ImageCount:            result.ImageCount,
 		ImageSize:             optionalTrimmedStringPtr(result.ImageSize),
 		CacheTTLOverridden:    cacheTTLOverridden,
+		IsSandbox:             apiKey.IsSandbox,
 		ChannelID:             optionalInt64Ptr(input.ChannelID),
 		ModelMappingChain:     optionalTrimmedStringPtr(input.ModelMappingChain),
 		UserAgent:             optionalTrimmedStringPtr(input.UserAgent),
@@ -8884,6 +9073,7 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 	}
 
 	// 发送请求
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 	if err != nil {
 		setOpsUpstreamError(c, 0, sanitizeUpstreamErrorMessage(err.Error()), "")
@@ -8911,6 +9101,7 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 		filteredBody := FilterThinkingBlocksForRetry(body)
 		retryReq, buildErr := s.buildCountTokensRequest(ctx, c, account, filteredBody, token, tokenType, reqModel, shouldMimicClaudeCode)
 		if buildErr == nil {
+			applyAccountCustomHeaders(retryReq, account)
 			retryResp, retryErr := s.httpUpstream.DoWithTLS(retryReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 			if retryErr == nil {
 				resp = retryResp
@@ -8997,6 +9188,7 @@ func (s *GatewayService) forwardCountTokensAnthropicAPIKeyPassthrough(ctx contex
 		proxyURL = account.Proxy.URL()
 	}
 
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.DoWithTLS(upstreamReq, proxyURL, account.ID, account.Concurrency, s.tlsFPProfileService.ResolveTLSProfile(account))
 	if err != nil {
 		setOpsUpstreamError(c, 0, sanitizeUpstreamErrorMessage(err.Error()), "")
@@ -9339,6 +9531,14 @@ func (s *GatewayService) validateUpstreamBaseURL(raw string) (string, error) {
 	return normalized, nil
 }
 
+// ListCatalogModels 返回价格目录中该 API Key 可用的模型，见 BillingService.ListCatalogModels
+func (s *GatewayService) ListCatalogModels(apiKey *APIKey, provider string) []CatalogModel {
+	if s.billingService == nil {
+		return nil
+	}
+	return s.billingService.ListCatalogModels(apiKey, provider)
+}
+
 // GetAvailableModels returns the list of models available for a group
 // It aggregates model_mapping keys from all schedulable accounts in the group
 func (s *GatewayService) GetAvailableModels(ctx context.Context, groupID *int64, platform string) []string {
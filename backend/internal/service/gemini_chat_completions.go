@@ -0,0 +1,182 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/apicompat"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// ForwardAsChatCompletions 将 OpenAI Chat Completions 请求转换为 Gemini generateContent 转发到 Gemini 账号，
+// 并将响应（含流式）转换回 Chat Completions 格式。上游请求、重试、failover 与 usageMetadata 计费复用 ForwardNative。
+func (s *GeminiMessagesCompatService) ForwardAsChatCompletions(ctx context.Context, c *gin.Context, account *Account, body []byte) (*ForwardResult, error) {
+	var ccReq apicompat.ChatCompletionsRequest
+	if err := json.Unmarshal(body, &ccReq); err != nil {
+		return nil, geminiChatCompletionsError(c, http.StatusBadRequest, "Failed to parse request body")
+	}
+	geminiReq, err := apicompat.ChatCompletionsToGeminiRequest(&ccReq)
+	if err != nil {
+		return nil, geminiChatCompletionsError(c, http.StatusBadRequest, err.Error())
+	}
+	geminiBody, err := json.Marshal(geminiReq)
+	if err != nil {
+		return nil, geminiChatCompletionsError(c, http.StatusInternalServerError, "Failed to build upstream request")
+	}
+
+	action := "generateContent"
+	if ccReq.Stream {
+		action = "streamGenerateContent"
+	}
+	includeUsage := ccReq.StreamOptions != nil && ccReq.StreamOptions.IncludeUsage
+
+	writer := &geminiChatCompletionsWriter{
+		ResponseWriter: c.Writer,
+		model:          ccReq.Model,
+		stream:         ccReq.Stream,
+		state:          apicompat.NewGeminiStreamToChatState(ccReq.Model, includeUsage),
+		status:         http.StatusOK,
+	}
+	c.Writer = writer
+	result, err := s.ForwardNative(ctx, c, account, ccReq.Model, action, ccReq.Stream, geminiBody)
+	c.Writer = writer.ResponseWriter
+	writer.finish(c)
+	return result, err
+}
+
+// geminiChatCompletionsWriter 将 ForwardNative 写出的 Gemini 原生响应转换为 Chat Completions 格式：
+// 流式响应逐行转换 SSE 事件，非流式与错误响应缓冲后在 finish 时整体转换。
+type geminiChatCompletionsWriter struct {
+	gin.ResponseWriter
+	model  string
+	stream bool
+	state  *apicompat.GeminiStreamToChatState
+
+	status  int
+	written bool
+	buf     bytes.Buffer
+}
+
+func (w *geminiChatCompletionsWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *geminiChatCompletionsWriter) Write(b []byte) (int, error) {
+	w.written = true
+	w.buf.Write(b)
+	if w.stream && w.status < http.StatusBadRequest {
+		w.drainStreamLines()
+	}
+	return len(b), nil
+}
+
+func (w *geminiChatCompletionsWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// drainStreamLines 转换缓冲区中所有完整的 SSE 行，不完整的行留待后续写入
+func (w *geminiChatCompletionsWriter) drainStreamLines() {
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// 不完整的行放回缓冲区
+			rest := []byte(line)
+			w.buf.Reset()
+			w.buf.Write(rest)
+			return
+		}
+		w.convertStreamLine(line)
+	}
+}
+
+func (w *geminiChatCompletionsWriter) convertStreamLine(line string) {
+	payload, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "data:")
+	if !ok {
+		return
+	}
+	payload = strings.TrimSpace(payload)
+	if payload == "" || payload == "[DONE]" {
+		return
+	}
+	var chunk apicompat.GeminiResponse
+	if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+		return
+	}
+	for _, cc := range apicompat.GeminiChunkToChatChunks(&chunk, w.state) {
+		w.writeChunk(cc)
+	}
+}
+
+func (w *geminiChatCompletionsWriter) writeChunk(chunk apicompat.ChatCompletionsChunk) {
+	sse, err := apicompat.ChatChunkToSSE(chunk)
+	if err != nil {
+		return
+	}
+	_, _ = w.ResponseWriter.WriteString(sse)
+}
+
+// finish 输出缓冲的非流式/错误响应，或为流式响应补齐结束 chunk 与 [DONE]。未写出任何内容（如 failover）时不输出。
+func (w *geminiChatCompletionsWriter) finish(c *gin.Context) {
+	if !w.written {
+		return
+	}
+	switch {
+	case w.status >= http.StatusBadRequest:
+		_ = geminiChatCompletionsError(c, w.status, geminiErrorMessage(w.buf.Bytes(), w.status))
+	case w.stream:
+		if w.buf.Len() > 0 {
+			w.convertStreamLine(w.buf.String())
+			w.buf.Reset()
+		}
+		for _, cc := range apicompat.FinalizeGeminiChatStream(w.state) {
+			w.writeChunk(cc)
+		}
+		_, _ = w.ResponseWriter.WriteString("data: [DONE]\n\n")
+		w.ResponseWriter.Flush()
+	default:
+		var resp apicompat.GeminiResponse
+		if err := json.Unmarshal(w.buf.Bytes(), &resp); err != nil {
+			_ = geminiChatCompletionsError(c, http.StatusBadGateway, "Failed to parse upstream response")
+			return
+		}
+		out, err := json.Marshal(apicompat.GeminiToChatCompletions(&resp, w.model))
+		if err != nil {
+			return
+		}
+		w.ResponseWriter.Header().Set("Content-Type", "application/json")
+		_, _ = w.ResponseWriter.Write(out)
+	}
+}
+
+// geminiErrorMessage 提取 Google 格式错误响应中的 message
+func geminiErrorMessage(body []byte, status int) string {
+	if msg := strings.TrimSpace(gjson.GetBytes(body, "error.message").String()); msg != "" {
+		return sanitizeUpstreamErrorMessage(msg)
+	}
+	return http.StatusText(status)
+}
+
+// geminiChatCompletionsError 按 HTTP 状态码选择 OpenAI 错误类型并以 Chat Completions 格式输出
+func geminiChatCompletionsError(c *gin.Context, status int, message string) error {
+	errType := "api_error"
+	switch status {
+	case http.StatusBadRequest:
+		errType = "invalid_request_error"
+	case http.StatusUnauthorized:
+		errType = "authentication_error"
+	case http.StatusForbidden:
+		errType = "permission_error"
+	case http.StatusNotFound:
+		errType = "not_found_error"
+	case http.StatusTooManyRequests:
+		errType = "rate_limit_error"
+	}
+	writeChatCompletionsError(c, status, errType, message)
+	return fmt.Errorf("%s", message)
+}
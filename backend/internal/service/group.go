@@ -67,6 +67,9 @@ type Group struct {
 	// 一旦设置即接管该分组用户的限流（覆盖用户级 rpm_limit），可被 user-group rpm_override 进一步覆盖。
 	RPMLimit int
 
+	// SchedulingStrategy 账号调度策略：load_balance（默认）/ cheapest（优先有效成本最低的账号）/ latency（优先平均延迟最低的账号）
+	SchedulingStrategy string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -76,6 +79,16 @@ type Group struct {
 	RateLimitedAccountCount int64
 }
 
+// IsCheapestScheduling 分组是否启用成本优先调度
+func (g *Group) IsCheapestScheduling() bool {
+	return g != nil && g.SchedulingStrategy == GroupSchedulingStrategyCheapest
+}
+
+// IsLatencyScheduling 分组是否启用延迟优先调度
+func (g *Group) IsLatencyScheduling() bool {
+	return g != nil && g.SchedulingStrategy == GroupSchedulingStrategyLatency
+}
+
 func (g *Group) IsActive() bool {
 	return g.Status == StatusActive
 }
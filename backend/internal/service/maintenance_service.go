@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	// DefaultMaintenanceMessage 未指定提示信息时返回给客户端的默认文案
+	DefaultMaintenanceMessage = "Service is under maintenance, please retry later."
+	// DefaultMaintenanceRetryAfterSeconds 未指定时 Retry-After 的默认秒数
+	DefaultMaintenanceRetryAfterSeconds = 300
+
+	maintenanceMaxRetryAfterSeconds = 86400
+	maintenanceMaxMessageLength     = 1024
+	maintenanceLoadTimeout          = 5 * time.Second
+)
+
+var ErrMaintenanceInvalidState = infraerrors.BadRequest("MAINTENANCE_INVALID_STATE", "invalid maintenance state")
+
+// MaintenanceState 网关维护模式状态
+type MaintenanceState struct {
+	Enabled           bool   `json:"enabled"`
+	Message           string `json:"message"`
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+	// BypassAPIKeyIDs 维护期间仍放行的 API Key（用于冒烟测试）
+	BypassAPIKeyIDs []int64 `json:"bypass_api_key_ids"`
+	// Persist 是否写入系统设置，进程重启后恢复该状态
+	Persist   bool       `json:"persist"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// MaintenanceService 网关维护模式：开启后代理请求统一返回 503，管理接口不受影响。
+// 状态保存在进程内存中，供网关中间件无锁读取；选择持久化时同时写入系统设置并在启动时恢复。
+type MaintenanceService struct {
+	settingRepo SettingRepository
+	mu          sync.Mutex
+	state       atomic.Pointer[MaintenanceState]
+	bypass      atomic.Pointer[map[int64]struct{}]
+}
+
+// NewMaintenanceService 创建维护模式服务，并恢复已持久化的状态
+func NewMaintenanceService(settingRepo SettingRepository) *MaintenanceService {
+	s := &MaintenanceService{settingRepo: settingRepo}
+	s.publish(&MaintenanceState{})
+	s.loadPersisted()
+	return s
+}
+
+func (s *MaintenanceService) loadPersisted() {
+	if s.settingRepo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceLoadTimeout)
+	defer cancel()
+	raw, err := s.settingRepo.GetValue(ctx, SettingKeyMaintenanceMode)
+	if err != nil || strings.TrimSpace(raw) == "" {
+		return
+	}
+	var state MaintenanceState
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		slog.Warn("maintenance: failed to parse persisted state", "error", err)
+		return
+	}
+	state.Persist = true
+	normalizeMaintenanceState(&state)
+	s.publish(&state)
+}
+
+// State 返回当前状态的副本
+func (s *MaintenanceService) State() MaintenanceState {
+	state := *s.state.Load()
+	state.BypassAPIKeyIDs = append([]int64(nil), state.BypassAPIKeyIDs...)
+	return state
+}
+
+// ShouldReject 维护模式开启且 API Key 不在放行列表中时返回 true，同时返回提示信息与 Retry-After 秒数
+func (s *MaintenanceService) ShouldReject(apiKeyID int64) (reject bool, message string, retryAfterSeconds int) {
+	if s == nil {
+		return false, "", 0
+	}
+	state := s.state.Load()
+	if state == nil || !state.Enabled {
+		return false, "", 0
+	}
+	if bypass := s.bypass.Load(); bypass != nil {
+		if _, ok := (*bypass)[apiKeyID]; ok {
+			return false, "", 0
+		}
+	}
+	return true, state.Message, state.RetryAfterSeconds
+}
+
+// Update 切换维护模式。Persist 为 true 时写入系统设置；为 false 时清除已持久化的状态，重启后恢复正常服务。
+func (s *MaintenanceService) Update(ctx context.Context, state MaintenanceState) (*MaintenanceState, error) {
+	if state.RetryAfterSeconds < 0 || state.RetryAfterSeconds > maintenanceMaxRetryAfterSeconds {
+		return nil, ErrMaintenanceInvalidState.WithMetadata(map[string]string{
+			"reason": fmt.Sprintf("retry_after_seconds must be between 0 and %d", maintenanceMaxRetryAfterSeconds),
+		})
+	}
+	if len(state.Message) > maintenanceMaxMessageLength {
+		return nil, ErrMaintenanceInvalidState.WithMetadata(map[string]string{
+			"reason": fmt.Sprintf("message must be at most %d characters", maintenanceMaxMessageLength),
+		})
+	}
+	normalizeMaintenanceState(&state)
+	now := time.Now()
+	state.UpdatedAt = &now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.settingRepo != nil {
+		if state.Persist {
+			data, err := json.Marshal(state)
+			if err != nil {
+				return nil, fmt.Errorf("marshal maintenance state: %w", err)
+			}
+			if err := s.settingRepo.Set(ctx, SettingKeyMaintenanceMode, string(data)); err != nil {
+				return nil, fmt.Errorf("persist maintenance state: %w", err)
+			}
+		} else if err := s.settingRepo.Delete(ctx, SettingKeyMaintenanceMode); err != nil {
+			return nil, fmt.Errorf("clear persisted maintenance state: %w", err)
+		}
+	}
+	s.publish(&state)
+	out := s.State()
+	return &out, nil
+}
+
+func (s *MaintenanceService) publish(state *MaintenanceState) {
+	bypass := make(map[int64]struct{}, len(state.BypassAPIKeyIDs))
+	for _, id := range state.BypassAPIKeyIDs {
+		bypass[id] = struct{}{}
+	}
+	s.bypass.Store(&bypass)
+	s.state.Store(state)
+}
+
+func normalizeMaintenanceState(state *MaintenanceState) {
+	state.Message = strings.TrimSpace(state.Message)
+	if state.Message == "" {
+		state.Message = DefaultMaintenanceMessage
+	}
+	if state.RetryAfterSeconds == 0 {
+		state.RetryAfterSeconds = DefaultMaintenanceRetryAfterSeconds
+	}
+	ids := make([]int64, 0, len(state.BypassAPIKeyIDs))
+	seen := make(map[int64]struct{}, len(state.BypassAPIKeyIDs))
+	for _, id := range state.BypassAPIKeyIDs {
+		if _, ok := seen[id]; ok || id <= 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	state.BypassAPIKeyIDs = ids
+}
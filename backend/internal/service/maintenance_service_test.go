@@ -0,0 +1,51 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceService_RejectsUnlessBypassed(t *testing.T) {
+	svc := NewMaintenanceService(newMockSettingRepo())
+	reject, _, _ := svc.ShouldReject(1)
+	require.False(t, reject)
+
+	state, err := svc.Update(context.Background(), MaintenanceState{Enabled: true, BypassAPIKeyIDs: []int64{7, 7, 0}})
+	require.NoError(t, err)
+	require.Equal(t, DefaultMaintenanceMessage, state.Message)
+	require.Equal(t, DefaultMaintenanceRetryAfterSeconds, state.RetryAfterSeconds)
+	require.Equal(t, []int64{7}, state.BypassAPIKeyIDs)
+
+	reject, message, retryAfter := svc.ShouldReject(1)
+	require.True(t, reject)
+	require.Equal(t, DefaultMaintenanceMessage, message)
+	require.Equal(t, DefaultMaintenanceRetryAfterSeconds, retryAfter)
+
+	reject, _, _ = svc.ShouldReject(7)
+	require.False(t, reject)
+
+	_, err = svc.Update(context.Background(), MaintenanceState{Enabled: true, RetryAfterSeconds: -1})
+	require.ErrorIs(t, err, ErrMaintenanceInvalidState)
+}
+
+func TestMaintenanceService_PersistsAcrossRestart(t *testing.T) {
+	repo := newMockSettingRepo()
+	svc := NewMaintenanceService(repo)
+	_, err := svc.Update(context.Background(), MaintenanceState{Enabled: true, Message: "upgrading", RetryAfterSeconds: 60, Persist: true})
+	require.NoError(t, err)
+
+	restored := NewMaintenanceService(repo).State()
+	require.True(t, restored.Enabled)
+	require.True(t, restored.Persist)
+	require.Equal(t, "upgrading", restored.Message)
+	require.Equal(t, 60, restored.RetryAfterSeconds)
+
+	// 不持久化的切换会清除已保存的状态，重启后恢复正常服务
+	_, err = svc.Update(context.Background(), MaintenanceState{Enabled: true})
+	require.NoError(t, err)
+	require.False(t, NewMaintenanceService(repo).State().Enabled)
+}
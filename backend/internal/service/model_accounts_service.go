@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 账号不可调度原因
+const (
+	ModelAccountUnavailableInactive          = "inactive"
+	ModelAccountUnavailableUnschedulable     = "unschedulable"
+	ModelAccountUnavailableProviderDisabled  = "provider_disabled"
+	ModelAccountUnavailableUnhealthy         = "unhealthy"
+	ModelAccountUnavailableCircuitOpen       = "circuit_open"
+	ModelAccountUnavailableRateLimited       = "rate_limited"
+	ModelAccountUnavailableOverloaded        = "overloaded"
+	ModelAccountUnavailableTempUnschedulable = "temp_unschedulable"
+)
+
+// ModelAccountEntry 可服务某模型的账号及其调度状态
+type ModelAccountEntry struct {
+	AccountID   int64  `json:"account_id"`
+	Name        string `json:"name"`
+	Platform    string `json:"platform"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Schedulable bool   `json:"schedulable"`
+	Priority    int    `json:"priority"`
+	Weight      int    `json:"weight"`
+	// MappedModel 按账号 model_mapping 转发到上游的模型名
+	MappedModel string `json:"mapped_model"`
+
+	Healthy bool `json:"healthy"`
+	// AvgLatencyMs 健康检查测得的平均延迟（毫秒），latency 调度策略据此排序；尚无数据时省略
+	AvgLatencyMs     *float64 `json:"avg_latency_ms,omitempty"`
+	CircuitState     string   `json:"circuit_state"`
+	ProviderDisabled bool     `json:"provider_disabled"`
+	// Available 当前能否被调度；不可调度时 UnavailableReason 给出首个原因
+	Available         bool   `json:"available"`
+	UnavailableReason string `json:"unavailable_reason,omitempty"`
+
+	MaxConcurrency     int `json:"max_concurrency"`
+	CurrentConcurrency int `json:"current_concurrency"`
+	WaitingCount       int `json:"waiting_count"`
+}
+
+// ModelAccountsReport 服务某模型的全部账号
+type ModelAccountsReport struct {
+	Model string `json:"model"`
+	// Provider 模型在价格数据中所属厂商；ProviderDisabled 为 true 时该模型的请求会被直接拒绝
+	Provider         string              `json:"provider,omitempty"`
+	ProviderDisabled bool                `json:"provider_disabled"`
+	ModelDisabled    bool                `json:"model_disabled"`
+	Accounts         []ModelAccountEntry `json:"accounts"`
+	Total            int                 `json:"total"`
+	AvailableCount   int                 `json:"available_count"`
+}
+
+// ModelAccountsService 按模型反查可服务的账号，用于排查某个模型整体不可用的原因
+type ModelAccountsService struct {
+	accountRepo        AccountRepository
+	billingService     *BillingService
+	concurrencyService *ConcurrencyService
+}
+
+// NewModelAccountsService 创建模型账号反查服务
+func NewModelAccountsService(accountRepo AccountRepository, billingService *BillingService, concurrencyService *ConcurrencyService) *ModelAccountsService {
+	return &ModelAccountsService{
+		accountRepo:        accountRepo,
+		billingService:     billingService,
+		concurrencyService: concurrencyService,
+	}
+}
+
+// ListAccountsForModel 列出 model_mapping 覆盖该模型的全部账号（含未启用/不可调度账号），
+// 附带健康检查、熔断、厂商禁用与实时并发状态。按可用优先、优先级、ID 排序。
+func (s *ModelAccountsService) ListAccountsForModel(ctx context.Context, model string) (*ModelAccountsReport, error) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	accounts, _, err := s.accountRepo.ListAllWithFilters(ctx, "", "", "", "", 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("list accounts: %w", err)
+	}
+
+	report := &ModelAccountsReport{Model: model, Accounts: make([]ModelAccountEntry, 0)}
+	if s.billingService != nil {
+		report.Provider = s.billingService.modelProvider(strings.ToLower(model))
+		report.ProviderDisabled = report.Provider != "" && s.billingService.IsProviderDisabled(report.Provider)
+		report.ModelDisabled = s.billingService.IsModelDisabled(model)
+	}
+
+	platforms := modelCandidatePlatforms(model)
+	for i := range accounts {
+		account := &accounts[i]
+		if len(platforms) > 0 && !slices.Contains(platforms, account.Platform) {
+			continue
+		}
+		if !accountSupportsModel(account, model) {
+			continue
+		}
+		report.Accounts = append(report.Accounts, buildModelAccountEntry(account, model, report.ProviderDisabled))
+	}
+	s.fillConcurrency(ctx, report.Accounts)
+
+	sort.SliceStable(report.Accounts, func(i, j int) bool {
+		a, b := report.Accounts[i], report.Accounts[j]
+		if a.Available != b.Available {
+			return a.Available
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.AccountID < b.AccountID
+	})
+	report.Total = len(report.Accounts)
+	for _, entry := range report.Accounts {
+		if entry.Available {
+			report.AvailableCount++
+		}
+	}
+	return report, nil
+}
+
+func buildModelAccountEntry(account *Account, model string, modelProviderDisabled bool) ModelAccountEntry {
+	entry := ModelAccountEntry{
+		AccountID:        account.ID,
+		Name:             account.Name,
+		Platform:         account.Platform,
+		Type:             account.Type,
+		Status:           account.Status,
+		Schedulable:      account.Schedulable,
+		Priority:         account.Priority,
+		Weight:           account.EffectiveWeight(),
+		MappedModel:      account.GetMappedModel(model),
+		Healthy:          !isAccountMarkedUnhealthy(account.ID),
+		CircuitState:     defaultAccountCircuitBreakerRegistry.state(account.ID),
+		ProviderDisabled: modelProviderDisabled || isAccountProviderDisabled(account),
+		MaxConcurrency:   account.Concurrency,
+	}
+	if latency, ok := AccountAverageLatencyMs(account.ID); ok {
+		entry.AvgLatencyMs = &latency
+	}
+	entry.UnavailableReason = modelAccountUnavailableReason(account, &entry)
+	entry.Available = entry.UnavailableReason == ""
+	return entry
+}
+
+// modelAccountUnavailableReason 按调度路径的判断顺序返回首个不可调度原因，可调度时返回空
+func modelAccountUnavailableReason(account *Account, entry *ModelAccountEntry) string {
+	switch {
+	case !account.IsActive():
+		return ModelAccountUnavailableInactive
+	case !account.Schedulable:
+		return ModelAccountUnavailableUnschedulable
+	case entry.ProviderDisabled:
+		return ModelAccountUnavailableProviderDisabled
+	case !entry.Healthy:
+		return ModelAccountUnavailableUnhealthy
+	case entry.CircuitState == CircuitStateOpen:
+		return ModelAccountUnavailableCircuitOpen
+	case account.IsRateLimited():
+		return ModelAccountUnavailableRateLimited
+	case account.IsOverloaded():
+		return ModelAccountUnavailableOverloaded
+	case account.TempUnschedulableUntil != nil && time.Now().Before(*account.TempUnschedulableUntil):
+		return ModelAccountUnavailableTempUnschedulable
+	case !account.IsSchedulable():
+		return ModelAccountUnavailableUnschedulable
+	}
+	return ""
+}
+
+// fillConcurrency 按并发槽位填充实时并发与排队数（查询失败时保持为 0）
+func (s *ModelAccountsService) fillConcurrency(ctx context.Context, entries []ModelAccountEntry) {
+	if s.concurrencyService == nil || len(entries) == 0 {
+		return
+	}
+	accounts := make([]AccountWithConcurrency, 0, len(entries))
+	for _, entry := range entries {
+		accounts = append(accounts, AccountWithConcurrency{ID: entry.AccountID, MaxConcurrency: entry.MaxConcurrency})
+	}
+	loads, err := s.concurrencyService.GetAccountsLoadBatch(ctx, accounts)
+	if err != nil {
+		return
+	}
+	for i := range entries {
+		if load := loads[entries[i].AccountID]; load != nil {
+			entries[i].CurrentConcurrency = load.CurrentConcurrency
+			entries[i].WaitingCount = load.WaitingCount
+		}
+	}
+}
+
+// modelCandidatePlatforms 按模型名推断可能服务它的账号平台；无法推断时返回 nil（不按平台过滤）。
+// 未配置 model_mapping 的账号允许任意模型，需按平台排除明显无关的账号。
+func modelCandidatePlatforms(model string) []string {
+	m := strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	switch {
+	case strings.HasPrefix(m, "claude"):
+		return []string{PlatformAnthropic, PlatformAntigravity}
+	case strings.HasPrefix(m, "gemini"):
+		return []string{PlatformGemini, PlatformAntigravity}
+	case strings.HasPrefix(m, "gpt"), strings.HasPrefix(m, "chatgpt"), strings.HasPrefix(m, "codex"),
+		strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return []string{PlatformOpenAI}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// modelAccountsRepoStub 仅实现按模型反查需要的 ListAllWithFilters
+type modelAccountsRepoStub struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (s *modelAccountsRepoStub) ListAllWithFilters(_ context.Context, _, _, _, _ string, _ int64, _ string) ([]Account, int64, error) {
+	return append([]Account(nil), s.accounts...), int64(len(s.accounts)), nil
+}
+
+func TestModelAccountsService_ListAccountsForModel(t *testing.T) {
+	billing := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o": {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+	})
+	t.Cleanup(func() { _, _ = billing.EnableProvider("openai") })
+
+	resetAt := time.Now().Add(time.Hour)
+	mapping := func(m map[string]any) map[string]any { return map[string]any{"model_mapping": m} }
+	svc := NewModelAccountsService(&modelAccountsRepoStub{accounts: []Account{
+		{ID: 1, Name: "mapped", Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Priority: 2,
+			Credentials: mapping(map[string]any{"gpt-4o": "gpt-4o-2024-08-06"})},
+		{ID: 2, Name: "all-models", Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Priority: 1},
+		{ID: 3, Name: "other-model", Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true,
+			Credentials: mapping(map[string]any{"gpt-4.1": "gpt-4.1"})},
+		{ID: 4, Name: "anthropic", Platform: PlatformAnthropic, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true},
+		{ID: 5, Name: "disabled", Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusDisabled, Schedulable: true},
+		{ID: 6, Name: "limited", Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, RateLimitResetAt: &resetAt},
+	}}, billing, nil)
+
+	report, err := svc.ListAccountsForModel(context.Background(), "gpt-4o")
+	require.NoError(t, err)
+	require.Equal(t, "openai", report.Provider)
+	require.False(t, report.ProviderDisabled)
+	require.Equal(t, 4, report.Total)
+	require.Equal(t, 2, report.AvailableCount)
+
+	ids := make([]int64, 0, len(report.Accounts))
+	for _, entry := range report.Accounts {
+		ids = append(ids, entry.AccountID)
+	}
+	require.Equal(t, []int64{2, 1, 5, 6}, ids, "可用账号在前，按优先级排序")
+	require.Equal(t, "gpt-4o-2024-08-06", report.Accounts[1].MappedModel)
+	require.Equal(t, ModelAccountUnavailableInactive, report.Accounts[2].UnavailableReason)
+	require.Equal(t, ModelAccountUnavailableRateLimited, report.Accounts[3].UnavailableReason)
+	require.Equal(t, CircuitStateClosed, report.Accounts[0].CircuitState)
+
+	_, err = billing.DisableProvider("openai")
+	require.NoError(t, err)
+	report, err = svc.ListAccountsForModel(context.Background(), "gpt-4o")
+	require.NoError(t, err)
+	require.True(t, report.ProviderDisabled)
+	require.Zero(t, report.AvailableCount)
+	for _, entry := range report.Accounts {
+		require.True(t, entry.ProviderDisabled)
+		if entry.AccountID == 2 {
+			require.Equal(t, ModelAccountUnavailableProviderDisabled, entry.UnavailableReason)
+		}
+	}
+}
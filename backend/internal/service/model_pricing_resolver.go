@@ -103,7 +103,7 @@ func (r *ModelPricingResolver) Resolve(ctx context.Context, input PricingInput)
 
 // resolveBasePricing 从 LiteLLM 或 Fallback 获取基础定价
 func (r *ModelPricingResolver) resolveBasePricing(model string) (*ModelPricing, string) {
-	pricing, err := r.billingService.GetModelPricing(model)
+	pricing, err := r.billingService.billingModelPricing(model)
 	if err != nil {
 		slog.Debug("failed to get model pricing from LiteLLM, using fallback",
 			"model", model, "error", err)
@@ -148,6 +148,11 @@ func (r *ModelPricingResolver) applyTokenOverrides(chPricing *ChannelModelPricin
 	if resolved.BasePricing == nil {
 		resolved.BasePricing = &ModelPricing{}
 	}
+	// 渠道显式配置的价格优先于免费标记
+	if chPricing.InputPrice != nil || chPricing.OutputPrice != nil ||
+		chPricing.CacheWritePrice != nil || chPricing.CacheReadPrice != nil {
+		resolved.BasePricing.IsFree = false
+	}
 
 	if chPricing.InputPrice != nil {
 		resolved.BasePricing.InputPricePerToken = *chPricing.InputPrice
@@ -161,10 +166,12 @@ func (r *ModelPricingResolver) applyTokenOverrides(chPricing *ChannelModelPricin
 		resolved.BasePricing.CacheCreationPricePerToken = *chPricing.CacheWritePrice
 		resolved.BasePricing.CacheCreation5mPrice = *chPricing.CacheWritePrice
 		resolved.BasePricing.CacheCreation1hPrice = *chPricing.CacheWritePrice
+		resolved.BasePricing.PromptCachingUnsupported = false
 	}
 	if chPricing.CacheReadPrice != nil {
 		resolved.BasePricing.CacheReadPricePerToken = *chPricing.CacheReadPrice
 		resolved.BasePricing.CacheReadPricePerTokenPriority = *chPricing.CacheReadPrice
+		resolved.BasePricing.PromptCachingUnsupported = false
 	}
 	if chPricing.ImageOutputPrice != nil {
 		resolved.BasePricing.ImageOutputPricePerToken = *chPricing.ImageOutputPrice
@@ -557,7 +557,8 @@ func buildOpenAIWeightedSelectionOrder(
 		if math.IsNaN(weight) || math.IsInf(weight, 0) || weight <= 0 {
 			weight = 1.0
 		}
-		weights[i] = weight
+		// 叠加账号调度权重，使同分账号按配置比例分配
+		weights[i] = weight * float64(pool[i].account.EffectiveWeight())
 	}
 
 	order := make([]openAIAccountCandidateScore, 0, len(pool))
@@ -590,6 +591,50 @@ func buildOpenAIWeightedSelectionOrder(
 	return order
 }
 
+// routingCostGroup 返回用于判断调度策略的分组：优先使用调度快照，其次请求上下文中的分组
+func (s *defaultOpenAIAccountScheduler) routingCostGroup(ctx context.Context, schedGroup *Group, groupID *int64) *Group {
+	if schedGroup != nil || groupID == nil {
+		return schedGroup
+	}
+	if group := groupFromRequestContext(ctx); group != nil && group.ID == *groupID {
+		return group
+	}
+	return nil
+}
+
+func sortOpenAICandidatesByRoutingCost(pool []openAIAccountCandidateScore, billing *BillingService, requestedModel string) []openAIAccountCandidateScore {
+	accounts := make([]*Account, 0, len(pool))
+	for _, candidate := range pool {
+		accounts = append(accounts, candidate.account)
+	}
+	return sortOpenAICandidatesByRoutingTable(pool, buildRoutingCostTable(billing, accounts, requestedModel))
+}
+
+// openAICandidateLatencyTable latency 策略下按平均延迟构建调度表；非 latency 分组或无延迟数据时返回 nil
+func openAICandidateLatencyTable(group *Group, pool []openAIAccountCandidateScore) routingCostTable {
+	if !group.IsLatencyScheduling() {
+		return nil
+	}
+	accounts := make([]*Account, 0, len(pool))
+	for _, candidate := range pool {
+		accounts = append(accounts, candidate.account)
+	}
+	return buildRoutingLatencyTable(accounts)
+}
+
+// sortOpenAICandidatesByRoutingTable 按调度表（成本/延迟）升序稳定排序，相同时按综合评分
+func sortOpenAICandidatesByRoutingTable(pool []openAIAccountCandidateScore, costs routingCostTable) []openAIAccountCandidateScore {
+	ordered := append([]openAIAccountCandidateScore(nil), pool...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ci, cj := costs[ordered[i].account.ID], costs[ordered[j].account.ID]
+		if ci != cj {
+			return ci < cj
+		}
+		return ordered[i].score > ordered[j].score
+	})
+	return ordered
+}
+
 func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 	ctx context.Context,
 	req OpenAIAccountScheduleRequest,
@@ -620,6 +665,10 @@ func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 		if !account.IsSchedulable() || !account.IsOpenAI() {
 			continue
 		}
+		// 权重为 0 的账号仅服务已有粘性会话
+		if account.IsDrainOnly() {
+			continue
+		}
 		// require_privacy_set: 跳过 privacy 未设置的账号并标记异常
 		if schedGroup != nil && schedGroup.RequirePrivacySet && !account.IsPrivacySet() {
 			_ = s.service.accountRepo.SetError(ctx, account.ID,
@@ -722,6 +771,7 @@ func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 		loadSkew = calcLoadSkewByMoments(loadRateSum, loadRateSumSquares, len(candidates))
 
 		weights := s.service.openAIWSSchedulerWeights()
+		lowRatio := s.service.schedulingConfig().UpstreamRateLimitLowRatio
 		for i := range candidates {
 			item := &candidates[i]
 			priorityFactor := 1.0
@@ -741,6 +791,10 @@ func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 				weights.Queue*queueFactor +
 				weights.ErrorRate*errorFactor +
 				weights.TTFT*ttftFactor
+			// 上游剩余配额偏低的账号扣除全部权重之和，排在配额充足的账号之后
+			if isAccountLowOnUpstreamRateLimit(item.account.ID, lowRatio) {
+				item.score -= weights.Priority + weights.Load + weights.Queue + weights.ErrorRate + weights.TTFT
+			}
 		}
 	}
 
@@ -816,6 +870,12 @@ func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 		if len(staleSnapshotCompactRetry) > 0 && s.service.schedulerSnapshot != nil {
 			selectionOrder = append(selectionOrder, sortCompactRetryCandidates(staleSnapshotCompactRetry)...)
 		}
+	} else if costGroup := s.routingCostGroup(ctx, schedGroup, req.GroupID); costGroup.IsCheapestScheduling() {
+		// cheapest 策略：按有效成本升序尝试全部候选，成本相同时按综合评分
+		selectionOrder = sortOpenAICandidatesByRoutingCost(candidates, s.service.billingService, req.RequestedModel)
+	} else if latencies := openAICandidateLatencyTable(costGroup, candidates); latencies != nil {
+		// latency 策略：按平均延迟升序尝试全部候选；没有任何延迟数据时回退为默认顺序
+		selectionOrder = sortOpenAICandidatesByRoutingTable(candidates, latencies)
 	} else {
 		selectionOrder = buildSelectionOrder(candidates)
 	}
@@ -846,6 +906,7 @@ func (s *defaultOpenAIAccountScheduler) selectByLoadBalance(
 			if req.SessionHash != "" {
 				_ = s.service.BindStickySession(ctx, req.GroupID, req.SessionHash, fresh.ID)
 			}
+			recordAccountSelection(req.GroupID, fresh)
 			return &AccountSelectionResult{
 				Account:     fresh,
 				Acquired:    true,
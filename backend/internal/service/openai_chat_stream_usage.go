@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/apicompat"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// openAIChatStreamMeta 记录流中 chunk 的 id/created/model，供合成末尾 usage chunk 使用
+type openAIChatStreamMeta struct {
+	id      string
+	created int64
+	model   string
+}
+
+func (m *openAIChatStreamMeta) observe(payload string) {
+	if id := gjson.Get(payload, "id").String(); id != "" {
+		m.id = id
+	}
+	if created := gjson.Get(payload, "created").Int(); created > 0 {
+		m.created = created
+	}
+	if model := gjson.Get(payload, "model").String(); model != "" {
+		m.model = model
+	}
+}
+
+// clientRequestedStreamUsage 客户端是否通过 stream_options.include_usage 请求末尾 usage chunk
+func clientRequestedStreamUsage(body []byte) bool {
+	return gjson.GetBytes(body, "stream_options.include_usage").Bool()
+}
+
+// buildOpenAIChatUsageChunk 按 OpenAI 协议构造末尾 usage chunk（choices 为空数组）。
+// cost 非 nil 时在 usage 中附加 cost 字段（USD，仅对开启 usage_headers 的 API Key）。
+func buildOpenAIChatUsageChunk(meta openAIChatStreamMeta, usage OpenAIUsage, cost *float64) string {
+	chatUsage := &apicompat.ChatUsage{
+		PromptTokens:     usage.InputTokens,
+		CompletionTokens: usage.OutputTokens,
+		TotalTokens:      usage.InputTokens + usage.OutputTokens,
+	}
+	if usage.CacheReadInputTokens > 0 {
+		chatUsage.PromptTokensDetails = &apicompat.ChatTokenDetails{CachedTokens: usage.CacheReadInputTokens}
+	}
+	data, _ := json.Marshal(apicompat.ChatCompletionsChunk{
+		ID:      meta.id,
+		Object:  "chat.completion.chunk",
+		Created: meta.created,
+		Model:   meta.model,
+		Choices: []apicompat.ChatChunkChoice{},
+		Usage:   chatUsage,
+	})
+	return withOpenAIChatUsageCost(string(data), cost)
+}
+
+// withOpenAIChatUsageCost 在 usage chunk 中附加 usage.cost
+func withOpenAIChatUsageCost(payload string, cost *float64) string {
+	if cost == nil {
+		return payload
+	}
+	updated, err := sjson.Set(payload, "usage.cost", *cost)
+	if err != nil {
+		return payload
+	}
+	return updated
+}
+
+// stripOpenAIChatChunkUsage 删除 chunk 中的 usage 字段（网关为计费强制开启 include_usage 时上游会在每个 chunk 带 usage: null）
+func stripOpenAIChatChunkUsage(payload string) string {
+	if !gjson.Get(payload, "usage").Exists() {
+		return payload
+	}
+	updated, err := sjson.Delete(payload, "usage")
+	if err != nil {
+		return payload
+	}
+	return updated
+}
+
+// openAIChatStreamUsageCost 按计费记录相同的计价路径计算本次用量向用户计费的费用，
+// 用于末尾 usage chunk；API Key 未开启 usage_headers 或计价失败时返回 nil
+func (s *OpenAIGatewayService) openAIChatStreamUsageCost(ctx context.Context, c *gin.Context, billingModel string, usage OpenAIUsage, serviceTier *string) *float64 {
+	apiKey := getAPIKeyFromContext(c)
+	if apiKey == nil || !apiKey.UsageHeaders || s.billingService == nil {
+		return nil
+	}
+	actualInputTokens := usage.InputTokens - usage.CacheReadInputTokens
+	if actualInputTokens < 0 {
+		actualInputTokens = 0
+	}
+	tokens := UsageTokens{
+		InputTokens:         actualInputTokens,
+		OutputTokens:        usage.OutputTokens,
+		CacheCreationTokens: usage.CacheCreationInputTokens,
+		CacheReadTokens:     usage.CacheReadInputTokens,
+	}
+
+	multiplier := 1.0
+	if s.cfg != nil {
+		multiplier = s.cfg.Default.RateMultiplier
+	}
+	if apiKey.GroupID != nil && apiKey.Group != nil {
+		resolver := s.userGroupRateResolver
+		if resolver == nil {
+			resolver = newUserGroupRateResolver(nil, nil, resolveUserGroupRateCacheTTL(s.cfg), nil, "service.openai_gateway")
+		}
+		multiplier = resolver.Resolve(ctx, apiKey.UserID, *apiKey.GroupID, apiKey.Group.RateMultiplier)
+	}
+	tier := ""
+	if serviceTier != nil {
+		tier = strings.TrimSpace(*serviceTier)
+	}
+	cost, err := s.calculateOpenAIRecordUsageTokenCost(ctx, apiKey, billingModel, multiplier, tokens, tier)
+	if err != nil || cost == nil {
+		return nil
+	}
+	return &cost.ActualCost
+}
@@ -162,6 +162,7 @@ func (s *OpenAIGatewayService) forwardAsRawChatCompletions(
 	if account.Proxy != nil {
 		proxyURL = account.Proxy.URL()
 	}
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
 	if err != nil {
 		safeErr := sanitizeUpstreamErrorMessage(err.Error())
@@ -174,6 +175,9 @@ func (s *OpenAIGatewayService) forwardAsRawChatCompletions(
 			Kind:               "request_error",
 			Message:            safeErr,
 		})
+		if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+			return nil, failoverErr
+		}
 		writeChatCompletionsError(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
 		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
 	}
@@ -220,20 +224,27 @@ func (s *OpenAIGatewayService) forwardAsRawChatCompletions(
 
 	// 8. Forward response
 	if clientStream {
-		return s.streamRawChatCompletions(c, resp, originalModel, billingModel, upstreamModel, reasoningEffort, serviceTier, startTime)
+		return s.streamRawChatCompletions(c, resp, body, clientRequestedStreamUsage(body), originalModel, billingModel, upstreamModel, reasoningEffort, serviceTier, startTime)
 	}
-	return s.bufferRawChatCompletions(c, resp, originalModel, billingModel, upstreamModel, reasoningEffort, serviceTier, startTime)
+	return s.bufferRawChatCompletions(c, resp, body, originalModel, billingModel, upstreamModel, reasoningEffort, serviceTier, startTime)
 }
 
 // streamRawChatCompletions 透传上游 CC SSE 流到客户端，并提取 usage（包括
 // 末尾 [DONE] 之前的 chunk 中的 usage 字段，按 OpenAI CC 协议）。
 //
 // usage 字段仅在客户端请求 stream_options.include_usage=true 时出现于上游响应中。
-// 网关会对上游强制打开 include_usage 以保证计费完整，并原样向下游透传 usage，
-// 让级联代理或下游计费系统也能拿到完整用量。
+// 网关会对上游强制打开 include_usage 以保证计费完整；向下游则严格按客户端的 include_usage 输出：
+//   - 客户端请求了 include_usage：透传上游的末尾 usage chunk；上游未返回时在 [DONE] 之前合成一个
+//     （按网关计算的用量，API Key 开启 usage_headers 时 usage 附带 cost）
+//   - 客户端未请求：丢弃 usage chunk 并删除各 chunk 中的 usage 字段，不向下游注入额外内容
+//
+// 部分兼容上游会忽略 include_usage，流中断时也拿不到末尾 usage chunk：
+// 此时按计费模型对应的 tokenizer 估算请求 messages 与已下发 delta 内容的 token，保证已输出部分仍被计费。
 func (s *OpenAIGatewayService) streamRawChatCompletions(
 	c *gin.Context,
 	resp *http.Response,
+	requestBody []byte,
+	clientIncludeUsage bool,
 	originalModel string,
 	billingModel string,
 	upstreamModel string,
@@ -260,43 +271,97 @@ func (s *OpenAIGatewayService) streamRawChatCompletions(
 	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
 
 	var usage OpenAIUsage
+	var usageSeen bool
+	var estimator streamUsageEstimator
 	var firstTokenMs *int
+	var meta openAIChatStreamMeta
 	clientDisconnected := false
+	usageChunkSent := false // 已向下游输出末尾 usage chunk
+	skipBlank := false      // 丢弃的 data 行之后紧跟的事件分隔空行一并丢弃
+
+	writeLine := func(line string) {
+		if clientDisconnected {
+			return
+		}
+		if _, werr := c.Writer.WriteString(line + "\n"); werr != nil {
+			clientDisconnected = true
+			logger.L().Debug("openai chat_completions raw: client disconnected, continuing to drain upstream for billing",
+				zap.Error(werr),
+				zap.String("request_id", requestID),
+			)
+			return
+		}
+		// 仅在 SSE 事件边界（空行）刷新，避免把一个事件拆成多次写出
+		if line == "" {
+			c.Writer.Flush()
+		}
+	}
+	usageEstimated := false
+	finalizeUsage := func() {
+		if usageEstimated {
+			return
+		}
+		usageEstimated = true
+		if applyCCStreamUsageEstimate(&usage, usageSeen, requestBody, &estimator, s.billingService.tokenCountFor(billingModel)) {
+			logger.L().Info("openai chat_completions raw: upstream stream usage incomplete, billing with estimated tokens",
+				zap.String("request_id", requestID),
+				zap.Int("input_tokens", usage.InputTokens),
+				zap.Int("output_tokens", usage.OutputTokens),
+			)
+		}
+	}
+	// 客户端请求了 include_usage 但上游未返回 usage chunk 时，按网关计算的用量合成一个
+	emitSynthesizedUsage := func() {
+		if !clientIncludeUsage || usageChunkSent {
+			return
+		}
+		usageChunkSent = true
+		finalizeUsage()
+		cost := s.openAIChatStreamUsageCost(c.Request.Context(), c, billingModel, usage, serviceTier)
+		writeLine("data: " + buildOpenAIChatUsageChunk(meta, usage, cost))
+		writeLine("")
+	}
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		if line == "" && skipBlank {
+			skipBlank = false
+			continue
+		}
+		skipBlank = false
 		if payload, ok := extractOpenAISSEDataLine(line); ok {
 			trimmedPayload := strings.TrimSpace(payload)
-			if trimmedPayload != "[DONE]" {
+			if trimmedPayload == "[DONE]" {
+				emitSynthesizedUsage()
+			} else {
 				usageOnlyChunk := isOpenAIChatUsageOnlyStreamChunk(payload)
+				meta.observe(payload)
 				if u := extractCCStreamUsage(payload); u != nil {
 					usage = *u
+					usageSeen = true
+				}
+				if !usageOnlyChunk {
+					estimator.observeCCChunk(payload)
 				}
 				if firstTokenMs == nil && !usageOnlyChunk {
 					elapsed := int(time.Since(startTime).Milliseconds())
 					firstTokenMs = &elapsed
 				}
-			}
-		}
 
-		if !clientDisconnected {
-			if _, werr := c.Writer.WriteString(line + "\n"); werr != nil {
-				clientDisconnected = true
-				logger.L().Debug("openai chat_completions raw: client disconnected, continuing to drain upstream for billing",
-					zap.Error(werr),
-					zap.String("request_id", requestID),
-				)
-			}
-		}
-		if line == "" {
-			if !clientDisconnected {
-				c.Writer.Flush()
+				switch {
+				case !clientIncludeUsage && usageOnlyChunk:
+					skipBlank = true
+					continue
+				case !clientIncludeUsage:
+					line = "data: " + stripOpenAIChatChunkUsage(payload)
+				case usageOnlyChunk && !usageChunkSent:
+					usageChunkSent = true
+					line = "data: " + withOpenAIChatUsageCost(payload, s.openAIChatStreamUsageCost(c.Request.Context(), c, billingModel, usage, serviceTier))
+				}
 			}
-			continue
-		}
-		if !clientDisconnected {
-			c.Writer.Flush()
 		}
+
+		writeLine(line)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -308,6 +373,13 @@ func (s *OpenAIGatewayService) streamRawChatCompletions(
 		}
 	}
 
+	// 上游未发送 [DONE]（流中断）时仍补发 usage chunk，让客户端拿到已计费的用量
+	emitSynthesizedUsage()
+	finalizeUsage()
+	if !clientDisconnected {
+		c.Writer.Flush()
+	}
+
 	return &OpenAIForwardResult{
 		RequestID:       requestID,
 		Usage:           usage,
@@ -365,6 +437,7 @@ func extractCCStreamUsage(payload string) *OpenAIUsage {
 func (s *OpenAIGatewayService) bufferRawChatCompletions(
 	c *gin.Context,
 	resp *http.Response,
+	requestBody []byte,
 	originalModel string,
 	billingModel string,
 	upstreamModel string,
@@ -384,7 +457,9 @@ func (s *OpenAIGatewayService) bufferRawChatCompletions(
 
 	var ccResp apicompat.ChatCompletionsResponse
 	var usage OpenAIUsage
+	usageSeen := false
 	if err := json.Unmarshal(respBody, &ccResp); err == nil && ccResp.Usage != nil {
+		usageSeen = true
 		usage = OpenAIUsage{
 			InputTokens:  ccResp.Usage.PromptTokens,
 			OutputTokens: ccResp.Usage.CompletionTokens,
@@ -393,6 +468,14 @@ func (s *OpenAIGatewayService) bufferRawChatCompletions(
 			usage.CacheReadInputTokens = ccResp.Usage.PromptTokensDetails.CachedTokens
 		}
 	}
+	// 部分兼容上游不返回 usage 或 prompt_tokens，按计费模型的 tokenizer 估算缺失部分
+	if applyCCResponseUsageEstimate(&usage, usageSeen, requestBody, respBody, s.billingService.tokenCountFor(billingModel)) {
+		logger.L().Info("openai chat_completions raw: upstream usage incomplete, billing with estimated tokens",
+			zap.String("request_id", requestID),
+			zap.Int("input_tokens", usage.InputTokens),
+			zap.Int("output_tokens", usage.OutputTokens),
+		)
+	}
 
 	if s.responseHeaderFilter != nil {
 		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.responseHeaderFilter)
@@ -76,7 +76,7 @@ func TestBuildOpenAIResponsesURL_ProbeURL(t *testing.T) {
 	}
 }
 
-func TestForwardAsRawChatCompletions_ForcesStreamUsageUpstreamAndPassesUsageDownstream(t *testing.T) {
+func TestForwardAsRawChatCompletions_ForcesStreamUsageUpstreamWithoutLeakingUsageDownstream(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	body := []byte(`{"model":"gpt-5.4","messages":[{"role":"user","content":"hello"}],"stream":true}`)
@@ -114,8 +114,164 @@ func TestForwardAsRawChatCompletions_ForcesStreamUsageUpstreamAndPassesUsageDown
 	require.NotNil(t, upstream.lastReq)
 	require.NoError(t, upstream.lastReq.Context().Err())
 	require.True(t, gjson.GetBytes(upstream.lastBody, "stream_options.include_usage").Bool())
-	require.Contains(t, rec.Body.String(), `"usage"`)
+	// 客户端未请求 include_usage：下游不应出现 usage chunk
+	require.NotContains(t, rec.Body.String(), `"usage"`)
+	require.Contains(t, rec.Body.String(), `"content":"ok"`)
 	require.Contains(t, rec.Body.String(), "data: [DONE]")
+	require.NotContains(t, rec.Body.String(), "\n\n\n")
+}
+
+func TestForwardAsRawChatCompletions_ClientIncludeUsagePassesUpstreamUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := []byte(`{"model":"gpt-5.4","messages":[{"role":"user","content":"hello"}],"stream":true,"stream_options":{"include_usage":true}}`)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	upstreamBody := strings.Join([]string{
+		`data: {"id":"chatcmpl_1","object":"chat.completion.chunk","model":"gpt-5.4","choices":[{"index":0,"delta":{"content":"ok"}}],"usage":null}`,
+		"",
+		`data: {"id":"chatcmpl_1","object":"chat.completion.chunk","model":"gpt-5.4","choices":[],"usage":{"prompt_tokens":9,"completion_tokens":4,"total_tokens":13}}`,
+		"",
+		"data: [DONE]",
+		"",
+	}, "\n")
+	upstream := &httpUpstreamRecorder{resp: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(upstreamBody)),
+	}}
+
+	svc := &OpenAIGatewayService{
+		cfg:          rawChatCompletionsTestConfig(),
+		httpUpstream: upstream,
+	}
+
+	_, err := svc.forwardAsRawChatCompletions(context.Background(), c, rawChatCompletionsTestAccount(), body, "")
+	require.NoError(t, err)
+
+	out := rec.Body.String()
+	require.Equal(t, 1, strings.Count(out, `"prompt_tokens"`))
+	require.Contains(t, out, `"usage":{"prompt_tokens":9,"completion_tokens":4,"total_tokens":13}`)
+	require.Less(t, strings.Index(out, `"prompt_tokens"`), strings.Index(out, "data: [DONE]"))
+}
+
+func TestForwardAsRawChatCompletions_ClientIncludeUsageSynthesizesMissingUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := []byte(`{"model":"gpt-5.4","messages":[{"role":"user","content":"hello"}],"stream":true,"stream_options":{"include_usage":true}}`)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// 上游忽略 include_usage
+	upstreamBody := strings.Join([]string{
+		`data: {"id":"chatcmpl_syn","object":"chat.completion.chunk","created":1700000000,"model":"gpt-5.4","choices":[{"index":0,"delta":{"content":"hello world"}}]}`,
+		"",
+		"data: [DONE]",
+		"",
+	}, "\n")
+	upstream := &httpUpstreamRecorder{resp: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(upstreamBody)),
+	}}
+
+	svc := &OpenAIGatewayService{
+		cfg:          rawChatCompletionsTestConfig(),
+		httpUpstream: upstream,
+	}
+
+	result, err := svc.forwardAsRawChatCompletions(context.Background(), c, rawChatCompletionsTestAccount(), body, "")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	var usageChunk string
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if payload, ok := extractOpenAISSEDataLine(line); ok && gjson.Get(payload, "usage").Exists() {
+			usageChunk = payload
+		}
+	}
+	require.NotEmpty(t, usageChunk)
+	require.Equal(t, "chatcmpl_syn", gjson.Get(usageChunk, "id").String())
+	require.Equal(t, "chat.completion.chunk", gjson.Get(usageChunk, "object").String())
+	require.Equal(t, int64(1700000000), gjson.Get(usageChunk, "created").Int())
+	require.Equal(t, "gpt-5.4", gjson.Get(usageChunk, "model").String())
+	require.True(t, gjson.Get(usageChunk, "choices").IsArray())
+	require.Empty(t, gjson.Get(usageChunk, "choices").Array())
+	require.Equal(t, int64(result.Usage.InputTokens), gjson.Get(usageChunk, "usage.prompt_tokens").Int())
+	require.Equal(t, int64(result.Usage.OutputTokens), gjson.Get(usageChunk, "usage.completion_tokens").Int())
+	require.Equal(t, int64(result.Usage.InputTokens+result.Usage.OutputTokens), gjson.Get(usageChunk, "usage.total_tokens").Int())
+	require.False(t, gjson.Get(usageChunk, "usage.cost").Exists())
+	require.Less(t, strings.Index(rec.Body.String(), `"usage"`), strings.Index(rec.Body.String(), "data: [DONE]"))
+}
+
+// openAIChatFlushRecorder 记录每次 Flush 时已写出的内容
+type openAIChatFlushRecorder struct {
+	gin.ResponseWriter
+	body    *httptest.ResponseRecorder
+	flushed []string
+}
+
+func (w *openAIChatFlushRecorder) Flush() {
+	w.flushed = append(w.flushed, w.body.Body.String())
+	w.ResponseWriter.Flush()
+}
+
+func TestForwardAsRawChatCompletions_FlushesOnlyAtEventBoundaries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := []byte(`{"model":"gpt-5.4","messages":[{"role":"user","content":"hello"}],"stream":true,"stream_options":{"include_usage":true}}`)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	writer := &openAIChatFlushRecorder{ResponseWriter: c.Writer, body: rec}
+	c.Writer = writer
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// 多行事件（event + data）只能在空行处整体刷新
+	upstreamBody := strings.Join([]string{
+		"event: chunk",
+		`data: {"id":"chatcmpl_f","object":"chat.completion.chunk","created":1700000000,"model":"gpt-5.4","choices":[{"index":0,"delta":{"content":"hi"}}]}`,
+		"",
+		"data: [DONE]",
+		"",
+	}, "\n") + "\n"
+	upstream := &httpUpstreamRecorder{resp: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(upstreamBody)),
+	}}
+
+	svc := &OpenAIGatewayService{
+		cfg:          rawChatCompletionsTestConfig(),
+		httpUpstream: upstream,
+	}
+
+	_, err := svc.forwardAsRawChatCompletions(context.Background(), c, rawChatCompletionsTestAccount(), body, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, writer.flushed)
+	for _, flushed := range writer.flushed {
+		require.True(t, strings.HasSuffix(flushed, "\n\n"), "flush 必须落在事件边界: %q", flushed)
+	}
+	require.Equal(t, rec.Body.String(), writer.flushed[len(writer.flushed)-1])
+}
+
+func TestBuildOpenAIChatUsageChunk(t *testing.T) {
+	meta := openAIChatStreamMeta{id: "chatcmpl_1", created: 1700000000, model: "gpt-5.4"}
+	usage := OpenAIUsage{InputTokens: 10, OutputTokens: 5, CacheReadInputTokens: 4}
+
+	chunk := buildOpenAIChatUsageChunk(meta, usage, nil)
+	require.JSONEq(t, `{"id":"chatcmpl_1","object":"chat.completion.chunk","created":1700000000,"model":"gpt-5.4","choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15,"prompt_tokens_details":{"cached_tokens":4}}}`, chunk)
+
+	cost := 0.0125
+	chunk = buildOpenAIChatUsageChunk(meta, usage, &cost)
+	require.InDelta(t, 0.0125, gjson.Get(chunk, "usage.cost").Float(), 1e-12)
+
+	require.Equal(t, `{"id":"x","choices":[]}`, stripOpenAIChatChunkUsage(`{"id":"x","choices":[],"usage":null}`))
 }
 
 func TestForwardAsRawChatCompletions_ClientDisconnectDrainsUsage(t *testing.T) {
@@ -157,6 +313,43 @@ func TestForwardAsRawChatCompletions_ClientDisconnectDrainsUsage(t *testing.T) {
 	require.True(t, gjson.GetBytes(upstream.lastBody, "stream_options.include_usage").Bool())
 }
 
+func TestForwardAsRawChatCompletions_EstimatesUsageWhenUpstreamOmitsIt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 31 个 ASCII 字符 → 8 token
+	body := []byte(`{"model":"gpt-5.4","messages":[{"role":"user","content":"please summarize this document."}],"stream":true}`)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// 上游忽略 include_usage 且流在 [DONE] 之前中断
+	upstreamBody := strings.Join([]string{
+		`data: {"id":"chatcmpl_1","object":"chat.completion.chunk","model":"gpt-5.4","choices":[{"index":0,"delta":{"content":"The document "}}]}`,
+		"",
+		`data: {"id":"chatcmpl_1","object":"chat.completion.chunk","model":"gpt-5.4","choices":[{"index":0,"delta":{"content":"covers billing."}}]}`,
+		"",
+	}, "\n")
+	upstream := &httpUpstreamRecorder{resp: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}, "x-request-id": []string{"rid_raw_estimate"}},
+		Body:       io.NopCloser(strings.NewReader(upstreamBody)),
+	}}
+
+	svc := &OpenAIGatewayService{
+		cfg:          rawChatCompletionsTestConfig(),
+		httpUpstream: upstream,
+	}
+
+	result, err := svc.forwardAsRawChatCompletions(context.Background(), c, rawChatCompletionsTestAccount(), body, "")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, 8, result.Usage.InputTokens)
+	// "The document covers billing." 共 28 个字符 → 7 token
+	require.Equal(t, 7, result.Usage.OutputTokens)
+	require.Zero(t, result.Usage.CacheReadInputTokens)
+}
+
 func TestForwardAsRawChatCompletions_UpstreamRequestIgnoresClientCancel(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -228,7 +421,7 @@ func TestBufferRawChatCompletions_RejectsOversizedResponse(t *testing.T) {
 	svc := &OpenAIGatewayService{cfg: rawChatCompletionsTestConfig()}
 	svc.cfg.Gateway.UpstreamResponseReadMaxBytes = 3
 
-	result, err := svc.bufferRawChatCompletions(c, resp, "gpt-5.4", "gpt-5.4", "gpt-5.4", nil, nil, time.Now())
+	result, err := svc.bufferRawChatCompletions(c, resp, nil, "gpt-5.4", "gpt-5.4", "gpt-5.4", nil, nil, time.Now())
 	require.ErrorIs(t, err, ErrUpstreamResponseBodyTooLarge)
 	require.Nil(t, result)
 	require.Equal(t, http.StatusBadGateway, rec.Code)
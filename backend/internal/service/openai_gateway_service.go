@@ -336,6 +336,7 @@ type OpenAIGatewayService struct {
 	channelService        *ChannelService
 	balanceNotifyService  *BalanceNotifyService
 	settingService        *SettingService
+	slowRequestLogger     *SlowRequestLogger
 
 	openaiWSPoolOnce              sync.Once
 	openaiWSStateStoreOnce        sync.Once
@@ -378,6 +379,7 @@ func NewOpenAIGatewayService(
 	channelService *ChannelService,
 	balanceNotifyService *BalanceNotifyService,
 	settingService *SettingService,
+	slowRequestLogger *SlowRequestLogger,
 ) *OpenAIGatewayService {
 	svc := &OpenAIGatewayService{
 		accountRepo:         accountRepo,
@@ -409,6 +411,7 @@ func NewOpenAIGatewayService(
 		channelService:        channelService,
 		balanceNotifyService:  balanceNotifyService,
 		settingService:        settingService,
+		slowRequestLogger:     slowRequestLogger,
 		responseHeaderFilter:  compileResponseHeaderFilter(cfg),
 		codexSnapshotThrottle: newAccountWriteThrottle(openAICodexSnapshotPersistMinInterval),
 	}
@@ -514,6 +517,7 @@ func (s *OpenAIGatewayService) billingDeps() *billingDeps {
 		billingCacheService:  s.billingCacheService,
 		deferredService:      s.deferredService,
 		balanceNotifyService: s.balanceNotifyService,
+		slowRequestLogger:    s.slowRequestLogger,
 	}
 }
 
@@ -1371,6 +1375,18 @@ func isOpenAIAccountEligibleForRequest(account *Account, requestedModel string,
 	if account == nil || !account.IsSchedulable() || !account.IsOpenAI() {
 		return false
 	}
+	// 健康检查连续失败的账号在恢复前不参与调度
+	if isAccountMarkedUnhealthy(account.ID) {
+		return false
+	}
+	// 上游失败率过高被熔断的账号在冷却结束前不参与调度
+	if isAccountCircuitOpen(account.ID) {
+		return false
+	}
+	// 厂商被管理员整体禁用时跳过其账号
+	if isAccountProviderDisabled(account) {
+		return false
+	}
 	if requestedModel != "" && !account.IsModelSupported(requestedModel) {
 		return false
 	}
@@ -1765,6 +1781,10 @@ func (s *OpenAIGatewayService) selectAccountWithLoadAwareness(ctx context.Contex
 		if !acc.IsSchedulable() {
 			continue
 		}
+		// 权重为 0 的账号仅服务已有粘性会话
+		if acc.IsDrainOnly() {
+			continue
+		}
 		if requestedModel != "" && !acc.IsModelSupported(requestedModel) {
 			continue
 		}
@@ -1869,6 +1889,8 @@ func (s *OpenAIGatewayService) selectAccountWithLoadAwareness(ctx context.Contex
 				}
 			})
 			shuffleWithinSortGroups(available)
+			// 上游剩余配额偏低的账号排到最后
+			available = moveUpstreamRateLimitLowToEnd(available, cfg.UpstreamRateLimitLowRatio)
 
 			selectionOrder := make([]accountWithLoad, 0, len(available))
 			if requireCompact {
@@ -2845,6 +2867,7 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 
 		// Send request
 		upstreamStart := time.Now()
+		applyAccountCustomHeaders(upstreamReq, account)
 		resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
 		SetOpsLatencyMs(c, OpsUpstreamLatencyMsKey, time.Since(upstreamStart).Milliseconds())
 		if err != nil {
@@ -2859,6 +2882,9 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 				Kind:               "request_error",
 				Message:            safeErr,
 			})
+			if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+				return nil, failoverErr
+			}
 			c.JSON(http.StatusBadGateway, gin.H{
 				"error": gin.H{
 					"type":    "upstream_error",
@@ -2942,6 +2968,8 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 			imageCount = nonStreamResult.imageCount
 		}
 
+		recordUpstreamRateLimitHeaders(account, resp.Header)
+
 		// Extract and save Codex usage snapshot from response headers (for OAuth accounts)
 		if account.Type == AccountTypeOAuth {
 			if snapshot := ParseCodexRateLimitHeaders(resp.Header); snapshot != nil {
@@ -3151,6 +3179,7 @@ func (s *OpenAIGatewayService) forwardOpenAIPassthrough(
 	}
 
 	upstreamStart := time.Now()
+	applyAccountCustomHeaders(upstreamReq, account)
 	resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
 	SetOpsLatencyMs(c, OpsUpstreamLatencyMsKey, time.Since(upstreamStart).Milliseconds())
 	if err != nil {
@@ -3165,6 +3194,9 @@ func (s *OpenAIGatewayService) forwardOpenAIPassthrough(
 			Kind:               "request_error",
 			Message:            safeErr,
 		})
+		if failoverErr := upstreamRequestErrorFailover(ctx, s.cfg, err); failoverErr != nil {
+			return nil, failoverErr
+		}
 		c.JSON(http.StatusBadGateway, gin.H{
 			"error": gin.H{
 				"type":    "upstream_error",
@@ -3243,6 +3275,7 @@ func (s *OpenAIGatewayService) forwardOpenAIPassthrough(
 		imageCount = result.imageCount
 	}
 
+	recordUpstreamRateLimitHeaders(account, resp.Header)
 	if snapshot := ParseCodexRateLimitHeaders(resp.Header); snapshot != nil {
 		s.updateCodexUsageSnapshot(ctx, account.ID, snapshot)
 	}
@@ -5447,6 +5480,7 @@ type OpenAIRecordUsageInput struct {
 	UserAgent          string // 请求的 User-Agent
 	IPAddress          string // 请求的客户端 IP 地址
 	RequestPayloadHash string
+	ResponseCacheHit   bool // 命中响应缓存：按 hit_billing_rate 折算计费，不计入账号成本
 	APIKeyService      APIKeyQuotaUpdater
 	ChannelUsageFields
 }
@@ -5527,6 +5561,13 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if err != nil {
 		return err
 	}
+	if input.ResponseCacheHit {
+		hitRate := 0.0
+		if s.cfg != nil {
+			hitRate = s.cfg.Gateway.ResponseCache.HitBillingRate
+		}
+		applyResponseCacheHitCost(cost, hitRate)
+	}
 
 	// Determine billing type
 	isSubscriptionBilling := subscription != nil && apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
@@ -5565,6 +5606,7 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		ImageOutputTokens:   result.Usage.ImageOutputTokens,
 		ImageCount:          result.ImageCount,
 		ImageSize:           optionalTrimmedStringPtr(result.ImageSize),
+		IsSandbox:           apiKey.IsSandbox,
 	}
 	if cost != nil {
 		usageLog.InputCost = cost.InputCost
@@ -5618,11 +5660,11 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		usageLog.SubscriptionID = &subscription.ID
 	}
 
-	// 计算账号统计定价费用（使用最终上游模型匹配自定义规则）
-	if apiKey.GroupID != nil {
+	// 计算账号统计定价费用（使用最终上游模型匹配自定义规则）；命中响应缓存时未消耗账号
+	if apiKey.GroupID != nil && !input.ResponseCacheHit {
 		applyAccountStatsCost(ctx, usageLog, s.channelService, s.billingService,
 			account.ID, *apiKey.GroupID, result.UpstreamModel, result.Model,
-			tokens, cost.TotalCost,
+			tokens, cost.UpstreamCost(),
 		)
 	}
 
@@ -5668,7 +5710,7 @@ func (s *OpenAIGatewayService) calculateOpenAIRecordUsageCost(
 ) (*CostBreakdown, error) {
 	billingModel := firstUsageBillingModel(billingModels)
 	if result != nil && result.ImageCount > 0 {
-		return s.calculateOpenAIImageCost(ctx, billingModel, apiKey, result, imageMultiplier), nil
+		return s.calculateOpenAIImageCost(ctx, billingModel, apiKey, result, tokens, imageMultiplier), nil
 	}
 	if len(billingModels) == 0 || billingModel == "" {
 		return nil, errors.New("openai usage billing model is empty")
@@ -5681,6 +5723,7 @@ func (s *OpenAIGatewayService) calculateOpenAIRecordUsageCost(
 		}
 		cost, err := s.calculateOpenAIRecordUsageTokenCost(ctx, apiKey, candidate, multiplier, tokens, serviceTier)
 		if err == nil {
+			s.billingService.applyStructuredOutputMultiplier(ctx, cost)
 			return cost, nil
 		}
 		lastErr = err
@@ -5720,6 +5763,7 @@ func (s *OpenAIGatewayService) calculateOpenAIImageCost(
 	billingModel string,
 	apiKey *APIKey,
 	result *OpenAIForwardResult,
+	tokens UsageTokens,
 	multiplier float64,
 ) *CostBreakdown {
 	if resolved := s.resolveOpenAIChannelPricing(ctx, billingModel, apiKey); resolved != nil &&
@@ -5749,7 +5793,7 @@ func (s *OpenAIGatewayService) calculateOpenAIImageCost(
 			Price4K: apiKey.Group.ImagePrice4K,
 		}
 	}
-	return s.billingService.CalculateImageCost(billingModel, result.ImageSize, result.ImageCount, groupConfig, multiplier)
+	return s.billingService.CalculateImageCostWithTokens(billingModel, result.ImageSize, result.ImageCount, tokens, groupConfig, multiplier)
 }
 
 func (s *OpenAIGatewayService) resolveOpenAIChannelPricing(ctx context.Context, billingModel string, apiKey *APIKey) *ResolvedPricing {
@@ -225,6 +225,9 @@ type OpenAIWSIngressHooks struct {
 	BeforeTurn          func(turn int) error
 	BeforeRequest       func(turn int, payload []byte, originalModel string) error
 	AfterTurn           func(turn int, result *OpenAIForwardResult, turnErr error)
+	// BeforeClientWrite 在每个上游事件写给客户端前调用，可改写事件（如屏蔽模型名）。
+	// 返回错误时不再向客户端写出并以该错误结束会话；ctx_pool 模式下仍读完本轮上游事件以记录用量。
+	BeforeClientWrite func(payload []byte) ([]byte, error)
 }
 
 func normalizeOpenAIWSLogValue(value string) string {
@@ -2797,7 +2800,17 @@ func (s *OpenAIGatewayService) ProxyResponsesWebSocketFromClient(
 		return lease, nil
 	}
 
+	// clientWriteAbortErr 记录 BeforeClientWrite 中止写出的原因，本轮读完上游并回调 AfterTurn 后以此结束会话
+	var clientWriteAbortErr error
 	writeClientMessage := func(message []byte) error {
+		if hooks != nil && hooks.BeforeClientWrite != nil {
+			rewritten, err := hooks.BeforeClientWrite(message)
+			if err != nil {
+				clientWriteAbortErr = err
+				return err
+			}
+			message = rewritten
+		}
 		writeCtx, cancel := context.WithTimeout(ctx, s.openAIWSWriteTimeout())
 		defer cancel()
 		return clientConn.Write(writeCtx, coderws.MessageText, message)
@@ -2977,7 +2990,7 @@ func (s *OpenAIGatewayService) ProxyResponsesWebSocketFromClient(
 					}
 				}
 				if err := writeClientMessage(upstreamMessage); err != nil {
-					if isOpenAIWSClientDisconnectError(err) {
+					if isOpenAIWSClientDisconnectError(err) || clientWriteAbortErr != nil {
 						clientDisconnected = true
 						closeStatus, closeReason := summarizeOpenAIWSReadCloseError(err)
 						logOpenAIWSModeInfo(
@@ -3538,6 +3551,9 @@ func (s *OpenAIGatewayService) ProxyResponsesWebSocketFromClient(
 		if hooks != nil && hooks.AfterTurn != nil {
 			hooks.AfterTurn(turn, result, nil)
 		}
+		if clientWriteAbortErr != nil {
+			return clientWriteAbortErr
+		}
 		if result == nil {
 			return errors.New("websocket turn result is nil")
 		}
@@ -30,10 +30,14 @@ type openAIWSClientFrameConn struct {
 //     event via onBlock and surfaces a transport-level error so the relay
 //     stops reading from the client.
 //   - _, _, err: a transport error other than block.
+//
+// beforeWrite, when set, runs on every upstream→client text frame and may
+// rewrite it; an error stops the relay at the write_client stage.
 type openAIWSPolicyEnforcingFrameConn struct {
-	inner   openaiwsv2.FrameConn
-	filter  func(msgType coderws.MessageType, payload []byte) ([]byte, *OpenAIFastBlockedError, error)
-	onBlock func(blocked *OpenAIFastBlockedError)
+	inner       openaiwsv2.FrameConn
+	filter      func(msgType coderws.MessageType, payload []byte) ([]byte, *OpenAIFastBlockedError, error)
+	onBlock     func(blocked *OpenAIFastBlockedError)
+	beforeWrite func(payload []byte) ([]byte, error)
 }
 
 var _ openaiwsv2.FrameConn = (*openAIWSPolicyEnforcingFrameConn)(nil)
@@ -66,6 +70,13 @@ func (c *openAIWSPolicyEnforcingFrameConn) WriteFrame(ctx context.Context, msgTy
 	if c == nil || c.inner == nil {
 		return errOpenAIWSConnClosed
 	}
+	if c.beforeWrite != nil && msgType == coderws.MessageText {
+		rewritten, err := c.beforeWrite(payload)
+		if err != nil {
+			return err
+		}
+		payload = rewritten
+	}
 	return c.inner.WriteFrame(ctx, msgType, payload)
 }
 
@@ -455,6 +466,11 @@ func (s *OpenAIGatewayService) proxyResponsesWebSocketV2Passthrough(
 			cancel()
 		},
 	}
+	if hooks != nil && hooks.BeforeClientWrite != nil {
+		// 在 runUpstreamToClient goroutine 中调用，与 filter 中的 BeforeRequest 并发，
+		// hook 自身负责共享状态的并发安全。
+		policyClientConn.beforeWrite = hooks.BeforeClientWrite
+	}
 	relayResult, relayExit := openaiwsv2.RunEntry(openaiwsv2.EntryInput{
 		Ctx:                ctx,
 		ClientConn:         policyClientConn,
@@ -0,0 +1,84 @@
+package service
+
+import "encoding/json"
+
+// ModelCapabilities 模型能力标记，取自导入的价格数据；字段缺失时为 nil 表示未知
+type ModelCapabilities struct {
+	SupportsVision           *bool `json:"supports_vision,omitempty"`
+	SupportsFunctionCalling  *bool `json:"supports_function_calling,omitempty"`
+	SupportsStreaming        *bool `json:"supports_streaming,omitempty"`
+	SupportsStructuredOutput *bool `json:"supports_structured_output,omitempty"`
+	MaxContextTokens         *int  `json:"max_context_tokens,omitempty"`
+}
+
+// modelCapabilityFields 能力标记的 JSON 字段名（价格差异比较时忽略）
+var modelCapabilityFields = []string{
+	"supports_vision",
+	"supports_function_calling",
+	"supports_streaming",
+	"supports_structured_output",
+	"max_context_tokens",
+}
+
+// parseModelCapabilities 从原始条目中宽松解析能力标记：字段缺失或类型不符时保持未知，不影响价格导入。
+// supports_streaming 兼容 LiteLLM 的 supports_native_streaming，supports_structured_output 兼容 supports_response_schema，
+// max_context_tokens 兼容 max_input_tokens。
+func parseModelCapabilities(raw json.RawMessage) ModelCapabilities {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return ModelCapabilities{}
+	}
+	return ModelCapabilities{
+		SupportsVision:           capabilityBool(fields, "supports_vision"),
+		SupportsFunctionCalling:  capabilityBool(fields, "supports_function_calling"),
+		SupportsStreaming:        capabilityBool(fields, "supports_streaming", "supports_native_streaming"),
+		SupportsStructuredOutput: capabilityBool(fields, "supports_structured_output", "supports_response_schema"),
+		MaxContextTokens:         capabilityInt(fields, "max_context_tokens", "max_input_tokens"),
+	}
+}
+
+// capabilityBool 按顺序取第一个可解析为 bool 的字段
+func capabilityBool(fields map[string]json.RawMessage, names ...string) *bool {
+	for _, name := range names {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var v bool
+		if err := json.Unmarshal(raw, &v); err == nil {
+			return &v
+		}
+	}
+	return nil
+}
+
+// capabilityInt 按顺序取第一个可解析为正数的字段（兼容 1e6 这类浮点写法）
+func capabilityInt(fields map[string]json.RawMessage, names ...string) *int {
+	for _, name := range names {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var v float64
+		if err := json.Unmarshal(raw, &v); err == nil && v > 0 {
+			n := int(v)
+			return &n
+		}
+	}
+	return nil
+}
+
+// Matches 按能力筛选：want 为 true 时要求明确支持，为 false 时要求不支持或未知
+func (c ModelCapabilities) Matches(vision, functionCalling, streaming *bool) bool {
+	return capabilityMatches(c.SupportsVision, vision) &&
+		capabilityMatches(c.SupportsFunctionCalling, functionCalling) &&
+		capabilityMatches(c.SupportsStreaming, streaming)
+}
+
+func capabilityMatches(flag, want *bool) bool {
+	if want == nil {
+		return true
+	}
+	supported := flag != nil && *flag
+	return supported == *want
+}
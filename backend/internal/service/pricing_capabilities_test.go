@@ -0,0 +1,57 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePricingData_Capabilities(t *testing.T) {
+	svc := &PricingService{}
+	data, err := svc.parsePricingData([]byte(`{
+		"gpt-4o": {"input_cost_per_token": 2.5e-6, "output_cost_per_token": 1e-5, "supports_vision": true,
+			"supports_function_calling": true, "supports_native_streaming": true, "supports_response_schema": true,
+			"max_input_tokens": 128000},
+		"legacy": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "max_input_tokens": 1e6,
+			"supports_vision": "yes"},
+		"bare": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, data, 3)
+
+	caps := data["gpt-4o"].ModelCapabilities
+	require.True(t, *caps.SupportsVision)
+	require.True(t, *caps.SupportsFunctionCalling)
+	require.True(t, *caps.SupportsStreaming)
+	require.True(t, *caps.SupportsStructuredOutput)
+	require.Equal(t, 128000, *caps.MaxContextTokens)
+
+	// 类型不符的能力字段视为未知，不影响价格导入
+	legacy := data["legacy"]
+	require.InDelta(t, 1e-6, legacy.InputCostPerToken, 1e-12)
+	require.Nil(t, legacy.SupportsVision)
+	require.Equal(t, 1_000_000, *legacy.MaxContextTokens)
+
+	require.Equal(t, ModelCapabilities{}, data["bare"].ModelCapabilities)
+}
+
+func TestModelCapabilities_Matches(t *testing.T) {
+	yes, no := true, false
+	caps := ModelCapabilities{SupportsVision: &yes, SupportsStreaming: &no}
+
+	require.True(t, caps.Matches(nil, nil, nil))
+	require.True(t, caps.Matches(&yes, nil, nil))
+	require.False(t, caps.Matches(&no, nil, nil))
+	// 未知视为不支持
+	require.False(t, caps.Matches(nil, &yes, nil))
+	require.True(t, caps.Matches(nil, &no, &no))
+}
+
+func TestDiffModelPricing_IgnoresCapabilities(t *testing.T) {
+	yes := true
+	oldPricing := &LiteLLMModelPricing{InputCostPerToken: 1e-6}
+	newPricing := &LiteLLMModelPricing{InputCostPerToken: 1e-6, ModelCapabilities: ModelCapabilities{SupportsVision: &yes}}
+	require.Empty(t, diffModelPricing(oldPricing, newPricing))
+}
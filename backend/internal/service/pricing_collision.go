@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// PricingCollision 上传文件中重复出现的模型名（按 JSON 语义后出现的条目生效）
+type PricingCollision struct {
+	Model        string   `json:"model"`
+	Providers    []string `json:"providers"`     // 各条目的 litellm_provider，按出现顺序
+	KeptProvider string   `json:"kept_provider"` // 最终生效（最后出现）的条目厂商
+}
+
+// PricingCollisionError strict 模式下上传文件存在重复模型时拒绝导入
+type PricingCollisionError struct {
+	Collisions []PricingCollision
+}
+
+func (e *PricingCollisionError) Error() string {
+	return fmt.Sprintf("pricing data contains %d duplicate model entries", len(e.Collisions))
+}
+
+// detectPricingCollisions 逐个读取顶层键，找出重复出现的模型名（按首次出现顺序）
+func detectPricingCollisions(body []byte) ([]PricingCollision, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("parse raw JSON: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("parse raw JSON: pricing data must be a JSON object")
+	}
+
+	providers := make(map[string][]string)
+	order := make([]string, 0)
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("parse raw JSON: %w", err)
+		}
+		model, _ := keyTok.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("parse raw JSON: %w", err)
+		}
+		if model == "sample_spec" {
+			continue
+		}
+		// 条目格式错误由 validatePricingUpload 报告，这里只取厂商
+		var entry struct {
+			LiteLLMProvider string `json:"litellm_provider"`
+		}
+		_ = json.Unmarshal(raw, &entry)
+
+		if _, ok := providers[model]; !ok {
+			order = append(order, model)
+		}
+		providers[model] = append(providers[model], entry.LiteLLMProvider)
+	}
+
+	collisions := make([]PricingCollision, 0)
+	for _, model := range order {
+		list := providers[model]
+		if len(list) < 2 {
+			continue
+		}
+		collisions = append(collisions, PricingCollision{
+			Model:        model,
+			Providers:    list,
+			KeptProvider: list[len(list)-1],
+		})
+	}
+	return collisions, nil
+}
+
+// checkPricingCollisions 检测上传文件中的重复模型：strict 时返回 PricingCollisionError，
+// 否则保持后出现者生效，并记录被覆盖的条目
+func checkPricingCollisions(body []byte, strict bool) ([]PricingCollision, error) {
+	collisions, err := detectPricingCollisions(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(collisions) == 0 {
+		return collisions, nil
+	}
+	if strict {
+		return nil, &PricingCollisionError{Collisions: collisions}
+	}
+	for _, collision := range collisions {
+		for _, provider := range collision.Providers[:len(collision.Providers)-1] {
+			logger.LegacyPrintf("service.pricing", "[Pricing] Duplicate model %s in uploaded file: entry from provider %q overwritten by %q",
+				collision.Model, provider, collision.KeptProvider)
+		}
+	}
+	return collisions, nil
+}
@@ -0,0 +1,60 @@
+//go:build unit
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+const duplicatePricingUpload = `{
+	"gpt-4o": {"input_cost_per_token": 2.5e-6, "output_cost_per_token": 1e-5, "litellm_provider": "openai"},
+	"claude-sonnet-4": {"input_cost_per_token": 3e-6, "output_cost_per_token": 1.5e-5, "litellm_provider": "anthropic"},
+	"gpt-4o": {"input_cost_per_token": 5e-6, "output_cost_per_token": 2e-5, "litellm_provider": "azure"}
+}`
+
+func TestDetectPricingCollisions(t *testing.T) {
+	collisions, err := detectPricingCollisions([]byte(duplicatePricingUpload))
+	require.NoError(t, err)
+	require.Equal(t, []PricingCollision{{
+		Model:        "gpt-4o",
+		Providers:    []string{"openai", "azure"},
+		KeptProvider: "azure",
+	}}, collisions)
+
+	collisions, err = detectPricingCollisions([]byte(`{"a": {"litellm_provider": "openai"}, "sample_spec": {}, "b": 1}`))
+	require.NoError(t, err)
+	require.Empty(t, collisions)
+
+	_, err = detectPricingCollisions([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestImportPricingData_CollisionsLastWinsUnlessStrict(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.ImportPricingData([]byte(duplicatePricingUpload), true)
+	var collisionErr *PricingCollisionError
+	require.True(t, errors.As(err, &collisionErr))
+	require.Len(t, collisionErr.Collisions, 1)
+	require.Empty(t, svc.ListAllPricing())
+
+	result, err := svc.ImportPricingData([]byte(duplicatePricingUpload), false)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Total)
+	require.Len(t, result.Collisions, 1)
+	pricing := svc.ListAllPricing()["gpt-4o"]
+	require.Equal(t, "azure", pricing.LiteLLMProvider)
+	require.InDelta(t, 5e-6, pricing.InputCostPerToken, 1e-12)
+
+	_, err = svc.MergePricingData([]byte(duplicatePricingUpload), true)
+	require.True(t, errors.As(err, &collisionErr))
+	merged, err := svc.MergePricingData([]byte(duplicatePricingUpload), false)
+	require.NoError(t, err)
+	require.Len(t, merged.Collisions, 1)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ModelDeprecation 模型弃用标记，取自导入的价格数据
+type ModelDeprecation struct {
+	Deprecated      bool   `json:"deprecated,omitempty"`
+	Replacement     string `json:"replacement,omitempty"`      // 建议替代的模型
+	DeprecationDate string `json:"deprecation_date,omitempty"` // 上游公布的弃用日期（YYYY-MM-DD），到期后视为已弃用
+}
+
+// modelDeprecationFields 弃用标记的 JSON 字段名（价格差异比较时忽略）
+var modelDeprecationFields = []string{
+	"deprecated",
+	"replacement",
+	"deprecation_date",
+}
+
+// IsDeprecated 显式标记弃用，或弃用日期已到
+func (d ModelDeprecation) IsDeprecated(now time.Time) bool {
+	if d.Deprecated {
+		return true
+	}
+	if d.DeprecationDate == "" {
+		return false
+	}
+	date, err := time.Parse(time.DateOnly, d.DeprecationDate)
+	return err == nil && !now.Before(date)
+}
+
+// parseModelDeprecation 从原始条目中宽松解析弃用标记：字段缺失或类型不符时忽略，不影响价格导入。
+// replacement 兼容 replacement_model。
+func parseModelDeprecation(raw json.RawMessage) ModelDeprecation {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return ModelDeprecation{}
+	}
+	var d ModelDeprecation
+	if v := capabilityBool(fields, "deprecated"); v != nil {
+		d.Deprecated = *v
+	}
+	d.Replacement = deprecationString(fields, "replacement", "replacement_model")
+	if date := deprecationString(fields, "deprecation_date"); date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err == nil {
+			d.DeprecationDate = date
+		}
+	}
+	return d
+}
+
+// deprecationString 按顺序取第一个非空字符串字段
+func deprecationString(fields map[string]json.RawMessage, names ...string) string {
+	for _, name := range names {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var v string
+		if err := json.Unmarshal(raw, &v); err == nil && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// DeprecationAutorouteEnabled 是否将弃用模型的请求自动改写为替代模型
+func (s *BillingService) DeprecationAutorouteEnabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Pricing.DeprecationAutoroute
+}
+
+// ModelDeprecation 返回模型的弃用状态与替代模型；未弃用或无价格数据时 deprecated 为 false
+func (s *BillingService) ModelDeprecation(model string) (replacement string, deprecated bool) {
+	if s == nil || s.pricingService == nil || model == "" {
+		return "", false
+	}
+	pricing := s.pricingService.GetModelPricing(model)
+	if pricing == nil || !pricing.IsDeprecated(time.Now()) {
+		return "", false
+	}
+	if pricing.Replacement == model {
+		return "", true
+	}
+	return pricing.Replacement, true
+}
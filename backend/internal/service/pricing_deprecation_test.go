@@ -0,0 +1,40 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePricingData_Deprecation(t *testing.T) {
+	svc := &PricingService{}
+	data, err := svc.parsePricingData([]byte(`{
+		"old": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "deprecated": true, "replacement_model": " new "},
+		"dated": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "deprecation_date": "2026-06-01"},
+		"bad": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "deprecated": "yes", "deprecation_date": "soon"},
+		"new": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, data, 4)
+
+	require.Equal(t, ModelDeprecation{Deprecated: true, Replacement: "new"}, data["old"].ModelDeprecation)
+	require.True(t, data["old"].IsDeprecated(time.Now()))
+
+	// 弃用日期当天起视为已弃用
+	dated := data["dated"].ModelDeprecation
+	require.False(t, dated.IsDeprecated(time.Date(2026, 5, 31, 23, 0, 0, 0, time.UTC)))
+	require.True(t, dated.IsDeprecated(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	// 类型或格式不符的字段忽略，不影响价格导入
+	require.Equal(t, ModelDeprecation{}, data["bad"].ModelDeprecation)
+	require.Equal(t, ModelDeprecation{}, data["new"].ModelDeprecation)
+}
+
+func TestPricingFieldMap_IgnoresDeprecation(t *testing.T) {
+	before := &LiteLLMModelPricing{InputCostPerToken: 1e-6}
+	after := &LiteLLMModelPricing{InputCostPerToken: 1e-6, ModelDeprecation: ModelDeprecation{Deprecated: true, Replacement: "new"}}
+	require.Equal(t, pricingFieldMap(before), pricingFieldMap(after))
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// PricingFieldChange 单个价格字段的变更
+type PricingFieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// PricingModelChange 单个模型的价格变更
+type PricingModelChange struct {
+	Model   string               `json:"model"`
+	Changes []PricingFieldChange `json:"changes"`
+}
+
+// PricingDiff 两份价格数据之间的差异
+type PricingDiff struct {
+	Added   []string             `json:"added"`
+	Removed []string             `json:"removed"`
+	Changed []PricingModelChange `json:"changed"`
+}
+
+// HasChanges 是否存在任何差异
+func (d *PricingDiff) HasChanges() bool {
+	return d != nil && (len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0)
+}
+
+// diffPricingData 计算从 oldData 到 newData 的差异（结果按模型名排序）
+func diffPricingData(oldData, newData map[string]*LiteLLMModelPricing) *PricingDiff {
+	diff := &PricingDiff{
+		Added:   make([]string, 0),
+		Removed: make([]string, 0),
+		Changed: make([]PricingModelChange, 0),
+	}
+
+	for model, newPricing := range newData {
+		oldPricing, ok := oldData[model]
+		if !ok {
+			diff.Added = append(diff.Added, model)
+			continue
+		}
+		if changes := diffModelPricing(oldPricing, newPricing); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, PricingModelChange{Model: model, Changes: changes})
+		}
+	}
+	for model := range oldData {
+		if _, ok := newData[model]; !ok {
+			diff.Removed = append(diff.Removed, model)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Model < diff.Changed[j].Model })
+	return diff
+}
+
+// diffModelPricing 按 JSON 字段比较两条价格记录，返回发生变化的字段
+func diffModelPricing(oldPricing, newPricing *LiteLLMModelPricing) []PricingFieldChange {
+	oldFields := pricingFieldMap(oldPricing)
+	newFields := pricingFieldMap(newPricing)
+
+	names := make([]string, 0, len(newFields))
+	seen := make(map[string]struct{}, len(newFields))
+	for name := range newFields {
+		names = append(names, name)
+		seen[name] = struct{}{}
+	}
+	for name := range oldFields {
+		if _, ok := seen[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]PricingFieldChange, 0)
+	for _, name := range names {
+		oldValue, newValue := oldFields[name], newFields[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, PricingFieldChange{Field: name, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+// pricingFieldMap 将价格记录转换为 JSON 字段名 -> 值 的映射（不含能力标记与弃用标记）
+func pricingFieldMap(pricing *LiteLLMModelPricing) map[string]any {
+	if pricing == nil {
+		return map[string]any{}
+	}
+	data, err := json.Marshal(pricing)
+	if err != nil {
+		return map[string]any{}
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return map[string]any{}
+	}
+	// 能力标记与弃用标记不属于价格，变化时不视为价格变更
+	for _, name := range modelCapabilityFields {
+		delete(fields, name)
+	}
+	for _, name := range modelDeprecationFields {
+		delete(fields, name)
+	}
+	return fields
+}
+
+// DiffPricingData 解析上传的价格数据并与当前数据对比（不修改任何状态）
+func (s *PricingService) DiffPricingData(body []byte) (*PricingDiff, error) {
+	data, err := s.parsePricingData(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse pricing data: %w", err)
+	}
+	return diffPricingData(s.ListAllPricing(), data), nil
+}
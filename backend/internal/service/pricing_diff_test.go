@@ -0,0 +1,40 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPricingData_AddedRemovedChanged(t *testing.T) {
+	svc := &PricingService{pricingData: map[string]*LiteLLMModelPricing{
+		"gpt-4o":  {InputCostPerToken: 2.5e-6, OutputCostPerToken: 1e-5, LiteLLMProvider: "openai"},
+		"old-one": {InputCostPerToken: 1e-6, OutputCostPerToken: 1e-6},
+		"same":    {InputCostPerToken: 1e-6, OutputCostPerToken: 2e-6},
+	}}
+
+	diff, err := svc.DiffPricingData([]byte(`{
+		"gpt-4o": {"input_cost_per_token": 2e-6, "output_cost_per_token": 1e-5, "litellm_provider": "openai"},
+		"same": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6},
+		"new-one": {"input_cost_per_token": 1e-6, "output_cost_per_token": 1e-6}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"new-one"}, diff.Added)
+	require.Equal(t, []string{"old-one"}, diff.Removed)
+	require.Len(t, diff.Changed, 1)
+	require.Equal(t, "gpt-4o", diff.Changed[0].Model)
+	require.Equal(t, []PricingFieldChange{{Field: "input_cost_per_token", Old: 2.5e-6, New: 2e-6}}, diff.Changed[0].Changes)
+
+	// 预览不修改当前数据
+	require.Len(t, svc.ListAllPricing(), 3)
+	require.InDelta(t, 2.5e-6, svc.ListAllPricing()["gpt-4o"].InputCostPerToken, 1e-12)
+}
+
+func TestDiffPricingData_InvalidJSON(t *testing.T) {
+	svc := &PricingService{pricingData: map[string]*LiteLLMModelPricing{}}
+
+	_, err := svc.DiffPricingData([]byte(`{not json`))
+	require.Error(t, err)
+}
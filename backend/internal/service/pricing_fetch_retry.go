@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+const (
+	// pricingFetchTimeout 单次拉取价格数据的超时时间
+	pricingFetchTimeout = 30 * time.Second
+	// pricingFetchMaxDelay 重试退避的等待上限
+	pricingFetchMaxDelay = time.Minute
+)
+
+// PricingFetchStatusError 远程价格数据源返回了非 200 状态码
+type PricingFetchStatusError struct {
+	StatusCode int
+}
+
+func (e *PricingFetchStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+// isRetryablePricingFetchError 4xx 响应不会自行恢复，不重试；其余错误（网络错误、5xx）可重试
+func isRetryablePricingFetchError(err error) bool {
+	var statusErr *PricingFetchStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode < 400 || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// pricingFetchRetryPolicy 返回最大尝试次数与基础退避时间（未配置时至少尝试一次且不等待）
+func (s *PricingService) pricingFetchRetryPolicy() (int, time.Duration) {
+	if s.cfg == nil {
+		return 1, 0
+	}
+	attempts := s.cfg.Pricing.FetchRetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := time.Duration(s.cfg.Pricing.FetchRetryBaseDelayMs) * time.Millisecond
+	if delay < 0 {
+		delay = 0
+	}
+	return attempts, delay
+}
+
+// fetchPricingJSONWithRetry 按指数退避重试拉取价格数据；最终失败时错误中包含已尝试次数。
+// 服务停止时中止等待。
+func (s *PricingService) fetchPricingJSONWithRetry(remoteURL string) ([]byte, error) {
+	maxAttempts, baseDelay := s.pricingFetchRetryPolicy()
+	delay := baseDelay
+
+	var lastErr error
+	attempt := 0
+	for attempt < maxAttempts {
+		attempt++
+		ctx, cancel := context.WithTimeout(context.Background(), pricingFetchTimeout)
+		body, err := s.remoteClient.FetchPricingJSON(ctx, remoteURL)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				logger.LegacyPrintf("service.pricing", "[Pricing] Download succeeded on attempt %d/%d", attempt, maxAttempts)
+			}
+			return body, nil
+		}
+		lastErr = err
+		if !isRetryablePricingFetchError(err) || attempt >= maxAttempts {
+			break
+		}
+
+		logger.LegacyPrintf("service.pricing", "[Pricing] Download attempt %d/%d failed, retrying in %v: %v", attempt, maxAttempts, delay, err)
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-s.stopCh:
+				timer.Stop()
+				return nil, fmt.Errorf("download failed after %d attempt(s): %w", attempt, lastErr)
+			}
+		}
+		delay = min(delay*2, pricingFetchMaxDelay)
+	}
+	return nil, fmt.Errorf("download failed after %d attempt(s): %w", attempt, lastErr)
+}
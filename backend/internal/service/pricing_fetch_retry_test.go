@@ -0,0 +1,106 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type flakyPricingRemoteClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *flakyPricingRemoteClient) FetchPricingJSON(context.Context, string) ([]byte, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return []byte(`{"gpt-4o":{"input_cost_per_token":2.5e-06,"output_cost_per_token":1e-05}}`), nil
+}
+
+func (c *flakyPricingRemoteClient) FetchHashText(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func newRetryTestPricingService(t *testing.T, client PricingRemoteClient, attempts int) *PricingService {
+	cfg := &config.Config{}
+	cfg.Pricing.RemoteURL = "https://example.com/model_prices.json"
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.FetchRetryAttempts = attempts
+	return NewPricingService(cfg, client)
+}
+
+func TestPricingFetchRetry_RecoversFromTransientErrors(t *testing.T) {
+	client := &flakyPricingRemoteClient{errs: []error{
+		errors.New("connection reset"),
+		&PricingFetchStatusError{StatusCode: 503},
+	}}
+	svc := newRetryTestPricingService(t, client, 3)
+
+	require.NoError(t, svc.ForceUpdate())
+	require.Equal(t, 3, client.calls)
+	require.NotNil(t, svc.GetExactModelPricing("gpt-4o"))
+}
+
+func TestPricingFetchRetry_ReportsAttemptsWhenExhausted(t *testing.T) {
+	client := &flakyPricingRemoteClient{errs: []error{
+		&PricingFetchStatusError{StatusCode: 502},
+		&PricingFetchStatusError{StatusCode: 502},
+	}}
+	svc := newRetryTestPricingService(t, client, 2)
+
+	err := svc.ForceUpdate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "after 2 attempt(s)")
+	require.Equal(t, 2, client.calls)
+}
+
+func TestPricingFetchRetry_DoesNotRetryClientErrors(t *testing.T) {
+	client := &flakyPricingRemoteClient{errs: []error{&PricingFetchStatusError{StatusCode: 404}}}
+	svc := newRetryTestPricingService(t, client, 5)
+
+	err := svc.ForceUpdate()
+	var statusErr *PricingFetchStatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, 404, statusErr.StatusCode)
+	require.Contains(t, err.Error(), "after 1 attempt(s)")
+	require.Equal(t, 1, client.calls)
+}
+
+type hashedPricingRemoteClient struct {
+	body string
+	hash string
+}
+
+func (c *hashedPricingRemoteClient) FetchPricingJSON(context.Context, string) ([]byte, error) {
+	return []byte(c.body), nil
+}
+
+func (c *hashedPricingRemoteClient) FetchHashText(context.Context, string) (string, error) {
+	return c.hash, nil
+}
+
+func TestPricingFetch_VerifyChecksumRejectsMismatch(t *testing.T) {
+	client := &hashedPricingRemoteClient{
+		body: `{"gpt-4o":{"input_cost_per_token":2.5e-06,"output_cost_per_token":1e-05}}`,
+		hash: "deadbeef",
+	}
+	svc := newRetryTestPricingService(t, client, 1)
+	svc.cfg.Pricing.HashURL = "https://example.com/model_prices.sha256"
+
+	// 默认仅告警
+	require.NoError(t, svc.ForceUpdate())
+	require.NotNil(t, svc.GetExactModelPricing("gpt-4o"))
+
+	svc.cfg.Pricing.VerifyChecksum = true
+	client.body = `{"gpt-4o":{"input_cost_per_token":9e-06,"output_cost_per_token":1e-05}}`
+	err := svc.ForceUpdate()
+	require.ErrorIs(t, err, ErrPricingChecksumMismatch)
+	require.Equal(t, 2.5e-06, svc.GetExactModelPricing("gpt-4o").InputCostPerToken)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// maxPricingHistoryVersions 每个模型保留的价格版本上限（超出时丢弃最早的版本）
+const maxPricingHistoryVersions = 100
+
+// ErrPricingHistoryNotFound 模型没有任何价格历史
+var ErrPricingHistoryNotFound = errors.New("pricing history not found")
+
+// PricingVersion 模型价格的一个历史版本，自 EffectiveFrom 起生效直到下一个版本
+type PricingVersion struct {
+	EffectiveFrom time.Time            `json:"effective_from"`
+	Pricing       *LiteLLMModelPricing `json:"pricing"`
+}
+
+// recordPricingHistoryLocked 为新增或价格变化的模型追加历史版本并持久化（调用方需持有写锁）。
+// updated 为各模型价格最近一次变化的时间，作为新版本的生效时间。
+func (s *PricingService) recordPricingHistoryLocked(data map[string]*LiteLLMModelPricing, updated map[string]time.Time, now time.Time) {
+	if s.history == nil {
+		// 首次记录时恢复已持久化的历史，避免重启后覆盖
+		s.history = s.loadPricingHistory()
+	}
+	changed := false
+	for model, pricing := range data {
+		versions := s.history[model]
+		if n := len(versions); n > 0 && len(diffModelPricing(versions[n-1].Pricing, pricing)) == 0 {
+			continue
+		}
+		effectiveFrom := updated[model]
+		if n := len(versions); n > 0 && !effectiveFrom.After(versions[n-1].EffectiveFrom) {
+			effectiveFrom = now
+		}
+		versions = append(versions, PricingVersion{EffectiveFrom: effectiveFrom, Pricing: pricing})
+		if len(versions) > maxPricingHistoryVersions {
+			versions = versions[len(versions)-maxPricingHistoryVersions:]
+		}
+		s.history[model] = versions
+		changed = true
+	}
+	if changed {
+		s.savePricingHistory(s.history)
+	}
+}
+
+// GetModelPricingAsOf 返回模型在 asOf 时刻生效的价格版本（不含管理员覆盖）。
+// 早于最早版本时返回最早的已知版本；模型已从当前价格数据中移除时仍可查询其历史。
+func (s *PricingService) GetModelPricingAsOf(modelName string, asOf time.Time) (string, *PricingVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	modelLower := strings.ToLower(strings.TrimSpace(modelName))
+	for _, candidate := range s.buildModelLookupCandidates(modelLower) {
+		versions := s.history[candidate]
+		if len(versions) == 0 {
+			continue
+		}
+		// 第一个生效时间晚于 asOf 的版本之前即为当时生效的版本
+		idx := sort.Search(len(versions), func(i int) bool {
+			return versions[i].EffectiveFrom.After(asOf)
+		})
+		if idx > 0 {
+			idx--
+		}
+		version := versions[idx]
+		return candidate, &version, nil
+	}
+	return "", nil, ErrPricingHistoryNotFound
+}
+
+// loadPricingHistory 读取持久化的价格历史（不存在或损坏时返回空）
+func (s *PricingService) loadPricingHistory() map[string][]PricingVersion {
+	result := make(map[string][]PricingVersion)
+	if s.cfg == nil || s.cfg.Pricing.DataDir == "" {
+		return result
+	}
+	data, err := os.ReadFile(s.getPricingHistoryFilePath())
+	if err != nil {
+		return result
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to parse pricing history: %v", err)
+		return make(map[string][]PricingVersion)
+	}
+	for model, versions := range result {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versions[i].EffectiveFrom.Before(versions[j].EffectiveFrom)
+		})
+		result[model] = versions
+	}
+	return result
+}
+
+// savePricingHistory 持久化价格历史
+func (s *PricingService) savePricingHistory(history map[string][]PricingVersion) {
+	if s.cfg == nil || s.cfg.Pricing.DataDir == "" {
+		return
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(s.getPricingHistoryFilePath(), data, 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save pricing history: %v", err)
+	}
+}
+
+// getPricingHistoryFilePath 获取价格历史文件路径
+func (s *PricingService) getPricingHistoryFilePath() string {
+	return filepath.Join(s.cfg.Pricing.DataDir, "model_pricing_history.json")
+}
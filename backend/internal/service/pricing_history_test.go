@@ -0,0 +1,63 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func historyTestPricing(input float64) *LiteLLMModelPricing {
+	return &LiteLLMModelPricing{InputCostPerToken: input, OutputCostPerToken: 2e-6, LiteLLMProvider: "openai"}
+}
+
+func TestPricingHistory_LookupAsOf(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	may := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	svc.mu.Lock()
+	svc.replacePricingDataLocked(map[string]*LiteLLMModelPricing{"gpt-x": historyTestPricing(1e-6)}, may)
+	// 价格未变化的刷新不产生新版本
+	svc.replacePricingDataLocked(map[string]*LiteLLMModelPricing{"gpt-x": historyTestPricing(1e-6)}, june)
+	svc.replacePricingDataLocked(map[string]*LiteLLMModelPricing{"gpt-x": historyTestPricing(3e-6)}, july)
+	svc.mu.Unlock()
+
+	cases := []struct {
+		asOf      time.Time
+		wantInput float64
+		wantFrom  time.Time
+	}{
+		{june.Add(15 * 24 * time.Hour), 1e-6, may},
+		{july, 3e-6, july},
+		{time.Now(), 3e-6, july},
+		// 早于最早版本时返回最早的已知价格
+		{may.AddDate(-1, 0, 0), 1e-6, may},
+	}
+	for _, tc := range cases {
+		matched, version, err := svc.GetModelPricingAsOf("GPT-X", tc.asOf)
+		require.NoError(t, err)
+		require.Equal(t, "gpt-x", matched)
+		require.InDelta(t, tc.wantInput, version.Pricing.InputCostPerToken, 1e-12)
+		require.True(t, tc.wantFrom.Equal(version.EffectiveFrom), "as_of %s", tc.asOf)
+	}
+
+	_, _, err := svc.GetModelPricingAsOf("unknown", time.Now())
+	require.ErrorIs(t, err, ErrPricingHistoryNotFound)
+
+	// 历史持久化到数据目录，重启后继续追加而不是覆盖
+	restarted := NewPricingService(cfg, nil)
+	restarted.mu.Lock()
+	restarted.replacePricingDataLocked(map[string]*LiteLLMModelPricing{"gpt-x": historyTestPricing(3e-6)}, time.Now())
+	restarted.mu.Unlock()
+	_, version, err := restarted.GetModelPricingAsOf("gpt-x", june)
+	require.NoError(t, err)
+	require.InDelta(t, 1e-6, version.Pricing.InputCostPerToken, 1e-12)
+	require.Len(t, restarted.history["gpt-x"], 2)
+}
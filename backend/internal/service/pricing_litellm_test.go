@@ -0,0 +1,106 @@
+//go:build unit
+
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPricingData_LiteLLMModelPricesSlice(t *testing.T) {
+	body, err := os.ReadFile("testdata/litellm_model_prices_slice.json")
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+	result, err := svc.ImportPricingData(body, true)
+	require.NoError(t, err)
+	// sample_spec 与按像素计价的 dall-e-3 不计入
+	require.Equal(t, 4, result.Total)
+
+	all := svc.ListAllPricing()
+	require.NotContains(t, all, "sample_spec")
+	require.NotContains(t, all, "standard/1024-x-1024/dall-e-3")
+
+	claude := all["claude-sonnet-4-20250514"]
+	require.NotNil(t, claude)
+	require.Equal(t, "anthropic", claude.LiteLLMProvider)
+	require.Equal(t, "chat", claude.Mode)
+	require.InDelta(t, 3e-6, claude.InputCostPerToken, 1e-15)
+	require.InDelta(t, 1.5e-5, claude.OutputCostPerToken, 1e-15)
+	require.InDelta(t, 3.75e-6, claude.CacheCreationInputTokenCost, 1e-15)
+	require.InDelta(t, 6e-6, claude.CacheCreationInputTokenCostAbove1hr, 1e-15)
+	require.InDelta(t, 3e-7, claude.CacheReadInputTokenCost, 1e-15)
+	require.True(t, claude.SupportsPromptCaching)
+	require.True(t, *claude.SupportsVision)
+	require.True(t, *claude.SupportsFunctionCalling)
+	require.True(t, *claude.SupportsStructuredOutput)
+	require.Equal(t, 1000000, *claude.MaxContextTokens)
+	require.Equal(t, "2026-05-14", claude.DeprecationDate)
+	// *_above_200k_tokens 分档价格不自动换算为长上下文倍率
+	require.Zero(t, claude.LongContextInputTokenThreshold)
+
+	gpt := all["gpt-4o"]
+	require.NotNil(t, gpt)
+	require.Equal(t, "openai", gpt.LiteLLMProvider)
+	require.InDelta(t, 4.25e-6, gpt.InputCostPerTokenPriority, 1e-15)
+	require.InDelta(t, 1.7e-5, gpt.OutputCostPerTokenPriority, 1e-15)
+	require.InDelta(t, 2.125e-6, gpt.CacheReadInputTokenCostPriority, 1e-15)
+	require.True(t, gpt.SupportsServiceTier)
+	require.Nil(t, gpt.SupportsStreaming)
+
+	gemini := all["gemini-2.5-pro"]
+	require.NotNil(t, gemini)
+	require.Equal(t, PlatformGemini, gemini.LiteLLMProvider)
+	require.Equal(t, 1048576, *gemini.MaxContextTokens)
+
+	embedding := all["text-embedding-3-small"]
+	require.NotNil(t, embedding)
+	require.Equal(t, "embedding", embedding.Mode)
+	require.Zero(t, embedding.OutputCostPerToken)
+}
+
+func TestParsePricingData_IgnoresMismatchedFieldsWithoutDroppingEntry(t *testing.T) {
+	svc := NewPricingService(&config.Config{}, nil)
+	data, err := svc.parsePricingData([]byte(`{
+		"odd-model": {
+			"input_cost_per_token": 1e-6,
+			"output_cost_per_token": 2e-6,
+			"litellm_provider": "openai",
+			"mode": ["chat"],
+			"supports_service_tier": "yes",
+			"supports_prompt_caching": true
+		}
+	}`))
+	require.NoError(t, err)
+	pricing := data["odd-model"]
+	require.NotNil(t, pricing)
+	require.InDelta(t, 1e-6, pricing.InputCostPerToken, 1e-15)
+	require.Equal(t, "openai", pricing.LiteLLMProvider)
+	require.Empty(t, pricing.Mode)
+	require.False(t, pricing.SupportsServiceTier)
+	require.True(t, pricing.SupportsPromptCaching)
+}
+
+func TestParsePricingData_ExplicitLongContextFields(t *testing.T) {
+	svc := NewPricingService(&config.Config{}, nil)
+	data, err := svc.parsePricingData([]byte(`{
+		"long-model": {
+			"input_cost_per_token": 1e-6,
+			"output_cost_per_token": 2e-6,
+			"litellm_provider": "openai",
+			"long_context_input_token_threshold": 272000,
+			"long_context_input_cost_multiplier": 2
+		}
+	}`))
+	require.NoError(t, err)
+	pricing := data["long-model"]
+	require.Equal(t, 272000, pricing.LongContextInputTokenThreshold)
+	require.InDelta(t, 2.0, pricing.LongContextInputCostMultiplier, 1e-9)
+	// 未提供的输出倍率按 1 处理
+	require.InDelta(t, 1.0, pricing.LongContextOutputCostMultiplier, 1e-9)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// PricingMergeResult 合并模式导入的统计
+type PricingMergeResult struct {
+	Added     int `json:"added"`     // 新增模型数
+	Updated   int `json:"updated"`   // 价格发生变化的已有模型数
+	Untouched int `json:"untouched"` // 未出现在上传文件中或价格未变化的模型数
+	Total     int `json:"total"`     // 合并后的模型总数
+
+	Collisions []PricingCollision `json:"collisions"` // 上传文件中重复出现的模型
+}
+
+// MergePricingData 合并模式导入：仅新增/更新上传文件中出现的模型，其余模型保持不变。
+// 合并后的完整数据会写回本地价格文件，保证重启后仍然生效。重复模型的处理同 ImportPricingData。
+func (s *PricingService) MergePricingData(body []byte, strict bool) (*PricingMergeResult, error) {
+	if err := validatePricingUpload(body); err != nil {
+		return nil, err
+	}
+	collisions, err := checkPricingCollisions(body, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.parsePricingData(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse pricing data: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &PricingMergeResult{Collisions: collisions}
+	merged := make(map[string]*LiteLLMModelPricing, len(s.pricingData)+len(data))
+	for model, pricing := range s.pricingData {
+		merged[model] = pricing
+	}
+	for model, pricing := range data {
+		prev, existed := merged[model]
+		switch {
+		case !existed:
+			result.Added++
+		case len(diffModelPricing(prev, pricing)) > 0:
+			result.Updated++
+		}
+		merged[model] = pricing
+	}
+	result.Total = len(merged)
+	result.Untouched = result.Total - result.Added - result.Updated
+
+	mergedBody, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("marshal merged pricing data: %w", err)
+	}
+
+	// 保存到本地文件
+	if err := os.WriteFile(s.getPricingFilePath(), mergedBody, 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save merged file: %v", err)
+	}
+
+	// 保存哈希
+	hash := sha256.Sum256(mergedBody)
+	hashStr := hex.EncodeToString(hash[:])
+	if err := os.WriteFile(s.getHashFilePath(), []byte(hashStr+"\n"), 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save hash: %v", err)
+	}
+
+	s.replacePricingDataLocked(merged, time.Now())
+	s.localHash = hashStr
+
+	logger.LegacyPrintf("service.pricing", "[Pricing] Merged uploaded file: %d added, %d updated, %d untouched",
+		result.Added, result.Updated, result.Untouched)
+	return result, nil
+}
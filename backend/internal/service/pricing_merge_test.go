@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePricingData_PersistsMergedDataset(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.ImportPricingData([]byte(`{
+		"a": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"b": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"}
+	}`), false)
+	require.NoError(t, err)
+
+	result, err := svc.MergePricingData([]byte(`{
+		"b": {"input_cost_per_token": 3e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"c": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "anthropic"}
+	}`), false)
+	require.NoError(t, err)
+	require.Equal(t, PricingMergeResult{Added: 1, Updated: 1, Untouched: 1, Total: 3, Collisions: []PricingCollision{}}, *result)
+
+	restarted := NewPricingService(cfg, nil)
+	require.NoError(t, restarted.loadPricingData(restarted.getPricingFilePath()))
+	all := restarted.ListAllPricing()
+	require.Len(t, all, 3)
+	require.InDelta(t, 3e-6, all["b"].InputCostPerToken, 1e-12)
+	require.Equal(t, "anthropic", all["c"].LiteLLMProvider)
+}
+
+func TestMergePricingData_RejectsInvalidEntries(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.MergePricingData([]byte(`{"a": {"input_cost_per_token": -1, "litellm_provider": "openai"}}`), false)
+	require.Error(t, err)
+	require.Empty(t, svc.ListAllPricing())
+}
@@ -11,10 +11,12 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
 	"go.uber.org/zap"
@@ -72,6 +74,11 @@ type LiteLLMModelPricing struct {
 	SupportsPromptCaching               bool    `json:"supports_prompt_caching"`
 	OutputCostPerImage                  float64 `json:"output_cost_per_image"`       // 图片生成模型每张图片价格
 	OutputCostPerImageToken             float64 `json:"output_cost_per_image_token"` // 图片输出 token 价格
+	IsFree                              bool    `json:"is_free,omitempty"`           // 显式标记为免费（内部/促销模型），计费为 0 且不加价
+	// 能力标记（视觉/函数调用/流式/上下文长度），可选
+	ModelCapabilities
+	// 弃用标记与替代模型，可选
+	ModelDeprecation
 }
 
 // PricingRemoteClient 远程价格数据获取接口
@@ -90,12 +97,16 @@ type LiteLLMRawEntry struct {
 	CacheCreationInputTokenCostAbove1hr *float64 `json:"cache_creation_input_token_cost_above_1hr"`
 	CacheReadInputTokenCost             *float64 `json:"cache_read_input_token_cost"`
 	CacheReadInputTokenCostPriority     *float64 `json:"cache_read_input_token_cost_priority"`
+	LongContextInputTokenThreshold      *int     `json:"long_context_input_token_threshold"`
+	LongContextInputCostMultiplier      *float64 `json:"long_context_input_cost_multiplier"`
+	LongContextOutputCostMultiplier     *float64 `json:"long_context_output_cost_multiplier"`
 	SupportsServiceTier                 bool     `json:"supports_service_tier"`
 	LiteLLMProvider                     string   `json:"litellm_provider"`
 	Mode                                string   `json:"mode"`
 	SupportsPromptCaching               bool     `json:"supports_prompt_caching"`
 	OutputCostPerImage                  *float64 `json:"output_cost_per_image"`
 	OutputCostPerImageToken             *float64 `json:"output_cost_per_image_token"`
+	IsFree                              bool     `json:"is_free"`
 }
 
 // PricingService 动态价格服务
@@ -104,8 +115,12 @@ type PricingService struct {
 	remoteClient PricingRemoteClient
 	mu           sync.RWMutex
 	pricingData  map[string]*LiteLLMModelPricing
+	modelUpdated map[string]time.Time        // 每个模型价格最近一次实际变化的时间
+	history      map[string][]PricingVersion // 每个模型的价格版本历史（按生效时间升序）
 	lastUpdated  time.Time
 	localHash    string
+	dataVersion  atomic.Uint64         // 价格数据版本，每次替换数据时递增（供上层缓存判断失效）
+	sourceStatus []PricingSourceStatus // 多价格源的拉取状态（未配置时为空）
 
 	// 停止信号
 	stopCh chan struct{}
@@ -118,6 +133,8 @@ func NewPricingService(cfg *config.Config, remoteClient PricingRemoteClient) *Pr
 		cfg:          cfg,
 		remoteClient: remoteClient,
 		pricingData:  make(map[string]*LiteLLMModelPricing),
+		modelUpdated: make(map[string]time.Time),
+		sourceStatus: newPricingSourceStatuses(cfg),
 		stopCh:       make(chan struct{}),
 	}
 	return s
@@ -138,6 +155,11 @@ func (s *PricingService) Initialize() error {
 		}
 	}
 
+	// 指标的模型标签仅保留定价数据中已知的模型
+	metrics.SetModelFilter(func(model string) bool {
+		return s.GetExactModelPricing(model) != nil
+	})
+
 	// 启动定时更新
 	s.startUpdateScheduler()
 
@@ -197,8 +219,8 @@ func (s *PricingService) checkAndUpdatePricing() error {
 		return s.downloadPricingData()
 	}
 
-	// 如果配置了哈希URL，通过远程哈希检查是否有更新
-	if s.cfg.Pricing.HashURL != "" {
+	// 如果配置了哈希URL，通过远程哈希检查是否有更新（多价格源不使用哈希）
+	if s.cfg.Pricing.HashURL != "" && !s.hasPricingSources() {
 		remoteHash, err := s.fetchRemoteHash()
 		if err != nil {
 			logger.LegacyPrintf("service.pricing", "[Pricing] Failed to fetch remote hash on startup: %v", err)
@@ -240,8 +262,8 @@ func (s *PricingService) checkAndUpdatePricing() error {
 
 // syncWithRemote 与远程同步（基于哈希校验）
 func (s *PricingService) syncWithRemote() error {
-	// 如果配置了哈希URL，从远程获取哈希进行比对
-	if s.cfg.Pricing.HashURL != "" {
+	// 如果配置了哈希URL，从远程获取哈希进行比对（多价格源不使用哈希）
+	if s.cfg.Pricing.HashURL != "" && !s.hasPricingSources() {
 		remoteHash, err := s.fetchRemoteHash()
 		if err != nil {
 			logger.LegacyPrintf("service.pricing", "[Pricing] Failed to fetch remote hash: %v", err)
@@ -279,16 +301,51 @@ func (s *PricingService) syncWithRemote() error {
 	return nil
 }
 
+// remotePricingFetch 一次远程价格拉取的结果
+type remotePricingFetch struct {
+	body     []byte
+	data     map[string]*LiteLLMModelPricing
+	syncHash string
+}
+
 // downloadPricingData 从远程下载价格数据
 func (s *PricingService) downloadPricingData() error {
-	remoteURL, err := s.validatePricingURL(s.cfg.Pricing.RemoteURL)
+	fetched, err := s.fetchRemotePricing()
 	if err != nil {
 		return err
 	}
-	logger.LegacyPrintf("service.pricing", "[Pricing] Downloading from %s", remoteURL)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// 保存到本地文件
+	pricingFile := s.getPricingFilePath()
+	if err := os.WriteFile(pricingFile, fetched.body, 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save file: %v", err)
+	}
+	hashFile := s.getHashFilePath()
+	if err := os.WriteFile(hashFile, []byte(fetched.syncHash+"\n"), 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save hash: %v", err)
+	}
+
+	// 更新内存数据
+	s.mu.Lock()
+	s.replacePricingDataLocked(fetched.data, time.Now())
+	s.localHash = fetched.syncHash
+	s.mu.Unlock()
+
+	logger.LegacyPrintf("service.pricing", "[Pricing] Downloaded %d models successfully", len(fetched.data))
+	return nil
+}
+
+// fetchRemotePricing 下载并解析远程价格数据（不写文件、不修改内存状态）
+// 配置了多价格源时拉取全部启用的源并合并。
+func (s *PricingService) fetchRemotePricing() (*remotePricingFetch, error) {
+	if s.hasPricingSources() {
+		return s.fetchPricingSources()
+	}
+	remoteURL, err := s.validatePricingURL(s.cfg.Pricing.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+	logger.LegacyPrintf("service.pricing", "[Pricing] Downloading from %s", remoteURL)
 
 	// 获取远程哈希（用于同步锚点，不作为完整性校验）
 	var remoteHash string
@@ -299,16 +356,21 @@ func (s *PricingService) downloadPricingData() error {
 		}
 	}
 
-	body, err := s.remoteClient.FetchPricingJSON(ctx, remoteURL)
+	// 失败时按 fetch_retry_attempts 指数退避重试（手动与定时刷新共用）
+	body, err := s.fetchPricingJSONWithRetry(remoteURL)
 	if err != nil {
-		return fmt.Errorf("download failed: %w", err)
+		return nil, err
 	}
 
-	// 哈希校验：不匹配时仅告警，不阻止更新
-	// 远程哈希文件可能与数据文件不同步（如维护者更新了数据但未更新哈希文件）
+	// 哈希校验：默认不匹配时仅告警，不阻止更新
+	// 远程哈希文件可能与数据文件不同步（如维护者更新了数据但未更新哈希文件）；
+	// 开启 verify_checksum 时在解析前拒绝，避免下载不完整的数据进入价格目录
 	dataHash := sha256.Sum256(body)
 	dataHashStr := hex.EncodeToString(dataHash[:])
 	if remoteHash != "" && !strings.EqualFold(remoteHash, dataHashStr) {
+		if s.cfg.Pricing.VerifyChecksum {
+			return nil, fmt.Errorf("%w: remote=%s data=%s", ErrPricingChecksumMismatch, remoteHash[:min(8, len(remoteHash))], dataHashStr[:8])
+		}
 		logger.LegacyPrintf("service.pricing", "[Pricing] Hash mismatch warning: remote=%s data=%s (hash file may be out of sync)",
 			remoteHash[:min(8, len(remoteHash))], dataHashStr[:8])
 	}
@@ -316,13 +378,7 @@ func (s *PricingService) downloadPricingData() error {
 	// 解析JSON数据（使用灵活的解析方式）
 	data, err := s.parsePricingData(body)
 	if err != nil {
-		return fmt.Errorf("parse pricing data: %w", err)
-	}
-
-	// 保存到本地文件
-	pricingFile := s.getPricingFilePath()
-	if err := os.WriteFile(pricingFile, body, 0644); err != nil {
-		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save file: %v", err)
+		return nil, fmt.Errorf("parse pricing data: %w", err)
 	}
 
 	// 使用远程哈希作为同步锚点，防止重复下载
@@ -331,28 +387,48 @@ func (s *PricingService) downloadPricingData() error {
 	if remoteHash != "" {
 		syncHash = remoteHash
 	}
-	hashFile := s.getHashFilePath()
-	if err := os.WriteFile(hashFile, []byte(syncHash+"\n"), 0644); err != nil {
-		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save hash: %v", err)
-	}
-
-	// 更新内存数据
-	s.mu.Lock()
-	s.pricingData = data
-	s.lastUpdated = time.Now()
-	s.localHash = syncHash
-	s.mu.Unlock()
+	return &remotePricingFetch{body: body, data: data, syncHash: syncHash}, nil
+}
 
-	logger.LegacyPrintf("service.pricing", "[Pricing] Downloaded %d models successfully", len(data))
-	return nil
+// decodeLiteLLMRawEntry 解析单个价格条目；个别已知字段类型不符时只忽略该字段，不丢弃整个条目
+func decodeLiteLLMRawEntry(raw json.RawMessage) (LiteLLMRawEntry, error) {
+	var entry LiteLLMRawEntry
+	if err := json.Unmarshal(raw, &entry); err == nil {
+		return entry, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return LiteLLMRawEntry{}, err
+	}
+	entry = LiteLLMRawEntry{}
+	for name, value := range fields {
+		field, err := json.Marshal(map[string]json.RawMessage{name: value})
+		if err != nil {
+			continue
+		}
+		var probe LiteLLMRawEntry
+		if json.Unmarshal(field, &probe) == nil {
+			_ = json.Unmarshal(field, &entry)
+		}
+	}
+	return entry, nil
 }
 
-// parsePricingData 解析价格数据（处理各种格式）
+// parsePricingData 解析价格数据，兼容 LiteLLM model_prices_and_context_window.json 格式：
+//   - litellm_provider → 厂商（vertex_ai 下的 gemini-* 归为 gemini），mode 原样保留
+//   - input/output_cost_per_token(_priority)、cache_creation_input_token_cost(_above_1hr)、
+//     cache_read_input_token_cost(_priority)、output_cost_per_image(_token) → 对应价格
+//   - supports_vision / supports_function_calling / supports_native_streaming / supports_response_schema /
+//     max_input_tokens → 能力标记；deprecation_date → 弃用标记
+//
+// 其余字段（max_tokens、*_batches、*_above_200k_tokens 分档价格、supported_regions 等）忽略；
+// 分档价格不自动换算为长上下文倍率，需要时显式提供 long_context_* 字段。
+// 无输入/输出价格的条目（如按像素计价的图片模型）跳过。
 func (s *PricingService) parsePricingData(body []byte) (map[string]*LiteLLMModelPricing, error) {
 	// 首先解析为 map[string]json.RawMessage
 	var rawData map[string]json.RawMessage
 	if err := json.Unmarshal(body, &rawData); err != nil {
-		return nil, fmt.Errorf("parse raw JSON: %w", err)
+		return nil, pricingJSONParseError(body, err)
 	}
 
 	result := make(map[string]*LiteLLMModelPricing)
@@ -365,22 +441,25 @@ func (s *PricingService) parsePricingData(body []byte) (map[string]*LiteLLMModel
 		}
 
 		// 尝试解析每个条目
-		var entry LiteLLMRawEntry
-		if err := json.Unmarshal(rawEntry, &entry); err != nil {
+		entry, err := decodeLiteLLMRawEntry(rawEntry)
+		if err != nil {
 			skipped++
 			continue
 		}
 
-		// 只保留有有效价格的条目
-		if entry.InputCostPerToken == nil && entry.OutputCostPerToken == nil {
+		// 只保留有有效价格的条目（显式标记免费的条目无需价格）
+		if entry.InputCostPerToken == nil && entry.OutputCostPerToken == nil && !entry.IsFree {
 			continue
 		}
 
 		pricing := &LiteLLMModelPricing{
-			LiteLLMProvider:       entry.LiteLLMProvider,
+			LiteLLMProvider:       canonicalPricingProvider(modelName, entry.LiteLLMProvider),
 			Mode:                  entry.Mode,
 			SupportsPromptCaching: entry.SupportsPromptCaching,
 			SupportsServiceTier:   entry.SupportsServiceTier,
+			IsFree:                entry.IsFree,
+			ModelCapabilities:     parseModelCapabilities(rawEntry),
+			ModelDeprecation:      parseModelDeprecation(rawEntry),
 		}
 
 		if entry.InputCostPerToken != nil {
@@ -413,6 +492,18 @@ func (s *PricingService) parsePricingData(body []byte) (map[string]*LiteLLMModel
 		if entry.OutputCostPerImageToken != nil {
 			pricing.OutputCostPerImageToken = *entry.OutputCostPerImageToken
 		}
+		if entry.LongContextInputTokenThreshold != nil && *entry.LongContextInputTokenThreshold > 0 {
+			pricing.LongContextInputTokenThreshold = *entry.LongContextInputTokenThreshold
+			// 缺省倍率按 1 处理，避免只配置一侧倍率时另一侧价格被乘以 0
+			pricing.LongContextInputCostMultiplier = 1
+			pricing.LongContextOutputCostMultiplier = 1
+			if entry.LongContextInputCostMultiplier != nil && *entry.LongContextInputCostMultiplier > 0 {
+				pricing.LongContextInputCostMultiplier = *entry.LongContextInputCostMultiplier
+			}
+			if entry.LongContextOutputCostMultiplier != nil && *entry.LongContextOutputCostMultiplier > 0 {
+				pricing.LongContextOutputCostMultiplier = *entry.LongContextOutputCostMultiplier
+			}
+		}
 
 		result[modelName] = pricing
 	}
@@ -445,16 +536,22 @@ func (s *PricingService) loadPricingData(filePath string) error {
 	hash := sha256.Sum256(data)
 	hashStr := hex.EncodeToString(hash[:])
 
-	s.mu.Lock()
-	s.pricingData = pricingData
-	s.localHash = hashStr
+	loadedAt := time.Now()
+	if info, _ := os.Stat(filePath); info != nil {
+		loadedAt = info.ModTime()
+	}
 
-	info, _ := os.Stat(filePath)
-	if info != nil {
-		s.lastUpdated = info.ModTime()
-	} else {
-		s.lastUpdated = time.Now()
+	s.mu.Lock()
+	// 启动加载时恢复已持久化的模型更新时间，避免重启后全部模型时间被重置
+	if len(s.pricingData) == 0 {
+		for model, updatedAt := range s.loadModelUpdatedTimes() {
+			if _, ok := pricingData[model]; ok {
+				s.modelUpdated[model] = updatedAt
+			}
+		}
 	}
+	s.replacePricingDataLocked(pricingData, loadedAt)
+	s.localHash = hashStr
 	s.mu.Unlock()
 
 	logger.LegacyPrintf("service.pricing", "[Pricing] Loaded %d models from %s", len(pricingData), filePath)
@@ -576,6 +673,13 @@ func (s *PricingService) GetModelPricing(modelName string) *LiteLLMModelPricing
 	return nil
 }
 
+// GetExactModelPricing 仅按模型名精确查找价格（不做任何模糊匹配）
+func (s *PricingService) GetExactModelPricing(modelName string) *LiteLLMModelPricing {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pricingData[strings.ToLower(strings.TrimSpace(modelName))]
+}
+
 func (s *PricingService) buildModelLookupCandidates(modelLower string) []string {
 	// Prefer canonical model name first (this also improves billing compatibility with "models/xxx").
 	candidates := []string{
@@ -607,6 +711,15 @@ func (s *PricingService) buildModelLookupCandidates(modelLower string) []string
 	return out
 }
 
+// canonicalPricingProvider 统一 Gemini 模型的厂商：LiteLLM 将 AI Studio 条目标为 gemini、
+// Vertex 条目标为 vertex_ai-*，网关对两者按同一 gemini 厂商计价与启停
+func canonicalPricingProvider(modelName, provider string) string {
+	if strings.HasPrefix(provider, "vertex_ai") && strings.HasPrefix(lastSegment(strings.ToLower(modelName)), "gemini-") {
+		return PlatformGemini
+	}
+	return provider
+}
+
 func normalizeModelNameForPricing(model string) string {
 	// Common Gemini/VertexAI forms:
 	// - models/gemini-2.0-flash-exp
@@ -881,11 +994,16 @@ func (s *PricingService) GetStatus() map[string]any {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return map[string]any{
+	status := map[string]any{
 		"model_count":  len(s.pricingData),
 		"last_updated": s.lastUpdated,
 		"local_hash":   s.localHash[:min(8, len(s.localHash))],
 	}
+	if len(s.sourceStatus) > 0 {
+		status["sources"] = append([]PricingSourceStatus(nil), s.sourceStatus...)
+		status["conflict_resolution"] = pricingSourceConflictResolution
+	}
+	return status
 }
 
 // ListAllPricing 返回所有价格数据（用于管理后台展示）
@@ -900,16 +1018,41 @@ func (s *PricingService) ListAllPricing() map[string]*LiteLLMModelPricing {
 	return result
 }
 
-// ForceUpdate 强制更新
+// ForceUpdate 强制更新（多价格源时刷新全部启用的源）
 func (s *PricingService) ForceUpdate() error {
 	return s.downloadPricingData()
 }
 
+// DryRunForceUpdate 拉取远程价格数据并与当前数据对比，不写入任何状态
+func (s *PricingService) DryRunForceUpdate() (*PricingDiff, error) {
+	fetched, err := s.fetchRemotePricing()
+	if err != nil {
+		return nil, err
+	}
+	return diffPricingData(s.ListAllPricing(), fetched.data), nil
+}
+
+// PricingImportResult 替换模式导入的结果
+type PricingImportResult struct {
+	Total      int                `json:"total"`      // 导入的模型数
+	Collisions []PricingCollision `json:"collisions"` // 上传文件中重复出现的模型
+}
+
 // ImportPricingData 从上传的JSON数据导入价格（手动上传）
-func (s *PricingService) ImportPricingData(body []byte) (int, error) {
+// 存在任何无效条目时整体中止，并通过 PricingValidationErrors 返回全部问题；
+// 同名模型重复出现时 strict 拒绝导入，否则后出现者生效并在结果中列出。
+func (s *PricingService) ImportPricingData(body []byte, strict bool) (*PricingImportResult, error) {
+	if err := validatePricingUpload(body); err != nil {
+		return nil, err
+	}
+	collisions, err := checkPricingCollisions(body, strict)
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := s.parsePricingData(body)
 	if err != nil {
-		return 0, fmt.Errorf("parse pricing data: %w", err)
+		return nil, fmt.Errorf("parse pricing data: %w", err)
 	}
 
 	// 保存到本地文件
@@ -928,13 +1071,89 @@ func (s *PricingService) ImportPricingData(body []byte) (int, error) {
 
 	// 更新内存数据
 	s.mu.Lock()
-	s.pricingData = data
-	s.lastUpdated = time.Now()
+	s.replacePricingDataLocked(data, time.Now())
 	s.localHash = hashStr
 	s.mu.Unlock()
 
 	logger.LegacyPrintf("service.pricing", "[Pricing] Imported %d models from uploaded file", len(data))
-	return len(data), nil
+	return &PricingImportResult{Total: len(data), Collisions: collisions}, nil
+}
+
+// replacePricingDataLocked 替换价格数据，仅对新增或价格实际变化的模型刷新更新时间并记录价格历史（调用方需持有写锁）
+func (s *PricingService) replacePricingDataLocked(data map[string]*LiteLLMModelPricing, now time.Time) {
+	if s.modelUpdated == nil {
+		s.modelUpdated = make(map[string]time.Time)
+	}
+	updated := make(map[string]time.Time, len(data))
+	for model, pricing := range data {
+		prevTime, hasTime := s.modelUpdated[model]
+		prev, existed := s.pricingData[model]
+		if hasTime && (!existed || len(diffModelPricing(prev, pricing)) == 0) {
+			updated[model] = prevTime
+			continue
+		}
+		updated[model] = now
+	}
+
+	s.pricingData = data
+	s.dataVersion.Add(1)
+	s.modelUpdated = updated
+	s.lastUpdated = now
+	s.saveModelUpdatedTimes(updated)
+	s.recordPricingHistoryLocked(data, updated, now)
+}
+
+// DataVersion 当前价格数据版本，数据被替换（更新/导入/合并）后递增
+func (s *PricingService) DataVersion() uint64 {
+	return s.dataVersion.Load()
+}
+
+// GetModelUpdatedTimes 获取每个模型价格最近一次变化的时间
+func (s *PricingService) GetModelUpdatedTimes() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]time.Time, len(s.modelUpdated))
+	for k, v := range s.modelUpdated {
+		result[k] = v
+	}
+	return result
+}
+
+// loadModelUpdatedTimes 读取持久化的模型更新时间（不存在或损坏时返回空）
+func (s *PricingService) loadModelUpdatedTimes() map[string]time.Time {
+	result := make(map[string]time.Time)
+	if s.cfg == nil || s.cfg.Pricing.DataDir == "" {
+		return result
+	}
+	data, err := os.ReadFile(s.getModelUpdatedFilePath())
+	if err != nil {
+		return result
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to parse model update times: %v", err)
+		return make(map[string]time.Time)
+	}
+	return result
+}
+
+// saveModelUpdatedTimes 持久化模型更新时间
+func (s *PricingService) saveModelUpdatedTimes(updated map[string]time.Time) {
+	if s.cfg == nil || s.cfg.Pricing.DataDir == "" {
+		return
+	}
+	data, err := json.Marshal(updated)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(s.getModelUpdatedFilePath(), data, 0644); err != nil {
+		logger.LegacyPrintf("service.pricing", "[Pricing] Failed to save model update times: %v", err)
+	}
+}
+
+// getModelUpdatedFilePath 获取模型更新时间文件路径
+func (s *PricingService) getModelUpdatedFilePath() string {
+	return filepath.Join(s.cfg.Pricing.DataDir, "model_pricing_updated_at.json")
 }
 
 // getPricingFilePath 获取价格文件路径
@@ -216,3 +216,16 @@ func TestParsePricingData_PreservesServiceTierPriorityFields(t *testing.T) {
 	require.InDelta(t, 0.0000005, pricing.CacheReadInputTokenCostPriority, 1e-12)
 	require.True(t, pricing.SupportsServiceTier)
 }
+
+func TestParsePricingData_VertexGeminiUsesGeminiProvider(t *testing.T) {
+	svc := &PricingService{}
+	data, err := svc.parsePricingData([]byte(`{
+		"vertex_ai/gemini-2.5-pro": {"input_cost_per_token": 0.00000125, "litellm_provider": "vertex_ai-language-models", "mode": "chat"},
+		"vertex_ai/claude-sonnet-4": {"input_cost_per_token": 0.000003, "litellm_provider": "vertex_ai-anthropic_models", "mode": "chat"},
+		"gemini-2.5-flash": {"input_cost_per_token": 0.0000003, "litellm_provider": "gemini", "mode": "chat"}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, PlatformGemini, data["vertex_ai/gemini-2.5-pro"].LiteLLMProvider)
+	require.Equal(t, "vertex_ai-anthropic_models", data["vertex_ai/claude-sonnet-4"].LiteLLMProvider)
+	require.Equal(t, PlatformGemini, data["gemini-2.5-flash"].LiteLLMProvider)
+}
@@ -0,0 +1,51 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPricingData_OnlyBumpsChangedModels(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.ImportPricingData([]byte(`{
+		"a": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"b": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"}
+	}`), false)
+	require.NoError(t, err)
+	first := svc.GetModelUpdatedTimes()
+
+	time.Sleep(5 * time.Millisecond)
+	_, err = svc.ImportPricingData([]byte(`{
+		"a": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"b": {"input_cost_per_token": 3e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"c": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"}
+	}`), false)
+	require.NoError(t, err)
+	second := svc.GetModelUpdatedTimes()
+
+	require.Equal(t, first["a"], second["a"])
+	require.True(t, second["b"].After(first["b"]))
+	require.False(t, second["c"].IsZero())
+}
+
+func TestLoadPricingData_RestoresPersistedModelTimes(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.ImportPricingData([]byte(`{"a": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"}}`), false)
+	require.NoError(t, err)
+	saved := svc.GetModelUpdatedTimes()["a"]
+
+	restarted := NewPricingService(cfg, nil)
+	require.NoError(t, restarted.loadPricingData(restarted.getPricingFilePath()))
+	require.True(t, saved.Equal(restarted.GetModelUpdatedTimes()["a"]))
+}
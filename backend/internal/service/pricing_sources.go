@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// pricingSourceConflictResolution 多价格源冲突处理规则（随状态一起输出）
+const pricingSourceConflictResolution = "models present in several sources take the entry from the source with the highest priority; " +
+	"equal priorities keep the source listed first. A refresh is applied only when every enabled source fetches successfully; " +
+	"otherwise the current pricing data is kept."
+
+// PricingSourceStatus 单个价格源的配置与最近一次拉取状态
+type PricingSourceStatus struct {
+	Name        string     `json:"name"`
+	URL         string     `json:"url,omitempty"`
+	File        string     `json:"file,omitempty"`
+	Priority    int        `json:"priority"`
+	Enabled     bool       `json:"enabled"`
+	LastFetchAt *time.Time `json:"last_fetch_at,omitempty"`
+	LastSuccess *time.Time `json:"last_success_at,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	// ModelCount 最近一次成功拉取的模型条目数
+	ModelCount int `json:"model_count"`
+	// ModelsUsed 合并后采用该源价格的模型数
+	ModelsUsed int `json:"models_used"`
+	// ModelsOverridden 该源提供但被更高优先级源覆盖的模型数
+	ModelsOverridden int `json:"models_overridden"`
+}
+
+// hasPricingSources 是否配置了多价格源（未配置时沿用 remote_url/hash_url 单源逻辑）
+func (s *PricingService) hasPricingSources() bool {
+	return s.cfg != nil && len(s.cfg.Pricing.Sources) > 0
+}
+
+// newPricingSourceStatuses 按配置初始化各价格源状态
+func newPricingSourceStatuses(cfg *config.Config) []PricingSourceStatus {
+	if cfg == nil {
+		return nil
+	}
+	statuses := make([]PricingSourceStatus, 0, len(cfg.Pricing.Sources))
+	for _, src := range cfg.Pricing.Sources {
+		statuses = append(statuses, PricingSourceStatus{
+			Name:     strings.TrimSpace(src.Name),
+			URL:      strings.TrimSpace(src.URL),
+			File:     strings.TrimSpace(src.File),
+			Priority: src.Priority,
+			Enabled:  src.IsEnabled(),
+		})
+	}
+	return statuses
+}
+
+// orderedPricingSources 返回启用的价格源下标，按优先级从高到低排列（相同优先级保持配置顺序）
+func orderedPricingSources(statuses []PricingSourceStatus) []int {
+	order := make([]int, 0, len(statuses))
+	for i := range statuses {
+		if statuses[i].Enabled {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return statuses[order[a]].Priority > statuses[order[b]].Priority
+	})
+	return order
+}
+
+// fetchPricingSource 读取单个价格源的原始条目
+func (s *PricingService) fetchPricingSource(src PricingSourceStatus) (map[string]json.RawMessage, error) {
+	var body []byte
+	var err error
+	if src.File != "" {
+		body, err = os.ReadFile(src.File)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+	} else {
+		remoteURL, urlErr := s.validatePricingURL(src.URL)
+		if urlErr != nil {
+			return nil, urlErr
+		}
+		body, err = s.fetchPricingJSONWithRetry(remoteURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("parse raw JSON: %w", err)
+	}
+	delete(entries, "sample_spec")
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no pricing entries found")
+	}
+	return entries, nil
+}
+
+// fetchPricingSources 拉取全部启用的价格源并按优先级合并。
+// 任一源失败时返回错误且不修改价格数据，但各源的拉取状态照常更新。
+func (s *PricingService) fetchPricingSources() (*remotePricingFetch, error) {
+	s.mu.RLock()
+	statuses := append([]PricingSourceStatus(nil), s.sourceStatus...)
+	s.mu.RUnlock()
+
+	order := orderedPricingSources(statuses)
+	merged := make(map[string]json.RawMessage)
+	owner := make(map[string]int)
+	var failed []string
+	now := time.Now()
+
+	for _, idx := range order {
+		st := &statuses[idx]
+		fetchedAt := now
+		st.LastFetchAt = &fetchedAt
+		st.ModelsUsed, st.ModelsOverridden = 0, 0
+
+		entries, err := s.fetchPricingSource(*st)
+		if err != nil {
+			st.LastError = err.Error()
+			failed = append(failed, fmt.Sprintf("%s: %v", st.Name, err))
+			logger.LegacyPrintf("service.pricing", "[Pricing] Source %s fetch failed: %v", st.Name, err)
+			continue
+		}
+		st.LastError = ""
+		st.LastSuccess = &fetchedAt
+		st.ModelCount = len(entries)
+		for model, raw := range entries {
+			if _, taken := merged[model]; taken {
+				st.ModelsOverridden++
+				continue
+			}
+			merged[model] = raw
+			owner[model] = idx
+		}
+	}
+	for _, idx := range owner {
+		statuses[idx].ModelsUsed++
+	}
+
+	s.mu.Lock()
+	s.sourceStatus = statuses
+	s.mu.Unlock()
+
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("pricing sources failed: %s", strings.Join(failed, "; "))
+	}
+
+	body, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("marshal merged pricing: %w", err)
+	}
+	data, err := s.parsePricingData(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse pricing data: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	logger.LegacyPrintf("service.pricing", "[Pricing] Merged %d models from %d sources", len(data), len(order))
+	return &remotePricingFetch{body: body, data: data, syncHash: hex.EncodeToString(hash[:])}, nil
+}
@@ -0,0 +1,116 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type urlPricingRemoteClient struct {
+	bodies map[string]string
+	errs   map[string]error
+}
+
+func (c *urlPricingRemoteClient) FetchPricingJSON(_ context.Context, url string) ([]byte, error) {
+	if err := c.errs[url]; err != nil {
+		return nil, err
+	}
+	return []byte(c.bodies[url]), nil
+}
+
+func (c *urlPricingRemoteClient) FetchHashText(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func newSourcesTestPricingService(t *testing.T, client PricingRemoteClient, sources ...config.PricingSourceConfig) *PricingService {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	cfg.Pricing.FetchRetryAttempts = 1
+	cfg.Pricing.Sources = sources
+	return NewPricingService(cfg, client)
+}
+
+func pricingSourceStatuses(t *testing.T, svc *PricingService) []PricingSourceStatus {
+	statuses, ok := svc.GetStatus()["sources"].([]PricingSourceStatus)
+	require.True(t, ok)
+	return statuses
+}
+
+func TestPricingSources_HigherPriorityWinsOnConflict(t *testing.T) {
+	overrides := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(overrides, []byte(`{"gpt-4o":{"input_cost_per_token":1e-06,"output_cost_per_token":2e-06}}`), 0o644))
+	client := &urlPricingRemoteClient{bodies: map[string]string{
+		"https://a.example.com/prices.json": `{
+			"gpt-4o":{"input_cost_per_token":2.5e-06,"output_cost_per_token":1e-05},
+			"claude-sonnet-4":{"input_cost_per_token":3e-06,"output_cost_per_token":1.5e-05}
+		}`,
+	}}
+	disabled := false
+	svc := newSourcesTestPricingService(t, client,
+		config.PricingSourceConfig{Name: "litellm", URL: "https://a.example.com/prices.json"},
+		config.PricingSourceConfig{Name: "internal", File: overrides, Priority: 10},
+		config.PricingSourceConfig{Name: "unused", URL: "https://b.example.com/prices.json", Enabled: &disabled},
+	)
+
+	require.NoError(t, svc.ForceUpdate())
+	require.Equal(t, 1e-06, svc.GetExactModelPricing("gpt-4o").InputCostPerToken)
+	require.Equal(t, 3e-06, svc.GetExactModelPricing("claude-sonnet-4").InputCostPerToken)
+
+	status := svc.GetStatus()
+	require.Equal(t, pricingSourceConflictResolution, status["conflict_resolution"])
+	statuses := pricingSourceStatuses(t, svc)
+	require.Len(t, statuses, 3)
+	require.Equal(t, 2, statuses[0].ModelCount)
+	require.Equal(t, 1, statuses[0].ModelsUsed)
+	require.Equal(t, 1, statuses[0].ModelsOverridden)
+	require.Equal(t, 1, statuses[1].ModelsUsed)
+	require.NotNil(t, statuses[1].LastSuccess)
+	require.False(t, statuses[2].Enabled)
+	require.Nil(t, statuses[2].LastFetchAt)
+}
+
+func TestPricingSources_EqualPriorityKeepsFirstListed(t *testing.T) {
+	client := &urlPricingRemoteClient{bodies: map[string]string{
+		"https://a.example.com/prices.json": `{"gpt-4o":{"input_cost_per_token":1e-06,"output_cost_per_token":1e-06}}`,
+		"https://b.example.com/prices.json": `{"gpt-4o":{"input_cost_per_token":9e-06,"output_cost_per_token":9e-06}}`,
+	}}
+	svc := newSourcesTestPricingService(t, client,
+		config.PricingSourceConfig{Name: "a", URL: "https://a.example.com/prices.json"},
+		config.PricingSourceConfig{Name: "b", URL: "https://b.example.com/prices.json"},
+	)
+
+	require.NoError(t, svc.ForceUpdate())
+	require.Equal(t, 1e-06, svc.GetExactModelPricing("gpt-4o").InputCostPerToken)
+}
+
+func TestPricingSources_FailureKeepsCurrentDataAndRecordsError(t *testing.T) {
+	client := &urlPricingRemoteClient{
+		bodies: map[string]string{
+			"https://a.example.com/prices.json": `{"gpt-4o":{"input_cost_per_token":1e-06,"output_cost_per_token":1e-06}}`,
+		},
+		errs: map[string]error{},
+	}
+	svc := newSourcesTestPricingService(t, client,
+		config.PricingSourceConfig{Name: "a", URL: "https://a.example.com/prices.json"},
+		config.PricingSourceConfig{Name: "b", URL: "https://b.example.com/prices.json", Priority: 5},
+	)
+	client.bodies["https://b.example.com/prices.json"] = `{"claude-sonnet-4":{"input_cost_per_token":3e-06,"output_cost_per_token":1.5e-05}}`
+	require.NoError(t, svc.ForceUpdate())
+
+	client.errs["https://b.example.com/prices.json"] = errors.New("connection reset")
+	client.bodies["https://a.example.com/prices.json"] = `{"gpt-4o":{"input_cost_per_token":5e-06,"output_cost_per_token":5e-06}}`
+	err := svc.ForceUpdate()
+	require.ErrorContains(t, err, "b: ")
+	require.Equal(t, 1e-06, svc.GetExactModelPricing("gpt-4o").InputCostPerToken)
+
+	statuses := pricingSourceStatuses(t, svc)
+	require.Contains(t, statuses[1].LastError, "connection reset")
+	require.Empty(t, statuses[0].LastError)
+}
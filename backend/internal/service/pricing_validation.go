@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrPricingDataIncomplete 价格数据被截断（来源返回了不完整的内容），区别于内容完整但格式错误的文件
+var ErrPricingDataIncomplete = errors.New("source returned incomplete data")
+
+// pricingCostFields 需要校验为非负数值的价格字段
+var pricingCostFields = []string{
+	"input_cost_per_token",
+	"input_cost_per_token_priority",
+	"output_cost_per_token",
+	"output_cost_per_token_priority",
+	"cache_creation_input_token_cost",
+	"cache_creation_input_token_cost_above_1hr",
+	"cache_read_input_token_cost",
+	"cache_read_input_token_cost_priority",
+	"output_cost_per_image",
+	"output_cost_per_image_token",
+}
+
+// PricingValidationError 单个模型条目的校验错误
+type PricingValidationError struct {
+	Model   string `json:"model"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// PricingValidationErrors 上传价格数据的全部校验错误（按模型名、字段排序）
+type PricingValidationErrors []PricingValidationError
+
+func (e PricingValidationErrors) Error() string {
+	if len(e) == 0 {
+		return "pricing data validation failed"
+	}
+	first := e[0]
+	msg := fmt.Sprintf("%s: %s", first.Model, first.Message)
+	if first.Field != "" {
+		msg = fmt.Sprintf("%s.%s: %s", first.Model, first.Field, first.Message)
+	}
+	if len(e) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e)-1)
+	}
+	return "pricing data validation failed: " + msg
+}
+
+// validatePricingUpload 校验上传的价格数据，收集所有条目的错误而非遇到第一个即返回。
+// 规则：条目必须是对象；价格字段必须为非负数值；含价格的条目必须提供 litellm_provider；
+// is_free 必须为布尔值，且免费条目的价格字段只能为 0。
+func validatePricingUpload(body []byte) error {
+	var rawData map[string]json.RawMessage
+	if err := json.Unmarshal(body, &rawData); err != nil {
+		return pricingJSONParseError(body, err)
+	}
+
+	errs := make(PricingValidationErrors, 0)
+	for modelName, rawEntry := range rawData {
+		if modelName == "sample_spec" {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(rawEntry, &fields); err != nil {
+			errs = append(errs, PricingValidationError{Model: modelName, Message: "entry must be a JSON object"})
+			continue
+		}
+
+		isFree := false
+		if raw, ok := fields["is_free"]; ok && !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if err := json.Unmarshal(raw, &isFree); err != nil {
+				errs = append(errs, PricingValidationError{Model: modelName, Field: "is_free", Message: "must be a boolean"})
+				continue
+			}
+		}
+
+		hasPrice := false
+		for _, field := range pricingCostFields {
+			raw, ok := fields[field]
+			if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+				continue
+			}
+			var value float64
+			if err := json.Unmarshal(raw, &value); err != nil {
+				errs = append(errs, PricingValidationError{Model: modelName, Field: field, Message: "must be a number"})
+				continue
+			}
+			if value < 0 {
+				errs = append(errs, PricingValidationError{Model: modelName, Field: field, Message: "must be non-negative"})
+				continue
+			}
+			if isFree && value > 0 {
+				errs = append(errs, PricingValidationError{Model: modelName, Field: field, Message: "must be zero for free models"})
+				continue
+			}
+			if field == "input_cost_per_token" || field == "output_cost_per_token" {
+				hasPrice = true
+			}
+		}
+
+		// 无输入/输出价格的条目导入时会被忽略，无需要求厂商字段
+		if !hasPrice {
+			continue
+		}
+		var provider string
+		if raw, ok := fields["litellm_provider"]; ok {
+			if err := json.Unmarshal(raw, &provider); err != nil {
+				errs = append(errs, PricingValidationError{Model: modelName, Field: "litellm_provider", Message: "must be a string"})
+				continue
+			}
+		}
+		if strings.TrimSpace(provider) == "" {
+			errs = append(errs, PricingValidationError{Model: modelName, Field: "litellm_provider", Message: "provider is required"})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].Model != errs[j].Model {
+			return errs[i].Model < errs[j].Model
+		}
+		return errs[i].Field < errs[j].Field
+	})
+	return errs
+}
+
+// pricingJSONParseError 包装价格 JSON 的解析错误：在输入末尾才出错（截断/EOF）时返回 ErrPricingDataIncomplete
+func pricingJSONParseError(body []byte, err error) error {
+	if isTruncatedJSON(body, err) {
+		return fmt.Errorf("%w: JSON ends unexpectedly after %d bytes", ErrPricingDataIncomplete, len(body))
+	}
+	return fmt.Errorf("parse raw JSON: %w", err)
+}
+
+// isTruncatedJSON 判断解析错误是否由内容截断引起：空内容，或 JSON 在结构闭合前结束
+func isTruncatedJSON(body []byte, err error) bool {
+	if err == nil {
+		return false
+	}
+	if len(bytes.TrimSpace(body)) == 0 || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input"
+}
@@ -0,0 +1,85 @@
+//go:build unit
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePricingUpload_CollectsAllErrors(t *testing.T) {
+	body := []byte(`{
+		"sample_spec": {"input_cost_per_token": "ignored"},
+		"good": {"input_cost_per_token": 1e-6, "output_cost_per_token": 2e-6, "litellm_provider": "openai"},
+		"negative": {"input_cost_per_token": -1, "litellm_provider": "openai"},
+		"no-provider": {"input_cost_per_token": 1e-6},
+		"bad-type": {"output_cost_per_token": "cheap", "litellm_provider": "openai"},
+		"not-object": 42
+	}`)
+
+	err := validatePricingUpload(body)
+	var errs PricingValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, []PricingValidationError{
+		{Model: "bad-type", Field: "output_cost_per_token", Message: "must be a number"},
+		{Model: "negative", Field: "input_cost_per_token", Message: "must be non-negative"},
+		{Model: "no-provider", Field: "litellm_provider", Message: "provider is required"},
+		{Model: "not-object", Message: "entry must be a JSON object"},
+	}, []PricingValidationError(errs))
+}
+
+func TestImportPricingData_AbortsOnValidationErrors(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	_, err := svc.ImportPricingData([]byte(`{"gpt-4o":{"input_cost_per_token":1e-6,"litellm_provider":"openai"},"bad":{"input_cost_per_token":-1,"litellm_provider":"openai"}}`), false)
+	require.Error(t, err)
+	require.Empty(t, svc.ListAllPricing())
+}
+
+func TestValidatePricingUpload_FreeModels(t *testing.T) {
+	require.NoError(t, validatePricingUpload([]byte(`{
+		"promo": {"is_free": true},
+		"promo-zero": {"is_free": true, "input_cost_per_token": 0, "litellm_provider": "openai"}
+	}`)))
+
+	err := validatePricingUpload([]byte(`{
+		"bad-flag": {"is_free": "yes"},
+		"priced-free": {"is_free": true, "output_cost_per_token": 1e-6, "litellm_provider": "openai"}
+	}`))
+	var errs PricingValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, []PricingValidationError{
+		{Model: "bad-flag", Field: "is_free", Message: "must be a boolean"},
+		{Model: "priced-free", Field: "output_cost_per_token", Message: "must be zero for free models"},
+	}, []PricingValidationError(errs))
+}
+
+func TestImportPricingData_TruncatedJSONIsIncomplete(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.DataDir = t.TempDir()
+	svc := NewPricingService(cfg, nil)
+
+	complete := `{"gpt-4o":{"input_cost_per_token":1e-6,"output_cost_per_token":2e-6,"litellm_provider":"openai"}}`
+	for _, body := range []string{complete[:40], complete[:len(complete)-1], "", "  \n"} {
+		_, err := svc.ImportPricingData([]byte(body), false)
+		require.ErrorIs(t, err, ErrPricingDataIncomplete, "body=%q", body)
+	}
+	require.Empty(t, svc.ListAllPricing())
+
+	// 内容完整但格式错误不视为截断
+	_, err := svc.ImportPricingData([]byte(`{"gpt-4o": oops}`), false)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrPricingDataIncomplete))
+	_, err = svc.ImportPricingData([]byte(complete+"}"), false)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrPricingDataIncomplete))
+
+	_, err = svc.ImportPricingData([]byte(complete), false)
+	require.NoError(t, err)
+	require.Len(t, svc.ListAllPricing(), 1)
+}
@@ -78,6 +78,7 @@ func (m *sessionWindowMockRepo) ListCRSAccountIDs(context.Context) (map[string]i
 }
 func (m *sessionWindowMockRepo) Update(context.Context, *Account) error { panic("unexpected") }
 func (m *sessionWindowMockRepo) Delete(context.Context, int64) error    { panic("unexpected") }
+func (m *sessionWindowMockRepo) Restore(context.Context, int64) error   { panic("unexpected") }
 func (m *sessionWindowMockRepo) List(context.Context, pagination.PaginationParams) ([]Account, *pagination.PaginationResult, error) {
 	panic("unexpected")
 }
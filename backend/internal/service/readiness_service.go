@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// 就绪检查项名称
+const (
+	ReadinessCheckWarmup   = "warmup"
+	ReadinessCheckDatabase = "database"
+	ReadinessCheckPricing  = "pricing"
+	ReadinessCheckAccounts = "accounts"
+)
+
+const (
+	readinessDBTimeout      = 2 * time.Second
+	readinessAccountTimeout = 3 * time.Second
+	// readinessAccountCacheTTL 可调度账号数的缓存时间，避免探针频繁查询账号表
+	readinessAccountCacheTTL = 10 * time.Second
+	readinessWarmupTimeout   = 2 * time.Minute
+)
+
+// ReadinessCheck 单个就绪检查项的结果
+type ReadinessCheck struct {
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ReadinessReport 就绪检查结果，全部检查项就绪时 Ready 为 true
+type ReadinessReport struct {
+	Ready  bool             `json:"ready"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// ReadinessService 为 Kubernetes readiness 探针判断实例是否可以接收流量：
+// 启动预热已完成、数据库可达、价格数据已加载且至少有一个可调度账号。
+type ReadinessService struct {
+	db             *sql.DB
+	billingService *BillingService
+	accountRepo    AccountRepository
+	warmupService  *WarmupService
+
+	warmedUp atomic.Bool
+
+	accountMu        sync.Mutex
+	accountCheckedAt time.Time
+	accountCount     int
+	accountErr       error
+}
+
+// NewReadinessService 创建就绪检查服务（调用 Start 前视为仍在预热）
+func NewReadinessService(db *sql.DB, billingService *BillingService, accountRepo AccountRepository, warmupService *WarmupService) *ReadinessService {
+	return &ReadinessService{
+		db:             db,
+		billingService: billingService,
+		accountRepo:    accountRepo,
+		warmupService:  warmupService,
+	}
+}
+
+// Start 在后台执行启动预热（tokenizer 与定价缓存，不探测上游账号），完成后才报告就绪
+func (s *ReadinessService) Start() {
+	if s.warmupService == nil {
+		s.warmedUp.Store(true)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), readinessWarmupTimeout)
+		defer cancel()
+		report := s.warmupService.Run(ctx, WarmupOptions{SkipAccounts: true})
+		if !report.Success {
+			// 预热失败只影响首批请求的延迟，不应让实例永久不就绪
+			logger.LegacyPrintf("service.readiness", "Warning: startup warmup finished with errors in %dms", report.DurationMs)
+		}
+		s.warmedUp.Store(true)
+	}()
+}
+
+// WarmedUp 启动预热是否已完成
+func (s *ReadinessService) WarmedUp() bool {
+	return s.warmedUp.Load()
+}
+
+// Check 执行全部就绪检查
+func (s *ReadinessService) Check(ctx context.Context) *ReadinessReport {
+	report := &ReadinessReport{Ready: true}
+	add := func(check ReadinessCheck) {
+		if !check.Ready {
+			report.Ready = false
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	warmup := ReadinessCheck{Name: ReadinessCheckWarmup, Ready: s.WarmedUp()}
+	if !warmup.Ready {
+		warmup.Detail = "startup warmup in progress"
+	}
+	add(warmup)
+	add(s.checkDatabase(ctx))
+	add(s.checkPricing())
+	add(s.checkAccounts(ctx))
+	return report
+}
+
+func (s *ReadinessService) checkDatabase(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: ReadinessCheckDatabase}
+	if s.db == nil {
+		check.Detail = "database not configured"
+		return check
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, readinessDBTimeout)
+	defer cancel()
+	if err := s.db.PingContext(pingCtx); err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	check.Ready = true
+	return check
+}
+
+func (s *ReadinessService) checkPricing() ReadinessCheck {
+	check := ReadinessCheck{Name: ReadinessCheckPricing}
+	if s.billingService == nil || s.billingService.pricingService == nil {
+		check.Detail = "pricing service not configured"
+		return check
+	}
+	count := len(s.billingService.pricingService.ListAllPricing())
+	if count == 0 {
+		check.Detail = "pricing data not loaded"
+		return check
+	}
+	check.Ready = true
+	check.Detail = fmt.Sprintf("%d models", count)
+	return check
+}
+
+func (s *ReadinessService) checkAccounts(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: ReadinessCheckAccounts}
+	count, err := s.schedulableAccountCount(ctx)
+	switch {
+	case err != nil:
+		check.Detail = err.Error()
+	case count == 0:
+		check.Detail = "no schedulable accounts"
+	default:
+		check.Ready = true
+		check.Detail = fmt.Sprintf("%d schedulable accounts", count)
+	}
+	return check
+}
+
+// schedulableAccountCount 返回当前可调度（未限流、未过载、未暂停）的账号数，结果缓存 readinessAccountCacheTTL
+func (s *ReadinessService) schedulableAccountCount(ctx context.Context) (int, error) {
+	if s.accountRepo == nil {
+		return 0, fmt.Errorf("account repository not configured")
+	}
+	s.accountMu.Lock()
+	defer s.accountMu.Unlock()
+	if !s.accountCheckedAt.IsZero() && time.Since(s.accountCheckedAt) < readinessAccountCacheTTL {
+		return s.accountCount, s.accountErr
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, readinessAccountTimeout)
+	defer cancel()
+	accounts, err := s.accountRepo.ListSchedulable(listCtx)
+	count := 0
+	for i := range accounts {
+		if accounts[i].IsSchedulable() {
+			count++
+		}
+	}
+	s.accountCheckedAt, s.accountCount, s.accountErr = time.Now(), count, err
+	return count, err
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadinessTestService(t *testing.T, accounts []Account, pricing map[string]*LiteLLMModelPricing) (*ReadinessService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	svc := NewReadinessService(db, newTestBillingServiceWithPricing(pricing), &healthCheckAccountRepoStub{accounts: accounts}, nil)
+	return svc, mock
+}
+
+func readinessCheckByName(report *ReadinessReport, name string) ReadinessCheck {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	return ReadinessCheck{}
+}
+
+func TestReadinessService_ReadyWhenAllChecksPass(t *testing.T) {
+	pricing := map[string]*LiteLLMModelPricing{"claude-sonnet-4": {InputCostPerToken: 3e-6}}
+	svc, mock := newReadinessTestService(t, []Account{{ID: 1, Status: StatusActive, Schedulable: true}}, pricing)
+	mock.ExpectPing()
+
+	// 启动预热完成前不就绪
+	report := svc.Check(context.Background())
+	require.False(t, report.Ready)
+	require.False(t, readinessCheckByName(report, ReadinessCheckWarmup).Ready)
+
+	svc.Start()
+	require.True(t, svc.WarmedUp())
+	mock.ExpectPing()
+	report = svc.Check(context.Background())
+	require.True(t, report.Ready, "%+v", report.Checks)
+	require.Len(t, report.Checks, 4)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadinessService_NotReadyWithoutHealthyAccountsOrPricing(t *testing.T) {
+	limited := time.Now().Add(time.Hour)
+	svc, mock := newReadinessTestService(t, []Account{{ID: 1, Status: StatusActive, Schedulable: true, RateLimitResetAt: &limited}}, nil)
+	svc.Start()
+	mock.ExpectPing()
+
+	report := svc.Check(context.Background())
+	require.False(t, report.Ready)
+	require.True(t, readinessCheckByName(report, ReadinessCheckDatabase).Ready)
+	require.Equal(t, "no schedulable accounts", readinessCheckByName(report, ReadinessCheckAccounts).Detail)
+	require.Equal(t, "pricing data not loaded", readinessCheckByName(report, ReadinessCheckPricing).Detail)
+}
+
+func TestReadinessService_NotReadyWhenDatabaseUnreachable(t *testing.T) {
+	pricing := map[string]*LiteLLMModelPricing{"claude-sonnet-4": {InputCostPerToken: 3e-6}}
+	svc, mock := newReadinessTestService(t, []Account{{ID: 1, Status: StatusActive, Schedulable: true}}, pricing)
+	svc.Start()
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	report := svc.Check(context.Background())
+	require.False(t, report.Ready)
+	database := readinessCheckByName(report, ReadinessCheckDatabase)
+	require.False(t, database.Ready)
+	require.Contains(t, database.Detail, "connection refused")
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/dgraph-io/ristretto"
+	"github.com/tidwall/gjson"
+)
+
+// ResponseCacheHeader 标记响应是否来自响应缓存（HIT / MISS）
+const ResponseCacheHeader = "X-Sub2API-Cache"
+
+// ResponseCacheEntry 已缓存的成功非流式响应，以及命中时按原始用量计费所需的结果
+type ResponseCacheEntry struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	// Result 原始请求的转发结果（用量/模型），命中时按 hit_billing_rate 折算计费
+	Result ForwardResult
+	// OpenAIResult OpenAI 接口（Chat Completions / Responses）缓存的转发结果，Anthropic Messages 条目为 nil
+	OpenAIResult *OpenAIForwardResult
+	// Account 原始请求使用的账号，命中时用量记录归属该账号（不计入账号成本）
+	Account  *Account
+	StoredAt time.Time
+}
+
+// ResponseCacheService 确定性请求（temperature 为 0 或未设置、非流式）的进程内响应缓存。
+// 相同用户、分组、入站端点下规范化请求体相同的请求在 TTL 内直接返回缓存响应，不再转发上游。
+// 接入 Anthropic Messages、OpenAI Chat Completions 与 Responses 接口。
+type ResponseCacheService struct {
+	cache        *ristretto.Cache
+	ttl          time.Duration
+	maxBodyBytes int
+}
+
+// NewResponseCacheService 创建响应缓存服务；未启用时返回的服务 Enabled() 为 false
+func NewResponseCacheService(cfg *config.Config) *ResponseCacheService {
+	s := &ResponseCacheService{}
+	if cfg == nil || !cfg.Gateway.ResponseCache.Enabled {
+		return s
+	}
+	rc := cfg.Gateway.ResponseCache
+	if rc.TTLSeconds <= 0 || rc.MaxEntries <= 0 {
+		return s
+	}
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters:        int64(rc.MaxEntries) * 10,
+		MaxCost:            int64(rc.MaxEntries),
+		BufferItems:        64,
+		IgnoreInternalCost: true,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to init response cache: %v", err)
+		return s
+	}
+	s.cache = cache
+	s.ttl = time.Duration(rc.TTLSeconds) * time.Second
+	s.maxBodyBytes = rc.MaxBodyBytes
+	return s
+}
+
+// Enabled 是否启用响应缓存
+func (s *ResponseCacheService) Enabled() bool {
+	return s != nil && s.cache != nil
+}
+
+// MaxBodyBytes 可缓存响应体的最大长度
+func (s *ResponseCacheService) MaxBodyBytes() int {
+	if s == nil {
+		return 0
+	}
+	return s.maxBodyBytes
+}
+
+// Get 查找缓存响应；未命中或已过期时返回 nil
+func (s *ResponseCacheService) Get(key string) *ResponseCacheEntry {
+	if !s.Enabled() || key == "" {
+		return nil
+	}
+	v, ok := s.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	entry, _ := v.(*ResponseCacheEntry)
+	return entry
+}
+
+// Put 缓存成功的响应；非 200 或超过长度上限的响应不缓存
+func (s *ResponseCacheService) Put(key string, entry *ResponseCacheEntry) {
+	if !s.Enabled() || key == "" || entry == nil || entry.StatusCode != 200 || len(entry.Body) == 0 {
+		return
+	}
+	if s.maxBodyBytes > 0 && len(entry.Body) > s.maxBodyBytes {
+		return
+	}
+	if entry.StoredAt.IsZero() {
+		entry.StoredAt = time.Now()
+	}
+	_ = s.cache.SetWithTTL(key, entry, 1, s.ttl)
+}
+
+// ResponseCacheKey 计算请求的缓存键；流式、temperature 非 0 或请求体非法时不可缓存（返回 false）。
+// 请求体规范化为按字段名排序的 JSON，并忽略 metadata（仅含客户端会话标识，不影响输出）。
+func ResponseCacheKey(userID int64, groupID *int64, endpoint string, body []byte) (string, bool) {
+	if len(body) == 0 || !gjson.ValidBytes(body) {
+		return "", false
+	}
+	if gjson.GetBytes(body, "stream").Bool() {
+		return "", false
+	}
+	if temperature := gjson.GetBytes(body, "temperature"); temperature.Exists() && temperature.Type != gjson.Null && temperature.Float() != 0 {
+		return "", false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var payload map[string]any
+	if err := decoder.Decode(&payload); err != nil || payload == nil {
+		return "", false
+	}
+	delete(payload, "metadata")
+	normalized, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+
+	var gid int64
+	if groupID != nil {
+		gid = *groupID
+	}
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(userID, 10) + ":" + strconv.FormatInt(gid, 10) + ":" + endpoint + ":"))
+	h.Write([]byte(gjson.GetBytes(body, "model").String() + ":"))
+	h.Write(normalized)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// applyResponseCacheHitCost 命中响应缓存时按 rate 折算向用户计费的费用；未访问上游，上游成本记为 0
+func applyResponseCacheHitCost(cost *CostBreakdown, rate float64) {
+	if cost == nil {
+		return
+	}
+	if rate < 0 {
+		rate = 0
+	}
+	cost.InputCost *= rate
+	cost.OutputCost *= rate
+	cost.ImageOutputCost *= rate
+	cost.CacheCreationCost *= rate
+	cost.CacheReadCost *= rate
+	cost.CacheReadFullCost *= rate
+	cost.MinChargeTopUp *= rate
+	cost.TotalCost *= rate
+	cost.ActualCost *= rate
+	cost.BaseCost = 0
+	cost.ListCost = 0
+	cost.baseCostSet = true
+}
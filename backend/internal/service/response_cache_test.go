@@ -0,0 +1,75 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheKey_DeterministicRequestsOnly(t *testing.T) {
+	groupID := int64(3)
+	base := []byte(`{"model":"claude-sonnet-4","max_tokens":100,"messages":[{"role":"user","content":"hi"}],"metadata":{"user_id":"a"}}`)
+
+	key, ok := ResponseCacheKey(1, &groupID, "/v1/messages", base)
+	require.True(t, ok)
+
+	// 字段顺序与 metadata 不影响缓存键
+	reordered := []byte(`{"messages":[{"role":"user","content":"hi"}],"max_tokens":100,"model":"claude-sonnet-4","metadata":{"user_id":"b"}}`)
+	key2, ok := ResponseCacheKey(1, &groupID, "/v1/messages", reordered)
+	require.True(t, ok)
+	require.Equal(t, key, key2)
+
+	// 用户、分组隔离
+	other, ok := ResponseCacheKey(2, &groupID, "/v1/messages", base)
+	require.True(t, ok)
+	require.NotEqual(t, key, other)
+	other, ok = ResponseCacheKey(1, nil, "/v1/messages", base)
+	require.True(t, ok)
+	require.NotEqual(t, key, other)
+
+	_, ok = ResponseCacheKey(1, &groupID, "/v1/messages", []byte(`{"model":"m","temperature":0,"stream":false}`))
+	require.True(t, ok)
+	_, ok = ResponseCacheKey(1, &groupID, "/v1/messages", []byte(`{"model":"m","stream":true}`))
+	require.False(t, ok)
+	_, ok = ResponseCacheKey(1, &groupID, "/v1/messages", []byte(`{"model":"m","temperature":0.7}`))
+	require.False(t, ok)
+	_, ok = ResponseCacheKey(1, &groupID, "/v1/messages", []byte(`not json`))
+	require.False(t, ok)
+}
+
+func TestResponseCacheService_PutGet(t *testing.T) {
+	require.False(t, NewResponseCacheService(&config.Config{}).Enabled())
+
+	cfg := &config.Config{}
+	cfg.Gateway.ResponseCache = config.GatewayResponseCacheConfig{Enabled: true, TTLSeconds: 60, MaxEntries: 10, MaxBodyBytes: 8}
+	svc := NewResponseCacheService(cfg)
+	require.True(t, svc.Enabled())
+
+	svc.Put("ok", &ResponseCacheEntry{StatusCode: 200, Body: []byte("{}"), Account: &Account{ID: 1}})
+	svc.Put("error", &ResponseCacheEntry{StatusCode: 500, Body: []byte("{}")})
+	svc.Put("large", &ResponseCacheEntry{StatusCode: 200, Body: []byte("0123456789")})
+	svc.cache.Wait()
+
+	entry := svc.Get("ok")
+	require.NotNil(t, entry)
+	require.Equal(t, int64(1), entry.Account.ID)
+	require.False(t, entry.StoredAt.IsZero())
+	require.Nil(t, svc.Get("error"))
+	require.Nil(t, svc.Get("large"))
+}
+
+func TestApplyResponseCacheHitCost(t *testing.T) {
+	cost := &CostBreakdown{InputCost: 1, OutputCost: 2, TotalCost: 3, ActualCost: 6, BaseCost: 2.5, baseCostSet: true}
+	applyResponseCacheHitCost(cost, 0.5)
+	require.InDelta(t, 0.5, cost.InputCost, 1e-12)
+	require.InDelta(t, 1.5, cost.TotalCost, 1e-12)
+	require.InDelta(t, 3, cost.ActualCost, 1e-12)
+	require.Zero(t, cost.UpstreamCost())
+
+	cost = &CostBreakdown{TotalCost: 3, ActualCost: 3}
+	applyResponseCacheHitCost(cost, 0)
+	require.Zero(t, cost.ActualCost)
+}
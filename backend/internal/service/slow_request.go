@@ -0,0 +1,172 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const defaultSlowRequestBufferSize = 200
+
+// SlowRequest 一条慢请求记录
+type SlowRequest struct {
+	RequestID           string    `json:"request_id"`
+	Model               string    `json:"model"`
+	Provider            string    `json:"provider"`
+	AccountID           int64     `json:"account_id"`
+	APIKeyID            int64     `json:"api_key_id"`
+	UserID              int64     `json:"user_id"`
+	Stream              bool      `json:"stream"`
+	DurationMs          int       `json:"duration_ms"`
+	FirstTokenMs        *int      `json:"first_token_ms,omitempty"`
+	ThresholdMs         int       `json:"threshold_ms"`
+	InputTokens         int       `json:"input_tokens"`
+	OutputTokens        int       `json:"output_tokens"`
+	CacheCreationTokens int       `json:"cache_creation_tokens"`
+	CacheReadTokens     int       `json:"cache_read_tokens"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// slowRequestModelThreshold 按模型覆盖的阈值
+type slowRequestModelThreshold struct {
+	pattern     string
+	thresholdMs int
+}
+
+// SlowRequestLogger 代理请求耗时超过阈值时记录 WARN 日志，并在内存环形缓冲中保留最近的记录供管理端查询
+type SlowRequestLogger struct {
+	thresholdMs     int
+	modelThresholds []slowRequestModelThreshold
+
+	mu    sync.Mutex
+	buf   []SlowRequest
+	next  int
+	count int
+}
+
+// NewSlowRequestLogger 创建慢请求日志；未配置全局阈值且无按模型阈值时不记录
+func NewSlowRequestLogger(cfg *config.Config) *SlowRequestLogger {
+	s := &SlowRequestLogger{}
+	size := defaultSlowRequestBufferSize
+	if cfg != nil {
+		s.thresholdMs = cfg.SlowRequest.ThresholdMs
+		for pattern, ms := range cfg.SlowRequest.ModelThresholdsMs {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if pattern == "" {
+				continue
+			}
+			s.modelThresholds = append(s.modelThresholds, slowRequestModelThreshold{pattern: pattern, thresholdMs: ms})
+		}
+		if cfg.SlowRequest.BufferSize > 0 {
+			size = cfg.SlowRequest.BufferSize
+		}
+	}
+	// 精确匹配优先，其次按通配前缀由长到短
+	sort.Slice(s.modelThresholds, func(i, j int) bool {
+		a, b := s.modelThresholds[i].pattern, s.modelThresholds[j].pattern
+		aw, bw := strings.HasSuffix(a, "*"), strings.HasSuffix(b, "*")
+		if aw != bw {
+			return !aw
+		}
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	s.buf = make([]SlowRequest, size)
+	return s
+}
+
+// ThresholdFor 返回模型适用的阈值（毫秒），<= 0 表示不记录
+func (s *SlowRequestLogger) ThresholdFor(model string) int {
+	if s == nil {
+		return 0
+	}
+	model = strings.ToLower(model)
+	for _, t := range s.modelThresholds {
+		if matchModelPattern(t.pattern, model) {
+			return t.thresholdMs
+		}
+	}
+	return s.thresholdMs
+}
+
+// Observe 检查一次已完成请求的耗时，超过阈值时记录。nil-safe。
+func (s *SlowRequestLogger) Observe(usageLog *UsageLog, provider string) {
+	if s == nil || usageLog == nil || usageLog.DurationMs == nil {
+		return
+	}
+	threshold := s.ThresholdFor(usageLog.Model)
+	duration := *usageLog.DurationMs
+	if threshold <= 0 || duration <= threshold {
+		return
+	}
+
+	entry := SlowRequest{
+		RequestID:           usageLog.RequestID,
+		Model:               usageLog.Model,
+		Provider:            provider,
+		AccountID:           usageLog.AccountID,
+		APIKeyID:            usageLog.APIKeyID,
+		UserID:              usageLog.UserID,
+		Stream:              usageLog.Stream,
+		DurationMs:          duration,
+		FirstTokenMs:        usageLog.FirstTokenMs,
+		ThresholdMs:         threshold,
+		InputTokens:         usageLog.InputTokens,
+		OutputTokens:        usageLog.OutputTokens,
+		CacheCreationTokens: usageLog.CacheCreationTokens,
+		CacheReadTokens:     usageLog.CacheReadTokens,
+		CreatedAt:           time.Now().UTC(),
+	}
+	s.record(entry)
+
+	logger.L().Warn("slow request",
+		zap.String("request_id", entry.RequestID),
+		zap.String("model", entry.Model),
+		zap.String("provider", entry.Provider),
+		zap.Int64("account_id", entry.AccountID),
+		zap.Int64("api_key_id", entry.APIKeyID),
+		zap.Bool("stream", entry.Stream),
+		zap.Int("duration_ms", entry.DurationMs),
+		zap.Int("threshold_ms", entry.ThresholdMs),
+		zap.Int("input_tokens", entry.InputTokens),
+		zap.Int("output_tokens", entry.OutputTokens),
+		zap.Int("cache_creation_tokens", entry.CacheCreationTokens),
+		zap.Int("cache_read_tokens", entry.CacheReadTokens),
+	)
+}
+
+func (s *SlowRequestLogger) record(entry SlowRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf[s.next] = entry
+	s.next = (s.next + 1) % len(s.buf)
+	if s.count < len(s.buf) {
+		s.count++
+	}
+}
+
+// Recent 返回最近的慢请求（最新在前）；limit <= 0 时返回全部
+func (s *SlowRequestLogger) Recent(limit int) []SlowRequest {
+	if s == nil {
+		return []SlowRequest{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.count
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	out := make([]SlowRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		idx := (s.next - i + len(s.buf)) % len(s.buf)
+		out = append(out, s.buf[idx])
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newSlowRequestTestLogger(threshold, bufferSize int, models map[string]int) *SlowRequestLogger {
+	cfg := &config.Config{}
+	cfg.SlowRequest.ThresholdMs = threshold
+	cfg.SlowRequest.BufferSize = bufferSize
+	cfg.SlowRequest.ModelThresholdsMs = models
+	return NewSlowRequestLogger(cfg)
+}
+
+func slowRequestUsageLog(model string, durationMs int) *UsageLog {
+	return &UsageLog{RequestID: model, Model: model, AccountID: 3, InputTokens: 10, OutputTokens: 20, DurationMs: &durationMs}
+}
+
+func TestSlowRequestLogger_ThresholdFor(t *testing.T) {
+	l := newSlowRequestTestLogger(1000, 10, map[string]int{
+		"o1*":           60000,
+		"o1-mini":       5000,
+		"claude-opus-*": 0,
+	})
+
+	require.Equal(t, 1000, l.ThresholdFor("gpt-4o"))
+	require.Equal(t, 60000, l.ThresholdFor("o1-preview"))
+	require.Equal(t, 5000, l.ThresholdFor("O1-Mini"))
+	require.Equal(t, 0, l.ThresholdFor("claude-opus-4"))
+	require.Equal(t, 0, (*SlowRequestLogger)(nil).ThresholdFor("gpt-4o"))
+}
+
+func TestSlowRequestLogger_ObserveRecordsOnlySlowRequests(t *testing.T) {
+	l := newSlowRequestTestLogger(1000, 10, map[string]int{"o1*": 60000, "claude-opus-*": 0})
+
+	l.Observe(slowRequestUsageLog("gpt-4o", 1000), "openai")
+	l.Observe(slowRequestUsageLog("o1", 30000), "openai")
+	l.Observe(slowRequestUsageLog("claude-opus-4", 999999), "anthropic")
+	l.Observe(&UsageLog{Model: "gpt-4o"}, "openai")
+	l.Observe(slowRequestUsageLog("gpt-4o-mini", 1500), "openai")
+
+	recent := l.Recent(0)
+	require.Len(t, recent, 1)
+	require.Equal(t, "gpt-4o-mini", recent[0].Model)
+	require.Equal(t, "openai", recent[0].Provider)
+	require.Equal(t, int64(3), recent[0].AccountID)
+	require.Equal(t, 1500, recent[0].DurationMs)
+	require.Equal(t, 1000, recent[0].ThresholdMs)
+	require.Equal(t, 20, recent[0].OutputTokens)
+}
+
+func TestSlowRequestLogger_RecentIsBoundedNewestFirst(t *testing.T) {
+	l := newSlowRequestTestLogger(10, 3, nil)
+	for _, model := range []string{"a", "b", "c", "d"} {
+		l.Observe(slowRequestUsageLog(model, 100), "openai")
+	}
+
+	models := func(items []SlowRequest) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Model)
+		}
+		return out
+	}
+	require.Equal(t, []string{"d", "c", "b"}, models(l.Recent(0)))
+	require.Equal(t, []string{"d", "c"}, models(l.Recent(2)))
+	require.Empty(t, newSlowRequestTestLogger(0, 0, nil).Recent(10))
+}
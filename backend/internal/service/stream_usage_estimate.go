@@ -0,0 +1,207 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/apicompat"
+	"github.com/tidwall/gjson"
+)
+
+// textTokenCounter 增量统计文本字符数，按 estimateTokensForText 的启发式规则估算 token 数，
+// 无需缓存整段输出即可在流式场景中累计。
+type textTokenCounter struct {
+	runes int
+	ascii int
+}
+
+func (c *textTokenCounter) add(s string) {
+	for _, r := range s {
+		c.runes++
+		if r <= 0x7f {
+			c.ascii++
+		}
+	}
+}
+
+func (c *textTokenCounter) tokens() int {
+	if c.runes == 0 {
+		return 0
+	}
+	if float64(c.ascii)/float64(c.runes) >= 0.8 {
+		// Roughly 4 chars per token for English-like text.
+		return (c.runes + 3) / 4
+	}
+	// For CJK-heavy text, approximate 1 rune per token.
+	return c.runes
+}
+
+// streamEstimateMaxTextBytes 流式估算缓存输出文本的上限，超出后只按字符启发式计数
+const streamEstimateMaxTextBytes = 1 << 20
+
+// tokenCountFunc 计算文本 token 数（通常绑定请求模型对应的 tokenizer）
+type tokenCountFunc func(text string) int
+
+// streamUsageEstimator 累计流式响应中已下发给客户端的内容，
+// 用于上游未返回 usage 或流中途中断时估算输出 token 数。
+type streamUsageEstimator struct {
+	text     strings.Builder
+	overflow bool
+	chars    textTokenCounter
+}
+
+func (e *streamUsageEstimator) add(s string) {
+	if s == "" {
+		return
+	}
+	e.chars.add(s)
+	if e.overflow {
+		return
+	}
+	if e.text.Len()+len(s) > streamEstimateMaxTextBytes {
+		e.overflow = true
+		return
+	}
+	e.text.WriteString(s)
+}
+
+// observeCCChunk 累计 Chat Completions 流式 chunk 中 choices[].delta 的输出内容
+func (e *streamUsageEstimator) observeCCChunk(payload string) {
+	gjson.Get(payload, "choices").ForEach(func(_, choice gjson.Result) bool {
+		delta := choice.Get("delta")
+		for _, field := range []string{"content", "reasoning_content", "reasoning", "refusal"} {
+			if v := delta.Get(field); v.Type == gjson.String {
+				e.add(v.String())
+			}
+		}
+		delta.Get("tool_calls").ForEach(func(_, call gjson.Result) bool {
+			e.add(call.Get("function.name").String())
+			e.add(call.Get("function.arguments").String())
+			return true
+		})
+		return true
+	})
+}
+
+// observeAnthropicEvent 累计 Anthropic 流式 content_block_delta 中的输出内容
+func (e *streamUsageEstimator) observeAnthropicEvent(event *apicompat.AnthropicStreamEvent) {
+	if event == nil || event.Type != "content_block_delta" || event.Delta == nil {
+		return
+	}
+	e.add(event.Delta.Text)
+	e.add(event.Delta.PartialJSON)
+	e.add(event.Delta.Thinking)
+}
+
+// outputTokens 估算已下发内容的 token 数；count 为 nil 或文本超出缓存上限时使用字符启发式估算
+func (e *streamUsageEstimator) outputTokens(count tokenCountFunc) int {
+	if count == nil || e.overflow {
+		return e.chars.tokens()
+	}
+	return count(e.text.String())
+}
+
+// ccRequestPromptText 提取 Chat Completions 请求中计入输入 token 的文本（messages 文本与 tools 定义）
+func ccRequestPromptText(body []byte) string {
+	var b strings.Builder
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(s)
+	}
+	gjson.GetBytes(body, "messages").ForEach(func(_, msg gjson.Result) bool {
+		content := msg.Get("content")
+		if content.Type == gjson.String {
+			write(content.String())
+		} else {
+			content.ForEach(func(_, part gjson.Result) bool {
+				write(part.Get("text").String())
+				return true
+			})
+		}
+		msg.Get("tool_calls").ForEach(func(_, call gjson.Result) bool {
+			write(call.Get("function.arguments").String())
+			return true
+		})
+		return true
+	})
+	if tools := gjson.GetBytes(body, "tools"); tools.Exists() {
+		write(tools.Raw)
+	}
+	return b.String()
+}
+
+// applyCCStreamUsageEstimate 上游未在流中返回 usage 时，用估算值补齐输入/输出 token；
+// 返回了 usage 但缺少 prompt_tokens 时仅补齐输入。返回是否使用了估算。
+func applyCCStreamUsageEstimate(usage *OpenAIUsage, usageSeen bool, requestBody []byte, est *streamUsageEstimator, count tokenCountFunc) bool {
+	if usage == nil || est == nil {
+		return false
+	}
+	estimated := fillCCInputTokenEstimate(usage, requestBody, count)
+	if !usageSeen {
+		usage.OutputTokens = est.outputTokens(count)
+		estimated = estimated || usage.OutputTokens > 0
+	}
+	return estimated
+}
+
+// applyCCResponseUsageEstimate 非流式 Chat Completions 响应缺少 usage（或缺少 prompt_tokens）时补齐估算值，返回是否使用了估算
+func applyCCResponseUsageEstimate(usage *OpenAIUsage, usageSeen bool, requestBody, respBody []byte, count tokenCountFunc) bool {
+	if usage == nil {
+		return false
+	}
+	estimated := fillCCInputTokenEstimate(usage, requestBody, count)
+	if !usageSeen {
+		var est streamUsageEstimator
+		gjson.GetBytes(respBody, "choices").ForEach(func(_, choice gjson.Result) bool {
+			msg := choice.Get("message")
+			for _, field := range []string{"content", "reasoning_content", "refusal"} {
+				if v := msg.Get(field); v.Type == gjson.String {
+					est.add(v.String())
+				}
+			}
+			msg.Get("tool_calls").ForEach(func(_, call gjson.Result) bool {
+				est.add(call.Get("function.name").String())
+				est.add(call.Get("function.arguments").String())
+				return true
+			})
+			return true
+		})
+		usage.OutputTokens = est.outputTokens(count)
+		estimated = estimated || usage.OutputTokens > 0
+	}
+	return estimated
+}
+
+// fillCCInputTokenEstimate 上游未给出输入 token 时按请求内容估算
+func fillCCInputTokenEstimate(usage *OpenAIUsage, requestBody []byte, count tokenCountFunc) bool {
+	if usage.InputTokens > 0 || usage.CacheReadInputTokens > 0 {
+		return false
+	}
+	usage.InputTokens = countOrEstimateTokens(count, ccRequestPromptText(requestBody))
+	return usage.InputTokens > 0
+}
+
+// applyAnthropicStreamOutputEstimate 流在 message_delta 之前中断时，用已下发内容估算输出 token，返回是否使用了估算。
+// 输入 token 由 message_start 给出，保持上游值不变。
+func applyAnthropicStreamOutputEstimate(usage *ClaudeUsage, finalUsageSeen bool, est *streamUsageEstimator, count tokenCountFunc) bool {
+	if usage == nil || finalUsageSeen || est == nil {
+		return false
+	}
+	estimated := est.outputTokens(count)
+	if estimated <= usage.OutputTokens {
+		return false
+	}
+	usage.OutputTokens = estimated
+	return true
+}
+
+func countOrEstimateTokens(count tokenCountFunc, text string) int {
+	if count == nil {
+		return estimateTokensForText(text)
+	}
+	return count(text)
+}
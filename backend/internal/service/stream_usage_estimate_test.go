@@ -0,0 +1,79 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/apicompat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamUsageEstimator_ObserveCCChunk(t *testing.T) {
+	var est streamUsageEstimator
+	est.observeCCChunk(`{"choices":[{"delta":{"role":"assistant","content":"abcd"}}]}`)
+	est.observeCCChunk(`{"choices":[{"delta":{"reasoning_content":"efgh"}}]}`)
+	est.observeCCChunk(`{"choices":[{"delta":{"tool_calls":[{"index":0,"function":{"name":"f","arguments":"{\"a\":1}"}}]}}]}`)
+	est.observeCCChunk(`{"choices":[{"delta":{"content":null},"finish_reason":"stop"}]}`)
+	// 4 + 4 + 1 + 7 = 16 个字符 → 4 token
+	require.Equal(t, 4, est.outputTokens(nil))
+}
+
+func TestStreamUsageEstimator_ObserveAnthropicEvent(t *testing.T) {
+	var est streamUsageEstimator
+	est.observeAnthropicEvent(&apicompat.AnthropicStreamEvent{Type: "content_block_delta", Delta: &apicompat.AnthropicDelta{Text: "你好世界"}})
+	est.observeAnthropicEvent(&apicompat.AnthropicStreamEvent{Type: "message_delta", Delta: &apicompat.AnthropicDelta{Text: "ignored"}})
+	est.observeAnthropicEvent(nil)
+	// CJK 文本按 1 字 1 token 估算
+	require.Equal(t, 4, est.outputTokens(nil))
+}
+
+func TestApplyCCStreamUsageEstimate_KeepsUpstreamUsage(t *testing.T) {
+	var est streamUsageEstimator
+	est.observeCCChunk(`{"choices":[{"delta":{"content":"abcdefgh"}}]}`)
+
+	usage := OpenAIUsage{InputTokens: 10, OutputTokens: 0}
+	require.False(t, applyCCStreamUsageEstimate(&usage, true, []byte(`{"messages":[]}`), &est, nil))
+	require.Equal(t, OpenAIUsage{InputTokens: 10}, usage)
+
+	usage = OpenAIUsage{}
+	body := []byte(`{"messages":[{"role":"user","content":[{"type":"text","text":"abcd"}]}],"tools":[]}`)
+	require.True(t, applyCCStreamUsageEstimate(&usage, false, body, &est, nil))
+	// "abcd\n[]" = 7 个字符 → 2 token
+	require.Equal(t, 2, usage.InputTokens)
+	require.Equal(t, 2, usage.OutputTokens)
+}
+
+func TestApplyAnthropicStreamOutputEstimate(t *testing.T) {
+	var est streamUsageEstimator
+	est.observeAnthropicEvent(&apicompat.AnthropicStreamEvent{Type: "content_block_delta", Delta: &apicompat.AnthropicDelta{Text: "abcdefghijkl"}})
+
+	usage := ClaudeUsage{InputTokens: 5, OutputTokens: 1}
+	require.False(t, applyAnthropicStreamOutputEstimate(&usage, true, &est, nil))
+	require.Equal(t, 1, usage.OutputTokens)
+
+	require.True(t, applyAnthropicStreamOutputEstimate(&usage, false, &est, nil))
+	require.Equal(t, 3, usage.OutputTokens)
+	require.Equal(t, 5, usage.InputTokens)
+}
+
+func TestApplyCCResponseUsageEstimate(t *testing.T) {
+	body := []byte(`{"messages":[{"role":"user","content":"abcdefgh"}]}`)
+	resp := []byte(`{"choices":[{"message":{"role":"assistant","content":"abcd"}}]}`)
+	count := func(text string) int { return len(text) }
+
+	// 上游返回 completion_tokens 但缺少 prompt_tokens：仅补齐输入
+	usage := OpenAIUsage{OutputTokens: 3}
+	require.True(t, applyCCResponseUsageEstimate(&usage, true, body, resp, count))
+	require.Equal(t, OpenAIUsage{InputTokens: 8, OutputTokens: 3}, usage)
+
+	// 完全没有 usage：输入输出均估算
+	usage = OpenAIUsage{}
+	require.True(t, applyCCResponseUsageEstimate(&usage, false, body, resp, count))
+	require.Equal(t, OpenAIUsage{InputTokens: 8, OutputTokens: 4}, usage)
+
+	// 完整 usage 保持不变
+	usage = OpenAIUsage{InputTokens: 5, OutputTokens: 2}
+	require.False(t, applyCCResponseUsageEstimate(&usage, true, body, resp, count))
+	require.Equal(t, OpenAIUsage{InputTokens: 5, OutputTokens: 2}, usage)
+}
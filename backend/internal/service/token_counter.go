@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+	"github.com/pkoukk/tiktoken-go"
+	tiktokenloader "github.com/pkoukk/tiktoken-go-loader"
+)
+
+// 支持的 tokenizer
+const (
+	TokenizerCL100K    = "cl100k_base"
+	TokenizerO200K     = "o200k_base"
+	TokenizerClaude    = "claude"    // 基于 cl100k 的近似估算（Anthropic 未公开 tokenizer）
+	TokenizerHeuristic = "heuristic" // tokenizer 加载失败时的字符启发式估算
+)
+
+// claudeTokenRatio Claude 模型 token 数相对 cl100k 的经验放大系数
+const claudeTokenRatio = 1.1
+
+func init() {
+	// 使用内嵌的 BPE 词表，避免运行时从公网下载
+	tiktoken.SetBpeLoader(tiktokenloader.NewOfflineLoader())
+}
+
+// TokenCounter 按模型选择 tokenizer 计算文本 token 数，已加载的 tokenizer 进程内复用
+type TokenCounter struct {
+	mu       sync.Mutex
+	encoders map[string]*tiktoken.Tiktoken
+	failed   map[string]bool // 加载失败的编码不再重试，直接回落到启发式估算
+}
+
+// NewTokenCounter 创建 token 计数服务
+func NewTokenCounter() *TokenCounter {
+	return &TokenCounter{
+		encoders: make(map[string]*tiktoken.Tiktoken),
+		failed:   make(map[string]bool),
+	}
+}
+
+// TokenizerForModel 返回模型对应的 tokenizer 名称
+func TokenizerForModel(model string) string {
+	m := strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	switch {
+	case strings.HasPrefix(m, "claude"):
+		return TokenizerClaude
+	case strings.HasPrefix(m, "gpt-4o"), strings.HasPrefix(m, "chatgpt-4o"),
+		strings.HasPrefix(m, "gpt-4.1"), strings.HasPrefix(m, "gpt-4.5"),
+		strings.HasPrefix(m, "gpt-5"), strings.HasPrefix(m, "codex"),
+		strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return TokenizerO200K
+	default:
+		return TokenizerCL100K
+	}
+}
+
+// CountTokens 返回 text 在 model 对应 tokenizer 下的 token 数及实际使用的 tokenizer
+func (c *TokenCounter) CountTokens(model, text string) (int, string) {
+	if text == "" {
+		return 0, TokenizerForModel(model)
+	}
+	tokenizer := TokenizerForModel(model)
+	encodingName := tokenizer
+	if tokenizer == TokenizerClaude {
+		encodingName = TokenizerCL100K
+	}
+	enc := c.encoder(encodingName)
+	if enc == nil {
+		return estimateTokensForText(text), TokenizerHeuristic
+	}
+	count := len(enc.EncodeOrdinary(text))
+	if tokenizer == TokenizerClaude {
+		count = int(math.Ceil(float64(count) * claudeTokenRatio))
+	}
+	return count, tokenizer
+}
+
+func (c *TokenCounter) encoder(encodingName string) *tiktoken.Tiktoken {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if enc, ok := c.encoders[encodingName]; ok {
+		return enc
+	}
+	if c.failed[encodingName] {
+		return nil
+	}
+	enc, err := tiktoken.GetEncoding(encodingName)
+	if err != nil {
+		c.failed[encodingName] = true
+		logger.LegacyPrintf("service.token_counter", "Warning: load tokenizer %s failed, falling back to heuristic: %v", encodingName, err)
+		return nil
+	}
+	c.encoders[encodingName] = enc
+	return enc
+}
+
+// Warmup 预加载全部 tokenizer 编码，返回已加载的编码名；加载失败的编码以错误返回（计数时回落到启发式估算）
+func (c *TokenCounter) Warmup() ([]string, error) {
+	loaded := make([]string, 0, 2)
+	var failed []string
+	for _, name := range []string{TokenizerCL100K, TokenizerO200K} {
+		if c.encoder(name) == nil {
+			failed = append(failed, name)
+			continue
+		}
+		loaded = append(loaded, name)
+	}
+	if len(failed) > 0 {
+		return loaded, fmt.Errorf("load tokenizers failed: %s", strings.Join(failed, ", "))
+	}
+	return loaded, nil
+}
+
+// SetTokenCounter 注入 tokenizer，上游未返回 usage 时用于估算 token
+func (s *BillingService) SetTokenCounter(counter *TokenCounter) {
+	s.tokenCounter = counter
+}
+
+// CountTokens 按模型对应的 tokenizer 计算文本 token 数；未注入 tokenizer 时回落到字符启发式估算
+func (s *BillingService) CountTokens(model, text string) (int, string) {
+	if s == nil || s.tokenCounter == nil {
+		return estimateTokensForText(text), TokenizerHeuristic
+	}
+	return s.tokenCounter.CountTokens(model, text)
+}
+
+// WarmupTokenizers 预加载 tokenizer，避免首个需要估算 token 的请求承担加载耗时
+func (s *BillingService) WarmupTokenizers() ([]string, error) {
+	if s == nil || s.tokenCounter == nil {
+		return nil, fmt.Errorf("token counter not configured")
+	}
+	return s.tokenCounter.Warmup()
+}
+
+// tokenCountFor 返回绑定模型的 token 计数函数，供上游 usage 缺失时的用量估算使用
+func (s *BillingService) tokenCountFor(model string) tokenCountFunc {
+	return func(text string) int {
+		count, _ := s.CountTokens(model, text)
+		return count
+	}
+}
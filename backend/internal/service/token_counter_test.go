@@ -0,0 +1,55 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizerForModel(t *testing.T) {
+	require.Equal(t, TokenizerO200K, TokenizerForModel("gpt-4o-mini"))
+	require.Equal(t, TokenizerO200K, TokenizerForModel("gpt-5.4"))
+	require.Equal(t, TokenizerO200K, TokenizerForModel("openai/o3-mini"))
+	require.Equal(t, TokenizerCL100K, TokenizerForModel("gpt-4-turbo"))
+	require.Equal(t, TokenizerCL100K, TokenizerForModel("gpt-3.5-turbo"))
+	require.Equal(t, TokenizerClaude, TokenizerForModel("Claude-Sonnet-4-5"))
+	require.Equal(t, TokenizerCL100K, TokenizerForModel("unknown-model"))
+}
+
+func TestTokenCounter_CountTokens(t *testing.T) {
+	counter := NewTokenCounter()
+
+	tokens, tokenizer := counter.CountTokens("gpt-4", "hello world")
+	require.Equal(t, TokenizerCL100K, tokenizer)
+	require.Equal(t, 2, tokens)
+
+	tokens, tokenizer = counter.CountTokens("gpt-4o", "hello world")
+	require.Equal(t, TokenizerO200K, tokenizer)
+	require.Equal(t, 2, tokens)
+
+	// Claude 近似：cl100k 计数 × 1.1 向上取整
+	tokens, tokenizer = counter.CountTokens("claude-sonnet-4", "hello world")
+	require.Equal(t, TokenizerClaude, tokenizer)
+	require.Equal(t, 3, tokens)
+
+	tokens, _ = counter.CountTokens("gpt-4", "")
+	require.Zero(t, tokens)
+
+	// 已加载的 tokenizer 被复用
+	require.Len(t, counter.encoders, 2)
+}
+
+func TestBillingService_CountTokensFallsBackWithoutCounter(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+	tokens, tokenizer := svc.CountTokens("gpt-4", "abcdefgh")
+	require.Equal(t, TokenizerHeuristic, tokenizer)
+	require.Equal(t, 2, tokens)
+
+	svc.SetTokenCounter(NewTokenCounter())
+	tokens, tokenizer = svc.CountTokens("gpt-4", "hello world")
+	require.Equal(t, TokenizerCL100K, tokenizer)
+	require.Equal(t, 2, tokens)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// upstreamRequestErrorFailover 将上游请求错误（连接失败/超时，未收到任何响应）转换为 failover 错误，
+// 由 handler 切换到其他健康账号重试。
+//
+// 返回 nil 表示不切换：功能关闭、客户端已断开、模型级超时已触发，或本请求已切换账号次数达到 MaxRequestErrorRetries。
+// 此时调用方按原逻辑直接向客户端返回 502。
+// 请求错误发生时没有任何字节写入客户端，切换账号不会破坏响应。
+func upstreamRequestErrorFailover(ctx context.Context, cfg *config.Config, err error) *UpstreamFailoverError {
+	if err == nil || cfg == nil || !cfg.Gateway.FailoverOnRequestError {
+		return nil
+	}
+	if ctx != nil {
+		// 客户端断开或模型级超时已触发：上下文已结束，换账号重试也会立即失败，且不应把账号记为故障
+		if ctx.Err() != nil {
+			return nil
+		}
+		if _, _, exceeded := ModelTimeoutExceeded(ctx); exceeded {
+			return nil
+		}
+	}
+	switches, _ := AccountSwitchCountFromContext(ctx)
+	if switches >= cfg.Gateway.MaxRequestErrorRetries {
+		return nil
+	}
+
+	statusCode := http.StatusBadGateway
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		statusCode = http.StatusGatewayTimeout
+	}
+	return &UpstreamFailoverError{StatusCode: statusCode}
+}
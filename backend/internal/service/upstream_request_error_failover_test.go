@@ -0,0 +1,83 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type requestErrorTimeoutStub struct{}
+
+func (requestErrorTimeoutStub) Error() string   { return "i/o timeout" }
+func (requestErrorTimeoutStub) Timeout() bool   { return true }
+func (requestErrorTimeoutStub) Temporary() bool { return true }
+
+func requestErrorFailoverConfig(enabled bool, maxRetries int) *config.Config {
+	cfg := &config.Config{}
+	cfg.Gateway.FailoverOnRequestError = enabled
+	cfg.Gateway.MaxRequestErrorRetries = maxRetries
+	return cfg
+}
+
+func TestUpstreamRequestErrorFailover_StatusByErrorKind(t *testing.T) {
+	cfg := requestErrorFailoverConfig(true, 2)
+	ctx := context.Background()
+
+	failoverErr := upstreamRequestErrorFailover(ctx, cfg, errors.New("connection refused"))
+	require.NotNil(t, failoverErr)
+	require.Equal(t, http.StatusBadGateway, failoverErr.StatusCode)
+
+	failoverErr = upstreamRequestErrorFailover(ctx, cfg, fmt.Errorf("dial: %w", requestErrorTimeoutStub{}))
+	require.NotNil(t, failoverErr)
+	require.Equal(t, http.StatusGatewayTimeout, failoverErr.StatusCode)
+
+	failoverErr = upstreamRequestErrorFailover(ctx, cfg, context.DeadlineExceeded)
+	require.NotNil(t, failoverErr)
+	require.Equal(t, http.StatusGatewayTimeout, failoverErr.StatusCode)
+}
+
+func TestUpstreamRequestErrorFailover_StopsAtMaxRetries(t *testing.T) {
+	cfg := requestErrorFailoverConfig(true, 2)
+	err := errors.New("connection reset by peer")
+
+	ctx := WithAccountSwitchCount(context.Background(), 1, false)
+	require.NotNil(t, upstreamRequestErrorFailover(ctx, cfg, err))
+
+	ctx = WithAccountSwitchCount(context.Background(), 2, false)
+	require.Nil(t, upstreamRequestErrorFailover(ctx, cfg, err))
+
+	require.Nil(t, upstreamRequestErrorFailover(context.Background(), requestErrorFailoverConfig(true, 0), err))
+}
+
+func TestUpstreamRequestErrorFailover_DisabledOrClientCanceled(t *testing.T) {
+	err := errors.New("connection refused")
+	require.Nil(t, upstreamRequestErrorFailover(context.Background(), requestErrorFailoverConfig(false, 2), err))
+	require.Nil(t, upstreamRequestErrorFailover(context.Background(), nil, err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Nil(t, upstreamRequestErrorFailover(ctx, requestErrorFailoverConfig(true, 2), err))
+}
+
+func TestUpstreamRequestErrorFailover_ModelTimeoutDoesNotFailover(t *testing.T) {
+	cfg := requestErrorFailoverConfig(true, 2)
+
+	ctx, cancel := WithModelTimeout(context.Background(), "claude-sonnet-4", time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	require.Nil(t, upstreamRequestErrorFailover(ctx, cfg, fmt.Errorf("read: %w", context.DeadlineExceeded)))
+
+	// 上游请求使用脱离客户端取消的上下文时同样识别模型级超时
+	detached := keepModelTimeout(ctx, context.WithoutCancel(ctx))
+	require.Nil(t, upstreamRequestErrorFailover(detached, cfg, requestErrorTimeoutStub{}))
+}
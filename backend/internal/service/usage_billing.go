@@ -39,6 +39,15 @@ type UsageBillingCommand struct {
 	APIKeyQuotaCost     float64
 	APIKeyRateLimitCost float64
 	AccountQuotaCost    float64
+
+	// 请求发生时按当时定价计算的费用，累加到用户日消费汇总（user_spend_daily）
+	SpendTotalCost  float64
+	SpendActualCost float64
+	// 缓存读取费用及相对普通输入价格的节省（未乘倍率），累加到用户日消费汇总
+	SpendCacheReadCost float64
+	SpendCacheSavings  float64
+	// Sandbox 沙盒 Key 的请求：不扣费，消费累加到沙盒列，不计入消费合计与预算
+	Sandbox bool
 }
 
 func (c *UsageBillingCommand) Normalize() {
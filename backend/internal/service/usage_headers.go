@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+)
+
+// 计费用量响应头（仅对开启 usage_headers 的 API Key 返回）
+const (
+	UsageHeaderCostUSD      = "X-Cost-USD"
+	UsageHeaderInputTokens  = "X-Input-Tokens"
+	UsageHeaderOutputTokens = "X-Output-Tokens"
+	UsageHeaderModelUsed    = "X-Model-Used"
+)
+
+// UsageHeaderNames 全部计费用量响应头，流式响应据此声明 Trailer
+var UsageHeaderNames = []string{UsageHeaderCostUSD, UsageHeaderInputTokens, UsageHeaderOutputTokens, UsageHeaderModelUsed}
+
+// UsageHeaderReport 单个请求的计费用量汇总，供响应头/trailer 使用。
+// 异步用量记录任务通过 Hold/Release 声明仍需补齐用量，中间件在写出前调用 Wait 等待。
+type UsageHeaderReport struct {
+	mu       sync.Mutex
+	pending  int
+	done     chan struct{}
+	recorded bool
+
+	model        string
+	inputTokens  int
+	outputTokens int
+	actualCost   float64
+}
+
+// NewUsageHeaderReport 创建空的用量汇总
+func NewUsageHeaderReport() *UsageHeaderReport {
+	return &UsageHeaderReport{}
+}
+
+// WithUsageHeaderReport 将用量汇总放入 context
+func WithUsageHeaderReport(ctx context.Context, report *UsageHeaderReport) context.Context {
+	if report == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.UsageHeaderReport, report)
+}
+
+// UsageHeaderReportFromContext 取出当前请求的用量汇总，Key 未开启 usage_headers 时返回 nil
+func UsageHeaderReportFromContext(ctx context.Context) *UsageHeaderReport {
+	if ctx == nil {
+		return nil
+	}
+	report, _ := ctx.Value(ctxkey.UsageHeaderReport).(*UsageHeaderReport)
+	return report
+}
+
+// Hold 声明有一个异步用量记录任务将补齐用量
+func (r *UsageHeaderReport) Hold() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.pending++
+	r.mu.Unlock()
+}
+
+// Release 用量记录任务结束（无论成功与否）
+func (r *UsageHeaderReport) Release() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending > 0 {
+		r.pending--
+	}
+	if r.pending == 0 && r.done != nil {
+		close(r.done)
+		r.done = nil
+	}
+}
+
+// Wait 等待所有已声明的用量记录任务结束，最多等待 timeout；返回是否已全部完成
+func (r *UsageHeaderReport) Wait(timeout time.Duration) bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	if r.pending == 0 {
+		r.mu.Unlock()
+		return true
+	}
+	if r.done == nil {
+		r.done = make(chan struct{})
+	}
+	done := r.done
+	r.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// SetUsage 累加计费用量（WebSocket 多轮请求会多次调用）
+func (r *UsageHeaderReport) SetUsage(usageLog *UsageLog) {
+	if r == nil || usageLog == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorded = true
+	r.inputTokens += usageLog.InputTokens
+	r.outputTokens += usageLog.OutputTokens
+	r.actualCost += usageLog.ActualCost
+	if r.model == "" {
+		r.model = usageLog.Model
+	}
+}
+
+// Headers 返回计费用量响应头；尚未记录任何用量时返回 nil
+func (r *UsageHeaderReport) Headers() map[string]string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recorded {
+		return nil
+	}
+	return map[string]string{
+		UsageHeaderCostUSD:      strconv.FormatFloat(r.actualCost, 'f', 6, 64),
+		UsageHeaderInputTokens:  strconv.Itoa(r.inputTokens),
+		UsageHeaderOutputTokens: strconv.Itoa(r.outputTokens),
+		UsageHeaderModelUsed:    r.model,
+	}
+}
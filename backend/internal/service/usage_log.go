@@ -160,6 +160,13 @@ type UsageLog struct {
 
 	// Cache TTL Override 标记（管理员强制替换了缓存 TTL 计费）
 	CacheTTLOverridden bool
+	// IsSandbox 沙盒 Key 产生的用量：费用照常计算，但不扣费、不计入消费汇总与预算
+	IsSandbox bool
+
+	// AbortReason 响应被网关中止的原因（如超出 API Key 响应体大小上限），正常完成时为 nil
+	AbortReason *string
+	// TraceID 本地追踪 ID（X-Request-ID），用于关联请求日志、审计记录与上游请求
+	TraceID *string
 
 	// 图片生成字段
 	ImageCount int
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// usageReportMaxRangeDays 单次报表允许的最大天数
+const usageReportMaxRangeDays = 366
+
+var (
+	ErrUsageReportInvalidRange   = infraerrors.BadRequest("USAGE_REPORT_INVALID_RANGE", "invalid usage report date range")
+	ErrUsageReportInvalidGroupBy = infraerrors.BadRequest("USAGE_REPORT_INVALID_GROUP_BY", "group_by must be a comma-separated list of model, provider, user, day")
+)
+
+// UsageReportDimension 用量报表的分组维度
+type UsageReportDimension string
+
+const (
+	UsageReportByModel    UsageReportDimension = "model"
+	UsageReportByProvider UsageReportDimension = "provider" // 账号平台
+	UsageReportByUser     UsageReportDimension = "user"
+	UsageReportByDay      UsageReportDimension = "day" // UTC 日期
+)
+
+// UsageReportQuery 用量报表查询：[From, To] 为 UTC 日期（含两端），GroupBy 按给定顺序分组与排序
+type UsageReportQuery struct {
+	From    time.Time
+	To      time.Time
+	GroupBy []UsageReportDimension
+	// IncludeSandbox 是否包含沙盒 Key 的用量（默认排除）
+	IncludeSandbox bool
+}
+
+// Has 是否按该维度分组
+func (q UsageReportQuery) Has(dim UsageReportDimension) bool {
+	for _, d := range q.GroupBy {
+		if d == dim {
+			return true
+		}
+	}
+	return false
+}
+
+// UsageReportRow 报表的一行聚合结果；未参与分组的维度字段为空
+type UsageReportRow struct {
+	Day                 string  `json:"day,omitempty"`
+	Model               string  `json:"model,omitempty"`
+	Provider            string  `json:"provider,omitempty"`
+	UserID              int64   `json:"user_id,omitempty"`
+	UserEmail           string  `json:"user_email,omitempty"`
+	Requests            int64   `json:"requests"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	TotalCost           float64 `json:"total_cost"`
+	ActualCost          float64 `json:"actual_cost"`
+}
+
+// UsageReportRepository 按维度聚合请求级用量记录
+type UsageReportRepository interface {
+	// StreamUsageReport 逐行回调聚合结果（不在内存中缓冲全部行）；fn 返回错误时停止并返回该错误
+	StreamUsageReport(ctx context.Context, query UsageReportQuery, fn func(UsageReportRow) error) error
+}
+
+// UsageReportService 用量对账报表
+type UsageReportService struct {
+	repo UsageReportRepository
+}
+
+// NewUsageReportService 创建用量报表服务
+func NewUsageReportService(repo UsageReportRepository) *UsageReportService {
+	return &UsageReportService{repo: repo}
+}
+
+// ParseUsageReportGroupBy 解析逗号分隔的分组维度（去重并保持顺序）；为空时默认 model,day
+func ParseUsageReportGroupBy(raw string) ([]UsageReportDimension, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return []UsageReportDimension{UsageReportByModel, UsageReportByDay}, nil
+	}
+	dims := make([]UsageReportDimension, 0, 4)
+	seen := make(map[UsageReportDimension]struct{}, 4)
+	for _, part := range strings.Split(raw, ",") {
+		dim := UsageReportDimension(strings.ToLower(strings.TrimSpace(part)))
+		switch dim {
+		case UsageReportByModel, UsageReportByProvider, UsageReportByUser, UsageReportByDay:
+		default:
+			return nil, ErrUsageReportInvalidGroupBy
+		}
+		if _, ok := seen[dim]; ok {
+			continue
+		}
+		seen[dim] = struct{}{}
+		dims = append(dims, dim)
+	}
+	return dims, nil
+}
+
+// StreamReport 校验查询并逐行输出聚合结果
+func (s *UsageReportService) StreamReport(ctx context.Context, query UsageReportQuery, fn func(UsageReportRow) error) error {
+	query.From = truncateToUTCDate(query.From)
+	query.To = truncateToUTCDate(query.To)
+	if query.To.Before(query.From) || query.To.Sub(query.From) >= usageReportMaxRangeDays*24*time.Hour {
+		return ErrUsageReportInvalidRange
+	}
+	if len(query.GroupBy) == 0 {
+		return ErrUsageReportInvalidGroupBy
+	}
+	return s.repo.StreamUsageReport(ctx, query, fn)
+}
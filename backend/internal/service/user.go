@@ -53,6 +53,11 @@ type User struct {
 	// 且该 (用户, 分组) 无 rpm_override 时作为全局兜底生效，计数键 rpm:u:{userID}:{min}。
 	RPMLimit int
 
+	// BudgetLimit 月度预算（USD，0 = 不限制），按 billing.budget_cycle_day 划分计费周期。
+	// BudgetSoftLimit 为 true 时超出预算仅记录告警，不拦截请求。
+	BudgetLimit     float64
+	BudgetSoftLimit bool
+
 	// UserGroupRPMOverride 来自 auth cache snapshot 的 (user, group) RPM 覆盖值。
 	// nil = 该 API Key 对应的 (user, group) 无 override；非 nil 时 checkRPM 直接使用，
 	// 避免每请求查 DB。字段不持久化到数据库。
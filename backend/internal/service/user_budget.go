@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/logger"
+)
+
+// ErrUserBudgetExceeded 用户月度预算超限。gateway_handler 负责映射为 HTTP 402。
+var ErrUserBudgetExceeded = infraerrors.New(http.StatusPaymentRequired, "USER_BUDGET_EXCEEDED", "monthly budget exceeded")
+
+// userBudgetSpendCacheTTL 周期内已用费用的进程内缓存时间，避免每个请求都查询 user_spend_daily
+const userBudgetSpendCacheTTL = 15 * time.Second
+
+// UserBudgetExceededError 携带预算超限时的额度信息
+type UserBudgetExceededError struct {
+	Limit     float64   // 月度预算
+	Spent     float64   // 本周期已用（实际费用）
+	Projected float64   // 计入本次请求预估费用后的用量
+	ResetAt   time.Time // 下个计费周期开始时间（UTC）
+}
+
+func (e *UserBudgetExceededError) Error() string { return ErrUserBudgetExceeded.Error() }
+
+func (e *UserBudgetExceededError) Unwrap() error { return ErrUserBudgetExceeded }
+
+// Remaining 返回本周期剩余预算（不小于 0）
+func (e *UserBudgetExceededError) Remaining() float64 {
+	return math.Max(0, e.Limit-e.Spent)
+}
+
+// UserBudgetExceededFromError 提取预算超限错误的额度信息
+func UserBudgetExceededFromError(err error) (*UserBudgetExceededError, bool) {
+	var budgetErr *UserBudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return budgetErr, true
+	}
+	return nil, false
+}
+
+// budgetCycleBounds 返回 now 所在计费周期 [start, end)。cycleDay 为每月重置日（UTC，1-28），非法值按 1 处理。
+func budgetCycleBounds(now time.Time, cycleDay int) (time.Time, time.Time) {
+	if cycleDay < 1 || cycleDay > 28 {
+		cycleDay = 1
+	}
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), cycleDay, 0, 0, 0, 0, time.UTC)
+	if now.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start, start.AddDate(0, 1, 0)
+}
+
+// userBudgetSpend 用户本周期已用费用快照
+type userBudgetSpend struct {
+	cycleStart time.Time
+	requests   int64
+	actualCost float64
+	loadedAt   time.Time
+	softWarned atomic.Bool // 软限制告警每个快照只打印一次，避免日志刷屏
+}
+
+// projected 以本周期平均单次请求费用作为本次请求的预估费用
+func (s *userBudgetSpend) projected() float64 {
+	if s.requests <= 0 {
+		return s.actualCost
+	}
+	return s.actualCost + s.actualCost/float64(s.requests)
+}
+
+// SetUserSpendRepository 注入用户消费汇总仓储，未注入时跳过预算检查
+func (s *BillingCacheService) SetUserSpendRepository(repo UserSpendRepository) {
+	s.spendRepo = repo
+}
+
+// checkUserBudget 检查用户本计费周期的预计用量是否超出月度预算。
+// 软限制仅记录告警；查询失败一律 fail-open（打 warning，不阻塞业务）。
+func (s *BillingCacheService) checkUserBudget(ctx context.Context, user *User) error {
+	if s == nil || s.spendRepo == nil || user == nil || user.BudgetLimit <= 0 {
+		return nil
+	}
+	now := time.Now()
+	cycleStart, cycleEnd := budgetCycleBounds(now, s.cfg.Billing.BudgetCycleDay)
+	spend, err := s.loadUserBudgetSpend(user.ID, cycleStart, now)
+	if err != nil {
+		logger.LegacyPrintf("service.billing_cache", "Warning: budget spend lookup failed for user=%d: %v", user.ID, err)
+		return nil
+	}
+
+	// 进行中的流式请求预留一并计入
+	projected := spend.projected() + s.reservedBudget(user.ID, now, spend.loadedAt)
+	if projected <= user.BudgetLimit {
+		return nil
+	}
+	exceeded := &UserBudgetExceededError{
+		Limit:     user.BudgetLimit,
+		Spent:     spend.actualCost,
+		Projected: projected,
+		ResetAt:   cycleEnd,
+	}
+	if user.BudgetSoftLimit {
+		if spend.softWarned.CompareAndSwap(false, true) {
+			logger.LegacyPrintf("service.billing_cache", "Warning: user=%d over soft budget: spent=%.4f projected=%.4f limit=%.4f",
+				user.ID, exceeded.Spent, exceeded.Projected, exceeded.Limit)
+		}
+		return nil
+	}
+	return exceeded
+}
+
+func (s *BillingCacheService) loadUserBudgetSpend(userID int64, cycleStart, now time.Time) (*userBudgetSpend, error) {
+	if cached, ok := s.budgetSpendCache.Load(userID); ok {
+		spend := cached.(*userBudgetSpend)
+		if spend.cycleStart.Equal(cycleStart) && now.Sub(spend.loadedAt) < userBudgetSpendCacheTTL {
+			return spend, nil
+		}
+	}
+
+	value, err, _ := s.balanceLoadSF.Do("budget:"+strconv.FormatInt(userID, 10), func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.Background(), balanceLoadTimeout)
+		defer cancel()
+		requests, actualCost, err := s.spendRepo.SumUserSpendSince(loadCtx, userID, cycleStart)
+		if err != nil {
+			return nil, err
+		}
+		spend := &userBudgetSpend{
+			cycleStart: cycleStart,
+			requests:   requests,
+			actualCost: actualCost,
+			loadedAt:   now,
+		}
+		s.budgetSpendCache.Store(userID, spend)
+		return spend, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*userBudgetSpend), nil
+}
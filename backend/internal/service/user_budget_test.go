@@ -0,0 +1,76 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newBudgetTestService(repo UserSpendRepository, cycleDay int) *BillingCacheService {
+	cfg := &config.Config{}
+	cfg.Billing.BudgetCycleDay = cycleDay
+	svc := &BillingCacheService{cfg: cfg}
+	svc.SetUserSpendRepository(repo)
+	return svc
+}
+
+func TestBudgetCycleBounds(t *testing.T) {
+	start, end := budgetCycleBounds(time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC), 15)
+	require.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC), end)
+
+	// 重置日之前仍属于上个周期
+	start, end = budgetCycleBounds(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), 15)
+	require.Equal(t, time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), end)
+
+	// 非法配置回落到每月 1 日
+	start, _ = budgetCycleBounds(time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC), 0)
+	require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestCheckUserBudget_BlocksWhenProjectedOverLimit(t *testing.T) {
+	// 已用 9.5，共 5 次请求，预估本次 1.9 → 11.4 > 10
+	repo := &userSpendRepoStub{rows: []UserDailySpend{{Requests: 5, ActualCost: 9.5}}}
+	svc := newBudgetTestService(repo, 1)
+
+	err := svc.checkUserBudget(context.Background(), &User{ID: 7, BudgetLimit: 10})
+	require.ErrorIs(t, err, ErrUserBudgetExceeded)
+	budgetErr, ok := UserBudgetExceededFromError(err)
+	require.True(t, ok)
+	require.InDelta(t, 9.5, budgetErr.Spent, 1e-9)
+	require.InDelta(t, 11.4, budgetErr.Projected, 1e-9)
+	require.InDelta(t, 0.5, budgetErr.Remaining(), 1e-9)
+	require.True(t, budgetErr.ResetAt.After(time.Now()))
+
+	// 周期内已用费用被缓存，不重复查询
+	_ = svc.checkUserBudget(context.Background(), &User{ID: 7, BudgetLimit: 10})
+	require.Equal(t, 1, repo.queryCnt)
+}
+
+func TestCheckUserBudget_AllowsUnderLimitSoftModeAndUnlimited(t *testing.T) {
+	repo := &userSpendRepoStub{rows: []UserDailySpend{{Requests: 4, ActualCost: 4}}}
+	svc := newBudgetTestService(repo, 1)
+
+	require.NoError(t, svc.checkUserBudget(context.Background(), &User{ID: 1, BudgetLimit: 10}))
+	require.NoError(t, svc.checkUserBudget(context.Background(), &User{ID: 2, BudgetLimit: 3, BudgetSoftLimit: true}))
+	require.NoError(t, svc.checkUserBudget(context.Background(), &User{ID: 3}))
+	require.Equal(t, 2, repo.queryCnt, "unlimited users should not query spend")
+}
+
+type failingSpendRepo struct{ userSpendRepoStub }
+
+func (failingSpendRepo) SumUserSpendSince(context.Context, int64, time.Time) (int64, float64, error) {
+	return 0, 0, errors.New("db down")
+}
+
+func TestCheckUserBudget_FailOpenOnLookupError(t *testing.T) {
+	svc := newBudgetTestService(&failingSpendRepo{}, 1)
+	require.NoError(t, svc.checkUserBudget(context.Background(), &User{ID: 1, BudgetLimit: 1}))
+}
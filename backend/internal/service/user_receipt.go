@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+)
+
+// userReceiptMonthLayout 账单月份格式
+const userReceiptMonthLayout = "2006-01"
+
+var ErrUserReceiptInvalidMonth = infraerrors.BadRequest("USER_RECEIPT_INVALID_MONTH", "invalid receipt month, expected YYYY-MM")
+
+// UserReceiptLine 账单明细行（按模型汇总）
+type UserReceiptLine struct {
+	Model               string  `json:"model"`
+	Requests            int64   `json:"requests"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	TotalTokens         int64   `json:"total_tokens"`
+	Cost                float64 `json:"cost"`
+}
+
+// UserReceipt 用户月度账单：按模型列出请求数、token 用量与实际扣费，并给出合计
+type UserReceipt struct {
+	UserID      int64             `json:"user_id"`
+	Month       string            `json:"month"`
+	PeriodStart time.Time         `json:"period_start"`
+	PeriodEnd   time.Time         `json:"period_end"`
+	Lines       []UserReceiptLine `json:"lines"`
+	Requests    int64             `json:"requests"`
+	TotalTokens int64             `json:"total_tokens"`
+	TotalCost   float64           `json:"total_cost"`
+}
+
+// ParseUserReceiptMonth 解析账单月份（YYYY-MM，UTC）；为空时返回当月
+func ParseUserReceiptMonth(raw string, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		u := now.UTC()
+		return time.Date(u.Year(), u.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	month, err := time.Parse(userReceiptMonthLayout, raw)
+	if err != nil {
+		return time.Time{}, ErrUserReceiptInvalidMonth
+	}
+	return month, nil
+}
+
+// GetUserReceipt 返回用户在指定月份（UTC）的账单明细。
+// 费用为请求发生时实际扣除的金额（已乘倍率），只包含该用户自己的用量。
+func (s *UsageService) GetUserReceipt(ctx context.Context, userID int64, month time.Time) (*UserReceipt, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	stats, err := s.usageRepo.GetUserModelStats(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("get user receipt: %w", err)
+	}
+	return buildUserReceipt(userID, start, end, stats), nil
+}
+
+func buildUserReceipt(userID int64, start, end time.Time, stats []usagestats.ModelStat) *UserReceipt {
+	receipt := &UserReceipt{
+		UserID:      userID,
+		Month:       start.Format(userReceiptMonthLayout),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Lines:       make([]UserReceiptLine, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		if stat.Requests == 0 {
+			continue
+		}
+		receipt.Lines = append(receipt.Lines, UserReceiptLine{
+			Model:               stat.Model,
+			Requests:            stat.Requests,
+			InputTokens:         stat.InputTokens,
+			OutputTokens:        stat.OutputTokens,
+			CacheCreationTokens: stat.CacheCreationTokens,
+			CacheReadTokens:     stat.CacheReadTokens,
+			TotalTokens:         stat.TotalTokens,
+			Cost:                stat.ActualCost,
+		})
+		receipt.Requests += stat.Requests
+		receipt.TotalTokens += stat.TotalTokens
+		receipt.TotalCost += stat.ActualCost
+	}
+	sort.SliceStable(receipt.Lines, func(i, j int) bool {
+		if receipt.Lines[i].Cost != receipt.Lines[j].Cost {
+			return receipt.Lines[i].Cost > receipt.Lines[j].Cost
+		}
+		return receipt.Lines[i].Model < receipt.Lines[j].Model
+	})
+	return receipt
+}
@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserReceiptMonth(t *testing.T) {
+	now := time.Date(2026, 3, 15, 23, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	month, err := ParseUserReceiptMonth("", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), month)
+
+	month, err = ParseUserReceiptMonth("2025-12", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), month)
+
+	for _, raw := range []string{"2025-13", "2025/12", "2025-12-01"} {
+		_, err = ParseUserReceiptMonth(raw, now)
+		require.ErrorIs(t, err, ErrUserReceiptInvalidMonth, raw)
+	}
+}
+
+func TestBuildUserReceipt_LinesAndTotals(t *testing.T) {
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	receipt := buildUserReceipt(7, start, start.AddDate(0, 1, 0), []usagestats.ModelStat{
+		{Model: "gpt-4o", Requests: 3, InputTokens: 300, OutputTokens: 30, TotalTokens: 330, Cost: 0.5, ActualCost: 0.4, AccountCost: 0.2},
+		{Model: "claude-sonnet-4", Requests: 2, InputTokens: 100, OutputTokens: 50, CacheReadTokens: 10, TotalTokens: 160, Cost: 1, ActualCost: 1.2},
+		{Model: "empty", Requests: 0},
+	})
+
+	require.Equal(t, "2026-02", receipt.Month)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), receipt.PeriodEnd)
+	require.Len(t, receipt.Lines, 2)
+	require.Equal(t, "claude-sonnet-4", receipt.Lines[0].Model)
+	require.Equal(t, int64(10), receipt.Lines[0].CacheReadTokens)
+	require.InDelta(t, 0.4, receipt.Lines[1].Cost, 1e-12)
+	require.Equal(t, int64(5), receipt.Requests)
+	require.Equal(t, int64(490), receipt.TotalTokens)
+	require.InDelta(t, 1.6, receipt.TotalCost, 1e-12)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// userSpendMaxRangeDays 单次查询允许的最大天数
+const userSpendMaxRangeDays = 366
+
+var ErrUserSpendInvalidRange = infraerrors.BadRequest("USER_SPEND_INVALID_RANGE", "invalid spend date range")
+
+// UserDailySpend 用户单日消费汇总（UTC 日期）
+type UserDailySpend struct {
+	Date       string  `json:"date"`
+	Requests   int64   `json:"requests"`
+	TotalCost  float64 `json:"total_cost"`
+	ActualCost float64 `json:"actual_cost"`
+
+	// 缓存读取 token 数、缓存读取费用及相对普通输入价格节省的费用（未乘倍率，不含沙盒用量）
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheReadCostUSD float64 `json:"cache_read_cost_usd"`
+	CacheSavingsUSD  float64 `json:"cache_savings_usd"`
+
+	// 沙盒 Key 的用量（未实际扣费），仅在 include_sandbox 时计入上面的合计
+	SandboxRequests   int64   `json:"-"`
+	SandboxTotalCost  float64 `json:"-"`
+	SandboxActualCost float64 `json:"-"`
+}
+
+// UserMonthlySpend 用户单月消费汇总，由日汇总聚合
+type UserMonthlySpend struct {
+	Month            string  `json:"month"`
+	Requests         int64   `json:"requests"`
+	TotalCost        float64 `json:"total_cost"`
+	ActualCost       float64 `json:"actual_cost"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheReadCostUSD float64 `json:"cache_read_cost_usd"`
+	CacheSavingsUSD  float64 `json:"cache_savings_usd"`
+}
+
+// UserSpendSummary 用户在 [From, To] 日期区间内的消费合计与明细
+type UserSpendSummary struct {
+	UserID         int64   `json:"user_id"`
+	From           string  `json:"from"`
+	To             string  `json:"to"`
+	IncludeSandbox bool    `json:"include_sandbox"`
+	Requests       int64   `json:"requests"`
+	TotalCost      float64 `json:"total_cost"`
+	ActualCost     float64 `json:"actual_cost"`
+	// 缓存命中统计：缓存读取费用与按普通输入价格计费相比节省的费用
+	CacheReadTokens  int64              `json:"cache_read_tokens"`
+	CacheReadCostUSD float64            `json:"cache_read_cost_usd"`
+	CacheSavingsUSD  float64            `json:"cache_savings_usd"`
+	Daily            []UserDailySpend   `json:"daily"`
+	Monthly          []UserMonthlySpend `json:"monthly"`
+	// 费用已按该有效数字位数舍入展示（0 表示完整精度）
+	DisplaySignificantDigits int `json:"display_significant_digits,omitempty"`
+}
+
+// UserSpendRepository 读取计费时累加的用户日消费汇总
+type UserSpendRepository interface {
+	// ListUserDailySpend 返回 [from, to] 区间（UTC 日期，含两端）内有消费记录的日汇总（含沙盒用量列），按日期升序
+	ListUserDailySpend(ctx context.Context, userID int64, from, to time.Time) ([]UserDailySpend, error)
+	// SumUserSpendSince 返回自 from（UTC 日期，含当日）起累计的请求数与实际费用（不含沙盒用量），用于月度预算检查
+	SumUserSpendSince(ctx context.Context, userID int64, from time.Time) (requests int64, actualCost float64, err error)
+}
+
+// UserSpendService 用户消费汇总查询
+type UserSpendService struct {
+	repo UserSpendRepository
+	cfg  *config.Config
+}
+
+// NewUserSpendService 创建用户消费汇总服务
+func NewUserSpendService(repo UserSpendRepository, cfg *config.Config) *UserSpendService {
+	return &UserSpendService{repo: repo, cfg: cfg}
+}
+
+// CostDisplaySignificantDigits 接口返回费用的展示精度（有效数字位数），0 表示不舍入
+func (s *UserSpendService) CostDisplaySignificantDigits() int {
+	if s == nil || s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Pricing.DisplaySignificantDigits
+}
+
+// GetUserSpend 返回用户在 [from, to] 日期区间（UTC，含两端）内的消费合计、日明细与月汇总。
+// 费用为请求发生时按当时定价计算并累加的结果，不会按当前价格重算；includeSandbox 为 true 时计入沙盒 Key 的用量。
+func (s *UserSpendService) GetUserSpend(ctx context.Context, userID int64, from, to time.Time, includeSandbox bool) (*UserSpendSummary, error) {
+	from = truncateToUTCDate(from)
+	to = truncateToUTCDate(to)
+	if to.Before(from) || to.Sub(from) >= userSpendMaxRangeDays*24*time.Hour {
+		return nil, ErrUserSpendInvalidRange
+	}
+
+	daily, err := s.repo.ListUserDailySpend(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeUserSpend(userID, from, to, daily, includeSandbox), nil
+}
+
+func summarizeUserSpend(userID int64, from, to time.Time, daily []UserDailySpend, includeSandbox bool) *UserSpendSummary {
+	summary := &UserSpendSummary{
+		UserID:         userID,
+		From:           from.Format(time.DateOnly),
+		To:             to.Format(time.DateOnly),
+		IncludeSandbox: includeSandbox,
+		Daily:          make([]UserDailySpend, 0, len(daily)),
+		Monthly:        make([]UserMonthlySpend, 0),
+	}
+	for _, day := range daily {
+		if includeSandbox {
+			day.Requests += day.SandboxRequests
+			day.TotalCost += day.SandboxTotalCost
+			day.ActualCost += day.SandboxActualCost
+		}
+		if day.Requests == 0 {
+			continue // 仅有沙盒用量的日期
+		}
+		summary.Requests += day.Requests
+		summary.TotalCost += day.TotalCost
+		summary.ActualCost += day.ActualCost
+		summary.CacheReadTokens += day.CacheReadTokens
+		summary.CacheReadCostUSD += day.CacheReadCostUSD
+		summary.CacheSavingsUSD += day.CacheSavingsUSD
+		summary.Daily = append(summary.Daily, day)
+
+		month := day.Date
+		if len(month) >= 7 {
+			month = month[:7]
+		}
+		if n := len(summary.Monthly); n == 0 || summary.Monthly[n-1].Month != month {
+			summary.Monthly = append(summary.Monthly, UserMonthlySpend{Month: month})
+		}
+		last := &summary.Monthly[len(summary.Monthly)-1]
+		last.Requests += day.Requests
+		last.TotalCost += day.TotalCost
+		last.ActualCost += day.ActualCost
+		last.CacheReadTokens += day.CacheReadTokens
+		last.CacheReadCostUSD += day.CacheReadCostUSD
+		last.CacheSavingsUSD += day.CacheSavingsUSD
+	}
+	return summary
+}
+
+func truncateToUTCDate(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+}
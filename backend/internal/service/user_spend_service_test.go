@@ -0,0 +1,153 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type userSpendRepoStub struct {
+	rows     []UserDailySpend
+	gotFrom  time.Time
+	gotTo    time.Time
+	gotUser  int64
+	queryCnt int
+}
+
+func (s *userSpendRepoStub) ListUserDailySpend(_ context.Context, userID int64, from, to time.Time) ([]UserDailySpend, error) {
+	s.queryCnt++
+	s.gotUser, s.gotFrom, s.gotTo = userID, from, to
+	return s.rows, nil
+}
+
+func (s *userSpendRepoStub) SumUserSpendSince(_ context.Context, userID int64, from time.Time) (int64, float64, error) {
+	s.queryCnt++
+	s.gotUser, s.gotFrom = userID, from
+	var requests int64
+	var actual float64
+	for _, row := range s.rows {
+		requests += row.Requests
+		actual += row.ActualCost
+	}
+	return requests, actual, nil
+}
+
+func TestUserSpendService_TotalsDailyAndMonthly(t *testing.T) {
+	repo := &userSpendRepoStub{rows: []UserDailySpend{
+		{Date: "2026-01-30", Requests: 2, TotalCost: 1.5, ActualCost: 1.2},
+		{Date: "2026-01-31", Requests: 1, TotalCost: 0.5, ActualCost: 0.4},
+		{Date: "2026-02-01", Requests: 4, TotalCost: 2, ActualCost: 3},
+	}}
+	svc := NewUserSpendService(repo, nil)
+
+	from := time.Date(2026, 1, 15, 13, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	summary, err := svc.GetUserSpend(context.Background(), 9, from, to, false)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(9), repo.gotUser)
+	require.Equal(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), repo.gotFrom)
+	require.Equal(t, "2026-01-15", summary.From)
+	require.Equal(t, "2026-02-10", summary.To)
+	require.Equal(t, int64(7), summary.Requests)
+	require.InDelta(t, 4.0, summary.TotalCost, 1e-9)
+	require.InDelta(t, 4.6, summary.ActualCost, 1e-9)
+	require.Len(t, summary.Daily, 3)
+
+	require.Len(t, summary.Monthly, 2)
+	require.Equal(t, "2026-01", summary.Monthly[0].Month)
+	require.Equal(t, int64(3), summary.Monthly[0].Requests)
+	require.InDelta(t, 1.6, summary.Monthly[0].ActualCost, 1e-9)
+	require.Equal(t, "2026-02", summary.Monthly[1].Month)
+	require.InDelta(t, 2.0, summary.Monthly[1].TotalCost, 1e-9)
+}
+
+func TestUserSpendService_RejectsInvalidRange(t *testing.T) {
+	repo := &userSpendRepoStub{}
+	svc := NewUserSpendService(repo, nil)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.GetUserSpend(context.Background(), 1, day, day.AddDate(0, 0, -1), false)
+	require.ErrorIs(t, err, ErrUserSpendInvalidRange)
+	_, err = svc.GetUserSpend(context.Background(), 1, day, day.AddDate(0, 0, userSpendMaxRangeDays), false)
+	require.ErrorIs(t, err, ErrUserSpendInvalidRange)
+	require.Zero(t, repo.queryCnt)
+
+	summary, err := svc.GetUserSpend(context.Background(), 1, day, day, false)
+	require.NoError(t, err)
+	require.Empty(t, summary.Daily)
+	require.NotNil(t, summary.Monthly)
+}
+
+func TestUserSpendService_IncludeSandboxFoldsSandboxUsage(t *testing.T) {
+	repo := &userSpendRepoStub{rows: []UserDailySpend{
+		{Date: "2026-03-01", Requests: 1, TotalCost: 1, ActualCost: 1, SandboxRequests: 2, SandboxTotalCost: 0.5, SandboxActualCost: 0.5},
+		{Date: "2026-03-02", SandboxRequests: 3, SandboxTotalCost: 1.5, SandboxActualCost: 1.5},
+	}}
+	svc := NewUserSpendService(repo, nil)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	summary, err := svc.GetUserSpend(context.Background(), 1, day, day.AddDate(0, 0, 1), false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.Requests)
+	require.InDelta(t, 1.0, summary.ActualCost, 1e-9)
+	require.Len(t, summary.Daily, 1)
+
+	summary, err = svc.GetUserSpend(context.Background(), 1, day, day.AddDate(0, 0, 1), true)
+	require.NoError(t, err)
+	require.True(t, summary.IncludeSandbox)
+	require.Equal(t, int64(6), summary.Requests)
+	require.InDelta(t, 3.0, summary.ActualCost, 1e-9)
+	require.Len(t, summary.Daily, 2)
+}
+
+func TestUserSpendService_SumsCacheSavings(t *testing.T) {
+	repo := &userSpendRepoStub{rows: []UserDailySpend{
+		{Date: "2026-03-31", Requests: 2, TotalCost: 1, ActualCost: 1, CacheReadTokens: 1000, CacheReadCostUSD: 0.01, CacheSavingsUSD: 0.09},
+		{Date: "2026-04-01", Requests: 1, TotalCost: 1, ActualCost: 1, CacheReadTokens: 500, CacheReadCostUSD: 0.005, CacheSavingsUSD: 0.045},
+	}}
+	svc := NewUserSpendService(repo, nil)
+	day := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	summary, err := svc.GetUserSpend(context.Background(), 1, day, day.AddDate(0, 0, 1), false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), summary.CacheReadTokens)
+	require.InDelta(t, 0.015, summary.CacheReadCostUSD, 1e-9)
+	require.InDelta(t, 0.135, summary.CacheSavingsUSD, 1e-9)
+	require.Len(t, summary.Monthly, 2)
+	require.InDelta(t, 0.09, summary.Monthly[0].CacheSavingsUSD, 1e-9)
+	require.Equal(t, int64(500), summary.Monthly[1].CacheReadTokens)
+}
+
+func TestBuildUsageBillingCommand_RecordsSpendCosts(t *testing.T) {
+	cmd := buildUsageBillingCommand("req-1", &UsageLog{Model: "claude-sonnet-4"}, &postUsageBillingParams{
+		Cost:    &CostBreakdown{TotalCost: 2, ActualCost: 2.5, CacheReadCost: 0.1, CacheReadFullCost: 1},
+		User:    &User{ID: 3},
+		APIKey:  &APIKey{ID: 4},
+		Account: &Account{ID: 5},
+	})
+	require.NotNil(t, cmd)
+	require.InDelta(t, 2.0, cmd.SpendTotalCost, 1e-9)
+	require.InDelta(t, 2.5, cmd.SpendActualCost, 1e-9)
+	require.InDelta(t, 0.1, cmd.SpendCacheReadCost, 1e-9)
+	require.InDelta(t, 0.9, cmd.SpendCacheSavings, 1e-9)
+}
+
+func TestBuildUsageBillingCommand_SandboxSkipsCharges(t *testing.T) {
+	cmd := buildUsageBillingCommand("req-2", &UsageLog{Model: "claude-sonnet-4"}, &postUsageBillingParams{
+		Cost:    &CostBreakdown{TotalCost: 2, ActualCost: 2.5},
+		User:    &User{ID: 3},
+		APIKey:  &APIKey{ID: 4, IsSandbox: true, Quota: 10},
+		Account: &Account{ID: 5},
+	})
+	require.NotNil(t, cmd)
+	require.True(t, cmd.Sandbox)
+	require.Zero(t, cmd.BalanceCost)
+	require.Zero(t, cmd.SubscriptionCost)
+	require.Zero(t, cmd.APIKeyQuotaCost)
+	require.InDelta(t, 2.5, cmd.SpendActualCost, 1e-9)
+}
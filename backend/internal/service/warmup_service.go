@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 预热步骤名称
+const (
+	WarmupStepTokenizers   = "tokenizers"
+	WarmupStepPricingCache = "pricing_cache"
+	WarmupStepAccounts     = "accounts"
+)
+
+const (
+	warmupDefaultAccountLimit = 5
+	warmupMaxAccountLimit     = 50
+	warmupAccountTimeout      = 30 * time.Second
+	warmupAccountWorkers      = 5
+)
+
+// WarmupOptions 预热参数
+type WarmupOptions struct {
+	// AccountLimit 探测的可调度账号数量（按优先级选取），0 使用默认值
+	AccountLimit int
+	// SkipAccounts 跳过上游账号探测
+	SkipAccounts bool
+}
+
+// WarmupAccountResult 单个账号的探测结果
+type WarmupAccountResult struct {
+	AccountID int64  `json:"account_id"`
+	Name      string `json:"name"`
+	Platform  string `json:"platform"`
+	Success   bool   `json:"success"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// WarmupStep 单个预热步骤的耗时与结果；Count 为加载的 tokenizer 数 / 缓存的模型数 / 探测成功的账号数
+type WarmupStep struct {
+	Name       string `json:"name"`
+	Success    bool   `json:"success"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Count      int    `json:"count"`
+	Error      string `json:"error,omitempty"`
+
+	Tokenizers []string              `json:"tokenizers,omitempty"`
+	Accounts   []WarmupAccountResult `json:"accounts,omitempty"`
+}
+
+// WarmupReport 预热结果
+type WarmupReport struct {
+	Success    bool         `json:"success"`
+	DurationMs int64        `json:"duration_ms"`
+	Steps      []WarmupStep `json:"steps"`
+}
+
+// WarmupService 部署后切流量前的预热：加载 tokenizer、填充内存定价缓存并探测部分上游账号，
+// 避免首批请求承担懒加载与建立上游连接的耗时。
+type WarmupService struct {
+	billingService *BillingService
+	accountRepo    AccountRepository
+	probe          func(ctx context.Context, accountID int64) (*ScheduledTestResult, error)
+}
+
+// NewWarmupService 创建预热服务
+func NewWarmupService(billingService *BillingService, accountRepo AccountRepository, accountTestSvc *AccountTestService) *WarmupService {
+	s := &WarmupService{billingService: billingService, accountRepo: accountRepo}
+	if accountTestSvc != nil {
+		s.probe = func(ctx context.Context, accountID int64) (*ScheduledTestResult, error) {
+			return accountTestSvc.RunTestBackground(ctx, accountID, "")
+		}
+	}
+	return s
+}
+
+// NormalizeWarmupAccountLimit 校验探测账号数量：0 使用默认值，超出上限时截断
+func NormalizeWarmupAccountLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, fmt.Errorf("account_limit must be non-negative")
+	case limit == 0:
+		return warmupDefaultAccountLimit, nil
+	case limit > warmupMaxAccountLimit:
+		return warmupMaxAccountLimit, nil
+	}
+	return limit, nil
+}
+
+// Run 依次执行各预热步骤；单个步骤失败不影响后续步骤，全部成功（或跳过）时 Success 为 true
+func (s *WarmupService) Run(ctx context.Context, opts WarmupOptions) *WarmupReport {
+	startedAt := time.Now()
+	report := &WarmupReport{Success: true, Steps: make([]WarmupStep, 0, 3)}
+
+	report.Steps = append(report.Steps, timeWarmupStep(WarmupStepTokenizers, func(step *WarmupStep) error {
+		loaded, err := s.billingService.WarmupTokenizers()
+		step.Tokenizers = loaded
+		step.Count = len(loaded)
+		return err
+	}))
+	report.Steps = append(report.Steps, timeWarmupStep(WarmupStepPricingCache, func(step *WarmupStep) error {
+		if s.billingService == nil {
+			return fmt.Errorf("billing service not configured")
+		}
+		step.Count = s.billingService.WarmupPricingCache()
+		return nil
+	}))
+	if opts.SkipAccounts {
+		report.Steps = append(report.Steps, WarmupStep{Name: WarmupStepAccounts, Success: true, Skipped: true})
+	} else {
+		report.Steps = append(report.Steps, timeWarmupStep(WarmupStepAccounts, func(step *WarmupStep) error {
+			return s.probeAccounts(ctx, opts.AccountLimit, step)
+		}))
+	}
+
+	for _, step := range report.Steps {
+		if !step.Success {
+			report.Success = false
+		}
+	}
+	report.DurationMs = time.Since(startedAt).Milliseconds()
+	return report
+}
+
+func timeWarmupStep(name string, run func(step *WarmupStep) error) WarmupStep {
+	step := WarmupStep{Name: name}
+	startedAt := time.Now()
+	err := run(&step)
+	step.DurationMs = time.Since(startedAt).Milliseconds()
+	step.Success = err == nil
+	if err != nil {
+		step.Error = err.Error()
+	}
+	return step
+}
+
+// probeAccounts 按优先级选取前 limit 个可调度账号并发发送探测请求，预先建立上游连接
+func (s *WarmupService) probeAccounts(ctx context.Context, limit int, step *WarmupStep) error {
+	if s.accountRepo == nil || s.probe == nil {
+		return fmt.Errorf("account probe not configured")
+	}
+	limit, err := NormalizeWarmupAccountLimit(limit)
+	if err != nil {
+		return err
+	}
+	accounts, err := s.accountRepo.ListSchedulable(ctx)
+	if err != nil {
+		return fmt.Errorf("list schedulable accounts: %w", err)
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if accounts[i].Priority != accounts[j].Priority {
+			return accounts[i].Priority < accounts[j].Priority
+		}
+		return accounts[i].ID < accounts[j].ID
+	})
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+
+	results := make([]WarmupAccountResult, len(accounts))
+	sem := make(chan struct{}, warmupAccountWorkers)
+	var wg sync.WaitGroup
+	for i := range accounts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, account *Account) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.probeAccount(ctx, account)
+		}(i, &accounts[i])
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.Success {
+			step.Count++
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%d", result.AccountID))
+	}
+	step.Accounts = results
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d account probes failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (s *WarmupService) probeAccount(ctx context.Context, account *Account) WarmupAccountResult {
+	probeCtx, cancel := context.WithTimeout(ctx, warmupAccountTimeout)
+	defer cancel()
+
+	result := WarmupAccountResult{AccountID: account.ID, Name: account.Name, Platform: account.Platform}
+	startedAt := time.Now()
+	probed, err := s.probe(probeCtx, account.ID)
+	result.LatencyMs = time.Since(startedAt).Milliseconds()
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case probed == nil:
+		result.Error = "probe returned no result"
+	case probed.Status != "success":
+		result.Error = probed.ErrorMessage
+		result.LatencyMs = probed.LatencyMs
+	default:
+		result.Success = true
+		result.LatencyMs = probed.LatencyMs
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmupService_RunReportsEachStep(t *testing.T) {
+	billing := newTestBillingServiceWithPricing(map[string]*LiteLLMModelPricing{
+		"gpt-4o":          {InputCostPerToken: 2e-6, OutputCostPerToken: 8e-6},
+		"claude-sonnet-4": {InputCostPerToken: 3e-6, OutputCostPerToken: 1.5e-5},
+	})
+	billing.SetTokenCounter(NewTokenCounter())
+
+	var mu sync.Mutex
+	probed := make([]int64, 0)
+	svc := &WarmupService{
+		billingService: billing,
+		accountRepo: &healthCheckAccountRepoStub{accounts: []Account{
+			{ID: 3, Name: "c", Priority: 1},
+			{ID: 1, Name: "a", Priority: 2},
+			{ID: 2, Name: "b", Priority: 1},
+		}},
+		probe: func(_ context.Context, accountID int64) (*ScheduledTestResult, error) {
+			mu.Lock()
+			probed = append(probed, accountID)
+			mu.Unlock()
+			if accountID == 3 {
+				return nil, errors.New("dial tcp: timeout")
+			}
+			return &ScheduledTestResult{Status: "success", LatencyMs: 7}, nil
+		},
+	}
+
+	report := svc.Run(context.Background(), WarmupOptions{AccountLimit: 2})
+	require.Len(t, report.Steps, 3)
+
+	tokenizers := report.Steps[0]
+	require.Equal(t, WarmupStepTokenizers, tokenizers.Name)
+	require.True(t, tokenizers.Success)
+	require.ElementsMatch(t, []string{TokenizerCL100K, TokenizerO200K}, tokenizers.Tokenizers)
+
+	pricing := report.Steps[1]
+	require.Equal(t, WarmupStepPricingCache, pricing.Name)
+	require.True(t, pricing.Success)
+	require.Equal(t, 2, pricing.Count)
+	require.Equal(t, 2, billing.pricingCache.stats()["cache_entries"])
+
+	accounts := report.Steps[2]
+	require.Equal(t, WarmupStepAccounts, accounts.Name)
+	require.False(t, accounts.Success)
+	require.Equal(t, 1, accounts.Count)
+	require.ElementsMatch(t, []int64{2, 3}, probed, "按优先级选取前 2 个账号")
+	require.Equal(t, int64(2), accounts.Accounts[0].AccountID)
+	require.True(t, accounts.Accounts[0].Success)
+	require.Equal(t, "dial tcp: timeout", accounts.Accounts[1].Error)
+	require.False(t, report.Success)
+
+	skipped := svc.Run(context.Background(), WarmupOptions{SkipAccounts: true})
+	require.True(t, skipped.Success)
+	require.True(t, skipped.Steps[2].Skipped)
+}
+
+func TestNormalizeWarmupAccountLimit(t *testing.T) {
+	limit, err := NormalizeWarmupAccountLimit(0)
+	require.NoError(t, err)
+	require.Equal(t, warmupDefaultAccountLimit, limit)
+	limit, err = NormalizeWarmupAccountLimit(500)
+	require.NoError(t, err)
+	require.Equal(t, warmupMaxAccountLimit, limit)
+	_, err = NormalizeWarmupAccountLimit(-1)
+	require.Error(t, err)
+}
@@ -149,6 +149,27 @@ func ProvideAccountExpiryService(accountRepo AccountRepository) *AccountExpirySe
 	return svc
 }
 
+// ProvideAccountHealthCheckService creates and starts AccountHealthCheckService.
+func ProvideAccountHealthCheckService(accountRepo AccountRepository, accountTestSvc *AccountTestService, cfg *config.Config) *AccountHealthCheckService {
+	svc := NewAccountHealthCheckService(accountRepo, accountTestSvc, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideReadinessService creates ReadinessService and starts the startup warmup.
+func ProvideReadinessService(db *sql.DB, billingService *BillingService, accountRepo AccountRepository, warmupService *WarmupService) *ReadinessService {
+	svc := NewReadinessService(db, billingService, accountRepo, warmupService)
+	svc.Start()
+	return svc
+}
+
+// ProvideGatewayLoadService creates GatewayLoadService and exports its load hints to /metrics.
+func ProvideGatewayLoadService(cfg *config.Config, accountRepo AccountRepository, concurrencyService *ConcurrencyService, priorityQueue *GatewayPriorityQueue) *GatewayLoadService {
+	svc := NewGatewayLoadService(cfg, accountRepo, concurrencyService, priorityQueue)
+	svc.ExportMetrics()
+	return svc
+}
+
 // ProvideSubscriptionExpiryService creates and starts SubscriptionExpiryService.
 func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository) *SubscriptionExpiryService {
 	svc := NewSubscriptionExpiryService(userSubRepo, time.Minute)
@@ -299,6 +320,13 @@ func ProvideOpsSystemLogSink(opsRepo OpsRepository) *OpsSystemLogSink {
 	return sink
 }
 
+// ProvideAuditService creates and starts AuditService
+func ProvideAuditService(cfg *config.Config, repo AuditLogRepository) *AuditService {
+	svc := NewAuditService(cfg, repo)
+	svc.Start()
+	return svc
+}
+
 func buildIdempotencyConfig(cfg *config.Config) IdempotencyConfig {
 	idempotencyCfg := DefaultIdempotencyConfig()
 	if cfg != nil {
@@ -415,7 +443,17 @@ func ProvideContentModerationService(
 	return svc
 }
 
-// ProvideBillingCacheService wires BillingCacheService with its RPM dependencies.
+// ProvideBillingService wires BillingService with the tokenizer used for missing-usage estimates
+// and starts the scheduled pricing refresh.
+func ProvideBillingService(cfg *config.Config, pricingService *PricingService, tokenCounter *TokenCounter, billingCacheService *BillingCacheService) *BillingService {
+	svc := NewBillingService(cfg, pricingService)
+	svc.SetTokenCounter(tokenCounter)
+	billingCacheService.SetStreamCostEstimator(svc)
+	svc.StartPricingAutoRefresh()
+	return svc
+}
+
+// ProvideBillingCacheService wires BillingCacheService with its RPM and budget dependencies.
 func ProvideBillingCacheService(
 	cache BillingCache,
 	userRepo UserRepository,
@@ -423,9 +461,12 @@ func ProvideBillingCacheService(
 	apiKeyRepo APIKeyRepository,
 	rpmCache UserRPMCache,
 	rateRepo UserGroupRateRepository,
+	spendRepo UserSpendRepository,
 	cfg *config.Config,
 ) *BillingCacheService {
-	return NewBillingCacheService(cache, userRepo, subRepo, apiKeyRepo, rpmCache, rateRepo, cfg)
+	svc := NewBillingCacheService(cache, userRepo, subRepo, apiKeyRepo, rpmCache, rateRepo, cfg)
+	svc.SetUserSpendRepository(spendRepo)
+	return svc
 }
 
 // ProvideAPIKeyService wires APIKeyService and connects rate-limit cache invalidation.
@@ -458,8 +499,17 @@ var ProviderSet = wire.NewSet(
 	NewPromoService,
 	NewUsageService,
 	NewDashboardService,
+	NewUserSpendService,
+	NewGatewayIdempotencyService,
+	NewResponseCacheService,
+	NewMaintenanceService,
+	NewGatewayPriorityQueue,
+	ProvideGatewayLoadService,
+	NewSlowRequestLogger,
+	NewUsageReportService,
 	ProvidePricingService,
-	NewBillingService,
+	NewTokenCounter,
+	ProvideBillingService,
 	ProvideBillingCacheService,
 	NewAnnouncementService,
 	NewAdminService,
@@ -483,11 +533,15 @@ var ProviderSet = wire.NewSet(
 	ProvideRateLimitService,
 	NewAccountUsageService,
 	NewAccountTestService,
+	NewWarmupService,
+	ProvideReadinessService,
+	NewModelAccountsService,
 	ProvideSettingService,
 	NewDataManagementService,
 	ProvideBackupService,
 	NewPostgresBackupService,
 	ProvideOpsSystemLogSink,
+	ProvideAuditService,
 	NewOpsService,
 	ProvideOpsMetricsCollector,
 	ProvideOpsAggregationService,
@@ -508,6 +562,7 @@ var ProviderSet = wire.NewSet(
 	ProvideUpdateService,
 	ProvideTokenRefreshService,
 	ProvideAccountExpiryService,
+	ProvideAccountHealthCheckService,
 	ProvideSubscriptionExpiryService,
 	ProvideTimingWheelService,
 	ProvideDashboardAggregationService,
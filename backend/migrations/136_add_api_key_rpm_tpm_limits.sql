@@ -0,0 +1,8 @@
+-- Add per-API-key throughput limits (token bucket).
+-- rpm_limit: 每分钟请求数上限；tpm_limit: 每分钟 token 数上限。
+-- 0 表示使用全局默认值（rate_limit.api_key_default_rpm / api_key_default_tpm）。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS rpm_limit integer NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tpm_limit integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN api_keys.rpm_limit IS 'API Key 每分钟请求数上限；0 表示使用全局默认值。';
+COMMENT ON COLUMN api_keys.tpm_limit IS 'API Key 每分钟 token 数上限；0 表示使用全局默认值。';
@@ -0,0 +1,5 @@
+-- Add scheduling weight for accounts (weighted round-robin within the same priority).
+-- NULL 表示默认权重 1；0 表示仅排空（不再接收新会话，已有粘性会话继续）。
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS weight integer;
+
+COMMENT ON COLUMN accounts.weight IS '同优先级账号间的调度权重；NULL 表示默认 1，0 表示仅排空。';
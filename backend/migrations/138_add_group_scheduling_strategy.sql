@@ -0,0 +1,5 @@
+-- Add account scheduling strategy for groups.
+-- load_balance：默认负载均衡；cheapest：优先选择对请求模型有效成本最低的账号。
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS scheduling_strategy varchar(20) NOT NULL DEFAULT 'load_balance';
+
+COMMENT ON COLUMN groups.scheduling_strategy IS '账号调度策略：load_balance（负载均衡）/ cheapest（优先有效成本最低的账号）。';
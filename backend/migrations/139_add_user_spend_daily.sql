@@ -0,0 +1,32 @@
+-- Per-user daily spend rollup, written in the same transaction as usage billing.
+-- 费用按请求发生时的定价计算后累加，不随后续价格调整重算；按日存储，月度汇总由日数据聚合。
+CREATE TABLE IF NOT EXISTS user_spend_daily (
+    user_id BIGINT NOT NULL,
+    bucket_date DATE NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    total_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    actual_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, bucket_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_spend_daily_bucket_date
+    ON user_spend_daily (bucket_date);
+
+COMMENT ON TABLE user_spend_daily IS 'Per-user daily spend totals accumulated at billing time.';
+COMMENT ON COLUMN user_spend_daily.bucket_date IS 'UTC date of the day bucket.';
+COMMENT ON COLUMN user_spend_daily.total_cost IS '按请求时定价计算的原始费用（倍率前）。';
+COMMENT ON COLUMN user_spend_daily.actual_cost IS '实际扣费金额（含分组/用户倍率）。';
+
+-- Backfill history from existing usage logs (costs stored on each log were computed at request time).
+INSERT INTO user_spend_daily (user_id, bucket_date, request_count, total_cost, actual_cost, updated_at)
+SELECT
+    user_id,
+    (created_at AT TIME ZONE 'UTC')::date,
+    COUNT(*),
+    COALESCE(SUM(total_cost), 0),
+    COALESCE(SUM(actual_cost), 0),
+    NOW()
+FROM usage_logs
+GROUP BY user_id, (created_at AT TIME ZONE 'UTC')::date
+ON CONFLICT (user_id, bucket_date) DO NOTHING;
@@ -0,0 +1,6 @@
+-- 用户月度预算：按计费周期累计 user_spend_daily.actual_cost，超出后拒绝请求（软限制仅告警）。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS budget_limit decimal(20,8) NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS budget_soft_limit boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN users.budget_limit IS '用户月度预算（USD）；0 表示不限制；周期起始日由 billing.budget_cycle_day 配置。';
+COMMENT ON COLUMN users.budget_soft_limit IS '为 true 时超出预算仅记录告警，不拦截请求。';
@@ -0,0 +1,39 @@
+-- Request-level audit trail for gateway calls.
+-- 记录调用方、模型、上游平台、token 用量、费用、状态与耗时；请求/响应正文默认不记录，
+-- 仅对开启 api_keys.audit_capture_body 的 Key 截断保存。
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    request_id VARCHAR(128),
+    user_id BIGINT,
+    api_key_id BIGINT,
+    account_id BIGINT,
+    model VARCHAR(128),
+    platform VARCHAR(32),
+    method VARCHAR(16) NOT NULL,
+    path VARCHAR(256) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    input_tokens INT NOT NULL DEFAULT 0,
+    output_tokens INT NOT NULL DEFAULT 0,
+    cache_creation_tokens INT NOT NULL DEFAULT 0,
+    cache_read_tokens INT NOT NULL DEFAULT 0,
+    total_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    actual_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    client_ip VARCHAR(64),
+    request_body TEXT,
+    response_body TEXT,
+    body_truncated BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_created_at ON audit_logs (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_model_created_at ON audit_logs (model, created_at);
+
+COMMENT ON TABLE audit_logs IS 'Per-request gateway audit trail.';
+COMMENT ON COLUMN audit_logs.request_body IS '请求正文（仅 Key 开启正文记录时保存，按 audit.max_body_bytes 截断）。';
+COMMENT ON COLUMN audit_logs.response_body IS '响应正文（仅 Key 开启正文记录时保存，按 audit.max_body_bytes 截断）。';
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS audit_capture_body BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN api_keys.audit_capture_body IS '审计日志是否记录该 Key 的请求/响应正文；默认不记录。';
@@ -0,0 +1,7 @@
+-- Add per-API-key model allow/deny lists.
+-- allowed_models 为空表示允许所有模型；denied_models 优先于 allowed_models。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS allowed_models JSONB DEFAULT NULL;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS denied_models JSONB DEFAULT NULL;
+
+COMMENT ON COLUMN api_keys.allowed_models IS 'API Key 允许请求的模型列表；为空表示允许所有模型。';
+COMMENT ON COLUMN api_keys.denied_models IS 'API Key 禁止请求的模型列表；优先于 allowed_models。';
@@ -0,0 +1,5 @@
+-- Add per-API-key opt-in for billed usage response headers.
+-- 开启后网关在响应头（流式为 trailer）中返回 X-Cost-USD / X-Input-Tokens / X-Output-Tokens / X-Model-Used。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_headers BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN api_keys.usage_headers IS 'API Key 是否在响应头中返回本次请求的计费金额与 token 用量。';
@@ -0,0 +1,14 @@
+-- Sandbox API keys: requests are forwarded and priced normally, but never charged.
+-- 沙盒 Key 的用量照常记录（usage_logs.is_sandbox 标记），费用累加到 user_spend_daily 的 sandbox_* 列，
+-- 不计入消费合计与月度预算，管理端消费查询可通过 include_sandbox 参数选择是否包含。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS is_sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE usage_logs ADD COLUMN IF NOT EXISTS is_sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS sandbox_request_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS sandbox_total_cost DECIMAL(20, 10) NOT NULL DEFAULT 0;
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS sandbox_actual_cost DECIMAL(20, 10) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN api_keys.is_sandbox IS '沙盒 Key：计算并返回费用，但不扣费、不计入消费汇总与预算。';
+COMMENT ON COLUMN usage_logs.is_sandbox IS '是否为沙盒 Key 产生的用量。';
+COMMENT ON COLUMN user_spend_daily.sandbox_actual_cost IS '沙盒 Key 的费用（未实际扣费，不计入 actual_cost）。';
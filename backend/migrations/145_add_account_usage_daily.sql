@@ -0,0 +1,45 @@
+-- Per-account daily usage rollup, written in the same transaction as usage billing.
+-- 用量归属于实际承接请求的账号（故障转移重试时为最终成功的账号），用于评估各账号的价值。
+CREATE TABLE IF NOT EXISTS account_usage_daily (
+    account_id BIGINT NOT NULL,
+    bucket_date DATE NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    input_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    cache_creation_tokens BIGINT NOT NULL DEFAULT 0,
+    cache_read_tokens BIGINT NOT NULL DEFAULT 0,
+    total_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    actual_cost DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, bucket_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_usage_daily_bucket_date
+    ON account_usage_daily (bucket_date);
+
+COMMENT ON TABLE account_usage_daily IS 'Per-account daily usage totals accumulated at billing time.';
+COMMENT ON COLUMN account_usage_daily.bucket_date IS 'UTC date of the day bucket.';
+COMMENT ON COLUMN account_usage_daily.total_cost IS '按请求时定价计算的原始费用（倍率前）。';
+COMMENT ON COLUMN account_usage_daily.actual_cost IS '向用户实际收取的金额（含分组/用户倍率）。';
+
+-- Backfill history from existing usage logs (each log records the account that served the request).
+INSERT INTO account_usage_daily (
+    account_id, bucket_date, request_count,
+    input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens,
+    total_cost, actual_cost, updated_at
+)
+SELECT
+    account_id,
+    (created_at AT TIME ZONE 'UTC')::date,
+    COUNT(*),
+    COALESCE(SUM(input_tokens), 0),
+    COALESCE(SUM(output_tokens), 0),
+    COALESCE(SUM(cache_creation_tokens), 0),
+    COALESCE(SUM(cache_read_tokens), 0),
+    COALESCE(SUM(total_cost), 0),
+    COALESCE(SUM(actual_cost), 0),
+    NOW()
+FROM usage_logs
+WHERE account_id > 0
+GROUP BY account_id, (created_at AT TIME ZONE 'UTC')::date
+ON CONFLICT (account_id, bucket_date) DO NOTHING;
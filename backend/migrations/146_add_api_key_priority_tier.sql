@@ -0,0 +1,5 @@
+-- Gateway request queue priority tier per API key.
+-- 网关开启优先级队列后，并发已满时等待的请求按 priority_tier 从高到低、同层按到达顺序放行；默认 0 保持原有行为。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS priority_tier INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN api_keys.priority_tier IS '网关排队优先级（越大越优先，0 为默认层级）。';
@@ -0,0 +1,27 @@
+-- Per-user prompt cache statistics on the daily spend rollup.
+-- 缓存节省 = 缓存读取 token 按普通输入价格计算的费用 - 实际缓存读取费用（未乘倍率，按请求时定价累加，不含沙盒用量）。
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS cache_read_tokens BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS cache_read_cost DECIMAL(20, 10) NOT NULL DEFAULT 0;
+ALTER TABLE user_spend_daily ADD COLUMN IF NOT EXISTS cache_savings DECIMAL(20, 10) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN user_spend_daily.cache_read_tokens IS '缓存读取 token 数（不含沙盒用量）。';
+COMMENT ON COLUMN user_spend_daily.cache_read_cost IS '缓存读取费用（倍率前）。';
+COMMENT ON COLUMN user_spend_daily.cache_savings IS '缓存读取相对普通输入价格节省的费用（倍率前）。';
+
+-- Backfill cache token counts and read cost from existing usage logs; savings cannot be
+-- reconstructed without historical input prices and start accumulating from this migration.
+UPDATE user_spend_daily d
+SET
+    cache_read_tokens = agg.cache_read_tokens,
+    cache_read_cost = agg.cache_read_cost
+FROM (
+    SELECT
+        user_id,
+        (created_at AT TIME ZONE 'UTC')::date AS bucket_date,
+        COALESCE(SUM(cache_read_tokens), 0) AS cache_read_tokens,
+        COALESCE(SUM(cache_read_cost), 0) AS cache_read_cost
+    FROM usage_logs
+    WHERE is_sandbox = FALSE
+    GROUP BY user_id, (created_at AT TIME ZONE 'UTC')::date
+) agg
+WHERE d.user_id = agg.user_id AND d.bucket_date = agg.bucket_date;
@@ -0,0 +1,6 @@
+-- Hashed API key storage.
+-- 启用 security.api_key_hashing 后，key 列保存查找摘要（kh: 前缀），完整 Key 的 bcrypt/argon2id 哈希保存在 key_hash 中；
+-- 明文存储的旧 Key 在首次使用时重新哈希存储。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_hash VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN api_keys.key_hash IS '完整 Key 的 bcrypt/argon2id 哈希（空表示明文存储）。';
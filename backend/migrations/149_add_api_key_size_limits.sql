@@ -0,0 +1,9 @@
+-- Per API key request/response size limits.
+-- 0 表示使用 gateway.key_size_limits 全局默认值；响应超限中止时的原因记录在 usage_logs.abort_reason。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS max_request_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS max_response_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE usage_logs ADD COLUMN IF NOT EXISTS abort_reason TEXT;
+
+COMMENT ON COLUMN api_keys.max_request_bytes IS '请求体大小上限（字节，0 表示使用全局默认值）。';
+COMMENT ON COLUMN api_keys.max_response_bytes IS '响应体大小上限（字节，0 表示使用全局默认值）。';
+COMMENT ON COLUMN usage_logs.abort_reason IS '响应被网关中止的原因（如超出 API Key 响应大小上限）。';
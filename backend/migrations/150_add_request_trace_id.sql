@@ -0,0 +1,7 @@
+-- Request tracing: store the local X-Request-ID alongside usage and audit records.
+-- trace_id 与响应头 X-Request-ID 一致，用于关联请求日志、上游请求、用量记录与审计记录。
+ALTER TABLE usage_logs ADD COLUMN IF NOT EXISTS trace_id VARCHAR(128);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS trace_id VARCHAR(128);
+
+COMMENT ON COLUMN usage_logs.trace_id IS '请求追踪 ID（X-Request-ID）。';
+COMMENT ON COLUMN audit_logs.trace_id IS '请求追踪 ID（X-Request-ID）。';
@@ -0,0 +1,15 @@
+-- Per API key scheduled usage quotas (request count / token count).
+-- 额度按 usage_quota_period（hourly/daily/weekly/monthly，UTC 日历边界）重置；0 表示不限制。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_requests BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_tokens BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_period VARCHAR(16) NOT NULL DEFAULT 'daily';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_requests_used BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_tokens_used BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS usage_quota_window_start TIMESTAMPTZ;
+
+COMMENT ON COLUMN api_keys.usage_quota_requests IS '每个额度周期的请求数上限；0 表示不限制。';
+COMMENT ON COLUMN api_keys.usage_quota_tokens IS '每个额度周期的 token 数上限；0 表示不限制。';
+COMMENT ON COLUMN api_keys.usage_quota_period IS '额度重置周期：hourly/daily/weekly/monthly（UTC 日历边界）。';
+COMMENT ON COLUMN api_keys.usage_quota_requests_used IS '当前额度周期已用请求数。';
+COMMENT ON COLUMN api_keys.usage_quota_tokens_used IS '当前额度周期已用 token 数。';
+COMMENT ON COLUMN api_keys.usage_quota_window_start IS '当前额度周期开始时间。';
@@ -0,0 +1,5 @@
+-- Per API key response model masking.
+-- 开启后网关在响应的 model 字段中回显客户端请求的模型名（如别名），而不是实际的上游模型；计费仍按实际模型。
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS mask_response_model BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN api_keys.mask_response_model IS 'API Key 是否在响应中回显请求的模型名以隐藏实际上游模型。';
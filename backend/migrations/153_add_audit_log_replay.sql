@@ -0,0 +1,7 @@
+-- Replay of audited gateway requests for debugging.
+-- 管理员可按审计记录重放请求：正文经过脱敏的记录无法原样重放；重放产生的审计记录通过 replay_of_id 指向原始记录。
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS request_body_redacted BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS replay_of_id BIGINT;
+
+COMMENT ON COLUMN audit_logs.request_body_redacted IS '保存的请求正文是否经过脱敏（脱敏后无法原样重放）。';
+COMMENT ON COLUMN audit_logs.replay_of_id IS '管理员重放请求时指向原始审计记录 ID；重放不向客户扣费。';
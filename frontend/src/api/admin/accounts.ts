@@ -14,6 +14,7 @@ import type {
   ClaudeModel,
   AccountUsageStatsResponse,
   TempUnschedulableStatus,
+  AccountDrainStatus,
   AdminDataPayload,
   AdminDataImportResult,
   CodexSessionImportRequest,
@@ -40,6 +41,7 @@ export async function list(
     search?: string
     privacy_mode?: string
     lite?: string
+    include_deleted?: boolean
     sort_by?: string
     sort_order?: 'asc' | 'desc'
   },
@@ -75,6 +77,7 @@ export async function listWithEtag(
     search?: string
     privacy_mode?: string
     lite?: string
+    include_deleted?: boolean
     sort_by?: string
     sort_order?: 'asc' | 'desc'
   },
@@ -166,6 +169,16 @@ export async function deleteAccount(id: number): Promise<{ message: string }> {
   return data
 }
 
+/**
+ * Restore a soft-deleted account
+ * @param id - Account ID
+ * @returns Restored account
+ */
+export async function restoreAccount(id: number): Promise<Account> {
+  const { data } = await apiClient.post<Account>(`/admin/accounts/${id}/restore`)
+  return data
+}
+
 /**
  * Toggle account status
  * @param id - Account ID
@@ -177,20 +190,38 @@ export async function toggleStatus(id: number, status: 'active' | 'inactive'): P
 }
 
 /**
- * Test account connectivity
- * @param id - Account ID
- * @returns Test result
+ * Account test summary (non-streaming). Test requests are never billed.
  */
-export async function testAccount(id: number): Promise<{
+export interface AccountTestSummary {
+  account_id: number
+  model?: string
   success: boolean
-  message: string
-  latency_ms?: number
-}> {
-  const { data } = await apiClient.post<{
-    success: boolean
-    message: string
-    latency_ms?: number
-  }>(`/admin/accounts/${id}/test`)
+  upstream_status?: number
+  latency_ms: number
+  usage?: {
+    input_tokens: number
+    output_tokens: number
+  }
+  response_text?: string
+  error?: string
+  test: boolean
+  billed: boolean
+}
+
+/**
+ * Test account connectivity by forcing one request through this account
+ * @param id - Account ID
+ * @param options - Optional model / prompt
+ * @returns Test summary with upstream status, latency and token usage
+ */
+export async function testAccount(
+  id: number,
+  options: { model_id?: string; prompt?: string } = {}
+): Promise<AccountTestSummary> {
+  const { data } = await apiClient.post<AccountTestSummary>(`/admin/accounts/${id}/test`, {
+    ...options,
+    stream: false
+  })
   return data
 }
 
@@ -239,6 +270,53 @@ export async function getUsage(id: number, source?: 'passive' | 'active'): Promi
   return data
 }
 
+export interface AccountUsageAttributionDay {
+  date: string
+  requests: number
+  input_tokens: number
+  output_tokens: number
+  cache_creation_tokens: number
+  cache_read_tokens: number
+  total_cost: number
+  actual_cost: number
+}
+
+export interface AccountUsageAttribution {
+  account_id: number
+  from: string
+  to: string
+  requests: number
+  input_tokens: number
+  output_tokens: number
+  cache_creation_tokens: number
+  cache_read_tokens: number
+  total_tokens: number
+  total_cost: number
+  actual_cost: number
+  daily: AccountUsageAttributionDay[]
+}
+
+/**
+ * Get usage and cost actually served by an account (failover attempts excluded)
+ * @param id - Account ID
+ * @param from - Start date (YYYY-MM-DD, UTC, inclusive), defaults to first day of current month
+ * @param to - End date (YYYY-MM-DD, UTC, inclusive), defaults to today
+ * @returns Usage totals with daily breakdown
+ */
+export async function getUsageAttribution(
+  id: number,
+  from?: string,
+  to?: string
+): Promise<AccountUsageAttribution> {
+  const params: Record<string, string> = { source: 'attribution' }
+  if (from) params.from = from
+  if (to) params.to = to
+  const { data } = await apiClient.get<AccountUsageAttribution>(`/admin/accounts/${id}/usage`, {
+    params
+  })
+  return data
+}
+
 /**
  * Clear account rate limit status
  * @param id - Account ID
@@ -273,6 +351,26 @@ export async function resetAccountQuota(id: number): Promise<Account> {
   return data
 }
 
+/**
+ * Put account into draining state (no new requests, in-flight ones finish)
+ * @param id - Account ID
+ * @returns Drain status with in-flight request count
+ */
+export async function drainAccount(id: number): Promise<AccountDrainStatus> {
+  const { data } = await apiClient.post<AccountDrainStatus>(`/admin/accounts/${id}/drain`)
+  return data
+}
+
+/**
+ * Get account drain status
+ * @param id - Account ID
+ * @returns Drain status with in-flight request count
+ */
+export async function getDrainStatus(id: number): Promise<AccountDrainStatus> {
+  const { data } = await apiClient.get<AccountDrainStatus>(`/admin/accounts/${id}/drain`)
+  return data
+}
+
 /**
  * Get temporary unschedulable status
  * @param id - Account ID
@@ -446,6 +544,158 @@ export async function getAvailableModels(id: number): Promise<ClaudeModel[]> {
   return data
 }
 
+export interface AccountHealthStatus {
+  account_id: number
+  name: string
+  platform: string
+  healthy: boolean
+  consecutive_failures: number
+  last_check_at?: string
+  last_success_at?: string
+  last_latency_ms: number
+  /** Moving average of successful probe latency; 0 means no data yet. Used by `latency` group scheduling */
+  avg_latency_ms: number
+  last_error?: string
+  /** 0 means unlimited */
+  max_concurrency: number
+  current_concurrency: number
+  waiting_count: number
+  /** Circuit breaker state, independent of `healthy` and of manual disable */
+  circuit_state: CircuitState
+  /** Spend in the current daily quota period (USD); present only when a daily cap is set */
+  daily_spend?: number
+  daily_spend_cap?: number
+  /** Account is not scheduled until the daily quota period resets */
+  daily_spend_cap_reached: boolean
+}
+
+export type CircuitState = 'closed' | 'open' | 'half_open'
+
+export interface AccountCircuitBreakerStatus {
+  account_id: number
+  state: CircuitState
+  requests: number
+  failures: number
+  failure_rate: number
+  last_error?: string
+  opened_at?: string
+  half_open_at?: string
+}
+
+export interface UpstreamRateLimitBucket {
+  limit?: number
+  remaining: number
+  reset_at?: string
+}
+
+export interface AccountUpstreamRateLimit {
+  account_id: number
+  requests?: UpstreamRateLimitBucket
+  tokens?: UpstreamRateLimitBucket
+  input_tokens?: UpstreamRateLimitBucket
+  output_tokens?: UpstreamRateLimitBucket
+  updated_at: string
+}
+
+/**
+ * Get upstream health status recorded by the background health checker
+ */
+export interface AccountHealthResponse {
+  accounts: AccountHealthStatus[]
+  total: number
+  unhealthy_count: number
+  saturated_count: number
+  upstream_rate_limits: AccountUpstreamRateLimit[]
+  circuit_breakers: AccountCircuitBreakerStatus[]
+  circuit_open_count: number
+  daily_spend_cap_reached_count: number
+}
+
+export async function getHealth(): Promise<AccountHealthResponse> {
+  const { data } = await apiClient.get<AccountHealthResponse>('/admin/accounts/health')
+  return data
+}
+
+export interface ModelAccountEntry {
+  account_id: number
+  name: string
+  platform: string
+  type: string
+  status: string
+  schedulable: boolean
+  priority: number
+  weight: number
+  mapped_model: string
+  healthy: boolean
+  /** Average health-check latency; absent when not measured yet */
+  avg_latency_ms?: number
+  circuit_state: CircuitState
+  provider_disabled: boolean
+  available: boolean
+  unavailable_reason?: string
+  /** 0 means unlimited */
+  max_concurrency: number
+  current_concurrency: number
+  waiting_count: number
+}
+
+export interface ModelAccountsResponse {
+  model: string
+  provider?: string
+  provider_disabled: boolean
+  model_disabled: boolean
+  accounts: ModelAccountEntry[]
+  total: number
+  available_count: number
+}
+
+/**
+ * List all accounts whose model mapping covers the given model, with scheduling state
+ * @param model - Requested model name
+ */
+export async function getModelAccounts(model: string): Promise<ModelAccountsResponse> {
+  const { data } = await apiClient.get<ModelAccountsResponse>(
+    `/admin/models/${encodeURIComponent(model)}/accounts`
+  )
+  return data
+}
+
+export interface AccountSelectionCount {
+  account_id: number
+  name: string
+  platform: string
+  priority: number
+  weight: number
+  selections: number
+  share: number
+  expected_share: number
+}
+
+export interface AccountSelectionDistribution {
+  group_id: number
+  total: number
+  accounts: AccountSelectionCount[]
+}
+
+/**
+ * Get load-balancing selection distribution across accounts (process-local, since start or last reset)
+ * @param groupId - Optional group filter
+ */
+export async function getSelectionDistribution(
+  groupId?: number
+): Promise<{ groups: AccountSelectionDistribution[]; since: string }> {
+  const { data } = await apiClient.get<{ groups: AccountSelectionDistribution[]; since: string }>(
+    '/admin/accounts/selection-distribution',
+    { params: groupId !== undefined ? { group_id: groupId } : undefined }
+  )
+  return data
+}
+
+export async function resetSelectionDistribution(): Promise<{ message: string }> {
+  const { data } = await apiClient.post<{ message: string }>('/admin/accounts/selection-distribution/reset')
+  return data
+}
+
 export interface CRSPreviewAccount {
   crs_account_id: string
   kind: string
@@ -645,12 +895,14 @@ export const accountsAPI = {
   update,
   checkMixedChannelRisk,
   delete: deleteAccount,
+  restore: restoreAccount,
   toggleStatus,
   testAccount,
   refreshCredentials,
   getStats,
   clearError,
   getUsage,
+  getUsageAttribution,
   getTodayStats,
   getBatchTodayStats,
   clearRateLimit,
@@ -658,8 +910,14 @@ export const accountsAPI = {
   resetAccountQuota,
   getTempUnschedulableStatus,
   resetTempUnschedulable,
+  drainAccount,
+  getDrainStatus,
   setSchedulable,
   getAvailableModels,
+  getHealth,
+  getModelAccounts,
+  getSelectionDistribution,
+  resetSelectionDistribution,
   generateAuthUrl,
   exchangeCode,
   refreshOpenAIToken,
@@ -26,8 +26,126 @@ export async function updateApiKeyGroup(id: number, groupId: number | null): Pro
   return data
 }
 
+export interface ApiKeyModelAccess {
+  /** Models the key may request (trailing * wildcard supported); empty allows all models */
+  allowed_models?: string[]
+  /** Models the key may not request; takes precedence over allowed_models */
+  denied_models?: string[]
+}
+
+/**
+ * Update an API key's model allow/deny lists
+ * @param id - API Key ID
+ * @param access - Lists to replace (omitted lists are left unchanged, [] clears)
+ * @returns Updated API key
+ */
+export async function updateApiKeyModelAccess(
+  id: number,
+  access: ApiKeyModelAccess
+): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, access)
+  return data
+}
+
+/**
+ * Mark or unmark an API key as sandbox (usage is recorded but never charged)
+ * @param id - API Key ID
+ * @param sandbox - Whether the key is a sandbox key
+ * @returns Updated API key
+ */
+export async function updateApiKeySandbox(id: number, sandbox: boolean): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, {
+    is_sandbox: sandbox
+  })
+  return data
+}
+
+/**
+ * Set the gateway priority tier of an API key (higher tiers are served first when the queue is full)
+ * @param id - API Key ID
+ * @param tier - Priority tier, 0 = default
+ * @returns Updated API key
+ */
+export async function updateApiKeyPriorityTier(id: number, tier: number): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, {
+    priority_tier: tier
+  })
+  return data
+}
+
+/**
+ * Echo the requested model name in responses instead of the real upstream model
+ * @param id - API Key ID
+ * @param enabled - Whether response model names are masked
+ * @returns Updated API key
+ */
+export async function updateApiKeyMaskResponseModel(
+  id: number,
+  enabled: boolean
+): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, {
+    mask_response_model: enabled
+  })
+  return data
+}
+
+/**
+ * Request/response size limits of an API key (bytes, 0 = use the global default)
+ */
+export interface ApiKeySizeLimits {
+  max_request_bytes?: number
+  max_response_bytes?: number
+}
+
+/**
+ * Set the request/response size limits of an API key (omitted fields are unchanged)
+ * @param id - API Key ID
+ * @param limits - Size limits in bytes, 0 = use the global default
+ * @returns Updated API key
+ */
+export async function updateApiKeySizeLimits(
+  id: number,
+  limits: ApiKeySizeLimits
+): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, limits)
+  return data
+}
+
+export type ApiKeyUsageQuotaPeriod = 'hourly' | 'daily' | 'weekly' | 'monthly'
+
+/**
+ * Scheduled request/token usage quota of an API key (0 = unlimited)
+ */
+export interface ApiKeyUsageQuota {
+  usage_quota_requests?: number
+  usage_quota_tokens?: number
+  usage_quota_period?: ApiKeyUsageQuotaPeriod
+  reset_usage_quota?: boolean
+}
+
+/**
+ * Set the scheduled usage quota of an API key (omitted fields are unchanged).
+ * Changing the period or setting reset_usage_quota clears the current window usage.
+ * @param id - API Key ID
+ * @param quota - Request/token quota per period, 0 = unlimited
+ * @returns Updated API key
+ */
+export async function updateApiKeyUsageQuota(
+  id: number,
+  quota: ApiKeyUsageQuota
+): Promise<UpdateApiKeyGroupResult> {
+  const { data } = await apiClient.put<UpdateApiKeyGroupResult>(`/admin/api-keys/${id}`, quota)
+  return data
+}
+
 export const apiKeysAPI = {
-  updateApiKeyGroup
+  updateApiKeyGroup,
+  updateApiKeyModelAccess,
+  updateApiKeySandbox,
+  updateApiKeyPriorityTier,
+  updateApiKeyMaskResponseModel,
+  updateApiKeySizeLimits,
+  updateApiKeyUsageQuota
 }
 
 export default apiKeysAPI
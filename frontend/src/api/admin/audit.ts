@@ -0,0 +1,93 @@
+/**
+ * Admin Audit Log API endpoints
+ * Query request-level audit logs recorded by the gateway
+ */
+
+import { apiClient } from '../client'
+import type { PaginatedResponse } from '@/types'
+
+export interface AuditLog {
+  id: number
+  created_at: string
+  request_id: string
+  trace_id: string
+  user_id: number
+  api_key_id: number
+  account_id: number
+  model: string
+  platform: string
+  method: string
+  path: string
+  status_code: number
+  duration_ms: number
+  input_tokens: number
+  output_tokens: number
+  cache_creation_tokens: number
+  cache_read_tokens: number
+  total_cost: number
+  actual_cost: number
+  client_ip: string
+  /** Only present for API keys with audit_capture_body enabled (truncated and redacted). */
+  request_body?: string
+  response_body?: string
+  body_truncated: boolean
+  /** The stored request body had fields redacted, so it cannot be replayed. */
+  request_body_redacted: boolean
+  /** Set on entries created by an admin replay; points at the original entry. */
+  replay_of_id?: number
+}
+
+export interface AuditReplayOutput {
+  request_id: string
+  status_code: number
+  duration_ms: number
+  response_body?: string
+  body_truncated: boolean
+}
+
+export interface AuditReplayResult {
+  original: AuditLog
+  replay: AuditReplayOutput
+}
+
+export interface ListAuditLogsParams {
+  page?: number
+  page_size?: number
+  user_id?: number
+  api_key_id?: number
+  model?: string
+  /** RFC3339; takes precedence over time_range */
+  start_time?: string
+  end_time?: string
+  /** e.g. 1h, 24h, 7d, 30d (default 24h) */
+  time_range?: string
+}
+
+export async function listAuditLogs(params: ListAuditLogsParams = {}): Promise<PaginatedResponse<AuditLog>> {
+  const { data } = await apiClient.get<PaginatedResponse<AuditLog>>('/admin/audit', { params })
+  return data
+}
+
+/**
+ * Re-issue a captured request through current routing (sandboxed, never billed to the customer).
+ * Fails when the body was not captured, was redacted, or was truncated.
+ */
+export async function replayAuditLog(id: number): Promise<AuditReplayResult> {
+  const { data } = await apiClient.post<AuditReplayResult>(`/admin/audit/${id}/replay`)
+  return data
+}
+
+/**
+ * Enable or disable request/response body capture for an API key
+ */
+export async function setApiKeyAuditCapture(id: number, enabled: boolean): Promise<void> {
+  await apiClient.put(`/admin/api-keys/${id}`, { audit_capture_body: enabled })
+}
+
+export const auditAPI = {
+  listAuditLogs,
+  replayAuditLog,
+  setApiKeyAuditCapture
+}
+
+export default auditAPI
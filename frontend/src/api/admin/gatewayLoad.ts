@@ -0,0 +1,42 @@
+/**
+ * Admin Gateway Load API endpoints
+ * Autoscaling hints: in-flight requests, queue depth, utilization and per-model saturation
+ */
+
+import { apiClient } from '../client'
+
+export interface ModelLoadInfo {
+  model: string
+  /** Requests in flight on this instance. */
+  in_flight: number
+  /** Total concurrency of schedulable accounts that can serve the model. */
+  capacity: number
+  in_use: number
+  saturation_percent: number
+}
+
+export interface GatewayLoadSnapshot {
+  enabled: boolean
+  /** Requests in flight on this instance. */
+  in_flight: number
+  /** Requests on this instance waiting for a priority queue or concurrency slot. */
+  queue_depth: number
+  /** Total concurrency of schedulable accounts. */
+  capacity: number
+  /** Account slots in use across all instances. */
+  in_use: number
+  utilization_percent: number
+  models: ModelLoadInfo[]
+  collected_at: string
+}
+
+export async function getSnapshot(): Promise<GatewayLoadSnapshot> {
+  const { data } = await apiClient.get<GatewayLoadSnapshot>('/admin/gateway-load')
+  return data
+}
+
+export const gatewayLoadAPI = {
+  getSnapshot
+}
+
+export default gatewayLoadAPI
@@ -32,6 +32,11 @@ import channelMonitorTemplateAPI from './channelMonitorTemplate'
 import adminPaymentAPI from './payment'
 import affiliatesAPI from './affiliates'
 import riskControlAPI from './riskControl'
+import auditAPI from './audit'
+import maintenanceAPI from './maintenance'
+import slowRequestsAPI from './slowRequests'
+import priorityQueueAPI from './priorityQueue'
+import gatewayLoadAPI from './gatewayLoad'
 
 /**
  * Unified admin API object for convenient access
@@ -65,7 +70,12 @@ export const adminAPI = {
   channelMonitorTemplate: channelMonitorTemplateAPI,
   payment: adminPaymentAPI,
   affiliates: affiliatesAPI,
-  riskControl: riskControlAPI
+  riskControl: riskControlAPI,
+  audit: auditAPI,
+  maintenance: maintenanceAPI,
+  slowRequests: slowRequestsAPI,
+  priorityQueue: priorityQueueAPI,
+  gatewayLoad: gatewayLoadAPI
 }
 
 export {
@@ -97,7 +107,12 @@ export {
   channelMonitorTemplateAPI,
   adminPaymentAPI,
   affiliatesAPI,
-  riskControlAPI
+  riskControlAPI,
+  auditAPI,
+  maintenanceAPI,
+  slowRequestsAPI,
+  priorityQueueAPI,
+  gatewayLoadAPI
 }
 
 export default adminAPI
@@ -108,3 +123,8 @@ export type { ErrorPassthroughRule, CreateRuleRequest, UpdateRuleRequest } from
 export type { BackupAgentHealth, DataManagementConfig } from './dataManagement'
 export type { TLSFingerprintProfile, CreateProfileRequest, UpdateProfileRequest } from './tlsFingerprintProfile'
 export type { ContentModerationConfig, ContentModerationLog, ModerationMode } from './riskControl'
+export type { AuditLog, AuditReplayResult, ListAuditLogsParams } from './audit'
+export type { MaintenanceState, UpdateMaintenanceRequest } from './maintenance'
+export type { SlowRequest } from './slowRequests'
+export type { PriorityQueueStatus, PriorityQueueTierDepth } from './priorityQueue'
+export type { GatewayLoadSnapshot, ModelLoadInfo } from './gatewayLoad'
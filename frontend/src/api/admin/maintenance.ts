@@ -0,0 +1,36 @@
+/**
+ * Admin Maintenance Mode API endpoints
+ * Toggle gateway maintenance mode (proxy requests get 503, admin endpoints stay reachable)
+ */
+
+import { apiClient } from '../client'
+
+export interface MaintenanceState {
+  enabled: boolean
+  message: string
+  retry_after_seconds: number
+  /** API keys still allowed through during maintenance (smoke testing). */
+  bypass_api_key_ids: number[]
+  /** Whether the state is saved to settings and restored after restart. */
+  persist: boolean
+  updated_at?: string
+}
+
+export type UpdateMaintenanceRequest = Omit<MaintenanceState, 'updated_at'>
+
+export async function getMaintenance(): Promise<MaintenanceState> {
+  const { data } = await apiClient.get<MaintenanceState>('/admin/maintenance')
+  return data
+}
+
+export async function updateMaintenance(req: UpdateMaintenanceRequest): Promise<MaintenanceState> {
+  const { data } = await apiClient.put<MaintenanceState>('/admin/maintenance', req)
+  return data
+}
+
+export const maintenanceAPI = {
+  getMaintenance,
+  updateMaintenance
+}
+
+export default maintenanceAPI
@@ -507,6 +507,29 @@ export async function getRealtimeTrafficSummary(
   return data
 }
 
+export interface OpsTokenBucketCount {
+  le: string
+  count: number
+}
+
+export interface OpsTokenSizeHistogram {
+  model: string
+  type: 'input' | 'output'
+  count: number
+  sum: number
+  buckets: OpsTokenBucketCount[]
+}
+
+/** Per-model distribution of input/output tokens per billed request (since process start). */
+export async function getTokenHistograms(model?: string): Promise<{ histograms: OpsTokenSizeHistogram[] }> {
+  const params: Record<string, any> = {}
+  if (model) {
+    params.model = model
+  }
+  const { data } = await apiClient.get<{ histograms: OpsTokenSizeHistogram[] }>('/admin/ops/token-histograms', { params })
+  return data
+}
+
 /**
  * Subscribe to realtime QPS updates via WebSocket.
  *
@@ -1375,6 +1398,7 @@ export const opsAPI = {
   getUserConcurrencyStats,
   getAccountAvailabilityStats,
   getRealtimeTrafficSummary,
+  getTokenHistograms,
   subscribeQPS,
 
   // Legacy unified endpoints
@@ -3,25 +3,144 @@
  */
 
 import { apiClient } from '../client'
+import type { AdminErrorCode } from '@/types'
 
-export interface ModelPricingItem {
+/** Capability flags; omitted when the upstream data does not specify them */
+export interface ModelCapabilities {
+  supports_vision?: boolean
+  supports_function_calling?: boolean
+  supports_streaming?: boolean
+  supports_structured_output?: boolean
+  max_context_tokens?: number
+}
+
+export interface ModelPricingItem extends ModelCapabilities {
   model: string
   input_cost_per_token: number
   output_cost_per_token: number
   input_cost_per_mtok: number
   output_cost_per_mtok: number
+  blended_cost_per_mtok: number
   cache_creation_input_token_cost?: number
   cache_read_input_token_cost?: number
   provider: string
   mode: string
   supports_prompt_caching: boolean
   output_cost_per_image?: number
+  overridden: boolean
+  disabled: boolean
+  /** 显式标记的免费模型（价格为 0 且不加价），区别于缺少价格数据 */
+  is_free: boolean
+  last_updated: string
+  aliases?: string[] // only with include_aliases=true
+  deprecated: boolean // explicitly deprecated or past its deprecation date
+  replacement?: string // suggested replacement model
+  deprecation_date?: string // YYYY-MM-DD
+  base_cost: PricingCostPerMTok
+  charged_cost: PricingCostPerMTok
+  markup_source: 'none' | 'global' | 'provider' | 'model'
+  /** 生效的承诺用量折扣百分比（收费价格 = 标价扣除折扣后再加价） */
+  committed_discount_percent: number
+  committed_discount_source: 'none' | 'provider' | 'model'
+  /** 生效的请求超时（秒），0 表示不限制 */
+  timeout_seconds: number
+  timeout_source: 'none' | 'default' | 'model'
+  /** batch API 请求的价格倍率 */
+  batch_multiplier: number
+  batch_multiplier_source: 'default' | 'model'
+  batch_base_cost: PricingCostPerMTok
+  batch_charged_cost: PricingCostPerMTok
+}
+
+export interface PricingCostPerMTok {
+  input_per_mtok: number
+  output_per_mtok: number
+  blended_per_mtok: number
+}
+
+/** 价格接口错误响应中 reason 字段的机器可读错误码（code 字段为 HTTP 状态码） */
+export type PricingErrorCode =
+  | AdminErrorCode
+  | 'PRICING_NOT_FOUND'
+  | 'PRICING_UPDATE_FAILED'
+  | 'PRICING_FETCH_FAILED'
+  | 'PRICING_MODEL_DISABLED'
+  | 'PRICING_PROVIDER_DISABLED'
+  | 'NO_FILE_UPLOADED'
+  | 'FILE_TOO_LARGE'
+  | 'INVALID_FILE_TYPE'
+  | 'INVALID_JSON'
+  | 'INVALID_PRICING_DATA'
+  | 'DUPLICATE_MODELS'
+  | 'INVALID_URL'
+  | 'CURRENCY_NOT_CONFIGURED'
+  | 'OVERRIDE_NOT_FOUND'
+  | 'ALIAS_NOT_FOUND'
+  | 'MARKUP_NOT_FOUND'
+  | 'TIMEOUT_NOT_FOUND'
+  | 'BATCH_MULTIPLIER_NOT_FOUND'
+  | 'COMMITTED_DISCOUNT_NOT_FOUND'
+  | 'MIN_CHARGE_NOT_FOUND'
+  | 'PRICING_CHECKSUM_MISMATCH'
+  | 'PRICING_DATA_INCOMPLETE'
+
+export interface PricingProviderStatus {
+  name: string
+  enabled: boolean
+  disabled_at?: string
 }
 
 export interface PricingListResponse {
   items: ModelPricingItem[]
   total: number
-  providers: string[]
+  page: number
+  page_size: number
+  total_pages: number
+  providers: PricingProviderStatus[]
+  currency: string
+  exchange_rate: number
+  io_ratio: number
+}
+
+export interface PricingListParams {
+  search?: string
+  provider?: string
+  currency?: string
+  io_ratio?: number
+  page?: number
+  page_size?: number
+  include_aliases?: boolean
+  is_free?: boolean
+  deprecated?: boolean
+  supports_vision?: boolean
+  supports_function_calling?: boolean
+  supports_streaming?: boolean
+}
+
+export interface PricingAutoRefreshStatus {
+  running: boolean
+  paused: boolean
+  interval_hours: number
+  jitter_minutes: number
+  next_run_at?: string
+  last_success_at?: string
+  last_failure_at?: string
+  last_error?: string
+}
+
+// 多价格源（config pricing.sources）的单源状态
+export interface PricingSourceStatus {
+  name: string
+  url?: string
+  file?: string
+  priority: number
+  enabled: boolean
+  last_fetch_at?: string
+  last_success_at?: string
+  last_error?: string
+  model_count: number
+  models_used: number
+  models_overridden: number
 }
 
 export interface PricingStatusResponse {
@@ -29,6 +148,10 @@ export interface PricingStatusResponse {
     model_count: number
     last_updated: string
     local_hash: string
+    auto_refresh?: PricingAutoRefreshStatus
+    // 仅配置了多价格源时返回
+    sources?: PricingSourceStatus[]
+    conflict_resolution?: string
   }
   config: {
     remote_url: string
@@ -36,11 +159,14 @@ export interface PricingStatusResponse {
     data_dir: string
     update_interval_hours: number
     hash_check_interval_minutes: number
+    auto_refresh_enabled: boolean
+    auto_refresh_jitter_minutes: number
   }
 }
 
 export interface PricingUpdateResponse {
   message: string
+  dry_run: false
   status: {
     model_count: number
     last_updated: string
@@ -57,10 +183,20 @@ export interface ModelLookupResponse {
     output_cost_per_mtok: number
     cache_creation_input_token_cost: number
     cache_read_input_token_cost: number
+    is_free: boolean
+    is_default: boolean
   }
+  capabilities: ModelCapabilities
+  batch?: boolean // only with batch=true
+  batch_multiplier?: number
+  // only with as_of: historical catalog pricing (without overrides/markup)
+  matched_model?: string
+  as_of?: string
+  effective_from?: string
+  before_history?: boolean
 }
 
-export async function listPricing(params?: { search?: string; provider?: string }): Promise<PricingListResponse> {
+export async function listPricing(params?: PricingListParams): Promise<PricingListResponse> {
   const { data } = await apiClient.get<PricingListResponse>('/admin/pricing', { params })
   return data
 }
@@ -75,9 +211,67 @@ export async function forceUpdatePricing(): Promise<PricingUpdateResponse> {
   return data
 }
 
+export async function getPricingAutoRefresh(): Promise<PricingAutoRefreshStatus> {
+  const { data } = await apiClient.get<PricingAutoRefreshStatus>('/admin/pricing/auto-refresh')
+  return data
+}
+
+export async function setPricingAutoRefreshInterval(
+  intervalHours: number
+): Promise<PricingAutoRefreshStatus> {
+  const { data } = await apiClient.put<PricingAutoRefreshStatus>('/admin/pricing/auto-refresh', {
+    interval_hours: intervalHours
+  })
+  return data
+}
+
+export async function pausePricingAutoRefresh(): Promise<PricingAutoRefreshStatus> {
+  const { data } = await apiClient.post<PricingAutoRefreshStatus>('/admin/pricing/auto-refresh/pause')
+  return data
+}
+
+export async function resumePricingAutoRefresh(): Promise<PricingAutoRefreshStatus> {
+  const { data } = await apiClient.post<PricingAutoRefreshStatus>('/admin/pricing/auto-refresh/resume')
+  return data
+}
+
+export interface PricingModelChange {
+  model: string
+  changes: { field: string; old: unknown; new: unknown }[]
+}
+
+export interface PricingDryRunResponse {
+  message: string
+  dry_run: true
+  applied: false
+  added: string[]
+  removed: string[]
+  changed: PricingModelChange[]
+  added_count: number
+  removed_count: number
+  changed_count: number
+}
+
+/** 预览强制更新将带来的变化，不修改价格数据 */
+export async function dryRunForceUpdatePricing(): Promise<PricingDryRunResponse> {
+  const { data } = await apiClient.post<PricingDryRunResponse>('/admin/pricing/update', null, {
+    params: { dry_run: true }
+  })
+  return data
+}
+
+export type PricingUploadMode = 'replace' | 'merge'
+
 export interface PricingUploadResponse {
   message: string
+  mode: PricingUploadMode
   model_count: number
+  // 仅 merge 模式返回
+  added?: number
+  updated?: number
+  untouched?: number
+  collisions: PricingCollision[]
+  collision_count: number
   status: {
     model_count: number
     last_updated: string
@@ -85,17 +279,376 @@ export interface PricingUploadResponse {
   }
 }
 
-export async function uploadPricing(file: File): Promise<PricingUploadResponse> {
+// 上传校验失败时错误响应 data.errors 中的单条问题
+export interface PricingValidationError {
+  model: string
+  field?: string
+  message: string
+}
+
+// 同名模型在上传文件中重复出现（后出现者生效）
+export interface PricingCollision {
+  model: string
+  providers: string[]
+  kept_provider: string
+}
+
+/** strict 为 true 时，文件中存在重复模型即拒绝导入 */
+export async function uploadPricing(
+  file: File,
+  mode: PricingUploadMode = 'replace',
+  strict = false
+): Promise<PricingUploadResponse> {
   const formData = new FormData()
   formData.append('file', file)
+  formData.append('mode', mode)
+  formData.append('strict', String(strict))
   const { data } = await apiClient.post<PricingUploadResponse>('/admin/pricing/upload', formData, {
     headers: { 'Content-Type': 'multipart/form-data' }
   })
   return data
 }
 
-export async function lookupModel(model: string): Promise<ModelLookupResponse> {
-  const { data } = await apiClient.get<ModelLookupResponse>('/admin/pricing/lookup', { params: { model } })
+// sha256: 可选，下载内容的 SHA-256，不一致时拒绝导入（PRICING_CHECKSUM_MISMATCH）
+export async function importPricingFromURL(
+  url: string,
+  strict = false,
+  sha256?: string
+): Promise<PricingUploadResponse> {
+  const { data } = await apiClient.post<PricingUploadResponse>('/admin/pricing/import-url', {
+    url,
+    strict,
+    ...(sha256 ? { sha256 } : {})
+  })
+  return data
+}
+
+/**
+ * Look up a model's pricing. asOf (YYYY-MM-DD or RFC3339) returns the catalog pricing in effect at that time.
+ */
+export async function lookupModel(model: string, batch = false, asOf?: string): Promise<ModelLookupResponse> {
+  const params: Record<string, string | boolean> = { model }
+  if (batch) params.batch = true
+  if (asOf) params.as_of = asOf
+  const { data } = await apiClient.get<ModelLookupResponse>('/admin/pricing/lookup', { params })
+  return data
+}
+
+export interface TokenCountResponse {
+  model: string
+  tokenizer: 'cl100k_base' | 'o200k_base' | 'claude' | 'heuristic'
+  tokens: number
+  chars: number
+}
+
+export async function countTokens(model: string, text: string): Promise<TokenCountResponse> {
+  const { data } = await apiClient.get<TokenCountResponse>('/admin/tokens/count', { params: { model, text } })
+  return data
+}
+
+export async function exportPricing(
+  params?: Omit<PricingListParams, 'page' | 'page_size'> & { format?: 'csv' | 'json' }
+): Promise<Blob> {
+  const response = await apiClient.get('/admin/pricing/export', {
+    params,
+    responseType: 'blob'
+  })
+  return response.data
+}
+
+export interface ModelAlias {
+  alias: string
+  model: string
+  updated_at: string
+}
+
+export async function listModelAliases(): Promise<ModelAlias[]> {
+  const { data } = await apiClient.get<ModelAlias[]>('/admin/pricing/aliases')
+  return data
+}
+
+export async function setModelAlias(alias: string, model: string): Promise<ModelAlias> {
+  const { data } = await apiClient.post<ModelAlias>('/admin/pricing/aliases', { alias, model })
+  return data
+}
+
+export async function removeModelAlias(alias: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/aliases', { params: { alias } })
+}
+
+export interface PricingMarkup {
+  percent: number
+  flat_per_mtok: number
+  updated_at?: string
+}
+
+export interface ModelPricingMarkup extends PricingMarkup {
+  model: string
+}
+
+export interface ProviderPricingMarkup extends PricingMarkup {
+  provider: string
+}
+
+export interface PricingMarkupResponse {
+  global: PricingMarkup
+  providers: ProviderPricingMarkup[]
+  models: ModelPricingMarkup[]
+}
+
+export async function getPricingMarkup(): Promise<PricingMarkupResponse> {
+  const { data } = await apiClient.get<PricingMarkupResponse>('/admin/pricing/markup')
+  return data
+}
+
+/** model 为空时设置全局加价 */
+export async function setPricingMarkup(
+  percent: number,
+  flatPerMTok = 0,
+  model?: string
+): Promise<{ model: string; markup: PricingMarkup }> {
+  const { data } = await apiClient.put<{ model: string; markup: PricingMarkup }>(
+    '/admin/pricing/markup',
+    { model: model ?? '', percent, flat_per_mtok: flatPerMTok }
+  )
+  return data
+}
+
+export async function removeModelMarkup(model: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/markup', { params: { model } })
+}
+
+/** 厂商级加价：该厂商下未单独设置加价的模型继承 */
+export async function setProviderMarkup(
+  provider: string,
+  percent: number,
+  flatPerMTok = 0
+): Promise<{ provider: string; markup: PricingMarkup }> {
+  const { data } = await apiClient.put<{ provider: string; markup: PricingMarkup }>(
+    '/admin/pricing/markup',
+    { provider, percent, flat_per_mtok: flatPerMTok }
+  )
+  return data
+}
+
+export async function removeProviderMarkup(provider: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/markup', { params: { provider } })
+}
+
+export type DefaultPricingSource = 'none' | 'config' | 'admin'
+
+export interface DefaultPricingResponse {
+  pricing: {
+    input_cost_per_token: number
+    output_cost_per_token: number
+  }
+  source: DefaultPricingSource
+}
+
+export async function getDefaultPricing(): Promise<DefaultPricingResponse> {
+  const { data } = await apiClient.get<DefaultPricingResponse>('/admin/pricing/default')
+  return data
+}
+
+export async function setDefaultPricing(
+  inputCostPerToken: number,
+  outputCostPerToken: number
+): Promise<DefaultPricingResponse> {
+  const { data } = await apiClient.put<DefaultPricingResponse>('/admin/pricing/default', {
+    input_cost_per_token: inputCostPerToken,
+    output_cost_per_token: outputCostPerToken
+  })
+  return data
+}
+
+export async function removeDefaultPricing(): Promise<DefaultPricingResponse> {
+  const { data } = await apiClient.delete<DefaultPricingResponse>('/admin/pricing/default')
+  return data
+}
+
+export interface ProviderToggleResponse {
+  provider: string
+  enabled: boolean
+  changed: boolean
+}
+
+export async function disableProvider(provider: string): Promise<ProviderToggleResponse> {
+  const { data } = await apiClient.post<ProviderToggleResponse>('/admin/pricing/providers/disable', {
+    provider
+  })
+  return data
+}
+
+export async function enableProvider(provider: string): Promise<ProviderToggleResponse> {
+  const { data } = await apiClient.post<ProviderToggleResponse>('/admin/pricing/providers/enable', {
+    provider
+  })
+  return data
+}
+
+export interface ModelTimeout {
+  model: string
+  timeout_seconds: number
+}
+
+export interface ModelTimeoutsResponse {
+  default_timeout_seconds: number
+  models: ModelTimeout[]
+}
+
+export async function listModelTimeouts(): Promise<ModelTimeoutsResponse> {
+  const { data } = await apiClient.get<ModelTimeoutsResponse>('/admin/pricing/timeouts')
+  return data
+}
+
+export async function setModelTimeout(model: string, timeoutSeconds: number): Promise<ModelTimeout> {
+  const { data } = await apiClient.put<ModelTimeout>('/admin/pricing/timeouts', {
+    model,
+    timeout_seconds: timeoutSeconds
+  })
+  return data
+}
+
+export async function removeModelTimeout(model: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/timeouts', { params: { model } })
+}
+
+export interface ModelBatchMultiplier {
+  model: string
+  multiplier: number
+}
+
+export interface BatchMultipliersResponse {
+  default_multiplier: number
+  models: ModelBatchMultiplier[]
+}
+
+export async function listBatchMultipliers(): Promise<BatchMultipliersResponse> {
+  const { data } = await apiClient.get<BatchMultipliersResponse>('/admin/pricing/batch-multipliers')
+  return data
+}
+
+export async function setBatchMultiplier(model: string, multiplier: number): Promise<ModelBatchMultiplier> {
+  const { data } = await apiClient.put<ModelBatchMultiplier>('/admin/pricing/batch-multipliers', {
+    model,
+    multiplier
+  })
+  return data
+}
+
+export async function removeBatchMultiplier(model: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/batch-multipliers', { params: { model } })
+}
+
+export interface CommittedDiscount {
+  model?: string
+  provider?: string
+  percent: number
+}
+
+export interface CommittedDiscountsResponse {
+  providers: CommittedDiscount[]
+  models: CommittedDiscount[]
+}
+
+export async function listCommittedDiscounts(): Promise<CommittedDiscountsResponse> {
+  const { data } = await apiClient.get<CommittedDiscountsResponse>('/admin/pricing/committed-discounts')
+  return data
+}
+
+/** model 与 provider 二选一 */
+export async function setCommittedDiscount(discount: CommittedDiscount): Promise<CommittedDiscount> {
+  const { data } = await apiClient.put<CommittedDiscount>('/admin/pricing/committed-discounts', discount)
+  return data
+}
+
+export async function removeCommittedDiscount(target: { model?: string; provider?: string }): Promise<void> {
+  await apiClient.delete('/admin/pricing/committed-discounts', { params: target })
+}
+
+export interface ModelMinCharge {
+  model: string
+  min_charge: number
+}
+
+export interface MinChargesResponse {
+  default_min_charge: number
+  models: ModelMinCharge[]
+}
+
+export async function listMinCharges(): Promise<MinChargesResponse> {
+  const { data } = await apiClient.get<MinChargesResponse>('/admin/pricing/min-charges')
+  return data
+}
+
+/** min_charge 为 0 表示该模型不收取最低费用（覆盖全局默认） */
+export async function setMinCharge(model: string, minCharge: number): Promise<ModelMinCharge> {
+  const { data } = await apiClient.put<ModelMinCharge>('/admin/pricing/min-charges', {
+    model,
+    min_charge: minCharge
+  })
+  return data
+}
+
+export async function removeMinCharge(model: string): Promise<void> {
+  await apiClient.delete('/admin/pricing/min-charges', { params: { model } })
+}
+
+export interface CostCompareParams {
+  input_tokens: number
+  output_tokens: number
+  cache_read_tokens?: number
+  cache_creation_tokens?: number
+  provider?: string
+  limit?: number
+}
+
+export interface CostComparisonItem {
+  model: string
+  provider: string
+  supports_prompt_caching: boolean
+  is_free: boolean
+  breakdown: {
+    input_cost: number
+    output_cost: number
+    cache_read_cost: number
+    cache_creation_cost: number
+    image_cost: number
+  }
+  total_cost: number
+  base_cost: number
+  warnings: string[]
+}
+
+export interface CostCompareResponse {
+  items: CostComparisonItem[]
+  total: number
+  limit: number
+}
+
+export async function compareCost(params: CostCompareParams): Promise<CostCompareResponse> {
+  const { data } = await apiClient.post<CostCompareResponse>('/admin/pricing/compare', params)
+  return data
+}
+
+export interface ModelValueEntry {
+  rank: number
+  model: string
+  provider: string
+  cost_per_token: number
+  tokens_per_dollar: number
+}
+
+export interface ModelValueRankingResponse {
+  by_output: ModelValueEntry[]
+  by_input: ModelValueEntry[]
+  /** Free or unpriced models that are not ranked */
+  excluded: { model: string; provider: string; reason: 'free' | 'unpriced' }[]
+  limit: number
+}
+
+export async function getValueRanking(params?: { provider?: string; limit?: number }): Promise<ModelValueRankingResponse> {
+  const { data } = await apiClient.get<ModelValueRankingResponse>('/admin/pricing/value', { params })
   return data
 }
 
@@ -103,8 +656,41 @@ export const pricingAPI = {
   list: listPricing,
   getStatus: getPricingStatus,
   forceUpdate: forceUpdatePricing,
+  dryRunForceUpdate: dryRunForceUpdatePricing,
+  getAutoRefresh: getPricingAutoRefresh,
+  setAutoRefreshInterval: setPricingAutoRefreshInterval,
+  pauseAutoRefresh: pausePricingAutoRefresh,
+  resumeAutoRefresh: resumePricingAutoRefresh,
   lookupModel,
-  upload: uploadPricing
+  countTokens,
+  upload: uploadPricing,
+  importFromURL: importPricingFromURL,
+  exportPricing,
+  listAliases: listModelAliases,
+  setAlias: setModelAlias,
+  removeAlias: removeModelAlias,
+  getMarkup: getPricingMarkup,
+  setMarkup: setPricingMarkup,
+  removeModelMarkup,
+  getDefaultPricing,
+  setDefaultPricing,
+  removeDefaultPricing,
+  disableProvider,
+  enableProvider,
+  listTimeouts: listModelTimeouts,
+  setTimeout: setModelTimeout,
+  removeTimeout: removeModelTimeout,
+  listBatchMultipliers,
+  setBatchMultiplier,
+  removeBatchMultiplier,
+  listCommittedDiscounts,
+  setCommittedDiscount,
+  removeCommittedDiscount,
+  listMinCharges,
+  setMinCharge,
+  removeMinCharge,
+  compareCost,
+  getValueRanking
 }
 
 export default pricingAPI
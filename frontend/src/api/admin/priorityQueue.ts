@@ -0,0 +1,33 @@
+/**
+ * Admin Priority Queue API endpoints
+ * Inspect the gateway priority queue (active requests and queue depth per API key tier)
+ */
+
+import { apiClient } from '../client'
+
+export interface PriorityQueueTierDepth {
+  tier: number
+  waiting: number
+}
+
+export interface PriorityQueueStatus {
+  enabled: boolean
+  max_concurrent_requests: number
+  max_wait_seconds: number
+  max_queue_size: number
+  active: number
+  waiting: number
+  /** Queue depth per tier, highest tier first. */
+  tiers: PriorityQueueTierDepth[]
+}
+
+export async function getStatus(): Promise<PriorityQueueStatus> {
+  const { data } = await apiClient.get<PriorityQueueStatus>('/admin/priority-queue')
+  return data
+}
+
+export const priorityQueueAPI = {
+  getStatus
+}
+
+export default priorityQueueAPI
@@ -0,0 +1,39 @@
+/**
+ * Admin Slow Request API endpoints
+ * Recent proxied requests that exceeded the configured slow_request threshold
+ */
+
+import { apiClient } from '../client'
+
+export interface SlowRequest {
+  request_id: string
+  model: string
+  provider: string
+  account_id: number
+  api_key_id: number
+  user_id: number
+  stream: boolean
+  duration_ms: number
+  first_token_ms?: number
+  /** Threshold that applied to this model when the request was logged. */
+  threshold_ms: number
+  input_tokens: number
+  output_tokens: number
+  cache_creation_tokens: number
+  cache_read_tokens: number
+  created_at: string
+}
+
+/** Newest first; the server keeps at most slow_request.buffer_size entries. */
+export async function listSlowRequests(limit?: number): Promise<SlowRequest[]> {
+  const { data } = await apiClient.get<SlowRequest[]>('/admin/slow-requests', {
+    params: limit ? { limit } : undefined
+  })
+  return data
+}
+
+export const slowRequestsAPI = {
+  listSlowRequests
+}
+
+export default slowRequestsAPI
@@ -196,6 +196,27 @@ export async function cancelCleanupTask(taskId: number): Promise<{ id: number; s
   return data
 }
 
+export interface UsageReportParams {
+  from?: string // YYYY-MM-DD (UTC)
+  to?: string // YYYY-MM-DD (UTC)
+  group_by?: string // comma-separated: model, provider, user, day
+  format?: 'csv' | 'json'
+  include_sandbox?: boolean // Also count usage from sandbox API keys
+}
+
+/**
+ * Export aggregated usage report for reconciliation (admin only)
+ * @param params - Date range, grouping dimensions and output format
+ * @returns Report file blob
+ */
+export async function exportUsageReport(params?: UsageReportParams): Promise<Blob> {
+  const response = await apiClient.get('/admin/usage/report', {
+    params,
+    responseType: 'blob'
+  })
+  return response.data
+}
+
 export const adminUsageAPI = {
   list,
   getStats,
@@ -203,7 +224,8 @@ export const adminUsageAPI = {
   searchApiKeys,
   listCleanupTasks,
   createCleanupTask,
-  cancelCleanupTask
+  cancelCleanupTask,
+  exportUsageReport
 }
 
 export default adminUsageAPI
@@ -267,6 +267,65 @@ export async function getUserBalanceHistory(
   return data
 }
 
+export interface UserSpendDay {
+  date: string
+  requests: number
+  total_cost: number
+  actual_cost: number
+  cache_read_tokens: number
+  cache_read_cost_usd: number
+  cache_savings_usd: number
+}
+
+export interface UserSpendMonth {
+  month: string
+  requests: number
+  total_cost: number
+  actual_cost: number
+  cache_read_tokens: number
+  cache_read_cost_usd: number
+  cache_savings_usd: number
+}
+
+export interface UserSpendSummary {
+  user_id: number
+  from: string
+  to: string
+  requests: number
+  total_cost: number
+  actual_cost: number
+  cache_read_tokens: number
+  cache_read_cost_usd: number
+  cache_savings_usd: number
+  include_sandbox: boolean
+  daily: UserSpendDay[]
+  monthly: UserSpendMonth[]
+  /** Costs are rounded to this many significant digits for display (absent = full precision) */
+  display_significant_digits?: number
+}
+
+/**
+ * Get user's persisted spend totals (priced at request time)
+ * @param id - User ID
+ * @param from - Start date (YYYY-MM-DD, UTC, inclusive), defaults to first day of current month
+ * @param to - End date (YYYY-MM-DD, UTC, inclusive), defaults to today
+ * @param includeSandbox - Also count usage from sandbox API keys
+ * @returns Spend totals with daily and monthly breakdown
+ */
+export async function getUserSpend(
+  id: number,
+  from?: string,
+  to?: string,
+  includeSandbox?: boolean
+): Promise<UserSpendSummary> {
+  const params: Record<string, string> = {}
+  if (from) params.from = from
+  if (to) params.to = to
+  if (includeSandbox) params.include_sandbox = 'true'
+  const { data } = await apiClient.get<UserSpendSummary>(`/admin/users/${id}/spend`, { params })
+  return data
+}
+
 /**
  * Replace user's exclusive group
  * @param userId - User ID
@@ -309,6 +368,7 @@ export const usersAPI = {
   getUserApiKeys,
   getUserUsageStats,
   getUserBalanceHistory,
+  getUserSpend,
   replaceGroup,
   bindUserAuthIdentity
 }
@@ -185,6 +185,50 @@ export async function transferAffiliateQuota(): Promise<AffiliateTransferRespons
   return data
 }
 
+export interface UserReceiptLine {
+  model: string
+  requests: number
+  input_tokens: number
+  output_tokens: number
+  cache_creation_tokens: number
+  cache_read_tokens: number
+  total_tokens: number
+  cost: number
+}
+
+export interface UserReceipt {
+  user_id: number
+  month: string
+  period_start: string
+  period_end: string
+  lines: UserReceiptLine[]
+  requests: number
+  total_tokens: number
+  total_cost: number
+}
+
+/**
+ * Get the current user's itemized receipt for a month
+ * @param month - YYYY-MM (UTC), defaults to the current month
+ */
+export async function getReceipt(month?: string): Promise<UserReceipt> {
+  const { data } = await apiClient.get<UserReceipt>('/user/receipts', { params: { month } })
+  return data
+}
+
+/**
+ * Download the current user's receipt for a month as CSV
+ * @param month - YYYY-MM (UTC), defaults to the current month
+ * @returns CSV file blob
+ */
+export async function exportReceiptCSV(month?: string): Promise<Blob> {
+  const response = await apiClient.get('/user/receipts', {
+    params: { month, format: 'csv' },
+    responseType: 'blob'
+  })
+  return response.data
+}
+
 export const userAPI = {
   getProfile,
   updateProfile,
@@ -199,7 +243,9 @@ export const userAPI = {
   buildOAuthBindingStartURL,
   startOAuthBinding,
   getAffiliateDetail,
-  transferAffiliateQuota
+  transferAffiliateQuota,
+  getReceipt,
+  exportReceiptCSV
 }
 
 export default userAPI
@@ -655,6 +655,8 @@ export default {
     copyToClipboard: 'Copy to clipboard',
     copied: 'Copied!',
     importToCcSwitch: 'Import to CCS',
+    keyHashed: 'Stored hashed',
+    keyHashedHint: 'This key is stored as a hash. The full key was shown once on creation and cannot be copied or exported again',
     enable: 'Enable',
     disable: 'Disable',
     nameLabel: 'Name',
@@ -4370,6 +4372,8 @@ export default {
         cacheCreate: 'Cache Create',
         cacheRead: 'Cache Read',
         caching: 'Caching',
+        free: 'Free',
+        providerDisabled: 'disabled',
         noData: 'No data available'
       }
     },
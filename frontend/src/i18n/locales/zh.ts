@@ -654,6 +654,8 @@ export default {
     copyToClipboard: '复制到剪贴板',
     copied: '已复制！',
     importToCcSwitch: '导入到 CCS',
+    keyHashed: '已哈希存储',
+    keyHashedHint: '该密钥以哈希形式存储，完整密钥仅在创建时显示一次，无法再次复制或导出',
     enable: '启用',
     disable: '禁用',
     nameLabel: '名称',
@@ -4526,6 +4528,8 @@ export default {
         cacheCreate: '缓存创建',
         cacheRead: '缓存读取',
         caching: '缓存',
+        free: '免费',
+        providerDisabled: '已禁用',
         noData: '暂无数据'
       }
     },
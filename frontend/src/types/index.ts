@@ -107,6 +107,9 @@ export interface AdminUser extends User {
   group_rates?: Record<number, number>
   // 当前并发数（仅管理员列表接口返回）
   current_concurrency?: number
+  // 月度预算（USD，0 = 不限制）；软限制时超出仅告警不拦截
+  budget_limit?: number
+  budget_soft_limit?: boolean
 }
 
 export interface LoginRequest {
@@ -420,11 +423,29 @@ export interface UserStats {
 // ==================== API Response Types ====================
 
 export interface ApiResponse<T = unknown> {
+  /** 0 on success, otherwise the HTTP status code */
   code: number
   message: string
+  /** Machine-readable error code on failures (e.g. INVALID_PARAMETER, PRICING_NOT_FOUND); branch on this, not on message */
+  reason?: string
   data: T
 }
 
+/** Generic machine-readable error codes carried in `reason` by admin endpoints */
+export type AdminErrorCode =
+  | 'BAD_REQUEST'
+  | 'INVALID_REQUEST'
+  | 'INVALID_PARAMETER'
+  | 'MISSING_PARAMETER'
+  | 'UNAUTHORIZED'
+  | 'FORBIDDEN'
+  | 'NOT_FOUND'
+  | 'CONFLICT'
+  | 'TOO_MANY_REQUESTS'
+  | 'INTERNAL_ERROR'
+  | 'BAD_GATEWAY'
+  | 'SERVICE_UNAVAILABLE'
+
 export interface ApiError {
   detail: string
   code?: string
@@ -487,6 +508,9 @@ export type GroupPlatform = 'anthropic' | 'openai' | 'gemini' | 'antigravity'
 
 export type SubscriptionType = 'standard' | 'subscription'
 
+// load_balance: 负载均衡（默认）；cheapest: 优先有效成本最低的账号；latency: 优先平均延迟最低的账号
+export type GroupSchedulingStrategy = 'load_balance' | 'cheapest' | 'latency'
+
 export interface OpenAIMessagesDispatchModelConfig {
   opus_mapped_model?: string
   sonnet_mapped_model?: string
@@ -551,6 +575,9 @@ export interface AdminGroup extends Group {
 
   // 分组排序
   sort_order: number
+
+  // 账号调度策略
+  scheduling_strategy?: GroupSchedulingStrategy
 }
 
 export interface ApiKey {
@@ -581,6 +608,24 @@ export interface ApiKey {
   reset_5h_at: string | null
   reset_1d_at: string | null
   reset_7d_at: string | null
+  rpm_limit: number // Requests per minute (0 = use global default)
+  tpm_limit: number // Tokens per minute (0 = use global default)
+  audit_capture_body?: boolean // Audit log records request/response bodies
+  allowed_models?: string[] | null // Allowed models (empty = all models)
+  denied_models?: string[] | null // Denied models (takes precedence over allowed_models)
+  usage_headers?: boolean // Return billed cost/token usage in response headers (trailers when streaming)
+  is_sandbox?: boolean // Sandbox key: cost is computed but never charged or counted toward spend/budgets
+  priority_tier?: number // Gateway queue priority when concurrency is maxed (higher first, default 0)
+  max_request_bytes?: number // Max request body size in bytes (0 = use global default)
+  max_response_bytes?: number // Max response body size in bytes (0 = use global default)
+  key_hashed?: boolean // Stored as a hash: `key` is empty, the full key is shown once on creation
+  usage_quota_requests?: number // Requests allowed per quota period (0 = unlimited)
+  usage_quota_tokens?: number // Tokens allowed per quota period (0 = unlimited)
+  usage_quota_period?: 'hourly' | 'daily' | 'weekly' | 'monthly' | '' // Quota reset schedule (UTC calendar boundaries)
+  usage_quota_requests_used?: number // Requests used in the current quota period
+  usage_quota_tokens_used?: number // Tokens used in the current quota period
+  usage_quota_reset_at?: string | null // When the current quota period resets
+  mask_response_model?: boolean // Echo the requested model name in responses instead of the real upstream model
 }
 
 export interface CreateApiKeyRequest {
@@ -609,6 +654,7 @@ export interface UpdateApiKeyRequest {
   rate_limit_1d?: number
   rate_limit_7d?: number
   reset_rate_limit_usage?: boolean
+  usage_headers?: boolean
 }
 
 export interface CreateGroupRequest {
@@ -635,6 +681,7 @@ export interface CreateGroupRequest {
   require_oauth_only?: boolean
   require_privacy_set?: boolean
   openai_force_codex?: boolean
+  scheduling_strategy?: GroupSchedulingStrategy
   // 从指定分组复制账号
   copy_accounts_from_group_ids?: number[]
 }
@@ -664,6 +711,7 @@ export interface UpdateGroupRequest {
   require_oauth_only?: boolean
   require_privacy_set?: boolean
   openai_force_codex?: boolean
+  scheduling_strategy?: GroupSchedulingStrategy
   copy_accounts_from_group_ids?: number[]
 }
 
@@ -792,6 +840,14 @@ export interface TempUnschedulableStatus {
   state?: TempUnschedulableState
 }
 
+export interface AccountDrainStatus {
+  account_id: number
+  status: Account['status']
+  draining: boolean
+  in_flight: number // In-flight requests still holding concurrency slots
+  can_delete: boolean
+}
+
 export interface Account {
   id: number
   name: string
@@ -803,20 +859,24 @@ export interface Account {
   extra?: (CodexUsageSnapshot & OpenAICompactState & {
     model_rate_limits?: Record<string, { rate_limited_at: string; rate_limit_reset_at: string }>
     antigravity_credits_overages?: Record<string, { activated_at: string; active_until: string }>
+    // Static headers injected on outbound upstream requests; sensitive values are masked in responses
+    custom_headers?: Record<string, string>
   } & Record<string, unknown>)
   proxy_id: number | null
   concurrency: number
   load_factor?: number | null
+  weight: number // Scheduling weight within the same priority (0 = drain-only)
   current_concurrency?: number // Real-time concurrency count from Redis
   priority: number
   rate_multiplier?: number // Account billing multiplier (>=0, 0 means free)
-  status: 'active' | 'inactive' | 'error'
+  status: 'active' | 'inactive' | 'error' | 'draining'
   error_message: string | null
   last_used_at: string | null
   expires_at: number | null
   auto_pause_on_expired: boolean
   created_at: string
   updated_at: string
+  deleted_at?: string | null // 软删除时间（仅 include_deleted 列表中出现）
   proxy?: Proxy
   group_ids?: number[] // Groups this account belongs to
   groups?: Group[] // Preloaded group objects
@@ -992,6 +1052,7 @@ export interface CreateAccountRequest {
   proxy_id?: number | null
   concurrency?: number
   load_factor?: number | null
+  weight?: number
   priority?: number
   rate_multiplier?: number // Account billing multiplier (>=0, 0 means free)
   group_ids?: number[]
@@ -1009,6 +1070,7 @@ export interface UpdateAccountRequest {
   proxy_id?: number | null
   concurrency?: number
   load_factor?: number | null
+  weight?: number
   priority?: number
   rate_multiplier?: number // Account billing multiplier (>=0, 0 means free)
   schedulable?: boolean
@@ -1119,6 +1181,7 @@ export interface CodexSessionImportRequest {
   priority?: number
   rate_multiplier?: number
   load_factor?: number | null
+  weight?: number
   expires_at?: number | null
   auto_pause_on_expired?: boolean
   credential_extras?: Record<string, unknown>
@@ -1208,6 +1271,11 @@ export interface UsageLog {
   // 计费模式
   billing_mode?: string | null
 
+  // 响应被网关中止的原因（如超出 API Key 响应体大小上限）
+  abort_reason?: string | null
+  // 请求追踪 ID（与响应头 X-Request-ID 一致）
+  trace_id?: string | null
+
   created_at: string
 
   user?: User
@@ -1469,6 +1537,8 @@ export interface UpdateUserRequest {
   // 用户专属分组倍率配置 (group_id -> rate_multiplier | null)
   // null 表示删除该分组的专属倍率
   group_rates?: Record<number, number | null>
+  budget_limit?: number
+  budget_soft_limit?: boolean
 }
 
 export interface ChangePasswordRequest {